@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
@@ -51,10 +52,11 @@ func main() {
 // registryEntry holds the full signature info for a stdlib function.
 type registryEntry struct {
 	count           int
-	types           []typeRepr        // per-position return types
-	paramNames      []string          // parameter names (for named argument support)
-	defaultValues   []string          // Go expression strings for default values; "" = no default
+	types           []typeRepr         // per-position return types
+	paramNames      []string           // parameter names (for named argument support)
+	defaultValues   []string           // Go expression strings for default values; "" = no default
 	paramFuncParams map[int][]typeRepr // func-typed param index → inner param types (for lambda inference)
+	voidFuncParams  []int              // indexes of func-typed params whose func returns nothing
 }
 
 // typeRepr is the generator's representation of a type, emitted as goStdlibType.
@@ -64,16 +66,21 @@ type typeRepr struct {
 	elementType *typeRepr  // element type for TypeKindList
 	keyType     *typeRepr  // key type for TypeKindMap
 	valueType   *typeRepr  // value type for TypeKindMap
+	params      []typeRepr // parameter types for TypeKindFunction
+	returns     []typeRepr // return types for TypeKindFunction
 }
 
 // scanResult holds the scanned registry and deprecated map.
 type scanResult struct {
 	registry     map[string]registryEntry
-	deprecated   map[string]string // qualified name → deprecation message
-	genericClass map[string]string // qualified name → generic class ("T", "K", or "TK")
-	security     map[string]string // qualified name → security category (sql, html, fetch, files, redirect, shell)
-	interfaces   map[string]bool   // qualified interface names (e.g., "mcp.Server")
-	panics       map[string]string // qualified name → panics message
+	deprecated   map[string]string              // qualified name → deprecation message
+	genericClass map[string]string              // qualified name → generic class ("T", "K", or "TK")
+	security     map[string]string              // qualified name → security category (sql, html, fetch, files, redirect, shell)
+	interfaces   map[string]bool                // qualified interface names (e.g., "mcp.Server")
+	panics       map[string]string              // qualified name → panics message
+	structFields map[string]map[string]typeRepr // qualified struct name → exported field name → type
+	operators    map[string]map[string]string   // qualified type name → operator → method name
+	methods      map[string]registryEntry       // qualified method name (pkg.Type.Method) → signature
 }
 
 // scanRegistry reads and parses all .kuki files in paths, returning a map of
@@ -88,6 +95,9 @@ func scanRegistry(paths []string) (scanResult, []error) {
 		security:     map[string]string{},
 		interfaces:   map[string]bool{},
 		panics:       map[string]string{},
+		structFields: map[string]map[string]typeRepr{},
+		operators:    map[string]map[string]string{},
+		methods:      map[string]registryEntry{},
 	}
 	var errs []error
 
@@ -132,6 +142,26 @@ func scanRegistry(paths []string) (scanResult, []error) {
 				continue
 			}
 
+			// Collect the exported fields of exported struct types, so field
+			// access on a value returned by a stdlib function is typed.
+			if td, ok := decl.(*ast.TypeDecl); ok {
+				name := td.Name.Value
+				if td.Fields == nil || len(name) == 0 || name[0] < 'A' || name[0] > 'Z' {
+					continue
+				}
+				fields := map[string]typeRepr{}
+				for _, field := range td.Fields {
+					fieldName := field.Name.Value
+					if len(fieldName) > 0 && fieldName[0] >= 'A' && fieldName[0] <= 'Z' {
+						fields[fieldName] = typeAnnotationToRepr(field.Type)
+					}
+				}
+				if len(fields) > 0 {
+					result.structFields[pkgName+"."+name] = fields
+				}
+				continue
+			}
+
 			fd, ok := decl.(*ast.FunctionDecl)
 			if !ok {
 				continue
@@ -144,8 +174,33 @@ func scanRegistry(paths []string) (scanResult, []error) {
 			}
 
 			// Skip methods — they have a receiver and are called as value.Method(),
-			// not as pkg.Method(), so they don't belong in this registry.
+			// not as pkg.Method(), so they don't belong in this registry. An
+			// operator method is recorded so the operator works on the type in
+			// programs that import the package, and exported methods are kept
+			// for the types that have one (see formatRegistry).
 			if fd.Receiver != nil {
+				if typeName := receiverName(fd.Receiver); typeName != "" {
+					types := make([]typeRepr, len(fd.Returns))
+					for i, ret := range fd.Returns {
+						types[i] = typeAnnotationToRepr(ret)
+					}
+					paramNames := make([]string, len(fd.Parameters))
+					for i, param := range fd.Parameters {
+						paramNames[i] = param.Name.Value
+					}
+					result.methods[pkgName+"."+typeName+"."+name] = registryEntry{count: len(fd.Returns), types: types, paramNames: paramNames}
+				}
+				if typeName, ok := operatorReceiver(fd); ok {
+					key := pkgName + "." + typeName
+					if result.operators[key] == nil {
+						result.operators[key] = map[string]string{}
+					}
+					op := fd.Operator
+					if op == "equals" {
+						op = "=="
+					}
+					result.operators[key][op] = name
+				}
 				continue
 			}
 
@@ -176,7 +231,13 @@ func scanRegistry(paths []string) (scanResult, []error) {
 			// Detect generic placeholder usage for stdlib functions.
 			// This drives codegen's type parameter inference so new functions
 			// don't need to be manually added to hardcoded allowlists.
-			if pkgName == "slice" || pkgName == "sort" || pkgName == "concurrent" {
+			if pkgName == "math" {
+				if signatureContainsPlaceholder(fd, "number") {
+					result.genericClass[key] = "N"
+				} else if signatureContainsPlaceholder(fd, "ordered") {
+					result.genericClass[key] = "O"
+				}
+			} else if pkgName == "slice" || pkgName == "sort" || pkgName == "concurrent" || pkgName == "random" || pkgName == "limit" || pkgName == "pool" {
 				usesAny := signatureContainsPlaceholder(fd, "any")
 				usesAny2 := signatureContainsPlaceholder(fd, "any2")
 				usesOrdered := signatureContainsPlaceholder(fd, "ordered")
@@ -196,23 +257,12 @@ func scanRegistry(paths []string) (scanResult, []error) {
 				}
 			}
 
-			returnCount := len(fd.Returns)
-			if returnCount == 0 {
-				// Void functions don't need an entry; the codegen handles them fine.
-				continue
-			}
-
-			// Extract per-position return types
-			types := make([]typeRepr, returnCount)
-			for i, ret := range fd.Returns {
-				types[i] = typeAnnotationToRepr(ret)
-			}
-
 			// Extract parameter names, default values, and func-typed param inner types
 			paramNames := make([]string, len(fd.Parameters))
 			defaultValues := make([]string, len(fd.Parameters))
 			hasDefaults := false
 			var paramFuncParams map[int][]typeRepr
+			var voidFuncParams []int
 			for i, param := range fd.Parameters {
 				paramNames[i] = param.Name.Value
 				if param.DefaultValue != nil {
@@ -231,7 +281,7 @@ func scanRegistry(paths []string) (scanResult, []error) {
 						// Qualify unqualified named types with the package name.
 						if tr.kind == "TypeKindNamed" && tr.name != "" &&
 							!strings.Contains(tr.name, ".") &&
-							tr.name != "any" && tr.name != "any2" && tr.name != "ordered" && tr.name != "result" && tr.name != "error" {
+							tr.name != "any" && tr.name != "any2" && tr.name != "ordered" && tr.name != "number" && tr.name != "result" && tr.name != "error" {
 							tr.name = pkgName + "." + tr.name
 						}
 						innerTypes[j] = tr
@@ -241,11 +291,28 @@ func scanRegistry(paths []string) (scanResult, []error) {
 					}
 					paramFuncParams[i] = innerTypes
 				}
+				// A lambda passed for a func with no results is run for its effect.
+				if ft, ok := param.Type.(*ast.FunctionType); ok && len(ft.Returns) == 0 {
+					voidFuncParams = append(voidFuncParams, i)
+				}
 			}
 			if !hasDefaults {
 				defaultValues = nil
 			}
 
+			returnCount := len(fd.Returns)
+			if returnCount == 0 && len(paramFuncParams) == 0 && len(voidFuncParams) == 0 {
+				// Void functions don't need an entry unless a lambda passed to
+				// them needs typing; the codegen handles them fine.
+				continue
+			}
+
+			// Extract per-position return types
+			types := make([]typeRepr, returnCount)
+			for i, ret := range fd.Returns {
+				types[i] = typeAnnotationToRepr(ret)
+			}
+
 			if existing, exists := result.registry[key]; !exists || returnCount > existing.count {
 				result.registry[key] = registryEntry{
 					count:           returnCount,
@@ -253,6 +320,7 @@ func scanRegistry(paths []string) (scanResult, []error) {
 					paramNames:      paramNames,
 					defaultValues:   defaultValues,
 					paramFuncParams: paramFuncParams,
+					voidFuncParams:  voidFuncParams,
 				}
 			}
 		}
@@ -261,6 +329,42 @@ func scanRegistry(paths []string) (scanResult, []error) {
 	return result, errs
 }
 
+// receiverName returns the type name of a method's receiver, whether it is
+// a value or a reference.
+func receiverName(recv *ast.Receiver) string {
+	t := recv.Type
+	if ref, ok := t.(*ast.ReferenceType); ok {
+		t = ref.ElementType
+	}
+	if named, ok := t.(*ast.NamedType); ok {
+		return named.Name
+	}
+	return ""
+}
+
+// operatorReceiver returns the type an operator method (func Add on d
+// Decimal(o Decimal) Decimal operator +) serves. Only the shape the analyzer
+// relies on for a stdlib type is recorded: a value receiver whose parameter
+// and, for arithmetic, result are the receiver type itself.
+func operatorReceiver(fd *ast.FunctionDecl) (string, bool) {
+	recv, ok := fd.Receiver.Type.(*ast.NamedType)
+	if fd.Operator == "" || !ok || len(fd.Parameters) != 1 || len(fd.Returns) != 1 {
+		return "", false
+	}
+	if param, ok := fd.Parameters[0].Type.(*ast.NamedType); !ok || param.Name != recv.Name {
+		return "", false
+	}
+	switch fd.Operator {
+	case "==", "equals", "<":
+		ret, ok := fd.Returns[0].(*ast.PrimitiveType)
+		return recv.Name, ok && ret.Name == "bool"
+	case "+", "-", "*", "/":
+		ret, ok := fd.Returns[0].(*ast.NamedType)
+		return recv.Name, ok && ret.Name == recv.Name
+	}
+	return "", false
+}
+
 // signatureContainsPlaceholder checks if a function's parameters or return types
 // contain a placeholder name (e.g., "any" or "any2").
 func signatureContainsPlaceholder(fd *ast.FunctionDecl, placeholder string) bool {
@@ -339,11 +443,19 @@ func typeAnnotationToRepr(ann ast.TypeAnnotation) typeRepr {
 		val := typeAnnotationToRepr(t.ValueType)
 		return typeRepr{kind: "TypeKindMap", keyType: &key, valueType: &val}
 	case *ast.ChannelType:
-		return typeRepr{kind: "TypeKindChannel"}
+		elem := typeAnnotationToRepr(t.ElementType)
+		return typeRepr{kind: "TypeKindChannel", elementType: &elem}
 	case *ast.ReferenceType:
 		return typeRepr{kind: "TypeKindReference"}
 	case *ast.FunctionType:
-		return typeRepr{kind: "TypeKindFunction"}
+		tr := typeRepr{kind: "TypeKindFunction"}
+		for _, param := range t.Parameters {
+			tr.params = append(tr.params, typeAnnotationToRepr(param))
+		}
+		for _, ret := range t.Returns {
+			tr.returns = append(tr.returns, typeAnnotationToRepr(ret))
+		}
+		return tr
 	default:
 		return typeRepr{kind: "TypeKindUnknown"}
 	}
@@ -364,9 +476,26 @@ func formatTypeRepr(tr typeRepr) string {
 	if tr.valueType != nil {
 		parts = append(parts, fmt.Sprintf("ValueType: &goStdlibType{%s}", formatTypeReprInner(*tr.valueType)))
 	}
+	if len(tr.params) > 0 {
+		parts = append(parts, "Params: "+formatTypeReprList(tr.params))
+	}
+	if len(tr.returns) > 0 {
+		parts = append(parts, "Returns: "+formatTypeReprList(tr.returns))
+	}
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
+// formatTypeReprList formats function parameter or return types as a
+// []goStdlibType literal, keeping nested function signatures such as the
+// yield callback of an iterator.
+func formatTypeReprList(trs []typeRepr) string {
+	items := make([]string, len(trs))
+	for i, tr := range trs {
+		items[i] = formatTypeRepr(tr)
+	}
+	return "[]goStdlibType{" + strings.Join(items, ", ") + "}"
+}
+
 // formatTypeReprInner formats a typeRepr for use inside a nested &goStdlibType{}.
 func formatTypeReprInner(tr typeRepr) string {
 	parts := []string{fmt.Sprintf("Kind: %s", tr.kind)}
@@ -454,6 +583,13 @@ func formatRegistry(result scanResult) []byte {
 		if paramFuncLiteral != "" {
 			entry += fmt.Sprintf(", ParamFuncParams: map[int][]goStdlibType{%s}", paramFuncLiteral)
 		}
+		if len(v.voidFuncParams) > 0 {
+			idxParts := make([]string, len(v.voidFuncParams))
+			for i, idx := range v.voidFuncParams {
+				idxParts[i] = strconv.Itoa(idx)
+			}
+			entry += fmt.Sprintf(", VoidFuncParams: []int{%s}", strings.Join(idxParts, ", "))
+		}
 		entry += "}"
 		entries = append(entries, fmt.Sprintf("\t%q: %s,", k, entry))
 	}
@@ -483,6 +619,55 @@ func formatRegistry(result scanResult) []byte {
 	}
 	sort.Strings(panicsEntries)
 
+	structEntries := make([]string, 0, len(result.structFields))
+	for k, fields := range result.structFields {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fieldParts := make([]string, len(names))
+		for i, name := range names {
+			fieldParts[i] = fmt.Sprintf("%q: %s", name, formatTypeRepr(fields[name]))
+		}
+		structEntries = append(structEntries, fmt.Sprintf("\t%q: {%s},", k, strings.Join(fieldParts, ", ")))
+	}
+	sort.Strings(structEntries)
+
+	operatorEntries := make([]string, 0, len(result.operators))
+	for k, ops := range result.operators {
+		names := make([]string, 0, len(ops))
+		for op := range ops {
+			names = append(names, op)
+		}
+		sort.Strings(names)
+		opParts := make([]string, len(names))
+		for i, op := range names {
+			opParts[i] = fmt.Sprintf("%q: %q", op, ops[op])
+		}
+		operatorEntries = append(operatorEntries, fmt.Sprintf("\t%q: {%s},", k, strings.Join(opParts, ", ")))
+	}
+	sort.Strings(operatorEntries)
+
+	// Methods are only needed where a value's type must survive a method
+	// call, so total.Round(2) + fee still resolves the operator.
+	methodEntries := make([]string, 0)
+	for k, v := range result.methods {
+		if _, ok := result.operators[k[:strings.LastIndex(k, ".")]]; !ok {
+			continue
+		}
+		typeParts := make([]string, len(v.types))
+		for i, tr := range v.types {
+			typeParts[i] = formatTypeRepr(tr)
+		}
+		paramParts := make([]string, len(v.paramNames))
+		for i, name := range v.paramNames {
+			paramParts[i] = fmt.Sprintf("%q", name)
+		}
+		methodEntries = append(methodEntries, fmt.Sprintf("\t%q: {Count: %d, Types: []goStdlibType{%s}, ParamNames: []string{%s}},", k, v.count, strings.Join(typeParts, ", "), strings.Join(paramParts, ", ")))
+	}
+	sort.Strings(methodEntries)
+
 	ifaceEntries := make([]string, 0, len(result.interfaces))
 	for k := range result.interfaces {
 		ifaceEntries = append(ifaceEntries, fmt.Sprintf("\t%q: true,", k))
@@ -530,6 +715,8 @@ var generatedSecurityFunctions = map[string]string{
 //   - "K"  = uses "any2" placeholder only → emits [K comparable]
 //   - "TK" = uses both                    → emits [T any, K comparable]
 //   - "TR" = uses "any" and "result"      → emits [T any, R any]
+//   - "O"  = uses "ordered" only          → emits [K cmp.Ordered]
+//   - "N"  = uses "number" (stdlib/math)  → emits [N ~int | ... | ~float64]
 //
 // Functions not in this map do not use placeholders and are not made generic.
 var generatedSliceGenericClass = map[string]string{
@@ -541,7 +728,29 @@ var generatedSliceGenericClass = map[string]string{
 var generatedStdlibInterfaces = map[string]bool{
 %s
 }
-`, strings.Join(entries, "\n"), strings.Join(depEntries, "\n"), strings.Join(panicsEntries, "\n"), strings.Join(securityEntries, "\n"), strings.Join(genericEntries, "\n"), strings.Join(ifaceEntries, "\n"))
+
+// generatedStdlibStructFields maps qualified Kukicha stdlib struct names to the
+// types of their exported fields. The analyzer attaches them to struct values
+// returned by stdlib functions, so ws.Receive in "receive from ws.Receive" is typed.
+var generatedStdlibStructFields = map[string]map[string]goStdlibType{
+%s
+}
+
+// generatedStdlibOperators maps qualified Kukicha stdlib type names to their
+// operator methods (operator → method name), so a + b on two decimal.Decimal
+// values compiles to a.Add(b). Arithmetic methods return the type itself and
+// == and < methods return bool; the other comparisons derive from those two.
+var generatedStdlibOperators = map[string]map[string]string{
+%s
+}
+
+// generatedStdlibMethods maps qualified Kukicha stdlib method names
+// (pkg.Type.Method) to their signatures, for the types in
+// generatedStdlibOperators.
+var generatedStdlibMethods = map[string]goStdlibEntry{
+%s
+}
+`, strings.Join(entries, "\n"), strings.Join(depEntries, "\n"), strings.Join(panicsEntries, "\n"), strings.Join(securityEntries, "\n"), strings.Join(genericEntries, "\n"), strings.Join(ifaceEntries, "\n"), strings.Join(structEntries, "\n"), strings.Join(operatorEntries, "\n"), strings.Join(methodEntries, "\n"))
 
 	formatted, fmtErr := format.Source([]byte(src))
 	if fmtErr != nil {
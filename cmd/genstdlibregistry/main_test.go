@@ -516,3 +516,45 @@ func TestTypeAnnotationToRepr(t *testing.T) {
 		})
 	}
 }
+
+func TestScanRegistry_OperatorMethods(t *testing.T) {
+	dir := t.TempDir()
+	path := writeKukiFile(t, dir, "money/money.kuki", `petiole money
+
+type Amount
+    cents int
+
+func Add on a Amount(o Amount) Amount operator +
+    return Amount{cents: a.cents + o.cents}
+
+func Same on a Amount(o Amount) bool operator equals
+    return a.cents == o.cents
+
+func Scale on a Amount(o Amount) int operator *
+    return a.cents * o.cents
+
+func Plus on a Amount(o Amount) Amount
+    return a.Add(o)
+`)
+
+	result, errs := scanRegistry([]string{path})
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ops := result.operators["money.Amount"]
+	if ops["+"] != "Add" || ops["=="] != "Same" {
+		t.Errorf("expected + → Add and == → Same, got %v", ops)
+	}
+	if _, ok := ops["*"]; ok {
+		t.Error("an arithmetic operator method not returning its type should not be recorded")
+	}
+	if len(ops) != 2 {
+		t.Errorf("expected 2 operators, got %v", ops)
+	}
+
+	output := string(formatRegistry(result))
+	if !strings.Contains(output, `"money.Amount": {"+": "Add", "==": "Same"}`) {
+		t.Errorf("expected operator entry in output:\n%s", output)
+	}
+}
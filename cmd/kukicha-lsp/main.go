@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lsp"
 )
 
@@ -15,6 +16,13 @@ func main() {
 
 	log.Println("Starting kukicha-lsp server...")
 
+	// Diagnostics follow KUKICHA_LANG, as they do for the kukicha command
+	if lang := os.Getenv(catalog.EnvVar); lang != "" {
+		if err := catalog.SetLanguage(lang); err != nil {
+			log.Printf("Ignoring %s: %v", catalog.EnvVar, err)
+		}
+	}
+
 	ctx := context.Background()
 	server := lsp.NewServer(os.Stdin, os.Stdout)
 
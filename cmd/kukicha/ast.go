@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+func astCommand(filename string, typed bool) {
+	absFile, err := filepath.Abs(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving file path: %v\n", err)
+		os.Exit(1)
+	}
+	target := targetsFor(absFile, "", ast.DefaultTarget)[0]
+	pass, err := loadAndAnalyze(absFile, target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	writeOutline(os.Stdout, filename, pass.Program, pass.Captures, typed)
+}
+
+// writeOutline prints the functions of program and the closures (go blocks,
+// function literals, arrow lambdas) inside each, in source order. With typed,
+// every closure also lists the variables it captures, their types and
+// whether the closure gets a copy or shares the enclosing variable.
+func writeOutline(w io.Writer, filename string, program *ast.Program, captures map[ast.Node][]semantic.Capture, typed bool) {
+	var closures []ast.Node
+	for node := range captures {
+		closures = append(closures, node)
+	}
+	sort.Slice(closures, func(i, j int) bool {
+		pi, pj := closures[i].Pos(), closures[j].Pos()
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+
+	next := 0
+	writeClosures := func(before int) {
+		for ; next < len(closures) && (before < 0 || closures[next].Pos().Line < before); next++ {
+			node := closures[next]
+			fmt.Fprintf(w, "%s:%d    %s\n", filename, node.Pos().Line, closureKind(node))
+			if !typed {
+				continue
+			}
+			for _, c := range captures[node] {
+				mode := "shared"
+				if c.ByValue {
+					mode = "copied"
+				}
+				fmt.Fprintf(w, "        captures %s %s (%s)\n", c.Name, c.Type, mode)
+			}
+		}
+	}
+
+	for i, decl := range program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok {
+			continue
+		}
+		writeClosures(fn.Pos().Line)
+		fmt.Fprintf(w, "%s:%d  %s\n", filename, fn.Pos().Line, outlineSignature(fn))
+		end := -1
+		if i+1 < len(program.Declarations) {
+			end = program.Declarations[i+1].Pos().Line
+		}
+		writeClosures(end)
+	}
+	writeClosures(-1)
+}
+
+// closureKind names a closure for the outline; lambdas list their parameters.
+func closureKind(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.GoStmt:
+		return "go block"
+	case *ast.FunctionLiteral:
+		return "func literal"
+	case *ast.ArrowLambda:
+		names := make([]string, len(n.Parameters))
+		for i, p := range n.Parameters {
+			names[i] = p.Name.Value
+		}
+		return "lambda (" + strings.Join(names, ", ") + ")"
+	}
+	return "closure"
+}
+
+// outlineSignature is the function's name with its receiver, if any.
+func outlineSignature(fn *ast.FunctionDecl) string {
+	if fn.Receiver == nil {
+		return "func " + fn.Name.Value
+	}
+	recvType := fn.Receiver.Type
+	prefix := ""
+	if ref, ok := recvType.(*ast.ReferenceType); ok {
+		recvType, prefix = ref.ElementType, "reference "
+	}
+	if named, ok := recvType.(*ast.NamedType); ok {
+		return fmt.Sprintf("func %s on %s %s%s", fn.Name.Value, fn.Receiver.Name.Value, prefix, named.Name)
+	}
+	return fmt.Sprintf("func %s on %s", fn.Name.Value, fn.Receiver.Name.Value)
+}
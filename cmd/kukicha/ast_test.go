@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+func TestWriteOutline_TypedCaptures(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.kuki")
+	source := `func run(names list of string)
+    current := ""
+    for name in names
+        current = name
+        go
+            print(current)
+
+func main()
+    run(["a"])
+`
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pass, err := loadAndAnalyze(path, ast.DefaultTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plain, typed bytes.Buffer
+	writeOutline(&plain, "app.kuki", pass.Program, pass.Captures, false)
+	writeOutline(&typed, "app.kuki", pass.Program, pass.Captures, true)
+
+	want := "app.kuki:1  func run\napp.kuki:5    go block\napp.kuki:8  func main\n"
+	if plain.String() != want {
+		t.Errorf("outline:\n%s\nwant:\n%s", plain.String(), want)
+	}
+	if !strings.Contains(typed.String(), "app.kuki:5    go block\n        captures current string (copied)\n") {
+		t.Errorf("typed outline missing the copied capture:\n%s", typed.String())
+	}
+}
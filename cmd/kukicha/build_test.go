@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/version"
+)
+
+func TestBuildArgs(t *testing.T) {
+	metadata := buildMetadata{Version: "1.2.3", Source: "sha256:ab", Built: "2026-01-02T03:04:05Z"}
+	xflag := "-X main.kukichaBuild=kukicha-build;version=1.2.3;source=sha256:ab;built=2026-01-02T03:04:05Z;end"
+
+	got := buildArgs("/p/app", "/p/app.go", false, metadata)
+	want := []string{"build", "-mod=mod", "-ldflags", xflag, "-o", "/p/app", "/p/app.go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("buildArgs = %q, want %q", got, want)
+	}
+
+	got = buildArgs("/p/app", "/p/app.go", true, metadata)
+	want = []string{"build", "-mod=mod", "-trimpath", "-ldflags", "-s -w " + xflag, "-o", "/p/app", "/p/app.go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("release buildArgs = %q, want %q", got, want)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KB",
+		3 * 1024 * 1024: "3.0 MB",
+		1887437:         "1.8 MB",
+	}
+	for n, want := range tests {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestBuildMetadata(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "app.kuki")
+	if err := os.WriteFile(source, []byte("func main()\n    print(1)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	m, err := newBuildMetadata(source)
+	if err != nil {
+		t.Fatalf("newBuildMetadata: %v", err)
+	}
+	if m.Version != version.Version || m.Built != "2023-11-14T22:13:20Z" || !strings.HasPrefix(m.Source, "sha256:") || len(m.Source) != len("sha256:")+64 {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+
+	// The linker packs other strings right after the value, and the prefix
+	// also appears alone where stdlib/buildinfo strips it
+	binary := []byte("kukicha-build;invalid syntax" + strings.Repeat("\x00", 600) + "\x00\x01runtime.main" + m.String() + "go:buildid\x7f")
+	got, ok := findBuildMetadata(binary)
+	if !ok || got != m {
+		t.Errorf("findBuildMetadata = %+v, %v; want %+v", got, ok, m)
+	}
+	if _, ok := findBuildMetadata([]byte("no metadata here")); ok {
+		t.Error("expected no metadata in a plain binary")
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, err := newBuildMetadata(source); err == nil {
+		t.Error("expected an error for an invalid SOURCE_DATE_EPOCH")
+	}
+}
+
+func TestImportPathsExpandEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.kuki")
+	source := "import \"${KUKICHA_TEST_PKG}\"\n\nfunc main()\n    print(strings.ToUpper(\"x\"))\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUKICHA_TEST_PKG", "strings")
+	pass, err := loadAndAnalyze(path, ast.DefaultTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pass.Program.Imports[0].Path.Value; got != "strings" {
+		t.Errorf("import path = %q, want strings", got)
+	}
+
+	os.Unsetenv("KUKICHA_TEST_PKG")
+	_, err = loadAndAnalyze(path, ast.DefaultTarget)
+	if err == nil || !strings.Contains(err.Error(), "app.kuki:1:1: import path: environment variable KUKICHA_TEST_PKG is not set") {
+		t.Errorf("expected unset variable error, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duber000/kukicha/internal/codegen"
+	"github.com/duber000/kukicha/internal/version"
+)
+
+// buildMetadataPrefix and buildMetadataSuffix delimit the metadata string in
+// a binary. The suffix marks the end because the linker packs string data
+// back to back with no terminator.
+const (
+	buildMetadataPrefix = "kukicha-build;"
+	buildMetadataSuffix = ";end"
+)
+
+// buildMetadata is what kukicha build embeds in a binary: the compiler
+// version, the SHA-256 of the .kuki source, and the build time.
+type buildMetadata struct {
+	Version string
+	Source  string // "sha256:<hex>"
+	Built   string // RFC 3339, UTC
+}
+
+// newBuildMetadata describes a build of sourceFile now. The build time comes
+// from SOURCE_DATE_EPOCH when it is set, so rebuilding the same source gives
+// the same binary.
+func newBuildMetadata(sourceFile string) (buildMetadata, error) {
+	source, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return buildMetadata{}, err
+	}
+	sum := sha256.Sum256(source)
+	built := time.Now()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		secs, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return buildMetadata{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q", epoch)
+		}
+		built = time.Unix(secs, 0)
+	}
+	return buildMetadata{
+		Version: version.Version,
+		Source:  "sha256:" + hex.EncodeToString(sum[:]),
+		Built:   built.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// String encodes m as the value kukicha build passes to -X. It has no spaces,
+// so it needs no quoting inside -ldflags.
+func (m buildMetadata) String() string {
+	return buildMetadataPrefix + "version=" + m.Version + ";source=" + m.Source + ";built=" + m.Built + buildMetadataSuffix
+}
+
+// ldflag returns the -X flag that sets codegen.BuildMetadataVar to m.
+func (m buildMetadata) ldflag() string {
+	return "-X main." + codegen.BuildMetadataVar + "=" + m.String()
+}
+
+// findBuildMetadata extracts the metadata kukicha build embedded in a binary.
+// The prefix also occurs on its own (stdlib/buildinfo strips it), so every
+// occurrence is tried.
+func findBuildMetadata(data []byte) (buildMetadata, bool) {
+	for {
+		start := bytes.Index(data, []byte(buildMetadataPrefix))
+		if start < 0 {
+			return buildMetadata{}, false
+		}
+		data = data[start+len(buildMetadataPrefix):]
+		end := bytes.Index(data, []byte(buildMetadataSuffix))
+		if end < 0 || end > 512 {
+			continue
+		}
+		var m buildMetadata
+		for field := range strings.SplitSeq(string(data[:end]), ";") {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "version":
+				m.Version = value
+			case "source":
+				m.Source = value
+			case "built":
+				m.Built = value
+			}
+		}
+		if m.Version != "" {
+			return m, true
+		}
+	}
+}
+
+// versionCommand prints the compiler version, or with --of the metadata
+// embedded in a binary built by kukicha build.
+func versionCommand(args []string) {
+	versionFlags := flag.NewFlagSet("version", flag.ContinueOnError)
+	versionFlags.SetOutput(os.Stderr)
+	of := versionFlags.String("of", "", "Show the kukicha build metadata of a binary")
+	if err := versionFlags.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, "Usage: kukicha version [--of <binary>]")
+		os.Exit(1)
+	}
+	if *of == "" {
+		fmt.Printf("kukicha version %s\n", version.Version)
+		return
+	}
+
+	data, err := os.ReadFile(*of)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	m, ok := findBuildMetadata(data)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: %s has no kukicha build metadata (not built with kukicha build, or it does not import stdlib/buildinfo)\n", *of)
+		os.Exit(1)
+	}
+	fmt.Printf("kukicha %s\n", m.Version)
+	fmt.Printf("source  %s\n", m.Source)
+	fmt.Printf("built   %s\n", m.Built)
+	if info, err := buildinfo.ReadFile(*of); err == nil {
+		fmt.Printf("go      %s\n", info.GoVersion)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/version"
+)
+
+// compilerPackages prefixes the functions that belong to the compiler, as
+// opposed to the runtime or the CLI.
+const compilerPackages = "github.com/duber000/kukicha/internal/"
+
+// reportCompilerPanic turns a panic anywhere in the compiler into a bug
+// report instead of a bare goroutine dump. Commands that run the pipeline
+// defer it; action names what they were doing with filename ("checking").
+func reportCompilerPanic(action, filename string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	writeCompilerBug(os.Stderr, action, filename, r, panicFrame(), debug.Stack())
+	os.Exit(1)
+}
+
+// writeCompilerBug writes the report for panic value r: what the CLI was
+// doing, the compiler function that panicked, and the stack to attach to the
+// bug report.
+func writeCompilerBug(w io.Writer, action, filename string, r any, frame string, stack []byte) {
+	fmt.Fprintf(w, "internal compiler error: %v\n", r)
+	fmt.Fprintf(w, "  while %s %s\n", action, filename)
+	if frame != "" {
+		fmt.Fprintf(w, "  in %s\n", frame)
+	}
+	fmt.Fprintf(w, "This is a bug in kukicha %s, not in your code. Please report it at\n", version.Version)
+	fmt.Fprintf(w, "https://github.com/duber000/kukicha/issues with %s and this stack:\n\n", filename)
+	w.Write(stack)
+}
+
+// panicFrame returns the innermost compiler function on the panicking
+// goroutine's stack, as "pkg.Func (file.go:line)", or "" when the panic did
+// not come from the compiler. It must be called from the deferred function.
+func panicFrame() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, compilerPackages); ok {
+			return fmt.Sprintf("%s (%s:%d)", name, filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
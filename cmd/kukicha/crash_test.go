@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/lexer"
+)
+
+func TestWriteCompilerBug(t *testing.T) {
+	var out bytes.Buffer
+	writeCompilerBug(&out, "checking", "app.kuki", "index out of range [3] with length 3",
+		"semantic.(*Analyzer).analyzeCall (semantic_calls.go:42)", []byte("goroutine 1 [running]:\n"))
+	got := out.String()
+	for _, want := range []string{
+		"internal compiler error: index out of range [3] with length 3\n",
+		"  while checking app.kuki\n",
+		"  in semantic.(*Analyzer).analyzeCall (semantic_calls.go:42)\n",
+		"This is a bug in kukicha",
+		"with app.kuki and this stack:\n\ngoroutine 1 [running]:\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestPanicFrame(t *testing.T) {
+	var frame string
+	func() {
+		defer func() {
+			recover()
+			frame = panicFrame()
+		}()
+		var l *lexer.Lexer
+		l.ScanTokens()
+	}()
+	if !strings.HasPrefix(frame, "lexer.(*Lexer).") || !strings.Contains(frame, "(lexer.go:") {
+		t.Errorf("expected the lexer frame, got %q", frame)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/duber000/kukicha/internal/migrate"
+)
+
+func fixCommand(paths []string, name string, dryRun bool) {
+	m := migrate.Lookup(name)
+	if m == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown migration %q (see 'kukicha fix --list')\n", name)
+		os.Exit(1)
+	}
+	files, err := expandKukiFiles(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no .kuki files found")
+		os.Exit(1)
+	}
+
+	exitCode := 0
+	for _, file := range files {
+		n, err := fixFile(file, m, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+			exitCode = 1
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		if dryRun {
+			fmt.Printf("%s: %d rewrite(s) pending\n", file, n)
+			exitCode = 1
+		} else {
+			fmt.Printf("%s: %d rewrite(s)\n", file, n)
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// fixFile applies m to filename and returns the number of rewrites. The file
+// is only written when something changed and dryRun is false.
+func fixFile(filename string, m *migrate.Migration, dryRun bool) (int, error) {
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	out, n, err := migrate.Apply(m, string(source), filename)
+	if err != nil || n == 0 || dryRun {
+		return n, err
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		return 0, err
+	}
+	return n, os.WriteFile(filename, []byte(out), info.Mode())
+}
+
+func printMigrations() {
+	for _, m := range migrate.Migrations() {
+		fmt.Printf("  %-18s %s\n", m.Name, m.Description)
+	}
+}
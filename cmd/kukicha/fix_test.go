@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/migrate"
+)
+
+func TestFixFile_DryRunDoesNotWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.kuki")
+	content := "func main()\n    n := int64(3)\n    print(n)\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := migrate.Lookup("go-conversions")
+
+	n, err := fixFile(path, m, true)
+	if err != nil || n != 1 {
+		t.Fatalf("dry run: got %d rewrites, err %v", n, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != content {
+		t.Errorf("dry run modified the file:\n%s", got)
+	}
+
+	if _, err := fixFile(path, m, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); !strings.Contains(string(got), "n := 3 as int64") {
+		t.Errorf("expected migration written to file, got:\n%s", got)
+	}
+}
@@ -57,33 +57,10 @@ func fmtCommand(args []string) {
 		os.Exit(1)
 	}
 
-	// Expand directories to .kuki files
-	var allFiles []string
-	for _, file := range files {
-		info, err := os.Stat(file)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-
-		if info.IsDir() {
-			// Find all .kuki files in directory
-			err := filepath.WalkDir(file, func(path string, d os.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
-				if !d.IsDir() && strings.HasSuffix(path, ".kuki") {
-					allFiles = append(allFiles, path)
-				}
-				return nil
-			})
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error walking directory: %v\n", err)
-				os.Exit(1)
-			}
-		} else {
-			allFiles = append(allFiles, file)
-		}
+	allFiles, err := expandKukiFiles(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	if len(allFiles) == 0 {
@@ -115,6 +92,35 @@ func fmtCommand(args []string) {
 	os.Exit(exitCode)
 }
 
+// expandKukiFiles replaces each directory in paths with the .kuki files
+// found beneath it.
+func expandKukiFiles(paths []string) ([]string, error) {
+	var allFiles []string
+	for _, file := range paths {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			allFiles = append(allFiles, file)
+			continue
+		}
+		err = filepath.WalkDir(file, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(path, ".kuki") {
+				allFiles = append(allFiles, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walking directory: %v", err)
+		}
+	}
+	return allFiles, nil
+}
+
 func checkFile(filename string, opts formatter.FormatOptions) bool {
 	source, err := os.ReadFile(filename)
 	if err != nil {
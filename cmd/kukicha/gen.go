@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/apigen"
+)
+
+// genCommand writes the Kukicha file generated from spec (kind is openapi
+// or proto) to output, or to stdout when output is "".
+func genCommand(kind, spec, pkg, goPackage, output string) {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if pkg == "" {
+		pkg = packageNameFor(spec)
+	}
+	opts := apigen.Options{Package: pkg, Source: filepath.Base(spec), GoPackage: goPackage}
+	var source string
+	switch kind {
+	case "openapi":
+		source, err = apigen.OpenAPI(data, opts)
+	case "proto":
+		source, err = apigen.Proto(data, opts)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", spec, err)
+		os.Exit(1)
+	}
+	if output == "" {
+		fmt.Print(source)
+		return
+	}
+	if err := os.WriteFile(output, []byte(source), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s\n", output)
+}
+
+// packageNameFor derives a petiole from a spec file name: its letters and
+// digits, lowercased ("pet-store.v2.yaml" is petstorev2).
+func packageNameFor(spec string) string {
+	base := strings.TrimSuffix(filepath.Base(spec), filepath.Ext(spec))
+	var b strings.Builder
+	for _, r := range strings.ToLower(base) {
+		if unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "api"
+	}
+	return b.String()
+}
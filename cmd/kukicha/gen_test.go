@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestPackageNameFor(t *testing.T) {
+	for spec, want := range map[string]string{
+		"petstore.yaml":           "petstore",
+		"specs/pet-store.v2.json": "petstorev2",
+		"2024_api.yaml":           "api",
+		"---.yaml":                "api",
+	} {
+		if got := packageNameFor(spec); got != want {
+			t.Errorf("packageNameFor(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
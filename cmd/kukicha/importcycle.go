@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/workspace"
+	"golang.org/x/mod/modfile"
+)
+
+// kukichaModule is the module `import "stdlib/..."` paths are rewritten into
+// (see codegen.rewriteStdlibImport).
+const kukichaModule = "github.com/duber000/kukicha"
+
+// importEdge is one import of a package: the file and line that declares it,
+// the path as written and the Go import path it resolves to.
+type importEdge struct {
+	pos     string
+	written string
+	path    string
+}
+
+// importGraph resolves the imports of the petioles (packages) of one Go
+// module: those whose import path is the module's or below it, and
+// stdlib/... paths when the module is kukicha itself. With a kukicha.work,
+// the petioles of the workspace's other modules resolve too.
+type importGraph struct {
+	moduleDir  string
+	modulePath string
+	workspace  *workspace.Workspace    // nil outside a workspace
+	edges      map[string][]importEdge // package path → its imports, loaded on demand
+}
+
+// checkImportCycles reports an import cycle reachable from the petiole that
+// filename belongs to, with the chain of imports that closes it. That
+// includes a petiole importing itself, such as a file in kukicha's
+// stdlib/json importing "stdlib/json". Files outside a Go module, and test
+// files (which may import their own package from an external test
+// petiole), are not checked.
+func checkImportCycles(filename string) error {
+	absFile, err := filepath.Abs(filename)
+	if err != nil || strings.HasSuffix(absFile, "_test.kuki") {
+		return nil
+	}
+	g, err := newImportGraph(absFile)
+	if g == nil {
+		return err
+	}
+	start, ok := g.packagePath(filepath.Dir(absFile))
+	if !ok {
+		return nil
+	}
+	return g.findCycle(start, nil, nil, map[string]bool{})
+}
+
+// newImportGraph returns the import graph of the module absFile belongs
+// to, or nil when it is outside a Go module.
+func newImportGraph(absFile string) (*importGraph, error) {
+	moduleDir := findProjectDir(absFile)
+	data, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return nil, nil
+	}
+	modulePath := modfile.ModulePath(data)
+	if modulePath == "" {
+		return nil, nil
+	}
+	ws, err := workspace.ForDir(moduleDir)
+	if err != nil {
+		return nil, err
+	}
+	return &importGraph{moduleDir: moduleDir, modulePath: modulePath, workspace: ws, edges: make(map[string][]importEdge)}, nil
+}
+
+// findCycle walks the imports of pkg depth first. pkgs are the packages on
+// the way to pkg and edges the imports between them (edges[i] leads from
+// pkgs[i] to pkgs[i+1]); done marks packages already known to be cycle-free.
+func (g *importGraph) findCycle(pkg string, pkgs []string, edges []importEdge, done map[string]bool) error {
+	pkgs = append(pkgs, pkg)
+	for _, edge := range g.importsOf(pkg) {
+		if _, ok := g.packageDir(edge.path); !ok || done[edge.path] {
+			continue
+		}
+		if i := slices.Index(pkgs, edge.path); i >= 0 {
+			return cycleError(edge.path, append(edges[i:len(edges):len(edges)], edge))
+		}
+		if err := g.findCycle(edge.path, pkgs, append(edges, edge), done); err != nil {
+			return err
+		}
+	}
+	done[pkg] = true
+	return nil
+}
+
+// importsOf returns the imports declared by the .kuki files of pkg, loading
+// them on first use (see parsePetiole).
+func (g *importGraph) importsOf(pkg string) []importEdge {
+	if edges, ok := g.edges[pkg]; ok {
+		return edges
+	}
+	var edges []importEdge
+	dir, _ := g.packageDir(pkg)
+	for _, program := range parsePetiole(dir) {
+		for _, imp := range program.Imports {
+			pos := imp.Pos()
+			rel, err := filepath.Rel(g.moduleDir, pos.File)
+			if err != nil {
+				rel = pos.File
+			}
+			written := imp.Path.Value
+			path := written
+			if strings.HasPrefix(path, "stdlib/") {
+				path = kukichaModule + "/" + path
+			}
+			edges = append(edges, importEdge{
+				pos:     fmt.Sprintf("%s:%d", filepath.ToSlash(rel), pos.Line),
+				written: written,
+				path:    path,
+			})
+		}
+	}
+	g.edges[pkg] = edges
+	return edges
+}
+
+// parsePetiole returns the parsed .kuki files of the package in dir, their
+// import paths expanded. Test files and files that do not parse are skipped;
+// check reports the latter on its own.
+func parsePetiole(dir string) []*ast.Program {
+	var programs []*ast.Program
+	files, _ := filepath.Glob(filepath.Join(dir, "*.kuki"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.kuki") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		p, err := parser.New(string(source), file)
+		if err != nil {
+			continue
+		}
+		program, errs := p.Parse()
+		if len(errs) > 0 || expandImportPaths(program) != nil {
+			continue
+		}
+		programs = append(programs, program)
+	}
+	return programs
+}
+
+// packagePath returns the import path of the package in dir.
+func (g *importGraph) packagePath(dir string) (string, bool) {
+	rel, err := filepath.Rel(g.moduleDir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return g.modulePath, true
+	}
+	return g.modulePath + "/" + filepath.ToSlash(rel), true
+}
+
+// packageDir returns the directory of the package with import path pkg, or
+// false for a package outside the module and workspace.
+func (g *importGraph) packageDir(pkg string) (string, bool) {
+	if pkg == g.modulePath {
+		return g.moduleDir, true
+	}
+	if rel, ok := strings.CutPrefix(pkg, g.modulePath+"/"); ok {
+		return filepath.Join(g.moduleDir, filepath.FromSlash(rel)), true
+	}
+	if g.workspace != nil {
+		return g.workspace.Resolve(pkg)
+	}
+	return "", false
+}
+
+// cycleError formats the chain of imports that leads from pkg back to it.
+func cycleError(pkg string, chain []importEdge) error {
+	var b strings.Builder
+	if len(chain) == 1 {
+		fmt.Fprintf(&b, "import cycle not allowed: package %s imports itself", pkg)
+	} else {
+		fmt.Fprintf(&b, "import cycle not allowed:\n  package %s", pkg)
+	}
+	for _, e := range chain {
+		fmt.Fprintf(&b, "\n  %s: imports %s", e.pos, e.path)
+		if e.written != e.path {
+			fmt.Fprintf(&b, " (as %q)", e.written)
+		}
+	}
+	return errors.New(b.String())
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeModule(t *testing.T, module string, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module " + module + "\n\ngo 1.26\n"
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckImportCyclesTrace(t *testing.T) {
+	dir := writeModule(t, "example.com/app", map[string]string{
+		"main.kuki": "import \"example.com/app/a\"\n\nfunc main()\n    print(a.A())\n",
+		"a/a.kuki":  "petiole a\n\nimport \"example.com/app/b\"\n\nfunc A() int\n    return b.B()\n",
+		"b/b.kuki":  "petiole b\n\nimport \"strings\"\nimport \"example.com/app/a\"\n\nfunc B() int\n    return len(strings.Fields(\"x\")) + a.A()\n",
+	})
+
+	err := checkImportCycles(filepath.Join(dir, "main.kuki"))
+	if err == nil {
+		t.Fatal("expected an import cycle error")
+	}
+	want := "import cycle not allowed:\n" +
+		"  package example.com/app/a\n" +
+		"  a/a.kuki:3: imports example.com/app/b\n" +
+		"  b/b.kuki:4: imports example.com/app/a"
+	if err.Error() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", err, want)
+	}
+}
+
+func TestCheckImportCyclesNone(t *testing.T) {
+	dir := writeModule(t, "example.com/app", map[string]string{
+		"main.kuki":     "import \"example.com/app/a\"\nimport \"example.com/app/b\"\n\nfunc main()\n    print(a.A() + b.B())\n",
+		"a/a.kuki":      "petiole a\n\nimport \"example.com/app/b\"\n\nfunc A() int\n    return b.B()\n",
+		"b/b.kuki":      "petiole b\n\nfunc B() int\n    return 1\n",
+		"b/b_test.kuki": "petiole b_test\n\nimport \"example.com/app/a\"\n",
+	})
+
+	for _, file := range []string{"main.kuki", "a/a.kuki", "b/b.kuki"} {
+		if err := checkImportCycles(filepath.Join(dir, file)); err != nil {
+			t.Errorf("%s: unexpected error: %v", file, err)
+		}
+	}
+}
+
+func TestCheckImportCyclesStdlibSelfImport(t *testing.T) {
+	dir := writeModule(t, kukichaModule, map[string]string{
+		"stdlib/json/json.kuki": "petiole json\n\nimport \"stdlib/json\"\n",
+	})
+
+	err := checkImportCycles(filepath.Join(dir, "stdlib", "json", "json.kuki"))
+	if err == nil || !strings.Contains(err.Error(), "package github.com/duber000/kukicha/stdlib/json imports itself") ||
+		!strings.Contains(err.Error(), `(as "stdlib/json")`) {
+		t.Fatalf("expected a self-import error, got %v", err)
+	}
+}
+
+func TestCheckImportCyclesAcrossWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"kukicha.work":       "use ./lib\nuse ./app\n",
+		"lib/go.mod":         "module example.com/lib\n",
+		"lib/util/util.kuki": "petiole util\n\nimport \"example.com/app/api\"\n",
+		"app/go.mod":         "module example.com/app\n",
+		"app/api/api.kuki":   "petiole api\n\nimport \"example.com/lib/util\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := checkImportCycles(filepath.Join(dir, "app", "api", "api.kuki"))
+	want := "import cycle not allowed:\n" +
+		"  package example.com/app/api\n" +
+		"  api/api.kuki:3: imports example.com/lib/util\n" +
+		"  ../lib/util/util.kuki:3: imports example.com/app/api"
+	if err == nil || err.Error() != want {
+		t.Errorf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestLoadAndAnalyzeChecksPetioleCalls(t *testing.T) {
+	dir := writeModule(t, "example.com/app", map[string]string{
+		"main.kuki": "import \"example.com/app/a\"\n\nfunc main()\n    print(a.A(1, 2))\n",
+		"a/a.kuki":  "petiole a\n\nfunc A(n int) int\n    return n\n",
+	})
+
+	_, err := loadAndAnalyze(filepath.Join(dir, "main.kuki"), "")
+	if err == nil || !strings.Contains(err.Error(), "at most 1 arguments, got 2") {
+		t.Fatalf("expected an arity error for a.A, got %v", err)
+	}
+}
@@ -5,9 +5,52 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
-func initCommand(args []string) {
+// initTemplates are the starter programs `kukicha init --template` writes
+// to main.kuki, keyed by template name.
+var initTemplates = map[string]string{
+	"a2a": a2aTemplate,
+}
+
+const a2aTemplate = `# target: a2a
+
+# An A2A agent: other agents discover it at
+# http://localhost:9000/.well-known/agent-card.json and send it tasks.
+# Run it with: kukicha run main.kuki
+
+import "stdlib/a2a"
+import "stdlib/string"
+
+func main()
+    server := a2a.NewServer("echo", "Repeats what you say, loudly")
+        |> a2a.AddSkill("shout", "Echo a message in capitals", list of string{"hello there"})
+        |> a2a.Handle(shout)
+    print("A2A agent listening on :9000")
+    server |> a2a.Serve(":9000") onerr panic "{error}"
+
+# shout runs one task: report progress, stream the reply as an artifact,
+# then return empty to complete the task (or an error to fail it).
+func shout(task reference a2a.TaskContext) error
+    task.Status("shouting") onerr return
+    for word in string.Fields(task.Text)
+        task.Artifact("reply", string.ToUpper(word) + " ") onerr return
+    return empty
+`
+
+func initCommand(args []string, template string) {
+	templateSource := ""
+	if template != "" {
+		src, ok := initTemplates[template]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: unknown template %q (available: %s)\n", template, strings.Join(templateNames(), ", "))
+			os.Exit(1)
+		}
+		templateSource = src
+	}
+
 	projectDir, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
@@ -57,6 +100,14 @@ func initCommand(args []string) {
 		fmt.Fprintln(os.Stderr, "kukicha run will update go.sum automatically on first use.")
 	}
 
+	if templateSource != "" {
+		if err := writeTemplate(projectDir, templateSource); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing template: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote main.kuki from the %s template.\n", template)
+	}
+
 	fmt.Println("Kukicha project initialized.")
 	fmt.Printf("  Stdlib extracted to: %s\n", stdlibPath)
 	fmt.Println("  go.mod updated with replace directive.")
@@ -66,3 +117,22 @@ func initCommand(args []string) {
 	fmt.Println("Commit AGENTS.md. Add .kukicha/ to your .gitignore:")
 	fmt.Println("  echo '.kukicha/' >> .gitignore")
 }
+
+// writeTemplate writes a starter program to main.kuki, refusing to replace
+// an existing one.
+func writeTemplate(projectDir, source string) error {
+	path := filepath.Join(projectDir, "main.kuki")
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	return os.WriteFile(path, []byte(source), 0644)
+}
+
+func templateNames() []string {
+	names := make([]string, 0, len(initTemplates))
+	for name := range initTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
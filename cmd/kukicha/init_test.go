@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/duber000/kukicha/internal/ast"
 	"github.com/duber000/kukicha/internal/version"
 )
 
@@ -343,3 +344,21 @@ func TestFindProjectDir_NoGoMod(t *testing.T) {
 		t.Errorf("expected %s, got %s", absSub, result)
 	}
 }
+
+func TestInitTemplatesCheck(t *testing.T) {
+	for _, name := range templateNames() {
+		dir := t.TempDir()
+		if err := writeTemplate(dir, initTemplates[name]); err != nil {
+			t.Fatalf("%s: writeTemplate: %v", name, err)
+		}
+		file := filepath.Join(dir, "main.kuki")
+		for _, target := range targetsFor(file, "", ast.DefaultTarget) {
+			if _, err := loadAndAnalyze(file, target); err != nil {
+				t.Errorf("%s template (target %s) does not check: %v", name, target, err)
+			}
+		}
+		if err := writeTemplate(dir, initTemplates[name]); err == nil {
+			t.Errorf("%s: writeTemplate replaced an existing main.kuki", name)
+		}
+	}
+}
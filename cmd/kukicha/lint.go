@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/lint"
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+func lintCommand(paths []string, fix bool, configPath string) {
+	files, err := expandKukiFiles(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no .kuki files found")
+		os.Exit(1)
+	}
+
+	var explicit *lint.Config
+	if configPath != "" {
+		explicit, err = lint.LoadConfig(configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	configs := make(map[string]*lint.Config)
+
+	exitCode := 0
+	for _, file := range files {
+		cfg := explicit
+		if cfg == nil {
+			cfg, err = configForFile(file, configs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		diags, err := lintFile(file, cfg, fix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			exitCode = 1
+			continue
+		}
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+		if len(diags) > 0 {
+			exitCode = 1
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// configForFile returns the kukicha.toml configuration that applies to file,
+// caching by config path. Files with no kukicha.toml use the defaults.
+func configForFile(file string, cache map[string]*lint.Config) (*lint.Config, error) {
+	path := lint.FindConfig(filepath.Dir(file))
+	if path == "" {
+		return lint.DefaultConfig(), nil
+	}
+	if cfg, ok := cache[path]; ok {
+		return cfg, nil
+	}
+	cfg, err := lint.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	cache[path] = cfg
+	return cfg, nil
+}
+
+// lintFile parses, analyzes, and lints filename. With fix, safe fixes are
+// written back to the file and only the remaining diagnostics are returned.
+// Lint needs a program that type checks; parse and semantic errors are
+// returned as an error.
+func lintFile(filename string, cfg *lint.Config, fix bool) ([]lint.Diagnostic, error) {
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %v", filename, err)
+	}
+
+	p, err := parser.New(string(source), filename)
+	if err != nil {
+		return nil, fmt.Errorf("Lexer error: %v", err)
+	}
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		var msgs []string
+		for _, e := range parseErrors {
+			msgs = append(msgs, fmt.Sprintf("  %v", e))
+		}
+		return nil, fmt.Errorf("Parse errors:\n%s", strings.Join(msgs, "\n"))
+	}
+
+	analyzer := semantic.NewWithFile(program, filename)
+	if semanticErrors := analyzer.Analyze(); len(semanticErrors) > 0 {
+		var msgs []string
+		for _, e := range semanticErrors {
+			msgs = append(msgs, fmt.Sprintf("  %v", e))
+		}
+		return nil, fmt.Errorf("Semantic errors (run 'kukicha check' and fix these first):\n%s", strings.Join(msgs, "\n"))
+	}
+
+	diags := lint.Run(lint.Input{
+		Program:         program,
+		File:            filename,
+		Source:          string(source),
+		ReturnCounts:    analyzer.ReturnCounts(),
+		ExprTypes:       analyzer.ExprTypes(),
+		UncheckedErrors: analyzer.UncheckedErrors(),
+		LoopCaptures:    analyzer.LoopCaptures(),
+	}, cfg)
+	if !fix {
+		return diags, nil
+	}
+
+	fixed, applied := lint.ApplyFixes(string(source), diags)
+	if applied > 0 {
+		if err := os.WriteFile(filename, []byte(fixed), 0644); err != nil {
+			return nil, err
+		}
+		fmt.Printf("fixed %d issue(s) in %s\n", applied, filename)
+	}
+	var remaining []lint.Diagnostic
+	for _, d := range diags {
+		if d.Fix == nil {
+			remaining = append(remaining, d)
+		}
+	}
+	return remaining, nil
+}
+
+func printLintRules() {
+	for _, rule := range lint.Rules() {
+		state := "on"
+		if !rule.DefaultEnabled() {
+			state = "off"
+		}
+		fmt.Printf("  %-22s %-4s %s\n", rule.Name(), state, rule.Description())
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/lint"
+)
+
+func TestLintFile_FixWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.kuki")
+	content := "import \"os\"\n\nfunc main()\n    data := os.ReadFile(\"x\") onerr panic \"read failed\"\n    print(len(data))\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := lintFile(path, lint.DefaultConfig(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 0 {
+		t.Errorf("expected fixed diagnostics to be dropped, got: %v", diags)
+	}
+	got, _ := os.ReadFile(path)
+	if !strings.Contains(string(got), `onerr panic "read failed: {error}"`) {
+		t.Errorf("expected fix written to file, got:\n%s", got)
+	}
+}
+
+func TestLintFile_SemanticErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.kuki")
+	if err := os.WriteFile(path, []byte("func main()\n    print(missing)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lintFile(path, lint.DefaultConfig(), false); err == nil || !strings.Contains(err.Error(), "kukicha check") {
+		t.Errorf("expected semantic errors to be reported, got: %v", err)
+	}
+}
+
+func TestConfigForFile_UsesNearestToml(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kukicha.toml"), []byte("[lint]\ndisable = [\"naming\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := make(map[string]*lint.Config)
+	cfg, err := configForFile(filepath.Join(dir, "app.kuki"), cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Disable["naming"] || len(cache) != 1 {
+		t.Errorf("expected kukicha.toml to be loaded and cached, got %+v (cache %d)", cfg, len(cache))
+	}
+}
@@ -5,20 +5,22 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
-	"go/format"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/codegen"
+	"github.com/duber000/kukicha/internal/env"
+	"github.com/duber000/kukicha/internal/hooks"
+	"github.com/duber000/kukicha/internal/lint"
 	"github.com/duber000/kukicha/internal/parser"
 	"github.com/duber000/kukicha/internal/semantic"
-	"github.com/duber000/kukicha/internal/version"
 )
 
 func main() {
@@ -34,48 +36,186 @@ func main() {
 	case "build":
 		buildFlags := flag.NewFlagSet("build", flag.ContinueOnError)
 		buildFlags.SetOutput(os.Stderr)
-		target := buildFlags.String("target", "", "Compile target")
+		target := buildFlags.String("target", "", "Compile targets (comma-separated: cli, mcp, a2a, http, worker)")
 		skipBuild := buildFlags.Bool("skip-build", false, "Skip go build step (for test files)")
 		ifChanged := buildFlags.Bool("if-changed", false, "Skip writing output if Go body (excluding generated header) is unchanged")
 		vulncheck := buildFlags.Bool("vulncheck", false, "Run govulncheck after successful build")
+		release := buildFlags.Bool("release", false, "Build a small distributable binary (-trimpath, -ldflags \"-s -w\", no //line directives, no must.True/must.False assertions)")
+		upx := buildFlags.Bool("upx", false, "Compress the binary with upx (must be on PATH) and report the size saved")
+		goos := buildFlags.String("goos", "", "Cross-compile for this operating system (sets GOOS)")
+		goarch := buildFlags.String("goarch", "", "Cross-compile for this architecture (sets GOARCH)")
+		lang := buildFlags.String("lang", "", "Language of compiler errors (en, es); default $KUKICHA_LANG")
+		autoImportFlag := buildFlags.Bool("auto-import", false, "Import known Go stdlib packages (strings, filepath, ...) used without an import")
+		checkCasts := buildFlags.Bool("check-casts", false, "Panic at runtime when a numeric 'as' conversion loses the value (debugging)")
+		instrument := buildFlags.Bool("instrument", false, "Wrap exported functions in OpenTelemetry spans and call metrics (configured with stdlib/telemetry)")
+		explainCodegen := buildFlags.Bool("explain-codegen", false, "Comment the generated Go with why non-obvious code was generated (onerr checks, pipe temps, inferred generics, added imports)")
 		if err := buildFlags.Parse(args); err != nil {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha build [--target <target>] [--skip-build] [--if-changed] [--vulncheck] <file.kuki>")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha build [--target <target>[,<target>...]] [--goos <os>] [--goarch <arch>] [--release] [--upx] [--skip-build] [--if-changed] [--vulncheck] [--auto-import] [--check-casts] [--instrument] [--explain-codegen] [--lang <lang>] <file.kuki>")
 			os.Exit(1)
 		}
 		buildArgs := buildFlags.Args()
 		if len(buildArgs) < 1 {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha build [--target <target>] [--skip-build] [--if-changed] [--vulncheck] <file.kuki>")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha build [--target <target>[,<target>...]] [--goos <os>] [--goarch <arch>] [--release] [--upx] [--skip-build] [--if-changed] [--vulncheck] [--auto-import] [--check-casts] [--instrument] [--explain-codegen] [--lang <lang>] <file.kuki>")
 			os.Exit(1)
 		}
-		buildCommand(buildArgs[0], *target, *skipBuild, *ifChanged, *vulncheck)
+		setLanguage(*lang)
+		loadPlugins()
+		autoImport = *autoImportFlag
+		// go build, the stdlib extraction and the .exe suffix all follow GOOS/GOARCH
+		if *goos != "" {
+			os.Setenv("GOOS", *goos)
+		}
+		if *goarch != "" {
+			os.Setenv("GOARCH", *goarch)
+		}
+		buildCommand(buildArgs[0], *target, BuildOptions{
+			SkipBuild:      *skipBuild,
+			IfChanged:      *ifChanged,
+			Vulncheck:      *vulncheck,
+			Release:        *release,
+			UPX:            *upx,
+			CheckCasts:     *checkCasts,
+			Instrument:     *instrument,
+			ExplainCodegen: *explainCodegen,
+		})
 	case "run":
 		runFlags := flag.NewFlagSet("run", flag.ContinueOnError)
 		runFlags.SetOutput(os.Stderr)
 		target := runFlags.String("target", "", "Run target")
+		lang := runFlags.String("lang", "", "Language of compiler errors (en, es); default $KUKICHA_LANG")
+		autoImportFlag := runFlags.Bool("auto-import", true, "Import known Go stdlib packages (strings, filepath, ...) used without an import")
+		checkCasts := runFlags.Bool("check-casts", false, "Panic at runtime when a numeric 'as' conversion loses the value (debugging)")
+		instrument := runFlags.Bool("instrument", false, "Wrap exported functions in OpenTelemetry spans and call metrics (configured with stdlib/telemetry)")
 		if err := runFlags.Parse(args); err != nil {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha run [--target <target>] <file.kuki> [args...]")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha run [--target <target>] [--auto-import=false] [--check-casts] [--instrument] [--lang <lang>] <file.kuki> [args...]")
 			os.Exit(1)
 		}
 		runArgs := runFlags.Args()
 		if len(runArgs) < 1 {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha run [--target <target>] <file.kuki> [args...]")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha run [--target <target>] [--auto-import=false] [--check-casts] [--instrument] [--lang <lang>] <file.kuki> [args...]")
+			os.Exit(1)
+		}
+		setLanguage(*lang)
+		loadPlugins()
+		autoImport = *autoImportFlag
+		runCommand(runArgs[0], *target, runArgs[1:], BuildOptions{CheckCasts: *checkCasts, Instrument: *instrument})
+	case "profile":
+		const usage = "Usage: kukicha profile run [--top n] [--out dir] [--target <target>] [--lang <lang>] <file.kuki> [args...]"
+		if len(args) < 1 || args[0] != "run" {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+		profileFlags := flag.NewFlagSet("profile run", flag.ContinueOnError)
+		profileFlags.SetOutput(os.Stderr)
+		target := profileFlags.String("target", "", "Run target")
+		lang := profileFlags.String("lang", "", "Language of compiler errors (en, es); default $KUKICHA_LANG")
+		top := profileFlags.Int("top", 10, "Lines to show per section (0: all)")
+		out := profileFlags.String("out", "", "Keep cpu.pprof and allocs.pprof in this directory")
+		if err := profileFlags.Parse(args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+		profileArgs := profileFlags.Args()
+		if len(profileArgs) < 1 {
+			fmt.Fprintln(os.Stderr, usage)
 			os.Exit(1)
 		}
-		runCommand(runArgs[0], *target, runArgs[1:])
+		setLanguage(*lang)
+		loadPlugins()
+		autoImport = true // as for kukicha run
+		if code := runProfile(profileArgs[0], profileArgs[1:], ProfileOptions{Target: *target, Top: *top, Out: *out}); code != 0 {
+			os.Exit(code)
+		}
 	case "check":
 		checkFlags := flag.NewFlagSet("check", flag.ContinueOnError)
 		checkFlags.SetOutput(os.Stderr)
 		strictOnerr := checkFlags.Bool("strict-onerr", false, "Treat onerr lint warnings as errors")
+		shadow := checkFlags.String("shadow", "default", "Shadowing diagnostics: default (err, ctx, parameters), all, or off")
+		strictTypes := checkFlags.Bool("strict-types", false, "Report every place type inference falls back to unknown")
+		lang := checkFlags.String("lang", "", "Language of compiler errors (en, es); default $KUKICHA_LANG")
+		autoImportFlag := checkFlags.Bool("auto-import", false, "Import known Go stdlib packages (strings, filepath, ...) used without an import")
 		if err := checkFlags.Parse(args); err != nil {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha check [--strict-onerr] <file.kuki>")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha check [--strict-onerr] [--strict-types] [--shadow default|all|off] [--auto-import] [--lang <lang>] <file.kuki>")
 			os.Exit(1)
 		}
 		checkArgs := checkFlags.Args()
 		if len(checkArgs) < 1 {
-			fmt.Fprintln(os.Stderr, "Usage: kukicha check [--strict-onerr] <file.kuki>")
+			fmt.Fprintln(os.Stderr, "Usage: kukicha check [--strict-onerr] [--strict-types] [--shadow default|all|off] [--auto-import] [--lang <lang>] <file.kuki>")
+			os.Exit(1)
+		}
+		setLanguage(*lang)
+		loadPlugins()
+		autoImport = *autoImportFlag
+		shadowCheck, err := semantic.ParseShadowCheck(*shadow)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		checkCommand(checkArgs[0], *strictOnerr, *strictTypes, shadowCheck)
+	case "ast":
+		astFlags := flag.NewFlagSet("ast", flag.ContinueOnError)
+		astFlags.SetOutput(os.Stderr)
+		typed := astFlags.Bool("typed", false, "List what each closure captures, with types")
+		if err := astFlags.Parse(args); err != nil || astFlags.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha ast [--typed] <file.kuki>")
+			os.Exit(1)
+		}
+		loadPlugins()
+		astCommand(astFlags.Arg(0), *typed)
+	case "lint":
+		lintFlags := flag.NewFlagSet("lint", flag.ContinueOnError)
+		lintFlags.SetOutput(os.Stderr)
+		fix := lintFlags.Bool("fix", false, "Apply safe automatic fixes in place")
+		configPath := lintFlags.String("config", "", "Path to kukicha.toml (default: nearest one above each file)")
+		listRules := lintFlags.Bool("rules", false, "List available lint rules")
+		if err := lintFlags.Parse(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha lint [--fix] [--config kukicha.toml] [--rules] <files|dirs>")
+			os.Exit(1)
+		}
+		if *listRules {
+			printLintRules()
+			return
+		}
+		if lintFlags.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha lint [--fix] [--config kukicha.toml] [--rules] <files|dirs>")
+			os.Exit(1)
+		}
+		lintCommand(lintFlags.Args(), *fix, *configPath)
+	case "vet":
+		vetFlags := flag.NewFlagSet("vet", flag.ContinueOnError)
+		vetFlags.SetOutput(os.Stderr)
+		staticcheck := vetFlags.Bool("staticcheck", false, "Also run staticcheck (must be on PATH)")
+		if err := vetFlags.Parse(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha vet [--staticcheck] [files|dirs]")
+			os.Exit(1)
+		}
+		paths := vetFlags.Args()
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+		loadPlugins()
+		if code := vetCommand(paths, VetOptions{Staticcheck: *staticcheck}); code != 0 {
+			os.Exit(code)
+		}
+	case "fix":
+		fixFlags := flag.NewFlagSet("fix", flag.ContinueOnError)
+		fixFlags.SetOutput(os.Stderr)
+		migration := fixFlags.String("migrate", "", "Migration to apply (see --list)")
+		dryRun := fixFlags.Bool("dry-run", false, "Report files that would change without writing them")
+		list := fixFlags.Bool("list", false, "List available migrations")
+		if err := fixFlags.Parse(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha fix --migrate <name> [--dry-run] <files|dirs>")
 			os.Exit(1)
 		}
-		checkCommand(checkArgs[0], *strictOnerr)
+		if *list {
+			printMigrations()
+			return
+		}
+		if *migration == "" || fixFlags.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha fix --migrate <name> [--dry-run] <files|dirs>")
+			os.Exit(1)
+		}
+		fixCommand(fixFlags.Args(), *migration, *dryRun)
 	case "fmt":
 		if len(args) < 1 {
 			fmt.Fprintln(os.Stderr, "Usage: kukicha fmt [options] <files>")
@@ -107,9 +247,58 @@ func main() {
 		}
 		auditCommand(auditFlags.Args(), *jsonFlag, *warnOnly)
 	case "init":
-		initCommand(args)
+		initFlags := flag.NewFlagSet("init", flag.ContinueOnError)
+		initFlags.SetOutput(os.Stderr)
+		template := initFlags.String("template", "", "Starter program to write (a2a)")
+		if err := initFlags.Parse(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha init [--template name] [module-name]")
+			os.Exit(1)
+		}
+		initCommand(initFlags.Args(), *template)
+	case "test":
+		testFlags := flag.NewFlagSet("test", flag.ContinueOnError)
+		testFlags.SetOutput(os.Stderr)
+		seed := testFlags.String("seed", "", "Seed stdlib/random and expect.ForAll so every run draws the same values")
+		run := testFlags.String("run", "", "Run only tests matching the pattern (go test -run)")
+		updateSnapshots := testFlags.Bool("update-snapshots", false, "Record stdlib/expect snapshots instead of checking them")
+		if err := testFlags.Parse(args); err != nil {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha test [--seed n] [--run pattern] [--update-snapshots] [dir]")
+			os.Exit(1)
+		}
+		dir := "."
+		if testFlags.NArg() > 0 {
+			dir = testFlags.Arg(0)
+		}
+		loadPlugins()
+		testCommand(dir, TestOptions{Seed: *seed, Run: *run, UpdateSnapshots: *updateSnapshots})
+	case "selftest":
+		selftestFlags := flag.NewFlagSet("selftest", flag.ContinueOnError)
+		selftestFlags.SetOutput(os.Stderr)
+		run := selftestFlags.String("run", "", "Run only cases whose name matches the regexp")
+		update := selftestFlags.Bool("update", false, "Write each case's transcript to its .golden file (needs a directory)")
+		if err := selftestFlags.Parse(args); err != nil || selftestFlags.NArg() > 1 {
+			fmt.Fprintln(os.Stderr, "Usage: kukicha selftest [--run pattern] [--update] [dir]")
+			os.Exit(1)
+		}
+		selftestCommand(selftestFlags.Arg(0), SelftestOptions{Run: *run, Update: *update})
+	case "gen":
+		const usage = "Usage: kukicha gen openapi [--package name] [--output file.kuki] <spec.yaml|spec.json>\n       kukicha gen proto [--package name] [--go-package path] [--output file.kuki] <file.proto>"
+		if len(args) < 1 || (args[0] != "openapi" && args[0] != "proto") {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+		genFlags := flag.NewFlagSet("gen "+args[0], flag.ContinueOnError)
+		genFlags.SetOutput(os.Stderr)
+		pkg := genFlags.String("package", "", "Petiole of the generated file (default: from the spec file name)")
+		output := genFlags.String("output", "", "Write the generated file here instead of stdout")
+		goPackage := genFlags.String("go-package", "", "proto: import path of the protoc-gen-go package (default: the file's go_package option)")
+		if err := genFlags.Parse(args[1:]); err != nil || genFlags.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+		genCommand(args[0], genFlags.Arg(0), *pkg, *goPackage, *output)
 	case "version":
-		fmt.Printf("kukicha version %s\n", version.Version)
+		versionCommand(args)
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -123,28 +312,102 @@ func printUsage() {
 	fmt.Fprintln(os.Stderr, "Kukicha - A transpiler that compiles Kukicha to Go")
 	fmt.Fprintln(os.Stderr)
 	fmt.Fprintln(os.Stderr, "Usage:")
-	fmt.Fprintln(os.Stderr, "  kukicha build [--target t] [--vulncheck] <file.kuki>  Compile Kukicha file to Go")
+	fmt.Fprintln(os.Stderr, "  kukicha build [--target t[,t...]] [--vulncheck] <file.kuki>  Compile Kukicha file to Go")
+	fmt.Fprintln(os.Stderr, "    --release   Small distributable binary (stripped, no //line directives or must.True/False asserts)")
+	fmt.Fprintln(os.Stderr, "    --upx       Compress the binary with upx and report the size")
+	fmt.Fprintln(os.Stderr, "    --goos, --goarch  Cross-compile (stdlib packages without sources for the platform are not extracted)")
+	fmt.Fprintln(os.Stderr, "    --explain-codegen  Comment the generated Go with why it looks the way it does (onerr checks, pipe temps, inferred generics, added imports)")
+	fmt.Fprintln(os.Stderr, "    --lang      Language of compiler errors: en, es (also for run and check; default $KUKICHA_LANG)")
 	fmt.Fprintln(os.Stderr, "  kukicha run [--target t] <file.kuki>   Transpile and execute Kukicha file")
+	fmt.Fprintln(os.Stderr, "    --auto-import   Import known Go stdlib packages used without an import (default on for run; opt-in for build and check)")
+	fmt.Fprintln(os.Stderr, "    --check-casts   Panic when a numeric 'as' conversion loses the value (debugging; also for build)")
+	fmt.Fprintln(os.Stderr, "    --instrument    Trace exported functions with OpenTelemetry spans and call metrics; KUKICHA_TELEMETRY=stderr|<file> shows them (also for build)")
+	fmt.Fprintln(os.Stderr, "  kukicha profile run [--top n] [--out dir] <file.kuki>  Run under the CPU and allocation profilers and rank the hottest .kuki lines")
 	fmt.Fprintln(os.Stderr, "  kukicha check <file.kuki>   Type check Kukicha file")
+	fmt.Fprintln(os.Stderr, "    --strict-onerr   Treat warnings as errors")
+	fmt.Fprintln(os.Stderr, "    --strict-types   Report every unresolved import member, method or field (types unknown to Kukicha)")
+	fmt.Fprintln(os.Stderr, "    --shadow mode    Shadowing warnings: default (err, ctx, parameters), all, off")
+	fmt.Fprintln(os.Stderr, "  kukicha ast [--typed] <file.kuki>  Outline functions and closures")
+	fmt.Fprintln(os.Stderr, "    --typed     Also list each closure's captured variables, their types and whether they are copied or shared")
+	fmt.Fprintln(os.Stderr, "  kukicha test [--seed n] [--run pattern] [--update-snapshots] [dir]  Compile the .kuki files in dir and run go test")
+	fmt.Fprintln(os.Stderr, "    --seed      Make stdlib/random and expect.ForAll deterministic (sets KUKICHA_SEED)")
+	fmt.Fprintln(os.Stderr, "    --update-snapshots  Record expect.Snapshot golden files instead of checking them")
+	fmt.Fprintln(os.Stderr, "  kukicha selftest [--run pattern] [--update] [dir]  Compile, vet and run the conformance corpus, comparing with golden files")
+	fmt.Fprintln(os.Stderr, "    dir         Run your own cases (name.kuki + name.golden) instead of the built-in corpus; --update records them")
+	fmt.Fprintln(os.Stderr, "  kukicha lint [--fix] [--config f] <files|dirs>  Style and hygiene suggestions (rules from kukicha.toml)")
+	fmt.Fprintln(os.Stderr, "    --rules     List lint rules and whether they are on by default")
+	fmt.Fprintln(os.Stderr, "  kukicha vet [--staticcheck] [files|dirs]  Run go vet on the generated Go and report findings at .kuki lines")
+	fmt.Fprintln(os.Stderr, "    --staticcheck  Also run staticcheck (must be on PATH)")
+	fmt.Fprintln(os.Stderr, "  kukicha fix --migrate <name> [--dry-run] <files|dirs>  Rewrite sources for a language or API change")
+	fmt.Fprintln(os.Stderr, "    --list      List available migrations")
 	fmt.Fprintln(os.Stderr, "  kukicha audit [--json] [--warn-only] [dir]  Check dependencies for vulnerabilities")
 	fmt.Fprintln(os.Stderr, "  kukicha fmt [options] <files>  Fix indentation and normalize style")
 	fmt.Fprintln(os.Stderr, "    -w          Write result to file instead of stdout")
 	fmt.Fprintln(os.Stderr, "    --check     Check if files are formatted (exit 1 if not)")
 	fmt.Fprintln(os.Stderr, "  kukicha pack [--output dir] <skill.kuki>  Package skill for distribution")
-	fmt.Fprintln(os.Stderr, "  kukicha init [module-name]  Initialize project (go mod init + extract stdlib)")
-	fmt.Fprintln(os.Stderr, "  kukicha version             Show version information")
+	fmt.Fprintln(os.Stderr, "  kukicha gen openapi [--package name] [--output file.kuki] <spec>  Generate types and fetch client functions from an OpenAPI 3 spec")
+	fmt.Fprintln(os.Stderr, "  kukicha gen proto [--package name] [--go-package path] [--output file.kuki] <file.proto>  Generate message types and gRPC client wrappers over protoc-gen-go code")
+	fmt.Fprintln(os.Stderr, "  kukicha init [--template name] [module-name]  Initialize project (go mod init + extract stdlib)")
+	fmt.Fprintln(os.Stderr, "    --template  Also write a starter main.kuki (a2a: agent server)")
+	fmt.Fprintln(os.Stderr, "  kukicha version [--of binary]  Show version information (--of: the version, source hash and build time a binary was built with)")
 	fmt.Fprintln(os.Stderr, "  kukicha help                Show this help message")
 }
 
-func loadAndAnalyze(filename string) (*ast.Program, map[ast.Expression]int, map[ast.Expression]*semantic.TypeInfo, error) {
+// setLanguage selects the language of compiler errors: the --lang flag if
+// given, else $KUKICHA_LANG, else English.
+func setLanguage(flagValue string) {
+	lang := flagValue
+	if lang == "" {
+		lang = os.Getenv(catalog.EnvVar)
+	}
+	if lang == "" {
+		return
+	}
+	if err := catalog.SetLanguage(lang); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadPlugins opens the compile pipeline plugins listed in $KUKICHA_PLUGINS.
+func loadPlugins() {
+	if err := hooks.LoadFromEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runHooks runs the plugin hooks for stage, printing their warnings, and
+// returns their errors as one error (nil when there are none).
+func runHooks(stage hooks.Stage, pass *hooks.Pass) error {
+	errs, warnings := hooks.Run(stage, pass)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", w)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	var msgs []string
+	for _, e := range errs {
+		msgs = append(msgs, fmt.Sprintf("  %v", e))
+	}
+	return fmt.Errorf("plugin errors (%s):\n%s", stage, strings.Join(msgs, "\n"))
+}
+
+// autoImport makes the analyzer import known Go stdlib packages the program
+// uses without importing them (semantic.SetAutoImport). On by default for
+// kukicha run, opt-in for build and check.
+var autoImport bool
+
+func loadAndAnalyze(filename, target string) (*hooks.Pass, error) {
 	source, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error reading file: %v", err)
+		return nil, fmt.Errorf("error reading file: %v", err)
 	}
 
 	p, err := parser.New(string(source), filename)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("lexer error: %v", err)
+		return nil, fmt.Errorf("lexer error: %v", err)
 	}
 
 	program, parseErrors := p.Parse()
@@ -153,20 +416,54 @@ func loadAndAnalyze(filename string) (*ast.Program, map[ast.Expression]int, map[
 		for _, e := range parseErrors {
 			msgs = append(msgs, fmt.Sprintf("  %v", e))
 		}
-		return nil, nil, nil, fmt.Errorf("parse errors:\n%s", strings.Join(msgs, "\n"))
+		return nil, fmt.Errorf("parse errors:\n%s", strings.Join(msgs, "\n"))
+	}
+	if err := expandImportPaths(program); err != nil {
+		return nil, err
+	}
+
+	// The analyzer resolves `when target` blocks, so the target must be set first
+	program.Target = target
+	pass := &hooks.Pass{Program: program, File: filename, Target: target}
+	if err := runHooks(hooks.AfterParse, pass); err != nil {
+		return nil, err
 	}
 
 	analyzer := semantic.NewWithFile(program, filename)
+	analyzer.SetAutoImport(autoImport)
+	registerPetioles(analyzer, program, filename)
 	semanticErrors := analyzer.Analyze()
 	if len(semanticErrors) > 0 {
 		var msgs []string
 		for _, e := range semanticErrors {
 			msgs = append(msgs, fmt.Sprintf("  %v", e))
 		}
-		return nil, nil, nil, fmt.Errorf("semantic errors:\n%s", strings.Join(msgs, "\n"))
+		return nil, fmt.Errorf("semantic errors:\n%s", strings.Join(msgs, "\n"))
 	}
 
-	return program, analyzer.ReturnCounts(), analyzer.ExprTypes(), nil
+	pass.ReturnCounts, pass.ExprTypes = analyzer.ReturnCounts(), analyzer.ExprTypes()
+	pass.AnnTypes = analyzer.TypeAnnotationTypes()
+	pass.Captures, pass.Constants = analyzer.Captures(), analyzer.Constants()
+	if err := runHooks(hooks.AfterAnalysis, pass); err != nil {
+		return nil, err
+	}
+
+	return pass, nil
+}
+
+// expandImportPaths replaces ${VAR} references in the program's import
+// paths with their environment values, so a build script can choose the
+// module base (`import "${APP_MODULE}/internal/db"`).
+func expandImportPaths(program *ast.Program) error {
+	for _, imp := range program.Imports {
+		path, err := env.Expand(imp.Path.Value)
+		if err != nil {
+			pos := imp.Pos()
+			return fmt.Errorf("%s:%d:%d: import path: %v", pos.File, pos.Line, pos.Column, err)
+		}
+		imp.Path.Value = path
+	}
+	return nil
 }
 
 // compileResult holds the output of the shared compile pipeline.
@@ -176,12 +473,15 @@ type compileResult struct {
 	program    *ast.Program
 	goCode     string
 	formatted  []byte
+	// deriveTests is the companion test file for `@derive jsontest`
+	// types, or nil when the file has none (see writeDeriveTests).
+	deriveTests []byte
 }
 
-// compile runs the shared pipeline: resolve path, parse, analyze, detect target,
-// generate Go code, and format it. targetFlag overrides auto-detection when non-empty.
-// defaultTarget is used when no flag is given and no target directive is found in source.
-func compile(filename, targetFlag, defaultTarget string) compileResult {
+// compile runs the shared pipeline for one target: resolve path, parse,
+// analyze, generate Go code, and format it. Use targetsFor to pick the
+// targets. buildTag, when non-empty, is emitted as a //go:build constraint.
+func compile(filename, target, buildTag string, opts BuildOptions) compileResult {
 	absFile, err := filepath.Abs(filename)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error resolving file path: %v\n", err)
@@ -189,55 +489,123 @@ func compile(filename, targetFlag, defaultTarget string) compileResult {
 	}
 	projectDir := findProjectDir(absFile)
 
-	program, returnCounts, exprTypes, err := loadAndAnalyze(absFile)
+	pass, err := loadAndAnalyze(absFile, target)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-
-	// Detect target from source if not provided by flag
-	if targetFlag != "" {
-		program.Target = targetFlag
-	} else {
-		t, readErr := detectTargetFromFile(absFile)
-		if readErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not read %s for target detection: %v\n", absFile, readErr)
-		}
-		if t != "" {
-			program.Target = t
-		} else if defaultTarget != "" {
-			program.Target = defaultTarget
-		}
+	program := pass.Program
+	if err := runHooks(hooks.BeforeCodegen, pass); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
 	// Generate Go code
 	gen := codegen.New(program)
 	gen.SetSourceFile(absFile)
-	gen.SetExprReturnCounts(returnCounts)
-	gen.SetExprTypes(exprTypes)
+	gen.SetExprReturnCounts(pass.ReturnCounts)
+	gen.SetExprTypes(pass.ExprTypes)
+	gen.SetTypeAnnotationTypes(pass.AnnTypes)
+	gen.SetCaptures(pass.Captures)
+	gen.SetConstants(pass.Constants)
 	if program.Target == "mcp" {
 		gen.SetMCPTarget(true)
 	}
+	gen.SetBuildTag(buildTag)
+	gen.SetRelease(opts.Release)
+	gen.SetBuildMetadata(opts.Metadata)
+	gen.SetCheckCasts(opts.CheckCasts)
+	gen.SetInstrument(opts.Instrument)
+	gen.SetProfile(opts.Profile)
+	gen.SetExplainCodegen(opts.ExplainCodegen)
+	if path := lint.FindConfig(filepath.Dir(absFile)); path != "" {
+		buildCfg, err := lint.LoadBuildConfig(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		gen.SetHeader(buildCfg.Header)
+		gen.SetOnErrLog(buildCfg.OnErrLog)
+	}
 	goCode, err := gen.Generate()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Code generation error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Format with gofmt
-	formatted, err := format.Source([]byte(goCode))
+	// Reparse, drop redundant parens and apply gofmt layout. Output that does
+	// not parse is a codegen bug, so it is fatal rather than written as-is.
+	formatted, err := codegen.FormatGo([]byte(goCode))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: gofmt failed, using unformatted output: %v\n", err)
-		formatted = []byte(goCode)
+		fmt.Fprintf(os.Stderr, "Code generation error: generated Go does not parse (this is a kukicha bug): %v\n", err)
+		os.Exit(1)
+	}
+
+	var deriveTests []byte
+	if tests := gen.GenerateDeriveTests(); tests != "" {
+		if deriveTests, err = codegen.FormatGo([]byte(tests)); err != nil {
+			fmt.Fprintf(os.Stderr, "Code generation error: generated @derive jsontest file does not parse (this is a kukicha bug): %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	return compileResult{
-		absFile:    absFile,
-		projectDir: projectDir,
-		program:    program,
-		goCode:     goCode,
-		formatted:  formatted,
+		absFile:     absFile,
+		projectDir:  projectDir,
+		program:     program,
+		goCode:      goCode,
+		formatted:   formatted,
+		deriveTests: deriveTests,
+	}
+}
+
+// deriveTestsSuffix names the companion test file written next to base.go.
+const deriveTestsSuffix = "_jsontest_test.go"
+
+// writeDeriveTests writes the `@derive jsontest` round-trip tests to
+// base_jsontest_test.go, or removes a file left there by an earlier build
+// once the file no longer has such types.
+func writeDeriveTests(cr compileResult, base string) error {
+	path := base + deriveTestsSuffix
+	if cr.deriveTests == nil {
+		if existing, err := os.ReadFile(path); err == nil && bytes.HasPrefix(existing, []byte("// Generated by Kukicha")) {
+			return os.Remove(path)
+		}
+		return nil
 	}
+	return os.WriteFile(path, cr.deriveTests, 0644)
+}
+
+// targetsFor returns the targets to compile filename for: the --target flag
+// when given, else the file's `# target:` pragma, else defaultTarget. Both the
+// flag and the pragma take a comma-separated list (`# target: cli, mcp`).
+// Unknown targets are fatal.
+func targetsFor(filename, targetFlag, defaultTarget string) []string {
+	var targets []string
+	if targetFlag != "" {
+		targets = parseTargetList(targetFlag)
+	} else {
+		source, readErr := os.ReadFile(filename)
+		if readErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read %s for target detection: %v\n", filename, readErr)
+		}
+		t, err := detectTargets(string(source))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", filename, err)
+			os.Exit(1)
+		}
+		targets = t
+	}
+	if len(targets) == 0 {
+		return []string{defaultTarget}
+	}
+	for _, t := range targets {
+		if !ast.IsTarget(t) {
+			fmt.Fprintf(os.Stderr, "Error: unknown target %q (known targets: %s)\n", t, strings.Join(ast.Targets, ", "))
+			os.Exit(1)
+		}
+	}
+	return targets
 }
 
 // ensureStdlibIfNeeded checks if the generated Go code imports Kukicha stdlib
@@ -246,6 +614,11 @@ func ensureStdlibIfNeeded(goCode, projectDir string) {
 	if !needsStdlib(goCode, projectDir) {
 		return
 	}
+	goos, goarch := targetPlatform()
+	if missing := unavailableStdlibImports(goCode, goos, goarch); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: not available for %s/%s: %s\n", goos, goarch, strings.Join(missing, ", "))
+		os.Exit(1)
+	}
 	stdlibPath, err := ensureStdlib(projectDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error extracting stdlib: %v\n", err)
@@ -257,7 +630,10 @@ func ensureStdlibIfNeeded(goCode, projectDir string) {
 	}
 }
 
-func detectTarget(source string) string {
+// detectTargets returns the targets listed by a `# target:` pragma in the
+// first 10 lines of source, or nil. ${VAR} references in the pragma are
+// expanded from the environment.
+func detectTargets(source string) ([]string, error) {
 	lines := strings.Split(source, "\n")
 	for i, line := range lines {
 		if i >= 10 { // Only look at first 10 lines
@@ -265,18 +641,28 @@ func detectTarget(source string) string {
 		}
 		line = strings.TrimSpace(line)
 		if after, ok := strings.CutPrefix(line, "# target:"); ok {
-			return strings.TrimSpace(after)
+			expanded, err := env.Expand(after)
+			if err != nil {
+				return nil, fmt.Errorf("# target: %v", err)
+			}
+			return parseTargetList(expanded), nil
 		}
 	}
-	return ""
+	return nil, nil
 }
 
-func detectTargetFromFile(filename string) (string, error) {
-	source, err := os.ReadFile(filename)
-	if err != nil {
-		return "", err
+// parseTargetList splits "cli, mcp" (commas and/or spaces) into target
+// names, dropping duplicates.
+func parseTargetList(s string) []string {
+	var targets []string
+	seen := make(map[string]bool)
+	for _, t := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
 	}
-	return detectTarget(string(source)), nil
+	return targets
 }
 
 // rewriteGoErrors replaces references to the generated .go file path in stderr
@@ -307,16 +693,66 @@ func stripFirstLine(b []byte) []byte {
 	return b
 }
 
-func buildCommand(filename string, targetFlag string, skipBuild bool, ifChanged bool, vulncheck bool) {
-	cr := compile(filename, targetFlag, "")
+// BuildOptions are the kukicha build flags that apply to every target.
+type BuildOptions struct {
+	SkipBuild      bool
+	IfChanged      bool
+	Vulncheck      bool
+	Release        bool // -trimpath, -ldflags "-s -w", and release codegen (codegen.SetRelease)
+	UPX            bool
+	Metadata       bool   // Embed buildMetadata (codegen.SetBuildMetadata); always on for kukicha build
+	CheckCasts     bool   // Runtime checks on lossy numeric conversions (codegen.SetCheckCasts)
+	Instrument     bool   // Telemetry spans and metrics for exported functions (codegen.SetInstrument)
+	Profile        string // Directory main writes CPU and allocation profiles to (codegen.SetProfile)
+	ExplainCodegen bool   // Comment the generated Go with the reasons for its lowerings (codegen.SetExplainCodegen)
+}
+
+func buildCommand(filename string, targetFlag string, opts BuildOptions) {
+	opts.Metadata = true
+	targets := targetsFor(filename, targetFlag, "")
+	multi := len(targets) > 1
+
+	projectDir := ""
+	changed := false
+	for _, target := range targets {
+		buildTag := ""
+		if multi {
+			buildTag = "kukicha_" + target
+		}
+		cr := compile(filename, target, buildTag, opts)
+		projectDir = cr.projectDir
+		if buildTarget(cr, target, multi, opts) {
+			changed = true
+		}
+	}
+
+	if opts.Vulncheck && changed {
+		code := runAudit(AuditOptions{Dir: projectDir})
+		if code != 0 {
+			os.Exit(code)
+		}
+	}
+}
+
+// buildTarget writes the Go file for one compiled target and runs go build on
+// it. Multi-target builds name the outputs after the target (app_mcp.go and
+// app-mcp for target mcp); single-target builds keep app.go and app. It
+// reports false when --if-changed found the output up to date.
+func buildTarget(cr compileResult, target string, multi bool, opts BuildOptions) bool {
+	base := strings.TrimSuffix(cr.absFile, ".kuki")
+	binaryName := strings.TrimSuffix(filepath.Base(cr.absFile), ".kuki")
+	if multi {
+		base += "_" + target
+		binaryName += "-" + target
+	}
 
 	// Write Go file
-	outputFile := strings.TrimSuffix(cr.absFile, ".kuki") + ".go"
+	outputFile := base + ".go"
 
-	if ifChanged {
+	if opts.IfChanged {
 		if existing, readErr := os.ReadFile(outputFile); readErr == nil {
 			if bytes.Equal(stripFirstLine(existing), stripFirstLine(cr.formatted)) {
-				return // body unchanged — preserve old version comment, skip write+build
+				return false // body unchanged — preserve old version comment, skip write+build
 			}
 		}
 	}
@@ -327,52 +763,138 @@ func buildCommand(filename string, targetFlag string, skipBuild bool, ifChanged
 	}
 
 	fmt.Printf("Successfully compiled %s to %s\n", cr.absFile, outputFile)
+	if err := writeDeriveTests(cr, base); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing @derive jsontest file: %v\n", err)
+		os.Exit(1)
+	}
 
 	ensureStdlibIfNeeded(cr.goCode, cr.projectDir)
+	inWorkspace := syncWorkspace(cr.projectDir)
 
 	// Determine the output binary name. When cross-compiling for Windows
 	// (GOOS=windows), append .exe so the binary is recognised as executable.
-	binaryName := strings.TrimSuffix(filepath.Base(cr.absFile), ".kuki")
-	targetOS := os.Getenv("GOOS")
-	if targetOS == "" {
-		targetOS = runtime.GOOS
-	}
-	if targetOS == "windows" {
+	if targetOS, _ := targetPlatform(); targetOS == "windows" {
 		binaryName += ".exe"
 	}
 	binaryPath := filepath.Join(cr.projectDir, binaryName)
 
-	// Run go build on the generated file. Use -mod=mod so go.sum is updated
-	// automatically when stdlib transitive dependencies are not yet listed.
-	if !skipBuild {
-		cmd := exec.Command("go", "build", "-mod=mod", "-o", binaryPath, outputFile)
+	// Run go build on the generated file (see buildArgs). Naming the file
+	// builds it regardless of its //go:build constraint.
+	if !opts.SkipBuild {
+		metadata, err := newBuildMetadata(cr.absFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		args := buildArgs(binaryPath, outputFile, opts.Release, metadata)
+		if inWorkspace {
+			args = workspaceGoArgs(args)
+		}
+		cmd := exec.Command("go", args...)
 		cmd.Dir = cr.projectDir
 		cmd.Env = os.Environ()
 		cmd.Stdout = os.Stdout
 		var stderrBuf bytes.Buffer
 		cmd.Stderr = &stderrBuf
-		err := cmd.Run()
+		err = cmd.Run()
 		if stderrBuf.Len() > 0 {
-			os.Stderr.Write(rewriteGoErrors(stderrBuf.Bytes(), outputFile, cr.absFile))
+			// Release builds have no //line directives, so the errors point
+			// at the generated file and are left as they are.
+			if opts.Release {
+				os.Stderr.Write(stderrBuf.Bytes())
+			} else {
+				os.Stderr.Write(rewriteGoErrors(stderrBuf.Bytes(), outputFile, cr.absFile))
+			}
 		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: go build failed: %v\n", err)
 			os.Exit(1)
 		}
 
-		fmt.Printf("Successfully built binary: %s\n", binaryName)
+		if opts.Release || opts.UPX {
+			fmt.Printf("Successfully built binary: %s (%s)\n", binaryName, binarySize(binaryPath))
+		} else {
+			fmt.Printf("Successfully built binary: %s\n", binaryName)
+		}
+		if opts.UPX {
+			compressBinary(binaryPath)
+		}
 	}
+	return true
+}
 
-	if vulncheck {
-		code := runAudit(AuditOptions{Dir: cr.projectDir})
-		if code != 0 {
-			os.Exit(code)
-		}
+// buildArgs returns the go build arguments for the generated file. Use
+// -mod=mod so go.sum is updated automatically when stdlib transitive
+// dependencies are not yet listed. The build metadata is set with -X;
+// release builds also drop file system paths and the symbol and DWARF
+// tables.
+func buildArgs(binaryPath, outputFile string, release bool, metadata buildMetadata) []string {
+	args := []string{"build", "-mod=mod"}
+	ldflags := metadata.ldflag()
+	if release {
+		args = append(args, "-trimpath")
+		ldflags = "-s -w " + ldflags
+	}
+	return append(args, "-ldflags", ldflags, "-o", binaryPath, outputFile)
+}
+
+// compressBinary packs the binary with upx and reports the size before and
+// after.
+func compressBinary(binaryPath string) {
+	upx, err := exec.LookPath("upx")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: --upx needs upx on PATH (https://upx.github.io)")
+		os.Exit(1)
+	}
+	before := binarySize(binaryPath)
+	cmd := exec.Command(upx, "-q", "--best", binaryPath)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		os.Stderr.Write(out.Bytes())
+		fmt.Fprintf(os.Stderr, "Error: upx failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Compressed %s with upx: %s -> %s\n", filepath.Base(binaryPath), before, binarySize(binaryPath))
+}
+
+// binarySize returns the size of the file at path for display ("1.8 MB").
+func binarySize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "size unknown"
+	}
+	return formatSize(info.Size())
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// runCommand runs the first target of a multi-target file; pass --target to
+// pick another.
+func runCommand(filename string, targetFlag string, scriptArgs []string, opts BuildOptions) {
+	if code := runProgram("run", filename, targetFlag, scriptArgs, opts); code != 0 {
+		os.Exit(code)
 	}
 }
 
-func runCommand(filename string, targetFlag string, scriptArgs []string) {
-	cr := compile(filename, targetFlag, "")
+// runProgram compiles filename and runs it with go run, returning the
+// program's exit code. command names the kukicha command in errors.
+func runProgram(command, filename, targetFlag string, scriptArgs []string, opts BuildOptions) int {
+	targets := targetsFor(filename, targetFlag, "")
+	if targetFlag != "" && len(targets) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: kukicha %s takes a single --target\n", command)
+		return 1
+	}
+	cr := compile(filename, targets[0], "", opts)
 
 	// If stdlib is needed, extract it and ensure go.mod is configured.
 	// Keep temp source in project context so local replace directives resolve.
@@ -386,23 +908,26 @@ func runCommand(filename string, targetFlag string, scriptArgs []string) {
 	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating temporary file: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
 	tmpFile := tmp.Name()
 	defer os.Remove(tmpFile)
 
 	if _, err := tmp.Write(cr.formatted); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing temporary file: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
 	if err := tmp.Close(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error closing temporary file: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
 
 	// Run with go run. Use -mod=mod so Go updates go.sum automatically when
 	// stdlib transitive dependencies (e.g. gopkg.in/yaml.v3) are not yet listed.
 	goArgs := append([]string{"run", "-mod=mod", tmpFile}, scriptArgs...)
+	if syncWorkspace(cr.projectDir) {
+		goArgs = workspaceGoArgs(goArgs)
+	}
 	cmd := exec.Command("go", goArgs...)
 	cmd.Dir = cr.projectDir
 	cmd.Env = os.Environ()
@@ -416,51 +941,125 @@ func runCommand(filename string, targetFlag string, scriptArgs []string) {
 	}
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			os.Exit(exitErr.ExitCode())
+			return exitErr.ExitCode()
 		}
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
+	return 0
 }
 
-func checkCommand(filename string, strictOnerr bool) {
+func checkCommand(filename string, strictOnerr, strictTypes bool, shadowCheck semantic.ShadowCheck) {
+	defer reportCompilerPanic("checking", filename)
 	source, err := os.ReadFile(filename)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
 		os.Exit(1)
 	}
 
-	p, err := parser.New(string(source), filename)
+	if err := checkImportCycles(filename); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// The unchecked-error lint rule runs here too, configured by kukicha.toml
+	lintConfig, err := configForFile(filename, make(map[string]*lint.Config))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Lexer error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	program, parseErrors := p.Parse()
-	if len(parseErrors) > 0 {
-		var msgs []string
-		for _, e := range parseErrors {
-			msgs = append(msgs, fmt.Sprintf("  %v", e))
+	// Each target compiles different `when target` branches, so check them all
+	targets := targetsFor(filename, "", "")
+	var warnings, uncheckedErrors []error
+	seenWarnings := make(map[string]bool)
+	for _, target := range targets {
+		p, err := parser.New(string(source), filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Lexer error: %v\n", err)
+			os.Exit(1)
 		}
-		fmt.Fprintf(os.Stderr, "Parse errors:\n%s\n", strings.Join(msgs, "\n"))
-		os.Exit(1)
-	}
 
-	analyzer := semantic.NewWithFile(program, filename)
-	semanticErrors := analyzer.Analyze()
-	if len(semanticErrors) > 0 {
-		var msgs []string
-		for _, e := range semanticErrors {
-			msgs = append(msgs, fmt.Sprintf("  %v", e))
+		program, parseErrors := p.Parse()
+		if len(parseErrors) > 0 {
+			var msgs []string
+			for _, e := range parseErrors {
+				msgs = append(msgs, fmt.Sprintf("  %v", e))
+			}
+			fmt.Fprintf(os.Stderr, "Parse errors:\n%s\n", strings.Join(msgs, "\n"))
+			os.Exit(1)
+		}
+		if err := expandImportPaths(program); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		program.Target = target
+		pass := &hooks.Pass{Program: program, File: filename, Target: target}
+		if err := runHooks(hooks.AfterParse, pass); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		analyzer := semantic.NewWithFile(program, filename)
+		analyzer.SetShadowCheck(shadowCheck)
+		analyzer.SetStrictTypes(strictTypes)
+		analyzer.SetAutoImport(autoImport)
+		registerPetioles(analyzer, program, filename)
+		semanticErrors := analyzer.Analyze()
+		if len(semanticErrors) > 0 {
+			var msgs []string
+			for _, e := range semanticErrors {
+				msgs = append(msgs, fmt.Sprintf("  %v", e))
+			}
+			if len(targets) > 1 {
+				fmt.Fprintf(os.Stderr, "Semantic errors (target %s):\n%s\n", target, strings.Join(msgs, "\n"))
+			} else {
+				fmt.Fprintf(os.Stderr, "Semantic errors:\n%s\n", strings.Join(msgs, "\n"))
+			}
+			os.Exit(1)
+		}
+
+		pass.ReturnCounts, pass.ExprTypes = analyzer.ReturnCounts(), analyzer.ExprTypes()
+		pass.AnnTypes = analyzer.TypeAnnotationTypes()
+		pass.Captures, pass.Constants = analyzer.Captures(), analyzer.Constants()
+		if err := runHooks(hooks.AfterAnalysis, pass); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		fmt.Fprintf(os.Stderr, "Semantic errors:\n%s\n", strings.Join(msgs, "\n"))
-		os.Exit(1)
-	}
 
-	warnings := analyzer.Warnings()
+		for _, w := range analyzer.Warnings() {
+			if !seenWarnings[w.Error()] {
+				seenWarnings[w.Error()] = true
+				warnings = append(warnings, w)
+			}
+		}
+		unchecked := lint.RunRule(lint.Input{
+			Program:         program,
+			File:            filename,
+			Source:          string(source),
+			ExprTypes:       analyzer.ExprTypes(),
+			UncheckedErrors: analyzer.UncheckedErrors(),
+		}, lintConfig, lint.UncheckedErrorRule)
+		for _, d := range unchecked {
+			if seenWarnings[d.String()] {
+				continue
+			}
+			seenWarnings[d.String()] = true
+			if d.Severity == lint.SeverityError {
+				uncheckedErrors = append(uncheckedErrors, errors.New(d.String()))
+			} else {
+				warnings = append(warnings, errors.New(d.String()))
+			}
+		}
+	}
 	for _, w := range warnings {
 		fmt.Fprintf(os.Stderr, "warning: %v\n", w)
 	}
+	for _, e := range uncheckedErrors {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	if len(uncheckedErrors) > 0 {
+		os.Exit(1)
+	}
 	if strictOnerr && len(warnings) > 0 {
 		fmt.Fprintln(os.Stderr, "onerr warnings promoted to errors (--strict-onerr)")
 		os.Exit(1)
@@ -6,7 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -15,7 +15,11 @@ import (
 )
 
 func packCommand(filename string, outputDir string) {
-	cr := compile(filename, "", "mcp")
+	target := "mcp"
+	if targets := targetsFor(filename, "", "mcp"); !slices.Contains(targets, "mcp") {
+		target = targets[0]
+	}
+	cr := compile(filename, target, "", BuildOptions{})
 
 	// Validate skill declaration exists
 	if cr.program.SkillDecl == nil {
@@ -59,11 +63,7 @@ func packCommand(filename string, outputDir string) {
 
 	// Build binary into scripts/
 	binaryName := toSnakeCase(skill.Name.Value)
-	targetOS := os.Getenv("GOOS")
-	if targetOS == "" {
-		targetOS = runtime.GOOS
-	}
-	if targetOS == "windows" {
+	if targetOS, _ := targetPlatform(); targetOS == "windows" {
 		binaryName += ".exe"
 	}
 	binaryPath := filepath.Join(scriptsDir, binaryName)
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// registerPetioles gives analyzer the exported functions and methods of the
+// petioles of this module (or workspace) that program imports, read from
+// their .kuki files, so calls into them are type checked. stdlib imports are
+// typed by the analyzer's registries instead.
+func registerPetioles(analyzer *semantic.Analyzer, program *ast.Program, filename string) {
+	absFile, err := filepath.Abs(filename)
+	if err != nil {
+		return
+	}
+	g, _ := newImportGraph(absFile)
+	if g == nil {
+		return
+	}
+	for _, imp := range program.Imports {
+		path := imp.Path.Value
+		if strings.HasPrefix(path, "stdlib/") {
+			continue
+		}
+		dir, ok := g.packageDir(path)
+		if !ok {
+			continue
+		}
+		if programs := parsePetiole(dir); len(programs) > 0 {
+			analyzer.RegisterPetiole(path, programs...)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/codegen"
+)
+
+// ProfileOptions are the kukicha profile run flags.
+type ProfileOptions struct {
+	Target string
+	Top    int    // Lines to show per section
+	Out    string // Keep the profiles in this directory (default: a temp dir, removed afterwards)
+}
+
+// profileSection is one part of the report: a profile file and the pprof
+// sample index it is ranked by.
+type profileSection struct {
+	title       string
+	file        string
+	sampleIndex string
+}
+
+var profileSections = []profileSection{
+	{"CPU", codegen.ProfileCPUFile, ""},
+	{"Allocations", codegen.ProfileAllocsFile, "alloc_space"},
+}
+
+// profileRow is one .kuki line from `go tool pprof -top -lines`. The values
+// are kept as pprof prints them (40ms, 13.35MB).
+type profileRow struct {
+	Flat, FlatPct, Cum, CumPct string
+	Func                       string
+	File                       string
+	Line                       int
+}
+
+// runProfile runs filename with main profiled (codegen.SetProfile), then
+// prints the hottest .kuki lines by CPU time and by bytes allocated. The
+// generated Go carries //line directives, so pprof already reports .kuki
+// positions; showing only the project's .kuki frames charges time spent in
+// Go packages to the .kuki line that called them. It returns the exit code:
+// the program's, or 1 if the profiles could not be read.
+func runProfile(filename string, scriptArgs []string, opts ProfileOptions) int {
+	dir := opts.Out
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "kukicha-profile-*")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating profile directory: %v\n", err)
+			return 1
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating profile directory: %v\n", err)
+		return 1
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving profile directory: %v\n", err)
+		return 1
+	}
+
+	code := runProgram("profile run", filename, opts.Target, scriptArgs, BuildOptions{Profile: dir})
+	absFile, err := filepath.Abs(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving file path: %v\n", err)
+		return 1
+	}
+	show := regexp.QuoteMeta(findProjectDir(absFile)+string(filepath.Separator)) + `.*\.kuki$`
+	sources := make(map[string][]string)
+
+	fmt.Println()
+	for _, section := range profileSections {
+		path := filepath.Join(dir, section.file)
+		if _, err := os.Stat(path); err != nil {
+			fmt.Printf("%s: no profile written (profiles are written when main returns, not on os.Exit)\n", section.title)
+			continue
+		}
+		out, err := pprofTop(path, section.sampleIndex, show)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s profile: %v\n", section.title, err)
+			code = 1
+			continue
+		}
+		total, rows := parsePprofTop(out)
+		printProfileSection(os.Stdout, section.title, total, topRows(rows, opts.Top), sources)
+	}
+	if opts.Out != "" {
+		fmt.Printf("Profiles written to %s (inspect with go tool pprof)\n", dir)
+	}
+	return code
+}
+
+// pprofTop runs `go tool pprof -top -lines` on a profile, keeping only the
+// frames whose file matches show and dropping the profiler's own samples.
+func pprofTop(path, sampleIndex, show string) ([]byte, error) {
+	args := []string{"tool", "pprof", "-top", "-lines", "-nodecount=0", "-nodefraction=0",
+		"-show=" + show, "-ignore=^main\\." + codegen.ProfileFunc}
+	if sampleIndex != "" {
+		args = append(args, "-sample_index="+sampleIndex)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", append(args, path)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+var pprofTotal = regexp.MustCompile(`of (\S+) total`)
+
+// parsePprofTop reads `go tool pprof -top -lines` output: the sampled total
+// and one row per line with samples, hottest first. Rows outside .kuki files
+// are skipped.
+func parsePprofTop(out []byte) (string, []profileRow) {
+	total := ""
+	var rows []profileRow
+	inRows := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !inRows {
+			if m := pprofTotal.FindStringSubmatch(line); m != nil {
+				total = m[1]
+			}
+			inRows = strings.HasPrefix(strings.TrimSpace(line), "flat ")
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+		// A path may contain spaces: it is everything after the function.
+		loc := strings.Join(fields[6:], " ")
+		colon := strings.LastIndex(loc, ":")
+		if colon < 0 || !strings.HasSuffix(loc[:colon], ".kuki") {
+			continue
+		}
+		row := profileRow{
+			Flat: fields[0], FlatPct: fields[1], Cum: fields[3], CumPct: fields[4],
+			Func: fields[5],
+			File: loc[:colon],
+		}
+		if _, err := fmt.Sscanf(loc[colon+1:], "%d", &row.Line); err != nil {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return total, rows
+}
+
+// topRows returns at most n rows (all of them when n <= 0).
+func topRows(rows []profileRow, n int) []profileRow {
+	if n > 0 && len(rows) > n {
+		return rows[:n]
+	}
+	return rows
+}
+
+// printProfileSection prints a ranked table of rows with each line's source.
+// sources caches file contents across sections.
+func printProfileSection(w io.Writer, title, total string, rows []profileRow, sources map[string][]string) {
+	if total != "" {
+		fmt.Fprintf(w, "%s (%s sampled)\n", title, total)
+	} else {
+		fmt.Fprintln(w, title)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "  no samples in .kuki code (the program may have run too briefly)")
+		fmt.Fprintln(w)
+		return
+	}
+	fmt.Fprintf(w, "  %9s %7s %9s %7s  %s\n", "flat", "flat%", "cum", "cum%", "location")
+	for _, row := range rows {
+		loc := fmt.Sprintf("%s:%d", displayPath(row.File), row.Line)
+		fmt.Fprintf(w, "  %9s %7s %9s %7s  %s  %s\n", row.Flat, row.FlatPct, row.Cum, row.CumPct, loc, shortFuncName(row.Func))
+		if src := sourceLine(sources, row.File, row.Line); src != "" {
+			fmt.Fprintf(w, "  %35s  %s\n", "", src)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// displayPath shortens path relative to the working directory when it is
+// below it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// shortFuncName drops the package path from a Go symbol (main.words ->
+// words, github.com/x/y/pkg.Load -> pkg.Load).
+func shortFuncName(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	if rest, ok := strings.CutPrefix(name, "main."); ok {
+		return rest
+	}
+	return name
+}
+
+// sourceLine returns line n of file, trimmed, or "" if it cannot be read.
+func sourceLine(sources map[string][]string, file string, n int) string {
+	lines, ok := sources[file]
+	if !ok {
+		if data, err := os.ReadFile(file); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		sources[file] = lines
+	}
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[n-1])
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pprofTopOutput = `File: kukicha-run-1075176593
+Build ID: 5ea3e27eb6916cf209a65c9e82c841d9185d5ae9
+Type: cpu
+Time: 2026-10-18 02:57:11 UTC
+Duration: 59.33ms, Total samples = 70ms (117.99%)
+Active filters:
+   show=/tmp/prof/.*\.kuki$
+Showing nodes accounting for 70ms, 100% of 70ms total
+      flat  flat%   sum%        cum   cum%
+      40ms 57.14% 57.14%       40ms 57.14%  main.words /tmp/prof/hot.kuki:9
+      20ms 28.57% 85.71%       20ms 28.57%  main.fib /tmp/my scripts/hot.kuki:3
+      10ms 14.29%   100%       10ms 14.29%  runtime.mallocgc /usr/lib/go/src/runtime/malloc.go:1020
+         0     0%   100%       40ms 57.14%  main.main /tmp/prof/hot.kuki:16
+`
+
+func TestParsePprofTop(t *testing.T) {
+	total, rows := parsePprofTop([]byte(pprofTopOutput))
+	if total != "70ms" {
+		t.Errorf("expected total 70ms, got %q", total)
+	}
+	want := []profileRow{
+		{Flat: "40ms", FlatPct: "57.14%", Cum: "40ms", CumPct: "57.14%", Func: "main.words", File: "/tmp/prof/hot.kuki", Line: 9},
+		{Flat: "20ms", FlatPct: "28.57%", Cum: "20ms", CumPct: "28.57%", Func: "main.fib", File: "/tmp/my scripts/hot.kuki", Line: 3},
+		{Flat: "0", FlatPct: "0%", Cum: "40ms", CumPct: "57.14%", Func: "main.main", File: "/tmp/prof/hot.kuki", Line: 16},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %+v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], rows[i])
+		}
+	}
+	if got := topRows(rows, 2); len(got) != 2 {
+		t.Errorf("expected --top 2 to keep 2 rows, got %d", len(got))
+	}
+	if got := topRows(rows, 0); len(got) != 3 {
+		t.Errorf("expected --top 0 to keep every row, got %d", len(got))
+	}
+}
+
+func TestPrintProfileSection(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hot.kuki")
+	if err := os.WriteFile(file, []byte("func words(n int) list of string\n    out := list of string{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rows := []profileRow{{Flat: "13.37MB", FlatPct: "92.10%", Cum: "13.37MB", CumPct: "92.10%", Func: "main.words", File: file, Line: 2}}
+	var out bytes.Buffer
+	printProfileSection(&out, "Allocations", "14.51MB", rows, make(map[string][]string))
+	got := out.String()
+	for _, want := range []string{"Allocations (14.51MB sampled)", "hot.kuki:2  words", "out := list of string{}"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	printProfileSection(&out, "CPU", "", nil, nil)
+	if !strings.Contains(out.String(), "no samples in .kuki code") {
+		t.Errorf("expected a note for an empty section, got:\n%s", out.String())
+	}
+}
+
+func TestShortFuncName(t *testing.T) {
+	for in, want := range map[string]string{
+		"main.words":                    "words",
+		"main.(*Server).Handle":         "(*Server).Handle",
+		"github.com/x/y/pkg.Load.func1": "pkg.Load.func1",
+	} {
+		if got := shortFuncName(in); got != want {
+			t.Errorf("shortFuncName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
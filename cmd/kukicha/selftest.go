@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/duber000/kukicha/internal/conformance"
+)
+
+// SelftestOptions are the kukicha selftest flags.
+type SelftestOptions struct {
+	Run    string // Only cases matching this regexp
+	Update bool   // Record the transcripts as the golden files (dir only)
+}
+
+// selftestCommand runs a conformance corpus (internal/conformance): the one
+// built into the compiler, or the name.kuki/name.golden cases in dir.
+func selftestCommand(dir string, opts SelftestOptions) {
+	var fsys fs.FS
+	if dir == "" {
+		if opts.Update {
+			fmt.Fprintln(os.Stderr, "Error: --update needs a directory; the built-in corpus is updated with go test ./internal/conformance -update")
+			os.Exit(1)
+		}
+		fsys = conformance.Corpus()
+	} else {
+		fsys = os.DirFS(dir)
+	}
+	cases, err := conformance.Load(fsys)
+	if err == nil {
+		cases, err = conformance.Select(cases, opts.Run)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(cases) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no .kuki cases found")
+		os.Exit(1)
+	}
+	if !writeSelftestResults(os.Stdout, conformance.RunAll(cases, conformance.Options{}), dir, opts.Update) {
+		os.Exit(1)
+	}
+}
+
+// writeSelftestResults reports each result (or, with update, records it in
+// dir) and returns whether every case passed.
+func writeSelftestResults(w io.Writer, results []conformance.Result, dir string, update bool) bool {
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			fmt.Fprintf(w, "FAIL %s: %v\n", r.Case.Name, r.Err)
+			failed++
+		case update:
+			if err := conformance.WriteGolden(dir, r); err != nil {
+				fmt.Fprintf(w, "FAIL %s: %v\n", r.Case.Name, err)
+				failed++
+			} else if !r.Passed() {
+				fmt.Fprintf(w, "updated %s.golden\n", r.Case.Name)
+			}
+		case !r.Case.HasGolden:
+			fmt.Fprintf(w, "FAIL %s: no %s.golden (run with --update to record it)\n", r.Case.Name, r.Case.Name)
+			failed++
+		case !r.Passed():
+			fmt.Fprintf(w, "FAIL %s\n%s", r.Case.Name, conformance.Diff(r.Case.Golden, r.Transcript))
+			failed++
+		default:
+			fmt.Fprintf(w, "ok   %s\n", r.Case.Name)
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(w, "%d of %d case(s) failed\n", failed, len(results))
+		return false
+	}
+	fmt.Fprintf(w, "%d case(s) passed\n", len(results))
+	return true
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/conformance"
+)
+
+func TestWriteSelftestResults(t *testing.T) {
+	results := []conformance.Result{
+		{Case: conformance.Case{Name: "ok", Golden: "1\n", HasGolden: true}, Transcript: "1\n"},
+		{Case: conformance.Case{Name: "changed", Golden: "1\n", HasGolden: true}, Transcript: "2\n"},
+		{Case: conformance.Case{Name: "new"}, Transcript: "3\n"},
+		{Case: conformance.Case{Name: "broken"}, Err: errors.New("go: not found")},
+	}
+	var out bytes.Buffer
+	if writeSelftestResults(&out, results, "", false) {
+		t.Error("expected failures to be reported")
+	}
+	for _, want := range []string{
+		"ok   ok\n",
+		"FAIL changed\n  1: - 1\n  1: + 2\n",
+		"FAIL new: no new.golden (run with --update to record it)",
+		"FAIL broken: go: not found",
+		"3 of 4 case(s) failed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+
+	dir := t.TempDir()
+	out.Reset()
+	if !writeSelftestResults(&out, results[:3], dir, true) {
+		t.Fatalf("expected --update to succeed, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "updated ok.golden") || !strings.Contains(out.String(), "updated new.golden") {
+		t.Errorf("expected only changed and new goldens to be reported, got:\n%s", out.String())
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "changed.golden")); err != nil || string(data) != "2\n" {
+		t.Errorf("expected changed.golden to hold the new transcript, got %q (%v)", data, err)
+	}
+}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"fmt"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	kukicha "github.com/duber000/kukicha"
@@ -16,6 +20,7 @@ import (
 
 const stdlibDirName = ".kukicha/stdlib"
 const stdlibVersionFile = "KUKICHA_VERSION"
+const stdlibPlatformFile = "KUKICHA_PLATFORM"
 
 // stdlibGoMod is the go.mod content for the extracted stdlib module.
 // This declares the stdlib as a standalone Go module so user projects can
@@ -26,13 +31,23 @@ go 1.26.1
 
 require (
 	github.com/a2aproject/a2a-go v0.3.6
+	go.opentelemetry.io/otel v1.40.0
+	go.opentelemetry.io/otel/metric v1.40.0
+	go.opentelemetry.io/otel/sdk v1.40.0
+	go.opentelemetry.io/otel/sdk/metric v1.40.0
+	go.opentelemetry.io/otel/trace v1.40.0
+	golang.org/x/net v0.41.0
 	golang.org/x/text v0.26.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/google/uuid v1.6.0 // indirect
-	golang.org/x/net v0.41.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	golang.org/x/sync v0.15.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250715232539-7130f93afb79 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250715232539-7130f93afb79 // indirect
@@ -44,8 +59,12 @@ require (
 // stdlibGoSum contains dependency checksums for the stdlib module.
 const stdlibGoSum = `github.com/a2aproject/a2a-go v0.3.6 h1:VbRoM2MNsfc7o4GkjGt3KZCjbqILAJq846K1z8rpHTc=
 github.com/a2aproject/a2a-go v0.3.6/go.mod h1:I7Cm+a1oL+UT6zMoP+roaRE5vdfUa1iQGVN8aSOuZ0I=
+github.com/cespare/xxhash/v2 v2.3.0 h1:UL815xU9SqsFlibzuggzjXhog7bL6oX9BbNZnL2UFvs=
+github.com/cespare/xxhash/v2 v2.3.0/go.mod h1:VGX0DQ3Q6kWi7AoAeZDth3/j3BFtOZR5XLFGgcrjCOs=
 github.com/go-logr/logr v1.4.2 h1:6pFjapn8bFcIbiKo3XT4j/BhANplGihG6tvd+8rYgrY=
 github.com/go-logr/logr v1.4.2/go.mod h1:9T104GzyrTigFIr8wt5mBrctHMim0Nb2HLGrmQ40KvY=
+github.com/go-logr/logr v1.4.3 h1:CjnDlHq8ikf6E492q6eKboGOC0T8CDaOvkHCIg8idEI=
+github.com/go-logr/logr v1.4.3/go.mod h1:9T104GzyrTigFIr8wt5mBrctHMim0Nb2HLGrmQ40KvY=
 github.com/go-logr/stdr v1.2.2 h1:hSWxHoqTgW2S2qGc0LTAI563KZ5YKYRhT3MFKZMbjag=
 github.com/go-logr/stdr v1.2.2/go.mod h1:mMo/vtBO5dYbehREoey6XUKy/eSumjCCveDpRre4VKE=
 github.com/golang/protobuf v1.5.4 h1:i7eJL8qZTpSEXOPTxNKhASYpMn+8e5Q6AdndVa1dWek=
@@ -56,16 +75,28 @@ github.com/google/uuid v1.6.0 h1:NIvaJDMOsjHA8n1jAhLSgzrAzy1Hgr+hNrb57e+94F0=
 github.com/google/uuid v1.6.0/go.mod h1:TIyPZe4MgqvfeYDBFedMoGGpEw/LqOeaOT+nhxU+yHo=
 go.opentelemetry.io/auto/sdk v1.1.0 h1:cH53jehLUN6UFLY71z+NDOiNJqDdPRaXzTel0sJySYA=
 go.opentelemetry.io/auto/sdk v1.1.0/go.mod h1:3wSPjt5PWp2RhlCcmmOial7AvC4DQqZb7a7wCow3W8A=
+go.opentelemetry.io/auto/sdk v1.2.1 h1:jXsnJ4Lmnqd11kwkBV2LgLoFMZKizbCi5fNZ/ipaZ64=
+go.opentelemetry.io/auto/sdk v1.2.1/go.mod h1:KRTj+aOaElaLi+wW1kO/DZRXwkF4C5xPbEe3ZiIhN7Y=
 go.opentelemetry.io/otel v1.35.0 h1:xKWKPxrxB6OtMCbmMY021CqC45J+3Onta9MqjhnusiQ=
 go.opentelemetry.io/otel v1.35.0/go.mod h1:UEqy8Zp11hpkUrL73gSlELM0DupHoiq72dR+Zqel/+Y=
+go.opentelemetry.io/otel v1.40.0 h1:oA5YeOcpRTXq6NN7frwmwFR0Cn3RhTVZvXsP4duvCms=
+go.opentelemetry.io/otel v1.40.0/go.mod h1:IMb+uXZUKkMXdPddhwAHm6UfOwJyh4ct1ybIlV14J0g=
 go.opentelemetry.io/otel/metric v1.35.0 h1:0znxYu2SNyuMSQT4Y9WDWej0VpcsxkuklLa4/siN90M=
 go.opentelemetry.io/otel/metric v1.35.0/go.mod h1:nKVFgxBZ2fReX6IlyW28MgZojkoAkJGaE8CpgeAU3oE=
+go.opentelemetry.io/otel/metric v1.40.0 h1:rcZe317KPftE2rstWIBitCdVp89A2HqjkxR3c11+p9g=
+go.opentelemetry.io/otel/metric v1.40.0/go.mod h1:ib/crwQH7N3r5kfiBZQbwrTge743UDc7DTFVZrrXnqc=
 go.opentelemetry.io/otel/sdk v1.35.0 h1:iPctf8iprVySXSKJffSS79eOjl9pvxV9ZqOWT0QejKY=
 go.opentelemetry.io/otel/sdk v1.35.0/go.mod h1:+ga1bZliga3DxJ3CQGg3updiaAJoNECOgJREo9KHGQg=
+go.opentelemetry.io/otel/sdk v1.40.0 h1:KHW/jUzgo6wsPh9At46+h4upjtccTmuZCFAc9OJ71f8=
+go.opentelemetry.io/otel/sdk v1.40.0/go.mod h1:Ph7EFdYvxq72Y8Li9q8KebuYUr2KoeyHx0DRMKrYBUE=
 go.opentelemetry.io/otel/sdk/metric v1.35.0 h1:1RriWBmCKgkeHEhM7a2uMjMUfP7MsOF5JpUCaEqEI9o=
 go.opentelemetry.io/otel/sdk/metric v1.35.0/go.mod h1:is6XYCUMpcKi+ZsOvfluY5YstFnhW0BidkR+gL+qN+w=
+go.opentelemetry.io/otel/sdk/metric v1.40.0 h1:mtmdVqgQkeRxHgRv4qhyJduP3fYJRMX4AtAlbuWdCYw=
+go.opentelemetry.io/otel/sdk/metric v1.40.0/go.mod h1:4Z2bGMf0KSK3uRjlczMOeMhKU2rhUqdWNoKcYrtcBPg=
 go.opentelemetry.io/otel/trace v1.35.0 h1:dPpEfJu1sDIqruz7BHFG3c7528f6ddfSWfFDVt/xgMs=
 go.opentelemetry.io/otel/trace v1.35.0/go.mod h1:WUk7DtFp1Aw2MkvqGdwiXYDZZNvA/1J8o6xRXLrIkyc=
+go.opentelemetry.io/otel/trace v1.40.0 h1:WA4etStDttCSYuhwvEa8OP8I5EWu24lkOzp+ZYblVjw=
+go.opentelemetry.io/otel/trace v1.40.0/go.mod h1:zeAhriXecNGP/s2SEG3+Y8X9ujcJOTqQ5RgdEJcawiA=
 golang.org/x/net v0.41.0 h1:vBTly1HeNPEn3wtREYfy4GZ/NECgw2Cnl+nK6Nz3uvw=
 golang.org/x/net v0.41.0/go.mod h1:B/K4NNqkfmg07DQYrbwvSluqCJOOXwUjeb/5lOisjbA=
 golang.org/x/sync v0.15.0 h1:KWH3jNZsfyT6xfAfKiz6MRNmd46ByHDYaZ7KSkCtdW8=
@@ -89,25 +120,29 @@ gopkg.in/yaml.v3 v3.0.1/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
 `
 
 // ensureStdlib extracts the embedded stdlib to projectDir/.kukicha/stdlib/ if not present
-// or if the cached version stamp doesn't match the current binary version.
+// or if the cached version stamp doesn't match the current binary version or
+// the platform being built for (see targetPlatform).
 // Returns the absolute path to the extracted stdlib directory.
 func ensureStdlib(projectDir string) (string, error) {
 	stdlibPath := filepath.Join(projectDir, stdlibDirName)
+	goos, goarch := targetPlatform()
 
-	// Check version stamp: only skip extraction if cache exists AND matches current version.
+	// Check version stamp: only skip extraction if cache exists AND matches current version
+	// and platform.
 	stampPath := filepath.Join(stdlibPath, stdlibVersionFile)
 	if stamp, err := os.ReadFile(stampPath); err == nil {
-		if strings.TrimSpace(string(stamp)) == version.Version {
+		platform, _ := os.ReadFile(filepath.Join(stdlibPath, stdlibPlatformFile))
+		if strings.TrimSpace(string(stamp)) == version.Version && strings.TrimSpace(string(platform)) == goos+"/"+goarch {
 			return stdlibPath, nil
 		}
-		// Version mismatch — remove stale cache and re-extract.
+		// Version or platform mismatch — remove stale cache and re-extract.
 		if err := os.RemoveAll(stdlibPath); err != nil {
 			return "", fmt.Errorf("removing stale stdlib cache: %w", err)
 		}
 	}
 
 	// Extract from embedded FS
-	if err := extractStdlib(stdlibPath); err != nil {
+	if err := extractStdlib(stdlibPath, goos, goarch); err != nil {
 		return "", fmt.Errorf("extracting stdlib: %w", err)
 	}
 
@@ -120,13 +155,19 @@ func ensureStdlib(projectDir string) (string, error) {
 }
 
 // extractStdlib writes the embedded stdlib files to the target directory,
-// plus a generated go.mod and go.sum for the standalone module.
-func extractStdlib(targetDir string) error {
+// plus a generated go.mod and go.sum for the standalone module. Packages with
+// no source files for goos/goarch are left out, so a cross-compile never
+// vets or builds OS-specific helpers it cannot use; every build-tagged
+// variant of the packages that remain is extracted.
+func extractStdlib(targetDir, goos, goarch string) error {
 	// Walk embedded FS and extract all files under "stdlib/"
 	err := fs.WalkDir(kukicha.StdlibFS, "stdlib", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+		if d.IsDir() && path != "stdlib" && !stdlibPackageBuilds(kukicha.StdlibFS, path, goos, goarch) {
+			return fs.SkipDir
+		}
 
 		// Map embedded path "stdlib/json/json.go" -> targetDir + "/json/json.go"
 		relPath, _ := filepath.Rel("stdlib", path)
@@ -161,10 +202,70 @@ func extractStdlib(targetDir string) error {
 	if err := os.WriteFile(filepath.Join(targetDir, stdlibVersionFile), []byte(version.Version), 0644); err != nil {
 		return err
 	}
+	if err := os.WriteFile(filepath.Join(targetDir, stdlibPlatformFile), []byte(goos+"/"+goarch), 0644); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// targetPlatform returns the GOOS and GOARCH being built for: the GOOS and
+// GOARCH environment variables (which --goos and --goarch set), else the host.
+func targetPlatform() (goos, goarch string) {
+	goos, goarch = os.Getenv("GOOS"), os.Getenv("GOARCH")
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return goos, goarch
+}
+
+// stdlibPackageBuilds reports whether the stdlib package in dir of fsys has
+// a non-test Go file that builds for goos/goarch, going by file name
+// suffixes (_windows.go, _linux_arm64.go) and //go:build lines.
+func stdlibPackageBuilds(fsys fs.FS, dir, goos, goarch string) bool {
+	ctx := build.Default
+	ctx.GOOS = goos
+	ctx.GOARCH = goarch
+	ctx.CgoEnabled = ctx.CgoEnabled && goos == runtime.GOOS && goarch == runtime.GOARCH
+	ctx.JoinPath = path.Join
+	ctx.OpenFile = func(name string) (io.ReadCloser, error) { return fsys.Open(name) }
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := ctx.MatchFile(dir, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unavailableStdlibImports returns the stdlib packages goCode imports that
+// have no source files for goos/goarch.
+func unavailableStdlibImports(goCode, goos, goarch string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", goCode, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+	var missing []string
+	for _, imp := range file.Imports {
+		pkg, ok := strings.CutPrefix(strings.Trim(imp.Path.Value, "\""), "github.com/duber000/kukicha/stdlib/")
+		if ok && !stdlibPackageBuilds(kukicha.StdlibFS, "stdlib/"+pkg, goos, goarch) {
+			missing = append(missing, "stdlib/"+pkg)
+		}
+	}
+	return missing
+}
+
 const skillStart = "<!-- kukicha:start -->"
 const skillEnd = "<!-- kukicha:end -->"
 
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
+
+	kukicha "github.com/duber000/kukicha"
 )
 
 func TestNeedsStdlib_NoStdlibImport(t *testing.T) {
@@ -37,3 +41,74 @@ import _ "github.com/duber000/kukicha/stdlib/json"
 		t.Fatal("expected needsStdlib=true for non-kukicha projects importing stdlib")
 	}
 }
+
+func TestStdlibPackageBuilds(t *testing.T) {
+	fsys := fstest.MapFS{
+		"stdlib/portable/portable.go":         {Data: []byte("package portable\n")},
+		"stdlib/unixonly/unixonly.go":         {Data: []byte("//go:build unix\n\npackage unixonly\n")},
+		"stdlib/unixonly/unixonly_test.go":    {Data: []byte("package unixonly\n")},
+		"stdlib/variants/variants_windows.go": {Data: []byte("package variants\n")},
+		"stdlib/variants/variants_unix.go":    {Data: []byte("//go:build unix\n\npackage variants\n")},
+	}
+	tests := []struct {
+		dir, goos string
+		want      bool
+	}{
+		{"stdlib/portable", "windows", true},
+		{"stdlib/unixonly", "linux", true},
+		{"stdlib/unixonly", "windows", false},
+		{"stdlib/variants", "windows", true},
+		{"stdlib/variants", "darwin", true},
+		{"stdlib/missing", "linux", false},
+	}
+	for _, tt := range tests {
+		if got := stdlibPackageBuilds(fsys, tt.dir, tt.goos, "amd64"); got != tt.want {
+			t.Errorf("stdlibPackageBuilds(%s, %s) = %v, want %v", tt.dir, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestStdlibBuildsForReleasePlatforms(t *testing.T) {
+	entries, err := fs.ReadDir(kukicha.StdlibFS, "stdlib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		for _, e := range entries {
+			if e.IsDir() && !stdlibPackageBuilds(kukicha.StdlibFS, "stdlib/"+e.Name(), goos, "amd64") {
+				t.Errorf("stdlib/%s has no source files for %s", e.Name(), goos)
+			}
+		}
+	}
+
+	goCode := "package main\nimport _ \"github.com/duber000/kukicha/stdlib/json\"\n"
+	if missing := unavailableStdlibImports(goCode, "windows", "amd64"); len(missing) != 0 {
+		t.Errorf("expected stdlib/json to be available for windows, got missing %v", missing)
+	}
+}
+
+func TestEnsureStdlib_ReExtractsOnPlatformChange(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GOOS", "linux")
+	t.Setenv("GOARCH", "amd64")
+	stdlibPath, err := ensureStdlib(dir)
+	if err != nil {
+		t.Fatalf("first ensureStdlib: %v", err)
+	}
+	marker := filepath.Join(stdlibPath, "test_marker.txt")
+	if err := os.WriteFile(marker, []byte("exists"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GOOS", "windows")
+	if _, err := ensureStdlib(dir); err != nil {
+		t.Fatalf("second ensureStdlib: %v", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("expected the stdlib to be re-extracted for a new platform")
+	}
+	platform, err := os.ReadFile(filepath.Join(stdlibPath, stdlibPlatformFile))
+	if err != nil || string(platform) != "windows/amd64" {
+		t.Errorf("expected platform stamp windows/amd64, got %q (%v)", platform, err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetectTargets(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{"# target: mcp\nfunc main()\n", []string{"mcp"}},
+		{"# header\n# target: cli, mcp\n", []string{"cli", "mcp"}},
+		{"# target: cli mcp cli\n", []string{"cli", "mcp"}},
+		{"# target: a2a\n", []string{"a2a"}},
+		{"func main()\n", nil},
+	}
+	for _, tt := range tests {
+		if got, err := detectTargets(tt.source); err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("detectTargets(%q) = %v, %v, want %v", tt.source, got, err, tt.want)
+		}
+	}
+}
+
+func TestDetectTargetsExpandsEnv(t *testing.T) {
+	t.Setenv("KUKICHA_TEST_TARGET", "mcp")
+	if got, err := detectTargets("# target: cli, ${KUKICHA_TEST_TARGET}\n"); err != nil || !slices.Equal(got, []string{"cli", "mcp"}) {
+		t.Errorf("detectTargets = %v, %v, want [cli mcp]", got, err)
+	}
+
+	_, err := detectTargets("# target: ${KUKICHA_TEST_UNSET}\n")
+	if err == nil || err.Error() != "# target: environment variable KUKICHA_TEST_UNSET is not set" {
+		t.Errorf("expected unset variable error, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TestOptions controls kukicha test.
+type TestOptions struct {
+	Seed            string // decimal seed passed to stdlib/random and expect.ForAll via KUKICHA_SEED; "" leaves it unset
+	Run             string // go test -run pattern
+	UpdateSnapshots bool   // record stdlib/expect snapshots instead of checking them (KUKICHA_UPDATE_SNAPSHOTS)
+}
+
+// testCommand compiles every .kuki file in dir (sources and _test.kuki files)
+// next to its source and runs go test on the package. With --seed the tests
+// see a deterministic stdlib/random; with --update-snapshots expect.Snapshot
+// records what the tests produce.
+func testCommand(dir string, opts TestOptions) {
+	if opts.Seed != "" {
+		if _, err := strconv.ParseInt(opts.Seed, 10, 64); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --seed must be an integer, got %q\n", opts.Seed)
+			os.Exit(1)
+		}
+	}
+
+	files, err := kukiFilesIn(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no .kuki files in %s\n", dir)
+		os.Exit(1)
+	}
+
+	projectDir := ""
+	for _, file := range files {
+		cr := compile(file, targetsFor(file, "", "")[0], "", BuildOptions{})
+		projectDir = cr.projectDir
+		base := strings.TrimSuffix(cr.absFile, ".kuki")
+		outFile := base + ".go"
+		if err := os.WriteFile(outFile, cr.formatted, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outFile, err)
+			os.Exit(1)
+		}
+		if err := writeDeriveTests(cr, base); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing @derive jsontest file: %v\n", err)
+			os.Exit(1)
+		}
+		ensureStdlibIfNeeded(cr.goCode, projectDir)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving path: %v\n", err)
+		os.Exit(1)
+	}
+	pkg, err := filepath.Rel(projectDir, absDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving package: %v\n", err)
+		os.Exit(1)
+	}
+
+	goArgs := goTestArgs(pkg, opts)
+	if syncWorkspace(projectDir) {
+		goArgs = workspaceGoArgs(goArgs)
+	}
+	cmd := exec.Command("go", goArgs...)
+	cmd.Dir = projectDir
+	cmd.Env = testEnv(os.Environ(), opts)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error running go test: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// kukiFilesIn returns the .kuki files directly inside dir, sorted.
+func kukiFilesIn(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".kuki") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// goTestArgs returns the go test arguments for the package at pkg, a path
+// relative to the project directory.
+func goTestArgs(pkg string, opts TestOptions) []string {
+	args := []string{"test", "-mod=mod"}
+	if opts.Run != "" {
+		args = append(args, "-run", opts.Run)
+	}
+	return append(args, "./"+filepath.ToSlash(pkg))
+}
+
+// testEnv returns env with KUKICHA_SEED set when opts has a seed, and
+// KUKICHA_UPDATE_SNAPSHOTS when it updates snapshots. The names match
+// random.SeedEnv in stdlib/random and expect.UpdateEnv in stdlib/expect.
+func testEnv(env []string, opts TestOptions) []string {
+	if opts.Seed != "" {
+		env = append(env, "KUKICHA_SEED="+opts.Seed)
+	}
+	if opts.UpdateSnapshots {
+		env = append(env, "KUKICHA_UPDATE_SNAPSHOTS=1")
+	}
+	return env
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGoTestArgs(t *testing.T) {
+	got := goTestArgs(filepath.Join("pkg", "shop"), TestOptions{Run: "TestCart"})
+	want := []string{"test", "-mod=mod", "-run", "TestCart", "./pkg/shop"}
+	if !slices.Equal(got, want) {
+		t.Errorf("goTestArgs = %v, want %v", got, want)
+	}
+	if got := goTestArgs(".", TestOptions{}); !slices.Equal(got, []string{"test", "-mod=mod", "./."}) {
+		t.Errorf("goTestArgs(.) = %v", got)
+	}
+}
+
+func TestTestEnvSeed(t *testing.T) {
+	env := []string{"HOME=/home/u"}
+	if got := testEnv(env, TestOptions{}); !slices.Equal(got, env) {
+		t.Errorf("testEnv without seed = %v, want %v", got, env)
+	}
+	got := testEnv(env, TestOptions{Seed: "42"})
+	if !slices.Contains(got, "KUKICHA_SEED=42") {
+		t.Errorf("testEnv with seed = %v, want KUKICHA_SEED=42", got)
+	}
+	got = testEnv(env, TestOptions{UpdateSnapshots: true})
+	if !slices.Contains(got, "KUKICHA_UPDATE_SNAPSHOTS=1") || slices.ContainsFunc(got, func(kv string) bool { return strings.HasPrefix(kv, "KUKICHA_SEED=") }) {
+		t.Errorf("testEnv updating snapshots = %v, want only KUKICHA_UPDATE_SNAPSHOTS=1 added", got)
+	}
+}
+
+func TestKukiFilesIn(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.kuki", "a_test.kuki", "a.go", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub.kuki"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := kukiFilesIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "a_test.kuki"), filepath.Join(dir, "b.kuki")}
+	if !slices.Equal(files, want) {
+		t.Errorf("kukiFilesIn = %v, want %v", files, want)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VetOptions controls kukicha vet.
+type VetOptions struct {
+	Staticcheck bool // Also run staticcheck (must be on PATH)
+}
+
+// generatedFile is a Go file kukicha vet compiled, kept to map findings in
+// it back to the .kuki source.
+type generatedFile struct {
+	kukiFile string
+	source   []byte
+}
+
+// vetCommand compiles the .kuki files under paths next to their sources and
+// runs go vet, and with --staticcheck staticcheck, on their packages. Findings
+// are reported at .kuki positions (see translateFindings). It returns the exit
+// code: 1 when a tool reported anything or failed.
+func vetCommand(paths []string, opts VetOptions) int {
+	files, err := expandKukiFiles(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no .kuki files found")
+		return 1
+	}
+	staticcheck := ""
+	if opts.Staticcheck {
+		staticcheck, err = exec.LookPath("staticcheck")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: --staticcheck needs staticcheck on PATH (go install honnef.co/go/tools/cmd/staticcheck@latest)")
+			return 1
+		}
+	}
+
+	generated := make(map[string]generatedFile)
+	packages := make(map[string][]string) // project directory -> package patterns
+	for _, file := range files {
+		cr := compile(file, targetsFor(file, "", "")[0], "", BuildOptions{})
+		outFile := strings.TrimSuffix(cr.absFile, ".kuki") + ".go"
+		if err := os.WriteFile(outFile, cr.formatted, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outFile, err)
+			return 1
+		}
+		ensureStdlibIfNeeded(cr.goCode, cr.projectDir)
+		generated[outFile] = generatedFile{kukiFile: cr.absFile, source: cr.formatted}
+
+		pkg, err := filepath.Rel(cr.projectDir, filepath.Dir(cr.absFile))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving package: %v\n", err)
+			return 1
+		}
+		pattern := "./" + filepath.ToSlash(pkg)
+		if !slices.Contains(packages[cr.projectDir], pattern) {
+			packages[cr.projectDir] = append(packages[cr.projectDir], pattern)
+		}
+	}
+
+	projectDirs := make([]string, 0, len(packages))
+	for dir := range packages {
+		projectDirs = append(projectDirs, dir)
+	}
+	sort.Strings(projectDirs)
+
+	exitCode := 0
+	for _, dir := range projectDirs {
+		inWorkspace := syncWorkspace(dir)
+		args := append([]string{"vet", "-mod=mod"}, packages[dir]...)
+		if inWorkspace {
+			args = workspaceGoArgs(args)
+		}
+		if !runVetTool(exec.Command("go", args...), dir, generated) {
+			exitCode = 1
+		}
+		if staticcheck != "" && !runVetTool(exec.Command(staticcheck, packages[dir]...), dir, generated) {
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
+
+// runVetTool runs a vet tool in dir and writes its findings, translated to
+// .kuki positions, to stderr. It reports whether the tool found nothing.
+func runVetTool(cmd *exec.Cmd, dir string, generated map[string]generatedFile) bool {
+	var out bytes.Buffer
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	os.Stderr.Write(translateFindings(out.Bytes(), dir, generated))
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			fmt.Fprintf(os.Stderr, "Error running %s: %v\n", filepath.Base(cmd.Path), err)
+		}
+		return false
+	}
+	return true
+}
+
+// findingPattern matches the position that starts a go vet or staticcheck
+// finding: file.go:line[:column]: message.
+var findingPattern = regexp.MustCompile(`^(.+\.go):(\d+)(?::\d+)?: (.*)$`)
+
+// translateFindings rewrites findings that point into a generated Go file so
+// they point at the .kuki line it was compiled from. go vet already follows
+// the //line directives, but staticcheck reports the physical Go position,
+// and code before the first directive (imports) has no .kuki line; those
+// findings name the .kuki file alone. The Go column is dropped since it does
+// not correspond to a .kuki column. Relative paths are resolved against dir,
+// where the tool ran.
+func translateFindings(out []byte, dir string, generated map[string]generatedFile) []byte {
+	var result bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := findingPattern.FindStringSubmatch(line); m != nil {
+			path := m[1]
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			if gf, ok := generated[path]; ok {
+				goLine, _ := strconv.Atoi(m[2])
+				if file, kukiLine, ok := kukiPosition(gf.source, goLine); ok {
+					line = fmt.Sprintf("%s:%d: %s", file, kukiLine, m[3])
+				} else {
+					line = fmt.Sprintf("%s: %s (in generated code)", gf.kukiFile, m[3])
+				}
+			}
+		}
+		result.WriteString(line)
+		result.WriteByte('\n')
+	}
+	return result.Bytes()
+}
+
+// kukiPosition follows the //line directives of generated Go source, as the
+// Go toolchain does, to the .kuki file and line that Go line goLine came
+// from. ok is false for lines before the first directive.
+func kukiPosition(source []byte, goLine int) (file string, line int, ok bool) {
+	directiveLine := 0
+	n := 0
+	for text := range bytes.Lines(source) {
+		n++
+		if n >= goLine {
+			break
+		}
+		spec, found := bytes.CutPrefix(bytes.TrimRight(text, "\r\n"), []byte("//line "))
+		if !found {
+			continue
+		}
+		i := bytes.LastIndexByte(spec, ':')
+		if i < 0 {
+			continue
+		}
+		l, err := strconv.Atoi(string(spec[i+1:]))
+		if err != nil {
+			continue
+		}
+		file, line, directiveLine, ok = string(spec[:i]), l, n, true
+	}
+	if !ok {
+		return "", 0, false
+	}
+	return file, line + goLine - directiveLine - 1, true
+}
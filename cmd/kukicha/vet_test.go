@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const vetGenerated = `// Generated by Kukicha (requires Go 1.26+)
+
+package main
+
+import "fmt"
+
+//line /src/app.kuki:3
+func main() {
+//line /src/app.kuki:4
+	name := "x"
+	fmt.Printf("%d\n", name)
+}
+`
+
+func TestKukiPosition(t *testing.T) {
+	tests := []struct {
+		goLine int
+		line   int
+		ok     bool
+	}{
+		{5, 0, false}, // import, before the first directive
+		{8, 3, true},
+		{10, 4, true},
+		{11, 5, true},
+	}
+	for _, tt := range tests {
+		file, line, ok := kukiPosition([]byte(vetGenerated), tt.goLine)
+		if ok != tt.ok || line != tt.line || (ok && file != "/src/app.kuki") {
+			t.Errorf("kukiPosition(%d) = %q, %d, %v; want /src/app.kuki, %d, %v", tt.goLine, file, line, ok, tt.line, tt.ok)
+		}
+	}
+}
+
+func TestTranslateFindings(t *testing.T) {
+	dir := filepath.FromSlash("/src")
+	generated := map[string]generatedFile{
+		filepath.Join(dir, "app.go"): {kukiFile: "/src/app.kuki", source: []byte(vetGenerated)},
+	}
+	out := "# example.com/app\n" +
+		"app.go:11:2: this value of name is never used (SA4006)\n" +
+		"app.go:5:8: \"fmt\" imported and not used\n" +
+		"/src/app.kuki:5: fmt.Printf format %d has arg name of wrong type string\n" +
+		"other.go:3:1: hand-written finding\n"
+	want := "# example.com/app\n" +
+		"/src/app.kuki:5: this value of name is never used (SA4006)\n" +
+		"/src/app.kuki: \"fmt\" imported and not used (in generated code)\n" +
+		"/src/app.kuki:5: fmt.Printf format %d has arg name of wrong type string\n" +
+		"other.go:3:1: hand-written finding\n"
+	if got := string(translateFindings([]byte(out), dir, generated)); got != want {
+		t.Errorf("translateFindings:\n%s\nwant:\n%s", got, want)
+	}
+}
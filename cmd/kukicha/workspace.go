@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/duber000/kukicha/internal/workspace"
+)
+
+// syncWorkspace writes go.work for the kukicha.work projectDir belongs to, so
+// go build and go run use the local copies of the other projects, and
+// reports whether there is one. The stdlib is replaced with projectDir's
+// extracted copy, else that of the first project that has one.
+func syncWorkspace(projectDir string) bool {
+	ws, err := workspace.ForDir(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", workspace.FileName, err)
+		os.Exit(1)
+	}
+	if ws == nil {
+		return false
+	}
+	stdlibDir := ""
+	dirs := []string{projectDir}
+	for _, m := range ws.Modules {
+		dirs = append(dirs, m.Dir)
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, stdlibDirName, "go.mod")); err == nil {
+			stdlibDir = filepath.Join(dir, stdlibDirName)
+			break
+		}
+	}
+	if err := ws.WriteGoWork(stdlibDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing go.work: %v\n", err)
+		os.Exit(1)
+	}
+	return true
+}
+
+// workspaceGoArgs drops -mod=mod from go command arguments, which go rejects
+// in workspace mode.
+func workspaceGoArgs(args []string) []string {
+	return slices.DeleteFunc(args, func(arg string) bool { return arg == "-mod=mod" })
+}
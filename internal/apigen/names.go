@@ -0,0 +1,127 @@
+package apigen
+
+import (
+	goToken "go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/lexer"
+)
+
+// initialisms are words written in capitals in Go names (userId → UserID).
+var initialisms = map[string]bool{
+	"api": true, "cpu": true, "css": true, "dns": true, "html": true, "http": true, "https": true,
+	"id": true, "ip": true, "json": true, "sql": true, "ssh": true, "tcp": true, "tls": true,
+	"ttl": true, "ui": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// words splits a name at punctuation, spaces and case changes:
+// "X-Rate-Limit", "pet_id" and "HTTPServer" give [X Rate Limit], [pet id]
+// and [HTTP Server].
+func words(s string) []string {
+	var out []string
+	var word []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(word) > 0 {
+			out = append(out, string(word))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return out
+}
+
+// joinWords writes the words of s in PascalCase, initialisms in capitals.
+func joinWords(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		b.WriteString(strings.ToUpper(string(r[0])))
+		b.WriteString(string(r[1:]))
+	}
+	return b.String()
+}
+
+// pascal returns an exported name for s, or fallback when s has no letters
+// or digits. A name that would start with a digit gets fallback in front.
+func pascal(s, fallback string) string {
+	name := joinWords(s)
+	if name == "" {
+		return fallback
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		return fallback + name
+	}
+	return name
+}
+
+// shadowed are Go predeclared names a parameter must not take, since the
+// generated function uses them.
+var shadowed = map[string]bool{
+	"any": true, "bool": true, "int": true, "int32": true, "int64": true, "float32": true,
+	"float64": true, "string": true, "byte": true, "len": true, "make": true, "new": true,
+	"append": true, "nil": true, "true": true, "false": true, "print": true,
+}
+
+// paramName returns a parameter name for a wire name: camelCase, suffixed
+// with Param when it is a keyword or a name the function uses, and
+// numbered when another parameter has it.
+func paramName(wire string, used map[string]bool) string {
+	name := joinWords(wire)
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Param" + name
+	}
+	first := words(name)[0]
+	if initialisms[strings.ToLower(first)] {
+		name = strings.ToLower(first) + name[len(first):]
+	} else {
+		name = strings.ToLower(name[:1]) + name[1:]
+	}
+	if lexer.IsKeyword(name) || goToken.IsKeyword(name) || localNames[name] || shadowed[name] {
+		name += "Param"
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// quote returns s as a Kukicha string literal, braces escaped so they don't
+// read as interpolation.
+func quote(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "{", `\{`)
+	return strings.ReplaceAll(q, "}", `\}`)
+}
+
+// writeDoc writes the first paragraph of text as comment lines.
+func writeDoc(b *strings.Builder, indent, text string) {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(text), "\n\n")
+	for line := range strings.Lines(paragraph) {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString(indent + "# " + line + "\n")
+		}
+	}
+}
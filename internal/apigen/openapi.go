@@ -0,0 +1,822 @@
+// Package apigen writes Kukicha source from API descriptions, for
+// `kukicha gen`: an OpenAPI 3 document becomes type declarations for its
+// schemas and a function per operation built on stdlib/fetch.
+package apigen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options configures a generated file.
+type Options struct {
+	Package   string // petiole of the generated file
+	Source    string // spec file name, for the header comment
+	GoPackage string // proto: import path of the generated Go messages, instead of go_package
+}
+
+// ordered is a YAML mapping that keeps its keys in document order, so the
+// generated types and functions follow the spec.
+type ordered[T any] struct {
+	keys   []string
+	values map[string]T
+}
+
+func (o *ordered[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	o.values = make(map[string]T)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		key := node.Content[i].Value
+		o.keys = append(o.keys, key)
+		o.values[key] = value
+	}
+	return nil
+}
+
+// spec is the part of an OpenAPI 3 document the generator reads.
+type spec struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL       string `yaml:"url"`
+		Variables map[string]struct {
+			Default string `yaml:"default"`
+		} `yaml:"variables"`
+	} `yaml:"servers"`
+	Paths      ordered[pathItem] `yaml:"paths"`
+	Components struct {
+		Schemas       ordered[*schema]        `yaml:"schemas"`
+		Parameters    map[string]*parameter   `yaml:"parameters"`
+		RequestBodies map[string]*requestBody `yaml:"requestBodies"`
+		Responses     map[string]*response    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Put        *operation   `yaml:"put"`
+	Post       *operation   `yaml:"post"`
+	Delete     *operation   `yaml:"delete"`
+	Options    *operation   `yaml:"options"`
+	Head       *operation   `yaml:"head"`
+	Patch      *operation   `yaml:"patch"`
+	Trace      *operation   `yaml:"trace"`
+}
+
+type operation struct {
+	OperationID string             `yaml:"operationId"`
+	Summary     string             `yaml:"summary"`
+	Description string             `yaml:"description"`
+	Deprecated  bool               `yaml:"deprecated"`
+	Parameters  []*parameter       `yaml:"parameters"`
+	RequestBody *requestBody       `yaml:"requestBody"`
+	Responses   ordered[*response] `yaml:"responses"`
+}
+
+type parameter struct {
+	Ref         string  `yaml:"$ref"`
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Schema      *schema `yaml:"schema"`
+}
+
+type requestBody struct {
+	Ref      string              `yaml:"$ref"`
+	Required bool                `yaml:"required"`
+	Content  ordered[*mediaType] `yaml:"content"`
+}
+
+type response struct {
+	Ref     string              `yaml:"$ref"`
+	Content ordered[*mediaType] `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref                  string           `yaml:"$ref"`
+	Type                 schemaType       `yaml:"type"`
+	Format               string           `yaml:"format"`
+	Description          string           `yaml:"description"`
+	Properties           ordered[*schema] `yaml:"properties"`
+	Required             []string         `yaml:"required"`
+	Items                *schema          `yaml:"items"`
+	AdditionalProperties *additional      `yaml:"additionalProperties"`
+	Enum                 []any            `yaml:"enum"`
+	AllOf                []*schema        `yaml:"allOf"`
+	OneOf                []*schema        `yaml:"oneOf"`
+	AnyOf                []*schema        `yaml:"anyOf"`
+}
+
+// schemaType is a schema's type: a name, or in OpenAPI 3.1 a list such as
+// [string, "null"], of which the first type other than null is kept.
+type schemaType string
+
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = schemaType(node.Value)
+		return nil
+	}
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name != "null" {
+			*t = schemaType(name)
+			break
+		}
+	}
+	return nil
+}
+
+// additional is additionalProperties: true, false or a schema for the values.
+type additional struct {
+	schema *schema
+}
+
+func (a *additional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return nil
+	}
+	a.schema = new(schema)
+	return node.Decode(a.schema)
+}
+
+// typeKind is what a declared type is, which decides its zero value.
+type typeKind int
+
+const (
+	kindStruct typeKind = iota
+	kindEnum
+	kindAlias
+)
+
+// declared is a type the generated file declares.
+type declared struct {
+	kind       typeKind
+	underlying string // kindAlias: the type it names
+	firstValue string // kindEnum: its first value constant
+}
+
+// openAPIGen generates one file. Functions are written before the file is
+// assembled, since inline schemas in their requests and responses add
+// types; the file then lists the types above them.
+type openAPIGen struct {
+	spec     *spec
+	types    []string // declarations, in order
+	funcs    strings.Builder
+	taken    map[string]bool      // top-level names in use
+	schemas  map[string]string    // component schema → its type name
+	declared map[string]*declared // type name → what it is
+	imports  map[string]bool
+	err      error
+}
+
+// OpenAPI returns a Kukicha file for an OpenAPI 3 document, in YAML or JSON:
+// a type per component schema (structs with json aliases, string enums as
+// enums), a Client holding the server URL and headers, and a function per
+// operation that sends its parameters and decodes the success response.
+func OpenAPI(data []byte, opts Options) (string, error) {
+	s := new(spec)
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return "", fmt.Errorf("reading the spec: %w", err)
+	}
+	if s.Swagger != "" {
+		return "", fmt.Errorf("swagger %s documents are not supported; convert the spec to OpenAPI 3", s.Swagger)
+	}
+	if !strings.HasPrefix(s.OpenAPI, "3.") {
+		return "", fmt.Errorf("not an OpenAPI 3 document (openapi: %q)", s.OpenAPI)
+	}
+
+	g := &openAPIGen{
+		spec:     s,
+		taken:    map[string]bool{"Client": true, "NewClient": true},
+		schemas:  make(map[string]string),
+		declared: make(map[string]*declared),
+		imports:  map[string]bool{"net/http": true, "stdlib/fetch": true},
+	}
+	for _, name := range s.Components.Schemas.keys {
+		g.schemas[name] = g.claim(pascal(name, "Type"))
+	}
+	for _, name := range s.Components.Schemas.keys {
+		g.declare(g.schemas[name], s.Components.Schemas.values[name])
+	}
+	for _, path := range s.Paths.keys {
+		item := s.Paths.values[path]
+		for _, op := range item.operations() {
+			g.writeOperation(path, op.method, op.op, item.Parameters)
+		}
+	}
+	if g.err != nil {
+		return "", g.err
+	}
+	return g.file(opts), nil
+}
+
+// file assembles the generated source.
+func (g *openAPIGen) file(opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by kukicha gen openapi from %s; do not edit.\n", opts.Source)
+	if title := strings.TrimSpace(g.spec.Info.Title + " " + g.spec.Info.Version); title != "" {
+		fmt.Fprintf(&b, "# %s\n", title)
+	}
+	fmt.Fprintf(&b, "\npetiole %s\n\n", opts.Package)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	slices.Sort(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&b, "import %q\n", path)
+	}
+
+	b.WriteString("\n# Client holds what every call sends: the server URL and headers, such as\n")
+	b.WriteString("# Authorization.\ntype Client\n    BaseURL string\n    Headers map of string to string\n\n")
+	b.WriteString("# NewClient returns a Client for the spec's first server.\n")
+	fmt.Fprintf(&b, "func NewClient() Client\n    return Client{BaseURL: %s, Headers: empty map of string to string}\n", quote(g.serverURL()))
+	for _, decl := range g.types {
+		b.WriteString(decl)
+	}
+	b.WriteString(`
+# call sends a request with the client's headers and the operation's, and
+# returns the response when its status is not an error.
+func call(c Client, method string, url string, headers map of string to string, body any) (reference http.Response, error)
+    req := fetch.New(url) |> fetch.Method(method)
+    for name, value in c.Headers
+        req = fetch.Header(req, name, value)
+    for name, value in headers
+        req = fetch.Header(req, name, value)
+    if body != empty
+        req = fetch.Body(req, body)
+    resp := fetch.Do(req) onerr return
+    return fetch.CheckStatus(resp)
+`)
+	b.WriteString(g.funcs.String())
+	return b.String()
+}
+
+// serverURL returns the first server's URL with its variables at their
+// defaults, or "" when the spec names none.
+func (g *openAPIGen) serverURL() string {
+	if len(g.spec.Servers) == 0 {
+		return ""
+	}
+	server := g.spec.Servers[0]
+	url := server.URL
+	for name, v := range server.Variables {
+		url = strings.ReplaceAll(url, "{"+name+"}", v.Default)
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// claim reserves a top-level name, numbering it when it is taken.
+func (g *openAPIGen) claim(name string) string {
+	unique := name
+	for i := 2; g.taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.taken[unique] = true
+	return unique
+}
+
+func (g *openAPIGen) fail(format string, args ...any) {
+	if g.err == nil {
+		g.err = fmt.Errorf(format, args...)
+	}
+}
+
+// schemaRef returns the type name of a #/components/schemas/ reference.
+func (g *openAPIGen) schemaRef(ref string) string {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		g.fail("unsupported reference %q (only #/components/schemas/ references are followed)", ref)
+		return "any"
+	}
+	typeName, ok := g.schemas[name]
+	if !ok {
+		g.fail("reference %q names no schema", ref)
+		return "any"
+	}
+	return typeName
+}
+
+// declare adds the declaration of type name for s. Its slot is taken
+// before the declaration is written, so the types an inline schema adds
+// follow the type that uses them.
+func (g *openAPIGen) declare(name string, s *schema) {
+	slot := len(g.types)
+	g.types = append(g.types, "")
+	g.types[slot] = g.declareSchema(name, s)
+}
+
+// declareSchema returns the declaration of type name for s: a struct for an
+// object with properties, an enum for a string enum, else a named type.
+func (g *openAPIGen) declareSchema(name string, s *schema) string {
+	if s == nil {
+		s = new(schema)
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	writeDoc(&b, "", s.Description)
+	switch {
+	case s.isObject():
+		g.declared[name] = &declared{kind: kindStruct}
+		fmt.Fprintf(&b, "type %s\n", name)
+		g.writeFields(&b, name, s)
+	case s.isStringEnum():
+		fmt.Fprintf(&b, "type %s string distinct\n", name)
+		first := ""
+		for i, v := range s.Enum {
+			value := v.(string)
+			suffix := joinWords(value)
+			if suffix == "" {
+				suffix = fmt.Sprintf("Value%d", i+1)
+			}
+			constant := g.claim(name + suffix)
+			if first == "" {
+				first = constant
+			}
+			fmt.Fprintf(&b, "    %s %s\n", constant, quote(value))
+		}
+		g.declared[name] = &declared{kind: kindEnum, firstValue: first}
+	default:
+		underlying := g.typeOf(s, name+"Value")
+		g.declared[name] = &declared{kind: kindAlias, underlying: underlying}
+		fmt.Fprintf(&b, "type %s %s\n", name, underlying)
+	}
+	return b.String()
+}
+
+// writeFields writes the fields of struct type name: one per property (and
+// per property of each allOf part), aliased to the property name and
+// omitted from requests when empty unless required.
+func (g *openAPIGen) writeFields(b *strings.Builder, name string, s *schema) {
+	props, required := s.flatten(g)
+	fieldNames := make(map[string]bool)
+	for _, prop := range props.keys {
+		field := pascal(prop, "Field")
+		for i := 2; fieldNames[field]; i++ {
+			field = fmt.Sprintf("%s%d", pascal(prop, "Field"), i)
+		}
+		fieldNames[field] = true
+		typ := g.typeOf(props.values[prop], name+pascal(prop, "Field"))
+		if p := props.values[prop]; p != nil {
+			writeDoc(b, "    ", p.Description)
+		}
+		if slices.Contains(required, prop) {
+			fmt.Fprintf(b, "    %s %s as %s\n", field, typ, quote(prop))
+		} else {
+			fmt.Fprintf(b, "    %s %s json:%s\n", field, typ, quote(prop+",omitempty"))
+		}
+	}
+	if len(props.keys) == 0 {
+		// A struct needs a field; keep the object's content as a map
+		b.WriteString("    Fields map of string to any json:\"-\"\n")
+	}
+}
+
+// flatten returns the properties of an object schema and its allOf parts,
+// in order, and the names of the required ones.
+func (s *schema) flatten(g *openAPIGen) (ordered[*schema], []string) {
+	props := ordered[*schema]{values: make(map[string]*schema)}
+	var required []string
+	var add func(s *schema, depth int)
+	add = func(s *schema, depth int) {
+		if s == nil || depth > 8 {
+			return
+		}
+		if s.Ref != "" {
+			name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+			if !ok {
+				g.fail("unsupported reference %q (only #/components/schemas/ references are followed)", s.Ref)
+				return
+			}
+			add(g.spec.Components.Schemas.values[name], depth+1)
+			return
+		}
+		for _, part := range s.AllOf {
+			add(part, depth+1)
+		}
+		for _, key := range s.Properties.keys {
+			if _, ok := props.values[key]; !ok {
+				props.keys = append(props.keys, key)
+			}
+			props.values[key] = s.Properties.values[key]
+		}
+		required = append(required, s.Required...)
+	}
+	add(s, 0)
+	return props, required
+}
+
+func (s *schema) isObject() bool {
+	return len(s.Properties.keys) > 0 || len(s.AllOf) > 1
+}
+
+func (s *schema) isStringEnum() bool {
+	if len(s.Enum) == 0 || (s.Type != "" && s.Type != "string") {
+		return false
+	}
+	for _, v := range s.Enum {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// typeOf returns the Kukicha type for s. An inline object or string enum
+// becomes a type of its own named hint.
+func (g *openAPIGen) typeOf(s *schema, hint string) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return g.schemaRef(s.Ref)
+	}
+	if len(s.AllOf) == 1 && len(s.Properties.keys) == 0 {
+		return g.typeOf(s.AllOf[0], hint)
+	}
+	if s.isObject() || s.isStringEnum() {
+		name := g.claim(hint)
+		g.declare(name, s)
+		return name
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return "any"
+	}
+	switch s.Type {
+	case "string":
+		if s.Format == "byte" {
+			return "list of byte" // encoding/json reads and writes base64
+		}
+		return "string"
+	case "integer":
+		switch s.Format {
+		case "int32", "int64":
+			return s.Format
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "list of " + g.typeOf(s.Items, hint+"Item")
+	case "object":
+		if s.AdditionalProperties != nil && s.AdditionalProperties.schema != nil {
+			return "map of string to " + g.typeOf(s.AdditionalProperties.schema, hint+"Value")
+		}
+		return "map of string to any"
+	}
+	return "any"
+}
+
+// zeroOf returns an expression for the zero value of typ, which fetch.Json
+// reads the response into.
+func (g *openAPIGen) zeroOf(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "list of "), strings.HasPrefix(typ, "map of "):
+		return "empty " + typ
+	}
+	switch typ {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	case "int":
+		return "0"
+	case "int32", "int64", "float32", "float64":
+		return "0 as " + typ
+	case "any":
+		return "empty as any"
+	}
+	d := g.declared[typ]
+	switch {
+	case d == nil || d.kind == kindStruct:
+		return typ + "{}"
+	case d.kind == kindEnum:
+		return d.firstValue
+	case strings.HasPrefix(d.underlying, "list of ") || strings.HasPrefix(d.underlying, "map of "):
+		return typ + "{}"
+	}
+	return g.zeroOf(d.underlying) + " as " + typ
+}
+
+type methodOp struct {
+	method string
+	op     *operation
+}
+
+// operations returns the item's operations in a fixed method order.
+func (item pathItem) operations() []methodOp {
+	var ops []methodOp
+	for _, m := range []methodOp{
+		{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete},
+		{"OPTIONS", item.Options}, {"HEAD", item.Head}, {"PATCH", item.Patch}, {"TRACE", item.Trace},
+	} {
+		if m.op != nil {
+			ops = append(ops, m)
+		}
+	}
+	return ops
+}
+
+// callParam is one parameter of an operation function.
+type callParam struct {
+	name     string // Kukicha parameter name
+	typ      string
+	wire     string // name in the path, query or header
+	in       string
+	required bool
+}
+
+// localNames are the names the operation functions use for themselves;
+// parameters get another name.
+var localNames = map[string]bool{
+	"c": true, "url": true, "query": true, "headers": true, "resp": true, "body": true,
+	"fetch": true, "http": true, "strings": true, "call": true,
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// writeOperation writes the function for one operation.
+func (g *openAPIGen) writeOperation(path, method string, op *operation, shared []*parameter) {
+	name := op.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + " " + pathParam.ReplaceAllString(path, "by $1")
+	}
+	name = g.claim(pascal(name, "Call"))
+
+	// Operation parameters override path-level ones with the same name and location
+	var params []*parameter
+	for _, p := range append(slices.Clone(shared), op.Parameters...) {
+		p = g.resolveParameter(p)
+		if p == nil || p.In == "cookie" {
+			continue
+		}
+		params = slices.DeleteFunc(params, func(q *parameter) bool { return q.Name == p.Name && q.In == p.In })
+		params = append(params, p)
+	}
+	// Path parameters come first, in the order the path uses them
+	var ordered []*parameter
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		for _, p := range params {
+			if p.In == "path" && p.Name == m[1] {
+				ordered = append(ordered, p)
+			}
+		}
+	}
+	for _, p := range params {
+		if p.In != "path" {
+			ordered = append(ordered, p)
+		}
+	}
+
+	used := make(map[string]bool)
+	var args []callParam
+	var signature []string
+	for _, p := range ordered {
+		arg := callParam{
+			name:     paramName(p.Name, used),
+			typ:      g.typeOf(p.Schema, name+pascal(p.Name, "Param")),
+			wire:     p.Name,
+			in:       p.In,
+			required: p.Required || p.In == "path",
+		}
+		args = append(args, arg)
+		signature = append(signature, arg.name+" "+arg.typ)
+	}
+	bodyArg := "empty"
+	if body := g.resolveRequestBody(op.RequestBody); body != nil {
+		if media := jsonMedia(body.Content); media != nil {
+			signature = append(signature, "body "+g.typeOf(media.Schema, name+"Request"))
+			bodyArg = "body"
+		}
+	}
+
+	result, decode := g.responseOf(name, op)
+	returns := "error"
+	if result != "" {
+		returns = "(" + result + ", error)"
+	}
+
+	b := &g.funcs
+	b.WriteString("\n")
+	fmt.Fprintf(b, "# %s calls %s %s.\n", name, method, path)
+	writeDoc(b, "", strings.TrimSpace(op.Summary+"\n\n"+op.Description))
+	if op.Deprecated {
+		b.WriteString("# Deprecated: the API marks this operation as deprecated.\n")
+	}
+	fmt.Fprintf(b, "func %s(%s) %s\n", name, strings.Join(append([]string{"c Client"}, signature...), ", "), returns)
+	fmt.Fprintf(b, "    url := c.BaseURL + %s\n", g.pathExpr(path, args))
+
+	query := filterParams(args, "query")
+	if len(query) > 0 {
+		b.WriteString("    query := empty map of string to string\n")
+		for _, arg := range query {
+			g.writeSet(b, "query", arg)
+		}
+		b.WriteString("    url = fetch.URLWithQuery(url, query) onerr return\n")
+	}
+	headersArg := "empty"
+	if headers := filterParams(args, "header"); len(headers) > 0 {
+		b.WriteString("    headers := empty map of string to string\n")
+		for _, arg := range headers {
+			g.writeSet(b, "headers", arg)
+		}
+		headersArg = "headers"
+	}
+	fmt.Fprintf(b, "    resp := call(c, %q, url, %s, %s) onerr return\n", method, headersArg, bodyArg)
+	switch decode {
+	case "json":
+		fmt.Fprintf(b, "    return fetch.Json(resp, %s)\n", g.zeroOf(result))
+	case "text":
+		b.WriteString("    return fetch.Text(resp)\n")
+	default:
+		b.WriteString("    return resp.Body.Close()\n")
+	}
+}
+
+// pathExpr returns the expression for the path, its parameters escaped.
+func (g *openAPIGen) pathExpr(path string, args []callParam) string {
+	var parts []string
+	rest := path
+	for {
+		loc := pathParam.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			break
+		}
+		if loc[0] > 0 {
+			parts = append(parts, quote(rest[:loc[0]]))
+		}
+		wire := rest[loc[2]:loc[3]]
+		value := quote(rest[loc[0]:loc[1]]) // a parameter the operation doesn't declare stays as written
+		for _, arg := range args {
+			if arg.in == "path" && arg.wire == wire {
+				value = "fetch.PathEscape(" + g.stringOf(arg) + ")"
+			}
+		}
+		parts = append(parts, value)
+		rest = rest[loc[1]:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, quote(rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// writeSet writes the line adding a query or header parameter to the map,
+// inside an if that skips an optional one left empty.
+func (g *openAPIGen) writeSet(b *strings.Builder, mapName string, arg callParam) {
+	indent := "    "
+	if !arg.required {
+		if cond := g.setCondition(arg); cond != "" {
+			fmt.Fprintf(b, "    if %s\n", cond)
+			indent += "    "
+		}
+	}
+	fmt.Fprintf(b, "%s%s[%s] = %s\n", indent, mapName, quote(arg.wire), g.stringOf(arg))
+}
+
+// setCondition returns the test that an optional parameter was given, or ""
+// when its zero value can't be told apart.
+func (g *openAPIGen) setCondition(arg callParam) string {
+	typ := arg.typ
+	if d := g.declared[typ]; d != nil && d.kind == kindAlias {
+		typ = d.underlying
+	}
+	switch {
+	case strings.HasPrefix(typ, "list of ") || strings.HasPrefix(typ, "map of "):
+		return "len(" + arg.name + ") > 0"
+	case g.declared[typ] != nil && g.declared[typ].kind == kindEnum:
+		return arg.name + ` as string != ""` // an enum compares only with its values
+	case typ == "string":
+		return arg.name + ` != ""`
+	case typ == "bool":
+		return arg.name
+	case typ == "int", typ == "int32", typ == "int64", typ == "float32", typ == "float64":
+		return arg.name + " != 0"
+	}
+	return ""
+}
+
+// stringOf returns arg's value as a string: as is for a string, joined
+// with commas for a list of strings, else interpolated.
+func (g *openAPIGen) stringOf(arg callParam) string {
+	switch arg.typ {
+	case "string":
+		return arg.name
+	case "list of string":
+		g.imports["strings"] = true
+		return "strings.Join(" + arg.name + `, ",")`
+	}
+	return `"{` + arg.name + `}"`
+}
+
+// responseOf returns the result type of an operation and how to read it:
+// "json", "text" or "" when the success response has no body.
+func (g *openAPIGen) responseOf(name string, op *operation) (string, string) {
+	var success *response
+	for _, code := range op.Responses.keys {
+		if strings.HasPrefix(code, "2") {
+			success = op.Responses.values[code]
+			break
+		}
+	}
+	if success == nil {
+		success = op.Responses.values["default"]
+	}
+	success = g.resolveResponse(success)
+	if success == nil || len(success.Content.keys) == 0 {
+		return "", ""
+	}
+	if media := jsonMedia(success.Content); media != nil {
+		return g.typeOf(media.Schema, name+"Response"), "json"
+	}
+	return "string", "text"
+}
+
+// jsonMedia returns the JSON media type of a content map: application/json,
+// another +json type, or nil.
+func jsonMedia(content ordered[*mediaType]) *mediaType {
+	for _, key := range content.keys {
+		mediaName, _, _ := strings.Cut(key, ";")
+		if mediaName == "application/json" || strings.HasSuffix(mediaName, "+json") || mediaName == "*/*" {
+			if m := content.values[key]; m != nil {
+				return m
+			}
+			return new(mediaType)
+		}
+	}
+	return nil
+}
+
+func (g *openAPIGen) resolveParameter(p *parameter) *parameter {
+	if p == nil || p.Ref == "" {
+		return p
+	}
+	name, _ := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if resolved, ok := g.spec.Components.Parameters[name]; ok {
+		return resolved
+	}
+	g.fail("reference %q names no parameter", p.Ref)
+	return nil
+}
+
+func (g *openAPIGen) resolveRequestBody(r *requestBody) *requestBody {
+	if r == nil || r.Ref == "" {
+		return r
+	}
+	name, _ := strings.CutPrefix(r.Ref, "#/components/requestBodies/")
+	if resolved, ok := g.spec.Components.RequestBodies[name]; ok {
+		return resolved
+	}
+	g.fail("reference %q names no request body", r.Ref)
+	return nil
+}
+
+func (g *openAPIGen) resolveResponse(r *response) *response {
+	if r == nil || r.Ref == "" {
+		return r
+	}
+	name, _ := strings.CutPrefix(r.Ref, "#/components/responses/")
+	if resolved, ok := g.spec.Components.Responses[name]; ok {
+		return resolved
+	}
+	g.fail("reference %q names no response", r.Ref)
+	return nil
+}
+
+func filterParams(args []callParam, in string) []callParam {
+	var out []callParam
+	for _, arg := range args {
+		if arg.in == in {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package apigen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+const petstore = `openapi: "3.0.3"
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1/
+    variables:
+      region:
+        default: eu
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer, format: int32}
+        - name: status
+          in: query
+          schema: {$ref: '#/components/schemas/Status'}
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema: {type: string}
+      responses:
+        '200':
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pets'}
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        '201':
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+  /pets/{petId}:
+    parameters:
+      - {name: petId, in: path, required: true, schema: {type: integer, format: int64}}
+    delete:
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id: {type: integer, format: int64}
+        type:
+          type: string
+          description: Species
+        owner:
+          type: object
+          properties:
+            email: {type: string}
+    Pets:
+      type: array
+      items: {$ref: '#/components/schemas/Pet'}
+`
+
+func TestOpenAPI(t *testing.T) {
+	out, err := OpenAPI([]byte(petstore), Options{Package: "petstore", Source: "petstore.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Generated by kukicha gen openapi from petstore.yaml; do not edit.\n# Petstore 1.0.0\n\npetiole petstore\n",
+		`return Client{BaseURL: "https://eu.example.com/v1", Headers: empty map of string to string}`,
+		"type Status string distinct\n    StatusAvailable \"available\"\n    StatusSold \"sold\"\n",
+		"type Pet\n    ID int64 as \"id\"\n    # Species\n    Type string json:\"type,omitempty\"\n    Owner PetOwner json:\"owner,omitempty\"\n\ntype PetOwner\n",
+		"type Pets list of Pet\n",
+		"type CreatePetRequest\n    Name string as \"name\"\n",
+		"# ListPets calls GET /pets.\n# List all pets\nfunc ListPets(c Client, limit int32, status Status, xRequestID string) (Pets, error)\n",
+		"    if status as string != \"\"\n        query[\"status\"] = \"{status}\"\n",
+		"    headers[\"X-Request-ID\"] = xRequestID\n",
+		"    resp := call(c, \"GET\", url, headers, empty) onerr return\n    return fetch.Json(resp, Pets{})\n",
+		"func CreatePet(c Client, body CreatePetRequest) (Pet, error)\n",
+		"func DeletePetsByPetID(c Client, petID int64) error\n    url := c.BaseURL + \"/pets/\" + fetch.PathEscape(\"{petID}\")\n",
+		"    return resp.Body.Close()\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	p, err := parser.New(out, "petstore.kuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, parseErrs := p.Parse()
+	if len(parseErrs) > 0 {
+		t.Fatalf("parse errors: %v\n%s", parseErrs, out)
+	}
+	analyzer := semantic.NewWithFile(program, "petstore.kuki")
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v\n%s", errs, out)
+	}
+}
+
+func TestOpenAPIErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{"swagger 2", "swagger: \"2.0\"\n", "swagger 2.0 documents are not supported"},
+		{"not openapi", "title: x\n", "not an OpenAPI 3 document"},
+		{"missing schema", "openapi: 3.1.0\ncomponents:\n  schemas:\n    A:\n      type: array\n      items: {$ref: '#/components/schemas/B'}\n", `reference "#/components/schemas/B" names no schema`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := OpenAPI([]byte(tt.spec), Options{Package: "api"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	for in, want := range map[string]string{
+		"petId": "PetID", "X-Rate-Limit": "XRateLimit", "HTTPServer": "HTTPServer",
+		"phone_numbers": "PhoneNumbers", "3d": "Type3d", "": "Type",
+	} {
+		if got := pascal(in, "Type"); got != want {
+			t.Errorf("pascal(%q) = %q, want %q", in, got, want)
+		}
+	}
+	used := map[string]bool{}
+	for _, tt := range []struct{ wire, want string }{
+		{"petId", "petID"}, {"ID", "id"}, {"type", "typeParam"}, {"url", "urlParam"}, {"pet_id", "petID2"},
+	} {
+		if got := paramName(tt.wire, used); got != tt.want {
+			t.Errorf("paramName(%q) = %q, want %q", tt.wire, got, tt.want)
+		}
+	}
+}
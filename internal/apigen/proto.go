@@ -0,0 +1,979 @@
+package apigen
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// protoFile is the part of a proto3 file the generator reads.
+type protoFile struct {
+	pkg       string
+	goPackage string
+	messages  []*protoMessage // every message, nested ones after their parent
+	enums     []*protoEnum    // every enum, in the same order
+	services  []*protoService
+}
+
+type protoMessage struct {
+	fullName string // package.Outer.Inner
+	goName   string // Outer_Inner, as protoc-gen-go names it
+	doc      string
+	fields   []*protoField
+	oneofs   []string // oneof names, in order
+}
+
+type protoField struct {
+	name    string
+	typ     string // scalar name or type reference, as written
+	keyType string // for map<K, V> fields: K (typ is V)
+	label   string // "repeated", "optional" or ""
+	oneof   string // the oneof the field belongs to, if any
+	doc     string
+	line    int
+}
+
+type protoEnum struct {
+	fullName string
+	goName   string
+	doc      string
+	values   []string
+}
+
+type protoService struct {
+	name    string
+	doc     string
+	methods []*protoRPC
+}
+
+type protoRPC struct {
+	name         string
+	input        string
+	output       string
+	clientStream bool
+	serverStream bool
+	doc          string
+	line         int
+}
+
+// protoToken is a word, string literal or punctuation mark of a .proto
+// file, with the comment lines directly above it.
+type protoToken struct {
+	text string
+	str  bool // a string literal (text is its value)
+	line int
+	doc  string
+}
+
+// tokenizeProto splits a .proto file into tokens. A run of // comments on
+// the lines right above a token is kept as its doc; comments after a token
+// on the same line and /* */ comments are dropped.
+func tokenizeProto(src string) ([]protoToken, error) {
+	var toks []protoToken
+	var doc []string
+	line, docLine, tokenLine := 1, 0, 0
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			text := strings.TrimSpace(strings.TrimLeft(src[i:i+end], "/"))
+			if tokenLine != line {
+				if docLine != line-1 {
+					doc = nil
+				}
+				doc = append(doc, text)
+				docLine = line
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			raw := src[i+1 : j]
+			value, err := strconv.Unquote(`"` + strings.ReplaceAll(raw, `"`, `\"`) + `"`)
+			if err != nil {
+				value = raw
+			}
+			toks = append(toks, protoToken{text: value, str: true, line: line})
+			tokenLine = line
+			i = j + 1
+		case isProtoWordByte(c):
+			j := i
+			for j < len(src) && isProtoWordByte(src[j]) {
+				j++
+			}
+			tok := protoToken{text: src[i:j], line: line}
+			if docLine == line-1 {
+				tok.doc = strings.Join(doc, "\n")
+			}
+			toks = append(toks, tok)
+			doc, tokenLine = nil, line
+			i = j
+		default:
+			toks = append(toks, protoToken{text: string(c), line: line})
+			tokenLine = line
+			i++
+		}
+	}
+	return toks, nil
+}
+
+func isProtoWordByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// protoParser reads the declarations of a proto3 file. Options other than
+// go_package, reserved ranges, extensions and imports are skipped.
+type protoParser struct {
+	toks []protoToken
+	pos  int
+	file *protoFile
+	err  error
+}
+
+func parseProto(src string) (*protoFile, error) {
+	toks, err := tokenizeProto(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &protoParser{toks: toks, file: &protoFile{}}
+	if p.peek() != "syntax" && p.peek() != "edition" {
+		return nil, fmt.Errorf("the file has no syntax = \"proto3\"; proto2 files are not supported")
+	}
+	keyword := p.next().text
+	p.expect("=")
+	if syntax := p.next(); keyword != "syntax" || syntax.text != "proto3" {
+		return nil, fmt.Errorf("line %d: %s = %q files are not supported; only proto3 is", syntax.line, keyword, syntax.text)
+	}
+	p.expect(";")
+	for p.err == nil && p.pos < len(p.toks) {
+		tok := p.next()
+		switch tok.text {
+		case "package":
+			p.file.pkg = p.next().text
+			p.expect(";")
+		case "option":
+			name := p.next().text
+			p.expect("=")
+			value := p.next()
+			if name == "go_package" && value.str {
+				p.file.goPackage, _, _ = strings.Cut(value.text, ";")
+			}
+			p.skipStatement()
+		case "message":
+			p.parseMessage(p.file.pkg, "", tok.doc)
+		case "enum":
+			p.parseEnum(p.file.pkg, "", tok.doc)
+		case "service":
+			p.parseService(tok.doc)
+		case ";":
+		default:
+			// import, extend and anything newer
+			p.skipStatement()
+		}
+	}
+	return p.file, p.err
+}
+
+func (p *protoParser) next() protoToken {
+	if p.pos >= len(p.toks) {
+		p.fail("unexpected end of file")
+		return protoToken{line: p.lastLine()}
+	}
+	tok := p.toks[p.pos]
+	p.pos++
+	return tok
+}
+
+func (p *protoParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos].text
+}
+
+func (p *protoParser) expect(text string) {
+	if tok := p.next(); tok.text != text && p.err == nil {
+		p.err = fmt.Errorf("line %d: expected %q, found %q", tok.line, text, tok.text)
+	}
+}
+
+func (p *protoParser) fail(msg string) {
+	if p.err == nil {
+		p.err = fmt.Errorf("line %d: %s", p.lastLine(), msg)
+	}
+}
+
+func (p *protoParser) lastLine() int {
+	if len(p.toks) == 0 {
+		return 1
+	}
+	return p.toks[min(p.pos, len(p.toks))-1].line
+}
+
+// skipStatement skips to the end of the current statement: past its ";" or
+// its { } block.
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for p.err == nil {
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			if depth--; depth <= 0 {
+				return
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseMessage reads a message body after the keyword. scope is the full
+// name of the enclosing package or message, goScope its Go name.
+func (p *protoParser) parseMessage(scope, goScope, doc string) {
+	m := &protoMessage{doc: doc}
+	m.fullName, m.goName = nestedNames(scope, goScope, p.next().text)
+	p.file.messages = append(p.file.messages, m)
+	p.expect("{")
+	p.parseFields(m, "")
+}
+
+// parseFields reads the fields and nested declarations of m up to the
+// closing brace, marking them as members of oneof when it is not "".
+func (p *protoParser) parseFields(m *protoMessage, oneof string) {
+	for p.err == nil && p.peek() != "}" {
+		tok := p.next()
+		switch tok.text {
+		case "message":
+			p.parseMessage(m.fullName, m.goName, tok.doc)
+		case "enum":
+			p.parseEnum(m.fullName, m.goName, tok.doc)
+		case "oneof":
+			name := p.next().text
+			m.oneofs = append(m.oneofs, name)
+			p.expect("{")
+			p.parseFields(m, name)
+		case "option", "reserved", "extensions", "extend":
+			p.skipStatement()
+		case ";":
+		case "required", "group":
+			p.fail(fmt.Sprintf("%s fields are proto2; only proto3 is supported", tok.text))
+		default:
+			p.parseField(m, oneof, tok)
+		}
+	}
+	p.expect("}")
+}
+
+// parseField reads a field declaration that starts with tok.
+func (p *protoParser) parseField(m *protoMessage, oneof string, tok protoToken) {
+	f := &protoField{oneof: oneof, doc: tok.doc, line: tok.line}
+	if tok.text == "repeated" || tok.text == "optional" {
+		f.label = tok.text
+		tok = p.next()
+	}
+	f.typ = tok.text
+	if f.typ == "map" {
+		p.expect("<")
+		f.keyType = p.next().text
+		p.expect(",")
+		f.typ = p.next().text
+		p.expect(">")
+	}
+	f.name = p.next().text
+	p.expect("=")
+	p.next()
+	if p.peek() == "[" {
+		for p.err == nil && p.next().text != "]" {
+		}
+	}
+	p.expect(";")
+	m.fields = append(m.fields, f)
+}
+
+// parseEnum reads an enum body after the keyword.
+func (p *protoParser) parseEnum(scope, goScope, doc string) {
+	e := &protoEnum{doc: doc}
+	e.fullName, e.goName = nestedNames(scope, goScope, p.next().text)
+	p.file.enums = append(p.file.enums, e)
+	p.expect("{")
+	for p.err == nil && p.peek() != "}" {
+		tok := p.next()
+		switch tok.text {
+		case "option", "reserved":
+			p.skipStatement()
+		case ";":
+		default:
+			e.values = append(e.values, tok.text)
+			p.skipStatement()
+		}
+	}
+	p.expect("}")
+}
+
+// parseService reads a service body after the keyword.
+func (p *protoParser) parseService(doc string) {
+	s := &protoService{name: p.next().text, doc: doc}
+	p.file.services = append(p.file.services, s)
+	p.expect("{")
+	for p.err == nil && p.peek() != "}" {
+		tok := p.next()
+		if tok.text != "rpc" {
+			p.skipStatement()
+			continue
+		}
+		r := &protoRPC{name: p.next().text, doc: tok.doc, line: tok.line}
+		p.expect("(")
+		if p.peek() == "stream" {
+			r.clientStream = true
+			p.next()
+		}
+		r.input = p.next().text
+		p.expect(")")
+		p.expect("returns")
+		p.expect("(")
+		if p.peek() == "stream" {
+			r.serverStream = true
+			p.next()
+		}
+		r.output = p.next().text
+		p.expect(")")
+		if p.peek() == "{" {
+			p.skipStatement()
+		} else {
+			p.expect(";")
+		}
+		s.methods = append(s.methods, r)
+	}
+	p.expect("}")
+}
+
+// nestedNames returns the full and Go names of a declaration called name
+// inside scope (full name) and goScope (its Go name, "" at the top level).
+func nestedNames(scope, goScope, name string) (string, string) {
+	full := name
+	if scope != "" {
+		full = scope + "." + name
+	}
+	if goScope == "" {
+		return full, goCamelCase(name)
+	}
+	return full, goCamelCase(goScope + "." + name)
+}
+
+// goCamelCase converts a proto name to the Go name protoc-gen-go gives it:
+// "user_id" is UserId, "Outer.Inner" is Outer_Inner.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// ".x" joins the words
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// "_x" joins the words
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+// protoReserved are the Go field names protoc-gen-go suffixes with "_",
+// since generated methods take them.
+var protoReserved = map[string]bool{
+	"Reset": true, "String": true, "ProtoMessage": true, "Marshal": true, "Unmarshal": true,
+	"ExtensionRangeArray": true, "ExtensionMap": true, "Descriptor": true,
+}
+
+// goFieldName returns the Go name of a message field.
+func goFieldName(name string) string {
+	goName := goCamelCase(name)
+	if protoReserved[goName] {
+		goName += "_"
+	}
+	return goName
+}
+
+// protoScalars are the Kukicha types of the proto3 scalar types.
+var protoScalars = map[string]string{
+	"double": "float64", "float": "float32", "int32": "int32", "int64": "int64",
+	"uint32": "uint32", "uint64": "uint64", "sint32": "int32", "sint64": "int64",
+	"fixed32": "uint32", "fixed64": "uint64", "sfixed32": "int32", "sfixed64": "int64",
+	"bool": "bool", "string": "string", "bytes": "list of byte",
+}
+
+// protoKind is what a field or rpc type refers to.
+type protoKind int
+
+const (
+	protoScalar protoKind = iota
+	protoEnumKind
+	protoMessageKind
+	protoEmpty     // a message without fields, or google.protobuf.Empty
+	protoTimestamp // google.protobuf.Timestamp
+	protoDuration  // google.protobuf.Duration
+)
+
+// protoType is a resolved type reference.
+type protoType struct {
+	kind   protoKind
+	name   string // protoScalar: the Kukicha type; else the Kukicha type name
+	goType string // the Go type of a message (pb.Outer_Inner, timestamppb.Timestamp) or enum
+}
+
+// protoGen generates the Kukicha file for one .proto file.
+type protoGen struct {
+	file     *protoFile
+	pbAlias  string
+	taken    map[string]bool
+	names    map[string]string // message or enum full name → Kukicha type name
+	messages map[string]*protoMessage
+	enums    map[string]*protoEnum
+	imports  map[string]string // import path → alias
+	types    strings.Builder
+	convs    strings.Builder
+	clients  strings.Builder
+	err      error
+}
+
+// Proto returns a Kukicha file for a proto3 file with at least one service:
+// a struct per message and an enum per enum, converted to and from the
+// messages protoc-gen-go generates, and per service a client type whose
+// methods make the unary and server-streaming calls. opts.GoPackage is the
+// Go import path of the generated messages, by default the file's
+// go_package option.
+func Proto(data []byte, opts Options) (string, error) {
+	f, err := parseProto(string(data))
+	if err != nil {
+		return "", err
+	}
+	if opts.GoPackage != "" {
+		f.goPackage = opts.GoPackage
+	}
+	if f.goPackage == "" {
+		return "", fmt.Errorf("the file has no go_package option; pass --go-package with the import path of its generated Go package")
+	}
+	if len(f.services) == 0 {
+		return "", fmt.Errorf("the file declares no services")
+	}
+
+	g := &protoGen{
+		file:     f,
+		pbAlias:  "pb",
+		taken:    make(map[string]bool),
+		names:    make(map[string]string),
+		messages: make(map[string]*protoMessage),
+		enums:    make(map[string]*protoEnum),
+		imports:  map[string]string{f.goPackage: "pb"},
+	}
+	for _, m := range f.messages {
+		g.messages[m.fullName] = m
+		if len(m.fields) > 0 {
+			g.names[m.fullName] = g.claim(typeName(f.pkg, m.fullName))
+		}
+	}
+	for _, e := range f.enums {
+		g.enums[e.fullName] = e
+		g.names[e.fullName] = g.claim(typeName(f.pkg, e.fullName))
+	}
+	for _, m := range f.messages {
+		if len(m.fields) > 0 {
+			g.writeMessage(m)
+		}
+	}
+	for _, e := range f.enums {
+		g.writeEnum(e)
+	}
+	for _, s := range f.services {
+		g.writeService(s)
+	}
+	if g.err != nil {
+		return "", g.err
+	}
+	return g.fileSource(opts), nil
+}
+
+// typeName returns the Kukicha name of a message or enum: its name within
+// the package, each part in PascalCase (Outer.Inner is OuterInner).
+func typeName(pkg, fullName string) string {
+	rel := strings.TrimPrefix(fullName, pkg+".")
+	var b strings.Builder
+	for part := range strings.SplitSeq(rel, ".") {
+		b.WriteString(pascal(part, "Type"))
+	}
+	return b.String()
+}
+
+// fileSource assembles the generated source.
+func (g *protoGen) fileSource(opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by kukicha gen proto from %s; do not edit.\n", opts.Source)
+	if g.file.pkg != "" {
+		fmt.Fprintf(&b, "# Package %s\n", g.file.pkg)
+	}
+	fmt.Fprintf(&b, "\npetiole %s\n\n", opts.Package)
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		if alias := g.imports[path]; alias != "" {
+			fmt.Fprintf(&b, "import %q as %s\n", path, alias)
+		} else {
+			fmt.Fprintf(&b, "import %q\n", path)
+		}
+	}
+	b.WriteString(g.types.String())
+	b.WriteString(g.convs.String())
+	b.WriteString(g.clients.String())
+	return b.String()
+}
+
+// claim reserves a top-level name, numbering it when it is taken.
+func (g *protoGen) claim(name string) string {
+	unique := name
+	for i := 2; g.taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.taken[unique] = true
+	return unique
+}
+
+func (g *protoGen) fail(format string, args ...any) {
+	if g.err == nil {
+		g.err = fmt.Errorf(format, args...)
+	}
+}
+
+// resolve returns the type a reference written in scope (a package or
+// message full name) names, searching from scope outward as protoc does.
+func (g *protoGen) resolve(ref, scope string, line int) protoType {
+	if kt, ok := protoScalars[ref]; ok {
+		return protoType{kind: protoScalar, name: kt}
+	}
+	var candidates []string
+	if full, ok := strings.CutPrefix(ref, "."); ok {
+		candidates = []string{full}
+	} else {
+		for s := scope; s != ""; {
+			candidates = append(candidates, s+"."+ref)
+			i := strings.LastIndexByte(s, '.')
+			if i < 0 {
+				break
+			}
+			s = s[:i]
+		}
+		candidates = append(candidates, ref)
+	}
+	for _, full := range candidates {
+		if m, ok := g.messages[full]; ok {
+			if len(m.fields) == 0 {
+				return protoType{kind: protoEmpty, goType: g.pbAlias + "." + m.goName}
+			}
+			return protoType{kind: protoMessageKind, name: g.names[full], goType: g.pbAlias + "." + m.goName}
+		}
+		if e, ok := g.enums[full]; ok {
+			return protoType{kind: protoEnumKind, name: g.names[full], goType: g.pbAlias + "." + e.goName}
+		}
+		switch full {
+		case "google.protobuf.Empty":
+			g.imports["google.golang.org/protobuf/types/known/emptypb"] = ""
+			return protoType{kind: protoEmpty, goType: "emptypb.Empty"}
+		case "google.protobuf.Timestamp":
+			g.imports["google.golang.org/protobuf/types/known/timestamppb"] = ""
+			g.imports["time"] = ""
+			return protoType{kind: protoTimestamp, name: "time.Time", goType: "timestamppb.Timestamp"}
+		case "google.protobuf.Duration":
+			g.imports["google.golang.org/protobuf/types/known/durationpb"] = ""
+			g.imports["time"] = ""
+			return protoType{kind: protoDuration, name: "time.Duration", goType: "durationpb.Duration"}
+		}
+	}
+	g.fail("line %d: type %s is not declared in the file (types from imported files other than google.protobuf.Empty, Timestamp and Duration are not supported)", line, ref)
+	return protoType{kind: protoScalar, name: "any"}
+}
+
+// kukichaType returns the Kukicha type a value of t takes.
+func (t protoType) kukichaType() string {
+	if t.kind == protoEmpty {
+		return "bool"
+	}
+	return t.name
+}
+
+// pbType returns the Go type of a value of t in a generated message.
+func (t protoType) pbType() string {
+	switch t.kind {
+	case protoScalar:
+		return t.name
+	case protoEnumKind:
+		return t.goType
+	default:
+		return "reference " + t.goType
+	}
+}
+
+// toPB returns the conversion of the Kukicha value v of t to its Go type.
+func (t protoType) toPB(v string) string {
+	switch t.kind {
+	case protoEnumKind, protoMessageKind:
+		return "to" + t.name + "(" + v + ")"
+	case protoEmpty:
+		return "reference of " + t.goType + "{}"
+	case protoTimestamp:
+		return "timestamppb.New(" + v + ")"
+	case protoDuration:
+		return "durationpb.New(" + v + ")"
+	default:
+		return v
+	}
+}
+
+// fromPB returns the conversion of the Go value v of t to Kukicha.
+func (t protoType) fromPB(v string) string {
+	switch t.kind {
+	case protoEnumKind, protoMessageKind:
+		return "from" + t.name + "(" + v + ")"
+	case protoEmpty:
+		return v + " != empty"
+	case protoTimestamp:
+		return v + ".AsTime()"
+	case protoDuration:
+		return v + ".AsDuration()"
+	default:
+		return v
+	}
+}
+
+// isSet returns the condition that the Kukicha value v of t is not zero.
+func (t protoType) isSet(v string) string {
+	switch t.kind {
+	case protoEnumKind:
+		return v + " as string != \"\""
+	case protoMessageKind:
+		return v + " != empty"
+	case protoEmpty:
+		return v
+	case protoTimestamp:
+		return "!" + v + ".IsZero()"
+	}
+	switch t.name {
+	case "bool":
+		return v
+	case "string":
+		return v + " != \"\""
+	case "list of byte":
+		return "len(" + v + ") > 0"
+	default:
+		return v + " != 0"
+	}
+}
+
+// zero returns the zero value of the Kukicha type of t.
+func (t protoType) zero() string {
+	switch t.kind {
+	case protoMessageKind, protoTimestamp:
+		return t.name + "{}"
+	case protoDuration:
+		return "0 as time.Duration"
+	}
+	return "empty"
+}
+
+// writeMessage writes the struct for m and its conversions.
+func (g *protoGen) writeMessage(m *protoMessage) {
+	name := g.names[m.fullName]
+	types := make([]protoType, len(m.fields))
+	for i, f := range m.fields {
+		types[i] = g.resolve(f.typ, m.fullName, f.line)
+		if types[i].kind == protoEmpty && (f.label == "repeated" || f.keyType != "") {
+			g.fail("line %d: field %s: lists and maps of empty messages are not supported", f.line, f.name)
+		}
+	}
+
+	g.types.WriteString("\n")
+	writeDoc(&g.types, "", m.doc)
+	fmt.Fprintf(&g.types, "type %s\n", name)
+	for i, f := range m.fields {
+		writeDoc(&g.types, "    ", f.doc)
+		fmt.Fprintf(&g.types, "    %s %s\n", goCamelCase(f.name), fieldType(f, types[i]))
+	}
+
+	c := &g.convs
+	fmt.Fprintf(c, "\n# to%s converts m to its protobuf message.\n", name)
+	fmt.Fprintf(c, "func to%s(m %s) reference %s.%s\n", name, name, g.pbAlias, m.goName)
+	fmt.Fprintf(c, "    out := reference of %s.%s{}\n", g.pbAlias, m.goName)
+	for i, f := range m.fields {
+		if f.oneof == "" {
+			writeToPB(c, f, types[i])
+		}
+	}
+	for _, oneof := range m.oneofs {
+		keyword := "if"
+		for i, f := range m.fields {
+			if f.oneof != oneof {
+				continue
+			}
+			t, field := types[i], goCamelCase(f.name)
+			value := t.toPB("m." + field)
+			if t.kind == protoMessageKind {
+				value = t.toPB("dereference m." + field)
+			}
+			fmt.Fprintf(c, "    %s %s\n", keyword, t.isSet("m."+field))
+			fmt.Fprintf(c, "        out.%s = reference of %s.%s_%s{%s: %s}\n", goCamelCase(oneof), g.pbAlias, m.goName, goFieldName(f.name), goFieldName(f.name), value)
+			keyword = "else if"
+		}
+	}
+	c.WriteString("    return out\n")
+
+	fmt.Fprintf(c, "\n# from%s converts a protobuf message; nil gives the zero value.\n", name)
+	fmt.Fprintf(c, "func from%s(m reference %s.%s) %s\n", name, g.pbAlias, m.goName, name)
+	fmt.Fprintf(c, "    out := %s{}\n    if m == empty\n        return out\n", name)
+	for i, f := range m.fields {
+		if f.oneof == "" {
+			writeFromPB(c, f, types[i])
+		}
+	}
+	for _, oneof := range m.oneofs {
+		fmt.Fprintf(c, "    switch m.%s as v\n", goCamelCase(oneof))
+		for i, f := range m.fields {
+			if f.oneof != oneof {
+				continue
+			}
+			t, field := types[i], goCamelCase(f.name)
+			fmt.Fprintf(c, "        when reference %s.%s_%s\n", g.pbAlias, m.goName, goFieldName(f.name))
+			switch t.kind {
+			case protoMessageKind:
+				fmt.Fprintf(c, "            value := %s\n            out.%s = reference of value\n", t.fromPB("v."+goFieldName(f.name)), field)
+			case protoEmpty:
+				fmt.Fprintf(c, "            out.%s = true\n", field)
+			default:
+				fmt.Fprintf(c, "            out.%s = %s\n", field, t.fromPB("v."+goFieldName(f.name)))
+			}
+		}
+	}
+	c.WriteString("    return out\n")
+}
+
+// fieldType returns the Kukicha type of field f of type t: a reference for
+// a message (which may be absent) and for an optional scalar.
+func fieldType(f *protoField, t protoType) string {
+	switch {
+	case f.keyType != "":
+		return "map of " + protoScalars[f.keyType] + " to " + t.kukichaType()
+	case f.label == "repeated":
+		return "list of " + t.kukichaType()
+	case t.kind == protoMessageKind:
+		return "reference " + t.name
+	case f.label == "optional" && t.kind == protoScalar && t.name != "list of byte":
+		return "reference " + t.name
+	}
+	return t.kukichaType()
+}
+
+// writeToPB writes the statements that set field f of out from m.
+func writeToPB(c *strings.Builder, f *protoField, t protoType) {
+	field, goField := goCamelCase(f.name), goFieldName(f.name)
+	switch {
+	case t.kind == protoScalar:
+		fmt.Fprintf(c, "    out.%s = m.%s\n", goField, field)
+	case f.keyType != "":
+		fmt.Fprintf(c, "    out.%s = make(map of %s to %s)\n", goField, protoScalars[f.keyType], t.pbType())
+		fmt.Fprintf(c, "    for k, v in m.%s\n        out.%s[k] = %s\n", field, goField, t.toPB("v"))
+	case f.label == "repeated":
+		fmt.Fprintf(c, "    for v in m.%s\n        out.%s = append(out.%s, %s)\n", field, goField, goField, t.toPB("v"))
+	case t.kind == protoMessageKind:
+		fmt.Fprintf(c, "    if m.%s != empty\n        out.%s = %s\n", field, goField, t.toPB("dereference m."+field))
+	case t.kind == protoEnumKind && f.label != "optional":
+		fmt.Fprintf(c, "    out.%s = %s\n", goField, t.toPB("m."+field))
+	case t.kind == protoEnumKind:
+		fmt.Fprintf(c, "    if %s\n        out.%s = %s.Enum()\n", t.isSet("m."+field), goField, t.toPB("m."+field))
+	default:
+		fmt.Fprintf(c, "    if %s\n        out.%s = %s\n", t.isSet("m."+field), goField, t.toPB("m."+field))
+	}
+}
+
+// writeFromPB writes the statements that set field f of out from m.
+func writeFromPB(c *strings.Builder, f *protoField, t protoType) {
+	field, goField := goCamelCase(f.name), goFieldName(f.name)
+	switch {
+	case t.kind == protoScalar:
+		fmt.Fprintf(c, "    out.%s = m.%s\n", field, goField)
+	case f.keyType != "":
+		fmt.Fprintf(c, "    out.%s = make(map of %s to %s)\n", field, protoScalars[f.keyType], t.kukichaType())
+		fmt.Fprintf(c, "    for k, v in m.%s\n        out.%s[k] = %s\n", goField, field, t.fromPB("v"))
+	case f.label == "repeated":
+		fmt.Fprintf(c, "    for v in m.%s\n        out.%s = append(out.%s, %s)\n", goField, field, field, t.fromPB("v"))
+	case t.kind == protoMessageKind:
+		fmt.Fprintf(c, "    if m.%s != empty\n        value := %s\n        out.%s = reference of value\n", goField, t.fromPB("m."+goField), field)
+	case t.kind == protoEnumKind && f.label != "optional":
+		fmt.Fprintf(c, "    out.%s = %s\n", field, t.fromPB("m."+goField))
+	case t.kind == protoEnumKind:
+		fmt.Fprintf(c, "    if m.%s != empty\n        out.%s = %s\n", goField, field, t.fromPB("dereference m."+goField))
+	case t.kind == protoEmpty:
+		fmt.Fprintf(c, "    out.%s = %s\n", field, t.fromPB("m."+goField))
+	default:
+		fmt.Fprintf(c, "    if m.%s != empty\n        out.%s = %s\n", goField, field, t.fromPB("m."+goField))
+	}
+}
+
+// writeEnum writes the string enum for e, its values the proto names, and
+// its conversions.
+func (g *protoGen) writeEnum(e *protoEnum) {
+	name := g.names[e.fullName]
+	g.types.WriteString("\n")
+	writeDoc(&g.types, "", e.doc)
+	fmt.Fprintf(&g.types, "type %s string distinct\n", name)
+	prefix := strings.ToUpper(strings.Join(words(e.fullName[strings.LastIndexByte(e.fullName, '.')+1:]), "_")) + "_"
+	for _, value := range e.values {
+		short := strings.TrimPrefix(value, prefix)
+		if short == "" || unicode.IsDigit(rune(short[0])) {
+			short = value
+		}
+		fmt.Fprintf(&g.types, "    %s %s\n", g.claim(name+pascal(strings.ToLower(short), "Value")), quote(value))
+	}
+
+	goType := g.pbAlias + "." + e.goName
+	fmt.Fprintf(&g.convs, "\n# to%s converts v to its protobuf enum; unknown names give 0.\n", name)
+	fmt.Fprintf(&g.convs, "func to%s(v %s) %s\n    return %s(%s_value[v as string])\n", name, name, goType, goType, goType)
+	fmt.Fprintf(&g.convs, "\n# from%s converts a protobuf enum to its name.\n", name)
+	fmt.Fprintf(&g.convs, "func from%s(v %s) %s\n    return v.String() as %s\n", name, goType, name, name)
+}
+
+// writeService writes the client type for s and its methods.
+func (g *protoGen) writeService(s *protoService) {
+	g.imports["context"] = ""
+	g.imports["google.golang.org/grpc"] = ""
+	g.imports["google.golang.org/grpc/credentials/insecure"] = ""
+	svc := pascal(s.name, "Service")
+	goSvc := goCamelCase(s.name)
+	client := g.claim(svc + "Client")
+	dial := g.claim("Dial" + svc)
+	newClient := g.claim("New" + svc + "Client")
+
+	b := &g.clients
+	b.WriteString("\n")
+	fmt.Fprintf(b, "# %s calls the %s service.\n", client, s.name)
+	writeDoc(b, "", s.doc)
+	fmt.Fprintf(b, "type %s\n    conn reference grpc.ClientConn\n    stub %s.%sClient\n", client, g.pbAlias, goSvc)
+	fmt.Fprintf(b, "\n# %s connects to the %s service at target (host:port) without TLS.\n", dial, s.name)
+	fmt.Fprintf(b, "# For TLS or other dial options, pass a connection of your own to %s.\n", newClient)
+	fmt.Fprintf(b, "func %s(target string) (%s, error)\n", dial, client)
+	b.WriteString("    conn := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials())) onerr return\n")
+	fmt.Fprintf(b, "    return %s(conn), empty\n", newClient)
+	fmt.Fprintf(b, "\n# %s returns a client for the %s service on conn.\n", newClient, s.name)
+	fmt.Fprintf(b, "func %s(conn reference grpc.ClientConn) %s\n", newClient, client)
+	fmt.Fprintf(b, "    return %s{conn: conn, stub: %s.New%sClient(conn)}\n", client, g.pbAlias, goSvc)
+	b.WriteString("\n# Close closes the client's connection.\n")
+	fmt.Fprintf(b, "func Close on c %s() error\n    return c.conn.Close()\n", client)
+	b.WriteString("\n# Stub returns the generated Go client, for the calls this one leaves out.\n")
+	fmt.Fprintf(b, "func Stub on c %s() %s.%sClient\n    return c.stub\n", client, g.pbAlias, goSvc)
+
+	methods := map[string]bool{"Close": true, "Stub": true}
+	for _, r := range s.methods {
+		name := goCamelCase(r.name)
+		if r.clientStream {
+			fmt.Fprintf(b, "\n# %s streams requests to the server, which %s leaves out; call it\n# through c.Stub().%s.\n", r.name, client, name)
+			continue
+		}
+		method := name
+		if methods[method] {
+			method += "RPC"
+		}
+		methods[method] = true
+		g.writeRPC(b, client, method, name, s.name, r)
+	}
+}
+
+// writeRPC writes the client method for a unary or server-streaming rpc.
+func (g *protoGen) writeRPC(b *strings.Builder, client, method, goMethod, service string, r *protoRPC) {
+	in := g.resolve(r.input, g.file.pkg, r.line)
+	out := g.resolve(r.output, g.file.pkg, r.line)
+	params := "ctx context.Context"
+	arg := in.toPB("req")
+	if in.kind != protoEmpty {
+		params += ", req " + in.kukichaType()
+	}
+	if in.kind == protoScalar || out.kind == protoScalar {
+		g.fail("line %d: rpc %s: request and response types must be messages", r.line, r.name)
+		return
+	}
+
+	b.WriteString("\n")
+	if r.serverStream {
+		if out.kind == protoEmpty {
+			fmt.Fprintf(b, "# %s streams empty messages, which %s leaves out; call it through\n# c.Stub().%s.\n", r.name, client, goMethod)
+			return
+		}
+		g.imports["errors"] = ""
+		g.imports["io"] = ""
+		fmt.Fprintf(b, "# %s calls %s.%s and yields the messages the server streams.\n", method, service, r.name)
+		writeDoc(b, "", r.doc)
+		fmt.Fprintf(b, "func %s on c %s(%s) yields %s, error\n", method, client, params, out.kukichaType())
+		fmt.Fprintf(b, "    stream, err := c.stub.%s(ctx, %s)\n", goMethod, arg)
+		fmt.Fprintf(b, "    if err != empty\n        yield %s, err\n        return\n", out.zero())
+		b.WriteString("    for\n        resp, err := stream.Recv()\n        if errors.Is(err, io.EOF)\n            return\n")
+		fmt.Fprintf(b, "        if err != empty\n            yield %s, err\n            return\n", out.zero())
+		fmt.Fprintf(b, "        yield %s, empty\n", out.fromPB("resp"))
+		return
+	}
+	fmt.Fprintf(b, "# %s calls %s.%s.\n", method, service, r.name)
+	writeDoc(b, "", r.doc)
+	if out.kind == protoEmpty {
+		fmt.Fprintf(b, "func %s on c %s(%s) error\n", method, client, params)
+		fmt.Fprintf(b, "    _, err := c.stub.%s(ctx, %s)\n    return err\n", goMethod, arg)
+		return
+	}
+	fmt.Fprintf(b, "func %s on c %s(%s) (%s, error)\n", method, client, params, out.kukichaType())
+	fmt.Fprintf(b, "    resp := c.stub.%s(ctx, %s) onerr return\n", goMethod, arg)
+	fmt.Fprintf(b, "    return %s, empty\n", out.fromPB("resp"))
+}
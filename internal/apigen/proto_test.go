@@ -0,0 +1,142 @@
+package apigen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+const storeProto = `// Copyright notice, not a doc
+
+syntax = "proto3";
+
+package demo.store.v1;
+
+import "google/protobuf/empty.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/demo/storepb;storepb";
+
+// An Item is a stored thing.
+message Item {
+  enum State {
+    STATE_UNSPECIFIED = 0;
+    STATE_ACTIVE = 1 [deprecated = true];
+  }
+  message Meta {
+    map<string, string> tags = 1;
+  }
+  reserved 20 to 25;
+  string name = 1; // trailing, not a doc
+  // How many there are.
+  optional int32 count = 2;
+  State state = 3;
+  Meta meta = 4;
+  repeated Item children = 5;
+  map<string, Meta> labels = 6;
+  google.protobuf.Timestamp created = 7;
+  string string = 8;
+  oneof source {
+    string path = 9;
+    Meta inline = 10;
+  }
+}
+
+message GetRequest {
+  string item_id = 1;
+}
+
+/* Store keeps items. */
+service Store {
+  // Get returns one item.
+  rpc Get(GetRequest) returns (Item) {
+    option idempotency_level = NO_SIDE_EFFECTS;
+  }
+  rpc Delete(GetRequest) returns (google.protobuf.Empty);
+  rpc List(google.protobuf.Empty) returns (stream Item);
+  rpc Upload(stream Item) returns (google.protobuf.Empty);
+}
+`
+
+func TestProto(t *testing.T) {
+	out, err := Proto([]byte(storeProto), Options{Package: "store", Source: "store.proto"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Generated by kukicha gen proto from store.proto; do not edit.\n# Package demo.store.v1\n\npetiole store\n",
+		"import \"example.com/demo/storepb\" as pb\n",
+		"# An Item is a stored thing.\ntype Item\n    Name string\n    # How many there are.\n    Count reference int32\n    State ItemState\n    Meta reference ItemMeta\n    Children list of Item\n    Labels map of string to ItemMeta\n    Created time.Time\n    String string\n    Path string\n    Inline reference ItemMeta\n",
+		"type ItemState string distinct\n    ItemStateUnspecified \"STATE_UNSPECIFIED\"\n    ItemStateActive \"STATE_ACTIVE\"\n",
+		"type GetRequest\n    ItemId string\n",
+		"func toItem(m Item) reference pb.Item\n    out := reference of pb.Item{}\n",
+		"    if m.Meta != empty\n        out.Meta = toItemMeta(dereference m.Meta)\n",
+		"    out.Labels = make(map of string to reference pb.Item_Meta)\n",
+		"    out.String_ = m.String\n",
+		"    if m.Path != \"\"\n        out.Source = reference of pb.Item_Path{Path: m.Path}\n    else if m.Inline != empty\n",
+		"    switch m.Source as v\n        when reference pb.Item_Path\n            out.Path = v.Path\n",
+		"    out.ItemId = m.ItemId\n",
+		"func toItemState(v ItemState) pb.Item_State\n    return pb.Item_State(pb.Item_State_value[v as string])\n",
+		"func DialStore(target string) (StoreClient, error)\n",
+		"# Get calls Store.Get.\n# Get returns one item.\nfunc Get on c StoreClient(ctx context.Context, req GetRequest) (Item, error)\n    resp := c.stub.Get(ctx, toGetRequest(req)) onerr return\n    return fromItem(resp), empty\n",
+		"func Delete on c StoreClient(ctx context.Context, req GetRequest) error\n    _, err := c.stub.Delete(ctx, toGetRequest(req))\n",
+		"func List on c StoreClient(ctx context.Context) yields Item, error\n    stream, err := c.stub.List(ctx, reference of emptypb.Empty{})\n",
+		"# Upload streams requests to the server, which StoreClient leaves out; call it\n# through c.Stub().Upload.\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	p, err := parser.New(out, "store.kuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, parseErrs := p.Parse()
+	if len(parseErrs) > 0 {
+		t.Fatalf("parse errors: %v\n%s", parseErrs, out)
+	}
+	analyzer := semantic.NewWithFile(program, "store.kuki")
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v\n%s", errs, out)
+	}
+}
+
+func TestProtoErrors(t *testing.T) {
+	const service = "service S {\n  rpc Get(M) returns (M);\n}\n"
+	tests := []struct {
+		name  string
+		proto string
+		opts  Options
+		want  string
+	}{
+		{"proto2", "syntax = \"proto2\";\n", Options{}, `syntax = "proto2" files are not supported`},
+		{"no syntax", "package a;\n", Options{}, "proto2 files are not supported"},
+		{"no go_package", "syntax = \"proto3\";\nmessage M { string a = 1; }\n" + service, Options{}, "no go_package option"},
+		{"no services", "syntax = \"proto3\";\nmessage M { string a = 1; }\n", Options{GoPackage: "x/pb"}, "declares no services"},
+		{"imported type", "syntax = \"proto3\";\nimport \"google/protobuf/any.proto\";\nmessage M { google.protobuf.Any a = 1; }\n" + service, Options{GoPackage: "x/pb"}, "line 3: type google.protobuf.Any is not declared in the file"},
+		{"syntax error", "syntax = \"proto3\";\nmessage M { string a 1; }\n", Options{}, `line 2: expected "=", found "1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Package = "api"
+			_, err := Proto([]byte(tt.proto), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGoCamelCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"item_id": "ItemId", "Outer.Inner": "Outer_Inner", "Outer.inner": "OuterInner",
+		"_private": "XPrivate", "foo2bar": "Foo2Bar", "HTTPServer": "HTTPServer",
+	} {
+		if got := goCamelCase(in); got != want {
+			t.Errorf("goCamelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
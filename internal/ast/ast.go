@@ -1,6 +1,11 @@
 package ast
 
-import "github.com/duber000/kukicha/internal/lexer"
+import (
+	"math/big"
+	"time"
+
+	"github.com/duber000/kukicha/internal/lexer"
+)
 
 // Node is the base interface for all AST nodes
 type Node interface {
@@ -15,16 +20,28 @@ type Position struct {
 	File   string
 }
 
+// TokenPos is the position of a token, which stores it compactly.
+func TokenPos(t lexer.Token) Position {
+	line, column := t.Position()
+	return Position{Line: line, Column: column, File: t.File()}
+}
+
 // ============================================================================
 // Program - Root node
 // ============================================================================
 
 type Program struct {
-	Target       string        // Directive target (e.g., "mcp")
-	PetioleDecl  *PetioleDecl  // Optional petiole declaration
-	SkillDecl    *SkillDecl    // Optional skill declaration
-	Imports      []*ImportDecl // Import declarations
-	Declarations []Declaration // Top-level declarations (types, interfaces, functions)
+	Target        string        // Directive target (e.g., "mcp")
+	Language      string        // Version from a `# kukicha: X.Y.Z` header pragma; "" when absent
+	LanguagePos   Position      // Position of the `# kukicha:` pragma
+	Shutdown      string        // `# shutdown:` header pragma: "on", "off", or "" for the default
+	ShutdownGrace time.Duration // Grace period from `# shutdown: 10s`; 0 for the default
+	OnErrLog      string        // `# onerr:` header pragma: "log", "off", or "" for the project default
+	Header        []string      // Lines from `# header:` pragmas, emitted before the generated package clause
+	PetioleDecl   *PetioleDecl  // Optional petiole declaration
+	SkillDecl     *SkillDecl    // Optional skill declaration
+	Imports       []*ImportDecl // Import declarations
+	Declarations  []Declaration // Top-level declarations (types, interfaces, functions)
 }
 
 func (p *Program) TokenLiteral() string {
@@ -71,7 +88,7 @@ func (d *PetioleDecl) TokenLiteral() string {
 	return d.Token.Lexeme
 }
 func (d *PetioleDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *PetioleDecl) declNode() {}
 
@@ -86,7 +103,7 @@ func (d *SkillDecl) TokenLiteral() string {
 	return d.Token.Lexeme
 }
 func (d *SkillDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *SkillDecl) declNode() {}
 
@@ -94,11 +111,12 @@ type ImportDecl struct {
 	Token lexer.Token // The 'import' token
 	Path  *StringLiteral
 	Alias *Identifier // Optional alias
+	Auto  bool        // Added by semantic auto-import, not written in the source
 }
 
 func (d *ImportDecl) TokenLiteral() string { return d.Token.Lexeme }
 func (d *ImportDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *ImportDecl) declNode() {}
 
@@ -121,10 +139,77 @@ type ConstDecl struct {
 
 func (d *ConstDecl) TokenLiteral() string { return d.Token.Lexeme }
 func (d *ConstDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *ConstDecl) declNode() {}
 
+// ErrorSpec is a single sentinel error inside an error declaration.
+type ErrorSpec struct {
+	Name    *Identifier
+	Message *StringLiteral // Plain string literal; sentinel messages are fixed
+}
+
+// ErrorDecl declares package-level sentinel errors (single or grouped):
+//
+//	error NotFound "resource not found"
+//	error
+//	    Conflict "resource already exists"
+//	    Expired "token expired"
+//
+// Each spec becomes `var Name = errors.New("message")`, so callers can
+// compare against it with errors.Is.
+type ErrorDecl struct {
+	Token lexer.Token  // The 'error' token
+	Specs []*ErrorSpec // One or more name/message pairs
+}
+
+func (d *ErrorDecl) TokenLiteral() string { return d.Token.Lexeme }
+func (d *ErrorDecl) Pos() Position {
+	return TokenPos(d.Token)
+}
+func (d *ErrorDecl) declNode() {}
+
+// ImplementsDecl is a top-level conformance assertion:
+//
+//	Circle implements Shape, fmt.Stringer
+//
+// The analyzer checks the type's methods against each local interface, and
+// codegen emits `var _ Shape = (*Circle)(nil)` so Go checks the rest.
+type ImplementsDecl struct {
+	Token      lexer.Token      // The 'implements' token
+	Type       *Identifier      // The implementing type
+	Interfaces []TypeAnnotation // One or more interfaces, possibly qualified
+}
+
+func (d *ImplementsDecl) TokenLiteral() string { return d.Token.Lexeme }
+func (d *ImplementsDecl) Pos() Position        { return d.Type.Pos() }
+func (d *ImplementsDecl) declNode()            {}
+
+// TargetDecl is a top-level `when target NAME[, NAME...]` block:
+//
+//	when target mcp
+//	    func main()
+//	        server.Serve()
+//	otherwise
+//	    func main()
+//	        cli.Run(app)
+//
+// Only the declarations of the branch matching the build target are compiled
+// (see SelectTarget).
+type TargetDecl struct {
+	Token        lexer.Token   // The 'when' token
+	Targets      []*Identifier // Target names, e.g. mcp
+	Declarations []Declaration
+	Otherwise    []Declaration // nil when there is no otherwise branch
+	OtherwiseTok lexer.Token   // The 'otherwise' token, if any
+}
+
+func (d *TargetDecl) TokenLiteral() string { return d.Token.Lexeme }
+func (d *TargetDecl) Pos() Position {
+	return TokenPos(d.Token)
+}
+func (d *TargetDecl) declNode() {}
+
 // Directive represents a `# kuki:name args...` annotation attached to a declaration.
 type Directive struct {
 	Token lexer.Token // The TOKEN_DIRECTIVE token
@@ -136,16 +221,28 @@ type TypeDecl struct {
 	Token      lexer.Token // The 'type' token
 	Name       *Identifier
 	Fields     []*FieldDecl   // nil for type aliases
-	AliasType  TypeAnnotation // non-nil for type aliases (e.g., func(...) ...)
+	AliasType  TypeAnnotation // non-nil for type aliases (e.g., func(...) ..., int)
+	Distinct   bool           // `type Meters int distinct`: does not mix with other types
+	Values     []*EnumValue   // Value set of a distinct string type (an enum)
 	Directives []Directive    // Attached `# kuki:` directives
 }
 
 func (d *TypeDecl) TokenLiteral() string { return d.Token.Lexeme }
 func (d *TypeDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *TypeDecl) declNode() {}
 
+// EnumValue is one constant of a distinct string type's value set:
+//
+//	type Status string distinct
+//	    Active "active"
+//	    Banned "banned"
+type EnumValue struct {
+	Name  *Identifier
+	Value *StringLiteral // Plain string literal
+}
+
 type FieldDecl struct {
 	Name *Identifier
 	Type TypeAnnotation
@@ -161,7 +258,7 @@ type InterfaceDecl struct {
 
 func (d *InterfaceDecl) TokenLiteral() string { return d.Token.Lexeme }
 func (d *InterfaceDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *InterfaceDecl) declNode() {}
 
@@ -176,14 +273,16 @@ type FunctionDecl struct {
 	Name       *Identifier
 	Parameters []*Parameter
 	Returns    []TypeAnnotation
+	Yields     []TypeAnnotation // Values the body yields: `yields T` / `yields K, V`, or a `sequence of T` generator
 	Body       *BlockStmt
 	Receiver   *Receiver   // For methods (optional)
+	Operator   string      // `operator +`: the binary operator the method implements on its type
 	Directives []Directive // Attached `# kuki:` directives
 }
 
 func (d *FunctionDecl) TokenLiteral() string { return d.Token.Lexeme }
 func (d *FunctionDecl) Pos() Position {
-	return Position{Line: d.Token.Line, Column: d.Token.Column, File: d.Token.File}
+	return TokenPos(d.Token)
 }
 func (d *FunctionDecl) declNode() {}
 
@@ -215,7 +314,7 @@ type PrimitiveType struct {
 
 func (t *PrimitiveType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *PrimitiveType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *PrimitiveType) typeNode() {}
 
@@ -226,7 +325,7 @@ type NamedType struct {
 
 func (t *NamedType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *NamedType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *NamedType) typeNode() {}
 
@@ -237,7 +336,7 @@ type ReferenceType struct {
 
 func (t *ReferenceType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *ReferenceType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *ReferenceType) typeNode() {}
 
@@ -248,7 +347,7 @@ type ListType struct {
 
 func (t *ListType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *ListType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *ListType) typeNode() {}
 
@@ -260,23 +359,36 @@ type MapType struct {
 
 func (t *MapType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *MapType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *MapType) typeNode() {}
 
 type ChannelType struct {
 	Token       lexer.Token // The 'channel' token
 	ElementType TypeAnnotation
+	Direction   string // "", "send-only" or "receive-only"
 }
 
 func (t *ChannelType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *ChannelType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *ChannelType) typeNode() {}
 
 // FunctionType represents a function type annotation
 // e.g., func(int, string) bool
+// SequenceType is `sequence of T`, a lazy sequence (Go iter.Seq[T]).
+type SequenceType struct {
+	Token       lexer.Token // The 'sequence' identifier
+	ElementType TypeAnnotation
+}
+
+func (t *SequenceType) TokenLiteral() string { return t.Token.Lexeme }
+func (t *SequenceType) Pos() Position {
+	return TokenPos(t.Token)
+}
+func (t *SequenceType) typeNode() {}
+
 type FunctionType struct {
 	Token      lexer.Token      // The 'func' token
 	Parameters []TypeAnnotation // Parameter types
@@ -285,7 +397,7 @@ type FunctionType struct {
 
 func (t *FunctionType) TokenLiteral() string { return t.Token.Lexeme }
 func (t *FunctionType) Pos() Position {
-	return Position{Line: t.Token.Line, Column: t.Token.Column, File: t.Token.File}
+	return TokenPos(t.Token)
 }
 func (t *FunctionType) typeNode() {}
 
@@ -294,7 +406,8 @@ func (t *FunctionType) typeNode() {}
 // ============================================================================
 
 // OnErrClause represents the error handling part of an onerr statement.
-// It is not an AST node itself — it is a field on VarDeclStmt, AssignStmt, and ExpressionStmt.
+// It is not an AST node itself — it is a field on VarDeclStmt, AssignStmt,
+// ExpressionStmt and OnErrExpr.
 type OnErrClause struct {
 	Token           lexer.Token // The 'onerr' token
 	Handler         Expression  // Error handler (panic, error, empty, discard, or default value)
@@ -305,6 +418,22 @@ type OnErrClause struct {
 	Alias             string      // Named alias for the caught error in block handlers (e.g., "onerr as e")
 }
 
+// OnErrExpr is a fallible expression with its own inline onerr handler, in a
+// call argument or a struct, list or map literal element:
+// Config{port: GetPort() onerr 8080}. Codegen hoists it into a temporary
+// declared before the enclosing statement.
+type OnErrExpr struct {
+	Token      lexer.Token // The 'onerr' token
+	Expression Expression  // The fallible expression
+	OnErr      *OnErrClause
+}
+
+func (e *OnErrExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *OnErrExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *OnErrExpr) exprNode() {}
+
 // ============================================================================
 // Statements
 // ============================================================================
@@ -321,7 +450,7 @@ type BlockStmt struct {
 
 func (s *BlockStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *BlockStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *BlockStmt) stmtNode() {}
 
@@ -335,7 +464,7 @@ type VarDeclStmt struct {
 
 func (s *VarDeclStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *VarDeclStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *VarDeclStmt) stmtNode() {}
 func (s *VarDeclStmt) declNode() {}
@@ -349,7 +478,7 @@ type AssignStmt struct {
 
 func (s *AssignStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *AssignStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *AssignStmt) stmtNode() {}
 
@@ -361,7 +490,7 @@ type IncDecStmt struct {
 
 func (s *IncDecStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *IncDecStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *IncDecStmt) stmtNode() {}
 
@@ -372,27 +501,53 @@ type ReturnStmt struct {
 
 func (s *ReturnStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ReturnStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ReturnStmt) stmtNode() {}
 
+// YieldStmt hands values to the loop ranging over an iterator function
+// (`yield item`); the function stops when the loop breaks.
+type YieldStmt struct {
+	Token  lexer.Token // The 'yield' identifier
+	Values []Expression
+}
+
+func (s *YieldStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *YieldStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *YieldStmt) stmtNode() {}
+
 type ContinueStmt struct {
 	Token lexer.Token // The 'continue' token
 }
 
 func (s *ContinueStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ContinueStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ContinueStmt) stmtNode() {}
 
+// FallthroughStmt is `continue to next` as the last statement of a when
+// branch: control continues into the body of the next branch (Go's
+// fallthrough), without checking its values or guard.
+type FallthroughStmt struct {
+	Token lexer.Token // The 'continue' token
+}
+
+func (s *FallthroughStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *FallthroughStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *FallthroughStmt) stmtNode() {}
+
 type BreakStmt struct {
 	Token lexer.Token // The 'break' token
 }
 
 func (s *BreakStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *BreakStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *BreakStmt) stmtNode() {}
 
@@ -406,7 +561,7 @@ type IfStmt struct {
 
 func (s *IfStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *IfStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *IfStmt) stmtNode() {}
 
@@ -417,7 +572,7 @@ type ElseStmt struct {
 
 func (s *ElseStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ElseStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ElseStmt) stmtNode() {}
 
@@ -430,17 +585,52 @@ type SwitchStmt struct {
 
 func (s *SwitchStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *SwitchStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *SwitchStmt) stmtNode()            {}
 func (s *SwitchStmt) pipedSwitchBodyNode() {}
 
+// TargetStmt is a `when target NAME[, NAME...]` block inside a function
+// body. Only the branch matching the build target is compiled.
+type TargetStmt struct {
+	Token     lexer.Token   // The 'when' token
+	Targets   []*Identifier // Target names, e.g. mcp
+	Body      *BlockStmt
+	Otherwise *OtherwiseCase // Optional
+}
+
+func (s *TargetStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *TargetStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *TargetStmt) stmtNode() {}
+
 type WhenCase struct {
 	Token  lexer.Token // The 'when' or 'case' token
 	Values []Expression
+	Guard  Expression // Optional `if cond` after the values; the branch is taken only when it holds
 	Body   *BlockStmt
 }
 
+// WhenPattern is a `when` value that is tested against the switch value
+// rather than compared for equality: `when > 100`, `when 1 to 9` (end
+// excluded, as in for loops), `when 1 through 9`, `when matches re"^t"`.
+type WhenPattern struct {
+	Token    lexer.Token // The operator, 'to', 'through' or 'matches' token
+	Operator string      // "<", "<=", ">", ">=", "to", "through" or "matches"
+	Low      Expression  // Range start; nil unless Operator is "to" or "through"
+	Value    Expression  // Comparison operand, range end or pattern
+}
+
+func (e *WhenPattern) TokenLiteral() string { return e.Token.Lexeme }
+func (e *WhenPattern) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *WhenPattern) exprNode() {}
+
+// IsRange reports whether the pattern is a `to` or `through` range.
+func (e *WhenPattern) IsRange() bool { return e.Low != nil }
+
 type OtherwiseCase struct {
 	Token lexer.Token // The 'otherwise' or 'default' token
 	Body  *BlockStmt
@@ -454,7 +644,7 @@ type SelectStmt struct {
 
 func (s *SelectStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *SelectStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *SelectStmt) stmtNode() {}
 
@@ -477,7 +667,7 @@ type TypeSwitchStmt struct {
 
 func (s *TypeSwitchStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *TypeSwitchStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *TypeSwitchStmt) stmtNode()            {}
 func (s *TypeSwitchStmt) pipedSwitchBodyNode() {}
@@ -500,7 +690,7 @@ type ForRangeStmt struct {
 
 func (s *ForRangeStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ForRangeStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ForRangeStmt) stmtNode() {}
 
@@ -516,7 +706,7 @@ type ForNumericStmt struct {
 
 func (s *ForNumericStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ForNumericStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ForNumericStmt) stmtNode() {}
 
@@ -529,7 +719,7 @@ type ForConditionStmt struct {
 
 func (s *ForConditionStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *ForConditionStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *ForConditionStmt) stmtNode() {}
 
@@ -540,7 +730,7 @@ type DeferStmt struct {
 
 func (s *DeferStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *DeferStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *DeferStmt) stmtNode() {}
 
@@ -552,10 +742,61 @@ type GoStmt struct {
 
 func (s *GoStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *GoStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *GoStmt) stmtNode() {}
 
+// SafelyStmt runs Body with panics recovered. A panic becomes an error
+// ("panic: <value>") handled by OnErr, like a failed call's:
+//
+//	safely
+//	    result = legacy.Parse(data)
+//	onerr as e
+//	    log.Print(e)
+type SafelyStmt struct {
+	Token lexer.Token // The 'safely' identifier
+	Body  *BlockStmt
+	OnErr *OnErrClause
+}
+
+func (s *SafelyStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *SafelyStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *SafelyStmt) stmtNode() {}
+
+// FailStmt prints Message to stderr and exits with Code (1 when nil). It is
+// for command-line scripts, where panic's stack trace is the wrong output:
+//
+//	fail "config file {path} not found" code 2
+type FailStmt struct {
+	Token   lexer.Token // The 'fail' identifier
+	Message Expression
+	Code    Expression // nil means exit code 1
+}
+
+func (s *FailStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *FailStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *FailStmt) stmtNode() {}
+
+// RequireStmt is a guard clause: when Condition is false, Else runs, and it
+// must leave the enclosing function or loop:
+//
+//	require len(items) > 0 else return error "empty"
+type RequireStmt struct {
+	Token     lexer.Token // The 'require' identifier
+	Condition Expression
+	Else      *BlockStmt // The statement after 'else', or the indented block below it
+}
+
+func (s *RequireStmt) TokenLiteral() string { return s.Token.Lexeme }
+func (s *RequireStmt) Pos() Position {
+	return TokenPos(s.Token)
+}
+func (s *RequireStmt) stmtNode() {}
+
 type SendStmt struct {
 	Token   lexer.Token // The 'send' token
 	Value   Expression
@@ -564,7 +805,7 @@ type SendStmt struct {
 
 func (s *SendStmt) TokenLiteral() string { return s.Token.Lexeme }
 func (s *SendStmt) Pos() Position {
-	return Position{Line: s.Token.Line, Column: s.Token.Column, File: s.Token.File}
+	return TokenPos(s.Token)
 }
 func (s *SendStmt) stmtNode() {}
 
@@ -593,18 +834,19 @@ type Identifier struct {
 
 func (e *Identifier) TokenLiteral() string { return e.Token.Lexeme }
 func (e *Identifier) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *Identifier) exprNode() {}
 
 type IntegerLiteral struct {
 	Token lexer.Token
 	Value int64
+	Big   *big.Int // Set (and Value left 0) when the literal overflows int64
 }
 
 func (e *IntegerLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *IntegerLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *IntegerLiteral) exprNode() {}
 
@@ -615,10 +857,23 @@ type FloatLiteral struct {
 
 func (e *FloatLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *FloatLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *FloatLiteral) exprNode() {}
 
+// DecimalLiteral is an exact decimal number, 19.99d, typed as the
+// stdlib/decimal Decimal. Value is the number without the d or _ separators.
+type DecimalLiteral struct {
+	Token lexer.Token
+	Value string
+}
+
+func (e *DecimalLiteral) TokenLiteral() string { return e.Token.Lexeme }
+func (e *DecimalLiteral) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *DecimalLiteral) exprNode() {}
+
 type RuneLiteral struct {
 	Token lexer.Token
 	Value rune
@@ -626,7 +881,7 @@ type RuneLiteral struct {
 
 func (e *RuneLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *RuneLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *RuneLiteral) exprNode() {}
 
@@ -639,10 +894,75 @@ type StringLiteral struct {
 
 func (e *StringLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *StringLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *StringLiteral) exprNode() {}
 
+// CommandExpr runs a program and produces its trimmed stdout and an error,
+// like a shell's command substitution but without a shell (see CommandWords):
+//
+//	head := $ "git rev-parse HEAD" onerr return
+type CommandExpr struct {
+	Token   lexer.Token    // The '$' token
+	Command *StringLiteral // The command line; interpolations stay single arguments
+}
+
+func (e *CommandExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *CommandExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *CommandExpr) exprNode() {}
+
+// DataLiteral is a `data` block: the indented lines below it, verbatim.
+// It is a string, or with `data lines` a list of string, one per line:
+//
+//	query := data
+//	    SELECT name
+//	    FROM users
+type DataLiteral struct {
+	Token lexer.Token // The data block token; its lexeme is the text
+	Value string      // The lines joined by newlines, block indentation removed
+	Lines bool        // data lines: a list of string
+}
+
+func (e *DataLiteral) TokenLiteral() string { return e.Token.Lexeme }
+func (e *DataLiteral) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *DataLiteral) exprNode() {}
+
+// RegexLiteral is a regular expression, re"^t\d+": a string whose backslashes
+// are kept verbatim and which is checked at compile time.
+type RegexLiteral struct {
+	Token   lexer.Token
+	Pattern string
+}
+
+func (e *RegexLiteral) TokenLiteral() string { return e.Token.Lexeme }
+func (e *RegexLiteral) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *RegexLiteral) exprNode() {}
+
+// BuildStringExpr is a `build string` block. The body appends to Builder, a
+// strings.Builder that exists only inside the block, and the block's value
+// is the accumulated string:
+//
+//	report := build string as b
+//	    for u in users
+//	        b.WriteString("{u.Name}\n")
+type BuildStringExpr struct {
+	Token   lexer.Token // The 'build' token
+	Builder *Identifier // The builder variable, named after 'as'
+	Body    *BlockStmt
+}
+
+func (e *BuildStringExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *BuildStringExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *BuildStringExpr) exprNode() {}
+
 type StringInterpolation struct {
 	IsLiteral bool       // True for literal parts, false for expressions
 	Literal   string     // For literal parts
@@ -656,7 +976,7 @@ type BooleanLiteral struct {
 
 func (e *BooleanLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *BooleanLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *BooleanLiteral) exprNode() {}
 
@@ -665,14 +985,49 @@ type BinaryExpr struct {
 	Left     Expression
 	Operator string
 	Right    Expression
+	Method   string // Set by the analyzer: the operator method of Left's type it calls
 }
 
 func (e *BinaryExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *BinaryExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *BinaryExpr) exprNode() {}
 
+// ComparisonChainExpr is a chain of ordering comparisons, 0 <= x < 10: the
+// conjunction of each pair, with every operand evaluated once.
+type ComparisonChainExpr struct {
+	Token     lexer.Token   // The first operator token
+	Operands  []Expression  // One more than Operators
+	Operators []lexer.Token // Operators[i] compares Operands[i] with Operands[i+1]
+}
+
+func (e *ComparisonChainExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *ComparisonChainExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *ComparisonChainExpr) exprNode() {}
+
+// RangeCheckExpr tests whether Value lies between two bounds: x between 1 and
+// 10, x in 0 to n (High excluded, as in for loops) or x in 1 through 10.
+type RangeCheckExpr struct {
+	Token   lexer.Token // The 'between' or 'in' token
+	Value   Expression
+	Low     Expression
+	High    Expression
+	Bound   string // "and" (between), "to" or "through"
+	Negated bool   // x not in 0 to n
+}
+
+// Inclusive reports whether High itself is in the range.
+func (e *RangeCheckExpr) Inclusive() bool { return e.Bound != "to" }
+
+func (e *RangeCheckExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *RangeCheckExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *RangeCheckExpr) exprNode() {}
+
 type UnaryExpr struct {
 	Token    lexer.Token // The operator token
 	Operator string
@@ -681,7 +1036,7 @@ type UnaryExpr struct {
 
 func (e *UnaryExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *UnaryExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *UnaryExpr) exprNode() {}
 
@@ -693,10 +1048,25 @@ type PipeExpr struct {
 
 func (e *PipeExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *PipeExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *PipeExpr) exprNode() {}
 
+// ParallelPipeExpr represents a parallel pipe: items |>> f(args).
+// Right is applied to every element of Left on a bounded pool of goroutines;
+// the results keep the order of Left.
+type ParallelPipeExpr struct {
+	Token lexer.Token // The '|>>' token
+	Left  Expression  // The list being mapped
+	Right Expression  // A call, method call, or function name applied to each element
+}
+
+func (e *ParallelPipeExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *ParallelPipeExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *ParallelPipeExpr) exprNode() {}
+
 // NamedArgument represents a named argument in a function call
 // e.g., foo(name: "value", count: 5)
 type NamedArgument struct {
@@ -707,7 +1077,7 @@ type NamedArgument struct {
 
 func (e *NamedArgument) TokenLiteral() string { return e.Token.Lexeme }
 func (e *NamedArgument) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *NamedArgument) exprNode() {}
 
@@ -721,7 +1091,7 @@ type CallExpr struct {
 
 func (e *CallExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *CallExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *CallExpr) exprNode() {}
 
@@ -736,7 +1106,7 @@ type MethodCallExpr struct {
 
 func (e *MethodCallExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *MethodCallExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *MethodCallExpr) exprNode() {}
 
@@ -748,7 +1118,7 @@ type FieldAccessExpr struct {
 
 func (e *FieldAccessExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *FieldAccessExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *FieldAccessExpr) exprNode() {}
 
@@ -760,7 +1130,7 @@ type IndexExpr struct {
 
 func (e *IndexExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *IndexExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *IndexExpr) exprNode() {}
 
@@ -773,7 +1143,7 @@ type SliceExpr struct {
 
 func (e *SliceExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *SliceExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *SliceExpr) exprNode() {}
 
@@ -785,7 +1155,7 @@ type StructLiteralExpr struct {
 
 func (e *StructLiteralExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *StructLiteralExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *StructLiteralExpr) exprNode() {}
 
@@ -802,7 +1172,7 @@ type ListLiteralExpr struct {
 
 func (e *ListLiteralExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *ListLiteralExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *ListLiteralExpr) exprNode() {}
 
@@ -815,7 +1185,7 @@ type MapLiteralExpr struct {
 
 func (e *MapLiteralExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *MapLiteralExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *MapLiteralExpr) exprNode() {}
 
@@ -831,10 +1201,26 @@ type ReceiveExpr struct {
 
 func (e *ReceiveExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *ReceiveExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *ReceiveExpr) exprNode() {}
 
+// WaitExpr waits for a result: `wait for ch` receives one value and
+// `wait for all chans` receives one value from each channel in a list. With
+// `within d` it gives up after the duration and also produces an error.
+type WaitExpr struct {
+	Token   lexer.Token // The 'wait' identifier
+	All     bool
+	Channel Expression // a channel, or a list of channels for All
+	Timeout Expression // nil without `within`
+}
+
+func (e *WaitExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *WaitExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *WaitExpr) exprNode() {}
+
 type TypeCastExpr struct {
 	Token      lexer.Token // The 'as' token
 	Expression Expression
@@ -843,7 +1229,7 @@ type TypeCastExpr struct {
 
 func (e *TypeCastExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *TypeCastExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *TypeCastExpr) exprNode() {}
 
@@ -855,7 +1241,7 @@ type TypeAssertionExpr struct {
 
 func (e *TypeAssertionExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *TypeAssertionExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *TypeAssertionExpr) exprNode() {}
 
@@ -866,7 +1252,7 @@ type EmptyExpr struct {
 
 func (e *EmptyExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *EmptyExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *EmptyExpr) exprNode() {}
 
@@ -876,7 +1262,7 @@ type DiscardExpr struct {
 
 func (e *DiscardExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *DiscardExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *DiscardExpr) exprNode() {}
 
@@ -887,7 +1273,7 @@ type ErrorExpr struct {
 
 func (e *ErrorExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *ErrorExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *ErrorExpr) exprNode() {}
 
@@ -898,7 +1284,7 @@ type ReturnExpr struct {
 
 func (e *ReturnExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *ReturnExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *ReturnExpr) exprNode() {}
 
@@ -910,7 +1296,7 @@ type MakeExpr struct {
 
 func (e *MakeExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *MakeExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *MakeExpr) exprNode() {}
 
@@ -921,7 +1307,7 @@ type CloseExpr struct {
 
 func (e *CloseExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *CloseExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *CloseExpr) exprNode() {}
 
@@ -932,7 +1318,7 @@ type PanicExpr struct {
 
 func (e *PanicExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *PanicExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *PanicExpr) exprNode() {}
 
@@ -942,10 +1328,23 @@ type RecoverExpr struct {
 
 func (e *RecoverExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *RecoverExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *RecoverExpr) exprNode() {}
 
+// ReadExpr reads standard input: `read line` (one line, without its line
+// ending) or `read all` (everything up to EOF). Both produce (string, error).
+type ReadExpr struct {
+	Token lexer.Token // The 'read' identifier
+	All   bool        // read all rather than read line
+}
+
+func (e *ReadExpr) TokenLiteral() string { return e.Token.Lexeme }
+func (e *ReadExpr) Pos() Position {
+	return TokenPos(e.Token)
+}
+func (e *ReadExpr) exprNode() {}
+
 type FunctionLiteral struct {
 	Token      lexer.Token // The 'func' token
 	Parameters []*Parameter
@@ -955,7 +1354,7 @@ type FunctionLiteral struct {
 
 func (e *FunctionLiteral) TokenLiteral() string { return e.Token.Lexeme }
 func (e *FunctionLiteral) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *FunctionLiteral) exprNode() {}
 
@@ -974,7 +1373,7 @@ type ArrowLambda struct {
 
 func (e *ArrowLambda) TokenLiteral() string { return e.Token.Lexeme }
 func (e *ArrowLambda) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *ArrowLambda) exprNode() {}
 
@@ -985,7 +1384,7 @@ type AddressOfExpr struct {
 
 func (e *AddressOfExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *AddressOfExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *AddressOfExpr) exprNode() {}
 
@@ -996,7 +1395,7 @@ type DerefExpr struct {
 
 func (e *DerefExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *DerefExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *DerefExpr) exprNode() {}
 
@@ -1008,7 +1407,7 @@ type PipedSwitchExpr struct {
 
 func (e *PipedSwitchExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *PipedSwitchExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *PipedSwitchExpr) exprNode() {}
 
@@ -1024,6 +1423,6 @@ type BlockExpr struct {
 
 func (e *BlockExpr) TokenLiteral() string { return e.Token.Lexeme }
 func (e *BlockExpr) Pos() Position {
-	return Position{Line: e.Token.Line, Column: e.Token.Column, File: e.Token.File}
+	return TokenPos(e.Token)
 }
 func (e *BlockExpr) exprNode() {}
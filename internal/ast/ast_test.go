@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/duber000/kukicha/internal/lexer"
@@ -67,7 +68,7 @@ func TestProgram_TokenLiteral_Empty(t *testing.T) {
 func TestProgram_Pos_WithPetiole(t *testing.T) {
 	p := &Program{
 		PetioleDecl: &PetioleDecl{
-			Token: lexer.Token{Line: 1, Column: 1, File: "main.kuki"},
+			Token: tokenAt("main.kuki", "", 1, 1),
 		},
 	}
 	pos := p.Pos()
@@ -79,7 +80,7 @@ func TestProgram_Pos_WithPetiole(t *testing.T) {
 func TestProgram_Pos_NoPetioleWithImports(t *testing.T) {
 	p := &Program{
 		Imports: []*ImportDecl{
-			{Token: lexer.Token{Line: 3, Column: 1, File: "test.kuki"}},
+			{Token: tokenAt("test.kuki", "", 3, 1)},
 		},
 	}
 	pos := p.Pos()
@@ -92,7 +93,7 @@ func TestProgram_Pos_NoPetioleNoImportsWithDecls(t *testing.T) {
 	p := &Program{
 		Declarations: []Declaration{
 			&FunctionDecl{
-				Token: lexer.Token{Line: 5, Column: 1, File: "test.kuki"},
+				Token: tokenAt("test.kuki", "", 5, 1),
 				Name:  &Identifier{Value: "main"},
 			},
 		},
@@ -117,12 +118,12 @@ func TestProgram_Pos_Empty(t *testing.T) {
 
 func TestNodeInterface_Declarations(t *testing.T) {
 	nodes := []Node{
-		&PetioleDecl{Token: lexer.Token{Lexeme: "petiole", Line: 1, Column: 1}},
-		&SkillDecl{Token: lexer.Token{Lexeme: "skill", Line: 2, Column: 1}},
-		&ImportDecl{Token: lexer.Token{Lexeme: "import", Line: 3, Column: 1}},
-		&TypeDecl{Token: lexer.Token{Lexeme: "type", Line: 4, Column: 1}, Name: &Identifier{Value: "T"}},
-		&InterfaceDecl{Token: lexer.Token{Lexeme: "interface", Line: 5, Column: 1}, Name: &Identifier{Value: "I"}},
-		&FunctionDecl{Token: lexer.Token{Lexeme: "func", Line: 6, Column: 1}, Name: &Identifier{Value: "F"}},
+		&PetioleDecl{Token: tokenAt("", "petiole", 1, 1)},
+		&SkillDecl{Token: tokenAt("", "skill", 2, 1)},
+		&ImportDecl{Token: tokenAt("", "import", 3, 1)},
+		&TypeDecl{Token: tokenAt("", "type", 4, 1), Name: &Identifier{Value: "T"}},
+		&InterfaceDecl{Token: tokenAt("", "interface", 5, 1), Name: &Identifier{Value: "I"}},
+		&FunctionDecl{Token: tokenAt("", "func", 6, 1), Name: &Identifier{Value: "F"}},
 	}
 	for _, n := range nodes {
 		if n.TokenLiteral() == "" {
@@ -137,24 +138,24 @@ func TestNodeInterface_Declarations(t *testing.T) {
 
 func TestNodeInterface_Statements(t *testing.T) {
 	nodes := []Node{
-		&BlockStmt{Token: lexer.Token{Lexeme: "{", Line: 1, Column: 1}},
-		&VarDeclStmt{Token: lexer.Token{Lexeme: ":=", Line: 2, Column: 1}},
-		&AssignStmt{Token: lexer.Token{Lexeme: "=", Line: 3, Column: 1}},
-		&ReturnStmt{Token: lexer.Token{Lexeme: "return", Line: 4, Column: 1}},
-		&ContinueStmt{Token: lexer.Token{Lexeme: "continue", Line: 5, Column: 1}},
-		&BreakStmt{Token: lexer.Token{Lexeme: "break", Line: 6, Column: 1}},
-		&IfStmt{Token: lexer.Token{Lexeme: "if", Line: 7, Column: 1}},
-		&ElseStmt{Token: lexer.Token{Lexeme: "else", Line: 8, Column: 1}},
-		&SwitchStmt{Token: lexer.Token{Lexeme: "switch", Line: 9, Column: 1}},
-		&SelectStmt{Token: lexer.Token{Lexeme: "select", Line: 10, Column: 1}},
-		&TypeSwitchStmt{Token: lexer.Token{Lexeme: "switch", Line: 11, Column: 1}},
-		&ForRangeStmt{Token: lexer.Token{Lexeme: "for", Line: 12, Column: 1}},
-		&ForNumericStmt{Token: lexer.Token{Lexeme: "for", Line: 13, Column: 1}},
-		&ForConditionStmt{Token: lexer.Token{Lexeme: "for", Line: 14, Column: 1}},
-		&DeferStmt{Token: lexer.Token{Lexeme: "defer", Line: 15, Column: 1}},
-		&GoStmt{Token: lexer.Token{Lexeme: "go", Line: 16, Column: 1}},
-		&SendStmt{Token: lexer.Token{Lexeme: "send", Line: 17, Column: 1}},
-		&IncDecStmt{Token: lexer.Token{Lexeme: "++", Line: 18, Column: 1}},
+		&BlockStmt{Token: tokenAt("", "{", 1, 1)},
+		&VarDeclStmt{Token: tokenAt("", ":=", 2, 1)},
+		&AssignStmt{Token: tokenAt("", "=", 3, 1)},
+		&ReturnStmt{Token: tokenAt("", "return", 4, 1)},
+		&ContinueStmt{Token: tokenAt("", "continue", 5, 1)},
+		&BreakStmt{Token: tokenAt("", "break", 6, 1)},
+		&IfStmt{Token: tokenAt("", "if", 7, 1)},
+		&ElseStmt{Token: tokenAt("", "else", 8, 1)},
+		&SwitchStmt{Token: tokenAt("", "switch", 9, 1)},
+		&SelectStmt{Token: tokenAt("", "select", 10, 1)},
+		&TypeSwitchStmt{Token: tokenAt("", "switch", 11, 1)},
+		&ForRangeStmt{Token: tokenAt("", "for", 12, 1)},
+		&ForNumericStmt{Token: tokenAt("", "for", 13, 1)},
+		&ForConditionStmt{Token: tokenAt("", "for", 14, 1)},
+		&DeferStmt{Token: tokenAt("", "defer", 15, 1)},
+		&GoStmt{Token: tokenAt("", "go", 16, 1)},
+		&SendStmt{Token: tokenAt("", "send", 17, 1)},
+		&IncDecStmt{Token: tokenAt("", "++", 18, 1)},
 	}
 	for _, n := range nodes {
 		if n.TokenLiteral() == "" {
@@ -169,40 +170,40 @@ func TestNodeInterface_Statements(t *testing.T) {
 
 func TestNodeInterface_Expressions(t *testing.T) {
 	nodes := []Node{
-		&Identifier{Token: lexer.Token{Lexeme: "x", Line: 1, Column: 1}, Value: "x"},
-		&IntegerLiteral{Token: lexer.Token{Lexeme: "42", Line: 2, Column: 1}},
-		&FloatLiteral{Token: lexer.Token{Lexeme: "3.14", Line: 3, Column: 1}},
-		&RuneLiteral{Token: lexer.Token{Lexeme: "'a'", Line: 4, Column: 1}},
-		&StringLiteral{Token: lexer.Token{Lexeme: `"hello"`, Line: 5, Column: 1}},
-		&BooleanLiteral{Token: lexer.Token{Lexeme: "true", Line: 6, Column: 1}},
-		&BinaryExpr{Token: lexer.Token{Lexeme: "+", Line: 7, Column: 1}},
-		&UnaryExpr{Token: lexer.Token{Lexeme: "-", Line: 8, Column: 1}},
-		&PipeExpr{Token: lexer.Token{Lexeme: "|>", Line: 9, Column: 1}},
-		&CallExpr{Token: lexer.Token{Lexeme: "(", Line: 10, Column: 1}},
-		&MethodCallExpr{Token: lexer.Token{Lexeme: ".", Line: 11, Column: 1}},
-		&FieldAccessExpr{Token: lexer.Token{Lexeme: ".", Line: 12, Column: 1}},
-		&IndexExpr{Token: lexer.Token{Lexeme: "[", Line: 13, Column: 1}},
-		&SliceExpr{Token: lexer.Token{Lexeme: "[", Line: 14, Column: 1}},
-		&EmptyExpr{Token: lexer.Token{Lexeme: "empty", Line: 15, Column: 1}},
-		&DiscardExpr{Token: lexer.Token{Lexeme: "discard", Line: 16, Column: 1}},
-		&ErrorExpr{Token: lexer.Token{Lexeme: "error", Line: 17, Column: 1}},
-		&MakeExpr{Token: lexer.Token{Lexeme: "make", Line: 18, Column: 1}},
-		&CloseExpr{Token: lexer.Token{Lexeme: "close", Line: 19, Column: 1}},
-		&PanicExpr{Token: lexer.Token{Lexeme: "panic", Line: 20, Column: 1}},
-		&RecoverExpr{Token: lexer.Token{Lexeme: "recover", Line: 21, Column: 1}},
-		&ReceiveExpr{Token: lexer.Token{Lexeme: "receive", Line: 22, Column: 1}},
-		&TypeCastExpr{Token: lexer.Token{Lexeme: "as", Line: 23, Column: 1}},
-		&TypeAssertionExpr{Token: lexer.Token{Lexeme: ".", Line: 24, Column: 1}},
-		&AddressOfExpr{Token: lexer.Token{Lexeme: "reference", Line: 25, Column: 1}},
-		&DerefExpr{Token: lexer.Token{Lexeme: "dereference", Line: 25, Column: 1}},
-		&ArrowLambda{Token: lexer.Token{Lexeme: "=>", Line: 26, Column: 1}},
-		&ReturnExpr{Token: lexer.Token{Lexeme: "return", Line: 27, Column: 1}},
-		&FunctionLiteral{Token: lexer.Token{Lexeme: "func", Line: 28, Column: 1}},
-		&NamedArgument{Token: lexer.Token{Lexeme: "name", Line: 29, Column: 1}},
-		&StructLiteralExpr{Token: lexer.Token{Lexeme: "User", Line: 30, Column: 1}},
-		&ListLiteralExpr{Token: lexer.Token{Lexeme: "[", Line: 31, Column: 1}},
-		&MapLiteralExpr{Token: lexer.Token{Lexeme: "{", Line: 32, Column: 1}},
-		&BlockExpr{Token: lexer.Token{Lexeme: "INDENT", Line: 33, Column: 1}},
+		&Identifier{Token: tokenAt("", "x", 1, 1), Value: "x"},
+		&IntegerLiteral{Token: tokenAt("", "42", 2, 1)},
+		&FloatLiteral{Token: tokenAt("", "3.14", 3, 1)},
+		&RuneLiteral{Token: tokenAt("", "'a'", 4, 1)},
+		&StringLiteral{Token: tokenAt("", `"hello"`, 5, 1)},
+		&BooleanLiteral{Token: tokenAt("", "true", 6, 1)},
+		&BinaryExpr{Token: tokenAt("", "+", 7, 1)},
+		&UnaryExpr{Token: tokenAt("", "-", 8, 1)},
+		&PipeExpr{Token: tokenAt("", "|>", 9, 1)},
+		&CallExpr{Token: tokenAt("", "(", 10, 1)},
+		&MethodCallExpr{Token: tokenAt("", ".", 11, 1)},
+		&FieldAccessExpr{Token: tokenAt("", ".", 12, 1)},
+		&IndexExpr{Token: tokenAt("", "[", 13, 1)},
+		&SliceExpr{Token: tokenAt("", "[", 14, 1)},
+		&EmptyExpr{Token: tokenAt("", "empty", 15, 1)},
+		&DiscardExpr{Token: tokenAt("", "discard", 16, 1)},
+		&ErrorExpr{Token: tokenAt("", "error", 17, 1)},
+		&MakeExpr{Token: tokenAt("", "make", 18, 1)},
+		&CloseExpr{Token: tokenAt("", "close", 19, 1)},
+		&PanicExpr{Token: tokenAt("", "panic", 20, 1)},
+		&RecoverExpr{Token: tokenAt("", "recover", 21, 1)},
+		&ReceiveExpr{Token: tokenAt("", "receive", 22, 1)},
+		&TypeCastExpr{Token: tokenAt("", "as", 23, 1)},
+		&TypeAssertionExpr{Token: tokenAt("", ".", 24, 1)},
+		&AddressOfExpr{Token: tokenAt("", "reference", 25, 1)},
+		&DerefExpr{Token: tokenAt("", "dereference", 25, 1)},
+		&ArrowLambda{Token: tokenAt("", "=>", 26, 1)},
+		&ReturnExpr{Token: tokenAt("", "return", 27, 1)},
+		&FunctionLiteral{Token: tokenAt("", "func", 28, 1)},
+		&NamedArgument{Token: tokenAt("", "name", 29, 1)},
+		&StructLiteralExpr{Token: tokenAt("", "User", 30, 1)},
+		&ListLiteralExpr{Token: tokenAt("", "[", 31, 1)},
+		&MapLiteralExpr{Token: tokenAt("", "{", 32, 1)},
+		&BlockExpr{Token: tokenAt("", "INDENT", 33, 1)},
 	}
 	for _, n := range nodes {
 		if n.TokenLiteral() == "" {
@@ -217,13 +218,13 @@ func TestNodeInterface_Expressions(t *testing.T) {
 
 func TestNodeInterface_TypeAnnotations(t *testing.T) {
 	nodes := []Node{
-		&PrimitiveType{Token: lexer.Token{Lexeme: "int", Line: 1, Column: 1}},
-		&NamedType{Token: lexer.Token{Lexeme: "User", Line: 2, Column: 1}},
-		&ReferenceType{Token: lexer.Token{Lexeme: "reference", Line: 3, Column: 1}},
-		&ListType{Token: lexer.Token{Lexeme: "list", Line: 4, Column: 1}},
-		&MapType{Token: lexer.Token{Lexeme: "map", Line: 5, Column: 1}},
-		&ChannelType{Token: lexer.Token{Lexeme: "channel", Line: 6, Column: 1}},
-		&FunctionType{Token: lexer.Token{Lexeme: "func", Line: 7, Column: 1}},
+		&PrimitiveType{Token: tokenAt("", "int", 1, 1)},
+		&NamedType{Token: tokenAt("", "User", 2, 1)},
+		&ReferenceType{Token: tokenAt("", "reference", 3, 1)},
+		&ListType{Token: tokenAt("", "list", 4, 1)},
+		&MapType{Token: tokenAt("", "map", 5, 1)},
+		&ChannelType{Token: tokenAt("", "channel", 6, 1)},
+		&FunctionType{Token: tokenAt("", "func", 7, 1)},
 	}
 	for _, n := range nodes {
 		if n.TokenLiteral() == "" {
@@ -242,7 +243,7 @@ func TestNodeInterface_TypeAnnotations(t *testing.T) {
 
 func TestExpressionStmt_DelegatesToExpression(t *testing.T) {
 	expr := &Identifier{
-		Token: lexer.Token{Lexeme: "foo", Line: 10, Column: 5, File: "test.kuki"},
+		Token: tokenAt("test.kuki", "foo", 10, 5),
 		Value: "foo",
 	}
 	stmt := &ExpressionStmt{Expression: expr}
@@ -262,7 +263,7 @@ func TestExpressionStmt_DelegatesToExpression(t *testing.T) {
 
 func TestVarDeclStmt_ImplementsBothInterfaces(t *testing.T) {
 	stmt := &VarDeclStmt{
-		Token: lexer.Token{Lexeme: ":=", Line: 1, Column: 1},
+		Token: tokenAt("", ":=", 1, 1),
 		Names: []*Identifier{{Value: "x"}},
 	}
 
@@ -272,3 +273,9 @@ func TestVarDeclStmt_ImplementsBothInterfaces(t *testing.T) {
 	// Verify it satisfies Declaration
 	var _ Declaration = stmt
 }
+
+// tokenAt returns a token with the given lexeme at line:column of file.
+func tokenAt(file, lexeme string, line, column int) lexer.Token {
+	f := lexer.NewFile(file, strings.Repeat(strings.Repeat(" ", column)+"\n", line))
+	return f.Token(lexer.TOKEN_IDENTIFIER, lexeme, f.Offset(line, column))
+}
@@ -0,0 +1,65 @@
+package ast
+
+import (
+	"errors"
+	"strings"
+)
+
+// CommandWords splits the string of a `$ "..."` command expression into
+// the program name and its arguments. Words are separated by whitespace in
+// the literal text; single quotes group text with spaces into one word. An
+// interpolated value always stays inside the word it appears in, however
+// many spaces it holds, so no value can add arguments: there is no shell.
+func CommandWords(lit *StringLiteral) ([][]*StringInterpolation, error) {
+	parts := lit.Parts
+	if !lit.Interpolated {
+		parts = []*StringInterpolation{{IsLiteral: true, Literal: lit.Value}}
+	}
+
+	var words [][]*StringInterpolation
+	var word []*StringInterpolation
+	var text strings.Builder
+	inWord, quoted := false, false
+	flushText := func() {
+		if text.Len() > 0 {
+			word = append(word, &StringInterpolation{IsLiteral: true, Literal: text.String()})
+			text.Reset()
+		}
+	}
+	endWord := func() {
+		flushText()
+		if inWord {
+			words = append(words, word)
+		}
+		word, inWord = nil, false
+	}
+
+	for _, part := range parts {
+		if !part.IsLiteral {
+			flushText()
+			word = append(word, part)
+			inWord = true
+			continue
+		}
+		for _, r := range part.Literal {
+			switch {
+			case r == '\'':
+				quoted = !quoted
+				inWord = true
+			case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+				endWord()
+			default:
+				text.WriteRune(r)
+				inWord = true
+			}
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated ' quote in command")
+	}
+	endWord()
+	if len(words) == 0 {
+		return nil, errors.New("empty command")
+	}
+	return words, nil
+}
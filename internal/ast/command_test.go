@@ -0,0 +1,54 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandWords(t *testing.T) {
+	name := &Identifier{Value: "name"}
+	tests := []struct {
+		lit   *StringLiteral
+		words []string // literal text, with {} marking an interpolated value
+	}{
+		{&StringLiteral{Value: "git rev-parse  HEAD"}, []string{"git", "rev-parse", "HEAD"}},
+		{&StringLiteral{Value: "printf '%s %s' 'a b' ''"}, []string{"printf", "%s %s", "a b", ""}},
+		{&StringLiteral{Interpolated: true, Parts: []*StringInterpolation{
+			{IsLiteral: true, Literal: "echo hi "},
+			{Expr: name},
+			{IsLiteral: true, Literal: ".txt done"},
+		}}, []string{"echo", "hi", "{}.txt", "done"}},
+	}
+	for _, tt := range tests {
+		words, err := CommandWords(tt.lit)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.lit.Value, err)
+		}
+		var got []string
+		for _, word := range words {
+			var b strings.Builder
+			for _, part := range word {
+				if part.IsLiteral {
+					b.WriteString(part.Literal)
+				} else {
+					b.WriteString("{}")
+				}
+			}
+			got = append(got, b.String())
+		}
+		if strings.Join(got, "|") != strings.Join(tt.words, "|") {
+			t.Errorf("%q: expected words %q, got %q", tt.lit.Value, tt.words, got)
+		}
+	}
+}
+
+func TestCommandWordsErrors(t *testing.T) {
+	for value, want := range map[string]string{
+		"   ":        "empty command",
+		"echo 'oops": "unterminated ' quote in command",
+	} {
+		if _, err := CommandWords(&StringLiteral{Value: value}); err == nil || err.Error() != want {
+			t.Errorf("%q: expected error %q, got %v", value, want, err)
+		}
+	}
+}
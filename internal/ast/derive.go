@@ -0,0 +1,43 @@
+package ast
+
+import "strings"
+
+// Derive is one name in an `@derive` directive on a type, e.g. "json" in
+// `@derive json, stringer`. Codegen writes the members it names.
+type Derive struct {
+	Directive Directive
+	Name      string
+}
+
+// TypeDerives returns the derives requested on decl, in order. Names may be
+// separated by commas or spaces, and a type may carry several `@derive`
+// (or `# kuki:derive`) directives.
+func TypeDerives(decl *TypeDecl) []Derive {
+	var derives []Derive
+	for _, d := range decl.Directives {
+		if d.Name != "derive" {
+			continue
+		}
+		for _, arg := range d.Args {
+			for name := range strings.SplitSeq(arg, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					derives = append(derives, Derive{Directive: d, Name: name})
+				}
+			}
+		}
+	}
+	return derives
+}
+
+// ConstructorName is the name of the function `@derive new` writes for a
+// type: NewPoint for Point, newPoint for point.
+func ConstructorName(typeName string) string {
+	if typeName == "" {
+		return ""
+	}
+	rest := strings.ToUpper(typeName[:1]) + typeName[1:]
+	if typeName[0] >= 'a' && typeName[0] <= 'z' {
+		return "new" + rest
+	}
+	return "New" + rest
+}
@@ -0,0 +1,274 @@
+package ast
+
+// Rewrite helpers for source-to-source tools (kukicha fix). They visit the
+// same expressions as the Walk helpers, bottom-up, and store whatever fn
+// returns back into the parent. fn returns its argument to leave an
+// expression unchanged.
+
+// RewriteProgram rewrites every expression in program's declarations.
+func RewriteProgram(program *Program, fn func(Expression) Expression) {
+	for _, decl := range program.Declarations {
+		switch d := decl.(type) {
+		case *FunctionDecl:
+			rewriteParams(d.Parameters, fn)
+			RewriteBlock(d.Body, fn)
+		case *ConstDecl:
+			for _, spec := range d.Specs {
+				spec.Value = RewriteExpr(spec.Value, fn)
+			}
+		case *VarDeclStmt:
+			RewriteStmt(d, fn)
+		case *TargetDecl:
+			RewriteProgram(&Program{Declarations: d.Declarations}, fn)
+			RewriteProgram(&Program{Declarations: d.Otherwise}, fn)
+		}
+	}
+}
+
+// RewriteBlock rewrites every expression in block.
+func RewriteBlock(block *BlockStmt, fn func(Expression) Expression) {
+	if block == nil {
+		return
+	}
+	for _, stmt := range block.Statements {
+		RewriteStmt(stmt, fn)
+	}
+}
+
+// RewriteStmt rewrites every expression reachable from stmt.
+func RewriteStmt(stmt Statement, fn func(Expression) Expression) {
+	if stmt == nil {
+		return
+	}
+	switch s := stmt.(type) {
+	case *VarDeclStmt:
+		rewriteList(s.Values, fn)
+		rewriteOnErr(s.OnErr, fn)
+	case *AssignStmt:
+		rewriteList(s.Targets, fn)
+		rewriteList(s.Values, fn)
+		rewriteOnErr(s.OnErr, fn)
+	case *ReturnStmt:
+		rewriteList(s.Values, fn)
+	case *YieldStmt:
+		rewriteList(s.Values, fn)
+	case *IncDecStmt:
+		s.Variable = RewriteExpr(s.Variable, fn)
+	case *IfStmt:
+		RewriteStmt(s.Init, fn)
+		s.Condition = RewriteExpr(s.Condition, fn)
+		RewriteBlock(s.Consequence, fn)
+		RewriteStmt(s.Alternative, fn)
+	case *ElseStmt:
+		RewriteBlock(s.Body, fn)
+	case *SwitchStmt:
+		s.Expression = RewriteExpr(s.Expression, fn)
+		rewriteSwitchCases(s, fn)
+	case *SelectStmt:
+		for _, c := range s.Cases {
+			if c.Recv != nil {
+				c.Recv.Channel = RewriteExpr(c.Recv.Channel, fn)
+			}
+			if c.Send != nil {
+				RewriteStmt(c.Send, fn)
+			}
+			RewriteBlock(c.Body, fn)
+		}
+		if s.Otherwise != nil {
+			RewriteBlock(s.Otherwise.Body, fn)
+		}
+	case *TypeSwitchStmt:
+		s.Expression = RewriteExpr(s.Expression, fn)
+		rewriteTypeSwitchCases(s, fn)
+	case *ForRangeStmt:
+		s.Collection = RewriteExpr(s.Collection, fn)
+		RewriteBlock(s.Body, fn)
+	case *ForNumericStmt:
+		s.Start = RewriteExpr(s.Start, fn)
+		s.End = RewriteExpr(s.End, fn)
+		RewriteBlock(s.Body, fn)
+	case *ForConditionStmt:
+		s.Condition = RewriteExpr(s.Condition, fn)
+		RewriteBlock(s.Body, fn)
+	case *DeferStmt:
+		s.Call = RewriteExpr(s.Call, fn)
+	case *GoStmt:
+		s.Call = RewriteExpr(s.Call, fn)
+		RewriteBlock(s.Block, fn)
+	case *SafelyStmt:
+		RewriteBlock(s.Body, fn)
+		rewriteOnErr(s.OnErr, fn)
+	case *RequireStmt:
+		s.Condition = RewriteExpr(s.Condition, fn)
+		RewriteBlock(s.Else, fn)
+	case *FailStmt:
+		s.Message = RewriteExpr(s.Message, fn)
+		s.Code = RewriteExpr(s.Code, fn)
+	case *SendStmt:
+		s.Value = RewriteExpr(s.Value, fn)
+		s.Channel = RewriteExpr(s.Channel, fn)
+	case *ExpressionStmt:
+		s.Expression = RewriteExpr(s.Expression, fn)
+		rewriteOnErr(s.OnErr, fn)
+	case *TargetStmt:
+		RewriteBlock(s.Body, fn)
+		if s.Otherwise != nil {
+			RewriteBlock(s.Otherwise.Body, fn)
+		}
+	}
+}
+
+// RewriteExpr rewrites expr's sub-expressions, then returns fn(expr).
+// A nil expr is returned unchanged without calling fn.
+func RewriteExpr(expr Expression, fn func(Expression) Expression) Expression {
+	if expr == nil {
+		return nil
+	}
+	switch e := expr.(type) {
+	case *StringLiteral:
+		for _, part := range e.Parts {
+			if !part.IsLiteral {
+				part.Expr = RewriteExpr(part.Expr, fn)
+			}
+		}
+	case *BinaryExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		e.Right = RewriteExpr(e.Right, fn)
+	case *ComparisonChainExpr:
+		rewriteList(e.Operands, fn)
+	case *RangeCheckExpr:
+		e.Value = RewriteExpr(e.Value, fn)
+		e.Low = RewriteExpr(e.Low, fn)
+		e.High = RewriteExpr(e.High, fn)
+	case *WhenPattern:
+		e.Low = RewriteExpr(e.Low, fn)
+		e.Value = RewriteExpr(e.Value, fn)
+	case *UnaryExpr:
+		e.Right = RewriteExpr(e.Right, fn)
+	case *PipeExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		e.Right = RewriteExpr(e.Right, fn)
+	case *ParallelPipeExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		e.Right = RewriteExpr(e.Right, fn)
+	case *CallExpr:
+		e.Function = RewriteExpr(e.Function, fn)
+		rewriteList(e.Arguments, fn)
+		for _, na := range e.NamedArguments {
+			na.Value = RewriteExpr(na.Value, fn)
+		}
+	case *MethodCallExpr:
+		e.Object = RewriteExpr(e.Object, fn)
+		rewriteList(e.Arguments, fn)
+		for _, na := range e.NamedArguments {
+			na.Value = RewriteExpr(na.Value, fn)
+		}
+	case *FieldAccessExpr:
+		e.Object = RewriteExpr(e.Object, fn)
+	case *IndexExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		e.Index = RewriteExpr(e.Index, fn)
+	case *SliceExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		e.Start = RewriteExpr(e.Start, fn)
+		e.End = RewriteExpr(e.End, fn)
+	case *ErrorExpr:
+		e.Message = RewriteExpr(e.Message, fn)
+	case *PanicExpr:
+		e.Message = RewriteExpr(e.Message, fn)
+	case *OnErrExpr:
+		e.Expression = RewriteExpr(e.Expression, fn)
+		rewriteOnErr(e.OnErr, fn)
+	case *CommandExpr:
+		if lit, ok := RewriteExpr(e.Command, fn).(*StringLiteral); ok {
+			e.Command = lit
+		}
+	case *ReturnExpr:
+		rewriteList(e.Values, fn)
+	case *MakeExpr:
+		rewriteList(e.Args, fn)
+	case *CloseExpr:
+		e.Channel = RewriteExpr(e.Channel, fn)
+	case *ReceiveExpr:
+		e.Channel = RewriteExpr(e.Channel, fn)
+	case *WaitExpr:
+		e.Channel = RewriteExpr(e.Channel, fn)
+		e.Timeout = RewriteExpr(e.Timeout, fn)
+	case *AddressOfExpr:
+		e.Operand = RewriteExpr(e.Operand, fn)
+	case *DerefExpr:
+		e.Operand = RewriteExpr(e.Operand, fn)
+	case *TypeCastExpr:
+		e.Expression = RewriteExpr(e.Expression, fn)
+	case *TypeAssertionExpr:
+		e.Expression = RewriteExpr(e.Expression, fn)
+	case *StructLiteralExpr:
+		for _, f := range e.Fields {
+			f.Value = RewriteExpr(f.Value, fn)
+		}
+	case *ListLiteralExpr:
+		rewriteList(e.Elements, fn)
+	case *MapLiteralExpr:
+		for _, pair := range e.Pairs {
+			pair.Key = RewriteExpr(pair.Key, fn)
+			pair.Value = RewriteExpr(pair.Value, fn)
+		}
+	case *FunctionLiteral:
+		rewriteParams(e.Parameters, fn)
+		RewriteBlock(e.Body, fn)
+	case *ArrowLambda:
+		e.Body = RewriteExpr(e.Body, fn)
+		RewriteBlock(e.Block, fn)
+	case *BlockExpr:
+		RewriteBlock(e.Body, fn)
+	case *BuildStringExpr:
+		RewriteBlock(e.Body, fn)
+	case *PipedSwitchExpr:
+		e.Left = RewriteExpr(e.Left, fn)
+		switch s := e.Switch.(type) {
+		case *SwitchStmt:
+			rewriteSwitchCases(s, fn)
+		case *TypeSwitchStmt:
+			rewriteTypeSwitchCases(s, fn)
+		}
+	}
+	return fn(expr)
+}
+
+func rewriteList(exprs []Expression, fn func(Expression) Expression) {
+	for i, e := range exprs {
+		exprs[i] = RewriteExpr(e, fn)
+	}
+}
+
+func rewriteParams(params []*Parameter, fn func(Expression) Expression) {
+	for _, p := range params {
+		p.DefaultValue = RewriteExpr(p.DefaultValue, fn)
+	}
+}
+
+func rewriteOnErr(clause *OnErrClause, fn func(Expression) Expression) {
+	if clause != nil {
+		clause.Handler = RewriteExpr(clause.Handler, fn)
+	}
+}
+
+func rewriteSwitchCases(s *SwitchStmt, fn func(Expression) Expression) {
+	for _, c := range s.Cases {
+		rewriteList(c.Values, fn)
+		c.Guard = RewriteExpr(c.Guard, fn)
+		RewriteBlock(c.Body, fn)
+	}
+	if s.Otherwise != nil {
+		RewriteBlock(s.Otherwise.Body, fn)
+	}
+}
+
+func rewriteTypeSwitchCases(s *TypeSwitchStmt, fn func(Expression) Expression) {
+	for _, c := range s.Cases {
+		RewriteBlock(c.Body, fn)
+	}
+	if s.Otherwise != nil {
+		RewriteBlock(s.Otherwise.Body, fn)
+	}
+}
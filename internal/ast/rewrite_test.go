@@ -0,0 +1,55 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/duber000/kukicha/internal/lexer"
+)
+
+// renameIdentifiers returns a rewrite function that renames identifiers
+// found in names.
+func renameIdentifiers(names map[string]string) func(Expression) Expression {
+	return func(expr Expression) Expression {
+		if id, ok := expr.(*Identifier); ok {
+			if to, ok := names[id.Value]; ok {
+				return &Identifier{Value: to}
+			}
+		}
+		return expr
+	}
+}
+
+func TestRewriteExprOnErrExpr(t *testing.T) {
+	expr := &OnErrExpr{
+		Expression: &CallExpr{Function: &Identifier{Value: "getPort"}},
+		OnErr:      &OnErrClause{Handler: &Identifier{Value: "fallback"}},
+	}
+	RewriteExpr(expr, renameIdentifiers(map[string]string{"getPort": "loadPort", "fallback": "defaultPort"}))
+
+	call, ok := expr.Expression.(*CallExpr)
+	if !ok {
+		t.Fatalf("expected the call to stay a call, got %T", expr.Expression)
+	}
+	if got := call.Function.(*Identifier).Value; got != "loadPort" {
+		t.Errorf("expected the fallible call to be rewritten, got %q", got)
+	}
+	if got := expr.OnErr.Handler.(*Identifier).Value; got != "defaultPort" {
+		t.Errorf("expected the onerr handler to be rewritten, got %q", got)
+	}
+}
+
+func TestRewriteExprComparisonChain(t *testing.T) {
+	expr := &ComparisonChainExpr{
+		Operands:  []Expression{&Identifier{Value: "low"}, &Identifier{Value: "x"}, &Identifier{Value: "high"}},
+		Operators: []lexer.Token{{Lexeme: "<="}, {Lexeme: "<"}},
+	}
+	RewriteExpr(expr, renameIdentifiers(map[string]string{"low": "lo", "x": "n", "high": "hi"}))
+
+	var got []string
+	for _, operand := range expr.Operands {
+		got = append(got, operand.(*Identifier).Value)
+	}
+	if len(got) != 3 || got[0] != "lo" || got[1] != "n" || got[2] != "hi" {
+		t.Errorf("expected every operand to be rewritten, got %v", got)
+	}
+}
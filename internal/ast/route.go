@@ -0,0 +1,147 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteMethods are the HTTP methods a `# route:` directive accepts.
+var RouteMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// Route is a `# route: METHOD /path` directive on a function. The http
+// target registers the function as the handler for Method and Path.
+type Route struct {
+	Directive Directive
+	Method    string
+	Path      string
+	Params    []string // Path parameter names (`{id}`), in order
+}
+
+// Pattern returns the route as a net/http ServeMux pattern ("GET /users/{id}").
+func (r *Route) Pattern() string {
+	return r.Method + " " + r.Path
+}
+
+// FunctionRoute returns the route declared on fn, or nil when fn has no
+// `# route:` directive. A malformed directive is reported as an error
+// together with the directive, so callers can point at it.
+func FunctionRoute(fn *FunctionDecl) (*Route, *Directive, error) {
+	for i := range fn.Directives {
+		d := &fn.Directives[i]
+		if d.Name != "route" {
+			continue
+		}
+		r, err := ParseRoute(*d)
+		return r, d, err
+	}
+	return nil, nil, nil
+}
+
+// ParseRoute parses the arguments of a `# route:` directive.
+func ParseRoute(d Directive) (*Route, error) {
+	if len(d.Args) != 2 {
+		return nil, fmt.Errorf("# route: expects a method and a path, e.g. # route: GET /users/{id}")
+	}
+	method := strings.ToUpper(d.Args[0])
+	known := false
+	for _, m := range RouteMethods {
+		if m == method {
+			known = true
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("# route: unknown method '%s' (expected one of %s)", d.Args[0], strings.Join(RouteMethods, ", "))
+	}
+	path := d.Args[1]
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("# route: path '%s' must start with '/'", path)
+	}
+
+	r := &Route{Directive: d, Method: method, Path: path}
+	seen := make(map[string]bool)
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			return nil, fmt.Errorf("# route: path parameter '%s' must fill a whole segment, e.g. /users/{id}", segment)
+		}
+		name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+		if !isRouteParamName(name) {
+			return nil, fmt.Errorf("# route: invalid path parameter name '%s'", segment)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("# route: path parameter '%s' appears twice", name)
+		}
+		seen[name] = true
+		r.Params = append(r.Params, name)
+	}
+	return r, nil
+}
+
+func isRouteParamName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// RouteParamTypes are the types a path parameter can be bound to.
+var RouteParamTypes = []string{
+	"string", "bool", "float32", "float64",
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64",
+}
+
+// IsRouteParamType reports whether a path parameter can be bound to t.
+func IsRouteParamType(t TypeAnnotation) bool {
+	p, ok := t.(*PrimitiveType)
+	if !ok {
+		return false
+	}
+	for _, name := range RouteParamTypes {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHTTPWriterType reports whether t is http.ResponseWriter, where httpPkg
+// is the name net/http is imported under.
+func IsHTTPWriterType(t TypeAnnotation, httpPkg string) bool {
+	n, ok := t.(*NamedType)
+	return ok && n.Name == httpPkg+".ResponseWriter"
+}
+
+// IsHTTPRequestType reports whether t is `reference http.Request`, where
+// httpPkg is the name net/http is imported under.
+func IsHTTPRequestType(t TypeAnnotation, httpPkg string) bool {
+	ref, ok := t.(*ReferenceType)
+	if !ok {
+		return false
+	}
+	n, ok := ref.ElementType.(*NamedType)
+	return ok && n.Name == httpPkg+".Request"
+}
+
+// ImportName returns the name path is imported under in program, or "" when
+// it is not imported.
+func ImportName(program *Program, path string) string {
+	for _, imp := range program.Imports {
+		if imp.Path.Value != path {
+			continue
+		}
+		if imp.Alias != nil {
+			return imp.Alias.Value
+		}
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	return ""
+}
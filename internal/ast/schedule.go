@@ -0,0 +1,131 @@
+package ast
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a `# schedule: "*/5 * * * *"` directive on a function. The
+// worker target registers the function with stdlib/cron to run on Spec.
+type Schedule struct {
+	Directive Directive
+	Spec      string
+}
+
+// FunctionSchedule returns the schedule declared on fn, or nil when fn has
+// no `# schedule:` directive. A malformed directive is reported as an error
+// together with the directive, so callers can point at it.
+func FunctionSchedule(fn *FunctionDecl) (*Schedule, *Directive, error) {
+	for i := range fn.Directives {
+		d := &fn.Directives[i]
+		if d.Name != "schedule" {
+			continue
+		}
+		s, err := ParseSchedule(*d)
+		return s, d, err
+	}
+	return nil, nil, nil
+}
+
+// ParseSchedule parses the arguments of a `# schedule:` directive. The
+// expression may be quoted or not; it is checked the way stdlib/cron's
+// Parse reads it, so a schedule that compiles also runs.
+func ParseSchedule(d Directive) (*Schedule, error) {
+	spec := strings.Join(d.Args, " ")
+	if spec == "" {
+		return nil, fmt.Errorf(`# schedule: expects a cron expression, e.g. # schedule: "*/5 * * * *"`)
+	}
+	if err := checkCronSpec(spec); err != nil {
+		return nil, fmt.Errorf("# schedule: %v", err)
+	}
+	return &Schedule{Directive: d, Spec: spec}, nil
+}
+
+// cronShorthands are the @ forms a schedule accepts besides "@every <duration>".
+var cronShorthands = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+var cronMonths = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+var cronWeekdays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+func checkCronSpec(spec string) error {
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(interval)); err != nil || d <= 0 {
+			return fmt.Errorf("bad interval in '%s' (expected a duration such as 90s)", spec)
+		}
+		return nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		if !slices.Contains(cronShorthands, spec) {
+			return fmt.Errorf("unknown shorthand '%s' (expected @every <duration> or one of %s)", spec, strings.Join(cronShorthands, ", "))
+		}
+		return nil
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return fmt.Errorf("'%s' has %d fields, expected 5 (minute hour day month weekday)", spec, len(fields))
+	}
+	checks := []struct {
+		name      string
+		low, high int
+		names     []string
+	}{
+		{"minute", 0, 59, nil},
+		{"hour", 0, 23, nil},
+		{"day", 1, 31, nil},
+		{"month", 1, 12, cronMonths},
+		{"weekday", 0, 7, cronWeekdays},
+	}
+	for i, c := range checks {
+		if err := checkCronField(c.name, fields[i], c.low, c.high, c.names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCronField(name, text string, low, high int, names []string) error {
+	for part := range strings.SplitSeq(text, ",") {
+		span, stepText, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(stepText); err != nil || n <= 0 {
+				return fmt.Errorf("bad step '%s' in %s field '%s'", stepText, name, text)
+			}
+		}
+		if span == "*" {
+			continue
+		}
+		start, end, isRange := strings.Cut(span, "-")
+		first, last := cronValue(start, names), high
+		if isRange {
+			last = cronValue(end, names)
+		} else if !hasStep {
+			last = first
+		}
+		if first < low || last > high || first > last {
+			return fmt.Errorf("%s field '%s' must be within %d-%d", name, text, low, high)
+		}
+	}
+	return nil
+}
+
+// cronValue reads a number or a name from names, returning -1 for neither.
+func cronValue(text string, names []string) int {
+	if n, err := strconv.Atoi(text); err == nil {
+		return n
+	}
+	if text == "" {
+		return -1
+	}
+	return slices.Index(names, strings.ToUpper(text))
+}
+
+// IsContextType reports whether t is context.Context, where contextPkg is
+// the name the context package is imported under.
+func IsContextType(t TypeAnnotation, contextPkg string) bool {
+	n, ok := t.(*NamedType)
+	return ok && n.Name == contextPkg+".Context"
+}
@@ -0,0 +1,162 @@
+package ast
+
+// Targets are the build targets `# target:` and `when target` accept. A
+// program with no target is built as DefaultTarget.
+var Targets = []string{"cli", "mcp", "a2a", "http", "worker"}
+
+// DefaultTarget is the target of programs that declare none.
+const DefaultTarget = "cli"
+
+// IsTarget reports whether name is a known build target.
+func IsTarget(name string) bool {
+	for _, t := range Targets {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// targetMatches reports whether a `when target` list selects target.
+func targetMatches(names []*Identifier, target string) bool {
+	if target == "" {
+		target = DefaultTarget
+	}
+	for _, n := range names {
+		if n.Value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectTarget replaces every `when target` block in program with the
+// branch for target ("" means DefaultTarget), in place. Blocks inside
+// function literals and lambdas are resolved too. Running it again is a
+// no-op, so the analyzer and codegen can both call it.
+func SelectTarget(program *Program, target string) {
+	program.Declarations = selectDecls(program.Declarations, target)
+
+	resolveClosures := func(e Expression) bool {
+		switch e := e.(type) {
+		case *FunctionLiteral:
+			selectBlock(e.Body, target)
+		case *ArrowLambda:
+			selectBlock(e.Block, target)
+		case *BlockExpr:
+			selectBlock(e.Body, target)
+		case *BuildStringExpr:
+			selectBlock(e.Body, target)
+		}
+		return false
+	}
+	for _, decl := range program.Declarations {
+		switch d := decl.(type) {
+		case *FunctionDecl:
+			if d.Body != nil {
+				selectBlock(d.Body, target)
+				WalkBlock(d.Body, resolveClosures)
+			}
+		case *VarDeclStmt:
+			WalkStmt(d, resolveClosures)
+		}
+	}
+}
+
+func selectDecls(decls []Declaration, target string) []Declaration {
+	out := make([]Declaration, 0, len(decls))
+	for _, decl := range decls {
+		td, ok := decl.(*TargetDecl)
+		if !ok {
+			out = append(out, decl)
+			continue
+		}
+		if targetMatches(td.Targets, target) {
+			out = append(out, selectDecls(td.Declarations, target)...)
+		} else {
+			out = append(out, selectDecls(td.Otherwise, target)...)
+		}
+	}
+	return out
+}
+
+// selectBlock resolves the `when target` blocks in block and in the blocks
+// of its compound statements.
+func selectBlock(block *BlockStmt, target string) {
+	if block == nil {
+		return
+	}
+	block.Statements = selectStmts(block.Statements, target)
+	WalkStmts(block, func(stmt Statement) bool {
+		for _, b := range nestedBlocks(stmt) {
+			b.Statements = selectStmts(b.Statements, target)
+		}
+		return false
+	})
+}
+
+func selectStmts(stmts []Statement, target string) []Statement {
+	out := make([]Statement, 0, len(stmts))
+	for _, stmt := range stmts {
+		ts, ok := stmt.(*TargetStmt)
+		if !ok {
+			out = append(out, stmt)
+			continue
+		}
+		if targetMatches(ts.Targets, target) {
+			out = append(out, selectStmts(ts.Body.Statements, target)...)
+		} else if ts.Otherwise != nil {
+			out = append(out, selectStmts(ts.Otherwise.Body.Statements, target)...)
+		}
+	}
+	return out
+}
+
+// nestedBlocks returns the blocks directly owned by a compound statement.
+func nestedBlocks(stmt Statement) []*BlockStmt {
+	var blocks []*BlockStmt
+	add := func(b *BlockStmt) {
+		if b != nil {
+			blocks = append(blocks, b)
+		}
+	}
+	addOtherwise := func(o *OtherwiseCase) {
+		if o != nil {
+			add(o.Body)
+		}
+	}
+	switch s := stmt.(type) {
+	case *IfStmt:
+		add(s.Consequence)
+	case *ElseStmt:
+		add(s.Body)
+	case *SwitchStmt:
+		for _, c := range s.Cases {
+			add(c.Body)
+		}
+		addOtherwise(s.Otherwise)
+	case *TypeSwitchStmt:
+		for _, c := range s.Cases {
+			add(c.Body)
+		}
+		addOtherwise(s.Otherwise)
+	case *SelectStmt:
+		for _, c := range s.Cases {
+			add(c.Body)
+		}
+		addOtherwise(s.Otherwise)
+	case *ForRangeStmt:
+		add(s.Body)
+	case *ForNumericStmt:
+		add(s.Body)
+	case *ForConditionStmt:
+		add(s.Body)
+	case *GoStmt:
+		add(s.Block)
+	case *SafelyStmt:
+		add(s.Body)
+	case *RequireStmt:
+		add(s.Else)
+	}
+	return blocks
+}
@@ -0,0 +1,546 @@
+package ast
+
+// Walk helpers shared by the semantic analyzer and codegen. Each function
+// calls visit for every expression reachable from its root, including the
+// expression holes of interpolated strings, and stops as soon as visit
+// returns true.
+
+// WalkBlock walks all statements in block, short-circuiting on the first true.
+func WalkBlock(block *BlockStmt, visit func(Expression) bool) bool {
+	for _, stmt := range block.Statements {
+		if WalkStmt(stmt, visit) {
+			return true
+		}
+	}
+	return false
+}
+
+// WalkStmt walks all expressions reachable from stmt.
+func WalkStmt(stmt Statement, visit func(Expression) bool) bool {
+	if stmt == nil {
+		return false
+	}
+	switch s := stmt.(type) {
+	case *VarDeclStmt:
+		for _, v := range s.Values {
+			if WalkExpr(v, visit) {
+				return true
+			}
+		}
+		if s.OnErr != nil && WalkExpr(s.OnErr.Handler, visit) {
+			return true
+		}
+	case *AssignStmt:
+		for _, t := range s.Targets {
+			if WalkExpr(t, visit) {
+				return true
+			}
+		}
+		for _, v := range s.Values {
+			if WalkExpr(v, visit) {
+				return true
+			}
+		}
+		if s.OnErr != nil && WalkExpr(s.OnErr.Handler, visit) {
+			return true
+		}
+	case *ReturnStmt:
+		for _, v := range s.Values {
+			if WalkExpr(v, visit) {
+				return true
+			}
+		}
+	case *YieldStmt:
+		for _, v := range s.Values {
+			if WalkExpr(v, visit) {
+				return true
+			}
+		}
+	case *IncDecStmt:
+		if WalkExpr(s.Variable, visit) {
+			return true
+		}
+	case *IfStmt:
+		if s.Init != nil && WalkStmt(s.Init, visit) {
+			return true
+		}
+		if WalkExpr(s.Condition, visit) {
+			return true
+		}
+		if s.Consequence != nil && WalkBlock(s.Consequence, visit) {
+			return true
+		}
+		if s.Alternative != nil && WalkStmt(s.Alternative, visit) {
+			return true
+		}
+	case *ElseStmt:
+		if s.Body != nil && WalkBlock(s.Body, visit) {
+			return true
+		}
+	case *SwitchStmt:
+		if s.Expression != nil && WalkExpr(s.Expression, visit) {
+			return true
+		}
+		for _, c := range s.Cases {
+			for _, v := range c.Values {
+				if WalkExpr(v, visit) {
+					return true
+				}
+			}
+			if c.Guard != nil && WalkExpr(c.Guard, visit) {
+				return true
+			}
+			if c.Body != nil && WalkBlock(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+			return true
+		}
+	case *SelectStmt:
+		for _, c := range s.Cases {
+			if c.Recv != nil && WalkExpr(c.Recv, visit) {
+				return true
+			}
+			if c.Send != nil {
+				if WalkExpr(c.Send.Value, visit) {
+					return true
+				}
+				if WalkExpr(c.Send.Channel, visit) {
+					return true
+				}
+			}
+			if c.Body != nil && WalkBlock(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+			return true
+		}
+	case *TypeSwitchStmt:
+		if WalkExpr(s.Expression, visit) {
+			return true
+		}
+		for _, c := range s.Cases {
+			if c.Body != nil && WalkBlock(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+			return true
+		}
+	case *ForRangeStmt:
+		if WalkExpr(s.Collection, visit) {
+			return true
+		}
+		if s.Body != nil && WalkBlock(s.Body, visit) {
+			return true
+		}
+	case *ForNumericStmt:
+		if WalkExpr(s.Start, visit) {
+			return true
+		}
+		if WalkExpr(s.End, visit) {
+			return true
+		}
+		if s.Body != nil && WalkBlock(s.Body, visit) {
+			return true
+		}
+	case *ForConditionStmt:
+		if WalkExpr(s.Condition, visit) {
+			return true
+		}
+		if s.Body != nil && WalkBlock(s.Body, visit) {
+			return true
+		}
+	case *DeferStmt:
+		if WalkExpr(s.Call, visit) {
+			return true
+		}
+	case *GoStmt:
+		if s.Call != nil && WalkExpr(s.Call, visit) {
+			return true
+		}
+		if s.Block != nil && WalkBlock(s.Block, visit) {
+			return true
+		}
+	case *SafelyStmt:
+		if WalkBlock(s.Body, visit) {
+			return true
+		}
+		if s.OnErr != nil && WalkExpr(s.OnErr.Handler, visit) {
+			return true
+		}
+	case *RequireStmt:
+		if WalkExpr(s.Condition, visit) {
+			return true
+		}
+		if s.Else != nil && WalkBlock(s.Else, visit) {
+			return true
+		}
+	case *FailStmt:
+		if WalkExpr(s.Message, visit) {
+			return true
+		}
+		if s.Code != nil && WalkExpr(s.Code, visit) {
+			return true
+		}
+	case *SendStmt:
+		if WalkExpr(s.Value, visit) {
+			return true
+		}
+		if WalkExpr(s.Channel, visit) {
+			return true
+		}
+	case *ExpressionStmt:
+		if WalkExpr(s.Expression, visit) {
+			return true
+		}
+		if s.OnErr != nil && WalkExpr(s.OnErr.Handler, visit) {
+			return true
+		}
+	case *TargetStmt:
+		if s.Body != nil && WalkBlock(s.Body, visit) {
+			return true
+		}
+		if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+			return true
+		}
+	}
+	return false
+}
+
+// WalkExpr calls visit(expr) first; if visit returns true, walkExpr returns
+// true immediately (short-circuit). Otherwise it recurses into all
+// sub-expressions and returns true as soon as any recursive call does.
+func WalkExpr(expr Expression, visit func(Expression) bool) bool {
+	if expr == nil {
+		return false
+	}
+	if visit(expr) {
+		return true
+	}
+	switch e := expr.(type) {
+	case *StringLiteral:
+		for _, part := range e.Parts {
+			if !part.IsLiteral && WalkExpr(part.Expr, visit) {
+				return true
+			}
+		}
+	case *BinaryExpr:
+		return WalkExpr(e.Left, visit) || WalkExpr(e.Right, visit)
+	case *ComparisonChainExpr:
+		for _, operand := range e.Operands {
+			if WalkExpr(operand, visit) {
+				return true
+			}
+		}
+		return false
+	case *RangeCheckExpr:
+		return WalkExpr(e.Value, visit) || WalkExpr(e.Low, visit) || WalkExpr(e.High, visit)
+	case *WhenPattern:
+		return WalkExpr(e.Low, visit) || WalkExpr(e.Value, visit)
+	case *UnaryExpr:
+		return WalkExpr(e.Right, visit)
+	case *PipeExpr:
+		return WalkExpr(e.Left, visit) || WalkExpr(e.Right, visit)
+	case *ParallelPipeExpr:
+		return WalkExpr(e.Left, visit) || WalkExpr(e.Right, visit)
+	case *CallExpr:
+		if WalkExpr(e.Function, visit) {
+			return true
+		}
+		for _, arg := range e.Arguments {
+			if WalkExpr(arg, visit) {
+				return true
+			}
+		}
+		for _, na := range e.NamedArguments {
+			if WalkExpr(na.Value, visit) {
+				return true
+			}
+		}
+	case *MethodCallExpr:
+		if WalkExpr(e.Object, visit) {
+			return true
+		}
+		for _, arg := range e.Arguments {
+			if WalkExpr(arg, visit) {
+				return true
+			}
+		}
+		for _, na := range e.NamedArguments {
+			if WalkExpr(na.Value, visit) {
+				return true
+			}
+		}
+	case *FieldAccessExpr:
+		if WalkExpr(e.Object, visit) {
+			return true
+		}
+	case *IndexExpr:
+		return WalkExpr(e.Left, visit) || WalkExpr(e.Index, visit)
+	case *SliceExpr:
+		if WalkExpr(e.Left, visit) {
+			return true
+		}
+		if WalkExpr(e.Start, visit) {
+			return true
+		}
+		if WalkExpr(e.End, visit) {
+			return true
+		}
+	case *ErrorExpr:
+		return WalkExpr(e.Message, visit)
+	case *PanicExpr:
+		return WalkExpr(e.Message, visit)
+	case *OnErrExpr:
+		return WalkExpr(e.Expression, visit) || WalkExpr(e.OnErr.Handler, visit)
+	case *CommandExpr:
+		return e.Command != nil && WalkExpr(e.Command, visit)
+	case *ReturnExpr:
+		for _, v := range e.Values {
+			if WalkExpr(v, visit) {
+				return true
+			}
+		}
+	case *MakeExpr:
+		for _, arg := range e.Args {
+			if WalkExpr(arg, visit) {
+				return true
+			}
+		}
+	case *CloseExpr:
+		return WalkExpr(e.Channel, visit)
+	case *ReceiveExpr:
+		return WalkExpr(e.Channel, visit)
+	case *WaitExpr:
+		return WalkExpr(e.Channel, visit) || WalkExpr(e.Timeout, visit)
+	case *AddressOfExpr:
+		return WalkExpr(e.Operand, visit)
+	case *DerefExpr:
+		return WalkExpr(e.Operand, visit)
+	case *TypeCastExpr:
+		return WalkExpr(e.Expression, visit)
+	case *TypeAssertionExpr:
+		return WalkExpr(e.Expression, visit)
+	case *StructLiteralExpr:
+		for _, f := range e.Fields {
+			if WalkExpr(f.Value, visit) {
+				return true
+			}
+		}
+	case *ListLiteralExpr:
+		for _, elem := range e.Elements {
+			if WalkExpr(elem, visit) {
+				return true
+			}
+		}
+	case *MapLiteralExpr:
+		for _, pair := range e.Pairs {
+			if WalkExpr(pair.Key, visit) || WalkExpr(pair.Value, visit) {
+				return true
+			}
+		}
+	case *FunctionLiteral:
+		if e.Body != nil && WalkBlock(e.Body, visit) {
+			return true
+		}
+	case *ArrowLambda:
+		if e.Body != nil && WalkExpr(e.Body, visit) {
+			return true
+		}
+		if e.Block != nil && WalkBlock(e.Block, visit) {
+			return true
+		}
+	case *BlockExpr:
+		if e.Body != nil && WalkBlock(e.Body, visit) {
+			return true
+		}
+	case *BuildStringExpr:
+		if e.Body != nil && WalkBlock(e.Body, visit) {
+			return true
+		}
+	case *PipedSwitchExpr:
+		if WalkExpr(e.Left, visit) {
+			return true
+		}
+		switch s := e.Switch.(type) {
+		case *SwitchStmt:
+			for _, c := range s.Cases {
+				for _, v := range c.Values {
+					if WalkExpr(v, visit) {
+						return true
+					}
+				}
+				if c.Guard != nil && WalkExpr(c.Guard, visit) {
+					return true
+				}
+				if c.Body != nil && WalkBlock(c.Body, visit) {
+					return true
+				}
+			}
+			if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+				return true
+			}
+		case *TypeSwitchStmt:
+			for _, c := range s.Cases {
+				if c.Body != nil && WalkBlock(c.Body, visit) {
+					return true
+				}
+			}
+			if s.Otherwise != nil && s.Otherwise.Body != nil && WalkBlock(s.Otherwise.Body, visit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OnErrExprs returns the expression-level onerrs that codegen hoists out of
+// stmt into temporaries, in the order they are evaluated (an onerr inside
+// another's expression comes first): those reached from the values of a
+// declaration, assignment, return or expression statement through call
+// arguments and literal elements only. Anywhere else (a condition, an
+// operand, a pipe step, a lambda) hoisting would change whether the call
+// runs, and the analyzer rejects them.
+func OnErrExprs(stmt Statement) []*OnErrExpr {
+	var roots []Expression
+	switch s := stmt.(type) {
+	case *VarDeclStmt:
+		roots = s.Values
+	case *AssignStmt:
+		roots = s.Values
+	case *ReturnStmt:
+		roots = s.Values
+	case *ExpressionStmt:
+		roots = []Expression{s.Expression}
+	default:
+		return nil
+	}
+	var found []*OnErrExpr
+	var collect func(Expression)
+	collect = func(expr Expression) {
+		switch e := expr.(type) {
+		case *OnErrExpr:
+			collect(e.Expression)
+			found = append(found, e)
+		case *CallExpr:
+			collect(e.Function)
+			for _, arg := range e.Arguments {
+				collect(arg)
+			}
+			for _, na := range e.NamedArguments {
+				collect(na.Value)
+			}
+		case *MethodCallExpr:
+			collect(e.Object)
+			for _, arg := range e.Arguments {
+				collect(arg)
+			}
+			for _, na := range e.NamedArguments {
+				collect(na.Value)
+			}
+		case *StructLiteralExpr:
+			for _, f := range e.Fields {
+				collect(f.Value)
+			}
+		case *ListLiteralExpr:
+			for _, elem := range e.Elements {
+				collect(elem)
+			}
+		case *MapLiteralExpr:
+			for _, pair := range e.Pairs {
+				collect(pair.Value)
+			}
+		}
+	}
+	for _, root := range roots {
+		collect(root)
+	}
+	return found
+}
+
+// WalkStmts calls visit for every statement in block and in the nested blocks
+// of compound statements (if/else, switch, select, loops, go blocks, when
+// target). It does
+// not descend into function literals or lambdas.
+func WalkStmts(block *BlockStmt, visit func(Statement) bool) bool {
+	if block == nil {
+		return false
+	}
+	for _, stmt := range block.Statements {
+		if walkNestedStmts(stmt, visit) {
+			return true
+		}
+	}
+	return false
+}
+
+func walkNestedStmts(stmt Statement, visit func(Statement) bool) bool {
+	if stmt == nil {
+		return false
+	}
+	if visit(stmt) {
+		return true
+	}
+	switch s := stmt.(type) {
+	case *IfStmt:
+		if s.Init != nil && walkNestedStmts(s.Init, visit) {
+			return true
+		}
+		if WalkStmts(s.Consequence, visit) {
+			return true
+		}
+		return walkNestedStmts(s.Alternative, visit)
+	case *ElseStmt:
+		return WalkStmts(s.Body, visit)
+	case *SwitchStmt:
+		for _, c := range s.Cases {
+			if WalkStmts(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil {
+			return WalkStmts(s.Otherwise.Body, visit)
+		}
+	case *TypeSwitchStmt:
+		for _, c := range s.Cases {
+			if WalkStmts(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil {
+			return WalkStmts(s.Otherwise.Body, visit)
+		}
+	case *SelectStmt:
+		for _, c := range s.Cases {
+			if WalkStmts(c.Body, visit) {
+				return true
+			}
+		}
+		if s.Otherwise != nil {
+			return WalkStmts(s.Otherwise.Body, visit)
+		}
+	case *ForRangeStmt:
+		return WalkStmts(s.Body, visit)
+	case *ForNumericStmt:
+		return WalkStmts(s.Body, visit)
+	case *ForConditionStmt:
+		return WalkStmts(s.Body, visit)
+	case *GoStmt:
+		return WalkStmts(s.Block, visit)
+	case *SafelyStmt:
+		return WalkStmts(s.Body, visit)
+	case *RequireStmt:
+		return WalkStmts(s.Else, visit)
+	case *TargetStmt:
+		if WalkStmts(s.Body, visit) {
+			return true
+		}
+		if s.Otherwise != nil {
+			return WalkStmts(s.Otherwise.Body, visit)
+		}
+	}
+	return false
+}
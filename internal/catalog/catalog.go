@@ -0,0 +1,109 @@
+// Package catalog holds the text of compiler diagnostics, keyed by stable
+// message IDs, so the lexer, parser and semantic analyzer can report them in
+// the user's language.
+//
+// IDs are part of the tooling surface (the LSP reports them as diagnostic
+// codes), so an ID is never renumbered or reused once released: a message
+// whose meaning changes gets a new ID. Diagnostics not yet in the catalog are
+// reported in English only.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// EnvVar selects the diagnostic language when no --lang flag is given.
+const EnvVar = "KUKICHA_LANG"
+
+// ID identifies a diagnostic independently of its wording: K01xx for the
+// lexer, K02xx for the parser and K03xx-K04xx for semantic analysis.
+type ID string
+
+// messages maps IDs to fmt format strings. Translations may reorder
+// arguments with explicit indexes (%[2]s) but must use the same verbs.
+type messages map[ID]string
+
+var languages = map[string]messages{
+	"en": english,
+	"es": spanish,
+}
+
+var current atomic.Pointer[messages]
+
+func init() {
+	en := languages["en"]
+	current.Store(&en)
+}
+
+// Languages returns the supported language codes, sorted.
+func Languages() []string {
+	return slices.Sorted(maps.Keys(languages))
+}
+
+// SetLanguage selects the language of diagnostics. It accepts a language code
+// ("es") or a locale such as "es_ES.UTF-8" or "es-MX"; "", "C" and "POSIX"
+// select English.
+func SetLanguage(lang string) error {
+	code := normalize(lang)
+	msgs, ok := languages[code]
+	if !ok {
+		return fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	current.Store(&msgs)
+	return nil
+}
+
+// normalize reduces a locale name to its language code.
+func normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" || lang == "c" || lang == "posix" {
+		return "en"
+	}
+	return lang
+}
+
+// Text formats the message for id in the current language, falling back to
+// English when the language has no translation for it.
+func Text(id ID, args ...any) string {
+	format, ok := (*current.Load())[id]
+	if !ok {
+		format = english[id]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Diagnostic is a compiler error that carries its catalog ID. Error() has the
+// same "file:line:col: message" form as uncatalogued errors.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	ID      ID
+	Message string
+}
+
+// Errorf returns a Diagnostic at file:line:col with the text of id.
+func Errorf(file string, line, column int, id ID, args ...any) *Diagnostic {
+	return &Diagnostic{File: file, Line: line, Column: column, ID: id, Message: Text(id, args...)}
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// IDOf returns the catalog ID of err, or "" when err is not a Diagnostic.
+func IDOf(err error) ID {
+	var d *Diagnostic
+	if errors.As(err, &d) {
+		return d.ID
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verbPattern matches fmt verbs, with or without an explicit argument index.
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?([a-z])`)
+
+// verbs returns the verb letters of format in argument order.
+func verbs(format string) []string {
+	var out []string
+	next := 1
+	byArg := map[int]string{}
+	for _, m := range verbPattern.FindAllStringSubmatch(format, -1) {
+		arg := next
+		if m[1] != "" {
+			fmt.Sscan(m[1], &arg)
+		}
+		byArg[arg] = m[2]
+		next = arg + 1
+	}
+	for i := 1; i <= len(byArg); i++ {
+		out = append(out, byArg[i])
+	}
+	return out
+}
+
+func TestTranslationsMatchEnglish(t *testing.T) {
+	for lang, msgs := range languages {
+		for id, format := range msgs {
+			en, ok := english[id]
+			if !ok {
+				t.Errorf("%s: %s has no English text", lang, id)
+				continue
+			}
+			if got, want := verbs(format), verbs(en); !slices.Equal(got, want) {
+				t.Errorf("%s: %s uses verbs %v, English uses %v", lang, id, got, want)
+			}
+		}
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	t.Cleanup(func() { SetLanguage("en") })
+
+	for _, lang := range []string{"es", "es_ES.UTF-8", "es-MX", "ES"} {
+		if err := SetLanguage(lang); err != nil {
+			t.Fatalf("SetLanguage(%q): %v", lang, err)
+		}
+		if got := Text(UndefinedIdentifier, "x"); got != "identificador no definido 'x'" {
+			t.Errorf("SetLanguage(%q): got %q", lang, got)
+		}
+	}
+	if err := SetLanguage("C"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Text(UndefinedIdentifier, "x"); got != "undefined identifier 'x'" {
+		t.Errorf("C locale: got %q", got)
+	}
+	if err := SetLanguage("xx"); err == nil {
+		t.Error("SetLanguage(\"xx\") succeeded, want an error")
+	}
+}
+
+func TestDiagnostic(t *testing.T) {
+	err := error(Errorf("main.kuki", 3, 5, ExpectedBlock))
+	if got := err.Error(); got != "main.kuki:3:5: expected indented block" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := IDOf(fmt.Errorf("wrapped: %w", err)); got != ExpectedBlock {
+		t.Errorf("IDOf = %q, want %q", got, ExpectedBlock)
+	}
+	if got := IDOf(fmt.Errorf("plain")); got != "" {
+		t.Errorf("IDOf(plain error) = %q, want empty", got)
+	}
+}
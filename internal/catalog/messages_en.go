@@ -0,0 +1,371 @@
+package catalog
+
+// Lexer diagnostics.
+const (
+	UnexpectedCharacter   ID = "K0101"
+	TabIndent             ID = "K0102"
+	IndentNotMultiple     ID = "K0103"
+	IndentJump            ID = "K0104"
+	DedentMismatch        ID = "K0105"
+	UnterminatedString    ID = "K0106"
+	UnterminatedCharacter ID = "K0107"
+	EmptyCharacter        ID = "K0108"
+)
+
+// Parser diagnostics.
+const (
+	UnexpectedDeclaration  ID = "K0201"
+	UnexpectedExpression   ID = "K0202"
+	ExpectedBlock          ID = "K0203"
+	ExpectedType           ID = "K0204"
+	UnreachableWhen        ID = "K0205"
+	DeferNeedsCall         ID = "K0206"
+	GoNeedsCall            ID = "K0207"
+	ExpectedIdentifier     ID = "K0208"
+	PositionalAfterNamed   ID = "K0209"
+	WalrusNeedsIdentifiers ID = "K0210"
+)
+
+// Semantic diagnostics.
+const (
+	UndefinedIdentifier        ID = "K0301"
+	UndefinedType              ID = "K0302"
+	PackageNotImported         ID = "K0303"
+	IfNotBoolean               ID = "K0304"
+	ForNotBoolean              ID = "K0305"
+	BreakOutsideLoop           ID = "K0306"
+	ContinueOutsideLoop        ID = "K0307"
+	ReturnOutsideFunction      ID = "K0308"
+	ReturnCount                ID = "K0309"
+	ReturnType                 ID = "K0310"
+	AssignCount                ID = "K0311"
+	AssignType                 ID = "K0312"
+	AssignConstant             ID = "K0313"
+	TooFewArguments            ID = "K0314"
+	TooManyArguments           ID = "K0315"
+	ArgumentType               ID = "K0316"
+	InvalidOperands            ID = "K0317"
+	CannotCompare              ID = "K0318"
+	UnaryMinusNotNumeric       ID = "K0319"
+	NotNotBoolean              ID = "K0320"
+	ListIndexNotInt            ID = "K0321"
+	UnknownField               ID = "K0322"
+	OnerrReturnOutsideFunction ID = "K0323"
+	OnerrReturnNoError         ID = "K0324"
+	OnerrContinueOutsideLoop   ID = "K0325"
+	OnerrBreakOutsideLoop      ID = "K0326"
+
+	UndefinedInInterpolation        ID = "K0327"
+	UndefinedInInterpolationSuggest ID = "K0328"
+	OnerrFallbackType               ID = "K0329"
+	ReferenceMethodOnValue          ID = "K0330"
+	StrictUnknownPackageMember      ID = "K0331"
+	StrictUnknownMethod             ID = "K0332"
+	StrictUnknownField              ID = "K0333"
+	UnknownDerive                   ID = "K0334"
+	DeriveNeedsStruct               ID = "K0335"
+	DeriveConflict                  ID = "K0336"
+	MissingInterfaceMethod          ID = "K0337"
+	InterfaceMethodMismatch         ID = "K0338"
+	NotAnInterface                  ID = "K0339"
+	WhenGuardNotBool                ID = "K0340"
+	FallthroughPlacement            ID = "K0341"
+	FallthroughLastBranch           ID = "K0342"
+	IntegerOverflowsInt             ID = "K0343"
+	IntegerOverflows64              ID = "K0344"
+	ConstantOverflows               ID = "K0345"
+	ConstantTruncated               ID = "K0346"
+	WhenPatternNeedsValue           ID = "K0347"
+	MatchesNotString                ID = "K0348"
+	InvalidRegex                    ID = "K0349"
+	RequireNotBoolean               ID = "K0350"
+	RequireElseMustExit             ID = "K0351"
+	SendOnReceiveOnly               ID = "K0352"
+	ReceiveOnSendOnly               ID = "K0353"
+	YieldOutsideIterator            ID = "K0354"
+	YieldCount                      ID = "K0355"
+	YieldType                       ID = "K0356"
+	IteratorReturnValue             ID = "K0357"
+	WaitNotChannel                  ID = "K0358"
+	WaitAllNotChannels              ID = "K0359"
+	WaitTimeout                     ID = "K0360"
+	PureGlobalWrite                 ID = "K0361"
+	PureIOCall                      ID = "K0362"
+	PureSideEffect                  ID = "K0363"
+	PureCallsImpure                 ID = "K0364"
+	DistinctMix                     ID = "K0365"
+	DistinctUnderlying              ID = "K0366"
+	AliasMethod                     ID = "K0367"
+	EnumNotString                   ID = "K0368"
+	EnumUnknownValue                ID = "K0369"
+	EnumDuplicate                   ID = "K0370"
+	SwitchMissingValues             ID = "K0371"
+	OperatorUnsupported             ID = "K0372"
+	OperatorNotMethod               ID = "K0373"
+	OperatorSignature               ID = "K0374"
+	OperatorDuplicate               ID = "K0375"
+	DecimalMix                      ID = "K0376"
+	StructTagMalformed              ID = "K0377"
+	StructTagCollision              ID = "K0378"
+	StructTagJSONName               ID = "K0379"
+	StructTagJSONOption             ID = "K0380"
+	BuilderCaptured                 ID = "K0381"
+	BuilderMisuse                   ID = "K0382"
+	BuilderInGoDefer                ID = "K0383"
+	DuplicateNamedArgument          ID = "K0384"
+	NamedArgumentsUnsupported       ID = "K0385"
+	VariadicSpreadType              ID = "K0386"
+	UnknownParameterName            ID = "K0387"
+	PackageNameConflict             ID = "K0388"
+	SkillNameNotExported            ID = "K0389"
+	SkillNeedsPetiole               ID = "K0390"
+	SkillNeedsDescription           ID = "K0391"
+	SkillVersionNotSemver           ID = "K0392"
+	InvalidConstName                ID = "K0393"
+	InvalidErrorName                ID = "K0394"
+	SentinelErrorNeedsMessage       ID = "K0395"
+	InvalidTypeName                 ID = "K0396"
+	InvalidInterfaceName            ID = "K0397"
+	InvalidFunctionName             ID = "K0398"
+	InvalidFieldName                ID = "K0399"
+	InvalidMethodName               ID = "K0400"
+	InvalidVariableName             ID = "K0401"
+	InvalidParameterName            ID = "K0402"
+	VariadicNotUnique               ID = "K0403"
+	VariadicNotLast                 ID = "K0404"
+	IteratorYieldArity              ID = "K0405"
+	StructFieldType                 ID = "K0406"
+	InvalidCommand                  ID = "K0407"
+	LogicalNotBoolean               ID = "K0408"
+	BitwiseAndNotInteger            ID = "K0409"
+	ParallelPipeNotList             ID = "K0410"
+	ParallelPipeStepArity           ID = "K0411"
+	ParallelPipeNeedsCall           ID = "K0412"
+	ParallelPipeStepResults         ID = "K0413"
+	MapKeyType                      ID = "K0414"
+	SliceStartNotInt                ID = "K0415"
+	SliceEndNotInt                  ID = "K0416"
+	ListElementType                 ID = "K0417"
+	OnerrReturnInSafely             ID = "K0418"
+	OnerrReturnInBlock              ID = "K0419"
+	OnerrExprPlacement              ID = "K0420"
+	OnerrExprResults                ID = "K0421"
+	ErrInOnerr                      ID = "K0422"
+	ErrInOnerrAlias                 ID = "K0423"
+	MissingReturn                   ID = "K0424"
+	RouteOnMethod                   ID = "K0425"
+	RouteDuplicate                  ID = "K0426"
+	RouteParamType                  ID = "K0427"
+	RouteUnknownParam               ID = "K0428"
+	RouteUnboundParam               ID = "K0429"
+	RouteResults                    ID = "K0430"
+	RouteNotFunction                ID = "K0431"
+	ScheduleOnMethod                ID = "K0432"
+	ScheduleAndRoute                ID = "K0433"
+	ScheduleParams                  ID = "K0434"
+	ScheduleResults                 ID = "K0435"
+	ScheduleNotFunction             ID = "K0436"
+	SQLInjectionRisk                ID = "K0437"
+	XSSRisk                         ID = "K0438"
+	SSRFRisk                        ID = "K0439"
+	PathTraversalRisk               ID = "K0440"
+	CommandInjectionRisk            ID = "K0441"
+	OpenRedirectRisk                ID = "K0442"
+	SwitchBranchNotBool             ID = "K0443"
+	BitwiseAndAssignShape           ID = "K0444"
+	BitwiseAndAssignNotInteger      ID = "K0445"
+	ReturnInBlock                   ID = "K0446"
+	YieldInBlock                    ID = "K0447"
+	FailOutsideMain                 ID = "K0448"
+	FailMessageType                 ID = "K0449"
+	FailCodeType                    ID = "K0450"
+	ForStartNotInt                  ID = "K0451"
+	ForEndNotInt                    ID = "K0452"
+	UnknownTarget                   ID = "K0453"
+	TargetListedTwice               ID = "K0454"
+	InvalidQualifiedType            ID = "K0455"
+	LanguageTooNew                  ID = "K0456"
+	FeatureTooNew                   ID = "K0457"
+)
+
+// english is the reference text. Every ID must have an entry here.
+var english = messages{
+	UnexpectedCharacter:   "Unexpected character: %c",
+	TabIndent:             "indentation error: tabs are not allowed — use 4 spaces per indent level",
+	IndentNotMultiple:     "indentation error: found %d spaces, but Kukicha requires multiples of 4 spaces (nearest valid: %d)",
+	IndentJump:            "indentation error: indentation can only increase by 4 spaces at a time (jumped from %d to %d)",
+	DedentMismatch:        "indentation error: dedent does not match any outer indent level (found %d spaces, expected one of: %s)",
+	UnterminatedString:    "Unterminated string",
+	UnterminatedCharacter: "Unterminated character literal",
+	EmptyCharacter:        "Empty character literal",
+
+	UnexpectedDeclaration:  "unexpected token %s, expected declaration",
+	UnexpectedExpression:   "unexpected token in expression: %s",
+	ExpectedBlock:          "expected indented block",
+	ExpectedType:           "expected type annotation, got %s",
+	UnreachableWhen:        "'when' branch after 'otherwise' will never execute",
+	DeferNeedsCall:         "defer must be followed by a function call",
+	GoNeedsCall:            "go must be followed by a function call or indented block",
+	ExpectedIdentifier:     "expected identifier",
+	PositionalAfterNamed:   "positional argument cannot follow named argument",
+	WalrusNeedsIdentifiers: "walrus operator can only be used with identifiers",
+
+	UndefinedIdentifier:        "undefined identifier '%s'",
+	UndefinedType:              "undefined type '%s'",
+	PackageNotImported:         "package '%s' not imported (for type '%s')",
+	IfNotBoolean:               "if condition must be boolean",
+	ForNotBoolean:              "for condition must be boolean",
+	BreakOutsideLoop:           "break statement outside of loop",
+	ContinueOutsideLoop:        "continue statement outside of loop",
+	ReturnOutsideFunction:      "return statement outside of function",
+	ReturnCount:                "expected %d return values, got %d",
+	ReturnType:                 "cannot return %s as %s",
+	AssignCount:                "assignment mismatch: %d variables but %d values",
+	AssignType:                 "cannot assign %s to %s",
+	AssignConstant:             "cannot assign to constant '%s'",
+	TooFewArguments:            "expected at least %d arguments, got %d%s",
+	TooManyArguments:           "expected at most %d arguments, got %d%s",
+	ArgumentType:               "argument %d: cannot use %s as %s",
+	InvalidOperands:            "cannot apply %s to %s and %s",
+	CannotCompare:              "cannot compare %s and %s",
+	UnaryMinusNotNumeric:       "unary minus requires numeric type",
+	NotNotBoolean:              "not operator requires boolean",
+	ListIndexNotInt:            "list index must be int",
+	UnknownField:               "unknown field '%s' on struct '%s'",
+	OnerrReturnOutsideFunction: "'onerr return' used outside of a function",
+	OnerrReturnNoError:         "'onerr return' requires the enclosing function to return an error; use an explicit handler instead",
+	OnerrContinueOutsideLoop:   "'onerr continue' used outside of a loop",
+	OnerrBreakOutsideLoop:      "'onerr break' used outside of a loop or switch",
+
+	UndefinedInInterpolation:        "undefined identifier '%s' in string interpolation",
+	UndefinedInInterpolationSuggest: "undefined identifier '%s' in string interpolation (did you mean '%s'?)",
+	OnerrFallbackType:               "onerr fallback value has type %s, but the value it replaces has type %s",
+	ReferenceMethodOnValue:          "cannot call '%s' on this %s value: the method has a reference receiver and the value has no address; assign it to a variable first",
+	StrictUnknownPackageMember:      "cannot infer the type of '%s': it is not in Kukicha's type registry (--strict-types)",
+	StrictUnknownMethod:             "cannot infer the result of method '%s' on %s (--strict-types)",
+	StrictUnknownField:              "cannot infer the type of field '%s' on %s (--strict-types)",
+	UnknownDerive:                   "unknown derive '%s' (available: %s)",
+	DeriveNeedsStruct:               "cannot derive '%s' for %s: it is not a struct type",
+	DeriveConflict:                  "'%s' is already declared for %s; remove it or drop '%s' from @derive",
+	MissingInterfaceMethod:          "%s does not implement %s: missing method %s",
+	InterfaceMethodMismatch:         "%s does not implement %s: method %s is %s, want %s",
+	NotAnInterface:                  "'%s' is not an interface",
+	WhenGuardNotBool:                "'if' guard of a when branch must be bool, got %s",
+	FallthroughPlacement:            "'continue to next' must be the last statement of a when branch in a switch (not a type switch)",
+	FallthroughLastBranch:           "'continue to next' in the last branch of a switch: there is no next branch",
+	IntegerOverflowsInt:             "integer literal %s overflows int; write '%s as uint64' to keep it unsigned",
+	ConstantOverflows:               "constant %v overflows %s (range %v to %v)",
+	ConstantTruncated:               "constant %v is not a whole number; 'as %s' would drop the fraction (round it first, e.g. with math.Round)",
+	IntegerOverflows64:              "integer literal %s does not fit in 64 bits; keep it in a const expression or use math/big (new(big.Int).SetString(\"%s\", 0))",
+	WhenPatternNeedsValue:           "'when %s' needs a switch value to test; write the full condition in a condition switch",
+	MatchesNotString:                "'matches' needs a string switch value and pattern, got %s and %s",
+	InvalidRegex:                    "invalid regex: %s",
+	RequireNotBoolean:               "require condition must be bool, got %s",
+	RequireElseMustExit:             "the else of a require must leave: end it with return, break, continue, panic or fail",
+	SendOnReceiveOnly:               "cannot send on %s",
+	ReceiveOnSendOnly:               "cannot receive from %s",
+	YieldOutsideIterator:            "yield is only allowed in a 'yields' or 'sequence of T' function",
+	YieldCount:                      "expected %d yielded values, got %d",
+	YieldType:                       "cannot yield %s as %s",
+	IteratorReturnValue:             "return in an iterator takes no values; it only stops the iteration",
+	WaitNotChannel:                  "wait for needs a channel, got %s",
+	WaitAllNotChannels:              "wait for all needs a list of channels, got %s",
+	WaitTimeout:                     "within needs a duration, got %s",
+	PureGlobalWrite:                 "pure function %s can't assign to package variable %s",
+	PureIOCall:                      "pure function %s can't call %s, which does input/output",
+	PureSideEffect:                  "pure function %s can't use %s",
+	PureCallsImpure:                 "pure function %s can't call %s, which is not marked pure",
+	DistinctMix:                     "cannot mix %s and %s; convert one with as",
+	DistinctUnderlying:              "distinct needs a number, string or bool type, got %s",
+	AliasMethod:                     "cannot declare methods on %s, an alias of %s; declare it distinct",
+	EnumNotString:                   "only a distinct string type can list values; %s is %s",
+	EnumUnknownValue:                "%q is not a %s value (one of %s)",
+	EnumDuplicate:                   "%s lists %q twice",
+	SwitchMissingValues:             "switch on %s misses %s; add a when for each or an otherwise",
+	OperatorUnsupported:             "operator %s can't be declared; use one of + - * / == <",
+	OperatorNotMethod:               "operator %s needs a method on a struct type with a value receiver",
+	OperatorSignature:               "operator %s method %s needs one parameter and one result, a bool for == and <",
+	OperatorDuplicate:               "%s already has operator %s (%s)",
+	DecimalMix:                      "cannot use %[2]s on decimal.Decimal and %[1]s; write a decimal literal (1.5d) or convert with decimal.FromInt / decimal.FromFloat",
+	StructTagMalformed:              "malformed struct tag on field %s: %s; tag values can't contain quotes, backslashes, backticks or line breaks",
+	StructTagCollision:              "fields %s and %s both use the %s name %q; the encoder would drop or overwrite one",
+	StructTagJSONName:               "encoding/json ignores the json name %q on field %s; use letters, digits and punctuation other than quotes and backslashes",
+	StructTagJSONOption:             "unknown json option %q on field %s; use omitempty, omitzero or string",
+	BuilderCaptured:                 "builder '%s' cannot be captured by a closure; it only exists inside its build string block",
+	BuilderMisuse:                   "builder '%s' can only call its methods (e.g. %s.WriteString(s)); it cannot be passed, assigned or returned",
+	BuilderInGoDefer:                "builder '%s' cannot be used in a go or defer statement; it only exists inside its build string block",
+	DuplicateNamedArgument:          "duplicate named argument: %s",
+	NamedArgumentsUnsupported:       "named arguments are not supported for imported or unknown %s (please use positional arguments)",
+	VariadicSpreadType:              "argument %d: cannot use %s as []%s in variadic spread",
+	UnknownParameterName:            "unknown parameter name '%s'",
+	PackageNameConflict:             "package name '%s' conflicts with Go standard library package",
+	SkillNameNotExported:            "skill name '%s' must be exported (start with uppercase letter)",
+	SkillNeedsPetiole:               "skill declaration requires a petiole declaration (skills are packages)",
+	SkillNeedsDescription:           "skill should have a description (skills should be self-documenting)",
+	SkillVersionNotSemver:           "skill version '%s' should follow semver format (e.g., '1.0.0')",
+	InvalidConstName:                "invalid const name '%s'",
+	InvalidErrorName:                "invalid error name '%s'",
+	SentinelErrorNeedsMessage:       "sentinel error '%s' needs a message",
+	InvalidTypeName:                 "invalid type name '%s'",
+	InvalidInterfaceName:            "invalid interface name '%s'",
+	InvalidFunctionName:             "invalid function name '%s'",
+	InvalidFieldName:                "invalid field name '%s'",
+	InvalidMethodName:               "invalid method name '%s'",
+	InvalidVariableName:             "invalid variable name '%s'",
+	InvalidParameterName:            "invalid parameter name '%s'",
+	VariadicNotUnique:               "only one variadic parameter allowed per function",
+	VariadicNotLast:                 "variadic parameter must be the last parameter",
+	IteratorYieldArity:              "an iterator yields one value, or a key and a value",
+	StructFieldType:                 "cannot use %s as %s in field '%s' of struct '%s'",
+	InvalidCommand:                  "invalid command: %v",
+	LogicalNotBoolean:               "logical operator requires boolean operands, got %s and %s",
+	BitwiseAndNotInteger:            "bitwise AND requires integer operands, got %s and %s",
+	ParallelPipeNotList:             "parallel pipe '|>>' needs a list on the left, got %s",
+	ParallelPipeStepArity:           "parallel pipe step '%s' must take exactly one argument, takes %d",
+	ParallelPipeNeedsCall:           "parallel pipe '|>>' must be followed by a function call or function name",
+	ParallelPipeStepResults:         "parallel pipe step returns %d values; it must return a value or (value, error)",
+	MapKeyType:                      "cannot use %s as map key type %s",
+	SliceStartNotInt:                "slice start must be int",
+	SliceEndNotInt:                  "slice end must be int",
+	ListElementType:                 "list element %d: incompatible type %s, expected %s",
+	OnerrReturnInSafely:             "onerr inside a safely block cannot return from the function; handle the error or let it panic to the safely block's onerr",
+	OnerrReturnInBlock:              "onerr inside a %s block cannot return from the function; handle the error inside the block",
+	OnerrExprPlacement:              "onerr inside an expression is only allowed in a call argument or a struct, list or map element of a declaration, assignment, return or expression statement; move the call to its own statement",
+	OnerrExprResults:                "onerr inside an expression needs an expression that returns a value and an error, but it returns (%s)",
+	ErrInOnerr:                      "use {error} not {err} inside onerr — the caught error is always named 'error', or name it with 'onerr as e' and use {e}",
+	ErrInOnerrAlias:                 "use {error} not {err} inside onerr — the caught error is always named 'error', or {%s} via your 'onerr as %s' alias",
+	MissingReturn:                   "missing return in %s (returns %s): %s",
+	RouteOnMethod:                   "# route: cannot be used on method %s; route handlers are plain functions",
+	RouteDuplicate:                  "route %s is already handled by %s",
+	RouteParamType:                  "path parameter '%s' of %s must be one of %s (got %s)",
+	RouteUnknownParam:               "parameter '%s' of %s is not a path parameter of %s; route handlers take path parameters, %s.ResponseWriter and reference %s.Request",
+	RouteUnboundParam:               "path parameter '{%s}' of %s has no matching parameter in %s",
+	RouteResults:                    "route handler %s must return nothing, a value, an error, or a value and an error",
+	RouteNotFunction:                "# route: only applies to functions",
+	ScheduleOnMethod:                "# schedule: cannot be used on method %s; scheduled jobs are plain functions",
+	ScheduleAndRoute:                "%s has both # route: and # schedule:; a function is either a handler or a job",
+	ScheduleParams:                  "scheduled job %s must take no parameters or a single %s.Context",
+	ScheduleResults:                 "scheduled job %s must return nothing or an error",
+	ScheduleNotFunction:             "# schedule: only applies to functions",
+	SQLInjectionRisk:                "SQL injection risk: string interpolation in %s query — use parameter placeholders ($1, $2, ...) instead",
+	XSSRisk:                         "XSS risk: %s with non-literal content — use http.SafeHTML to HTML-escape user-controlled content",
+	SSRFRisk:                        "SSRF risk: %s inside an HTTP handler — use fetch.SafeGet or add fetch.Transport(netguard.HTTPTransport(...)) to restrict outbound requests",
+	PathTraversalRisk:               "path traversal risk: %s inside an HTTP handler — use sandbox.* with a restricted root for user-controlled paths",
+	CommandInjectionRisk:            "command injection risk: shell.Run with non-literal argument — shell.Run splits on whitespace without quoting; use shell.Output() with separate arguments for variable input",
+	OpenRedirectRisk:                "open redirect risk: %s with non-literal URL — use http.SafeRedirect(w, r, url, allowedHosts...) to validate the destination",
+	SwitchBranchNotBool:             "switch condition branch must be bool",
+	BitwiseAndAssignShape:           "bitwise AND assignment requires a single target and a single value",
+	BitwiseAndAssignNotInteger:      "bitwise AND assignment requires integer operands, got %s and %s",
+	ReturnInBlock:                   "return inside a %s block would only leave the block; set a variable and return after it",
+	YieldInBlock:                    "yield inside a %s block can't stop the iterator; yield after it",
+	FailOutsideMain:                 "fail exits the program and is only allowed in petiole main; return an error to the caller instead",
+	FailMessageType:                 "fail message must be a string or error, got %s",
+	FailCodeType:                    "fail code must be int, got %s",
+	ForStartNotInt:                  "for loop start must be int",
+	ForEndNotInt:                    "for loop end must be int",
+	UnknownTarget:                   "unknown target '%s' (known targets: %s)",
+	TargetListedTwice:               "target '%s' listed twice",
+	InvalidQualifiedType:            "invalid qualified type '%s'",
+	LanguageTooNew:                  "file requires kukicha %s, but this is kukicha %s — upgrade the compiler",
+	FeatureTooNew:                   "%s requires kukicha >= %s (file declares # kukicha: %s)",
+}
@@ -0,0 +1,184 @@
+package catalog
+
+// spanish translates the catalog into Spanish. Code (keywords, types and
+// identifiers) stays as written in the source.
+var spanish = messages{
+	UnexpectedCharacter:   "Carácter inesperado: %c",
+	TabIndent:             "error de sangría: no se permiten tabuladores — usa 4 espacios por nivel de sangría",
+	IndentNotMultiple:     "error de sangría: hay %d espacios, pero Kukicha necesita múltiplos de 4 espacios (el valor válido más cercano es %d)",
+	IndentJump:            "error de sangría: la sangría solo puede aumentar de 4 en 4 espacios (saltó de %d a %d)",
+	DedentMismatch:        "error de sangría: la reducción de sangría no coincide con ningún nivel exterior (hay %d espacios; se esperaba uno de: %s)",
+	UnterminatedString:    "Cadena sin cerrar",
+	UnterminatedCharacter: "Literal de carácter sin cerrar",
+	EmptyCharacter:        "Literal de carácter vacío",
+
+	UnexpectedDeclaration:  "símbolo inesperado %s; se esperaba una declaración",
+	UnexpectedExpression:   "símbolo inesperado en la expresión: %s",
+	ExpectedBlock:          "se esperaba un bloque con sangría",
+	ExpectedType:           "se esperaba un tipo, pero se encontró %s",
+	UnreachableWhen:        "una rama 'when' después de 'otherwise' nunca se ejecutará",
+	DeferNeedsCall:         "defer debe ir seguido de una llamada a función",
+	GoNeedsCall:            "go debe ir seguido de una llamada a función o de un bloque con sangría",
+	ExpectedIdentifier:     "se esperaba un identificador",
+	PositionalAfterNamed:   "un argumento posicional no puede ir después de un argumento con nombre",
+	WalrusNeedsIdentifiers: "el operador := solo se puede usar con identificadores",
+
+	UndefinedIdentifier:        "identificador no definido '%s'",
+	UndefinedType:              "tipo no definido '%s'",
+	PackageNotImported:         "el paquete '%s' no está importado (para el tipo '%s')",
+	IfNotBoolean:               "la condición de if debe ser booleana",
+	ForNotBoolean:              "la condición de for debe ser booleana",
+	BreakOutsideLoop:           "break fuera de un bucle",
+	ContinueOutsideLoop:        "continue fuera de un bucle",
+	ReturnOutsideFunction:      "return fuera de una función",
+	ReturnCount:                "se esperaban %d valores de retorno, pero hay %d",
+	ReturnType:                 "no se puede devolver %s como %s",
+	AssignCount:                "asignación desigual: %d variables pero %d valores",
+	AssignType:                 "no se puede asignar %s a %s",
+	AssignConstant:             "no se puede asignar a la constante '%s'",
+	TooFewArguments:            "se esperaban al menos %d argumentos, pero hay %d%s",
+	TooManyArguments:           "se esperaban como máximo %d argumentos, pero hay %d%s",
+	ArgumentType:               "argumento %d: no se puede usar %s como %s",
+	InvalidOperands:            "no se puede aplicar %s a %s y %s",
+	CannotCompare:              "no se pueden comparar %s y %s",
+	UnaryMinusNotNumeric:       "el menos unario necesita un tipo numérico",
+	NotNotBoolean:              "el operador not necesita un valor booleano",
+	ListIndexNotInt:            "el índice de una lista debe ser int",
+	UnknownField:               "el campo '%s' no existe en el struct '%s'",
+	OnerrReturnOutsideFunction: "'onerr return' usado fuera de una función",
+	OnerrReturnNoError:         "'onerr return' necesita que la función que lo contiene devuelva un error; usa un manejador explícito",
+	OnerrContinueOutsideLoop:   "'onerr continue' usado fuera de un bucle",
+	OnerrBreakOutsideLoop:      "'onerr break' usado fuera de un bucle o de un switch",
+
+	UndefinedInInterpolation:        "identificador no definido '%s' en la interpolación de la cadena",
+	UndefinedInInterpolationSuggest: "identificador no definido '%s' en la interpolación de la cadena (¿quisiste decir '%s'?)",
+	OnerrFallbackType:               "el valor de respaldo de onerr es de tipo %s, pero el valor que reemplaza es de tipo %s",
+	ReferenceMethodOnValue:          "no se puede llamar a '%s' sobre este valor %s: el método tiene un receptor reference y el valor no tiene dirección; asígnalo primero a una variable",
+	StrictUnknownPackageMember:      "no se puede inferir el tipo de '%s': no está en el registro de tipos de Kukicha (--strict-types)",
+	StrictUnknownMethod:             "no se puede inferir el resultado del método '%s' sobre %s (--strict-types)",
+	StrictUnknownField:              "no se puede inferir el tipo del campo '%s' sobre %s (--strict-types)",
+	UnknownDerive:                   "derive desconocido '%s' (disponibles: %s)",
+	DeriveNeedsStruct:               "no se puede derivar '%s' para %s: no es un tipo struct",
+	DeriveConflict:                  "'%s' ya está declarado para %s; elimínalo o quita '%s' de @derive",
+	MissingInterfaceMethod:          "%s no implementa %s: falta el método %s",
+	InterfaceMethodMismatch:         "%s no implementa %s: el método %s es %s, se esperaba %s",
+	NotAnInterface:                  "'%s' no es una interfaz",
+	WhenGuardNotBool:                "la guarda 'if' de una rama when debe ser bool, no %s",
+	FallthroughPlacement:            "'continue to next' debe ser la última instrucción de una rama when de un switch (no de un switch de tipos)",
+	FallthroughLastBranch:           "'continue to next' en la última rama de un switch: no hay rama siguiente",
+	IntegerOverflowsInt:             "el literal entero %s desborda int; escribe '%s as uint64' para mantenerlo sin signo",
+	ConstantOverflows:               "la constante %v desborda %s (rango de %v a %v)",
+	ConstantTruncated:               "la constante %v no es un número entero; 'as %s' descartaría la parte decimal (redondéala antes, p. ej. con math.Round)",
+	IntegerOverflows64:              "el literal entero %s no cabe en 64 bits; déjalo en una expresión const o usa math/big (new(big.Int).SetString(\"%s\", 0))",
+	WhenPatternNeedsValue:           "'when %s' necesita un valor de switch que probar; escribe la condición completa en un switch de condiciones",
+	MatchesNotString:                "'matches' necesita un valor de switch y un patrón de tipo string, no %s y %s",
+	InvalidRegex:                    "regex no válida: %s",
+	RequireNotBoolean:               "la condición de require debe ser bool, no %s",
+	RequireElseMustExit:             "el else de un require debe salir: termínalo con return, break, continue, panic o fail",
+	SendOnReceiveOnly:               "no se puede enviar por %s",
+	ReceiveOnSendOnly:               "no se puede recibir de %s",
+	YieldOutsideIterator:            "yield solo se permite en una función 'yields' o 'sequence of T'",
+	YieldCount:                      "se esperaban %d valores en yield, pero hay %d",
+	YieldType:                       "no se puede hacer yield de %s como %s",
+	IteratorReturnValue:             "return en un iterador no lleva valores; solo detiene la iteración",
+	WaitNotChannel:                  "wait for necesita un canal, se obtuvo %s",
+	WaitAllNotChannels:              "wait for all necesita una lista de canales, se obtuvo %s",
+	WaitTimeout:                     "within necesita una duración, se obtuvo %s",
+	PureGlobalWrite:                 "la función pura %s no puede asignar a la variable de paquete %s",
+	PureIOCall:                      "la función pura %s no puede llamar a %s, que hace entrada/salida",
+	PureSideEffect:                  "la función pura %s no puede usar %s",
+	PureCallsImpure:                 "la función pura %s no puede llamar a %s, que no está marcada como pura",
+	DistinctMix:                     "no se pueden mezclar %s y %s; convierte uno con as",
+	DistinctUnderlying:              "distinct necesita un tipo numérico, string o bool, se obtuvo %s",
+	AliasMethod:                     "no se pueden declarar métodos en %s, un alias de %s; decláralo distinct",
+	EnumNotString:                   "solo un tipo string distinct puede listar valores; %s es %s",
+	EnumUnknownValue:                "%q no es un valor de %s (uno de %s)",
+	EnumDuplicate:                   "%s lista %q dos veces",
+	SwitchMissingValues:             "el switch sobre %s omite %s; agrega un when para cada uno o un otherwise",
+	OperatorUnsupported:             "el operador %s no se puede declarar; usa uno de + - * / == <",
+	OperatorNotMethod:               "el operador %s necesita un método sobre un tipo struct con receptor por valor",
+	OperatorSignature:               "el método %[2]s del operador %[1]s necesita un parámetro y un resultado, un bool para == y <",
+	OperatorDuplicate:               "%s ya tiene el operador %s (%s)",
+	DecimalMix:                      "no se puede usar %[2]s con decimal.Decimal y %[1]s; escribe un literal decimal (1.5d) o convierte con decimal.FromInt / decimal.FromFloat",
+	StructTagMalformed:              "etiqueta de struct mal formada en el campo %s: %s; los valores no pueden contener comillas, barras invertidas, acentos graves ni saltos de línea",
+	StructTagCollision:              "los campos %s y %s usan el mismo nombre %s %q; el codificador descartaría o sobrescribiría uno",
+	StructTagJSONName:               "encoding/json ignora el nombre json %q del campo %s; usa letras, dígitos y signos de puntuación que no sean comillas ni barras invertidas",
+	StructTagJSONOption:             "opción json desconocida %q en el campo %s; usa omitempty, omitzero o string",
+	BuilderCaptured:                 "el builder '%s' no puede capturarse en una clausura; solo existe dentro de su bloque build string",
+	BuilderMisuse:                   "el builder '%s' solo puede llamar a sus métodos (p. ej. %s.WriteString(s)); no se puede pasar, asignar ni devolver",
+	BuilderInGoDefer:                "el builder '%s' no se puede usar en una sentencia go o defer; solo existe dentro de su bloque build string",
+	DuplicateNamedArgument:          "argumento con nombre duplicado: %s",
+	NamedArgumentsUnsupported:       "los argumentos con nombre no se admiten en %s importadas o desconocidas (usa argumentos posicionales)",
+	VariadicSpreadType:              "argumento %d: no se puede usar %s como []%s al expandir un variádico",
+	UnknownParameterName:            "nombre de parámetro desconocido '%s'",
+	PackageNameConflict:             "el nombre de paquete '%s' choca con un paquete de la biblioteca estándar de Go",
+	SkillNameNotExported:            "el nombre de skill '%s' debe ser exportado (empezar por mayúscula)",
+	SkillNeedsPetiole:               "la declaración skill necesita una declaración petiole (los skills son paquetes)",
+	SkillNeedsDescription:           "el skill debería tener una descripción (los skills deben documentarse a sí mismos)",
+	SkillVersionNotSemver:           "la versión de skill '%s' debería seguir el formato semver (p. ej., '1.0.0')",
+	InvalidConstName:                "nombre de const no válido '%s'",
+	InvalidErrorName:                "nombre de error no válido '%s'",
+	SentinelErrorNeedsMessage:       "el error centinela '%s' necesita un mensaje",
+	InvalidTypeName:                 "nombre de tipo no válido '%s'",
+	InvalidInterfaceName:            "nombre de interfaz no válido '%s'",
+	InvalidFunctionName:             "nombre de función no válido '%s'",
+	InvalidFieldName:                "nombre de campo no válido '%s'",
+	InvalidMethodName:               "nombre de método no válido '%s'",
+	InvalidVariableName:             "nombre de variable no válido '%s'",
+	InvalidParameterName:            "nombre de parámetro no válido '%s'",
+	VariadicNotUnique:               "solo se permite un parámetro variádico por función",
+	VariadicNotLast:                 "el parámetro variádico debe ser el último",
+	IteratorYieldArity:              "un iterador produce un valor, o una clave y un valor",
+	StructFieldType:                 "no se puede usar %s como %s en el campo '%s' del struct '%s'",
+	InvalidCommand:                  "comando no válido: %v",
+	LogicalNotBoolean:               "el operador lógico necesita operandos booleanos, pero hay %s y %s",
+	BitwiseAndNotInteger:            "el AND de bits necesita operandos enteros, pero hay %s y %s",
+	ParallelPipeNotList:             "la tubería paralela '|>>' necesita una lista a la izquierda, pero hay %s",
+	ParallelPipeStepArity:           "el paso '%s' de la tubería paralela debe recibir exactamente un argumento, recibe %d",
+	ParallelPipeNeedsCall:           "la tubería paralela '|>>' debe ir seguida de una llamada o un nombre de función",
+	ParallelPipeStepResults:         "el paso de la tubería paralela devuelve %d valores; debe devolver un valor o (valor, error)",
+	MapKeyType:                      "no se puede usar %s como clave de tipo %s del mapa",
+	SliceStartNotInt:                "el inicio del slice debe ser int",
+	SliceEndNotInt:                  "el final del slice debe ser int",
+	ListElementType:                 "elemento %d de la lista: tipo %s incompatible, se esperaba %s",
+	OnerrReturnInSafely:             "onerr dentro de un bloque safely no puede salir de la función; maneja el error o deja que el panic llegue al onerr del bloque safely",
+	OnerrReturnInBlock:              "onerr dentro de un bloque %s no puede salir de la función; maneja el error dentro del bloque",
+	OnerrExprPlacement:              "onerr dentro de una expresión solo se permite en un argumento de llamada o un elemento de struct, lista o mapa de una declaración, asignación, return o sentencia de expresión; mueve la llamada a su propia sentencia",
+	OnerrExprResults:                "onerr dentro de una expresión necesita una expresión que devuelva un valor y un error, pero devuelve (%s)",
+	ErrInOnerr:                      "usa {error} y no {err} dentro de onerr — el error capturado siempre se llama 'error', o ponle nombre con 'onerr as e' y usa {e}",
+	ErrInOnerrAlias:                 "usa {error} y no {err} dentro de onerr — el error capturado siempre se llama 'error', o {%s} mediante tu alias 'onerr as %s'",
+	MissingReturn:                   "falta return en %s (devuelve %s): %s",
+	RouteOnMethod:                   "# route: no se puede usar en el método %s; los manejadores de rutas son funciones normales",
+	RouteDuplicate:                  "la ruta %s ya la maneja %s",
+	RouteParamType:                  "el parámetro de ruta '%s' de %s debe ser uno de %s (es %s)",
+	RouteUnknownParam:               "el parámetro '%s' de %s no es un parámetro de ruta de %s; los manejadores de rutas reciben parámetros de ruta, %s.ResponseWriter y reference %s.Request",
+	RouteUnboundParam:               "el parámetro de ruta '{%s}' de %s no tiene un parámetro correspondiente en %s",
+	RouteResults:                    "el manejador de ruta %s debe devolver nada, un valor, un error, o un valor y un error",
+	RouteNotFunction:                "# route: solo se aplica a funciones",
+	ScheduleOnMethod:                "# schedule: no se puede usar en el método %s; las tareas programadas son funciones normales",
+	ScheduleAndRoute:                "%s tiene # route: y # schedule: a la vez; una función es un manejador o una tarea, no ambas",
+	ScheduleParams:                  "la tarea programada %s no debe recibir parámetros o solo un %s.Context",
+	ScheduleResults:                 "la tarea programada %s debe devolver nada o un error",
+	ScheduleNotFunction:             "# schedule: solo se aplica a funciones",
+	SQLInjectionRisk:                "riesgo de inyección SQL: interpolación de cadenas en la consulta de %s — usa marcadores de parámetros ($1, $2, ...)",
+	XSSRisk:                         "riesgo de XSS: %s con contenido no literal — usa http.SafeHTML para escapar el HTML controlado por el usuario",
+	SSRFRisk:                        "riesgo de SSRF: %s dentro de un manejador HTTP — usa fetch.SafeGet o añade fetch.Transport(netguard.HTTPTransport(...)) para restringir las peticiones salientes",
+	PathTraversalRisk:               "riesgo de recorrido de rutas: %s dentro de un manejador HTTP — usa sandbox.* con una raíz restringida para rutas controladas por el usuario",
+	CommandInjectionRisk:            "riesgo de inyección de comandos: shell.Run con un argumento no literal — shell.Run separa por espacios sin respetar comillas; usa shell.Output() con argumentos separados para valores variables",
+	OpenRedirectRisk:                "riesgo de redirección abierta: %s con una URL no literal — usa http.SafeRedirect(w, r, url, allowedHosts...) para validar el destino",
+	SwitchBranchNotBool:             "la rama de un switch de condiciones debe ser bool",
+	BitwiseAndAssignShape:           "la asignación con AND de bits necesita un solo destino y un solo valor",
+	BitwiseAndAssignNotInteger:      "la asignación con AND de bits necesita operandos enteros, pero hay %s y %s",
+	ReturnInBlock:                   "return dentro de un bloque %s solo saldría del bloque; asigna una variable y haz return después",
+	YieldInBlock:                    "yield dentro de un bloque %s no puede detener el iterador; haz yield después",
+	FailOutsideMain:                 "fail termina el programa y solo se permite en petiole main; devuelve un error a quien llama",
+	FailMessageType:                 "el mensaje de fail debe ser string o error, no %s",
+	FailCodeType:                    "el código de fail debe ser int, no %s",
+	ForStartNotInt:                  "el inicio del bucle for debe ser int",
+	ForEndNotInt:                    "el final del bucle for debe ser int",
+	UnknownTarget:                   "destino desconocido '%s' (destinos conocidos: %s)",
+	TargetListedTwice:               "el destino '%s' aparece dos veces",
+	InvalidQualifiedType:            "tipo cualificado no válido '%s'",
+	LanguageTooNew:                  "el archivo necesita kukicha %s, pero este es kukicha %s — actualiza el compilador",
+	FeatureTooNew:                   "%s necesita kukicha >= %s (el archivo declara # kukicha: %s)",
+}
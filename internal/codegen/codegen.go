@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"go/constant"
 	"strings"
 	"github.com/duber000/kukicha/internal/semantic"
 
@@ -48,6 +49,7 @@ type Generator struct {
 	indent               int
 	placeholderMap       map[string]string        // Maps placeholder names to type param names (e.g., "any" -> "T", "any2" -> "K")
 	autoImports          map[string]bool          // Tracks auto-imports needed (e.g., "cmp" for generic constraints)
+	fusedImports         map[string]bool          // Import paths whose calls pipeline fusion inlined or release mode dropped; removed at the end if no longer referenced
 	pkgAliases           map[string]string        // Maps original package name -> alias when collision detected (e.g., "json" -> "kukijson")
 	funcDefaults         map[string]*FuncDefaults // Maps function names to their default parameter info
 	isStdlibIter         bool                     // True if generating stdlib/iterator code (enables iter-specific generic transpilation)
@@ -55,15 +57,32 @@ type Generator struct {
 	currentFuncName      string                   // Current function being generated (for context-aware decisions)
 	currentReturnTypes   []ast.TypeAnnotation     // Return types of current function (for type coercion in returns)
 	processingReturnType bool                     // Whether we are currently generating return types
-	tempCounter          int                      // Counter for generating unique temporary variable names
+	tempCounter          int                      // Counter for generating unique temporary variable names; restarts in each function
 	exprReturnCounts     map[ast.Expression]int      // Semantic return counts passed from analyzer (drives onerr multi-value split)
 	// exprTypes holds per-expression type info from semantic analysis.
 	// Used by isErrorOnlyReturn, inferExprReturnType, inferExprType,
 	// pipedSwitchReturnType, empty keyword resolution, and zeroValueForType.
 	exprTypes            map[ast.Expression]*semantic.TypeInfo
+	annTypes             map[ast.TypeAnnotation]*semantic.TypeInfo // Resolved declared types from semantic analysis (see SetTypeAnnotationTypes)
+	captures             map[ast.Node][]semantic.Capture // Closure captures from semantic analysis (see SetCaptures)
+	constants            map[ast.Expression]constant.Value // Constant expressions folded by semantic analysis (see SetConstants)
+	shutdownCtx          string                      // Shutdown context variable while generating main (see generateShutdownPrelude)
+	contextParam         string                      // The current function's context.Context parameter, if any (see commandContext)
+	buildMetadata        bool                        // Declare kukichaBuild for kukicha build to fill in (see SetBuildMetadata)
+	release              bool                        // Release build: no //line directives, assertions dropped (see SetRelease)
+	checkCasts           bool                        // Panic when a numeric `as` conversion loses the value (see SetCheckCasts)
+	profileDir           string                      // Write CPU and allocation profiles from main here (see SetProfile)
+	instrument           bool                        // Wrap exported functions in telemetry spans (see SetInstrument)
+	onErrLog             bool                        // Log the error before each onerr handler runs (see SetOnErrLog)
+	explainCodegen       bool                        // Comment non-obvious lowerings in the output (see SetExplainCodegen)
 	mcpTarget            bool                        // True if targeting MCP (Model Context Protocol)
+	buildTag             string                      // Emitted as a //go:build constraint when set (multi-target builds)
+	header               []string                    // Project header lines from kukicha.toml (see SetHeader)
+	onErrTemps           map[*ast.OnErrExpr]string   // Temporaries holding hoisted expression-level onerr values (see hoistOnErrExprs)
+	regexVars            map[string]string           // Package-level compiled regexes for `when matches`, by pattern (see regexVar)
 	currentOnErrVar      string                   // Render-time context: set/restored only by renderHandler in lower.go
 	currentOnErrAlias    string                   // Render-time context: set/restored only by renderHandler in lower.go
+	onErrValueType       *semantic.TypeInfo       // Type of the value an onerr fallback replaces (see convertFallback)
 	currentReturnIndex   int                      // Index of return value being generated (-1 if not in return)
 	stdlibModuleBase     string                   // Base module path for rewriting "stdlib/X" imports (default: defaultStdlibModuleBase)
 	reservedNames        map[string]bool          // User-declared identifiers — uniqueId skips these to avoid collisions
@@ -75,8 +94,10 @@ func New(program *ast.Program) *Generator {
 		program:            program,
 		indent:             0,
 		autoImports:        make(map[string]bool),
+		fusedImports:       make(map[string]bool),
 		pkgAliases:         make(map[string]string),
 		funcDefaults:       make(map[string]*FuncDefaults),
+		regexVars:          make(map[string]string),
 		stdlibModuleBase:   defaultStdlibModuleBase,
 		currentReturnIndex: -1,
 	}
@@ -95,12 +116,22 @@ func (g *Generator) childGenerator(extraIndent int) *Generator {
 		indent:             g.indent + extraIndent,
 		placeholderMap:     g.placeholderMap,
 		autoImports:        g.autoImports,
+		fusedImports:       g.fusedImports,
 		pkgAliases:         g.pkgAliases,
 		funcDefaults:       g.funcDefaults,
+		regexVars:          g.regexVars,
 		isStdlibIter:       g.isStdlibIter,
 		sourceFile:         g.sourceFile,
 		exprTypes:          g.exprTypes,
 		exprReturnCounts:   g.exprReturnCounts,
+		captures:           g.captures,
+		constants:          g.constants,
+		mcpTarget:          g.mcpTarget,
+		shutdownCtx:        g.shutdownCtx,
+		contextParam:       g.contextParam,
+		release:            g.release,
+		checkCasts:         g.checkCasts,
+		onErrLog:           g.onErrLog,
 		currentReturnIndex: -1,
 		stdlibModuleBase:   g.stdlibModuleBase,
 		reservedNames:      g.reservedNames,
@@ -129,23 +160,136 @@ func (g *Generator) SetExprReturnCounts(counts map[ast.Expression]int) {
 
 // SetExprTypes passes semantic analysis expression types to the generator.
 // Used by isErrorOnlyReturn, inferExprReturnType, empty keyword resolution,
-// piped switch return type inference, and zeroValueForType.
+// piped switch return type inference, and cast lowering.
 func (g *Generator) SetExprTypes(types map[ast.Expression]*semantic.TypeInfo) {
 	g.exprTypes = types
 }
 
+// SetTypeAnnotationTypes passes the resolved types of declared type
+// annotations to the generator. zeroValueForType reads their kind to choose
+// nil for an interface and *new(T) otherwise.
+func (g *Generator) SetTypeAnnotationTypes(types map[ast.TypeAnnotation]*semantic.TypeInfo) {
+	g.annTypes = types
+}
+
+// SetCaptures passes the closure captures from semantic analysis to the
+// generator. A go block passes its by-value captures to the goroutine as
+// arguments (see generateGoBlock).
+func (g *Generator) SetCaptures(captures map[ast.Node][]semantic.Capture) {
+	g.captures = captures
+}
+
+// SetConstants passes the constant expressions semantic analysis folded to
+// the generator, which emits each as its value: len("abc") becomes 3 and
+// strings.ToUpper("id") becomes "ID", so they can appear in a const
+// declaration.
+func (g *Generator) SetConstants(constants map[ast.Expression]constant.Value) {
+	g.constants = constants
+}
+
 // SetMCPTarget enables special codegen for MCP servers (e.g., print to stderr)
 func (g *Generator) SetMCPTarget(v bool) {
 	g.mcpTarget = v
 }
 
+// SetRelease turns on release codegen for `kukicha build --release`: no
+// //line directives mapping the Go code back to the .kuki source, and
+// must.True/must.False assertion statements are dropped (their arguments
+// are not evaluated).
+func (g *Generator) SetRelease(v bool) {
+	g.release = v
+}
+
+// SetCheckCasts turns on runtime checks for numeric `as` conversions that
+// can lose the value (int64 to uint8, float64 to int, ...): the program
+// panics with the .kuki position instead of silently wrapping or truncating.
+// Used by --check-casts on kukicha build and run, for debugging.
+func (g *Generator) SetCheckCasts(v bool) {
+	g.checkCasts = v
+}
+
+// SetProfile makes main write a CPU profile and an allocation profile
+// (ProfileCPUFile, ProfileAllocsFile) to dir when it returns. Used by
+// kukicha profile run, which maps the samples back to .kuki lines.
+func (g *Generator) SetProfile(dir string) {
+	g.profileDir = dir
+}
+
+// SetInstrument wraps every exported function in an OpenTelemetry span
+// with call, error and duration metrics named after it, recorded by
+// stdlib/telemetry, and starts main with telemetry.Auto, which configures
+// telemetry from the environment. Used by --instrument on kukicha build
+// and run.
+func (g *Generator) SetInstrument(v bool) {
+	g.instrument = v
+}
+
+// SetExplainCodegen makes the output explain lowerings that are not obvious
+// from the source, in `// kukicha:` comments: what each onerr check does,
+// pipe chains split into temps, type parameters inferred for stdlib generics
+// and imports the source does not contain. Used by kukicha build
+// --explain-codegen, for learning what Kukicha becomes and debugging codegen.
+func (g *Generator) SetExplainCodegen(v bool) {
+	g.explainCodegen = v
+}
+
+// SetBuildMetadata makes a main package that imports stdlib/buildinfo declare
+// the kukichaBuild variable, which kukicha build sets with -ldflags -X to the
+// compiler version, source hash and build time, and buildinfo.Get and
+// `kukicha version --of` read back.
+func (g *Generator) SetBuildMetadata(v bool) {
+	g.buildMetadata = v
+}
+
+// SetBuildTag makes the output build only under the given tag. Multi-target
+// builds write one Go file per target next to each other; the tags keep them
+// out of each other's (and the enclosing package's) default build, while
+// `go build file.go` still compiles a named file.
+func (g *Generator) SetBuildTag(tag string) {
+	g.buildTag = tag
+}
+
+// SetOnErrLog makes every onerr handler log the error it handles with
+// log/slog before it runs, so errors a handler defaults or skips still show
+// up in production. A file's `# onerr: log` or `# onerr: off` pragma
+// overrides it. The CLI passes onerr-log from the [build] table of
+// kukicha.toml.
+func (g *Generator) SetOnErrLog(v bool) {
+	g.onErrLog = v
+}
+
+// SetHeader adds lines (license notices, //go:generate directives, lint
+// suppressions) to the top of the output, ahead of the file's own
+// `# header:` pragmas. The CLI passes the [build] header from kukicha.toml.
+func (g *Generator) SetHeader(lines []string) {
+	g.header = lines
+}
+
 // Generate generates Go code from the AST
 func (g *Generator) Generate() (string, error) {
 	g.output.Reset()
 
+	// A no-op when the analyzer has already resolved the `when target` blocks
+	ast.SelectTarget(g.program, g.program.Target)
+
 	// Generate header comment
 	g.writeLine("// Generated by Kukicha (requires Go 1.26+)")
 	g.writeLine("")
+	// The blank line after the header keeps a license notice from becoming
+	// the package doc comment.
+	if len(g.header) > 0 || len(g.program.Header) > 0 {
+		for _, line := range g.header {
+			g.writeLine(line)
+		}
+		for _, line := range g.program.Header {
+			g.writeLine(line)
+		}
+		g.writeLine("")
+	}
+	if g.buildTag != "" {
+		g.writeLine("//go:build " + g.buildTag)
+		g.writeLine("")
+	}
 
 	// Generate package declaration
 	g.generatePackage()
@@ -176,7 +320,23 @@ func (g *Generator) Generate() (string, error) {
 		g.generateDeclaration(decl)
 	}
 
-	return g.output.String(), nil
+	// Register # route: handlers (http target)
+	g.generateRoutes()
+
+	// Register # schedule: jobs (worker target)
+	g.generateSchedules()
+
+	g.generateBuildMetadata()
+	g.generateStdinHelpers()
+	g.generateRegexVars()
+	g.generateCommandHelper()
+	g.generateProfileHelper()
+
+	out := g.output.String()
+	for path := range g.fusedImports {
+		out = dropUnusedImport(out, path)
+	}
+	return out, nil
 }
 
 func (g *Generator) generatePackage() {
@@ -207,6 +367,7 @@ func (g *Generator) generateDeclaration(decl ast.Declaration) {
 	switch d := decl.(type) {
 	case *ast.TypeDecl:
 		g.generateTypeDecl(d)
+		g.generateDerives(d)
 	case *ast.InterfaceDecl:
 		g.generateInterfaceDecl(d)
 	case *ast.FunctionDecl:
@@ -215,6 +376,10 @@ func (g *Generator) generateDeclaration(decl ast.Declaration) {
 		g.generateGlobalVarDecl(d)
 	case *ast.ConstDecl:
 		g.generateConstDecl(d)
+	case *ast.ErrorDecl:
+		g.generateErrorDecl(d)
+	case *ast.ImplementsDecl:
+		g.generateImplementsDecl(d)
 	}
 }
 
@@ -223,6 +388,12 @@ func (g *Generator) write(s string) {
 }
 
 func (g *Generator) writeLine(s string) {
+	// Go only honors a //line directive at column 1, so re-indented output
+	// (a rendered onerr handler, for one) keeps its directives there.
+	if strings.HasPrefix(s, "//line ") {
+		g.output.WriteString(s + "\n")
+		return
+	}
 	if s != "" {
 		g.output.WriteString(g.indentStr() + s)
 	}
@@ -238,6 +409,9 @@ func (g *Generator) indentStr() string {
 // these directives, so compile errors, panics, and stack traces will reference
 // the .kuki file instead of the generated .go file.
 func (g *Generator) emitLineDirective(pos ast.Position) {
+	if g.release {
+		return
+	}
 	if pos.Line > 0 && pos.File != "" {
 		g.output.WriteString(fmt.Sprintf("//line %s:%d\n", pos.File, pos.Line))
 	}
@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// BuildMetadataVar is the variable kukicha build sets with
+// -ldflags "-X main.kukichaBuild=...".
+const BuildMetadataVar = "kukichaBuild"
+
+// buildInfoImport is the stdlib package that reads BuildMetadataVar back.
+const buildInfoImport = "stdlib/buildinfo"
+
+// needsBuildMetadata reports whether the program declares BuildMetadataVar:
+// SetBuildMetadata is on, the program is a main package, and it imports
+// stdlib/buildinfo. Programs that never read their build info get neither
+// the variable nor its init.
+func (g *Generator) needsBuildMetadata() bool {
+	if !g.buildMetadata {
+		return false
+	}
+	if g.program.PetioleDecl != nil && g.program.PetioleDecl.Name.Value != "main" {
+		return false
+	}
+	return ast.ImportName(g.program, buildInfoImport) != ""
+}
+
+// generateBuildMetadata declares BuildMetadataVar and hands it to
+// stdlib/buildinfo. The init also keeps the variable reachable, so the
+// linker does not drop the value -X sets.
+func (g *Generator) generateBuildMetadata() {
+	if !g.needsBuildMetadata() {
+		return
+	}
+	g.writeLine("")
+	g.writeLine("// Set by kukicha build; read back with buildinfo.Get or `kukicha version --of <binary>`")
+	g.writeLine(fmt.Sprintf("var %s string", BuildMetadataVar))
+	g.writeLine("")
+	g.writeLine("func init() {")
+	g.writeLine(fmt.Sprintf("\t%s.Set(%s)", ast.ImportName(g.program, buildInfoImport), BuildMetadataVar))
+	g.writeLine("}")
+}
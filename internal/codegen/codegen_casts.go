@@ -0,0 +1,92 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// intTypeSizes describes the Go integer types a checked conversion can target or
+// start from (int and uint are taken as 64 bits).
+var intTypeSizes = map[string]struct {
+	bits     int
+	unsigned bool
+}{
+	"int": {64, false}, "int8": {8, false}, "int16": {16, false}, "int32": {32, false}, "int64": {64, false}, "rune": {32, false},
+	"uint": {64, true}, "uint8": {8, true}, "uint16": {16, true}, "uint32": {32, true}, "uint64": {64, true}, "byte": {8, true},
+}
+
+// castCheck is the runtime check for a numeric `as` conversion under
+// --check-casts (see SetCheckCasts).
+type castCheck struct {
+	from, to string // Go types
+	cond     string // True when the conversion lost the value; v is the input, r the result
+	imports  []string
+}
+
+// castCheckFor returns the runtime check for cast, or false when the
+// conversion cannot lose the value (widening, same type), is of a constant
+// (the analyzer checks those), or the operand types are not known numbers.
+func (g *Generator) castCheckFor(cast *ast.TypeCastExpr) (castCheck, bool) {
+	if !g.checkCasts || isNumberLiteral(cast.Expression) {
+		return castCheck{}, false
+	}
+	fromTI, toTI := g.exprTypes[cast.Expression], g.exprTypes[cast]
+	if fromTI == nil || toTI == nil {
+		return castCheck{}, false
+	}
+	from, to := g.typeInfoToGoString(fromTI), g.typeInfoToGoString(toTI)
+	if from == to {
+		return castCheck{}, false
+	}
+	fromInt, fromIsInt := intTypeSizes[from]
+	switch {
+	case toTI.Kind == semantic.TypeKindFloat && to == "float32" && from == "float64":
+		return castCheck{from, to, "math.IsInf(float64(r), 0) && !math.IsInf(v, 0)", []string{"fmt", "math"}}, true
+	case toTI.Kind != semantic.TypeKindInt:
+		return castCheck{}, false
+	}
+	toInt, ok := intTypeSizes[to]
+	if !ok || (!fromIsInt && fromTI.Kind != semantic.TypeKindFloat) {
+		return castCheck{}, false
+	}
+	if fromIsInt {
+		widening := toInt.unsigned == fromInt.unsigned && toInt.bits >= fromInt.bits ||
+			!toInt.unsigned && fromInt.unsigned && toInt.bits > fromInt.bits
+		if widening {
+			return castCheck{}, false
+		}
+	}
+	cond := from + "(r) != v"
+	if toInt.unsigned && (!fromIsInt || !fromInt.unsigned) {
+		cond += " || v < 0"
+	}
+	if !toInt.unsigned && fromIsInt && fromInt.unsigned {
+		cond += " || r < 0"
+	}
+	return castCheck{from, to, cond, []string{"fmt"}}, true
+}
+
+// generateCheckedCast wraps a conversion in a function literal that panics
+// with the source position when the value does not survive it, e.g. 300 as
+// uint8 or 2.5 as int.
+func (g *Generator) generateCheckedCast(cast *ast.TypeCastExpr, check castCheck, value string) string {
+	pos := cast.Pos()
+	msg := fmt.Sprintf("%s:%d: %%v does not convert to %s exactly", filepath.Base(pos.File), pos.Line, check.to)
+	return fmt.Sprintf("func(v %s) %s { r := %s(v); if %s { panic(fmt.Sprintf(%q, v)) }; return r }(%s)",
+		check.from, check.to, check.to, check.cond, msg, value)
+}
+
+// isNumberLiteral reports a number literal, possibly negated.
+func isNumberLiteral(expr ast.Expression) bool {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Operator == "-" {
+		expr = u.Right
+	}
+	switch expr.(type) {
+	case *ast.IntegerLiteral, *ast.FloatLiteral:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// commandFunc is declared by generateCommandHelper for `$ "..."` expressions.
+const commandFunc = "kukichaCommand"
+
+// generateCommandExpr lowers `$ "git rev-parse HEAD"` to a kukichaCommand
+// call with the program name and each argument as separate strings, so no
+// shell is involved and interpolated values cannot inject arguments.
+func (g *Generator) generateCommandExpr(e *ast.CommandExpr) string {
+	words, err := ast.CommandWords(e.Command)
+	if err != nil {
+		// Reported by semantic analysis.
+		return commandFunc + "(nil, \"\")"
+	}
+	args := []string{g.commandContext()}
+	for _, word := range words {
+		args = append(args, g.commandWord(word))
+	}
+	return fmt.Sprintf("%s(%s)", commandFunc, strings.Join(args, ", "))
+}
+
+// commandWord generates the Go string for one word of a command.
+func (g *Generator) commandWord(parts []*ast.StringInterpolation) string {
+	if len(parts) == 1 && !parts[0].IsLiteral {
+		if ti := g.exprTypes[parts[0].Expr]; ti != nil && ti.Kind == semantic.TypeKindString {
+			return g.exprToString(parts[0].Expr)
+		}
+	}
+	return g.generateStringFromParts(&ast.StringLiteral{Interpolated: true, Parts: parts})
+}
+
+// commandContext returns the context a command runs under: the shutdown
+// context in main, the enclosing function's context.Context parameter, or
+// context.Background().
+func (g *Generator) commandContext() string {
+	if g.shutdownCtx != "" {
+		return g.shutdownCtx
+	}
+	if g.contextParam != "" {
+		return g.contextParam
+	}
+	return g.importedName("context") + ".Background()"
+}
+
+// contextParamName returns the name of the first context.Context parameter.
+func contextParamName(params []*ast.Parameter) string {
+	for _, param := range params {
+		if t, ok := param.Type.(*ast.NamedType); ok && t.Name == "context.Context" && param.Name != nil {
+			return param.Name.Value
+		}
+	}
+	return ""
+}
+
+// needsCommandHelper reports whether the program uses `$ "..."`.
+func (g *Generator) needsCommandHelper() bool {
+	return g.walkProgram(func(e ast.Expression) bool {
+		_, ok := e.(*ast.CommandExpr)
+		return ok
+	})
+}
+
+// generateCommandHelper declares kukichaCommand, which runs a program and
+// returns its trimmed standard output. A failing command's error carries the
+// program name and its trimmed standard error. The imports are added by
+// scanExprForAutoImports.
+func (g *Generator) generateCommandHelper() {
+	if !g.needsCommandHelper() {
+		return
+	}
+	contextPkg, execPkg, fmtPkg, stringsPkg := g.importedName("context"), g.importedName("os/exec"), g.importedName("fmt"), g.importedName("strings")
+	g.writeLine("")
+	g.writeLine("// " + commandFunc + " implements `$ \"...\"`: the trimmed standard output of a command.")
+	g.writeLine(fmt.Sprintf("func %s(ctx %s.Context, name string, args ...string) (string, error) {", commandFunc, contextPkg))
+	g.writeLine(fmt.Sprintf("\tcmd := %s.CommandContext(ctx, name, args...)", execPkg))
+	g.writeLine(fmt.Sprintf("\tvar stderr %s.Builder", stringsPkg))
+	g.writeLine("\tcmd.Stderr = &stderr")
+	g.writeLine("\tout, err := cmd.Output()")
+	g.writeLine("\tif err != nil {")
+	g.writeLine(fmt.Sprintf("\t\tif msg := %s.TrimSpace(stderr.String()); msg != \"\" {", stringsPkg))
+	g.writeLine(fmt.Sprintf("\t\t\treturn \"\", %s.Errorf(\"%%s: %%w: %%s\", name, err, msg)", fmtPkg))
+	g.writeLine("\t\t}")
+	g.writeLine(fmt.Sprintf("\t\treturn \"\", %s.Errorf(\"%%s: %%w\", name, err)", fmtPkg))
+	g.writeLine("\t}")
+	g.writeLine(fmt.Sprintf("\treturn %s.TrimSpace(string(out)), nil", stringsPkg))
+	g.writeLine("}")
+}
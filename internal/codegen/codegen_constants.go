@@ -0,0 +1,33 @@
+package codegen
+
+import (
+	"go/constant"
+	"strconv"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// constantLiteral returns the Go literal for an expression semantic analysis
+// folded (see SetConstants). Folding can remove every call into strings or
+// stdlib/string, so their imports are dropped at the end if nothing else
+// uses them.
+func (g *Generator) constantLiteral(expr ast.Expression) (string, bool) {
+	v, ok := g.constants[expr]
+	if !ok {
+		return "", false
+	}
+	if ast.WalkExpr(expr, func(e ast.Expression) bool {
+		_, ok := e.(*ast.MethodCallExpr)
+		return ok
+	}) {
+		g.fusedImports["strings"] = true
+		g.fusedImports[g.rewriteStdlibImport("stdlib/string")] = true
+	}
+	switch v.Kind() {
+	case constant.String:
+		return strconv.Quote(constant.StringVal(v)), true
+	case constant.Int, constant.Bool:
+		return v.ExactString(), true
+	}
+	return "", false
+}
@@ -10,9 +10,18 @@ import (
 )
 
 func (g *Generator) generateTypeDecl(decl *ast.TypeDecl) {
-	// Type alias (e.g., type Handler func(string))
+	// Type alias (e.g., type Handler func(string)). Only a distinct number,
+	// string or bool type becomes a Go defined type; otherwise it is the Go
+	// type itself, so it mixes with it as the analyzer allows.
+	if _, ok := decl.AliasType.(*ast.PrimitiveType); ok && !decl.Distinct {
+		g.writeLine(fmt.Sprintf("type %s = %s", decl.Name.Value, g.generateTypeAnnotation(decl.AliasType)))
+		return
+	}
 	if decl.AliasType != nil {
 		g.writeLine(fmt.Sprintf("type %s %s", decl.Name.Value, g.generateTypeAnnotation(decl.AliasType)))
+		if len(decl.Values) > 0 {
+			g.generateEnumValues(decl)
+		}
 		return
 	}
 
@@ -33,6 +42,15 @@ func (g *Generator) generateTypeDecl(decl *ast.TypeDecl) {
 	g.writeLine("}")
 }
 
+// generateImplementsDecl emits a compile-time assertion per interface. A
+// pointer satisfies the interface whether the methods have value or
+// reference receivers.
+func (g *Generator) generateImplementsDecl(decl *ast.ImplementsDecl) {
+	for _, iface := range decl.Interfaces {
+		g.writeLine(fmt.Sprintf("var _ %s = (*%s)(nil)", g.generateTypeAnnotation(iface), decl.Type.Value))
+	}
+}
+
 func (g *Generator) generateInterfaceDecl(decl *ast.InterfaceDecl) {
 	g.write(fmt.Sprintf("type %s interface {", decl.Name.Value))
 	g.writeLine("")
@@ -72,6 +90,25 @@ func (g *Generator) generateConstDecl(decl *ast.ConstDecl) {
 	g.writeLine(")")
 }
 
+// generateErrorDecl emits sentinel errors as package-level errors.New vars.
+func (g *Generator) generateErrorDecl(decl *ast.ErrorDecl) {
+	if len(decl.Specs) == 0 {
+		return
+	}
+	if len(decl.Specs) == 1 {
+		spec := decl.Specs[0]
+		g.writeLine(fmt.Sprintf("var %s = errors.New(%s)", spec.Name.Value, g.exprToString(spec.Message)))
+		return
+	}
+	g.writeLine("var (")
+	g.indent++
+	for _, spec := range decl.Specs {
+		g.writeLine(fmt.Sprintf("%s = errors.New(%s)", spec.Name.Value, g.exprToString(spec.Message)))
+	}
+	g.indent--
+	g.writeLine(")")
+}
+
 func (g *Generator) generateGlobalVarDecl(stmt *ast.VarDeclStmt) {
 	if len(stmt.Names) == 0 {
 		return
@@ -136,6 +173,13 @@ func (g *Generator) generateFunctionDecl(decl *ast.FunctionDecl) {
 	g.placeholderMap = make(map[string]string)
 
 	g.currentFuncName = decl.Name.Value
+	g.contextParam = contextParamName(decl.Parameters)
+
+	// Temp names (pipe_N, err_N) are numbered per function, so editing one
+	// function does not rename the temps of every function after it
+	outerTemps := g.tempCounter
+	g.tempCounter = 0
+	defer func() { g.tempCounter = outerTemps }()
 
 	// Check if this is a stdlib function that needs special transpilation
 	// (generic type parameter inference from placeholder types)
@@ -176,6 +220,52 @@ func (g *Generator) generateFunctionDecl(decl *ast.FunctionDecl) {
 		for _, tp := range typeParams {
 			g.placeholderMap[tp.Placeholder] = tp.Name
 		}
+	} else if g.isStdlibMath() {
+		// Generate type parameters for number/ordered placeholders in math
+		typeParams = g.inferMathTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibRandom() {
+		// Generate type parameters for list helpers in random
+		typeParams = g.inferRandomTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibLimit() {
+		// Generate type parameters for the pipe stages in limit
+		typeParams = g.inferLimitTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibCache() {
+		// Generate type parameters for the sample-pattern helpers in cache
+		typeParams = g.sampleTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibPool() {
+		// Generate type parameters for the worker pool maps
+		typeParams = g.inferPoolTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibEnv() {
+		// Generate type parameters for selected env helpers (e.g., Load)
+		typeParams = g.inferEnvTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	} else if g.isStdlibExpect() {
+		// Generate type parameters for the property checks (ForAll, Check)
+		typeParams = g.inferExpectTypeParameters(decl)
+		for _, tp := range typeParams {
+			g.placeholderMap[tp.Placeholder] = tp.Name
+		}
+	}
+
+	if len(typeParams) > 0 {
+		g.annotate("%s", explainTypeParams(typeParams))
 	}
 
 	// Generate function signature
@@ -200,9 +290,17 @@ func (g *Generator) generateFunctionDecl(decl *ast.FunctionDecl) {
 	params := g.generateFunctionParameters(decl.Parameters)
 	signature += fmt.Sprintf("(%s)", params)
 
-	// Add return types
+	// Add return types; an instrumented function names its error result
+	instrumented := g.instrumentedName(decl)
+	instrumentErr := ""
 	g.processingReturnType = true
 	returns := g.generateReturnTypes(decl.Returns)
+	if instrumented != "" {
+		returns, instrumentErr = g.instrumentedReturns(decl.Returns)
+	}
+	if decl.Yields != nil {
+		returns = g.generateIteratorType(decl.Yields)
+	}
 	g.processingReturnType = false
 
 	if returns != "" {
@@ -212,13 +310,33 @@ func (g *Generator) generateFunctionDecl(decl *ast.FunctionDecl) {
 	g.write(signature + " {")
 	g.writeLine("")
 
-	// Set return types for type coercion in return statements
+	// Set return types for type coercion in return statements; an
+	// iterator's returns are bare
 	g.currentReturnTypes = decl.Returns
+	if decl.Yields != nil {
+		g.currentReturnTypes = nil
+	}
 
 	// Generate body
 	if decl.Body != nil {
 		g.indent++
-		g.generateBlock(decl.Body)
+		if decl.Receiver == nil && decl.Name.Value == "main" && g.needsProfile() {
+			g.generateProfilePrelude()
+		}
+		if decl.Receiver == nil && decl.Name.Value == "main" {
+			g.generateTelemetrySetup()
+		}
+		if instrumented != "" {
+			g.generateInstrumentPrelude(instrumented, instrumentErr)
+		}
+		if decl.Receiver == nil && decl.Name.Value == "main" && g.needsGracefulShutdown() {
+			g.generateShutdownPrelude()
+		}
+		if decl.Yields != nil {
+			g.generateIteratorBody(decl.Yields, decl.Body)
+		} else {
+			g.generateBlock(decl.Body)
+		}
 		g.indent--
 	}
 
@@ -227,7 +345,9 @@ func (g *Generator) generateFunctionDecl(decl *ast.FunctionDecl) {
 	// Clear function context
 	g.placeholderMap = nil
 	g.currentFuncName = ""
+	g.contextParam = ""
 	g.currentReturnTypes = nil
+	g.shutdownCtx = ""
 }
 
 func (g *Generator) generateFunctionLiteral(lit *ast.FunctionLiteral) string {
@@ -314,11 +434,21 @@ func (g *Generator) generateArrowLambda(lambda *ast.ArrowLambda) string {
 		}
 	}
 	params := strings.Join(paramParts, ", ")
+	sig, hasSig := g.lambdaSignature(lambda)
 
 	if lambda.Body != nil {
 		// Expression lambda: auto-return the expression
 		bodyStr := g.exprToString(lambda.Body)
 
+		// The parameter it is passed to fixes the results: none means the
+		// expression is run for its effect (() => os.Remove(path) as a func()).
+		if hasSig {
+			if sig == "" {
+				return fmt.Sprintf("func(%s) { %s }", params, bodyStr)
+			}
+			return fmt.Sprintf("func(%s) %s { return %s }", params, sig, bodyStr)
+		}
+
 		// Infer return type from the expression for the Go func signature.
 		// For typed params, we can determine the return type.
 		// For the common case, we omit the return type and let Go infer it
@@ -338,7 +468,10 @@ func (g *Generator) generateArrowLambda(lambda *ast.ArrowLambda) string {
 
 	if lambda.Block != nil {
 		// Block lambda: generate as multi-line anonymous function
-		returnType := g.inferBlockReturnType(lambda.Block)
+		returnType := sig
+		if !hasSig {
+			returnType = g.inferBlockReturnType(lambda.Block)
+		}
 
 		// Generate body using child generator
 		child := g.childGenerator(1)
@@ -365,6 +498,28 @@ func (g *Generator) generateArrowLambda(lambda *ast.ArrowLambda) string {
 	return fmt.Sprintf("func(%s) {}", params)
 }
 
+// lambdaSignature returns the Go result list ("", "bool", "(int, error)") of
+// an arrow lambda whose signature the analyzer took from the parameter it is
+// passed to. ok is false when the call site fixed no signature or one of its
+// results is unknown; the caller then infers results from the body.
+func (g *Generator) lambdaSignature(lambda *ast.ArrowLambda) (results string, ok bool) {
+	ti := g.exprTypes[lambda]
+	if ti == nil || ti.Kind != semantic.TypeKindFunction || ti.Params == nil {
+		return "", false
+	}
+	parts := make([]string, len(ti.Returns))
+	for i, r := range ti.Returns {
+		if r == nil || r.Kind == semantic.TypeKindUnknown {
+			return "", false
+		}
+		parts[i] = g.typeInfoToGoString(r)
+	}
+	if len(parts) > 1 {
+		return "(" + strings.Join(parts, ", ") + ")", true
+	}
+	return strings.Join(parts, ""), true
+}
+
 // generateTypeParameters generates Go generic type parameter list
 func (g *Generator) generateTypeParameters(typeParams []*TypeParameter) string {
 	if len(typeParams) == 0 {
@@ -406,6 +561,33 @@ func (g *Generator) generateParameters(params []*ast.Parameter) string {
 	return g.generateFunctionParameters(params)
 }
 
+// generateIteratorType renders the iter.Seq or iter.Seq2 a `yields` function
+// returns.
+func (g *Generator) generateIteratorType(yields []ast.TypeAnnotation) string {
+	parts := make([]string, len(yields))
+	for i, y := range yields {
+		parts[i] = g.generateTypeAnnotation(y)
+	}
+	if len(parts) == 2 {
+		return fmt.Sprintf("iter.Seq2[%s]", strings.Join(parts, ", "))
+	}
+	return fmt.Sprintf("iter.Seq[%s]", parts[0])
+}
+
+// generateIteratorBody wraps the body of a `yields` function in the
+// range-over-func closure it returns; a bare return ends the iteration.
+func (g *Generator) generateIteratorBody(yields []ast.TypeAnnotation, body *ast.BlockStmt) {
+	parts := make([]string, len(yields))
+	for i, y := range yields {
+		parts[i] = g.generateTypeAnnotation(y)
+	}
+	g.writeLine(fmt.Sprintf("return func(yield func(%s) bool) {", strings.Join(parts, ", ")))
+	g.indent++
+	g.generateBlock(body)
+	g.indent--
+	g.writeLine("}")
+}
+
 func (g *Generator) generateReturnTypes(returns []ast.TypeAnnotation) string {
 	if len(returns) == 0 {
 		return ""
@@ -502,7 +684,20 @@ func (g *Generator) generateTypeAnnotation(typeAnn ast.TypeAnnotation) string {
 		return fmt.Sprintf("map[%s]%s", keyType, valueType)
 		// Note: keyType and valueType already have placeholders substituted via recursion
 	case *ast.ChannelType:
-		return "chan " + g.generateTypeAnnotation(t.ElementType)
+		elem := g.generateTypeAnnotation(t.ElementType)
+		switch t.Direction {
+		case "send-only":
+			return "chan<- " + elem
+		case "receive-only":
+			return "<-chan " + elem
+		}
+		if strings.HasPrefix(elem, "<-") {
+			// chan <-chan T would parse as chan<- (chan T)
+			return "chan (" + elem + ")"
+		}
+		return "chan " + elem
+	case *ast.SequenceType:
+		return "iter.Seq[" + g.generateTypeAnnotation(t.ElementType) + "]"
 	case *ast.FunctionType:
 		// Generate Go function type: func(params) returns
 		var paramTypes []string
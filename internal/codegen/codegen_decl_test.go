@@ -3,6 +3,8 @@ package codegen
 import (
 	"strings"
 	"testing"
+
+	"github.com/duber000/kukicha/internal/semantic"
 )
 
 func TestGenerateInterfaceDecl(t *testing.T) {
@@ -231,3 +233,118 @@ func TestGenerateStructWithJsonTag(t *testing.T) {
 		t.Errorf("expected URL json tag, got:\n%s", output)
 	}
 }
+
+func TestGenerateDerives(t *testing.T) {
+	input := `@derive json, stringer, new
+type Point
+    x int
+    label string
+    Note string json:"note,omitempty"
+`
+	output := generateSource(t, input)
+
+	for _, want := range []string{
+		"func (v Point) MarshalJSON() ([]byte, error) {",
+		"F0 int `json:\"x\"`",
+		"F2 string `json:\"note,omitempty\"`",
+		"}{v.x, v.label, v.Note})",
+		"func (v *Point) UnmarshalJSON(data []byte) error {",
+		"v.label = aux.F1",
+		`return fmt.Sprintf("Point{x: %v, label: %q, Note: %q}", v.x, v.label, v.Note)`,
+		"func NewPoint(x int, label string, Note string) Point {",
+		"return Point{x: x, label: label, Note: Note}",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestGenerateDeriveTests(t *testing.T) {
+	input := `type Role string distinct
+    Admin "admin"
+    Guest "guest"
+
+@derive jsontest
+type User
+    ID int as "id"
+    Name string
+    Role Role
+    Tags list of string
+    Manager reference User
+    Secret string json:"-"
+`
+	output := generateDeriveTestsFor(t, input)
+
+	for _, want := range []string{
+		"func TestUserJSONRoundTrip(t *testing.T) {",
+		`{name: "defaults", value: User{Role: Admin}},`,
+		`keys: []string{"id", "Name", "Role", "Tags", "Manager"}`,
+		`Tags: []string{"Tags"}`,
+		"Manager: new(User{",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+	assertValidGo(t, output)
+
+	if out := generateDeriveTestsFor(t, "type Plain\n    ID int\n"); out != "" {
+		t.Errorf("expected no test file without @derive jsontest, got:\n%s", out)
+	}
+}
+
+func generateDeriveTestsFor(t *testing.T, input string) string {
+	t.Helper()
+	gen := New(mustParseProgram(t, input))
+	if _, err := gen.Generate(); err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	return gen.GenerateDeriveTests()
+}
+
+func TestDeriversMatchAnalyzer(t *testing.T) {
+	for _, name := range semantic.DeriveNames() {
+		if _, ok := derivers[name]; !ok {
+			t.Errorf("derive %q has signatures in semantic but no generator", name)
+		}
+	}
+	if len(derivers) != len(semantic.DeriveNames()) {
+		t.Errorf("derivers has %d entries, semantic declares %d", len(derivers), len(semantic.DeriveNames()))
+	}
+}
+
+func TestGenerateStructWithTagGroups(t *testing.T) {
+	input := `type User
+    Name string as "name" for json, yaml, db
+    Email string as "email" db:"email_address"
+`
+
+	output := generateSource(t, input)
+
+	if !strings.Contains(output, "Name string `json:\"name\" yaml:\"name\" db:\"name\"`") {
+		t.Errorf("expected Name tag group, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Email string `json:\"email\" db:\"email_address\"`") {
+		t.Errorf("expected Email alias plus db tag, got:\n%s", output)
+	}
+}
+
+func TestGenerateImplementsDecl(t *testing.T) {
+	input := `import "fmt"
+
+type Circle
+    r float64
+
+Circle implements fmt.Stringer, error
+`
+
+	output := generateSource(t, input)
+
+	if !strings.Contains(output, "var _ fmt.Stringer = (*Circle)(nil)") {
+		t.Errorf("expected fmt.Stringer assertion, got:\n%s", output)
+	}
+	if !strings.Contains(output, "var _ error = (*Circle)(nil)") {
+		t.Errorf("expected error assertion, got:\n%s", output)
+	}
+}
@@ -0,0 +1,132 @@
+package codegen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// deriver writes the members one `@derive` name adds to a struct type. The
+// analyzer declares their signatures (semantic.derives) and has already
+// rejected unknown names, non-struct types, and clashes.
+type deriver struct {
+	imports  []string
+	generate func(g *Generator, decl *ast.TypeDecl)
+}
+
+// derivers is the derive registry. To add a derive, add its generator here
+// and its signatures to semantic.derives.
+var derivers = map[string]deriver{
+	"json":     {imports: []string{"encoding/json"}, generate: (*Generator).deriveJSON},
+	"stringer": {imports: []string{"fmt"}, generate: (*Generator).deriveStringer},
+	"new":      {generate: (*Generator).deriveNew},
+	// jsontest adds no members; GenerateDeriveTests writes its tests
+	"jsontest": {},
+}
+
+// scanDerivesForAutoImports adds the imports derived members use.
+func (g *Generator) scanDerivesForAutoImports() {
+	for _, decl := range g.program.Declarations {
+		if typeDecl, ok := decl.(*ast.TypeDecl); ok {
+			for _, d := range ast.TypeDerives(typeDecl) {
+				for _, path := range derivers[d.Name].imports {
+					g.addImport(path)
+				}
+			}
+		}
+	}
+}
+
+// generateDerives writes the members requested by decl's `@derive`
+// directives, after the type itself.
+func (g *Generator) generateDerives(decl *ast.TypeDecl) {
+	for _, d := range ast.TypeDerives(decl) {
+		if dv, ok := derivers[d.Name]; ok && dv.generate != nil {
+			g.writeLine("")
+			dv.generate(g, decl)
+		}
+	}
+}
+
+// deriveJSON writes MarshalJSON and UnmarshalJSON through an anonymous
+// struct with exported fields, so every field is encoded under its json tag,
+// or its Kukicha name when it has none, whether or not it is exported.
+func (g *Generator) deriveJSON(decl *ast.TypeDecl) {
+	jsonPkg := g.importedName("encoding/json")
+	name := decl.Name.Value
+	var shape strings.Builder
+	shape.WriteString("struct {\n")
+	values := make([]string, len(decl.Fields))
+	for i, field := range decl.Fields {
+		tag := field.Tag
+		if _, ok := reflect.StructTag(tag).Lookup("json"); !ok {
+			tag = strings.TrimSpace(fmt.Sprintf("json:%q %s", field.Name.Value, tag))
+		}
+		fmt.Fprintf(&shape, "F%d %s `%s`\n", i, g.generateTypeAnnotation(field.Type), tag)
+		values[i] = "v." + field.Name.Value
+	}
+	shape.WriteString("}")
+
+	g.writeLine(fmt.Sprintf("func (v %s) MarshalJSON() ([]byte, error) {", name))
+	g.indent++
+	g.writeLine(fmt.Sprintf("return %s.Marshal(%s{%s})", jsonPkg, shape.String(), strings.Join(values, ", ")))
+	g.indent--
+	g.writeLine("}")
+	g.writeLine("")
+
+	// Start from the current values so keys missing from data keep them,
+	// as json.Unmarshal does for a plain struct.
+	g.writeLine(fmt.Sprintf("func (v *%s) UnmarshalJSON(data []byte) error {", name))
+	g.indent++
+	g.writeLine(fmt.Sprintf("aux := %s{%s}", shape.String(), strings.Join(values, ", ")))
+	g.writeLine(fmt.Sprintf("if err := %s.Unmarshal(data, &aux); err != nil {", jsonPkg))
+	g.indent++
+	g.writeLine("return err")
+	g.indent--
+	g.writeLine("}")
+	for i, field := range decl.Fields {
+		g.writeLine(fmt.Sprintf("v.%s = aux.F%d", field.Name.Value, i))
+	}
+	g.writeLine("return nil")
+	g.indent--
+	g.writeLine("}")
+}
+
+// deriveStringer writes a String method that formats the value like a
+// struct literal, quoting string fields: Point{x: 1, label: "a"}.
+func (g *Generator) deriveStringer(decl *ast.TypeDecl) {
+	name := decl.Name.Value
+	parts := make([]string, len(decl.Fields))
+	args := make([]string, len(decl.Fields))
+	for i, field := range decl.Fields {
+		verb := "%v"
+		if isStringType(field.Type) {
+			verb = "%q"
+		}
+		parts[i] = field.Name.Value + ": " + verb
+		args[i] = ", v." + field.Name.Value
+	}
+	g.writeLine(fmt.Sprintf("func (v %s) String() string {", name))
+	g.indent++
+	g.writeLine(fmt.Sprintf("return %s.Sprintf(%q%s)", g.importedName("fmt"), name+"{"+strings.Join(parts, ", ")+"}", strings.Join(args, "")))
+	g.indent--
+	g.writeLine("}")
+}
+
+// deriveNew writes a constructor taking every field in declaration order.
+func (g *Generator) deriveNew(decl *ast.TypeDecl) {
+	name := decl.Name.Value
+	params := make([]string, len(decl.Fields))
+	inits := make([]string, len(decl.Fields))
+	for i, field := range decl.Fields {
+		params[i] = field.Name.Value + " " + g.generateTypeAnnotation(field.Type)
+		inits[i] = field.Name.Value + ": " + field.Name.Value
+	}
+	g.writeLine(fmt.Sprintf("func %s(%s) %s {", ast.ConstructorName(name), strings.Join(params, ", "), name))
+	g.indent++
+	g.writeLine(fmt.Sprintf("return %s{%s}", name, strings.Join(inits, ", ")))
+	g.indent--
+	g.writeLine("}")
+}
@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// scanEnumsForAutoImports adds the imports an enum's JSON methods use.
+func (g *Generator) scanEnumsForAutoImports() {
+	for _, decl := range g.program.Declarations {
+		if typeDecl, ok := decl.(*ast.TypeDecl); ok && len(typeDecl.Values) > 0 {
+			g.addImport("encoding/json")
+			g.addImport("fmt")
+		}
+	}
+}
+
+// generateEnumValues writes an enum's values as constants of its type, and
+// MarshalJSON and UnmarshalJSON methods that reject any other string, so a
+// decoded value is always one of them.
+func (g *Generator) generateEnumValues(decl *ast.TypeDecl) {
+	name := decl.Name.Value
+	names := make([]string, len(decl.Values))
+	g.writeLine("")
+	g.writeLine("const (")
+	g.indent++
+	for i, v := range decl.Values {
+		names[i] = v.Name.Value
+		g.writeLine(fmt.Sprintf("%s %s = %s", v.Name.Value, name, g.exprToString(v.Value)))
+	}
+	g.indent--
+	g.writeLine(")")
+
+	jsonPkg := g.importedName("encoding/json")
+	fmtPkg := g.importedName("fmt")
+	cases := strings.Join(names, ", ")
+	g.writeLine("")
+	g.writeLine(fmt.Sprintf("func (v %s) MarshalJSON() ([]byte, error) {", name))
+	g.indent++
+	g.writeLine("switch v {")
+	g.writeLine(fmt.Sprintf("case %s:", cases))
+	g.indent++
+	g.writeLine(fmt.Sprintf("return %s.Marshal(string(v))", jsonPkg))
+	g.indent--
+	g.writeLine("}")
+	g.writeLine(fmt.Sprintf("return nil, %s.Errorf(\"%%q is not a %s value\", string(v))", fmtPkg, name))
+	g.indent--
+	g.writeLine("}")
+	g.writeLine("")
+
+	g.writeLine(fmt.Sprintf("func (v *%s) UnmarshalJSON(data []byte) error {", name))
+	g.indent++
+	g.writeLine("var s string")
+	g.writeLine(fmt.Sprintf("if err := %s.Unmarshal(data, &s); err != nil {", jsonPkg))
+	g.indent++
+	g.writeLine("return err")
+	g.indent--
+	g.writeLine("}")
+	g.writeLine(fmt.Sprintf("switch %s(s) {", name))
+	g.writeLine(fmt.Sprintf("case %s:", cases))
+	g.indent++
+	g.writeLine(fmt.Sprintf("*v = %s(s)", name))
+	g.writeLine("return nil")
+	g.indent--
+	g.writeLine("}")
+	g.writeLine(fmt.Sprintf("return %s.Errorf(\"%%q is not a %s value\", s)", fmtPkg, name))
+	g.indent--
+	g.writeLine("}")
+}
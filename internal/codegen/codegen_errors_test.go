@@ -206,6 +206,78 @@ func TestEscapedBracesLiteral(t *testing.T) {
 	}
 }
 
+func TestStringInterpolationFormatsFloatsInDecimal(t *testing.T) {
+	input := `func Report(total float64, ratio float32, count int) (string, error)
+    return "{total} {ratio} {count}", error "bad total {total}"
+`
+
+	p, err := parser.New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+
+	analyzer := semantic.NewWithFile(program, "test.kuki")
+	semErrors := analyzer.Analyze()
+	if len(semErrors) > 0 {
+		t.Fatalf("semantic errors: %v", semErrors)
+	}
+
+	gen := New(program)
+	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	for _, want := range []string{
+		`"strconv"`,
+		`fmt.Sprintf("%s %s %v", strconv.FormatFloat(total, 'f', -1, 64), strconv.FormatFloat(float64(ratio), 'f', -1, 32), count)`,
+		`fmt.Errorf("bad total %s", strconv.FormatFloat(total, 'f', -1, 64))`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestStringInterpolationOfIntWithFloatConstant(t *testing.T) {
+	// n / 2.0 is an int division in Go, so it must not go to FormatFloat
+	input := `func Report(n int, f float32) string
+    return "{n / 2.0} {f * 2}"
+`
+
+	p, err := parser.New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+	analyzer := semantic.NewWithFile(program, "test.kuki")
+	if semErrors := analyzer.Analyze(); len(semErrors) > 0 {
+		t.Fatalf("semantic errors: %v", semErrors)
+	}
+	gen := New(program)
+	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	want := `fmt.Sprintf("%v %s", (n / 2.0), strconv.FormatFloat(float64((f * 2)), 'f', -1, 32))`
+	if !strings.Contains(output, want) {
+		t.Errorf("expected %q in output, got:\n%s", want, output)
+	}
+}
+
 func TestEscapedBracesMixedWithInterpolation(t *testing.T) {
 	// \{ and \} should produce literal braces even when mixed with {expr} interpolation
 	input := `func Format(name string) string
@@ -456,6 +528,7 @@ func Run() int
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -704,7 +777,7 @@ func TestTypeCastInterfaceUsesAssertion(t *testing.T) {
 	input := `func Foo(x any) error
     return x as error
 `
-	output := generateSource(t, input)
+	output := fullPipeline(t, input, "test.kuki")
 
 	if !strings.Contains(output, ".(error)") {
 		t.Errorf("expected assertion syntax .(error), got: %s", output)
@@ -719,13 +792,35 @@ func TestTypeCastLocalInterfaceUsesAssertion(t *testing.T) {
 func Foo(x any) Stringer
     return x as Stringer
 `
-	output := generateSource(t, input)
+	output := fullPipeline(t, input, "test.kuki")
 
 	if !strings.Contains(output, ".(Stringer)") {
 		t.Errorf("expected assertion syntax .(Stringer) for local interface, got: %s", output)
 	}
 }
 
+func TestOnErrZeroValuesUseResolvedTypes(t *testing.T) {
+	// A named return type is nil when the analyzer resolved it to an
+	// interface, and a zero variable of the type otherwise
+	input := `import "os"
+
+interface Storer
+    Store(data string) error
+
+type Config
+    Path string
+
+func Load(path string) (Storer, Config, error)
+    data := os.ReadFile(path) onerr return
+    print(data)
+    return empty, Config{}, empty
+`
+	output := fullPipeline(t, input, "test.kuki")
+	if !strings.Contains(output, "var _zero1 Config") || !strings.Contains(output, "return nil, _zero1, err_1") {
+		t.Errorf("expected nil for the interface and a zero Config for the struct, got: %s", output)
+	}
+}
+
 func TestDeeplyNestedIndentation(t *testing.T) {
 	// 10+ levels of nesting should compile correctly
 	input := `func deep(n int) int
@@ -808,6 +903,7 @@ func sumValid(items list of string) int
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -848,6 +944,7 @@ func firstValid(items list of string) int
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -887,6 +984,7 @@ func readOrDie(path string) list of byte
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -899,3 +997,80 @@ func readOrDie(path string) list of byte
 		t.Errorf("expected panic in onerr block, got:\n%s", output)
 	}
 }
+
+func TestOnErrFallbackConvertedToFloat(t *testing.T) {
+	input := `func ratio() (float64, error)
+    return 0.5, empty
+
+func main()
+    fallback := 3
+    r := ratio() onerr fallback
+    q := ratio() onerr 1
+    r = ratio() onerr fallback * 2
+    print(r, q)
+`
+	p, err := parser.New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+	analyzer := semantic.New(program)
+	if semErrors := analyzer.Analyze(); len(semErrors) > 0 {
+		t.Fatalf("semantic errors: %v", semErrors)
+	}
+
+	gen := New(program)
+	gen.SetExprReturnCounts(analyzer.ReturnCounts())
+	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	formatted, err := FormatGo([]byte(output))
+	if err != nil {
+		t.Fatalf("format error: %v\n%s", err, output)
+	}
+	output = string(formatted)
+
+	for _, want := range []string{"r = float64(fallback)", "q = 1", "r = float64(fallback * 2)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestOnErrExprHoistedBeforeStatement(t *testing.T) {
+	input := `type Config
+    host string
+    port int
+
+func GetPort() (int, error)
+    return 80, empty
+
+func double(n int) int
+    return n * 2
+
+func load() (Config, error)
+    return Config{host: "h", port: double(GetPort() onerr return)}, empty
+
+func main()
+    cfg := Config{host: "localhost", port: GetPort() onerr 8080}
+    print(double(GetPort() onerr 0), cfg)
+`
+	output := generateSource(t, input)
+	assertValidGo(t, output)
+
+	for _, want := range []string{
+		"v_1, err_2 := GetPort()\n\tif err_2 != nil {\n\t\tvar _zero0 Config\n\t\treturn _zero0, err_2\n\t}\n\treturn Config{host: \"h\", port: double(v_1)}, nil",
+		"v_1, err_2 := GetPort()\n\tif err_2 != nil {\n\t\tv_1 = 8080\n\t}\n\tcfg := Config{host: \"localhost\", port: v_1}",
+		"fmt.Println(double(v_3), cfg)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/ir"
+)
+
+// annotatePrefix starts every --explain-codegen comment, as it does the
+// comments codegen already leaves (e.g. an uninferred return count).
+const annotatePrefix = "kukicha: "
+
+// annotate writes a `// kukicha: ...` comment explaining the code that
+// follows, when SetExplainCodegen is on.
+func (g *Generator) annotate(format string, args ...any) {
+	if g.explainCodegen {
+		g.writeLine("// " + annotatePrefix + fmt.Sprintf(format, args...))
+	}
+}
+
+// annotate adds an explanation comment to an IR block, when
+// SetExplainCodegen is on.
+func (l *Lowerer) annotate(block *ir.Block, format string, args ...any) {
+	if l.gen.explainCodegen {
+		block.Add(&ir.Comment{Text: annotatePrefix + fmt.Sprintf(format, args...)})
+	}
+}
+
+// explainOnErr describes what an onerr clause does once errVar is set.
+func explainOnErr(clause *ast.OnErrClause, errVar string) string {
+	switch {
+	case clause.ShorthandReturn:
+		return "onerr return: return " + errVar + " with zero values for the other results"
+	case clause.ShorthandContinue:
+		return "onerr continue: skip to the next loop iteration"
+	case clause.ShorthandBreak:
+		return "onerr break: leave the loop"
+	case clause.Handler == nil && clause.Explain != "":
+		return fmt.Sprintf("onerr explain: return %s wrapped as %q", errVar, clause.Explain+": %w")
+	case clause.Explain != "":
+		return fmt.Sprintf("onerr explain: wrap %s as %q, then %s", errVar, clause.Explain+": %w",
+			strings.TrimPrefix(explainOnErrHandler(clause.Handler, errVar), "onerr "))
+	}
+	return explainOnErrHandler(clause.Handler, errVar)
+}
+
+// explainOnErrHandler describes an onerr handler expression, following the
+// cases of generateOnErrHandler.
+func explainOnErrHandler(handler ast.Expression, errVar string) string {
+	switch handler.(type) {
+	case *ast.PanicExpr:
+		return "onerr panic: panic with the message"
+	case *ast.ErrorExpr, *ast.ReturnExpr, *ast.EmptyExpr:
+		return "onerr return: return from the function"
+	case *ast.BlockExpr:
+		return "onerr block: run the block ({error} is " + errVar + ")"
+	case *ast.CallExpr, *ast.MethodCallExpr:
+		return "onerr call: run the call as a statement"
+	default:
+		return "onerr default: assign the fallback value instead"
+	}
+}
+
+// explainImport gives the reason an import the source does not contain was
+// added, or "" for an import written in the source.
+func explainImport(path string, source map[string]*ast.ImportDecl) string {
+	imp, ok := source[path]
+	switch {
+	case !ok:
+		return "imported for generated code"
+	case imp.Auto:
+		return fmt.Sprintf("auto-imported: %s is used without an import", extractPkgName(path))
+	}
+	return ""
+}
+
+// explainTypeParams describes the type parameters inferred for a stdlib
+// function from its placeholder types.
+func explainTypeParams(typeParams []*TypeParameter) string {
+	parts := make([]string, len(typeParams))
+	for i, tp := range typeParams {
+		parts[i] = fmt.Sprintf("%s -> %s %s", tp.Placeholder, tp.Name, tp.Constraint)
+	}
+	return "generic: type parameters inferred from placeholder types (" + strings.Join(parts, ", ") + ")"
+}
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
@@ -12,6 +13,9 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 	if expr == nil {
 		return ""
 	}
+	if lit, ok := g.constantLiteral(expr); ok {
+		return lit
+	}
 
 	switch e := expr.(type) {
 	case *ast.Identifier:
@@ -31,7 +35,7 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 			if t, ok := g.exprTypes[e]; ok && t.Kind == semantic.TypeKindNil {
 				// In generic stdlib context, use *new(T) or *new(K) for zero value instead of nil
 				// But only if the return type at this position actually uses a placeholder type
-				if (g.isStdlibIter || g.isStdlibSlice() || g.isStdlibSort()) && g.placeholderMap != nil {
+				if (g.isStdlibIter || g.isStdlibSlice() || g.isStdlibSort() || g.isStdlibMath() || g.isStdlibRandom() || g.isStdlibLimit()) && g.placeholderMap != nil {
 					// If we're in a return statement and know the return type, check if it's a placeholder
 					if g.currentReturnIndex >= 0 && g.currentReturnIndex < len(g.currentReturnTypes) {
 						retType := g.currentReturnTypes[g.currentReturnIndex]
@@ -44,6 +48,9 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 						if _, hasK := g.placeholderMap["ordered"]; hasK && g.typeContainsPlaceholder(retType, "ordered") {
 							return "*new(K)"
 						}
+						if _, hasN := g.placeholderMap["number"]; hasN && g.typeContainsPlaceholder(retType, "number") {
+							return "*new(N)"
+						}
 						// Return type doesn't use a placeholder — fall through to nil
 					} else {
 						// Not in a return statement context — use *new(T) as default
@@ -65,18 +72,25 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 
 		return e.Value
 	case *ast.IntegerLiteral:
-		// Preserve original representation for octal (0...), hex (0x...), binary (0b...)
+		// Preserve original representation for octal (0...), hex (0x...), binary (0b...),
+		// digit separators (1_000) and constants too large for int64
 		lexeme := e.Token.Lexeme
-		if len(lexeme) > 1 && lexeme[0] == '0' {
+		if (len(lexeme) > 1 && lexeme[0] == '0') || e.Big != nil || strings.Contains(lexeme, "_") {
 			return lexeme // Keep original format
 		}
 		return fmt.Sprintf("%d", e.Value)
 	case *ast.FloatLiteral:
 		return e.Token.Lexeme
+	case *ast.DecimalLiteral:
+		return g.generateDecimalLiteral(e)
 	case *ast.RuneLiteral:
 		return fmt.Sprintf("'%s'", g.escapeRune(e.Value))
 	case *ast.StringLiteral:
 		return g.generateStringLiteral(e)
+	case *ast.DataLiteral:
+		return g.generateDataLiteral(e)
+	case *ast.RegexLiteral:
+		return goRegexString(e.Pattern)
 	case *ast.BooleanLiteral:
 		if e.Value {
 			return "true"
@@ -84,10 +98,16 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 		return "false"
 	case *ast.BinaryExpr:
 		return g.generateBinaryExpr(e)
+	case *ast.ComparisonChainExpr:
+		return g.generateComparisonChain(e)
+	case *ast.RangeCheckExpr:
+		return g.generateRangeCheck(e)
 	case *ast.UnaryExpr:
 		return g.generateUnaryExpr(e)
 	case *ast.PipeExpr:
 		return g.generatePipeExpr(e)
+	case *ast.ParallelPipeExpr:
+		return g.generateParallelPipeExpr(e)
 	case *ast.CallExpr:
 		return g.generateCallExpr(e)
 	case *ast.MethodCallExpr:
@@ -113,13 +133,18 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 	case *ast.ReceiveExpr:
 		channel := g.exprToString(e.Channel)
 		return fmt.Sprintf("<-%s", channel)
+	case *ast.WaitExpr:
+		return g.generateWaitExpr(e)
 	case *ast.TypeCastExpr:
 		targetType := g.generateTypeAnnotation(e.TargetType)
 		expr := g.exprToString(e.Expression)
 		// Use type assertion for interface types, conversion for concrete types.
-		if g.isLikelyInterfaceType(targetType) {
+		if g.isInterfaceTypedExpr(e) {
 			return fmt.Sprintf("%s.(%s)", expr, targetType)
 		}
+		if check, ok := g.castCheckFor(e); ok {
+			return g.generateCheckedCast(e, check, expr)
+		}
 		return fmt.Sprintf("%s(%s)", targetType, expr)
 	case *ast.EmptyExpr:
 		if e.Type != nil {
@@ -132,11 +157,14 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 					}
 				}
 			}
+			if g.isInterfaceTypedExpr(e) {
+				return "nil"
+			}
 			return g.zeroValueForType(e.Type)
 		}
 		// In generic stdlib context, use *new(T) or *new(K) for zero value instead of nil
 		// But only if the return type at this position actually uses a placeholder type
-		if (g.isStdlibIter || g.isStdlibSlice() || g.isStdlibSort()) && g.placeholderMap != nil {
+		if (g.isStdlibIter || g.isStdlibSlice() || g.isStdlibSort() || g.isStdlibMath() || g.isStdlibRandom() || g.isStdlibLimit()) && g.placeholderMap != nil {
 			// If we're in a return statement and know the return type, check if it's a placeholder
 			if g.currentReturnIndex >= 0 && g.currentReturnIndex < len(g.currentReturnTypes) {
 				retType := g.currentReturnTypes[g.currentReturnIndex]
@@ -149,6 +177,9 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 				if _, hasK := g.placeholderMap["ordered"]; hasK && g.typeContainsPlaceholder(retType, "ordered") {
 					return "*new(K)"
 				}
+				if _, hasN := g.placeholderMap["number"]; hasN && g.typeContainsPlaceholder(retType, "number") {
+					return "*new(N)"
+				}
 				// Return type doesn't use a placeholder — fall through to nil
 			} else {
 				// Not in a return statement context — use *new(T) as default
@@ -182,8 +213,22 @@ func (g *Generator) exprToString(expr ast.Expression) string {
 	case *ast.PanicExpr:
 		message := g.exprToString(e.Message)
 		return fmt.Sprintf("panic(%s)", message)
+	case *ast.OnErrExpr:
+		if tmp, ok := g.onErrTemps[e]; ok {
+			return tmp
+		}
+		return g.exprToString(e.Expression)
 	case *ast.RecoverExpr:
 		return "recover()"
+	case *ast.CommandExpr:
+		return g.generateCommandExpr(e)
+	case *ast.BuildStringExpr:
+		return g.generateBuildStringExpr(e)
+	case *ast.ReadExpr:
+		if e.All {
+			return stdinReadAllFunc + "()"
+		}
+		return stdinReadLineFunc + "()"
 	case *ast.FunctionLiteral:
 		return g.generateFunctionLiteral(e)
 	case *ast.ArrowLambda:
@@ -241,6 +286,29 @@ func (g *Generator) generatePipedSwitchExpr(expr *ast.PipedSwitchExpr) string {
 	return result.String()
 }
 
+// generateBuildStringExpr runs the body of a build string block in a func
+// literal that owns the builder (the strings import is added by
+// scanExprForAutoImports):
+//
+//	func() string {
+//		var b strings.Builder
+//		...
+//		return b.String()
+//	}()
+func (g *Generator) generateBuildStringExpr(e *ast.BuildStringExpr) string {
+	child := g.childGenerator(1)
+	child.currentFuncName = g.currentFuncName
+	child.currentReturnTypes = g.currentReturnTypes
+	child.tempCounter = g.tempCounter
+	child.writeLine(fmt.Sprintf("var %s %s.Builder", e.Builder.Value, g.importedName("strings")))
+	for _, stmt := range e.Body.Statements {
+		child.generateStatement(stmt)
+	}
+	child.writeLine(fmt.Sprintf("return %s.String()", e.Builder.Value))
+	g.tempCounter = child.tempCounter
+	return fmt.Sprintf("func() string {\n%s%s}()", child.output.String(), g.indentStr())
+}
+
 func (g *Generator) pipedSwitchReturnType(expr *ast.PipedSwitchExpr) string {
 	if g.exprTypes != nil {
 		if ti, ok := g.exprTypes[expr]; ok && ti != nil && ti.Kind != semantic.TypeKindUnknown {
@@ -450,6 +518,20 @@ func (g *Generator) generateStringLiteral(lit *ast.StringLiteral) string {
 	return g.generateStringFromParts(lit)
 }
 
+// generateDataLiteral emits a data block as one escaped string literal, or
+// for `data lines` a []string with a literal per line.
+func (g *Generator) generateDataLiteral(lit *ast.DataLiteral) string {
+	if !lit.Lines {
+		return fmt.Sprintf("\"%s\"", g.escapeString(lit.Value))
+	}
+	lines := strings.Split(lit.Value, "\n")
+	quoted := make([]string, len(lines))
+	for i, line := range lines {
+		quoted[i] = fmt.Sprintf("\"%s\"", g.escapeString(line))
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
 // generateSepOnlyString handles non-interpolated strings that contain \uE002 (\sep) sentinels.
 func (g *Generator) generateSepOnlyString(value string) string {
 	g.addImport("path/filepath")
@@ -496,17 +578,9 @@ func (g *Generator) generateStringFromParts(lit *ast.StringLiteral) string {
 				format.WriteString(g.escapeString(literal))
 			}
 		} else {
-			format.WriteString("%v")
-			// Check onerr substitution
-			if g.currentOnErrVar != "" {
-				if ident, ok := part.Expr.(*ast.Identifier); ok {
-					if ident.Value == "error" || (g.currentOnErrAlias != "" && ident.Value == g.currentOnErrAlias) {
-						args = append(args, g.currentOnErrVar)
-						continue
-					}
-				}
-			}
-			args = append(args, g.exprToString(part.Expr))
+			verb, arg := g.interpolatedArg(part.Expr)
+			format.WriteString(verb)
+			args = append(args, arg)
 		}
 	}
 
@@ -517,6 +591,36 @@ func (g *Generator) generateStringFromParts(lit *ast.StringLiteral) string {
 	return fmt.Sprintf("fmt.Sprintf(\"%s\", %s)", format.String(), argsStr)
 }
 
+// interpolatedArg returns the Sprintf verb and argument for an expression
+// interpolated into a string.
+func (g *Generator) interpolatedArg(expr ast.Expression) (string, string) {
+	// Check onerr substitution
+	if g.currentOnErrVar != "" {
+		if ident, ok := expr.(*ast.Identifier); ok {
+			if ident.Value == "error" || (g.currentOnErrAlias != "" && ident.Value == g.currentOnErrAlias) {
+				return "%v", g.currentOnErrVar
+			}
+		}
+	}
+	if ti, ok := g.exprTypes[expr]; ok && ti != nil && ti.Kind == semantic.TypeKindFloat {
+		return "%s", g.formatFloatArg(expr, ti)
+	}
+	return "%v", g.exprToString(expr)
+}
+
+// formatFloatArg formats a float interpolated into a string in plain decimal
+// notation: "{total}" reads 1000000 and 0.000012 where %v gives 1e+06 and
+// 1.2e-05. The shortest form that round-trips is kept, as with %v.
+func (g *Generator) formatFloatArg(expr ast.Expression, ti *semantic.TypeInfo) string {
+	g.addImport("strconv")
+	strconv := g.importedName("strconv")
+	value := g.exprToString(expr)
+	if ti.Name == "float32" {
+		return fmt.Sprintf("%s.FormatFloat(float64(%s), 'f', -1, 32)", strconv, value)
+	}
+	return fmt.Sprintf("%s.FormatFloat(%s, 'f', -1, 64)", strconv, value)
+}
+
 // parseStringPartsOrInterpolation returns a format string and args from a StringLiteral.
 func (g *Generator) parseStringPartsOrInterpolation(lit *ast.StringLiteral) (string, []string) {
 	var format strings.Builder
@@ -538,16 +642,9 @@ func (g *Generator) parseStringPartsOrInterpolation(lit *ast.StringLiteral) (str
 				format.WriteString(g.escapeString(literal))
 			}
 		} else {
-			format.WriteString("%v")
-			if g.currentOnErrVar != "" {
-				if ident, ok := part.Expr.(*ast.Identifier); ok {
-					if ident.Value == "error" || (g.currentOnErrAlias != "" && ident.Value == g.currentOnErrAlias) {
-						args = append(args, g.currentOnErrVar)
-						continue
-					}
-				}
-			}
-			args = append(args, g.exprToString(part.Expr))
+			verb, arg := g.interpolatedArg(part.Expr)
+			format.WriteString(verb)
+			args = append(args, arg)
 		}
 	}
 	return format.String(), args
@@ -556,6 +653,9 @@ func (g *Generator) parseStringPartsOrInterpolation(lit *ast.StringLiteral) (str
 func (g *Generator) generateBinaryExpr(expr *ast.BinaryExpr) string {
 	left := g.exprToString(expr.Left)
 	right := g.exprToString(expr.Right)
+	if expr.Method != "" {
+		return g.generateOperatorCall(expr, left, right)
+	}
 
 	// Map Kukicha operators to Go operators
 	op := expr.Operator
@@ -573,7 +673,95 @@ func (g *Generator) generateBinaryExpr(expr *ast.BinaryExpr) string {
 	return fmt.Sprintf("(%s %s %s)", left, op, right)
 }
 
+// generateOperatorCall lowers an operator the analyzer resolved to an
+// operator method: a + b → a.Add(b). The other comparisons derive from ==
+// and <: a != b → !a.Equal(b), a > b → b.Less(a), a <= b → !b.Less(a),
+// a >= b → !a.Less(b). Swapped operands that may have side effects are
+// passed to a func literal, so they are still evaluated left to right.
+func (g *Generator) generateOperatorCall(expr *ast.BinaryExpr, left, right string) string {
+	negate, swap := false, false
+	switch expr.Operator {
+	case "!=", "not equals", ">=":
+		negate = true
+	case ">":
+		swap = true
+	case "<=":
+		negate, swap = true, true
+	}
+	not := ""
+	if negate {
+		not = "!"
+	}
+	if !swap {
+		return fmt.Sprintf("%s%s.%s(%s)", not, left, expr.Method, right)
+	}
+	if isSideEffectFree(expr.Left) && isSideEffectFree(expr.Right) {
+		return fmt.Sprintf("%s%s.%s(%s)", not, right, expr.Method, left)
+	}
+	return fmt.Sprintf("func(l %s, r %s) bool { return %sr.%s(l) }(%s, %s)",
+		g.typeInfoToGoString(g.exprTypes[expr.Left]), g.typeInfoToGoString(g.exprTypes[expr.Right]), not, expr.Method, left, right)
+}
+
+// generateComparisonChain emits 0 <= x < 10 as (0 <= x && x < 10). A middle
+// operand appears in two comparisons, so when one has side effects the
+// operands are bound to temporaries, in order, inside a func literal:
+// func() bool { v_1 := next(); return 0 <= v_1 && v_1 < 10 }().
+func (g *Generator) generateComparisonChain(expr *ast.ComparisonChainExpr) string {
+	last := len(expr.Operands) - 1
+	bind := slices.ContainsFunc(expr.Operands[1:last], func(e ast.Expression) bool { return !isSideEffectFree(e) })
+	operands := make([]string, len(expr.Operands))
+	var temps []string
+	for i, operand := range expr.Operands {
+		operands[i] = g.exprToString(operand)
+		// The last operand is evaluated once, after the others
+		if bind && i < last && !isSideEffectFree(operand) {
+			tmp := g.uniqueId("v")
+			temps = append(temps, fmt.Sprintf("%s := %s", tmp, operands[i]))
+			operands[i] = tmp
+		}
+	}
+	comparisons := make([]string, len(expr.Operators))
+	for i, operator := range expr.Operators {
+		comparisons[i] = fmt.Sprintf("%s %s %s", operands[i], operator.Lexeme, operands[i+1])
+	}
+	if len(temps) == 0 {
+		return "(" + strings.Join(comparisons, " && ") + ")"
+	}
+	return fmt.Sprintf("func() bool { %s; return %s }()", strings.Join(temps, "; "), strings.Join(comparisons, " && "))
+}
+
+// generateRangeCheck emits x between 1 and 10 as (1 <= x && x <= 10), and
+// x not in 0 to n as (x < 0 || x >= n). A value with side effects is bound to
+// a temporary inside a func literal so it runs once.
+func (g *Generator) generateRangeCheck(expr *ast.RangeCheckExpr) string {
+	value := g.exprToString(expr.Value)
+	var temp string
+	if !isSideEffectFree(expr.Value) {
+		tmp := g.uniqueId("v")
+		temp, value = tmp+" := "+value, tmp
+	}
+	low, high := g.exprToString(expr.Low), g.exprToString(expr.High)
+	var check string
+	switch {
+	case expr.Negated && expr.Inclusive():
+		check = fmt.Sprintf("%s < %s || %s > %s", value, low, value, high)
+	case expr.Negated:
+		check = fmt.Sprintf("%s < %s || %s >= %s", value, low, value, high)
+	case expr.Inclusive():
+		check = fmt.Sprintf("%s <= %s && %s <= %s", low, value, value, high)
+	default:
+		check = fmt.Sprintf("%s <= %s && %s < %s", low, value, value, high)
+	}
+	if temp == "" {
+		return "(" + check + ")"
+	}
+	return fmt.Sprintf("func() bool { %s; return %s }()", temp, check)
+}
+
 func (g *Generator) generateUnaryExpr(expr *ast.UnaryExpr) string {
+	if expr.Operator == "-" && semantic.IsDecimal(g.exprTypes[expr.Right]) {
+		return g.generateDecimalNeg(expr.Right)
+	}
 	right := g.exprToString(expr.Right)
 
 	op := expr.Operator
@@ -631,9 +819,17 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 	// Supports placeholder strategy: a |> b(x, _) becomes b(x, a)
 	// Supports context-first strategy: ctx |> b(x) becomes b(ctx, x)
 
-	// Calculate Left expression first, handling multi-return values if needed
+	// A run of stdlib/slice steps may be fused into a single loop.
+	if fused, ok := g.generateFusedSlicePipe(expr); ok {
+		return fused
+	}
+
+	// Calculate Left expression first, handling multi-return values if needed.
+	// A multi-value source either spreads into the call's leading arguments
+	// (when the callee's arity asks for all of them) or pipes its first value.
 	leftExpr := g.exprToString(expr.Left)
-	if count, ok := g.inferReturnCount(expr.Left); ok && count >= 2 {
+	spread, callee := g.pipeSpread(expr.Left, expr.Right)
+	if count, ok := g.inferReturnCount(expr.Left); ok && count >= 2 && spread == 0 {
 		// Wrap in a function call to only take the first return value
 		// e.g., func() any { val, _ := fetch.Get(...); return val }()
 		blanks := make([]string, count-1)
@@ -650,13 +846,9 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 
 	if call, ok := expr.Right.(*ast.CallExpr); ok {
 		funcName = g.exprToString(call.Function)
-		// Check if this is a print() builtin - transpile to fmt.Println() or fmt.Fprintln(os.Stderr)
-		if id, ok := call.Function.(*ast.Identifier); ok && id.Value == "print" {
-			if g.mcpTarget {
-				funcName = "fmt.Fprintln"
-			} else {
-				funcName = "fmt.Println"
-			}
+		// Check if this is a print() or write() builtin - transpile to fmt.Println() or fmt.Fprintln(os.Stderr)
+		if name, ok := g.printBuiltinFunc(call.Function); ok {
+			funcName = name
 		}
 		arguments = call.Arguments
 		isVariadic = call.Variadic
@@ -668,7 +860,7 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 		funcName = objStr + "." + method.Method.Value
 		if method.Object == nil {
 			// Shorthand: .Method()
-			funcName = leftExpr + "." + method.Method.Value
+			funcName = g.methodReceiver(expr.Left, leftExpr, method) + "." + method.Method.Value
 
 			// Method call: obj.Method(args)
 			arguments = method.Arguments
@@ -694,11 +886,11 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 		// Bare identifier on right side of pipe: treat as function call with piped value
 		// e.g., data |> print  →  fmt.Println(data)
 		funcName := id.Value
-		if funcName == "print" {
+		if name, ok := g.printBuiltinFunc(id); ok {
 			if g.mcpTarget {
-				return fmt.Sprintf("fmt.Fprintln(os.Stderr, %s)", leftExpr)
+				return fmt.Sprintf("%s(os.Stderr, %s)", name, leftExpr)
 			}
-			return fmt.Sprintf("fmt.Println(%s)", leftExpr)
+			return fmt.Sprintf("%s(%s)", name, leftExpr)
 		}
 		return fmt.Sprintf("%s(%s)", funcName, leftExpr)
 	} else {
@@ -706,8 +898,14 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 		panic(fmt.Sprintf("codegen: unhandled pipe target %T at %s:%d:%d", expr.Right, pos.File, pos.Line, pos.Column))
 	}
 
+	// Spreading into a call with other arguments needs the values in temps:
+	// Go only allows f(g()) when g's results are the sole arguments.
+	if spread > 0 && len(arguments) > 0 {
+		return g.generateSpreadPipeCall(leftExpr, spread, callee, funcName, arguments, isVariadic)
+	}
+
 	// Build the argument list using the shared helper
-	args := g.buildPipeArgs(leftExpr, arguments)
+	args := g.appendNamedArgs(expr.Right, g.buildPipeArgs(leftExpr, arguments))
 
 	// Fill in missing trailing args from stdlib registry defaults.
 	// funcName may have been aliased (e.g., "kukistring.PadRight"), so
@@ -726,20 +924,194 @@ func (g *Generator) generatePipeExpr(expr *ast.PipeExpr) string {
 	return fmt.Sprintf("%s(%s)", funcName, strings.Join(args, ", "))
 }
 
+// generateParallelPipeExpr lowers items |>> step into an IIFE that runs the
+// step for every element on a pool of at most GOMAXPROCS goroutines and
+// collects the results by index, so they keep the order of items:
+//
+//	func() []R {
+//		items_1 := items
+//		results_2 := make([]R, len(items_1))
+//		sem_3 := make(chan struct{}, runtime.GOMAXPROCS(0))
+//		var wg_4 sync.WaitGroup
+//		for i_5, item_6 := range items_1 {
+//			wg_4.Add(1)
+//			sem_3 <- struct{}{}
+//			go func() {
+//				defer wg_4.Done()
+//				defer func() { <-sem_3 }()
+//				results_2[i_5] = step(item_6)
+//			}()
+//		}
+//		wg_4.Wait()
+//		return results_2
+//	}()
+//
+// A step returning (T, error) also collects errors by index and the IIFE
+// returns ([]T, error) with the first error in element order.
+func (g *Generator) generateParallelPipeExpr(expr *ast.ParallelPipeExpr) string {
+	count, ok := g.inferReturnCount(expr)
+	if !ok {
+		count = 1
+	}
+	elemType := "any"
+	if ti, ok := g.exprTypes[expr]; ok && ti != nil && ti.Kind == semantic.TypeKindList && ti.ElementType != nil && ti.ElementType.Kind != semantic.TypeKindUnknown {
+		elemType = g.typeInfoToGoString(ti.ElementType)
+	}
+
+	items := g.uniqueId("items")
+	var results, errs string
+	if count > 0 {
+		results = g.uniqueId("results")
+	}
+	if count == 2 {
+		errs = g.uniqueId("errs")
+	}
+	sem := g.uniqueId("sem")
+	wg := g.uniqueId("wg")
+	idx := "_"
+	if count > 0 {
+		idx = g.uniqueId("i")
+	}
+	item := g.uniqueId("item")
+
+	var call string
+	if id, isIdent := expr.Right.(*ast.Identifier); isIdent {
+		call = fmt.Sprintf("%s(%s)", id.Value, item)
+	} else if stepCall, ok := g.generatePipedStepCall(expr.Right, item); ok {
+		call = stepCall
+	} else {
+		pos := expr.Right.Pos()
+		panic(fmt.Sprintf("codegen: unhandled parallel pipe target %T at %s:%d:%d", expr.Right, pos.File, pos.Line, pos.Column))
+	}
+
+	indent := strings.Repeat("\t", g.indent)
+	var b strings.Builder
+	line := func(depth int, format string, args ...any) {
+		b.WriteString(indent + strings.Repeat("\t", depth) + fmt.Sprintf(format, args...) + "\n")
+	}
+
+	switch count {
+	case 0:
+		b.WriteString("func() {\n")
+	case 2:
+		b.WriteString(fmt.Sprintf("func() ([]%s, error) {\n", elemType))
+	default:
+		b.WriteString(fmt.Sprintf("func() []%s {\n", elemType))
+	}
+	line(1, "%s := %s", items, g.exprToString(expr.Left))
+	if count > 0 {
+		line(1, "%s := make([]%s, len(%s))", results, elemType, items)
+	}
+	if count == 2 {
+		line(1, "%s := make([]error, len(%s))", errs, items)
+	}
+	line(1, "%s := make(chan struct{}, runtime.GOMAXPROCS(0))", sem)
+	line(1, "var %s sync.WaitGroup", wg)
+	line(1, "for %s, %s := range %s {", idx, item, items)
+	line(2, "%s.Add(1)", wg)
+	line(2, "%s <- struct{}{}", sem)
+	line(2, "go func() {")
+	line(3, "defer %s.Done()", wg)
+	line(3, "defer func() { <-%s }()", sem)
+	switch count {
+	case 0:
+		line(3, "%s", call)
+	case 2:
+		line(3, "%s[%s], %s[%s] = %s", results, idx, errs, idx, call)
+	default:
+		line(3, "%s[%s] = %s", results, idx, call)
+	}
+	line(2, "}()")
+	line(1, "}")
+	line(1, "%s.Wait()", wg)
+	switch count {
+	case 0:
+	case 2:
+		line(1, "for _, err := range %s {", errs)
+		line(2, "if err != nil {")
+		line(3, "return nil, err")
+		line(2, "}")
+		line(1, "}")
+		line(1, "return %s, nil", results)
+	default:
+		line(1, "return %s", results)
+	}
+	b.WriteString(indent + "}()")
+	return b.String()
+}
+
+// pipeSpread reports how many values a multi-value pipe source passes to the
+// call on the right when they are spread across its leading parameters
+// (split(s) |> join(",") → join(a, b, ",")), along with the callee's type.
+// It returns 0 when only the first value is piped. The decision mirrors the
+// analyzer's via semantic.PipeSpreadsValues and the callee type it recorded.
+func (g *Generator) pipeSpread(left, right ast.Expression) (int, *semantic.TypeInfo) {
+	count, ok := g.inferReturnCount(left)
+	if !ok || count < 2 || g.exprTypes == nil {
+		return 0, nil
+	}
+	var callee ast.Expression
+	var arguments []ast.Expression
+	switch r := right.(type) {
+	case *ast.CallExpr:
+		if len(r.NamedArguments) > 0 {
+			return 0, nil
+		}
+		callee, arguments = r.Function, r.Arguments
+	case *ast.MethodCallExpr:
+		if r.Object == nil || len(r.NamedArguments) > 0 {
+			return 0, nil
+		}
+		callee, arguments = r.Method, r.Arguments
+	default:
+		return 0, nil
+	}
+	if pipePlaceholderIndex(arguments) != -1 {
+		return 0, nil
+	}
+	fn := g.exprTypes[callee]
+	if !semantic.PipeSpreadsValues(fn, count, len(arguments)) {
+		return 0, nil
+	}
+	return count, fn
+}
+
+// generateSpreadPipeCall emits a spread pipe whose call has other arguments
+// as an IIFE that unpacks the source into temps:
+//
+//	func() string { pipe_1, pipe_2 := split(s); return join(pipe_1, pipe_2, ",") }()
+func (g *Generator) generateSpreadPipeCall(leftExpr string, count int, callee *semantic.TypeInfo, funcName string, arguments []ast.Expression, isVariadic bool) string {
+	temps := make([]string, count)
+	for i := range temps {
+		temps[i] = g.uniqueId("pipe")
+	}
+	args := g.buildPipeArgs(strings.Join(temps, ", "), arguments)
+	call := fmt.Sprintf("%s(%s)", funcName, strings.Join(args, ", "))
+	if isVariadic {
+		call = fmt.Sprintf("%s(%s...)", funcName, strings.Join(args, ", "))
+	}
+	unpack := fmt.Sprintf("%s := %s", strings.Join(temps, ", "), leftExpr)
+
+	switch len(callee.Returns) {
+	case 0:
+		return fmt.Sprintf("func() { %s; %s }()", unpack, call)
+	case 1:
+		return fmt.Sprintf("func() %s { %s; return %s }()", g.typeInfoToGoString(callee.Returns[0]), unpack, call)
+	}
+	rets := make([]string, len(callee.Returns))
+	for i, r := range callee.Returns {
+		rets[i] = g.typeInfoToGoString(r)
+	}
+	return fmt.Sprintf("func() (%s) { %s; return %s }()", strings.Join(rets, ", "), unpack, call)
+}
+
 func (g *Generator) generateCallExpr(expr *ast.CallExpr) string {
 	funcName := g.exprToString(expr.Function)
 
-	// Check if this is a print() builtin - transpile to fmt.Println() or fmt.Fprintln(os.Stderr)
-	isPrintCall := false
-	if id, ok := expr.Function.(*ast.Identifier); ok {
-		if id.Value == "print" {
-			isPrintCall = true
-			if g.mcpTarget {
-				funcName = "fmt.Fprintln"
-			} else {
-				funcName = "fmt.Println"
-			}
-		}
+	// Check if this is a print() or write() builtin - transpile to fmt.Println() or fmt.Fprintln(os.Stderr)
+	name, isPrintCall := g.printBuiltinFunc(expr.Function)
+	if isPrintCall {
+		funcName = name
 	}
 
 	// If there are no named arguments and no defaults need filling, use the simple path
@@ -828,7 +1200,7 @@ func (g *Generator) generateCallExpr(expr *ast.CallExpr) string {
 }
 
 func (g *Generator) generateMethodCallExpr(expr *ast.MethodCallExpr) string {
-	object := g.exprToString(expr.Object)
+	object := g.methodReceiver(expr.Object, g.exprToString(expr.Object), expr)
 	method := expr.Method.Value
 
 	// Rewrite package name if it was auto-aliased due to collision
@@ -880,6 +1252,20 @@ func (g *Generator) generateMethodCallExpr(expr *ast.MethodCallExpr) string {
 	return fmt.Sprintf("%s.%s(%s)", object, method, strings.Join(args, ", "))
 }
 
+// methodReceiver returns the Go receiver for a method call. Go takes the
+// address of an addressable receiver implicitly, but a struct literal is not
+// addressable, so a reference-receiver method on one needs an explicit &.
+// (The analyzer rejects other non-addressable receivers.)
+func (g *Generator) methodReceiver(object ast.Expression, objStr string, call *ast.MethodCallExpr) string {
+	if _, ok := object.(*ast.StructLiteralExpr); !ok {
+		return objStr
+	}
+	if methodType := g.exprTypes[call.Method]; methodType != nil && methodType.ReferenceReceiver {
+		return "(&" + objStr + ")"
+	}
+	return objStr
+}
+
 func (g *Generator) generateFieldAccessExpr(expr *ast.FieldAccessExpr) string {
 	object := g.exprToString(expr.Object)
 	field := expr.Field.Value
@@ -1095,3 +1481,25 @@ func (g *Generator) generateReturnExpr(expr *ast.ReturnExpr) string {
 	}
 	return "return " + strings.Join(values, ", ")
 }
+
+// generateDecimalLiteral emits 19.99d as decimal.MustParse("19.99"); the
+// literal's text is checked by the lexer, so it never panics.
+func (g *Generator) generateDecimalLiteral(e *ast.DecimalLiteral) string {
+	pkg := g.importedName("stdlib/decimal")
+	if alias, ok := g.pkgAliases[pkg]; ok {
+		pkg = alias
+	}
+	return fmt.Sprintf("%s.MustParse(%q)", pkg, e.Value)
+}
+
+// generateDecimalNeg emits -x for a Decimal x as x.Neg(), and -19.99d as the
+// negative literal.
+func (g *Generator) generateDecimalNeg(operand ast.Expression) string {
+	switch e := operand.(type) {
+	case *ast.DecimalLiteral:
+		return g.generateDecimalLiteral(&ast.DecimalLiteral{Token: e.Token, Value: "-" + e.Value})
+	case *ast.Identifier, *ast.CallExpr, *ast.MethodCallExpr, *ast.FieldAccessExpr, *ast.IndexExpr:
+		return g.exprToString(operand) + ".Neg()"
+	}
+	return "(" + g.exprToString(operand) + ").Neg()"
+}
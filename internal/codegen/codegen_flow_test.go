@@ -460,6 +460,7 @@ func FilterSemver(tags list of string) list of string
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, genErr := gen.Generate()
 	if genErr != nil {
 		t.Fatalf("codegen error: %v", genErr)
@@ -0,0 +1,248 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// Slice pipeline fusion.
+//
+// A run of stdlib/slice steps in a pipe normally materializes a new slice per
+// step:
+//
+//	nums |> slice.Filter(n => n > 0) |> slice.Map(n => n * 2) |> slice.First(3)
+//	→ slice.First(slice.Map(slice.Filter(nums, ...), ...), 3)
+//
+// When every step is fusible, the run is emitted as one loop that filters,
+// maps, and stops early without intermediate slices:
+//
+//	func() []int {
+//		src_1 := nums
+//		keep_2 := func(n int) bool { return n > 0 }
+//		map_3 := func(n int) int { return n * 2 }
+//		n_4 := 3
+//		taken_5 := 0
+//		out_6 := make([]int, 0)
+//		for _, v_7 := range src_1 {
+//			if !keep_2(v_7) {
+//				continue
+//			}
+//			v_8 := map_3(v_7)
+//			if taken_5 >= n_4 {
+//				break
+//			}
+//			taken_5++
+//			out_6 = append(out_6, v_8)
+//		}
+//		return out_6
+//	}()
+//
+// Fusion changes the order in which callbacks run (element by element instead
+// of step by step) and skips elements past a First, so it only applies when
+// that cannot be observed: callbacks must be expression lambdas whose bodies
+// make no calls or channel operations, counts must be side-effect free, and the
+// analyzer must have recorded a concrete list type for the source and every
+// step so the loop can be typed.
+
+// fusedStep is one fusible slice operation in a pipe run.
+type fusedStep struct {
+	op   string         // "Filter", "Map", "First", or "Drop"
+	arg  ast.Expression // callback for Filter/Map, count for First/Drop
+	elem string         // Go element type after this step
+}
+
+// fusibleSliceOps maps the stdlib/slice functions that fusion understands to
+// whether their argument is a callback (true) or a count (false).
+var fusibleSliceOps = map[string]bool{
+	"Filter": true,
+	"Map":    true,
+	"First":  false,
+	"Drop":   false,
+}
+
+// generateFusedSlicePipe emits expr as a single loop when it ends in a run of
+// at least two fusible stdlib/slice steps that includes a Filter or Map.
+// It returns false when the pipe must be generated step by step.
+func (g *Generator) generateFusedSlicePipe(expr *ast.PipeExpr) (string, bool) {
+	pkg := g.sliceImportName()
+	if pkg == "" || g.exprTypes == nil {
+		return "", false
+	}
+
+	// Collect the trailing run of fusible steps, outermost last.
+	var steps []fusedStep
+	var source ast.Expression = expr
+	for {
+		pipe, ok := source.(*ast.PipeExpr)
+		if !ok {
+			break
+		}
+		step, ok := g.fusibleStep(pkg, pipe)
+		if !ok {
+			break
+		}
+		steps = append([]fusedStep{step}, steps...)
+		source = pipe.Left
+	}
+	if len(steps) < 2 {
+		return "", false
+	}
+	hasCallback := false
+	for _, s := range steps {
+		hasCallback = hasCallback || fusibleSliceOps[s.op]
+	}
+	if !hasCallback {
+		return "", false
+	}
+	if count, ok := g.inferReturnCount(source); ok && count != 1 {
+		return "", false
+	}
+	srcType := g.exprTypes[source]
+	if !isConcreteList(srcType) {
+		return "", false
+	}
+
+	g.fusedImports[g.rewriteStdlibImport("stdlib/slice")] = true
+
+	src := g.uniqueId("src")
+	var b strings.Builder
+	indent := strings.Repeat("\t", g.indent)
+	line := func(depth int, format string, args ...any) {
+		b.WriteString(indent + strings.Repeat("\t", depth) + fmt.Sprintf(format, args...) + "\n")
+	}
+
+	outElem := steps[len(steps)-1].elem
+	b.WriteString(fmt.Sprintf("func() []%s {\n", outElem))
+	line(1, "%s := %s", src, g.exprToString(source))
+
+	// Hoist callbacks and counts so each is evaluated once, in step order.
+	names := make([]string, len(steps))
+	counters := make([]string, len(steps))
+	for i, s := range steps {
+		switch s.op {
+		case "Filter":
+			names[i] = g.uniqueId("keep")
+		case "Map":
+			names[i] = g.uniqueId("map")
+		default:
+			names[i] = g.uniqueId("n")
+		}
+		line(1, "%s := %s", names[i], g.exprToString(s.arg))
+		switch s.op {
+		case "First":
+			counters[i] = g.uniqueId("taken")
+			line(1, "%s := 0", counters[i])
+		case "Drop":
+			counters[i] = g.uniqueId("dropped")
+			line(1, "%s := 0", counters[i])
+		}
+	}
+	out := g.uniqueId("out")
+	line(1, "%s := make([]%s, 0)", out, outElem)
+
+	v := g.uniqueId("v")
+	line(1, "for _, %s := range %s {", v, src)
+	for i, s := range steps {
+		switch s.op {
+		case "Filter":
+			line(2, "if !%s(%s) {", names[i], v)
+			line(3, "continue")
+			line(2, "}")
+		case "Map":
+			next := g.uniqueId("v")
+			line(2, "%s := %s(%s)", next, names[i], v)
+			v = next
+		case "First":
+			line(2, "if %s >= %s {", counters[i], names[i])
+			line(3, "break")
+			line(2, "}")
+			line(2, "%s++", counters[i])
+		case "Drop":
+			line(2, "if %s < %s {", counters[i], names[i])
+			line(3, "%s++", counters[i])
+			line(3, "continue")
+			line(2, "}")
+		}
+	}
+	line(2, "%s = append(%s, %s)", out, out, v)
+	line(1, "}")
+	line(1, "return %s", out)
+	b.WriteString(indent + "}()")
+	return b.String(), true
+}
+
+// fusibleStep reports whether pipe's right side is a stdlib/slice call that
+// can join a fused loop, and describes it.
+func (g *Generator) fusibleStep(pkg string, pipe *ast.PipeExpr) (fusedStep, bool) {
+	method, ok := pipe.Right.(*ast.MethodCallExpr)
+	if !ok || method.Variadic || len(method.NamedArguments) > 0 || len(method.Arguments) != 1 {
+		return fusedStep{}, false
+	}
+	obj, ok := method.Object.(*ast.Identifier)
+	if !ok || obj.Value != pkg {
+		return fusedStep{}, false
+	}
+	callback, known := fusibleSliceOps[method.Method.Value]
+	if !known {
+		return fusedStep{}, false
+	}
+	arg := method.Arguments[0]
+	if callback {
+		lambda, ok := arg.(*ast.ArrowLambda)
+		if !ok || lambda.Body == nil || len(lambda.Parameters) != 1 || !isSideEffectFree(lambda.Body) {
+			return fusedStep{}, false
+		}
+	} else if !isSideEffectFree(arg) {
+		return fusedStep{}, false
+	}
+	ti := g.exprTypes[pipe]
+	if !isConcreteList(ti) {
+		return fusedStep{}, false
+	}
+	return fusedStep{op: method.Method.Value, arg: arg, elem: g.typeInfoToGoString(ti.ElementType)}, true
+}
+
+// sliceImportName returns the name the program uses for stdlib/slice, or ""
+// when it is not imported.
+func (g *Generator) sliceImportName() string {
+	if g.program == nil {
+		return ""
+	}
+	for _, imp := range g.program.Imports {
+		if imp.Path.Value != "stdlib/slice" {
+			continue
+		}
+		if imp.Alias != nil {
+			return imp.Alias.Value
+		}
+		return "slice"
+	}
+	return ""
+}
+
+// isConcreteList reports whether ti is a list whose element type is known.
+func isConcreteList(ti *semantic.TypeInfo) bool {
+	return ti != nil && ti.Kind == semantic.TypeKindList && ti.ElementType != nil &&
+		ti.ElementType.Kind != semantic.TypeKindUnknown
+}
+
+// isSideEffectFree reports whether evaluating expr cannot run user code or
+// touch channels: only identifiers, literals, operators, field access,
+// indexing, conversions, and len() are allowed.
+func isSideEffectFree(expr ast.Expression) bool {
+	return !ast.WalkExpr(expr, func(e ast.Expression) bool {
+		switch e := e.(type) {
+		case *ast.Identifier, *ast.IntegerLiteral, *ast.FloatLiteral, *ast.DecimalLiteral, *ast.RuneLiteral,
+			*ast.StringLiteral, *ast.BooleanLiteral, *ast.BinaryExpr, *ast.UnaryExpr,
+			*ast.FieldAccessExpr, *ast.IndexExpr, *ast.TypeCastExpr:
+			return false
+		case *ast.CallExpr:
+			id, ok := e.Function.(*ast.Identifier)
+			return !ok || id.Value != "len" || len(e.Arguments) != 1
+		}
+		return true
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
 )
 
 // addImport adds an auto-import
@@ -13,9 +14,19 @@ func (g *Generator) addImport(path string) {
 	g.autoImports[path] = true
 }
 
+// importedName returns the name an import used by generated code is
+// available under: the program's own alias, else the package name.
+func (g *Generator) importedName(path string) string {
+	if name := ast.ImportName(g.program, path); name != "" {
+		return name
+	}
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
 func (g *Generator) generateImports() {
 	// Collect all imports
 	imports := make(map[string]string) // path -> alias
+	source := make(map[string]*ast.ImportDecl)
 
 	for _, imp := range g.program.Imports {
 		path := imp.Path.Value
@@ -28,6 +39,7 @@ func (g *Generator) generateImports() {
 		path = g.rewriteStdlibImport(path)
 
 		imports[path] = alias
+		source[path] = imp
 	}
 
 	// Check if we need fmt for string interpolation, print builtin, or onerr explain
@@ -126,7 +138,11 @@ func (g *Generator) generateImports() {
 	// Generate import block
 	specs := make([]importSpec, 0, len(imports))
 	for path, alias := range imports {
-		specs = append(specs, importSpec{path: path, alias: alias})
+		spec := importSpec{path: path, alias: alias}
+		if g.explainCodegen {
+			spec.note = explainImport(path, source)
+		}
+		specs = append(specs, spec)
 	}
 
 	sort.Slice(specs, func(i, j int) bool {
@@ -137,23 +153,14 @@ func (g *Generator) generateImports() {
 	})
 
 	if len(specs) == 1 {
-		spec := specs[0]
-		if spec.alias != "" {
-			g.writeLine(fmt.Sprintf("import %s \"%s\"", spec.alias, spec.path))
-		} else {
-			g.writeLine(fmt.Sprintf("import \"%s\"", spec.path))
-		}
+		g.writeLine("import " + specs[0].String())
 		return
 	}
 
 	g.writeLine("import (")
 	g.indent++
 	for _, spec := range specs {
-		if spec.alias != "" {
-			g.writeLine(fmt.Sprintf("%s \"%s\"", spec.alias, spec.path))
-		} else {
-			g.writeLine(fmt.Sprintf("\"%s\"", spec.path))
-		}
+		g.writeLine(spec.String())
 	}
 	g.indent--
 	g.writeLine(")")
@@ -162,6 +169,19 @@ func (g *Generator) generateImports() {
 type importSpec struct {
 	path  string
 	alias string
+	note  string // Why the import was added (--explain-codegen)
+}
+
+// String renders the spec as it appears after `import`.
+func (s importSpec) String() string {
+	line := fmt.Sprintf("\"%s\"", s.path)
+	if s.alias != "" {
+		line = s.alias + " " + line
+	}
+	if s.note != "" {
+		line += " // " + annotatePrefix + s.note
+	}
+	return line
 }
 
 // extractPkgName returns the Go package name from an import path.
@@ -204,15 +224,39 @@ func (g *Generator) rewriteStdlibImport(path string) string {
 }
 
 func (g *Generator) scanForAutoImports() {
+	g.scanRoutesForAutoImports()
+	g.scanSchedulesForAutoImports()
+	g.scanShutdownForAutoImports()
+	g.scanProfileForAutoImports()
+	g.scanInstrumentForAutoImports()
+	g.scanDerivesForAutoImports()
+	g.scanEnumsForAutoImports()
 	for _, decl := range g.program.Declarations {
-		if fn, ok := decl.(*ast.FunctionDecl); ok {
-			if fn.Body != nil {
-				g.scanBlockForAutoImports(fn.Body)
+		switch d := decl.(type) {
+		case *ast.TypeDecl:
+			g.scanTypeForAutoImports(d.AliasType)
+			for _, field := range d.Fields {
+				g.scanTypeForAutoImports(field.Type)
+			}
+		case *ast.VarDeclStmt:
+			g.scanTypeForAutoImports(d.Type)
+		case *ast.FunctionDecl:
+			if d.Body != nil {
+				g.scanBlockForAutoImports(d.Body)
+			}
+			if d.Yields != nil {
+				g.addImport("iter")
+			}
+			for _, param := range d.Parameters {
+				g.scanTypeForAutoImports(param.Type)
+			}
+			for _, ret := range d.Returns {
+				g.scanTypeForAutoImports(ret)
 			}
 			// Pre-scan for cmp.Ordered constraint: detect "ordered" placeholder
 			// in function signatures so the cmp import is emitted before declarations.
-			if g.isStdlibSort() || g.isStdlibSlice() {
-				if g.funcUsesOrderedPlaceholder(fn) {
+			if g.isStdlibSort() || g.isStdlibSlice() || g.isStdlibMath() {
+				if g.funcUsesOrderedPlaceholder(d) {
 					g.addImport("cmp")
 				}
 			}
@@ -257,27 +301,64 @@ func (g *Generator) scanForFunctionDefaults() {
 	}
 }
 
+// scanTypeForAutoImports adds the import a type annotation compiles to, such
+// as iter for `sequence of T`.
+func (g *Generator) scanTypeForAutoImports(t ast.TypeAnnotation) {
+	switch t := t.(type) {
+	case *ast.SequenceType:
+		g.addImport("iter")
+		g.scanTypeForAutoImports(t.ElementType)
+	case *ast.ListType:
+		g.scanTypeForAutoImports(t.ElementType)
+	case *ast.ChannelType:
+		g.scanTypeForAutoImports(t.ElementType)
+	case *ast.ReferenceType:
+		g.scanTypeForAutoImports(t.ElementType)
+	case *ast.MapType:
+		g.scanTypeForAutoImports(t.KeyType)
+		g.scanTypeForAutoImports(t.ValueType)
+	case *ast.FunctionType:
+		for _, sub := range t.Parameters {
+			g.scanTypeForAutoImports(sub)
+		}
+		for _, sub := range t.Returns {
+			g.scanTypeForAutoImports(sub)
+		}
+	}
+}
+
 func (g *Generator) scanBlockForAutoImports(block *ast.BlockStmt) {
 	for _, stmt := range block.Statements {
 		g.scanStmtForAutoImports(stmt)
 	}
 }
 
+// scanOnErrForAutoImports scans an onerr handler, and adds log/slog when
+// handlers log the errors they handle (see logsOnErr). A discarded error
+// is never checked, so it is not logged either.
+func (g *Generator) scanOnErrForAutoImports(clause *ast.OnErrClause) {
+	g.scanExprForAutoImports(clause.Handler)
+	if _, discard := clause.Handler.(*ast.DiscardExpr); !discard && g.logsOnErr() {
+		g.addImport("log/slog")
+	}
+}
+
 func (g *Generator) scanStmtForAutoImports(stmt ast.Statement) {
 	switch s := stmt.(type) {
 	case *ast.VarDeclStmt:
+		g.scanTypeForAutoImports(s.Type)
 		for _, val := range s.Values {
 			g.scanExprForAutoImports(val)
 		}
 		if s.OnErr != nil {
-			g.scanExprForAutoImports(s.OnErr.Handler)
+			g.scanOnErrForAutoImports(s.OnErr)
 		}
 	case *ast.AssignStmt:
 		for _, val := range s.Values {
 			g.scanExprForAutoImports(val)
 		}
 		if s.OnErr != nil {
-			g.scanExprForAutoImports(s.OnErr.Handler)
+			g.scanOnErrForAutoImports(s.OnErr)
 		}
 	case *ast.ReturnStmt:
 		for _, val := range s.Values {
@@ -302,6 +383,9 @@ func (g *Generator) scanStmtForAutoImports(stmt ast.Statement) {
 			for _, v := range c.Values {
 				g.scanExprForAutoImports(v)
 			}
+			if c.Guard != nil {
+				g.scanExprForAutoImports(c.Guard)
+			}
 			if c.Body != nil {
 				g.scanBlockForAutoImports(c.Body)
 			}
@@ -354,7 +438,7 @@ func (g *Generator) scanStmtForAutoImports(stmt ast.Statement) {
 	case *ast.ExpressionStmt:
 		g.scanExprForAutoImports(s.Expression)
 		if s.OnErr != nil {
-			g.scanExprForAutoImports(s.OnErr.Handler)
+			g.scanOnErrForAutoImports(s.OnErr)
 		}
 	case *ast.DeferStmt:
 		g.scanExprForAutoImports(s.Call)
@@ -365,9 +449,31 @@ func (g *Generator) scanStmtForAutoImports(stmt ast.Statement) {
 		if s.Block != nil {
 			g.scanBlockForAutoImports(s.Block)
 		}
+	case *ast.SafelyStmt:
+		g.addImport("fmt") // the recovered panic is wrapped with fmt.Errorf
+		g.scanBlockForAutoImports(s.Body)
+		if s.OnErr != nil {
+			g.scanOnErrForAutoImports(s.OnErr)
+		}
 	case *ast.SendStmt:
 		g.scanExprForAutoImports(s.Value)
 		g.scanExprForAutoImports(s.Channel)
+	case *ast.YieldStmt:
+		for _, v := range s.Values {
+			g.scanExprForAutoImports(v)
+		}
+	case *ast.RequireStmt:
+		g.scanExprForAutoImports(s.Condition)
+		if s.Else != nil {
+			g.scanBlockForAutoImports(s.Else)
+		}
+	case *ast.FailStmt:
+		g.addImport("fmt")
+		g.addImport("os")
+		g.scanExprForAutoImports(s.Message)
+		if s.Code != nil {
+			g.scanExprForAutoImports(s.Code)
+		}
 	case *ast.ElseStmt:
 		if s.Body != nil {
 			g.scanBlockForAutoImports(s.Body)
@@ -381,18 +487,68 @@ func (g *Generator) scanExprForAutoImports(expr ast.Expression) {
 	}
 
 	switch e := expr.(type) {
+	case *ast.CommandExpr:
+		g.addImport("context")
+		g.addImport("fmt")
+		g.addImport("os/exec")
+		g.addImport("strings")
+		g.scanExprForAutoImports(e.Command)
+	case *ast.ReadExpr:
+		g.addImport("bufio")
+		g.addImport("io")
+		g.addImport("os")
+		g.addImport("strings")
+	case *ast.WaitExpr:
+		if e.Timeout != nil {
+			g.addImport("fmt")
+			g.addImport("time")
+			g.scanExprForAutoImports(e.Timeout)
+		}
+		g.scanExprForAutoImports(e.Channel)
 	case *ast.StringLiteral:
 		if strings.ContainsRune(e.Value, '\uE002') {
 			g.addImport("path/filepath")
 		}
+		for _, part := range e.Parts {
+			if part.IsLiteral {
+				continue
+			}
+			// Floats are interpolated with strconv.FormatFloat (see formatFloatArg)
+			if ti := g.exprTypes[part.Expr]; ti != nil && ti.Kind == semantic.TypeKindFloat {
+				g.addImport("strconv")
+			}
+			g.scanExprForAutoImports(part.Expr)
+		}
 	case *ast.BinaryExpr:
 		g.scanExprForAutoImports(e.Left)
 		g.scanExprForAutoImports(e.Right)
+	case *ast.ComparisonChainExpr:
+		for _, operand := range e.Operands {
+			g.scanExprForAutoImports(operand)
+		}
+	case *ast.DecimalLiteral:
+		g.addImport(g.rewriteStdlibImport("stdlib/decimal"))
+	case *ast.RangeCheckExpr:
+		g.scanExprForAutoImports(e.Value)
+		g.scanExprForAutoImports(e.Low)
+		g.scanExprForAutoImports(e.High)
+	case *ast.WhenPattern:
+		if e.Operator == "matches" {
+			g.addImport("regexp")
+		}
+		g.scanExprForAutoImports(e.Low)
+		g.scanExprForAutoImports(e.Value)
 	case *ast.UnaryExpr:
 		g.scanExprForAutoImports(e.Right)
 	case *ast.PipeExpr:
 		g.scanExprForAutoImports(e.Left)
 		g.scanExprForAutoImports(e.Right)
+	case *ast.ParallelPipeExpr:
+		// The worker pool bounds concurrency with runtime.GOMAXPROCS and waits on a sync.WaitGroup.
+		g.addImport("runtime")
+		g.addImport("sync")
+		g.scanExprForAutoImports(e.Left)
+		g.scanExprForAutoImports(e.Right)
 	case *ast.CallExpr:
 		g.scanExprForAutoImports(e.Function)
 		for _, arg := range e.Arguments {
@@ -417,6 +573,12 @@ func (g *Generator) scanExprForAutoImports(expr ast.Expression) {
 			g.scanExprForAutoImports(e.End)
 		}
 	case *ast.FunctionLiteral:
+		for _, param := range e.Parameters {
+			g.scanTypeForAutoImports(param.Type)
+		}
+		for _, ret := range e.Returns {
+			g.scanTypeForAutoImports(ret)
+		}
 		if e.Body != nil {
 			g.scanBlockForAutoImports(e.Body)
 		}
@@ -444,7 +606,16 @@ func (g *Generator) scanExprForAutoImports(expr ast.Expression) {
 		g.scanExprForAutoImports(e.Message)
 	case *ast.PanicExpr:
 		g.scanExprForAutoImports(e.Message)
+	case *ast.OnErrExpr:
+		g.scanExprForAutoImports(e.Expression)
+		g.scanOnErrForAutoImports(e.OnErr)
 	case *ast.TypeCastExpr:
+		if check, ok := g.castCheckFor(e); ok {
+			for _, path := range check.imports {
+				g.addImport(path)
+			}
+		}
+		g.scanTypeForAutoImports(e.TargetType)
 		g.scanExprForAutoImports(e.Expression)
 	case *ast.TypeAssertionExpr:
 		g.scanExprForAutoImports(e.Expression)
@@ -456,6 +627,9 @@ func (g *Generator) scanExprForAutoImports(expr ast.Expression) {
 		if e.Body != nil {
 			g.scanBlockForAutoImports(e.Body)
 		}
+	case *ast.BuildStringExpr:
+		g.addImport("strings")
+		g.scanBlockForAutoImports(e.Body)
 	case *ast.PipedSwitchExpr:
 		g.scanExprForAutoImports(e.Left)
 	}
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// instrumentedName returns the span and metric name of an instrumented
+// function, "petiole.Func" or "petiole.Type.Method", or "" when decl is not
+// instrumented: only exported functions with a body are, and not iterators
+// (their span would end before the first value is yielded).
+func (g *Generator) instrumentedName(decl *ast.FunctionDecl) string {
+	name := decl.Name.Value
+	if !g.instrument || decl.Body == nil || decl.Yields != nil || !unicode.IsUpper([]rune(name)[0]) {
+		return ""
+	}
+	pkg := "main"
+	if g.program.PetioleDecl != nil {
+		pkg = g.program.PetioleDecl.Name.Value
+	}
+	if decl.Receiver != nil {
+		t := decl.Receiver.Type
+		if ref, ok := t.(*ast.ReferenceType); ok {
+			t = ref.ElementType
+		}
+		recv := g.generateTypeAnnotation(t)
+		// A generic receiver is named without its type arguments
+		recv, _, _ = strings.Cut(recv, "[")
+		return pkg + "." + recv + "." + name
+	}
+	return pkg + "." + name
+}
+
+// needsInstrument reports whether the program uses stdlib/telemetry: some
+// function is instrumented, or main sets telemetry up.
+func (g *Generator) needsInstrument() bool {
+	if !g.instrument {
+		return false
+	}
+	if g.mainFunc() != nil || g.needsRouteMain(g.routeHandlers()) || g.needsScheduleMain(g.scheduledJobs()) {
+		return true
+	}
+	for _, decl := range g.program.Declarations {
+		if fn, ok := decl.(*ast.FunctionDecl); ok && g.instrumentedName(fn) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// scanInstrumentForAutoImports adds stdlib/telemetry for --instrument.
+func (g *Generator) scanInstrumentForAutoImports() {
+	if g.needsInstrument() {
+		g.addImport(g.rewriteStdlibImport("stdlib/telemetry"))
+	}
+}
+
+// instrumentedReturns names the results of an instrumented function that
+// returns an error, so the deferred End can see the error it returns:
+// (_ int, err_1 error). It returns the result list and the error's name,
+// or "" when the function returns no error and keeps its results unnamed.
+func (g *Generator) instrumentedReturns(returns []ast.TypeAnnotation) (string, string) {
+	if len(returns) == 0 || !isErrorType(returns[len(returns)-1]) {
+		return g.generateReturnTypes(returns), ""
+	}
+	errVar := g.uniqueId("err")
+	parts := make([]string, len(returns))
+	for i, ret := range returns {
+		parts[i] = "_ " + g.generateTypeAnnotation(ret)
+	}
+	parts[len(parts)-1] = errVar + " error"
+	return "(" + strings.Join(parts, ", ") + ")", errVar
+}
+
+// generateInstrumentPrelude starts an instrumented function with its span:
+//
+//	defer telemetry.Start("main.Charge").End(&err_1)
+//
+// A function taking a context.Context starts a child of the span in it,
+// and passes the new span on to the functions it calls:
+//
+//	ctx, call_2 := telemetry.StartContext(ctx, "main.Charge")
+//	defer call_2.End(&err_1)
+func (g *Generator) generateInstrumentPrelude(name, errVar string) {
+	telemetryPkg := g.importedName("stdlib/telemetry")
+	errArg := "nil"
+	if errVar != "" {
+		errArg = "&" + errVar
+	}
+	if g.contextParam == "" || g.contextParam == "_" {
+		g.writeLine(fmt.Sprintf("defer %s.Start(%q).End(%s)", telemetryPkg, name, errArg))
+		return
+	}
+	call := g.uniqueId("call")
+	g.writeLine(fmt.Sprintf("%s, %s := %s.StartContext(%s, %q)", g.contextParam, call, telemetryPkg, g.contextParam, name))
+	g.writeLine(fmt.Sprintf("defer %s.End(%s)", call, errArg))
+}
+
+// generateTelemetrySetup starts main with `defer telemetry.Auto()()`, which
+// configures telemetry from the environment and flushes it when main
+// returns.
+func (g *Generator) generateTelemetrySetup() {
+	if g.needsInstrument() {
+		g.writeLine(fmt.Sprintf("defer %s.Auto()()", g.importedName("stdlib/telemetry")))
+	}
+}
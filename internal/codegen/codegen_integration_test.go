@@ -3,6 +3,7 @@ package codegen
 import (
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 
 	kukiparser "github.com/duber000/kukicha/internal/parser"
@@ -35,6 +36,8 @@ func fullPipeline(t *testing.T, source, filename string) string {
 	gen.SetSourceFile(filename)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	gen.SetCaptures(analyzer.Captures())
 
 	output, err := gen.Generate()
 	if err != nil {
@@ -146,6 +149,56 @@ func TestIntegration_ForRangeLoop(t *testing.T) {
 	assertValidGo(t, output)
 }
 
+func TestIntegration_ForRangeOneValue(t *testing.T) {
+	// Channels and iterator functions yield one value, so no index is emitted;
+	// a blank variable drops both.
+	source := `func drain(ch channel of string, seq func(func(int) bool), items list of int) int
+    total := 0
+    for msg in ch
+        total = total + len(msg)
+    for n in seq
+        total = total + n
+    for _ in ch
+        total = total + 1
+    for _ in items
+        total = total + 1
+    return total
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"for msg := range ch {", "for n := range seq {", "for range ch {", "for range items {"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_PipeNamedArgsAndDefaults(t *testing.T) {
+	// Piped stdlib calls keep named arguments and fill in defaults, with or
+	// without an onerr clause.
+	source := `import "stdlib/pool"
+
+func upper(s string) (string, error)
+    return s, empty
+
+func run(words list of string) (list of string, error)
+    a := words |> pool.Map(upper) onerr return
+    b := words |> pool.Map(upper, concurrency: 2) onerr return
+    c, err := words |> pool.Map(upper)
+    if err != empty
+        return empty, err
+    all := append(a, many b)
+    return append(all, many c), empty
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"pool.Map(words, upper, 8)", "pool.Map(words, upper, 2)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
 func TestIntegration_ForNumericLoop(t *testing.T) {
 	source := `func countUp(n int) int
     total := 0
@@ -249,6 +302,254 @@ func TestIntegration_Channel(t *testing.T) {
 	assertValidGo(t, output)
 }
 
+func TestIntegration_YieldsFunction(t *testing.T) {
+	source := `type Cache
+    data map of string to int
+
+func Entries on c Cache yields string, int
+    for k, v in c.data
+        yield k, v
+
+func Evens(limit int) yields int
+    for i from 0 to limit
+        if i % 2 equals 0
+            yield i
+
+func main()
+    for n in Evens(10)
+        print(n)
+    c := Cache{data: map of string to int{"a": 1}}
+    for k, v in c.Entries()
+        print("{k}={v}")
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"func (c Cache) Entries() iter.Seq2[string, int] {",
+		"return func(yield func(string, int) bool) {",
+		"func Evens(limit int) iter.Seq[int] {",
+		"if !yield(i) {",
+		"for n := range Evens(10) {",
+		`"iter"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_SequenceGenerator(t *testing.T) {
+	source := `func Fib(limit int) sequence of int
+    a, b := 0, 1
+    for a < limit
+        yield a
+        a, b = b, a + b
+
+func Take(seq sequence of int, n int) list of int
+    out := list of int{}
+    for v in seq
+        if len(out) >= n
+            break
+        out = append(out, v)
+    return out
+
+func main()
+    print(Take(Fib(100), 5))
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"func Fib(limit int) iter.Seq[int] {",
+		"return func(yield func(int) bool) {",
+		"func Take(seq iter.Seq[int], n int) []int {",
+		"for v := range seq {",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_ChannelDirection(t *testing.T) {
+	source := `func produce(out channel of int send-only)
+    send 1 to out
+    close(out)
+
+func consume(src channel of int receive-only) int
+    return receive from src
+
+func nested(c channel of channel of int receive-only)
+    print(c)
+
+func main()
+    ch := make(channel of int, 1)
+    produce(ch)
+    print(consume(ch))
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"out chan<- int", "src <-chan int", "c chan (<-chan int)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_WaitExpr(t *testing.T) {
+	source := `import "time"
+
+func fetch(n int, out channel of int send-only)
+    send n * 10 to out
+
+func main()
+    a := make(channel of int, 1)
+    b := make(channel of int, 1)
+    go fetch(1, a)
+    first := wait for a
+    go fetch(2, a)
+    second := wait for a within 2 * time.Second onerr panic "{error}"
+    go fetch(3, a)
+    go fetch(4, b)
+    both := wait for all [a, b]
+    chans := list of channel of int{a, b}
+    rest, err := wait for all chans within time.Second
+    print(first, second, both, rest, err)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"first := <-a",
+		"func(c <-chan int, d time.Duration) (int, error) {",
+		"case <-time.After(d):",
+		"}(a, time.Duration((2 * time.Second)))",
+		"func(chans []<-chan int) []int {",
+		"}([]<-chan int{a, b})",
+		"func(chans []chan int, d time.Duration) ([]int, error) {",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_DistinctTypes(t *testing.T) {
+	source := `type Meters float64 distinct
+type Seconds float64 distinct
+type Count int
+
+func Speed(d Meters, t Seconds) float64
+    return (d as float64) / (t as float64)
+
+func main()
+    d := Meters(100) + 20
+    n := 3
+    c := Count(1) + n
+    print(Speed(d, 4 as Seconds), c)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"type Meters float64",
+		"type Seconds float64",
+		"type Count = int",
+		"func Speed(d Meters, t Seconds) float64 {",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_EnumType(t *testing.T) {
+	source := `import "encoding/json"
+
+type Status string distinct
+    Active "active"
+    Banned "banned"
+
+type User
+    Name string as "name"
+    Status Status as "status"
+
+func main()
+    data := json.Marshal(User{Name: "a", Status: Active}) onerr panic "{error}"
+    switch Banned
+        when Active
+            print("active")
+        when "banned"
+            print(data)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"type Status string",
+		"Active Status = \"active\"",
+		"func (v Status) MarshalJSON() ([]byte, error) {",
+		"case Active, Banned:",
+		"func (v *Status) UnmarshalJSON(data []byte) error {",
+		"return fmt.Errorf(\"%q is not a Status value\", s)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_OperatorMethods(t *testing.T) {
+	source := `type Vector
+    x int
+    y int
+
+func Add on v Vector(o Vector) Vector operator +
+    return Vector{x: v.x + o.x, y: v.y + o.y}
+
+func Equal on v Vector(o Vector) bool operator ==
+    return v.x == o.x and v.y == o.y
+
+func Less on v Vector(o Vector) bool operator <
+    return v.x < o.x
+
+func origin() Vector
+    return Vector{}
+
+func main()
+    a := Vector{x: 1, y: 2}
+    b := a + a + a
+    print(b == a, b != a, a < b, b > a, a <= b, b >= a, origin() > a)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"b := a.Add(a).Add(a)",
+		"b.Equal(a), !b.Equal(a), a.Less(b), a.Less(b), !b.Less(a), !b.Less(a)",
+		"func(l Vector, r Vector) bool { return r.Less(l) }(origin(), a)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_DecimalLiterals(t *testing.T) {
+	source := `func main()
+    price := 19.99d
+    total := price * 3d + 0.5d
+    print(total.Round(1), total >= price)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		`"github.com/duber000/kukicha/stdlib/decimal"`,
+		`price := decimal.MustParse("19.99")`,
+		`total := price.Mul(decimal.MustParse("3")).Add(decimal.MustParse("0.5"))`,
+		"!total.Less(price)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
 func TestIntegration_DefaultParams(t *testing.T) {
 	source := `func Greet(name string, greeting string = "Hello") string
     return "{greeting}, {name}!"
@@ -353,3 +654,244 @@ func TestIntegration_ForThrough(t *testing.T) {
 	output := fullPipeline(t, source, "test.kuki")
 	assertValidGo(t, output)
 }
+
+func TestIntegration_InterfaceCastUsesAnalyzerType(t *testing.T) {
+	source := `import "io"
+
+interface Shape
+    Area() float64
+
+func describe(x any, r io.Reader) float64
+    s := x as Shape
+    r = empty io.Reader
+    print(r)
+    return s.Area()
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	if !strings.Contains(output, "x.(Shape)") {
+		t.Errorf("expected type assertion for interface cast, got:\n%s", output)
+	}
+	if !strings.Contains(output, "r = nil") {
+		t.Errorf("expected nil zero value for interface empty, got:\n%s", output)
+	}
+}
+
+func TestIntegration_GoBlockCopiesLoopReassignedCaptures(t *testing.T) {
+	// current is reassigned by the loop, so each goroutine gets its own copy;
+	// total is written by the goroutine and stays shared.
+	source := `func run(names list of string)
+    current := ""
+    total := 0
+    for name in names
+        current = name
+        total = total + 1
+        go
+            print(current)
+        go
+            total = total + 1
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	if !strings.Contains(output, "go func(current string) {") || !strings.Contains(output, "}(current)") {
+		t.Errorf("expected current passed to the goroutine, got:\n%s", output)
+	}
+	if !strings.Contains(output, "go func() {") {
+		t.Errorf("expected total to stay shared, got:\n%s", output)
+	}
+}
+
+func TestIntegration_SafelyRecoversIntoOnErr(t *testing.T) {
+	source := `func risky(n int) int
+    if n > 2
+        panic("too big")
+    return n
+
+func compute(n int) (int, error)
+    result := 0
+    safely
+        result = risky(n)
+    onerr return
+    return result, empty
+
+func main()
+    safely
+        risky(9)
+    onerr as e
+        print("recovered: {e}")
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"defer func() {", "if r := recover(); r != nil {", `fmt.Errorf("panic: %v", r)`, "return nil\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_FailExitsWithCode(t *testing.T) {
+	source := `func main()
+    path := "config.json"
+    if path == ""
+        fail "no path"
+    fail "missing {path}" code 2
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{`"os"`, `fmt.Fprintln(os.Stderr, "no path")`, "os.Exit(1)", "os.Exit(2)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_RequireStmt(t *testing.T) {
+	source := `func First(items list of string) (string, error)
+    require len(items) > 0 else return "", error "empty"
+    for item in items
+        require not (item == "") else continue
+        return item, empty
+    return "", error "all blank"
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"if !(len(items) > 0) {", `return "", errors.New("empty")`, `if (item == "") {`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_StdinBuiltins(t *testing.T) {
+	source := `func main()
+    write("name? ")
+    name := read line onerr "anon"
+    rest := read all onerr ""
+    print(name, len(rest))
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{`fmt.Print("name? ")`, "kukichaReadLine()", "kukichaReadAll()",
+		"var kukichaStdin = bufio.NewReader(os.Stdin)", "func kukichaReadLine() (string, error) {", `"io"`, `"strings"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_DeclaredWriteIsNotBuiltin(t *testing.T) {
+	source := `func write(s string)
+    print(s)
+
+func main()
+    write("x")
+`
+	output := fullPipeline(t, source, "test.kuki")
+	if !strings.Contains(output, `write("x")`) || strings.Contains(output, "fmt.Print(") {
+		t.Errorf("expected the declared write to be called:\n%s", output)
+	}
+}
+
+func TestIntegration_DataBlocks(t *testing.T) {
+	source := `func main()
+    query := data
+        SELECT "name"
+            FROM users
+    rows := data lines
+        a
+        b
+    print(query, len(rows))
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{`query := "SELECT \"name\"\n    FROM users"`, `rows := []string{"a", "b"}`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_CommandExpr(t *testing.T) {
+	source := `import "context"
+
+func head(ctx context.Context, ref string) (string, error)
+    return $ "git log -1 '--format=%H %s' {ref}"
+
+func main()
+    n := 2
+    out := $ "echo {n}" onerr panic "{error}"
+    print(out)
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		`return kukichaCommand(ctx, "git", "log", "-1", "--format=%H %s", ref)`,
+		`kukichaCommand(context.Background(), "echo", fmt.Sprintf("%v", n))`,
+		"func kukichaCommand(ctx context.Context, name string, args ...string) (string, error) {",
+		"exec.CommandContext(ctx, name, args...)", `"os/exec"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_SentinelErrors(t *testing.T) {
+	source := `error NotFound "resource not found"
+
+error
+    Conflict "resource already exists"
+    Expired "token expired"
+
+func find(key string) error
+    if key == "x"
+        return Conflict
+    return NotFound
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		`var NotFound = errors.New("resource not found")`,
+		"var (\n\tConflict = errors.New(\"resource already exists\")\n\tExpired = errors.New(\"token expired\")\n)",
+		`import "errors"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_BuildString(t *testing.T) {
+	source := `func render(names list of string) string
+    return build string as b
+        for n in names
+            b.WriteString("- {n}\n")
+
+func main()
+    print(render(list of string{"a", "b"}))
+`
+	output := fullPipeline(t, source, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"return func() string {\n\t\tvar b strings.Builder\n",
+		"\t\treturn b.String()\n\t}()",
+		`"strings"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestIntegration_SafelyImportsFmt(t *testing.T) {
+	source := `func run() error
+    safely
+        panic("boom")
+    onerr return
+    return empty
+`
+	output := fullPipeline(t, source, "test.kuki")
+	if !strings.Contains(output, `import "fmt"`) {
+		t.Errorf("expected fmt import for the recovered panic:\n%s", output)
+	}
+}
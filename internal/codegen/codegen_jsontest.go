@@ -0,0 +1,199 @@
+package codegen
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// `@derive jsontest` adds nothing to the type itself. Instead the build
+// writes a companion _test.go file (GenerateDeriveTests) with a table-driven
+// round-trip test per marked type. Two values, the defaults (the zero value,
+// with enums at their first value) and a sample with every field it can
+// fill, are marshalled, unmarshalled and marshalled again; the encodings
+// must match, and the sample's JSON object must carry each field under its
+// alias (or its name).
+
+// GenerateDeriveTests returns the Go test file for the types marked
+// `@derive jsontest`, or "" when there are none. Call it after Generate,
+// which resolves the package aliases type names use.
+func (g *Generator) GenerateDeriveTests() string {
+	var types []*ast.TypeDecl
+	for _, decl := range g.program.Declarations {
+		typeDecl, ok := decl.(*ast.TypeDecl)
+		if !ok || typeDecl.AliasType != nil {
+			continue
+		}
+		for _, d := range ast.TypeDerives(typeDecl) {
+			if d.Name == "jsontest" {
+				types = append(types, typeDecl)
+				break
+			}
+		}
+	}
+	if len(types) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("// Generated by Kukicha from @derive jsontest (requires Go 1.26+)\n\n")
+	if g.buildTag != "" {
+		fmt.Fprintf(&b, "//go:build %s\n\n", g.buildTag)
+	}
+	packageName := "main"
+	if g.program.PetioleDecl != nil {
+		packageName = g.program.PetioleDecl.Name.Value
+	}
+	fmt.Fprintf(&b, "package %s\n\nimport (\n\t\"encoding/json\"\n\t\"testing\"\n)\n", packageName)
+	for _, decl := range types {
+		b.WriteString("\n")
+		g.writeJSONRoundTripTest(&b, decl)
+	}
+	return b.String()
+}
+
+// writeJSONRoundTripTest writes TestNameJSONRoundTrip for one type.
+func (g *Generator) writeJSONRoundTripTest(b *strings.Builder, decl *ast.TypeDecl) {
+	name := decl.Name.Value
+	var keys []string
+	for _, field := range decl.Fields {
+		if _, ok := g.jsonValue(field.Type, field.Name.Value, 1, true, 0); !ok {
+			continue
+		}
+		if key, ok := jsonKey(field); ok {
+			keys = append(keys, strconv.Quote(key))
+		}
+	}
+
+	fmt.Fprintf(b, "func Test%sJSONRoundTrip(t *testing.T) {\n", exportedTestName(name))
+	b.WriteString("\ttests := []struct {\n\t\tname  string\n")
+	fmt.Fprintf(b, "\t\tvalue %s\n", name)
+	b.WriteString("\t\tkeys  []string\n\t}{\n")
+	fmt.Fprintf(b, "\t\t{name: \"defaults\", value: %s{%s}},\n", name, strings.Join(g.jsonFields(decl, false, 0), ", "))
+	fmt.Fprintf(b, "\t\t{name: \"sample\", value: %s{%s}, keys: []string{%s}},\n", name, strings.Join(g.jsonFields(decl, true, 0), ", "), strings.Join(keys, ", "))
+	b.WriteString("\t}\n")
+	b.WriteString("\tfor _, tt := range tests {\n")
+	b.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	b.WriteString("\t\t\tdata, err := json.Marshal(tt.value)\n")
+	b.WriteString("\t\t\tif err != nil {\n\t\t\t\tt.Fatalf(\"marshal: %v\", err)\n\t\t\t}\n")
+	fmt.Fprintf(b, "\t\t\tvar decoded %s\n", name)
+	b.WriteString("\t\t\tif err := json.Unmarshal(data, &decoded); err != nil {\n\t\t\t\tt.Fatalf(\"unmarshal %s: %v\", data, err)\n\t\t\t}\n")
+	b.WriteString("\t\t\tagain, err := json.Marshal(decoded)\n")
+	b.WriteString("\t\t\tif err != nil {\n\t\t\t\tt.Fatalf(\"marshal decoded value: %v\", err)\n\t\t\t}\n")
+	b.WriteString("\t\t\tif string(again) != string(data) {\n\t\t\t\tt.Errorf(\"round trip changed the JSON:\\n first  %s\\n second %s\", data, again)\n\t\t\t}\n")
+	b.WriteString("\t\t\tif len(tt.keys) == 0 {\n\t\t\t\treturn\n\t\t\t}\n")
+	b.WriteString("\t\t\tvar wire map[string]json.RawMessage\n")
+	b.WriteString("\t\t\tif err := json.Unmarshal(data, &wire); err != nil {\n\t\t\t\tt.Fatalf(\"expected a JSON object, got %s\", data)\n\t\t\t}\n")
+	b.WriteString("\t\t\tfor _, key := range tt.keys {\n")
+	b.WriteString("\t\t\t\tif _, ok := wire[key]; !ok {\n")
+	b.WriteString("\t\t\t\t\tt.Errorf(\"key %q is missing from %s (encoding/json skips unexported fields unless the type has @derive json)\", key, data)\n")
+	b.WriteString("\t\t\t\t}\n\t\t\t}\n\t\t})\n\t}\n}\n")
+}
+
+// jsonFields returns the `field: value` pairs of a composite literal of
+// decl: every field jsonValue can fill when full, else only those the zero
+// value can't encode (enums, which reject "").
+func (g *Generator) jsonFields(decl *ast.TypeDecl, full bool, depth int) []string {
+	var fields []string
+	for i, field := range decl.Fields {
+		if value, ok := g.jsonValue(field.Type, field.Name.Value, i+1, full, depth); ok {
+			fields = append(fields, field.Name.Value+": "+value)
+		}
+	}
+	return fields
+}
+
+// jsonValue returns a Go expression for a value of t. When full it is a
+// non-zero sample, with n varying the numbers between fields; otherwise it
+// is the defaults value, reported only where the zero value won't do.
+// Interfaces, functions, channels and types from other packages report
+// false and stay at their zero value, as do nested structs past depth 2.
+func (g *Generator) jsonValue(t ast.TypeAnnotation, field string, n int, full bool, depth int) (string, bool) {
+	if _, named := t.(*ast.NamedType); !named && !full {
+		return "", false
+	}
+	switch t := t.(type) {
+	case *ast.PrimitiveType:
+		switch t.Name {
+		case "string":
+			return strconv.Quote(field), true
+		case "bool":
+			return "true", true
+		case "float32", "float64":
+			return fmt.Sprintf("%d.5", n), true
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "rune":
+			return strconv.Itoa(n), true
+		}
+	case *ast.ListType:
+		if elem, ok := g.jsonValue(t.ElementType, field, n, true, depth+1); ok {
+			return g.generateTypeAnnotation(t) + "{" + elem + "}", true
+		}
+	case *ast.MapType:
+		key, keyOK := g.jsonValue(t.KeyType, field, n, true, depth+1)
+		value, valueOK := g.jsonValue(t.ValueType, field, n, true, depth+1)
+		if keyOK && valueOK {
+			return g.generateTypeAnnotation(t) + "{" + key + ": " + value + "}", true
+		}
+	case *ast.ReferenceType:
+		if elem, ok := g.jsonValue(t.ElementType, field, n, true, depth+1); ok {
+			// new(1) would be a *int; convert constants to the element type
+			if _, primitive := t.ElementType.(*ast.PrimitiveType); primitive {
+				elem = g.generateTypeAnnotation(t.ElementType) + "(" + elem + ")"
+			}
+			return "new(" + elem + ")", true
+		}
+	case *ast.NamedType:
+		decl := g.localTypeDecl(t.Name)
+		switch {
+		case decl == nil:
+		case decl.AliasType == nil:
+			fields := g.jsonFields(decl, full && depth < 2, depth+1)
+			if full || len(fields) > 0 {
+				return t.Name + "{" + strings.Join(fields, ", ") + "}", true
+			}
+		case len(decl.Values) > 0:
+			return decl.Values[0].Name.Value, true
+		default:
+			if inner, ok := g.jsonValue(decl.AliasType, field, n, full, depth); ok {
+				return t.Name + "(" + inner + ")", true
+			}
+		}
+	}
+	return "", false
+}
+
+// localTypeDecl returns the type declared in this file under name, or nil.
+func (g *Generator) localTypeDecl(name string) *ast.TypeDecl {
+	for _, decl := range g.program.Declarations {
+		if typeDecl, ok := decl.(*ast.TypeDecl); ok && typeDecl.Name.Value == name {
+			return typeDecl
+		}
+	}
+	return nil
+}
+
+// jsonKey returns the key a field is expected under on the wire, or false
+// when its json tag leaves it out.
+func jsonKey(field *ast.FieldDecl) (string, bool) {
+	tag, ok := reflect.StructTag(field.Tag).Lookup("json")
+	if !ok {
+		return field.Name.Value, true
+	}
+	name, _, hasOptions := strings.Cut(tag, ",")
+	if name == "-" && !hasOptions {
+		return "", false
+	}
+	if name == "" {
+		return field.Name.Value, true
+	}
+	return name, true
+}
+
+// exportedTestName capitalizes name so the test function is TestX, which go
+// test requires even for an unexported type x.
+func exportedTestName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -28,6 +28,7 @@ func pipelineLambda(t *testing.T, source string) string {
 	gen.SetSourceFile("test.kuki")
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -182,3 +183,92 @@ func Foo()
 		t.Errorf("expected 'func(r string)' in output, got:\n%s", out)
 	}
 }
+
+func TestUntypedLambda_NamedFuncType(t *testing.T) {
+	src := `petiole main
+
+type User
+    Active bool
+
+type Pred func(User) bool
+
+func keep(users list of User, p Pred) list of User
+    return users
+
+func Foo()
+    _ = keep(list of User{}, u => u.Active)
+`
+	out := pipelineLambda(t, src)
+	if !strings.Contains(out, "func(u User) bool { return u.Active }") {
+		t.Errorf("expected 'func(u User) bool { return u.Active }' in output, got:\n%s", out)
+	}
+}
+
+func TestUntypedLambda_ResultsFromParameter(t *testing.T) {
+	src := `petiole main
+import "os"
+
+func parse(s string) (int, error)
+    return len(s), empty
+
+func load(f func(string) (int, error)) int
+    n, _ := f("ab")
+    return n
+
+func cleanup(f func())
+    f()
+
+func Foo()
+    _ = load(s => parse(s))
+    cleanup(() => os.RemoveAll("tmp"))
+`
+	out := pipelineLambda(t, src)
+	for _, want := range []string{
+		"func(s string) (int, error) { return parse(s) }",
+		`func() { os.RemoveAll("tmp") }`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestUntypedLambda_UserMethodBlock(t *testing.T) {
+	src := `petiole main
+
+type Router
+    paths list of string
+
+func Each on r Router(visit func(string) (bool, error))
+    for p in r.paths
+        visit(p)
+
+func Foo(r Router) (string, error)
+    r.Each(p =>
+        if p == ""
+            return false, error "empty path"
+        return true, empty
+    )
+    return "", empty
+`
+	out := pipelineLambda(t, src)
+	if !strings.Contains(out, "func(p string) (bool, error) {") {
+		t.Errorf("expected 'func(p string) (bool, error) {' in output, got:\n%s", out)
+	}
+}
+
+func TestUntypedLambda_StdlibFuncWithoutResults(t *testing.T) {
+	src := `petiole main
+import "os"
+import "stdlib/concurrent"
+
+func Foo()
+    concurrent.Parallel(() => os.Remove("a"), () => os.Remove("b"))
+`
+	out := pipelineLambda(t, src)
+	for _, want := range []string{`func() { os.Remove("a") }`, `func() { os.Remove("b") }`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
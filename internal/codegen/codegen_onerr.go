@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
 )
 
 // generateOnErrVarDecl handles variable declarations with onerr
@@ -13,6 +14,12 @@ import (
 // e.g., port := getPort() onerr "8080" → port, err := getPort(); if err != nil { port = "8080" }
 // e.g., val := foo() onerr explain "hint" → val, err := foo(); if err != nil { return ..., fmt.Errorf("hint: %w", err) }
 func (g *Generator) generateOnErrVarDecl(names []*ast.Identifier, values []ast.Expression, clause *ast.OnErrClause) {
+	if len(values) == 1 {
+		prev := g.onErrValueType
+		g.onErrValueType = g.exprTypes[values[0]]
+		defer func() { g.onErrValueType = prev }()
+	}
+
 	// Build the value expression string (typically a single call expression)
 	valueExpr := strings.Join(g.exprStrings(values), ", ")
 
@@ -63,6 +70,41 @@ func (g *Generator) generateOnErrVarDecl(names []*ast.Identifier, values []ast.E
 	g.emitIR(block)
 }
 
+// hoistOnErrExprs declares a temporary for each expression-level onerr of
+// stmt (see ast.OnErrExprs) ahead of the statement, which then refers to the
+// temporaries: Config{port: GetPort() onerr 8080} becomes
+//
+//	v_1, err_2 := GetPort()
+//	if err_2 != nil {
+//		v_1 = 8080
+//	}
+//	cfg := Config{port: v_1}
+//
+// The fallible calls thus run before the rest of the statement.
+func (g *Generator) hoistOnErrExprs(stmt ast.Statement) {
+	for _, e := range ast.OnErrExprs(stmt) {
+		tmp := g.uniqueId("v")
+		g.annotate("onerr in an expression: evaluated before the statement into %s", tmp)
+		g.generateOnErrVarDecl([]*ast.Identifier{{Token: e.Token, Value: tmp}}, []ast.Expression{e.Expression}, e.OnErr)
+		if g.onErrTemps == nil {
+			g.onErrTemps = make(map[*ast.OnErrExpr]string)
+		}
+		g.onErrTemps[e] = tmp
+	}
+}
+
+// logsOnErr reports whether onerr handlers log the errors they handle: the
+// file's `# onerr:` pragma when it has one, or else SetOnErrLog.
+func (g *Generator) logsOnErr() bool {
+	switch g.program.OnErrLog {
+	case "log":
+		return true
+	case "off":
+		return false
+	}
+	return g.onErrLog
+}
+
 // generateOnErrHandler generates code for the onerr handler expression
 func (g *Generator) generateOnErrHandler(names []*ast.Identifier, handler ast.Expression, errVar string) {
 	// If handler is nil, the explain wrapping already generated the return
@@ -133,12 +175,28 @@ func (g *Generator) generateOnErrHandler(names []*ast.Identifier, handler ast.Ex
 			// Default value expression — assign to the first variable.
 			// e.g., port := getPort() onerr "8080" → port = "8080"
 			if len(names) > 0 {
-				g.writeLine(fmt.Sprintf("%s = %s", names[0].Value, handlerStr))
+				g.writeLine(fmt.Sprintf("%s = %s", names[0].Value, g.convertFallback(handler, handlerStr)))
 			}
 		}
 	}
 }
 
+// convertFallback converts an onerr fallback value to the type of the value it
+// replaces where Go will not: an int variable or expression standing in for a
+// float (the analyzer rejects other mismatches). Literals are untyped
+// constants and need no conversion.
+func (g *Generator) convertFallback(handler ast.Expression, handlerStr string) string {
+	target := g.onErrValueType
+	value := g.exprTypes[handler]
+	if target == nil || value == nil || target.Kind != semantic.TypeKindFloat || value.Kind != semantic.TypeKindInt {
+		return handlerStr
+	}
+	if _, ok := handler.(*ast.IntegerLiteral); ok {
+		return handlerStr
+	}
+	return fmt.Sprintf("%s(%s)", g.typeInfoToGoString(target), handlerStr)
+}
+
 // emitOnErrDiscard handles the discard case for all three onerr forms.
 // lhsParts: pre-built target strings (nil for statement-level); op: ":=" or "=";
 // valueExpr: RHS string; isMultiReturn: whether len(targets) > 1 && len(values) == 1;
@@ -151,6 +209,7 @@ func (g *Generator) emitOnErrDiscard(clause *ast.OnErrClause, lhsParts []string,
 	if _, isDiscard := clause.Handler.(*ast.DiscardExpr); !isDiscard {
 		return false
 	}
+	g.annotate("onerr discard: the error result is assigned to _ and never checked")
 
 	// Statement-level (no named targets): use inferReturnCount to determine blank count
 	if lhsParts == nil {
@@ -286,7 +345,8 @@ func (g *Generator) generatePipedStepCall(right ast.Expression, leftExpr string)
 		return "", false
 	}
 
-	args := g.buildPipeArgs(leftExpr, arguments)
+	args := g.appendNamedArgs(right, g.buildPipeArgs(leftExpr, arguments))
+	g.fillStdlibDefaults(funcName, right, &args)
 
 	if isVariadic {
 		return fmt.Sprintf("%s(%s...)", funcName, strings.Join(args, ", ")), true
@@ -297,17 +357,7 @@ func (g *Generator) generatePipedStepCall(right ast.Expression, leftExpr string)
 // buildPipeArgs builds the argument list for a piped function call.
 // It handles placeholder substitution (_), context-first insertion, and default data-first insertion.
 func (g *Generator) buildPipeArgs(leftExpr string, arguments []ast.Expression) []string {
-	placeholderIndex := -1
-	for i, arg := range arguments {
-		if ident, isIdent := arg.(*ast.Identifier); isIdent && ident.Value == "_" {
-			placeholderIndex = i
-			break
-		}
-		if _, isDiscard := arg.(*ast.DiscardExpr); isDiscard {
-			placeholderIndex = i
-			break
-		}
-	}
+	placeholderIndex := pipePlaceholderIndex(arguments)
 
 	var args []string
 	if placeholderIndex != -1 {
@@ -327,6 +377,37 @@ func (g *Generator) buildPipeArgs(leftExpr string, arguments []ast.Expression) [
 	return args
 }
 
+// appendNamedArgs adds the values of a pipe step's named arguments after its
+// positional ones, in the order written, as generateMethodCallExpr does:
+// items |> pool.Map(fetch, concurrency: 4) → pool.Map(items, fetch, 4).
+func (g *Generator) appendNamedArgs(step ast.Expression, args []string) []string {
+	var named []*ast.NamedArgument
+	switch s := step.(type) {
+	case *ast.CallExpr:
+		named = s.NamedArguments
+	case *ast.MethodCallExpr:
+		named = s.NamedArguments
+	}
+	for _, namedArg := range named {
+		args = append(args, g.exprToString(namedArg.Value))
+	}
+	return args
+}
+
+// pipePlaceholderIndex returns the position of the "_" placeholder that marks
+// where the piped value goes, or -1 when the value is passed first.
+func pipePlaceholderIndex(arguments []ast.Expression) int {
+	for i, arg := range arguments {
+		if ident, isIdent := arg.(*ast.Identifier); isIdent && ident.Value == "_" {
+			return i
+		}
+		if _, isDiscard := arg.(*ast.DiscardExpr); isDiscard {
+			return i
+		}
+	}
+	return -1
+}
+
 func (g *Generator) generateOnErrPipeChain(pipe *ast.PipeExpr, clause *ast.OnErrClause, names []*ast.Identifier, targetName string) (string, bool) {
 	l := newLowerer(g)
 	nameStrs := identNames(names)
@@ -371,6 +452,11 @@ func (g *Generator) generateOnErrStmt(expr ast.Expression, clause *ast.OnErrClau
 // e.g., x = foo() onerr panic "error" → x, err = foo(); if err != nil { panic("error") }
 func (g *Generator) generateOnErrAssign(stmt *ast.AssignStmt) {
 	clause := stmt.OnErr
+	if len(stmt.Targets) > 0 {
+		prev := g.onErrValueType
+		g.onErrValueType = g.exprTypes[stmt.Targets[0]]
+		defer func() { g.onErrValueType = prev }()
+	}
 
 	// Build value expression
 	valueExpr := strings.Join(g.exprStrings(stmt.Values), ", ")
@@ -437,6 +523,9 @@ func (g *Generator) blockHasExplain(block *ast.BlockStmt) bool {
 }
 
 func (g *Generator) stmtHasExplain(stmt ast.Statement) bool {
+	if slices.ContainsFunc(ast.OnErrExprs(stmt), func(e *ast.OnErrExpr) bool { return e.OnErr.Explain != "" }) {
+		return true
+	}
 	switch s := stmt.(type) {
 	case *ast.VarDeclStmt:
 		if s.OnErr != nil && s.OnErr.Explain != "" {
@@ -502,6 +591,14 @@ func (g *Generator) stmtHasExplain(stmt ast.Statement) bool {
 		if s.Block != nil && g.blockHasExplain(s.Block) {
 			return true
 		}
+	case *ast.SafelyStmt:
+		if g.blockHasExplain(s.Body) || (s.OnErr != nil && s.OnErr.Explain != "") {
+			return true
+		}
+	case *ast.RequireStmt:
+		if s.Else != nil && g.blockHasExplain(s.Else) {
+			return true
+		}
 	}
 	return false
 }
@@ -206,6 +206,7 @@ func Write(data list of byte, path string) error
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -253,6 +254,7 @@ func Write(data any, path string) error
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -329,6 +331,7 @@ func TestTypedPipedSwitchCodegen(t *testing.T) {
 
 	gen := New(program)
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -373,6 +376,7 @@ func Risky(value string) (any, error)
 
 	gen := New(program)
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	output, err := gen.Generate()
 	if err != nil {
@@ -416,6 +420,7 @@ func ExitCodeOrOne(err error) int
 
 	gen := New(program)
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -491,6 +496,7 @@ func Process() error
 	gen := New(program)
 	gen.SetExprReturnCounts(analyzer.ReturnCounts())
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -603,6 +609,7 @@ func Run() int
 
 	gen := New(program)
 	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
 	output, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("codegen error: %v", err)
@@ -612,3 +619,163 @@ func Run() int
 		t.Fatalf("expected typed reducer lambda to emit an int return type, got: %s", output)
 	}
 }
+
+func TestPipeIntoMethodWithReceiver(t *testing.T) {
+	input := `type Counter
+    total int
+
+func Add on c reference Counter (n int)
+    c.total = c.total + n
+
+func Run(c reference Counter)
+    5 |> c.Add()
+`
+	output := fullPipeline(t, input, "test.kuki")
+	if !strings.Contains(output, "c.Add(5)") {
+		t.Errorf("expected piped value as first method argument, got:\n%s", output)
+	}
+}
+
+func TestReferenceMethodOnStructLiteral(t *testing.T) {
+	input := `type Counter
+    n int
+
+func Get on c reference Counter int
+    return c.n
+
+func Show on c Counter string
+    return "n={c.n}"
+
+func Run() (int, int, string)
+    a := Counter{n: 1}.Get()
+    b := Counter{n: 2} |> .Get()
+    return a, b, Counter{n: 3}.Show()
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{"a := (&Counter{n: 1}).Get()", "b := (&Counter{n: 2}).Get()", "Counter{n: 3}.Show()"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "(&Counter{n: 3})") {
+		t.Errorf("value-receiver call should not take the literal's address, got:\n%s", output)
+	}
+}
+
+func TestPipeSpreadsMultipleValues(t *testing.T) {
+	input := `func split(s string) (string, string)
+    return s, s
+
+func pair(a string, b string) string
+    return a + b
+
+func join(a string, b string, sep string) string
+    return a + sep + b
+
+func Run() (string, string)
+    p := split("x") |> pair()
+    j := split("y") |> join(",")
+    return p, j
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	if !strings.Contains(output, "p := pair(split(\"x\"))") {
+		t.Errorf("expected direct multi-value call, got:\n%s", output)
+	}
+	if !strings.Contains(output, `func() string { pipe_1, pipe_2 := split("y"); return join(pipe_1, pipe_2, ",") }()`) {
+		t.Errorf("expected spread IIFE with temps, got:\n%s", output)
+	}
+}
+
+func TestPipeSpreadInOnErrChain(t *testing.T) {
+	input := `func split(s string) (string, string)
+    return s, s
+
+func join(a string, b string, sep string) string
+    return a + sep + b
+
+func parse(s string) (int, error)
+    return len(s), empty
+
+func Run() (int, error)
+    n := split("4") |> join("") |> parse() onerr return
+    return n, empty
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	if !strings.Contains(output, `pipe_3, pipe_4 := split("4")`) || !strings.Contains(output, `parse(join(pipe_3, pipe_4, ""))`) {
+		t.Errorf("expected spread temps without an error check, got:\n%s", output)
+	}
+}
+
+func TestParallelPipe(t *testing.T) {
+	input := `func parse(s string) (int, error)
+    return len(s), empty
+
+func square(n int) int
+    return n * n
+
+func Run(nums list of int, words list of string) (list of int, error)
+    squares := nums |>> square()
+    lengths := words |>> parse() onerr return
+    return append(squares, many lengths), empty
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		`"runtime"`,
+		`"sync"`,
+		"squares := func() []int {",
+		"make(chan struct{}, runtime.GOMAXPROCS(0))",
+		"results_2[i_5] = square(item_6)",
+		"func() ([]int, error) {",
+		"], errs_",
+		"return nil, err",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestSlicePipeFusion(t *testing.T) {
+	input := `import "stdlib/slice"
+
+func Run(nums list of int) list of int
+    return nums |> slice.Filter(n => n % 2 == 0) |> slice.Map(n => n * 10) |> slice.First(2)
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	for _, want := range []string{
+		"func() []int {",
+		"keep_2 := func(n int) bool {",
+		"if !keep_2(v_7) {",
+		"v_8 := map_3(v_7)",
+		"if taken_5 >= n_4 {",
+		"out_6 = append(out_6, v_8)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in fused loop, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "slice.") || strings.Contains(output, "stdlib/slice") {
+		t.Errorf("expected fused pipe to drop the unused slice import, got:\n%s", output)
+	}
+}
+
+func TestSlicePipeFusionSkipsCallbacksWithCalls(t *testing.T) {
+	input := `import "stdlib/slice"
+
+func double(n int) int
+    return n * 2
+
+func Run(nums list of int) list of int
+    return nums |> slice.Filter(n => n > 0) |> slice.Map(n => double(n))
+`
+	output := fullPipeline(t, input, "test.kuki")
+	assertValidGo(t, output)
+	if !strings.Contains(output, "slice.Map(slice.Filter(nums,") {
+		t.Errorf("expected unfused slice calls when a callback calls a function, got:\n%s", output)
+	}
+}
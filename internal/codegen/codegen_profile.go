@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ProfileFunc is the generated helper that profiles main (see SetProfile).
+// Its own samples are not the program's: kukicha profile run ignores them.
+const ProfileFunc = "kukichaProfile"
+
+// ProfileCPUFile and ProfileAllocsFile are the profiles a program compiled
+// with SetProfile writes to its profile directory when main returns.
+const (
+	ProfileCPUFile    = "cpu.pprof"
+	ProfileAllocsFile = "allocs.pprof"
+)
+
+// profileMemRate samples one allocation per 4 KiB instead of Go's default
+// 512 KiB, so short scripts still show their allocation sites.
+const profileMemRate = 4096
+
+// needsProfile reports whether main gets the profiling prelude.
+func (g *Generator) needsProfile() bool {
+	return g.profileDir != "" && g.mainFunc() != nil
+}
+
+// scanProfileForAutoImports adds the imports the profiling helper uses.
+func (g *Generator) scanProfileForAutoImports() {
+	if !g.needsProfile() {
+		return
+	}
+	for _, path := range []string{"fmt", "os", "path/filepath", "runtime", "runtime/pprof"} {
+		g.addImport(path)
+	}
+}
+
+// generateProfilePrelude starts main with `defer kukichaProfile(dir)()`.
+// Profiles are only written when main returns: a program that ends with
+// os.Exit (fail, a second interrupt) leaves none.
+func (g *Generator) generateProfilePrelude() {
+	g.writeLine(fmt.Sprintf("defer %s(%s)()", ProfileFunc, strconv.Quote(g.profileDir)))
+}
+
+// generateProfileHelper declares kukichaProfile, which starts the CPU
+// profile and returns the func that stops it and writes the allocation
+// profile.
+func (g *Generator) generateProfileHelper() {
+	if !g.needsProfile() {
+		return
+	}
+	fmtPkg, osPkg, filepathPkg := g.importedName("fmt"), g.importedName("os"), g.importedName("path/filepath")
+	runtimePkg, pprofPkg := g.importedName("runtime"), g.importedName("runtime/pprof")
+	g.writeLine("")
+	g.writeLine("// " + ProfileFunc + " profiles main for `kukicha profile run`.")
+	g.writeLine(fmt.Sprintf("func %s(dir string) func() {", ProfileFunc))
+	g.writeLine(fmt.Sprintf("\t%s.MemProfileRate = %d", runtimePkg, profileMemRate))
+	g.writeLine(fmt.Sprintf("\tcpu, err := %s.Create(%s.Join(dir, %q))", osPkg, filepathPkg, ProfileCPUFile))
+	g.writeLine("\tif err == nil {")
+	g.writeLine(fmt.Sprintf("\t\terr = %s.StartCPUProfile(cpu)", pprofPkg))
+	g.writeLine("\t}")
+	g.writeLine("\tif err != nil {")
+	g.writeLine(fmt.Sprintf("\t\t%s.Fprintln(%s.Stderr, \"profile:\", err)", fmtPkg, osPkg))
+	g.writeLine("\t\treturn func() {}")
+	g.writeLine("\t}")
+	g.writeLine("\treturn func() {")
+	g.writeLine(fmt.Sprintf("\t\t%s.StopCPUProfile()", pprofPkg))
+	g.writeLine("\t\tcpu.Close()")
+	g.writeLine(fmt.Sprintf("\t\tallocs, err := %s.Create(%s.Join(dir, %q))", osPkg, filepathPkg, ProfileAllocsFile))
+	g.writeLine("\t\tif err != nil {")
+	g.writeLine(fmt.Sprintf("\t\t\t%s.Fprintln(%s.Stderr, \"profile:\", err)", fmtPkg, osPkg))
+	g.writeLine("\t\t\treturn")
+	g.writeLine("\t\t}")
+	g.writeLine(fmt.Sprintf("\t\t%s.Lookup(\"allocs\").WriteTo(allocs, 0)", pprofPkg))
+	g.writeLine("\t\tallocs.Close()")
+	g.writeLine("\t}")
+	g.writeLine("}")
+}
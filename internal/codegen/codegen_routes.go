@@ -0,0 +1,251 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// routeHandler is a function with a `# route:` directive.
+type routeHandler struct {
+	fn    *ast.FunctionDecl
+	route *ast.Route
+}
+
+// routeHandlers returns the route handlers to register. Only the http
+// target registers them; the analyzer has already validated the routes.
+func (g *Generator) routeHandlers() []routeHandler {
+	if g.program.Target != "http" {
+		return nil
+	}
+	var handlers []routeHandler
+	for _, decl := range g.program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Receiver != nil {
+			continue
+		}
+		if route, _, err := ast.FunctionRoute(fn); route != nil && err == nil {
+			handlers = append(handlers, routeHandler{fn: fn, route: route})
+		}
+	}
+	return handlers
+}
+
+// needsRouteMain reports whether the http target must supply main: the
+// program has routes but does not declare main itself.
+func (g *Generator) needsRouteMain(handlers []routeHandler) bool {
+	if len(handlers) == 0 {
+		return false
+	}
+	if g.program.PetioleDecl != nil && g.program.PetioleDecl.Name.Value != "main" {
+		return false
+	}
+	for _, decl := range g.program.Declarations {
+		if fn, ok := decl.(*ast.FunctionDecl); ok && fn.Receiver == nil && fn.Name.Value == "main" {
+			return false
+		}
+	}
+	return true
+}
+
+// scanRoutesForAutoImports adds the imports the generated route
+// registrations use.
+func (g *Generator) scanRoutesForAutoImports() {
+	handlers := g.routeHandlers()
+	if len(handlers) == 0 {
+		return
+	}
+	g.addImport("net/http")
+	for _, h := range handlers {
+		for _, param := range h.fn.Parameters {
+			if p, ok := param.Type.(*ast.PrimitiveType); ok && p.Name != "string" && routeParamIn(h.route, param) {
+				g.addImport("strconv")
+			}
+		}
+		if len(h.fn.Returns) > 0 && !isErrorType(h.fn.Returns[0]) {
+			if isStringType(h.fn.Returns[0]) {
+				g.addImport("io")
+			} else {
+				g.addImport("encoding/json")
+			}
+		}
+	}
+	if g.needsRouteMain(handlers) {
+		g.addImport("log")
+		g.addImport("os")
+	}
+}
+
+// generateRoutes registers every route handler on http.DefaultServeMux in an
+// init function, so main only has to start the server. Each registration
+// binds the path parameters to the handler's typed parameters (a bad value
+// is a 400), passes the writer and request through, and writes the result:
+// strings as text, other values as JSON, errors as a 500.
+func (g *Generator) generateRoutes() {
+	handlers := g.routeHandlers()
+	if len(handlers) == 0 {
+		return
+	}
+	httpPkg := g.importedName("net/http")
+
+	g.writeLine("")
+	g.writeLine("func init() {")
+	g.indent++
+	for _, h := range handlers {
+		g.emitLineDirective(ast.TokenPos(h.route.Directive.Token))
+		w := g.uniqueId("w")
+		r := g.uniqueId("r")
+		g.writeLine(fmt.Sprintf("%s.HandleFunc(%q, func(%s %s.ResponseWriter, %s *%s.Request) {", httpPkg, h.route.Pattern(), w, httpPkg, r, httpPkg))
+		g.indent++
+		g.generateRouteBody(h, httpPkg, w, r)
+		g.indent--
+		g.writeLine("})")
+	}
+	g.indent--
+	g.writeLine("}")
+
+	if !g.needsRouteMain(handlers) {
+		return
+	}
+	g.writeLine("")
+	g.writeLine("func main() {")
+	g.indent++
+	g.generateTelemetrySetup()
+	graceful := g.needsGracefulShutdown()
+	if graceful {
+		g.generateShutdownPrelude()
+	}
+	g.writeLine(`addr := ":8080"`)
+	g.writeLine(`if port := os.Getenv("PORT"); port != "" {`)
+	g.writeLine(`	addr = ":" + port`)
+	g.writeLine("}")
+	g.writeLine(`log.Printf("listening on %s", addr)`)
+	if !graceful {
+		g.writeLine(fmt.Sprintf("log.Fatal(%s.ListenAndServe(addr, nil))", httpPkg))
+		g.indent--
+		g.writeLine("}")
+		return
+	}
+	// Stop accepting connections on shutdown and let in-flight requests finish
+	server := g.uniqueId("server")
+	g.writeLine(fmt.Sprintf("%s := &%s.Server{Addr: addr}", server, httpPkg))
+	g.writeLine("go func() {")
+	g.writeLine(fmt.Sprintf("\t<-%s.Done()", g.shutdownCtx))
+	g.writeLine(fmt.Sprintf("\t%s.Shutdown(%s.Background())", server, g.importedName("context")))
+	g.writeLine("}()")
+	g.writeLine(fmt.Sprintf("if err := %s.ListenAndServe(); err != %s.ErrServerClosed {", server, httpPkg))
+	g.writeLine("\tlog.Fatal(err)")
+	g.writeLine("}")
+	g.shutdownCtx = ""
+	g.indent--
+	g.writeLine("}")
+}
+
+func (g *Generator) generateRouteBody(h routeHandler, httpPkg, w, r string) {
+	args := make([]string, len(h.fn.Parameters))
+	for i, param := range h.fn.Parameters {
+		switch {
+		case ast.IsHTTPWriterType(param.Type, httpPkg):
+			args[i] = w
+		case ast.IsHTTPRequestType(param.Type, httpPkg):
+			args[i] = r
+		default:
+			args[i] = g.generatePathParam(param, httpPkg, w, r)
+		}
+	}
+	call := fmt.Sprintf("%s(%s)", h.fn.Name.Value, strings.Join(args, ", "))
+
+	returns := h.fn.Returns
+	switch {
+	case len(returns) == 0:
+		g.writeLine(call)
+		return
+	case len(returns) == 1 && isErrorType(returns[0]):
+		errVar := g.uniqueId("err")
+		g.writeLine(fmt.Sprintf("if %s := %s; %s != nil {", errVar, call, errVar))
+		g.writeLine(fmt.Sprintf("\t%s.Error(%s, %s.Error(), %s.StatusInternalServerError)", httpPkg, w, errVar, httpPkg))
+		g.writeLine("}")
+		return
+	}
+
+	result := g.uniqueId("result")
+	if len(returns) == 2 {
+		errVar := g.uniqueId("err")
+		g.writeLine(fmt.Sprintf("%s, %s := %s", result, errVar, call))
+		g.writeLine(fmt.Sprintf("if %s != nil {", errVar))
+		g.writeLine(fmt.Sprintf("\t%s.Error(%s, %s.Error(), %s.StatusInternalServerError)", httpPkg, w, errVar, httpPkg))
+		g.writeLine("\treturn")
+		g.writeLine("}")
+	} else {
+		g.writeLine(fmt.Sprintf("%s := %s", result, call))
+	}
+	if isStringType(returns[0]) {
+		g.writeLine(fmt.Sprintf(`%s.Header().Set("Content-Type", "text/plain; charset=utf-8")`, w))
+		g.writeLine(fmt.Sprintf("%s.WriteString(%s, %s)", g.importedName("io"), w, result))
+		return
+	}
+	g.writeLine(fmt.Sprintf(`%s.Header().Set("Content-Type", "application/json")`, w))
+	g.writeLine(fmt.Sprintf("%s.NewEncoder(%s).Encode(%s)", g.importedName("encoding/json"), w, result))
+}
+
+// generatePathParam parses a path parameter into the handler parameter's
+// type and returns the variable holding it. A value that does not parse
+// ends the request with 400 Bad Request.
+func (g *Generator) generatePathParam(param *ast.Parameter, httpPkg, w, r string) string {
+	name := param.Name.Value
+	raw := fmt.Sprintf("%s.PathValue(%q)", r, name)
+	typeName := param.Type.(*ast.PrimitiveType).Name
+	if typeName == "string" {
+		return raw
+	}
+
+	parsed := g.uniqueId("path")
+	errVar := g.uniqueId("err")
+	strconvPkg := g.importedName("strconv")
+	var parse string
+	switch {
+	case typeName == "bool":
+		parse = fmt.Sprintf("%s.ParseBool(%s)", strconvPkg, raw)
+	case strings.HasPrefix(typeName, "float"):
+		parse = fmt.Sprintf("%s.ParseFloat(%s, %s)", strconvPkg, raw, strings.TrimPrefix(typeName, "float"))
+	case strings.HasPrefix(typeName, "uint"):
+		parse = fmt.Sprintf("%s.ParseUint(%s, 10, %s)", strconvPkg, raw, intBits(strings.TrimPrefix(typeName, "uint")))
+	default:
+		parse = fmt.Sprintf("%s.ParseInt(%s, 10, %s)", strconvPkg, raw, intBits(strings.TrimPrefix(typeName, "int")))
+	}
+	g.writeLine(fmt.Sprintf("%s, %s := %s", parsed, errVar, parse))
+	g.writeLine(fmt.Sprintf("if %s != nil {", errVar))
+	g.writeLine(fmt.Sprintf("\t%s.Error(%s, %q+%s.Error(), %s.StatusBadRequest)", httpPkg, w, "invalid path parameter "+name+": ", errVar, httpPkg))
+	g.writeLine("\treturn")
+	g.writeLine("}")
+	return fmt.Sprintf("%s(%s)", typeName, parsed)
+}
+
+func routeParamIn(route *ast.Route, param *ast.Parameter) bool {
+	for _, p := range route.Params {
+		if p == param.Name.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// intBits returns the bit size argument strconv takes for an integer type
+// with the given size suffix ("" for int and uint means the platform size).
+func intBits(suffix string) string {
+	if suffix == "" {
+		return "0"
+	}
+	return suffix
+}
+
+func isErrorType(t ast.TypeAnnotation) bool {
+	n, ok := t.(*ast.NamedType)
+	return ok && n.Name == "error"
+}
+
+func isStringType(t ast.TypeAnnotation) bool {
+	p, ok := t.(*ast.PrimitiveType)
+	return ok && p.Name == "string"
+}
@@ -0,0 +1,121 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// scheduledJob is a function with a `# schedule:` directive.
+type scheduledJob struct {
+	fn       *ast.FunctionDecl
+	schedule *ast.Schedule
+}
+
+// scheduledJobs returns the jobs to register. Only the worker target
+// registers them; the analyzer has already validated the schedules.
+func (g *Generator) scheduledJobs() []scheduledJob {
+	if g.program.Target != "worker" {
+		return nil
+	}
+	var jobs []scheduledJob
+	for _, decl := range g.program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Receiver != nil {
+			continue
+		}
+		if schedule, _, err := ast.FunctionSchedule(fn); schedule != nil && err == nil {
+			jobs = append(jobs, scheduledJob{fn: fn, schedule: schedule})
+		}
+	}
+	return jobs
+}
+
+// needsScheduleMain reports whether the worker target must supply main:
+// the program has scheduled jobs but does not declare main itself.
+func (g *Generator) needsScheduleMain(jobs []scheduledJob) bool {
+	if len(jobs) == 0 {
+		return false
+	}
+	if g.program.PetioleDecl != nil && g.program.PetioleDecl.Name.Value != "main" {
+		return false
+	}
+	return g.mainFunc() == nil
+}
+
+// scanSchedulesForAutoImports adds the imports the generated job
+// registrations use.
+func (g *Generator) scanSchedulesForAutoImports() {
+	jobs := g.scheduledJobs()
+	if len(jobs) == 0 {
+		return
+	}
+	g.addImport("context")
+	g.addImport(g.rewriteStdlibImport("stdlib/cron"))
+	if g.needsScheduleMain(jobs) {
+		g.addImport("log")
+	}
+}
+
+// generateSchedules registers every scheduled job with stdlib/cron in an
+// init function, so main only has to call cron.Run. Each registration
+// adapts the function to a cron job: the worker's context is passed when
+// the function takes one, and a function without an error result never
+// fails (a panic is still reported as a failed run).
+func (g *Generator) generateSchedules() {
+	jobs := g.scheduledJobs()
+	if len(jobs) == 0 {
+		return
+	}
+	cronPkg := g.importedName("stdlib/cron")
+	contextPkg := g.importedName("context")
+
+	g.writeLine("")
+	g.writeLine("func init() {")
+	g.indent++
+	for _, job := range jobs {
+		g.emitLineDirective(ast.TokenPos(job.schedule.Directive.Token))
+		ctx := g.uniqueId("ctx")
+		errVar := g.uniqueId("err")
+		g.writeLine(fmt.Sprintf("if %s := %s.Register(%q, %q, func(%s %s.Context) error {",
+			errVar, cronPkg, job.fn.Name.Value, job.schedule.Spec, ctx, contextPkg))
+		g.indent++
+		args := ""
+		if len(job.fn.Parameters) == 1 {
+			args = ctx
+		}
+		call := fmt.Sprintf("%s(%s)", job.fn.Name.Value, args)
+		if len(job.fn.Returns) == 1 {
+			g.writeLine("return " + call)
+		} else {
+			g.writeLine(call)
+			g.writeLine("return nil")
+		}
+		g.indent--
+		g.writeLine(fmt.Sprintf("}); %s != nil {", errVar))
+		g.writeLine(fmt.Sprintf("\tpanic(%s)", errVar))
+		g.writeLine("}")
+	}
+	g.indent--
+	g.writeLine("}")
+
+	if !g.needsScheduleMain(jobs) {
+		return
+	}
+	g.writeLine("")
+	g.writeLine("func main() {")
+	g.indent++
+	g.generateTelemetrySetup()
+	// Stop starting jobs on shutdown and let the runs in progress finish
+	ctx := contextPkg + ".Background()"
+	if g.needsGracefulShutdown() {
+		g.generateShutdownPrelude()
+		ctx = g.shutdownCtx
+	}
+	g.writeLine(fmt.Sprintf("if err := %s.Run(%s); err != nil {", cronPkg, ctx))
+	g.writeLine("\tlog.Fatal(err)")
+	g.writeLine("}")
+	g.shutdownCtx = ""
+	g.indent--
+	g.writeLine("}")
+}
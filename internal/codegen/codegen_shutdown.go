@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// defaultShutdownGrace is how long main gets to return after SIGINT/SIGTERM
+// before the program exits anyway.
+const defaultShutdownGrace = 5 * time.Second
+
+// needsGracefulShutdown reports whether main gets the shutdown scaffolding:
+// always with `# shutdown: on`, never with `# shutdown: off`, and otherwise
+// for long-running programs — the http, mcp and worker targets, and programs
+// whose main has an infinite `for true` loop.
+func (g *Generator) needsGracefulShutdown() bool {
+	if g.program.PetioleDecl != nil && g.program.PetioleDecl.Name.Value != "main" {
+		return false
+	}
+	main := g.mainFunc()
+	if main == nil && !g.needsRouteMain(g.routeHandlers()) && !g.needsScheduleMain(g.scheduledJobs()) {
+		return false
+	}
+	switch g.program.Shutdown {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	if g.program.Target == "http" || g.program.Target == "mcp" || g.program.Target == "worker" {
+		return true
+	}
+	return main != nil && ast.WalkStmts(main.Body, func(stmt ast.Statement) bool {
+		return isInfiniteLoop(stmt)
+	})
+}
+
+// mainFunc returns the program's main function, or nil.
+func (g *Generator) mainFunc() *ast.FunctionDecl {
+	for _, decl := range g.program.Declarations {
+		if fn, ok := decl.(*ast.FunctionDecl); ok && fn.Receiver == nil && fn.Name.Value == "main" {
+			return fn
+		}
+	}
+	return nil
+}
+
+// scanShutdownForAutoImports adds the imports the shutdown scaffolding uses.
+func (g *Generator) scanShutdownForAutoImports() {
+	if !g.needsGracefulShutdown() {
+		return
+	}
+	for _, path := range []string{"context", "os", "os/signal", "syscall", "time"} {
+		g.addImport(path)
+	}
+}
+
+// generateShutdownPrelude starts main with a context that SIGINT/SIGTERM
+// cancel, so deferred cleanup runs when main returns instead of the signal
+// killing the program outright. Infinite loops in main check the context
+// (see generateForConditionStmt). If main has not returned after the grace
+// period the program exits with status 1; a second signal ends it at once.
+// It records the context variable in g.shutdownCtx.
+func (g *Generator) generateShutdownPrelude() {
+	contextPkg := g.importedName("context")
+	osPkg := g.importedName("os")
+	timePkg := g.importedName("time")
+	ctx := g.uniqueId("shutdownCtx")
+	stop := g.uniqueId("stop")
+	g.writeLine(fmt.Sprintf("%s, %s := %s.NotifyContext(%s.Background(), %s.Interrupt, %s.SIGTERM)",
+		ctx, stop, g.importedName("os/signal"), contextPkg, osPkg, g.importedName("syscall")))
+	g.writeLine(fmt.Sprintf("defer %s()", stop))
+	g.writeLine("go func() {")
+	g.indent++
+	g.writeLine(fmt.Sprintf("<-%s.Done()", ctx))
+	g.writeLine(fmt.Sprintf("%s()", stop))
+	g.writeLine(fmt.Sprintf("%s.Sleep(%s)", timePkg, g.shutdownGraceExpr(timePkg)))
+	g.writeLine(fmt.Sprintf("%s.Exit(1)", osPkg))
+	g.indent--
+	g.writeLine("}()")
+	g.shutdownCtx = ctx
+}
+
+// shutdownGraceExpr returns the grace period as a Go expression.
+func (g *Generator) shutdownGraceExpr(timePkg string) string {
+	grace := g.program.ShutdownGrace
+	if grace == 0 {
+		grace = defaultShutdownGrace
+	}
+	if grace%time.Second == 0 {
+		return fmt.Sprintf("%d * %s.Second", grace/time.Second, timePkg)
+	}
+	if grace%time.Millisecond == 0 {
+		return fmt.Sprintf("%d * %s.Millisecond", grace/time.Millisecond, timePkg)
+	}
+	return fmt.Sprintf("%s.Duration(%d)", timePkg, grace)
+}
+
+// isInfiniteLoop reports whether stmt is a `for true` loop.
+func isInfiniteLoop(stmt ast.Statement) bool {
+	loop, ok := stmt.(*ast.ForConditionStmt)
+	if !ok {
+		return false
+	}
+	lit, ok := loop.Condition.(*ast.BooleanLiteral)
+	return ok && lit.Value
+}
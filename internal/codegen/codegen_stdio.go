@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// Helpers declared by generateStdinHelpers for `read line` and `read all`.
+const (
+	stdinReaderVar    = "kukichaStdin"
+	stdinReadLineFunc = "kukichaReadLine"
+	stdinReadAllFunc  = "kukichaReadAll"
+)
+
+// printBuiltinFunc returns the fmt function a print() or write() call
+// becomes: Println/Print, or Fprintln/Fprint (to os.Stderr) on the MCP
+// target, where stdout carries the protocol. A function or variable the
+// program declares named write is called as written.
+func (g *Generator) printBuiltinFunc(fn ast.Expression) (string, bool) {
+	id, ok := fn.(*ast.Identifier)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case id.Value == "print" && g.mcpTarget:
+		return "fmt.Fprintln", true
+	case id.Value == "print":
+		return "fmt.Println", true
+	case id.Value != "write" || g.declaresName("write"):
+		return "", false
+	case g.mcpTarget:
+		return "fmt.Fprint", true
+	}
+	return "fmt.Print", true
+}
+
+// declaresName reports whether the program declares name at package level
+// or as a local, parameter or receiver of one of its functions.
+func (g *Generator) declaresName(name string) bool {
+	if g.reservedNames[name] {
+		return true
+	}
+	for _, decl := range g.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.FunctionDecl:
+			if d.Receiver == nil && d.Name.Value == name {
+				return true
+			}
+		case *ast.VarDeclStmt:
+			for _, n := range d.Names {
+				if n.Value == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// needsStdinHelpers reports whether the program uses `read line` or `read all`.
+func (g *Generator) needsStdinHelpers() bool {
+	return g.walkProgram(func(e ast.Expression) bool {
+		_, ok := e.(*ast.ReadExpr)
+		return ok
+	})
+}
+
+// generateStdinHelpers declares one buffered reader over os.Stdin, shared by
+// every `read line` and `read all`, so input buffered by one read is not lost
+// to the next. The imports are added by scanExprForAutoImports.
+func (g *Generator) generateStdinHelpers() {
+	if !g.needsStdinHelpers() {
+		return
+	}
+	bufioPkg, ioPkg, osPkg, stringsPkg := g.importedName("bufio"), g.importedName("io"), g.importedName("os"), g.importedName("strings")
+	g.writeLine("")
+	g.writeLine(fmt.Sprintf("var %s = %s.NewReader(%s.Stdin)", stdinReaderVar, bufioPkg, osPkg))
+	g.writeLine("")
+	g.writeLine("// " + stdinReadLineFunc + " implements `read line`: the next line without its line ending.")
+	g.writeLine("// A last line without a newline is returned with a nil error; io.EOF means no more input.")
+	g.writeLine(fmt.Sprintf("func %s() (string, error) {", stdinReadLineFunc))
+	g.writeLine(fmt.Sprintf("\tline, err := %s.ReadString('\\n')", stdinReaderVar))
+	g.writeLine(fmt.Sprintf("\tif err == %s.EOF && line != \"\" {", ioPkg))
+	g.writeLine("\t\terr = nil")
+	g.writeLine("\t}")
+	g.writeLine(fmt.Sprintf("\treturn %s.TrimRight(line, \"\\r\\n\"), err", stringsPkg))
+	g.writeLine("}")
+	g.writeLine("")
+	g.writeLine("// " + stdinReadAllFunc + " implements `read all`: the rest of standard input.")
+	g.writeLine(fmt.Sprintf("func %s() (string, error) {", stdinReadAllFunc))
+	g.writeLine(fmt.Sprintf("\tdata, err := %s.ReadAll(%s)", ioPkg, stdinReaderVar))
+	g.writeLine("\treturn string(data), err")
+	g.writeLine("}")
+}
@@ -26,6 +26,8 @@ func (g *Generator) inferExprReturnType(expr ast.Expression) string {
 	}
 
 	switch e := expr.(type) {
+	case *ast.ComparisonChainExpr, *ast.RangeCheckExpr:
+		return "bool"
 	case *ast.BinaryExpr:
 		switch e.Operator {
 		case "==", "!=", "<", ">", "<=", ">=", "equals", "not equals",
@@ -158,6 +160,41 @@ func (g *Generator) isStdlibJSON() bool {
 	return strings.Contains(g.sourceFile, "stdlib/json/") || strings.Contains(g.sourceFile, "stdlib\\json\\")
 }
 
+// isStdlibMath checks if we're generating code in stdlib/math.
+func (g *Generator) isStdlibMath() bool {
+	return strings.Contains(g.sourceFile, "stdlib/math/") || strings.Contains(g.sourceFile, "stdlib\\math\\")
+}
+
+// isStdlibRandom checks if we're generating code in stdlib/random.
+func (g *Generator) isStdlibRandom() bool {
+	return strings.Contains(g.sourceFile, "stdlib/random/") || strings.Contains(g.sourceFile, "stdlib\\random\\")
+}
+
+// isStdlibLimit checks if we're generating code in stdlib/limit.
+func (g *Generator) isStdlibLimit() bool {
+	return strings.Contains(g.sourceFile, "stdlib/limit/") || strings.Contains(g.sourceFile, "stdlib\\limit\\")
+}
+
+// isStdlibCache checks if we're generating code in stdlib/cache.
+func (g *Generator) isStdlibCache() bool {
+	return strings.Contains(g.sourceFile, "stdlib/cache/") || strings.Contains(g.sourceFile, "stdlib\\cache\\")
+}
+
+// isStdlibPool checks if we're generating code in stdlib/pool.
+func (g *Generator) isStdlibPool() bool {
+	return strings.Contains(g.sourceFile, "stdlib/pool/") || strings.Contains(g.sourceFile, "stdlib\\pool\\")
+}
+
+// isStdlibEnv checks if we're generating code in stdlib/env.
+func (g *Generator) isStdlibEnv() bool {
+	return strings.Contains(g.sourceFile, "stdlib/env/") || strings.Contains(g.sourceFile, "stdlib\\env\\")
+}
+
+// isStdlibExpect checks if we're generating code in stdlib/expect.
+func (g *Generator) isStdlibExpect() bool {
+	return strings.Contains(g.sourceFile, "stdlib/expect/") || strings.Contains(g.sourceFile, "stdlib\\expect\\")
+}
+
 // inferSliceTypeParameters infers type parameters for stdlib/slice functions
 // using the generated registry (generatedSliceGenericClass) which classifies
 // each function by its placeholder usage:
@@ -169,13 +206,45 @@ func (g *Generator) inferSliceTypeParameters(decl *ast.FunctionDecl) []*TypePara
 	return g.typeParamsFromClass(class)
 }
 
+// inferMathTypeParameters infers type parameters for stdlib/math functions:
+// "number" becomes [N numberConstraint] and "ordered" becomes [K cmp.Ordered].
+func (g *Generator) inferMathTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	class := semantic.GetSliceGenericClass("math." + decl.Name.Value)
+	return g.typeParamsFromClass(class)
+}
+
+// inferRandomTypeParameters infers type parameters for stdlib/random
+// functions over lists (Choice, Shuffle).
+func (g *Generator) inferRandomTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	class := semantic.GetSliceGenericClass("random." + decl.Name.Value)
+	return g.typeParamsFromClass(class)
+}
+
+// inferLimitTypeParameters infers type parameters for the stdlib/limit
+// pipe stages (Limited, Run, Guard).
+func (g *Generator) inferLimitTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	class := semantic.GetSliceGenericClass("limit." + decl.Name.Value)
+	return g.typeParamsFromClass(class)
+}
+
+// inferPoolTypeParameters infers type parameters for the stdlib/pool
+// maps and loops (Map, MapContext, ForEach, ForEachContext).
+func (g *Generator) inferPoolTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	class := semantic.GetSliceGenericClass("pool." + decl.Name.Value)
+	return g.typeParamsFromClass(class)
+}
+
 // inferSortTypeParameters infers type parameters for stdlib/sort functions.
 func (g *Generator) inferSortTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
 	class := semantic.GetSliceGenericClass("sort." + decl.Name.Value)
 	return g.typeParamsFromClass(class)
 }
 
-// typeParamsFromClass converts a generic class string ("T", "K", "TK", "O", "TO", "TR")
+// numberConstraint is the Go constraint for the "number" placeholder: every
+// integer and float type, including named types built on them.
+const numberConstraint = "~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64"
+
+// typeParamsFromClass converts a generic class string ("T", "K", "TK", "O", "TO", "TR", "N")
 // into TypeParameter slices.
 func (g *Generator) typeParamsFromClass(class string) []*TypeParameter {
 	if class == "" {
@@ -205,6 +274,13 @@ func (g *Generator) typeParamsFromClass(class string) []*TypeParameter {
 			Constraint:  "cmp.Ordered",
 		})
 	}
+	if strings.Contains(class, "N") {
+		typeParams = append(typeParams, &TypeParameter{
+			Name:        "N",
+			Placeholder: "number",
+			Constraint:  numberConstraint,
+		})
+	}
 	if strings.Contains(class, "R") {
 		typeParams = append(typeParams, &TypeParameter{
 			Name:        "R",
@@ -222,42 +298,41 @@ func (g *Generator) inferFetchTypeParameters(decl *ast.FunctionDecl) []*TypePara
 	if decl.Name == nil || decl.Name.Value != "Json" {
 		return nil
 	}
+	return g.sampleTypeParameters(decl)
+}
 
-	usesAny := false
-	for _, param := range decl.Parameters {
-		if g.typeContainsPlaceholder(param.Type, "any") {
-			usesAny = true
-			break
-		}
-	}
-	if !usesAny {
-		for _, ret := range decl.Returns {
-			if g.typeContainsPlaceholder(ret, "any") {
-				usesAny = true
-				break
-			}
-		}
-	}
-	if !usesAny {
+// inferJSONTypeParameters infers type parameters for selected stdlib/json helpers.
+// DecodeRead uses placeholders to produce: func DecodeRead[T any](reader io.Reader, sample T) (T, error)
+func (g *Generator) inferJSONTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	if decl.Name == nil || decl.Name.Value != "DecodeRead" {
 		return nil
 	}
+	return g.sampleTypeParameters(decl)
+}
 
-	return []*TypeParameter{
-		{
-			Name:        "T",
-			Placeholder: "any",
-			Constraint:  "any",
-		},
+// inferExpectTypeParameters infers type parameters for the property checks
+// in stdlib/expect, so the property takes the sample's type:
+// func ForAll[T any](t *testing.T, sample T, property func(T) bool)
+func (g *Generator) inferExpectTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	if decl.Name == nil || (decl.Name.Value != "ForAll" && decl.Name.Value != "Check") {
+		return nil
 	}
+	return g.sampleTypeParameters(decl)
 }
 
-// inferJSONTypeParameters infers type parameters for selected stdlib/json helpers.
-// DecodeRead uses placeholders to produce: func DecodeRead[T any](reader io.Reader, sample T) (T, error)
-func (g *Generator) inferJSONTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
-	if decl.Name == nil || decl.Name.Value != "DecodeRead" {
+// inferEnvTypeParameters infers type parameters for selected stdlib/env helpers.
+// Load uses placeholders to produce: func Load[T any](sample T) (T, error)
+func (g *Generator) inferEnvTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
+	if decl.Name == nil || decl.Name.Value != "Load" {
 		return nil
 	}
+	return g.sampleTypeParameters(decl)
+}
 
+// sampleTypeParameters makes a "sample pattern" helper generic: every `any`
+// in its signature becomes T, so the result has the type of the sample the
+// caller passed.
+func (g *Generator) sampleTypeParameters(decl *ast.FunctionDecl) []*TypeParameter {
 	usesAny := false
 	for _, param := range decl.Parameters {
 		if g.typeContainsPlaceholder(param.Type, "any") {
@@ -313,6 +388,8 @@ func (g *Generator) typeContainsPlaceholder(typeAnn ast.TypeAnnotation, placehol
 		return g.typeContainsPlaceholder(t.KeyType, placeholder) || g.typeContainsPlaceholder(t.ValueType, placeholder)
 	case *ast.ChannelType:
 		return g.typeContainsPlaceholder(t.ElementType, placeholder)
+	case *ast.SequenceType:
+		return g.typeContainsPlaceholder(t.ElementType, placeholder)
 	case *ast.ReferenceType:
 		return g.typeContainsPlaceholder(t.ElementType, placeholder)
 	case *ast.FunctionType:
@@ -330,28 +407,16 @@ func (g *Generator) typeContainsPlaceholder(typeAnn ast.TypeAnnotation, placehol
 	return false
 }
 
-// isLikelyInterfaceType checks if a Go type name is an interface type.
-// Used to determine whether empty Type should generate nil (interface) vs Type{} (struct).
-func (g *Generator) isLikelyInterfaceType(typeName string) bool {
-	// "error" is always an interface
-	if typeName == "error" {
-		return true
-	}
-
-	// Check current program's declarations for local interface types
-	for _, decl := range g.program.Declarations {
-		if iface, ok := decl.(*ast.InterfaceDecl); ok {
-			if iface.Name.Value == typeName {
-				return true
-			}
-		}
-	}
-
-	// Check auto-generated Go stdlib and Kukicha stdlib interface registries
-	return semantic.IsKnownInterface(typeName)
+// isInterfaceTypedExpr reports whether expr (a cast or typed empty) targets an
+// interface type, as resolved by the semantic analyzer.
+func (g *Generator) isInterfaceTypedExpr(expr ast.Expression) bool {
+	ti := g.exprTypes[expr]
+	return ti != nil && ti.Kind == semantic.TypeKindInterface
 }
 
-// zeroValueForType returns a Go expression for the zero value of a type annotation.
+// zeroValueForType returns a Go expression for the zero value of a type
+// annotation. A named type is nil when the analyzer resolved it to an
+// interface, and *new(T) otherwise.
 func (g *Generator) zeroValueForType(typeAnn ast.TypeAnnotation) string {
 	switch t := typeAnn.(type) {
 	case *ast.PrimitiveType:
@@ -367,14 +432,13 @@ func (g *Generator) zeroValueForType(typeAnn ast.TypeAnnotation) string {
 		return g.generateTypeAnnotation(t) + "{}"
 	case *ast.MapType:
 		return g.generateTypeAnnotation(typeAnn) + "{}"
-	case *ast.ReferenceType, *ast.ChannelType, *ast.FunctionType:
+	case *ast.ReferenceType, *ast.ChannelType, *ast.SequenceType, *ast.FunctionType:
 		return "nil"
 	case *ast.NamedType:
-		typeName := g.generateTypeAnnotation(t)
-		if g.isLikelyInterfaceType(typeName) {
+		if ti := g.annTypes[t]; ti != nil && ti.Kind == semantic.TypeKindInterface {
 			return "nil"
 		}
-		return fmt.Sprintf("*new(%s)", typeName)
+		return fmt.Sprintf("*new(%s)", g.generateTypeAnnotation(t))
 	default:
 		return "nil"
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/duber000/kukicha/internal/ast"
 	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
 )
 
 // TestConcurrentCodeGeneration tests that multiple code generators can run
@@ -211,6 +212,116 @@ func DecodeRead(reader io.Reader, sample any) (any, error)
 	}
 }
 
+func TestEnvLoadGenerics(t *testing.T) {
+	input := `petiole env
+
+func Load(sample any) (any, error)
+    config := sample
+    return config, empty
+`
+
+	p, err := parser.New(input, "stdlib/env/env.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+
+	gen := New(program)
+	gen.SetSourceFile("stdlib/env/env.kuki")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	if !strings.Contains(output, "func Load[T any](sample T) (T, error)") {
+		t.Errorf("expected env.Load generic signature, got: %s", output)
+	}
+}
+
+func TestExpectForAllGenerics(t *testing.T) {
+	input := `petiole expect
+
+func ForAll(t reference testing.T, sample any, property func(any) bool)
+    t.Helper()
+
+func Show(value any) string
+    return ""
+`
+
+	p, err := parser.New(input, "stdlib/expect/expect.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+
+	gen := New(program)
+	gen.SetSourceFile("stdlib/expect/expect.kuki")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	if !strings.Contains(output, "func ForAll[T any](t *testing.T, sample T, property func(T) bool)") {
+		t.Errorf("expected expect.ForAll generic signature, got: %s", output)
+	}
+	if !strings.Contains(output, "func Show(value any) string") {
+		t.Errorf("expected expect.Show to stay non-generic, got: %s", output)
+	}
+}
+
+func TestMathGenerics(t *testing.T) {
+	input := `petiole math
+
+func Sum(items list of number) number
+    total := empty number
+    for item in items
+        total = total + item
+    return total
+
+func Min(items list of ordered) ordered
+    if len(items) == 0
+        return empty
+    return items[0]
+`
+
+	p, err := parser.New(input, "stdlib/math/math.kuki")
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("parse errors: %v", parseErrors)
+	}
+
+	gen := New(program)
+	gen.SetSourceFile("stdlib/math/math.kuki")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	for _, want := range []string{
+		"func Sum[N " + numberConstraint + "](items []N) N",
+		"total := *new(N)",
+		"func Min[K cmp.Ordered](items []K) K",
+		"var _zero0 K",
+		`"cmp"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in math output, got: %s", want, output)
+		}
+	}
+}
+
 func TestStdlibImportRewriting(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -273,6 +384,17 @@ func main()
 			expectedImport: `"github.com/duber000/kukicha/stdlib/json"`,
 			shouldContain:  "json.Marshal",
 		},
+		{
+			name: "stdlib/hash pipe",
+			source: `import "stdlib/hash"
+
+func main()
+    digest := "hello" |> hash.SHA256() |> hash.Hex()
+    print(digest)
+`,
+			expectedImport: `"github.com/duber000/kukicha/stdlib/hash"`,
+			shouldContain:  `hash.Hex(hash.SHA256("hello"))`,
+		},
 		{
 			name: "non-stdlib import unchanged",
 			source: `import "encoding/json"
@@ -385,37 +507,49 @@ func TestZeroValueForType(t *testing.T) {
 	}
 }
 
-func TestIsLikelyInterfaceType(t *testing.T) {
-	// Test with a program that declares a local interface
-	input := `interface Storer
+func TestZeroValueForResolvedType(t *testing.T) {
+	input := `import "io"
+
+interface Storer
     Store(data string) error
 
-func main()
-    x := 1
-`
+type User
+    Name string
 
-	prog := mustParseProgram(t, input)
-	gen := New(prog)
+func LoadError() error
+    return empty
 
-	tests := []struct {
-		typeName string
-		expected bool
-	}{
-		{"error", true},
-		{"Storer", true},    // local interface
-		{"io.Reader", true}, // Go stdlib interface
-		{"http.Handler", true},
-		{"User", false}, // unknown type, not interface
-		{"int", false},
-	}
+func LoadStorer() Storer
+    return empty
 
-	for _, tt := range tests {
-		t.Run(tt.typeName, func(t *testing.T) {
-			got := gen.isLikelyInterfaceType(tt.typeName)
-			if got != tt.expected {
-				t.Errorf("isLikelyInterfaceType(%q) = %v, want %v", tt.typeName, got, tt.expected)
-			}
-		})
+func LoadReader() io.Reader
+    return empty
+
+func LoadUser() User
+    return User{}
+`
+	program := mustParseProgram(t, input)
+	analyzer := semantic.New(program)
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v", errs)
+	}
+	gen := New(program)
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+
+	want := map[string]string{
+		"LoadError":  "nil",
+		"LoadStorer": "nil", // local interface
+		"LoadReader": "nil", // Go stdlib interface
+		"LoadUser":   "*new(User)",
+	}
+	for _, decl := range program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok {
+			continue
+		}
+		if got := gen.zeroValueForType(fn.Returns[0]); got != want[fn.Name.Value] {
+			t.Errorf("%s: zeroValueForType = %q, want %q", fn.Name.Value, got, want[fn.Name.Value])
+		}
 	}
 }
 
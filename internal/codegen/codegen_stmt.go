@@ -2,9 +2,11 @@ package codegen
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
 )
 
 // replaceGenericZeroExprs post-processes a slice of return-value expression strings.
@@ -34,6 +36,7 @@ func (g *Generator) generateBlock(block *ast.BlockStmt) {
 
 func (g *Generator) generateStatement(stmt ast.Statement) {
 	g.emitLineDirective(stmt.Pos())
+	g.hoistOnErrExprs(stmt)
 	switch s := stmt.(type) {
 	case *ast.VarDeclStmt:
 		g.generateVarDeclStmt(s)
@@ -61,24 +64,35 @@ func (g *Generator) generateStatement(stmt ast.Statement) {
 		g.writeLine("defer " + g.exprToString(s.Call))
 	case *ast.GoStmt:
 		if s.Block != nil {
-			// Block form: go NEWLINE INDENT ... DEDENT
-			// Generates: go func() { ... }()
-			g.write(g.indentStr() + "go func() {\n")
-			g.indent++
-			for _, stmt := range s.Block.Statements {
-				g.generateStatement(stmt)
-			}
-			g.indent--
-			g.write(g.indentStr() + "}()\n")
+			g.generateGoBlock(s)
 		} else {
 			g.writeLine("go " + g.exprToString(s.Call))
 		}
+	case *ast.SafelyStmt:
+		g.generateSafelyStmt(s)
+	case *ast.FailStmt:
+		g.generateFailStmt(s)
+	case *ast.RequireStmt:
+		g.generateRequireStmt(s)
 	case *ast.SendStmt:
 		channel := g.exprToString(s.Channel)
 		value := g.exprToString(s.Value)
 		g.writeLine(fmt.Sprintf("%s <- %s", channel, value))
+	case *ast.YieldStmt:
+		values := make([]string, len(s.Values))
+		for i, v := range s.Values {
+			values[i] = g.exprToString(v)
+		}
+		// The loop broke: stop producing values
+		g.writeLine(fmt.Sprintf("if !yield(%s) {", strings.Join(values, ", ")))
+		g.indent++
+		g.writeLine("return")
+		g.indent--
+		g.writeLine("}")
 	case *ast.ContinueStmt:
 		g.writeLine("continue")
+	case *ast.FallthroughStmt:
+		g.writeLine("fallthrough")
 	case *ast.BreakStmt:
 		g.writeLine("break")
 	case *ast.ExpressionStmt:
@@ -86,12 +100,119 @@ func (g *Generator) generateStatement(stmt ast.Statement) {
 			g.generateOnErrStmt(s.Expression, s.OnErr)
 		} else if pipedSwitch, ok := s.Expression.(*ast.PipedSwitchExpr); ok {
 			g.generatePipedSwitchStmt(pipedSwitch)
+		} else if g.release && g.isAssertion(s.Expression) {
+			// Release builds drop assertions; the import goes if nothing else uses it
+			g.fusedImports[g.rewriteStdlibImport("stdlib/must")] = true
 		} else {
 			g.writeLine(g.exprToString(s.Expression))
 		}
 	}
 }
 
+// generateGoBlock generates the block form (go NEWLINE INDENT ... DEDENT)
+// as go func() { ... }(). Captures the analyzer marked ByValue (variables a
+// surrounding loop reassigns) become parameters, so each goroutine keeps
+// the value from when it was started: go func(x T) { ... }(x).
+func (g *Generator) generateGoBlock(s *ast.GoStmt) {
+	var params, args []string
+	for _, c := range g.captures[s] {
+		if c.ByValue {
+			params = append(params, c.Name+" "+g.typeInfoToGoString(c.Type))
+			args = append(args, c.Name)
+		}
+	}
+	g.write(g.indentStr() + "go func(" + strings.Join(params, ", ") + ") {\n")
+	g.indent++
+	for _, stmt := range s.Block.Statements {
+		g.generateStatement(stmt)
+	}
+	g.indent--
+	g.write(g.indentStr() + "}(" + strings.Join(args, ", ") + ")\n")
+}
+
+// generateSafelyStmt runs the body of a safely block in a func literal whose
+// deferred recover turns a panic into its error result, then handles that
+// error like a statement-level onerr:
+//
+//	if err_1 := func() (err_2 error) {
+//		defer func() {
+//			if r := recover(); r != nil {
+//				err_2 = fmt.Errorf("panic: %v", r)
+//			}
+//		}()
+//		...
+//		return nil
+//	}(); err_1 != nil { ... }
+func (g *Generator) generateSafelyStmt(s *ast.SafelyStmt) {
+	g.addImport("fmt")
+	recovered := g.uniqueId("err")
+	child := g.childGenerator(1)
+	child.tempCounter = g.tempCounter
+	child.writeLine("defer func() {")
+	child.indent++
+	child.writeLine("if r := recover(); r != nil {")
+	child.indent++
+	child.writeLine(fmt.Sprintf("%s = %s.Errorf(\"panic: %%v\", r)", recovered, g.importedName("fmt")))
+	child.indent--
+	child.writeLine("}")
+	child.indent--
+	child.writeLine("}()")
+	for _, stmt := range s.Body.Statements {
+		child.generateStatement(stmt)
+	}
+	child.writeLine("return nil")
+	g.tempCounter = child.tempCounter
+	fn := fmt.Sprintf("func() (%s error) {\n%s%s}()", recovered, child.output.String(), g.indentStr())
+
+	if _, ok := s.OnErr.Handler.(*ast.DiscardExpr); ok {
+		g.writeLine("_ = " + fn)
+		return
+	}
+	l := newLowerer(g)
+	g.emitIR(l.lowerOnErrWithExplicitErr([]string{"_"}, fn, s.OnErr, true))
+}
+
+// generateFailStmt prints the message to stderr and exits (the fmt and os
+// imports are added by scanStmtForAutoImports):
+//
+//	fmt.Fprintln(os.Stderr, msg)
+//	os.Exit(code)
+func (g *Generator) generateFailStmt(s *ast.FailStmt) {
+	code := "1"
+	if s.Code != nil {
+		code = g.exprToString(s.Code)
+	}
+	g.writeLine(fmt.Sprintf("%s.Fprintln(%s.Stderr, %s)", g.importedName("fmt"), g.importedName("os"), g.exprToString(s.Message)))
+	g.writeLine(fmt.Sprintf("%s.Exit(%s)", g.importedName("os"), code))
+}
+
+// generateRequireStmt emits a guard clause as an inverted if: require x > 0
+// else return becomes `if !(x > 0) { return }`, and require not done else
+// break becomes `if done { break }`.
+func (g *Generator) generateRequireStmt(s *ast.RequireStmt) {
+	var condition string
+	if not, ok := s.Condition.(*ast.UnaryExpr); ok && (not.Operator == "not" || not.Operator == "!") {
+		condition = g.exprToString(not.Right)
+	} else {
+		condition = "!" + g.exprToString(s.Condition)
+	}
+	g.writeLine(fmt.Sprintf("if %s {", condition))
+	g.indent++
+	g.generateBlock(s.Else)
+	g.indent--
+	g.writeLine("}")
+}
+
+// isAssertion reports whether expr is a must.True or must.False call.
+func (g *Generator) isAssertion(expr ast.Expression) bool {
+	call, ok := expr.(*ast.MethodCallExpr)
+	if !ok || (call.Method.Value != "True" && call.Method.Value != "False") {
+		return false
+	}
+	pkg, ok := call.Object.(*ast.Identifier)
+	return ok && pkg.Value != "" && pkg.Value == ast.ImportName(g.program, "stdlib/must")
+}
+
 func (g *Generator) generatePipedSwitchStmt(expr *ast.PipedSwitchExpr) {
 	switch stmt := expr.Switch.(type) {
 	case *ast.SwitchStmt:
@@ -120,7 +241,7 @@ func (g *Generator) generateVarDeclStmt(stmt *ast.VarDeclStmt) {
 		if emptyExpr, ok := stmt.Values[0].(*ast.EmptyExpr); ok {
 			if emptyExpr.Type != nil {
 				targetType := g.generateTypeAnnotation(emptyExpr.Type)
-				if g.isLikelyInterfaceType(targetType) {
+				if g.isInterfaceTypedExpr(emptyExpr) {
 					g.writeLine(fmt.Sprintf("var %s %s", stmt.Names[0].Value, targetType))
 					return
 				}
@@ -356,7 +477,14 @@ func (g *Generator) generateIfStmtContinued(stmt *ast.IfStmt) {
 }
 
 func (g *Generator) generateSwitchStmt(stmt *ast.SwitchStmt) {
-	if stmt.Expression != nil {
+	// A guard can't follow Go case values, so a value switch with a guarded
+	// branch or a `when` pattern becomes a condition switch over a copy of
+	// the value: `switch sw := x; { case (sw == "a") && guard: ...`
+	tag := ""
+	if stmt.Expression != nil && (hasGuardedCase(stmt) || hasPatternCase(stmt)) {
+		tag = g.uniqueId("sw")
+		g.writeLine(fmt.Sprintf("switch %s := %s; {", tag, g.exprToString(stmt.Expression)))
+	} else if stmt.Expression != nil {
 		g.writeLine(fmt.Sprintf("switch %s {", g.exprToString(stmt.Expression)))
 	} else {
 		g.writeLine("switch {")
@@ -366,7 +494,21 @@ func (g *Generator) generateSwitchStmt(stmt *ast.SwitchStmt) {
 	for _, c := range stmt.Cases {
 		caseValues := make([]string, len(c.Values))
 		for i, value := range c.Values {
+			if pattern, ok := value.(*ast.WhenPattern); ok && tag != "" {
+				caseValues[i] = g.generateWhenPattern(pattern, tag)
+				continue
+			}
 			caseValues[i] = g.exprToString(value)
+			if tag != "" {
+				caseValues[i] = fmt.Sprintf("(%s == %s)", tag, caseValues[i])
+			}
+		}
+		if c.Guard != nil {
+			cond := caseValues[0]
+			if len(caseValues) > 1 {
+				cond = "(" + strings.Join(caseValues, " || ") + ")"
+			}
+			caseValues = []string{fmt.Sprintf("%s && %s", cond, g.exprToString(c.Guard))}
 		}
 		g.writeLine(fmt.Sprintf("case %s:", strings.Join(caseValues, ", ")))
 
@@ -385,6 +527,86 @@ func (g *Generator) generateSwitchStmt(stmt *ast.SwitchStmt) {
 	g.writeLine("}")
 }
 
+// hasGuardedCase reports whether a branch of stmt has an `if` guard.
+func hasGuardedCase(stmt *ast.SwitchStmt) bool {
+	for _, c := range stmt.Cases {
+		if c.Guard != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPatternCase reports whether a branch of stmt has a `when` pattern.
+func hasPatternCase(stmt *ast.SwitchStmt) bool {
+	for _, c := range stmt.Cases {
+		for _, value := range c.Values {
+			if _, ok := value.(*ast.WhenPattern); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateWhenPattern renders a `when` pattern as a condition on tag, the
+// copy of the switch value: `when 1 to 9` is (1 <= sw && sw < 9).
+func (g *Generator) generateWhenPattern(pattern *ast.WhenPattern, tag string) string {
+	value := g.exprToString(pattern.Value)
+	switch pattern.Operator {
+	case "to":
+		return fmt.Sprintf("(%s <= %s && %s < %s)", g.exprToString(pattern.Low), tag, tag, value)
+	case "through":
+		return fmt.Sprintf("(%s <= %s && %s <= %s)", g.exprToString(pattern.Low), tag, tag, value)
+	case "matches":
+		if lit, ok := pattern.Value.(*ast.RegexLiteral); ok {
+			return fmt.Sprintf("%s.MatchString(%s)", g.regexVar(lit.Pattern), tag)
+		}
+		return fmt.Sprintf("%s.MustCompile(%s).MatchString(%s)", g.importedName("regexp"), value, tag)
+	default:
+		return fmt.Sprintf("(%s %s %s)", tag, pattern.Operator, value)
+	}
+}
+
+// regexVar returns the package-level variable holding pattern compiled, so a
+// `when matches` in a loop compiles its regex once. Identical patterns share
+// a variable; generateRegexVars declares them at the end of the file.
+func (g *Generator) regexVar(pattern string) string {
+	if name, ok := g.regexVars[pattern]; ok {
+		return name
+	}
+	name := fmt.Sprintf("kukichaRegex%d", len(g.regexVars)+1)
+	g.regexVars[pattern] = name
+	return name
+}
+
+// generateRegexVars declares the regexes collected by regexVar, in order of
+// first use. The regexp import is added by scanExprForAutoImports.
+func (g *Generator) generateRegexVars() {
+	if len(g.regexVars) == 0 {
+		return
+	}
+	byName := make(map[string]string, len(g.regexVars))
+	for pattern, name := range g.regexVars {
+		byName[name] = pattern
+	}
+	regexpPkg := g.importedName("regexp")
+	g.writeLine("")
+	for i := 1; i <= len(byName); i++ {
+		name := fmt.Sprintf("kukichaRegex%d", i)
+		g.writeLine(fmt.Sprintf("var %s = %s.MustCompile(%s)", name, regexpPkg, goRegexString(byName[name])))
+	}
+}
+
+// goRegexString renders a regex pattern as a Go string literal: raw, so the
+// pattern reads as written, unless it contains a backquote.
+func goRegexString(pattern string) string {
+	if strings.Contains(pattern, "`") {
+		return strconv.Quote(pattern)
+	}
+	return "`" + pattern + "`"
+}
+
 func (g *Generator) generateSelectStmt(stmt *ast.SelectStmt) {
 	g.writeLine("select {")
 	g.indent++
@@ -455,8 +677,11 @@ func (g *Generator) generateForRangeStmt(stmt *ast.ForRangeStmt) {
 			g.writeLine(fmt.Sprintf("for %s, %s := range %s {", stmt.Index.Value, stmt.Variable.Value, collection))
 		}
 	} else {
-		// In stdlib/iter, all range loops are over iter.Seq which yields one value
-		if g.isStdlibIter {
+		// In stdlib/iter, all range loops are over iter.Seq which yields one
+		// value; so do channels and iterator functions anywhere
+		if stmt.Variable.Value == "_" {
+			g.writeLine(fmt.Sprintf("for range %s {", collection))
+		} else if g.isStdlibIter || g.rangesOneValue(stmt.Collection) {
 			g.writeLine(fmt.Sprintf("for %s := range %s {", stmt.Variable.Value, collection))
 		} else {
 			g.writeLine(fmt.Sprintf("for _, %s := range %s {", stmt.Variable.Value, collection))
@@ -470,6 +695,17 @@ func (g *Generator) generateForRangeStmt(stmt *ast.ForRangeStmt) {
 	g.writeLine("}")
 }
 
+// rangesOneValue reports whether ranging over collection yields a single
+// value per iteration (channels and iter.Seq-shaped functions), so the loop
+// variable must not be preceded by an index.
+func (g *Generator) rangesOneValue(collection ast.Expression) bool {
+	ti := g.exprTypes[collection]
+	if ti == nil {
+		return false
+	}
+	return ti.Kind == semantic.TypeKindChannel || ti.Kind == semantic.TypeKindFunction
+}
+
 func (g *Generator) generateForNumericStmt(stmt *ast.ForNumericStmt) {
 	varName := stmt.Variable.Value
 	start := g.exprToString(stmt.Start)
@@ -546,6 +782,12 @@ func (g *Generator) generateForConditionStmt(stmt *ast.ForConditionStmt) {
 	}
 
 	g.indent++
+	if condition == "true" && g.shutdownCtx != "" {
+		// Stop looping once main's shutdown context is cancelled
+		g.writeLine(fmt.Sprintf("if %s.Err() != nil {", g.shutdownCtx))
+		g.writeLine("\tbreak")
+		g.writeLine("}")
+	}
 	g.generateBlock(stmt.Body)
 	g.indent--
 
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
 	"strings"
 	"testing"
 )
@@ -212,6 +213,119 @@ func TestConditionSwitchStatement(t *testing.T) {
 	}
 }
 
+func TestSwitchWhenGuard(t *testing.T) {
+	input := `func Route(cmd string, allowNetwork bool) string
+    switch cmd
+        when "fetch", "pull" if allowNetwork
+            return "network"
+        when "list"
+            return "list"
+        otherwise
+            return "unknown"
+
+func Level(x int, debug bool) string
+    switch
+        when x > 10 if debug
+            return "debug"
+    return "quiet"
+`
+
+	output := generateSource(t, input)
+
+	for _, want := range []string{
+		"switch sw_1 := cmd; {",
+		`case ((sw_1 == "fetch") || (sw_1 == "pull")) && allowNetwork:`,
+		`case (sw_1 == "list"):`,
+		"case (x > 10) && debug:",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+}
+
+func TestWhenPatterns(t *testing.T) {
+	input := `func Size(n int) string
+    switch n
+        when < 0
+            return "negative"
+        when 0, 1 to 10
+            return "small"
+        when 10 through 99
+            return "medium"
+    return "large"
+
+func Kind(name string) string
+    switch name
+        when matches re"^t\d+"
+            return "test"
+        when matches re"^p", "main" if len(name) > 3
+            return "prod"
+        when matches re"^t\d+"
+            return "again"
+    return "other"
+`
+
+	output := generateSource(t, input)
+
+	for _, want := range []string{
+		"switch sw_1 := n; {",
+		"case (sw_1 < 0):",
+		"case (sw_1 == 0), (1 <= sw_1 && sw_1 < 10):",
+		"case (10 <= sw_1 && sw_1 <= 99):",
+		"case kukichaRegex1.MatchString(sw_1):",
+		"var kukichaRegex1 = regexp.MustCompile(`^t\\d+`)",
+		"var kukichaRegex2 = regexp.MustCompile(`^p`)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+	// identical patterns share one compiled regex
+	if strings.Contains(output, "kukichaRegex3") {
+		t.Errorf("expected the repeated pattern to reuse kukichaRegex1, got: %s", output)
+	}
+	assertValidGo(t, output)
+}
+
+func TestNumberLiteralsKeepSpelling(t *testing.T) {
+	input := `const Huge = 1_000_000_000_000_000_000_000
+
+func main()
+    print(1_000_000, 0xFF, 0b1010, 1e9, 0x1p-2, 18446744073709551615 as uint64, Huge)
+`
+
+	output := generateSource(t, input)
+
+	for _, want := range []string{
+		"Huge = 1_000_000_000_000_000_000_000",
+		"1_000_000, 0xFF, 0b1010, 1e9, 0x1p-2, uint64(18446744073709551615)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+}
+
+func TestSwitchContinueToNext(t *testing.T) {
+	input := `func Steps(level int) int
+    n := 0
+    switch level
+        when 2
+            n = n + 2
+            continue to next
+        when 1
+            n = n + 1
+    return n
+`
+
+	output := generateSource(t, input)
+
+	if !strings.Contains(output, "fallthrough\n\t\tcase 1:") {
+		t.Errorf("expected fallthrough ending the first case, got: %s", output)
+	}
+}
+
 func TestEmptyTypedZeroValues(t *testing.T) {
 	input := `func ZeroInt() int
     return empty int
@@ -265,6 +379,51 @@ func TestBooleanOperators(t *testing.T) {
 	}
 }
 
+func TestComparisonChain(t *testing.T) {
+	input := `func next() int
+    return 5
+
+func Test(x int) bool
+    return 0 <= x < 10 and 0 < next() < x
+`
+
+	output := generateSource(t, input)
+	assertValidGo(t, output)
+
+	if !strings.Contains(output, "(0 <= x && x < 10)") {
+		t.Errorf("expected pairwise comparisons, got: %s", output)
+	}
+	// next() is compared twice but must run once
+	if !strings.Contains(output, "func() bool { v_1 := next(); return 0 < v_1 && v_1 < x }()") {
+		t.Errorf("expected next() bound to a temporary, got: %s", output)
+	}
+}
+
+func TestRangeCheck(t *testing.T) {
+	input := `func next() int
+    return 5
+
+func Test(x int, ok bool) bool
+    return ok and x not in 0 to 10 or x between 1 and 5 or next() in 1 through x
+`
+
+	output := generateSource(t, input)
+	assertValidGo(t, output)
+	formatted, err := FormatGo([]byte(output))
+	if err != nil {
+		t.Fatalf("format error: %v", err)
+	}
+
+	for _, want := range []string{
+		"ok && (x < 0 || x >= 10) || 1 <= x && x <= 5",
+		"func() bool { v_1 := next(); return 1 <= v_1 && v_1 <= x }()",
+	} {
+		if !strings.Contains(string(formatted), want) {
+			t.Errorf("expected %q, got: %s", want, formatted)
+		}
+	}
+}
+
 func TestReferenceType(t *testing.T) {
 	input := `type Person
     Name string
@@ -454,3 +613,509 @@ func TestOnErrBreak(t *testing.T) {
 		t.Errorf("expected error check, got: %s", output)
 	}
 }
+
+func TestRouteCodegen(t *testing.T) {
+	input := `# shutdown: off
+
+# route: GET /users/{id}
+func getUser(id int) (string, error)
+    return "user {id}", empty
+`
+	program := mustParseProgram(t, input)
+	program.Target = "http"
+	output, err := New(program).Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		`http.HandleFunc("GET /users/{id}", func(w_1 http.ResponseWriter, r_2 *http.Request) {`,
+		`path_3, err_4 := strconv.ParseInt(r_2.PathValue("id"), 10, 0)`,
+		`result_5, err_6 := getUser(int(path_3))`,
+		`io.WriteString(w_1, result_5)`,
+		`log.Fatal(http.ListenAndServe(addr, nil))`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+
+	if output := generateSource(t, input); strings.Contains(output, "HandleFunc") {
+		t.Errorf("expected routes to be registered only for the http target, got: %s", output)
+	}
+}
+
+func TestScheduleCodegen(t *testing.T) {
+	input := `import "context"
+
+# schedule: "*/5 * * * *"
+func cleanup(ctx context.Context) error
+    return empty
+
+# schedule: @hourly
+func report()
+    print("report")
+`
+	program := mustParseProgram(t, input)
+	program.Target = "worker"
+	output, err := New(program).Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		`if err_2 := cron.Register("cleanup", "*/5 * * * *", func(ctx_1 context.Context) error {`,
+		"\t\treturn cleanup(ctx_1)\n\t}); err_2 != nil {\n\t\tpanic(err_2)\n\t}",
+		"\t\treport()\n\t\treturn nil\n",
+		`"github.com/duber000/kukicha/stdlib/cron"`,
+		`if err := cron.Run(shutdownCtx_5); err != nil {`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+
+	if output := generateSource(t, input); strings.Contains(output, "cron.") {
+		t.Errorf("expected jobs to be registered only for the worker target, got: %s", output)
+	}
+}
+
+func TestInstrumentCodegen(t *testing.T) {
+	input := `import "context"
+
+type Store
+    items map of string to int
+
+func Lookup on s reference Store(ctx context.Context, key string) (int, error)
+    return s.items[key], empty
+
+func Greet(name string)
+    print("hello {name}")
+
+func helper() int
+    return 1
+
+func main()
+    Greet("world")
+`
+	program := mustParseProgram(t, input)
+	gen := New(program)
+	gen.SetInstrument(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		"func (s *Store) Lookup(ctx context.Context, key string) (_ int, err_1 error) {\n\tctx, call_2 := telemetry.StartContext(ctx, \"main.Store.Lookup\")\n\tdefer call_2.End(&err_1)\n",
+		"func Greet(name string) {\n\tdefer telemetry.Start(\"main.Greet\").End(nil)\n",
+		"func helper() int {\n//line test.kuki:13\n\treturn 1",
+		"func main() {\n\tdefer telemetry.Auto()()\n",
+		`"github.com/duber000/kukicha/stdlib/telemetry"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+
+	if output := generateSource(t, input); strings.Contains(output, "telemetry") {
+		t.Errorf("expected no instrumentation without SetInstrument, got: %s", output)
+	}
+}
+
+func TestOnErrLogCodegen(t *testing.T) {
+	input := `import "strconv"
+
+func parse(s string) (int, error)
+    n := strconv.Atoi(s) onerr explain "parsing count"
+    return n, empty
+
+func main()
+    for s in list of string{"1", "x"}
+        n := strconv.Atoi(s) onerr continue
+        print(n)
+    strconv.Atoi("y") onerr discard
+`
+	program := mustParseProgram(t, input)
+	gen := New(program)
+	gen.SetOnErrLog(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		"err_1 = fmt.Errorf(\"parsing count: %w\", err_1)\n\t\tslog.Error(\"onerr\", \"function\", \"parse\", \"at\", \"test.kuki:4\", \"explain\", \"parsing count\", \"error\", err_1)\n",
+		"slog.Error(\"onerr\", \"function\", \"main\", \"at\", \"test.kuki:9\", \"error\", err_1)\n\t\t\tcontinue",
+		`"log/slog"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+	if strings.Count(output, "slog.Error") != 2 {
+		t.Errorf("expected the discarded error not to be logged, got: %s", output)
+	}
+
+	if output := generateSource(t, "# onerr: off\n\n"+input); strings.Contains(output, "slog") {
+		t.Errorf("expected # onerr: off to turn logging off, got: %s", output)
+	}
+	if output := generateSource(t, "# onerr: log\n\n"+input); !strings.Contains(output, "slog.Error") {
+		t.Errorf("expected # onerr: log to turn logging on, got: %s", output)
+	}
+	if output := generateSource(t, input); strings.Contains(output, "slog") {
+		t.Errorf("expected no logging by default, got: %s", output)
+	}
+}
+
+func TestGracefulShutdownCodegen(t *testing.T) {
+	input := `func main()
+    defer print("bye")
+    for true
+        print("tick")
+`
+	output := generateSource(t, input)
+	for _, want := range []string{
+		`shutdownCtx_1, stop_2 := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)`,
+		"defer stop_2()",
+		"time.Sleep(5 * time.Second)",
+		"for {\n\t\tif shutdownCtx_1.Err() != nil {\n\t\t\tbreak\n\t\t}",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+
+	if output := generateSource(t, "# shutdown: off\n\n"+input); strings.Contains(output, "NotifyContext") {
+		t.Errorf("expected # shutdown: off to drop the scaffolding, got: %s", output)
+	}
+	if output := generateSource(t, "func main()\n    print(\"once\")\n"); strings.Contains(output, "NotifyContext") {
+		t.Errorf("expected no scaffolding for a program without a loop, got: %s", output)
+	}
+	if output := generateSource(t, "# shutdown: 1500ms\n\nfunc main()\n    print(\"once\")\n"); !strings.Contains(output, "time.Sleep(1500 * time.Millisecond)") {
+		t.Errorf("expected the pragma's grace period, got: %s", output)
+	}
+
+	program := mustParseProgram(t, `# route: GET /health
+func health() string
+    return "ok"
+`)
+	program.Target = "http"
+	output, err := New(program).Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if !strings.Contains(output, "server_6.Shutdown(context.Background())") || !strings.Contains(output, "err != http.ErrServerClosed") {
+		t.Errorf("expected the route server to shut down gracefully, got: %s", output)
+	}
+}
+
+func TestGracefulShutdownInFunctionLiteral(t *testing.T) {
+	input := `func main()
+    worker := func()
+        for true
+            print("working")
+    go worker()
+    for true
+        print("tick")
+`
+	output := generateSource(t, input)
+	if n := strings.Count(output, "if shutdownCtx_1.Err() != nil {"); n != 2 {
+		t.Errorf("expected both loops, including the one in the function literal, to stop on shutdown, got %d: %s", n, output)
+	}
+}
+
+func TestReleaseCodegen(t *testing.T) {
+	input := `import "stdlib/must"
+
+func half(n int) int
+    must.True(n % 2 == 0, "n must be even")
+    return n / 2
+`
+	gen := New(mustParseProgram(t, input))
+	gen.SetRelease(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if strings.Contains(output, "//line") {
+		t.Errorf("expected no //line directives in release output, got: %s", output)
+	}
+	if strings.Contains(output, "must.True") || strings.Contains(output, "stdlib/must") {
+		t.Errorf("expected the assertion and its import to be dropped, got: %s", output)
+	}
+
+	gen = New(mustParseProgram(t, input+`
+func key() string
+    return must.Env("KEY")
+`))
+	gen.SetRelease(true)
+	output, err = gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if strings.Contains(output, "must.True") || !strings.Contains(output, `"github.com/duber000/kukicha/stdlib/must"`) {
+		t.Errorf("expected the must import to stay for must.Env, got: %s", output)
+	}
+
+	if output := generateSource(t, input); !strings.Contains(output, "must.True") || !strings.Contains(output, "//line test.kuki") {
+		t.Errorf("expected assertions and //line directives outside release mode, got: %s", output)
+	}
+}
+
+func TestCheckCastsCodegen(t *testing.T) {
+	input := `func convert(n int64, f float64, u uint64, i int32) (uint8, int, int64, float32, int64, uint8)
+    return n as uint8, f as int, u as int64, f as float32, i as int64, 7 as uint8
+`
+	program := mustParseProgram(t, input)
+	analyzer := semantic.NewWithFile(program, "app.kuki")
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v", errs)
+	}
+	gen := New(program)
+	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	gen.SetCheckCasts(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+
+	for _, want := range []string{
+		`"fmt"`,
+		`"math"`,
+		"func(v int64) uint8 { r := uint8(v); if int64(r) != v || v < 0 {",
+		"func(v float64) int { r := int(v); if float64(r) != v {",
+		"func(v uint64) int64 { r := int64(v); if uint64(r) != v || r < 0 {",
+		"func(v float64) float32 { r := float32(v); if math.IsInf(float64(r), 0) && !math.IsInf(v, 0) {",
+		`panic(fmt.Sprintf("test.kuki:2: %v does not convert to uint8 exactly", v))`,
+		"int64(i), uint8(7)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+}
+
+func TestBuildMetadataCodegen(t *testing.T) {
+	input := "import \"stdlib/buildinfo\"\n\nfunc main()\n    print(buildinfo.Version())\n"
+	gen := New(mustParseProgram(t, input))
+	gen.SetBuildMetadata(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if !strings.Contains(output, "var kukichaBuild string") || !strings.Contains(output, "buildinfo.Set(kukichaBuild)") {
+		t.Errorf("expected the build metadata variable, got: %s", output)
+	}
+
+	gen = New(mustParseProgram(t, "func main()\n    print(1)\n"))
+	gen.SetBuildMetadata(true)
+	if output, _ := gen.Generate(); strings.Contains(output, "kukichaBuild") || strings.Contains(output, "\"runtime\"") {
+		t.Errorf("expected no build metadata in a program that does not read it, got: %s", output)
+	}
+	gen = New(mustParseProgram(t, "petiole tools\n\nimport \"stdlib/buildinfo\"\n\nfunc Run()\n    print(buildinfo.Version())\n"))
+	gen.SetBuildMetadata(true)
+	if output, _ := gen.Generate(); strings.Contains(output, "kukichaBuild") {
+		t.Errorf("expected no build metadata outside package main, got: %s", output)
+	}
+	if output := generateSource(t, input); strings.Contains(output, "kukichaBuild") {
+		t.Errorf("expected build metadata only when requested, got: %s", output)
+	}
+}
+
+func TestProfileCodegen(t *testing.T) {
+	input := "func main()\n    print(1)\n"
+	gen := New(mustParseProgram(t, input))
+	gen.SetProfile("/tmp/prof")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		`"runtime/pprof"`,
+		"func main() {\n\tdefer kukichaProfile(\"/tmp/prof\")()",
+		"func kukichaProfile(dir string) func() {",
+		`pprof.StartCPUProfile(cpu)`,
+		`pprof.Lookup("allocs").WriteTo(allocs, 0)`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+	assertValidGo(t, output)
+
+	gen = New(mustParseProgram(t, "petiole tools\n\nfunc Run()\n    print(1)\n"))
+	gen.SetProfile("/tmp/prof")
+	if output, _ := gen.Generate(); strings.Contains(output, "kukichaProfile") {
+		t.Errorf("expected no profiling without a main function, got: %s", output)
+	}
+}
+
+func TestExplainCodegen(t *testing.T) {
+	input := `func load(path string) (string, error)
+    n := strconv.Atoi("12") onerr 0
+    data := path |> filepath.Clean() |> os.ReadFile() onerr explain "reading"
+    os.Remove(path) onerr discard
+    print("{n}")
+    return data as string, empty
+`
+	generate := func(explain bool) string {
+		program := mustParseProgram(t, input)
+		analyzer := semantic.NewWithFile(program, "app.kuki")
+		analyzer.SetAutoImport(true)
+		if errs := analyzer.Analyze(); len(errs) > 0 {
+			t.Fatalf("semantic errors: %v", errs)
+		}
+		gen := New(program)
+		gen.SetExprReturnCounts(analyzer.ReturnCounts())
+		gen.SetExprTypes(analyzer.ExprTypes())
+		gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+		gen.SetExplainCodegen(explain)
+		output, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("codegen error: %v", err)
+		}
+		return output
+	}
+
+	output := generate(true)
+	for _, want := range []string{
+		`"fmt" // kukicha: imported for generated code`,
+		`"strconv" // kukicha: auto-imported: strconv is used without an import`,
+		"// kukicha: onerr default: assign the fallback value instead\n\t\tn = 0",
+		"// kukicha: pipe with onerr: each step that returns an error gets its own temp and check",
+		`// kukicha: onerr explain: return err_3 wrapped as "reading: %w"`,
+		"// kukicha: onerr discard: the error result is assigned to _ and never checked\n\t_ = os.Remove(path)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+	assertValidGo(t, output)
+
+	if output := generate(false); strings.Contains(output, "kukicha:") {
+		t.Errorf("expected no explanations without SetExplainCodegen, got: %s", output)
+	}
+}
+
+func TestExplainCodegenTypeParameters(t *testing.T) {
+	program := mustParseProgram(t, "func Filter(items list of any, keep func(any) bool) list of any\n    return empty\n")
+	gen := New(program)
+	gen.SetSourceFile("stdlib/slice/slice.kuki")
+	gen.SetExplainCodegen(true)
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	want := "// kukicha: generic: type parameters inferred from placeholder types (any -> T any)\nfunc Filter[T any]("
+	if !strings.Contains(output, want) {
+		t.Errorf("expected %q in output, got: %s", want, output)
+	}
+}
+
+func TestWhenTargetCodegen(t *testing.T) {
+	input := `func main()
+    when target mcp
+        print("serving")
+    otherwise
+        print("running")
+`
+	program := mustParseProgram(t, input)
+	program.Target = "mcp"
+	gen := New(program)
+	gen.SetBuildTag("kukicha_mcp")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if !strings.Contains(output, `"serving"`) || strings.Contains(output, `"running"`) {
+		t.Errorf("expected only the mcp branch, got: %s", output)
+	}
+	if !strings.Contains(output, "//go:build kukicha_mcp\n\npackage main") {
+		t.Errorf("expected build constraint before package clause, got: %s", output)
+	}
+
+	output = generateSource(t, input)
+	if !strings.Contains(output, `"running"`) || strings.Contains(output, "//go:build") {
+		t.Errorf("expected the otherwise branch for the default target, got: %s", output)
+	}
+}
+
+func TestHeaderLinesBeforePackage(t *testing.T) {
+	program := mustParseProgram(t, "# header: //go:generate stringer -type=Color\n\nfunc main()\n    print(1)\n")
+	gen := New(program)
+	gen.SetHeader([]string{"// Copyright 2026 Acme Corp."})
+	gen.SetBuildTag("kukicha_mcp")
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	want := "// Generated by Kukicha (requires Go 1.26+)\n\n// Copyright 2026 Acme Corp.\n//go:generate stringer -type=Color\n\n//go:build kukicha_mcp\n\npackage main"
+	if !strings.HasPrefix(output, want) {
+		t.Errorf("expected project then file header lines before the package clause, got: %s", output)
+	}
+	assertValidGo(t, output)
+}
+
+func TestTempNamesArePerFunction(t *testing.T) {
+	second := `
+func second(s string) int
+    n := strconv.Atoi(s) onerr 0
+    return n
+`
+	before := generateSource(t, "import \"strconv\"\n\nfunc first(s string) int\n    return 1\n"+second)
+	after := generateSource(t, "import \"strconv\"\n\nfunc first(s string) int\n    a := strconv.Atoi(s) onerr 0\n    b := strconv.Atoi(s) onerr 0\n    return a + b\n"+second)
+	body := func(output string) string {
+		start := strings.Index(output, "func second(")
+		if start < 0 {
+			t.Fatalf("no second function in: %s", output)
+		}
+		fn := output[start : start+strings.Index(output[start:], "\n}\n")]
+		// The edit moves second down, so only its //line directives may change
+		var lines []string
+		for line := range strings.SplitSeq(fn, "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), "//line ") {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	if body(before) != body(after) {
+		t.Errorf("editing first renamed the temps of second:\n%s\n---\n%s", body(before), body(after))
+	}
+	if !strings.Contains(body(after), "err_1") {
+		t.Errorf("expected second's temps to be numbered from 1, got: %s", body(after))
+	}
+}
+
+func TestConstantFoldingCodegen(t *testing.T) {
+	input := `import "strings"
+
+const Prefix = strings.ToUpper("id") + "-"
+
+func main(kind string)
+    switch kind
+        when strings.Repeat("ab", 2)
+            print(Prefix, len("abc"), 7 * 24)
+`
+	program := mustParseProgram(t, input)
+	analyzer := semantic.New(program)
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v", errs)
+	}
+	gen := New(program)
+	gen.SetConstants(analyzer.Constants())
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	for _, want := range []string{
+		`const Prefix = "ID-"`,
+		`case "abab":`,
+		`fmt.Println(Prefix, 3, (7 * 24))`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+	if strings.Contains(output, `"strings"`) {
+		t.Errorf("expected the unused strings import to be dropped, got: %s", output)
+	}
+}
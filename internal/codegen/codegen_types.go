@@ -13,21 +13,48 @@ func (g *Generator) typeInfoToGoString(ti *semantic.TypeInfo) string {
 
 	switch ti.Kind {
 	case semantic.TypeKindInt:
+		if ti.Name != "" {
+			return ti.Name
+		}
 		return "int"
 	case semantic.TypeKindFloat:
+		if ti.Name != "" {
+			return ti.Name
+		}
 		return "float64"
 	case semantic.TypeKindString:
+		if ti.Name != "" {
+			return ti.Name
+		}
 		return "string"
 	case semantic.TypeKindBool:
+		if ti.Name != "" {
+			return ti.Name
+		}
 		return "bool"
 	case semantic.TypeKindList:
 		return "[]" + g.typeInfoToGoString(ti.ElementType)
 	case semantic.TypeKindMap:
 		return "map[" + g.typeInfoToGoString(ti.KeyType) + "]" + g.typeInfoToGoString(ti.ValueType)
 	case semantic.TypeKindChannel:
-		return "chan " + g.typeInfoToGoString(ti.ElementType)
+		elem := g.typeInfoToGoString(ti.ElementType)
+		switch ti.ChanDir {
+		case "send-only":
+			return "chan<- " + elem
+		case "receive-only":
+			return "<-chan " + elem
+		}
+		if strings.HasPrefix(elem, "<-") {
+			return "chan (" + elem + ")"
+		}
+		return "chan " + elem
 	case semantic.TypeKindReference:
 		return "*" + g.typeInfoToGoString(ti.ElementType)
+	case semantic.TypeKindInterface:
+		if ti.Name == "" {
+			return "any"
+		}
+		return g.typeInfoToGoString(&semantic.TypeInfo{Kind: semantic.TypeKindNamed, Name: ti.Name})
 	case semantic.TypeKindNamed:
 		// Rewrite package-qualified type names if the package was auto-aliased
 		name := ti.Name
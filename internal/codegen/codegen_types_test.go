@@ -1,8 +1,10 @@
 package codegen
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/duber000/kukicha/internal/ast"
 	"github.com/duber000/kukicha/internal/semantic"
 )
 
@@ -37,6 +39,19 @@ func TestTypeInfoToGoString(t *testing.T) {
 			Kind:        semantic.TypeKindChannel,
 			ElementType: &semantic.TypeInfo{Kind: semantic.TypeKindString},
 		}, "chan string"},
+		{"channel of string receive-only", &semantic.TypeInfo{
+			Kind:        semantic.TypeKindChannel,
+			ElementType: &semantic.TypeInfo{Kind: semantic.TypeKindString},
+			ChanDir:     "receive-only",
+		}, "<-chan string"},
+		{"channel of channel of int receive-only", &semantic.TypeInfo{
+			Kind: semantic.TypeKindChannel,
+			ElementType: &semantic.TypeInfo{
+				Kind:        semantic.TypeKindChannel,
+				ElementType: &semantic.TypeInfo{Kind: semantic.TypeKindInt},
+				ChanDir:     "receive-only",
+			},
+		}, "chan (<-chan int)"},
 		{"reference int", &semantic.TypeInfo{
 			Kind:        semantic.TypeKindReference,
 			ElementType: &semantic.TypeInfo{Kind: semantic.TypeKindInt},
@@ -102,3 +117,32 @@ func TestTypeInfoToGoStringWithPkgAlias(t *testing.T) {
 		t.Errorf("expected kukijson.Decoder with alias, got %q", got)
 	}
 }
+
+func TestTypeCastConsultsExprTypes(t *testing.T) {
+	// Shape is declared in another file, so the name-based heuristic cannot
+	// see it; the analyzer-recorded interface kind must drive the assertion.
+	prog := mustParseProgram(t, "func convert(x any) Shape\n    return x as Shape\n")
+	fn := prog.Declarations[0].(*ast.FunctionDecl)
+	cast := fn.Body.Statements[0].(*ast.ReturnStmt).Values[0].(*ast.TypeCastExpr)
+
+	gen := New(prog)
+	gen.SetExprTypes(map[ast.Expression]*semantic.TypeInfo{
+		cast: {Kind: semantic.TypeKindInterface, Name: "Shape"},
+	})
+	output, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if !strings.Contains(output, "return x.(Shape)") {
+		t.Errorf("expected type assertion from recorded interface type, got:\n%s", output)
+	}
+
+	gen = New(prog)
+	output, err = gen.Generate()
+	if err != nil {
+		t.Fatalf("codegen error: %v", err)
+	}
+	if !strings.Contains(output, "return Shape(x)") {
+		t.Errorf("expected conversion without type info, got:\n%s", output)
+	}
+}
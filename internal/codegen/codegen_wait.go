@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// generateWaitExpr lowers `wait for`. A plain wait is a receive; the other
+// forms are function literals called on the channel(s) and the duration, so
+// the user's expressions are evaluated once and nothing in them is shadowed:
+//
+//	wait for ch within d   →  select on ch and time.After(d), returning (T, error)
+//	wait for all chans     →  receive from each channel in turn into a []T
+func (g *Generator) generateWaitExpr(e *ast.WaitExpr) string {
+	if !e.All && e.Timeout == nil {
+		return "<-" + g.exprToString(e.Channel)
+	}
+	chans, chansType, elemType := g.waitChannels(e)
+	if !e.All {
+		timeout := g.importedName("time")
+		return fmt.Sprintf("func(c %s, d %s.Duration) (%s, error) { select { case v := <-c: return v, nil; case <-%s.After(d): var zero %s; return zero, %s.Errorf(\"wait for: timed out after %%v\", d) } }(%s, %s.Duration(%s))",
+			chansType, timeout, elemType, timeout, elemType, g.importedName("fmt"), chans, timeout, g.exprToString(e.Timeout))
+	}
+	if e.Timeout == nil {
+		return fmt.Sprintf("func(chans %s) []%s { out := make([]%s, 0, len(chans)); for _, c := range chans { out = append(out, <-c) }; return out }(%s)",
+			chansType, elemType, elemType, chans)
+	}
+	timeout := g.importedName("time")
+	return fmt.Sprintf("func(chans %s, d %s.Duration) ([]%s, error) { timeout := %s.After(d); out := make([]%s, 0, len(chans)); for _, c := range chans { select { case v := <-c: out = append(out, v); case <-timeout: return out, %s.Errorf(\"wait for all: timed out after %%v with %%d of %%d results\", d, len(out), len(chans)) } }; return out, nil }(%s, %s.Duration(%s))",
+		chansType, timeout, elemType, timeout, elemType, g.importedName("fmt"), chans, timeout, g.exprToString(e.Timeout))
+}
+
+// waitChannels returns the Go expression and type a wait's function literal
+// takes, and the Go type of the values it receives. A list literal of
+// channels is built as []<-chan T, since Go would not infer a common type
+// for it.
+func (g *Generator) waitChannels(e *ast.WaitExpr) (chans, chansType, elemType string) {
+	chanTI := g.exprTypes[e.Channel]
+	if e.All && chanTI != nil {
+		chanTI = chanTI.ElementType
+	}
+	elemType = "any"
+	if chanTI != nil && chanTI.Kind == semantic.TypeKindChannel && chanTI.ElementType != nil {
+		elemType = g.typeInfoToGoString(chanTI.ElementType)
+	}
+	if !e.All {
+		return g.exprToString(e.Channel), "<-chan " + elemType, elemType
+	}
+	if list, ok := e.Channel.(*ast.ListLiteralExpr); ok && list.Type == nil {
+		elems := make([]string, len(list.Elements))
+		for i, el := range list.Elements {
+			elems[i] = g.exprToString(el)
+		}
+		return fmt.Sprintf("[]<-chan %s{%s}", elemType, strings.Join(elems, ", ")), "[]<-chan " + elemType, elemType
+	}
+	chansType = "[]<-chan " + elemType
+	if listTI := g.exprTypes[e.Channel]; listTI != nil && listTI.Kind == semantic.TypeKindList {
+		chansType = g.typeInfoToGoString(listTI)
+	}
+	return g.exprToString(e.Channel), chansType, elemType
+}
@@ -119,6 +119,12 @@ func (g *Generator) collectStmtNames(stmt ast.Statement) {
 		if s.Block != nil {
 			g.collectBlockNames(s.Block)
 		}
+	case *ast.SafelyStmt:
+		g.collectBlockNames(s.Body)
+	case *ast.RequireStmt:
+		if s.Else != nil {
+			g.collectBlockNames(s.Else)
+		}
 	case *ast.DeferStmt:
 		// defer calls don't introduce new names
 	case *ast.ExpressionStmt:
@@ -132,330 +138,7 @@ func (g *Generator) collectStmtNames(stmt ast.Statement) {
 func (g *Generator) walkProgram(visit func(ast.Expression) bool) bool {
 	for _, decl := range g.program.Declarations {
 		if fn, ok := decl.(*ast.FunctionDecl); ok {
-			if fn.Body != nil && g.walkBlock(fn.Body, visit) {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-// walkBlock walks all statements in block, short-circuiting on the first true.
-func (g *Generator) walkBlock(block *ast.BlockStmt, visit func(ast.Expression) bool) bool {
-	for _, stmt := range block.Statements {
-		if g.walkStmt(stmt, visit) {
-			return true
-		}
-	}
-	return false
-}
-
-// walkStmt walks all expressions reachable from stmt.
-func (g *Generator) walkStmt(stmt ast.Statement, visit func(ast.Expression) bool) bool {
-	if stmt == nil {
-		return false
-	}
-	switch s := stmt.(type) {
-	case *ast.VarDeclStmt:
-		for _, v := range s.Values {
-			if g.walkExpr(v, visit) {
-				return true
-			}
-		}
-		if s.OnErr != nil && g.walkExpr(s.OnErr.Handler, visit) {
-			return true
-		}
-	case *ast.AssignStmt:
-		for _, t := range s.Targets {
-			if g.walkExpr(t, visit) {
-				return true
-			}
-		}
-		for _, v := range s.Values {
-			if g.walkExpr(v, visit) {
-				return true
-			}
-		}
-		if s.OnErr != nil && g.walkExpr(s.OnErr.Handler, visit) {
-			return true
-		}
-	case *ast.ReturnStmt:
-		for _, v := range s.Values {
-			if g.walkExpr(v, visit) {
-				return true
-			}
-		}
-	case *ast.IncDecStmt:
-		if g.walkExpr(s.Variable, visit) {
-			return true
-		}
-	case *ast.IfStmt:
-		if s.Init != nil && g.walkStmt(s.Init, visit) {
-			return true
-		}
-		if g.walkExpr(s.Condition, visit) {
-			return true
-		}
-		if s.Consequence != nil && g.walkBlock(s.Consequence, visit) {
-			return true
-		}
-		if s.Alternative != nil && g.walkStmt(s.Alternative, visit) {
-			return true
-		}
-	case *ast.ElseStmt:
-		if s.Body != nil && g.walkBlock(s.Body, visit) {
-			return true
-		}
-	case *ast.SwitchStmt:
-		if s.Expression != nil && g.walkExpr(s.Expression, visit) {
-			return true
-		}
-		for _, c := range s.Cases {
-			for _, v := range c.Values {
-				if g.walkExpr(v, visit) {
-					return true
-				}
-			}
-			if c.Body != nil && g.walkBlock(c.Body, visit) {
-				return true
-			}
-		}
-		if s.Otherwise != nil && s.Otherwise.Body != nil && g.walkBlock(s.Otherwise.Body, visit) {
-			return true
-		}
-	case *ast.SelectStmt:
-		for _, c := range s.Cases {
-			if c.Recv != nil && g.walkExpr(c.Recv, visit) {
-				return true
-			}
-			if c.Send != nil {
-				if g.walkExpr(c.Send.Value, visit) {
-					return true
-				}
-				if g.walkExpr(c.Send.Channel, visit) {
-					return true
-				}
-			}
-			if c.Body != nil && g.walkBlock(c.Body, visit) {
-				return true
-			}
-		}
-		if s.Otherwise != nil && s.Otherwise.Body != nil && g.walkBlock(s.Otherwise.Body, visit) {
-			return true
-		}
-	case *ast.TypeSwitchStmt:
-		if g.walkExpr(s.Expression, visit) {
-			return true
-		}
-		for _, c := range s.Cases {
-			if c.Body != nil && g.walkBlock(c.Body, visit) {
-				return true
-			}
-		}
-		if s.Otherwise != nil && s.Otherwise.Body != nil && g.walkBlock(s.Otherwise.Body, visit) {
-			return true
-		}
-	case *ast.ForRangeStmt:
-		if g.walkExpr(s.Collection, visit) {
-			return true
-		}
-		if s.Body != nil && g.walkBlock(s.Body, visit) {
-			return true
-		}
-	case *ast.ForNumericStmt:
-		if g.walkExpr(s.Start, visit) {
-			return true
-		}
-		if g.walkExpr(s.End, visit) {
-			return true
-		}
-		if s.Body != nil && g.walkBlock(s.Body, visit) {
-			return true
-		}
-	case *ast.ForConditionStmt:
-		if g.walkExpr(s.Condition, visit) {
-			return true
-		}
-		if s.Body != nil && g.walkBlock(s.Body, visit) {
-			return true
-		}
-	case *ast.DeferStmt:
-		if g.walkExpr(s.Call, visit) {
-			return true
-		}
-	case *ast.GoStmt:
-		if s.Call != nil && g.walkExpr(s.Call, visit) {
-			return true
-		}
-		if s.Block != nil && g.walkBlock(s.Block, visit) {
-			return true
-		}
-	case *ast.SendStmt:
-		if g.walkExpr(s.Value, visit) {
-			return true
-		}
-		if g.walkExpr(s.Channel, visit) {
-			return true
-		}
-	case *ast.ExpressionStmt:
-		if g.walkExpr(s.Expression, visit) {
-			return true
-		}
-		if s.OnErr != nil && g.walkExpr(s.OnErr.Handler, visit) {
-			return true
-		}
-	}
-	return false
-}
-
-// walkExpr calls visit(expr) first; if visit returns true, walkExpr returns
-// true immediately (short-circuit). Otherwise it recurses into all
-// sub-expressions and returns true as soon as any recursive call does.
-func (g *Generator) walkExpr(expr ast.Expression, visit func(ast.Expression) bool) bool {
-	if expr == nil {
-		return false
-	}
-	if visit(expr) {
-		return true
-	}
-	switch e := expr.(type) {
-	case *ast.BinaryExpr:
-		return g.walkExpr(e.Left, visit) || g.walkExpr(e.Right, visit)
-	case *ast.UnaryExpr:
-		return g.walkExpr(e.Right, visit)
-	case *ast.PipeExpr:
-		return g.walkExpr(e.Left, visit) || g.walkExpr(e.Right, visit)
-	case *ast.CallExpr:
-		if g.walkExpr(e.Function, visit) {
-			return true
-		}
-		for _, arg := range e.Arguments {
-			if g.walkExpr(arg, visit) {
-				return true
-			}
-		}
-		for _, na := range e.NamedArguments {
-			if g.walkExpr(na.Value, visit) {
-				return true
-			}
-		}
-	case *ast.MethodCallExpr:
-		if g.walkExpr(e.Object, visit) {
-			return true
-		}
-		for _, arg := range e.Arguments {
-			if g.walkExpr(arg, visit) {
-				return true
-			}
-		}
-		for _, na := range e.NamedArguments {
-			if g.walkExpr(na.Value, visit) {
-				return true
-			}
-		}
-	case *ast.FieldAccessExpr:
-		if g.walkExpr(e.Object, visit) {
-			return true
-		}
-	case *ast.IndexExpr:
-		return g.walkExpr(e.Left, visit) || g.walkExpr(e.Index, visit)
-	case *ast.SliceExpr:
-		if g.walkExpr(e.Left, visit) {
-			return true
-		}
-		if g.walkExpr(e.Start, visit) {
-			return true
-		}
-		if g.walkExpr(e.End, visit) {
-			return true
-		}
-	case *ast.ErrorExpr:
-		return g.walkExpr(e.Message, visit)
-	case *ast.PanicExpr:
-		return g.walkExpr(e.Message, visit)
-	case *ast.ReturnExpr:
-		for _, v := range e.Values {
-			if g.walkExpr(v, visit) {
-				return true
-			}
-		}
-	case *ast.MakeExpr:
-		for _, arg := range e.Args {
-			if g.walkExpr(arg, visit) {
-				return true
-			}
-		}
-	case *ast.CloseExpr:
-		return g.walkExpr(e.Channel, visit)
-	case *ast.ReceiveExpr:
-		return g.walkExpr(e.Channel, visit)
-	case *ast.AddressOfExpr:
-		return g.walkExpr(e.Operand, visit)
-	case *ast.DerefExpr:
-		return g.walkExpr(e.Operand, visit)
-	case *ast.TypeCastExpr:
-		return g.walkExpr(e.Expression, visit)
-	case *ast.TypeAssertionExpr:
-		return g.walkExpr(e.Expression, visit)
-	case *ast.StructLiteralExpr:
-		for _, f := range e.Fields {
-			if g.walkExpr(f.Value, visit) {
-				return true
-			}
-		}
-	case *ast.ListLiteralExpr:
-		for _, elem := range e.Elements {
-			if g.walkExpr(elem, visit) {
-				return true
-			}
-		}
-	case *ast.MapLiteralExpr:
-		for _, pair := range e.Pairs {
-			if g.walkExpr(pair.Key, visit) || g.walkExpr(pair.Value, visit) {
-				return true
-			}
-		}
-	case *ast.FunctionLiteral:
-		if e.Body != nil && g.walkBlock(e.Body, visit) {
-			return true
-		}
-	case *ast.ArrowLambda:
-		if e.Body != nil && g.walkExpr(e.Body, visit) {
-			return true
-		}
-		if e.Block != nil && g.walkBlock(e.Block, visit) {
-			return true
-		}
-	case *ast.BlockExpr:
-		if e.Body != nil && g.walkBlock(e.Body, visit) {
-			return true
-		}
-	case *ast.PipedSwitchExpr:
-		if g.walkExpr(e.Left, visit) {
-			return true
-		}
-		switch s := e.Switch.(type) {
-		case *ast.SwitchStmt:
-			for _, c := range s.Cases {
-				for _, v := range c.Values {
-					if g.walkExpr(v, visit) {
-						return true
-					}
-				}
-				if c.Body != nil && g.walkBlock(c.Body, visit) {
-					return true
-				}
-			}
-			if s.Otherwise != nil && s.Otherwise.Body != nil && g.walkBlock(s.Otherwise.Body, visit) {
-				return true
-			}
-		case *ast.TypeSwitchStmt:
-			for _, c := range s.Cases {
-				if c.Body != nil && g.walkBlock(c.Body, visit) {
-					return true
-				}
-			}
-			if s.Otherwise != nil && s.Otherwise.Body != nil && g.walkBlock(s.Otherwise.Body, visit) {
+			if fn.Body != nil && ast.WalkBlock(fn.Body, visit) {
 				return true
 			}
 		}
@@ -509,6 +192,10 @@ func (g *Generator) stmtHasNonPrintfInterpolation(stmt ast.Statement) bool {
 		if slices.ContainsFunc(s.Values, g.exprHasNonPrintfInterpolation) {
 			return true
 		}
+	case *ast.YieldStmt:
+		if slices.ContainsFunc(s.Values, g.exprHasNonPrintfInterpolation) {
+			return true
+		}
 	case *ast.IfStmt:
 		if g.exprHasNonPrintfInterpolation(s.Condition) {
 			return true
@@ -561,6 +248,9 @@ func (g *Generator) stmtHasNonPrintfInterpolation(stmt ast.Statement) bool {
 			if slices.ContainsFunc(c.Values, g.exprHasNonPrintfInterpolation) {
 				return true
 			}
+			if c.Guard != nil && g.exprHasNonPrintfInterpolation(c.Guard) {
+				return true
+			}
 			if c.Body != nil && g.blockHasNonPrintfInterpolation(c.Body) {
 				return true
 			}
@@ -610,10 +300,28 @@ func (g *Generator) stmtHasNonPrintfInterpolation(stmt ast.Statement) bool {
 		if s.Block != nil && g.blockHasNonPrintfInterpolation(s.Block) {
 			return true
 		}
+	case *ast.SafelyStmt:
+		if g.blockHasNonPrintfInterpolation(s.Body) {
+			return true
+		}
+		if s.OnErr != nil && g.exprHasNonPrintfInterpolation(s.OnErr.Handler) {
+			return true
+		}
 	case *ast.SendStmt:
 		if g.exprHasNonPrintfInterpolation(s.Value) || g.exprHasNonPrintfInterpolation(s.Channel) {
 			return true
 		}
+	case *ast.FailStmt:
+		if g.exprHasNonPrintfInterpolation(s.Message) || (s.Code != nil && g.exprHasNonPrintfInterpolation(s.Code)) {
+			return true
+		}
+	case *ast.RequireStmt:
+		if g.exprHasNonPrintfInterpolation(s.Condition) {
+			return true
+		}
+		if s.Else != nil && g.blockHasNonPrintfInterpolation(s.Else) {
+			return true
+		}
 	}
 	return false
 }
@@ -645,6 +353,12 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 		return false
 	case *ast.BinaryExpr:
 		return g.exprHasNonPrintfInterpolation(e.Left) || g.exprHasNonPrintfInterpolation(e.Right)
+	case *ast.ComparisonChainExpr:
+		return slices.ContainsFunc(e.Operands, g.exprHasNonPrintfInterpolation)
+	case *ast.RangeCheckExpr:
+		return g.exprHasNonPrintfInterpolation(e.Value) || g.exprHasNonPrintfInterpolation(e.Low) || g.exprHasNonPrintfInterpolation(e.High)
+	case *ast.WhenPattern:
+		return g.exprHasNonPrintfInterpolation(e.Low) || g.exprHasNonPrintfInterpolation(e.Value)
 	case *ast.UnaryExpr:
 		return g.exprHasNonPrintfInterpolation(e.Right)
 	case *ast.CallExpr:
@@ -683,10 +397,14 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 		return g.exprHasNonPrintfInterpolation(e.Object)
 	case *ast.PipeExpr:
 		return g.exprHasNonPrintfInterpolation(e.Left) || g.exprHasNonPrintfInterpolation(e.Right)
+	case *ast.ParallelPipeExpr:
+		return g.exprHasNonPrintfInterpolation(e.Left) || g.exprHasNonPrintfInterpolation(e.Right)
 	case *ast.ErrorExpr:
 		return g.exprHasNonPrintfInterpolation(e.Message)
 	case *ast.PanicExpr:
 		return g.exprHasNonPrintfInterpolation(e.Message)
+	case *ast.OnErrExpr:
+		return g.exprHasNonPrintfInterpolation(e.Expression) || g.exprHasNonPrintfInterpolation(e.OnErr.Handler)
 	case *ast.ReturnExpr:
 		if slices.ContainsFunc(e.Values, g.exprHasNonPrintfInterpolation) {
 			return true
@@ -699,6 +417,8 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 		return g.exprHasNonPrintfInterpolation(e.Channel)
 	case *ast.ReceiveExpr:
 		return g.exprHasNonPrintfInterpolation(e.Channel)
+	case *ast.WaitExpr:
+		return g.exprHasNonPrintfInterpolation(e.Channel) || g.exprHasNonPrintfInterpolation(e.Timeout)
 	case *ast.IndexExpr:
 		return g.exprHasNonPrintfInterpolation(e.Left) || g.exprHasNonPrintfInterpolation(e.Index)
 	case *ast.SliceExpr:
@@ -750,6 +470,8 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 		if e.Body != nil {
 			return g.blockHasNonPrintfInterpolation(e.Body)
 		}
+	case *ast.BuildStringExpr:
+		return g.blockHasNonPrintfInterpolation(e.Body)
 	case *ast.PipedSwitchExpr:
 		if g.exprHasNonPrintfInterpolation(e.Left) {
 			return true
@@ -760,6 +482,9 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 				if slices.ContainsFunc(c.Values, g.exprHasNonPrintfInterpolation) {
 					return true
 				}
+				if c.Guard != nil && g.exprHasNonPrintfInterpolation(c.Guard) {
+					return true
+				}
 				if c.Body != nil && g.blockHasNonPrintfInterpolation(c.Body) {
 					return true
 				}
@@ -781,23 +506,29 @@ func (g *Generator) exprHasNonPrintfInterpolation(expr ast.Expression) bool {
 	return false
 }
 
-// needsPrintBuiltin returns true if any call to the print() builtin exists in
-// the program.
+// needsPrintBuiltin returns true if any call to the print() or write()
+// builtin exists in the program.
 func (g *Generator) needsPrintBuiltin() bool {
 	return g.walkProgram(func(e ast.Expression) bool {
 		call, ok := e.(*ast.CallExpr)
 		if !ok {
 			return false
 		}
-		id, ok := call.Function.(*ast.Identifier)
-		return ok && id.Value == "print"
+		_, ok = g.printBuiltinFunc(call.Function)
+		return ok
 	})
 }
 
-// needsErrorsPackage returns true if any error() expression that generates a call
-// to errors.New is used in the program. Interpolated error expressions use
-// fmt.Errorf instead, so they do not require the errors package.
+// needsErrorsPackage returns true if the program declares sentinel errors or
+// uses an error() expression that generates a call to errors.New. Interpolated
+// error expressions use fmt.Errorf instead, so they do not require the errors
+// package.
 func (g *Generator) needsErrorsPackage() bool {
+	for _, decl := range g.program.Declarations {
+		if _, ok := decl.(*ast.ErrorDecl); ok {
+			return true
+		}
+	}
 	return g.walkProgram(func(e ast.Expression) bool {
 		errExpr, ok := e.(*ast.ErrorExpr)
 		if !ok {
@@ -0,0 +1,216 @@
+package codegen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// FormatGo is the post-pass over the Generator's output. The Generator still
+// writes Go source as text; FormatGo parses that text into a go/ast tree, drops
+// the defensive parentheses the emitter wraps around every binary expression
+// (e.g. `return (a + b)` becomes `return a + b`), and prints the result with the
+// standard gofmt layout. Parsing the output also checks it is syntactically
+// valid Go: any error here is a codegen bug, not a user error, so callers
+// report it rather than falling back to the unformatted text.
+func FormatGo(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	simplifyParens(file)
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// simplifyParens removes parentheses that do not affect how an expression
+// parses. Only positions where removal is provably safe are rewritten:
+// full-expression slots (return values, assignment right-hand sides, call
+// arguments, ...) and binary operands whose precedence already binds tighter.
+// Statements in an if, for or switch header keep the parens around composite
+// literals, as the header's condition does.
+func simplifyParens(file *ast.File) {
+	header := map[ast.Stmt]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			stripAll(n.Results)
+		case *ast.AssignStmt:
+			if header[n] {
+				for i, e := range n.Rhs {
+					n.Rhs[i] = stripControlClause(e)
+				}
+			} else {
+				stripAll(n.Rhs)
+			}
+		case *ast.ValueSpec:
+			stripAll(n.Values)
+		case *ast.CallExpr:
+			stripAll(n.Args)
+		case *ast.SendStmt:
+			if header[n] {
+				n.Value = stripControlClause(n.Value)
+			} else {
+				n.Value = stripParens(n.Value)
+			}
+		case *ast.CaseClause:
+			stripAll(n.List)
+		case *ast.KeyValueExpr:
+			n.Value = stripParens(n.Value)
+		case *ast.CompositeLit:
+			stripAll(n.Elts)
+		case *ast.IndexExpr:
+			n.Index = stripParens(n.Index)
+		case *ast.IfStmt:
+			header[n.Init] = true
+			n.Cond = stripControlClause(n.Cond)
+		case *ast.ForStmt:
+			header[n.Init] = true
+			header[n.Post] = true
+			n.Cond = stripControlClause(n.Cond)
+		case *ast.SwitchStmt:
+			header[n.Init] = true
+			n.Tag = stripControlClause(n.Tag)
+		case *ast.TypeSwitchStmt:
+			header[n.Init] = true
+		case *ast.UnaryExpr:
+			if isPrimary(unwrapParens(n.X)) {
+				n.X = unwrapParens(n.X)
+			}
+		case *ast.BinaryExpr:
+			prec := n.Op.Precedence()
+			// Go binary operators are left-associative: a left operand may
+			// drop its parens at equal precedence, a right operand may not.
+			if inner, ok := unwrapParens(n.X).(*ast.BinaryExpr); ok && inner.Op.Precedence() >= prec {
+				n.X = inner
+			} else if isPrimary(unwrapParens(n.X)) {
+				n.X = unwrapParens(n.X)
+			}
+			if inner, ok := unwrapParens(n.Y).(*ast.BinaryExpr); ok && inner.Op.Precedence() > prec {
+				n.Y = inner
+			} else if isPrimary(unwrapParens(n.Y)) {
+				n.Y = unwrapParens(n.Y)
+			}
+		}
+		return true
+	})
+}
+
+func stripAll(exprs []ast.Expr) {
+	for i, e := range exprs {
+		exprs[i] = stripParens(e)
+	}
+}
+
+// stripParens removes parens around a value in a full-expression slot.
+// Parenthesized types are kept: `(*T)` or `(func())` may be load-bearing.
+func stripParens(e ast.Expr) ast.Expr {
+	inner := unwrapParens(e)
+	switch inner.(type) {
+	case *ast.StarExpr, *ast.FuncType, *ast.ChanType, *ast.ArrayType, *ast.MapType, *ast.InterfaceType, *ast.StructType:
+		return e
+	}
+	return inner
+}
+
+// stripControlClause is stripParens for if/for/switch headers, where an
+// unparenthesized composite literal would be parsed as the statement's block.
+func stripControlClause(e ast.Expr) ast.Expr {
+	if e == nil || containsCompositeLit(e) {
+		return e
+	}
+	return stripParens(e)
+}
+
+func unwrapParens(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// isPrimary reports whether e binds at least as tightly as any operator, so
+// wrapping parens never change its meaning.
+func isPrimary(e ast.Expr) bool {
+	switch e.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.CallExpr, *ast.SelectorExpr,
+		*ast.IndexExpr, *ast.IndexListExpr, *ast.SliceExpr, *ast.TypeAssertExpr:
+		return true
+	}
+	return false
+}
+
+func containsCompositeLit(e ast.Expr) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if _, ok := n.(*ast.CompositeLit); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// dropUnusedImport removes the import of path from src when no selector in
+// the file refers to it. Codegen rewrites such as slice pipeline fusion can
+// replace every call into a package the user imported; Go rejects the
+// leftover import. src is returned unchanged if it does not parse.
+func dropUnusedImport(src, path string) string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return src
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if strings.Trim(imp.Path.Value, `"`) != path {
+				continue
+			}
+			name := path[strings.LastIndex(path, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if name == "_" || usesPackage(file, name) {
+				return src
+			}
+			var start, end token.Pos = imp.Pos(), imp.End()
+			if len(gen.Specs) == 1 {
+				start, end = gen.Pos(), gen.End()
+			}
+			return src[:fset.Position(start).Offset] + src[fset.Position(end).Offset:]
+		}
+	}
+	return src
+}
+
+// usesPackage reports whether any selector in file is qualified by name.
+func usesPackage(file *ast.File, name string) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
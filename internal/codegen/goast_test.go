@@ -0,0 +1,185 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGoDropsRedundantParens(t *testing.T) {
+	src := `package main
+
+func f(a, b, c int, ok bool) (int, bool) {
+	x := (a + b)
+	y := ((a + b) * c)
+	z := (a - (b - c))
+	w := (a * (b + c))
+	if (a > b) && ok {
+		return (x + y), (z == w)
+	}
+	return g((a + b)), !(a == b)
+}
+
+func g(n int) int { return n }
+`
+	out, err := FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		"x := a + b",
+		"y := (a + b) * c",
+		"z := a - (b - c)",
+		"w := a * (b + c)",
+		"if a > b && ok {",
+		"return x + y, z == w",
+		"return g(a + b), !(a == b)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatGoKeepsCompositeLitParensInControlClause(t *testing.T) {
+	src := `package main
+
+type P struct{ X int }
+
+func f(p P) bool {
+	if (p == P{X: 1}) {
+		return true
+	}
+	return false
+}
+`
+	out, err := FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	if !strings.Contains(string(out), "if (p == P{X: 1}) {") {
+		t.Errorf("expected composite literal parens to be kept, got:\n%s", out)
+	}
+}
+
+func TestFormatGoDropsCaseClauseParens(t *testing.T) {
+	src := `package main
+
+func f(x int) string {
+	switch {
+	case (1 <= x && x < 9), (x == 20):
+		return "in"
+	}
+	switch x {
+	case (1 + 2):
+		return "three"
+	}
+	return "out"
+}
+`
+	out, err := FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{"case 1 <= x && x < 9, x == 20:", "case 1 + 2:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatGoKeepsCompositeLitParensInHeaderStatements(t *testing.T) {
+	src := `package main
+
+type P struct{ X int }
+
+func f(ps []P) int {
+	if p := (P{X: 1}); p.X > 0 {
+		return p.X
+	}
+	switch p := (P{X: 2}); p.X {
+	case 2:
+		return 2
+	}
+	for p := (P{}); p.X < 3; p = (P{X: p.X + 1}) {
+	}
+	q := (P{X: 4})
+	return q.X
+}
+`
+	out, err := FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{
+		"if p := (P{X: 1}); p.X > 0 {",
+		"switch p := (P{X: 2}); p.X {",
+		"for p := (P{}); p.X < 3; p = (P{X: p.X + 1}) {",
+		"q := P{X: 4}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestLineDirectivesInOnErrBlockStartAtColumnOne(t *testing.T) {
+	input := `import "os"
+
+func load(path string) (string, error)
+    data := os.ReadFile(path) onerr
+        print("failed")
+        return "", error "load: {error}"
+    return data as string, empty
+`
+	output := generateSource(t, input)
+	for _, want := range []string{"\n//line test.kuki:5\n", "\n//line test.kuki:6\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q at column 1, got:\n%s", want, output)
+		}
+	}
+	formatted, err := FormatGo([]byte(output))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	if strings.Contains(string(formatted), "\t//line") {
+		t.Errorf("expected no indented //line directives after formatting, got:\n%s", formatted)
+	}
+}
+
+func TestFormatGoPreservesLineDirectives(t *testing.T) {
+	src := "package main\n\nfunc f(a int) int {\n//line app.kuki:3\n\treturn (a + 1)\n}\n"
+	out, err := FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo error: %v", err)
+	}
+	got := string(out)
+	if !strings.Contains(got, "//line app.kuki:3\n\treturn a + 1") {
+		t.Errorf("expected //line directive to stay attached, got:\n%s", got)
+	}
+}
+
+func TestFormatGoRejectsInvalidSource(t *testing.T) {
+	if _, err := FormatGo([]byte("package main\nfunc (")); err == nil {
+		t.Error("expected error for invalid Go source")
+	}
+}
+
+func TestDropUnusedImport(t *testing.T) {
+	src := "package main\n\nimport (\n\t\"fmt\"\n\tsl \"example.com/slice\"\n)\n\nfunc main() { fmt.Println(1) }\n"
+	out := dropUnusedImport(src, "example.com/slice")
+	if strings.Contains(out, "example.com/slice") || !strings.Contains(out, "\"fmt\"") {
+		t.Errorf("expected only the unused import to be removed, got:\n%s", out)
+	}
+	if _, err := FormatGo([]byte(out)); err != nil {
+		t.Errorf("expected valid Go after removal: %v", err)
+	}
+
+	used := "package main\n\nimport sl \"example.com/slice\"\n\nfunc main() { sl.Do() }\n"
+	if out := dropUnusedImport(used, "example.com/slice"); out != used {
+		t.Errorf("expected used import to be kept, got:\n%s", out)
+	}
+}
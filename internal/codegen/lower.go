@@ -2,6 +2,8 @@ package codegen
 
 import (
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
@@ -81,6 +83,27 @@ func (l *Lowerer) lowerPipeChain(pipe *ast.PipeExpr) (*ir.Block, string) {
 	return block, current
 }
 
+// spreadCount returns how many values src spreads into the first of the
+// remaining pipe steps, or 0 when it does not spread (or no step follows).
+func (l *Lowerer) spreadCount(src ast.Expression, rest ...ast.Expression) int {
+	if len(rest) == 0 {
+		return 0
+	}
+	n, _ := l.gen.pipeSpread(src, rest[0])
+	return n
+}
+
+// spreadTemps unpacks a multi-value expression into n fresh temps and returns
+// them as an argument list ("pipe_1, pipe_2") for the next piped call.
+func (l *Lowerer) spreadTemps(block *ir.Block, expr string, n int) string {
+	temps := make([]string, n)
+	for i := range temps {
+		temps[i] = l.uniqueId("pipe")
+	}
+	block.Add(&ir.Assign{Names: temps, Expr: expr, Walrus: true})
+	return strings.Join(temps, ", ")
+}
+
 // ---------- Phase 2: onerr on simple (non-pipe) expressions ----------
 
 // lowerOnErr produces IR for a single expression + onerr clause.
@@ -108,6 +131,10 @@ func (l *Lowerer) lowerOnErr(expr string, clause *ast.OnErrClause, names []strin
 // lowerOnErrHandler produces the IR body for an if-err-check block.
 func (l *Lowerer) lowerOnErrHandler(clause *ast.OnErrClause, names []string, errVar string) *ir.Block {
 	body := &ir.Block{}
+	l.annotate(body, "%s", explainOnErr(clause, errVar))
+	if clause.Explain == "" {
+		l.logOnErr(body, clause, errVar)
+	}
 
 	if clause.ShorthandReturn {
 		// onerr return (bare) — propagate error with zero values
@@ -133,6 +160,7 @@ func (l *Lowerer) lowerOnErrHandler(clause *ast.OnErrClause, names []string, err
 			Expr:   fmt.Sprintf(`fmt.Errorf("%s: %%w", %s)`, clause.Explain, errVar),
 			Walrus: false,
 		})
+		l.logOnErr(body, clause, errVar)
 		if clause.Handler == nil {
 			// Standalone explain: emit return
 			body.AddAll(l.buildReturnNode(errVar))
@@ -149,6 +177,34 @@ func (l *Lowerer) lowerOnErrHandler(clause *ast.OnErrClause, names []string, err
 	return body
 }
 
+// logOnErr adds the log line of SetOnErrLog to a handler body, before the
+// handler runs and after any explain wrapping:
+//
+//	slog.Error("onerr", "function", "load", "at", "config.kuki:12", "explain", "reading config", "error", err_1)
+//
+// The error is left out on the goto paths of piped switches (errVar ""),
+// where the failed step's error is no longer in scope.
+func (l *Lowerer) logOnErr(body *ir.Block, clause *ast.OnErrClause, errVar string) {
+	if !l.gen.logsOnErr() {
+		return
+	}
+	l.gen.addImport("log/slog")
+	file := clause.Token.File()
+	if file == "" {
+		file = l.gen.sourceFile
+	}
+	at := fmt.Sprintf("%s:%d", filepath.Base(file), clause.Token.Line())
+	args := []string{`"onerr"`, `"function"`, strconv.Quote(l.gen.currentFuncName), `"at"`, strconv.Quote(at)}
+	if clause.Explain != "" {
+		// Explain text is already escaped for a Go string, as in the fmt.Errorf wrapping
+		args = append(args, `"explain"`, `"`+clause.Explain+`"`)
+	}
+	if errVar != "" {
+		args = append(args, `"error"`, errVar)
+	}
+	body.Add(&ir.ExprStmt{Expr: fmt.Sprintf("%s.Error(%s)", l.gen.importedName("log/slog"), strings.Join(args, ", "))})
+}
+
 // renderHandler captures the output of generateOnErrHandler into a string.
 // It is the single point that sets currentOnErrVar and currentOnErrAlias,
 // ensuring exprToString resolves "error" / alias identifiers to errVar
@@ -209,12 +265,15 @@ func (l *Lowerer) lowerOnErrPipeChain(pipe *ast.PipeExpr, clause *ast.OnErrClaus
 	}
 
 	block := &ir.Block{}
+	l.annotate(block, "pipe with onerr: each step that returns an error gets its own temp and check; the other steps nest as calls")
 
 	// Start with the base as an expression string, not a temp variable.
 	// Only materialize to a temp if the base is multi-return (needs error check).
 	current := l.gen.exprToString(base)
 
-	if count, ok := l.gen.inferReturnCount(base); ok && count >= 2 {
+	if n := l.spreadCount(base, steps...); n > 0 {
+		current = l.spreadTemps(block, current, n)
+	} else if count, ok := l.gen.inferReturnCount(base); ok && count >= 2 {
 		tempVar := l.uniqueId("pipe")
 		// If all steps are error-only (no error-returning steps), base is the
 		// last value producer — use targetName if available.
@@ -243,7 +302,10 @@ func (l *Lowerer) lowerOnErrPipeChain(pipe *ast.PipeExpr, clause *ast.OnErrClaus
 			return nil, ""
 		}
 
-		if count, ok := l.gen.inferReturnCount(step); ok && count >= 2 {
+		if n := l.spreadCount(step, steps[i+1:]...); n > 0 {
+			// Multi-value step spread into the next call: unpack, no error check.
+			current = l.spreadTemps(block, callExpr, n)
+		} else if count, ok := l.gen.inferReturnCount(step); ok && count >= 2 {
 			// Error-returning step: must materialize to temp + error check.
 			next := l.uniqueId("pipe")
 			if targetName != "" && i == lastErrStep {
@@ -279,11 +341,14 @@ func (l *Lowerer) lowerOnErrPipeChainWithLabels(pipe *ast.PipeExpr, onErrLabel s
 
 	block := &ir.Block{}
 	gotoErr := &ir.Block{Nodes: []ir.Node{&ir.Goto{Label: onErrLabel}}}
+	l.annotate(block, "piped switch with onerr: each step that returns an error jumps to %s, where the onerr handler runs once", onErrLabel)
 
 	// Start with the base as an expression, materialize only if multi-return.
 	current := l.gen.exprToString(base)
 
-	if count, ok := l.gen.inferReturnCount(base); ok && count >= 2 {
+	if n := l.spreadCount(base, steps...); n > 0 {
+		current = l.spreadTemps(block, current, n)
+	} else if count, ok := l.gen.inferReturnCount(base); ok && count >= 2 {
 		tempVar := l.uniqueId("pipe")
 		errVar := l.uniqueId("err")
 		block.Add(&ir.Assign{Names: []string{tempVar, errVar}, Expr: current, Walrus: true})
@@ -291,13 +356,15 @@ func (l *Lowerer) lowerOnErrPipeChainWithLabels(pipe *ast.PipeExpr, onErrLabel s
 		current = tempVar
 	}
 
-	for _, step := range steps {
+	for i, step := range steps {
 		callExpr, ok := l.gen.generatePipedStepCall(step, current)
 		if !ok {
 			return nil, ""
 		}
 
-		if count, ok := l.gen.inferReturnCount(step); ok && count >= 2 {
+		if n := l.spreadCount(step, steps[i+1:]...); n > 0 {
+			current = l.spreadTemps(block, callExpr, n)
+		} else if count, ok := l.gen.inferReturnCount(step); ok && count >= 2 {
 			next := l.uniqueId("pipe")
 			errVar := l.uniqueId("err")
 			block.Add(&ir.Assign{Names: []string{next, errVar}, Expr: callExpr, Walrus: true})
@@ -371,6 +438,9 @@ func (l *Lowerer) lowerPipedSwitchVarDecl(varName string, ps *ast.PipedSwitchExp
 	if handlerDefault == "" {
 		handlerBlock := l.lowerOnErrHandler(clause, identNames(names), "")
 		block.AddAll(handlerBlock)
+	} else {
+		// The result already holds the default; only the log line is left
+		l.logOnErr(block, clause, "")
 	}
 
 	block.Add(&ir.Label{Name: endLabel})
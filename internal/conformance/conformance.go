@@ -0,0 +1,315 @@
+// Package conformance runs .kuki programs end to end and compares what they
+// do with golden files, so a change to the compiler cannot silently change
+// how existing code is lowered. `kukicha selftest` and this package's tests
+// run the built-in corpus (Corpus); users can keep their own regression cases
+// from bug reports in a directory and run those too.
+//
+// A case is name.kuki next to name.golden. The golden file holds the case's
+// transcript:
+//
+//   - for a program that compiles, its standard output, followed by an
+//     "exit N" line when it exits with a non-zero status;
+//   - for one that does not, its parse or semantic errors, one "error: "
+//     line each, with positions in name.kuki;
+//   - "vet: " or "build: " lines when go vet or go build rejects the
+//     generated Go, which is always a codegen bug.
+//
+// Each case is compiled like `kukicha build` (without auto-import), written
+// as main.go into a temporary module, vetted, built and run with a timeout
+// and no stdin. Cases may import Go packages but not the Kukicha stdlib,
+// which needs a project.
+//
+// WriteGolden records a case's current transcript; review the diff before
+// committing it.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duber000/kukicha/internal/codegen"
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+//go:embed corpus
+var corpus embed.FS
+
+// Corpus returns the built-in cases.
+func Corpus() fs.FS {
+	sub, err := fs.Sub(corpus, "corpus")
+	if err != nil {
+		panic(err) // The directory is embedded
+	}
+	return sub
+}
+
+// DefaultTimeout bounds how long one case's program may run.
+const DefaultTimeout = 10 * time.Second
+
+// Case is one program of a corpus.
+type Case struct {
+	Name      string // File name without .kuki
+	Source    string
+	Golden    string
+	HasGolden bool
+}
+
+// Result is what running a case produced.
+type Result struct {
+	Case       Case
+	Transcript string
+	Err        error // The case could not be run (no go toolchain, timeout, ...)
+}
+
+// Passed reports whether the transcript matches the golden file.
+func (r Result) Passed() bool {
+	return r.Err == nil && r.Case.HasGolden && r.Transcript == r.Case.Golden
+}
+
+// Options control RunAll.
+type Options struct {
+	Timeout  time.Duration // Per case; DefaultTimeout when zero
+	Parallel int           // Cases run at once; runtime.NumCPU() when zero
+}
+
+// Load reads the cases at the top level of fsys, sorted by name.
+func Load(fsys fs.FS) ([]Case, error) {
+	paths, err := fs.Glob(fsys, "*.kuki")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	cases := make([]Case, 0, len(paths))
+	for _, path := range paths {
+		source, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		c := Case{Name: strings.TrimSuffix(path, ".kuki"), Source: string(source)}
+		golden, err := fs.ReadFile(fsys, c.Name+".golden")
+		switch {
+		case err == nil:
+			c.Golden, c.HasGolden = string(golden), true
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+// Select returns the cases whose name matches pattern (all of them when
+// pattern is empty).
+func Select(cases []Case, pattern string) ([]Case, error) {
+	if pattern == "" {
+		return cases, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var out []Case
+	for _, c := range cases {
+		if re.MatchString(c.Name) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// RunAll runs cases concurrently and returns their results in order.
+func RunAll(cases []Case, opts Options) []Result {
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	results := make([]Result, len(cases))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, c := range cases {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			results[i] = Run(c, opts.Timeout)
+			<-sem
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Run compiles and runs one case.
+func Run(c Case, timeout time.Duration) Result {
+	transcript, err := Transcript(c.Name, c.Source, timeout)
+	return Result{Case: c, Transcript: transcript, Err: err}
+}
+
+// Transcript compiles source as name.kuki and, if it compiles, vets, builds
+// and runs it. The error is for failures of the harness, not of the case.
+func Transcript(name, source string, timeout time.Duration) (string, error) {
+	goCode, diags := compile(name+".kuki", source)
+	if len(diags) > 0 {
+		return prefixLines("error: ", strings.Join(diags, "\n")), nil
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	dir, err := os.MkdirTemp("", "kukicha-conformance-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module conformance\n\ngo 1.26\n"), 0644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), goCode, 0644); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	goTool := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(ctx, "go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+		out, err := cmd.CombinedOutput()
+		return strings.ReplaceAll(strings.TrimSpace(string(out)), dir+string(filepath.Separator), ""), err
+	}
+	if out, err := goTool("vet", "."); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok || ctx.Err() != nil {
+			return "", fmt.Errorf("go vet: %v", err)
+		}
+		return prefixLines("vet: ", out), nil
+	}
+	binary := filepath.Join(dir, "prog")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	if out, err := goTool("build", "-o", binary, "."); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok || ctx.Err() != nil {
+			return "", fmt.Errorf("go build: %v", err)
+		}
+		return prefixLines("build: ", out), nil
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("%s did not finish within %v", name, timeout)
+	}
+	transcript := stdout.String()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		transcript += fmt.Sprintf("exit %d\n", exitErr.ExitCode())
+	} else if err != nil {
+		return "", err
+	}
+	return transcript, nil
+}
+
+// compile runs the compiler pipeline on one file and returns the formatted
+// Go, or the errors that stopped it.
+func compile(filename, source string) ([]byte, []string) {
+	p, err := parser.New(source, filename)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		return nil, errorStrings(parseErrors)
+	}
+	analyzer := semantic.NewWithFile(program, filename)
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		return nil, errorStrings(errs)
+	}
+
+	gen := codegen.New(program)
+	gen.SetSourceFile(filename)
+	gen.SetExprReturnCounts(analyzer.ReturnCounts())
+	gen.SetExprTypes(analyzer.ExprTypes())
+	gen.SetTypeAnnotationTypes(analyzer.TypeAnnotationTypes())
+	gen.SetCaptures(analyzer.Captures())
+	gen.SetConstants(analyzer.Constants())
+	goCode, err := gen.Generate()
+	if err != nil {
+		return nil, []string{"code generation: " + err.Error()}
+	}
+	formatted, err := codegen.FormatGo([]byte(goCode))
+	if err != nil {
+		return nil, []string{"generated Go does not parse: " + err.Error()}
+	}
+	return formatted, nil
+}
+
+func errorStrings(errs []error) []string {
+	out := make([]string, len(errs))
+	for i, err := range errs {
+		out[i] = err.Error()
+	}
+	return out
+}
+
+// prefixLines puts prefix before every line of text and ends it with a
+// newline.
+func prefixLines(prefix, text string) string {
+	var b strings.Builder
+	for line := range strings.SplitSeq(text, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+	return b.String()
+}
+
+// WriteGolden writes r's transcript to name.golden in dir.
+func WriteGolden(dir string, r Result) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return os.WriteFile(filepath.Join(dir, r.Case.Name+".golden"), []byte(r.Transcript), 0644)
+}
+
+// Diff describes how got differs from want: the first line that differs,
+// with a line of context before it.
+func Diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		w, g := lineAt(wantLines, i), lineAt(gotLines, i)
+		if w == g {
+			continue
+		}
+		var b strings.Builder
+		if i > 0 {
+			fmt.Fprintf(&b, "  %d:   %s\n", i, wantLines[i-1])
+		}
+		fmt.Fprintf(&b, "  %d: - %s\n", i+1, w)
+		fmt.Fprintf(&b, "  %d: + %s\n", i+1, g)
+		return b.String()
+	}
+	return ""
+}
+
+// lineAt returns lines[i], or "<end>" past the last line.
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return "<end>"
+}
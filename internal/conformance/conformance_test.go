@@ -0,0 +1,122 @@
+package conformance
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var update = flag.Bool("update", false, "rewrite the corpus golden files with the current transcripts")
+
+// TestCorpus runs the built-in corpus. After an intended change to a
+// lowering, run `go test ./internal/conformance -update` and review the
+// golden diff.
+func TestCorpus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("vets, builds and runs every case")
+	}
+	cases, err := Load(os.DirFS("corpus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("no cases in corpus")
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			r := Run(c, 0)
+			if r.Err != nil {
+				t.Fatal(r.Err)
+			}
+			if *update {
+				if err := WriteGolden("corpus", r); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !c.HasGolden {
+				t.Fatalf("missing corpus/%s.golden (run with -update to record it)", c.Name)
+			}
+			if !r.Passed() {
+				t.Errorf("transcript differs from corpus/%s.golden:\n%s", c.Name, Diff(c.Golden, r.Transcript))
+			}
+		})
+	}
+}
+
+func TestCorpusIsEmbedded(t *testing.T) {
+	embedded, err := Load(Corpus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	onDisk, err := Load(os.DirFS("corpus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(embedded) != len(onDisk) {
+		t.Errorf("expected %d embedded cases, got %d", len(onDisk), len(embedded))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b.kuki":     {Data: []byte("func main()\n    print(1)\n")},
+		"b.golden":   {Data: []byte("1\n")},
+		"a.kuki":     {Data: []byte("func main()\n    print(2)\n")},
+		"notes.txt":  {Data: []byte("not a case")},
+		"c.golden":   {Data: []byte("orphan")},
+		"sub/d.kuki": {Data: []byte("func main()\n")},
+	}
+	cases, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || cases[0].Name != "a" || cases[1].Name != "b" {
+		t.Fatalf("expected cases a and b, got %+v", cases)
+	}
+	if cases[0].HasGolden || !cases[1].HasGolden || cases[1].Golden != "1\n" {
+		t.Errorf("expected only b to have a golden file, got %+v", cases)
+	}
+
+	selected, err := Select(cases, "^b$")
+	if err != nil || len(selected) != 1 || selected[0].Name != "b" {
+		t.Errorf("expected Select to keep b, got %+v (%v)", selected, err)
+	}
+	if _, err := Select(cases, "("); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestTranscriptCompileErrors(t *testing.T) {
+	transcript, err := Transcript("bad", "func main()\n    x := 1\n    x = \"text\"\n", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "error: bad.kuki:3:6: cannot assign string to int\n"; transcript != want {
+		t.Errorf("expected %q, got %q", want, transcript)
+	}
+
+	transcript, err = Transcript("syntax", "func main(\n", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(transcript, "error: syntax.kuki:") {
+		t.Errorf("expected parse errors, got %q", transcript)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if d := Diff("a\nb\n", "a\nb\n"); d != "" {
+		t.Errorf("expected no diff, got %q", d)
+	}
+	want := "  1:   a\n  2: - b\n  2: + c\n"
+	if d := Diff("a\nb\n", "a\nc\n"); d != want {
+		t.Errorf("expected %q, got %q", want, d)
+	}
+	if d := Diff("a\n", "a\nextra\n"); !strings.Contains(d, "- \n") || !strings.Contains(d, "+ extra") {
+		t.Errorf("expected an added line, got %q", d)
+	}
+}
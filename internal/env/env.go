@@ -0,0 +1,64 @@
+// Package env expands ${VAR} references in build settings: import paths,
+// the `# target:` pragma and kukicha.toml values. Expansion happens when a
+// program is built, so build scripts can parameterize module bases and
+// paths without wrapping the CLI.
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Expand replaces each ${NAME} in s with the value of environment variable
+// NAME. An unset variable is an error, naming the variable; a variable set
+// to the empty string expands to "". A $ not followed by { is kept as is.
+func Expand(s string) (string, error) {
+	return ExpandFunc(s, os.LookupEnv)
+}
+
+// ExpandFunc is Expand with lookup in place of os.LookupEnv.
+func ExpandFunc(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated ${ in %q", s)
+		}
+		name := s[start+2 : start+end]
+		if !validName(name) {
+			return "", fmt.Errorf("invalid environment variable name %q", name)
+		}
+		value, ok := lookup(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[start+end+1:]
+	}
+}
+
+// validName reports whether name is a shell-style variable name.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package env
+
+import "testing"
+
+func TestExpandFunc(t *testing.T) {
+	vars := map[string]string{"BASE": "example.com/app", "EMPTY": "", "V_2": "v2"}
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	for in, want := range map[string]string{
+		"${BASE}/internal/db": "example.com/app/internal/db",
+		"bin/${V_2}${EMPTY}":  "bin/v2",
+		"cost $5 and $HOME":   "cost $5 and $HOME",
+		"no variables":        "no variables",
+	} {
+		got, err := ExpandFunc(in, lookup)
+		if err != nil || got != want {
+			t.Errorf("ExpandFunc(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	for in, want := range map[string]string{
+		"${MISSING}/x": "environment variable MISSING is not set",
+		"${BASE":       `unterminated ${ in "${BASE"`,
+		"${1X}":        `invalid environment variable name "1X"`,
+		"${}":          `invalid environment variable name ""`,
+	} {
+		if _, err := ExpandFunc(in, lookup); err == nil || err.Error() != want {
+			t.Errorf("ExpandFunc(%q) error = %v; want %q", in, err, want)
+		}
+	}
+}
@@ -30,8 +30,8 @@ func ExtractComments(tokens []lexer.Token) []Comment {
 		if tok.Type == lexer.TOKEN_COMMENT {
 			comments = append(comments, Comment{
 				Text:   tok.Lexeme,
-				Line:   tok.Line,
-				Column: tok.Column,
+				Line:   tok.Line(),
+				Column: tok.Column(),
 			})
 		}
 	}
@@ -103,16 +103,30 @@ func collectDeclLines(decl ast.Declaration, lines map[int]bool) {
 	case *ast.TypeDecl:
 		if d.AliasType == nil {
 			for _, field := range d.Fields {
-				lines[field.Name.Token.Line] = true
+				lines[field.Name.Pos().Line] = true
 			}
 		}
 	case *ast.InterfaceDecl:
 		for _, method := range d.Methods {
-			lines[method.Name.Token.Line] = true
+			lines[method.Name.Pos().Line] = true
 		}
 	case *ast.ConstDecl:
 		for _, spec := range d.Specs {
-			lines[spec.Name.Token.Line] = true
+			lines[spec.Name.Pos().Line] = true
+		}
+	case *ast.ErrorDecl:
+		for _, spec := range d.Specs {
+			lines[spec.Name.Pos().Line] = true
+		}
+	case *ast.TargetDecl:
+		for _, inner := range d.Declarations {
+			collectDeclLines(inner, lines)
+		}
+		if d.Otherwise != nil {
+			lines[d.OtherwiseTok.Line()] = true
+			for _, inner := range d.Otherwise {
+				collectDeclLines(inner, lines)
+			}
 		}
 	}
 }
@@ -143,6 +157,8 @@ func collectStmtLines(stmt ast.Statement, lines map[int]bool) {
 		collectBlockLines(s.Body, lines)
 	case *ast.ForConditionStmt:
 		collectBlockLines(s.Body, lines)
+	case *ast.RequireStmt:
+		collectBlockLines(s.Else, lines)
 	case *ast.SwitchStmt:
 		for _, c := range s.Cases {
 			collectBlockLines(c.Body, lines)
@@ -157,9 +173,65 @@ func collectStmtLines(stmt ast.Statement, lines map[int]bool) {
 		if s.Otherwise != nil {
 			collectBlockLines(s.Otherwise.Body, lines)
 		}
+	case *ast.TargetStmt:
+		collectBlockLines(s.Body, lines)
+		if s.Otherwise != nil {
+			lines[s.Otherwise.Token.Line()] = true
+			collectBlockLines(s.Otherwise.Body, lines)
+		}
+	default:
+		if block := buildStringBody(stmt); block != nil {
+			collectBlockLines(block, lines)
+		}
+		if block := onErrBlock(stmt); block != nil {
+			collectBlockLines(block, lines)
+		}
 	}
 }
 
+// buildStringBody returns the body of a `build string` block that ends stmt,
+// or nil.
+func buildStringBody(stmt ast.Statement) *ast.BlockStmt {
+	var values []ast.Expression
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		values = s.Values
+	case *ast.AssignStmt:
+		values = s.Values
+	case *ast.ReturnStmt:
+		values = s.Values
+	case *ast.ExpressionStmt:
+		values = []ast.Expression{s.Expression}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if e, ok := values[len(values)-1].(*ast.BuildStringExpr); ok {
+		return e.Body
+	}
+	return nil
+}
+
+// onErrBlock returns the body of stmt's onerr block handler, or nil.
+func onErrBlock(stmt ast.Statement) *ast.BlockStmt {
+	var clause *ast.OnErrClause
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		clause = s.OnErr
+	case *ast.AssignStmt:
+		clause = s.OnErr
+	case *ast.ExpressionStmt:
+		clause = s.OnErr
+	}
+	if clause == nil {
+		return nil
+	}
+	if block, ok := clause.Handler.(*ast.BlockExpr); ok {
+		return block.Body
+	}
+	return nil
+}
+
 func attachCommentsToProgram(comments []Comment, program *ast.Program, cm CommentMap) {
 	commentIdx := 0
 
@@ -272,17 +344,37 @@ func attachCommentsToDecl(comments []Comment, idx *int, decl ast.Declaration, cm
 	case *ast.TypeDecl:
 		if d.AliasType == nil {
 			for _, field := range d.Fields {
-				fieldLine := field.Name.Token.Line
+				fieldLine := field.Name.Pos().Line
 				*idx = attachLeadingComments(comments, *idx, fieldLine, field.Name, cm)
 				*idx = attachTrailingComment(comments, *idx, fieldLine, field.Name, cm)
 			}
 		}
 	case *ast.InterfaceDecl:
 		for _, method := range d.Methods {
-			methodLine := method.Name.Token.Line
+			methodLine := method.Name.Pos().Line
 			*idx = attachLeadingComments(comments, *idx, methodLine, method.Name, cm)
 			*idx = attachTrailingComment(comments, *idx, methodLine, method.Name, cm)
 		}
+	case *ast.ErrorDecl:
+		for _, spec := range d.Specs {
+			specLine := spec.Name.Pos().Line
+			*idx = attachLeadingComments(comments, *idx, specLine, spec.Name, cm)
+			*idx = attachTrailingComment(comments, *idx, specLine, spec.Name, cm)
+		}
+	case *ast.TargetDecl:
+		attachCommentsToDecls(comments, idx, d.Declarations, cm)
+		attachCommentsToDecls(comments, idx, d.Otherwise, cm)
+	}
+}
+
+// attachCommentsToDecls attaches comments to the declarations nested in a
+// `when target` block.
+func attachCommentsToDecls(comments []Comment, idx *int, decls []ast.Declaration, cm CommentMap) {
+	for _, decl := range decls {
+		declLine := decl.Pos().Line
+		*idx = attachLeadingComments(comments, *idx, declLine, decl, cm)
+		attachCommentsToDecl(comments, idx, decl, cm)
+		*idx = attachTrailingComment(comments, *idx, declLine, decl, cm)
 	}
 }
 
@@ -313,6 +405,8 @@ func attachCommentsToStmt(comments []Comment, idx *int, stmt ast.Statement, cm C
 		attachCommentsToBlock(comments, idx, s.Body, cm)
 	case *ast.ForConditionStmt:
 		attachCommentsToBlock(comments, idx, s.Body, cm)
+	case *ast.RequireStmt:
+		attachCommentsToBlock(comments, idx, s.Else, cm)
 	case *ast.SwitchStmt:
 		for _, c := range s.Cases {
 			attachCommentsToBlock(comments, idx, c.Body, cm)
@@ -327,5 +421,17 @@ func attachCommentsToStmt(comments []Comment, idx *int, stmt ast.Statement, cm C
 		if s.Otherwise != nil {
 			attachCommentsToBlock(comments, idx, s.Otherwise.Body, cm)
 		}
+	case *ast.TargetStmt:
+		attachCommentsToBlock(comments, idx, s.Body, cm)
+		if s.Otherwise != nil {
+			attachCommentsToBlock(comments, idx, s.Otherwise.Body, cm)
+		}
+	default:
+		if block := buildStringBody(stmt); block != nil {
+			attachCommentsToBlock(comments, idx, block, cm)
+		}
+		if block := onErrBlock(stmt); block != nil {
+			attachCommentsToBlock(comments, idx, block, cm)
+		}
 	}
 }
@@ -86,10 +86,12 @@ type PrinterWithComments struct {
 
 // NewPrinterWithComments creates a printer that includes comments
 func NewPrinterWithComments(comments CommentMap) *PrinterWithComments {
-	return &PrinterWithComments{
+	p := &PrinterWithComments{
 		Printer:  NewPrinter(),
 		comments: comments,
 	}
+	p.nestedBlock = p.printBlockWithComments
+	return p
 }
 
 // Print prints the program with comments
@@ -122,6 +124,7 @@ func (p *PrinterWithComments) Print(program *ast.Program) string {
 			p.writeLine("")
 		}
 		p.printLeadingComments(decl)
+		p.printDirectives(decl)
 		p.printDeclarationWithComments(decl)
 	}
 
@@ -136,6 +139,24 @@ func (p *PrinterWithComments) printLeadingComments(node ast.Node) {
 	}
 }
 
+// printDirectives reprints the # kuki: directives attached to decl. The
+// lexer keeps them out of the comment stream, so they are not in the
+// comment map.
+func (p *PrinterWithComments) printDirectives(decl ast.Declaration) {
+	var dirs []ast.Directive
+	switch d := decl.(type) {
+	case *ast.FunctionDecl:
+		dirs = d.Directives
+	case *ast.TypeDecl:
+		dirs = d.Directives
+	case *ast.InterfaceDecl:
+		dirs = d.Directives
+	}
+	for _, dir := range dirs {
+		p.writeLine(strings.TrimRight(dir.Token.Lexeme, " \t"))
+	}
+}
+
 func (p *PrinterWithComments) printTrailingComment(node ast.Node) {
 	if attachment, ok := p.comments[node]; ok && attachment.Trailing != nil {
 		// Trailing comments go on the same line
@@ -159,9 +180,29 @@ func (p *PrinterWithComments) printDeclarationWithComments(decl ast.Declaration)
 		p.printFunctionDeclWithComments(d)
 	case *ast.ConstDecl:
 		p.printConstDeclWithComments(d)
+	case *ast.ErrorDecl:
+		p.printErrorDeclWithComments(d)
+	case *ast.ImplementsDecl:
+		p.writeLine(p.implementsLine(d))
+		p.printTrailingComment(decl)
+	case *ast.TargetDecl:
+		p.writeLine("when target " + targetNames(d.Targets))
+		p.printTargetDecls(d.Declarations, p.printNestedDeclWithComments)
+		if d.Otherwise != nil {
+			p.writeLine("otherwise")
+			p.printTargetDecls(d.Otherwise, p.printNestedDeclWithComments)
+		}
 	}
 }
 
+// printNestedDeclWithComments prints a declaration inside a `when target`
+// block together with its comments and directives.
+func (p *PrinterWithComments) printNestedDeclWithComments(decl ast.Declaration) {
+	p.printLeadingComments(decl)
+	p.printDirectives(decl)
+	p.printDeclarationWithComments(decl)
+}
+
 func (p *PrinterWithComments) printConstDeclWithComments(decl *ast.ConstDecl) {
 	if len(decl.Specs) == 1 {
 		spec := decl.Specs[0]
@@ -178,11 +219,30 @@ func (p *PrinterWithComments) printConstDeclWithComments(decl *ast.ConstDecl) {
 	p.indentLevel--
 }
 
+func (p *PrinterWithComments) printErrorDeclWithComments(decl *ast.ErrorDecl) {
+	if len(decl.Specs) == 1 {
+		// The spec shares the declaration's line and takes its trailing comment.
+		p.writeLine("error " + p.errorSpecLine(decl.Specs[0]))
+		p.printTrailingComment(decl.Specs[0].Name)
+		return
+	}
+	p.writeLine("error")
+	p.printTrailingComment(decl)
+	p.indentLevel++
+	for _, spec := range decl.Specs {
+		p.printLeadingComments(spec.Name)
+		p.writeLine(p.errorSpecLine(spec))
+		p.printTrailingComment(spec.Name)
+	}
+	p.indentLevel--
+}
+
 func (p *PrinterWithComments) printTypeDeclWithComments(decl *ast.TypeDecl) {
 	// Type alias (e.g., type Handler func(string))
 	if decl.AliasType != nil {
-		p.writeLine(fmt.Sprintf("type %s %s", decl.Name.Value, p.typeAnnotationToString(decl.AliasType)))
+		p.writeLine(fmt.Sprintf("type %s %s%s", decl.Name.Value, p.typeAnnotationToString(decl.AliasType), distinctSuffix(decl)))
 		p.printTrailingComment(decl)
+		p.printEnumValues(decl)
 		return
 	}
 
@@ -231,14 +291,14 @@ func (p *PrinterWithComments) printFunctionDeclWithComments(decl *ast.FunctionDe
 	if decl.Receiver != nil {
 		receiverType := p.typeAnnotationToString(decl.Receiver.Type)
 		params := p.parametersToString(decl.Parameters)
-		returns := p.returnTypesToString(decl.Returns)
+		returns := p.resultsToString(decl)
 		signature = fmt.Sprintf("func %s on %s %s(%s)", decl.Name.Value, decl.Receiver.Name.Value, receiverType, params)
 		if returns != "" {
 			signature += " " + returns
 		}
 	} else {
 		params := p.parametersToString(decl.Parameters)
-		returns := p.returnTypesToString(decl.Returns)
+		returns := p.resultsToString(decl)
 		signature = fmt.Sprintf("func %s(%s)", decl.Name.Value, params)
 		if returns != "" {
 			signature += " " + returns
@@ -272,6 +332,8 @@ func (p *PrinterWithComments) printStatementWithComments(stmt ast.Statement) {
 		p.printAssignStmt(s)
 	case *ast.ReturnStmt:
 		p.printReturnStmt(s)
+	case *ast.YieldStmt:
+		p.printYieldStmt(s)
 	case *ast.IfStmt:
 		p.printIfStmtWithComments(s)
 	case *ast.SwitchStmt:
@@ -299,6 +361,31 @@ func (p *PrinterWithComments) printStatementWithComments(stmt ast.Statement) {
 		} else {
 			p.writeLine("go " + p.exprToString(s.Call))
 		}
+	case *ast.SafelyStmt:
+		p.writeLine("safely")
+		p.indentLevel++
+		for _, stmt := range s.Body.Statements {
+			p.printStatementWithComments(stmt)
+		}
+		p.indentLevel--
+		p.writeWithOnErr("", s.OnErr)
+	case *ast.FailStmt:
+		line := "fail " + p.exprToString(s.Message)
+		if s.Code != nil {
+			line += " code " + p.exprToString(s.Code)
+		}
+		p.writeLine(line)
+	case *ast.RequireStmt:
+		if line, ok := p.requireInline(s); ok {
+			p.writeLine(line)
+			break
+		}
+		p.writeLine("require " + p.exprToString(s.Condition) + " else")
+		p.indentLevel++
+		for _, stmt := range s.Else.Statements {
+			p.printStatementWithComments(stmt)
+		}
+		p.indentLevel--
 	case *ast.SendStmt:
 		channel := p.exprToString(s.Channel)
 		value := p.exprToString(s.Value)
@@ -307,8 +394,21 @@ func (p *PrinterWithComments) printStatementWithComments(stmt ast.Statement) {
 		p.writeLine("break")
 	case *ast.ContinueStmt:
 		p.writeLine("continue")
+	case *ast.FallthroughStmt:
+		p.writeLine("continue to next")
 	case *ast.ExpressionStmt:
-		p.writeLine(p.exprToString(s.Expression))
+		p.writeWithOnErr(p.exprToString(s.Expression), s.OnErr)
+	case *ast.TargetStmt:
+		p.writeLine("when target " + targetNames(s.Targets))
+		p.indentLevel++
+		p.printBlockWithComments(s.Body)
+		p.indentLevel--
+		if s.Otherwise != nil {
+			p.writeLine("otherwise")
+			p.indentLevel++
+			p.printBlockWithComments(s.Otherwise.Body)
+			p.indentLevel--
+		}
 	}
 }
 
@@ -411,7 +511,11 @@ func (p *PrinterWithComments) printSwitchStmtWithComments(stmt *ast.SwitchStmt)
 		for i, v := range c.Values {
 			values[i] = p.exprToString(v)
 		}
-		p.writeLine(fmt.Sprintf("when %s", strings.Join(values, ", ")))
+		line := "when " + strings.Join(values, ", ")
+		if c.Guard != nil {
+			line += " if " + p.exprToString(c.Guard)
+		}
+		p.writeLine(line)
 		p.indentLevel++
 		p.printBlockWithComments(c.Body)
 		p.indentLevel--
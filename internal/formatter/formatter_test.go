@@ -110,3 +110,329 @@ func main() {
 
 	t.Logf("Result:\n%s", result)
 }
+
+func TestFormatTypeCast(t *testing.T) {
+	source := `func main()
+    s := data as string
+    n := (len(s) + 1) as int64
+    print(s, n)
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatKeepsDirectives(t *testing.T) {
+	source := `# Old greets someone.
+# kuki:deprecated "Use Greet instead"
+func Old(name string) string
+    return name
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatImplements(t *testing.T) {
+	source := `import "fmt"
+
+type Circle
+    r float64
+
+Circle implements fmt.Stringer, error # checked by the compiler
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatSwitchWhenGuard(t *testing.T) {
+	source := `func Route(cmd string, allowNetwork bool) string
+    switch cmd
+        when "fetch", "pull" if allowNetwork
+            return "network"
+        otherwise
+            return "unknown"
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatWhenPatterns(t *testing.T) {
+	source := `func Size(n int, name string) string
+    switch n
+        when < 0
+            return "negative"
+        when 0, 1 to 10
+            return "small"
+        when 10 through 99
+            return "medium"
+    switch name
+        when matches re"^t\d+ \"q\""
+            return "test"
+    return "other"
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatContinueToNext(t *testing.T) {
+	source := `func Steps(level int)
+    switch level
+        when 2
+            print("two")
+            continue to next
+        otherwise
+            print("done")
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatKeepsNumberSpelling(t *testing.T) {
+	source := `func main()
+    print(1_000_000, 0xFF, 0b1010, 0o17, 1e9, 1.5e-3, 0x1p-2, 99999999999999999999)
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatWhenTarget(t *testing.T) {
+	source := `when target mcp
+    # Serve answers on stdio.
+    # kuki:deprecated "Use Run instead"
+    func Serve()
+        print("mcp")
+otherwise
+    func Serve()
+        print("cli")
+
+func main()
+    when target cli, mcp
+        Serve()
+    otherwise
+        print("never")
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatKeepsOnErrClauses(t *testing.T) {
+	source := `func Load(path string) error
+    os.Remove(path) onerr return
+    data := os.ReadFile(path) onerr explain "check the path"
+    cfg := parse(data) onerr panic "bad config" explain "fix the file"
+    v := os.ReadFile(path) onerr as e
+        # keep going
+        print(e)
+        return e
+    for i from 0 to 3
+        os.Remove(path) onerr continue
+    print(cfg, v)
+    return empty
+`
+	assertFormatted(t, source, source)
+}
+
+func TestFormatFailStmt(t *testing.T) {
+	source := `func main()
+    fail "bad input"
+    fail "missing {path}" code 2
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatRequireStmt(t *testing.T) {
+	source := `func Check(items list of string) error
+    require (len(items) > 0) else return error "empty"
+    require (items[0] not equals "") else
+        print("blank")
+        return error "blank"
+    return empty
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatYieldsFunction(t *testing.T) {
+	source := `func Entries on c Cache() yields string, int
+    for k, v in c.data
+        yield k, v
+
+func Evens(limit int) yields int
+    for i from 0 to limit
+        yield i
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatSequenceGenerator(t *testing.T) {
+	source := `func Numbers(limit int) sequence of int
+    for i from 0 to limit
+        yield i
+
+func Sum(seq sequence of int) int
+    total := 0
+    for n in seq
+        total = (total + n)
+    return total
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatChannelDirection(t *testing.T) {
+	source := `func Pump(src channel of int receive-only, out channel of int send-only)
+    for v in src
+        send v to out
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatWaitExpr(t *testing.T) {
+	source := `func main()
+    first := wait for results
+    all := wait for all [a, b] within (2 * time.Second) onerr panic "{error}"
+    print(first, all)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatDistinctType(t *testing.T) {
+	source := `type Meters float64 distinct
+
+type Names list of string
+
+func main()
+    print(Meters(2))
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatEnumType(t *testing.T) {
+	source := `type Status string distinct
+    Active "active"
+    Banned "banned"
+
+func main()
+    print(Active)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatOperatorMethod(t *testing.T) {
+	source := `func Add on v Vector(o Vector) Vector operator +
+    return Vector{x: add(v.x, o.x)}
+
+func Less on v Vector(o Vector) bool operator <
+    return less(v.x, o.x)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatDecimalLiteral(t *testing.T) {
+	source := `func main()
+    price := 1_250.50d
+    print(price.Round(1), 3d)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatReadExpr(t *testing.T) {
+	source := `func main()
+    name := read line onerr "anon"
+    data := read all onerr panic "{error}"
+    write(name, data)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatCommandExpr(t *testing.T) {
+	source := `func main()
+    head := $ "git rev-parse {ref}" onerr return
+    print(head)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatErrorDecl(t *testing.T) {
+	source := `# Lookups that miss.
+error NotFound "resource not found" # 404
+
+error
+    # Writes.
+    Conflict "resource already exists"
+    Expired "token expired" # auth
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatBuildString(t *testing.T) {
+	source := `func main()
+    report := build string as b
+        # one entry per name
+        for n in names
+            b.WriteString(n)
+    print(report)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatDataBlock(t *testing.T) {
+	source := `func main()
+    query := data
+        SELECT name
+
+            FROM users
+    rows := data lines
+        a
+        b
+    print(query, rows)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatSafelyBlock(t *testing.T) {
+	source := `func main()
+    safely
+        risky()
+    onerr as e
+        print(e)
+    safely
+        risky()
+    onerr discard
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatOnErrExpr(t *testing.T) {
+	source := `func main()
+    cfg := Config{host: "localhost", port: GetPort() onerr 8080}
+    print(label(strconv.Atoi(s) onerr as e explain "parse"), cfg)
+    nums := [parse("1") onerr 0, parse("x") onerr -1]
+    print(nums)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatComparisonChain(t *testing.T) {
+	source := `func main()
+    if (0 <= x < 10)
+        print(x)
+`
+
+	assertFormatted(t, source, source)
+}
+
+func TestFormatRangeCheck(t *testing.T) {
+	source := `func main()
+    if (x between 1 and 10)
+        print((x in 0 to 10), (x not in 1 through 5))
+`
+
+	assertFormatted(t, source, source)
+}
@@ -12,6 +12,10 @@ type Printer struct {
 	output      strings.Builder
 	indentLevel int
 	indentStr   string // 4 spaces
+	// nestedBlock prints a block that belongs to an expression or clause
+	// (an `onerr` block handler, a `build string` body); nil means
+	// printBlock. PrinterWithComments swaps in its comment-aware version.
+	nestedBlock func(*ast.BlockStmt)
 }
 
 // NewPrinter creates a new printer
@@ -70,7 +74,47 @@ func (p *Printer) printDeclaration(decl ast.Declaration) {
 		p.printFunctionDecl(d)
 	case *ast.ConstDecl:
 		p.printConstDecl(d)
+	case *ast.ErrorDecl:
+		p.printErrorDecl(d)
+	case *ast.ImplementsDecl:
+		p.writeLine(p.implementsLine(d))
+	case *ast.TargetDecl:
+		p.writeLine("when target " + targetNames(d.Targets))
+		p.printTargetDecls(d.Declarations, p.printDeclaration)
+		if d.Otherwise != nil {
+			p.writeLine("otherwise")
+			p.printTargetDecls(d.Otherwise, p.printDeclaration)
+		}
+	}
+}
+
+// printTargetDecls prints the declarations of a `when target` branch one
+// level in, separated by blank lines.
+func (p *Printer) printTargetDecls(decls []ast.Declaration, print func(ast.Declaration)) {
+	p.indentLevel++
+	for i, decl := range decls {
+		if i > 0 {
+			p.writeLine("")
+		}
+		print(decl)
+	}
+	p.indentLevel--
+}
+
+func targetNames(targets []*ast.Identifier) string {
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Value
 	}
+	return strings.Join(names, ", ")
+}
+
+func (p *Printer) implementsLine(decl *ast.ImplementsDecl) string {
+	ifaces := make([]string, len(decl.Interfaces))
+	for i, iface := range decl.Interfaces {
+		ifaces[i] = p.typeAnnotationToString(iface)
+	}
+	return decl.Type.Value + " implements " + strings.Join(ifaces, ", ")
 }
 
 func (p *Printer) printConstDecl(decl *ast.ConstDecl) {
@@ -87,10 +131,45 @@ func (p *Printer) printConstDecl(decl *ast.ConstDecl) {
 	p.indentLevel--
 }
 
+func (p *Printer) printErrorDecl(decl *ast.ErrorDecl) {
+	if len(decl.Specs) == 1 {
+		p.writeLine("error " + p.errorSpecLine(decl.Specs[0]))
+		return
+	}
+	p.writeLine("error")
+	p.indentLevel++
+	for _, spec := range decl.Specs {
+		p.writeLine(p.errorSpecLine(spec))
+	}
+	p.indentLevel--
+}
+
+func (p *Printer) errorSpecLine(spec *ast.ErrorSpec) string {
+	return spec.Name.Value + " " + p.exprToString(spec.Message)
+}
+
+// distinctSuffix renders the `distinct` modifier of a type alias.
+func distinctSuffix(decl *ast.TypeDecl) string {
+	if decl.Distinct {
+		return " distinct"
+	}
+	return ""
+}
+
+// printEnumValues prints the value set of an enum, indented under its type.
+func (p *Printer) printEnumValues(decl *ast.TypeDecl) {
+	p.indentLevel++
+	for _, v := range decl.Values {
+		p.writeLine(v.Name.Value + " " + p.exprToString(v.Value))
+	}
+	p.indentLevel--
+}
+
 func (p *Printer) printTypeDecl(decl *ast.TypeDecl) {
 	// Type alias (e.g., type Handler func(string))
 	if decl.AliasType != nil {
-		p.writeLine(fmt.Sprintf("type %s %s", decl.Name.Value, p.typeAnnotationToString(decl.AliasType)))
+		p.writeLine(fmt.Sprintf("type %s %s%s", decl.Name.Value, p.typeAnnotationToString(decl.AliasType), distinctSuffix(decl)))
+		p.printEnumValues(decl)
 		return
 	}
 
@@ -129,7 +208,7 @@ func (p *Printer) printInterfaceDecl(decl *ast.InterfaceDecl) {
 
 func (p *Printer) printFunctionDecl(decl *ast.FunctionDecl) {
 	params := p.parametersToString(decl.Parameters)
-	returns := p.returnTypesToString(decl.Returns)
+	returns := p.resultsToString(decl)
 
 	var line string
 	if decl.Receiver != nil {
@@ -172,6 +251,26 @@ func (p *Printer) parametersToString(params []*ast.Parameter) string {
 	return strings.Join(parts, ", ")
 }
 
+// resultsToString renders what follows a function's parameters: its return
+// types, or `yields T` for an iterator (a generator keeps `sequence of T`),
+// then `operator +` for an operator method.
+func (p *Printer) resultsToString(decl *ast.FunctionDecl) string {
+	results := ""
+	if decl.Yields == nil || len(decl.Returns) > 0 {
+		results = p.returnTypesToString(decl.Returns)
+	} else {
+		parts := make([]string, len(decl.Yields))
+		for i, y := range decl.Yields {
+			parts[i] = p.typeAnnotationToString(y)
+		}
+		results = "yields " + strings.Join(parts, ", ")
+	}
+	if decl.Operator != "" {
+		results = strings.TrimSpace(results + " operator " + decl.Operator)
+	}
+	return results
+}
+
 func (p *Printer) returnTypesToString(returns []ast.TypeAnnotation) string {
 	if len(returns) == 0 {
 		return ""
@@ -208,7 +307,12 @@ func (p *Printer) typeAnnotationToString(typeAnn ast.TypeAnnotation) string {
 		valueType := p.typeAnnotationToString(t.ValueType)
 		return fmt.Sprintf("map of %s to %s", keyType, valueType)
 	case *ast.ChannelType:
+		if t.Direction != "" {
+			return "channel of " + p.typeAnnotationToString(t.ElementType) + " " + t.Direction
+		}
 		return "channel of " + p.typeAnnotationToString(t.ElementType)
+	case *ast.SequenceType:
+		return "sequence of " + p.typeAnnotationToString(t.ElementType)
 	case *ast.FunctionType:
 		var paramTypes []string
 		for _, param := range t.Parameters {
@@ -244,6 +348,8 @@ func (p *Printer) printStatement(stmt ast.Statement) {
 		p.printAssignStmt(s)
 	case *ast.ReturnStmt:
 		p.printReturnStmt(s)
+	case *ast.YieldStmt:
+		p.printYieldStmt(s)
 	case *ast.IfStmt:
 		p.printIfStmt(s)
 	case *ast.SwitchStmt:
@@ -269,6 +375,29 @@ func (p *Printer) printStatement(stmt ast.Statement) {
 		} else {
 			p.writeLine("go " + p.exprToString(s.Call))
 		}
+	case *ast.SafelyStmt:
+		p.writeLine("safely")
+		p.indentLevel++
+		for _, stmt := range s.Body.Statements {
+			p.printStatement(stmt)
+		}
+		p.indentLevel--
+		p.writeWithOnErr("", s.OnErr)
+	case *ast.FailStmt:
+		line := "fail " + p.exprToString(s.Message)
+		if s.Code != nil {
+			line += " code " + p.exprToString(s.Code)
+		}
+		p.writeLine(line)
+	case *ast.RequireStmt:
+		if line, ok := p.requireInline(s); ok {
+			p.writeLine(line)
+			break
+		}
+		p.writeLine("require " + p.exprToString(s.Condition) + " else")
+		p.indentLevel++
+		p.printBlock(s.Else)
+		p.indentLevel--
 	case *ast.SendStmt:
 		channel := p.exprToString(s.Channel)
 		value := p.exprToString(s.Value)
@@ -277,16 +406,89 @@ func (p *Printer) printStatement(stmt ast.Statement) {
 		p.writeLine("break")
 	case *ast.ContinueStmt:
 		p.writeLine("continue")
+	case *ast.FallthroughStmt:
+		p.writeLine("continue to next")
 	case *ast.ExpressionStmt:
-		p.writeLine(p.exprToString(s.Expression) + p.onErrSuffix(s.OnErr))
+		p.writeWithOnErr(p.exprToString(s.Expression), s.OnErr)
+	case *ast.TargetStmt:
+		p.writeLine("when target " + targetNames(s.Targets))
+		p.indentLevel++
+		p.printBlock(s.Body)
+		p.indentLevel--
+		if s.Otherwise != nil {
+			p.writeLine("otherwise")
+			p.indentLevel++
+			p.printBlock(s.Otherwise.Body)
+			p.indentLevel--
+		}
 	}
 }
 
-func (p *Printer) onErrSuffix(clause *ast.OnErrClause) string {
+// onErrSuffix renders clause for the end of a statement line. For a block
+// handler it returns the `onerr` (or `onerr as e`) head and the block to print
+// below the line.
+func (p *Printer) onErrSuffix(clause *ast.OnErrClause) (string, *ast.BlockStmt) {
 	if clause == nil {
-		return ""
+		return "", nil
+	}
+	suffix := " onerr"
+	if clause.Alias != "" {
+		suffix += " as " + clause.Alias
+	}
+	switch {
+	case clause.ShorthandReturn:
+		suffix += " return"
+	case clause.ShorthandContinue:
+		suffix += " continue"
+	case clause.ShorthandBreak:
+		suffix += " break"
+	case clause.Handler != nil:
+		if block, ok := clause.Handler.(*ast.BlockExpr); ok {
+			return suffix, block.Body
+		}
+		suffix += " " + p.exprToString(clause.Handler)
+	}
+	if clause.Explain != "" {
+		suffix += fmt.Sprintf(" explain \"%s\"", clause.Explain)
+	}
+	return suffix, nil
+}
+
+// writeWithOnErr writes a statement line followed by its onerr clause. An
+// empty line puts the clause on a line of its own (after a safely block).
+// requireInline renders a require whose else was written on the same line
+// as one line, if its statement still fits on one.
+func (p *Printer) requireInline(s *ast.RequireStmt) (string, bool) {
+	if len(s.Else.Statements) != 1 || s.Else.Token.Line() != s.Token.Line() {
+		return "", false
+	}
+	stmtPrinter := NewPrinter()
+	stmtPrinter.indentStr = p.indentStr
+	stmtPrinter.printStatement(s.Else.Statements[0])
+	stmt := strings.TrimRight(stmtPrinter.output.String(), "\n")
+	if strings.Contains(stmt, "\n") {
+		return "", false
+	}
+	return fmt.Sprintf("require %s else %s", p.exprToString(s.Condition), stmt), true
+}
+
+func (p *Printer) writeWithOnErr(line string, clause *ast.OnErrClause) {
+	suffix, block := p.onErrSuffix(clause)
+	p.writeLine(strings.TrimPrefix(line+suffix, " "))
+	if block == nil {
+		return
+	}
+	p.indentLevel++
+	p.printNestedBlock(block)
+	p.indentLevel--
+}
+
+func (p *Printer) printNestedBlock(block *ast.BlockStmt) {
+	if p.nestedBlock != nil {
+		p.nestedBlock(block)
+	} else {
+		p.printBlock(block)
 	}
-	return " onerr " + p.exprToString(clause.Handler)
 }
 
 func (p *Printer) printVarDeclStmt(stmt *ast.VarDeclStmt) {
@@ -298,7 +500,7 @@ func (p *Printer) printVarDeclStmt(stmt *ast.VarDeclStmt) {
 	for i, v := range stmt.Values {
 		values[i] = p.exprToString(v)
 	}
-	p.writeLine(fmt.Sprintf("%s := %s%s", strings.Join(names, ", "), strings.Join(values, ", "), p.onErrSuffix(stmt.OnErr)))
+	p.writeWithOnErr(fmt.Sprintf("%s := %s", strings.Join(names, ", "), strings.Join(values, ", ")), stmt.OnErr)
 }
 
 func (p *Printer) printAssignStmt(stmt *ast.AssignStmt) {
@@ -314,7 +516,7 @@ func (p *Printer) printAssignStmt(stmt *ast.AssignStmt) {
 	if op == "" {
 		op = "="
 	}
-	p.writeLine(fmt.Sprintf("%s %s %s%s", strings.Join(targets, ", "), op, strings.Join(values, ", "), p.onErrSuffix(stmt.OnErr)))
+	p.writeWithOnErr(fmt.Sprintf("%s %s %s", strings.Join(targets, ", "), op, strings.Join(values, ", ")), stmt.OnErr)
 }
 
 func (p *Printer) printReturnStmt(stmt *ast.ReturnStmt) {
@@ -331,6 +533,14 @@ func (p *Printer) printReturnStmt(stmt *ast.ReturnStmt) {
 	p.writeLine(fmt.Sprintf("return %s", strings.Join(values, ", ")))
 }
 
+func (p *Printer) printYieldStmt(stmt *ast.YieldStmt) {
+	values := make([]string, len(stmt.Values))
+	for i, val := range stmt.Values {
+		values[i] = p.exprToString(val)
+	}
+	p.writeLine("yield " + strings.Join(values, ", "))
+}
+
 func (p *Printer) printIfStmt(stmt *ast.IfStmt) {
 	condition := p.exprToString(stmt.Condition)
 	p.writeLine(fmt.Sprintf("if %s", condition))
@@ -437,7 +647,11 @@ func (p *Printer) printSwitchStmt(stmt *ast.SwitchStmt) {
 		for i, v := range c.Values {
 			values[i] = p.exprToString(v)
 		}
-		p.writeLine(fmt.Sprintf("when %s", strings.Join(values, ", ")))
+		line := "when " + strings.Join(values, ", ")
+		if c.Guard != nil {
+			line += " if " + p.exprToString(c.Guard)
+		}
+		p.writeLine(line)
 		p.indentLevel++
 		p.printBlock(c.Body)
 		p.indentLevel--
@@ -481,11 +695,26 @@ func (p *Printer) exprToString(expr ast.Expression) string {
 	case *ast.Identifier:
 		return e.Value
 	case *ast.IntegerLiteral:
+		// Keep the literal as written (0xFF, 1_000_000, big constants)
+		if e.Token.Lexeme != "" {
+			return e.Token.Lexeme
+		}
 		return fmt.Sprintf("%d", e.Value)
 	case *ast.FloatLiteral:
+		if e.Token.Lexeme != "" {
+			return e.Token.Lexeme
+		}
 		return fmt.Sprintf("%g", e.Value)
 	case *ast.StringLiteral:
 		return p.stringLiteralToString(e)
+	case *ast.DataLiteral:
+		return p.dataLiteralToString(e)
+	case *ast.RegexLiteral:
+		return `re"` + strings.ReplaceAll(e.Pattern, `"`, `\"`) + `"`
+	case *ast.DecimalLiteral:
+		return e.Token.Lexeme
+	case *ast.BuildStringExpr:
+		return p.buildStringToString(e)
 	case *ast.BooleanLiteral:
 		if e.Value {
 			return "true"
@@ -493,13 +722,33 @@ func (p *Printer) exprToString(expr ast.Expression) string {
 		return "false"
 	case *ast.BinaryExpr:
 		return p.binaryExprToString(e)
+	case *ast.ComparisonChainExpr:
+		var b strings.Builder
+		b.WriteString(p.exprToString(e.Operands[0]))
+		for i, operator := range e.Operators {
+			fmt.Fprintf(&b, " %s %s", operator.Lexeme, p.exprToString(e.Operands[i+1]))
+		}
+		return "(" + b.String() + ")"
+	case *ast.RangeCheckExpr:
+		if e.Bound == "and" {
+			return fmt.Sprintf("(%s between %s and %s)", p.exprToString(e.Value), p.exprToString(e.Low), p.exprToString(e.High))
+		}
+		return fmt.Sprintf("(%s %s %s %s %s)", p.exprToString(e.Value), e.Token.Lexeme, p.exprToString(e.Low), e.Bound, p.exprToString(e.High))
+	case *ast.WhenPattern:
+		if e.IsRange() {
+			return fmt.Sprintf("%s %s %s", p.exprToString(e.Low), e.Operator, p.exprToString(e.Value))
+		}
+		return fmt.Sprintf("%s %s", e.Operator, p.exprToString(e.Value))
 	case *ast.UnaryExpr:
 		return p.unaryExprToString(e)
 	case *ast.PipeExpr:
 		left := p.exprToString(e.Left)
 		right := p.exprToString(e.Right)
 		return fmt.Sprintf("%s |> %s", left, right)
-	// Note: OnErrExpr removed — onerr is now a clause on VarDeclStmt, AssignStmt, ExpressionStmt
+	case *ast.ParallelPipeExpr:
+		left := p.exprToString(e.Left)
+		right := p.exprToString(e.Right)
+		return fmt.Sprintf("%s |>> %s", left, right)
 	case *ast.CallExpr:
 		return p.callExprToString(e)
 	case *ast.MethodCallExpr:
@@ -521,10 +770,25 @@ func (p *Printer) exprToString(expr ast.Expression) string {
 	case *ast.ReceiveExpr:
 		channel := p.exprToString(e.Channel)
 		return fmt.Sprintf("receive %s", channel)
+	case *ast.WaitExpr:
+		out := "wait for "
+		if e.All {
+			out += "all "
+		}
+		out += p.exprToString(e.Channel)
+		if e.Timeout != nil {
+			out += " within " + p.exprToString(e.Timeout)
+		}
+		return out
 	case *ast.TypeCastExpr:
 		targetType := p.typeAnnotationToString(e.TargetType)
 		expr := p.exprToString(e.Expression)
-		return fmt.Sprintf("%s(%s)", targetType, expr)
+		// 'as' binds tighter than unary operators and pipes.
+		switch e.Expression.(type) {
+		case *ast.UnaryExpr, *ast.PipeExpr, *ast.ParallelPipeExpr:
+			expr = "(" + expr + ")"
+		}
+		return fmt.Sprintf("%s as %s", expr, targetType)
 	case *ast.EmptyExpr:
 		if e.Type != nil {
 			targetType := p.typeAnnotationToString(e.Type)
@@ -544,8 +808,19 @@ func (p *Printer) exprToString(expr ast.Expression) string {
 	case *ast.PanicExpr:
 		message := p.exprToString(e.Message)
 		return fmt.Sprintf("panic %s", message)
+	case *ast.OnErrExpr:
+		// The parser only accepts inline handlers here, so there is no block.
+		suffix, _ := p.onErrSuffix(e.OnErr)
+		return p.exprToString(e.Expression) + suffix
 	case *ast.RecoverExpr:
 		return "recover"
+	case *ast.CommandExpr:
+		return "$ " + p.exprToString(e.Command)
+	case *ast.ReadExpr:
+		if e.All {
+			return "read all"
+		}
+		return "read line"
 	case *ast.ArrowLambda:
 		return p.arrowLambdaToString(e)
 	case *ast.AddressOfExpr:
@@ -562,6 +837,38 @@ func (p *Printer) stringLiteralToString(lit *ast.StringLiteral) string {
 	return fmt.Sprintf("\"%s\"", lit.Value)
 }
 
+// dataLiteralToString prints a data block: the header, then its lines one
+// level deeper than the statement holding it.
+func (p *Printer) dataLiteralToString(lit *ast.DataLiteral) string {
+	var b strings.Builder
+	b.WriteString("data")
+	if lit.Lines {
+		b.WriteString(" lines")
+	}
+	indent := strings.Repeat(p.indentStr, p.indentLevel+1)
+	for _, line := range strings.Split(lit.Value, "\n") {
+		b.WriteString("\n")
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+	}
+	return b.String()
+}
+
+// buildStringToString prints a build string block: the header, then its body
+// one level deeper than the statement holding it. The body is printed into a
+// fresh output; the saved one is moved back afterwards, never written to.
+func (p *Printer) buildStringToString(e *ast.BuildStringExpr) string {
+	saved := p.output
+	p.output = strings.Builder{}
+	p.indentLevel++
+	p.printNestedBlock(e.Body)
+	p.indentLevel--
+	body := strings.TrimSuffix(p.output.String(), "\n")
+	p.output = saved
+	return "build string as " + e.Builder.Value + "\n" + body
+}
+
 func (p *Printer) binaryExprToString(expr *ast.BinaryExpr) string {
 	left := p.exprToString(expr.Left)
 	right := p.exprToString(expr.Right)
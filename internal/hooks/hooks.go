@@ -0,0 +1,187 @@
+// Package hooks runs compile pipeline plugins: code registered through
+// pkg/kukicha, either by a program embedding the compiler or by a Go plugin
+// (.so) that the CLI loads from $KUKICHA_PLUGINS when built with
+// -tags kukicha_plugins. Plugins add lint rules, inject code (telemetry), or
+// expand DSLs without forking the transpiler.
+//
+// A plugin implements any of AfterParser, AfterAnalyzer and BeforeCodegener.
+// Hooks run in registration order at each stage:
+//
+//   - AfterParse: the program as parsed, before type checking. Rewrites made
+//     here are type checked like hand-written code.
+//   - AfterAnalysis: the analyzed program with inferred types (also run by
+//     `kukicha check`), the place for lint rules.
+//   - BeforeCodegen: just before Go generation (build and run only). Nodes
+//     added here have no inferred types.
+package hooks
+
+import (
+	"fmt"
+	"go/constant"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// EnvVar lists Go plugins to load, separated like $PATH.
+const EnvVar = "KUKICHA_PLUGINS"
+
+// Plugin is the common part of every plugin.
+type Plugin interface {
+	// Name identifies the plugin in diagnostics.
+	Name() string
+}
+
+// AfterParser is implemented by plugins that run after parsing.
+type AfterParser interface {
+	Plugin
+	AfterParse(pass *Pass)
+}
+
+// AfterAnalyzer is implemented by plugins that run after semantic analysis.
+type AfterAnalyzer interface {
+	Plugin
+	AfterAnalysis(pass *Pass)
+}
+
+// BeforeCodegener is implemented by plugins that run before Go generation.
+type BeforeCodegener interface {
+	Plugin
+	BeforeCodegen(pass *Pass)
+}
+
+// Stage is a hook point in the compile pipeline.
+type Stage int
+
+const (
+	AfterParse Stage = iota
+	AfterAnalysis
+	BeforeCodegen
+)
+
+func (s Stage) String() string {
+	switch s {
+	case AfterParse:
+		return "after parse"
+	case AfterAnalysis:
+		return "after analysis"
+	case BeforeCodegen:
+		return "before codegen"
+	}
+	return "unknown stage"
+}
+
+// Pass carries the program to a hook and collects what it reports.
+// ReturnCounts, ExprTypes, AnnTypes, Captures and Constants are nil in AfterParse.
+type Pass struct {
+	Program      *ast.Program
+	File         string
+	Target       string
+	ReturnCounts map[ast.Expression]int
+	ExprTypes    map[ast.Expression]*semantic.TypeInfo
+	AnnTypes     map[ast.TypeAnnotation]*semantic.TypeInfo
+	Captures     map[ast.Node][]semantic.Capture
+	Constants    map[ast.Expression]constant.Value
+
+	plugin   string
+	errors   []error
+	warnings []error
+}
+
+// Errorf reports an error at pos; the compile fails after the stage's hooks run.
+func (p *Pass) Errorf(pos ast.Position, format string, args ...any) {
+	p.errors = append(p.errors, p.diagnostic(pos, format, args))
+}
+
+// Warnf reports a warning at pos.
+func (p *Pass) Warnf(pos ast.Position, format string, args ...any) {
+	p.warnings = append(p.warnings, p.diagnostic(pos, format, args))
+}
+
+func (p *Pass) diagnostic(pos ast.Position, format string, args []any) error {
+	if pos.File == "" {
+		pos.File = p.File
+	}
+	return fmt.Errorf("%s:%d:%d: %s (plugin %s)", pos.File, pos.Line, pos.Column, fmt.Sprintf(format, args...), p.plugin)
+}
+
+var (
+	mu      sync.Mutex
+	plugins []Plugin
+)
+
+// Register adds a plugin. It panics when the plugin implements no hook or
+// its name is already registered, as both are programming errors.
+func Register(p Plugin) {
+	switch p.(type) {
+	case AfterParser, AfterAnalyzer, BeforeCodegener:
+	default:
+		panic(fmt.Sprintf("hooks: plugin %q implements no hook", p.Name()))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, existing := range plugins {
+		if existing.Name() == p.Name() {
+			panic(fmt.Sprintf("hooks: plugin %q registered twice", p.Name()))
+		}
+	}
+	plugins = append(plugins, p)
+}
+
+// Registered returns the registered plugins in registration order.
+func Registered() []Plugin {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Plugin(nil), plugins...)
+}
+
+// Reset removes every registered plugin. It is meant for tests.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	plugins = nil
+}
+
+// LoadFromEnv opens each Go plugin listed in $KUKICHA_PLUGINS. A plugin
+// registers itself from an init function, which runs when it is opened.
+// Loading needs a compiler built with -tags kukicha_plugins (see open.go).
+func LoadFromEnv() error {
+	for _, path := range filepath.SplitList(os.Getenv(EnvVar)) {
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+		if err := open(path); err != nil {
+			return fmt.Errorf("loading plugin %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Run runs the hooks of every registered plugin for stage on pass and returns
+// the errors and warnings they reported.
+func Run(stage Stage, pass *Pass) (errs, warnings []error) {
+	for _, p := range Registered() {
+		pass.plugin = p.Name()
+		switch stage {
+		case AfterParse:
+			if h, ok := p.(AfterParser); ok {
+				h.AfterParse(pass)
+			}
+		case AfterAnalysis:
+			if h, ok := p.(AfterAnalyzer); ok {
+				h.AfterAnalysis(pass)
+			}
+		case BeforeCodegen:
+			if h, ok := p.(BeforeCodegener); ok {
+				h.BeforeCodegen(pass)
+			}
+		}
+	}
+	errs, warnings = pass.errors, pass.warnings
+	pass.errors, pass.warnings = nil, nil
+	return errs, warnings
+}
@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+type recorder struct {
+	name  string
+	calls *[]string
+}
+
+func (r recorder) Name() string { return r.name }
+
+func (r recorder) AfterParse(pass *Pass) {
+	*r.calls = append(*r.calls, r.name+":parse")
+}
+
+func (r recorder) BeforeCodegen(pass *Pass) {
+	*r.calls = append(*r.calls, r.name+":codegen")
+	pass.Warnf(ast.Position{Line: 1, Column: 1}, "from %s", r.name)
+}
+
+type linter struct{}
+
+func (linter) Name() string { return "lint" }
+
+func (linter) AfterAnalysis(pass *Pass) {
+	pass.Errorf(ast.Position{Line: 3, Column: 5}, "no %s here", "print")
+}
+
+func TestRunOrderAndDiagnostics(t *testing.T) {
+	t.Cleanup(Reset)
+	var calls []string
+	Register(recorder{name: "a", calls: &calls})
+	Register(linter{})
+	Register(recorder{name: "b", calls: &calls})
+
+	pass := &Pass{Program: &ast.Program{}, File: "app.kuki"}
+	if errs, warnings := Run(AfterParse, pass); len(errs) != 0 || len(warnings) != 0 {
+		t.Errorf("after parse: unexpected diagnostics %v %v", errs, warnings)
+	}
+	errs, _ := Run(AfterAnalysis, pass)
+	if len(errs) != 1 || errs[0].Error() != "app.kuki:3:5: no print here (plugin lint)" {
+		t.Errorf("after analysis: got %v", errs)
+	}
+	_, warnings := Run(BeforeCodegen, pass)
+	if len(warnings) != 2 || !strings.HasSuffix(warnings[1].Error(), "from b (plugin b)") {
+		t.Errorf("before codegen: got %v", warnings)
+	}
+
+	want := "a:parse b:parse a:codegen b:codegen"
+	if got := strings.Join(calls, " "); got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+}
+
+type nameOnly struct{}
+
+func (nameOnly) Name() string { return "nothing" }
+
+func TestRegisterRejectsInvalidPlugins(t *testing.T) {
+	t.Cleanup(Reset)
+	mustPanic := func(what string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected a panic", what)
+			}
+		}()
+		f()
+	}
+	mustPanic("no hooks", func() { Register(nameOnly{}) })
+	Register(linter{})
+	mustPanic("duplicate name", func() { Register(linter{}) })
+}
+
+func TestLoadFromEnvWithoutPlugins(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	if err := LoadFromEnv(); err != nil {
+		t.Errorf("LoadFromEnv with no plugins: %v", err)
+	}
+}
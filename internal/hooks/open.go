@@ -0,0 +1,13 @@
+//go:build kukicha_plugins
+
+package hooks
+
+import "plugin"
+
+// open loads a Go plugin. The plugin package needs cgo and links the
+// compiler dynamically, so it is only built in with -tags kukicha_plugins;
+// the default kukicha binary stays static.
+func open(path string) error {
+	_, err := plugin.Open(path)
+	return err
+}
@@ -0,0 +1,9 @@
+//go:build !kukicha_plugins
+
+package hooks
+
+import "errors"
+
+func open(path string) error {
+	return errors.New("this kukicha was built without plugin support (rebuild it with -tags kukicha_plugins)")
+}
@@ -0,0 +1,45 @@
+package lexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzLexer checks that no input makes the lexer panic: malformed source
+// must come back as an error. Seeds are snippets plus the examples and
+// stdlib sources; run with
+//
+//	go test -run=^$ -fuzz=FuzzLexer -fuzzminimizetime=1s ./internal/lexer
+func FuzzLexer(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, source string) {
+		tokens, err := NewLexer(source, "fuzz.kuki").ScanTokens()
+		if err == nil && (len(tokens) == 0 || tokens[len(tokens)-1].Type != TOKEN_EOF) {
+			t.Fatalf("token stream does not end in EOF: %v", tokens)
+		}
+	})
+}
+
+// addFuzzSeeds seeds f with snippets and the repo's .kuki sources.
+func addFuzzSeeds(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"func main()\n    print(\"hi {name}\")\n",
+		"x := \"\"\"\n    a\n    \"\"\"\n",
+		"x := data\n    a,b\n",
+		"r := 'x'\nn := 0x1Fe3\n",
+		"# comment\n# kuki:deprecated \"use New\"\n",
+		"items |>\n    filter(x)\n",
+	} {
+		f.Add(seed)
+	}
+	for _, pattern := range []string{"../../examples/*.kuki", "../../stdlib/*/*.kuki"} {
+		paths, _ := filepath.Glob(pattern)
+		for _, path := range paths {
+			if source, err := os.ReadFile(path); err == nil {
+				f.Add(string(source))
+			}
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unique"
+
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 // Lexer tokenizes Kukicha source code.
@@ -26,7 +28,7 @@ type Lexer struct {
 	current            int
 	line               int
 	column             int
-	file               string
+	file               *File
 	tokens             []Token
 	indentStack        []int // Stack of indentation levels (in spaces). Always starts with [0].
 	atLineStart        bool  // Whether we're at the start of a line
@@ -48,13 +50,25 @@ type Lexer struct {
 	// for the expression, and resumes string scanning when the matching } is found.
 	// Each entry in interpStack is the brace depth within that interpolation level.
 	interpStack []int
+
+	// Triple-quoted strings are lexed by splicing their dedented content
+	// into source after the closing quotes. Tokens scanned from the splice
+	// [spliceStart, spliceEnd) take the offset of the opening quotes, spliceAt;
+	// every other token drops the runes spliced in before it, so offsets stay
+	// in the caller's source.
+	spliceStart, spliceEnd, spliceAt int
+	spliced                          int // runes spliced in before spliceStart
 }
 
 // NewLexer creates a new lexer for the given source code
 func NewLexer(source string, filename string) *Lexer {
+	runes := []rune(source)
 	return &Lexer{
-		source:             []rune(source),
-		file:               filename,
+		source: runes,
+		file:   newFile(filename, runes),
+		// Kukicha averages 4-7 characters per token, so the slice rarely
+		// has to grow
+		tokens:             make([]Token, 0, len(runes)/4+1),
 		line:               1,
 		column:             1,
 		indentStack:        []int{0},
@@ -147,7 +161,7 @@ func (l *Lexer) scanToken() {
 		//
 		// NOTE: parenDepth > 0 does NOT suppress indentation when we're in a function
 		// literal (closure), because closures need INDENT/DEDENT tokens for their body.
-		isLineContinuation := (l.braceDepth > 0) || l.lastTokenType == TOKEN_PIPE || l.isPipeAtStartOfNextLine() || l.isOnErrAtStartOfNextLine()
+		isLineContinuation := (l.braceDepth > 0) || l.lastTokenType == TOKEN_PIPE || l.lastTokenType == TOKEN_PARALLEL_PIPE || l.isPipeAtStartOfNextLine() || l.isOnErrAtStartOfNextLine()
 		if isLineContinuation {
 			l.continuationLine = true
 		} else {
@@ -161,7 +175,7 @@ func (l *Lexer) scanToken() {
 		if l.peek() == '\n' {
 			l.advance()
 		}
-		isLineContinuation := (l.braceDepth > 0) || l.lastTokenType == TOKEN_PIPE || l.isPipeAtStartOfNextLine() || l.isOnErrAtStartOfNextLine()
+		isLineContinuation := (l.braceDepth > 0) || l.lastTokenType == TOKEN_PIPE || l.lastTokenType == TOKEN_PARALLEL_PIPE || l.isPipeAtStartOfNextLine() || l.isOnErrAtStartOfNextLine()
 		if isLineContinuation {
 			l.continuationLine = true
 		} else {
@@ -173,8 +187,16 @@ func (l *Lexer) scanToken() {
 		l.indentationHandled = false
 	case '#':
 		l.scanComment()
+	case '@':
+		if isAlpha(l.peek()) {
+			l.scanAnnotation()
+		} else {
+			l.errorMsg(catalog.UnexpectedCharacter, c)
+		}
 	case ';':
 		l.addToken(TOKEN_SEMICOLON)
+	case '$':
+		l.addToken(TOKEN_DOLLAR)
 	case '"':
 		l.scanString()
 	case '\'':
@@ -277,7 +299,11 @@ func (l *Lexer) scanToken() {
 		}
 	case '|':
 		if l.match('>') {
-			l.addToken(TOKEN_PIPE)
+			if l.match('>') {
+				l.addToken(TOKEN_PARALLEL_PIPE)
+			} else {
+				l.addToken(TOKEN_PIPE)
+			}
 		} else if l.match('|') {
 			l.addToken(TOKEN_OR_OR)
 		} else {
@@ -297,7 +323,7 @@ func (l *Lexer) scanToken() {
 		} else if isAlpha(c) {
 			l.scanIdentifier()
 		} else {
-			l.error(fmt.Sprintf("Unexpected character: %c", c))
+			l.errorMsg(catalog.UnexpectedCharacter, c)
 		}
 	}
 }
@@ -331,7 +357,7 @@ func (l *Lexer) handleIndentation() {
 
 	// Check for tabs
 	if tabs > 0 {
-		l.error("indentation error: tabs are not allowed — use 4 spaces per indent level")
+		l.errorMsg(catalog.TabIndent)
 		return
 	}
 
@@ -346,7 +372,7 @@ func (l *Lexer) handleIndentation() {
 		if nearest == 0 {
 			nearest = 4
 		}
-		l.error(fmt.Sprintf("indentation error: found %d spaces, but Kukicha requires multiples of 4 spaces (nearest valid: %d)", spaces, nearest))
+		l.errorMsg(catalog.IndentNotMultiple, spaces, nearest)
 		return
 	}
 
@@ -355,7 +381,7 @@ func (l *Lexer) handleIndentation() {
 	if spaces > currentIndent {
 		// Indent
 		if spaces != currentIndent+4 {
-			l.error(fmt.Sprintf("indentation error: indentation can only increase by 4 spaces at a time (jumped from %d to %d)", currentIndent, spaces))
+			l.errorMsg(catalog.IndentJump, currentIndent, spaces)
 			return
 		}
 		l.indentStack = append(l.indentStack, spaces)
@@ -377,7 +403,7 @@ func (l *Lexer) handleIndentation() {
 			for i, v := range validLevels {
 				parts[i] = fmt.Sprintf("%d", v)
 			}
-			l.error(fmt.Sprintf("indentation error: dedent does not match any outer indent level (found %d spaces, expected one of: %s)", spaces, strings.Join(parts, ", ")))
+			l.errorMsg(catalog.DedentMismatch, spaces, strings.Join(parts, ", "))
 		}
 	}
 }
@@ -568,6 +594,9 @@ func (l *Lexer) scanStringFromContent(content string) {
 	inject = append(inject, '"')
 
 	// Splice into l.source at l.current
+	l.spliced += l.spliceEnd - l.spliceStart
+	l.spliceStart, l.spliceEnd = l.current, l.current+len(inject)
+	l.spliceAt = l.sourceOffset(l.start)
 	newSource := make([]rune, 0, len(l.source)+len(inject))
 	newSource = append(newSource, l.source[:l.current]...)
 	newSource = append(newSource, inject...)
@@ -576,6 +605,18 @@ func (l *Lexer) scanStringFromContent(content string) {
 	l.scanStringBody(TOKEN_STRING_HEAD, TOKEN_STRING)
 }
 
+// sourceOffset maps an index into l.source back to the caller's source.
+func (l *Lexer) sourceOffset(i int) int {
+	switch {
+	case i < l.spliceStart:
+		return i - l.spliced
+	case i < l.spliceEnd:
+		return l.spliceAt
+	default:
+		return i - l.spliced - (l.spliceEnd - l.spliceStart)
+	}
+}
+
 // scanStringContinuation resumes string scanning after a } closes an
 // interpolation expression. Emits TOKEN_STRING_MID if another interpolation
 // follows, or TOKEN_STRING_TAIL at the closing quote.
@@ -591,7 +632,7 @@ func (l *Lexer) scanStringBody(interpTokenType TokenType, endTokenType TokenType
 
 	for !l.isAtEnd() && l.peek() != '"' {
 		if l.peek() == '\n' {
-			l.error("Unterminated string")
+			l.errorMsg(catalog.UnterminatedString)
 			return
 		}
 
@@ -610,7 +651,7 @@ func (l *Lexer) scanStringBody(interpTokenType TokenType, endTokenType TokenType
 	}
 
 	if l.isAtEnd() {
-		l.error("Unterminated string")
+		l.errorMsg(catalog.UnterminatedString)
 		return
 	}
 
@@ -621,8 +662,12 @@ func (l *Lexer) scanStringBody(interpTokenType TokenType, endTokenType TokenType
 // isInterpStart checks whether { at the current position starts a string
 // interpolation. Requires the character after { to be an identifier-start
 // character (letter or underscore). This avoids treating regex quantifiers
-// like {2,} as interpolation.
+// like {2,} as interpolation. Import paths are never interpolated: their
+// ${VAR} references are expanded at build time (see internal/env).
 func (l *Lexer) isInterpStart() bool {
+	if l.lastTokenType == TOKEN_IMPORT {
+		return false
+	}
 	// peek() is '{', check the character after it
 	nextIdx := l.current + 1
 	if nextIdx >= len(l.source) {
@@ -695,7 +740,7 @@ func (l *Lexer) scanStringEscape(value *strings.Builder) {
 // scanRune scans a single-quoted character/rune literal
 func (l *Lexer) scanRune() {
 	if l.isAtEnd() {
-		l.error("Unterminated character literal")
+		l.errorMsg(catalog.UnterminatedCharacter)
 		return
 	}
 
@@ -727,7 +772,7 @@ func (l *Lexer) scanRune() {
 			char = escaped
 		}
 	} else if l.peek() == '\'' {
-		l.error("Empty character literal")
+		l.errorMsg(catalog.EmptyCharacter)
 		return
 	} else {
 		char = l.advance()
@@ -746,24 +791,76 @@ func (l *Lexer) scanRune() {
 
 // scanNumber scans a number (integer or float)
 func (l *Lexer) scanNumber() {
-	for isDigit(l.peek()) {
+	// Number literals follow Go: 0x hex, 0o and 0 octal, 0b binary, decimal
+	// and hex floats with exponents, and _ between digits (1_000_000).
+	// Malformed ones (1__0, 0b2) are still one token; the parser reports them.
+	// A d suffix on a decimal number without an exponent (19.99d, 3d) makes
+	// it a decimal literal.
+	isFloat := false
+	first := l.source[l.start]
+	switch {
+	case first == '0' && (l.peek() == 'x' || l.peek() == 'X'):
 		l.advance()
-	}
-
-	// Look for decimal point
-	if l.peek() == '.' && isDigit(l.peekNext()) {
-		l.advance() // consume .
-
-		for isDigit(l.peek()) {
+		l.scanDigits(isHexDigit)
+		if l.peek() == '.' && isHexDigit(l.peekNext()) {
 			l.advance()
+			l.scanDigits(isHexDigit)
+			isFloat = true
+		}
+		if (l.peek() == 'p' || l.peek() == 'P') && l.scanExponent() {
+			isFloat = true
+		}
+	case first == '0' && (l.peek() == 'b' || l.peek() == 'B' || l.peek() == 'o' || l.peek() == 'O'):
+		l.advance()
+		l.scanDigits(isDigit)
+	default:
+		l.scanDigits(isDigit)
+		if l.peek() == '.' && isDigit(l.peekNext()) {
+			l.advance() // consume .
+			l.scanDigits(isDigit)
+			isFloat = true
 		}
+		if (l.peek() == 'e' || l.peek() == 'E') && l.scanExponent() {
+			isFloat = true
+		} else if l.peek() == 'd' && !isAlphaNumeric(l.peekNext()) {
+			l.advance()
+			l.addToken(TOKEN_DECIMAL)
+			return
+		}
+	}
 
+	if isFloat {
 		l.addToken(TOKEN_FLOAT)
 	} else {
 		l.addToken(TOKEN_INTEGER)
 	}
 }
 
+// scanDigits consumes digits accepted by isDigitFn and _ separators.
+func (l *Lexer) scanDigits(isDigitFn func(rune) bool) {
+	for isDigitFn(l.peek()) || l.peek() == '_' {
+		l.advance()
+	}
+}
+
+// scanExponent consumes an exponent (e10, p-2) when a digit follows the
+// marker and optional sign, reporting whether it did. Otherwise the e is left
+// for the next token.
+func (l *Lexer) scanExponent() bool {
+	n := 1
+	if sign := l.peekAt(1); sign == '+' || sign == '-' {
+		n = 2
+	}
+	if !isDigit(l.peekAt(n)) {
+		return false
+	}
+	for range n {
+		l.advance()
+	}
+	l.scanDigits(isDigit)
+	return true
+}
+
 // scanIdentifier scans an identifier or keyword
 func (l *Lexer) scanIdentifier() {
 	for isAlphaNumeric(l.peek()) {
@@ -771,6 +868,15 @@ func (l *Lexer) scanIdentifier() {
 	}
 
 	text := unique.Make(string(l.source[l.start:l.current])).Value()
+	if text == "data" && l.isDataBlockStart() {
+		l.scanDataBlock()
+		return
+	}
+	if text == "re" && l.peek() == '"' {
+		l.advance() // consume opening quote
+		l.scanRegex()
+		return
+	}
 	tokenType := LookupKeyword(text)
 	l.addTokenWithLexeme(tokenType, text)
 
@@ -780,23 +886,173 @@ func (l *Lexer) scanIdentifier() {
 	}
 }
 
-// scanComment scans a comment. If the comment starts with "# kuki:", it is
-// emitted as TOKEN_DIRECTIVE so the parser can attach it to a declaration.
-// Otherwise it is emitted as a regular TOKEN_COMMENT.
+// scanRegex scans the body of a regex literal re"...". The pattern is kept
+// verbatim so backslashes need no doubling; only \" is unescaped, to allow a
+// quote inside the pattern.
+func (l *Lexer) scanRegex() {
+	pattern := strings.Builder{}
+	for !l.isAtEnd() && l.peek() != '"' {
+		if l.peek() == '\n' {
+			l.errorMsg(catalog.UnterminatedString)
+			return
+		}
+		if l.peek() == '\\' && l.peekNext() == '"' {
+			l.advance()
+		}
+		pattern.WriteRune(l.advance())
+	}
+	if l.isAtEnd() {
+		l.errorMsg(catalog.UnterminatedString)
+		return
+	}
+	l.advance() // consume closing quote
+	l.addTokenWithLexeme(TOKEN_REGEX, pattern.String())
+}
+
+// isDataBlockStart reports whether the `data` just scanned starts a data
+// block: it is the value of an assignment or return, only `lines` may follow
+// it on the line, and the next non-blank line is indented one level deeper
+// and does not continue the expression. Anywhere else (`for x in data`,
+// `if data`) data is an ordinary identifier.
+func (l *Lexer) isDataBlockStart() bool {
+	switch l.lastTokenType {
+	case TOKEN_WALRUS, TOKEN_ASSIGN, TOKEN_RETURN:
+	default:
+		return false
+	}
+	rest, _ := l.dataBlockHeader()
+	if rest < 0 {
+		return false
+	}
+	body := l.dataBlockLines(rest)
+	if len(body) == 0 {
+		return false
+	}
+	// x := data followed by an indented |> or onerr line continues the expression
+	for _, line := range body {
+		if line.text != "" {
+			first := strings.TrimSpace(line.text)
+			return !strings.HasPrefix(first, "|>") && !strings.HasPrefix(first, "onerr")
+		}
+	}
+	return false
+}
+
+// dataBlockHeader returns the offset of the newline that ends the `data` line
+// and whether the header is `data lines`; the offset is -1 when anything
+// else follows data on the line.
+func (l *Lexer) dataBlockHeader() (int, bool) {
+	i := l.current
+	skipSpaces := func() {
+		for i < len(l.source) && (l.source[i] == ' ' || l.source[i] == '\t') {
+			i++
+		}
+	}
+	skipSpaces()
+	lines := false
+	if i+5 <= len(l.source) && string(l.source[i:i+5]) == "lines" && (i+5 == len(l.source) || !isAlphaNumeric(l.source[i+5])) {
+		lines = true
+		i += 5
+		skipSpaces()
+	}
+	if i < len(l.source) && l.source[i] == '\r' {
+		i++
+	}
+	if i >= len(l.source) || l.source[i] != '\n' {
+		return -1, false
+	}
+	return i, lines
+}
+
+// dataBlockLines returns the lines of the data block whose header ends at the
+// newline at offset nl: every following line indented at least one level
+// deeper than the current block, or blank, with that indentation removed.
+// Trailing blank lines are not part of the block. Each entry records the
+// text and the offset of the newline (or end of input) that ends the line.
+func (l *Lexer) dataBlockLines(nl int) []dataLine {
+	indent := l.indentStack[len(l.indentStack)-1] + 4
+	var lines []dataLine
+	content := 0 // lines up to the last non-blank one
+	for i := nl; i < len(l.source) && l.source[i] == '\n'; {
+		start := i + 1
+		end := start
+		for end < len(l.source) && l.source[end] != '\n' {
+			end++
+		}
+		text := strings.TrimRight(string(l.source[start:end]), "\r")
+		if strings.TrimSpace(text) == "" {
+			lines = append(lines, dataLine{end: end})
+		} else {
+			if len(text)-len(strings.TrimLeft(text, " ")) < indent {
+				break
+			}
+			lines = append(lines, dataLine{text: string([]rune(text)[indent:]), end: end})
+			content = len(lines)
+		}
+		i = end
+	}
+	return lines[:content]
+}
+
+// dataLine is one line of a data block.
+type dataLine struct {
+	text string
+	end  int // offset of the newline ending the line
+}
+
+// scanDataBlock emits the data block that starts after the `data` just
+// scanned as one TOKEN_DATA_BLOCK (or TOKEN_DATA_LINES) token whose lexeme
+// is the block's text, taken verbatim: no escapes and no interpolation. The
+// newline after the last line is left for the normal NEWLINE handling.
+func (l *Lexer) scanDataBlock() {
+	nl, asLines := l.dataBlockHeader()
+	body := l.dataBlockLines(nl)
+	texts := make([]string, len(body))
+	for i, line := range body {
+		texts[i] = line.text
+	}
+	tokenType := TOKEN_DATA_BLOCK
+	if asLines {
+		tokenType = TOKEN_DATA_LINES
+	}
+	l.addTokenWithLexeme(tokenType, strings.Join(texts, "\n"))
+
+	for l.current < body[len(body)-1].end {
+		if l.advance() == '\n' {
+			l.line++
+			l.column = 0
+		}
+	}
+}
+
+// scanComment scans a comment. If the comment starts with "# kuki:" (or is a
+// "# route:" or "# schedule:" comment), it is emitted as TOKEN_DIRECTIVE so
+// the parser can attach it to a declaration. Otherwise it is emitted as a
+// regular TOKEN_COMMENT.
 func (l *Lexer) scanComment() {
 	// Consume the rest of the comment line
 	for !l.isAtEnd() && l.peek() != '\n' {
 		l.advance()
 	}
-	// Check if this is a directive comment (# kuki:...)
+	// Check if this is a directive comment (# kuki:..., # route:... or # schedule:...)
 	lexeme := string(l.source[l.start:l.current])
-	if strings.HasPrefix(lexeme, "# kuki:") {
+	if strings.HasPrefix(lexeme, "# kuki:") || strings.HasPrefix(lexeme, "# route:") || strings.HasPrefix(lexeme, "# schedule:") {
 		l.addToken(TOKEN_DIRECTIVE)
 	} else {
 		l.addToken(TOKEN_COMMENT)
 	}
 }
 
+// scanAnnotation scans an `@name args...` annotation line, such as
+// `@derive json, stringer`. It is another spelling of `# kuki:name args...`
+// and is emitted as TOKEN_DIRECTIVE.
+func (l *Lexer) scanAnnotation() {
+	for !l.isAtEnd() && l.peek() != '\n' && l.peek() != '\r' {
+		l.advance()
+	}
+	l.addToken(TOKEN_DIRECTIVE)
+}
+
 // Helper methods
 
 func (l *Lexer) isAtEnd() bool {
@@ -827,6 +1083,15 @@ func (l *Lexer) peekNext() rune {
 	return l.source[l.current+1]
 }
 
+// peekAt returns the character n places past the current one (peekAt(0) is
+// peek), or 0 past the end.
+func (l *Lexer) peekAt(n int) rune {
+	if l.current+n >= len(l.source) {
+		return 0
+	}
+	return l.source[l.current+n]
+}
+
 func (l *Lexer) match(expected rune) bool {
 	if l.isAtEnd() {
 		return false
@@ -847,9 +1112,8 @@ func (l *Lexer) addTokenWithLexeme(tokenType TokenType, lexeme string) {
 	token := Token{
 		Type:   tokenType,
 		Lexeme: lexeme,
-		Line:   l.line,
-		Column: max(0, l.column-len([]rune(lexeme))),
-		File:   l.file,
+		Offset: int32(l.sourceOffset(l.start)),
+		file:   l.file,
 	}
 	l.tokens = append(l.tokens, token)
 	// Track last emitted type for pipe-continuation logic.  Comments are
@@ -861,10 +1125,15 @@ func (l *Lexer) addTokenWithLexeme(tokenType TokenType, lexeme string) {
 }
 
 func (l *Lexer) error(message string) {
-	err := fmt.Errorf("%s:%d:%d: %s", l.file, l.line, l.column, message)
+	err := fmt.Errorf("%s:%d:%d: %s", l.file.Name, l.line, l.column, message)
 	l.errors = append(l.errors, err)
 }
 
+// errorMsg records a catalogued error, reported in the selected language.
+func (l *Lexer) errorMsg(id catalog.ID, args ...any) {
+	l.errors = append(l.errors, catalog.Errorf(l.file.Name, l.line, l.column, id, args...))
+}
+
 // Character classification helpers
 
 func isDigit(c rune) bool {
@@ -884,6 +1153,11 @@ func hexDigit(c rune) (int, bool) {
 	}
 }
 
+func isHexDigit(c rune) bool {
+	_, ok := hexDigit(c)
+	return ok
+}
+
 func isAlpha(c rune) bool {
 	return (c >= 'a' && c <= 'z') ||
 		(c >= 'A' && c <= 'Z') ||
@@ -3,6 +3,7 @@ package lexer
 import (
 	"strings"
 	"testing"
+	"unsafe"
 )
 
 func TestBasicTokens(t *testing.T) {
@@ -60,6 +61,13 @@ func TestBasicTokens(t *testing.T) {
 				TOKEN_IDENTIFIER, TOKEN_PIPE, TOKEN_IDENTIFIER, TOKEN_LPAREN, TOKEN_RPAREN, TOKEN_NEWLINE, TOKEN_EOF,
 			},
 		},
+		{
+			name:  "parallel pipe operator",
+			input: "items |>> process()\n",
+			expected: []TokenType{
+				TOKEN_IDENTIFIER, TOKEN_PARALLEL_PIPE, TOKEN_IDENTIFIER, TOKEN_LPAREN, TOKEN_RPAREN, TOKEN_NEWLINE, TOKEN_EOF,
+			},
+		},
 		{
 			name:  "boolean operators",
 			input: "and or not\n",
@@ -275,6 +283,14 @@ func TestStringInterpolationTokens(t *testing.T) {
 			},
 			lexemes: []string{"Hello ", "", "", "", "!"},
 		},
+		{
+			name:  "import path is not interpolated",
+			input: `import "${BASE}/db"`,
+			expected: []TokenType{
+				TOKEN_IMPORT, TOKEN_STRING, TOKEN_EOF,
+			},
+			lexemes: []string{"import", "${BASE}/db"},
+		},
 		{
 			name:  "escaped braces mixed with interpolation",
 			input: `"\{key\}: {value}"`,
@@ -445,6 +461,17 @@ func TestNumbers(t *testing.T) {
 			input:    "123456789",
 			expected: TOKEN_INTEGER,
 		},
+		{name: "digit separators", input: "1_000_000", expected: TOKEN_INTEGER},
+		{name: "hex", input: "0xFF_FF", expected: TOKEN_INTEGER},
+		{name: "binary", input: "0b1010", expected: TOKEN_INTEGER},
+		{name: "octal", input: "0o17", expected: TOKEN_INTEGER},
+		{name: "beyond int64", input: "99999999999999999999", expected: TOKEN_INTEGER},
+		{name: "exponent", input: "1e9", expected: TOKEN_FLOAT},
+		{name: "signed exponent", input: "1.5e-3", expected: TOKEN_FLOAT},
+		{name: "hex float", input: "0x1.8p+1", expected: TOKEN_FLOAT},
+		{name: "decimal", input: "19.99d", expected: TOKEN_DECIMAL},
+		{name: "whole decimal", input: "1_000d", expected: TOKEN_DECIMAL},
+		{name: "hex digit d", input: "0x1d", expected: TOKEN_INTEGER},
 	}
 
 	for _, tt := range tests {
@@ -463,10 +490,41 @@ func TestNumbers(t *testing.T) {
 			if tokens[0].Type != tt.expected {
 				t.Errorf("Expected %s, got %s", tt.expected, tokens[0].Type)
 			}
+			if tokens[0].Lexeme != tt.input {
+				t.Errorf("Expected lexeme %q, got %q", tt.input, tokens[0].Lexeme)
+			}
 		})
 	}
 }
 
+func TestNumberStopsBeforeNonDigits(t *testing.T) {
+	// A '.' or 'e' not followed by a digit is not part of the number.
+	tokens, err := NewLexer("3.String 2e", "test.kuki").ScanTokens()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []TokenType{TOKEN_INTEGER, TOKEN_DOT, TOKEN_IDENTIFIER, TOKEN_INTEGER, TOKEN_IDENTIFIER}
+	for i, tt := range expected {
+		if tokens[i].Type != tt {
+			t.Errorf("token %d: expected %s, got %s (%q)", i, tt, tokens[i].Type, tokens[i].Lexeme)
+		}
+	}
+}
+
+func TestDecimalSuffixNeedsWordEnd(t *testing.T) {
+	// The d must end the number: 3days and 2e5d are not decimal literals.
+	tokens, err := NewLexer("3days 2e5d 1.5d.Round", "test.kuki").ScanTokens()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []TokenType{TOKEN_INTEGER, TOKEN_IDENTIFIER, TOKEN_FLOAT, TOKEN_IDENTIFIER, TOKEN_DECIMAL, TOKEN_DOT, TOKEN_IDENTIFIER}
+	for i, tt := range expected {
+		if tokens[i].Type != tt {
+			t.Errorf("token %d: expected %s, got %s (%q)", i, tt, tokens[i].Type, tokens[i].Lexeme)
+		}
+	}
+}
+
 func TestComments(t *testing.T) {
 	input := `# This is a comment
 func Hello()
@@ -516,6 +574,21 @@ func OldFunc()
 	}
 }
 
+func TestAnnotationToken(t *testing.T) {
+	input := "@derive json, stringer\ntype Point\n    x int\n"
+	tokens, err := NewLexer(input, "test.kuki").ScanTokens()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tokens[0].Type != TOKEN_DIRECTIVE || tokens[0].Lexeme != "@derive json, stringer" {
+		t.Errorf("expected @derive directive token, got %v %q", tokens[0].Type, tokens[0].Lexeme)
+	}
+
+	if _, err := NewLexer("x := 1 @ 2\n", "test.kuki").ScanTokens(); err == nil {
+		t.Error("expected an error for a bare '@'")
+	}
+}
+
 func TestDirectiveVsComment(t *testing.T) {
 	input := `# regular comment
 # kuki:fix inline
@@ -705,6 +778,68 @@ func TestPipeContinuation(t *testing.T) {
 	}
 }
 
+func TestRegexLiteral(t *testing.T) {
+	input := `p := re"^t\d+ \"x\"" + re`
+	tokens, err := NewLexer(input, "test.kuki").ScanTokens()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var regexes []Token
+	for _, tok := range tokens {
+		if tok.Type == TOKEN_REGEX {
+			regexes = append(regexes, tok)
+		}
+	}
+	if len(regexes) != 1 || regexes[0].Lexeme != `^t\d+ "x"` {
+		t.Fatalf("expected one regex ^t\\d+ \"x\", got %v", regexes)
+	}
+	// re not followed by a quote is an identifier
+	if last := tokens[len(tokens)-2]; last.Type != TOKEN_IDENTIFIER || last.Lexeme != "re" {
+		t.Errorf("expected trailing re as an identifier, got %s %q", last.Type, last.Lexeme)
+	}
+
+	if _, err := NewLexer("p := re\"abc\n", "test.kuki").ScanTokens(); err == nil {
+		t.Error("expected an error for an unterminated regex")
+	}
+}
+
+func TestDataBlock(t *testing.T) {
+	input := "func main()\n    q := data\n        SELECT \"name\" {x}\n\n            FROM users\n\n    rows := data lines\n        a\n        b\n    for r in data\n        print(r)\n"
+	tokens, err := NewLexer(input, "test.kuki").ScanTokens()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var blocks []Token
+	var dataIdents int
+	for _, tok := range tokens {
+		switch {
+		case tok.Type == TOKEN_DATA_BLOCK || tok.Type == TOKEN_DATA_LINES:
+			blocks = append(blocks, tok)
+		case tok.Type == TOKEN_IDENTIFIER && tok.Lexeme == "data":
+			dataIdents++
+		}
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 data blocks, got %v", blocks)
+	}
+	want := "SELECT \"name\" {x}\n\n    FROM users"
+	if blocks[0].Type != TOKEN_DATA_BLOCK || blocks[0].Lexeme != want || blocks[0].Line() != 2 {
+		t.Errorf("first block = %s %q on line %d, want DATA_BLOCK %q on line 2", blocks[0].Type, blocks[0].Lexeme, blocks[0].Line(), want)
+	}
+	if blocks[1].Type != TOKEN_DATA_LINES || blocks[1].Lexeme != "a\nb" || blocks[1].Line() != 7 {
+		t.Errorf("second block = %s %q on line %d, want DATA_LINES \"a\\nb\" on line 7", blocks[1].Type, blocks[1].Lexeme, blocks[1].Line())
+	}
+	// for r in data is a loop over a variable, not a data block
+	if dataIdents != 1 {
+		t.Errorf("expected data as an identifier once, got %d", dataIdents)
+	}
+	for _, tok := range tokens {
+		if tok.Type == TOKEN_FOR && tok.Line() != 10 {
+			t.Errorf("expected the for loop on line 10, got %d", tok.Line())
+		}
+	}
+}
+
 func TestErrorCases(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -940,3 +1075,81 @@ func TestClosureInFunctionCall(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenIsCompact(t *testing.T) {
+	// The AST holds every token, so growing Token grows every parse
+	if size := unsafe.Sizeof(Token{}); size > 40 {
+		t.Errorf("Token is %d bytes, want at most 40", size)
+	}
+}
+
+func TestFileNameIsInterned(t *testing.T) {
+	name := func() string { return strings.Repeat("a", 3) + ".kuki" }
+	a, _ := NewLexer("x := 1\n", name()).ScanTokens()
+	b, _ := NewLexer("y := 2\n", name()).ScanTokens()
+	if unsafe.StringData(a[0].File()) != unsafe.StringData(b[0].File()) {
+		t.Error("expected both lexers to share one copy of the file name")
+	}
+}
+
+func TestOffsetsAfterTripleQuoteString(t *testing.T) {
+	// The dedented content is lexed from a splice; tokens after it must
+	// still point into the original source
+	source := "x := \"\"\"\n    hi {name}\n    \"\"\"\ny := \"\"\"\n    b\n    \"\"\"\nz := 1\n"
+	tokens, err := NewLexer(source, "a.kuki").ScanTokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	runes := []rune(source)
+	for _, tok := range tokens {
+		if tok.Type != TOKEN_IDENTIFIER && tok.Type != TOKEN_INTEGER {
+			continue
+		}
+		if tok.Lexeme == "name" {
+			// Interpolation holes share the position of the opening quotes
+			if line, column := tok.Position(); line != 1 || column != 6 {
+				t.Errorf("name at %d:%d, want 1:6", line, column)
+			}
+			continue
+		}
+		end := int(tok.Offset) + len([]rune(tok.Lexeme))
+		if end > len(runes) || string(runes[tok.Offset:end]) != tok.Lexeme {
+			t.Errorf("%s at offset %d does not match the source", tok, tok.Offset)
+		}
+	}
+	if last := tokens[len(tokens)-3]; last.Lexeme != "1" || last.Line() != 7 {
+		t.Errorf("expected 1 on line 7, got %s", last)
+	}
+}
+
+// benchSource is a mix of the constructs a typical Kukicha file uses.
+const benchSource = `import "strings"
+
+# Repo is a repository from the search results
+type Repo
+    Name string as "name"
+    Stars int as "stars"
+
+func Popular(repos list of Repo, min int) list of string
+    names := empty list of string
+    for r in repos
+        if r.Stars >= min and not strings.HasPrefix(r.Name, "_")
+            names = append(names, "{r.Name} ({r.Stars} stars)")
+    return names |> slices.Sorted()
+
+func Load(path string) (list of Repo, error)
+    data := files.Read(path) onerr return
+    repos := json.Decode(data, list of Repo) onerr explain "check the JSON in {path}"
+    return repos, empty
+`
+
+func BenchmarkLex(b *testing.B) {
+	source := strings.Repeat(benchSource, 50)
+	b.SetBytes(int64(len(source)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := NewLexer(source, "bench.kuki").ScanTokens(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package lexer
 
 import (
 	"fmt"
+	"slices"
 	"sync"
+	"unique"
 )
 
 // TokenType represents the type of a token
@@ -13,10 +15,14 @@ const (
 	TOKEN_IDENTIFIER TokenType = iota
 	TOKEN_INTEGER
 	TOKEN_FLOAT
+	TOKEN_DECIMAL // Decimal literal 1.23d (lexeme keeps the d)
 	TOKEN_STRING
 	TOKEN_STRING_HEAD // Leading literal of an interpolated string (before first {expr})
 	TOKEN_STRING_MID  // Middle literal between two interpolations (between }...{)
 	TOKEN_STRING_TAIL // Trailing literal after last interpolation (after last })
+	TOKEN_DATA_BLOCK  // Verbatim text of a `data` block (lexeme is the dedented text)
+	TOKEN_DATA_LINES  // Verbatim lines of a `data lines` block (lexeme is the dedented text)
+	TOKEN_REGEX       // Regex literal re"..." (lexeme is the pattern, with \" unescaped)
 	TOKEN_TRUE
 	TOKEN_FALSE
 
@@ -98,8 +104,10 @@ const (
 	TOKEN_NOT            // not
 	TOKEN_BANG           // !
 	TOKEN_PIPE           // |>
+	TOKEN_PARALLEL_PIPE  // |>>
 	TOKEN_FAT_ARROW      // =>
 	TOKEN_ARROW_LEFT     // <-
+	TOKEN_DOLLAR         // $ (command substitution)
 
 	// Delimiters
 	TOKEN_LPAREN   // (
@@ -122,13 +130,106 @@ const (
 	TOKEN_SEMICOLON // ; (for Go-style syntax support)
 )
 
-// Token represents a single token in the source code
+// Token represents a single token in the source code. The AST keeps every
+// token it is built from, so a token stores only its offset; its line and
+// column come from the line table of the file it was scanned from, which all
+// of a file's tokens share. ast.TokenPos widens them to a Position.
 type Token struct {
 	Type   TokenType
 	Lexeme string
-	Line   int
-	Column int
-	File   string
+	Offset int32 // rune offset of the token in its file's source
+	file   *File
+}
+
+// File is a source file as the lexer saw it: its name and the rune offset at
+// which each line starts.
+type File struct {
+	Name  string
+	lines []int32
+}
+
+// NewFile returns the line table of source, for tokens made outside the
+// lexer (File.Token). A line ends at "\n", "\r\n" or a lone "\r", as in the
+// lexer.
+func NewFile(name, source string) *File {
+	return newFile(name, []rune(source))
+}
+
+func newFile(name string, source []rune) *File {
+	// Every token and diagnostic carries the file name; interning it shares
+	// one copy between all the parses of a file (the LSP re-parses on every
+	// edit)
+	f := &File{Name: unique.Make(name).Value(), lines: []int32{0}}
+	for i, c := range source {
+		switch {
+		case c == '\n' && i > 0 && source[i-1] == '\r':
+			f.lines[len(f.lines)-1] = int32(i + 1)
+		case c == '\n', c == '\r':
+			f.lines = append(f.lines, int32(i+1))
+		}
+	}
+	return f
+}
+
+// Token returns a token of f at the given rune offset.
+func (f *File) Token(tokenType TokenType, lexeme string, offset int) Token {
+	return Token{Type: tokenType, Lexeme: lexeme, Offset: int32(offset), file: f}
+}
+
+// Offset returns the rune offset of line:column, the inverse of
+// Token.Position.
+func (f *File) Offset(line, column int) int {
+	if line < 1 || line > len(f.lines) {
+		return 0
+	}
+	if line == 1 {
+		column--
+	}
+	return int(f.lines[line-1]) + column
+}
+
+// Position returns the line and column of t, or 0, 0 for a token that was not
+// scanned from a file. Columns count runes and, as they always have in
+// Kukicha diagnostics, start at 1 on the first line and at 0 on the others.
+func (t Token) Position() (line, column int) {
+	if t.file == nil {
+		return 0, 0
+	}
+	lines := t.file.lines
+	i, _ := slices.BinarySearch(lines, t.Offset+1)
+	line = i // lines[i-1] <= Offset < lines[i]
+	column = int(t.Offset - lines[line-1])
+	if line == 1 {
+		column++
+	}
+	return line, column
+}
+
+// Line returns the line of t; see Position.
+func (t Token) Line() int {
+	line, _ := t.Position()
+	return line
+}
+
+// Column returns the column of t; see Position.
+func (t Token) Column() int {
+	_, column := t.Position()
+	return column
+}
+
+// File returns the name of the file t was scanned from.
+func (t Token) File() string {
+	if t.file == nil {
+		return ""
+	}
+	return t.file.Name
+}
+
+// At returns t moved to the position of at, for tokens synthesized from
+// another token.
+func (t Token) At(at Token) Token {
+	t.Offset, t.file = at.Offset, at.file
+	return t
 }
 
 // String returns a string representation of the token type
@@ -140,6 +241,8 @@ func (t TokenType) String() string {
 		return "INTEGER"
 	case TOKEN_FLOAT:
 		return "FLOAT"
+	case TOKEN_DECIMAL:
+		return "DECIMAL"
 	case TOKEN_STRING:
 		return "STRING"
 	case TOKEN_STRING_HEAD:
@@ -148,6 +251,12 @@ func (t TokenType) String() string {
 		return "STRING_MID"
 	case TOKEN_STRING_TAIL:
 		return "STRING_TAIL"
+	case TOKEN_DATA_BLOCK:
+		return "DATA_BLOCK"
+	case TOKEN_DATA_LINES:
+		return "DATA_LINES"
+	case TOKEN_REGEX:
+		return "REGEX"
 	case TOKEN_TRUE:
 		return "TRUE"
 	case TOKEN_FALSE:
@@ -302,10 +411,14 @@ func (t TokenType) String() string {
 		return "BANG"
 	case TOKEN_PIPE:
 		return "PIPE"
+	case TOKEN_PARALLEL_PIPE:
+		return "PARALLEL_PIPE"
 	case TOKEN_FAT_ARROW:
 		return "FAT_ARROW"
 	case TOKEN_ARROW_LEFT:
 		return "ARROW_LEFT"
+	case TOKEN_DOLLAR:
+		return "DOLLAR"
 
 	// Delimiters
 	case TOKEN_LPAREN:
@@ -349,7 +462,8 @@ func (t TokenType) String() string {
 
 // String returns a string representation of the token
 func (t Token) String() string {
-	return fmt.Sprintf("Token{%s, %q, %d:%d}", t.Type, t.Lexeme, t.Line, t.Column)
+	line, column := t.Position()
+	return fmt.Sprintf("Token{%s, %q, %d:%d}", t.Type, t.Lexeme, line, column)
 }
 
 // keywords maps keyword strings to their token types
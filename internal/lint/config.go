@@ -0,0 +1,360 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/env"
+)
+
+// ConfigFileName is the project configuration file read by `kukicha lint`.
+const ConfigFileName = "kukicha.toml"
+
+// Config selects and tunes lint rules. It is read from the [lint] table of
+// kukicha.toml:
+//
+//	[lint]
+//	enable = ["magic-number"]        # rules that are off by default
+//	disable = ["naming"]
+//
+//	[lint.long-function]
+//	max-lines = 80
+//
+//	[lint.unchecked-error]
+//	severity = "error"               # "warning" (the default) or "error"
+//
+// Each [lint.<rule>] table holds options for that rule. Other tables in the
+// file are ignored so kukicha.toml can grow sections for other commands.
+type Config struct {
+	Enable  map[string]bool
+	Disable map[string]bool
+	Options map[string]map[string]Value
+}
+
+// DefaultConfig returns a configuration that runs every default-enabled rule
+// with its default options.
+func DefaultConfig() *Config {
+	return &Config{
+		Enable:  make(map[string]bool),
+		Disable: make(map[string]bool),
+		Options: make(map[string]map[string]Value),
+	}
+}
+
+// Severity returns how rule's findings are reported under c: the rule's
+// `severity` option, or SeverityWarning.
+func (c *Config) Severity(rule Rule) Severity {
+	if v, ok := c.Options[rule.Name()]["severity"]; ok && v.Str == string(SeverityError) {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// Enabled reports whether rule runs under c.
+func (c *Config) Enabled(rule Rule) bool {
+	name := rule.Name()
+	if c.Disable[name] {
+		return false
+	}
+	return c.Enable[name] || rule.DefaultEnabled()
+}
+
+// ValueKind identifies the type of a configuration value.
+type ValueKind int
+
+const (
+	StringValue ValueKind = iota
+	IntValue
+	BoolValue
+	ListValue
+)
+
+// Value is a parsed configuration value.
+type Value struct {
+	Kind ValueKind
+	Str  string
+	Int  int64
+	Bool bool
+	List []Value
+}
+
+// FindConfig walks up from dir looking for kukicha.toml, stopping at the
+// first directory that contains go.mod. It returns "" when none is found.
+func FindConfig(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		path := filepath.Join(d, ConfigFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil || d == filepath.Dir(d) {
+			return ""
+		}
+	}
+}
+
+// LoadConfig reads the lint configuration from the file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(string(data), path)
+}
+
+// ParseConfig parses the lint tables of a kukicha.toml document. It supports
+// the subset of TOML the configuration needs: [table] headers, comments, and
+// key = value pairs whose values are strings, integers, booleans, or
+// single-line arrays of those. ${VAR} in a string value is replaced by the
+// environment variable, and an unset variable is an error.
+func ParseConfig(src, filename string) (*Config, error) {
+	cfg := DefaultConfig()
+	known := make(map[string]bool)
+	for _, r := range Rules() {
+		known[r.Name()] = true
+	}
+
+	err := walkConfig(src, filename, func(table string) error {
+		if rule, ok := strings.CutPrefix(table, "lint."); ok && !known[rule] {
+			return fmt.Errorf("unknown lint rule %q", rule)
+		}
+		return nil
+	}, func(table, key, rawValue string) error {
+		if table != "lint" && !strings.HasPrefix(table, "lint.") {
+			return nil
+		}
+		value, err := parseValue(rawValue)
+		if err != nil {
+			return err
+		}
+
+		if rule, ok := strings.CutPrefix(table, "lint."); ok {
+			if key == "severity" && (value.Kind != StringValue || (value.Str != string(SeverityWarning) && value.Str != string(SeverityError))) {
+				return fmt.Errorf("severity must be \"warning\" or \"error\"")
+			}
+			if cfg.Options[rule] == nil {
+				cfg.Options[rule] = make(map[string]Value)
+			}
+			cfg.Options[rule][key] = value
+			return nil
+		}
+		switch key {
+		case "enable", "disable":
+			if value.Kind != ListValue {
+				return fmt.Errorf("%s must be a list of rule names", key)
+			}
+			target := cfg.Enable
+			if key == "disable" {
+				target = cfg.Disable
+			}
+			for _, item := range value.List {
+				if item.Kind != StringValue || !known[item.Str] {
+					return fmt.Errorf("unknown lint rule %q", item.Str)
+				}
+				target[item.Str] = true
+			}
+		default:
+			return fmt.Errorf("unknown [lint] key %q", key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// BuildConfig is the [build] table of kukicha.toml, which `kukicha build`
+// and `kukicha run` read:
+//
+//	[build]
+//	header = ["// Copyright 2026 Acme Corp.", "//go:generate stringer -type=Color"]
+//	onerr-log = true
+//
+// header lines are written at the top of every generated .go file, before
+// the package clause; each must be a Go comment or directive. onerr-log
+// makes every onerr handler log the error it handles (see
+// codegen.Generator.SetOnErrLog); a file's `# onerr:` pragma overrides it.
+type BuildConfig struct {
+	Header   []string
+	OnErrLog bool
+}
+
+// LoadBuildConfig reads the build configuration from the file at path.
+func LoadBuildConfig(path string) (*BuildConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBuildConfig(string(data), path)
+}
+
+// ParseBuildConfig parses the [build] table of a kukicha.toml document.
+func ParseBuildConfig(src, filename string) (*BuildConfig, error) {
+	cfg := &BuildConfig{}
+	err := walkConfig(src, filename, nil, func(table, key, rawValue string) error {
+		if table != "build" {
+			return nil
+		}
+		value, err := parseValue(rawValue)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "header":
+			if value.Kind != ListValue {
+				return fmt.Errorf("header must be a list of strings")
+			}
+			for _, item := range value.List {
+				if item.Kind != StringValue {
+					return fmt.Errorf("header must be a list of strings")
+				}
+				if !strings.HasPrefix(item.Str, "//") {
+					return fmt.Errorf("header line %q must be a Go comment or directive starting with //", item.Str)
+				}
+				cfg.Header = append(cfg.Header, item.Str)
+			}
+		case "onerr-log":
+			if value.Kind != BoolValue {
+				return fmt.Errorf("onerr-log must be true or false")
+			}
+			cfg.OnErrLog = value.Bool
+		default:
+			return fmt.Errorf("unknown [build] key %q", key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// walkConfig reads the tables of a kukicha.toml document, calling table (when
+// non-nil) for each [table] header and value for each key = value line with
+// the value unparsed, so each command parses only its own tables. An error
+// from either is reported at its line.
+func walkConfig(src, filename string, table func(name string) error, value func(table, key, rawValue string) error) error {
+	current := ""
+	for i, raw := range strings.Split(src, "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return fmt.Errorf("%s:%d: invalid table header %q", filename, lineNo, line)
+			}
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if table != nil {
+				if err := table(current); err != nil {
+					return fmt.Errorf("%s:%d: %v", filename, lineNo, err)
+				}
+			}
+			continue
+		}
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected key = value", filename, lineNo)
+		}
+		if err := value(current, strings.TrimSpace(key), strings.TrimSpace(rawValue)); err != nil {
+			return fmt.Errorf("%s:%d: %v", filename, lineNo, err)
+		}
+	}
+	return nil
+}
+
+// stripComment removes a trailing # comment that is not inside a string.
+func stripComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+func parseValue(s string) (Value, error) {
+	switch {
+	case s == "":
+		return Value{}, fmt.Errorf("missing value")
+	case s == "true" || s == "false":
+		return Value{Kind: BoolValue, Bool: s == "true"}, nil
+	case strings.HasPrefix(s, `"`):
+		str, err := strconv.Unquote(s)
+		if err != nil {
+			return Value{}, fmt.Errorf("invalid string %s", s)
+		}
+		// ${VAR} references are expanded when the configuration is loaded
+		if str, err = env.Expand(str); err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: StringValue, Str: str}, nil
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return Value{}, fmt.Errorf("unterminated array %s", s)
+		}
+		list := Value{Kind: ListValue}
+		for _, item := range splitArray(s[1 : len(s)-1]) {
+			v, err := parseValue(item)
+			if err != nil {
+				return Value{}, err
+			}
+			list.List = append(list.List, v)
+		}
+		return list, nil
+	}
+	n, err := strconv.ParseInt(strings.ReplaceAll(s, "_", ""), 0, 64)
+	if err != nil {
+		return Value{}, fmt.Errorf("invalid value %s", s)
+	}
+	return Value{Kind: IntValue, Int: n}, nil
+}
+
+// splitArray splits the body of a TOML array on commas outside strings,
+// dropping empty items (a trailing comma is allowed).
+func splitArray(body string) []string {
+	var items []string
+	inString := false
+	start := 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case ',':
+			if !inString {
+				items = append(items, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	items = append(items, body[start:])
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	src := `# project settings
+[build]
+target = "mcp"
+
+[lint]
+enable = ["magic-number", ]   # opt in
+disable = ["naming"]
+
+[lint.magic-number]
+allow = [0, 1, 60, 1_000]
+ignore-tests = false
+`
+	cfg, err := ParseConfig(src, "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enable["magic-number"] || !cfg.Disable["naming"] {
+		t.Errorf("unexpected enable/disable: %v %v", cfg.Enable, cfg.Disable)
+	}
+	pass := &Pass{options: cfg.Options["magic-number"]}
+	if got := pass.Ints("allow", nil); len(got) != 4 || got[3] != 1000 {
+		t.Errorf("unexpected allow list: %v", got)
+	}
+	if pass.Bool("ignore-tests", true) {
+		t.Error("expected ignore-tests = false")
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"[lint]\nenable = [\"no-such-rule\"]\n":          `kukicha.toml:2: unknown lint rule "no-such-rule"`,
+		"[lint.bogus]\n":                                 `kukicha.toml:1: unknown lint rule "bogus"`,
+		"[lint]\nenable\n":                               "kukicha.toml:2: expected key = value",
+		"[lint]\nlevel = 3\n":                            `kukicha.toml:2: unknown [lint] key "level"`,
+		"[lint.naming]\nx = [1, 2\n":                     "kukicha.toml:2: unterminated array",
+		"[lint.naming]\nx = \"${KUKICHA_TEST_UNSET}\"\n": "kukicha.toml:2: environment variable KUKICHA_TEST_UNSET is not set",
+		"[lint.naming]\nseverity = \"fatal\"\n":          `kukicha.toml:2: severity must be "warning" or "error"`,
+	}
+	for src, want := range tests {
+		_, err := ParseConfig(src, "kukicha.toml")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseConfig(%q): expected error %q, got %v", src, want, err)
+		}
+	}
+}
+
+func TestParseBuildConfig(t *testing.T) {
+	src := `[lint]
+enable = ["magic-number"]
+
+[build]
+header = ["// Copyright ${KUKICHA_TEST_OWNER}", "//go:generate stringer -type=Color"]
+onerr-log = true
+`
+	t.Setenv("KUKICHA_TEST_OWNER", "Acme Corp.")
+	cfg, err := ParseBuildConfig(src, "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Header) != 2 || cfg.Header[0] != "// Copyright Acme Corp." || cfg.Header[1] != "//go:generate stringer -type=Color" {
+		t.Errorf("unexpected header: %q", cfg.Header)
+	}
+	if !cfg.OnErrLog {
+		t.Error("expected onerr-log = true to set OnErrLog")
+	}
+
+	tests := map[string]string{
+		"[build]\nheader = \"// one line\"\n": "kukicha.toml:2: header must be a list of strings",
+		"[build]\nheader = [\"package x\"]\n": `kukicha.toml:2: header line "package x" must be a Go comment`,
+		"[build]\nflags = [\"-v\"]\n":         `kukicha.toml:2: unknown [build] key "flags"`,
+		"[build]\nonerr-log = \"yes\"\n":      "kukicha.toml:2: onerr-log must be true or false",
+	}
+	for src, want := range tests {
+		_, err := ParseBuildConfig(src, "kukicha.toml")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseBuildConfig(%q): expected error %q, got %v", src, want, err)
+		}
+	}
+}
+
+func TestParseConfigExpandsEnv(t *testing.T) {
+	t.Setenv("KUKICHA_TEST_RULE", "magic-number")
+	cfg, err := ParseConfig("[lint]\nenable = [\"${KUKICHA_TEST_RULE}\"]\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enable["magic-number"] {
+		t.Errorf("expected ${KUKICHA_TEST_RULE} to enable magic-number, got %v", cfg.Enable)
+	}
+}
+
+func TestFindConfigStopsAtModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "proj", "cmd")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ConfigFileName), []byte("[lint]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "proj", "go.mod"), []byte("module x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindConfig(sub); got != "" {
+		t.Errorf("expected search to stop at go.mod, found %s", got)
+	}
+	want := filepath.Join(root, "proj", ConfigFileName)
+	if err := os.WriteFile(want, []byte("[lint]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindConfig(sub); got != want {
+		t.Errorf("FindConfig = %q, want %q", got, want)
+	}
+}
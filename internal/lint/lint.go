@@ -0,0 +1,274 @@
+// Package lint implements `kukicha lint`: style and hygiene rules that are
+// reported as suggestions rather than the hard errors produced by `check`.
+//
+// Each rule implements Rule and inspects an analyzed program through a Pass.
+// Rules are enabled, disabled, and tuned from the [lint] tables of
+// kukicha.toml (see Config). A rule may attach a Fix to a diagnostic when the
+// rewrite is mechanical and cannot change behavior; `kukicha lint --fix`
+// applies those with ApplyFixes.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// Rule is a single lint check.
+type Rule interface {
+	// Name is the identifier used in diagnostics and kukicha.toml.
+	Name() string
+	// Description is a one-line summary shown by `kukicha lint --rules`.
+	Description() string
+	// DefaultEnabled reports whether the rule runs without being listed
+	// in the `enable` key.
+	DefaultEnabled() bool
+	// Check inspects pass.Program and reports findings with pass.Report.
+	Check(pass *Pass)
+}
+
+// UncheckedErrorRule is the rule `kukicha check` also runs (see RunRule).
+const UncheckedErrorRule = "unchecked-error"
+
+// Severity is how a rule's findings are reported. Rules report warnings
+// unless their [lint.<rule>] table sets `severity = "error"`.
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic is one finding reported by a rule.
+type Diagnostic struct {
+	Pos      ast.Position
+	Rule     string
+	Message  string
+	Severity Severity
+	Fix      *Fix // nil when the finding has no safe automatic fix
+}
+
+func (d Diagnostic) String() string {
+	message := d.Message
+	if d.Severity == SeverityError {
+		message = "error: " + message
+	}
+	s := fmt.Sprintf("%s:%d:%d: %s (%s)", d.Pos.File, d.Pos.Line, d.Pos.Column, message, d.Rule)
+	if d.Fix != nil {
+		s += " [fixable]"
+	}
+	return s
+}
+
+// Fix is a textual edit within a single source line: Delete bytes starting
+// at byte Offset of Line (1-based) are replaced by Insert.
+type Fix struct {
+	Line   int
+	Offset int
+	Delete int
+	Insert string
+}
+
+// Pass carries the analyzed program and rule options to a rule.
+type Pass struct {
+	Program      *ast.Program
+	File         string
+	Lines        []string // source lines, for rules that compute fixes
+	ReturnCounts map[ast.Expression]int
+	ExprTypes    map[ast.Expression]*semantic.TypeInfo
+	// UncheckedErrors are the statements that drop an error result, from
+	// semantic.Analyzer.UncheckedErrors.
+	UncheckedErrors []ast.Statement
+	// LoopCaptures are the closures in loops that capture a variable the
+	// loop reassigns, from semantic.Analyzer.LoopCaptures.
+	LoopCaptures []semantic.LoopCapture
+
+	rule     Rule
+	options  map[string]Value
+	severity Severity
+	diags    []Diagnostic
+}
+
+// Report records a finding for the running rule.
+func (p *Pass) Report(pos ast.Position, message string, fix *Fix) {
+	if pos.File == "" {
+		pos.File = p.File
+	}
+	p.diags = append(p.diags, Diagnostic{Pos: pos, Rule: p.rule.Name(), Message: message, Severity: p.severity, Fix: fix})
+}
+
+// IsTestFile reports whether the file being linted is a _test.kuki file.
+func (p *Pass) IsTestFile() bool {
+	return strings.HasSuffix(p.File, "_test.kuki")
+}
+
+// Int returns the integer option key for the running rule, or def.
+func (p *Pass) Int(key string, def int) int {
+	if v, ok := p.options[key]; ok && v.Kind == IntValue {
+		return int(v.Int)
+	}
+	return def
+}
+
+// Bool returns the boolean option key for the running rule, or def.
+func (p *Pass) Bool(key string, def bool) bool {
+	if v, ok := p.options[key]; ok && v.Kind == BoolValue {
+		return v.Bool
+	}
+	return def
+}
+
+// Ints returns the integer-list option key for the running rule, or def.
+func (p *Pass) Ints(key string, def []int64) []int64 {
+	v, ok := p.options[key]
+	if !ok || v.Kind != ListValue {
+		return def
+	}
+	var out []int64
+	for _, item := range v.List {
+		if item.Kind == IntValue {
+			out = append(out, item.Int)
+		}
+	}
+	return out
+}
+
+// Rules returns every built-in rule, sorted by name.
+func Rules() []Rule {
+	rules := []Rule{
+		&onerrPanicContextRule{},
+		&namingRule{},
+		&longFunctionRule{},
+		&loopCaptureRule{},
+		&magicNumberRule{},
+		&unusedResultRule{},
+		&uncheckedErrorRule{},
+		&unboundedLoopRule{},
+		&injectionRule{name: "shell-injection", kind: "shell", desc: "interpolated strings reaching shell commands"},
+		&injectionRule{name: "sql-injection", kind: "sql", desc: "interpolated strings reaching SQL queries"},
+		&injectionRule{name: "html-injection", kind: "html", desc: "interpolated strings reaching HTML output or template sources"},
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name() < rules[j].Name() })
+	return rules
+}
+
+// Input is an analyzed file to lint.
+type Input struct {
+	Program         *ast.Program
+	File            string
+	Source          string
+	ReturnCounts    map[ast.Expression]int
+	ExprTypes       map[ast.Expression]*semantic.TypeInfo
+	UncheckedErrors []ast.Statement
+	LoopCaptures    []semantic.LoopCapture
+}
+
+// Run applies the rules enabled by cfg to in and returns the diagnostics
+// sorted by position.
+func Run(in Input, cfg *Config) []Diagnostic {
+	return run(in, cfg, Rules())
+}
+
+// RunRule is Run for the rule named name alone; `kukicha check` uses it to
+// report unchecked errors. It returns nil when cfg disables the rule.
+func RunRule(in Input, cfg *Config, name string) []Diagnostic {
+	for _, rule := range Rules() {
+		if rule.Name() == name {
+			return run(in, cfg, []Rule{rule})
+		}
+	}
+	return nil
+}
+
+func run(in Input, cfg *Config, rules []Rule) []Diagnostic {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	var diags []Diagnostic
+	for _, rule := range rules {
+		if !cfg.Enabled(rule) {
+			continue
+		}
+		pass := &Pass{
+			Program:         in.Program,
+			File:            in.File,
+			Lines:           strings.Split(in.Source, "\n"),
+			ReturnCounts:    in.ReturnCounts,
+			ExprTypes:       in.ExprTypes,
+			UncheckedErrors: in.UncheckedErrors,
+			LoopCaptures:    in.LoopCaptures,
+			rule:            rule,
+			options:         cfg.Options[rule.Name()],
+			severity:        cfg.Severity(rule),
+		}
+		rule.Check(pass)
+		diags = append(diags, pass.diags...)
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Pos.Line != diags[j].Pos.Line {
+			return diags[i].Pos.Line < diags[j].Pos.Line
+		}
+		return diags[i].Pos.Column < diags[j].Pos.Column
+	})
+	return diags
+}
+
+// ApplyFixes applies the fixes attached to diags to source and returns the
+// result and the number of fixes applied. Overlapping fixes on one line are
+// skipped after the first.
+func ApplyFixes(source string, diags []Diagnostic) (string, int) {
+	byLine := make(map[int][]*Fix)
+	for _, d := range diags {
+		if d.Fix != nil {
+			byLine[d.Fix.Line] = append(byLine[d.Fix.Line], d.Fix)
+		}
+	}
+	lines := strings.Split(source, "\n")
+	applied := 0
+	for lineNo, fixes := range byLine {
+		if lineNo < 1 || lineNo > len(lines) {
+			continue
+		}
+		// Apply right to left so earlier offsets stay valid.
+		sort.Slice(fixes, func(i, j int) bool { return fixes[i].Offset > fixes[j].Offset })
+		line := lines[lineNo-1]
+		limit := len(line)
+		for _, f := range fixes {
+			if f.Offset < 0 || f.Offset+f.Delete > limit {
+				continue
+			}
+			line = line[:f.Offset] + f.Insert + line[f.Offset+f.Delete:]
+			limit = f.Offset
+			applied++
+		}
+		lines[lineNo-1] = line
+	}
+	return strings.Join(lines, "\n"), applied
+}
+
+// eachFunction calls fn for every function body in the program: declared
+// functions and methods, and the function literals and block lambdas nested
+// in them. name is empty for literals.
+func eachFunction(program *ast.Program, fn func(name string, pos ast.Position, body *ast.BlockStmt)) {
+	for _, decl := range program.Declarations {
+		d, ok := decl.(*ast.FunctionDecl)
+		if !ok || d.Body == nil {
+			continue
+		}
+		fn(d.Name.Value, d.Pos(), d.Body)
+		ast.WalkBlock(d.Body, func(e ast.Expression) bool {
+			switch lit := e.(type) {
+			case *ast.FunctionLiteral:
+				fn("", lit.Pos(), lit.Body)
+			case *ast.ArrowLambda:
+				if lit.Block != nil {
+					fn("", lit.Pos(), lit.Block)
+				}
+			}
+			return false
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+func lintSource(t *testing.T, source, file string, cfg *Config) []Diagnostic {
+	t.Helper()
+	p, err := parser.New(source, file)
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	program, errs := p.Parse()
+	if len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	analyzer := semantic.NewWithFile(program, file)
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		t.Fatalf("semantic errors: %v", errs)
+	}
+	return Run(Input{
+		Program:         program,
+		File:            file,
+		Source:          source,
+		ReturnCounts:    analyzer.ReturnCounts(),
+		ExprTypes:       analyzer.ExprTypes(),
+		UncheckedErrors: analyzer.UncheckedErrors(),
+		LoopCaptures:    analyzer.LoopCaptures(),
+	}, cfg)
+}
+
+func byRule(diags []Diagnostic, rule string) []Diagnostic {
+	var out []Diagnostic
+	for _, d := range diags {
+		if d.Rule == rule {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func TestOnErrPanicContextFix(t *testing.T) {
+	source := `import "os"
+
+func main()
+    a := os.ReadFile("a") onerr panic "read a"
+    b := os.ReadFile("b") onerr panic "read b: {error}"
+    c := os.ReadFile("c") onerr panic "read c:"
+    print(len(a) + len(b) + len(c))
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "onerr-panic-context")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 onerr-panic-context diagnostics, got: %v", diags)
+	}
+	fixed, n := ApplyFixes(source, diags)
+	if n != 2 {
+		t.Fatalf("expected 2 fixes applied, got %d", n)
+	}
+	if !strings.Contains(fixed, `onerr panic "read a: {error}"`) || !strings.Contains(fixed, `onerr panic "read c: {error}"`) {
+		t.Errorf("unexpected fixed source:\n%s", fixed)
+	}
+	if len(byRule(lintSource(t, fixed, "app.kuki", nil), "onerr-panic-context")) != 0 {
+		t.Errorf("expected no diagnostics after fixing:\n%s", fixed)
+	}
+}
+
+func TestOnErrPanicContextInExpression(t *testing.T) {
+	source := `import "os"
+
+func main()
+    print(len(os.ReadFile("a") onerr panic "read a"))
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "onerr-panic-context")
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 onerr-panic-context diagnostic, got: %v", diags)
+	}
+	if fixed, n := ApplyFixes(source, diags); n != 1 || !strings.Contains(fixed, `onerr panic "read a: {error}"))`) {
+		t.Errorf("unexpected fix (%d applied):\n%s", n, fixed)
+	}
+}
+
+func TestNamingRule(t *testing.T) {
+	source := `type http_client
+    base_url string
+
+func fetch_page(page_num int) int
+    next_page := page_num + 1
+    return next_page
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "naming")
+	var names []string
+	for _, d := range diags {
+		names = append(names, d.Message[:strings.Index(d.Message, " uses")])
+	}
+	want := []string{"type 'http_client'", "field 'base_url'", "function 'fetch_page'", "parameter 'page_num'", "variable 'next_page'"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if !strings.Contains(diags[0].Message, "'httpClient'") {
+		t.Errorf("expected mixedCaps suggestion, got: %s", diags[0].Message)
+	}
+}
+
+func TestMixedCaps(t *testing.T) {
+	for in, want := range map[string]string{
+		"max_size":  "maxSize",
+		"MAX_SIZE":  "MaxSize",
+		"_tmp_name": "tmpName",
+		"HTTP_url":  "HttpUrl",
+	} {
+		if got := mixedCaps(in); got != want {
+			t.Errorf("mixedCaps(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLongFunctionRespectsConfig(t *testing.T) {
+	source := "func work() int\n    x := 0\n" + strings.Repeat("    x = x + 1\n", 10) + "    return x\n"
+	if diags := byRule(lintSource(t, source, "app.kuki", nil), "long-function"); len(diags) != 0 {
+		t.Errorf("expected no diagnostics under the default limit, got: %v", diags)
+	}
+	cfg, err := ParseConfig("[lint.long-function]\nmax-lines = 5\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := byRule(lintSource(t, source, "app.kuki", cfg), "long-function")
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "is 13 lines long (max 5)") {
+		t.Errorf("expected long-function diagnostic, got: %v", diags)
+	}
+}
+
+func TestMagicNumberOffByDefault(t *testing.T) {
+	source := `func area(r float64) float64
+    return 3.14159 * r * r * 2
+`
+	if diags := byRule(lintSource(t, source, "app.kuki", nil), "magic-number"); len(diags) != 0 {
+		t.Errorf("magic-number should be off by default, got: %v", diags)
+	}
+	cfg, err := ParseConfig("[lint]\nenable = [\"magic-number\"]\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := byRule(lintSource(t, source, "app.kuki", cfg), "magic-number")
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "magic number 3.14159") {
+		t.Errorf("expected one magic-number diagnostic, got: %v", diags)
+	}
+	if diags := byRule(lintSource(t, source, "app_test.kuki", cfg), "magic-number"); len(diags) != 0 {
+		t.Errorf("expected test files to be skipped, got: %v", diags)
+	}
+}
+
+func TestUnusedResult(t *testing.T) {
+	source := `import "strconv"
+import "sort"
+
+func double(n int) int
+    return n * 2
+
+func check(n int) error
+    return empty
+
+func main()
+    double(4)
+    strconv.Itoa(3)
+    check(1)
+    names := list of string{"b", "a"}
+    sort.Strings(names)
+    print(double(2))
+`
+	if diags := byRule(lintSource(t, source, "app.kuki", nil), "unused-result"); len(diags) != 0 {
+		t.Errorf("unused-result should be off by default, got: %v", diags)
+	}
+	cfg, err := ParseConfig("[lint]\nenable = [\"unused-result\"]\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := byRule(lintSource(t, source, "app.kuki", cfg), "unused-result")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 unused-result diagnostics, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].Message, "result of 'double' (int)") || !strings.Contains(diags[1].Message, "'strconv.Itoa'") {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if !strings.Contains(diags[0].Message, "assign it to _ if that is intentional") {
+		t.Errorf("unexpected advice: %s", diags[0].Message)
+	}
+	// Reported at the callee, not the closing parenthesis
+	if pos := diags[0].Pos; pos.Line != 11 || pos.Column != 4 {
+		t.Errorf("double(4) reported at %d:%d, want 11:4", pos.Line, pos.Column)
+	}
+	if pos := diags[1].Pos; pos.Line != 12 || pos.Column != 12 {
+		t.Errorf("strconv.Itoa(3) reported at %d:%d, want 12:12", pos.Line, pos.Column)
+	}
+}
+
+func TestUnboundedLoop(t *testing.T) {
+	source := `import "context"
+import "net/http"
+import "os"
+import "stdlib/fetch"
+import "time"
+
+func poll(urls list of string)
+    for url in urls
+        resp := http.Get(url) onerr continue
+        resp.Body.Close()
+        page := fetch.Get(url) onerr continue
+        page.Body.Close()
+
+func spin(items list of int)
+    for true
+        for x in items
+            if x > 3
+                break
+        go
+            return
+
+func until(c context.Context)
+    for true
+        if c.Err() != empty
+            return
+        time.Sleep(time.Second)
+
+func counted(n int)
+    i := 0
+    for
+        i = i + 1
+        if i > n
+            break
+
+func load() error
+    for true
+        os.ReadFile("config") onerr return
+
+func main()
+    for true
+        time.Sleep(time.Second)
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "unbounded-loop")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 unbounded-loop diagnostics, got: %v", diags)
+	}
+	if diags[0].Pos.Line != 9 || !strings.Contains(diags[0].Message, "http.Get in a loop has no timeout") {
+		t.Errorf("expected http.Get flagged on line 9, got: %v", diags[0])
+	}
+	if diags[1].Pos.Line != 15 || !strings.Contains(diags[1].Message, "for true loop has no break") {
+		t.Errorf("expected the loop in spin flagged on line 15, got: %v", diags[1])
+	}
+
+	// Without graceful shutdown, nothing stops main's loop either
+	if diags := byRule(lintSource(t, "# shutdown: off\n"+source, "app.kuki", nil), "unbounded-loop"); len(diags) != 3 {
+		t.Errorf("expected main's loop flagged with # shutdown: off, got: %v", diags)
+	}
+}
+
+func TestUnboundedLoopBreakInSwitch(t *testing.T) {
+	source := `func next() int
+    return 1
+
+func events(ch channel of int)
+    for true
+        select
+            when v := receive from ch
+                if v < 0
+                    break
+
+func states()
+    for true
+        switch next()
+            when 0
+                break
+            otherwise
+                print("busy")
+
+func done()
+    for true
+        switch next()
+            when 0
+                return
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "unbounded-loop")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 unbounded-loop diagnostics, got: %v", diags)
+	}
+	if diags[0].Pos.Line != 5 || diags[1].Pos.Line != 12 {
+		t.Errorf("expected the loops in events and states flagged, got: %v", diags)
+	}
+}
+
+func TestUncheckedErrorFix(t *testing.T) {
+	source := `import "os"
+import "strings"
+
+func save(path string) (int, error)
+    return 0, empty
+
+func run() error
+    os.Remove("a")
+    n, _ := save("b")
+    _ = os.Remove("c")
+    save("d")
+    os.Remove("e") onerr return
+    sb := strings.Builder{}
+    sb.WriteString("x")
+    print(n, sb.String())
+    return empty
+
+func main()
+    os.Remove("f")
+    run() onerr panic "{error}"
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "unchecked-error")
+	if len(diags) != 5 {
+		t.Fatalf("expected 5 unchecked-error diagnostics, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].Message, "error returned by 'os.Remove' is not checked") || !strings.Contains(diags[1].Message, "error returned by 'save' is discarded with _") {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags[4].Fix != nil {
+		t.Errorf("expected no fix in main, which does not return an error: %v", diags[4])
+	}
+	fixed, n := ApplyFixes(source, diags)
+	if n != 4 {
+		t.Fatalf("expected 4 fixes applied, got %d", n)
+	}
+	for _, want := range []string{
+		`    os.Remove("a") onerr explain "os.Remove failed"`,
+		`    n := save("b") onerr explain "save failed"`,
+		`    os.Remove("c") onerr explain "os.Remove failed"`,
+		`    save("d") onerr explain "save failed"`,
+	} {
+		if !strings.Contains(fixed, want+"\n") {
+			t.Errorf("expected %q in fixed source:\n%s", want, fixed)
+		}
+	}
+	if left := byRule(lintSource(t, fixed, "app.kuki", nil), "unchecked-error"); len(left) != 1 {
+		t.Errorf("expected only the main diagnostic after fixing, got: %v", left)
+	}
+}
+
+func TestUncheckedErrorSeverity(t *testing.T) {
+	cfg, err := ParseConfig("[lint.unchecked-error]\nseverity = \"error\"\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := "import \"os\"\n\nfunc main()\n    os.Remove(\"x\")\n"
+	diags := byRule(lintSource(t, source, "app.kuki", cfg), "unchecked-error")
+	if len(diags) != 1 || diags[0].Severity != SeverityError {
+		t.Fatalf("expected one error-severity diagnostic, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].String(), "app.kuki:4:") || !strings.Contains(diags[0].String(), ": error: error returned by 'os.Remove'") {
+		t.Errorf("unexpected diagnostic text: %s", diags[0])
+	}
+	if diags := byRule(lintSource(t, source, "app_test.kuki", cfg), "unchecked-error"); len(diags) != 0 {
+		t.Errorf("expected test files to be skipped, got: %v", diags)
+	}
+}
+
+func TestLoopCaptureFix(t *testing.T) {
+	source := `func later(f func())
+    f()
+
+func main()
+    handlers := empty list of func()
+    label := ""
+    count := 0
+    for name in ["a", "b"]
+        label = name
+        handlers = append(handlers, () => print(label))
+        later(() => print(count))
+        count = count + 1
+    for h in handlers
+        h()
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "loop-capture")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 loop-capture diagnostics, got: %v", diags)
+	}
+	if diags[0].Pos.Line != 10 || diags[0].Fix == nil {
+		t.Errorf("expected a fix for label: %v", diags[0])
+	}
+	// count is assigned after the closure, which a copy would take over
+	if diags[1].Pos.Line != 11 || diags[1].Fix != nil {
+		t.Errorf("expected no fix for count: %v", diags[1])
+	}
+	fixed, n := ApplyFixes(source, diags)
+	if n != 1 || !strings.Contains(fixed, "        label = name\n        label := label\n        handlers = append(") {
+		t.Fatalf("unexpected fix (%d applied):\n%s", n, fixed)
+	}
+	if diags := byRule(lintSource(t, fixed, "app.kuki", nil), "loop-capture"); len(diags) != 1 {
+		t.Errorf("expected only count left after fixing, got: %v", diags)
+	}
+}
+
+func TestDisableRule(t *testing.T) {
+	cfg, err := ParseConfig("[lint]\ndisable = [\"naming\"]\n", "kukicha.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := lintSource(t, "func do_it()\n    print(1)\n", "app.kuki", cfg); len(diags) != 0 {
+		t.Errorf("expected no diagnostics with naming disabled, got: %v", diags)
+	}
+}
+
+func TestStdlibHasNoUncheckedErrors(t *testing.T) {
+	// The stdlib is what users read to learn the idioms, so it handles or
+	// explicitly discards every error it drops
+	files, err := filepath.Glob("../../stdlib/*/*.kuki")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no stdlib sources found: %v", err)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.kuki") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range byRule(lintSource(t, string(source), file, nil), UncheckedErrorRule) {
+			t.Errorf("%s", d)
+		}
+	}
+}
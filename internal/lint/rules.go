@@ -0,0 +1,877 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// ---------- onerr-panic-context ----------
+
+// onerrPanicContextRule flags `onerr panic "msg"` whose message drops the
+// error: the panic then says what failed but not why.
+type onerrPanicContextRule struct{}
+
+func (*onerrPanicContextRule) Name() string { return "onerr-panic-context" }
+func (*onerrPanicContextRule) Description() string {
+	return "onerr panic messages should include {error}"
+}
+func (*onerrPanicContextRule) DefaultEnabled() bool { return true }
+
+func (r *onerrPanicContextRule) Check(pass *Pass) {
+	eachFunction(pass.Program, func(_ string, _ ast.Position, body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			var clauses []*ast.OnErrClause
+			for _, e := range ast.OnErrExprs(stmt) {
+				clauses = append(clauses, e.OnErr)
+			}
+			if clause := onErrOf(stmt); clause != nil {
+				clauses = append(clauses, clause)
+			}
+			for _, clause := range clauses {
+				panicExpr, ok := clause.Handler.(*ast.PanicExpr)
+				if !ok {
+					continue
+				}
+				msg, ok := panicExpr.Message.(*ast.StringLiteral)
+				if !ok || mentionsError(msg, clause.Alias) {
+					continue
+				}
+				pos := ast.TokenPos(clause.Token)
+				pass.Report(pos, "onerr panic message does not include the error; add {error} so the cause is not lost", r.fix(pass, clause.Token.Line()))
+			}
+			return false
+		})
+	})
+}
+
+// fix appends ": {error}" inside the closing quote of the panic message on
+// line. It returns nil unless the message is a single-line "..." literal and
+// the line has one onerr.
+func (r *onerrPanicContextRule) fix(pass *Pass, line int) *Fix {
+	if line < 1 || line > len(pass.Lines) {
+		return nil
+	}
+	text := pass.Lines[line-1]
+	start := strings.Index(text, "onerr")
+	if start < 0 || strings.Count(text, "onerr") > 1 {
+		return nil
+	}
+	p := strings.Index(text[start:], "panic")
+	if p < 0 {
+		return nil
+	}
+	rest := strings.TrimLeft(text[start+p+len("panic"):], " ")
+	if !strings.HasPrefix(rest, `"`) {
+		return nil
+	}
+	open := len(text) - len(rest)
+	end := closingQuote(text, open)
+	if end < 0 {
+		return nil
+	}
+	body := text[open+1 : end]
+	insert := ": {error}"
+	switch {
+	case strings.HasSuffix(body, ": "):
+		insert = "{error}"
+	case strings.HasSuffix(body, ":"):
+		insert = " {error}"
+	}
+	return &Fix{Line: line, Offset: end, Insert: insert}
+}
+
+// closingQuote returns the index of the quote that closes the string opened
+// at text[open], or -1.
+func closingQuote(text string, open int) int {
+	for i := open + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// mentionsError reports whether an interpolated message references the
+// caught error ({error}, or the clause's alias).
+func mentionsError(msg *ast.StringLiteral, alias string) bool {
+	for _, part := range msg.Parts {
+		if part.IsLiteral {
+			continue
+		}
+		found := ast.WalkExpr(part.Expr, func(e ast.Expression) bool {
+			id, ok := e.(*ast.Identifier)
+			return ok && (id.Value == "error" || (alias != "" && id.Value == alias))
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func onErrOf(stmt ast.Statement) *ast.OnErrClause {
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		return s.OnErr
+	case *ast.AssignStmt:
+		return s.OnErr
+	case *ast.ExpressionStmt:
+		return s.OnErr
+	}
+	return nil
+}
+
+// ---------- naming ----------
+
+// namingRule flags snake_case names. Kukicha compiles to Go, where names are
+// mixedCaps and the first letter decides visibility.
+type namingRule struct{}
+
+func (*namingRule) Name() string         { return "naming" }
+func (*namingRule) Description() string  { return "names use mixedCaps, not snake_case" }
+func (*namingRule) DefaultEnabled() bool { return true }
+
+func (r *namingRule) Check(pass *Pass) {
+	check := func(id *ast.Identifier, kind string) {
+		if id == nil || !strings.Contains(strings.Trim(id.Value, "_"), "_") {
+			return
+		}
+		pass.Report(id.Pos(), fmt.Sprintf("%s '%s' uses underscores; use mixedCaps ('%s')", kind, id.Value, mixedCaps(id.Value)), nil)
+	}
+
+	for _, decl := range pass.Program.Declarations {
+		switch d := decl.(type) {
+		case *ast.TypeDecl:
+			check(d.Name, "type")
+			for _, f := range d.Fields {
+				check(f.Name, "field")
+			}
+		case *ast.InterfaceDecl:
+			check(d.Name, "interface")
+		case *ast.ConstDecl:
+			for _, spec := range d.Specs {
+				check(spec.Name, "constant")
+			}
+		case *ast.ErrorDecl:
+			for _, spec := range d.Specs {
+				check(spec.Name, "error")
+			}
+		case *ast.VarDeclStmt:
+			for _, n := range d.Names {
+				check(n, "variable")
+			}
+		case *ast.FunctionDecl:
+			if !(pass.IsTestFile() && isTestFuncName(d.Name.Value)) {
+				check(d.Name, "function")
+			}
+			for _, p := range d.Parameters {
+				check(p.Name, "parameter")
+			}
+		}
+	}
+
+	eachFunction(pass.Program, func(_ string, _ ast.Position, body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			switch s := stmt.(type) {
+			case *ast.VarDeclStmt:
+				for _, n := range s.Names {
+					check(n, "variable")
+				}
+			case *ast.ForRangeStmt:
+				check(s.Variable, "variable")
+				check(s.Index, "variable")
+			case *ast.ForNumericStmt:
+				check(s.Variable, "variable")
+			}
+			return false
+		})
+	})
+}
+
+func isTestFuncName(name string) bool {
+	for _, prefix := range []string{"Test", "Benchmark", "Example", "Fuzz"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mixedCaps converts snake_case to mixedCaps, keeping the case of the first
+// letter (and so the name's visibility): max_size → maxSize, MAX_SIZE → MaxSize.
+func mixedCaps(name string) string {
+	trimmed := strings.Trim(name, "_")
+	exported := unicode.IsUpper([]rune(trimmed)[0])
+	var b strings.Builder
+	for i, part := range strings.Split(trimmed, "_") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(strings.ToLower(part))
+		if i > 0 || exported {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// ---------- long-function ----------
+
+// longFunctionRule flags declared functions whose source spans more lines
+// than the max-lines option (default 60).
+type longFunctionRule struct{}
+
+func (*longFunctionRule) Name() string         { return "long-function" }
+func (*longFunctionRule) Description() string  { return "functions longer than max-lines (default 60)" }
+func (*longFunctionRule) DefaultEnabled() bool { return true }
+
+func (r *longFunctionRule) Check(pass *Pass) {
+	limit := pass.Int("max-lines", 60)
+	for _, decl := range pass.Program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		lines := lastLine(fn.Body) - fn.Pos().Line + 1
+		if lines > limit {
+			pass.Report(fn.Pos(), fmt.Sprintf("function '%s' is %d lines long (max %d); split it into smaller functions", fn.Name.Value, lines, limit), nil)
+		}
+	}
+}
+
+// lastLine returns the highest source line of any statement or expression in body.
+func lastLine(body *ast.BlockStmt) int {
+	last := 0
+	ast.WalkStmts(body, func(stmt ast.Statement) bool {
+		last = max(last, stmt.Pos().Line)
+		return false
+	})
+	ast.WalkBlock(body, func(e ast.Expression) bool {
+		last = max(last, e.Pos().Line)
+		return false
+	})
+	return last
+}
+
+// ---------- magic-number ----------
+
+// magicNumberRule flags numeric literals in function bodies other than the
+// values in the allow option (default [0, 1, 2]). Off by default; test files
+// are skipped unless ignore-tests is false.
+type magicNumberRule struct{}
+
+func (*magicNumberRule) Name() string { return "magic-number" }
+func (*magicNumberRule) Description() string {
+	return "unnamed numeric literals in function bodies (off by default)"
+}
+func (*magicNumberRule) DefaultEnabled() bool { return false }
+
+func (r *magicNumberRule) Check(pass *Pass) {
+	if pass.IsTestFile() && pass.Bool("ignore-tests", true) {
+		return
+	}
+	allowed := make(map[string]bool)
+	for _, n := range pass.Ints("allow", []int64{0, 1, 2}) {
+		allowed[strconv.FormatInt(n, 10)] = true
+	}
+	for _, decl := range pass.Program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		ast.WalkBlock(fn.Body, func(e ast.Expression) bool {
+			var text string
+			switch lit := e.(type) {
+			case *ast.IntegerLiteral:
+				text = strconv.FormatInt(lit.Value, 10)
+			case *ast.FloatLiteral:
+				text = strconv.FormatFloat(lit.Value, 'g', -1, 64)
+			default:
+				return false
+			}
+			if !allowed[text] {
+				pass.Report(e.Pos(), fmt.Sprintf("magic number %s; give it a name with a const", text), nil)
+			}
+			return false
+		})
+	}
+}
+
+// ---------- unused-result ----------
+
+// unusedResultRule flags calls used as statements whose single, non-error
+// result is thrown away — often a forgotten assignment, as in
+// `strings.TrimSpace(s)` on its own line. It is off by default: the rule
+// cannot tell those from helpers called for their side effects that also
+// return a value, like a `mustExec(db, sql)` returning the rows affected.
+type unusedResultRule struct{}
+
+func (*unusedResultRule) Name() string         { return "unused-result" }
+func (*unusedResultRule) Description() string  { return "call results that are computed and discarded" }
+func (*unusedResultRule) DefaultEnabled() bool { return false }
+
+func (r *unusedResultRule) Check(pass *Pass) {
+	if pass.ReturnCounts == nil || pass.ExprTypes == nil {
+		return
+	}
+	eachFunction(pass.Program, func(_ string, _ ast.Position, body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			s, ok := stmt.(*ast.ExpressionStmt)
+			if !ok || s.OnErr != nil {
+				return false
+			}
+			switch e := s.Expression.(type) {
+			case *ast.CallExpr:
+				if id, ok := e.Function.(*ast.Identifier); ok && (id.Value == "copy" || id.Value == "recover") {
+					return false
+				}
+			case *ast.MethodCallExpr:
+				if shadowsKukichaStdlib(pass.Program, e) {
+					return false
+				}
+			case *ast.PipeExpr:
+			default:
+				return false
+			}
+			if pass.ReturnCounts[s.Expression] != 1 {
+				return false
+			}
+			ti := pass.ExprTypes[s.Expression]
+			if ti == nil || ti.Kind == semantic.TypeKindUnknown || ti.String() == "error" {
+				return false
+			}
+			pass.Report(calleePos(s.Expression), fmt.Sprintf("result of %s (%s) is not used; assign it, or assign it to _ if that is intentional", callName(s.Expression), ti), nil)
+			return false
+		})
+	})
+}
+
+// shadowsKukichaStdlib reports whether call is pkg.Func on a Go import whose
+// name matches a Kukicha stdlib function (Go's sort.Strings vs stdlib/sort's).
+// The analyzer's registry lookup is by name, so the recorded result type may
+// belong to the Kukicha function.
+func shadowsKukichaStdlib(program *ast.Program, call *ast.MethodCallExpr) bool {
+	obj, ok := call.Object.(*ast.Identifier)
+	if !ok {
+		return false
+	}
+	if _, ok := semantic.GetStdlibEntry(obj.Value + "." + call.Method.Value); !ok {
+		return false
+	}
+	for _, imp := range program.Imports {
+		name := imp.Path.Value[strings.LastIndex(imp.Path.Value, "/")+1:]
+		if imp.Alias != nil {
+			name = imp.Alias.Value
+		}
+		if name == obj.Value {
+			return !strings.HasPrefix(imp.Path.Value, "stdlib/")
+		}
+	}
+	return false
+}
+
+// callName describes the callee of a statement-level call for messages.
+func callName(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		return callName(e.Function)
+	case *ast.Identifier:
+		return "'" + e.Value + "'"
+	case *ast.MethodCallExpr:
+		if obj, ok := e.Object.(*ast.Identifier); ok {
+			return "'" + obj.Value + "." + e.Method.Value + "'"
+		}
+		return "'" + e.Method.Value + "'"
+	case *ast.PipeExpr:
+		return "the pipe"
+	}
+	return "this call"
+}
+
+// calleePos returns the position of the function a call expression calls;
+// the call's own position is its closing parenthesis.
+func calleePos(expr ast.Expression) ast.Position {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		return e.Function.Pos()
+	case *ast.MethodCallExpr:
+		return e.Method.Pos()
+	case *ast.PipeExpr:
+		return calleePos(e.Right)
+	}
+	return expr.Pos()
+}
+
+// ---------- unbounded-loop ----------
+
+// unboundedLoopRule flags the two usual causes of scripts that never finish:
+// a `for true` loop with no way out — no break, return, fail, panic or
+// context check — and a network call without a timeout inside a loop, where
+// one stalled server hangs every later iteration. `for true` loops in main
+// are left alone unless `# shutdown: off` is set, since graceful shutdown
+// stops them on Ctrl-C or SIGTERM.
+type unboundedLoopRule struct{}
+
+func (*unboundedLoopRule) Name() string { return "unbounded-loop" }
+func (*unboundedLoopRule) Description() string {
+	return "for true loops with no exit, and network calls in loops without a timeout"
+}
+func (*unboundedLoopRule) DefaultEnabled() bool { return true }
+
+// untimedCalls are the Go network calls that wait forever on a stalled
+// peer, with what to use instead.
+var untimedCalls = map[string]map[string]string{
+	"net/http": {
+		"Get":      "fetch.Get (30s timeout) or http.NewRequestWithContext with context.WithTimeout",
+		"Head":     "fetch.New(url) |> fetch.Method(\"HEAD\") |> fetch.Do() (30s timeout)",
+		"Post":     "fetch.Post (30s timeout) or http.NewRequestWithContext with context.WithTimeout",
+		"PostForm": "fetch.New(url) |> fetch.FormData(data) |> fetch.Do() (30s timeout)",
+	},
+	"net": {
+		"Dial": "net.DialTimeout, or a net.Dialer's DialContext with context.WithTimeout",
+	},
+}
+
+func (r *unboundedLoopRule) Check(pass *Pass) {
+	imports := importPaths(pass.Program)
+	seen := make(map[ast.Expression]bool)
+	eachFunction(pass.Program, func(name string, _ ast.Position, body *ast.BlockStmt) {
+		stoppedByShutdown := name == "main" && pass.Program.Shutdown != "off"
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			loopBody := loopBodyOf(stmt)
+			if loopBody == nil {
+				return false
+			}
+			if isForTrue(stmt) && !stoppedByShutdown && !exitsLoop(loopBody) && !watchesContext(loopBody) {
+				pass.Report(stmt.Pos(), "for true loop has no break, return or context check and runs until the process is killed; stop it with a deadline from ctx.WithTimeout (checked with ctx.Done) or a break condition", nil)
+			}
+			ast.WalkBlock(loopBody, func(e ast.Expression) bool {
+				call, ok := e.(*ast.MethodCallExpr)
+				if !ok || seen[call] {
+					return false
+				}
+				obj, ok := call.Object.(*ast.Identifier)
+				if !ok {
+					return false
+				}
+				if instead, ok := untimedCalls[imports[obj.Value]][call.Method.Value]; ok {
+					seen[call] = true
+					pass.Report(call.Pos(), fmt.Sprintf("%s.%s in a loop has no timeout, so one stalled server hangs the loop; use %s", obj.Value, call.Method.Value, instead), nil)
+				}
+				return false
+			})
+			return false
+		})
+	})
+}
+
+// loopBodyOf returns the body of a loop statement, or nil.
+func loopBodyOf(stmt ast.Statement) *ast.BlockStmt {
+	switch s := stmt.(type) {
+	case *ast.ForConditionStmt:
+		return s.Body
+	case *ast.ForRangeStmt:
+		return s.Body
+	case *ast.ForNumericStmt:
+		return s.Body
+	}
+	return nil
+}
+
+// isForTrue reports whether stmt is a `for true` loop.
+func isForTrue(stmt ast.Statement) bool {
+	loop, ok := stmt.(*ast.ForConditionStmt)
+	if !ok {
+		return false
+	}
+	lit, ok := loop.Condition.(*ast.BooleanLiteral)
+	return ok && lit.Value
+}
+
+// exitsLoop reports whether body can leave the loop it belongs to: a
+// return, fail, panic or os.Exit anywhere in it, or a break (or onerr break)
+// outside its nested loops, switches and selects. A break in a switch or
+// select only leaves that statement (Kukicha has no labeled break).
+// Statements in go blocks run elsewhere and do not count.
+func exitsLoop(body *ast.BlockStmt) bool {
+	nested := make(map[ast.Statement]bool)
+	async := make(map[ast.Statement]bool)
+	mark := func(block *ast.BlockStmt, set map[ast.Statement]bool) {
+		ast.WalkStmts(block, func(s ast.Statement) bool {
+			set[s] = true
+			return false
+		})
+	}
+	ast.WalkStmts(body, func(stmt ast.Statement) bool {
+		if g, ok := stmt.(*ast.GoStmt); ok {
+			mark(g.Block, async)
+		} else if inner := loopBodyOf(stmt); inner != nil {
+			mark(inner, nested)
+		} else {
+			for _, arm := range switchArmsOf(stmt) {
+				mark(arm, nested)
+			}
+		}
+		return false
+	})
+	return ast.WalkStmts(body, func(stmt ast.Statement) bool {
+		switch {
+		case async[stmt]:
+			return false
+		case nested[stmt]:
+			return leavesFunction(stmt)
+		}
+		return breaksLoop(stmt) || leavesFunction(stmt)
+	})
+}
+
+// switchArmsOf returns the case bodies of a switch, type switch or select,
+// which a plain break leaves.
+func switchArmsOf(stmt ast.Statement) []*ast.BlockStmt {
+	var arms []*ast.BlockStmt
+	switch s := stmt.(type) {
+	case *ast.SwitchStmt:
+		for _, c := range s.Cases {
+			arms = append(arms, c.Body)
+		}
+		if s.Otherwise != nil {
+			arms = append(arms, s.Otherwise.Body)
+		}
+	case *ast.TypeSwitchStmt:
+		for _, c := range s.Cases {
+			arms = append(arms, c.Body)
+		}
+		if s.Otherwise != nil {
+			arms = append(arms, s.Otherwise.Body)
+		}
+	case *ast.SelectStmt:
+		for _, c := range s.Cases {
+			arms = append(arms, c.Body)
+		}
+		if s.Otherwise != nil {
+			arms = append(arms, s.Otherwise.Body)
+		}
+	}
+	return arms
+}
+
+// onErrClauses returns the onerr clauses of stmt, its own and those of its
+// expression-level onerrs.
+func onErrClauses(stmt ast.Statement) []*ast.OnErrClause {
+	var clauses []*ast.OnErrClause
+	if clause := onErrOf(stmt); clause != nil {
+		clauses = append(clauses, clause)
+	}
+	for _, e := range ast.OnErrExprs(stmt) {
+		clauses = append(clauses, e.OnErr)
+	}
+	return clauses
+}
+
+// breaksLoop reports whether stmt is a break, or has an onerr handler that
+// breaks.
+func breaksLoop(stmt ast.Statement) bool {
+	if _, ok := stmt.(*ast.BreakStmt); ok {
+		return true
+	}
+	for _, clause := range onErrClauses(stmt) {
+		if block, ok := clause.Handler.(*ast.BlockExpr); clause.ShorthandBreak || (ok && exitsLoop(block.Body)) {
+			return true
+		}
+	}
+	return false
+}
+
+// leavesFunction reports whether stmt returns from the function or ends
+// the program, itself or in an onerr handler.
+func leavesFunction(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt, *ast.FailStmt:
+		return true
+	case *ast.ExpressionStmt:
+		if isExitCall(s.Expression) {
+			return true
+		}
+	}
+	for _, clause := range onErrClauses(stmt) {
+		switch h := clause.Handler.(type) {
+		case *ast.PanicExpr, *ast.ReturnExpr, *ast.ErrorExpr:
+			return true
+		case *ast.BlockExpr:
+			if ast.WalkStmts(h.Body, leavesFunction) {
+				return true
+			}
+		case nil:
+			// onerr return, and a bare onerr explain, return the error
+			if !clause.ShorthandContinue && !clause.ShorthandBreak {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExitCall reports whether expr is a panic(...) or os.Exit(...) call.
+func isExitCall(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.PanicExpr:
+		return true
+	case *ast.CallExpr:
+		id, ok := e.Function.(*ast.Identifier)
+		return ok && id.Value == "panic"
+	case *ast.MethodCallExpr:
+		obj, ok := e.Object.(*ast.Identifier)
+		return ok && obj.Value == "os" && e.Method.Value == "Exit"
+	}
+	return false
+}
+
+// watchesContext reports whether body checks a context for cancellation:
+// ctx.Done() or ctx.Err() on a context.Context, or stdlib/ctx's
+// ctx.Done(handle) and ctx.Err(handle).
+func watchesContext(body *ast.BlockStmt) bool {
+	return ast.WalkBlock(body, func(e ast.Expression) bool {
+		call, ok := e.(*ast.MethodCallExpr)
+		return ok && (call.Method.Value == "Done" || call.Method.Value == "Err")
+	})
+}
+
+// ---------- unchecked-error ----------
+
+// uncheckedErrorRule flags statements that drop an error result without
+// onerr: a call used as a statement, or an error assigned to _. The analyzer
+// finds them (semantic.Analyzer.UncheckedErrors), so calls to the program's
+// own functions are covered as well as the stdlib's. `kukicha check` reports
+// them too, as warnings or, with `severity = "error"`, as errors.
+type uncheckedErrorRule struct{}
+
+func (*uncheckedErrorRule) Name() string { return UncheckedErrorRule }
+func (*uncheckedErrorRule) Description() string {
+	return "error results dropped by a call statement or assigned to _"
+}
+func (*uncheckedErrorRule) DefaultEnabled() bool { return true }
+
+func (r *uncheckedErrorRule) Check(pass *Pass) {
+	// Tests drop setup errors freely (as with onerr discard)
+	if len(pass.UncheckedErrors) == 0 || pass.IsTestFile() {
+		return
+	}
+	unchecked := make(map[ast.Statement]bool)
+	for _, stmt := range pass.UncheckedErrors {
+		if !cannotFail(pass, droppedCall(stmt)) {
+			unchecked[stmt] = true
+		}
+	}
+	returnsError := errorBodies(pass.Program)
+	eachFunction(pass.Program, func(_ string, _ ast.Position, body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			if !unchecked[stmt] {
+				return false
+			}
+			var fix *Fix
+			if returnsError[body] {
+				fix = r.fix(pass, stmt)
+			}
+			call := droppedCall(stmt)
+			if s, ok := stmt.(*ast.ExpressionStmt); ok {
+				pass.Report(s.Pos(), fmt.Sprintf("error returned by %s is not checked; handle it with onerr", callName(call)), fix)
+			} else {
+				pass.Report(stmt.Pos(), fmt.Sprintf("error returned by %s is discarded with _; handle it with onerr", callName(call)), fix)
+			}
+			return false
+		})
+	})
+}
+
+// fix rewrites a single-line statement to end in `onerr explain "..."`,
+// dropping the _ that received the error. The enclosing function must
+// return an error for explain to pass it on.
+func (r *uncheckedErrorRule) fix(pass *Pass, stmt ast.Statement) *Fix {
+	line := stmt.Pos().Line
+	if line < 1 || line > len(pass.Lines) {
+		return nil
+	}
+	text := pass.Lines[line-1]
+	// Comments, and statements continued on the next line, are left alone
+	if strings.Contains(text, "#") || continuesOnNextLine(pass.Lines, line) {
+		return nil
+	}
+	indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	code := text[len(indent):]
+
+	var names []string
+	op := ""
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		for _, n := range s.Names {
+			names = append(names, n.Value)
+		}
+		op = ":="
+	case *ast.AssignStmt:
+		for _, t := range s.Targets {
+			id, ok := t.(*ast.Identifier)
+			if !ok {
+				return nil
+			}
+			names = append(names, id.Value)
+		}
+		op = "="
+	}
+	if names != nil {
+		rest, ok := strings.CutPrefix(code, strings.Join(names, ", ")+" "+op+" ")
+		if !ok {
+			return nil
+		}
+		code = rest
+		if kept := names[:len(names)-1]; len(kept) > 0 {
+			code = strings.Join(kept, ", ") + " " + op + " " + rest
+		}
+	}
+
+	name := strings.Trim(callName(droppedCall(stmt)), "'")
+	if name == "the pipe" || name == "this call" {
+		name = "call"
+	}
+	newText := indent + code + ` onerr explain "` + name + ` failed"`
+	return &Fix{Line: line, Offset: 0, Delete: len(text), Insert: newText}
+}
+
+// cannotFail reports whether call's error is conventionally ignored: the
+// fmt print functions, and writes to a strings.Builder or bytes.Buffer,
+// which always return a nil error.
+func cannotFail(pass *Pass, call ast.Expression) bool {
+	m, ok := call.(*ast.MethodCallExpr)
+	if !ok {
+		return false
+	}
+	if obj, ok := m.Object.(*ast.Identifier); ok && obj.Value == "fmt" {
+		return strings.HasPrefix(m.Method.Value, "Print") || strings.HasPrefix(m.Method.Value, "Fprint")
+	}
+	ti := pass.ExprTypes[m.Object]
+	if ti != nil && ti.Kind == semantic.TypeKindReference {
+		ti = ti.ElementType
+	}
+	return ti != nil && (ti.Name == "strings.Builder" || ti.Name == "bytes.Buffer") && strings.HasPrefix(m.Method.Value, "Write")
+}
+
+// droppedCall returns the call whose error stmt drops; for a pipe, its
+// last step.
+func droppedCall(stmt ast.Statement) ast.Expression {
+	var call ast.Expression
+	switch s := stmt.(type) {
+	case *ast.ExpressionStmt:
+		call = s.Expression
+	case *ast.VarDeclStmt:
+		call = s.Values[0]
+	case *ast.AssignStmt:
+		call = s.Values[0]
+	}
+	if pipe, ok := call.(*ast.PipeExpr); ok {
+		return pipe.Right
+	}
+	return call
+}
+
+// continuesOnNextLine reports whether the statement on line (1-based) goes
+// on past it: the line ends in an operator or open bracket, or the next
+// non-blank line is indented further.
+func continuesOnNextLine(lines []string, line int) bool {
+	text := strings.TrimSpace(lines[line-1])
+	for _, suffix := range []string{"|>", "(", "[", "{", ","} {
+		if strings.HasSuffix(text, suffix) {
+			return true
+		}
+	}
+	indent := len(lines[line-1]) - len(strings.TrimLeft(lines[line-1], " "))
+	for _, next := range lines[line:] {
+		if strings.TrimSpace(next) == "" {
+			continue
+		}
+		return len(next)-len(strings.TrimLeft(next, " ")) > indent
+	}
+	return false
+}
+
+// errorBodies returns the bodies of the functions and function literals
+// whose last result is an error.
+func errorBodies(program *ast.Program) map[*ast.BlockStmt]bool {
+	bodies := make(map[*ast.BlockStmt]bool)
+	lastIsError := func(returns []ast.TypeAnnotation) bool {
+		if len(returns) == 0 {
+			return false
+		}
+		t, ok := returns[len(returns)-1].(*ast.NamedType)
+		return ok && t.Name == "error"
+	}
+	for _, decl := range program.Declarations {
+		d, ok := decl.(*ast.FunctionDecl)
+		if !ok || d.Body == nil {
+			continue
+		}
+		bodies[d.Body] = lastIsError(d.Returns)
+		ast.WalkBlock(d.Body, func(e ast.Expression) bool {
+			if lit, ok := e.(*ast.FunctionLiteral); ok {
+				bodies[lit.Body] = lastIsError(lit.Returns)
+			}
+			return false
+		})
+	}
+	return bodies
+}
+
+// ---------- loop-capture ----------
+
+// loopCaptureRule flags closures created in a loop (goroutines, defers, and
+// lambdas stored or passed to a call) that capture a variable the loop
+// reassigns. The analyzer finds them (semantic.Analyzer.LoopCaptures) and
+// `kukicha check` already warns; the rule adds a fix that copies the
+// variable into the iteration just before the statement: `total := total`.
+type loopCaptureRule struct{}
+
+func (*loopCaptureRule) Name() string { return "loop-capture" }
+func (*loopCaptureRule) Description() string {
+	return "closures in loops that capture a variable the loop reassigns"
+}
+func (*loopCaptureRule) DefaultEnabled() bool { return true }
+
+func (r *loopCaptureRule) Check(pass *Pass) {
+	for _, c := range pass.LoopCaptures {
+		var fix *Fix
+		if !c.Shared {
+			fix = r.fix(pass, c)
+		}
+		name := c.Ident.Value
+		pass.Report(c.Ident.Pos(), fmt.Sprintf("%s captures '%s', which the enclosing loop reassigns; every iteration shares one variable, so copy it first with '%s := %s'", c.Kind, name, name, name), fix)
+	}
+}
+
+// fix inserts `name := name` on its own line before the statement that
+// creates the closure, at the statement's indentation.
+func (r *loopCaptureRule) fix(pass *Pass, c semantic.LoopCapture) *Fix {
+	line := c.Stmt.Pos().Line
+	if line < 1 || line > len(pass.Lines) {
+		return nil
+	}
+	// The statement must start its line: not continue the one before it
+	if line > 1 {
+		prev := strings.TrimSpace(pass.Lines[line-2])
+		for _, suffix := range []string{"|>", "(", "[", "{", ","} {
+			if strings.HasSuffix(prev, suffix) {
+				return nil
+			}
+		}
+	}
+	text := pass.Lines[line-1]
+	indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	name := c.Ident.Value
+	return &Fix{Line: line, Insert: indent + name + " := " + name + "\n"}
+}
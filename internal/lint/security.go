@@ -0,0 +1,312 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// ---------- shell-injection, sql-injection, html-injection ----------
+
+// Interpolation is the easiest way to build a string in Kukicha, which makes
+// it the easiest way to build a command line, query, or page out of user
+// data. `check` already rejects the most direct forms (an interpolated
+// literal passed straight to pg.Query, a variable passed to shell.Run). These
+// rules follow interpolated strings through local variables, concatenation,
+// and pipes to the argument of a known sink:
+//
+//	query := "SELECT * FROM users WHERE name = '{name}'"
+//	rows := pool |> pg.Query(query) onerr return   # sql-injection
+//
+// A value is tainted when it is an interpolated string with at least one
+// expression hole, a `+` concatenation involving a tainted value, or a local
+// variable last assigned a tainted value. Passing it through any function
+// call (an escaping or validation helper) clears the taint.
+
+// injectionRule reports tainted strings reaching the sinks of one kind.
+type injectionRule struct {
+	name string
+	kind string // "shell", "sql", or "html"
+	desc string
+}
+
+func (r *injectionRule) Name() string        { return r.name }
+func (r *injectionRule) Description() string { return r.desc }
+func (*injectionRule) DefaultEnabled() bool  { return true }
+func (r *injectionRule) Check(pass *Pass) {
+	imports := importPaths(pass.Program)
+	seen := make(map[ast.Expression]bool)
+	eachFunction(pass.Program, func(_ string, _ ast.Position, body *ast.BlockStmt) {
+		tainted := make(map[string]bool)
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			for _, e := range ownExpressions(stmt) {
+				r.checkExpr(pass, imports, tainted, seen, e)
+			}
+			trackTaint(stmt, tainted)
+			return false
+		})
+	})
+}
+
+// checkExpr reports every sink call in expr whose sensitive argument is
+// tainted. Piped calls are checked with the piped value in its argument slot.
+func (r *injectionRule) checkExpr(pass *Pass, imports map[string]string, tainted map[string]bool, seen map[ast.Expression]bool, expr ast.Expression) {
+	ast.WalkExpr(expr, func(e ast.Expression) bool {
+		var call *ast.MethodCallExpr
+		var args []ast.Expression
+		switch e := e.(type) {
+		case *ast.PipeExpr:
+			method, ok := e.Right.(*ast.MethodCallExpr)
+			if !ok {
+				return false
+			}
+			call, args = method, pipedArguments(e.Left, method.Arguments)
+		case *ast.MethodCallExpr:
+			call, args = e, e.Arguments
+		default:
+			return false
+		}
+		if seen[call] {
+			return false
+		}
+		s, ok := findSink(pass, imports, call, args)
+		if !ok || s.kind != r.kind || s.arg >= len(args) || !isTainted(args[s.arg], tainted) {
+			return false
+		}
+		seen[call] = true
+		pass.Report(call.Pos(), fmt.Sprintf(s.message, describeTainted(args[s.arg]), s.name), nil)
+		return false
+	})
+}
+
+// pipedArguments returns the arguments a piped call receives: left fills the
+// `_` placeholder when there is one and is prepended otherwise.
+func pipedArguments(left ast.Expression, explicit []ast.Expression) []ast.Expression {
+	args := make([]ast.Expression, 0, len(explicit)+1)
+	placed := false
+	for _, a := range explicit {
+		if id, ok := a.(*ast.Identifier); ok && id.Value == "_" && !placed {
+			args = append(args, left)
+			placed = true
+			continue
+		}
+		args = append(args, a)
+	}
+	if !placed {
+		args = append([]ast.Expression{left}, args...)
+	}
+	return args
+}
+
+// sink describes the sensitive argument of a call.
+type sink struct {
+	kind    string
+	name    string // qualified name for messages, e.g. "pg.Query"
+	arg     int    // index of the sensitive argument, piped value included
+	message string // format with the tainted value and name
+}
+
+const (
+	shellSinkMessage    = "command injection risk: %s reaches %s; pass untrusted values as separate arguments with shell.Output(name, args...)"
+	sqlSinkMessage      = "SQL injection risk: %s reaches %s; use parameter placeholders ($1, $2, ...) and pass values as arguments"
+	htmlSinkMessage     = "XSS risk: %s reaches %s; use http.SafeHTML or template.HTMLExecute so values are escaped"
+	templateSinkMessage = "template injection risk: %s is used as template source in %s; pass values through template data instead"
+)
+
+// goSQLReceivers are the database/sql types whose query methods are sinks.
+var goSQLReceivers = map[string]bool{"sql.DB": true, "sql.Tx": true, "sql.Conn": true}
+
+// findSink reports whether call is a known sink and which argument matters.
+func findSink(pass *Pass, imports map[string]string, call *ast.MethodCallExpr, args []ast.Expression) (sink, bool) {
+	method := call.Method.Value
+	obj, ok := call.Object.(*ast.Identifier)
+	path := ""
+	if ok {
+		path = imports[obj.Value]
+	}
+	if path == "" {
+		// A method on a database/sql handle: db.Query(q, args...).
+		if !goSQLReceivers[namedTypeOf(pass.ExprTypes[call.Object])] {
+			return sink{}, false
+		}
+		name := namedTypeOf(pass.ExprTypes[call.Object]) + "." + method
+		switch method {
+		case "Query", "QueryRow", "Exec", "Prepare":
+			return sink{kind: "sql", name: name, arg: 0, message: sqlSinkMessage}, true
+		case "QueryContext", "QueryRowContext", "ExecContext", "PrepareContext":
+			return sink{kind: "sql", name: name, arg: 1, message: sqlSinkMessage}, true
+		}
+		return sink{}, false
+	}
+
+	name := obj.Value + "." + method
+	switch path {
+	case "stdlib/shell":
+		switch method {
+		case "Run":
+			return sink{kind: "shell", name: name, arg: 0, message: shellSinkMessage}, true
+		case "Output", "New":
+			if isShellDashC(args, 0) {
+				return sink{kind: "shell", name: name, arg: 2, message: shellSinkMessage}, true
+			}
+		}
+	case "os/exec":
+		switch method {
+		case "Command":
+			if isShellDashC(args, 0) {
+				return sink{kind: "shell", name: name, arg: 2, message: shellSinkMessage}, true
+			}
+		case "CommandContext":
+			if isShellDashC(args, 1) {
+				return sink{kind: "shell", name: name, arg: 3, message: shellSinkMessage}, true
+			}
+		}
+	case "stdlib/template":
+		switch method {
+		case "Render", "Parse", "RenderSimple":
+			return sink{kind: "html", name: name, arg: 0, message: templateSinkMessage}, true
+		case "WithContent":
+			return sink{kind: "html", name: name, arg: 1, message: templateSinkMessage}, true
+		}
+	case "html/template":
+		switch method {
+		case "HTML", "HTMLAttr", "JS", "CSS", "URL", "Srcset":
+			return sink{kind: "html", name: name, arg: 0, message: htmlSinkMessage}, true
+		}
+	}
+	if strings.HasPrefix(path, "stdlib/") {
+		qualified := path[len("stdlib/"):] + "." + method
+		switch semantic.GetSecurityCategory(qualified) {
+		case "sql":
+			return sink{kind: "sql", name: name, arg: 1, message: sqlSinkMessage}, true
+		case "html":
+			return sink{kind: "html", name: name, arg: 1, message: htmlSinkMessage}, true
+		}
+	}
+	return sink{}, false
+}
+
+// isShellDashC reports whether args[i:] starts with a shell name and "-c", so
+// the next argument is parsed as a shell script.
+func isShellDashC(args []ast.Expression, i int) bool {
+	if i+1 >= len(args) {
+		return false
+	}
+	shell, ok1 := args[i].(*ast.StringLiteral)
+	flag, ok2 := args[i+1].(*ast.StringLiteral)
+	if !ok1 || !ok2 || shell.Interpolated || flag.Interpolated || flag.Value != "-c" {
+		return false
+	}
+	switch shell.Value[strings.LastIndex(shell.Value, "/")+1:] {
+	case "sh", "bash", "zsh", "dash", "ksh":
+		return true
+	}
+	return false
+}
+
+// namedTypeOf returns the name of ti's named type, looking through a
+// reference, or "".
+func namedTypeOf(ti *semantic.TypeInfo) string {
+	if ti != nil && ti.Kind == semantic.TypeKindReference {
+		ti = ti.ElementType
+	}
+	if ti == nil || ti.Kind != semantic.TypeKindNamed {
+		return ""
+	}
+	return ti.Name
+}
+
+// importPaths maps the names a program uses for its imports to their paths.
+func importPaths(program *ast.Program) map[string]string {
+	paths := make(map[string]string)
+	for _, imp := range program.Imports {
+		name := imp.Path.Value[strings.LastIndex(imp.Path.Value, "/")+1:]
+		if imp.Alias != nil {
+			name = imp.Alias.Value
+		}
+		paths[name] = imp.Path.Value
+	}
+	return paths
+}
+
+// ownExpressions returns the expressions evaluated by stmt itself, leaving
+// out nested blocks (WalkStmts visits their statements separately).
+func ownExpressions(stmt ast.Statement) []ast.Expression {
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		return s.Values
+	case *ast.AssignStmt:
+		return s.Values
+	case *ast.ExpressionStmt:
+		return []ast.Expression{s.Expression}
+	case *ast.ReturnStmt:
+		return s.Values
+	case *ast.YieldStmt:
+		return s.Values
+	case *ast.DeferStmt:
+		return []ast.Expression{s.Call}
+	case *ast.GoStmt:
+		if s.Call != nil {
+			return []ast.Expression{s.Call}
+		}
+	case *ast.SendStmt:
+		return []ast.Expression{s.Value}
+	}
+	return nil
+}
+
+// trackTaint updates tainted for the local variables stmt assigns.
+func trackTaint(stmt ast.Statement, tainted map[string]bool) {
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		if len(s.Names) != len(s.Values) {
+			for _, n := range s.Names {
+				delete(tainted, n.Value)
+			}
+			return
+		}
+		for i, n := range s.Names {
+			tainted[n.Value] = isTainted(s.Values[i], tainted)
+		}
+	case *ast.AssignStmt:
+		for i, t := range s.Targets {
+			id, ok := t.(*ast.Identifier)
+			if !ok {
+				continue
+			}
+			tainted[id.Value] = len(s.Targets) == len(s.Values) && isTainted(s.Values[i], tainted)
+		}
+	}
+}
+
+// isTainted reports whether expr is an unvalidated interpolated string.
+func isTainted(expr ast.Expression, tainted map[string]bool) bool {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		if !e.Interpolated {
+			return false
+		}
+		for _, part := range e.Parts {
+			if !part.IsLiteral {
+				return true
+			}
+		}
+	case *ast.Identifier:
+		return tainted[e.Value]
+	case *ast.BinaryExpr:
+		return e.Operator == "+" && (isTainted(e.Left, tainted) || isTainted(e.Right, tainted))
+	case *ast.TypeCastExpr:
+		return isTainted(e.Expression, tainted)
+	}
+	return false
+}
+
+// describeTainted names the tainted argument for messages.
+func describeTainted(expr ast.Expression) string {
+	if id, ok := expr.(*ast.Identifier); ok {
+		return fmt.Sprintf("interpolated string '%s'", id.Value)
+	}
+	return "interpolated string"
+}
@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShellInjection(t *testing.T) {
+	source := `import "stdlib/shell"
+import "os/exec"
+
+func Quote(s string) string
+    return s
+
+func Clean(path string)
+    shell.Run("rm -rf {path}") onerr discard
+    script := "ls " + "{path}"
+    exec.Command("sh", "-c", script).Run() onerr discard
+    "echo {path}" |> shell.Run() onerr discard
+    safe := "ls {Quote(path)}" |> Quote()
+    shell.Output("sh", "-c", safe) onerr discard
+    shell.Output("ls", "{path}") onerr discard
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "shell-injection")
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 shell-injection diagnostics, got: %v", diags)
+	}
+	lines := []int{8, 10, 11}
+	for i, d := range diags {
+		if d.Pos.Line != lines[i] {
+			t.Errorf("diagnostic %d on line %d, want %d: %v", i, d.Pos.Line, lines[i], d)
+		}
+	}
+	if !strings.Contains(diags[1].Message, "'script' reaches exec.Command") {
+		t.Errorf("expected message to name the variable and sink, got: %s", diags[1].Message)
+	}
+}
+
+func TestSQLInjection(t *testing.T) {
+	source := `import "stdlib/pg"
+import "database/sql"
+
+func Find(pool pg.Pool, name string)
+    q := "SELECT * FROM users WHERE name = '{name}'"
+    rows := pool |> pg.Query(q) onerr discard
+    defer rows.Close()
+
+func Delete(db reference sql.DB, name string)
+    q := "DELETE FROM users WHERE name = '{name}'"
+    db.Exec(q) onerr discard
+    q = "DELETE FROM users WHERE name = $1"
+    db.Exec(q, name) onerr discard
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "sql-injection")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 sql-injection diagnostics, got: %v", diags)
+	}
+	if diags[0].Pos.Line != 6 || diags[1].Pos.Line != 11 {
+		t.Errorf("unexpected diagnostic lines: %v", diags)
+	}
+}
+
+func TestHTMLInjection(t *testing.T) {
+	source := `import "stdlib/http"
+import "stdlib/template"
+
+func Greet(w http.ResponseWriter, name string)
+    http.HTML(w, "<p>Hello {name}</p>")
+    src := "Hello {name}"
+    out := template.Render(src) |> template.Execute() onerr discard
+    print(out)
+    http.HTML(w, "<p>Hello</p>")
+`
+	diags := byRule(lintSource(t, source, "app.kuki", nil), "html-injection")
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 html-injection diagnostics, got: %v", diags)
+	}
+	if !strings.Contains(diags[1].Message, "template source") {
+		t.Errorf("expected template source message, got: %s", diags[1].Message)
+	}
+}
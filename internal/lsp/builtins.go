@@ -11,11 +11,14 @@ type BuiltinInfo struct {
 // used by both completion and hover handlers.
 var builtins = []BuiltinInfo{
 	{"print", "func print(args ...any)", "Prints values to stdout"},
+	{"write", "func write(args ...any)", "Prints values to stdout without a trailing newline"},
+	{"read", "read line | read all", "Reads a line (without its line ending) or the rest of stdin: (string, error)"},
 	{"len", "func len(v any) int", "Returns the length of a string, list, or map"},
 	{"append", "func append(slice []T, elems ...T) []T", "Appends elements to a slice"},
 	{"make", "func make(T type, size ...int) T", "Creates a slice, map, or channel"},
 	{"min", "func min(x T, y T, rest ...T) T", "Returns the minimum of its arguments (Go 1.21+)"},
 	{"max", "func max(x T, y T, rest ...T) T", "Returns the maximum of its arguments (Go 1.21+)"},
+	{"delete", "func delete(m map[K]V, key K)", "Removes a key from a map"},
 	{"close", "func close(ch chan T)", "Closes a channel"},
 	{"panic", "func panic(v any)", "Stops normal execution and begins panicking"},
 	{"recover", "func recover() any", "Regains control of a panicking goroutine"},
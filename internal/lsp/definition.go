@@ -138,5 +138,5 @@ func (s *Server) findDefinition(doc *Document, word string) *lsp.Location {
 		}
 	}
 
-	return nil
+	return s.findWorkspaceDefinition(doc, word)
 }
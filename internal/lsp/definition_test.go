@@ -1,6 +1,8 @@
 package lsp
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sourcegraph/go-lsp"
@@ -182,3 +184,45 @@ func GetUser() User
 		t.Errorf("expected author at line 5, got %d", authorLoc.Range.Start.Line)
 	}
 }
+
+func TestFindDefinition_WorkspaceImport(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"kukicha.work":        "use ./shared\nuse ./app\n",
+		"shared/go.mod":       "module example.com/shared\n",
+		"shared/greet/x.kuki": "petiole greet\n\nfunc helper() string\n    return \"hi\"\n",
+		"shared/greet/g.kuki": "petiole greet\n\nfunc Hello(name string) string\n    return \"hello {name}\"\n",
+		"app/go.mod":          "module example.com/app\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewServer(nil, nil)
+	uri := lsp.DocumentURI("file://" + filepath.ToSlash(filepath.Join(dir, "app", "main.kuki")))
+	s.documents.Open(uri, `import "example.com/shared/greet"
+
+func main()
+    print(greet.Hello("x"))
+`, 1)
+	doc := s.documents.Get(uri)
+
+	loc := s.findDefinition(doc, "Hello")
+	if loc == nil {
+		t.Fatal("expected definition location for 'Hello'")
+	}
+	wantURI := lsp.DocumentURI("file://" + filepath.ToSlash(filepath.Join(dir, "shared", "greet", "g.kuki")))
+	// "Hello" is declared on line 3 of g.kuki (0-indexed: 2)
+	if loc.URI != wantURI || loc.Range.Start.Line != 2 {
+		t.Errorf("got %s line %d, want %s line 2", loc.URI, loc.Range.Start.Line, wantURI)
+	}
+	if loc := s.findDefinition(doc, "helper"); loc != nil {
+		t.Errorf("unexported helper should not resolve, got %+v", loc)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/sourcegraph/go-lsp"
 )
 
@@ -71,6 +72,7 @@ func errorToDiagnostic(err error) lsp.Diagnostic {
 			End:   lsp.Position{Line: line, Character: col + 1},
 		},
 		Severity: lsp.Error,
+		Code:     string(catalog.IDOf(err)),
 		Source:   "kukicha",
 		Message:  message,
 	}
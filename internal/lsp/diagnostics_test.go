@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/sourcegraph/go-lsp"
 )
 
@@ -108,3 +109,18 @@ func TestErrorToDiagnostic_MessageWithColons(t *testing.T) {
 		t.Errorf("expected line 1, got %d", diag.Range.Start.Line)
 	}
 }
+
+func TestErrorToDiagnostic_CatalogCode(t *testing.T) {
+	err := catalog.Errorf("test.kuki", 3, 4, catalog.UndefinedIdentifier, "foo")
+	diag := errorToDiagnostic(err)
+
+	if diag.Code != "K0301" {
+		t.Errorf("expected code K0301, got %q", diag.Code)
+	}
+	if diag.Message != "undefined identifier 'foo'" {
+		t.Errorf("expected catalog message, got: %s", diag.Message)
+	}
+	if plain := errorToDiagnostic(errors.New("test.kuki:1:1: syntax error")); plain.Code != "" {
+		t.Errorf("expected no code for an uncatalogued error, got %q", plain.Code)
+	}
+}
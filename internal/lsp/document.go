@@ -3,6 +3,7 @@ package lsp
 import (
 	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -21,8 +22,11 @@ type Document struct {
 	// Cached analysis results
 	Program     *ast.Program
 	SymbolTable *semantic.SymbolTable
+	Captures    map[ast.Node][]semantic.Capture // Variables each closure and go block captures
 	Errors      []error
 	Lines       []string
+
+	lineStarts []int32 // Byte offset of each line in Content; never modified, so clones share it
 }
 
 // DocumentStore manages all open documents
@@ -38,6 +42,7 @@ func newDocument(uri lsp.DocumentURI, content string, version int) *Document {
 		Version: version,
 		Lines:   strings.Split(content, "\n"),
 	}
+	doc.lineStarts = lineTable(doc.Lines)
 	doc.analyze()
 	return doc
 }
@@ -90,7 +95,7 @@ func (ds *DocumentStore) Get(uri lsp.DocumentURI) *Document {
 }
 
 // cloneDocument returns a shallow copy of doc with deep-copied slices.
-// Program and SymbolTable are shared pointers; callers must treat them as read-only.
+// Program, SymbolTable and Captures are shared; callers must treat them as read-only.
 func cloneDocument(doc *Document) *Document {
 	if doc == nil {
 		return nil
@@ -102,6 +107,8 @@ func cloneDocument(doc *Document) *Document {
 		Version:     doc.Version,
 		Program:     doc.Program,
 		SymbolTable: doc.SymbolTable,
+		Captures:    doc.Captures,
+		lineStarts:  doc.lineStarts,
 	}
 	if len(doc.Errors) > 0 {
 		cloned.Errors = append([]error(nil), doc.Errors...)
@@ -134,6 +141,7 @@ func (doc *Document) analyze() {
 		analyzer := semantic.New(program)
 		semanticErrors := analyzer.Analyze()
 		doc.Errors = append(doc.Errors, semanticErrors...)
+		doc.Captures = analyzer.Captures()
 	}
 }
 
@@ -145,36 +153,40 @@ func (doc *Document) GetLineContent(line int) string {
 	return doc.Lines[line]
 }
 
-// PositionToOffset converts an LSP position to a byte offset
-func (doc *Document) PositionToOffset(pos lsp.Position) int {
+// lineTable returns the byte offset at which each of lines starts in the
+// content they were split from.
+func lineTable(lines []string) []int32 {
+	starts := make([]int32, len(lines))
 	offset := 0
-	for i := 0; i < int(pos.Line) && i < len(doc.Lines); i++ {
-		offset += len(doc.Lines[i]) + 1 // +1 for newline
+	for i, line := range lines {
+		starts[i] = int32(offset)
+		offset += len(line) + 1 // +1 for newline
 	}
-	if int(pos.Line) < len(doc.Lines) {
-		offset += utf16PosToByteOffset(doc.Lines[int(pos.Line)], int(pos.Character))
+	return starts
+}
+
+// PositionToOffset converts an LSP position to a byte offset
+func (doc *Document) PositionToOffset(pos lsp.Position) int {
+	line := int(pos.Line)
+	if line >= len(doc.lineStarts) {
+		return len(doc.Content) + 1 // Past the last line's newline
 	}
-	return offset
+	return int(doc.lineStarts[line]) + utf16PosToByteOffset(doc.Lines[line], int(pos.Character))
 }
 
 // OffsetToPosition converts a byte offset to an LSP position
 func (doc *Document) OffsetToPosition(offset int) lsp.Position {
-	currentOffset := 0
-	for line, content := range doc.Lines {
-		lineEnd := currentOffset + len(content) + 1 // +1 for newline
-		if offset < lineEnd {
-			byteInLine := min(max(offset-currentOffset, 0), len(content))
-			return lsp.Position{
-				Line:      line,
-				Character: byteOffsetToUTF16Pos(content, byteInLine),
-			}
-		}
-		currentOffset = lineEnd
+	// The last line starting at or before offset; offsets past the end
+	// land at the end of the document
+	line, found := slices.BinarySearch(doc.lineStarts, int32(min(offset, len(doc.Content))))
+	if !found {
+		line = max(line-1, 0)
 	}
-	// Return end of document
+	content := doc.Lines[line]
+	byteInLine := min(max(offset-int(doc.lineStarts[line]), 0), len(content))
 	return lsp.Position{
-		Line:      len(doc.Lines) - 1,
-		Character: byteOffsetToUTF16Pos(doc.Lines[len(doc.Lines)-1], len(doc.Lines[len(doc.Lines)-1])),
+		Line:      line,
+		Character: byteOffsetToUTF16Pos(content, byteInLine),
 	}
 }
 
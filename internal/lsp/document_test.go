@@ -31,3 +31,34 @@ func TestDocumentStoreGetReturnsSnapshot(t *testing.T) {
 	}
 }
 
+
+func TestPositionOffsetConversions(t *testing.T) {
+	doc := newDocument("file:///tmp/test.kuki", "func A()\n    s := \"é\"\n\nx", 1)
+	for _, tc := range []struct {
+		pos    lsp.Position
+		offset int
+	}{
+		{lsp.Position{Line: 0, Character: 0}, 0},
+		{lsp.Position{Line: 0, Character: 5}, 5},
+		{lsp.Position{Line: 1, Character: 4}, 13},
+		{lsp.Position{Line: 1, Character: 11}, 21}, // After the 2-byte é
+		{lsp.Position{Line: 2, Character: 0}, 23},
+		{lsp.Position{Line: 3, Character: 1}, 25},
+	} {
+		if got := doc.PositionToOffset(tc.pos); got != tc.offset {
+			t.Errorf("PositionToOffset(%v) = %d, want %d", tc.pos, got, tc.offset)
+		}
+		if got := doc.OffsetToPosition(tc.offset); got != tc.pos {
+			t.Errorf("OffsetToPosition(%d) = %v, want %v", tc.offset, got, tc.pos)
+		}
+	}
+	if got, want := doc.OffsetToPosition(100), (lsp.Position{Line: 3, Character: 1}); got != want {
+		t.Errorf("expected an offset past the end to map to the end, got %v", got)
+	}
+	if got, want := doc.OffsetToPosition(-3), (lsp.Position{Line: 0, Character: 0}); got != want {
+		t.Errorf("expected a negative offset to map to the start, got %v", got)
+	}
+	if got := cloneDocument(doc).PositionToOffset(lsp.Position{Line: 1, Character: 4}); got != 13 {
+		t.Errorf("expected a cloned document to convert positions too, got %d", got)
+	}
+}
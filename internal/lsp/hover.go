@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/semantic"
 	"github.com/sourcegraph/go-lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -61,6 +62,11 @@ func (s *Server) getHoverContent(doc *Document, word string, pos lsp.Position) s
 		return ""
 	}
 
+	// A go block, function literal or arrow lambda at the cursor: what it captures
+	if captures := closureCapturesAt(doc, word, pos); captures != "" {
+		return captures
+	}
+
 	// Check builtins first
 	if builtin := getBuiltinInfo(word); builtin != "" {
 		return builtin
@@ -92,6 +98,54 @@ func (s *Server) getHoverContent(doc *Document, word string, pos lsp.Position) s
 	return ""
 }
 
+// closureCapturesAt describes the captures of the closure the cursor is on:
+// the go or func keyword of a go block or function literal, or a parameter
+// of an arrow lambda, on the line where the closure starts.
+func closureCapturesAt(doc *Document, word string, pos lsp.Position) string {
+	line := int(pos.Line) + 1
+	for node, captures := range doc.Captures {
+		if node.Pos().Line != line {
+			continue
+		}
+		switch n := node.(type) {
+		case *ast.GoStmt:
+			if word == "go" {
+				return formatCaptures("go block", captures)
+			}
+		case *ast.FunctionLiteral:
+			if word == "func" {
+				return formatCaptures("func literal", captures)
+			}
+		case *ast.ArrowLambda:
+			for _, p := range n.Parameters {
+				if p.Name.Value == word {
+					return formatCaptures("lambda", captures)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// formatCaptures lists captured variables with their types. Copied
+// variables are passed to the goroutine when it starts; shared ones are
+// the enclosing function's variables.
+func formatCaptures(kind string, captures []semantic.Capture) string {
+	if len(captures) == 0 {
+		return kind + " captures nothing"
+	}
+	var b strings.Builder
+	b.WriteString(kind + " captures:")
+	for _, c := range captures {
+		mode := "shared"
+		if c.ByValue {
+			mode = "copied"
+		}
+		b.WriteString(fmt.Sprintf("\n    %s %s (%s)", c.Name, c.Type, mode))
+	}
+	return b.String()
+}
+
 // getBuiltinInfo returns documentation for builtin functions
 // using the shared builtin registry in builtins.go.
 func getBuiltinInfo(name string) string {
@@ -138,6 +192,17 @@ func formatFunctionDecl(decl *ast.FunctionDecl) string {
 			result.WriteString(")")
 		}
 	}
+	for i, y := range decl.Yields {
+		if len(decl.Returns) > 0 {
+			break // a generator shows its `sequence of T`
+		}
+		if i == 0 {
+			result.WriteString(" yields ")
+		} else {
+			result.WriteString(", ")
+		}
+		result.WriteString(formatTypeAnnotation(y))
+	}
 
 	return result.String()
 }
@@ -211,7 +276,12 @@ func formatTypeAnnotation(t ast.TypeAnnotation) string {
 	case *ast.MapType:
 		return fmt.Sprintf("map of %s to %s", formatTypeAnnotation(ta.KeyType), formatTypeAnnotation(ta.ValueType))
 	case *ast.ChannelType:
+		if ta.Direction != "" {
+			return "channel of " + formatTypeAnnotation(ta.ElementType) + " " + ta.Direction
+		}
 		return "channel of " + formatTypeAnnotation(ta.ElementType)
+	case *ast.SequenceType:
+		return "sequence of " + formatTypeAnnotation(ta.ElementType)
 	case *ast.FunctionType:
 		var result strings.Builder
 		result.WriteString("func(")
@@ -332,6 +402,10 @@ func lastLineInBlock(block *ast.BlockStmt) int {
 		if end := lastLineInBlock(s.Body); end > line {
 			line = end
 		}
+	case *ast.RequireStmt:
+		if end := lastLineInBlock(s.Else); end > line {
+			line = end
+		}
 	case *ast.SwitchStmt:
 		for _, c := range s.Cases {
 			if end := lastLineInBlock(c.Body); end > line {
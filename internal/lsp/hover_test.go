@@ -321,3 +321,44 @@ func TestLookupBuiltin_Unknown(t *testing.T) {
 		t.Errorf("expected empty for unknown builtin, got: %s", result)
 	}
 }
+
+func TestGetHoverContent_GoBlockCaptures(t *testing.T) {
+	s := NewServer(nil, nil)
+	store := s.documents
+	uri := lsp.DocumentURI("file:///tmp/test.kuki")
+	store.Open(uri, `func main()
+    current := ""
+    count := 0
+    for name in ["a", "b"]
+        current = name
+        go
+            print("{current} {count}")
+`, 1)
+
+	doc := store.Get(uri)
+	content := s.getHoverContent(doc, "go", lsp.Position{Line: 5, Character: 9})
+
+	for _, want := range []string{"go block captures:", "current string (copied)", "count int (shared)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in hover, got: %s", want, content)
+		}
+	}
+}
+
+func TestGetHoverContent_LambdaCaptures(t *testing.T) {
+	s := NewServer(nil, nil)
+	store := s.documents
+	uri := lsp.DocumentURI("file:///tmp/test.kuki")
+	store.Open(uri, `import "stdlib/slice"
+
+func label(names list of string, prefix string) list of string
+    return names |> slice.Map((n string) => "{prefix}{n}")
+`, 1)
+
+	doc := store.Get(uri)
+	content := s.getHoverContent(doc, "n", lsp.Position{Line: 3, Character: 31})
+
+	if !strings.Contains(content, "lambda captures:") || !strings.Contains(content, "prefix string (shared)") {
+		t.Errorf("expected lambda captures in hover, got: %s", content)
+	}
+}
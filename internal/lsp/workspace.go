@@ -0,0 +1,83 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/workspace"
+	"github.com/sourcegraph/go-lsp"
+)
+
+// findWorkspaceDefinition looks for an exported declaration named word in
+// the packages doc imports from the projects of its kukicha.work, so go to
+// definition on a shared library's function opens its .kuki source.
+func (s *Server) findWorkspaceDefinition(doc *Document, word string) *lsp.Location {
+	if len(doc.Program.Imports) == 0 || !unicode.IsUpper([]rune(word)[0]) {
+		return nil
+	}
+	ws, err := workspace.ForDir(filepath.Dir(uriToFilename(doc.URI)))
+	if err != nil || ws == nil {
+		return nil
+	}
+	for _, imp := range doc.Program.Imports {
+		dir, ok := ws.Resolve(imp.Path.Value)
+		if !ok {
+			continue
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "*.kuki"))
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.kuki") {
+				continue
+			}
+			if name := findTopLevelName(file, word); name != nil {
+				pos := name.Pos()
+				return &lsp.Location{
+					URI: lsp.DocumentURI("file://" + filepath.ToSlash(file)),
+					Range: lsp.Range{
+						Start: lsp.Position{Line: pos.Line - 1, Character: pos.Column - 1},
+						End:   lsp.Position{Line: pos.Line - 1, Character: pos.Column - 1 + len(word)},
+					},
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findTopLevelName returns the name of the function, type or interface
+// called word declared in file, or nil.
+func findTopLevelName(file, word string) *ast.Identifier {
+	source, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	p, err := parser.New(string(source), file)
+	if err != nil {
+		return nil
+	}
+	program, _ := p.Parse()
+	if program == nil {
+		return nil
+	}
+	for _, decl := range program.Declarations {
+		switch d := decl.(type) {
+		case *ast.FunctionDecl:
+			if d.Receiver == nil && d.Name.Value == word {
+				return d.Name
+			}
+		case *ast.TypeDecl:
+			if d.Name.Value == word {
+				return d.Name
+			}
+		case *ast.InterfaceDecl:
+			if d.Name.Value == word {
+				return d.Name
+			}
+		}
+	}
+	return nil
+}
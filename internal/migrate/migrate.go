@@ -0,0 +1,180 @@
+// Package migrate implements `kukicha fix --migrate <name>`: named rewrites
+// that move .kuki sources off retired syntax and deprecated APIs.
+//
+// A Migration reads the parsed program and returns text edits on token
+// spans of the original source. Apply splices them in and leaves every other
+// byte alone, so blank lines, comments and hand formatting survive. Files a
+// migration does not change are returned as is.
+//
+// To add a migration, write a function that walks the program (usually with
+// ast.RewriteProgram, returning each expression unchanged) and returns an
+// Edit per site, then list it in registry. File.Replace and File.Call turn
+// AST tokens into spans.
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/lexer"
+	"github.com/duber000/kukicha/internal/parser"
+)
+
+// Migration is one named source rewrite.
+type Migration struct {
+	Name        string
+	Description string
+	// Edits returns the edits that migrate f.
+	Edits func(f *File) []Edit
+}
+
+// Edit replaces the source runes [Start, End) with Text. Offsets are rune
+// offsets, as in lexer.Token.Offset.
+type Edit struct {
+	Start, End int
+	Text       string
+}
+
+// File is a parsed source handed to a migration.
+type File struct {
+	Program *ast.Program
+	Tokens  []lexer.Token
+	source  []rune
+}
+
+var registry = []*Migration{
+	{
+		Name:        "go-conversions",
+		Description: "Go-style conversions like string(x) become x as string",
+		Edits:       goConversions,
+	},
+	{
+		Name:        "deprecated-calls",
+		Description: `calls to functions marked # kuki:deprecated "Use X instead" call X`,
+		Edits:       deprecatedCalls,
+	},
+}
+
+// maxPasses bounds how often Apply reparses to retry edits that overlapped
+// an applied one, as in string(int64(x)).
+const maxPasses = 8
+
+// Migrations returns every migration, sorted by name.
+func Migrations() []*Migration {
+	out := append([]*Migration(nil), registry...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Lookup returns the migration called name, or nil.
+func Lookup(name string) *Migration {
+	for _, m := range registry {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Apply runs m over source and returns the rewritten source and the number
+// of rewrites. When there are none, source is returned unchanged. Go-style
+// brace preprocessing is off: migrations only take sources that already parse.
+func Apply(m *Migration, source, filename string) (string, int, error) {
+	count := 0
+	for pass := 0; pass < maxPasses; pass++ {
+		f, err := parse(source, filename)
+		if err != nil {
+			return "", 0, err
+		}
+		out, applied, skipped := f.apply(m.Edits(f))
+		source = out
+		count += applied
+		if skipped == 0 {
+			break
+		}
+	}
+	return source, count, nil
+}
+
+func parse(source, filename string) (*File, error) {
+	tokens, err := lexer.NewLexer(source, filename).ScanTokens()
+	if err != nil {
+		return nil, fmt.Errorf("lexer error: %w", err)
+	}
+	program, parseErrors := parser.NewFromTokens(tokens).Parse()
+	if len(parseErrors) > 0 {
+		var errMsgs []string
+		for _, e := range parseErrors {
+			errMsgs = append(errMsgs, e.Error())
+		}
+		return nil, fmt.Errorf("parse errors:\n  %s", strings.Join(errMsgs, "\n  "))
+	}
+	return &File{Program: program, Tokens: tokens, source: []rune(source)}, nil
+}
+
+// apply splices edits into the source. An edit that overlaps one before it
+// is skipped and counted so Apply can retry it on the result.
+func (f *File) apply(edits []Edit) (out string, applied, skipped int) {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Start < edits[j].Start })
+	var b strings.Builder
+	last := 0
+	for _, e := range edits {
+		if e.Start < last || e.Start > e.End || e.End > len(f.source) {
+			skipped++
+			continue
+		}
+		b.WriteString(string(f.source[last:e.Start]))
+		b.WriteString(e.Text)
+		last = e.End
+		applied++
+	}
+	if applied == 0 {
+		return string(f.source), 0, skipped
+	}
+	b.WriteString(string(f.source[last:]))
+	return b.String(), applied, skipped
+}
+
+// Text returns the source runes [start, end).
+func (f *File) Text(start, end int) string {
+	return string(f.source[start:end])
+}
+
+// Replace returns an edit that swaps tok's text for text. It reports false
+// when tok's lexeme is not what the source holds at its offset, as for
+// tokens the parser synthesizes.
+func (f *File) Replace(tok lexer.Token, text string) (Edit, bool) {
+	start := int(tok.Offset)
+	end := start + len([]rune(tok.Lexeme))
+	if end > len(f.source) || f.Text(start, end) != tok.Lexeme {
+		return Edit{}, false
+	}
+	return Edit{Start: start, End: end, Text: text}, true
+}
+
+// Call returns the index in f.Tokens of the ( and matching ) of the call
+// whose function name is tok, or false when tok is not followed by (.
+func (f *File) Call(tok lexer.Token) (lparen, rparen int, ok bool) {
+	i := sort.Search(len(f.Tokens), func(i int) bool { return f.Tokens[i].Offset >= tok.Offset })
+	for i < len(f.Tokens) && f.Tokens[i].Offset == tok.Offset && f.Tokens[i].Lexeme != tok.Lexeme {
+		i++
+	}
+	if i+1 >= len(f.Tokens) || f.Tokens[i].Offset != tok.Offset || f.Tokens[i+1].Type != lexer.TOKEN_LPAREN {
+		return 0, 0, false
+	}
+	depth := 0
+	for j := i + 1; j < len(f.Tokens); j++ {
+		switch f.Tokens[j].Type {
+		case lexer.TOKEN_LPAREN:
+			depth++
+		case lexer.TOKEN_RPAREN:
+			depth--
+			if depth == 0 {
+				return i + 1, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
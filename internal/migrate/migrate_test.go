@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+func apply(t *testing.T, name, source string) (string, int) {
+	t.Helper()
+	m := Lookup(name)
+	if m == nil {
+		t.Fatalf("migration %q not registered", name)
+	}
+	out, n, err := Apply(m, source, "app.kuki")
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	return out, n
+}
+
+func TestGoConversions(t *testing.T) {
+	source := `import "os"
+
+func main()
+    data := os.ReadFile("x") onerr panic "{error}"
+    # keep this comment
+    s := string(data)
+    n := int64(len(s) + 1)
+    print(s, n)
+`
+	out, n := apply(t, "go-conversions", source)
+	if n != 2 {
+		t.Fatalf("expected 2 rewrites, got %d:\n%s", n, out)
+	}
+	for _, want := range []string{"s := data as string", "n := (len(s) + 1) as int64", "# keep this comment"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDeprecatedCalls(t *testing.T) {
+	source := `# kuki:deprecated "Use Greet instead"
+func Hello(name string) string
+    return "hi {name}"
+
+# kuki:deprecated "no longer supported"
+func Wave() string
+    return "wave"
+
+func Greet(name string) string
+    return "hi {name}"
+
+func main()
+    print(Hello("a"), Wave())
+    print("b" |> Hello())
+`
+	out, n := apply(t, "deprecated-calls", source)
+	if n != 2 {
+		t.Fatalf("expected 2 rewrites, got %d:\n%s", n, out)
+	}
+	want := strings.NewReplacer(`print(Hello("a")`, `print(Greet("a")`, `|> Hello()`, `|> Greet()`).Replace(source)
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGoConversionsKeepTheRestOfTheFile(t *testing.T) {
+	// Blank lines, spacing and comments outside the rewritten spans are
+	// not kukicha fmt style and must come back byte for byte
+	untouched := `import "strings"
+
+
+# helper   keeps  odd spacing
+func pad(s string)    string
+    return strings.Repeat(" ",  4) + s   # trailing comment
+
+
+
+`
+	source := untouched + `func main()
+    n := int64(len("abc"))    # count
+    print(string(int64(n)), float64(n).String(), pad("x"))
+`
+	want := untouched + `func main()
+    n := len("abc") as int64    # count
+    print(n as int64 as string, (n as float64).String(), pad("x"))
+`
+	out, n := apply(t, "go-conversions", source)
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+	if n != 4 {
+		t.Errorf("expected 4 rewrites, got %d", n)
+	}
+}
+
+func TestApplyLeavesUnchangedSource(t *testing.T) {
+	source := "func main()\n    print(\"not fmt style\")   \n\n\n\nfunc other()\n    print(1)\n"
+	out, n := apply(t, "go-conversions", source)
+	if n != 0 || out != source {
+		t.Errorf("expected source back untouched, got %d rewrites:\n%s", n, out)
+	}
+}
+
+func TestReplacementName(t *testing.T) {
+	tests := map[string]string{
+		"Use NewFunc instead":         "NewFunc",
+		"use string.Title() instead":  "string.Title",
+		"Deprecated; will be removed": "",
+		"Use the builder API instead": "",
+	}
+	for msg, want := range tests {
+		if got := replacementName(msg); got != want {
+			t.Errorf("replacementName(%q) = %q, want %q", msg, got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/lexer"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+// ---------- go-conversions ----------
+
+// conversionTypes are the type names Go code converts with T(x) and Kukicha
+// converts with `x as T`.
+var conversionTypes = map[string]bool{
+	"string": true, "bool": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// goConversions turns string(x), int64(n), ... into casts. These calls do
+// not compile in Kukicha; they come from code ported from Go. The argument
+// keeps its source text, parenthesized unless it is a single operand.
+func goConversions(f *File) []Edit {
+	declared := declaredFunctions(f.Program)
+	var edits []Edit
+	ast.RewriteProgram(f.Program, func(e ast.Expression) ast.Expression {
+		call, ok := e.(*ast.CallExpr)
+		if !ok || len(call.Arguments) != 1 || len(call.NamedArguments) > 0 || call.Variadic {
+			return e
+		}
+		id, ok := call.Function.(*ast.Identifier)
+		if !ok || !conversionTypes[id.Value] || declared[id.Value] {
+			return e
+		}
+		lparen, rparen, ok := f.Call(id.Token)
+		if !ok {
+			return e
+		}
+		arg := strings.TrimSpace(f.Text(int(f.Tokens[lparen].Offset)+1, int(f.Tokens[rparen].Offset)))
+		if !isOperand(call.Arguments[0]) {
+			arg = "(" + arg + ")"
+		}
+		text := arg + " as " + id.Value
+		// `x as T.f()` would read .f as part of the type
+		if next := rparen + 1; next < len(f.Tokens) && isPostfix(f.Tokens[next].Type) {
+			text = "(" + text + ")"
+		}
+		edits = append(edits, Edit{Start: int(id.Token.Offset), End: int(f.Tokens[rparen].Offset) + 1, Text: text})
+		return e
+	})
+	return edits
+}
+
+// isOperand reports whether expr binds tighter than `as` without parens.
+func isOperand(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.Identifier, *ast.IntegerLiteral, *ast.FloatLiteral, *ast.DecimalLiteral,
+		*ast.RuneLiteral, *ast.StringLiteral, *ast.BooleanLiteral,
+		*ast.CallExpr, *ast.MethodCallExpr, *ast.FieldAccessExpr, *ast.IndexExpr, *ast.SliceExpr:
+		return true
+	}
+	return false
+}
+
+// isPostfix reports whether a token of type t continues the expression
+// before it.
+func isPostfix(t lexer.TokenType) bool {
+	return t == lexer.TOKEN_DOT || t == lexer.TOKEN_LBRACKET || t == lexer.TOKEN_LPAREN
+}
+
+// ---------- deprecated-calls ----------
+
+// replacementPattern extracts X from deprecation messages of the form
+// "Use X instead" (optionally "X()"), where X is Name or pkg.Name.
+var replacementPattern = regexp.MustCompile(`^(?i:use)\s+([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)?)(?:\(\))?\s+(?i:instead)\b`)
+
+// deprecatedCalls points calls to deprecated functions at their
+// replacements. Same-file functions are read from their # kuki:deprecated
+// directives and stdlib functions from the generated registry. Calls whose
+// message names no replacement, or names one in a package the file does not
+// import, are left for a person to migrate.
+func deprecatedCalls(f *File) []Edit {
+	program := f.Program
+	local := make(map[string]string)
+	for _, decl := range program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Receiver != nil {
+			continue
+		}
+		for _, d := range fn.Directives {
+			if d.Name == "deprecated" && len(d.Args) > 0 {
+				if repl := replacementName(d.Args[0]); repl != "" && !strings.Contains(repl, ".") {
+					local[fn.Name.Value] = repl
+				}
+			}
+		}
+	}
+
+	// Import names of stdlib packages: alias → package ("strpkg" → "string").
+	stdlibNames := make(map[string]string)
+	for _, imp := range program.Imports {
+		pkg, ok := strings.CutPrefix(imp.Path.Value, "stdlib/")
+		if !ok {
+			continue
+		}
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name := pkg
+		if imp.Alias != nil {
+			name = imp.Alias.Value
+		}
+		stdlibNames[name] = pkg
+	}
+	importNameOf := func(pkg string) string {
+		for name, p := range stdlibNames {
+			if p == pkg {
+				return name
+			}
+		}
+		return ""
+	}
+
+	var edits []Edit
+	replace := func(tok lexer.Token, text string) {
+		if edit, ok := f.Replace(tok, text); ok {
+			edits = append(edits, edit)
+		}
+	}
+	ast.RewriteProgram(program, func(e ast.Expression) ast.Expression {
+		switch e := e.(type) {
+		case *ast.CallExpr:
+			id, ok := e.Function.(*ast.Identifier)
+			if !ok {
+				return e
+			}
+			if repl, ok := local[id.Value]; ok {
+				replace(id.Token, repl)
+			}
+		case *ast.MethodCallExpr:
+			obj, ok := e.Object.(*ast.Identifier)
+			if !ok {
+				return e
+			}
+			pkg, ok := stdlibNames[obj.Value]
+			if !ok {
+				return e
+			}
+			repl := replacementName(semantic.GetDeprecation(pkg + "." + e.Method.Value))
+			if repl == "" {
+				return e
+			}
+			replPkg, replName, qualified := strings.Cut(repl, ".")
+			if !qualified {
+				replace(e.Method.Token, repl)
+				return e
+			}
+			name := importNameOf(replPkg)
+			if name == "" {
+				return e
+			}
+			// One edit from the package name through the method, so the call
+			// counts once
+			objEdit, ok1 := f.Replace(obj.Token, name)
+			methodEdit, ok2 := f.Replace(e.Method.Token, replName)
+			if ok1 && ok2 {
+				edits = append(edits, Edit{
+					Start: objEdit.Start,
+					End:   methodEdit.End,
+					Text:  name + f.Text(objEdit.End, methodEdit.Start) + replName,
+				})
+			}
+		}
+		return e
+	})
+	return edits
+}
+
+// replacementName returns the function a deprecation message points to, or "".
+func replacementName(msg string) string {
+	m := replacementPattern.FindStringSubmatch(strings.TrimSpace(msg))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// declaredFunctions returns the names of the program's top-level functions.
+func declaredFunctions(program *ast.Program) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range program.Declarations {
+		if fn, ok := decl.(*ast.FunctionDecl); ok && fn.Receiver == nil {
+			names[fn.Name.Value] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzParser checks that no input makes the lexer or parser panic:
+// malformed source must come back as errors. Run with
+//
+//	go test -run=^$ -fuzz=FuzzParser -fuzzminimizetime=1s ./internal/parser
+func FuzzParser(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"func main()\n    x := f() onerr return\n",
+		"type Point\n    X int\n    Y int\n",
+		"func f(xs list of int) map of string to int\n    return {}\n",
+		"x := items |> filter((n int) => n > 1) |> len()\n",
+		"switch x\n    when 1, 2\n        print(1)\n    otherwise\n        print(2)\n",
+		"select\n    when v := receive from ch\n        print(v)\n",
+		"s := \"a {b} c\"\n",
+	} {
+		f.Add(seed)
+	}
+	for _, pattern := range []string{"../../examples/*.kuki", "../../stdlib/*/*.kuki"} {
+		paths, _ := filepath.Glob(pattern)
+		for _, path := range paths {
+			if source, err := os.ReadFile(path); err == nil {
+				f.Add(string(source))
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		p, err := New(source, "fuzz.kuki")
+		if err != nil {
+			return
+		}
+		program, errs := p.Parse()
+		if len(errs) == 0 && program == nil {
+			t.Fatal("no program and no errors")
+		}
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
+	"github.com/duber000/kukicha/internal/version"
 )
 
 // Parser parses tokens into an AST using recursive descent.
@@ -21,8 +24,11 @@ import (
 type Parser struct {
 	tokens            []lexer.Token
 	pos               int
-	errors            []error         // Collected errors - parsing continues after errors for better diagnostics
-	pendingDirectives []ast.Directive // Directives collected before the next declaration
+	errors            []error          // Collected errors - parsing continues after errors for better diagnostics
+	pendingDirectives []ast.Directive  // Directives collected before the next declaration
+	idents            []ast.Identifier // Current chunk newIdentifier allocates from
+	inIterator        bool             // Parsing the body of a `yields` or `sequence of T` function, where `yield` is a statement
+	sawYield          bool             // A yield statement was parsed in the current iterator body
 }
 
 // New creates a new parser from a source string
@@ -49,11 +55,21 @@ func NewFromTokens(tokens []lexer.Token) *Parser {
 }
 
 // Parse parses the tokens into a Program AST
-func (p *Parser) Parse() (*ast.Program, []error) {
-	program := &ast.Program{
+func (p *Parser) Parse() (program *ast.Program, errs []error) {
+	program = &ast.Program{
 		Imports:      []*ast.ImportDecl{},
 		Declarations: []ast.Declaration{},
 	}
+	// A panic is a parser bug, but malformed input must still end in a
+	// diagnostic: report it at the token being parsed (or the nearest one)
+	defer func() {
+		if r := recover(); r != nil {
+			p.error(p.tokenAt(max(0, min(p.pos, len(p.tokens)-1))), fmt.Sprintf("internal parser error: %v (this is a bug in kukicha; please report it with this file)", r))
+			errs = p.errors
+		}
+	}()
+
+	p.parseHeaderPragmas(program)
 
 	// Skip leading newlines (may follow comments at file start)
 	p.skipNewlines()
@@ -88,6 +104,99 @@ func (p *Parser) Parse() (*ast.Program, []error) {
 	return program, p.errors
 }
 
+// parseHeaderPragmas records the `# kukicha: X.Y.Z`, `# shutdown:`,
+// `# onerr:` and `# header:` comments from the file header (the comments before the first token of
+// code) in program.
+func (p *Parser) parseHeaderPragmas(program *ast.Program) {
+	for _, t := range p.tokens {
+		switch t.Type {
+		case lexer.TOKEN_NEWLINE, lexer.TOKEN_DIRECTIVE:
+			continue
+		case lexer.TOKEN_COMMENT:
+		default:
+			return
+		}
+		if after, ok := strings.CutPrefix(t.Lexeme, "# shutdown:"); ok {
+			p.parseShutdownPragma(program, t, after)
+			continue
+		}
+		if after, ok := strings.CutPrefix(t.Lexeme, "# onerr:"); ok {
+			p.parseOnErrPragma(program, t, after)
+			continue
+		}
+		if after, ok := strings.CutPrefix(t.Lexeme, "# header:"); ok {
+			p.parseGoHeaderPragma(program, t, after)
+			continue
+		}
+		after, ok := strings.CutPrefix(t.Lexeme, "# kukicha:")
+		if !ok {
+			continue
+		}
+		if program.Language != "" {
+			p.error(t, "duplicate # kukicha: pragma")
+			continue
+		}
+		lang, err := version.ParseLanguage(after)
+		if err != nil {
+			p.error(t, err.Error())
+			continue
+		}
+		program.Language = lang.Text
+		program.LanguagePos = ast.TokenPos(t)
+	}
+}
+
+// parseGoHeaderPragma records a `# header: //...` pragma, a line copied
+// verbatim to the top of the generated Go file (a license notice, a
+// //go:generate directive, a //nolint comment). Only Go comments are allowed
+// so the line cannot change what the file compiles to.
+func (p *Parser) parseGoHeaderPragma(program *ast.Program, t lexer.Token, value string) {
+	line := strings.TrimSpace(value)
+	if !strings.HasPrefix(line, "//") {
+		p.error(t, fmt.Sprintf("invalid # header: pragma '%s' (expected a Go comment or directive such as //go:generate ...)", line))
+		return
+	}
+	program.Header = append(program.Header, line)
+}
+
+// parseShutdownPragma records a `# shutdown: on|off|<grace>` pragma. A
+// duration (e.g. 10s) turns graceful shutdown on with that grace period.
+func (p *Parser) parseShutdownPragma(program *ast.Program, t lexer.Token, value string) {
+	if program.Shutdown != "" {
+		p.error(t, "duplicate # shutdown: pragma")
+		return
+	}
+	value = strings.TrimSpace(value)
+	switch value {
+	case "on", "off":
+		program.Shutdown = value
+		return
+	}
+	grace, err := time.ParseDuration(value)
+	if err != nil || grace <= 0 {
+		p.error(t, fmt.Sprintf("invalid # shutdown: pragma '%s' (expected on, off, or a grace period such as 10s)", value))
+		return
+	}
+	program.Shutdown = "on"
+	program.ShutdownGrace = grace
+}
+
+// parseOnErrPragma records a `# onerr: log|off` pragma, which turns the
+// logging of handled errors on or off for the file whatever kukicha.toml
+// says.
+func (p *Parser) parseOnErrPragma(program *ast.Program, t lexer.Token, value string) {
+	if program.OnErrLog != "" {
+		p.error(t, "duplicate # onerr: pragma")
+		return
+	}
+	value = strings.TrimSpace(value)
+	if value != "log" && value != "off" {
+		p.error(t, fmt.Sprintf("invalid # onerr: pragma '%s' (expected log or off)", value))
+		return
+	}
+	program.OnErrLog = value
+}
+
 // Errors returns the parsing errors
 func (p *Parser) Errors() []error {
 	return p.errors
@@ -118,10 +227,20 @@ func (p *Parser) skipIgnoredTokens() {
 }
 
 // parseDirective extracts the directive name and arguments from a TOKEN_DIRECTIVE lexeme.
-// Format: "# kuki:name arg1 arg2 ..." or "# kuki:name \"quoted arg\""
+// Format: "# kuki:name arg1 arg2 ..." or "# kuki:name \"quoted arg\"".
+// "# route: GET /path" is the route directive, named "route", "# schedule:
+// */5 * * * *" the schedule directive, named "schedule", and "@name args..."
+// is the annotation form of "# kuki:name args...".
 func parseDirective(t lexer.Token) ast.Directive {
 	// Strip "# kuki:" prefix
 	content := strings.TrimPrefix(t.Lexeme, "# kuki:")
+	if after, ok := strings.CutPrefix(t.Lexeme, "# route:"); ok {
+		content = "route " + after
+	} else if after, ok := strings.CutPrefix(t.Lexeme, "# schedule:"); ok {
+		content = "schedule " + after
+	} else if after, ok := strings.CutPrefix(t.Lexeme, "@"); ok {
+		content = after
+	}
 	content = strings.TrimSpace(content)
 
 	// Split into name and remaining args
@@ -175,10 +294,7 @@ func parseDirectiveArgs(s string) []string {
 
 func (p *Parser) peekToken() lexer.Token {
 	p.skipIgnoredTokens()
-	if p.pos >= len(p.tokens) {
-		return lexer.Token{Type: lexer.TOKEN_EOF}
-	}
-	return p.tokens[p.pos]
+	return p.tokenAt(p.pos)
 }
 
 func (p *Parser) peekNextToken() lexer.Token {
@@ -208,10 +324,16 @@ func (p *Parser) peekAt(offset int) lexer.Token {
 }
 
 func (p *Parser) previousToken() lexer.Token {
-	if p.pos == 0 {
+	return p.tokenAt(p.pos - 1)
+}
+
+// tokenAt returns p.tokens[i], or an EOF token when i is out of range, so
+// lookahead past either end of the stream cannot index out of range.
+func (p *Parser) tokenAt(i int) lexer.Token {
+	if i < 0 || i >= len(p.tokens) {
 		return lexer.Token{Type: lexer.TOKEN_EOF}
 	}
-	return p.tokens[p.pos-1]
+	return p.tokens[i]
 }
 
 func (p *Parser) advance() lexer.Token {
@@ -245,11 +367,35 @@ func (p *Parser) consume(tokenType lexer.TokenType, message string) (lexer.Token
 }
 
 func (p *Parser) error(token lexer.Token, message string) error {
-	err := fmt.Errorf("%s:%d:%d: %s", token.File, token.Line, token.Column, message)
+	line, column := token.Position()
+	err := fmt.Errorf("%s:%d:%d: %s", token.File(), line, column, message)
 	p.errors = append(p.errors, err)
 	return err
 }
 
+// errorMsg records a catalogued error, reported in the selected language.
+func (p *Parser) errorMsg(token lexer.Token, id catalog.ID, args ...any) error {
+	line, column := token.Position()
+	err := catalog.Errorf(token.File(), line, column, id, args...)
+	p.errors = append(p.errors, err)
+	return err
+}
+
+// identChunk is how many identifiers newIdentifier allocates at once.
+const identChunk = 256
+
+// newIdentifier returns an identifier node. Identifiers are by far the most
+// common node, so they are carved out of chunks: one allocation per
+// identChunk identifiers rather than one each. A chunk lives as long as any
+// of its identifiers, which is as long as the AST.
+func (p *Parser) newIdentifier(token lexer.Token, value string) *ast.Identifier {
+	if len(p.idents) == cap(p.idents) {
+		p.idents = make([]ast.Identifier, 0, identChunk)
+	}
+	p.idents = append(p.idents, ast.Identifier{Token: token, Value: value})
+	return &p.idents[len(p.idents)-1]
+}
+
 func (p *Parser) skipNewlines() {
 	for p.match(lexer.TOKEN_NEWLINE) {
 	}
@@ -285,7 +431,7 @@ func (p *Parser) isIdentifierFollower() bool {
 		lexer.TOKEN_LT, lexer.TOKEN_GT, lexer.TOKEN_LTE, lexer.TOKEN_GTE,
 		lexer.TOKEN_PLUS, lexer.TOKEN_MINUS, lexer.TOKEN_STAR, lexer.TOKEN_SLASH, lexer.TOKEN_PERCENT,
 		lexer.TOKEN_AND, lexer.TOKEN_OR, lexer.TOKEN_AND_AND, lexer.TOKEN_OR_OR,
-		lexer.TOKEN_PIPE, lexer.TOKEN_ONERR:
+		lexer.TOKEN_PIPE, lexer.TOKEN_PARALLEL_PIPE, lexer.TOKEN_ONERR:
 		return true
 	default:
 		return false
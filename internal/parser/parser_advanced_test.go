@@ -1,8 +1,10 @@
 package parser
 
 import (
-	"github.com/duber000/kukicha/internal/ast"
+	"strings"
 	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
 )
 
 func TestParseSkillDeclSimple(t *testing.T) {
@@ -473,6 +475,122 @@ func TestParseSelectStatement(t *testing.T) {
 	}
 }
 
+func TestParseYieldsFunction(t *testing.T) {
+	input := `func Entries on c Cache yields string, int
+    for k, v in c.data
+        yield k, v
+
+func Run(next func(int) bool)
+    yield(1)
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	if len(fn.Yields) != 2 || len(fn.Returns) != 0 {
+		t.Fatalf("expected 2 yielded types and no returns, got %d and %d", len(fn.Yields), len(fn.Returns))
+	}
+	loop := fn.Body.Statements[0].(*ast.ForRangeStmt)
+	yield, ok := loop.Body.Statements[0].(*ast.YieldStmt)
+	if !ok {
+		t.Fatalf("expected YieldStmt, got %T", loop.Body.Statements[0])
+	}
+	if len(yield.Values) != 2 {
+		t.Errorf("expected 2 yielded values, got %d", len(yield.Values))
+	}
+
+	// Outside an iterator, yield(...) is an ordinary call
+	run := program.Declarations[1].(*ast.FunctionDecl)
+	if _, ok := run.Body.Statements[0].(*ast.ExpressionStmt); !ok {
+		t.Errorf("expected ExpressionStmt, got %T", run.Body.Statements[0])
+	}
+}
+
+func TestParseSequenceGenerator(t *testing.T) {
+	input := `func Fib(limit int) sequence of int
+    a, b := 0, 1
+    for a < limit
+        yield a
+        a, b = b, a + b
+
+func Evens() sequence of int
+    return func(yield func(int) bool)
+        yield(2)
+`
+
+	program := mustParseProgram(t, input)
+
+	fib := program.Declarations[0].(*ast.FunctionDecl)
+	seq, ok := fib.Returns[0].(*ast.SequenceType)
+	if !ok {
+		t.Fatalf("expected SequenceType, got %T", fib.Returns[0])
+	}
+	if len(fib.Yields) != 1 || fib.Yields[0] != seq.ElementType {
+		t.Errorf("expected the generator to yield the sequence element type, got %v", fib.Yields)
+	}
+
+	// Returning a hand-written iterator is not a generator: the literal's
+	// yield(2) calls its parameter
+	evens := program.Declarations[1].(*ast.FunctionDecl)
+	if evens.Yields != nil {
+		t.Errorf("expected no yields, got %v", evens.Yields)
+	}
+}
+
+func TestParseChannelDirection(t *testing.T) {
+	input := `func Pump(src channel of int receive-only, out channel of int send-only, both channel of int)
+    return
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	for i, want := range []string{"receive-only", "send-only", ""} {
+		ch, ok := fn.Parameters[i].Type.(*ast.ChannelType)
+		if !ok {
+			t.Fatalf("param %d: expected ChannelType, got %T", i, fn.Parameters[i].Type)
+		}
+		if ch.Direction != want {
+			t.Errorf("param %d: expected direction %q, got %q", i, want, ch.Direction)
+		}
+	}
+}
+
+func TestParseWaitExpr(t *testing.T) {
+	input := `func main()
+    a := wait for ch
+    b, err := wait for all [c1, c2] within 2 * time.Second
+    all := make(channel of int)
+    c := wait for all within time.Second
+`
+
+	program := mustParseProgram(t, input)
+
+	body := program.Declarations[0].(*ast.FunctionDecl).Body.Statements
+	tests := []struct {
+		stmt       int
+		all        bool
+		hasTimeout bool
+	}{
+		{0, false, false},
+		{1, true, true},
+		{3, false, true}, // a channel named all
+	}
+	for _, tt := range tests {
+		value := body[tt.stmt].(*ast.VarDeclStmt).Values[0]
+		wait, ok := value.(*ast.WaitExpr)
+		if !ok {
+			t.Fatalf("statement %d: expected WaitExpr, got %T", tt.stmt, value)
+		}
+		if wait.All != tt.all || (wait.Timeout != nil) != tt.hasTimeout {
+			t.Errorf("statement %d: got all=%v timeout=%v", tt.stmt, wait.All, wait.Timeout)
+		}
+	}
+	if _, ok := body[1].(*ast.VarDeclStmt).Values[0].(*ast.WaitExpr).Channel.(*ast.ListLiteralExpr); !ok {
+		t.Error("expected wait for all to take the list literal as its channels")
+	}
+}
+
 func TestParseMalformedTypeAnnotation_NoNilPanic(t *testing.T) {
 	// parseTypeAnnotation returns a sentinel, not nil, so the parser
 	// doesn't panic on malformed input.
@@ -553,3 +671,265 @@ func TestPeekAtSkipsComments(t *testing.T) {
 		t.Errorf("peekAt(0) type %s != peekToken() type %s", tok0.Type, tokPeek.Type)
 	}
 }
+
+func TestParseWhenTarget(t *testing.T) {
+	input := `when target mcp
+    func main()
+        serve()
+otherwise
+    func main()
+        run()
+
+func Log(msg string)
+    when target cli, mcp
+        print(msg)
+    switch msg
+        when "x"
+            print("x")
+`
+
+	program := mustParseProgram(t, input)
+	if len(program.Declarations) != 2 {
+		t.Fatalf("expected 2 declarations, got %d", len(program.Declarations))
+	}
+
+	td, ok := program.Declarations[0].(*ast.TargetDecl)
+	if !ok {
+		t.Fatalf("expected TargetDecl, got %T", program.Declarations[0])
+	}
+	if len(td.Targets) != 1 || td.Targets[0].Value != "mcp" {
+		t.Errorf("unexpected targets: %v", td.Targets)
+	}
+	if len(td.Declarations) != 1 || len(td.Otherwise) != 1 {
+		t.Errorf("expected one declaration per branch, got %d and %d", len(td.Declarations), len(td.Otherwise))
+	}
+
+	fn := program.Declarations[1].(*ast.FunctionDecl)
+	ts, ok := fn.Body.Statements[0].(*ast.TargetStmt)
+	if !ok {
+		t.Fatalf("expected TargetStmt, got %T", fn.Body.Statements[0])
+	}
+	if len(ts.Targets) != 2 || ts.Targets[1].Value != "mcp" || ts.Otherwise != nil {
+		t.Errorf("unexpected target statement: %+v", ts)
+	}
+	if _, ok := fn.Body.Statements[1].(*ast.SwitchStmt); !ok {
+		t.Errorf("expected switch after target block, got %T", fn.Body.Statements[1])
+	}
+}
+
+func TestParseSafelyBlock(t *testing.T) {
+	input := `func main()
+    safely
+        x := legacy.Parse(data)
+        print(x)
+    onerr as e
+        print(e)
+    safely := 1
+    print(safely)
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	stmt, ok := fn.Body.Statements[0].(*ast.SafelyStmt)
+	if !ok {
+		t.Fatalf("expected SafelyStmt, got %T", fn.Body.Statements[0])
+	}
+	if len(stmt.Body.Statements) != 2 {
+		t.Errorf("expected 2 statements in safely block, got %d", len(stmt.Body.Statements))
+	}
+	if stmt.OnErr == nil || stmt.OnErr.Alias != "e" {
+		t.Errorf("expected onerr as e, got %+v", stmt.OnErr)
+	}
+	// safely is only a keyword before a block
+	if _, ok := fn.Body.Statements[1].(*ast.VarDeclStmt); !ok {
+		t.Errorf("expected safely := 1 to declare a variable, got %T", fn.Body.Statements[1])
+	}
+}
+
+func TestParseFailStmt(t *testing.T) {
+	input := `func main()
+    fail "missing {path}" code 2
+    fail msg
+    fail := 1
+    print(fail)
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	first, ok := fn.Body.Statements[0].(*ast.FailStmt)
+	if !ok {
+		t.Fatalf("expected FailStmt, got %T", fn.Body.Statements[0])
+	}
+	if code, ok := first.Code.(*ast.IntegerLiteral); !ok || code.Value != 2 {
+		t.Errorf("expected code 2, got %#v", first.Code)
+	}
+	second, ok := fn.Body.Statements[1].(*ast.FailStmt)
+	if !ok {
+		t.Fatalf("expected FailStmt, got %T", fn.Body.Statements[1])
+	}
+	if second.Code != nil {
+		t.Errorf("expected no code, got %#v", second.Code)
+	}
+	// fail is only a keyword before a message
+	if _, ok := fn.Body.Statements[2].(*ast.VarDeclStmt); !ok {
+		t.Errorf("expected fail := 1 to declare a variable, got %T", fn.Body.Statements[2])
+	}
+}
+
+func TestParseRequireStmt(t *testing.T) {
+	input := `func Check(items list of string) error
+    require len(items) > 0 else return error "empty"
+    require items[0] != "" else
+        print("blank")
+        return error "blank"
+    require := 1
+    print(require)
+    return empty
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	inline, ok := fn.Body.Statements[0].(*ast.RequireStmt)
+	if !ok {
+		t.Fatalf("expected RequireStmt, got %T", fn.Body.Statements[0])
+	}
+	if _, ok := inline.Condition.(*ast.BinaryExpr); !ok {
+		t.Errorf("expected a comparison condition, got %#v", inline.Condition)
+	}
+	if len(inline.Else.Statements) != 1 {
+		t.Fatalf("expected one else statement, got %d", len(inline.Else.Statements))
+	}
+	if _, ok := inline.Else.Statements[0].(*ast.ReturnStmt); !ok {
+		t.Errorf("expected return after else, got %T", inline.Else.Statements[0])
+	}
+	block, ok := fn.Body.Statements[1].(*ast.RequireStmt)
+	if !ok || len(block.Else.Statements) != 2 {
+		t.Fatalf("expected a require with a two-statement else block, got %#v", fn.Body.Statements[1])
+	}
+	// require is only a keyword with an else on the line
+	if _, ok := fn.Body.Statements[2].(*ast.VarDeclStmt); !ok {
+		t.Errorf("expected require := 1 to declare a variable, got %T", fn.Body.Statements[2])
+	}
+}
+
+func TestParseReadExpr(t *testing.T) {
+	input := `func main()
+    name := read line onerr "anon"
+    data := read all onerr panic "{error}"
+    read := 1
+    print(read, name, data)
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	for i, all := range []bool{false, true} {
+		decl := fn.Body.Statements[i].(*ast.VarDeclStmt)
+		read, ok := decl.Values[0].(*ast.ReadExpr)
+		if !ok {
+			t.Fatalf("statement %d: expected ReadExpr, got %T", i, decl.Values[0])
+		}
+		if read.All != all {
+			t.Errorf("statement %d: expected All=%v", i, all)
+		}
+		if decl.OnErr == nil {
+			t.Errorf("statement %d: expected an onerr clause", i)
+		}
+	}
+	// read is only a keyword before line or all
+	decl := fn.Body.Statements[2].(*ast.VarDeclStmt)
+	if decl.Names[0].Value != "read" {
+		t.Errorf("expected read := 1 to declare a variable, got %q", decl.Names[0].Value)
+	}
+}
+
+func TestParseCommandExpr(t *testing.T) {
+	input := `func main()
+    head := $ "git rev-parse {ref}" onerr return
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	decl := fn.Body.Statements[0].(*ast.VarDeclStmt)
+	cmd, ok := decl.Values[0].(*ast.CommandExpr)
+	if !ok {
+		t.Fatalf("expected CommandExpr, got %T", decl.Values[0])
+	}
+	if !cmd.Command.Interpolated {
+		t.Errorf("expected an interpolated command string")
+	}
+	if decl.OnErr == nil {
+		t.Errorf("expected an onerr clause")
+	}
+}
+
+func TestParseCommandExprRequiresStringLiteral(t *testing.T) {
+	input := `func main()
+    out := $ cmd
+`
+
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errs := p.Parse()
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "$ must be followed by a string literal command") {
+		t.Errorf("expected string literal error, got %v", errs)
+	}
+}
+
+func TestParseBuildStringExpr(t *testing.T) {
+	input := `func main()
+    build := 1
+    s := build string as b
+        for i from 0 to build
+            b.WriteString("x")
+    print(s)
+`
+
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	if len(fn.Body.Statements) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(fn.Body.Statements))
+	}
+	decl := fn.Body.Statements[1].(*ast.VarDeclStmt)
+	bs, ok := decl.Values[0].(*ast.BuildStringExpr)
+	if !ok {
+		t.Fatalf("expected BuildStringExpr, got %T", decl.Values[0])
+	}
+	if bs.Builder.Value != "b" || len(bs.Body.Statements) != 1 {
+		t.Errorf("unexpected build string block: builder %q, %d statements", bs.Builder.Value, len(bs.Body.Statements))
+	}
+}
+
+func TestParseBuildStringExprRequiresBuilderName(t *testing.T) {
+	input := `func main()
+    s := build string
+        print("x")
+`
+
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errs := p.Parse()
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "build string needs a name for its builder") {
+		t.Errorf("expected builder name error, got %v", errs)
+	}
+}
+
+func TestParseSafelyBlockRequiresOnErr(t *testing.T) {
+	input := `func main()
+    safely
+        risky()
+    print("done")
+`
+
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errs := p.Parse()
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "safely block needs an onerr clause") {
+		t.Errorf("expected missing onerr error, got %v", errs)
+	}
+}
@@ -2,8 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
 )
 
@@ -127,9 +129,27 @@ func (p *Parser) parseDeclaration() ast.Declaration {
 		decl = p.parseVarDeclaration()
 	case lexer.TOKEN_CONST:
 		decl = p.parseConstDecl()
+	case lexer.TOKEN_ERROR:
+		decl = p.parseErrorDecl()
+	case lexer.TOKEN_CASE:
+		if p.isWhenTarget() {
+			if len(dirs) > 0 {
+				p.error(dirs[0].Token, "directives must be placed on the declarations inside a 'when target' block")
+			}
+			return p.parseTargetDecl()
+		}
+		p.error(p.peekToken(), "unexpected 'when' at top level (did you mean 'when target NAME'?)")
+		p.advance()
+		return nil
+	case lexer.TOKEN_IDENTIFIER:
+		if next := p.peekNextToken(); next.Type == lexer.TOKEN_IDENTIFIER && next.Lexeme == "implements" {
+			decl = p.parseImplementsDecl()
+			break
+		}
+		fallthrough
 	default:
 		if !p.isAtEnd() {
-			p.error(p.peekToken(), fmt.Sprintf("unexpected token %s, expected declaration", p.peekToken().Type))
+			p.errorMsg(p.peekToken(), catalog.UnexpectedDeclaration, p.peekToken().Type)
 			p.advance() // Skip the problematic token
 		}
 		return nil
@@ -150,23 +170,96 @@ func (p *Parser) parseDeclaration() ast.Declaration {
 	return decl
 }
 
+// parseImplementsDecl parses `Type implements Interface[, Interface...]`.
+// "implements" is contextual, so it stays usable as an identifier elsewhere.
+func (p *Parser) parseImplementsDecl() *ast.ImplementsDecl {
+	decl := &ast.ImplementsDecl{Type: p.parseIdentifier()}
+	decl.Token = p.advance() // consume 'implements'
+	for {
+		decl.Interfaces = append(decl.Interfaces, p.parseTypeAnnotation())
+		if !p.match(lexer.TOKEN_COMMA) {
+			break
+		}
+	}
+	if !p.check(lexer.TOKEN_NEWLINE) && !p.isAtEnd() {
+		p.error(p.peekToken(), "expected newline after implements declaration")
+	}
+	return decl
+}
+
+func (p *Parser) parseTargetDecl() *ast.TargetDecl {
+	token, targets := p.parseTargetNames()
+	decl := &ast.TargetDecl{Token: token, Targets: targets}
+	decl.Declarations = p.parseTargetDeclBody()
+
+	p.skipNewlines()
+	if p.match(lexer.TOKEN_DEFAULT) {
+		decl.OtherwiseTok = p.previousToken()
+		p.skipNewlines()
+		decl.Otherwise = p.parseTargetDeclBody()
+	}
+	return decl
+}
+
+// parseTargetDeclBody parses the indented declarations of a top-level
+// `when target` or `otherwise` branch.
+func (p *Parser) parseTargetDeclBody() []ast.Declaration {
+	decls := []ast.Declaration{}
+	if !p.match(lexer.TOKEN_INDENT) {
+		p.error(p.peekToken(), "expected indented block of declarations after 'when target'")
+		return decls
+	}
+	for !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
+		p.skipNewlines()
+		if p.check(lexer.TOKEN_DEDENT) {
+			break
+		}
+		if decl := p.parseDeclaration(); decl != nil {
+			decls = append(decls, decl)
+		}
+	}
+	p.consume(lexer.TOKEN_DEDENT, "expected dedent after 'when target' block") //nolint:errcheck
+	return decls
+}
+
 func (p *Parser) parseTypeDecl() ast.Declaration {
 	token := p.advance() // consume 'type'
 	p.skipNewlines()
 
 	name := p.parseIdentifier()
-	p.skipNewlines()
 
-	// Check for type alias: type Name func(...) ...
-	if p.check(lexer.TOKEN_FUNC) {
+	// Check for type alias: type Name func(...) ..., type Meters int distinct
+	if !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_INDENT) && !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
 		aliasType := p.parseTypeAnnotation()
+		distinct := p.check(lexer.TOKEN_IDENTIFIER) && p.peekToken().Lexeme == "distinct"
+		if distinct {
+			p.advance() // consume 'distinct'
+		}
 		p.skipNewlines()
-		return &ast.TypeDecl{
+		decl := &ast.TypeDecl{
 			Token:     token,
 			Name:      name,
 			AliasType: aliasType,
+			Distinct:  distinct,
+		}
+		// A distinct type may list its values: an enum
+		if distinct && p.match(lexer.TOKEN_INDENT) {
+			for !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
+				p.skipNewlines()
+				if p.check(lexer.TOKEN_DEDENT) {
+					break
+				}
+				if value := p.parseEnumValue(); value != nil {
+					decl.Values = append(decl.Values, value)
+				}
+				p.skipNewlines()
+			}
+			p.consume(lexer.TOKEN_DEDENT, "expected dedent after type values")
+			p.skipNewlines()
 		}
+		return decl
 	}
+	p.skipNewlines()
 
 	fields := []*ast.FieldDecl{}
 
@@ -185,15 +278,11 @@ func (p *Parser) parseTypeDecl() ast.Declaration {
 
 		fieldName := p.parseIdentifier()
 		fieldType := p.parseTypeAnnotation()
-		alias := p.parseFieldAlias()
+		alias, aliasKeys := p.parseFieldAlias()
 
-		// Parse optional struct tag (e.g., json:"name")
-		tag := p.parseStructTag()
-		if alias != "" && tag != "" {
-			p.error(p.peekToken(), "cannot combine field alias and explicit struct tag on the same field")
-		} else if alias != "" {
-			tag = `json:"` + alias + `"`
-		}
+		// Parse optional struct tags (e.g., json:"name" yaml:"name")
+		tags := p.parseStructTags()
+		tag := p.buildStructTag(fieldName, alias, aliasKeys, tags)
 
 		fields = append(fields, &ast.FieldDecl{
 			Name: fieldName,
@@ -296,19 +385,58 @@ func (p *Parser) parseFunctionDecl() *ast.FunctionDecl {
 		decl.Parameters = []*ast.Parameter{}
 	}
 
-	// Parse return types
-	if !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_INDENT) {
+	// Parse return types, or the values an iterator yields
+	if p.isOperatorClause() {
+		// No results: the analyzer reports it
+	} else if tok := p.peekToken(); tok.Type == lexer.TOKEN_IDENTIFIER && tok.Lexeme == "yields" {
+		p.advance()
+		decl.Yields = append(decl.Yields, p.parseTypeAnnotation())
+		for p.match(lexer.TOKEN_COMMA) {
+			decl.Yields = append(decl.Yields, p.parseTypeAnnotation())
+		}
+	} else if !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_INDENT) {
 		decl.Returns = p.parseReturnTypes()
 	}
 
+	// An operator method: func Add on v Vector(o Vector) Vector operator +
+	if p.isOperatorClause() {
+		p.advance() // consume 'operator'
+		decl.Operator = p.advance().Lexeme
+	}
+
 	p.skipNewlines()
 
-	// Parse function body
+	// Parse function body. A function returning `sequence of T` whose body
+	// yields is a generator: it yields T like a `yields T` function
+	seq, generator := sequenceReturn(decl.Returns)
+	outerIterator, outerSawYield := p.inIterator, p.sawYield
+	p.inIterator, p.sawYield = decl.Yields != nil || generator, false
 	decl.Body = p.parseBlock()
+	if generator && p.sawYield {
+		decl.Yields = []ast.TypeAnnotation{seq.ElementType}
+	}
+	p.inIterator, p.sawYield = outerIterator, outerSawYield
 
 	return decl
 }
 
+// isOperatorClause reports whether `operator <op>` ends a function signature.
+func (p *Parser) isOperatorClause() bool {
+	tok := p.peekToken()
+	next := p.peekNextToken()
+	return tok.Type == lexer.TOKEN_IDENTIFIER && tok.Lexeme == "operator" &&
+		next.Type != lexer.TOKEN_NEWLINE && next.Type != lexer.TOKEN_INDENT && next.Type != lexer.TOKEN_EOF
+}
+
+// sequenceReturn reports whether a function's only result is `sequence of T`.
+func sequenceReturn(returns []ast.TypeAnnotation) (*ast.SequenceType, bool) {
+	if len(returns) != 1 {
+		return nil, false
+	}
+	seq, ok := returns[0].(*ast.SequenceType)
+	return seq, ok
+}
+
 func (p *Parser) parseParameters() []*ast.Parameter {
 	params := []*ast.Parameter{}
 	hasDefaultValue := false // Track if we've seen a parameter with a default value
@@ -391,54 +519,96 @@ func (p *Parser) parseReturnTypes() []ast.TypeAnnotation {
 	return returns
 }
 
-// parseStructTag parses a struct tag like json:"name" or empty string if none present
-// Format: identifier:stringLiteral
-func (p *Parser) parseStructTag() string {
-	// Check if next token is an identifier (tag name like "json", "xml", etc.)
-	if !p.check(lexer.TOKEN_IDENTIFIER) {
-		return ""
-	}
-
-	// Look ahead to see if there's a colon
-	// Save current position
-	savedPos := p.pos
-	tagKeyToken := p.advance() // consume identifier
-
-	if !p.check(lexer.TOKEN_COLON) {
-		// Not a tag, restore position and return empty
-		p.pos = savedPos
-		return ""
-	}
+// structTag is one key:"value" pair of a struct tag.
+type structTag struct {
+	key   string
+	value string
+	token lexer.Token
+}
 
-	// We have a tag - continue parsing
-	tagKey := tagKeyToken.Lexeme
-	p.consume(lexer.TOKEN_COLON, "expected ':' in struct tag")
+// parseStructTags parses zero or more struct tags like json:"name" yaml:"name".
+// Format: identifier:stringLiteral, repeated
+func (p *Parser) parseStructTags() []structTag {
+	var tags []structTag
+	for p.check(lexer.TOKEN_IDENTIFIER) {
+		// Look ahead to see if there's a colon
+		// Save current position
+		savedPos := p.pos
+		tagKeyToken := p.advance() // consume identifier
+
+		if !p.check(lexer.TOKEN_COLON) {
+			// Not a tag, restore position and stop
+			p.pos = savedPos
+			break
+		}
+		p.consume(lexer.TOKEN_COLON, "expected ':' in struct tag")
 
-	if !p.check(lexer.TOKEN_STRING) {
-		p.error(p.peekToken(), "expected string value in struct tag")
-		return ""
+		if !p.check(lexer.TOKEN_STRING) {
+			p.error(p.peekToken(), "expected string value in struct tag")
+			break
+		}
+		tags = append(tags, structTag{key: tagKeyToken.Lexeme, value: p.advance().Lexeme, token: tagKeyToken})
 	}
-
-	tagValueToken := p.advance() // consume string
-	tagValue := tagValueToken.Lexeme
-
-	// Return formatted tag: json:"name"
-	return tagKey + ":" + `"` + tagValue + `"`
+	return tags
 }
 
-// parseFieldAlias parses optional field alias syntax: as "json_name"
-// Returns empty string when no alias is present.
-func (p *Parser) parseFieldAlias() string {
+// parseFieldAlias parses optional field alias syntax: as "json_name", or
+// as "name" for json, yaml to use the name for several tag keys. It returns
+// the name and the keys (json when there is no for clause), or "" and nil
+// when no alias is present.
+func (p *Parser) parseFieldAlias() (string, []lexer.Token) {
 	if !p.match(lexer.TOKEN_AS) {
-		return ""
+		return "", nil
 	}
 
 	if !p.check(lexer.TOKEN_STRING) {
 		p.error(p.peekToken(), "expected string value after 'as' in field alias")
-		return ""
+		return "", nil
 	}
+	alias := p.advance().Lexeme
 
-	return p.advance().Lexeme
+	if !p.match(lexer.TOKEN_FOR) {
+		return alias, []lexer.Token{{Lexeme: "json"}}
+	}
+	var keys []lexer.Token
+	for {
+		if !p.check(lexer.TOKEN_IDENTIFIER) {
+			p.error(p.peekToken(), "expected struct tag key (json, yaml, db, ...) after 'for' in field alias")
+			return alias, keys
+		}
+		keys = append(keys, p.advance())
+		if !p.match(lexer.TOKEN_COMMA) {
+			return alias, keys
+		}
+	}
+}
+
+// buildStructTag joins a field's alias keys and explicit tags into one Go
+// struct tag, alias keys first. A key may appear only once.
+func (p *Parser) buildStructTag(field *ast.Identifier, alias string, aliasKeys []lexer.Token, tags []structTag) string {
+	var parts []string
+	fromAlias := make(map[string]bool)
+	for _, key := range aliasKeys {
+		if fromAlias[key.Lexeme] {
+			p.error(key, fmt.Sprintf("duplicate struct tag key '%s' on field '%s'", key.Lexeme, field.Value))
+			continue
+		}
+		fromAlias[key.Lexeme] = true
+		parts = append(parts, key.Lexeme+`:"`+alias+`"`)
+	}
+	explicit := make(map[string]bool)
+	for _, tag := range tags {
+		switch {
+		case fromAlias[tag.key]:
+			p.error(tag.token, fmt.Sprintf("cannot combine field alias and explicit struct tag for '%s' on field '%s'", tag.key, field.Value))
+		case explicit[tag.key]:
+			p.error(tag.token, fmt.Sprintf("duplicate struct tag key '%s' on field '%s'", tag.key, field.Value))
+		default:
+			explicit[tag.key] = true
+			parts = append(parts, tag.key+`:"`+tag.value+`"`)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 // parseConstDecl parses a const declaration in one of two forms:
@@ -493,6 +663,78 @@ func (p *Parser) parseConstSpec() *ast.ConstSpec {
 	return &ast.ConstSpec{Name: name, Value: value}
 }
 
+// parseErrorDecl parses sentinel error declarations:
+//
+//	error NotFound "resource not found"
+//	error
+//	    Conflict "resource already exists"
+//	    Expired "token expired"
+func (p *Parser) parseErrorDecl() ast.Declaration {
+	token := p.advance() // consume 'error'
+
+	decl := &ast.ErrorDecl{Token: token}
+
+	// Grouped form: error followed by newline + INDENT
+	if p.check(lexer.TOKEN_NEWLINE) || p.check(lexer.TOKEN_INDENT) {
+		p.skipNewlines()
+		if !p.match(lexer.TOKEN_INDENT) {
+			p.error(p.peekToken(), "expected indented block or name after 'error'")
+			return nil
+		}
+		for !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
+			p.skipNewlines()
+			if p.check(lexer.TOKEN_DEDENT) {
+				break
+			}
+			spec := p.parseErrorSpec()
+			if spec != nil {
+				decl.Specs = append(decl.Specs, spec)
+			}
+			p.skipNewlines()
+		}
+		p.consume(lexer.TOKEN_DEDENT, "expected dedent after error block")
+	} else {
+		// Single-line form: error Name "message"
+		spec := p.parseErrorSpec()
+		if spec != nil {
+			decl.Specs = append(decl.Specs, spec)
+		}
+	}
+
+	p.skipNewlines()
+	return decl
+}
+
+func (p *Parser) parseErrorSpec() *ast.ErrorSpec {
+	name := p.parseIdentifier()
+	if name == nil {
+		return nil
+	}
+	if !p.check(lexer.TOKEN_STRING) {
+		p.error(p.peekToken(), fmt.Sprintf("expected message string after error name '%s' (interpolation is not allowed in sentinel errors)", name.Value))
+		for !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
+			p.advance()
+		}
+		return nil
+	}
+	return &ast.ErrorSpec{Name: name, Message: p.parseStringLiteral()}
+}
+
+func (p *Parser) parseEnumValue() *ast.EnumValue {
+	name := p.parseIdentifier()
+	if name == nil {
+		return nil
+	}
+	if !p.check(lexer.TOKEN_STRING) {
+		p.error(p.peekToken(), fmt.Sprintf("expected value string after '%s' (interpolation is not allowed in type values)", name.Value))
+		for !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_DEDENT) && !p.isAtEnd() {
+			p.advance()
+		}
+		return nil
+	}
+	return &ast.EnumValue{Name: name, Value: p.parseStringLiteral()}
+}
+
 func (p *Parser) parseVarDeclaration() ast.Declaration {
 	token := p.advance() // consume 'var'
 	p.skipNewlines()
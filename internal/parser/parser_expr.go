@@ -1,11 +1,14 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
 )
 
@@ -53,9 +56,19 @@ func (p *Parser) parseOrExpr() ast.Expression {
 func (p *Parser) parsePipeExpr() ast.Expression {
 	left := p.parseAndExpr()
 
-	for p.match(lexer.TOKEN_PIPE) {
+	for p.match(lexer.TOKEN_PIPE, lexer.TOKEN_PARALLEL_PIPE) {
 		operator := p.previousToken()
 
+		// Parallel pipe: list |>> f() maps f over the list concurrently
+		if operator.Type == lexer.TOKEN_PARALLEL_PIPE {
+			left = &ast.ParallelPipeExpr{
+				Token: operator,
+				Left:  left,
+				Right: p.parseAndExpr(),
+			}
+			continue
+		}
+
 		// Check for piped switch: expr |> switch
 		if p.check(lexer.TOKEN_SWITCH) {
 			switchToken := p.advance() // consume 'switch'
@@ -153,11 +166,30 @@ func (p *Parser) parseComparisonExpr() ast.Expression {
 			operator = p.advance() // consume NOT
 			operator.Lexeme = "not in"
 			p.advance() // consume IN
+		} else if p.check(lexer.TOKEN_IDENTIFIER) && p.peekToken().Lexeme == "between" {
+			left = p.parseBetween(left)
+			continue
 		} else {
 			break
 		}
 
 		right := p.parseAdditiveExpr()
+		if (operator.Type == lexer.TOKEN_IN || operator.Lexeme == "not in") && (p.check(lexer.TOKEN_TO) || p.check(lexer.TOKEN_THROUGH)) {
+			bound := p.advance()
+			left = &ast.RangeCheckExpr{
+				Token:   operator,
+				Value:   left,
+				Low:     right,
+				High:    p.parseAdditiveExpr(),
+				Bound:   bound.Lexeme,
+				Negated: operator.Lexeme == "not in",
+			}
+			continue
+		}
+		if isOrderingOperator(operator.Type) && isOrderingOperator(p.peekToken().Type) {
+			left = p.parseComparisonChain(left, operator, right)
+			continue
+		}
 		left = &ast.BinaryExpr{
 			Token:    operator,
 			Left:     left,
@@ -169,6 +201,47 @@ func (p *Parser) parseComparisonExpr() ast.Expression {
 	return left
 }
 
+// isOrderingOperator reports whether t is <, <=, > or >=, the operators that
+// chain: 0 <= x < 10 means 0 <= x and x < 10.
+func isOrderingOperator(t lexer.TokenType) bool {
+	switch t {
+	case lexer.TOKEN_LT, lexer.TOKEN_LTE, lexer.TOKEN_GT, lexer.TOKEN_GTE:
+		return true
+	}
+	return false
+}
+
+// parseComparisonChain parses the rest of a chain of ordering comparisons
+// whose first comparison is left operator right.
+func (p *Parser) parseComparisonChain(left ast.Expression, operator lexer.Token, right ast.Expression) ast.Expression {
+	chain := &ast.ComparisonChainExpr{
+		Token:     operator,
+		Operands:  []ast.Expression{left, right},
+		Operators: []lexer.Token{operator},
+	}
+	for isOrderingOperator(p.peekToken().Type) {
+		chain.Operators = append(chain.Operators, p.advance())
+		chain.Operands = append(chain.Operands, p.parseAdditiveExpr())
+	}
+	return chain
+}
+
+// parseBetween parses `between low and high` after value. between is
+// contextual: it is only an operator after an operand, and stays usable as a
+// name elsewhere.
+func (p *Parser) parseBetween(value ast.Expression) ast.Expression {
+	token := p.advance() // consume 'between'
+	low := p.parseAdditiveExpr()
+	p.consume(lexer.TOKEN_AND, "expected 'and' after the lower bound of 'between'")
+	return &ast.RangeCheckExpr{
+		Token: token,
+		Value: value,
+		Low:   low,
+		High:  p.parseAdditiveExpr(),
+		Bound: "and",
+	}
+}
+
 func (p *Parser) parseAdditiveExpr() ast.Expression {
 	left := p.parseMultiplicativeExpr()
 
@@ -304,7 +377,7 @@ func (p *Parser) parsePostfixExpr() ast.Expression {
 						for {
 							fieldName := p.parseIdentifier()
 							p.consume(lexer.TOKEN_COLON, "expected ':' after field name")
-							fieldValue := p.parseExpression()
+							fieldValue := p.parseElementExpr()
 							fields = append(fields, &ast.FieldValue{
 								Name:  fieldName,
 								Value: fieldValue,
@@ -399,16 +472,118 @@ func (p *Parser) parsePostfixExpr() ast.Expression {
 	}
 }
 
+// isReadExpr reports whether the next tokens are `read line` or `read all`.
+// Two identifiers in a row are not otherwise an expression, so a variable
+// or function named read is unaffected.
+func (p *Parser) isReadExpr() bool {
+	if p.peekToken().Lexeme != "read" {
+		return false
+	}
+	next := p.peekNextToken()
+	return next.Type == lexer.TOKEN_IDENTIFIER && (next.Lexeme == "line" || next.Lexeme == "all")
+}
+
+func (p *Parser) parseReadExpr() ast.Expression {
+	token := p.advance() // consume 'read'
+	kind := p.advance()  // consume 'line' or 'all'
+	return &ast.ReadExpr{Token: token, All: kind.Lexeme == "all"}
+}
+
+// isBuildStringExpr reports whether the next tokens are `build string`, which
+// like `read line` is not otherwise an expression.
+func (p *Parser) isBuildStringExpr() bool {
+	if p.peekToken().Lexeme != "build" {
+		return false
+	}
+	next := p.peekNextToken()
+	return next.Type == lexer.TOKEN_IDENTIFIER && next.Lexeme == "string"
+}
+
+// parseBuildStringExpr parses build string as NAME NEWLINE INDENT ... DEDENT.
+func (p *Parser) parseBuildStringExpr() ast.Expression {
+	token := p.advance() // consume 'build'
+	p.advance()          // consume 'string'
+	if !p.match(lexer.TOKEN_AS) {
+		p.error(token, "build string needs a name for its builder, e.g. build string as b")
+		return nil
+	}
+	builder := p.parseIdentifier()
+	if builder == nil {
+		return nil
+	}
+	p.skipNewlines()
+	if !p.check(lexer.TOKEN_INDENT) {
+		p.error(token, "build string must be followed by an indented block")
+		return nil
+	}
+	return &ast.BuildStringExpr{Token: token, Builder: builder, Body: p.parseBlock()}
+}
+
+// isWaitExpr reports whether the next tokens are `wait for`. An identifier
+// followed by the for keyword is not otherwise an expression.
+func (p *Parser) isWaitExpr() bool {
+	return p.peekToken().Lexeme == "wait" && p.peekNextToken().Type == lexer.TOKEN_FOR
+}
+
+// parseWaitExpr parses wait for [all] CHANNEL [within DURATION]. all is only
+// taken as the keyword when a channel list follows it, so a channel named
+// all still works.
+func (p *Parser) parseWaitExpr() ast.Expression {
+	token := p.advance() // consume 'wait'
+	p.advance()          // consume 'for'
+	expr := &ast.WaitExpr{Token: token}
+	if p.peekToken().Lexeme == "all" {
+		next := p.peekNextToken()
+		if next.Type == lexer.TOKEN_LBRACKET || (next.Type == lexer.TOKEN_IDENTIFIER && next.Lexeme != "within") {
+			p.advance() // consume 'all'
+			expr.All = true
+		}
+	}
+	expr.Channel = p.parseExpression()
+	if p.check(lexer.TOKEN_IDENTIFIER) && p.peekToken().Lexeme == "within" {
+		p.advance() // consume 'within'
+		expr.Timeout = p.parseExpression()
+	}
+	return expr
+}
+
+// parseCommandExpr parses $ "command args...". The command must be a string
+// literal: it is split into arguments at compile time (ast.CommandWords).
+func (p *Parser) parseCommandExpr() ast.Expression {
+	token := p.advance() // consume '$'
+	var command *ast.StringLiteral
+	switch p.peekToken().Type {
+	case lexer.TOKEN_STRING:
+		command = p.parseStringLiteral()
+	case lexer.TOKEN_STRING_HEAD:
+		command = p.parseInterpolatedStringLiteral()
+	default:
+		p.error(token, "$ must be followed by a string literal command, e.g. $ \"git status\"")
+		return nil
+	}
+	return &ast.CommandExpr{Token: token, Command: command}
+}
+
 func (p *Parser) parsePrimaryExpr() ast.Expression {
 	switch p.peekToken().Type {
 	case lexer.TOKEN_INTEGER:
 		return p.parseIntegerLiteral()
 	case lexer.TOKEN_FLOAT:
 		return p.parseFloatLiteral()
+	case lexer.TOKEN_DECIMAL:
+		token := p.advance()
+		value := strings.ReplaceAll(strings.TrimSuffix(token.Lexeme, "d"), "_", "")
+		return &ast.DecimalLiteral{Token: token, Value: value}
 	case lexer.TOKEN_STRING:
 		return p.parseStringLiteral()
 	case lexer.TOKEN_STRING_HEAD:
 		return p.parseInterpolatedStringLiteral()
+	case lexer.TOKEN_DATA_BLOCK, lexer.TOKEN_DATA_LINES:
+		token := p.advance()
+		return &ast.DataLiteral{Token: token, Value: token.Lexeme, Lines: token.Type == lexer.TOKEN_DATA_LINES}
+	case lexer.TOKEN_REGEX:
+		token := p.advance()
+		return &ast.RegexLiteral{Token: token, Pattern: token.Lexeme}
 	case lexer.TOKEN_RUNE:
 		return p.parseRuneLiteral()
 	case lexer.TOKEN_TRUE, lexer.TOKEN_FALSE:
@@ -418,6 +593,15 @@ func (p *Parser) parsePrimaryExpr() ast.Expression {
 		if p.peekNextToken().Type == lexer.TOKEN_FAT_ARROW {
 			return p.parseArrowLambda()
 		}
+		if p.isReadExpr() {
+			return p.parseReadExpr()
+		}
+		if p.isBuildStringExpr() {
+			return p.parseBuildStringExpr()
+		}
+		if p.isWaitExpr() {
+			return p.parseWaitExpr()
+		}
 		return p.parseIdentifierOrStructLiteral()
 	case lexer.TOKEN_EMPTY:
 		// empty is usually a literal, but it can also be used as an identifier.
@@ -427,11 +611,11 @@ func (p *Parser) parsePrimaryExpr() ast.Expression {
 		if next == lexer.TOKEN_WALRUS || next == lexer.TOKEN_ASSIGN ||
 			next == lexer.TOKEN_BIT_AND || next == lexer.TOKEN_BIT_AND_ASSIGN ||
 			next == lexer.TOKEN_DOT || next == lexer.TOKEN_LBRACKET ||
-			next == lexer.TOKEN_COLON || next == lexer.TOKEN_PIPE ||
+			next == lexer.TOKEN_COLON || next == lexer.TOKEN_PIPE || next == lexer.TOKEN_PARALLEL_PIPE ||
 			next == lexer.TOKEN_RPAREN || next == lexer.TOKEN_COMMA ||
 			next == lexer.TOKEN_STRING_MID || next == lexer.TOKEN_STRING_TAIL {
 			token := p.advance()
-			return &ast.Identifier{Token: token, Value: token.Lexeme}
+			return p.newIdentifier(token, token.Lexeme)
 		}
 		return p.parseEmptyExpr()
 	case lexer.TOKEN_DISCARD:
@@ -440,7 +624,7 @@ func (p *Parser) parsePrimaryExpr() ast.Expression {
 	case lexer.TOKEN_ERROR:
 		if p.isIdentifierFollower() || p.check(lexer.TOKEN_RPAREN) || p.check(lexer.TOKEN_COMMA) || p.check(lexer.TOKEN_COLON) {
 			token := p.advance()
-			return &ast.Identifier{Token: token, Value: token.Lexeme}
+			return p.newIdentifier(token, token.Lexeme)
 		}
 		return p.parseErrorExpr()
 	case lexer.TOKEN_MAKE:
@@ -454,18 +638,20 @@ func (p *Parser) parsePrimaryExpr() ast.Expression {
 		return &ast.RecoverExpr{Token: token}
 	case lexer.TOKEN_RECEIVE:
 		return p.parseReceiveExpr()
+	case lexer.TOKEN_DOLLAR:
+		return p.parseCommandExpr()
 	case lexer.TOKEN_LIST:
 		if p.peekNextToken().Type == lexer.TOKEN_OF {
 			return p.parseTypedListLiteral()
 		}
 		token := p.advance()
-		return &ast.Identifier{Token: token, Value: token.Lexeme}
+		return p.newIdentifier(token, token.Lexeme)
 	case lexer.TOKEN_MAP:
 		if p.peekNextToken().Type == lexer.TOKEN_OF {
 			return p.parseMapLiteral()
 		}
 		token := p.advance()
-		return &ast.Identifier{Token: token, Value: token.Lexeme}
+		return p.newIdentifier(token, token.Lexeme)
 	case lexer.TOKEN_LBRACKET:
 		return p.parseListLiteral()
 	case lexer.TOKEN_LPAREN:
@@ -482,34 +668,38 @@ func (p *Parser) parsePrimaryExpr() ast.Expression {
 		return p.parseReturnExpr()
 	default:
 		tok := p.peekToken()
-		p.error(tok, fmt.Sprintf("unexpected token in expression: %s", tok.Type))
+		p.errorMsg(tok, catalog.UnexpectedExpression, tok.Type)
 		p.advance()
 		// Return a sentinel so callers don't need nil checks.
 		// The error is already recorded; codegen will not run.
-		return &ast.Identifier{Token: tok, Value: "_"}
+		return p.newIdentifier(tok, "_")
 	}
 }
 
 func (p *Parser) parseIdentifier() *ast.Identifier {
 	token := p.advance()
 	if token.Type != lexer.TOKEN_IDENTIFIER && token.Type != lexer.TOKEN_EMPTY && token.Type != lexer.TOKEN_ERROR {
-		p.error(token, "expected identifier")
+		p.errorMsg(token, catalog.ExpectedIdentifier)
 		// Return a sentinel so callers don't need nil checks.
 		// The error is already recorded; codegen will not run.
-		return &ast.Identifier{Token: token, Value: "_"}
-	}
-	return &ast.Identifier{
-		Token: token,
-		Value: token.Lexeme,
+		return p.newIdentifier(token, "_")
 	}
+	return p.newIdentifier(token, token.Lexeme)
 }
 
 func (p *Parser) parseIntegerLiteral() *ast.IntegerLiteral {
 	token := p.advance()
 	// Use base 0 to auto-detect: 0x=hex, 0o/0=octal, 0b=binary, otherwise decimal
 	value, err := strconv.ParseInt(token.Lexeme, 0, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		// Too large for int64: keep the exact value so the analyzer can say
+		// where it fits, and codegen emits it as an untyped Go constant.
+		if n, ok := new(big.Int).SetString(token.Lexeme, 0); ok {
+			return &ast.IntegerLiteral{Token: token, Big: n}
+		}
+	}
 	if err != nil {
-		p.error(token, fmt.Sprintf("could not parse integer: %s", err))
+		p.error(token, fmt.Sprintf("invalid integer literal '%s'", token.Lexeme))
 		return &ast.IntegerLiteral{Token: token, Value: 0}
 	}
 	return &ast.IntegerLiteral{
@@ -522,7 +712,7 @@ func (p *Parser) parseFloatLiteral() *ast.FloatLiteral {
 	token := p.advance()
 	value, err := strconv.ParseFloat(token.Lexeme, 64)
 	if err != nil {
-		p.error(token, fmt.Sprintf("could not parse float: %s", err))
+		p.error(token, fmt.Sprintf("invalid float literal '%s'", token.Lexeme))
 		return &ast.FloatLiteral{Token: token, Value: 0}
 	}
 	return &ast.FloatLiteral{
@@ -572,7 +762,7 @@ func (p *Parser) parseInterpolatedStringLiteral() *ast.StringLiteral {
 			if i > startPos {
 				valueBuf.WriteByte(' ')
 			}
-			valueBuf.WriteString(p.tokens[i].Lexeme)
+			valueBuf.WriteString(p.tokenAt(i).Lexeme)
 		}
 		valueBuf.WriteByte('}')
 		parts = append(parts, &ast.StringInterpolation{
@@ -683,7 +873,7 @@ func (p *Parser) parseIdentifierOrStructLiteral() ast.Expression {
 				for {
 					fieldName := p.parseIdentifier()
 					p.consume(lexer.TOKEN_COLON, "expected ':' after field name")
-					fieldValue := p.parseExpression()
+					fieldValue := p.parseElementExpr()
 					fields = append(fields, &ast.FieldValue{
 						Name:  fieldName,
 						Value: fieldValue,
@@ -709,7 +899,7 @@ func (p *Parser) parseIdentifierOrStructLiteral() ast.Expression {
 
 				fieldName := p.parseIdentifier()
 				p.consume(lexer.TOKEN_COLON, "expected ':' after field name")
-				fieldValue := p.parseExpression()
+				fieldValue := p.parseElementExpr()
 				fields = append(fields, &ast.FieldValue{Name: fieldName, Value: fieldValue})
 
 				if p.check(lexer.TOKEN_COMMA) {
@@ -740,7 +930,7 @@ func (p *Parser) parseEmptyExpr() *ast.EmptyExpr {
 	next := p.peekToken().Type
 	if !p.check(lexer.TOKEN_NEWLINE) && !p.check(lexer.TOKEN_COMMA) && !p.check(lexer.TOKEN_RPAREN) &&
 		!p.check(lexer.TOKEN_AND) && !p.check(lexer.TOKEN_OR) && !p.check(lexer.TOKEN_NOT_EQUALS) &&
-		!p.check(lexer.TOKEN_DOUBLE_EQUALS) && !p.check(lexer.TOKEN_BANG) && !p.check(lexer.TOKEN_PIPE) &&
+		!p.check(lexer.TOKEN_DOUBLE_EQUALS) && !p.check(lexer.TOKEN_BANG) && !p.check(lexer.TOKEN_PIPE) && !p.check(lexer.TOKEN_PARALLEL_PIPE) &&
 		!p.isAtEnd() {
 		// Only parse if it looks like a type name or keywords like 'map', 'list', 'func', 'channel'
 		if next == lexer.TOKEN_IDENTIFIER || next == lexer.TOKEN_MAP || next == lexer.TOKEN_LIST ||
@@ -821,7 +1011,7 @@ func (p *Parser) parseListLiteral() *ast.ListLiteralExpr {
 
 	if !p.check(lexer.TOKEN_RBRACKET) {
 		for {
-			elements = append(elements, p.parseExpression())
+			elements = append(elements, p.parseElementExpr())
 			if !p.match(lexer.TOKEN_COMMA) {
 				break
 			}
@@ -862,7 +1052,7 @@ func (p *Parser) parseTypedListLiteral() ast.Expression {
 	elements := []ast.Expression{}
 	if !p.check(lexer.TOKEN_RBRACE) {
 		for {
-			elements = append(elements, p.parseExpression())
+			elements = append(elements, p.parseElementExpr())
 			if !p.match(lexer.TOKEN_COMMA) {
 				break
 			}
@@ -902,9 +1092,13 @@ func (p *Parser) parseFunctionLiteral() *ast.FunctionLiteral {
 		returns = p.parseReturnTypes()
 	}
 
-	// Parse body
+	// Parse body; a literal's yield(v) is an ordinary call, not the
+	// enclosing iterator's yield
 	p.skipNewlines()
+	outerIterator := p.inIterator
+	p.inIterator = false
 	body := p.parseBlock()
+	p.inIterator = outerIterator
 
 	return &ast.FunctionLiteral{
 		Token:      token,
@@ -963,7 +1157,7 @@ func (p *Parser) parseArrowLambda() *ast.ArrowLambda {
 		// Single untyped param: x => ...
 		paramToken := p.advance()
 		params = append(params, &ast.Parameter{
-			Name: &ast.Identifier{Token: paramToken, Value: paramToken.Lexeme},
+			Name: p.newIdentifier(paramToken, paramToken.Lexeme),
 		})
 	} else if p.check(lexer.TOKEN_LPAREN) {
 		p.advance() // consume '('
@@ -984,7 +1178,10 @@ func (p *Parser) parseArrowLambda() *ast.ArrowLambda {
 	if p.check(lexer.TOKEN_NEWLINE) || p.check(lexer.TOKEN_INDENT) {
 		p.skipNewlines()
 		if p.check(lexer.TOKEN_INDENT) {
+			outerIterator := p.inIterator
+			p.inIterator = false
 			lambda.Block = p.parseBlock()
+			p.inIterator = outerIterator
 		} else {
 			// Newline but no indent — parse as expression
 			lambda.Body = p.parseExpression()
@@ -1109,7 +1306,7 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 			// Newlines are suppressed inside braces by lexer, but we can verify
 			key := p.parseExpression()
 			p.consume(lexer.TOKEN_COLON, "expected ':' after map key")
-			val := p.parseExpression()
+			val := p.parseElementExpr()
 
 			pairs = append(pairs, &ast.KeyValuePair{Key: key, Value: val})
 
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/duber000/kukicha/internal/ast"
+	"strings"
 	"testing"
 )
 
@@ -162,6 +163,94 @@ func TestParsePipeExpressionMultiLine(t *testing.T) {
 	}
 }
 
+func TestParseParallelPipeExpression(t *testing.T) {
+	input := `func Test(urls list of string) list of int
+    return urls
+        |>> fetch()
+        |> Sum()
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	retStmt := fn.Body.Statements[0].(*ast.ReturnStmt)
+	outer, ok := retStmt.Values[0].(*ast.PipeExpr)
+	if !ok {
+		t.Fatalf("expected outer PipeExpr, got %T", retStmt.Values[0])
+	}
+	parallel, ok := outer.Left.(*ast.ParallelPipeExpr)
+	if !ok {
+		t.Fatalf("expected ParallelPipeExpr on Left, got %T", outer.Left)
+	}
+	if _, ok := parallel.Right.(*ast.CallExpr); !ok {
+		t.Errorf("expected call on the right of |>>, got %T", parallel.Right)
+	}
+}
+
+func TestParseComparisonChain(t *testing.T) {
+	input := `func Test(x int, ok bool) bool
+    return 0 <= x < 10 <= 20 and ok == x > 1
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	and := fn.Body.Statements[0].(*ast.ReturnStmt).Values[0].(*ast.BinaryExpr)
+	chain, ok := and.Left.(*ast.ComparisonChainExpr)
+	if !ok {
+		t.Fatalf("expected ComparisonChainExpr, got %T", and.Left)
+	}
+	if len(chain.Operands) != 4 || len(chain.Operators) != 3 {
+		t.Fatalf("expected 4 operands and 3 operators, got %d and %d", len(chain.Operands), len(chain.Operators))
+	}
+	if chain.Operators[0].Lexeme != "<=" || chain.Operators[1].Lexeme != "<" || chain.Operators[2].Lexeme != "<=" {
+		t.Errorf("unexpected operators %v", chain.Operators)
+	}
+
+	// Only ordering operators chain: ok == x > 1 stays (ok == x) > 1
+	gt, ok := and.Right.(*ast.BinaryExpr)
+	if !ok || gt.Operator != ">" {
+		t.Fatalf("expected > BinaryExpr, got %T", and.Right)
+	}
+	if eq, ok := gt.Left.(*ast.BinaryExpr); !ok || eq.Operator != "==" {
+		t.Errorf("expected == BinaryExpr on the left, got %T", gt.Left)
+	}
+}
+
+func TestParseRangeCheck(t *testing.T) {
+	input := `func Test(x int, between int) bool
+    return x between 1 and between + 1 and x not in 0 to 10 or x in 1 through 5
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	or := fn.Body.Statements[0].(*ast.ReturnStmt).Values[0].(*ast.BinaryExpr)
+	and := or.Left.(*ast.BinaryExpr)
+	tests := []struct {
+		expr    ast.Expression
+		bound   string
+		negated bool
+	}{
+		{and.Left, "and", false},
+		{and.Right, "to", true},
+		{or.Right, "through", false},
+	}
+	for _, tt := range tests {
+		check, ok := tt.expr.(*ast.RangeCheckExpr)
+		if !ok {
+			t.Fatalf("expected RangeCheckExpr, got %T", tt.expr)
+		}
+		if check.Bound != tt.bound || check.Negated != tt.negated {
+			t.Errorf("got bound %q negated %v, want %q %v", check.Bound, check.Negated, tt.bound, tt.negated)
+		}
+	}
+	// between is still a name: the upper bound is between + 1
+	if high, ok := and.Left.(*ast.RangeCheckExpr).High.(*ast.BinaryExpr); !ok || high.Left.(*ast.Identifier).Value != "between" {
+		t.Errorf("expected between + 1 as the upper bound, got %T", and.Left.(*ast.RangeCheckExpr).High)
+	}
+}
+
 func TestParseOnErrStatement(t *testing.T) {
 	input := `func Test()
     val := ReadFile("test.txt") onerr 0
@@ -193,6 +282,61 @@ func TestParseOnErrStatement(t *testing.T) {
 	}
 }
 
+func TestParseOnErrInFieldAndArgument(t *testing.T) {
+	input := `func Test()
+    cfg := Config{port: GetPort() onerr 8080, host: "h"}
+    print(label(strconv.Atoi(s) onerr as e explain "parse"), 1)
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	lit := fn.Body.Statements[0].(*ast.VarDeclStmt).Values[0].(*ast.StructLiteralExpr)
+	field, ok := lit.Fields[0].Value.(*ast.OnErrExpr)
+	if !ok {
+		t.Fatalf("expected OnErrExpr field value, got %T", lit.Fields[0].Value)
+	}
+	if _, ok := field.Expression.(*ast.CallExpr); !ok || field.OnErr.Handler == nil {
+		t.Errorf("expected call with a handler, got %T / %v", field.Expression, field.OnErr.Handler)
+	}
+	if len(lit.Fields) != 2 {
+		t.Errorf("expected the comma to end the handler, got %d fields", len(lit.Fields))
+	}
+
+	call := fn.Body.Statements[1].(*ast.ExpressionStmt).Expression.(*ast.CallExpr)
+	label := call.Arguments[0].(*ast.CallExpr)
+	arg, ok := label.Arguments[0].(*ast.OnErrExpr)
+	if !ok {
+		t.Fatalf("expected OnErrExpr argument, got %T", label.Arguments[0])
+	}
+	if arg.OnErr.Alias != "e" || arg.OnErr.Explain != "parse" {
+		t.Errorf("expected alias e and explain \"parse\", got %q, %q", arg.OnErr.Alias, arg.OnErr.Explain)
+	}
+	if len(call.Arguments) != 2 {
+		t.Errorf("expected 2 print arguments, got %d", len(call.Arguments))
+	}
+	if got := ast.OnErrExprs(fn.Body.Statements[1]); len(got) != 1 || got[0] != arg {
+		t.Errorf("OnErrExprs = %v, want the label argument", got)
+	}
+}
+
+func TestParseOnErrBlockInExpressionRejected(t *testing.T) {
+	input := `func Test()
+    cfg := Config
+        port: GetPort() onerr
+            print("no port")
+`
+
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errs := p.Parse()
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "needs an inline handler") {
+		t.Errorf("expected inline handler error, got %v", errs)
+	}
+}
+
 func TestParseListType(t *testing.T) {
 	input := `func Test(items list of string)
     return items
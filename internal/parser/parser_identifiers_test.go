@@ -1,8 +1,12 @@
 package parser
 
 import (
-	"github.com/duber000/kukicha/internal/ast"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/duber000/kukicha/internal/ast"
 )
 
 func TestErrorAsVariableName(t *testing.T) {
@@ -304,3 +308,162 @@ interface Foo
 		t.Errorf("expected args [\"Use NewFoo instead\"], got %v", d.Args)
 	}
 }
+
+func TestRouteDirective(t *testing.T) {
+	input := `# route: GET /users/{id}
+func getUser(id int) string
+    return "user"
+`
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	program, errors := p.Parse()
+	if len(errors) > 0 {
+		t.Fatalf("parser errors: %v", errors)
+	}
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	if len(fn.Directives) != 1 {
+		t.Fatalf("expected 1 directive, got %d", len(fn.Directives))
+	}
+	d := fn.Directives[0]
+	if d.Name != "route" || len(d.Args) != 2 || d.Args[0] != "GET" || d.Args[1] != "/users/{id}" {
+		t.Errorf("expected route directive [GET /users/{id}], got %q %v", d.Name, d.Args)
+	}
+}
+
+func TestScheduleDirective(t *testing.T) {
+	program := mustParseProgram(t, `# schedule: "*/5 * * * *"
+func cleanup()
+    print("clean")
+
+# schedule: 0 9 * * MON-FRI
+func report()
+    print("report")
+`)
+	for i, want := range [][]string{{"*/5 * * * *"}, {"0", "9", "*", "*", "MON-FRI"}} {
+		fn := program.Declarations[i].(*ast.FunctionDecl)
+		if len(fn.Directives) != 1 || fn.Directives[0].Name != "schedule" || !slices.Equal(fn.Directives[0].Args, want) {
+			t.Errorf("%s: expected schedule directive %q, got %+v", fn.Name.Value, want, fn.Directives)
+		}
+	}
+}
+
+func TestLanguagePragma(t *testing.T) {
+	program := mustParseProgram(t, `# Tool header
+# kukicha: 0.0.16
+
+func main()
+    # kukicha: 9.9 is just a comment here
+    print("hi")
+`)
+	if program.Language != "0.0.16" {
+		t.Errorf("expected Language 0.0.16, got %q", program.Language)
+	}
+	if program.LanguagePos.Line != 2 {
+		t.Errorf("expected pragma on line 2, got %d", program.LanguagePos.Line)
+	}
+}
+
+func TestLanguagePragmaInvalid(t *testing.T) {
+	p, err := New("# kukicha: one.two\n\nfunc main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors := p.Parse()
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "invalid kukicha version") {
+		t.Errorf("expected invalid version error, got %v", errors)
+	}
+}
+
+func TestShutdownPragma(t *testing.T) {
+	program := mustParseProgram(t, "# shutdown: 10s\n\nfunc main()\n    print(1)\n")
+	if program.Shutdown != "on" || program.ShutdownGrace != 10*time.Second {
+		t.Errorf("expected shutdown on with 10s grace, got %q %v", program.Shutdown, program.ShutdownGrace)
+	}
+
+	program = mustParseProgram(t, "# shutdown: off\n\nfunc main()\n    print(1)\n")
+	if program.Shutdown != "off" {
+		t.Errorf("expected shutdown off, got %q", program.Shutdown)
+	}
+
+	p, err := New("# shutdown: later\n\nfunc main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors := p.Parse()
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "invalid # shutdown: pragma 'later'") {
+		t.Errorf("expected invalid shutdown pragma error, got %v", errors)
+	}
+}
+
+func TestOnErrPragma(t *testing.T) {
+	program := mustParseProgram(t, "# onerr: log\n\nfunc main()\n    print(1)\n")
+	if program.OnErrLog != "log" {
+		t.Errorf("expected onerr log, got %q", program.OnErrLog)
+	}
+
+	p, err := New("# onerr: log\n# onerr: verbose\n\nfunc main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors := p.Parse()
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "duplicate # onerr: pragma") {
+		t.Errorf("expected duplicate onerr pragma error, got %v", errors)
+	}
+
+	p, err = New("# onerr: verbose\n\nfunc main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors = p.Parse()
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "invalid # onerr: pragma 'verbose'") {
+		t.Errorf("expected invalid onerr pragma error, got %v", errors)
+	}
+}
+
+func TestHeaderPragma(t *testing.T) {
+	program := mustParseProgram(t, "# header: // SPDX-License-Identifier: MIT\n# header: //go:generate go run ./gen\n\nfunc main()\n    # header: // not in the file header\n    print(1)\n")
+	want := []string{"// SPDX-License-Identifier: MIT", "//go:generate go run ./gen"}
+	if !slices.Equal(program.Header, want) {
+		t.Errorf("expected header %q, got %q", want, program.Header)
+	}
+
+	p, err := New("# header: package evil\n\nfunc main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors := p.Parse()
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "invalid # header: pragma 'package evil'") {
+		t.Errorf("expected invalid header pragma error, got %v", errors)
+	}
+}
+
+func TestAnnotationDirectiveAttachedToType(t *testing.T) {
+	input := `@derive json, stringer
+type Point
+    x int
+`
+	p, err := New(input, "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	program, errors := p.Parse()
+	if len(errors) > 0 {
+		t.Fatalf("parser errors: %v", errors)
+	}
+	td, ok := program.Declarations[0].(*ast.TypeDecl)
+	if !ok {
+		t.Fatalf("expected TypeDecl, got %T", program.Declarations[0])
+	}
+	if len(td.Directives) != 1 || td.Directives[0].Name != "derive" {
+		t.Fatalf("expected one 'derive' directive, got %+v", td.Directives)
+	}
+	var names []string
+	for _, d := range ast.TypeDerives(td) {
+		names = append(names, d.Name)
+	}
+	if got := strings.Join(names, " "); got != "json stringer" {
+		t.Errorf("expected derives 'json stringer', got %q", got)
+	}
+}
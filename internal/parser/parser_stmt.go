@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
 )
 
@@ -28,21 +29,21 @@ func (p *Parser) parseCallArguments() ([]ast.Expression, []*ast.NamedArgument, b
 		// We need to look ahead to see if this is "name: value" syntax
 		if p.check(lexer.TOKEN_IDENTIFIER) && p.peekNextToken().Type == lexer.TOKEN_COLON {
 			// Named argument
-			nameToken := p.advance()     // consume identifier
-			p.advance()                  // consume colon
-			value := p.parseExpression() // parse value
+			nameToken := p.advance()      // consume identifier
+			p.advance()                   // consume colon
+			value := p.parseElementExpr() // parse value
 			namedArgs = append(namedArgs, &ast.NamedArgument{
 				Token: nameToken,
-				Name:  &ast.Identifier{Token: nameToken, Value: nameToken.Lexeme},
+				Name:  p.newIdentifier(nameToken, nameToken.Lexeme),
 				Value: value,
 			})
 			hasNamedArg = true
 		} else {
 			// Positional argument
 			if hasNamedArg {
-				p.error(p.peekToken(), "positional argument cannot follow named argument")
+				p.errorMsg(p.peekToken(), catalog.PositionalAfterNamed)
 			}
-			args = append(args, p.parseExpression())
+			args = append(args, p.parseElementExpr())
 		}
 
 		if !p.match(lexer.TOKEN_COMMA) {
@@ -62,7 +63,7 @@ func (p *Parser) parseBlock() *ast.BlockStmt {
 	statements := []ast.Statement{}
 
 	if !p.match(lexer.TOKEN_INDENT) {
-		p.error(token, "expected indented block")
+		p.errorMsg(token, catalog.ExpectedBlock)
 		return &ast.BlockStmt{Token: token, Statements: statements}
 	}
 
@@ -108,6 +109,25 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseContinueStmt()
 	case lexer.TOKEN_BREAK:
 		return p.parseBreakStmt()
+	case lexer.TOKEN_CASE:
+		if p.isWhenTarget() {
+			return p.parseTargetStmt()
+		}
+		return p.parseExpressionOrAssignmentStmt()
+	case lexer.TOKEN_IDENTIFIER:
+		if p.isSafelyBlock() {
+			return p.parseSafelyStmt()
+		}
+		if p.isFailStmt() {
+			return p.parseFailStmt()
+		}
+		if p.isRequireStmt() {
+			return p.parseRequireStmt()
+		}
+		if p.isYieldStmt() {
+			return p.parseYieldStmt()
+		}
+		return p.parseExpressionOrAssignmentStmt()
 	default:
 		return p.parseExpressionOrAssignmentStmt()
 	}
@@ -135,8 +155,16 @@ func (p *Parser) parseReturnStmt() *ast.ReturnStmt {
 	return stmt
 }
 
-func (p *Parser) parseContinueStmt() *ast.ContinueStmt {
+func (p *Parser) parseContinueStmt() ast.Statement {
 	token := p.advance()
+	// `continue to next` falls through to the next when branch; `next` is
+	// only a keyword here
+	if p.check(lexer.TOKEN_TO) && p.peekNextToken().Type == lexer.TOKEN_IDENTIFIER && p.peekNextToken().Lexeme == "next" {
+		p.advance() // consume 'to'
+		p.advance() // consume 'next'
+		p.skipNewlines()
+		return &ast.FallthroughStmt{Token: token}
+	}
 	p.skipNewlines()
 	return &ast.ContinueStmt{Token: token}
 }
@@ -243,6 +271,45 @@ func (p *Parser) parseIfStmt() *ast.IfStmt {
 	return stmt
 }
 
+// isWhenTarget reports whether the next tokens start a `when target` block.
+func (p *Parser) isWhenTarget() bool {
+	next := p.peekNextToken()
+	return p.check(lexer.TOKEN_CASE) && next.Type == lexer.TOKEN_IDENTIFIER && next.Lexeme == "target"
+}
+
+// parseTargetNames parses `when target NAME[, NAME...]` up to the block.
+func (p *Parser) parseTargetNames() (lexer.Token, []*ast.Identifier) {
+	token := p.advance() // consume 'when'
+	p.advance()          // consume 'target'
+	targets := []*ast.Identifier{p.parseIdentifier()}
+	for p.match(lexer.TOKEN_COMMA) {
+		targets = append(targets, p.parseIdentifier())
+	}
+	p.skipNewlines()
+	return token, targets
+}
+
+func (p *Parser) parseTargetStmt() *ast.TargetStmt {
+	token, targets := p.parseTargetNames()
+	stmt := &ast.TargetStmt{
+		Token:   token,
+		Targets: targets,
+		Body:    p.parseBlock(),
+	}
+
+	p.skipNewlines()
+	if p.match(lexer.TOKEN_DEFAULT) {
+		otherwiseToken := p.previousToken()
+		p.skipNewlines()
+		stmt.Otherwise = &ast.OtherwiseCase{
+			Token: otherwiseToken,
+			Body:  p.parseBlock(),
+		}
+		p.skipNewlines()
+	}
+	return stmt
+}
+
 func (p *Parser) parseSwitchOrTypeSwitchStmt() ast.Statement {
 	token := p.advance() // consume 'switch'
 
@@ -257,10 +324,7 @@ func (p *Parser) parseSwitchOrTypeSwitchStmt() ast.Statement {
 	// where TargetType is a simple NamedType (the binding name)
 	if cast, ok := expr.(*ast.TypeCastExpr); ok {
 		if named, ok := cast.TargetType.(*ast.NamedType); ok {
-			return p.parseTypeSwitchBody(token, cast.Expression, &ast.Identifier{
-				Token: named.Token,
-				Value: named.Name,
-			})
+			return p.parseTypeSwitchBody(token, cast.Expression, p.newIdentifier(named.Token, named.Name))
 		}
 	}
 
@@ -290,11 +354,16 @@ func (p *Parser) parseSwitchBody(token lexer.Token, expr ast.Expression) *ast.Sw
 		if p.match(lexer.TOKEN_CASE) {
 			caseToken := p.previousToken()
 			if stmt.Otherwise != nil {
-				p.error(caseToken, "'when' branch after 'otherwise' will never execute")
+				p.errorMsg(caseToken, catalog.UnreachableWhen)
 			}
-			values := []ast.Expression{p.parseExpression()}
+			values := []ast.Expression{p.parseWhenValue()}
 			for p.match(lexer.TOKEN_COMMA) {
-				values = append(values, p.parseExpression())
+				values = append(values, p.parseWhenValue())
+			}
+			// `when "fetch" if allowNetwork` only matches while the guard holds
+			var guard ast.Expression
+			if p.match(lexer.TOKEN_IF) {
+				guard = p.parseExpression()
 			}
 
 			p.skipNewlines()
@@ -302,6 +371,7 @@ func (p *Parser) parseSwitchBody(token lexer.Token, expr ast.Expression) *ast.Sw
 			stmt.Cases = append(stmt.Cases, &ast.WhenCase{
 				Token:  caseToken,
 				Values: values,
+				Guard:  guard,
 				Body:   body,
 			})
 			continue
@@ -330,6 +400,31 @@ func (p *Parser) parseSwitchBody(token lexer.Token, expr ast.Expression) *ast.Sw
 	return stmt
 }
 
+// parseWhenValue parses one value of a `when` branch in a value switch: an
+// expression compared for equality, or a pattern (`> 100`, `1 to 9`,
+// `matches re"^t"`) tested against the switch value.
+func (p *Parser) parseWhenValue() ast.Expression {
+	if isOrderingOperator(p.peekToken().Type) {
+		op := p.advance()
+		return &ast.WhenPattern{Token: op, Operator: op.Lexeme, Value: p.parseExpression()}
+	}
+	if p.check(lexer.TOKEN_IDENTIFIER) && p.peekToken().Lexeme == "matches" {
+		switch p.peekNextToken().Type {
+		case lexer.TOKEN_COMMA, lexer.TOKEN_NEWLINE, lexer.TOKEN_IF, lexer.TOKEN_INDENT:
+			// `when matches` compares against a variable named matches
+		default:
+			token := p.advance()
+			return &ast.WhenPattern{Token: token, Operator: "matches", Value: p.parseExpression()}
+		}
+	}
+	value := p.parseExpression()
+	if p.check(lexer.TOKEN_TO) || p.check(lexer.TOKEN_THROUGH) {
+		bound := p.advance()
+		return &ast.WhenPattern{Token: bound, Operator: bound.Lexeme, Low: value, Value: p.parseExpression()}
+	}
+	return value
+}
+
 func (p *Parser) parseTypeSwitchBody(token lexer.Token, expr ast.Expression, binding *ast.Identifier) *ast.TypeSwitchStmt {
 	stmt := &ast.TypeSwitchStmt{
 		Token:      token,
@@ -353,7 +448,7 @@ func (p *Parser) parseTypeSwitchBody(token lexer.Token, expr ast.Expression, bin
 		if p.match(lexer.TOKEN_CASE) {
 			caseToken := p.previousToken()
 			if stmt.Otherwise != nil {
-				p.error(caseToken, "'when' branch after 'otherwise' will never execute")
+				p.errorMsg(caseToken, catalog.UnreachableWhen)
 			}
 			typeAnn := p.parseTypeAnnotation()
 
@@ -413,7 +508,7 @@ func (p *Parser) parseSelectStmt() *ast.SelectStmt {
 		if p.match(lexer.TOKEN_CASE) { // 'when'
 			caseToken := p.previousToken()
 			if stmt.Otherwise != nil {
-				p.error(caseToken, "'when' branch after 'otherwise' will never execute")
+				p.errorMsg(caseToken, catalog.UnreachableWhen)
 			}
 			sc := p.parseSelectCase(caseToken)
 			stmt.Cases = append(stmt.Cases, sc)
@@ -505,7 +600,7 @@ func (p *Parser) parseForStmt() ast.Statement {
 
 	if p.match(lexer.TOKEN_IDENTIFIER) {
 		firstIdentToken := p.previousToken()
-		firstIdent := &ast.Identifier{Token: firstIdentToken, Value: firstIdentToken.Lexeme}
+		firstIdent := p.newIdentifier(firstIdentToken, firstIdentToken.Lexeme)
 
 		if p.match(lexer.TOKEN_IN) {
 			// for item in collection
@@ -588,7 +683,7 @@ func (p *Parser) parseDeferStmt() *ast.DeferStmt {
 			Call:  call,
 		}
 	default:
-		p.error(token, "defer must be followed by a function call")
+		p.errorMsg(token, catalog.DeferNeedsCall)
 		return nil
 	}
 }
@@ -628,11 +723,131 @@ func (p *Parser) parseGoStmt() *ast.GoStmt {
 			Call:  call,
 		}
 	default:
-		p.error(token, "go must be followed by a function call or indented block")
+		p.errorMsg(token, catalog.GoNeedsCall)
 		return nil
 	}
 }
 
+// isSafelyBlock reports whether the next tokens start a `safely` block.
+// `safely` is only a keyword alone on its line, before an indented block.
+func (p *Parser) isSafelyBlock() bool {
+	return p.peekToken().Lexeme == "safely" && p.peekNextToken().Type == lexer.TOKEN_NEWLINE
+}
+
+// parseSafelyStmt parses safely NEWLINE INDENT ... DEDENT onerr <handler>.
+// The onerr clause is required: it is what handles the recovered panic.
+func (p *Parser) parseSafelyStmt() ast.Statement {
+	token := p.advance() // consume 'safely'
+	p.skipNewlines()
+	if !p.check(lexer.TOKEN_INDENT) {
+		p.error(token, "safely must be followed by an indented block")
+		return nil
+	}
+	stmt := &ast.SafelyStmt{Token: token, Body: p.parseBlock()}
+	p.skipNewlines()
+	if !p.check(lexer.TOKEN_ONERR) {
+		p.error(token, "safely block needs an onerr clause to handle the recovered panic")
+		return stmt
+	}
+	stmt.OnErr = p.parseOnErrClause()
+	p.skipNewlines()
+	return stmt
+}
+
+// isFailStmt reports whether the next tokens start a `fail` statement.
+// `fail` is only a keyword before a string or an identifier, so `fail(x)`
+// and `fail := ...` still refer to a variable or function named fail.
+func (p *Parser) isFailStmt() bool {
+	if p.peekToken().Lexeme != "fail" {
+		return false
+	}
+	switch p.peekNextToken().Type {
+	case lexer.TOKEN_STRING, lexer.TOKEN_STRING_HEAD, lexer.TOKEN_IDENTIFIER:
+		return true
+	}
+	return false
+}
+
+// parseFailStmt parses fail <message> [code <expr>].
+func (p *Parser) parseFailStmt() ast.Statement {
+	token := p.advance() // consume 'fail'
+	stmt := &ast.FailStmt{Token: token, Message: p.parseExpression()}
+	if p.peekToken().Type == lexer.TOKEN_IDENTIFIER && p.peekToken().Lexeme == "code" {
+		p.advance() // consume 'code'
+		stmt.Code = p.parseExpression()
+	}
+	p.skipNewlines()
+	return stmt
+}
+
+// isRequireStmt reports whether the next tokens start a `require` guard
+// clause: `require` is only a keyword when an `else` follows on the same
+// line, so a variable or function named require still works.
+func (p *Parser) isRequireStmt() bool {
+	if p.peekToken().Lexeme != "require" {
+		return false
+	}
+	for i := 1; ; i++ {
+		switch p.peekAt(i).Type {
+		case lexer.TOKEN_ELSE:
+			return i > 1
+		case lexer.TOKEN_NEWLINE, lexer.TOKEN_EOF:
+			return false
+		}
+	}
+}
+
+// parseRequireStmt parses require <cond> else <statement>, or require
+// <cond> else followed by an indented block.
+func (p *Parser) parseRequireStmt() ast.Statement {
+	token := p.advance() // consume 'require'
+	stmt := &ast.RequireStmt{Token: token, Condition: p.parseExpression()}
+	elseToken, _ := p.consume(lexer.TOKEN_ELSE, "expected 'else' after require condition")
+	if p.check(lexer.TOKEN_NEWLINE) {
+		p.skipNewlines()
+		stmt.Else = p.parseBlock()
+		return stmt
+	}
+	stmt.Else = &ast.BlockStmt{Token: elseToken}
+	if inner := p.parseStatement(); inner != nil {
+		stmt.Else.Statements = append(stmt.Else.Statements, inner)
+	}
+	return stmt
+}
+
+// isYieldStmt reports whether the statement is `yield value`. In an iterator
+// body `yield` is always the statement; elsewhere only when a value
+// follows that couldn't continue an expression, so a yield callback
+// parameter can still be called as yield(v) and the analyzer can explain a
+// misplaced yield.
+func (p *Parser) isYieldStmt() bool {
+	if p.peekToken().Lexeme != "yield" {
+		return false
+	}
+	if p.inIterator {
+		return true
+	}
+	switch p.peekNextToken().Type {
+	case lexer.TOKEN_IDENTIFIER, lexer.TOKEN_INTEGER, lexer.TOKEN_FLOAT, lexer.TOKEN_DECIMAL, lexer.TOKEN_STRING,
+		lexer.TOKEN_STRING_HEAD, lexer.TOKEN_TRUE, lexer.TOKEN_FALSE:
+		return true
+	}
+	return false
+}
+
+// parseYieldStmt parses `yield value` (or `yield key, value`) in the body of
+// a `yields` or `sequence of T` function.
+func (p *Parser) parseYieldStmt() *ast.YieldStmt {
+	stmt := &ast.YieldStmt{Token: p.advance()} // consume 'yield'
+	p.sawYield = p.sawYield || p.inIterator
+	stmt.Values = append(stmt.Values, p.parseExpression())
+	for p.match(lexer.TOKEN_COMMA) {
+		stmt.Values = append(stmt.Values, p.parseExpression())
+	}
+	p.skipNewlines()
+	return stmt
+}
+
 func (p *Parser) parseSendStmt() *ast.SendStmt {
 	token := p.advance() // consume 'send'
 
@@ -694,7 +909,7 @@ func (p *Parser) parseExpressionOrAssignmentStmt() ast.Statement {
 		// Variable declaration with inference: x := value
 		ident, ok := expr.(*ast.Identifier)
 		if !ok {
-			p.error(p.previousToken(), "walrus operator can only be used with identifiers")
+			p.errorMsg(p.previousToken(), catalog.WalrusNeedsIdentifiers)
 			return nil
 		}
 		values := p.parseExpressionList()
@@ -778,10 +993,7 @@ func (p *Parser) parseMultiValueAssignmentStmt() ast.Statement {
 		return nil
 	}
 	firstIdent := p.previousToken()
-	firstName := &ast.Identifier{
-		Token: firstIdent,
-		Value: firstIdent.Lexeme,
-	}
+	firstName := p.newIdentifier(firstIdent, firstIdent.Lexeme)
 	names = append(names, firstName)
 	targets = append(targets, firstName)
 
@@ -792,10 +1004,7 @@ func (p *Parser) parseMultiValueAssignmentStmt() ast.Statement {
 			return nil
 		}
 		identToken := p.previousToken()
-		name := &ast.Identifier{
-			Token: identToken,
-			Value: identToken.Lexeme,
-		}
+		name := p.newIdentifier(identToken, identToken.Lexeme)
 		names = append(names, name)
 		targets = append(targets, name)
 	}
@@ -924,10 +1133,7 @@ func (p *Parser) parseInlineOnErrHandler(token lexer.Token) *ast.OnErrClause {
 	// wrote the shorthand form; otherwise fall through to parseExpression so the
 	// existing verbose form continues to work.
 	if p.check(lexer.TOKEN_RETURN) {
-		next := p.peekNextToken()
-		if next.Type == lexer.TOKEN_NEWLINE ||
-			next.Type == lexer.TOKEN_DEDENT ||
-			next.Type == lexer.TOKEN_EOF {
+		if endsOnErrHandler(p.peekNextToken()) {
 			p.advance() // consume 'return'
 			return &ast.OnErrClause{
 				Token:           token,
@@ -937,10 +1143,7 @@ func (p *Parser) parseInlineOnErrHandler(token lexer.Token) *ast.OnErrClause {
 	}
 
 	if p.check(lexer.TOKEN_CONTINUE) {
-		next := p.peekNextToken()
-		if next.Type == lexer.TOKEN_NEWLINE ||
-			next.Type == lexer.TOKEN_DEDENT ||
-			next.Type == lexer.TOKEN_EOF {
+		if endsOnErrHandler(p.peekNextToken()) {
 			p.advance() // consume 'continue'
 			return &ast.OnErrClause{
 				Token:             token,
@@ -950,10 +1153,7 @@ func (p *Parser) parseInlineOnErrHandler(token lexer.Token) *ast.OnErrClause {
 	}
 
 	if p.check(lexer.TOKEN_BREAK) {
-		next := p.peekNextToken()
-		if next.Type == lexer.TOKEN_NEWLINE ||
-			next.Type == lexer.TOKEN_DEDENT ||
-			next.Type == lexer.TOKEN_EOF {
+		if endsOnErrHandler(p.peekNextToken()) {
 			p.advance() // consume 'break'
 			return &ast.OnErrClause{
 				Token:          token,
@@ -986,6 +1186,45 @@ func (p *Parser) parseInlineOnErrHandler(token lexer.Token) *ast.OnErrClause {
 	return clause
 }
 
+// endsOnErrHandler reports whether t ends an inline onerr handler, so the
+// return, continue or break before it is the bare shorthand: the end of the
+// line, or of the argument or literal element an expression-level onerr is in.
+func endsOnErrHandler(t lexer.Token) bool {
+	switch t.Type {
+	case lexer.TOKEN_NEWLINE, lexer.TOKEN_DEDENT, lexer.TOKEN_EOF,
+		lexer.TOKEN_COMMA, lexer.TOKEN_RPAREN, lexer.TOKEN_RBRACKET, lexer.TOKEN_RBRACE:
+		return true
+	}
+	return false
+}
+
+// parseElementExpr parses a call argument or a struct, list or map literal
+// element, which may carry its own inline onerr handler:
+// Config{port: GetPort() onerr 8080}. Block handlers need a statement.
+func (p *Parser) parseElementExpr() ast.Expression {
+	expr := p.parseExpression()
+	if !p.check(lexer.TOKEN_ONERR) {
+		return expr
+	}
+	token := p.advance() // consume 'onerr'
+	alias := ""
+	if p.match(lexer.TOKEN_AS) {
+		aliasToken := p.advance()
+		if aliasToken.Type != lexer.TOKEN_IDENTIFIER {
+			p.error(aliasToken, "expected identifier after 'onerr as'")
+			return expr
+		}
+		alias = aliasToken.Lexeme
+	}
+	if p.check(lexer.TOKEN_NEWLINE) || p.check(lexer.TOKEN_INDENT) {
+		p.error(token, "an onerr inside an expression needs an inline handler; move the call to its own statement for a block handler")
+		return expr
+	}
+	clause := p.parseInlineOnErrHandler(token)
+	clause.Alias = alias
+	return &ast.OnErrExpr{Token: token, Expression: expr, OnErr: clause}
+}
+
 // parseExplainString parses the string argument after the 'explain' keyword.
 // Accepts both plain strings (TOKEN_STRING) and interpolated strings
 // (TOKEN_STRING_HEAD ... TOKEN_STRING_TAIL). For interpolated strings the full
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/lexer"
 	"strings"
 	"testing"
 )
@@ -193,6 +194,113 @@ func TestParseFunctionTypeAlias(t *testing.T) {
 	}
 }
 
+func TestParseDistinctType(t *testing.T) {
+	input := `type Meters float64 distinct
+type Label string
+type Names list of string
+`
+	program := mustParseProgram(t, input)
+	if len(program.Declarations) != 3 {
+		t.Fatalf("expected 3 declarations, got %d", len(program.Declarations))
+	}
+	tests := []struct {
+		name     string
+		list     bool
+		distinct bool
+	}{
+		{"Meters", false, true},
+		{"Label", false, false},
+		{"Names", true, false},
+	}
+	for i, tt := range tests {
+		decl, ok := program.Declarations[i].(*ast.TypeDecl)
+		if !ok {
+			t.Fatalf("expected TypeDecl, got %T", program.Declarations[i])
+		}
+		if decl.Name.Value != tt.name || decl.Distinct != tt.distinct {
+			t.Errorf("expected %s distinct=%v, got %s distinct=%v", tt.name, tt.distinct, decl.Name.Value, decl.Distinct)
+		}
+		if _, isList := decl.AliasType.(*ast.ListType); isList != tt.list {
+			t.Errorf("expected %s list alias=%v, got %T", tt.name, tt.list, decl.AliasType)
+		}
+		if _, isPrim := decl.AliasType.(*ast.PrimitiveType); isPrim == tt.list {
+			t.Errorf("expected %s primitive alias=%v, got %T", tt.name, !tt.list, decl.AliasType)
+		}
+	}
+}
+
+func TestParseEnumType(t *testing.T) {
+	input := `type Status string distinct
+    Active "active"
+    Banned "banned"
+
+func main()
+    print(Active)
+`
+	program := mustParseProgram(t, input)
+	if len(program.Declarations) != 2 {
+		t.Fatalf("expected 2 declarations, got %d", len(program.Declarations))
+	}
+	decl, ok := program.Declarations[0].(*ast.TypeDecl)
+	if !ok {
+		t.Fatalf("expected TypeDecl, got %T", program.Declarations[0])
+	}
+	if !decl.Distinct || len(decl.Values) != 2 {
+		t.Fatalf("expected a distinct type with 2 values, got distinct=%v values=%d", decl.Distinct, len(decl.Values))
+	}
+	if decl.Values[0].Name.Value != "Active" || decl.Values[0].Value.Value != "active" {
+		t.Errorf("expected Active \"active\", got %s %q", decl.Values[0].Name.Value, decl.Values[0].Value.Value)
+	}
+	if decl.Values[1].Name.Value != "Banned" || decl.Values[1].Value.Value != "banned" {
+		t.Errorf("expected Banned \"banned\", got %s %q", decl.Values[1].Name.Value, decl.Values[1].Value.Value)
+	}
+}
+
+func TestParseDecimalLiteral(t *testing.T) {
+	input := `func main()
+    x := 1_250.50d.Round(1)
+`
+	program := mustParseProgram(t, input)
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	decl := fn.Body.Statements[0].(*ast.VarDeclStmt)
+	call, ok := decl.Values[0].(*ast.MethodCallExpr)
+	if !ok {
+		t.Fatalf("expected a method call, got %T", decl.Values[0])
+	}
+	lit, ok := call.Object.(*ast.DecimalLiteral)
+	if !ok || lit.Value != "1250.50" || lit.TokenLiteral() != "1_250.50d" {
+		t.Errorf("expected decimal literal 1250.50, got %#v", call.Object)
+	}
+}
+
+func TestParseOperatorMethod(t *testing.T) {
+	input := `func Add on v Vector(o Vector) Vector operator +
+    return v
+
+func Equal on v Vector(o Vector) bool operator ==
+    return true
+
+func operator() operator
+    return empty
+`
+	program := mustParseProgram(t, input)
+	if len(program.Declarations) != 3 {
+		t.Fatalf("expected 3 declarations, got %d", len(program.Declarations))
+	}
+	for i, want := range []string{"+", "==", ""} {
+		fn, ok := program.Declarations[i].(*ast.FunctionDecl)
+		if !ok {
+			t.Fatalf("expected FunctionDecl, got %T", program.Declarations[i])
+		}
+		if fn.Operator != want {
+			t.Errorf("%s: expected operator %q, got %q", fn.Name.Value, want, fn.Operator)
+		}
+		if len(fn.Returns) != 1 {
+			t.Errorf("%s: expected 1 return type, got %d", fn.Name.Value, len(fn.Returns))
+		}
+	}
+}
+
 func TestParseStructTypeStillWorks(t *testing.T) {
 	input := `type Person
     Name string
@@ -375,6 +483,151 @@ func TestParseConditionSwitchStatement(t *testing.T) {
 	}
 }
 
+func TestParseSwitchWhenGuard(t *testing.T) {
+	input := `func Route(cmd string, allowNetwork bool) string
+    switch cmd
+        when "fetch", "pull" if allowNetwork
+            return "network"
+        when "list"
+            return "list"
+        otherwise
+            return "unknown"
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	switchStmt := fn.Body.Statements[0].(*ast.SwitchStmt)
+	guarded := switchStmt.Cases[0]
+	if len(guarded.Values) != 2 {
+		t.Fatalf("expected 2 values, got %d", len(guarded.Values))
+	}
+	guard, ok := guarded.Guard.(*ast.Identifier)
+	if !ok || guard.Value != "allowNetwork" {
+		t.Fatalf("expected guard allowNetwork, got %#v", guarded.Guard)
+	}
+	if switchStmt.Cases[1].Guard != nil {
+		t.Errorf("expected no guard on the second branch, got %#v", switchStmt.Cases[1].Guard)
+	}
+}
+
+func TestParseWhenPatterns(t *testing.T) {
+	input := `func Size(n int, name string) string
+    switch n
+        when < 0
+            return "negative"
+        when 0, 1 to 10
+            return "small"
+        when 10 through 99 if name != ""
+            return "medium"
+    switch name
+        when matches re"^t\d+"
+            return "test"
+        when matches
+            return "same"
+    return "other"
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	sizes := fn.Body.Statements[0].(*ast.SwitchStmt)
+	compare, ok := sizes.Cases[0].Values[0].(*ast.WhenPattern)
+	if !ok || compare.Operator != "<" || compare.Low != nil {
+		t.Fatalf("expected a < pattern, got %#v", sizes.Cases[0].Values[0])
+	}
+	if _, ok := sizes.Cases[1].Values[0].(*ast.IntegerLiteral); !ok {
+		t.Errorf("expected a plain value before the range, got %#v", sizes.Cases[1].Values[0])
+	}
+	for i, want := range []string{"to", "through"} {
+		r, ok := sizes.Cases[i+1].Values[len(sizes.Cases[i+1].Values)-1].(*ast.WhenPattern)
+		if !ok || r.Operator != want || !r.IsRange() {
+			t.Errorf("expected a %s range, got %#v", want, sizes.Cases[i+1].Values)
+		}
+	}
+	if sizes.Cases[2].Guard == nil {
+		t.Error("expected the guard to follow the range")
+	}
+
+	names := fn.Body.Statements[1].(*ast.SwitchStmt)
+	matches, ok := names.Cases[0].Values[0].(*ast.WhenPattern)
+	if !ok || matches.Operator != "matches" {
+		t.Fatalf("expected a matches pattern, got %#v", names.Cases[0].Values[0])
+	}
+	if re, ok := matches.Value.(*ast.RegexLiteral); !ok || re.Pattern != `^t\d+` {
+		t.Errorf("expected regex ^t\\d+, got %#v", matches.Value)
+	}
+	// A bare `when matches` compares against a variable named matches
+	if id, ok := names.Cases[1].Values[0].(*ast.Identifier); !ok || id.Value != "matches" {
+		t.Errorf("expected identifier matches, got %#v", names.Cases[1].Values[0])
+	}
+}
+
+func TestParseContinueToNext(t *testing.T) {
+	input := `func Steps(level int)
+    for i from 0 to level
+        switch level
+            when 2
+                continue to next
+            when 1
+                continue
+`
+
+	program := mustParseProgram(t, input)
+
+	fn := program.Declarations[0].(*ast.FunctionDecl)
+	loop := fn.Body.Statements[0].(*ast.ForNumericStmt)
+	switchStmt := loop.Body.Statements[0].(*ast.SwitchStmt)
+	if _, ok := switchStmt.Cases[0].Body.Statements[0].(*ast.FallthroughStmt); !ok {
+		t.Errorf("expected FallthroughStmt, got %T", switchStmt.Cases[0].Body.Statements[0])
+	}
+	if _, ok := switchStmt.Cases[1].Body.Statements[0].(*ast.ContinueStmt); !ok {
+		t.Errorf("expected ContinueStmt, got %T", switchStmt.Cases[1].Body.Statements[0])
+	}
+}
+
+func TestParseNumberLiterals(t *testing.T) {
+	input := `func F()
+    a := 1_000_000
+    b := 0xFF
+    c := 0b1010
+    d := 0o17
+    e := 18446744073709551616
+    f := 0x1p-2
+`
+
+	program := mustParseProgram(t, input)
+
+	stmts := program.Declarations[0].(*ast.FunctionDecl).Body.Statements
+	ints := []int64{1000000, 255, 10, 15}
+	for i, want := range ints {
+		lit := stmts[i].(*ast.VarDeclStmt).Values[0].(*ast.IntegerLiteral)
+		if lit.Value != want || lit.Big != nil {
+			t.Errorf("%s: expected %d, got %d (big %v)", lit.Token.Lexeme, want, lit.Value, lit.Big)
+		}
+	}
+	big := stmts[4].(*ast.VarDeclStmt).Values[0].(*ast.IntegerLiteral)
+	if big.Big == nil || big.Big.String() != "18446744073709551616" {
+		t.Errorf("expected big value 18446744073709551616, got %v", big.Big)
+	}
+	float := stmts[5].(*ast.VarDeclStmt).Values[0].(*ast.FloatLiteral)
+	if float.Value != 0.25 {
+		t.Errorf("expected 0.25, got %g", float.Value)
+	}
+}
+
+func TestParseMalformedNumberLiteral(t *testing.T) {
+	p, err := New("func F()\n    x := 1__000\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errors := p.Parse()
+
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "invalid integer literal '1__000'") {
+		t.Fatalf("expected invalid integer literal error, got: %v", errors)
+	}
+}
+
 func TestParseWhenAfterOtherwiseIsError(t *testing.T) {
 	input := `func Route(command string) string
     switch command
@@ -405,3 +658,146 @@ func TestParseWhenAfterOtherwiseIsError(t *testing.T) {
 		t.Fatalf("expected 'will never execute' error, got: %v", errors)
 	}
 }
+
+func TestParseTypeDeclarationFieldAliasGroups(t *testing.T) {
+	input := `type User
+    Name string as "name" for json, yaml, db
+    Email string as "email" db:"email_address"
+    Age int json:"age,omitempty" yaml:"age"
+`
+	program := mustParseProgram(t, input)
+	typeDecl := program.Declarations[0].(*ast.TypeDecl)
+	want := []string{
+		`json:"name" yaml:"name" db:"name"`,
+		`json:"email" db:"email_address"`,
+		`json:"age,omitempty" yaml:"age"`,
+	}
+	for i, w := range want {
+		if got := typeDecl.Fields[i].Tag; got != w {
+			t.Errorf("field %d: expected tag %q, got %q", i, w, got)
+		}
+	}
+}
+
+func TestParseTypeDeclarationDuplicateTagKeys(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{`Name string as "name" for json, json`, "duplicate struct tag key 'json' on field 'Name'"},
+		{`Name string json:"a" json:"b"`, "duplicate struct tag key 'json' on field 'Name'"},
+		{`Name string as "name" for json, yaml yaml:"n"`, "cannot combine field alias and explicit struct tag for 'yaml' on field 'Name'"},
+		{`Name string as "name" for`, "expected struct tag key"},
+	}
+	for _, tt := range tests {
+		p, err := New("type User\n    "+tt.field+"\n", "test.kuki")
+		if err != nil {
+			t.Fatalf("lexer error: %v", err)
+		}
+		_, errors := p.Parse()
+		if len(errors) == 0 || !strings.Contains(errors[0].Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.field, tt.want, errors)
+		}
+	}
+}
+
+func TestParseImplementsDecl(t *testing.T) {
+	input := `Circle implements Shape, fmt.Stringer
+
+func main()
+    implements := 1
+    print(implements)
+`
+	program := mustParseProgram(t, input)
+	decl, ok := program.Declarations[0].(*ast.ImplementsDecl)
+	if !ok {
+		t.Fatalf("expected ImplementsDecl, got %T", program.Declarations[0])
+	}
+	if decl.Type.Value != "Circle" || len(decl.Interfaces) != 2 {
+		t.Fatalf("unexpected decl: %s implements %d interfaces", decl.Type.Value, len(decl.Interfaces))
+	}
+	if name := decl.Interfaces[1].(*ast.NamedType).Name; name != "fmt.Stringer" {
+		t.Errorf("expected fmt.Stringer, got %q", name)
+	}
+}
+
+func TestParseErrorDecl(t *testing.T) {
+	input := `error NotFound "resource not found"
+
+error
+    Conflict "resource already exists"
+    Expired "token expired"
+`
+	program := mustParseProgram(t, input)
+	if len(program.Declarations) != 2 {
+		t.Fatalf("expected 2 declarations, got %d", len(program.Declarations))
+	}
+	single, ok := program.Declarations[0].(*ast.ErrorDecl)
+	if !ok {
+		t.Fatalf("expected ErrorDecl, got %T", program.Declarations[0])
+	}
+	if len(single.Specs) != 1 || single.Specs[0].Name.Value != "NotFound" || single.Specs[0].Message.Value != "resource not found" {
+		t.Errorf("unexpected single error decl: %+v", single.Specs)
+	}
+	group := program.Declarations[1].(*ast.ErrorDecl)
+	if len(group.Specs) != 2 || group.Specs[1].Name.Value != "Expired" {
+		t.Errorf("unexpected grouped error decl: %+v", group.Specs)
+	}
+}
+
+func TestParseErrorDeclRejectsInterpolation(t *testing.T) {
+	p, err := New("error Missing \"no {name}\"\n", "test.kuki")
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	_, errs := p.Parse()
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "interpolation is not allowed in sentinel errors") {
+		t.Errorf("expected interpolation error, got %v", errs)
+	}
+}
+
+func TestParsePanicBecomesDiagnostic(t *testing.T) {
+	p, err := New("func main()\n    print(1)\n", "test.kuki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.pos = -1 // corrupt state so the first lookahead panics
+	program, errs := p.Parse()
+	if program == nil {
+		t.Fatal("expected a program")
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "test.kuki:1:1: internal parser error:") || !strings.Contains(errs[0].Error(), "please report it") {
+		t.Fatalf("expected one internal parser error, got %v", errs)
+	}
+}
+
+func TestTokenAtOutOfRange(t *testing.T) {
+	p := NewFromTokens(nil)
+	for _, i := range []int{-1, 0, 5} {
+		if tok := p.tokenAt(i); tok.Type != lexer.TOKEN_EOF {
+			t.Errorf("tokenAt(%d) = %v, want EOF", i, tok)
+		}
+	}
+	if tok := p.previousToken(); tok.Type != lexer.TOKEN_EOF {
+		t.Errorf("previousToken() at start = %v, want EOF", tok)
+	}
+}
+
+func TestNewIdentifierChunks(t *testing.T) {
+	p := NewFromTokens(nil)
+	n := 2*identChunk + 1
+	idents := make([]*ast.Identifier, n)
+	for i := range n {
+		idents[i] = p.newIdentifier(lexer.Token{Offset: int32(i)}, "x")
+	}
+	seen := make(map[*ast.Identifier]bool)
+	for i, ident := range idents {
+		if seen[ident] {
+			t.Fatalf("identifier %d shares a node with an earlier one", i)
+		}
+		seen[ident] = true
+		if ident.Token.Offset != int32(i) || ident.Value != "x" {
+			t.Fatalf("identifier %d was overwritten: %+v", i, ident)
+		}
+	}
+}
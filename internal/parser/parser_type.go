@@ -1,9 +1,8 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
 )
 
@@ -22,10 +21,11 @@ import (
 //	map of string to int      map[string]int
 //	reference User            *User
 //	channel of int            chan int
+//	sequence of int           iter.Seq[int]
 //	func(int) bool            func(int) bool
 //
-// Keywords `list`, `map`, `channel` are context-sensitive: they're only
-// treated as type keywords when followed by `of`. This allows using them
+// Keywords `list`, `map`, `channel` (and the identifier `sequence`) are
+// context-sensitive: they're only treated as type keywords when followed by `of`. This allows using them
 // as variable names elsewhere (e.g., `list := getData()`).
 func (p *Parser) parseTypeAnnotation() ast.TypeAnnotation {
 	switch p.peekToken().Type {
@@ -65,6 +65,7 @@ func (p *Parser) parseTypeAnnotation() ast.TypeAnnotation {
 		return &ast.ChannelType{
 			Token:       token,
 			ElementType: elementType,
+			Direction:   p.parseChannelDirection(),
 		}
 
 	case lexer.TOKEN_FUNC:
@@ -122,6 +123,12 @@ func (p *Parser) parseTypeAnnotation() ast.TypeAnnotation {
 
 	case lexer.TOKEN_IDENTIFIER:
 		token := p.advance()
+		if token.Lexeme == "sequence" && p.match(lexer.TOKEN_OF) {
+			return &ast.SequenceType{
+				Token:       token,
+				ElementType: p.parseTypeAnnotation(),
+			}
+		}
 		// Check for primitive types
 		switch token.Lexeme {
 		case "int", "int8", "int16", "int32", "int64",
@@ -147,9 +154,29 @@ func (p *Parser) parseTypeAnnotation() ast.TypeAnnotation {
 
 	default:
 		tok := p.peekToken()
-		p.error(tok, fmt.Sprintf("expected type annotation, got %s", tok.Type))
+		p.errorMsg(tok, catalog.ExpectedType, tok.Type)
 		// Return a sentinel so callers don't need nil checks.
 		// The error is already recorded; codegen will not run.
 		return &ast.NamedType{Token: tok, Name: "_"}
 	}
 }
+
+// parseChannelDirection consumes an optional `send-only` or `receive-only`
+// suffix after a channel element type.
+func (p *Parser) parseChannelDirection() string {
+	dir := p.peekToken()
+	if dir.Type != lexer.TOKEN_SEND && dir.Type != lexer.TOKEN_RECEIVE {
+		return ""
+	}
+	if p.peekAt(1).Type != lexer.TOKEN_MINUS {
+		return ""
+	}
+	only := p.peekAt(2)
+	if only.Type != lexer.TOKEN_IDENTIFIER || only.Lexeme != "only" {
+		return ""
+	}
+	p.advance()
+	p.advance()
+	p.advance()
+	return dir.Lexeme + "-only"
+}
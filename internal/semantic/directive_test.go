@@ -240,3 +240,110 @@ func main()
 		t.Errorf("expected panics warning for squareRoot usage, got warnings: %v", warnings)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// # kuki:pure enforcement
+// ---------------------------------------------------------------------------
+
+func TestPureFunctionAllowed(t *testing.T) {
+	input := `import "strings"
+
+var hits int
+
+type Point
+    X int
+
+@pure
+func Slug(s string) string
+    hits := 0
+    hits = hits + 1
+    return strings.ToLower(strings.TrimSpace(s))
+
+@pure
+func Norm on p Point int
+    return p.X * p.X
+
+# kuki:pure
+func Describe(p Point) string
+    return "{Slug("x")} {p.Norm()}"
+`
+	errs, _ := analyzeInputWithFile(t, input, "test.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestPureFunctionViolations(t *testing.T) {
+	input := `import "os"
+
+var hits int
+var seen map of string to bool
+
+type Counter
+    n int
+
+func Bump on c reference Counter
+    c.n = c.n + 1
+
+func helper() int
+    return 1
+
+@pure
+func Lookup(key string, c Counter) int
+    hits++
+    seen[key] = true
+    print(key)
+    os.Getenv(key)
+    ch := make(channel of int, 1)
+    send 1 to ch
+    n := receive from ch
+    c.Bump()
+    reset := () =>
+        hits = 0
+    reset()
+    return n + helper()
+`
+	errs, _ := analyzeInputWithFile(t, input, "test.kuki")
+	want := []string{
+		"17:4: pure function Lookup can't assign to package variable hits",
+		"18:4: pure function Lookup can't assign to package variable seen",
+		"19:4: pure function Lookup can't call print, which does input/output",
+		"20:4: pure function Lookup can't call os.Getenv, which does input/output",
+		"22:4: pure function Lookup can't use send",
+		"23:9: pure function Lookup can't use receive",
+		"24:4: pure function Lookup can't call Counter.Bump, which is not marked pure",
+		"26:8: pure function Lookup can't assign to package variable hits",
+		"28:15: pure function Lookup can't call helper, which is not marked pure",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestPureFunctionOperatorMethod(t *testing.T) {
+	input := `type Vector
+    x int
+
+func Add on v Vector(o Vector) Vector operator +
+    return Vector{x: v.x + o.x}
+
+@pure
+func Less on v Vector(o Vector) bool operator <
+    return v.x < o.x
+
+@pure
+func Max(a Vector, b Vector) Vector
+    if a < b
+        return b + a
+    return a
+`
+	errs, _ := analyzeInputWithFile(t, input, "test.kuki")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "14:17: pure function Max can't call Vector.Add, which is not marked pure") {
+		t.Fatalf("expected the impure operator call to be reported, got %v", errs)
+	}
+}
@@ -333,3 +333,154 @@ func analyzeInputWithFile(t *testing.T, input, filename string) (errs []error, w
 	analyzer, errs := analyzeSourceWithFile(t, input, filename)
 	return errs, analyzer.Warnings()
 }
+
+// TestOnErrFallbackTypeChecked verifies that a fallback value must fit the
+// type of the value it replaces, with int accepted for float.
+func TestOnErrFallbackTypeChecked(t *testing.T) {
+	input := `func count() (int, error)
+    return 1, empty
+
+func ratio() (float64, error)
+    return 0.5, empty
+
+func Process(n int)
+    a := count() onerr 2.5
+    b := count() onerr "none"
+    c := ratio() onerr n
+    d := count() onerr n
+    b = count() onerr true
+    print(a, b, c, d)
+`
+	errors := analyzeInput(t, input)
+	want := []string{
+		"test.kuki:8:23: onerr fallback value has type float, but the value it replaces has type int",
+		"test.kuki:9:23: onerr fallback value has type string, but the value it replaces has type int",
+		"test.kuki:12:22: onerr fallback value has type bool, but the value it replaces has type int",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errors)
+	}
+	for i, e := range errors {
+		if e.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, e.Error(), want[i])
+		}
+	}
+}
+
+// TestSafelyBodyCannotLeaveBlock verifies that returns, returning onerr
+// handlers and loop jumps inside a safely block are rejected: the body runs
+// in a func literal.
+func TestSafelyBodyCannotLeaveBlock(t *testing.T) {
+	input := `import "strconv"
+
+func parse(s string) (int, error)
+    for i from 0 to 3
+        safely
+            n := strconv.Atoi(s) onerr return
+            if n > i
+                continue
+            return n, empty
+        onerr return
+    return 0, empty
+`
+	errs := analyzeInput(t, input)
+	want := []string{"onerr inside a safely block", "continue statement outside", "return inside a safely block"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+// TestBuildStringBody verifies that a build string body follows the safely
+// rules and that its builder can only call its methods.
+func TestBuildStringBody(t *testing.T) {
+	input := `import "strings"
+
+func keep(b reference strings.Builder)
+    print(b.Len())
+
+func render(names list of string) (string, error)
+    s := build string as b
+        for n in names
+            if n == ""
+                continue
+            b.WriteString(n)
+        keep(reference of b)
+        f := func()
+            b.WriteString("!")
+        f()
+        return "", empty
+    return s, empty
+`
+	errs := analyzeInput(t, input)
+	want := []string{"return inside a build string block", "builder 'b' can only call its methods", "builder 'b' cannot be captured by a closure"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestSafelyBodyAllowsClosureReturns(t *testing.T) {
+	input := `func run() error
+    total := 0
+    safely
+        double := func(n int) int
+            return n * 2
+        total = double(3)
+    onerr return
+    print(total)
+    return empty
+`
+	if errs := analyzeInput(t, input); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestOnErrExprInFieldAndArgument(t *testing.T) {
+	input := `import "strconv"
+
+type Config
+    port int
+
+func label(n int) string
+    return "{n}"
+
+func main()
+    cfg := Config{port: strconv.Atoi("80") onerr 8080}
+    print(label(strconv.Atoi("1") onerr as e explain "parse"), cfg)
+`
+	if errs := analyzeInput(t, input); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestOnErrExprRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"if condition", `    if double(strconv.Atoi("1") onerr 0) > 0` + "\n        print(1)", "only allowed in a call argument"},
+		{"binary operand", `    n := 1 + double(strconv.Atoi("1") onerr 0)`, "only allowed in a call argument"},
+		{"no error result", `    n := double(double(1) onerr 0)`, "returns (int)"},
+		{"fallback type", `    n := double(strconv.Atoi("1") onerr "none")`, "fallback value has type string"},
+		{"send", `    ch := make(channel of int)` + "\n" + `    send double(strconv.Atoi("1") onerr 0) to ch`, "only allowed in a call argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "import \"strconv\"\n\nfunc double(n int) int\n    return n * 2\n\nfunc main()\n" + tt.body + "\n"
+			errs := analyzeInput(t, input)
+			if len(errs) == 0 || !strings.Contains(errs[0].Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, errs)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package semantic
 
 import (
 	"fmt"
+	"go/constant"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 // Analyzer performs semantic analysis on the AST
@@ -14,21 +16,50 @@ type Analyzer struct {
 	warnings         []error                // Non-fatal diagnostics (e.g. risky onerr handlers)
 	currentFunc      *ast.FunctionDecl      // Track current function for return type checking
 	loopDepth        int                    // Track loop nesting for break/continue
+	loops            []loopFrame            // Enclosing loops, innermost last (for closure capture warnings)
 	switchDepth      int                    // Track switch nesting for break
 	exprReturnCounts    map[ast.Expression]int // Inferred return counts for expressions (used by codegen for onerr multi-value split)
 	// exprTypes maps each analyzed expression to its inferred TypeInfo.
 	// Consumed by codegen for: error-only pipe step detection (isErrorOnlyReturn),
-	// piped switch return type inference, empty keyword resolution, expression
-	// return type inference, and typed zero-value generation (zeroValueForType).
+	// piped switch return type inference, empty keyword resolution, and
+	// expression return type inference.
 	exprTypes           map[ast.Expression]*TypeInfo
+	typeAnnTypes        map[ast.TypeAnnotation]*TypeInfo // Resolved declared types: return types, cast targets, typed empty (see TypeAnnotationTypes)
 	sourceFile          string                 // Source file path, used to detect stdlib context
 	inOnerr             bool                   // True while analyzing an onerr handler
 	currentOnerrrAlias  string                 // Named alias for caught error in current onerr block (e.g., "e" for "onerr as e")
 	inPipedSwitch       bool                   // True while analyzing piped switch case bodies (suppresses return-count checks)
+	inInterpolation     bool                   // True while analyzing a {expr} hole of an interpolated string
 	deprecatedFuncs     map[string]string      // Function name → deprecation message (from # kuki:deprecated directives)
 	deprecatedTypes     map[string]string      // Type name → deprecation message
 	panickedFuncs       map[string]string      // Function name → panic message (from # kuki:panics directives)
+	pureFuncs           map[string]bool        // Functions and Type.Method names marked # kuki:pure (see checkPurity)
 	importAliases       map[string]string      // alias → base package name (e.g., "strpkg" → "string")
+	stdlibImports       map[string]bool        // Local names of Kukicha stdlib imports (e.g., "math" for stdlib/math)
+	methods             map[string]map[string]*TypeInfo // Receiver type name → method name → signature (built in collectDeclarations)
+	operators           map[string]map[string]string // Struct type name → operator (+, -, *, /, ==, <) → method name (see collectOperators)
+	shadowCheck         ShadowCheck            // Which := shadowing cases are reported (see SetShadowCheck)
+	strictTypes         bool                   // Report where inference falls back to Unknown (see SetStrictTypes)
+	autoImport          bool                   // Import known Go stdlib packages used without an import (see SetAutoImport)
+	allowedFallthrough  ast.Statement          // The `continue to next` ending the when branch being analyzed, if any
+	constExprs          map[string]ast.Expression // Top-level const name → value expression (see constValue)
+	maybeNil            nilSet                 // Reference variables that may be empty at the current point (nil-use analysis)
+	pipedRest           []*TypeInfo            // Values after the first from a multi-value pipe source, consumed by the next call analysis
+	lambdaTargets       map[*ast.ArrowLambda]*TypeInfo // Signature an arrow lambda argument must have, from the parameter it is passed to
+	inLambda            bool                   // True while analyzing a block lambda: its returns leave the lambda, not currentFunc
+	inGoBlock           bool                   // True while analyzing a block-form go statement
+	lambdaSig           *TypeInfo              // Expected signature of the block lambda being analyzed; nil when unknown
+	captures            map[ast.Node][]Capture // Variables each closure and go block captures (see Captures)
+	loopCaptures        []LoopCapture          // Closures in loops that capture a variable the loop reassigns (see LoopCaptures)
+	block               *ast.BlockStmt         // Block whose statements are being analyzed
+	constants           map[ast.Expression]constant.Value // Folded constant expressions (see Constants)
+	inConstDecl         bool                              // Analyzing a const declaration's value (see recordConstant)
+	closureBlock        string                 // "safely" or "build string" while analyzing a body codegen wraps in a func literal; closures inside reset it (see analyzeSafelyStmt)
+	uncheckedErrors     []ast.Statement        // Statements that drop an error result without onerr (see UncheckedErrors)
+	targetBlocks        []ast.Position         // `when target` blocks, kept for the version pragma after selectTarget removes them
+	onErrExprSites      map[*ast.OnErrExpr]bool // Expression-level onerrs codegen can hoist out of the current statement (see onErrExprSites)
+	petioles            map[string]*petiole    // Import path → exported signatures of a petiole of this module (see RegisterPetiole)
+	petioleFuncs        map[string]*TypeInfo   // "name.Func" → signature of an exported function of an imported petiole
 }
 
 // New creates a new semantic analyzer
@@ -57,6 +88,15 @@ func (a *Analyzer) ExprTypes() map[ast.Expression]*TypeInfo {
 	return a.exprTypes
 }
 
+// TypeAnnotationTypes returns the resolved types of the declared type
+// annotations codegen builds zero values and casts from: function return
+// types, cast targets and typed empty. A name that refers to an interface is
+// recorded as TypeKindInterface. Call after Analyze() to pass these to codegen
+// via SetTypeAnnotationTypes.
+func (a *Analyzer) TypeAnnotationTypes() map[ast.TypeAnnotation]*TypeInfo {
+	return a.typeAnnTypes
+}
+
 // ReturnCounts returns the inferred return counts for expressions.
 // Call after Analyze() to pass these to codegen.
 func (a *Analyzer) ReturnCounts() map[ast.Expression]int {
@@ -65,11 +105,7 @@ func (a *Analyzer) ReturnCounts() map[ast.Expression]int {
 
 // Analyze performs semantic analysis on the program
 func (a *Analyzer) Analyze() []error {
-	a.exprReturnCounts = make(map[ast.Expression]int)
-	a.exprTypes = make(map[ast.Expression]*TypeInfo)
-	a.deprecatedFuncs = make(map[string]string)
-	a.deprecatedTypes = make(map[string]string)
-	a.panickedFuncs = make(map[string]string)
+	a.initTables()
 
 	// Check package name for collisions with Go stdlib
 	a.checkPackageName()
@@ -77,19 +113,51 @@ func (a *Analyzer) Analyze() []error {
 	// Validate skill declaration if present
 	a.checkSkillDecl()
 
+	// Keep only the `when target` branches for the build target
+	a.selectTarget()
+
+	// Enforce the # kukicha: version pragma, if any
+	a.checkLanguageVersion()
+
 	// Pre-pass: collect directives from declarations
 	a.collectDirectives()
 
+	// Validate # route: directives against their handlers' signatures
+	a.checkRoutes()
+
+	// Validate # schedule: directives and the scheduled functions' signatures
+	a.checkSchedules()
+
 	// First pass: Collect all type and interface declarations
 	a.collectDeclarations()
 
 	// Second pass: Analyze function bodies and validate
 	a.analyzeDeclarations()
 
+	// Enforce # kuki:pure on the functions that declare it
+	a.checkPurity()
+
 	return a.errors
 }
 
-// collectDirectives scans all declarations for # kuki:deprecated, # kuki:panics, and # kuki:todo directives.
+// initTables makes the maps the passes fill.
+func (a *Analyzer) initTables() {
+	a.exprReturnCounts = make(map[ast.Expression]int)
+	a.exprTypes = make(map[ast.Expression]*TypeInfo)
+	a.typeAnnTypes = make(map[ast.TypeAnnotation]*TypeInfo)
+	a.deprecatedFuncs = make(map[string]string)
+	a.deprecatedTypes = make(map[string]string)
+	a.panickedFuncs = make(map[string]string)
+	a.pureFuncs = make(map[string]bool)
+	a.methods = make(map[string]map[string]*TypeInfo)
+	a.operators = make(map[string]map[string]string)
+	a.constExprs = make(map[string]ast.Expression)
+	a.constants = make(map[ast.Expression]constant.Value)
+	a.lambdaTargets = make(map[*ast.ArrowLambda]*TypeInfo)
+	a.petioleFuncs = make(map[string]*TypeInfo)
+}
+
+// collectDirectives scans all declarations for # kuki:deprecated, # kuki:panics, # kuki:pure, and # kuki:todo directives.
 // It populates the corresponding maps and emits warnings for TODOs immediately.
 func (a *Analyzer) collectDirectives() {
 	for _, decl := range a.program.Declarations {
@@ -104,6 +172,9 @@ func (a *Analyzer) collectDirectives() {
 			if msg := directiveMessage(d.Directives, "panics"); msg != "" {
 				a.panickedFuncs[d.Name.Value] = msg
 			}
+			if directiveMessage(d.Directives, "pure") != "" {
+				a.pureFuncs[funcKey(d)] = true
+			}
 		case *ast.TypeDecl:
 			if msg := directiveMessage(d.Directives, "todo"); msg != "" {
 				a.warn(d.Pos(), fmt.Sprintf("TODO: %q on %s", msg, d.Name.Value))
@@ -158,6 +229,11 @@ func (a *Analyzer) error(pos ast.Position, message string) {
 	a.errors = append(a.errors, err)
 }
 
+// errorMsg records a catalogued error, reported in the selected language.
+func (a *Analyzer) errorMsg(pos ast.Position, id catalog.ID, args ...any) {
+	a.errors = append(a.errors, catalog.Errorf(pos.File, pos.Line, pos.Column, id, args...))
+}
+
 func (a *Analyzer) warn(pos ast.Position, message string) {
 	w := fmt.Errorf("%s:%d:%d: %s", pos.File, pos.Line, pos.Column, message)
 	a.warnings = append(a.warnings, w)
@@ -0,0 +1,85 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/lexer"
+)
+
+// autoImportPackages maps the names of common Go stdlib packages to their
+// import paths. Names shared by two packages (rand, template) are left out,
+// since the right one can't be told from the reference.
+var autoImportPackages = map[string]string{
+	"atomic":   "sync/atomic",
+	"base64":   "encoding/base64",
+	"big":      "math/big",
+	"bits":     "math/bits",
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"cmp":      "cmp",
+	"context":  "context",
+	"csv":      "encoding/csv",
+	"errors":   "errors",
+	"exec":     "os/exec",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"fs":       "io/fs",
+	"gzip":     "compress/gzip",
+	"hex":      "encoding/hex",
+	"http":     "net/http",
+	"io":       "io",
+	"iter":     "iter",
+	"json":     "encoding/json",
+	"log":      "log",
+	"maps":     "maps",
+	"math":     "math",
+	"net":      "net",
+	"os":       "os",
+	"path":     "path",
+	"regexp":   "regexp",
+	"runtime":  "runtime",
+	"sha256":   "crypto/sha256",
+	"signal":   "os/signal",
+	"slices":   "slices",
+	"slog":     "log/slog",
+	"sort":     "sort",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"time":     "time",
+	"unicode":  "unicode",
+	"url":      "net/url",
+	"utf8":     "unicode/utf8",
+}
+
+// SetAutoImport makes Analyze import a known Go stdlib package (see
+// autoImportPackages) the first time the program refers to it without an
+// import, as goimports would: `strings.ToUpper(x)` adds `import "strings"`.
+// The import is appended to the program, so codegen emits it. Call before
+// Analyze.
+func (a *Analyzer) SetAutoImport(auto bool) {
+	a.autoImport = auto
+}
+
+// autoImportPackage imports the Go stdlib package name refers to when
+// auto-import is on and name is not otherwise defined, reporting whether it
+// did. at is the first reference, which becomes the import's position.
+func (a *Analyzer) autoImportPackage(name string, at lexer.Token) bool {
+	if !a.autoImport || a.symbolTable.Resolve(name) != nil {
+		return false
+	}
+	path, ok := autoImportPackages[name]
+	if !ok {
+		return false
+	}
+	tok := lexer.Token{Type: lexer.TOKEN_IMPORT, Lexeme: "import"}.At(at)
+	imp := &ast.ImportDecl{Token: tok, Path: &ast.StringLiteral{Token: tok, Value: path}, Auto: true}
+	a.program.Imports = append(a.program.Imports, imp)
+	// Package names live in the file scope, whatever scope the reference is in
+	_ = a.symbolTable.scopes[0].Define(&Symbol{
+		Name:    name,
+		Kind:    SymbolVariable,
+		Type:    &TypeInfo{Kind: TypeKindUnknown},
+		Defined: imp.Pos(),
+	})
+	return true
+}
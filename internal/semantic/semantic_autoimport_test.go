@@ -0,0 +1,83 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+const autoImportInput = `func Elapsed(d time.Duration) string
+    return d.String()
+
+func main()
+    name := strings.ToUpper("x")
+    p := filepath.Join("a", name)
+    path := "local"
+    print(p, path.Len())
+`
+
+func importPaths(t *testing.T, input string, auto bool) ([]string, []error) {
+	t.Helper()
+	program := mustParseProgram(t, input)
+	analyzer := New(program)
+	analyzer.SetAutoImport(auto)
+	errs := analyzer.Analyze()
+	var paths []string
+	for _, imp := range program.Imports {
+		paths = append(paths, imp.Path.Value)
+	}
+	return paths, errs
+}
+
+func TestAutoImportAddsStdlibImports(t *testing.T) {
+	paths, errs := importPaths(t, autoImportInput, true)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	// path is a local variable, so path.Len() does not import "path"
+	want := []string{"time", "strings", "path/filepath"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("imports = %v, want %v", paths, want)
+	}
+}
+
+func TestAutoImportOnceAndRespectsExplicitImports(t *testing.T) {
+	input := `import "strings"
+
+func main()
+    a := strings.ToUpper("x")
+    b := strconv.Itoa(1)
+    c := strconv.Itoa(2)
+    print(a, b, c)
+`
+	paths, errs := importPaths(t, input, true)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []string{"strings", "strconv"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("imports = %v, want %v", paths, want)
+	}
+}
+
+func TestAutoImportOffReportsMissingPackage(t *testing.T) {
+	paths, errs := importPaths(t, autoImportInput, false)
+	if len(paths) != 0 {
+		t.Errorf("imports = %v, want none", paths)
+	}
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "time") {
+		t.Fatalf("expected a missing import error for time, got %v", errs)
+	}
+}
+
+func TestAutoImportIgnoresUnknownNames(t *testing.T) {
+	input := `func main()
+    print(rand.Int())
+`
+	paths, errs := importPaths(t, input, true)
+	if len(paths) != 0 {
+		t.Errorf("imports = %v, want none", paths)
+	}
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "rand") {
+		t.Fatalf("expected an undefined identifier error for rand, got %v", errs)
+	}
+}
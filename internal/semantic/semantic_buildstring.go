@@ -0,0 +1,72 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// analyzeBuildStringExpr checks a `build string as b` block. Codegen runs the
+// body in a func literal that declares b as a strings.Builder and returns
+// b.String(), so the body follows the safely rules (no return, no onerr that
+// returns, no break or continue to an outer loop). The builder may only call
+// its own methods: anything that lets it outlive the block is an error
+// (checkBuilderUses).
+func (a *Analyzer) analyzeBuildStringExpr(e *ast.BuildStringExpr) *TypeInfo {
+	savedClosureBlock, savedLoopDepth, savedSwitchDepth := a.closureBlock, a.loopDepth, a.switchDepth
+	a.closureBlock, a.loopDepth, a.switchDepth = "build string", 0, 0
+	a.symbolTable.EnterScope()
+	err := a.symbolTable.Define(&Symbol{
+		Name:    e.Builder.Value,
+		Kind:    SymbolVariable,
+		Type:    &TypeInfo{Kind: TypeKindNamed, Name: "strings.Builder"},
+		Defined: e.Builder.Pos(),
+		Mutable: true,
+	})
+	if err != nil {
+		a.error(e.Builder.Pos(), err.Error())
+	}
+	a.analyzeBlock(e.Body)
+	a.symbolTable.ExitScope()
+	a.closureBlock, a.loopDepth, a.switchDepth = savedClosureBlock, savedLoopDepth, savedSwitchDepth
+
+	a.checkBuilderUses(e)
+	return &TypeInfo{Kind: TypeKindString}
+}
+
+// checkBuilderUses reports uses of the builder other than calling one of its
+// methods directly in the block: passing, assigning or returning it, or
+// capturing it in a closure or go/defer statement.
+func (a *Analyzer) checkBuilderUses(e *ast.BuildStringExpr) {
+	name := e.Builder.Value
+	receivers := make(map[*ast.Identifier]bool)
+	isBuilder := func(x ast.Expression) bool {
+		id, ok := x.(*ast.Identifier)
+		return ok && id.Value == name
+	}
+	ast.WalkBlock(e.Body, func(x ast.Expression) bool {
+		switch x := x.(type) {
+		case *ast.MethodCallExpr:
+			if id, ok := x.Object.(*ast.Identifier); ok && id.Value == name {
+				receivers[id] = true
+			}
+		case *ast.FunctionLiteral, *ast.ArrowLambda:
+			if ast.WalkExpr(x, isBuilder) {
+				a.errorMsg(x.Pos(), catalog.BuilderCaptured, name)
+			}
+		case *ast.Identifier:
+			if x.Value == name && !receivers[x] {
+				a.errorMsg(x.Pos(), catalog.BuilderMisuse, name, name)
+			}
+		}
+		return false
+	})
+	ast.WalkStmts(e.Body, func(stmt ast.Statement) bool {
+		switch s := stmt.(type) {
+		case *ast.GoStmt, *ast.DeferStmt:
+			if ast.WalkStmt(s, isBuilder) {
+				a.errorMsg(s.Pos(), catalog.BuilderInGoDefer, name)
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,113 @@
+package semantic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// len(s) counts the bytes of a string, as in Go: "café" is 5 and "👍🏽" is 8.
+// That is right for buffers and protocols but not for text a person reads, so
+// the analyzer warns where the count is almost certainly meant as characters
+// and points to stdlib/text, which counts user-perceived characters.
+
+// stringLenOperand returns the variable name and the position of len when
+// expr is the builtin len(name) of a string variable.
+func (a *Analyzer) stringLenOperand(expr ast.Expression) (string, ast.Position, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Arguments) != 1 {
+		return "", ast.Position{}, false
+	}
+	fn, ok := call.Function.(*ast.Identifier)
+	if !ok || fn.Value != "len" || a.symbolTable.Resolve("len") != nil {
+		return "", ast.Position{}, false
+	}
+	arg, ok := call.Arguments[0].(*ast.Identifier)
+	if !ok {
+		return "", ast.Position{}, false
+	}
+	if t := a.exprTypes[arg]; t == nil || t.Kind != TypeKindString {
+		return "", ast.Position{}, false
+	}
+	return arg.Value, fn.Pos(), true
+}
+
+func (a *Analyzer) warnByteLength(pos ast.Position, name string) {
+	a.warn(pos, fmt.Sprintf("len(%s) counts bytes, not characters (\"café\" is 5); use text.Length(%s) from stdlib/text", name, name))
+}
+
+// checkLengthTruncation warns about the truncation idiom
+// `if len(s) > n` followed by s[:n], which counts and cuts bytes and can
+// split a character in half.
+func (a *Analyzer) checkLengthTruncation(stmt *ast.IfStmt) {
+	cond, ok := stmt.Condition.(*ast.BinaryExpr)
+	if !ok {
+		return
+	}
+	lenExpr := cond.Left
+	switch cond.Operator {
+	case ">", ">=":
+	case "<", "<=":
+		lenExpr = cond.Right
+	default:
+		return
+	}
+	name, pos, ok := a.stringLenOperand(lenExpr)
+	if !ok {
+		return
+	}
+	cuts := ast.WalkBlock(stmt.Consequence, func(e ast.Expression) bool {
+		slice, ok := e.(*ast.SliceExpr)
+		if !ok || slice.End == nil {
+			return false
+		}
+		id, ok := slice.Left.(*ast.Identifier)
+		return ok && id.Value == name && (slice.Start == nil || isZeroLiteral(slice.Start))
+	})
+	if cuts {
+		a.warn(pos, fmt.Sprintf("len(%s) and %s[:n] count and cut bytes, which can split a character; use text.Length(%s) and text.Slice(%s, 0, n) from stdlib/text", name, name, name, name))
+	}
+}
+
+// checkLengthRepeat warns about len(s) in the count of strings.Repeat,
+// the padding and underline idiom, where the width is meant in characters.
+func (a *Analyzer) checkLengthRepeat(expr *ast.MethodCallExpr) {
+	pkg, ok := expr.Object.(*ast.Identifier)
+	if !ok || expr.Method.Value != "Repeat" || len(expr.Arguments) != 2 {
+		return
+	}
+	if pkg.Value != "strings" && !(pkg.Value == "string" && a.stdlibImports["string"]) {
+		return
+	}
+	ast.WalkExpr(expr.Arguments[1], func(e ast.Expression) bool {
+		if name, pos, ok := a.stringLenOperand(e); ok {
+			a.warnByteLength(pos, name)
+			return true
+		}
+		return false
+	})
+}
+
+// checkLengthInterpolation warns about "{len(s)} characters" and similar
+// messages that report a byte count as a character count.
+func (a *Analyzer) checkLengthInterpolation(lit *ast.StringLiteral) {
+	for i, part := range lit.Parts {
+		if part.IsLiteral || i+1 >= len(lit.Parts) || !lit.Parts[i+1].IsLiteral {
+			continue
+		}
+		name, pos, ok := a.stringLenOperand(part.Expr)
+		if !ok {
+			continue
+		}
+		next := strings.ToLower(strings.TrimSpace(lit.Parts[i+1].Literal))
+		if strings.HasPrefix(next, "char") || strings.HasPrefix(next, "letter") {
+			a.warnByteLength(pos, name)
+		}
+	}
+}
+
+func isZeroLiteral(expr ast.Expression) bool {
+	lit, ok := expr.(*ast.IntegerLiteral)
+	return ok && lit.Big == nil && lit.Value == 0
+}
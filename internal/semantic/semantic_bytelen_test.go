@@ -0,0 +1,52 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringLengthAsCharacterCountWarns(t *testing.T) {
+	input := `import "strings"
+
+func Label(title string) string
+    if len(title) > 20
+        title = title[:20] + "..."
+    underline := strings.Repeat("=", len(title))
+    return "{title}\n{underline}\n{len(title)} characters"
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got: %v", warnings)
+	}
+	if !strings.Contains(warnings[0].Error(), "len(title) and title[:n] count and cut bytes") ||
+		!strings.Contains(warnings[0].Error(), "text.Slice(title, 0, n)") {
+		t.Errorf("expected a truncation warning, got: %v", warnings[0])
+	}
+	for _, w := range warnings[1:] {
+		if !strings.Contains(w.Error(), "len(title) counts bytes, not characters") || !strings.Contains(w.Error(), "text.Length(title)") {
+			t.Errorf("expected a text.Length warning, got: %v", w)
+		}
+	}
+}
+
+func TestStringLengthAsByteCountDoesNotWarn(t *testing.T) {
+	input := `func Unquote(value string, items list of string) (string, int)
+    if len(value) >= 2 and value[0] == '"'
+        value = value[1:len(value) - 1]
+    if len(items) > 3
+        items = items[:3]
+    if len(value) > 0
+        print("{len(value)} bytes")
+    return value, len(items)
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got: %v", warnings)
+	}
+}
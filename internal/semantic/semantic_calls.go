@@ -2,8 +2,11 @@ package semantic
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 // knownExternalReturns maps qualified function names to their return count.
@@ -49,19 +52,18 @@ func extractQualifiedName(fn ast.Expression) string {
 // parameter of the lambda that appears at paramIdx in the calling function's
 // signature. Three inference cases are handled:
 //
-//   - Case A: user-defined function with TypeKindFunction at paramIdx — uses
-//     funcType.Params[paramIdx].Params directly.
+//   - Case A: user-defined function with a func type (or a named func type)
+//     at paramIdx — uses that signature's Params directly.
 //   - Cases B & C: Kukicha stdlib registry with ParamFuncParams entry for
-//     paramIdx — substitutes placeholder names ("any", "any2", "ordered")
+//     paramIdx — substitutes placeholder names ("any", "any2", "ordered", "number")
 //     with elementType, and uses concrete names (e.g. "cli.Args") as-is.
 func (a *Analyzer) resolveExpectedLambdaParams(
 	qualName string, paramIdx int, funcType *TypeInfo, elementType *TypeInfo,
 ) []*TypeInfo {
 	// Case A: user-defined function with a known func-typed parameter
 	if funcType != nil && funcType.Kind == TypeKindFunction && paramIdx < len(funcType.Params) {
-		paramType := funcType.Params[paramIdx]
-		if paramType != nil && paramType.Kind == TypeKindFunction && len(paramType.Params) > 0 {
-			return paramType.Params
+		if sig := a.funcSignature(funcType.Params[paramIdx]); sig != nil && len(sig.Params) > 0 {
+			return sig.Params
 		}
 	}
 
@@ -77,7 +79,7 @@ func (a *Analyzer) resolveExpectedLambdaParams(
 					// not element type. Skip here — it will be resolved after
 					// lambda body analysis via resolveGenericPlaceholders.
 					hasAny = true
-				case gt.Kind == TypeKindNamed && (gt.Name == "any" || gt.Name == "any2" || gt.Name == "ordered"):
+				case gt.Kind == TypeKindNamed && (gt.Name == "any" || gt.Name == "any2" || gt.Name == "ordered" || gt.Name == "number"):
 					if elementType != nil {
 						result[j] = elementType
 						hasAny = true
@@ -124,10 +126,17 @@ func (a *Analyzer) inferLambdaParamTypes(
 
 	for i, arg := range expr.Arguments {
 		lambda, ok := arg.(*ast.ArrowLambda)
-		if !ok || !hasUntypedParams(lambda) {
+		if !ok {
 			continue
 		}
 		paramIdx := i + argOffset
+		a.recordLambdaTarget(lambda, funcType, paramIdx)
+		if a.lambdaTargets[lambda] == nil {
+			a.recordStdlibLambdaTarget(lambda, qualName, paramIdx)
+		}
+		if !hasUntypedParams(lambda) {
+			continue
+		}
 
 		inferredParamTypes := a.resolveExpectedLambdaParams(qualName, paramIdx, funcType, elementType)
 		for j, param := range lambda.Parameters {
@@ -139,13 +148,14 @@ func (a *Analyzer) inferLambdaParamTypes(
 }
 
 // inferLambdaParamTypesMethod records inferred parameter types for untyped arrow
-// lambda parameters in a MethodCallExpr's argument list.
-func (a *Analyzer) inferLambdaParamTypesMethod(expr *ast.MethodCallExpr, pipedArg *TypeInfo) {
+// lambda parameters in a MethodCallExpr's argument list. methodType is the
+// signature of a user-defined method, or nil for a package function.
+func (a *Analyzer) inferLambdaParamTypesMethod(expr *ast.MethodCallExpr, pipedArg *TypeInfo, methodType *TypeInfo) {
 	qualName := ""
 	if objID, ok := expr.Object.(*ast.Identifier); ok {
 		qualName = a.resolveQualifiedName(objID.Value + "." + expr.Method.Value)
 	}
-	if qualName == "" {
+	if qualName == "" && methodType == nil {
 		return
 	}
 
@@ -166,19 +176,26 @@ func (a *Analyzer) inferLambdaParamTypesMethod(expr *ast.MethodCallExpr, pipedAr
 	}
 
 	argOffset := 0
-	if pipedArg != nil {
+	if pipedArg != nil && expr.Object != nil {
 		argOffset = 1 // piped arg occupies parameter index 0
 	}
 
 	for i, arg := range expr.Arguments {
 		lambda, ok := arg.(*ast.ArrowLambda)
-		if !ok || !hasUntypedParams(lambda) {
+		if !ok {
 			continue
 		}
 		paramIdx := i + argOffset
+		a.recordLambdaTarget(lambda, methodType, paramIdx)
+		if a.lambdaTargets[lambda] == nil {
+			a.recordStdlibLambdaTarget(lambda, qualName, paramIdx)
+		}
+		if !hasUntypedParams(lambda) {
+			continue
+		}
 
-		// funcType is unknown for stdlib method calls, so only Cases B & C apply
-		inferredParamTypes := a.resolveExpectedLambdaParams(qualName, paramIdx, nil, elementType)
+		// methodType is nil for stdlib calls, so only Cases B & C apply there
+		inferredParamTypes := a.resolveExpectedLambdaParams(qualName, paramIdx, methodType, elementType)
 		for j, param := range lambda.Parameters {
 			if param.Type == nil && j < len(inferredParamTypes) && inferredParamTypes[j] != nil {
 				a.recordType(param.Name, inferredParamTypes[j])
@@ -187,7 +204,56 @@ func (a *Analyzer) inferLambdaParamTypesMethod(expr *ast.MethodCallExpr, pipedAr
 	}
 }
 
+// recordLambdaTarget remembers the signature a lambda must have when it is
+// passed for a parameter of known func type, so the analyzer and codegen give
+// it that signature instead of guessing from its body.
+func (a *Analyzer) recordLambdaTarget(lambda *ast.ArrowLambda, funcType *TypeInfo, paramIdx int) {
+	if funcType == nil || funcType.Kind != TypeKindFunction || len(funcType.Params) == 0 {
+		return
+	}
+	if funcType.Variadic && paramIdx >= len(funcType.Params)-1 {
+		paramIdx = len(funcType.Params) - 1
+	}
+	if paramIdx >= len(funcType.Params) {
+		return
+	}
+	sig := a.funcSignature(funcType.Params[paramIdx])
+	if sig == nil || len(sig.Params) != len(lambda.Parameters) {
+		return
+	}
+	if a.lambdaTargets == nil {
+		a.lambdaTargets = make(map[*ast.ArrowLambda]*TypeInfo)
+	}
+	a.lambdaTargets[lambda] = sig
+}
+
+// recordStdlibLambdaTarget gives a lambda passed to a Kukicha stdlib func
+// parameter with no results (osx.OnInterrupt, concurrent.Go) an empty result
+// list. The registry carries no result types for other func parameters.
+func (a *Analyzer) recordStdlibLambdaTarget(lambda *ast.ArrowLambda, qualName string, paramIdx int) {
+	entry, ok := generatedStdlibRegistry[qualName]
+	if !ok || len(entry.VoidFuncParams) == 0 {
+		return
+	}
+	if n := len(entry.ParamNames); n > 0 && paramIdx >= n {
+		paramIdx = n - 1 // extra arguments go to a variadic last parameter
+	}
+	if !slices.Contains(entry.VoidFuncParams, paramIdx) || len(entry.ParamFuncParams[paramIdx]) != len(lambda.Parameters) {
+		return
+	}
+	params := make([]*TypeInfo, len(lambda.Parameters))
+	for i := range params {
+		params[i] = &TypeInfo{Kind: TypeKindUnknown}
+	}
+	if a.lambdaTargets == nil {
+		a.lambdaTargets = make(map[*ast.ArrowLambda]*TypeInfo)
+	}
+	a.lambdaTargets[lambda] = &TypeInfo{Kind: TypeKindFunction, Params: params}
+}
+
 func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*TypeInfo {
+	pipedRest := a.takePipedRest()
+
 	// Check for known Go stdlib functions (parsed as direct Identifier, e.g. os.LookupEnv)
 	if id, ok := expr.Function.(*ast.Identifier); ok {
 		if entry, ok := generatedGoStdlib[id.Value]; ok {
@@ -201,7 +267,7 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 	if methodCall, ok := expr.Function.(*ast.MethodCallExpr); ok {
 		if objID, ok := methodCall.Object.(*ast.Identifier); ok {
 			qualifiedName := objID.Value + "." + methodCall.Method.Value
-			if entry, ok := generatedGoStdlib[qualifiedName]; ok {
+			if entry, ok := generatedGoStdlib[qualifiedName]; ok && !a.stdlibImports[objID.Value] {
 				types := goStdlibEntryToTypeInfos(entry)
 				a.recordReturnCount(expr, entry.Count)
 				return types
@@ -224,12 +290,29 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 	}
 
 	funcType := a.analyzeExpression(expr.Function)
+	if id, ok := expr.Function.(*ast.Identifier); ok {
+		if sym := a.symbolTable.Resolve(id.Value); sym != nil && sym.Kind == SymbolType &&
+			(funcType.Kind == TypeKindFunction || isPrimitiveKind(sym.Type.Kind)) {
+			// Conversion to a named func type, Handler(fn), or to a number,
+			// string or bool type, Meters(100)
+			for _, arg := range expr.Arguments {
+				a.analyzeExpression(arg)
+				a.checkEnumValue(arg, sym.Type)
+			}
+			a.recordReturnCount(expr, 1)
+			return []*TypeInfo{funcType}
+		}
+	}
+	// Calling a value of a named func type uses its signature
+	if sig := a.funcSignature(funcType); sig != nil {
+		funcType = sig
+	}
 
 	// Analyze named arguments (check for duplicates)
 	namedArgNames := make(map[string]bool)
 	for _, namedArg := range expr.NamedArguments {
 		if namedArgNames[namedArg.Name.Value] {
-			a.error(namedArg.Pos(), fmt.Sprintf("duplicate named argument: %s", namedArg.Name.Value))
+			a.errorMsg(namedArg.Pos(), catalog.DuplicateNamedArgument, namedArg.Name.Value)
 		}
 		namedArgNames[namedArg.Name.Value] = true
 		a.analyzeExpression(namedArg.Value)
@@ -245,10 +328,18 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 		}
 	}
 
+	// A multi-value pipe source is spread across the leading parameters when
+	// the callee's arity requires it: split(s) |> join(",") → join(a, b, ",").
+	spread := pipedArg != nil && !hasPlaceholder && len(expr.NamedArguments) == 0 &&
+		PipeSpreadsValues(funcType, 1+len(pipedRest), len(expr.Arguments))
+
 	// Analyze non-lambda arguments first so their types are available for inference.
 	var providedArgTypes []*TypeInfo
 	if pipedArg != nil && !hasPlaceholder {
 		providedArgTypes = append(providedArgTypes, pipedArg)
+		if spread {
+			providedArgTypes = append(providedArgTypes, pipedRest...)
+		}
 	}
 	lambdaIndices := make(map[int]bool)
 	for i, arg := range expr.Arguments {
@@ -274,10 +365,7 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 	// Now analyze lambda arguments — params are already typed from inference.
 	for i, arg := range expr.Arguments {
 		if lambdaIndices[i] {
-			offset := 0
-			if pipedArg != nil && !hasPlaceholder {
-				offset = 1
-			}
+			offset := len(providedArgTypes) - len(expr.Arguments)
 			providedArgTypes[i+offset] = a.analyzeExpression(arg)
 		}
 	}
@@ -300,85 +388,21 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 				if id, ok := expr.Function.(*ast.Identifier); ok {
 					name = fmt.Sprintf("function '%s'", id.Value)
 				}
-				a.error(expr.Pos(), fmt.Sprintf("named arguments are not supported for imported or unknown %s (please use positional arguments)", name))
+				a.errorMsg(expr.Pos(), catalog.NamedArgumentsUnsupported, name)
 			}
 		}
 	}
 
 	// If it's a known function, validate arguments
 	if funcType.Kind == TypeKindFunction {
-		// Validate argument count
-		totalProvidedArgs := len(expr.Arguments) + len(expr.NamedArguments)
-		if pipedArg != nil && !hasPlaceholder {
-			totalProvidedArgs++
-		}
-
-		// Calculate required arguments (parameters without defaults)
-		requiredParams := len(funcType.Params)
-		if funcType.DefaultCount > 0 {
-			requiredParams = len(funcType.Params) - funcType.DefaultCount
-		}
-
-		if funcType.Variadic {
-			if expr.Variadic {
-				// Spreading a slice into variadic: f(many args)
-				// The spread argument replaces the entire variadic portion,
-				// so we need at least (non-variadic params) + 1 (the spread) arguments.
-				nonVariadicParams := len(funcType.Params) - 1
-				if totalProvidedArgs < nonVariadicParams+1 {
-					a.error(expr.Pos(), fmt.Sprintf("expected at least %d arguments, got %d", nonVariadicParams+1, totalProvidedArgs))
-				}
-			} else {
-				// Variadic: must have at least (required params - 1) arguments
-				minArgs := max(requiredParams-1, 0)
-				if totalProvidedArgs < minArgs {
-					a.error(expr.Pos(), fmt.Sprintf("expected at least %d arguments, got %d", minArgs, totalProvidedArgs))
-				}
-			}
-		} else {
-			// Non-variadic: must have between required and total params
-			if totalProvidedArgs < requiredParams {
-				a.error(expr.Pos(), fmt.Sprintf("expected at least %d arguments, got %d", requiredParams, totalProvidedArgs))
-			}
-			if totalProvidedArgs > len(funcType.Params) {
-				a.error(expr.Pos(), fmt.Sprintf("expected at most %d arguments, got %d", len(funcType.Params), totalProvidedArgs))
-			}
-		}
-
-		// Validate positional argument types
-		for i, argType := range providedArgTypes {
-			// For variadic, all args beyond params-1 match the last param type
-			paramIndex := i
-			if funcType.Variadic && i >= len(funcType.Params)-1 {
-				paramIndex = len(funcType.Params) - 1
-			}
-
-			// When spreading a slice (expr.Variadic), the last argument is a
-			// slice being unpacked. Check that its element type matches the
-			// variadic parameter type instead of comparing directly.
-			if expr.Variadic && funcType.Variadic && paramIndex == len(funcType.Params)-1 && i == len(providedArgTypes)-1 {
-				variadicParamType := funcType.Params[paramIndex]
-				if argType.Kind == TypeKindList {
-					if argType.ElementType != nil {
-						// list of T spread into ...T — check element type
-						if !a.typesCompatible(variadicParamType, argType.ElementType) {
-							a.error(expr.Pos(), fmt.Sprintf("argument %d: cannot use %s as []%s in variadic spread", i+1, argType, variadicParamType))
-						}
-					}
-					// If ElementType is nil, we can't check — be lenient
-				} else if argType.Kind != TypeKindUnknown {
-					// Not a list — could still be valid for interface{} params or unknown types
-					if !a.typesCompatible(variadicParamType, argType) {
-						a.error(expr.Pos(), fmt.Sprintf("argument %d: cannot use %s as %s", i+1, argType, variadicParamType))
-					}
-				}
-				continue
-			}
-
-			if paramIndex < len(funcType.Params) && !a.typesCompatible(funcType.Params[paramIndex], argType) {
-				a.error(expr.Pos(), fmt.Sprintf("argument %d: cannot use %s as %s", i+1, argType, funcType.Params[paramIndex]))
-			}
+		// Validate argument count and positional argument types
+		hint := ""
+		if len(pipedRest) > 0 && !spread && !hasPlaceholder {
+			hint = pipedValuesHint(1+len(pipedRest), len(expr.Arguments))
 		}
+		totalProvidedArgs := len(providedArgTypes) + len(expr.NamedArguments)
+		a.checkCallArguments(expr.Pos(), funcType, providedArgTypes, totalProvidedArgs, expr.Variadic, hint)
+		a.checkEnumArgs(expr.Arguments, funcType, len(providedArgTypes)-len(expr.Arguments))
 
 		// Record expected param types on pipe placeholder "_" arguments
 		// so that exprTypes contains typed info rather than TypeKindUnknown.
@@ -395,6 +419,85 @@ func (a *Analyzer) analyzeCallExpr(expr *ast.CallExpr, pipedArg *TypeInfo) []*Ty
 	return []*TypeInfo{{Kind: TypeKindUnknown}}
 }
 
+// checkCallArguments validates the argument count and positional argument
+// types of a call to a known function or method. argTypes holds the types in
+// parameter order (including any piped values); totalProvidedArgs also counts named
+// arguments. hint is appended to arity errors.
+func (a *Analyzer) checkCallArguments(pos ast.Position, funcType *TypeInfo, argTypes []*TypeInfo, totalProvidedArgs int, variadicSpread bool, hint string) {
+	// Calculate required arguments (parameters without defaults)
+	requiredParams := len(funcType.Params)
+	if funcType.DefaultCount > 0 {
+		requiredParams = len(funcType.Params) - funcType.DefaultCount
+	}
+
+	if funcType.Variadic {
+		if variadicSpread {
+			// Spreading a slice into variadic: f(many args)
+			// The spread argument replaces the entire variadic portion,
+			// so we need at least (non-variadic params) + 1 (the spread) arguments.
+			nonVariadicParams := len(funcType.Params) - 1
+			if totalProvidedArgs < nonVariadicParams+1 {
+				a.errorMsg(pos, catalog.TooFewArguments, nonVariadicParams+1, totalProvidedArgs, hint)
+			}
+		} else {
+			// Variadic: must have at least (required params - 1) arguments
+			minArgs := max(requiredParams-1, 0)
+			if totalProvidedArgs < minArgs {
+				a.errorMsg(pos, catalog.TooFewArguments, minArgs, totalProvidedArgs, hint)
+			}
+		}
+	} else {
+		// Non-variadic: must have between required and total params
+		if totalProvidedArgs < requiredParams {
+			a.errorMsg(pos, catalog.TooFewArguments, requiredParams, totalProvidedArgs, hint)
+		}
+		if totalProvidedArgs > len(funcType.Params) {
+			a.errorMsg(pos, catalog.TooManyArguments, len(funcType.Params), totalProvidedArgs, hint)
+		}
+	}
+
+	// Validate positional argument types
+	for i, argType := range argTypes {
+		// For variadic, all args beyond params-1 match the last param type
+		paramIndex := i
+		if funcType.Variadic && i >= len(funcType.Params)-1 {
+			paramIndex = len(funcType.Params) - 1
+		}
+
+		// When spreading a slice (variadicSpread), the last argument is a
+		// slice being unpacked. Check that its element type matches the
+		// variadic parameter type instead of comparing directly.
+		if variadicSpread && funcType.Variadic && paramIndex == len(funcType.Params)-1 && i == len(argTypes)-1 {
+			variadicParamType := funcType.Params[paramIndex]
+			if argType.Kind == TypeKindList {
+				if argType.ElementType != nil {
+					// list of T spread into ...T — check element type
+					if !a.typesCompatible(variadicParamType, argType.ElementType) {
+						a.errorMsg(pos, catalog.VariadicSpreadType, i+1, argType, variadicParamType)
+					}
+				}
+				// If ElementType is nil, we can't check — be lenient
+			} else if argType.Kind != TypeKindUnknown {
+				// Not a list — could still be valid for interface{} params or unknown types
+				if !a.typesCompatible(variadicParamType, argType) {
+					a.errorMsg(pos, catalog.ArgumentType, i+1, argType, variadicParamType)
+				}
+			}
+			continue
+		}
+
+		if paramIndex < len(funcType.Params) && !a.typesCompatible(funcType.Params[paramIndex], argType) {
+			a.errorMsg(pos, catalog.ArgumentType, i+1, argType, funcType.Params[paramIndex])
+		}
+	}
+}
+
+// pipedValuesHint explains an arity error for a call fed by a multi-value pipe
+// source whose values were not spread.
+func pipedValuesHint(count, otherArgs int) string {
+	return fmt.Sprintf(" (only the first of the %d piped values is passed; taking all of them needs %d parameters)", count, count+otherArgs)
+}
+
 // goStdlibTypeToTypeInfo converts a goStdlibType to a TypeInfo, including nested
 // element/key/value types for lists and maps.
 func goStdlibTypeToTypeInfo(gt goStdlibType) *TypeInfo {
@@ -408,6 +511,12 @@ func goStdlibTypeToTypeInfo(gt goStdlibType) *TypeInfo {
 	if gt.ValueType != nil {
 		ti.ValueType = goStdlibTypeToTypeInfo(*gt.ValueType)
 	}
+	for _, p := range gt.Params {
+		ti.Params = append(ti.Params, goStdlibTypeToTypeInfo(p))
+	}
+	for _, r := range gt.Returns {
+		ti.Returns = append(ti.Returns, goStdlibTypeToTypeInfo(r))
+	}
 	return ti
 }
 
@@ -423,17 +532,70 @@ func goStdlibEntryToTypeInfos(entry goStdlibEntry) []*TypeInfo {
 	return types
 }
 
-// isPlaceholderType returns true if the type is a generic placeholder (any, any2, ordered, result).
+// attachStdlibFields gives struct results of a function in stdlib package pkg
+// the exported field types recorded by genstdlibregistry, including structs
+// nested in lists, channels and function signatures (the Event yielded by
+// fetch.Events). Registry names are unqualified ("Socket" for fetch.Socket),
+// so pkg qualifies the lookup.
+func attachStdlibFields(types []*TypeInfo, pkg string) {
+	for _, ti := range types {
+		if ti == nil {
+			continue
+		}
+		if ti.ElementType != nil {
+			attachStdlibFields([]*TypeInfo{ti.ElementType}, pkg)
+		}
+		attachStdlibFields(ti.Params, pkg)
+		attachStdlibFields(ti.Returns, pkg)
+		if ti.Kind != TypeKindNamed || ti.Fields != nil {
+			continue
+		}
+		fields, ok := generatedStdlibStructFields[pkg+"."+ti.Name]
+		if !ok {
+			continue
+		}
+		ti.Fields = make(map[string]*TypeInfo, len(fields))
+		for name, ft := range fields {
+			ti.Fields[name] = goStdlibTypeToTypeInfo(ft)
+		}
+	}
+}
+
+// isPlaceholderType returns true if the type is a generic placeholder (any, any2, ordered, number, result).
 func isPlaceholderType(ti *TypeInfo) bool {
 	return ti != nil && ti.Kind == TypeKindNamed &&
-		(ti.Name == "any" || ti.Name == "any2" || ti.Name == "ordered" || ti.Name == "result")
+		(ti.Name == "any" || ti.Name == "any2" || ti.Name == "ordered" || ti.Name == "number" || ti.Name == "result")
+}
+
+// scalarPlaceholderType picks the type an "ordered" or "number" result takes
+// when no list argument fixes it, e.g. math.Clamp(x, 0, 100) or math.Abs(n).
+// A float argument wins, as an untyped int constant next to it becomes a
+// float in Go.
+func scalarPlaceholderType(argTypes []*TypeInfo, pipedArg *TypeInfo) *TypeInfo {
+	var found *TypeInfo
+	for _, at := range append([]*TypeInfo{pipedArg}, argTypes...) {
+		if at == nil {
+			continue
+		}
+		switch at.Kind {
+		case TypeKindFloat:
+			return at
+		case TypeKindInt, TypeKindString:
+			if found == nil {
+				found = at
+			}
+		}
+	}
+	return found
 }
 
 // resolveGenericPlaceholders resolves placeholder element types in return types
 // using the actual call-site arguments. For example, if a function returns
 // "list of result" and a lambda argument returns RepoEntry, the element type
-// is resolved to RepoEntry.
-func resolveGenericPlaceholders(types []*TypeInfo, argTypes []*TypeInfo, pipedArg *TypeInfo) {
+// is resolved to RepoEntry. generic reports a function the codegen makes
+// generic, whose bare "any" result is the element type (slice.FirstOne,
+// random.Choice); elsewhere "any" is Go's any.
+func resolveGenericPlaceholders(types []*TypeInfo, argTypes []*TypeInfo, pipedArg *TypeInfo, generic bool) {
 	// Resolve "any" placeholder from the first list argument's element type
 	var anyType *TypeInfo
 	if pipedArg != nil && pipedArg.ElementType != nil && !isPlaceholderType(pipedArg.ElementType) {
@@ -447,6 +609,18 @@ func resolveGenericPlaceholders(types []*TypeInfo, argTypes []*TypeInfo, pipedAr
 		}
 	}
 
+	// A generic pipe stage over one value (limit.Limited) takes "any" from
+	// the value itself
+	if anyType == nil && generic {
+		first := pipedArg
+		if first == nil && len(argTypes) > 0 {
+			first = argTypes[0]
+		}
+		if first != nil && first.Kind != TypeKindList && first.Kind != TypeKindFunction && first.Kind != TypeKindUnknown && !isPlaceholderType(first) {
+			anyType = first
+		}
+	}
+
 	// Resolve "result" placeholder from lambda argument return types
 	var resultType *TypeInfo
 	for _, at := range argTypes {
@@ -460,13 +634,28 @@ func resolveGenericPlaceholders(types []*TypeInfo, argTypes []*TypeInfo, pipedAr
 	}
 
 	// Apply resolutions to return types
-	for _, ti := range types {
+	for i, ti := range types {
 		if ti == nil {
 			continue
 		}
+		// A bare ordered/number result has the type of the values passed in
+		if isPlaceholderType(ti) && (ti.Name == "ordered" || ti.Name == "number" || generic && ti.Name == "any") {
+			if anyType != nil {
+				types[i] = anyType
+			} else if st := scalarPlaceholderType(argTypes, pipedArg); st != nil && ti.Name != "any" {
+				types[i] = st
+			}
+			continue
+		}
+		if isPlaceholderType(ti) && ti.Name == "result" {
+			if resultType != nil {
+				types[i] = resultType
+			}
+			continue
+		}
 		if isPlaceholderType(ti.ElementType) {
 			switch ti.ElementType.Name {
-			case "any", "any2", "ordered":
+			case "any", "any2", "ordered", "number":
 				if anyType != nil {
 					ti.ElementType = anyType
 				}
@@ -479,10 +668,60 @@ func resolveGenericPlaceholders(types []*TypeInfo, argTypes []*TypeInfo, pipedAr
 	}
 }
 
+// sampleArgType returns the type of the "sample" argument of a stdlib call
+// that uses the sample pattern (fetch.Json, cache.Get), or nil when the call
+// has none or its type is not known. A piped value fills the first parameter
+// unless a "_" placeholder marks where it goes.
+func sampleArgType(expr *ast.MethodCallExpr, paramNames []string, argTypes []*TypeInfo, pipedArg *TypeInfo) *TypeInfo {
+	idx := slices.Index(paramNames, "sample")
+	if idx < 0 {
+		return nil
+	}
+	if pipedArg != nil && !slices.ContainsFunc(expr.Arguments, isPipePlaceholder) {
+		idx--
+	}
+	if idx < 0 || idx >= len(argTypes) {
+		return nil
+	}
+	st := argTypes[idx]
+	if st == nil || st.Kind == TypeKindUnknown || st.Kind == TypeKindNil || isPlaceholderType(st) {
+		return nil
+	}
+	return st
+}
+
+// resolveSamplePlaceholders replaces the bare "any" results of a sample-pattern
+// call, including those of a returned function (cache.Memoize), with the
+// sample's type.
+func resolveSamplePlaceholders(types []*TypeInfo, sample *TypeInfo) {
+	for i, ti := range types {
+		if ti == nil {
+			continue
+		}
+		if isPlaceholderType(ti) && ti.Name == "any" {
+			types[i] = sample
+			continue
+		}
+		if ti.Kind == TypeKindFunction {
+			resolveSamplePlaceholders(ti.Returns, sample)
+		}
+	}
+}
+
+func isPipePlaceholder(arg ast.Expression) bool {
+	id, ok := arg.(*ast.Identifier)
+	return ok && id.Value == "_"
+}
+
 func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *TypeInfo) []*TypeInfo {
+	pipedRest := a.takePipedRest()
+
 	// Analyze object
 	objType := pipedArg
 	if expr.Object != nil {
+		if id, ok := expr.Object.(*ast.Identifier); ok {
+			a.autoImportPackage(id.Value, id.Token)
+		}
 		objType = a.analyzeExpression(expr.Object)
 	}
 
@@ -498,11 +737,16 @@ func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *Typ
 			argTypes[i] = a.analyzeExpression(arg)
 		}
 	}
+	a.checkLengthRepeat(expr)
 
 	// Infer lambda param types before analyzing lambda bodies, so that
 	// parameters have their types in scope during body analysis (e.g.,
 	// e.name resolves correctly when e is inferred as RepoEntry).
-	a.inferLambdaParamTypesMethod(expr, pipedArg)
+	var methodType *TypeInfo
+	if objType != nil {
+		methodType = a.resolveMethodType(objType, expr.Method.Value)
+	}
+	a.inferLambdaParamTypesMethod(expr, pipedArg, methodType)
 
 	// Now analyze lambda arguments — params are already typed from inference.
 	for i, arg := range expr.Arguments {
@@ -536,8 +780,16 @@ func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *Typ
 		// Security: detect http.Redirect with non-literal URL (open redirect)
 		a.checkRedirectNonLiteral(qualifiedName, expr, pipedArg)
 
+		// An imported petiole of this module shadows any package of its name
+		if sig, ok := a.petioleFuncs[objID.Value+"."+methodName]; ok && a.isPackage(objID) {
+			a.recordType(expr.Method, sig)
+			a.checkMethodArguments(expr, sig, argTypes, pipedArg, pipedRest)
+			a.recordReturnCount(expr, len(sig.Returns))
+			return sig.Returns
+		}
+
 		// Check generated Go stdlib registry first (has full type info)
-		if entry, ok := generatedGoStdlib[qualifiedName]; ok {
+		if entry, ok := generatedGoStdlib[qualifiedName]; ok && !a.stdlibImports[objID.Value] {
 			a.checkDeprecated(expr, methodName, qualifiedName)
 			a.checkPanics(expr, methodName, qualifiedName)
 
@@ -552,14 +804,20 @@ func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *Typ
 			a.checkPanics(expr, methodName, qualifiedName)
 
 			types := goStdlibEntryToTypeInfos(entry)
-			resolveGenericPlaceholders(types, argTypes, pipedArg)
+			resolveGenericPlaceholders(types, argTypes, pipedArg, GetSliceGenericClass(qualifiedName) != "")
+			if st := sampleArgType(expr, entry.ParamNames, argTypes, pipedArg); st != nil {
+				resolveSamplePlaceholders(types, st)
+			}
+			pkg, _, _ := strings.Cut(qualifiedName, ".")
+			attachStdlibFields(types, pkg)
+			qualifyOperandTypes(types, pkg)
 			a.recordReturnCount(expr, entry.Count)
 			return types
 		}
 	}
 
 	// Check generated Go stdlib registry for method calls
-	if objType != nil && (objType.Kind == TypeKindNamed || objType.Kind == TypeKindReference) && objType.Name != "" {
+	if objType != nil && (objType.Kind == TypeKindNamed || objType.Kind == TypeKindReference || objType.Kind == TypeKindInterface) && objType.Name != "" {
 		qualifiedMethodName := objType.Name + "." + methodName
 		
 		a.checkDeprecated(expr, methodName, qualifiedMethodName)
@@ -570,11 +828,18 @@ func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *Typ
 			a.recordReturnCount(expr, entry.Count)
 			return types
 		}
+		if entry, ok := generatedStdlibMethods[qualifiedMethodName]; ok {
+			types := goStdlibEntryToTypeInfos(entry)
+			pkg, _, _ := strings.Cut(objType.Name, ".")
+			qualifyOperandTypes(types, pkg)
+			a.recordReturnCount(expr, entry.Count)
+			return types
+		}
 	}
 
 	// Method call on user-defined type: look up method signature
 	if objType != nil {
-		if objType.Kind == TypeKindNamed || objType.Kind == TypeKindStruct {
+		if objType.Kind == TypeKindNamed || objType.Kind == TypeKindStruct || objType.Kind == TypeKindInterface {
 			qualifiedMethodName := objType.Name + "." + methodName
 			a.checkDeprecated(expr, methodName, qualifiedMethodName)
 			a.checkPanics(expr, methodName, qualifiedMethodName)
@@ -584,20 +849,107 @@ func (a *Analyzer) analyzeMethodCallExpr(expr *ast.MethodCallExpr, pipedArg *Typ
 		}
 
 		methodType := a.resolveMethodType(objType, methodName)
+		if methodType != nil && methodType.Kind == TypeKindFunction {
+			// Record the signature on the method name so codegen can tell
+			// when a multi-value pipe source is spread into the arguments.
+			a.recordType(expr.Method, methodType)
+			a.checkMethodArguments(expr, methodType, argTypes, pipedArg, pipedRest)
+			if expr.Object != nil {
+				a.checkReceiverAddressable(expr.Object, objType, expr, methodType)
+			}
+		}
 		if methodType != nil && len(methodType.Returns) > 0 {
 			a.recordReturnCount(expr, len(methodType.Returns))
 			return methodType.Returns
 		}
 	}
 
+	// A resolved method with no results also ends here; only an unresolved
+	// one is an inference gap.
+	if objType == nil || a.resolveMethodType(objType, methodName) == nil {
+		a.reportUnknownMember(expr, expr.Object, objType, methodName, true)
+	}
+
 	// Return count of 1 gives codegen's onerr path a safe default.
 	a.recordReturnCount(expr, 1)
 	return []*TypeInfo{{Kind: TypeKindUnknown}}
 }
 
+// checkMethodArguments validates a call to a user-defined method. When the
+// call is a pipe target with an explicit receiver (value |> obj.Method(args)),
+// the piped value is the first argument, or fills the "_" placeholder.
+func (a *Analyzer) checkMethodArguments(expr *ast.MethodCallExpr, methodType *TypeInfo, argTypes []*TypeInfo, pipedArg *TypeInfo, pipedRest []*TypeInfo) {
+	var provided []*TypeInfo
+	hint := ""
+	if pipedArg != nil && expr.Object != nil {
+		placeholder := -1
+		for i, arg := range expr.Arguments {
+			if ident, ok := arg.(*ast.Identifier); ok && ident.Value == "_" {
+				placeholder = i
+				break
+			}
+		}
+		if placeholder >= 0 {
+			argTypes = append([]*TypeInfo{}, argTypes...)
+			argTypes[placeholder] = pipedArg
+		} else {
+			provided = append(provided, pipedArg)
+			if PipeSpreadsValues(methodType, 1+len(pipedRest), len(expr.Arguments)) {
+				provided = append(provided, pipedRest...)
+			} else if len(pipedRest) > 0 {
+				hint = pipedValuesHint(1+len(pipedRest), len(expr.Arguments))
+			}
+		}
+	}
+	provided = append(provided, argTypes...)
+	a.checkCallArguments(expr.Pos(), methodType, provided, len(provided)+len(expr.NamedArguments), expr.Variadic, hint)
+}
+
+// checkReceiverAddressable reports a call to a reference-receiver method on a
+// value Go cannot take the address of (a call result, a map element). Go only
+// inserts the implicit & for addressable operands, and silently calling the
+// method on a copy would lose its changes. Struct literals are fine: codegen
+// takes their address explicitly.
+func (a *Analyzer) checkReceiverAddressable(recv ast.Expression, recvType *TypeInfo, expr *ast.MethodCallExpr, methodType *TypeInfo) {
+	if !methodType.ReferenceReceiver || recvType == nil || recvType.Kind == TypeKindReference {
+		return
+	}
+	if !a.isAddressable(recv) {
+		a.errorMsg(expr.Pos(), catalog.ReferenceMethodOnValue, expr.Method.Value, recvType)
+	}
+}
+
+// isAddressable reports whether Go can take the address of expr. Expressions
+// whose addressability isn't known are treated as addressable, leaving the
+// final word to the Go compiler.
+func (a *Analyzer) isAddressable(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr, *ast.MethodCallExpr, *ast.TypeCastExpr:
+		return false
+	case *ast.FieldAccessExpr:
+		if e.Object == nil {
+			return true
+		}
+		if objType := a.exprTypes[e.Object]; objType != nil && objType.Kind == TypeKindReference {
+			return true
+		}
+		return a.isAddressable(e.Object)
+	case *ast.IndexExpr:
+		if leftType := a.exprTypes[e.Left]; leftType != nil && leftType.Kind == TypeKindMap {
+			return false
+		}
+		return true
+	default:
+		return true
+	}
+}
+
 func (a *Analyzer) analyzeFieldAccessExpr(expr *ast.FieldAccessExpr, pipedArg *TypeInfo) *TypeInfo {
 	objType := pipedArg
 	if expr.Object != nil {
+		if id, ok := expr.Object.(*ast.Identifier); ok {
+			a.autoImportPackage(id.Value, id.Token)
+		}
 		objType = a.analyzeExpression(expr.Object)
 	}
 
@@ -609,6 +961,7 @@ func (a *Analyzer) analyzeFieldAccessExpr(expr *ast.FieldAccessExpr, pipedArg *T
 		}
 	}
 
+	a.reportUnknownMember(expr, expr.Object, objType, expr.Field.Value, false)
 	a.recordReturnCount(expr, 1)
 	return &TypeInfo{Kind: TypeKindUnknown}
 }
@@ -673,7 +1026,8 @@ func (a *Analyzer) resolveFieldType(objType *TypeInfo, fieldName string) *TypeIn
 	return nil
 }
 
-// resolveMethodType looks up a method's function type on a struct type.
+// resolveMethodType looks up a method's function type in the method index,
+// dereferencing reference types so methods with either receiver kind resolve.
 func (a *Analyzer) resolveMethodType(objType *TypeInfo, methodName string) *TypeInfo {
 	typeInfo := objType
 	// Dereference pointer/reference types
@@ -681,24 +1035,10 @@ func (a *Analyzer) resolveMethodType(objType *TypeInfo, methodName string) *Type
 		typeInfo = typeInfo.ElementType
 	}
 
-	// Look up in type's methods
-	if typeInfo.Methods != nil {
-		if methodType, ok := typeInfo.Methods[methodName]; ok {
-			return methodType
-		}
+	if typeInfo.Name == "" {
+		return nil
 	}
-
-	// Try resolving via the symbol table (for named types)
-	if typeInfo.Kind == TypeKindNamed || typeInfo.Kind == TypeKindStruct {
-		name := typeInfo.Name
-		if sym := a.symbolTable.Resolve(name); sym != nil && sym.Type != nil && sym.Type.Methods != nil {
-			if methodType, ok := sym.Type.Methods[methodName]; ok {
-				return methodType
-			}
-		}
-	}
-
-	return nil
+	return a.methods[typeInfo.Name][methodName]
 }
 
 // typePlaceholderArgs records expected parameter types on "_" placeholder
@@ -734,7 +1074,7 @@ func (a *Analyzer) validateNamedArgs(expr *ast.CallExpr, paramNames []string) {
 	}
 	for _, namedArg := range expr.NamedArguments {
 		if !paramSet[namedArg.Name.Value] {
-			a.error(namedArg.Pos(), fmt.Sprintf("unknown parameter name '%s'", namedArg.Name.Value))
+			a.errorMsg(namedArg.Pos(), catalog.UnknownParameterName, namedArg.Name.Value)
 		}
 	}
 }
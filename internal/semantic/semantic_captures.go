@@ -0,0 +1,417 @@
+package semantic
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// loopFrame records what the capture check needs to know about an enclosing
+// loop (the scope just outside it, the statements each iteration runs and the
+// names they assign) plus the nil-use state at loop entry.
+type loopFrame struct {
+	outer      *Scope
+	assigned   map[string]bool
+	stmts      map[ast.Statement]bool
+	nilAtEntry nilSet // nil-use state before the loop; merged back on exit
+}
+
+// LoopCapture is a closure created in a loop that captures a variable the
+// loop reassigns (see checkLoopCaptures).
+type LoopCapture struct {
+	Ident *ast.Identifier // The captured variable, inside the closure
+	Stmt  ast.Statement   // The statement in the loop that creates the closure
+	Kind  string          // "goroutine", "deferred closure" or "closure"
+	// Shared is set when a copy made just before Stmt would change what the
+	// program does: the closure, or code after Stmt in its block, assigns
+	// the variable, and would then assign the copy instead.
+	Shared bool
+}
+
+// LoopCaptures returns the closures checkLoopCaptures warned about. Call
+// after Analyze(); the loop-capture lint rule fixes them.
+func (a *Analyzer) LoopCaptures() []LoopCapture {
+	return a.loopCaptures
+}
+
+// enterLoop pushes a loop frame. Call before the loop's own scope is entered
+// so that frame.outer excludes the loop variables.
+func (a *Analyzer) enterLoop(body *ast.BlockStmt) {
+	a.loopDepth++
+	stmts := iterationStmts(body)
+	assigned := make(map[string]bool)
+	for stmt := range stmts {
+		addAssigned(assigned, stmt)
+	}
+	a.loops = append(a.loops, loopFrame{
+		outer:      a.symbolTable.CurrentScope(),
+		assigned:   assigned,
+		stmts:      stmts,
+		nilAtEntry: a.maybeNil.clone(),
+	})
+}
+
+// exitLoop pops the loop frame. The body may run zero times, so the nil-use
+// state after the loop is the union of the states before and after the body.
+func (a *Analyzer) exitLoop() {
+	frame := a.loops[len(a.loops)-1]
+	a.loopDepth--
+	a.loops = a.loops[:len(a.loops)-1]
+	if a.maybeNil != nil {
+		a.maybeNil.union(frame.nilAtEntry)
+	}
+}
+
+// walkStmtsAndHandlers is ast.WalkStmts that also visits the statements of
+// onerr block handlers, which run as part of the statement they handle.
+func walkStmtsAndHandlers(block *ast.BlockStmt, visit func(ast.Statement)) {
+	ast.WalkStmts(block, func(stmt ast.Statement) bool {
+		visit(stmt)
+		if handler := onErrBlock(stmt); handler != nil {
+			walkStmtsAndHandlers(handler, visit)
+		}
+		return false
+	})
+}
+
+// onErrClauseOf returns stmt's onerr clause, or nil.
+func onErrClauseOf(stmt ast.Statement) *ast.OnErrClause {
+	switch s := stmt.(type) {
+	case *ast.VarDeclStmt:
+		return s.OnErr
+	case *ast.AssignStmt:
+		return s.OnErr
+	case *ast.ExpressionStmt:
+		return s.OnErr
+	case *ast.SafelyStmt:
+		return s.OnErr
+	}
+	return nil
+}
+
+// onErrBlock returns the body of stmt's onerr block handler, or nil.
+func onErrBlock(stmt ast.Statement) *ast.BlockStmt {
+	if clause := onErrClauseOf(stmt); clause != nil {
+		if block, ok := clause.Handler.(*ast.BlockExpr); ok {
+			return block.Body
+		}
+	}
+	return nil
+}
+
+// assignedNamesDeep is assignedNames for stmts, including the bodies of the
+// closures in them.
+func assignedNamesDeep(stmts []ast.Statement) map[string]bool {
+	block := &ast.BlockStmt{Statements: stmts}
+	names := assignedNames(block)
+	ast.WalkBlock(block, func(e ast.Expression) bool {
+		switch fn := e.(type) {
+		case *ast.FunctionLiteral:
+			maps.Copy(names, assignedNames(fn.Body))
+		case *ast.ArrowLambda:
+			if fn.Block != nil {
+				maps.Copy(names, assignedNames(fn.Block))
+			}
+		}
+		return false
+	})
+	return names
+}
+
+// iterationStmts collects the statements one iteration of a loop with this
+// body runs: those in nested blocks and onerr block handlers, but not in go
+// blocks, whose goroutine runs on its own.
+func iterationStmts(body *ast.BlockStmt) map[ast.Statement]bool {
+	stmts := make(map[ast.Statement]bool)
+	var inGo []ast.Statement
+	walkStmtsAndHandlers(body, func(stmt ast.Statement) {
+		stmts[stmt] = true
+		if g, ok := stmt.(*ast.GoStmt); ok && g.Block != nil {
+			walkStmtsAndHandlers(g.Block, func(s ast.Statement) { inGo = append(inGo, s) })
+		}
+	})
+	for _, stmt := range inGo {
+		delete(stmts, stmt)
+	}
+	return stmts
+}
+
+// assignedNames collects identifiers that are reassigned (=, ++, --) in block,
+// including in its onerr handlers.
+func assignedNames(block *ast.BlockStmt) map[string]bool {
+	names := make(map[string]bool)
+	walkStmtsAndHandlers(block, func(stmt ast.Statement) {
+		addAssigned(names, stmt)
+	})
+	return names
+}
+
+// addAssigned adds the identifiers stmt itself reassigns to names.
+func addAssigned(names map[string]bool, stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		for _, t := range s.Targets {
+			if id, ok := t.(*ast.Identifier); ok {
+				names[id.Value] = true
+			}
+		}
+	case *ast.IncDecStmt:
+		if id, ok := s.Variable.(*ast.Identifier); ok {
+			names[id.Value] = true
+		}
+	}
+}
+
+// checkLoopCaptures warns when a closure created inside a loop captures a
+// variable that is declared outside the loop and reassigned inside it (in the
+// body or in an onerr handler). Every closure shares that single variable, so
+// by the time a goroutine runs, a defer fires, or a closure stored or passed
+// to a call is invoked, it sees the last value, not the value from its own
+// iteration. Loop variables themselves are per-iteration since Go 1.22 and
+// are not flagged. Findings are kept for the loop-capture lint rule, which
+// copies the variable before the statement.
+func (a *Analyzer) checkLoopCaptures(stmt ast.Statement) {
+	if len(a.loops) == 0 {
+		return
+	}
+
+	kind := "closure"
+	var bodies []*ast.BlockStmt
+	var params []*ast.Parameter
+	collectClosures := func(exprs ...ast.Expression) {
+		for _, expr := range exprs {
+			ast.WalkExpr(expr, func(e ast.Expression) bool {
+				switch fn := e.(type) {
+				case *ast.FunctionLiteral:
+					bodies = append(bodies, fn.Body)
+					params = append(params, fn.Parameters...)
+				case *ast.ArrowLambda:
+					if fn.Block != nil {
+						bodies = append(bodies, fn.Block)
+					} else if fn.Body != nil {
+						bodies = append(bodies, &ast.BlockStmt{Statements: []ast.Statement{&ast.ExpressionStmt{Expression: fn.Body}}})
+					}
+					params = append(params, fn.Parameters...)
+				}
+				return false
+			})
+		}
+	}
+	copied := make(map[string]bool)
+	switch s := stmt.(type) {
+	case *ast.GoStmt:
+		kind = "goroutine"
+		if s.Block != nil {
+			bodies = append(bodies, s.Block)
+			// Codegen passes these to the goroutine as arguments.
+			for _, c := range a.captures[s] {
+				copied[c.Name] = c.ByValue
+			}
+		} else {
+			collectClosures(s.Call)
+		}
+	case *ast.DeferStmt:
+		kind = "deferred closure"
+		collectClosures(s.Call)
+	case *ast.VarDeclStmt:
+		collectClosures(s.Values...)
+	case *ast.AssignStmt:
+		collectClosures(s.Values...)
+	case *ast.ExpressionStmt:
+		collectClosures(s.Expression)
+	case *ast.SendStmt:
+		collectClosures(s.Value)
+	default:
+		return
+	}
+	if len(bodies) == 0 {
+		return
+	}
+
+	// Names declared inside the closure shadow outer ones.
+	local := make(map[string]bool)
+	for _, p := range params {
+		local[p.Name.Value] = true
+	}
+	written := make(map[string]bool)
+	for _, body := range bodies {
+		maps.Copy(local, declaredNames(body))
+		maps.Copy(written, assignedNames(body))
+	}
+	if a.block != nil {
+		if i := slices.Index(a.block.Statements, stmt); i >= 0 {
+			maps.Copy(written, assignedNamesDeep(a.block.Statements[i+1:]))
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, body := range bodies {
+		ast.WalkBlock(body, func(e ast.Expression) bool {
+			id, ok := e.(*ast.Identifier)
+			if !ok || local[id.Value] || reported[id.Value] || copied[id.Value] {
+				return false
+			}
+			sym := a.symbolTable.Resolve(id.Value)
+			if sym == nil || sym.Kind != SymbolVariable {
+				return false
+			}
+			for i := len(a.loops) - 1; i >= 0; i-- {
+				frame := a.loops[i]
+				// A statement inside a closure or go block in the loop is
+				// checked through that closure instead
+				if !frame.stmts[stmt] {
+					break
+				}
+				if frame.assigned[id.Value] && frame.outer.Resolve(id.Value) == sym {
+					reported[id.Value] = true
+					a.loopCaptures = append(a.loopCaptures, LoopCapture{Ident: id, Stmt: stmt, Kind: kind, Shared: written[id.Value]})
+					a.warn(id.Pos(), fmt.Sprintf("%s captures '%s', which is reassigned inside the enclosing loop; every iteration shares one variable — copy it before the closure with '%s := %s'", kind, id.Value, id.Value, id.Value))
+					break
+				}
+			}
+			return false
+		})
+	}
+}
+
+// declaredNames collects names introduced with := or typed var declarations
+// in block, and the error aliases of its onerr handlers.
+func declaredNames(block *ast.BlockStmt) map[string]bool {
+	names := make(map[string]bool)
+	walkStmtsAndHandlers(block, func(stmt ast.Statement) {
+		switch s := stmt.(type) {
+		case *ast.VarDeclStmt:
+			for _, n := range s.Names {
+				names[n.Value] = true
+			}
+		case *ast.ForRangeStmt:
+			names[s.Variable.Value] = true
+			if s.Index != nil {
+				names[s.Index.Value] = true
+			}
+		case *ast.ForNumericStmt:
+			names[s.Variable.Value] = true
+		}
+		if clause := onErrClauseOf(stmt); clause != nil && clause.Alias != "" {
+			names[clause.Alias] = true
+		}
+	})
+	return names
+}
+
+// Capture is a variable a closure or go block uses from its enclosing
+// function. ByValue marks go-block captures that codegen passes to the
+// goroutine as arguments, so it keeps the value from when it started.
+type Capture struct {
+	Name    string
+	Type    *TypeInfo
+	ByValue bool
+}
+
+// Captures returns the variables each closure captures, keyed by the
+// *ast.FunctionLiteral, *ast.ArrowLambda or block-form *ast.GoStmt.
+// Call after Analyze() to pass these to codegen via SetCaptures.
+func (a *Analyzer) Captures() map[ast.Node][]Capture {
+	return a.captures
+}
+
+// recordCaptures computes the free variables of a closure body: names that
+// resolve, from the current scope, to a local variable or parameter of an
+// enclosing function. Call before entering the closure's own scope.
+// Package-level variables are not captures.
+func (a *Analyzer) recordCaptures(node ast.Node, params []*ast.Parameter, body *ast.BlockStmt) {
+	if body == nil {
+		return
+	}
+	local := declaredNames(body)
+	for _, p := range params {
+		local[p.Name.Value] = true
+	}
+	var captures []Capture
+	seen := make(map[string]bool)
+	ast.WalkBlock(body, func(e ast.Expression) bool {
+		switch fn := e.(type) {
+		case *ast.FunctionLiteral:
+			for _, p := range fn.Parameters {
+				local[p.Name.Value] = true
+			}
+			for name := range declaredNames(fn.Body) {
+				local[name] = true
+			}
+		case *ast.ArrowLambda:
+			for _, p := range fn.Parameters {
+				local[p.Name.Value] = true
+			}
+			for name := range declaredNames(fn.Block) {
+				local[name] = true
+			}
+		case *ast.Identifier:
+			if local[fn.Value] || seen[fn.Value] {
+				return false
+			}
+			sym := a.symbolTable.Resolve(fn.Value)
+			if sym == nil || (sym.Kind != SymbolVariable && sym.Kind != SymbolParameter) || a.symbolTable.IsGlobal(sym) {
+				return false
+			}
+			seen[fn.Value] = true
+			captures = append(captures, Capture{Name: fn.Value, Type: sym.Type})
+		}
+		return false
+	})
+	if goStmt, ok := node.(*ast.GoStmt); ok {
+		a.markCopiedCaptures(goStmt.Block, captures)
+	}
+	if a.captures == nil {
+		a.captures = make(map[ast.Node][]Capture)
+	}
+	a.captures[node] = captures
+}
+
+// markCopiedCaptures sets ByValue on the go-block captures that are
+// reassigned by an enclosing loop, so each goroutine gets the value of its
+// own iteration. A capture the block itself writes or takes the address of,
+// or whose type is a struct (its methods may mutate it), stays shared.
+func (a *Analyzer) markCopiedCaptures(block *ast.BlockStmt, captures []Capture) {
+	if len(a.loops) == 0 {
+		return
+	}
+	written := assignedNames(block)
+	ast.WalkBlock(block, func(e ast.Expression) bool {
+		if addr, ok := e.(*ast.AddressOfExpr); ok {
+			if id, ok := addr.Operand.(*ast.Identifier); ok {
+				written[id.Value] = true
+			}
+		}
+		return false
+	})
+	for i := range captures {
+		c := &captures[i]
+		if written[c.Name] || !copyableCapture(c.Type) {
+			continue
+		}
+		sym := a.symbolTable.Resolve(c.Name)
+		for _, frame := range a.loops {
+			if frame.assigned[c.Name] && frame.outer.Resolve(c.Name) == sym {
+				c.ByValue = true
+				break
+			}
+		}
+	}
+}
+
+// copyableCapture reports whether a captured variable of type t can be
+// copied into a goroutine without changing what the goroutine sees: its
+// Go type is known and it is not a struct value.
+func copyableCapture(t *TypeInfo) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Kind {
+	case TypeKindInt, TypeKindFloat, TypeKindString, TypeKindBool,
+		TypeKindList, TypeKindMap, TypeKindChannel, TypeKindReference:
+		return true
+	}
+	return false
+}
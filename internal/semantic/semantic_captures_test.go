@@ -0,0 +1,261 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+func TestGoroutineCapturingReassignedOuterVariableWarns(t *testing.T) {
+	input := `type Job
+    name string
+
+func main()
+    current := Job{}
+    for name in ["a", "b"]
+        current = Job{name: name}
+        go
+            print(current.name)
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 capture warning, got %d: %v", len(warnings), warnings)
+	}
+	msg := warnings[0].Error()
+	if !strings.Contains(msg, "goroutine captures 'current'") || !strings.Contains(msg, "current := current") {
+		t.Errorf("unexpected warning: %s", msg)
+	}
+}
+
+func TestGoroutineWritingReassignedOuterVariableWarns(t *testing.T) {
+	input := `func main()
+    total := 0
+    for i from 0 to 3
+        total = total + i
+        go
+            total = 0
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "goroutine captures 'total'") {
+		t.Errorf("a go block that writes the variable shares it; expected a warning, got: %v", warnings)
+	}
+}
+
+func TestGoroutineCapturingReassignedOuterVariableCopied(t *testing.T) {
+	input := `func main()
+    current := ""
+    count := 0
+    for name in ["a", "b"]
+        current = name
+        go
+            print("{current} {count} {name}")
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if warnings := analyzer.Warnings(); len(warnings) != 0 {
+		t.Errorf("copied captures need no warning, got: %v", warnings)
+	}
+	var captures []Capture
+	for node, cs := range analyzer.Captures() {
+		if _, ok := node.(*ast.GoStmt); ok {
+			captures = cs
+		}
+	}
+	if len(captures) != 3 {
+		t.Fatalf("expected captures current, count, name; got %+v", captures)
+	}
+	want := map[string]bool{"current": true, "count": false, "name": false}
+	for _, c := range captures {
+		byValue, ok := want[c.Name]
+		if !ok || c.ByValue != byValue {
+			t.Errorf("capture %s: ByValue = %v", c.Name, c.ByValue)
+		}
+		if c.Type == nil || c.Type.Kind == TypeKindUnknown {
+			t.Errorf("capture %s has no type", c.Name)
+		}
+	}
+}
+
+func TestLambdaCapturesExcludeParamsAndGlobals(t *testing.T) {
+	input := `import "stdlib/slice"
+
+var limit = 3
+
+func main()
+    prefix := "x"
+    names := ["a", "b"]
+    print(names |> slice.Map((n string) => "{prefix}{n}{limit}"))
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for node, cs := range analyzer.Captures() {
+		if _, ok := node.(*ast.ArrowLambda); !ok {
+			continue
+		}
+		if len(cs) != 1 || cs[0].Name != "prefix" || cs[0].ByValue {
+			t.Errorf("expected the lambda to share prefix only, got %+v", cs)
+		}
+		return
+	}
+	t.Fatal("no captures recorded for the lambda")
+}
+
+func TestDeferredClosureInLoopWarns(t *testing.T) {
+	input := `func main()
+    count := 0
+    for i from 0 to 3
+        count++
+        defer func()
+            print("{count}")
+        ()
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "deferred closure captures 'count'") {
+		t.Errorf("expected deferred closure capture warning, got: %v", warnings)
+	}
+}
+
+func TestGoroutineCapturingLoopVariableNoWarn(t *testing.T) {
+	input := `func main()
+    for name in ["a", "b"]
+        go
+            print(name)
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 0 {
+		t.Errorf("loop variables are per-iteration; expected no warnings, got: %v", warnings)
+	}
+}
+
+func TestMutexAccumulatorInGoBlockNoWarn(t *testing.T) {
+	// total is only assigned by the goroutines, under the lock; the loop
+	// itself never reassigns it, and a copy would lose the sum
+	input := `import "sync"
+
+func main()
+    mu := sync.Mutex{}
+    wg := sync.WaitGroup{}
+    total := 0
+    for x in list of int{1, 2, 3}
+        wg.Add(1)
+        go
+            defer wg.Done()
+            mu.Lock()
+            total = total + x
+            mu.Unlock()
+    wg.Wait()
+    print(total)
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if warnings := analyzer.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got: %v", warnings)
+	}
+	if captures := analyzer.LoopCaptures(); len(captures) != 0 {
+		t.Errorf("expected no loop captures, got: %+v", captures)
+	}
+}
+
+func TestGoroutineCapturingShadowedCopyNoWarn(t *testing.T) {
+	input := `func main()
+    current := ""
+    for name in ["a", "b"]
+        current = name
+        snapshot := current
+        go
+            print(snapshot)
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings for per-iteration copy, got: %v", warnings)
+	}
+}
+
+func TestStoredAndPassedLambdasInLoopWarn(t *testing.T) {
+	input := `func later(f func())
+    f()
+
+func main()
+    handlers := empty list of func()
+    label := ""
+    for name in ["a", "b"]
+        label = name
+        handlers = append(handlers, () => print(label))
+        later(() => print(label))
+    for h in handlers
+        h()
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 capture warnings, got %d: %v", len(warnings), warnings)
+	}
+	for _, w := range warnings {
+		if !strings.Contains(w.Error(), "closure captures 'label'") {
+			t.Errorf("unexpected warning: %s", w)
+		}
+	}
+}
+
+func TestOnErrHandlerAssignmentInLoopWarns(t *testing.T) {
+	input := `import "strconv"
+
+func main()
+    handlers := empty list of func()
+    last := ""
+    for s in ["1", "x"]
+        n := strconv.Atoi(s) onerr as e
+            last = "{s}: {e}"
+            continue
+        handlers = append(handlers, () => print(last, n))
+    for h in handlers
+        h()
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	warnings := analyzer.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "closure captures 'last'") {
+		t.Fatalf("expected a warning for last, assigned in the onerr handler; got: %v", warnings)
+	}
+	captures := analyzer.LoopCaptures()
+	if len(captures) != 1 || captures[0].Ident.Value != "last" || captures[0].Shared {
+		t.Errorf("unexpected loop captures: %+v", captures)
+	}
+}
+
+func TestClosureInsideGoBlockInLoopWarnsOnce(t *testing.T) {
+	input := `type Job
+    name string
+
+func main()
+    current := Job{}
+    for name in ["a", "b"]
+        current = Job{name: name}
+        go
+            show := () => print(current.name)
+            show()
+        current = Job{}
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if warnings := analyzer.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "goroutine captures 'current'") {
+		t.Fatalf("expected one goroutine warning, got: %v", warnings)
+	}
+	// current is assigned after the go statement, which a copy would take over
+	if captures := analyzer.LoopCaptures(); len(captures) != 1 || !captures[0].Shared {
+		t.Errorf("expected one shared loop capture, got: %+v", captures)
+	}
+}
@@ -0,0 +1,406 @@
+package semantic
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"math"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// constValue evaluates expr when it is a numeric constant expression: number
+// literals, unary minus, + - * / % between constants, and names of top-level
+// consts. Integer division truncates as in Go.
+func (a *Analyzer) constValue(expr ast.Expression) (constant.Value, bool) {
+	v, ok := a.evalConst(expr, 0)
+	if !ok || (v.Kind() != constant.Int && v.Kind() != constant.Float) {
+		return nil, false
+	}
+	return v, true
+}
+
+// Constants returns the constant expressions the analyzer folded, for codegen
+// to emit as literals. Call after Analyze() to pass these to codegen via
+// SetConstants.
+func (a *Analyzer) Constants() map[ast.Expression]constant.Value {
+	return a.constants
+}
+
+// maxFoldedString bounds the strings folding may produce (strings.Repeat,
+// strings.ReplaceAll), so a constant cannot bloat the generated code.
+const maxFoldedString = 256
+
+// literalBraces turns the lexer's sentinels for \{ and \} back into braces.
+var literalBraces = strings.NewReplacer("\uE000", "{", "\uE001", "}")
+
+// recordConstant folds expr when it is a constant expression that calls len
+// of a constant string or a pure strings function (stdlib/string too), or
+// that concatenates string literals. Codegen then emits the value as a
+// literal, which a const declaration needs for the calls Go does not
+// evaluate itself. Arithmetic without calls is left as written: Go folds it
+// anyway, and 7 * 24 * time.Hour says more than 168 * time.Hour. Test files
+// only fold const declarations, so a test of strings.ToUpper("abc") still
+// calls it.
+func (a *Analyzer) recordConstant(expr ast.Expression) {
+	switch expr.(type) {
+	case *ast.BinaryExpr, *ast.CallExpr, *ast.MethodCallExpr, *ast.PipeExpr:
+	default:
+		return
+	}
+	if !a.inConstDecl && strings.HasSuffix(a.sourceFile, "_test.kuki") {
+		return
+	}
+	v, ok := a.evalConst(expr, 0)
+	if !ok || v.Kind() == constant.Float || v.Kind() == constant.Unknown {
+		return
+	}
+	hasCall := ast.WalkExpr(expr, func(e ast.Expression) bool {
+		switch e.(type) {
+		case *ast.CallExpr, *ast.MethodCallExpr:
+			return true
+		}
+		return false
+	})
+	hasName := ast.WalkExpr(expr, func(e ast.Expression) bool {
+		_, ok := e.(*ast.Identifier)
+		return ok
+	})
+	if hasCall || (v.Kind() == constant.String && !hasName) {
+		a.constants[expr] = v
+	}
+}
+
+// maxConstDepth bounds const-to-const references, so a cycle (which Go
+// reports) cannot recurse forever here.
+const maxConstDepth = 32
+
+func (a *Analyzer) evalConst(expr ast.Expression, depth int) (constant.Value, bool) {
+	if depth > maxConstDepth {
+		return nil, false
+	}
+	switch e := expr.(type) {
+	case *ast.IntegerLiteral:
+		v := constant.MakeFromLiteral(e.Token.Lexeme, token.INT, 0)
+		return v, v.Kind() == constant.Int
+	case *ast.FloatLiteral:
+		v := constant.MakeFromLiteral(e.Token.Lexeme, token.FLOAT, 0)
+		return v, v.Kind() == constant.Float || v.Kind() == constant.Int
+	case *ast.StringLiteral:
+		// \sep (U+E002) becomes the OS path separator at run time
+		if e.Interpolated || strings.ContainsRune(e.Value, '\uE002') {
+			return nil, false
+		}
+		return constant.MakeString(literalBraces.Replace(e.Value)), true
+	case *ast.CallExpr:
+		fn, ok := e.Function.(*ast.Identifier)
+		if !ok || fn.Value != "len" || len(e.Arguments) != 1 || a.symbolTable.Resolve("len") != nil {
+			return nil, false
+		}
+		v, ok := a.evalConst(e.Arguments[0], depth+1)
+		if !ok || v.Kind() != constant.String {
+			return nil, false
+		}
+		return constant.MakeInt64(int64(len(constant.StringVal(v)))), true
+	case *ast.MethodCallExpr:
+		return a.evalStringsCall(e, nil, depth)
+	case *ast.PipeExpr:
+		call, ok := e.Right.(*ast.MethodCallExpr)
+		if !ok {
+			return nil, false
+		}
+		return a.evalStringsCall(call, e.Left, depth)
+	case *ast.UnaryExpr:
+		if e.Operator != "-" {
+			return nil, false
+		}
+		v, ok := a.evalConst(e.Right, depth+1)
+		if !ok {
+			return nil, false
+		}
+		return constant.UnaryOp(token.SUB, v, 0), true
+	case *ast.BinaryExpr:
+		left, ok := a.evalConst(e.Left, depth+1)
+		if !ok {
+			return nil, false
+		}
+		right, ok := a.evalConst(e.Right, depth+1)
+		if !ok {
+			return nil, false
+		}
+		return binaryConst(e.Operator, left, right)
+	case *ast.Identifier:
+		value, ok := a.constExprs[e.Value]
+		if !ok {
+			return nil, false
+		}
+		// A local variable or parameter may shadow the const
+		if sym := a.symbolTable.Resolve(e.Value); sym == nil || sym.Kind != SymbolConst {
+			return nil, false
+		}
+		return a.evalConst(value, depth+1)
+	}
+	return nil, false
+}
+
+// evalStringsCall evaluates a call to a pure function of Go's strings
+// package or stdlib/string whose arguments are constants. piped is the value
+// piped into the call, or nil; it fills a _ placeholder or comes first.
+func (a *Analyzer) evalStringsCall(call *ast.MethodCallExpr, piped ast.Expression, depth int) (constant.Value, bool) {
+	pkg, ok := a.packageOf(call.Object)
+	if !ok || (pkg != ast.ImportName(a.program, "strings") && pkg != ast.ImportName(a.program, "stdlib/string")) {
+		return nil, false
+	}
+	fold, ok := stringFolds[call.Method.Value]
+	if !ok || (call.Method.Value == "Len" && pkg != ast.ImportName(a.program, "stdlib/string")) {
+		return nil, false
+	}
+	exprs := call.Arguments
+	if piped != nil {
+		exprs = nil
+		placed := false
+		for _, arg := range call.Arguments {
+			if id, ok := arg.(*ast.Identifier); ok && id.Value == "_" && !placed {
+				arg, placed = piped, true
+			}
+			exprs = append(exprs, arg)
+		}
+		if !placed {
+			exprs = append([]ast.Expression{piped}, exprs...)
+		}
+	}
+	if len(exprs) != len(fold.params) {
+		return nil, false
+	}
+	args := make([]constant.Value, len(exprs))
+	for i, arg := range exprs {
+		v, ok := a.evalConst(arg, depth+1)
+		if !ok || v.Kind() != fold.params[i] {
+			return nil, false
+		}
+		args[i] = v
+	}
+	return fold.eval(args)
+}
+
+// stringFold is a pure strings function codegen may evaluate at compile time.
+type stringFold struct {
+	params []constant.Kind
+	eval   func(args []constant.Value) (constant.Value, bool)
+}
+
+// stringFolds are the functions that Go's strings package and stdlib/string
+// both provide with the same meaning, by name, plus stdlib/string's Len.
+var stringFolds = map[string]stringFold{
+	"ToUpper":    stringToString(strings.ToUpper),
+	"ToLower":    stringToString(strings.ToLower),
+	"TrimSpace":  stringToString(strings.TrimSpace),
+	"Trim":       stringsToString(strings.Trim),
+	"TrimPrefix": stringsToString(strings.TrimPrefix),
+	"TrimSuffix": stringsToString(strings.TrimSuffix),
+	"ReplaceAll": {
+		params: []constant.Kind{constant.String, constant.String, constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			s := strings.ReplaceAll(constant.StringVal(args[0]), constant.StringVal(args[1]), constant.StringVal(args[2]))
+			return constant.MakeString(s), len(s) <= maxFoldedString
+		},
+	},
+	"Repeat": {
+		params: []constant.Kind{constant.String, constant.Int},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			s := constant.StringVal(args[0])
+			n, exact := constant.Int64Val(args[1])
+			if !exact || n < 0 || int64(len(s))*n > maxFoldedString {
+				return nil, false
+			}
+			return constant.MakeString(strings.Repeat(s, int(n))), true
+		},
+	},
+	"Contains":  stringsToBool(strings.Contains),
+	"HasPrefix": stringsToBool(strings.HasPrefix),
+	"HasSuffix": stringsToBool(strings.HasSuffix),
+	"Index":     stringsToInt(strings.Index),
+	"Count":     stringsToInt(strings.Count),
+	// stdlib/string only
+	"Len": {
+		params: []constant.Kind{constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			return constant.MakeInt64(int64(len(constant.StringVal(args[0])))), true
+		},
+	},
+}
+
+func stringToString(f func(string) string) stringFold {
+	return stringFold{
+		params: []constant.Kind{constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			return constant.MakeString(f(constant.StringVal(args[0]))), true
+		},
+	}
+}
+
+func stringsToString(f func(string, string) string) stringFold {
+	return stringFold{
+		params: []constant.Kind{constant.String, constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			return constant.MakeString(f(constant.StringVal(args[0]), constant.StringVal(args[1]))), true
+		},
+	}
+}
+
+func stringsToBool(f func(string, string) bool) stringFold {
+	return stringFold{
+		params: []constant.Kind{constant.String, constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			return constant.MakeBool(f(constant.StringVal(args[0]), constant.StringVal(args[1]))), true
+		},
+	}
+}
+
+func stringsToInt(f func(string, string) int) stringFold {
+	return stringFold{
+		params: []constant.Kind{constant.String, constant.String},
+		eval: func(args []constant.Value) (constant.Value, bool) {
+			return constant.MakeInt64(int64(f(constant.StringVal(args[0]), constant.StringVal(args[1])))), true
+		},
+	}
+}
+
+// binaryConst applies an arithmetic operator to two constants, or + to two
+// strings.
+func binaryConst(op string, left, right constant.Value) (constant.Value, bool) {
+	if left.Kind() == constant.String || right.Kind() == constant.String {
+		if op != "+" || left.Kind() != right.Kind() {
+			return nil, false
+		}
+		return constant.BinaryOp(left, token.ADD, right), true
+	}
+	if left.Kind() == constant.Bool || right.Kind() == constant.Bool {
+		return nil, false
+	}
+	bothInt := left.Kind() == constant.Int && right.Kind() == constant.Int
+	switch op {
+	case "+":
+		return constant.BinaryOp(left, token.ADD, right), true
+	case "-":
+		return constant.BinaryOp(left, token.SUB, right), true
+	case "*":
+		return constant.BinaryOp(left, token.MUL, right), true
+	case "/":
+		if constant.Sign(right) == 0 {
+			return nil, false
+		}
+		if bothInt {
+			return constant.BinaryOp(left, token.QUO_ASSIGN, right), true // truncated
+		}
+		return constant.BinaryOp(left, token.QUO, right), true
+	case "%":
+		if !bothInt || constant.Sign(right) == 0 {
+			return nil, false
+		}
+		return constant.BinaryOp(left, token.REM, right), true
+	}
+	return nil, false
+}
+
+// intRanges gives the bounds of each sized integer type (int and uint are
+// taken as 64 bits, as on every platform Kukicha targets).
+var intRanges = map[string][2]constant.Value{
+	"int8":   intRange(math.MinInt8, math.MaxInt8),
+	"int16":  intRange(math.MinInt16, math.MaxInt16),
+	"int32":  intRange(math.MinInt32, math.MaxInt32),
+	"rune":   intRange(math.MinInt32, math.MaxInt32),
+	"int64":  intRange(math.MinInt64, math.MaxInt64),
+	"int":    intRange(math.MinInt64, math.MaxInt64),
+	"uint8":  intRange(0, math.MaxUint8),
+	"byte":   intRange(0, math.MaxUint8),
+	"uint16": intRange(0, math.MaxUint16),
+	"uint32": intRange(0, math.MaxUint32),
+	"uint64": {constant.MakeInt64(0), constant.MakeUint64(math.MaxUint64)},
+	"uint":   {constant.MakeInt64(0), constant.MakeUint64(math.MaxUint64)},
+}
+
+func intRange(lo, hi int64) [2]constant.Value {
+	return [2]constant.Value{constant.MakeInt64(lo), constant.MakeInt64(hi)}
+}
+
+// checkNegatedUnsigned reports -1 as uint, which parses as -(1 as uint):
+// the negation of a positive unsigned constant overflows.
+func (a *Analyzer) checkNegatedUnsigned(expr *ast.UnaryExpr, operand *TypeInfo) {
+	cast, ok := expr.Right.(*ast.TypeCastExpr)
+	if !ok || operand.Kind != TypeKindInt {
+		return
+	}
+	bounds, ok := intRanges[operand.Name]
+	if !ok || constant.Sign(bounds[0]) != 0 {
+		return
+	}
+	v, ok := a.constValue(cast.Expression)
+	if !ok || constant.Sign(v) <= 0 {
+		return
+	}
+	a.errorMsg(expr.Pos(), catalog.ConstantOverflows, constant.UnaryOp(token.SUB, v, 0), operand.Name, bounds[0], bounds[1])
+}
+
+// checkConstantConversion reports an `as` conversion of a constant that
+// loses its value. Go rejects a constant that overflows the target type or
+// has a fraction converted to an integer type, so those are errors here with
+// the range spelled out; an integer constant that a float type can only round
+// is accepted by Go and gets a warning.
+func (a *Analyzer) checkConstantConversion(cast *ast.TypeCastExpr, target *TypeInfo) {
+	if target.Kind != TypeKindInt && target.Kind != TypeKindFloat {
+		return
+	}
+	v, ok := a.constValue(cast.Expression)
+	if !ok {
+		return
+	}
+	typeName := target.Name
+	if typeName == "" {
+		typeName = "int"
+		if target.Kind == TypeKindFloat {
+			typeName = "float64"
+		}
+	}
+
+	if target.Kind == TypeKindInt {
+		bounds, ok := intRanges[typeName]
+		if !ok {
+			return
+		}
+		if iv := constant.ToInt(v); iv.Kind() == constant.Int {
+			v = iv
+		} else {
+			a.errorMsg(cast.Pos(), catalog.ConstantTruncated, v, typeName)
+			return
+		}
+		if constant.Compare(v, token.LSS, bounds[0]) || constant.Compare(v, token.GTR, bounds[1]) {
+			a.errorMsg(cast.Pos(), catalog.ConstantOverflows, v, typeName, bounds[0], bounds[1])
+		}
+		return
+	}
+
+	if typeName == "float32" {
+		if f, _ := constant.Float64Val(v); math.IsInf(float64(float32(f)), 0) {
+			a.errorMsg(cast.Pos(), catalog.ConstantOverflows, v, typeName, -math.MaxFloat32, math.MaxFloat32)
+			return
+		}
+	}
+	if v.Kind() != constant.Int {
+		return
+	}
+	var rounded constant.Value
+	if typeName == "float32" {
+		f, _ := constant.Float32Val(v)
+		rounded = constant.MakeFloat64(float64(f))
+	} else {
+		f, _ := constant.Float64Val(v)
+		rounded = constant.MakeFloat64(f)
+	}
+	if constant.Compare(rounded, token.NEQ, v) {
+		a.warn(cast.Pos(), fmt.Sprintf("%s as %s rounds to %s: %s cannot hold every integer of this size",
+			v, typeName, constant.ToInt(rounded), typeName))
+	}
+}
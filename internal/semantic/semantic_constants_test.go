@@ -0,0 +1,147 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+func TestLossyConstantConversionErrors(t *testing.T) {
+	input := `const Limit = 250 + 10
+
+func main()
+    a := 300 as uint8
+    b := -1 as uint
+    c := 3.7 as int
+    d := Limit as byte
+    e := 1e39 as float32
+    print(a, b, c, d, e)
+`
+	_, errors := analyzeSource(t, input)
+
+	want := []string{
+		"test.kuki:4:13: constant 300 overflows uint8 (range 0 to 255)",
+		"test.kuki:5:9: constant -1 overflows uint (range 0 to 18446744073709551615)",
+		"test.kuki:6:13: constant 3.7 is not a whole number; 'as int' would drop the fraction (round it first, e.g. with math.Round)",
+		"test.kuki:7:15: constant 260 overflows byte (range 0 to 255)",
+		"test.kuki:8:14: constant 1e+39 overflows float32 (range -3.4028234663852886e+38 to 3.4028234663852886e+38)",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errors), errors)
+	}
+	for i, err := range errors {
+		if err.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, err.Error(), want[i])
+		}
+	}
+}
+
+func TestExactConstantConversionsAllowed(t *testing.T) {
+	input := `const Limit = 250
+
+func main(n int)
+    a := 255 as uint8
+    b := 3.0 as int
+    c := Limit as byte
+    d := (100 / 3) as int8
+    e := (-128) as int8
+    f := n as uint8
+    Limit := 1000
+    g := Limit as uint8
+    print(a, b, c, d, e, f, g)
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) != 0 {
+		t.Fatalf("unexpected errors: %v", errors)
+	}
+}
+
+func TestRoundingConstantConversionWarns(t *testing.T) {
+	input := `func main()
+    a := 16777217 as float32
+    b := 16777216 as float32
+    print(a, b)
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "app.kuki:2:18: 16777217 as float32 rounds to 16777216") {
+		t.Fatalf("expected one rounding warning, got: %v", warnings)
+	}
+}
+
+func TestConstantFolding(t *testing.T) {
+	input := `import "strings"
+import "stdlib/string"
+
+const Upper = strings.ToUpper("abc")
+const Width = len("hello") * 2
+const Greeting = "hello, " + "world"
+const Day = 60 * 60 * 24
+const Slug = "  Go Kukicha " |> strings.TrimSpace() |> strings.ReplaceAll(" ", "-")
+const Size = string.Len("four")
+
+func main(s string)
+    strings := list of string{"abc"}
+    print(len(strings), strings.ToUpper(s), strings)
+`
+	a, errors := analyzeSource(t, input)
+	if len(errors) != 0 {
+		t.Fatalf("unexpected errors: %v", errors)
+	}
+	want := map[string]string{
+		"Upper":    `"ABC"`,
+		"Width":    "10",
+		"Greeting": `"hello, world"`,
+		"Slug":     `"Go-Kukicha"`,
+		"Size":     "4",
+	}
+	folded := map[string]string{}
+	for _, decl := range a.program.Declarations {
+		if d, ok := decl.(*ast.ConstDecl); ok {
+			for _, spec := range d.Specs {
+				if v, ok := a.Constants()[spec.Value]; ok {
+					folded[spec.Name.Value] = v.ExactString()
+				}
+			}
+		}
+	}
+	if len(folded) != len(want) {
+		t.Errorf("folded %v, want %v", folded, want)
+	}
+	for name, value := range want {
+		if folded[name] != value {
+			t.Errorf("%s folded to %s, want %s", name, folded[name], value)
+		}
+	}
+	// Besides the consts: len("hello") and Slug's first step. Nothing in
+	// main folds, where strings is a local list.
+	if len(a.Constants()) != len(want)+2 {
+		t.Errorf("expected %d folded expressions, got %d", len(want)+2, len(a.Constants()))
+	}
+}
+
+func TestConstantFoldingLimits(t *testing.T) {
+	input := `import "strings"
+
+const Braces = "\{" + "x\}"
+const Rule = strings.Repeat("=", 300)
+
+func main(s string)
+    print(strings.ToUpper("abc"), Braces, Rule)
+`
+	a, errors := analyzeSourceWithFile(t, input, "app_test.kuki")
+	if len(errors) != 0 {
+		t.Fatalf("unexpected errors: %v", errors)
+	}
+	var folded []string
+	for _, v := range a.Constants() {
+		folded = append(folded, v.ExactString())
+	}
+	// Too long to fold: Rule. In a test file: the call in main.
+	if len(folded) != 1 || folded[0] != `"{x}"` {
+		t.Errorf("expected only Braces folded, to \"{x}\", got %v", folded)
+	}
+}
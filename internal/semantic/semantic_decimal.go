@@ -0,0 +1,57 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// decimalTypeName is the type of a decimal literal (19.99d), the Decimal of
+// stdlib/decimal. Its operator methods make + - * and comparisons exact.
+const decimalTypeName = "decimal.Decimal"
+
+// IsDecimal reports whether t is decimal.Decimal, whose negation codegen
+// lowers to a Neg call.
+func IsDecimal(t *TypeInfo) bool {
+	return t != nil && t.Kind == TypeKindNamed && t.Name == decimalTypeName
+}
+
+// decimalOperands reports an operator that mixes a Decimal with a float or
+// an int (price * 1.1): the number would go through a float, and the point
+// of a Decimal is that it never does. It returns the type to continue with,
+// or nil when the operands don't mix the two.
+func (a *Analyzer) decimalOperands(expr *ast.BinaryExpr, left, right *TypeInfo) *TypeInfo {
+	base, ok := operatorBase[expr.Operator]
+	if !ok {
+		return nil
+	}
+	number := right
+	if IsDecimal(right) {
+		number = left
+	} else if !IsDecimal(left) {
+		return nil
+	}
+	if number.Kind != TypeKindFloat && number.Kind != TypeKindInt {
+		return nil
+	}
+	a.errorMsg(expr.Pos(), catalog.DecimalMix, number, expr.Operator)
+	if base == "==" || base == "<" {
+		return &TypeInfo{Kind: TypeKindBool}
+	}
+	return &TypeInfo{Kind: TypeKindNamed, Name: decimalTypeName}
+}
+
+// qualifyOperandTypes qualifies the registry's unqualified type names in the
+// results of stdlib package pkg ("Decimal" for decimal.Decimal) when the type
+// has operator methods, so an operator on a returned value finds them.
+func qualifyOperandTypes(types []*TypeInfo, pkg string) {
+	for _, ti := range types {
+		if ti == nil || ti.Kind != TypeKindNamed || strings.Contains(ti.Name, ".") {
+			continue
+		}
+		if _, ok := generatedStdlibOperators[pkg+"."+ti.Name]; ok {
+			ti.Name = pkg + "." + ti.Name
+		}
+	}
+}
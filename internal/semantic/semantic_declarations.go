@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 func (a *Analyzer) checkPackageName() {
@@ -34,7 +35,7 @@ func (a *Analyzer) checkPackageName() {
 	}
 
 	if reservedPackages[name] {
-		a.error(a.program.PetioleDecl.Pos(), fmt.Sprintf("package name '%s' conflicts with Go standard library package", name))
+		a.errorMsg(a.program.PetioleDecl.Pos(), catalog.PackageNameConflict, name)
 	}
 }
 
@@ -46,23 +47,23 @@ func (a *Analyzer) checkSkillDecl() {
 
 	// Skill name must be exported (start with uppercase)
 	if skill.Name != nil && !isExported(skill.Name.Value) {
-		a.error(skill.Name.Pos(), fmt.Sprintf("skill name '%s' must be exported (start with uppercase letter)", skill.Name.Value))
+		a.errorMsg(skill.Name.Pos(), catalog.SkillNameNotExported, skill.Name.Value)
 	}
 
 	// Skill requires petiole (skills are packages, not main programs)
 	if a.program.PetioleDecl == nil {
-		a.error(skill.Pos(), "skill declaration requires a petiole declaration (skills are packages)")
+		a.errorMsg(skill.Pos(), catalog.SkillNeedsPetiole)
 	}
 
 	// Warn if description is empty
 	if skill.Description == "" {
-		a.error(skill.Pos(), "skill should have a description (skills should be self-documenting)")
+		a.errorMsg(skill.Pos(), catalog.SkillNeedsDescription)
 	}
 
 	// Basic semver validation if version is provided
 	if skill.Version != "" {
 		if !isBasicSemver(skill.Version) {
-			a.error(skill.Pos(), fmt.Sprintf("skill version '%s' should follow semver format (e.g., '1.0.0')", skill.Version))
+			a.errorMsg(skill.Pos(), catalog.SkillVersionNotSemver, skill.Version)
 		}
 	}
 }
@@ -102,6 +103,14 @@ func (a *Analyzer) collectDeclarations() {
 		if err != nil {
 			a.error(imp.Pos(), err.Error())
 		}
+		// A stdlib package shadows the Go package of the same name (stdlib/math
+		// vs math) in registry lookups
+		if strings.HasPrefix(strings.Trim(imp.Path.Value, "\""), "stdlib/") {
+			if a.stdlibImports == nil {
+				a.stdlibImports = make(map[string]bool)
+			}
+			a.stdlibImports[name] = true
+		}
 		// Track aliased imports so registry lookups can resolve aliases
 		if imp.Alias != nil {
 			baseName := extractBasePackageName(imp)
@@ -112,26 +121,42 @@ func (a *Analyzer) collectDeclarations() {
 				a.importAliases[name] = baseName
 			}
 		}
+		a.importPetiole(imp, name)
+	}
+
+	// Aliases first, so `x Meters` resolves to the underlying type wherever
+	// Meters is declared
+	for _, decl := range a.program.Declarations {
+		if d, ok := decl.(*ast.TypeDecl); ok && d.AliasType != nil {
+			a.collectTypeDecl(d)
+		}
 	}
 
 	for _, decl := range a.program.Declarations {
 		switch d := decl.(type) {
 		case *ast.TypeDecl:
-			a.collectTypeDecl(d)
+			if d.AliasType == nil {
+				a.collectTypeDecl(d)
+			}
 		case *ast.InterfaceDecl:
 			a.collectInterfaceDecl(d)
 		case *ast.FunctionDecl:
 			a.collectFunctionDecl(d)
 		case *ast.ConstDecl:
 			a.collectConstDecl(d)
+		case *ast.ErrorDecl:
+			a.collectErrorDecl(d)
 		}
 	}
+
+	a.collectDerives()
+	a.collectOperators()
 }
 
 func (a *Analyzer) collectConstDecl(decl *ast.ConstDecl) {
 	for _, spec := range decl.Specs {
 		if !isValidIdentifier(spec.Name.Value) {
-			a.error(spec.Name.Pos(), fmt.Sprintf("invalid const name '%s'", spec.Name.Value))
+			a.errorMsg(spec.Name.Pos(), catalog.InvalidConstName, spec.Name.Value)
 			continue
 		}
 		err := a.symbolTable.Define(&Symbol{
@@ -140,6 +165,32 @@ func (a *Analyzer) collectConstDecl(decl *ast.ConstDecl) {
 			Type:    &TypeInfo{Kind: TypeKindUnknown},
 			Defined: spec.Name.Pos(),
 		})
+		if err != nil {
+			a.error(spec.Name.Pos(), err.Error())
+			continue
+		}
+		a.constExprs[spec.Name.Value] = spec.Value
+	}
+}
+
+// collectErrorDecl defines each sentinel error as a package-level variable of
+// type error, so functions declared earlier in the file can return it.
+func (a *Analyzer) collectErrorDecl(decl *ast.ErrorDecl) {
+	for _, spec := range decl.Specs {
+		if !isValidIdentifier(spec.Name.Value) {
+			a.errorMsg(spec.Name.Pos(), catalog.InvalidErrorName, spec.Name.Value)
+			continue
+		}
+		if spec.Message.Value == "" {
+			a.errorMsg(spec.Message.Pos(), catalog.SentinelErrorNeedsMessage, spec.Name.Value)
+		}
+		err := a.symbolTable.Define(&Symbol{
+			Name:     spec.Name.Value,
+			Kind:     SymbolVariable,
+			Type:     &TypeInfo{Kind: TypeKindNamed, Name: "error"},
+			Defined:  spec.Name.Pos(),
+			Exported: isExported(spec.Name.Value),
+		})
 		if err != nil {
 			a.error(spec.Name.Pos(), err.Error())
 		}
@@ -149,7 +200,7 @@ func (a *Analyzer) collectConstDecl(decl *ast.ConstDecl) {
 func (a *Analyzer) collectTypeDecl(decl *ast.TypeDecl) {
 	// Check export rules: PascalCase = exported, camelCase = unexported
 	if !isValidIdentifier(decl.Name.Value) {
-		a.error(decl.Name.Pos(), fmt.Sprintf("invalid type name '%s'", decl.Name.Value))
+		a.errorMsg(decl.Name.Pos(), catalog.InvalidTypeName, decl.Name.Value)
 		return
 	}
 
@@ -157,7 +208,7 @@ func (a *Analyzer) collectTypeDecl(decl *ast.TypeDecl) {
 	typeKind := TypeKindStruct
 	if decl.AliasType != nil {
 		switch decl.AliasType.(type) {
-		case *ast.FunctionType:
+		case *ast.FunctionType, *ast.SequenceType:
 			typeKind = TypeKindFunction
 		case *ast.ListType:
 			typeKind = TypeKindList
@@ -192,11 +243,28 @@ func (a *Analyzer) collectTypeDecl(decl *ast.TypeDecl) {
 		}
 	}
 
+	typeInfo := &TypeInfo{Kind: typeKind, Name: decl.Name.Value, Fields: fields}
+	// Keep the signature of a func type so lambdas passed where it is
+	// expected can be typed from it (see funcSignature)
+	if ft, ok := decl.AliasType.(*ast.FunctionType); ok {
+		typeInfo = a.typeAnnotationToTypeInfo(ft)
+		typeInfo.Name = decl.Name.Value
+	}
+	if decl.Distinct {
+		if !isPrimitiveKind(typeKind) {
+			a.errorMsg(decl.AliasType.Pos(), catalog.DistinctUnderlying, a.typeAnnotationToTypeInfo(decl.AliasType))
+		}
+		typeInfo.Distinct = true
+	}
+	if len(decl.Values) > 0 {
+		a.collectEnumValues(decl, typeInfo)
+	}
+
 	// Add type to symbol table
 	symbol := &Symbol{
 		Name:     decl.Name.Value,
 		Kind:     SymbolType,
-		Type:     &TypeInfo{Kind: typeKind, Name: decl.Name.Value, Fields: fields},
+		Type:     typeInfo,
 		Defined:  decl.Name.Pos(),
 		Exported: isExported(decl.Name.Value),
 	}
@@ -209,7 +277,7 @@ func (a *Analyzer) collectTypeDecl(decl *ast.TypeDecl) {
 func (a *Analyzer) collectInterfaceDecl(decl *ast.InterfaceDecl) {
 	// Check export rules
 	if !isValidIdentifier(decl.Name.Value) {
-		a.error(decl.Name.Pos(), fmt.Sprintf("invalid interface name '%s'", decl.Name.Value))
+		a.errorMsg(decl.Name.Pos(), catalog.InvalidInterfaceName, decl.Name.Value)
 		return
 	}
 
@@ -225,12 +293,36 @@ func (a *Analyzer) collectInterfaceDecl(decl *ast.InterfaceDecl) {
 	if err := a.symbolTable.Define(symbol); err != nil {
 		a.error(decl.Name.Pos(), err.Error())
 	}
+
+	// Index the method set so calls through the interface are checked like
+	// calls on a struct
+	methods := make(map[string]*TypeInfo, len(decl.Methods))
+	for _, method := range decl.Methods {
+		funcType := &TypeInfo{
+			Kind:       TypeKindFunction,
+			Params:     make([]*TypeInfo, len(method.Parameters)),
+			Returns:    make([]*TypeInfo, len(method.Returns)),
+			ParamNames: make([]string, len(method.Parameters)),
+		}
+		for i, param := range method.Parameters {
+			funcType.Params[i] = a.typeAnnotationToTypeInfo(param.Type)
+			funcType.ParamNames[i] = param.Name.Value
+			if param.Variadic {
+				funcType.Variadic = true
+			}
+		}
+		for i, ret := range method.Returns {
+			funcType.Returns[i] = a.typeAnnotationToTypeInfo(ret)
+		}
+		methods[method.Name.Value] = funcType
+	}
+	a.methods[decl.Name.Value] = methods
 }
 
 func (a *Analyzer) collectFunctionDecl(decl *ast.FunctionDecl) {
 	// Check export rules
 	if !isValidIdentifier(decl.Name.Value) {
-		a.error(decl.Name.Pos(), fmt.Sprintf("invalid function name '%s'", decl.Name.Value))
+		a.errorMsg(decl.Name.Pos(), catalog.InvalidFunctionName, decl.Name.Value)
 		return
 	}
 
@@ -254,6 +346,9 @@ func (a *Analyzer) collectFunctionDecl(decl *ast.FunctionDecl) {
 	for i, ret := range decl.Returns {
 		returns[i] = a.typeAnnotationToTypeInfo(ret)
 	}
+	if decl.Yields != nil {
+		returns = []*TypeInfo{a.iteratorType(decl.Yields)}
+	}
 
 	funcType := &TypeInfo{
 		Kind:         TypeKindFunction,
@@ -284,39 +379,44 @@ func (a *Analyzer) collectFunctionDecl(decl *ast.FunctionDecl) {
 	}
 }
 
-// registerMethod adds a method's type info to its receiver type's Methods map.
+// registerMethod adds a method's signature to the method index under its
+// receiver type name. The index is keyed by name rather than attached to the
+// type's symbol, so a method may be declared before its receiver type.
 func (a *Analyzer) registerMethod(decl *ast.FunctionDecl, funcType *TypeInfo) {
-	// Extract the receiver type name (strip "reference" if pointer receiver)
-	typeName := ""
-	switch rt := decl.Receiver.Type.(type) {
-	case *ast.NamedType:
-		typeName = rt.Name
-	case *ast.PrimitiveType:
-		typeName = rt.Name
-	case *ast.ReferenceType:
-		// reference Type → extract inner type name
-		switch inner := rt.ElementType.(type) {
-		case *ast.NamedType:
-			typeName = inner.Name
-		case *ast.PrimitiveType:
-			typeName = inner.Name
-		}
+	if _, ok := decl.Receiver.Type.(*ast.ReferenceType); ok {
+		funcType.ReferenceReceiver = true
 	}
-
+	typeName := receiverTypeName(decl.Receiver)
 	if typeName == "" {
 		return
 	}
+	// A plain alias of int or string is the Go type itself, which can't
+	// take methods (see generateTypeDecl)
+	if sym := a.symbolTable.Resolve(typeName); sym != nil && sym.Kind == SymbolType &&
+		isPrimitiveKind(sym.Type.Kind) && !sym.Type.Distinct {
+		a.errorMsg(decl.Receiver.Type.Pos(), catalog.AliasMethod, typeName, sym.Type.Kind)
+	}
 
-	// Find the type symbol and attach the method
-	sym := a.symbolTable.Resolve(typeName)
-	if sym == nil || sym.Type == nil {
-		return
+	if a.methods[typeName] == nil {
+		a.methods[typeName] = make(map[string]*TypeInfo)
 	}
+	a.methods[typeName][decl.Name.Value] = funcType
+}
 
-	if sym.Type.Methods == nil {
-		sym.Type.Methods = make(map[string]*TypeInfo)
+// receiverTypeName returns the name of a method's receiver type, without
+// "reference", or "" when it is not a named type.
+func receiverTypeName(recv *ast.Receiver) string {
+	t := recv.Type
+	if ref, ok := t.(*ast.ReferenceType); ok {
+		t = ref.ElementType
 	}
-	sym.Type.Methods[decl.Name.Value] = funcType
+	switch rt := t.(type) {
+	case *ast.NamedType:
+		return rt.Name
+	case *ast.PrimitiveType:
+		return rt.Name
+	}
+	return ""
 }
 
 // analyzeDeclarations performs deep analysis of declarations
@@ -327,6 +427,8 @@ func (a *Analyzer) analyzeDeclarations() {
 			a.analyzeTypeDecl(d)
 		case *ast.InterfaceDecl:
 			a.analyzeInterfaceDecl(d)
+		case *ast.ImplementsDecl:
+			a.analyzeImplementsDecl(d)
 		case *ast.FunctionDecl:
 			a.analyzeFunctionDecl(d)
 		case *ast.VarDeclStmt:
@@ -338,6 +440,8 @@ func (a *Analyzer) analyzeDeclarations() {
 }
 
 func (a *Analyzer) analyzeConstDecl(decl *ast.ConstDecl) {
+	a.inConstDecl = true
+	defer func() { a.inConstDecl = false }()
 	for _, spec := range decl.Specs {
 		a.analyzeExpression(spec.Value)
 	}
@@ -353,19 +457,20 @@ func (a *Analyzer) analyzeTypeDecl(decl *ast.TypeDecl) {
 	// Validate field types exist
 	for _, field := range decl.Fields {
 		if !isValidIdentifier(field.Name.Value) {
-			a.error(field.Name.Pos(), fmt.Sprintf("invalid field name '%s'", field.Name.Value))
+			a.errorMsg(field.Name.Pos(), catalog.InvalidFieldName, field.Name.Value)
 		}
 
 		// Check that field type exists
 		a.validateTypeAnnotation(field.Type)
 	}
+	a.checkStructTags(decl)
 }
 
 func (a *Analyzer) analyzeInterfaceDecl(decl *ast.InterfaceDecl) {
 	// Validate method signatures
 	for _, method := range decl.Methods {
 		if !isValidIdentifier(method.Name.Value) {
-			a.error(method.Name.Pos(), fmt.Sprintf("invalid method name '%s'", method.Name.Value))
+			a.errorMsg(method.Name.Pos(), catalog.InvalidMethodName, method.Name.Value)
 		}
 
 		// Validate parameter types
@@ -389,7 +494,7 @@ func (a *Analyzer) analyzeGlobalVarDecl(stmt *ast.VarDeclStmt) {
 	// Register each name in the global scope
 	for i, name := range stmt.Names {
 		if !isValidIdentifier(name.Value) {
-			a.error(name.Pos(), fmt.Sprintf("invalid variable name '%s'", name.Value))
+			a.errorMsg(name.Pos(), catalog.InvalidVariableName, name.Value)
 			continue
 		}
 
@@ -397,6 +502,9 @@ func (a *Analyzer) analyzeGlobalVarDecl(stmt *ast.VarDeclStmt) {
 		var varType *TypeInfo
 		if stmt.Type != nil {
 			varType = a.typeAnnotationToTypeInfo(stmt.Type)
+			if i < len(stmt.Values) {
+				a.checkEnumValue(stmt.Values[i], varType)
+			}
 		} else if i < len(stmt.Values) {
 			varType = a.analyzeExpression(stmt.Values[i])
 		} else if len(stmt.Values) > 0 {
@@ -426,6 +534,7 @@ func (a *Analyzer) analyzeFunctionDecl(decl *ast.FunctionDecl) {
 
 	// Track current function for return checking
 	a.currentFunc = decl
+	a.resetNilness()
 
 	// Add receiver if present (for methods)
 	if decl.Receiver != nil {
@@ -448,10 +557,10 @@ func (a *Analyzer) analyzeFunctionDecl(decl *ast.FunctionDecl) {
 		if param.Variadic {
 			variadicCount++
 			if variadicCount > 1 {
-				a.error(param.Name.Pos(), "only one variadic parameter allowed per function")
+				a.errorMsg(param.Name.Pos(), catalog.VariadicNotUnique)
 			}
 			if i != len(decl.Parameters)-1 {
-				a.error(param.Name.Pos(), "variadic parameter must be the last parameter")
+				a.errorMsg(param.Name.Pos(), catalog.VariadicNotLast)
 			}
 		}
 	}
@@ -459,7 +568,7 @@ func (a *Analyzer) analyzeFunctionDecl(decl *ast.FunctionDecl) {
 	// Add parameters to scope
 	for _, param := range decl.Parameters {
 		if !isValidIdentifier(param.Name.Value) {
-			a.error(param.Name.Pos(), fmt.Sprintf("invalid parameter name '%s'", param.Name.Value))
+			a.errorMsg(param.Name.Pos(), catalog.InvalidParameterName, param.Name.Value)
 		}
 
 		a.validateTypeAnnotation(param.Type)
@@ -483,11 +592,22 @@ func (a *Analyzer) analyzeFunctionDecl(decl *ast.FunctionDecl) {
 	// Validate return types exist
 	for _, ret := range decl.Returns {
 		a.validateTypeAnnotation(ret)
+		a.resolveTypeAnnotation(ret)
+	}
+	for _, y := range decl.Yields {
+		a.validateTypeAnnotation(y)
+	}
+	if len(decl.Yields) > 2 {
+		a.errorMsg(decl.Yields[2].Pos(), catalog.IteratorYieldArity)
 	}
 
 	// Analyze function body
 	if decl.Body != nil {
 		a.analyzeBlock(decl.Body)
+		// An iterator body ends the iteration by falling off the end
+		if decl.Yields == nil {
+			a.checkMissingReturn(fmt.Sprintf("function '%s'", decl.Name.Value), decl.Returns, decl.Body, decl.Name.Pos())
+		}
 	}
 
 	a.currentFunc = nil
@@ -0,0 +1,116 @@
+package semantic
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// derivedMember is a method or function an `@derive` adds for a type.
+type derivedMember struct {
+	name     string // method name, or function name for functions
+	isMethod bool
+	typ      *TypeInfo
+}
+
+// derives lists, for each `@derive` name, the members it adds to a struct
+// type. Codegen writes the bodies (internal/codegen/codegen_derive.go); an
+// entry here needs a generator there.
+var derives = map[string]func(a *Analyzer, decl *ast.TypeDecl) []derivedMember{
+	// MarshalJSON/UnmarshalJSON encode every field under its Kukicha name
+	// (or its json tag), so unexported fields round-trip too.
+	"json": func(a *Analyzer, decl *ast.TypeDecl) []derivedMember {
+		bytes := &TypeInfo{Kind: TypeKindList, ElementType: &TypeInfo{Kind: TypeKindInt, Name: "byte"}}
+		errType := &TypeInfo{Kind: TypeKindNamed, Name: "error"}
+		return []derivedMember{
+			{name: "MarshalJSON", isMethod: true, typ: &TypeInfo{Kind: TypeKindFunction, Returns: []*TypeInfo{bytes, errType}}},
+			{name: "UnmarshalJSON", isMethod: true, typ: &TypeInfo{Kind: TypeKindFunction, Params: []*TypeInfo{bytes}, ParamNames: []string{"data"}, Returns: []*TypeInfo{errType}, ReferenceReceiver: true}},
+		}
+	},
+	// String formats the value like a struct literal: Point{x: 1, y: 2}.
+	"stringer": func(a *Analyzer, decl *ast.TypeDecl) []derivedMember {
+		return []derivedMember{
+			{name: "String", isMethod: true, typ: &TypeInfo{Kind: TypeKindFunction, Returns: []*TypeInfo{{Kind: TypeKindString}}}},
+		}
+	},
+	// jsontest adds no members: the build writes a companion _test.go file
+	// with a JSON round-trip test for the type (codegen.GenerateDeriveTests).
+	"jsontest": func(a *Analyzer, decl *ast.TypeDecl) []derivedMember {
+		return nil
+	},
+	// NewT (newT for an unexported t) takes every field in declaration
+	// order and returns a T.
+	"new": func(a *Analyzer, decl *ast.TypeDecl) []derivedMember {
+		ctor := &TypeInfo{Kind: TypeKindFunction, Returns: []*TypeInfo{{Kind: TypeKindNamed, Name: decl.Name.Value}}}
+		for _, field := range decl.Fields {
+			ctor.Params = append(ctor.Params, a.typeAnnotationToTypeInfo(field.Type))
+			ctor.ParamNames = append(ctor.ParamNames, field.Name.Value)
+		}
+		return []derivedMember{{name: ast.ConstructorName(decl.Name.Value), typ: ctor}}
+	},
+}
+
+// DeriveNames returns the names `@derive` accepts, sorted.
+func DeriveNames() []string {
+	return slices.Sorted(maps.Keys(derives))
+}
+
+// collectDerives registers the members requested by `@derive` directives on
+// type declarations, after every declaration is collected so a hand-written
+// member with the same name is reported as a conflict whatever its position.
+func (a *Analyzer) collectDerives() {
+	for _, decl := range a.program.Declarations {
+		typeDecl, ok := decl.(*ast.TypeDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range ast.TypeDerives(typeDecl) {
+			members, ok := derives[d.Name]
+			if !ok {
+				a.errorMsg(directivePos(&d.Directive), catalog.UnknownDerive, d.Name, strings.Join(DeriveNames(), ", "))
+				continue
+			}
+			if typeDecl.AliasType != nil {
+				a.errorMsg(directivePos(&d.Directive), catalog.DeriveNeedsStruct, d.Name, typeDecl.Name.Value)
+				continue
+			}
+			for _, m := range members(a, typeDecl) {
+				a.registerDerived(typeDecl, d, m)
+			}
+		}
+	}
+}
+
+// registerDerived adds one derived member, reporting a clash with a member
+// already declared by hand or by another derive.
+func (a *Analyzer) registerDerived(decl *ast.TypeDecl, d ast.Derive, m derivedMember) {
+	typeName := decl.Name.Value
+	pos := directivePos(&d.Directive)
+	if m.isMethod {
+		if _, exists := a.methods[typeName][m.name]; exists {
+			a.errorMsg(pos, catalog.DeriveConflict, m.name, typeName, d.Name)
+			return
+		}
+		if a.methods[typeName] == nil {
+			a.methods[typeName] = make(map[string]*TypeInfo)
+		}
+		a.methods[typeName][m.name] = m.typ
+		return
+	}
+	if a.symbolTable.Resolve(m.name) != nil {
+		a.errorMsg(pos, catalog.DeriveConflict, m.name, typeName, d.Name)
+		return
+	}
+	if err := a.symbolTable.Define(&Symbol{
+		Name:     m.name,
+		Kind:     SymbolFunction,
+		Type:     m.typ,
+		Defined:  pos,
+		Exported: isExported(m.name),
+	}); err != nil {
+		a.error(pos, err.Error())
+	}
+}
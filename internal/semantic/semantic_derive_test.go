@@ -0,0 +1,66 @@
+package semantic
+
+import (
+	"testing"
+)
+
+func TestDeriveAddsMembers(t *testing.T) {
+	input := `@derive json, stringer, new
+type Point
+    x int
+    label string
+
+func main()
+    p := NewPoint(1, "a")
+    s := p.String()
+    data := p.MarshalJSON() onerr panic "{error}"
+    q := Point{}
+    q.UnmarshalJSON(data) onerr panic "{error}"
+    print(s, q)
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	input := `@derive json, yaml
+type A
+    x int
+
+@derive new
+type B func(int)
+
+@derive stringer
+type C
+    x int
+
+func String on c C string
+    return "c"
+
+type D
+    x int
+
+# kuki:derive new
+type d
+    x int
+
+func newD() d
+    return d{x: 1}
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"test.kuki:1:1: unknown derive 'yaml' (available: json, jsontest, new, stringer)",
+		"test.kuki:5:0: cannot derive 'new' for B: it is not a struct type",
+		"test.kuki:8:0: 'String' is already declared for C; remove it or drop 'stringer' from @derive",
+		"test.kuki:18:0: 'newD' is already declared for d; remove it or drop 'new' from @derive",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package semantic
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// Integer division truncates, as in Go: 1 / 2 is 0. That is right for
+// indexes and counts but surprises anyone computing a ratio, so the analyzer
+// warns where the truncation is almost certainly unintended.
+
+// checkConstantDivision warns about "/" between two integer literals that do
+// not divide evenly, e.g. 1 / 2 or 7 / 2.
+func (a *Analyzer) checkConstantDivision(expr *ast.BinaryExpr) {
+	left, ok := expr.Left.(*ast.IntegerLiteral)
+	if !ok || left.Big != nil {
+		return
+	}
+	right, ok := expr.Right.(*ast.IntegerLiteral)
+	if !ok || right.Big != nil || right.Value == 0 || left.Value%right.Value == 0 {
+		return
+	}
+	a.warn(expr.Pos(), fmt.Sprintf("integer division: %d / %d is %d, the remainder is dropped; write %d.0 / %d for %g",
+		left.Value, right.Value, left.Value/right.Value, left.Value, right.Value, float64(left.Value)/float64(right.Value)))
+}
+
+// checkFloatConstantDivisor warns about n / 2.0 with n an int: Go converts
+// the constant to an int and divides as integers, so the .0 does not keep
+// the fraction.
+func (a *Analyzer) checkFloatConstantDivisor(expr *ast.BinaryExpr, left *TypeInfo) {
+	divisor, ok := expr.Right.(*ast.FloatLiteral)
+	if !ok || left.Kind != TypeKindInt || a.isUntypedConst(expr.Left) {
+		return
+	}
+	operand := "the left operand"
+	if id, ok := expr.Left.(*ast.Identifier); ok {
+		operand = id.Value
+	}
+	a.warn(expr.Pos(), fmt.Sprintf("integer division: %s is an int, so / %s divides as integers and drops the remainder; convert first: (%s as float64) / %s",
+		operand, divisor.Token.Lexeme, operand, divisor.Token.Lexeme))
+}
+
+// checkDivisionBeforeConversion warns about converting the result of an
+// integer division to a float, e.g. (done / total) as float64, where the
+// fraction is already lost before the conversion.
+func (a *Analyzer) checkDivisionBeforeConversion(cast *ast.TypeCastExpr, target *TypeInfo) {
+	div, ok := cast.Expression.(*ast.BinaryExpr)
+	if !ok || div.Operator != "/" || target.Kind != TypeKindFloat {
+		return
+	}
+	left, right := a.exprTypes[div.Left], a.exprTypes[div.Right]
+	if left == nil || right == nil || left.Kind != TypeKindInt || right.Kind != TypeKindInt {
+		return
+	}
+	operand := "the left operand"
+	if id, ok := div.Left.(*ast.Identifier); ok {
+		operand = id.Value
+	}
+	typeName := target.Name
+	if typeName == "" {
+		typeName = "float64"
+	}
+	a.warn(div.Pos(), fmt.Sprintf("integer division happens before the conversion to %s, so the fraction is lost; convert first: (%s as %s) / ...",
+		typeName, operand, typeName))
+}
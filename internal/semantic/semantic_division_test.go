@@ -0,0 +1,80 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+func TestIntegerDivisionOfLiteralsWarns(t *testing.T) {
+	input := `func main()
+    half := 1 / 2
+    even := 10 / 2
+    ratio := 1.0 / 2
+    print(half, even, ratio)
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := "app.kuki:2:14: integer division: 1 / 2 is 0, the remainder is dropped; write 1.0 / 2 for 0.5"
+	if len(warnings) != 1 || warnings[0].Error() != want {
+		t.Fatalf("expected %q, got: %v", want, warnings)
+	}
+}
+
+func TestIntegerDivisionBeforeFloatConversionWarns(t *testing.T) {
+	input := `func Progress(done int, total int) (float64, float64, int)
+    lost := (done / total) as float64
+    kept := (done as float64) / (total as float64)
+    count := (done / total) as int64
+    return lost, kept, count as int
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "integer division happens before the conversion to float64") ||
+		!strings.Contains(warnings[0].Error(), "(done as float64) / ...") {
+		t.Fatalf("expected one conversion warning, got: %v", warnings)
+	}
+}
+
+func TestFloatConstantTakesIntOperandType(t *testing.T) {
+	// As in Go, 2.0 becomes an int next to n, so n / 2.0 is an int division
+	input := `func Scale(n int, f float32) (int, float32)
+    half := n / 2.0
+    return half, f * 2
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	seen := 0
+	for expr, ti := range analyzer.ExprTypes() {
+		bin, ok := expr.(*ast.BinaryExpr)
+		if !ok {
+			continue
+		}
+		seen++
+		switch bin.Operator {
+		case "/":
+			if ti.Kind != TypeKindInt {
+				t.Errorf("n / 2.0: expected int, got %s", ti)
+			}
+		case "*":
+			if ti.Kind != TypeKindFloat || ti.Name != "float32" {
+				t.Errorf("f * 2: expected float32, got %s", ti)
+			}
+		}
+	}
+	if seen != 2 {
+		t.Errorf("expected types for both operations, got %d", seen)
+	}
+	warnings := analyzer.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "n is an int, so / 2.0 divides as integers") ||
+		!strings.Contains(warnings[0].Error(), "(n as float64) / 2.0") {
+		t.Errorf("expected one integer division warning, got: %v", warnings)
+	}
+}
@@ -0,0 +1,127 @@
+package semantic
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// collectEnumValues records the value set of an enum, a distinct string type
+// listing its values, and defines each value as a constant of the type.
+func (a *Analyzer) collectEnumValues(decl *ast.TypeDecl, typeInfo *TypeInfo) {
+	if typeInfo.Kind != TypeKindString {
+		a.errorMsg(decl.Values[0].Name.Pos(), catalog.EnumNotString, decl.Name.Value, typeInfo.Kind)
+		return
+	}
+	seen := make(map[string]bool, len(decl.Values))
+	for _, v := range decl.Values {
+		if seen[v.Value.Value] {
+			a.errorMsg(v.Value.Pos(), catalog.EnumDuplicate, decl.Name.Value, v.Value.Value)
+		} else {
+			typeInfo.Enum = append(typeInfo.Enum, v.Value.Value)
+		}
+		seen[v.Value.Value] = true
+		err := a.symbolTable.Define(&Symbol{
+			Name:     v.Name.Value,
+			Kind:     SymbolConst,
+			Type:     typeInfo,
+			Defined:  v.Name.Pos(),
+			Exported: isExported(v.Name.Value),
+		})
+		if err != nil {
+			a.error(v.Name.Pos(), err.Error())
+			continue
+		}
+		a.constExprs[v.Name.Value] = v.Value
+	}
+}
+
+// enumLiteral returns the string a plain string literal, or a constant
+// declared as one (including enum values), stands for.
+func (a *Analyzer) enumLiteral(expr ast.Expression) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return e.Value, !e.Interpolated
+	case *ast.Identifier:
+		lit, ok := a.constExprs[e.Value].(*ast.StringLiteral)
+		if !ok || lit.Interpolated {
+			return "", false
+		}
+		// A local variable or parameter may shadow the const
+		if sym := a.symbolTable.Resolve(e.Value); sym == nil || sym.Kind != SymbolConst {
+			return "", false
+		}
+		return lit.Value, true
+	}
+	return "", false
+}
+
+// checkEnumValue reports a string literal used where an enum is expected
+// that is not one of its values.
+func (a *Analyzer) checkEnumValue(expr ast.Expression, target *TypeInfo) {
+	if target == nil || target.Enum == nil {
+		return
+	}
+	lit, ok := expr.(*ast.StringLiteral)
+	if !ok || lit.Interpolated {
+		return
+	}
+	if !slices.Contains(target.Enum, lit.Value) {
+		a.errorMsg(lit.Pos(), catalog.EnumUnknownValue, lit.Value, target.Name, quotedList(target.Enum))
+	}
+}
+
+// checkEnumArgs checks the arguments of a call to a function with enum
+// parameters. offset is the number of piped values before the arguments.
+func (a *Analyzer) checkEnumArgs(args []ast.Expression, funcType *TypeInfo, offset int) {
+	for i, arg := range args {
+		idx := i + offset
+		if funcType.Variadic && idx >= len(funcType.Params)-1 {
+			idx = len(funcType.Params) - 1
+		}
+		if idx >= 0 && idx < len(funcType.Params) {
+			a.checkEnumValue(arg, funcType.Params[idx])
+		}
+	}
+}
+
+// checkSwitchExhaustive reports the values of an enum that a switch without
+// an otherwise branch has no when for. A branch with an `if` guard covers
+// nothing, since it may not be taken.
+func (a *Analyzer) checkSwitchExhaustive(stmt *ast.SwitchStmt, switchType *TypeInfo) {
+	if switchType == nil || switchType.Enum == nil || stmt.Otherwise != nil {
+		return
+	}
+	covered := make(map[string]bool)
+	for _, c := range stmt.Cases {
+		if c.Guard != nil {
+			continue
+		}
+		for _, val := range c.Values {
+			if s, ok := a.enumLiteral(val); ok {
+				covered[s] = true
+			}
+		}
+	}
+	var missing []string
+	for _, v := range switchType.Enum {
+		if !covered[v] {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		a.errorMsg(stmt.Pos(), catalog.SwitchMissingValues, switchType.Name, quotedList(missing))
+	}
+}
+
+// quotedList renders values as "a", "b", "c".
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
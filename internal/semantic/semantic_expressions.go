@@ -2,8 +2,10 @@ package semantic
 
 import (
 	"fmt"
+	"regexp/syntax"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
@@ -13,6 +15,7 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 
 	defer func() {
 		a.recordType(expr, result)
+		a.recordConstant(expr)
 	}()
 
 	switch e := expr.(type) {
@@ -22,19 +25,40 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 		return &TypeInfo{Kind: TypeKindInt}
 	case *ast.FloatLiteral:
 		return &TypeInfo{Kind: TypeKindFloat}
+	case *ast.DecimalLiteral:
+		return &TypeInfo{Kind: TypeKindNamed, Name: decimalTypeName}
 	case *ast.StringLiteral:
 		if e.Interpolated {
 			a.analyzeStringInterpolation(e)
 		}
 		return &TypeInfo{Kind: TypeKindString}
+	case *ast.DataLiteral:
+		if e.Lines {
+			return &TypeInfo{Kind: TypeKindList, ElementType: &TypeInfo{Kind: TypeKindString}}
+		}
+		return &TypeInfo{Kind: TypeKindString}
+	case *ast.RegexLiteral:
+		if _, err := syntax.Parse(e.Pattern, syntax.Perl); err != nil {
+			if serr, ok := err.(*syntax.Error); ok {
+				err = fmt.Errorf("%s: `%s`", serr.Code, serr.Expr)
+			}
+			a.errorMsg(e.Pos(), catalog.InvalidRegex, err)
+		}
+		return &TypeInfo{Kind: TypeKindString}
 	case *ast.BooleanLiteral:
 		return &TypeInfo{Kind: TypeKindBool}
 	case *ast.BinaryExpr:
 		return a.analyzeBinaryExpr(e)
+	case *ast.ComparisonChainExpr:
+		return a.analyzeComparisonChain(e)
+	case *ast.RangeCheckExpr:
+		return a.analyzeRangeCheck(e)
 	case *ast.UnaryExpr:
 		return a.analyzeUnaryExpr(e)
 	case *ast.PipeExpr:
 		return a.analyzePipeExpr(e)
+	case *ast.ParallelPipeExpr:
+		return a.analyzeParallelPipeExpr(e)[0]
 	case *ast.PipedSwitchExpr:
 		// Analyze the upstream pipe chain so call return counts and expression types
 		// are populated for codegen. For the switch body, only analyze the return
@@ -55,6 +79,7 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 		}
 		return &TypeInfo{Kind: TypeKindUnknown}
 	case *ast.FieldAccessExpr:
+		a.checkNilDeref(e.Object)
 		return a.analyzeFieldAccessExpr(e, nil)
 	case *ast.IndexExpr:
 		return a.analyzeIndexExpr(e)
@@ -62,9 +87,11 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 		return a.analyzeSliceExpr(e)
 	case *ast.ListLiteralExpr:
 		return a.analyzeListLiteral(e)
+	case *ast.MapLiteralExpr:
+		return a.analyzeMapLiteral(e)
 	case *ast.EmptyExpr:
 		if e.Type != nil {
-			return a.typeAnnotationToTypeInfo(e.Type)
+			return a.resolveTypeAnnotation(e.Type)
 		}
 		return &TypeInfo{Kind: TypeKindNil}
 	case *ast.StructLiteralExpr:
@@ -84,12 +111,13 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 			if structFields != nil {
 				fieldType, ok := structFields[field.Name.Value]
 				if !ok {
-					a.error(field.Name.Pos(), fmt.Sprintf("unknown field '%s' on struct '%s'", field.Name.Value, structType.Name))
+					a.errorMsg(field.Name.Pos(), catalog.UnknownField, field.Name.Value, structType.Name)
 				} else {
 					// Record the field's resolved type and check value compatibility.
 					a.recordType(field.Value, fieldType)
+					a.checkEnumValue(field.Value, fieldType)
 					if !a.typesCompatible(fieldType, valueType) {
-						a.error(field.Name.Pos(), fmt.Sprintf("cannot use %s as %s in field '%s' of struct '%s'", valueType, fieldType, field.Name.Value, structType.Name))
+						a.errorMsg(field.Name.Pos(), catalog.StructFieldType, valueType, fieldType, field.Name.Value, structType.Name)
 					}
 				}
 			}
@@ -100,6 +128,9 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 		return a.typeAnnotationToTypeInfo(e.Type)
 	case *ast.ReceiveExpr:
 		chanType := a.analyzeExpression(e.Channel)
+		if chanType.Kind == TypeKindChannel && chanType.ChanDir == "send-only" {
+			a.errorMsg(e.Channel.Pos(), catalog.ReceiveOnSendOnly, chanType)
+		}
 		if chanType.Kind == TypeKindChannel && chanType.ElementType != nil {
 			return chanType.ElementType
 		}
@@ -108,11 +139,19 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 		// Analyze the expression being cast
 		_ = a.analyzeExpression(e.Expression)
 		// Return the target type
-		return a.typeAnnotationToTypeInfo(e.TargetType)
+		target := a.resolveTypeAnnotation(e.TargetType)
+		a.checkDivisionBeforeConversion(e, target)
+		a.checkConstantConversion(e, target)
+		a.checkEnumValue(e.Expression, target)
+		return target
 	case *ast.FunctionLiteral:
 		// Analyze function literal — parameters and body must be validated
+		a.recordCaptures(e, e.Parameters, e.Body)
 		a.symbolTable.EnterScope()
 		defer a.symbolTable.ExitScope()
+		// The literal may run later, so nil-use state does not flow in or out.
+		prevNil := a.resetNilness()
+		defer func() { a.maybeNil = prevNil }()
 		for _, param := range e.Parameters {
 			if param.Type != nil {
 				a.validateTypeAnnotation(param.Type)
@@ -139,14 +178,27 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 				Parameters: e.Parameters,
 				Returns:    e.Returns,
 			}
+			savedInLambda, savedClosureBlock := a.inLambda, a.closureBlock
+			a.inLambda, a.closureBlock = false, ""
 			a.analyzeBlock(e.Body)
+			a.checkMissingReturn("function literal", e.Returns, e.Body, e.Pos())
 			a.currentFunc = savedFunc
+			a.inLambda, a.closureBlock = savedInLambda, savedClosureBlock
 		}
 		return &TypeInfo{Kind: TypeKindUnknown}
 	case *ast.ArrowLambda:
 		// Analyze arrow lambda body — parameters must be in scope
+		if e.Block != nil {
+			a.recordCaptures(e, e.Parameters, e.Block)
+		} else if e.Body != nil {
+			a.recordCaptures(e, e.Parameters, &ast.BlockStmt{Statements: []ast.Statement{&ast.ExpressionStmt{Expression: e.Body}}})
+		}
 		a.symbolTable.EnterScope()
 		defer a.symbolTable.ExitScope()
+		prevNil := a.resetNilness()
+		defer func() { a.maybeNil = prevNil }()
+		target := a.lambdaTargets[e]
+		paramTypes := make([]*TypeInfo, 0, len(e.Parameters))
 		for _, param := range e.Parameters {
 			if param.Type != nil {
 				a.validateTypeAnnotation(param.Type)
@@ -168,13 +220,24 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 			if err := a.symbolTable.Define(paramSymbol); err != nil {
 				a.error(param.Name.Pos(), err.Error())
 			}
+			paramTypes = append(paramTypes, paramType)
 		}
 		var bodyType *TypeInfo
 		if e.Body != nil {
 			bodyType = a.analyzeExpression(e.Body)
 		}
 		if e.Block != nil {
+			// Returns inside the block leave the lambda, so they are checked
+			// against its expected signature, not the enclosing function
+			savedInLambda, savedSig, savedClosureBlock := a.inLambda, a.lambdaSig, a.closureBlock
+			a.inLambda, a.lambdaSig, a.closureBlock = true, target, ""
 			a.analyzeBlock(e.Block)
+			a.inLambda, a.lambdaSig, a.closureBlock = savedInLambda, savedSig, savedClosureBlock
+		}
+		// A lambda passed for a parameter of known func type takes that
+		// signature; codegen tells it apart by its non-nil Params.
+		if target != nil {
+			return &TypeInfo{Kind: TypeKindFunction, Params: paramTypes, Returns: target.Returns}
 		}
 		// Return a function type with the body's return type so callers can
 		// resolve generic placeholders (e.g., "result" in concurrent.MapWithLimit).
@@ -195,6 +258,30 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) (result *TypeInfo) {
 	case *ast.BlockExpr:
 		a.analyzeBlock(e.Body)
 		return &TypeInfo{Kind: TypeKindUnknown}
+	case *ast.OnErrExpr:
+		return a.analyzeOnErrExpr(e)
+	case *ast.BuildStringExpr:
+		return a.analyzeBuildStringExpr(e)
+	case *ast.DerefExpr:
+		operandType := a.analyzeExpression(e.Operand)
+		a.checkNilDeref(e.Operand)
+		if operandType.Kind == TypeKindReference && operandType.ElementType != nil {
+			return operandType.ElementType
+		}
+		return &TypeInfo{Kind: TypeKindUnknown}
+	case *ast.ReadExpr:
+		a.recordReturnCount(e, 2)
+		return &TypeInfo{Kind: TypeKindString}
+	case *ast.CommandExpr:
+		return a.analyzeCommandExprMulti(e)[0]
+	case *ast.WaitExpr:
+		return a.analyzeWaitExprMulti(e)[0]
+	case *ast.AddressOfExpr:
+		operandType := a.analyzeExpression(e.Operand)
+		if operandType.Kind == TypeKindUnknown {
+			return operandType
+		}
+		return &TypeInfo{Kind: TypeKindReference, ElementType: operandType}
 	default:
 		return &TypeInfo{Kind: TypeKindUnknown}
 	}
@@ -206,6 +293,7 @@ func (a *Analyzer) analyzeExpressionMulti(expr ast.Expression) []*TypeInfo {
 	if expr == nil {
 		return []*TypeInfo{{Kind: TypeKindUnknown}}
 	}
+	defer a.recordConstant(expr)
 
 	switch e := expr.(type) {
 	case *ast.CallExpr:
@@ -216,6 +304,15 @@ func (a *Analyzer) analyzeExpressionMulti(expr ast.Expression) []*TypeInfo {
 		return []*TypeInfo{a.analyzeFieldAccessExpr(e, nil)}
 	case *ast.PipeExpr:
 		return a.analyzePipeExprMulti(e)
+	case *ast.ReadExpr:
+		a.recordReturnCount(e, 2)
+		return []*TypeInfo{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}
+	case *ast.CommandExpr:
+		return a.analyzeCommandExprMulti(e)
+	case *ast.WaitExpr:
+		return a.analyzeWaitExprMulti(e)
+	case *ast.ParallelPipeExpr:
+		return a.analyzeParallelPipeExpr(e)
 	case *ast.IndexExpr:
 		// Map index supports two-value form: val, ok := m[key]
 		leftType := a.analyzeExpression(e.Left)
@@ -229,6 +326,58 @@ func (a *Analyzer) analyzeExpressionMulti(expr ast.Expression) []*TypeInfo {
 	}
 }
 
+// analyzeCommandExprMulti checks a `$ "..."` command, which yields the
+// command's output and an error like a (string, error) call.
+func (a *Analyzer) analyzeCommandExprMulti(e *ast.CommandExpr) []*TypeInfo {
+	a.analyzeExpression(e.Command)
+	if _, err := ast.CommandWords(e.Command); err != nil {
+		a.errorMsg(e.Pos(), catalog.InvalidCommand, err)
+	}
+	a.recordReturnCount(e, 2)
+	return []*TypeInfo{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}
+}
+
+// analyzeWaitExprMulti checks `wait for [all] ch [within d]`. It yields the
+// channel's element type (a list of it for all), and with within also an
+// error for the timeout like a (T, error) call.
+func (a *Analyzer) analyzeWaitExprMulti(e *ast.WaitExpr) []*TypeInfo {
+	result := &TypeInfo{Kind: TypeKindUnknown}
+	chanType := a.analyzeExpression(e.Channel)
+	if e.All {
+		listType := chanType
+		chanType = &TypeInfo{Kind: TypeKindUnknown}
+		if listType.Kind == TypeKindList && listType.ElementType != nil {
+			chanType = listType.ElementType
+		}
+		if chanType.Kind != TypeKindChannel && listType.Kind != TypeKindUnknown &&
+			(listType.Kind != TypeKindList || chanType.Kind != TypeKindUnknown) {
+			a.errorMsg(e.Channel.Pos(), catalog.WaitAllNotChannels, listType)
+		}
+	} else if chanType.Kind != TypeKindChannel && chanType.Kind != TypeKindUnknown {
+		a.errorMsg(e.Channel.Pos(), catalog.WaitNotChannel, chanType)
+	}
+	if chanType.Kind == TypeKindChannel {
+		if chanType.ChanDir == "send-only" {
+			a.errorMsg(e.Channel.Pos(), catalog.ReceiveOnSendOnly, chanType)
+		}
+		if chanType.ElementType != nil {
+			result = chanType.ElementType
+		}
+	}
+	if e.All {
+		result = &TypeInfo{Kind: TypeKindList, ElementType: result}
+	}
+	if e.Timeout == nil {
+		return []*TypeInfo{result}
+	}
+	timeoutType := a.analyzeExpression(e.Timeout)
+	if !a.typesCompatible(&TypeInfo{Kind: TypeKindNamed, Name: "time.Duration"}, timeoutType) {
+		a.errorMsg(e.Timeout.Pos(), catalog.WaitTimeout, timeoutType)
+	}
+	a.recordReturnCount(e, 2)
+	return []*TypeInfo{result, {Kind: TypeKindNamed, Name: "error"}}
+}
+
 func (a *Analyzer) analyzeIdentifier(ident *ast.Identifier) *TypeInfo {
 	// Check for builtin functions first
 	if ident.Value == "print" {
@@ -241,6 +390,15 @@ func (a *Analyzer) analyzeIdentifier(ident *ast.Identifier) *TypeInfo {
 		}
 	}
 
+	if ident.Value == "write" && a.symbolTable.Resolve("write") == nil {
+		// write is print without the newline; a declared write takes precedence
+		return &TypeInfo{
+			Kind:     TypeKindFunction,
+			Params:   []*TypeInfo{{Kind: TypeKindUnknown}},
+			Variadic: true,
+		}
+	}
+
 	if ident.Value == "len" {
 		// len is a builtin that returns int
 		return &TypeInfo{
@@ -287,7 +445,25 @@ func (a *Analyzer) analyzeIdentifier(ident *ast.Identifier) *TypeInfo {
 		}
 	}
 
-	a.error(ident.Pos(), fmt.Sprintf("undefined identifier '%s'", ident.Value))
+	// delete removes a map key; like min/max it can be shadowed
+	if ident.Value == "delete" {
+		return &TypeInfo{
+			Kind:    TypeKindFunction,
+			Params:  []*TypeInfo{{Kind: TypeKindUnknown}, {Kind: TypeKindUnknown}},
+			Returns: nil,
+		}
+	}
+
+	if a.inInterpolation {
+		// Go would only report the generated Sprintf argument, so point at the hole
+		if suggestion := closestName(ident.Value, a.symbolTable.CurrentScope().Names()); suggestion != "" {
+			a.errorMsg(ident.Pos(), catalog.UndefinedInInterpolationSuggest, ident.Value, suggestion)
+		} else {
+			a.errorMsg(ident.Pos(), catalog.UndefinedInInterpolation, ident.Value)
+		}
+		return &TypeInfo{Kind: TypeKindUnknown}
+	}
+	a.errorMsg(ident.Pos(), catalog.UndefinedIdentifier, ident.Value)
 	return &TypeInfo{Kind: TypeKindUnknown}
 }
 
@@ -295,8 +471,26 @@ func (a *Analyzer) analyzeBinaryExpr(expr *ast.BinaryExpr) *TypeInfo {
 	leftType := a.analyzeExpression(expr.Left)
 	rightType := a.analyzeExpression(expr.Right)
 
+	if result := a.decimalOperands(expr, leftType, rightType); result != nil {
+		return result
+	}
+	// Arithmetic on two values of unknown type (a Go call's results, say)
+	// has an unknown type too, not int: they may be floats or Decimals.
+	if leftType.Kind == TypeKindUnknown && rightType.Kind == TypeKindUnknown {
+		switch expr.Operator {
+		case "+", "-", "*", "/", "%":
+			return &TypeInfo{Kind: TypeKindUnknown}
+		}
+	}
+	if result := a.operatorCall(expr, leftType, rightType); result != nil {
+		return result
+	}
+
 	switch expr.Operator {
 	case "+":
+		if distinct := a.distinctOperands(expr, leftType, rightType); distinct != nil {
+			return distinct
+		}
 		// String concatenation - allow Unknown on either side
 		if (leftType.Kind == TypeKindString || leftType.Kind == TypeKindUnknown) &&
 			(rightType.Kind == TypeKindString || rightType.Kind == TypeKindUnknown) &&
@@ -305,17 +499,21 @@ func (a *Analyzer) analyzeBinaryExpr(expr *ast.BinaryExpr) *TypeInfo {
 		}
 		// Numeric addition
 		if !isNumericType(leftType) || !isNumericType(rightType) {
-			a.error(expr.Pos(), fmt.Sprintf("cannot apply %s to %s and %s", expr.Operator, leftType, rightType))
-		}
-		if leftType.Kind == TypeKindFloat || rightType.Kind == TypeKindFloat {
-			return &TypeInfo{Kind: TypeKindFloat}
+			a.errorMsg(expr.Pos(), catalog.InvalidOperands, expr.Operator, leftType, rightType)
 		}
-		return &TypeInfo{Kind: TypeKindInt}
+		return a.numericResult(expr, leftType, rightType)
 
 	case "-", "*", "/", "%":
 		// Arithmetic operators
 		if !isNumericType(leftType) || !isNumericType(rightType) {
-			a.error(expr.Pos(), fmt.Sprintf("cannot apply %s to %s and %s", expr.Operator, leftType, rightType))
+			a.errorMsg(expr.Pos(), catalog.InvalidOperands, expr.Operator, leftType, rightType)
+		}
+		if expr.Operator == "/" {
+			a.checkConstantDivision(expr)
+			a.checkFloatConstantDivisor(expr, leftType)
+		}
+		if distinct := a.distinctOperands(expr, leftType, rightType); distinct != nil {
+			return distinct
 		}
 		// Special case: if one operand is a named type (like time.Duration), return that type for multiplication
 		if expr.Operator == "*" {
@@ -326,16 +524,12 @@ func (a *Analyzer) analyzeBinaryExpr(expr *ast.BinaryExpr) *TypeInfo {
 				return rightType
 			}
 		}
-		// Result type is the wider of the two
-		if leftType.Kind == TypeKindFloat || rightType.Kind == TypeKindFloat {
-			return &TypeInfo{Kind: TypeKindFloat}
-		}
-		return &TypeInfo{Kind: TypeKindInt}
+		return a.numericResult(expr, leftType, rightType)
 
 	case "==", "!=", "<", ">", "<=", ">=", "equals", "not equals":
 		// Comparison operators
-		if !a.typesCompatible(leftType, rightType) {
-			a.error(expr.Pos(), fmt.Sprintf("cannot compare %s and %s", leftType, rightType))
+		if a.distinctOperands(expr, leftType, rightType) == nil && !a.typesCompatible(leftType, rightType) {
+			a.errorMsg(expr.Pos(), catalog.CannotCompare, leftType, rightType)
 		}
 		return &TypeInfo{Kind: TypeKindBool}
 
@@ -344,13 +538,13 @@ func (a *Analyzer) analyzeBinaryExpr(expr *ast.BinaryExpr) *TypeInfo {
 		leftOk := leftType.Kind == TypeKindBool || leftType.Kind == TypeKindUnknown
 		rightOk := rightType.Kind == TypeKindBool || rightType.Kind == TypeKindUnknown
 		if !leftOk || !rightOk {
-			a.error(expr.Pos(), fmt.Sprintf("logical operator requires boolean operands, got %s and %s", leftType, rightType))
+			a.errorMsg(expr.Pos(), catalog.LogicalNotBoolean, leftType, rightType)
 		}
 		return &TypeInfo{Kind: TypeKindBool}
 
 	case "&":
 		if !isBitwiseType(leftType) || !isBitwiseType(rightType) {
-			a.error(expr.Pos(), fmt.Sprintf("bitwise AND requires integer operands, got %s and %s", leftType, rightType))
+			a.errorMsg(expr.Pos(), catalog.BitwiseAndNotInteger, leftType, rightType)
 		}
 		return &TypeInfo{Kind: TypeKindInt}
 
@@ -359,6 +553,87 @@ func (a *Analyzer) analyzeBinaryExpr(expr *ast.BinaryExpr) *TypeInfo {
 	}
 }
 
+// analyzeComparisonChain analyzes 0 <= x < 10 as its pairwise comparisons,
+// analyzing each operand once.
+func (a *Analyzer) analyzeComparisonChain(expr *ast.ComparisonChainExpr) *TypeInfo {
+	types := make([]*TypeInfo, len(expr.Operands))
+	for i, operand := range expr.Operands {
+		types[i] = a.analyzeExpression(operand)
+	}
+	for i, operator := range expr.Operators {
+		if !a.typesCompatible(types[i], types[i+1]) {
+			a.errorMsg(ast.TokenPos(operator), catalog.CannotCompare, types[i], types[i+1])
+		}
+	}
+	return &TypeInfo{Kind: TypeKindBool}
+}
+
+// analyzeRangeCheck analyzes x between low and high, and x in low to high, as
+// the comparisons of x with each bound.
+func (a *Analyzer) analyzeRangeCheck(expr *ast.RangeCheckExpr) *TypeInfo {
+	valueType := a.analyzeExpression(expr.Value)
+	for _, bound := range []ast.Expression{expr.Low, expr.High} {
+		if boundType := a.analyzeExpression(bound); !a.typesCompatible(valueType, boundType) {
+			a.errorMsg(bound.Pos(), catalog.CannotCompare, valueType, boundType)
+		}
+	}
+	return &TypeInfo{Kind: TypeKindBool}
+}
+
+// distinctOperands checks the operands of an arithmetic or comparison
+// operator when either has a distinct type: Meters + Seconds and Meters + n
+// (an int variable) are errors, while Meters + 5 stays Meters and a Label
+// compares with "x". It returns the distinct type, or nil when neither
+// operand has one.
+func (a *Analyzer) distinctOperands(expr *ast.BinaryExpr, left, right *TypeInfo) *TypeInfo {
+	if !left.Distinct && !right.Distinct {
+		return nil
+	}
+	distinct, other, otherExpr := left, right, expr.Right
+	if !left.Distinct {
+		distinct, other, otherExpr = right, left, expr.Left
+	}
+	if other.Name == distinct.Name || other.Kind == TypeKindUnknown {
+		return distinct
+	}
+	if other.Name == "" && a.isUntypedConst(otherExpr) {
+		a.checkEnumValue(otherExpr, distinct)
+		return distinct
+	}
+	a.errorMsg(expr.Pos(), catalog.DistinctMix, left, right)
+	return distinct
+}
+
+// numericResult returns the type of arithmetic on two numbers. As in Go, an
+// untyped constant takes the type of the other operand, so with n an int,
+// n / 2.0 is an int division; otherwise a float operand makes it a float.
+func (a *Analyzer) numericResult(expr *ast.BinaryExpr, left, right *TypeInfo) *TypeInfo {
+	leftConst, rightConst := a.isUntypedConst(expr.Left), a.isUntypedConst(expr.Right)
+	if rightConst && !leftConst && (left.Kind == TypeKindInt || left.Kind == TypeKindFloat) {
+		return left
+	}
+	if leftConst && !rightConst && (right.Kind == TypeKindInt || right.Kind == TypeKindFloat) {
+		return right
+	}
+	if left.Kind == TypeKindFloat || right.Kind == TypeKindFloat {
+		return &TypeInfo{Kind: TypeKindFloat}
+	}
+	return &TypeInfo{Kind: TypeKindInt}
+}
+
+// isUntypedConst reports whether expr is a constant Go would convert to the
+// other operand's type: a number, a plain string literal or true/false.
+func (a *Analyzer) isUntypedConst(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return !e.Interpolated
+	case *ast.BooleanLiteral:
+		return true
+	}
+	_, ok := a.constValue(expr)
+	return ok
+}
+
 func isBitwiseType(t *TypeInfo) bool {
 	if t == nil {
 		return false
@@ -371,13 +646,14 @@ func (a *Analyzer) analyzeUnaryExpr(expr *ast.UnaryExpr) *TypeInfo {
 
 	switch expr.Operator {
 	case "-":
-		if !isNumericType(rightType) {
-			a.error(expr.Pos(), "unary minus requires numeric type")
+		if !isNumericType(rightType) && !IsDecimal(rightType) {
+			a.errorMsg(expr.Pos(), catalog.UnaryMinusNotNumeric)
 		}
+		a.checkNegatedUnsigned(expr, rightType)
 		return rightType
 	case "not":
 		if rightType.Kind != TypeKindBool && rightType.Kind != TypeKindUnknown {
-			a.error(expr.Pos(), "not operator requires boolean")
+			a.errorMsg(expr.Pos(), catalog.NotNotBoolean)
 		}
 		return &TypeInfo{Kind: TypeKindBool}
 	default:
@@ -397,11 +673,15 @@ func (a *Analyzer) analyzePipeExpr(expr *ast.PipeExpr) *TypeInfo {
 // This handles cases like: return x |> f() where f() returns (T, error)
 func (a *Analyzer) analyzePipeExprMulti(expr *ast.PipeExpr) []*TypeInfo {
 	// Left side is piped as first argument to right side
-	leftType := a.analyzeExpression(expr.Left)
+	leftTypes := a.analyzePipeSource(expr.Left)
+	leftType := leftTypes[0]
 
-	// Pass left type as piped argument to right side
+	// Pass left type as piped argument to right side. The remaining values of
+	// a multi-value source are offered to the call, which spreads them into
+	// its arguments when its arity calls for it (see PipeSpreadsValues).
 	switch right := expr.Right.(type) {
 	case *ast.CallExpr:
+		a.pipedRest = leftTypes[1:]
 		types := a.analyzeCallExpr(right, leftType)
 		a.recordReturnCount(expr, len(types))
 		// Record type info on the step expression so codegen can detect
@@ -411,7 +691,12 @@ func (a *Analyzer) analyzePipeExprMulti(expr *ast.PipeExpr) []*TypeInfo {
 		}
 		return types
 	case *ast.MethodCallExpr:
+		a.pipedRest = leftTypes[1:]
 		types := a.analyzeMethodCallExpr(right, leftType)
+		if methodType := a.exprTypes[right.Method]; right.Object == nil && methodType != nil {
+			// Shorthand .Method(): the pipe source is the receiver
+			a.checkReceiverAddressable(expr.Left, leftType, right, methodType)
+		}
 		a.recordReturnCount(expr, len(types))
 		if len(types) > 0 {
 			a.recordType(right, types[0])
@@ -435,6 +720,111 @@ func (a *Analyzer) analyzePipeExprMulti(expr *ast.PipeExpr) []*TypeInfo {
 	}
 }
 
+// analyzeParallelPipeExpr analyzes items |>> step. The step receives one
+// element at a time (as the first argument or the "_" placeholder, like |>).
+// The result is a list of the step's value, plus an error when the step
+// returns (T, error); a step with no result makes the pipe a statement.
+func (a *Analyzer) analyzeParallelPipeExpr(expr *ast.ParallelPipeExpr) []*TypeInfo {
+	leftType := a.analyzeExpression(expr.Left)
+	elemType := &TypeInfo{Kind: TypeKindUnknown}
+	switch leftType.Kind {
+	case TypeKindList:
+		if leftType.ElementType != nil {
+			elemType = leftType.ElementType
+		}
+	case TypeKindUnknown:
+	default:
+		a.errorMsg(expr.Pos(), catalog.ParallelPipeNotList, leftType)
+	}
+
+	var stepTypes []*TypeInfo
+	count := 0
+	switch right := expr.Right.(type) {
+	case *ast.CallExpr:
+		stepTypes = a.analyzeCallExpr(right, elemType)
+		count = a.exprReturnCounts[right]
+	case *ast.MethodCallExpr:
+		stepTypes = a.analyzeMethodCallExpr(right, elemType)
+		count = a.exprReturnCounts[right]
+	case *ast.Identifier:
+		// Bare function name: items |>> process
+		fnType := a.analyzeExpression(right)
+		if fnType.Kind == TypeKindFunction {
+			if len(fnType.Params) != 1 && !fnType.Variadic {
+				a.errorMsg(right.Pos(), catalog.ParallelPipeStepArity, right.Value, len(fnType.Params))
+			}
+			stepTypes, count = fnType.Returns, len(fnType.Returns)
+		} else {
+			stepTypes, count = []*TypeInfo{{Kind: TypeKindUnknown}}, 1
+		}
+	default:
+		a.errorMsg(expr.Right.Pos(), catalog.ParallelPipeNeedsCall)
+		stepTypes, count = []*TypeInfo{{Kind: TypeKindUnknown}}, 1
+	}
+	if count > 0 && len(stepTypes) > 0 {
+		a.recordType(expr.Right, stepTypes[0])
+	}
+
+	switch {
+	case count == 0:
+		a.recordReturnCount(expr, 0)
+		return []*TypeInfo{{Kind: TypeKindUnknown}}
+	case count == 1:
+		a.recordReturnCount(expr, 1)
+		return []*TypeInfo{{Kind: TypeKindList, ElementType: stepTypes[0]}}
+	case count == 2 && len(stepTypes) == 2 && isErrorTypeInfo(stepTypes[1]):
+		a.recordReturnCount(expr, 2)
+		return []*TypeInfo{{Kind: TypeKindList, ElementType: stepTypes[0]}, stepTypes[1]}
+	}
+	a.errorMsg(expr.Right.Pos(), catalog.ParallelPipeStepResults, count)
+	a.recordReturnCount(expr, 1)
+	return []*TypeInfo{{Kind: TypeKindUnknown}}
+}
+
+// isErrorTypeInfo reports whether ti is the built-in error interface.
+func isErrorTypeInfo(ti *TypeInfo) bool {
+	return ti != nil && (ti.Kind == TypeKindNamed || ti.Kind == TypeKindInterface) && ti.Name == "error"
+}
+
+// analyzePipeSource analyzes the left side of a pipe. Calls and nested pipes
+// report all of their values so a multi-value result can be spread into the
+// next step; anything else contributes a single value.
+func (a *Analyzer) analyzePipeSource(left ast.Expression) []*TypeInfo {
+	switch left.(type) {
+	case *ast.CallExpr, *ast.MethodCallExpr, *ast.PipeExpr:
+		types := a.analyzeExpressionMulti(left)
+		if len(types) == 0 {
+			types = []*TypeInfo{{Kind: TypeKindUnknown}}
+		}
+		a.recordType(left, types[0])
+		return types
+	}
+	return []*TypeInfo{a.analyzeExpression(left)}
+}
+
+// takePipedRest returns and clears the extra piped values set by
+// analyzePipeExprMulti, so nested calls in the arguments never see them.
+func (a *Analyzer) takePipedRest() []*TypeInfo {
+	rest := a.pipedRest
+	a.pipedRest = nil
+	return rest
+}
+
+// PipeSpreadsValues reports whether a pipe source producing count values
+// should pass all of them to a call of fn that has otherArgs explicit
+// arguments, as in `Split(s) |> Join(",")` → `Join(a, b, ",")`. Spreading is
+// chosen only when the callee's arity requires it: if piping just the first
+// value fits (the usual (T, error) case), the first value is piped. Callers
+// must rule out placeholder and named arguments first.
+func PipeSpreadsValues(fn *TypeInfo, count, otherArgs int) bool {
+	if fn == nil || fn.Kind != TypeKindFunction || fn.Variadic || count < 2 {
+		return false
+	}
+	required := len(fn.Params) - fn.DefaultCount
+	fits := func(n int) bool { return n >= required && n <= len(fn.Params) }
+	return fits(count+otherArgs) && !fits(1+otherArgs)
+}
+
 func (a *Analyzer) analyzeIndexExpr(expr *ast.IndexExpr) *TypeInfo {
 	leftType := a.analyzeExpression(expr.Left)
 	indexType := a.analyzeExpression(expr.Index)
@@ -442,7 +832,7 @@ func (a *Analyzer) analyzeIndexExpr(expr *ast.IndexExpr) *TypeInfo {
 	// Index must be int for lists
 	if leftType.Kind == TypeKindList {
 		if indexType.Kind != TypeKindInt && indexType.Kind != TypeKindUnknown {
-			a.error(expr.Pos(), "list index must be int")
+			a.errorMsg(expr.Pos(), catalog.ListIndexNotInt)
 		}
 		if leftType.ElementType != nil {
 			return leftType.ElementType
@@ -452,7 +842,7 @@ func (a *Analyzer) analyzeIndexExpr(expr *ast.IndexExpr) *TypeInfo {
 	// For maps, validate key type
 	if leftType.Kind == TypeKindMap {
 		if leftType.KeyType != nil && !a.typesCompatible(leftType.KeyType, indexType) {
-			a.error(expr.Pos(), fmt.Sprintf("cannot use %s as map key type %s", indexType, leftType.KeyType))
+			a.errorMsg(expr.Pos(), catalog.MapKeyType, indexType, leftType.KeyType)
 		}
 		if leftType.ValueType != nil {
 			return leftType.ValueType
@@ -468,14 +858,14 @@ func (a *Analyzer) analyzeSliceExpr(expr *ast.SliceExpr) *TypeInfo {
 	if expr.Start != nil {
 		startType := a.analyzeExpression(expr.Start)
 		if startType.Kind != TypeKindInt && startType.Kind != TypeKindUnknown {
-			a.error(expr.Pos(), "slice start must be int")
+			a.errorMsg(expr.Pos(), catalog.SliceStartNotInt)
 		}
 	}
 
 	if expr.End != nil {
 		endType := a.analyzeExpression(expr.End)
 		if endType.Kind != TypeKindInt && endType.Kind != TypeKindUnknown {
-			a.error(expr.Pos(), "slice end must be int")
+			a.errorMsg(expr.Pos(), catalog.SliceEndNotInt)
 		}
 	}
 
@@ -483,6 +873,23 @@ func (a *Analyzer) analyzeSliceExpr(expr *ast.SliceExpr) *TypeInfo {
 	return leftType
 }
 
+// analyzeMapLiteral types a map literal from its declared key and value
+// types, so m[k] keeps the value type (and its operator methods).
+func (a *Analyzer) analyzeMapLiteral(expr *ast.MapLiteralExpr) *TypeInfo {
+	for _, pair := range expr.Pairs {
+		a.analyzeExpression(pair.Key)
+		a.analyzeExpression(pair.Value)
+	}
+	if expr.KeyType == nil || expr.ValType == nil {
+		return &TypeInfo{Kind: TypeKindUnknown}
+	}
+	return &TypeInfo{
+		Kind:      TypeKindMap,
+		KeyType:   a.typeAnnotationToTypeInfo(expr.KeyType),
+		ValueType: a.typeAnnotationToTypeInfo(expr.ValType),
+	}
+}
+
 func (a *Analyzer) analyzeListLiteral(expr *ast.ListLiteralExpr) *TypeInfo {
 	var elemType *TypeInfo
 
@@ -494,7 +901,7 @@ func (a *Analyzer) analyzeListLiteral(expr *ast.ListLiteralExpr) *TypeInfo {
 		for i, elem := range expr.Elements[1:] {
 			et := a.analyzeExpression(elem)
 			if !a.typesCompatible(elemType, et) {
-				a.error(expr.Pos(), fmt.Sprintf("list element %d: incompatible type %s, expected %s", i+2, et, elemType))
+				a.errorMsg(expr.Pos(), catalog.ListElementType, i+2, et, elemType)
 			}
 		}
 	} else if expr.Type != nil {
@@ -25,6 +25,52 @@ func isValidIdentifier(name string) bool {
 	return true
 }
 
+// closestName returns the candidate that name is most likely a misspelling
+// of: a case-insensitive match, or the nearest by edit distance within a
+// third of the name's length. It returns "" when nothing is close.
+func closestName(name string, candidates []string) string {
+	best, bestDist := "", len(name)/3+1
+	for _, c := range candidates {
+		if c == name || c == "_" {
+			continue
+		}
+		if strings.EqualFold(c, name) {
+			return c
+		}
+		if d := editDistance(name, c); d < bestDist || (d == bestDist && best != "" && c < best) {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the edit distance between a and b, counting an
+// insertion, deletion, substitution or swap of adjacent characters as one edit.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	before := make([]int, len(br)+1)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				cur[j] = min(cur[j], before[j-2]+1)
+			}
+		}
+		before, prev, cur = prev, cur, before
+	}
+	return prev[len(br)]
+}
+
 func (a *Analyzer) extractPackageName(imp *ast.ImportDecl) string {
 	if imp.Alias != nil {
 		return imp.Alias.Value
@@ -105,21 +151,37 @@ func isNumericType(t *TypeInfo) bool {
 	return t.Kind == TypeKindInt || t.Kind == TypeKindFloat || t.Kind == TypeKindUnknown
 }
 
+func isStringType(t *TypeInfo) bool {
+	if t == nil {
+		return false
+	}
+	return t.Kind == TypeKindString || t.Kind == TypeKindUnknown
+}
+
+// isPrimitiveKind reports whether a type declared over k is a number, string
+// or bool type: the types `distinct` applies to.
+func isPrimitiveKind(k TypeKind) bool {
+	return k == TypeKindInt || k == TypeKindFloat || k == TypeKindString || k == TypeKindBool
+}
+
 func primitiveTypeFromString(name string) *TypeInfo {
 	switch name {
-	case "int", "int8", "int16", "int32", "int64",
-		"uint", "uint8", "uint16", "uint32", "uint64":
+	case "int":
 		return &TypeInfo{Kind: TypeKindInt}
-	case "float32", "float64":
+	case "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		// Name keeps the Go type for conversions; compatibility goes by Kind
+		return &TypeInfo{Kind: TypeKindInt, Name: name}
+	case "float64":
 		return &TypeInfo{Kind: TypeKindFloat}
+	case "float32":
+		return &TypeInfo{Kind: TypeKindFloat, Name: name}
 	case "string":
 		return &TypeInfo{Kind: TypeKindString}
 	case "bool":
 		return &TypeInfo{Kind: TypeKindBool}
-	case "byte":
-		return &TypeInfo{Kind: TypeKindInt}
-	case "rune":
-		return &TypeInfo{Kind: TypeKindInt}
+	case "byte", "rune":
+		return &TypeInfo{Kind: TypeKindInt, Name: name}
 	default:
 		return &TypeInfo{Kind: TypeKindUnknown}
 	}
@@ -0,0 +1,70 @@
+package semantic
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// builtinInterfaces holds the method sets of Go's predeclared interfaces.
+var builtinInterfaces = map[string]map[string]*TypeInfo{
+	"error": {"Error": {Kind: TypeKindFunction, Returns: []*TypeInfo{{Kind: TypeKindString}}}},
+}
+
+// analyzeImplementsDecl checks `T implements I, ...`: T must be a declared
+// type, and it must have every method of each local (or predeclared)
+// interface with the same signature. Interfaces from other packages are
+// left to the Go compiler, through the assertion codegen emits.
+func (a *Analyzer) analyzeImplementsDecl(decl *ast.ImplementsDecl) {
+	typeName := decl.Type.Value
+	if sym := a.symbolTable.Resolve(typeName); sym == nil || sym.Kind != SymbolType {
+		a.errorMsg(decl.Type.Pos(), catalog.UndefinedType, typeName)
+		return
+	}
+	for _, iface := range decl.Interfaces {
+		named, ok := iface.(*ast.NamedType)
+		if !ok {
+			a.errorMsg(iface.Pos(), catalog.NotAnInterface, a.typeAnnotationToTypeInfo(iface))
+			continue
+		}
+		a.validateTypeAnnotation(named)
+		want, ok := a.interfaceMethods(named)
+		if !ok {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(want)) {
+			have, exists := a.methods[typeName][name]
+			if !exists {
+				a.errorMsg(named.Pos(), catalog.MissingInterfaceMethod, typeName, named.Name, name)
+				continue
+			}
+			if have.String() != want[name].String() || have.Variadic != want[name].Variadic {
+				a.errorMsg(named.Pos(), catalog.InterfaceMethodMismatch, typeName, named.Name, name, have, want[name])
+			}
+		}
+	}
+}
+
+// interfaceMethods returns the method set of the interface named by t when
+// the analyzer knows it. It reports a local type that is not an interface;
+// undefined names were already reported by validateTypeAnnotation.
+func (a *Analyzer) interfaceMethods(t *ast.NamedType) (map[string]*TypeInfo, bool) {
+	if methods, ok := builtinInterfaces[t.Name]; ok {
+		return methods, true
+	}
+	if strings.Contains(t.Name, ".") {
+		return nil, false
+	}
+	sym := a.symbolTable.Resolve(t.Name)
+	if sym == nil {
+		return nil, false
+	}
+	if sym.Kind != SymbolInterface {
+		a.errorMsg(t.Pos(), catalog.NotAnInterface, t.Name)
+		return nil, false
+	}
+	return a.methods[t.Name], true
+}
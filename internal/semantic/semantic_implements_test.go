@@ -0,0 +1,65 @@
+package semantic
+
+import (
+	"testing"
+)
+
+func TestImplementsConformance(t *testing.T) {
+	input := `import "fmt"
+
+interface Shape
+    Area() float64
+    Scale(factor float64)
+
+type Circle
+    r float64
+
+Circle implements Shape, fmt.Stringer, error
+
+func Area on c Circle float64
+    return c.r
+
+func Scale on c reference Circle(factor float64)
+    c.r = c.r * factor
+
+func Error on c Circle string
+    return "circle"
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestImplementsErrors(t *testing.T) {
+	input := `interface Shape
+    Area() float64
+    Name() string
+
+type Sq
+    s int
+
+func Area on q Sq int
+    return q.s
+
+Sq implements Shape
+Sq implements Sq
+Nope implements Shape
+Sq implements list of int
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"test.kuki:11:14: Sq does not implement Shape: method Area is func() int, want func() float",
+		"test.kuki:11:14: Sq does not implement Shape: missing method Name",
+		"test.kuki:12:14: 'Sq' is not an interface",
+		"test.kuki:13:0: undefined type 'Nope'",
+		"test.kuki:14:14: 'list of int' is not an interface",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
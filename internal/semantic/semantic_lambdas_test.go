@@ -1,6 +1,7 @@
 package semantic
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/duber000/kukicha/internal/ast"
@@ -288,3 +289,66 @@ func Foo()
 		t.Errorf("expected TypeKindString for 'r', got %v", ti.Kind)
 	}
 }
+
+func TestInferLambdaSignature_NamedFuncType(t *testing.T) {
+	src := `petiole main
+
+type User
+    Name string
+
+type Namer func(User) string
+
+func apply(n Namer) string
+    return n(User{Name: "a"})
+
+func Foo()
+    name := apply(u => u.Name)
+    bad := name + 1
+`
+	a, errs := analyzeSource(t, src)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cannot apply + to string and int") {
+		t.Fatalf("expected one string + int error, got %v", errs)
+	}
+	ti := findLambdaParamType(a, "u")
+	if ti == nil || ti.Kind != TypeKindNamed || ti.Name != "User" {
+		t.Errorf("expected lambda param 'u' inferred as User, got %v", ti)
+	}
+}
+
+func TestLambdaBlockReturn_CheckedAgainstLambda(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"matches lambda, not enclosing func", "return false, error \"empty\"", ""},
+		{"wrong count", "return 1", "expected 2"},
+		{"wrong type", "return \"no\", empty", "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := `petiole main
+
+func each(items list of string, visit func(string) (bool, error))
+    for s in items
+        visit(s)
+
+func Foo() string
+    each(list of string{"a"}, s =>
+        ` + tt.body + `
+    )
+    return ""
+`
+			_, errs := analyzeSource(t, src)
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Fatalf("expected one error containing %q, got %v", tt.wantErr, errs)
+			}
+		})
+	}
+}
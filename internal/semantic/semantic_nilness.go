@@ -0,0 +1,186 @@
+package semantic
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// Nil-use analysis for `reference T` variables.
+//
+// a.maybeNil maps a variable's symbol to the position where it became (or was
+// proven) empty. The set is threaded through statements in source order:
+// assigning empty adds a symbol, assigning anything else removes it, and an
+// `if p == empty` / `if p != empty` test narrows each branch. Where branches
+// join, the sets are unioned, skipping branches that end in return, break,
+// continue, or panic. Field access and `dereference` on a symbol still in the
+// set produce a "possible nil dereference" warning.
+//
+// The analysis is deliberately local and optimistic: parameters and call
+// results are assumed non-empty unless a comparison says otherwise, so every
+// warning points at an empty that is visible in the same function.
+
+type nilSet map[*Symbol]ast.Position
+
+func (s nilSet) clone() nilSet {
+	out := make(nilSet, len(s))
+	for k, v := range s {
+		out[k] = v
+	}
+	return out
+}
+
+func (s nilSet) union(other nilSet) {
+	for k, v := range other {
+		if _, ok := s[k]; !ok {
+			s[k] = v
+		}
+	}
+}
+
+// resetNilness starts a fresh nil-use state for a function or closure body and
+// returns the previous state so the caller can restore it.
+func (a *Analyzer) resetNilness() nilSet {
+	prev := a.maybeNil
+	a.maybeNil = make(nilSet)
+	return prev
+}
+
+// isNilLiteral reports whether expr is the `empty` literal (typed or untyped).
+func (a *Analyzer) isNilLiteral(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.EmptyExpr:
+		return true
+	case *ast.Identifier:
+		if e.Value == "empty" {
+			t, ok := a.exprTypes[e]
+			return ok && t.Kind == TypeKindNil
+		}
+	}
+	return false
+}
+
+// trackNilAssignment updates the nil state after `sym := value` or `sym = value`.
+func (a *Analyzer) trackNilAssignment(sym *Symbol, value ast.Expression) {
+	if sym == nil || a.maybeNil == nil {
+		return
+	}
+	if sym.Type != nil && sym.Type.Kind == TypeKindReference && a.isNilLiteral(value) {
+		a.maybeNil[sym] = value.Pos()
+		return
+	}
+	delete(a.maybeNil, sym)
+}
+
+// nilComparison recognises `x == empty`, `x != empty` (and the `equals` /
+// `not equals` spellings, with either operand order) on a reference variable.
+func (a *Analyzer) nilComparison(cond ast.Expression) (sym *Symbol, isEmpty bool, ok bool) {
+	bin, isBin := cond.(*ast.BinaryExpr)
+	if !isBin {
+		return nil, false, false
+	}
+	switch bin.Operator {
+	case "==", "equals":
+		isEmpty = true
+	case "!=", "not equals":
+		isEmpty = false
+	default:
+		return nil, false, false
+	}
+	var id *ast.Identifier
+	if left, isID := bin.Left.(*ast.Identifier); isID && a.isNilLiteral(bin.Right) {
+		id = left
+	} else if right, isID := bin.Right.(*ast.Identifier); isID && a.isNilLiteral(bin.Left) {
+		id = right
+	}
+	if id == nil {
+		return nil, false, false
+	}
+	sym = a.symbolTable.Resolve(id.Value)
+	if sym == nil || sym.Type == nil || sym.Type.Kind != TypeKindReference {
+		return nil, false, false
+	}
+	return sym, isEmpty, true
+}
+
+// narrowNil records what a nil comparison proves about sym inside a branch.
+func (a *Analyzer) narrowNil(sym *Symbol, isEmpty bool, pos ast.Position) {
+	if isEmpty {
+		a.maybeNil[sym] = pos
+	} else {
+		delete(a.maybeNil, sym)
+	}
+}
+
+// checkNilDeref warns when a field access or dereference reads through a
+// variable that may still be empty at this point.
+func (a *Analyzer) checkNilDeref(operand ast.Expression) {
+	id, ok := operand.(*ast.Identifier)
+	if !ok || len(a.maybeNil) == 0 {
+		return
+	}
+	sym := a.symbolTable.Resolve(id.Value)
+	if sym == nil {
+		return
+	}
+	where, ok := a.maybeNil[sym]
+	if !ok {
+		return
+	}
+	a.warn(id.Pos(), fmt.Sprintf("possible nil dereference: '%s' may be empty here (empty at %s:%d); check 'if %s != empty' first",
+		id.Value, where.File, where.Line, id.Value))
+	// Report each empty value once; the first use is the one to fix.
+	delete(a.maybeNil, sym)
+}
+
+// blockTerminates reports whether control cannot fall off the end of block
+// because its last statement is a return, break, continue, or panic.
+func blockTerminates(block *ast.BlockStmt) bool {
+	if block == nil || len(block.Statements) == 0 {
+		return false
+	}
+	switch s := block.Statements[len(block.Statements)-1].(type) {
+	case *ast.ReturnStmt, *ast.BreakStmt, *ast.ContinueStmt:
+		return true
+	case *ast.ExpressionStmt:
+		_, isPanic := s.Expression.(*ast.PanicExpr)
+		return isPanic
+	}
+	return false
+}
+
+// trackNilAssign updates the nil state for the identifier targets of an assignment.
+func (a *Analyzer) trackNilAssign(stmt *ast.AssignStmt) {
+	for i, target := range stmt.Targets {
+		id, ok := target.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		sym := a.symbolTable.Resolve(id.Value)
+		if len(stmt.Values) == len(stmt.Targets) {
+			a.trackNilAssignment(sym, stmt.Values[i])
+		} else if sym != nil && a.maybeNil != nil {
+			delete(a.maybeNil, sym)
+		}
+	}
+}
+
+// analyzeOnErrWithNilResults analyzes the onerr handler of a declaration with
+// its reference-typed results marked empty: the handler only runs when the
+// call failed, so those results hold their zero value.
+func (a *Analyzer) analyzeOnErrWithNilResults(stmt *ast.VarDeclStmt) {
+	if stmt.OnErr == nil || a.maybeNil == nil {
+		a.analyzeOnErrClause(stmt.OnErr)
+		return
+	}
+	saved := a.maybeNil.clone()
+	tok := stmt.OnErr.Token
+	pos := ast.TokenPos(tok)
+	for _, name := range stmt.Names {
+		if sym := a.symbolTable.CurrentScope().symbols[name.Value]; sym != nil && sym.Type != nil && sym.Type.Kind == TypeKindReference {
+			a.maybeNil[sym] = pos
+		}
+	}
+	a.analyzeOnErrClause(stmt.OnErr)
+	a.maybeNil = saved
+}
@@ -0,0 +1,118 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func nilWarnings(t *testing.T, input string) []string {
+	t.Helper()
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	var out []string
+	for _, w := range warnings {
+		if strings.Contains(w.Error(), "possible nil dereference") {
+			out = append(out, w.Error())
+		}
+	}
+	return out
+}
+
+func TestNilDerefAfterEmptyAssignment(t *testing.T) {
+	input := `type User
+    name string
+
+func main()
+    u := empty reference User
+    print(u.name)
+`
+	warnings := nilWarnings(t, input)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "'u' may be empty here (empty at app.kuki:5)") {
+		t.Errorf("expected nil dereference warning, got: %v", warnings)
+	}
+}
+
+func TestNilDerefGuardedByNotEmpty(t *testing.T) {
+	input := `type User
+    name string
+
+func lookup() reference User
+    return empty
+
+func main()
+    u := empty reference User
+    if u != empty
+        print(u.name)
+    u = lookup()
+    print(u.name)
+`
+	if warnings := nilWarnings(t, input); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got: %v", warnings)
+	}
+}
+
+func TestNilDerefEarlyReturnNarrows(t *testing.T) {
+	input := `type User
+    name string
+
+func greet(u reference User) string
+    if u == empty
+        return ""
+    return u.name
+`
+	if warnings := nilWarnings(t, input); len(warnings) != 0 {
+		t.Errorf("expected no warnings after early return, got: %v", warnings)
+	}
+}
+
+func TestNilDerefInsideEmptyBranch(t *testing.T) {
+	input := `type User
+    name string
+
+func greet(u reference User) string
+    if u == empty
+        print("missing {u.name}")
+    return "ok"
+`
+	warnings := nilWarnings(t, input)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "'u' may be empty") {
+		t.Errorf("expected warning inside the empty branch, got: %v", warnings)
+	}
+}
+
+func TestNilDerefJoinsBranches(t *testing.T) {
+	input := `type User
+    name string
+
+func pick(flag bool, fallback reference User) string
+    u := fallback
+    if flag
+        u = empty
+    return u.name
+`
+	warnings := nilWarnings(t, input)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "(empty at app.kuki:7)") {
+		t.Errorf("expected warning from the branch that set empty, got: %v", warnings)
+	}
+}
+
+func TestNilDerefInOnErrHandler(t *testing.T) {
+	input := `type User
+    name string
+
+func load() (reference User, error)
+    return empty, empty
+
+func main()
+    u := load() onerr
+        print("failed for {u.name}")
+        return
+    print(u.name)
+`
+	warnings := nilWarnings(t, input)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "app.kuki:9:") {
+		t.Errorf("expected one warning inside the onerr handler, got: %v", warnings)
+	}
+}
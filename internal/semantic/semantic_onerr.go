@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+	"github.com/duber000/kukicha/internal/lexer"
 )
 
 // analyzeOnErrClause analyzes the onerr clause on a statement
@@ -13,14 +15,23 @@ func (a *Analyzer) analyzeOnErrClause(clause *ast.OnErrClause) {
 		return
 	}
 
-	pos := ast.Position{Line: clause.Token.Line, Column: clause.Token.Column, File: clause.Token.File}
+	pos := ast.TokenPos(clause.Token)
+
+	if a.closureBlock != "" && onErrReturns(clause) {
+		if a.closureBlock == "safely" {
+			a.errorMsg(pos, catalog.OnerrReturnInSafely)
+		} else {
+			a.errorMsg(pos, catalog.OnerrReturnInBlock, a.closureBlock)
+		}
+		return
+	}
 
 	// Validate bare "onerr return" shorthand: enclosing function must return an error.
 	if clause.ShorthandReturn {
 		if a.currentFunc == nil {
-			a.error(pos, "'onerr return' used outside of a function")
+			a.errorMsg(pos, catalog.OnerrReturnOutsideFunction)
 		} else if !funcReturnsError(a.currentFunc) {
-			a.error(pos, "'onerr return' requires the enclosing function to return an error; use an explicit handler instead")
+			a.errorMsg(pos, catalog.OnerrReturnNoError)
 		}
 		return
 	}
@@ -28,7 +39,7 @@ func (a *Analyzer) analyzeOnErrClause(clause *ast.OnErrClause) {
 	// Validate "onerr continue" — must be inside a loop.
 	if clause.ShorthandContinue {
 		if a.loopDepth == 0 {
-			a.error(pos, "'onerr continue' used outside of a loop")
+			a.errorMsg(pos, catalog.OnerrContinueOutsideLoop)
 		}
 		return
 	}
@@ -36,7 +47,7 @@ func (a *Analyzer) analyzeOnErrClause(clause *ast.OnErrClause) {
 	// Validate "onerr break" — must be inside a loop or switch.
 	if clause.ShorthandBreak {
 		if a.loopDepth == 0 && a.switchDepth == 0 {
-			a.error(pos, "'onerr break' used outside of a loop or switch")
+			a.errorMsg(pos, catalog.OnerrBreakOutsideLoop)
 		}
 		return
 	}
@@ -77,6 +88,109 @@ func (a *Analyzer) analyzeOnErrClause(clause *ast.OnErrClause) {
 	a.currentOnerrrAlias = prevAlias
 }
 
+// analyzeSafelyStmt checks a safely block. Codegen runs the body in a func
+// literal, so a return (or an onerr that returns) would only leave the
+// literal and a break or continue cannot reach an enclosing loop; both are
+// errors in the body. The onerr clause runs in the enclosing function.
+func (a *Analyzer) analyzeSafelyStmt(stmt *ast.SafelyStmt) {
+	savedClosureBlock, savedLoopDepth, savedSwitchDepth := a.closureBlock, a.loopDepth, a.switchDepth
+	a.closureBlock, a.loopDepth, a.switchDepth = "safely", 0, 0
+	a.symbolTable.EnterScope()
+	a.analyzeBlock(stmt.Body)
+	a.symbolTable.ExitScope()
+	a.closureBlock, a.loopDepth, a.switchDepth = savedClosureBlock, savedLoopDepth, savedSwitchDepth
+	a.analyzeOnErrClause(stmt.OnErr)
+}
+
+// analyzeOnErrExpr checks an expression-level onerr (Config{port: GetPort()
+// onerr 8080}): it must be in a position codegen can hoist out of the
+// statement, and the expression must return a value and an error. Its type
+// is the value's.
+func (a *Analyzer) analyzeOnErrExpr(expr *ast.OnErrExpr) *TypeInfo {
+	if !a.onErrExprSites[expr] {
+		a.errorMsg(expr.Pos(), catalog.OnerrExprPlacement)
+	}
+	types := a.analyzeExpressionMulti(expr.Expression)
+	a.recordType(expr.Expression, types[0])
+	value := types[0]
+	switch {
+	case len(types) == 1 && value.Kind == TypeKindUnknown:
+		// Unresolved call: Go checks the result count
+	case len(types) != 2 || (types[1].Kind != TypeKindUnknown && !isErrorTypeInfo(types[1])):
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = t.String()
+		}
+		a.errorMsg(expr.Pos(), catalog.OnerrExprResults, strings.Join(names, ", "))
+		value = &TypeInfo{Kind: TypeKindUnknown}
+	}
+	a.analyzeOnErrClause(expr.OnErr)
+	a.checkOnErrFallback(expr.OnErr, value)
+	return value
+}
+
+// onErrExprSites returns the set of ast.OnErrExprs of stmt, the positions
+// codegen can hoist an expression-level onerr from.
+func onErrExprSites(stmt ast.Statement) map[*ast.OnErrExpr]bool {
+	exprs := ast.OnErrExprs(stmt)
+	if len(exprs) == 0 {
+		return nil
+	}
+	sites := make(map[*ast.OnErrExpr]bool, len(exprs))
+	for _, e := range exprs {
+		sites[e] = true
+	}
+	return sites
+}
+
+// onErrReturns reports whether clause returns from the enclosing function.
+func onErrReturns(clause *ast.OnErrClause) bool {
+	if clause.ShorthandReturn || (clause.Handler == nil && clause.Explain != "") {
+		return true
+	}
+	switch clause.Handler.(type) {
+	case *ast.ReturnExpr, *ast.ErrorExpr:
+		return true
+	}
+	return false
+}
+
+// isFallbackValue reports whether an onerr handler is a value that stands in
+// for the result (x := f() onerr 0) rather than an action. Calls are actions:
+// codegen runs them as statements.
+func isFallbackValue(handler ast.Expression) bool {
+	switch handler.(type) {
+	case nil, *ast.PanicExpr, *ast.ErrorExpr, *ast.ReturnExpr, *ast.BlockExpr,
+		*ast.EmptyExpr, *ast.DiscardExpr, *ast.CallExpr, *ast.MethodCallExpr:
+		return false
+	}
+	return true
+}
+
+// checkOnErrFallback checks that the fallback value of `x := f() onerr v`
+// fits target, the type of x. An int fallback for a float is allowed (codegen
+// converts it); other mismatches between basic types are errors here rather
+// than Go errors against the generated assignment.
+func (a *Analyzer) checkOnErrFallback(clause *ast.OnErrClause, target *TypeInfo) {
+	if target == nil || clause.ShorthandReturn || clause.ShorthandContinue || clause.ShorthandBreak ||
+		!isFallbackValue(clause.Handler) {
+		return
+	}
+	value := a.exprTypes[clause.Handler]
+	if value == nil || !isBasicKind(value.Kind) || !isBasicKind(target.Kind) || value.Kind == target.Kind {
+		return
+	}
+	if value.Kind == TypeKindInt && target.Kind == TypeKindFloat {
+		return
+	}
+	a.errorMsg(clause.Handler.Pos(), catalog.OnerrFallbackType, value, target)
+}
+
+// isBasicKind reports whether k is int, float, string, or bool.
+func isBasicKind(k TypeKind) bool {
+	return k == TypeKindInt || k == TypeKindFloat || k == TypeKindString || k == TypeKindBool
+}
+
 // funcReturnsError reports whether the function's last return type is "error".
 func funcReturnsError(decl *ast.FunctionDecl) bool {
 	if len(decl.Returns) == 0 {
@@ -97,13 +211,11 @@ func (a *Analyzer) analyzeStringInterpolation(lit *ast.StringLiteral) {
 			// Check for {err} in onerr context
 			if a.inOnerr {
 				if ident, ok := part.Expr.(*ast.Identifier); ok && ident.Value == "err" {
-					hint := "use {error} not {err} inside onerr — the caught error is always named 'error'"
 					if a.currentOnerrrAlias != "" {
-						hint += fmt.Sprintf(", or {%s} via your 'onerr as %s' alias", a.currentOnerrrAlias, a.currentOnerrrAlias)
+						a.errorMsg(lit.Pos(), catalog.ErrInOnerrAlias, a.currentOnerrrAlias, a.currentOnerrrAlias)
 					} else {
-						hint += ", or name it with 'onerr as e' and use {e}"
+						a.errorMsg(lit.Pos(), catalog.ErrInOnerr)
 					}
-					a.error(lit.Pos(), hint)
 					continue
 				}
 			}
@@ -115,33 +227,34 @@ func (a *Analyzer) analyzeStringInterpolation(lit *ast.StringLiteral) {
 					}
 				}
 			}
-			// Patch position info for better error reporting
-			patchExprPosition(part.Expr, lit.Token.File, lit.Token.Line, lit.Token.Column)
+			// Hole tokens come from the lexer with their own positions; only
+			// expressions without one fall back to the string's position
+			patchExprPosition(part.Expr, lit.Token)
+			outer := a.inInterpolation
+			a.inInterpolation = true
 			a.analyzeExpression(part.Expr)
+			a.inInterpolation = outer
 		}
+		a.checkLengthInterpolation(lit)
 		return
 	}
 }
 
-// patchExprPosition updates position info on an expression for error reporting.
-func patchExprPosition(expr ast.Expression, file string, line, column int) {
+// patchExprPosition gives an expression that has no position the position
+// of at, for error reporting.
+func patchExprPosition(expr ast.Expression, at lexer.Token) {
+	var ident *ast.Identifier
 	switch e := expr.(type) {
 	case *ast.Identifier:
-		e.Token.File = file
-		e.Token.Line = line
-		e.Token.Column = column
+		ident = e
 	case *ast.MethodCallExpr:
-		if obj, ok := e.Object.(*ast.Identifier); ok {
-			obj.Token.File = file
-			obj.Token.Line = line
-			obj.Token.Column = column
-		}
+		ident, _ = e.Object.(*ast.Identifier)
 	case *ast.FieldAccessExpr:
-		if obj, ok := e.Object.(*ast.Identifier); ok {
-			obj.Token.File = file
-			obj.Token.Line = line
-			obj.Token.Column = column
-		}
+		ident, _ = e.Object.(*ast.Identifier)
+	}
+	if ident == nil || ident.Token.Line() > 0 {
+		return
 	}
+	ident.Token = ident.Token.At(at)
 }
 
@@ -166,3 +166,41 @@ func TestComplexStringInterpolationUndefinedError(t *testing.T) {
 		t.Fatalf("expected undefined identifier error for 'unknown' in interpolation, got: %v", errors)
 	}
 }
+
+func TestStringInterpolationHolePositionAndSuggestion(t *testing.T) {
+	input := `func main()
+    userName := "Alice"
+    print("Hello, {usrName}! Total: {len(itms)}")
+`
+
+	_, errors := analyzeSource(t, input)
+	if len(errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errors)
+	}
+	// Reported at the holes, not at the start of the string
+	want := []string{
+		"test.kuki:3:19: undefined identifier 'usrName' in string interpolation (did you mean 'userName'?)",
+		"test.kuki:3:41: undefined identifier 'itms' in string interpolation",
+	}
+	for i, e := range errors {
+		if e.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, e.Error(), want[i])
+		}
+	}
+}
+
+func TestClosestName(t *testing.T) {
+	candidates := []string{"userName", "count", "items", "_"}
+	tests := map[string]string{
+		"usrName":  "userName",
+		"username": "userName",
+		"cnt":      "",
+		"itmes":    "items",
+		"x":        "",
+	}
+	for name, want := range tests {
+		if got := closestName(name, candidates); got != want {
+			t.Errorf("closestName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// operatorBase maps each binary operator an operator method can serve to
+// the operator it is declared as: the other comparisons derive from == and <.
+var operatorBase = map[string]string{
+	"+": "+", "-": "-", "*": "*", "/": "/",
+	"==": "==", "equals": "==", "!=": "==", "not equals": "==",
+	"<": "<", ">": "<", "<=": "<", ">=": "<",
+}
+
+// collectOperators indexes the operator methods (func Add on v Vector(o
+// Vector) Vector operator +) by type and operator, checking that each is a
+// value-receiver method of a struct type with one parameter and one result.
+func (a *Analyzer) collectOperators() {
+	for _, decl := range a.program.Declarations {
+		fn, ok := decl.(*ast.FunctionDecl)
+		if !ok || fn.Operator == "" {
+			continue
+		}
+		op := fn.Operator
+		if op == "equals" {
+			op = "=="
+		}
+		if base, ok := operatorBase[op]; !ok || base != op {
+			a.errorMsg(fn.Name.Pos(), catalog.OperatorUnsupported, fn.Operator)
+			continue
+		}
+		typeName := ""
+		if fn.Receiver != nil {
+			if _, isRef := fn.Receiver.Type.(*ast.ReferenceType); !isRef {
+				typeName = receiverTypeName(fn.Receiver)
+			}
+		}
+		sym := a.symbolTable.Resolve(typeName)
+		if typeName == "" || sym == nil || sym.Kind != SymbolType || sym.Type.Kind != TypeKindStruct {
+			a.errorMsg(fn.Name.Pos(), catalog.OperatorNotMethod, op)
+			continue
+		}
+		sig := a.methods[typeName][fn.Name.Value]
+		if len(sig.Params) != 1 || len(sig.Returns) != 1 ||
+			((op == "==" || op == "<") && sig.Returns[0].Kind != TypeKindBool) {
+			a.errorMsg(fn.Name.Pos(), catalog.OperatorSignature, op, fn.Name.Value)
+			continue
+		}
+		if a.operators[typeName] == nil {
+			a.operators[typeName] = make(map[string]string)
+		}
+		if prev, ok := a.operators[typeName][op]; ok {
+			a.errorMsg(fn.Name.Pos(), catalog.OperatorDuplicate, typeName, op, prev)
+			continue
+		}
+		a.operators[typeName][op] = fn.Name.Value
+	}
+}
+
+// operatorCall resolves a binary operator whose left operand's type declares
+// an operator method for it, recording the method on expr for codegen. It
+// returns the result type, or nil when no operator method applies. A stdlib
+// type's operator methods (decimal.Decimal) take and return the type itself.
+func (a *Analyzer) operatorCall(expr *ast.BinaryExpr, left, right *TypeInfo) *TypeInfo {
+	if left.Kind != TypeKindStruct && left.Kind != TypeKindNamed {
+		return nil
+	}
+	base, ok := operatorBase[expr.Operator]
+	if !ok {
+		return nil
+	}
+	param, result := left, left
+	method, ok := a.operators[left.Name][base]
+	if ok {
+		sig := a.methods[left.Name][method]
+		param, result = sig.Params[0], sig.Returns[0]
+	} else if method, ok = generatedStdlibOperators[left.Name][base]; !ok {
+		return nil
+	}
+	if !a.typesCompatible(param, right) {
+		a.errorMsg(expr.Pos(), catalog.InvalidOperands, expr.Operator, left, right)
+	}
+	expr.Method = method
+	if base == "==" || base == "<" {
+		return &TypeInfo{Kind: TypeKindBool}
+	}
+	return result
+}
@@ -341,3 +341,261 @@ func Wrap(handler http.Handler) http.Handler
 		}
 	}
 }
+
+func TestStdlibDateReturnsTime(t *testing.T) {
+	// date.Parse and date.Today return time.Time, so Before/After resolve to
+	// bool and misuse of the result is caught.
+	input := `import "stdlib/date"
+
+func main()
+    due := date.Parse("2024-01-02") onerr panic "bad date"
+    if due.Before(date.Today()) and date.Today().After(due)
+        print(date.Format(due, "D MMMM YYYY"))
+    n := due.Before(date.Today()) + 1
+`
+	program := mustParseProgram(t, input)
+	analyzer := New(program)
+	analyzer.SetStrictTypes(true)
+	errs := analyzer.Analyze()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cannot apply + to bool and int") {
+		t.Fatalf("expected only the bool + int error, got %v", errs)
+	}
+}
+
+func TestStdlibMathResultTypes(t *testing.T) {
+	// stdlib/math shadows Go's math in the registries, and its number/ordered
+	// results take the type of the values passed in. Each line adds a bool
+	// so the error spells out the inferred type.
+	input := `import "stdlib/math"
+
+func main()
+    scores := list of int{3, 1, 2}
+    a := math.Sum(scores) + true
+    b := math.Abs(-3) + true
+    c := math.Clamp(1.5, 0, 1) + true
+    d := math.Mean(scores) + true
+    e := math.Min(list of string{"b", "a"}) + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to int and bool",
+		"cannot apply + to int and bool",
+		"cannot apply + to float and bool",
+		"cannot apply + to float and bool",
+		"cannot apply + to string and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibRandomResultTypes(t *testing.T) {
+	// random.Choice returns (element, error), so onerr applies and the
+	// element keeps the list's type; the scalar helpers have fixed types.
+	input := `import "stdlib/random"
+
+func main()
+    names := list of string{"ann", "bob"}
+    pick := random.Choice(names) onerr panic "no names"
+    first, err := random.Choice(list of int{1, 2})
+    a := pick + true
+    b := random.Int(1, 6) + true
+    c := random.Float() + true
+    d := random.UUID() + true
+    e := random.Shuffle(names)[0] + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to int and bool",
+		"cannot apply + to float and bool",
+		"cannot apply + to string and bool",
+		"cannot apply + to string and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibHashPipesAndOnErr(t *testing.T) {
+	// stdlib/hash shadows Go's hash package; digests are bytes, encoders
+	// return strings, and the decoders return an error for onerr.
+	input := `import "stdlib/hash"
+
+func main()
+    digest := "hello" |> hash.SHA256() |> hash.Hex()
+    raw := hash.FromBase64("aGk=") onerr panic "bad base64"
+    a := digest + true
+    b := len(raw) + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to int and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibFetchStreamingTypes(t *testing.T) {
+	// Socket fields are typed channels, so receive, send and select see
+	// strings; SSE and Events are iterators whose loop variable is an Event.
+	input := `import "stdlib/fetch"
+
+func main()
+    ws := fetch.WebSocket("ws://localhost") onerr panic "dial"
+    send "hi" to ws.Send
+    msg := receive from ws.Receive
+    a := msg + true
+    events := fetch.SSE("http://localhost/stream") onerr panic "sse"
+    for ev in events
+        b := ev.Data + true
+    for m in ws.Receive
+        c := m + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to string and bool",
+		"cannot apply + to string and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibLimitStageTypes(t *testing.T) {
+	// Limited passes its value through, and Run/Guard take their result
+	// type from the step, including as the step of a parallel pipe.
+	input := `import "stdlib/limit"
+
+func shout(s string) (string, error)
+    return s, empty
+
+func main()
+    rate := limit.NewRate(10)
+    slots := limit.Semaphore(2)
+    word := "go" |> limit.Limited(rate)
+    loud := list of string{"a"} |>> limit.Run(rate, shout) onerr panic "{error}"
+    one := limit.Guard("b", slots, shout) onerr panic "{error}"
+    a := word + true
+    b := loud[0] + true
+    c := one + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to string and bool",
+		"cannot apply + to string and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibSampleResultTypes(t *testing.T) {
+	// Sample-pattern calls return the sample's type, piped or not, and
+	// cache.Memoize returns a function with that result type.
+	input := `import "stdlib/cache"
+import "stdlib/fetch"
+
+func lookup(name string) (int, error)
+    return len(name), empty
+
+func count() (int, error)
+    return 1, empty
+
+func main()
+    c := cache.New(0)
+    word, _ := cache.Get(c, "k", "")
+    n := cache.Remember(c, "k", 0, count) onerr panic "{error}"
+    cached := cache.Memoize(c, 0, lookup)
+    m := cached("x") onerr panic "{error}"
+    names := fetch.Get("https://example.com") |> fetch.Json(list of string) onerr panic "{error}"
+    a := word + true
+    b := n + true
+    d := m + true
+    e := names[0] + true
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to int and bool",
+		"cannot apply + to int and bool",
+		"cannot apply + to string and bool",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibOsxTypes(t *testing.T) {
+	input := `import "stdlib/osx"
+
+func main()
+    path := osx.Which("git") onerr panic "{error}"
+    home := osx.ExpandPath("~/x") onerr panic "{error}"
+    a := path + true
+    b := home + 1
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"cannot apply + to string and bool",
+		"cannot apply + to string and int",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStdlibPoolTypes(t *testing.T) {
+	input := `import "stdlib/pool"
+
+func upper(s string) (string, error)
+    return s, empty
+
+func main()
+    got := list of string{"a"} |> pool.Map(upper) onerr panic "{error}"
+    a := got[0] + true
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cannot apply + to string and bool") {
+		t.Fatalf("expected one string + bool error, got %v", errs)
+	}
+}
@@ -0,0 +1,163 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// petiole holds the exported API of another petiole of the module, with the
+// petiole's own type names unqualified (Client, not health.Client).
+type petiole struct {
+	funcs   map[string]*TypeInfo            // function name → signature
+	methods map[string]map[string]*TypeInfo // type name → method name → signature
+	types   map[string]*TypeInfo            // type name → its declaration
+}
+
+// RegisterPetiole records the exported functions and methods declared in
+// programs, the .kuki files of the petiole the program imports as path, so
+// calls into it are checked like calls to local functions instead of being
+// left untyped for the Go compiler. Call before Analyze.
+//
+// Structs keep their exported fields and interfaces stay interfaces; other
+// named types (enums, aliases of lists or funcs) are left Unknown, since the
+// constants and literals that fill them carry no type here. Parameter
+// defaults only apply within a petiole, so every parameter is required.
+func (a *Analyzer) RegisterPetiole(path string, programs ...*ast.Program) {
+	merged := &ast.Program{}
+	for _, program := range programs {
+		merged.Declarations = append(merged.Declarations, program.Declarations...)
+	}
+	sub := New(merged)
+	sub.initTables()
+	sub.collectDeclarations()
+
+	p := &petiole{
+		funcs:   make(map[string]*TypeInfo),
+		methods: make(map[string]map[string]*TypeInfo),
+		types:   make(map[string]*TypeInfo),
+	}
+	for name, sym := range sub.symbolTable.scopes[0].symbols {
+		switch {
+		case sym.Kind == SymbolFunction && sym.Exported:
+			p.funcs[name] = sym.Type
+		case sym.Kind == SymbolType || sym.Kind == SymbolInterface:
+			p.types[name] = sym.Type
+		}
+	}
+	for typeName, methods := range sub.methods {
+		if p.types[typeName] == nil || !isExported(typeName) {
+			continue
+		}
+		for name, sig := range methods {
+			if isExported(name) {
+				if p.methods[typeName] == nil {
+					p.methods[typeName] = make(map[string]*TypeInfo)
+				}
+				p.methods[typeName][name] = sig
+			}
+		}
+	}
+	if a.petioles == nil {
+		a.petioles = make(map[string]*petiole)
+	}
+	a.petioles[path] = p
+}
+
+// importPetiole adds the API of the registered petiole imp names, under the
+// name the program uses for it.
+func (a *Analyzer) importPetiole(imp *ast.ImportDecl, name string) {
+	p, ok := a.petioles[imp.Path.Value]
+	if !ok {
+		return
+	}
+	q := &petioleQualifier{pkg: name, types: p.types, structs: make(map[string]*TypeInfo)}
+	for fn, sig := range p.funcs {
+		a.petioleFuncs[name+"."+fn] = q.signature(sig)
+	}
+	for typeName, methods := range p.methods {
+		qualified := name + "." + typeName
+		if a.methods[qualified] == nil {
+			a.methods[qualified] = make(map[string]*TypeInfo)
+		}
+		for method, sig := range methods {
+			a.methods[qualified][method] = q.signature(sig)
+		}
+	}
+}
+
+// petioleQualifier rewrites types from a petiole's declarations into the
+// importing program's terms.
+type petioleQualifier struct {
+	pkg     string
+	types   map[string]*TypeInfo
+	structs map[string]*TypeInfo // qualified structs, shared so recursive ones end
+}
+
+// signature returns sig with parameter defaults dropped and its types
+// qualified.
+func (q *petioleQualifier) signature(sig *TypeInfo) *TypeInfo {
+	out := q.qualify(sig)
+	out.DefaultCount = 0
+	return out
+}
+
+// qualify returns t with the petiole's own types named pkg.Name.
+func (q *petioleQualifier) qualify(t *TypeInfo) *TypeInfo {
+	if t == nil {
+		return nil
+	}
+	if decl, ok := q.types[t.Name]; ok {
+		return q.declared(t.Name, decl)
+	}
+	if isPlaceholderType(t) && t.Name != "any" {
+		return &TypeInfo{Kind: TypeKindUnknown}
+	}
+	out := *t
+	out.ElementType = q.qualify(t.ElementType)
+	out.KeyType = q.qualify(t.KeyType)
+	out.ValueType = q.qualify(t.ValueType)
+	out.Params = q.qualifyAll(t.Params)
+	out.Returns = q.qualifyAll(t.Returns)
+	return &out
+}
+
+func (q *petioleQualifier) qualifyAll(types []*TypeInfo) []*TypeInfo {
+	if types == nil {
+		return nil
+	}
+	out := make([]*TypeInfo, len(types))
+	for i, t := range types {
+		out[i] = q.qualify(t)
+	}
+	return out
+}
+
+// declared returns the type a use of the petiole's type name refers to: a
+// named struct with its exported fields, an interface, or Unknown.
+func (q *petioleQualifier) declared(name string, decl *TypeInfo) *TypeInfo {
+	qualified := q.pkg + "." + name
+	switch decl.Kind {
+	case TypeKindStruct:
+		if ti, ok := q.structs[name]; ok {
+			return ti
+		}
+		ti := &TypeInfo{Kind: TypeKindNamed, Name: qualified, Fields: make(map[string]*TypeInfo)}
+		q.structs[name] = ti
+		for field, ft := range decl.Fields {
+			if isExported(field) {
+				ti.Fields[field] = q.qualify(ft)
+			}
+		}
+		return ti
+	case TypeKindInterface:
+		return &TypeInfo{Kind: TypeKindInterface, Name: qualified}
+	default:
+		return &TypeInfo{Kind: TypeKindUnknown}
+	}
+}
+
+// isPackage reports whether id names an import rather than a variable that
+// shadows it.
+func (a *Analyzer) isPackage(id *ast.Identifier) bool {
+	_, ok := a.packageOf(id)
+	return ok
+}
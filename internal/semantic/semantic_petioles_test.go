@@ -0,0 +1,119 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+const storePetiole = `petiole store
+
+import "context"
+
+type Item
+    Name string
+    Count int
+    secret string
+
+interface Named
+    Name() string
+
+type Status string distinct
+    StatusOK "ok"
+
+type Client
+    url string
+
+func Dial(target string) (Client, error)
+    return Client{url: target}, empty
+
+func Get on c Client(ctx context.Context, name string) (Item, error)
+    return Item{Name: name}, empty
+
+func Close on c Client() error
+    return empty
+
+func Describe(n Named, status Status, limit int = 10) string
+    return n.Name()
+
+func helper() int
+    return 1
+`
+
+func analyzeWithPetiole(t *testing.T, input string) []error {
+	t.Helper()
+	analyzer := NewWithFile(mustParseProgram(t, input), "main.kuki")
+	analyzer.RegisterPetiole("example.com/app/store", mustParseProgram(t, storePetiole))
+	return analyzer.Analyze()
+}
+
+func TestRegisterPetiole(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // substring of the one expected error; "" for none
+	}{
+		{"typed calls", `import "context"
+import "example.com/app/store"
+
+func main()
+    c := store.Dial("localhost:1") onerr panic "{error}"
+    defer c.Close()
+    item := c.Get(context.Background(), "a") onerr panic "{error}"
+    total := item.Count + 1
+    print(item.Name, total, store.Describe(empty, store.StatusOK, 3))
+`, ""},
+		{"too many arguments", `import "example.com/app/store"
+
+func main()
+    c := store.Dial("a", "b") onerr panic "{error}"
+    print(c)
+`, "at most 1 arguments, got 2"},
+		{"method argument type", `import "context"
+import "example.com/app/store"
+
+func main()
+    c := store.Dial("a") onerr panic "{error}"
+    item := c.Get(context.Background(), 42) onerr panic "{error}"
+    print(item)
+`, "argument 2: cannot use int as string"},
+		{"field type", `import "context"
+import "example.com/app/store"
+
+func main()
+    c := store.Dial("a") onerr panic "{error}"
+    item := c.Get(context.Background(), "a") onerr panic "{error}"
+    n := item.Name + 1
+    print(n)
+`, "cannot apply + to string and int"},
+		{"defaults are local", `import "example.com/app/store"
+
+func main()
+    print(store.Describe(empty, store.StatusOK))
+`, "at least 3 arguments, got 2"},
+		{"aliased import", `import "example.com/app/store" as st
+
+func main()
+    c := st.Dial() onerr panic "{error}"
+    print(c)
+`, "at least 1 arguments, got 0"},
+		{"unexported function", `import "example.com/app/store"
+
+func main()
+    print(store.helper("x"))
+`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := analyzeWithPetiole(t, tt.input)
+			if tt.want == "" {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.want) {
+				t.Fatalf("expected one error containing %q, got %v", tt.want, errs)
+			}
+		})
+	}
+}
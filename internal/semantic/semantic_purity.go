@@ -0,0 +1,223 @@
+package semantic
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// ioPackages are packages, by local name, whose functions read or write
+// outside the program: files, the network, the terminal, the environment.
+var ioPackages = map[string]bool{
+	"os": true, "io": true, "ioutil": true, "bufio": true, "net": true, "http": true,
+	"exec": true, "signal": true, "syscall": true, "log": true, "slog": true,
+	"files": true, "fetch": true, "shell": true, "input": true, "env": true, "term": true,
+	"osx": true, "git": true, "kube": true, "container": true, "pg": true, "llm": true,
+	"mcp": true, "a2a": true, "cache": true, "blob": true, "notify": true,
+	"telemetry": true, "expect": true,
+}
+
+// ioFuncs are the input/output functions of otherwise pure packages.
+var ioFuncs = map[string]bool{
+	"fmt.Print": true, "fmt.Printf": true, "fmt.Println": true,
+	"fmt.Fprint": true, "fmt.Fprintf": true, "fmt.Fprintln": true,
+	"fmt.Scan": true, "fmt.Scanf": true, "fmt.Scanln": true,
+	"fmt.Fscan": true, "fmt.Fscanf": true, "fmt.Fscanln": true,
+	"manifest.Load": true, "manifest.Read": true, "manifest.Save": true,
+	"cron.Run": true, "cron.RunJobs": true,
+}
+
+// funcKey names a function in pureFuncs: Name, or Type.Name for a method.
+func funcKey(decl *ast.FunctionDecl) string {
+	if decl.Receiver != nil {
+		return receiverTypeName(decl.Receiver) + "." + decl.Name.Value
+	}
+	return decl.Name.Value
+}
+
+// checkPurity enforces # kuki:pure (or @pure): the function may not assign
+// to package variables, call input/output functions, use channels or
+// goroutines, or call functions of this package that are not pure
+// themselves. Its result then depends only on its arguments, so it is safe
+// to memoize (cache.Memoize) or to evaluate once.
+func (a *Analyzer) checkPurity() {
+	if len(a.pureFuncs) == 0 {
+		return
+	}
+	globals := make(map[string]bool)
+	declared := make(map[string]bool)
+	for _, decl := range a.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.VarDeclStmt:
+			for _, n := range d.Names {
+				globals[n.Value] = true
+			}
+		case *ast.FunctionDecl:
+			declared[funcKey(d)] = true
+		}
+	}
+	for _, decl := range a.program.Declarations {
+		if fn, ok := decl.(*ast.FunctionDecl); ok && a.pureFuncs[funcKey(fn)] && fn.Body != nil {
+			a.checkPureFunc(fn, globals, declared)
+		}
+	}
+}
+
+// checkPureFunc reports the side effects in one pure function, including
+// those in the closures it creates.
+func (a *Analyzer) checkPureFunc(decl *ast.FunctionDecl, globals, declared map[string]bool) {
+	name := funcKey(decl)
+	// Statements and expressions are walked separately; report in source order
+	var found []pureViolation
+	report := func(pos ast.Position, id catalog.ID, what string) {
+		found = append(found, pureViolation{pos, id, what})
+	}
+	local := declaredNames(decl.Body)
+	for _, p := range decl.Parameters {
+		local[p.Name.Value] = true
+	}
+	if decl.Receiver != nil {
+		local[decl.Receiver.Name.Value] = true
+	}
+	effect := func(pos ast.Position, what string) {
+		report(pos, catalog.PureSideEffect, what)
+	}
+	write := func(target ast.Expression) {
+		if id := assignmentRoot(target); id != nil && globals[id.Value] && !local[id.Value] {
+			report(id.Pos(), catalog.PureGlobalWrite, id.Value)
+		}
+	}
+	checkStmts := func(body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			switch s := stmt.(type) {
+			case *ast.AssignStmt:
+				for _, t := range s.Targets {
+					write(t)
+				}
+			case *ast.IncDecStmt:
+				write(s.Variable)
+			case *ast.SendStmt:
+				effect(s.Pos(), "send")
+			case *ast.SelectStmt:
+				effect(s.Pos(), "select")
+			case *ast.GoStmt:
+				effect(s.Pos(), "go")
+			case *ast.FailStmt:
+				effect(s.Pos(), "fail")
+			case *ast.ForRangeStmt:
+				if t := a.exprTypes[s.Collection]; t != nil && t.Kind == TypeKindChannel {
+					effect(s.Collection.Pos(), "receive")
+				}
+			}
+			return false
+		})
+	}
+	checkStmts(decl.Body)
+	ast.WalkBlock(decl.Body, func(e ast.Expression) bool {
+		switch e := e.(type) {
+		case *ast.FunctionLiteral:
+			for _, p := range e.Parameters {
+				local[p.Name.Value] = true
+			}
+			checkStmts(e.Body)
+		case *ast.ArrowLambda:
+			for _, p := range e.Parameters {
+				local[p.Name.Value] = true
+			}
+			checkStmts(e.Block)
+		case *ast.ReceiveExpr:
+			effect(e.Pos(), "receive")
+		case *ast.WaitExpr:
+			effect(e.Pos(), "wait for")
+		case *ast.CloseExpr:
+			effect(e.Pos(), "close")
+		case *ast.ReadExpr:
+			effect(e.Pos(), e.Token.Lexeme)
+		case *ast.CommandExpr:
+			effect(e.Pos(), "$")
+		case *ast.CallExpr:
+			id, ok := e.Function.(*ast.Identifier)
+			if !ok || local[id.Value] {
+				break
+			}
+			switch {
+			case id.Value == "print" || id.Value == "write":
+				report(id.Pos(), catalog.PureIOCall, id.Value)
+			case declared[id.Value] && !a.pureFuncs[id.Value]:
+				report(id.Pos(), catalog.PureCallsImpure, id.Value)
+			}
+		case *ast.MethodCallExpr:
+			if id, what := a.pureMethodCall(e, local, globals, declared); id != "" {
+				report(e.Object.Pos(), id, what)
+			}
+		case *ast.BinaryExpr:
+			// An operator method call (see collectOperators)
+			if t := a.exprTypes[e.Left]; e.Method != "" && t != nil {
+				if method := t.Name + "." + e.Method; declared[method] && !a.pureFuncs[method] {
+					report(e.Pos(), catalog.PureCallsImpure, method)
+				}
+			}
+		}
+		return false
+	})
+	slices.SortStableFunc(found, func(x, y pureViolation) int {
+		return cmp.Or(cmp.Compare(x.pos.Line, y.pos.Line), cmp.Compare(x.pos.Column, y.pos.Column))
+	})
+	for _, v := range found {
+		a.errorMsg(v.pos, v.id, name, v.what)
+	}
+}
+
+// pureViolation is a side effect found in a pure function.
+type pureViolation struct {
+	pos  ast.Position
+	id   catalog.ID
+	what string
+}
+
+// pureMethodCall classifies a call in a pure function to a package function
+// or method: PureIOCall for input/output, PureCallsImpure for a method of
+// this package that is not pure, or "" when the call is allowed.
+func (a *Analyzer) pureMethodCall(call *ast.MethodCallExpr, local, globals, declared map[string]bool) (catalog.ID, string) {
+	if pkg, ok := call.Object.(*ast.Identifier); ok && !local[pkg.Value] && !globals[pkg.Value] {
+		qualified := a.resolveQualifiedName(pkg.Value + "." + call.Method.Value)
+		if ioPackages[qualified[:strings.IndexByte(qualified, '.')]] || ioFuncs[qualified] {
+			return catalog.PureIOCall, pkg.Value + "." + call.Method.Value
+		}
+		return "", ""
+	}
+	objType := a.exprTypes[call.Object]
+	if objType != nil && objType.Kind == TypeKindReference && objType.ElementType != nil {
+		objType = objType.ElementType
+	}
+	if objType == nil || objType.Name == "" {
+		return "", ""
+	}
+	method := objType.Name + "." + call.Method.Value
+	if declared[method] && !a.pureFuncs[method] {
+		return catalog.PureCallsImpure, method
+	}
+	return "", ""
+}
+
+// assignmentRoot returns the variable an assignment target writes through:
+// counts for counts["a"], cfg for cfg.Port, p for p.x[0].
+func assignmentRoot(target ast.Expression) *ast.Identifier {
+	for {
+		switch t := target.(type) {
+		case *ast.Identifier:
+			return t
+		case *ast.IndexExpr:
+			target = t.Left
+		case *ast.FieldAccessExpr:
+			target = t.Object
+		case *ast.DerefExpr:
+			target = t.Operand
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package semantic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// Missing-return detection.
+//
+// A function with declared return types must not be able to fall off the end
+// of its body. The rules mirror Go's "terminating statement" definition so
+// that anything accepted here is also accepted by the Go compiler, but the
+// error is reported against the Kukicha source with a hint pointing at the
+// branch that falls through.
+
+// checkMissingReturn reports an error if body can complete without returning.
+// name and returns describe the function for the message; fallback is used
+// as the position when the body is empty.
+func (a *Analyzer) checkMissingReturn(name string, returns []ast.TypeAnnotation, body *ast.BlockStmt, fallback ast.Position) {
+	if len(returns) == 0 || body == nil || isTerminatingBlock(body) {
+		return
+	}
+	pos, hint := missingReturnHint(body, fallback)
+
+	types := make([]string, len(returns))
+	for i, r := range returns {
+		types[i] = a.typeAnnotationToTypeInfo(r).String()
+	}
+	a.errorMsg(pos, catalog.MissingReturn, name, strings.Join(types, ", "), hint)
+}
+
+// isTerminatingBlock reports whether the last statement of block is terminating.
+func isTerminatingBlock(block *ast.BlockStmt) bool {
+	if block == nil || len(block.Statements) == 0 {
+		return false
+	}
+	return isTerminatingStmt(block.Statements[len(block.Statements)-1])
+}
+
+// isTerminatingStmt follows Go's terminating-statement rules: return, panic,
+// `continue to next` (it only ends a when branch, so the next one decides),
+// if/else with both branches terminating, switch/select with a default and
+// every branch terminating and no break, and an infinite for with no break.
+func isTerminatingStmt(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt, *ast.FallthroughStmt:
+		return true
+	case *ast.ExpressionStmt:
+		if s.OnErr != nil {
+			return false
+		}
+		switch e := s.Expression.(type) {
+		case *ast.PanicExpr:
+			return true
+		case *ast.PipedSwitchExpr:
+			return isTerminatingPipedSwitch(e.Switch)
+		}
+	case *ast.IfStmt:
+		if s.Alternative == nil || !isTerminatingBlock(s.Consequence) {
+			return false
+		}
+		switch alt := s.Alternative.(type) {
+		case *ast.ElseStmt:
+			return isTerminatingBlock(alt.Body)
+		case *ast.IfStmt:
+			return isTerminatingStmt(alt)
+		}
+	case *ast.SwitchStmt:
+		return isTerminatingPipedSwitch(s)
+	case *ast.TypeSwitchStmt:
+		return isTerminatingPipedSwitch(s)
+	case *ast.SelectStmt:
+		for _, c := range s.Cases {
+			if !isTerminatingBlock(c.Body) || hasBreak(c.Body) {
+				return false
+			}
+		}
+		if s.Otherwise != nil {
+			return isTerminatingBlock(s.Otherwise.Body) && !hasBreak(s.Otherwise.Body)
+		}
+		return true
+	case *ast.ForConditionStmt:
+		return isInfiniteLoop(s) && !hasBreak(s.Body)
+	}
+	return false
+}
+
+func isTerminatingPipedSwitch(body ast.PipedSwitchBody) bool {
+	var bodies []*ast.BlockStmt
+	var otherwise *ast.OtherwiseCase
+	switch s := body.(type) {
+	case *ast.SwitchStmt:
+		for _, c := range s.Cases {
+			bodies = append(bodies, c.Body)
+		}
+		otherwise = s.Otherwise
+	case *ast.TypeSwitchStmt:
+		for _, c := range s.Cases {
+			bodies = append(bodies, c.Body)
+		}
+		otherwise = s.Otherwise
+	}
+	if otherwise == nil {
+		return false
+	}
+	bodies = append(bodies, otherwise.Body)
+	for _, b := range bodies {
+		if !isTerminatingBlock(b) || hasBreak(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInfiniteLoop reports whether a condition loop is a bare `for` (which the
+// parser represents with a literal true condition).
+func isInfiniteLoop(s *ast.ForConditionStmt) bool {
+	lit, ok := s.Condition.(*ast.BooleanLiteral)
+	return ok && lit.Value
+}
+
+// hasBreak reports whether block contains a break (or `onerr break`) that
+// targets the enclosing statement, i.e. one not nested in an inner loop,
+// switch, or select.
+func hasBreak(block *ast.BlockStmt) bool {
+	return findBreak(block) != nil
+}
+
+// findBreak returns the position of the first break in block that targets
+// the enclosing statement, or nil.
+func findBreak(block *ast.BlockStmt) *ast.Position {
+	if block == nil {
+		return nil
+	}
+	for _, stmt := range block.Statements {
+		if pos := findBreakInStmt(stmt); pos != nil {
+			return pos
+		}
+	}
+	return nil
+}
+
+func findBreakInStmt(stmt ast.Statement) *ast.Position {
+	onErrBreak := func(clause *ast.OnErrClause) *ast.Position {
+		if clause != nil && clause.ShorthandBreak {
+			pos := ast.TokenPos(clause.Token)
+			return &pos
+		}
+		return nil
+	}
+	switch s := stmt.(type) {
+	case *ast.BreakStmt:
+		pos := s.Pos()
+		return &pos
+	case *ast.VarDeclStmt:
+		return onErrBreak(s.OnErr)
+	case *ast.AssignStmt:
+		return onErrBreak(s.OnErr)
+	case *ast.ExpressionStmt:
+		return onErrBreak(s.OnErr)
+	case *ast.IfStmt:
+		if pos := findBreak(s.Consequence); pos != nil {
+			return pos
+		}
+		switch alt := s.Alternative.(type) {
+		case *ast.ElseStmt:
+			return findBreak(alt.Body)
+		case *ast.IfStmt:
+			return findBreakInStmt(alt)
+		}
+	case *ast.RequireStmt:
+		return findBreak(s.Else)
+	}
+	// Loops, switches, and selects capture their own breaks.
+	return nil
+}
+
+// missingReturnHint locates the statement that lets control reach the end of
+// a non-terminating block and explains why.
+func missingReturnHint(block *ast.BlockStmt, fallback ast.Position) (ast.Position, string) {
+	if block == nil || len(block.Statements) == 0 {
+		return fallback, "the body is empty; add a return statement"
+	}
+	last := block.Statements[len(block.Statements)-1]
+	pos := last.Pos()
+	line := pos.Line
+
+	switch s := last.(type) {
+	case *ast.IfStmt:
+		if !isTerminatingBlock(s.Consequence) {
+			return missingReturnHint(s.Consequence, pos)
+		}
+		switch alt := s.Alternative.(type) {
+		case nil:
+			return pos, fmt.Sprintf("'if' at line %d has no 'else' branch; add an 'else' that returns, or a return after the 'if'", line)
+		case *ast.ElseStmt:
+			return missingReturnHint(alt.Body, alt.Pos())
+		case *ast.IfStmt:
+			return missingReturnHint(&ast.BlockStmt{Statements: []ast.Statement{alt}}, alt.Pos())
+		}
+	case *ast.SwitchStmt:
+		return switchHint(s, pos)
+	case *ast.TypeSwitchStmt:
+		return switchHint(s, pos)
+	case *ast.ExpressionStmt:
+		if ps, ok := s.Expression.(*ast.PipedSwitchExpr); ok && s.OnErr == nil {
+			return switchHint(ps.Switch, pos)
+		}
+	case *ast.SelectStmt:
+		for _, c := range s.Cases {
+			if bp := findBreak(c.Body); bp != nil {
+				return *bp, fmt.Sprintf("'break' at line %d leaves the 'select' without returning", bp.Line)
+			}
+			if !isTerminatingBlock(c.Body) {
+				return missingReturnHint(c.Body, ast.TokenPos(c.Token))
+			}
+		}
+		if s.Otherwise != nil {
+			return missingReturnHint(s.Otherwise.Body, pos)
+		}
+	case *ast.ForConditionStmt:
+		if isInfiniteLoop(s) {
+			if bp := findBreak(s.Body); bp != nil {
+				return *bp, fmt.Sprintf("'break' at line %d exits the loop; add a return after the loop", bp.Line)
+			}
+		}
+		return pos, fmt.Sprintf("the loop at line %d can finish without returning; add a return after the loop", line)
+	case *ast.ForRangeStmt, *ast.ForNumericStmt:
+		return pos, fmt.Sprintf("the loop at line %d can finish without returning; add a return after the loop", line)
+	}
+	return pos, "control reaches the end of the function; add a return statement"
+}
+
+func switchHint(body ast.PipedSwitchBody, pos ast.Position) (ast.Position, string) {
+	type branch struct {
+		tok  ast.Position
+		body *ast.BlockStmt
+	}
+	var branches []branch
+	var otherwise *ast.OtherwiseCase
+	switch s := body.(type) {
+	case *ast.SwitchStmt:
+		for _, c := range s.Cases {
+			branches = append(branches, branch{ast.TokenPos(c.Token), c.Body})
+		}
+		otherwise = s.Otherwise
+	case *ast.TypeSwitchStmt:
+		for _, c := range s.Cases {
+			branches = append(branches, branch{ast.TokenPos(c.Token), c.Body})
+		}
+		otherwise = s.Otherwise
+	}
+	for _, b := range branches {
+		if bp := findBreak(b.body); bp != nil {
+			return *bp, fmt.Sprintf("'break' at line %d leaves the 'switch' without returning", bp.Line)
+		}
+		if !isTerminatingBlock(b.body) {
+			p, hint := missingReturnHint(b.body, b.tok)
+			return p, fmt.Sprintf("'when' branch at line %d: %s", b.tok.Line, hint)
+		}
+	}
+	if otherwise == nil {
+		return pos, fmt.Sprintf("'switch' at line %d has no 'otherwise' branch; add one that returns, or a return after the 'switch'", pos.Line)
+	}
+	otherPos := ast.TokenPos(otherwise.Token)
+	if bp := findBreak(otherwise.Body); bp != nil {
+		return *bp, fmt.Sprintf("'break' at line %d leaves the 'switch' without returning", bp.Line)
+	}
+	p, hint := missingReturnHint(otherwise.Body, otherPos)
+	return p, fmt.Sprintf("'otherwise' branch at line %d: %s", otherPos.Line, hint)
+}
@@ -0,0 +1,138 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func missingReturnErrors(t *testing.T, input string) []string {
+	t.Helper()
+	errs, _ := analyzeInputWithFile(t, input, "app.kuki")
+	var out []string
+	for _, e := range errs {
+		if strings.Contains(e.Error(), "missing return") {
+			out = append(out, e.Error())
+		}
+	}
+	return out
+}
+
+func TestMissingReturnIfWithoutElse(t *testing.T) {
+	input := `func sign(n int) string
+    if n > 0
+        return "positive"
+`
+	errs := missingReturnErrors(t, input)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 missing return error, got: %v", errs)
+	}
+	if !strings.HasPrefix(errs[0], "app.kuki:2:") || !strings.Contains(errs[0], "'if' at line 2 has no 'else' branch") {
+		t.Errorf("unexpected error: %s", errs[0])
+	}
+	if !strings.Contains(errs[0], "function 'sign' (returns string)") {
+		t.Errorf("expected function name and return types in error, got: %s", errs[0])
+	}
+}
+
+func TestMissingReturnElseBranch(t *testing.T) {
+	input := `func sign(n int) string
+    if n > 0
+        return "positive"
+    else if n < 0
+        return "negative"
+    else
+        print("zero")
+`
+	errs := missingReturnErrors(t, input)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "app.kuki:7:") {
+		t.Fatalf("expected error at the end of the else branch, got: %v", errs)
+	}
+}
+
+func TestMissingReturnAllBranchesReturn(t *testing.T) {
+	input := `func sign(n int) string
+    if n > 0
+        return "positive"
+    else if n < 0
+        return "negative"
+    else
+        return "zero"
+
+func must(ok bool) int
+    if ok
+        return 1
+    panic("not ok")
+
+func forever() int
+    for
+        print("tick")
+`
+	if errs := missingReturnErrors(t, input); len(errs) != 0 {
+		t.Errorf("expected no missing return errors, got: %v", errs)
+	}
+}
+
+func TestMissingReturnSwitch(t *testing.T) {
+	input := `func name(n int) string
+    switch n
+        when 1
+            return "one"
+        when 2
+            print("two")
+        otherwise
+            return "many"
+
+func label(n int) string
+    switch n
+        when 1
+            return "one"
+`
+	errs := missingReturnErrors(t, input)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 missing return errors, got: %v", errs)
+	}
+	if !strings.Contains(errs[0], "'when' branch at line 5") {
+		t.Errorf("expected hint for the non-returning when branch, got: %s", errs[0])
+	}
+	if !strings.Contains(errs[1], "'switch' at line 11 has no 'otherwise' branch") {
+		t.Errorf("expected missing otherwise hint, got: %s", errs[1])
+	}
+}
+
+func TestMissingReturnLoops(t *testing.T) {
+	input := `func find(items list of string, want string) int
+    for i, item in items
+        if item == want
+            return i
+
+func first(ch channel of int) int
+    for
+        n := receive from ch
+        if n < 0
+            break
+        if n > 10
+            return n
+`
+	errs := missingReturnErrors(t, input)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 missing return errors, got: %v", errs)
+	}
+	if !strings.Contains(errs[0], "the loop at line 2 can finish without returning") {
+		t.Errorf("expected loop hint, got: %s", errs[0])
+	}
+	if !strings.HasPrefix(errs[1], "app.kuki:10:") || !strings.Contains(errs[1], "'break' at line 10 exits the loop") {
+		t.Errorf("expected break hint, got: %s", errs[1])
+	}
+}
+
+func TestMissingReturnFunctionLiteral(t *testing.T) {
+	input := `func main()
+    f := func(n int) int
+        print(n)
+    print(f(1))
+`
+	errs := missingReturnErrors(t, input)
+	if len(errs) != 1 || !strings.Contains(errs[0], "missing return in function literal") {
+		t.Errorf("expected missing return in function literal, got: %v", errs)
+	}
+}
@@ -0,0 +1,113 @@
+package semantic
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// checkRoutes validates `# route: METHOD /path` directives: the directive
+// itself, that no two functions handle the same route, and that each
+// handler's signature can be bound to its route — every parameter is a
+// path parameter (of a parseable type), the http.ResponseWriter or the
+// reference http.Request, and results are nothing, a value, an error, or
+// a value and an error. The http target turns the handlers into mux
+// registrations; other targets ignore them, but they are checked anyway so
+// a file's routes stay valid whichever target it is built for.
+func (a *Analyzer) checkRoutes() {
+	httpPkg := ast.ImportName(a.program, "net/http")
+	if httpPkg == "" {
+		httpPkg = "http"
+	}
+	handlers := make(map[string]string)
+	for _, decl := range a.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.TypeDecl:
+			a.rejectRouteDirective(d.Directives)
+		case *ast.InterfaceDecl:
+			a.rejectRouteDirective(d.Directives)
+		case *ast.FunctionDecl:
+			route, dir, err := ast.FunctionRoute(d)
+			if dir == nil {
+				continue
+			}
+			pos := routePos(dir)
+			if err != nil {
+				a.error(pos, err.Error())
+				continue
+			}
+			if d.Receiver != nil {
+				a.errorMsg(pos, catalog.RouteOnMethod, d.Name.Value)
+				continue
+			}
+			if prev, ok := handlers[route.Pattern()]; ok {
+				a.errorMsg(pos, catalog.RouteDuplicate, route.Pattern(), prev)
+				continue
+			}
+			handlers[route.Pattern()] = d.Name.Value
+			a.checkRouteHandler(d, route, pos, httpPkg)
+		}
+	}
+}
+
+func (a *Analyzer) checkRouteHandler(fn *ast.FunctionDecl, route *ast.Route, pos ast.Position, httpPkg string) {
+	name := fn.Name.Value
+	bound := make(map[string]bool)
+	for _, param := range fn.Parameters {
+		pname := param.Name.Value
+		switch {
+		case slices.Contains(route.Params, pname):
+			bound[pname] = true
+			if param.Variadic || !ast.IsRouteParamType(param.Type) {
+				a.errorMsg(pos, catalog.RouteParamType, pname, name, strings.Join(ast.RouteParamTypes, ", "), a.typeAnnotationToTypeInfo(param.Type))
+			}
+		case ast.IsHTTPWriterType(param.Type, httpPkg), ast.IsHTTPRequestType(param.Type, httpPkg):
+		default:
+			a.errorMsg(pos, catalog.RouteUnknownParam, pname, name, route.Pattern(), httpPkg, httpPkg)
+		}
+	}
+	for _, p := range route.Params {
+		if !bound[p] {
+			a.errorMsg(pos, catalog.RouteUnboundParam, p, route.Pattern(), name)
+		}
+	}
+	if len(fn.Returns) > 2 || (len(fn.Returns) == 2 && !isErrorAnnotation(fn.Returns[1])) {
+		a.errorMsg(pos, catalog.RouteResults, name)
+	}
+}
+
+// rejectRouteDirective reports `# route:` directives on declarations that
+// are not functions.
+func (a *Analyzer) rejectRouteDirective(dirs []ast.Directive) {
+	for i := range dirs {
+		if dirs[i].Name == "route" {
+			a.errorMsg(directivePos(&dirs[i]), catalog.RouteNotFunction)
+		}
+	}
+}
+
+func directivePos(d *ast.Directive) ast.Position {
+	return ast.TokenPos(d.Token)
+}
+
+// routePos is the position of the route itself (GET /users/{id}) within a
+// `# route:` directive, where route diagnostics point.
+func routePos(d *ast.Directive) ast.Position {
+	pos := directivePos(d)
+	_, route, ok := strings.Cut(d.Token.Lexeme, "route:")
+	if !ok {
+		return pos
+	}
+	route = strings.TrimLeft(route, " \t")
+	pos.Column += utf8.RuneCountInString(d.Token.Lexeme) - utf8.RuneCountInString(route)
+	return pos
+}
+
+// isErrorAnnotation reports whether t is the built-in error type.
+func isErrorAnnotation(t ast.TypeAnnotation) bool {
+	n, ok := t.(*ast.NamedType)
+	return ok && n.Name == "error"
+}
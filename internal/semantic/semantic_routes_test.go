@@ -0,0 +1,85 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouteHandlersValid(t *testing.T) {
+	input := `import "net/http"
+
+# route: GET /users/{id}
+func getUser(id int) (string, error)
+    return "user {id}", empty
+
+# route: POST /files/{path...}
+func upload(w http.ResponseWriter, r reference http.Request, path string)
+    print(path)
+
+# route: DELETE /users/{id}
+func deleteUser(id int64) error
+    return empty
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestRouteHandlerMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"unknown method",
+			"# route: FETCH /a\nfunc a() string\n    return \"a\"\n",
+			"unknown method 'FETCH'",
+		},
+		{
+			"unbound path parameter",
+			"# route: GET /users/{id}\nfunc getUser(userID int) string\n    return \"u\"\n",
+			"path parameter '{id}' of GET /users/{id} has no matching parameter in getUser",
+		},
+		{
+			"unsupported parameter type",
+			"# route: GET /tags/{tag}\nfunc tag(tag list of string) string\n    return \"t\"\n",
+			"path parameter 'tag' of tag must be one of",
+		},
+		{
+			"bad results",
+			"# route: GET /a\nfunc a() (string, int)\n    return \"a\", 1\n",
+			"route handler a must return nothing, a value, an error, or a value and an error",
+		},
+		{
+			"duplicate route",
+			"# route: GET /a\nfunc a() string\n    return \"a\"\n\n# route: get /a\nfunc b() string\n    return \"b\"\n",
+			"route GET /a is already handled by a",
+		},
+		{
+			"route on a type",
+			"# route: GET /t\ntype T\n    x int\n",
+			"# route: only applies to functions",
+		},
+	}
+	for _, tt := range tests {
+		_, errs := analyzeSource(t, tt.input)
+		found := false
+		for _, err := range errs {
+			if strings.Contains(err.Error(), tt.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, errs)
+		}
+	}
+}
+
+func TestRouteDiagnosticsPointAtTheRoute(t *testing.T) {
+	input := "# route: GET /a\nfunc a() string\n    return \"a\"\n\n# route:   get /a\nfunc b() string\n    return \"b\"\n"
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), ":5:11: route GET /a is already handled by a") {
+		t.Errorf("expected the conflict at the route on line 5, column 11, got %v", errs)
+	}
+}
@@ -0,0 +1,62 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// checkSchedules validates `# schedule:` directives: the cron expression,
+// and that each scheduled function can be called by the worker — a plain
+// function taking nothing or a context.Context and returning nothing or an
+// error. The worker target registers the functions with stdlib/cron; other
+// targets ignore them, but they are checked anyway so a file's schedules
+// stay valid whichever target it is built for.
+func (a *Analyzer) checkSchedules() {
+	contextPkg := ast.ImportName(a.program, "context")
+	if contextPkg == "" {
+		contextPkg = "context"
+	}
+	for _, decl := range a.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.TypeDecl:
+			a.rejectScheduleDirective(d.Directives)
+		case *ast.InterfaceDecl:
+			a.rejectScheduleDirective(d.Directives)
+		case *ast.FunctionDecl:
+			_, dir, err := ast.FunctionSchedule(d)
+			if dir == nil {
+				continue
+			}
+			pos := directivePos(dir)
+			if err != nil {
+				a.error(pos, err.Error())
+				continue
+			}
+			if d.Receiver != nil {
+				a.errorMsg(pos, catalog.ScheduleOnMethod, d.Name.Value)
+				continue
+			}
+			if _, routeDir, _ := ast.FunctionRoute(d); routeDir != nil {
+				a.errorMsg(pos, catalog.ScheduleAndRoute, d.Name.Value)
+				continue
+			}
+			params := d.Parameters
+			if len(params) > 1 || (len(params) == 1 && !ast.IsContextType(params[0].Type, contextPkg)) {
+				a.errorMsg(pos, catalog.ScheduleParams, d.Name.Value, contextPkg)
+			}
+			if len(d.Returns) > 1 || (len(d.Returns) == 1 && !isErrorAnnotation(d.Returns[0])) {
+				a.errorMsg(pos, catalog.ScheduleResults, d.Name.Value)
+			}
+		}
+	}
+}
+
+// rejectScheduleDirective reports `# schedule:` directives on declarations
+// that are not functions.
+func (a *Analyzer) rejectScheduleDirective(dirs []ast.Directive) {
+	for i := range dirs {
+		if dirs[i].Name == "schedule" {
+			a.errorMsg(directivePos(&dirs[i]), catalog.ScheduleNotFunction)
+		}
+	}
+}
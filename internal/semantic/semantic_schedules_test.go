@@ -0,0 +1,87 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScheduledJobsValid(t *testing.T) {
+	input := `import "context"
+
+# schedule: "*/5 * * * *"
+func cleanup()
+    print("cleanup")
+
+# schedule: 0 9-17 * * MON-FRI
+func report(ctx context.Context) error
+    return empty
+
+# schedule: "@every 90s"
+func heartbeat() error
+    return empty
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestScheduledJobMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"missing expression",
+			"# schedule:\nfunc a()\n    print(\"a\")\n",
+			"# schedule: expects a cron expression",
+		},
+		{
+			"wrong field count",
+			"# schedule: \"* * *\"\nfunc a()\n    print(\"a\")\n",
+			"'* * *' has 3 fields, expected 5",
+		},
+		{
+			"out of range",
+			"# schedule: \"0 24 * * *\"\nfunc a()\n    print(\"a\")\n",
+			"hour field '24' must be within 0-23",
+		},
+		{
+			"unknown shorthand",
+			"# schedule: @sometimes\nfunc a()\n    print(\"a\")\n",
+			"unknown shorthand '@sometimes'",
+		},
+		{
+			"parameters",
+			"# schedule: @hourly\nfunc a(n int)\n    print(n)\n",
+			"scheduled job a must take no parameters or a single context.Context",
+		},
+		{
+			"results",
+			"# schedule: @hourly\nfunc a() string\n    return \"a\"\n",
+			"scheduled job a must return nothing or an error",
+		},
+		{
+			"also a route",
+			"# route: GET /a\n# schedule: @hourly\nfunc a()\n    print(\"a\")\n",
+			"a has both # route: and # schedule:",
+		},
+		{
+			"schedule on a type",
+			"# schedule: @hourly\ntype T\n    x int\n",
+			"# schedule: only applies to functions",
+		},
+	}
+	for _, tt := range tests {
+		_, errs := analyzeSource(t, tt.input)
+		found := false
+		for _, err := range errs {
+			if strings.Contains(err.Error(), tt.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, errs)
+		}
+	}
+}
@@ -1,10 +1,10 @@
 package semantic
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 // securityCategory returns the security check category for a qualified function
@@ -61,10 +61,7 @@ func (a *Analyzer) checkSQLInterpolation(qualifiedName string, expr *ast.MethodC
 
 	sqlArg := expr.Arguments[sqlArgIndex]
 	if strLit, ok := sqlArg.(*ast.StringLiteral); ok && strLit.Interpolated {
-		a.error(strLit.Pos(), fmt.Sprintf(
-			"SQL injection risk: string interpolation in %s query — use parameter placeholders ($1, $2, ...) instead",
-			qualifiedName,
-		))
+		a.errorMsg(strLit.Pos(), catalog.SQLInjectionRisk, qualifiedName)
 	}
 }
 
@@ -87,10 +84,7 @@ func (a *Analyzer) checkHTMLNonLiteral(qualifiedName string, expr *ast.MethodCal
 
 	contentArg := expr.Arguments[contentArgIndex]
 	if _, ok := contentArg.(*ast.StringLiteral); !ok {
-		a.error(expr.Pos(), fmt.Sprintf(
-			"XSS risk: %s with non-literal content — use http.SafeHTML to HTML-escape user-controlled content",
-			qualifiedName,
-		))
+		a.errorMsg(expr.Pos(), catalog.XSSRisk, qualifiedName)
 	}
 }
 
@@ -103,10 +97,7 @@ func (a *Analyzer) checkFetchInHandler(qualifiedName string, expr *ast.MethodCal
 	if !a.isInHTTPHandler() {
 		return
 	}
-	a.error(expr.Pos(), fmt.Sprintf(
-		"SSRF risk: %s inside an HTTP handler — use fetch.SafeGet or add fetch.Transport(netguard.HTTPTransport(...)) to restrict outbound requests",
-		qualifiedName,
-	))
+	a.errorMsg(expr.Pos(), catalog.SSRFRisk, qualifiedName)
 }
 
 // checkFilesInHandler warns when files.* I/O functions are called inside an
@@ -118,10 +109,7 @@ func (a *Analyzer) checkFilesInHandler(qualifiedName string, expr *ast.MethodCal
 	if !a.isInHTTPHandler() {
 		return
 	}
-	a.error(expr.Pos(), fmt.Sprintf(
-		"path traversal risk: %s inside an HTTP handler — use sandbox.* with a restricted root for user-controlled paths",
-		qualifiedName,
-	))
+	a.errorMsg(expr.Pos(), catalog.PathTraversalRisk, qualifiedName)
 }
 
 // checkShellRunNonLiteral warns when shell.Run is called with a non-literal
@@ -147,9 +135,7 @@ func (a *Analyzer) checkShellRunNonLiteral(qualifiedName string, expr *ast.Metho
 	}
 	cmdArg := expr.Arguments[0]
 	if _, ok := cmdArg.(*ast.StringLiteral); !ok {
-		a.error(expr.Pos(),
-			"command injection risk: shell.Run with non-literal argument — shell.Run splits on whitespace without quoting; use shell.Output() with separate arguments for variable input",
-		)
+		a.errorMsg(expr.Pos(), catalog.CommandInjectionRisk)
 	}
 }
 
@@ -176,9 +162,6 @@ func (a *Analyzer) checkRedirectNonLiteral(qualifiedName string, expr *ast.Metho
 	}
 	urlArg := expr.Arguments[urlArgIndex]
 	if _, ok := urlArg.(*ast.StringLiteral); !ok {
-		a.error(expr.Pos(), fmt.Sprintf(
-			"open redirect risk: %s with non-literal URL — use http.SafeRedirect(w, r, url, allowedHosts...) to validate the destination",
-			qualifiedName,
-		))
+		a.errorMsg(expr.Pos(), catalog.OpenRedirectRisk, qualifiedName)
 	}
 }
@@ -156,6 +156,17 @@ func Handle(w http.ResponseWriter, r reference http.Request)
 	assertSecurityError(t, source, "path traversal risk")
 }
 
+func TestArchiveInHandler_Extract(t *testing.T) {
+	// stdlib/archive functions share the "files" category.
+	source := `import "net/http"
+import "stdlib/archive"
+
+func Handle(w http.ResponseWriter, r reference http.Request)
+    archive.Extract("/tmp/upload.zip", "/tmp/out") onerr return
+`
+	assertSecurityError(t, source, "path traversal risk")
+}
+
 func TestFilesInHandler_ListRecursive(t *testing.T) {
 	source := `import "net/http"
 import "stdlib/files"
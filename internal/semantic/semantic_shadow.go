@@ -0,0 +1,92 @@
+package semantic
+
+import (
+	"fmt"
+
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// ShadowCheck controls which `:=` declarations that shadow an outer variable
+// are reported as warnings.
+type ShadowCheck int
+
+const (
+	// ShadowCheckDefault reports shadowing of err, ctx, and function parameters —
+	// the cases that are almost always accidental.
+	ShadowCheckDefault ShadowCheck = iota
+	// ShadowCheckOff disables shadowing diagnostics.
+	ShadowCheckOff
+	// ShadowCheckAll reports shadowing of any local variable or parameter.
+	ShadowCheckAll
+)
+
+// ParseShadowCheck converts a --shadow flag value ("default", "off", "all").
+func ParseShadowCheck(s string) (ShadowCheck, error) {
+	switch s {
+	case "", "default":
+		return ShadowCheckDefault, nil
+	case "off":
+		return ShadowCheckOff, nil
+	case "all":
+		return ShadowCheckAll, nil
+	}
+	return ShadowCheckDefault, fmt.Errorf("invalid shadow check %q (want default, off, or all)", s)
+}
+
+// SetShadowCheck configures shadowing diagnostics. Call before Analyze.
+func (a *Analyzer) SetShadowCheck(mode ShadowCheck) {
+	a.shadowCheck = mode
+}
+
+// alwaysReportShadow lists names whose shadowing is reported even in the
+// default mode: a shadowed err or ctx silently drops errors and cancellation.
+var alwaysReportShadow = map[string]bool{
+	"err": true,
+	"ctx": true,
+}
+
+// checkShadowing warns when a `:=` declaration of name hides a variable or
+// parameter from an enclosing function scope. A declaration whose value reads
+// the outer variable (e.g. `name := name |> string.TrimSpace`) is treated as
+// an intentional re-binding and is not reported. Redeclaration in the same
+// scope is an error reported by Define, not a shadowing diagnostic.
+func (a *Analyzer) checkShadowing(stmt *ast.VarDeclStmt, name *ast.Identifier) {
+	if a.shadowCheck == ShadowCheckOff || name.Value == "_" {
+		return
+	}
+	scope := a.symbolTable.CurrentScope()
+	if _, sameScope := scope.symbols[name.Value]; sameScope {
+		return
+	}
+	outer := scope.Resolve(name.Value)
+	if outer == nil || (outer.Kind != SymbolVariable && outer.Kind != SymbolParameter) {
+		return
+	}
+	// Package-level declarations are not reported; shadowing them is common and deliberate.
+	if a.symbolTable.scopes[0].symbols[name.Value] == outer {
+		return
+	}
+	if a.shadowCheck != ShadowCheckAll && !alwaysReportShadow[name.Value] && outer.Kind != SymbolParameter {
+		return
+	}
+	for _, v := range stmt.Values {
+		if referencesName(v, name.Value) {
+			return
+		}
+	}
+
+	what := "variable"
+	if outer.Kind == SymbolParameter {
+		what = "parameter"
+	}
+	a.warn(name.Pos(), fmt.Sprintf("'%s' shadows %s declared at %s:%d:%d; use '=' to assign to the outer %s, or pick a new name",
+		name.Value, what, outer.Defined.File, outer.Defined.Line, outer.Defined.Column, what))
+}
+
+// referencesName reports whether expr reads an identifier called name.
+func referencesName(expr ast.Expression, name string) bool {
+	return ast.WalkExpr(expr, func(e ast.Expression) bool {
+		id, ok := e.(*ast.Identifier)
+		return ok && id.Value == name
+	})
+}
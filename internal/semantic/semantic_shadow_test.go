@@ -0,0 +1,112 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShadowingErrWarnsWithBothLocations(t *testing.T) {
+	input := `func parse(s string) (int, error)
+    return 0, empty
+
+func run() error
+    n, err := parse("1")
+    if n > 0
+        m, err := parse("2")
+        print(m, err)
+    return err
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 shadow warning, got %d: %v", len(warnings), warnings)
+	}
+	msg := warnings[0].Error()
+	if !strings.HasPrefix(msg, "app.kuki:7:11:") || !strings.Contains(msg, "'err' shadows variable declared at app.kuki:5:7") {
+		t.Errorf("unexpected warning: %s", msg)
+	}
+}
+
+func TestShadowingParameterWarns(t *testing.T) {
+	input := `func greet(name string) string
+    if name == ""
+        name := "world"
+        print(name)
+    return name
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "'name' shadows parameter") {
+		t.Errorf("expected parameter shadow warning, got: %v", warnings)
+	}
+}
+
+func TestIntentionalRebindingNoWarn(t *testing.T) {
+	input := `func greet(name string) string
+    if name != ""
+        name := "Hello " + name
+        return name
+    return ""
+`
+	_, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings for re-binding that reads the outer value, got: %v", warnings)
+	}
+}
+
+func TestShadowCheckModes(t *testing.T) {
+	input := `func main()
+    count := 1
+    if count > 0
+        count := 2
+        print(count)
+`
+	analyzer, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(analyzer.Warnings()) != 0 {
+		t.Errorf("default mode should not report ordinary locals, got: %v", analyzer.Warnings())
+	}
+
+	program := mustParseProgram(t, input)
+	all := NewWithFile(program, "test.kuki")
+	all.SetShadowCheck(ShadowCheckAll)
+	all.Analyze()
+	if len(all.Warnings()) != 1 || !strings.Contains(all.Warnings()[0].Error(), "'count' shadows variable") {
+		t.Errorf("all mode should report ordinary locals, got: %v", all.Warnings())
+	}
+
+	errInput := strings.ReplaceAll(input, "count", "err")
+	off := NewWithFile(mustParseProgram(t, errInput), "test.kuki")
+	off.SetShadowCheck(ShadowCheckOff)
+	off.Analyze()
+	if len(off.Warnings()) != 0 {
+		t.Errorf("off mode should report nothing, got: %v", off.Warnings())
+	}
+}
+
+func TestParseShadowCheck(t *testing.T) {
+	for in, want := range map[string]ShadowCheck{"": ShadowCheckDefault, "default": ShadowCheckDefault, "off": ShadowCheckOff, "all": ShadowCheckAll} {
+		got, err := ParseShadowCheck(in)
+		if err != nil || got != want {
+			t.Errorf("ParseShadowCheck(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseShadowCheck("loud"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestRedeclarationReportsPreviousLocation(t *testing.T) {
+	input := `func main()
+    x := 1
+    x := 2
+    print(x)
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "previous declaration at test.kuki:2:4") {
+		t.Errorf("expected redeclaration error with previous location, got: %v", errs)
+	}
+}
@@ -1,26 +1,42 @@
 package semantic
 
 import (
-	"fmt"
-
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 	"github.com/duber000/kukicha/internal/lexer"
 )
 
 func (a *Analyzer) analyzeBlock(block *ast.BlockStmt) {
+	saved := a.block
+	a.block = block
 	for _, stmt := range block.Statements {
 		a.analyzeStatement(stmt)
 	}
+	a.block = saved
 }
 
 func (a *Analyzer) analyzeStatement(stmt ast.Statement) {
+	prevSites := a.onErrExprSites
+	a.onErrExprSites = onErrExprSites(stmt)
+	defer func() { a.onErrExprSites = prevSites }()
+	// After the statement is analyzed, so a go block's captures are known
+	defer a.checkLoopCaptures(stmt)
+
 	switch s := stmt.(type) {
 	case *ast.VarDeclStmt:
 		a.analyzeVarDeclStmt(s)
-		a.analyzeOnErrClause(s.OnErr)
+		a.analyzeOnErrWithNilResults(s)
+		if s.OnErr != nil && len(s.Names) > 0 {
+			if sym := a.symbolTable.CurrentScope().symbols[s.Names[0].Value]; sym != nil {
+				a.checkOnErrFallback(s.OnErr, sym.Type)
+			}
+		}
 	case *ast.AssignStmt:
 		a.analyzeAssignStmt(s)
 		a.analyzeOnErrClause(s.OnErr)
+		if s.OnErr != nil && len(s.Targets) > 0 {
+			a.checkOnErrFallback(s.OnErr, a.exprTypes[s.Targets[0]])
+		}
 	case *ast.ReturnStmt:
 		a.analyzeReturnStmt(s)
 	case *ast.IfStmt:
@@ -42,11 +58,22 @@ func (a *Analyzer) analyzeStatement(stmt ast.Statement) {
 			a.analyzeExpression(s.Call)
 		}
 		if s.Block != nil {
+			a.recordCaptures(s, nil, s.Block)
+			savedInGoBlock := a.inGoBlock
+			a.inGoBlock = true
 			a.analyzeBlock(s.Block)
-		}
+			a.inGoBlock = savedInGoBlock
+		}
+	case *ast.SafelyStmt:
+		a.analyzeSafelyStmt(s)
+	case *ast.FailStmt:
+		a.analyzeFailStmt(s)
+	case *ast.RequireStmt:
+		a.analyzeRequireStmt(s)
 	case *ast.SendStmt:
-		a.analyzeExpression(s.Value)
-		a.analyzeExpression(s.Channel)
+		a.analyzeSendStmt(s)
+	case *ast.YieldStmt:
+		a.analyzeYieldStmt(s)
 	case *ast.SelectStmt:
 		a.switchDepth++ // reuse switchDepth so break works inside select
 		defer func() { a.switchDepth-- }()
@@ -59,14 +86,13 @@ func (a *Analyzer) analyzeStatement(stmt ast.Statement) {
 						Name:    binding,
 						Kind:    SymbolVariable,
 						Type:    &TypeInfo{Kind: TypeKindUnknown},
-						Defined: ast.Position{Line: c.Token.Line, Column: c.Token.Column, File: c.Token.File},
+						Defined: ast.TokenPos(c.Token),
 					}
 					a.symbolTable.Define(sym)
 				}
 			}
 			if c.Send != nil {
-				a.analyzeExpression(c.Send.Value)
-				a.analyzeExpression(c.Send.Channel)
+				a.analyzeSendStmt(c.Send)
 			}
 			a.analyzeBlock(c.Body)
 			a.symbolTable.ExitScope()
@@ -75,15 +101,31 @@ func (a *Analyzer) analyzeStatement(stmt ast.Statement) {
 			a.analyzeBlock(s.Otherwise.Body)
 		}
 	case *ast.ExpressionStmt:
-		a.analyzeExpression(s.Expression)
+		if s.OnErr == nil && isCallLike(s.Expression) {
+			// All results are needed to see a dropped error; record the
+			// first as analyzeExpression would
+			types := a.analyzeExpressionMulti(s.Expression)
+			if len(types) > 0 {
+				a.recordType(s.Expression, types[0])
+			} else {
+				a.recordType(s.Expression, &TypeInfo{Kind: TypeKindUnknown})
+			}
+			a.noteDroppedError(s, types, nil)
+		} else {
+			a.analyzeExpression(s.Expression)
+		}
 		a.analyzeOnErrClause(s.OnErr)
 	case *ast.ContinueStmt:
 		if a.loopDepth == 0 {
-			a.error(s.Pos(), "continue statement outside of loop")
+			a.errorMsg(s.Pos(), catalog.ContinueOutsideLoop)
+		}
+	case *ast.FallthroughStmt:
+		if ast.Statement(s) != a.allowedFallthrough {
+			a.errorMsg(s.Pos(), catalog.FallthroughPlacement)
 		}
 	case *ast.BreakStmt:
 		if a.loopDepth == 0 && a.switchDepth == 0 {
-			a.error(s.Pos(), "break statement outside of loop")
+			a.errorMsg(s.Pos(), catalog.BreakOutsideLoop)
 		}
 	}
 }
@@ -131,6 +173,9 @@ func (a *Analyzer) collectReturnTypes(body *ast.BlockStmt) *TypeInfo {
 					walk([]ast.Statement{alt})
 				}
 			}
+			if req, ok := s.(*ast.RequireStmt); ok && req.Else != nil {
+				walk(req.Else.Statements)
+			}
 		}
 	}
 	walk(body.Statements)
@@ -149,6 +194,11 @@ func (a *Analyzer) analyzePipedSwitchBody(stmt ast.PipedSwitchBody, leftType *Ty
 	switch s := stmt.(type) {
 	case *ast.SwitchStmt:
 		for _, c := range s.Cases {
+			for _, val := range c.Values {
+				if pattern, ok := val.(*ast.WhenPattern); ok {
+					a.analyzeWhenPattern(pattern, leftType)
+				}
+			}
 			a.analyzeBlock(c.Body)
 			inferred = a.mergePipedSwitchReturnType(inferred, a.collectReturnTypes(c.Body))
 		}
@@ -191,28 +241,110 @@ func (a *Analyzer) analyzePipedSwitchBody(stmt ast.PipedSwitchBody, leftType *Ty
 }
 
 func (a *Analyzer) analyzeSwitchStmt(stmt *ast.SwitchStmt) {
+	var switchType *TypeInfo
 	if stmt.Expression != nil {
-		a.analyzeExpression(stmt.Expression)
+		switchType = a.analyzeExpression(stmt.Expression)
 	}
 
 	a.switchDepth++
 	defer func() { a.switchDepth-- }()
 
-	for _, c := range stmt.Cases {
+	// Each case starts from the pre-switch nil-use state; the states of cases
+	// that fall through are joined afterwards.
+	nilBefore := a.maybeNil.clone()
+	nilAfter := make(nilSet)
+	var fallenThrough nilSet // State carried into the next branch by `continue to next`
+	savedFallthrough := a.allowedFallthrough
+	defer func() { a.allowedFallthrough = savedFallthrough }()
+	for i, c := range stmt.Cases {
+		a.maybeNil = nilBefore.clone()
+		a.maybeNil.union(fallenThrough)
+		fallenThrough = nil
 		for _, val := range c.Values {
+			if pattern, ok := val.(*ast.WhenPattern); ok {
+				a.analyzeWhenPattern(pattern, switchType)
+				continue
+			}
 			valType := a.analyzeExpression(val)
+			a.checkEnumValue(val, switchType)
 			if stmt.Expression == nil && valType != nil && valType.Kind != TypeKindBool && valType.Kind != TypeKindUnknown {
-				a.error(val.Pos(), "switch condition branch must be bool")
+				a.errorMsg(val.Pos(), catalog.SwitchBranchNotBool)
 			}
 		}
+		if c.Guard != nil {
+			guardType := a.analyzeExpression(c.Guard)
+			if guardType != nil && guardType.Kind != TypeKindBool && guardType.Kind != TypeKindUnknown {
+				a.errorMsg(c.Guard.Pos(), catalog.WhenGuardNotBool, guardType)
+			}
+		}
+		a.allowedFallthrough = a.branchFallthrough(c.Body, i < len(stmt.Cases)-1 || stmt.Otherwise != nil)
 		a.analyzeBlock(c.Body)
+		if a.allowedFallthrough != nil {
+			fallenThrough = a.maybeNil
+		} else if !blockTerminates(c.Body) {
+			nilAfter.union(a.maybeNil)
+		}
 	}
 
+	a.checkSwitchExhaustive(stmt, switchType)
+	a.maybeNil = nilBefore.clone()
+	a.maybeNil.union(fallenThrough)
 	if stmt.Otherwise != nil {
+		a.allowedFallthrough = a.branchFallthrough(stmt.Otherwise.Body, false)
 		a.analyzeBlock(stmt.Otherwise.Body)
+		if !blockTerminates(stmt.Otherwise.Body) {
+			nilAfter.union(a.maybeNil)
+		}
+	} else {
+		nilAfter.union(nilBefore)
+	}
+	if nilBefore != nil {
+		a.maybeNil = nilAfter
 	}
 }
 
+// analyzeWhenPattern checks a `when` pattern against the type of the value
+// being switched on, nil in a condition switch: comparison and range operands
+// must be comparable with it, and `matches` needs strings on both sides.
+func (a *Analyzer) analyzeWhenPattern(pattern *ast.WhenPattern, switchType *TypeInfo) {
+	if switchType == nil {
+		a.errorMsg(pattern.Pos(), catalog.WhenPatternNeedsValue, pattern.Operator)
+	}
+	for _, operand := range []ast.Expression{pattern.Low, pattern.Value} {
+		if operand == nil {
+			continue
+		}
+		operandType := a.analyzeExpression(operand)
+		if switchType == nil {
+			continue
+		}
+		if pattern.Operator == "matches" {
+			if !isStringType(switchType) || !isStringType(operandType) {
+				a.errorMsg(operand.Pos(), catalog.MatchesNotString, switchType, operandType)
+			}
+		} else if !a.typesCompatible(switchType, operandType) {
+			a.errorMsg(operand.Pos(), catalog.CannotCompare, switchType, operandType)
+		}
+	}
+}
+
+// branchFallthrough returns the `continue to next` ending a when branch body,
+// which is the one place it is allowed. hasNext is false for the last
+// branch, which has nowhere to continue to; that is reported here.
+func (a *Analyzer) branchFallthrough(body *ast.BlockStmt, hasNext bool) ast.Statement {
+	if body == nil || len(body.Statements) == 0 {
+		return nil
+	}
+	last, ok := body.Statements[len(body.Statements)-1].(*ast.FallthroughStmt)
+	if !ok {
+		return nil
+	}
+	if !hasNext {
+		a.errorMsg(last.Pos(), catalog.FallthroughLastBranch)
+	}
+	return last
+}
+
 func (a *Analyzer) analyzeTypeSwitchStmt(stmt *ast.TypeSwitchStmt) {
 	a.analyzeExpression(stmt.Expression)
 
@@ -247,11 +379,31 @@ func (a *Analyzer) analyzeTypeSwitchStmt(stmt *ast.TypeSwitchStmt) {
 	}
 }
 
+// checkBigIntegerLiteral reports an integer literal too large for int64
+// assigned to a variable. Without a declared type Go would infer int and fail
+// with "constant overflows int"; past 64 bits no integer type can hold it.
+func (a *Analyzer) checkBigIntegerLiteral(val ast.Expression, typed bool) {
+	lit, ok := val.(*ast.IntegerLiteral)
+	if !ok || lit.Big == nil {
+		return
+	}
+	lexeme := lit.Token.Lexeme
+	if lit.Big.BitLen() > 64 {
+		a.errorMsg(lit.Pos(), catalog.IntegerOverflows64, lexeme, lexeme)
+	} else if !typed {
+		a.errorMsg(lit.Pos(), catalog.IntegerOverflowsInt, lexeme, lexeme)
+	}
+}
+
 func (a *Analyzer) analyzeVarDeclStmt(stmt *ast.VarDeclStmt) {
 	// Analyze all value expressions
 	valueTypes := make([]*TypeInfo, len(stmt.Values))
 	for i, val := range stmt.Values {
 		valueTypes[i] = a.analyzeExpression(val)
+		a.checkBigIntegerLiteral(val, stmt.Type != nil)
+	}
+	if len(stmt.Names) == 1 && len(stmt.Values) == 1 && stmt.OnErr == nil && isCallLike(stmt.Values[0]) && a.exprReturnCounts[stmt.Values[0]] == 1 {
+		a.noteDroppedError(stmt, valueTypes, identNames(stmt.Names))
 	}
 
 	// Special handling for multi-value return from single function call or type assertion
@@ -275,25 +427,28 @@ func (a *Analyzer) analyzeVarDeclStmt(stmt *ast.VarDeclStmt) {
 			multiValueTypes = a.analyzeExpressionMulti(stmt.Values[0])
 		}
 
+		if stmt.OnErr == nil {
+			a.noteDroppedError(stmt, multiValueTypes, identNames(stmt.Names))
+		}
 		if len(multiValueTypes) != len(stmt.Names) {
 			// If we can't match exact count, check if it's dynamic/unknown
 			if len(multiValueTypes) == 1 && multiValueTypes[0].Kind == TypeKindUnknown {
 				// Allow assignment of Unknown to multiple variables
 			} else {
-				a.error(stmt.Pos(), fmt.Sprintf("assignment mismatch: %d variables but %d values", len(stmt.Names), len(multiValueTypes)))
+				a.errorMsg(stmt.Pos(), catalog.AssignCount, len(stmt.Names), len(multiValueTypes))
 			}
 		}
 	} else {
 		// Check that number of values matches number of names
 		if len(stmt.Values) != len(stmt.Names) {
-			a.error(stmt.Pos(), fmt.Sprintf("assignment mismatch: %d variables but %d values", len(stmt.Names), len(stmt.Values)))
+			a.errorMsg(stmt.Pos(), catalog.AssignCount, len(stmt.Names), len(stmt.Values))
 		}
 	}
 
 	// Type inference and validation
 	for i, name := range stmt.Names {
 		if !isValidIdentifier(name.Value) {
-			a.error(name.Pos(), fmt.Sprintf("invalid variable name '%s'", name.Value))
+			a.errorMsg(name.Pos(), catalog.InvalidVariableName, name.Value)
 			continue
 		}
 
@@ -326,11 +481,14 @@ func (a *Analyzer) analyzeVarDeclStmt(stmt *ast.VarDeclStmt) {
 
 		// Check type compatibility if explicit type is specified
 		if stmt.Type != nil && len(stmt.Values) == len(stmt.Names) {
+			a.checkEnumValue(stmt.Values[i], varType)
 			if !a.typesCompatible(varType, valueTypes[i]) {
-				a.error(stmt.Pos(), fmt.Sprintf("cannot assign %s to %s", valueTypes[i], varType))
+				a.errorMsg(stmt.Pos(), catalog.AssignType, valueTypes[i], varType)
 			}
 		}
 
+		a.checkShadowing(stmt, name)
+
 		// Add variable to symbol table
 		symbol := &Symbol{
 			Name:    name.Value,
@@ -341,6 +499,8 @@ func (a *Analyzer) analyzeVarDeclStmt(stmt *ast.VarDeclStmt) {
 		}
 		if err := a.symbolTable.Define(symbol); err != nil {
 			a.error(name.Pos(), err.Error())
+		} else if len(stmt.Values) == len(stmt.Names) {
+			a.trackNilAssignment(symbol, stmt.Values[i])
 		}
 	}
 }
@@ -350,7 +510,7 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 	for _, target := range stmt.Targets {
 		if ident, ok := target.(*ast.Identifier); ok && ident.Value != "_" {
 			if sym := a.symbolTable.Resolve(ident.Value); sym != nil && sym.Kind == SymbolConst {
-				a.error(ident.Pos(), fmt.Sprintf("cannot assign to constant '%s'", ident.Value))
+				a.errorMsg(ident.Pos(), catalog.AssignConstant, ident.Value)
 			}
 		}
 	}
@@ -365,14 +525,18 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 	for i, val := range stmt.Values {
 		valueTypes[i] = a.analyzeExpression(val)
 	}
+	a.trackNilAssign(stmt)
+	if len(stmt.Targets) == 1 && len(stmt.Values) == 1 && stmt.OnErr == nil && isCallLike(stmt.Values[0]) && a.exprReturnCounts[stmt.Values[0]] == 1 {
+		a.noteDroppedError(stmt, valueTypes, targetNames(stmt.Targets))
+	}
 
 	if stmt.Token.Type == lexer.TOKEN_BIT_AND_ASSIGN {
 		if len(stmt.Targets) != 1 || len(stmt.Values) != 1 {
-			a.error(stmt.Pos(), "bitwise AND assignment requires a single target and a single value")
+			a.errorMsg(stmt.Pos(), catalog.BitwiseAndAssignShape)
 			return
 		}
 		if !isBitwiseType(targetTypes[0]) || !isBitwiseType(valueTypes[0]) {
-			a.error(stmt.Pos(), fmt.Sprintf("bitwise AND assignment requires integer operands, got %s and %s", targetTypes[0], valueTypes[0]))
+			a.errorMsg(stmt.Pos(), catalog.BitwiseAndAssignNotInteger, targetTypes[0], valueTypes[0])
 		}
 	}
 
@@ -397,12 +561,15 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 			multiValueTypes = a.analyzeExpressionMulti(stmt.Values[0])
 		}
 
+		if stmt.OnErr == nil {
+			a.noteDroppedError(stmt, multiValueTypes, targetNames(stmt.Targets))
+		}
 		if len(multiValueTypes) != len(stmt.Targets) {
 			// If we can't match exact count, check if it's dynamic/unknown
 			if len(multiValueTypes) == 1 && multiValueTypes[0].Kind == TypeKindUnknown {
 				// Allow assignment of Unknown to multiple variables
 			} else {
-				a.error(stmt.Pos(), fmt.Sprintf("assignment mismatch: %d variables but %d values", len(stmt.Targets), len(multiValueTypes)))
+				a.errorMsg(stmt.Pos(), catalog.AssignCount, len(stmt.Targets), len(multiValueTypes))
 				return
 			}
 		}
@@ -417,7 +584,7 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 			}
 
 			if !a.typesCompatible(targetTypes[i], valType) {
-				a.error(stmt.Pos(), fmt.Sprintf("cannot assign %s to %s", valType, targetTypes[i]))
+				a.errorMsg(stmt.Pos(), catalog.AssignType, valType, targetTypes[i])
 			}
 		}
 		return
@@ -425,7 +592,7 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 
 	// Check that number of values matches number of targets
 	if len(stmt.Values) != len(stmt.Targets) {
-		a.error(stmt.Pos(), fmt.Sprintf("assignment mismatch: %d variables but %d values", len(stmt.Targets), len(stmt.Values)))
+		a.errorMsg(stmt.Pos(), catalog.AssignCount, len(stmt.Targets), len(stmt.Values))
 		return
 	}
 
@@ -433,16 +600,51 @@ func (a *Analyzer) analyzeAssignStmt(stmt *ast.AssignStmt) {
 	if len(stmt.Values) == len(stmt.Targets) {
 		// One value per target: check each pair
 		for i := range stmt.Targets {
+			a.checkEnumValue(stmt.Values[i], targetTypes[i])
 			if !a.typesCompatible(targetTypes[i], valueTypes[i]) {
-				a.error(stmt.Pos(), fmt.Sprintf("cannot assign %s to %s", valueTypes[i], targetTypes[i]))
+				a.errorMsg(stmt.Pos(), catalog.AssignType, valueTypes[i], targetTypes[i])
 			}
 		}
 	}
 }
 
+// analyzeLambdaReturn checks a return inside a block lambda against the
+// signature the lambda was given by its call site. Without one, the values
+// are only analyzed: the enclosing function's results do not apply.
+func (a *Analyzer) analyzeLambdaReturn(stmt *ast.ReturnStmt) {
+	var valueTypes []*TypeInfo
+	if len(stmt.Values) == 1 && a.lambdaSig != nil && len(a.lambdaSig.Returns) > 1 {
+		valueTypes = a.analyzeExpressionMulti(stmt.Values[0])
+	} else {
+		for _, v := range stmt.Values {
+			valueTypes = append(valueTypes, a.analyzeExpression(v))
+		}
+	}
+	if a.lambdaSig == nil {
+		return
+	}
+	if len(valueTypes) != len(a.lambdaSig.Returns) {
+		if len(valueTypes) == 1 && valueTypes[0].Kind == TypeKindUnknown {
+			return
+		}
+		a.errorMsg(stmt.Pos(), catalog.ReturnCount, len(a.lambdaSig.Returns), len(valueTypes))
+		return
+	}
+	for i, want := range a.lambdaSig.Returns {
+		if !a.typesCompatible(want, valueTypes[i]) {
+			a.errorMsg(stmt.Pos(), catalog.ReturnType, valueTypes[i], want)
+		}
+	}
+}
+
 func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 	if a.currentFunc == nil {
-		a.error(stmt.Pos(), "return statement outside of function")
+		a.errorMsg(stmt.Pos(), catalog.ReturnOutsideFunction)
+		return
+	}
+
+	if a.inLambda {
+		a.analyzeLambdaReturn(stmt)
 		return
 	}
 
@@ -455,6 +657,20 @@ func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 		return
 	}
 
+	if a.closureBlock != "" {
+		a.errorMsg(stmt.Pos(), catalog.ReturnInBlock, a.closureBlock)
+		return
+	}
+
+	// An iterator's body runs in the closure it returns, so return only
+	// stops the iteration
+	if a.currentFunc.Yields != nil {
+		if len(stmt.Values) > 0 {
+			a.errorMsg(stmt.Pos(), catalog.IteratorReturnValue)
+		}
+		return
+	}
+
 	// Special handling for multi-value return from single expression (e.g., pipe expression)
 	var valueTypes []*TypeInfo
 	if len(stmt.Values) == 1 && len(a.currentFunc.Returns) > 1 {
@@ -465,7 +681,7 @@ func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 			if len(valueTypes) == 1 && valueTypes[0].Kind == TypeKindUnknown {
 				// Allow return of Unknown to multiple return positions
 			} else {
-				a.error(stmt.Pos(), fmt.Sprintf("expected %d return values, got %d", len(a.currentFunc.Returns), len(valueTypes)))
+				a.errorMsg(stmt.Pos(), catalog.ReturnCount, len(a.currentFunc.Returns), len(valueTypes))
 				return
 			}
 		}
@@ -481,7 +697,7 @@ func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 			expectedType := a.typeAnnotationToTypeInfo(a.currentFunc.Returns[i])
 
 			if !a.typesCompatible(expectedType, valType) {
-				a.error(stmt.Pos(), fmt.Sprintf("cannot return %s as %s", valType, expectedType))
+				a.errorMsg(stmt.Pos(), catalog.ReturnType, valType, expectedType)
 			}
 		}
 		return
@@ -489,7 +705,7 @@ func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 
 	// Check return value count
 	if len(stmt.Values) != len(a.currentFunc.Returns) {
-		a.error(stmt.Pos(), fmt.Sprintf("expected %d return values, got %d", len(a.currentFunc.Returns), len(stmt.Values)))
+		a.errorMsg(stmt.Pos(), catalog.ReturnCount, len(a.currentFunc.Returns), len(stmt.Values))
 		return
 	}
 
@@ -497,9 +713,43 @@ func (a *Analyzer) analyzeReturnStmt(stmt *ast.ReturnStmt) {
 	for i, value := range stmt.Values {
 		valueType := a.analyzeExpression(value)
 		expectedType := a.typeAnnotationToTypeInfo(a.currentFunc.Returns[i])
+		a.checkEnumValue(value, expectedType)
 
 		if !a.typesCompatible(expectedType, valueType) {
-			a.error(stmt.Pos(), fmt.Sprintf("cannot return %s as %s", valueType, expectedType))
+			a.errorMsg(stmt.Pos(), catalog.ReturnType, valueType, expectedType)
+		}
+	}
+}
+
+// analyzeYieldStmt checks a yield against the values its iterator function
+// declares. A closure in between would stop itself instead of the iterator.
+func (a *Analyzer) analyzeYieldStmt(stmt *ast.YieldStmt) {
+	types := make([]*TypeInfo, len(stmt.Values))
+	for i, value := range stmt.Values {
+		types[i] = a.analyzeExpression(value)
+	}
+	if a.currentFunc == nil || a.currentFunc.Yields == nil || a.inLambda {
+		a.errorMsg(stmt.Pos(), catalog.YieldOutsideIterator)
+		return
+	}
+	if a.closureBlock != "" || a.inPipedSwitch || a.inGoBlock {
+		block := a.closureBlock
+		if a.inGoBlock {
+			block = "go"
+		} else if block == "" {
+			block = "piped switch"
+		}
+		a.errorMsg(stmt.Pos(), catalog.YieldInBlock, block)
+		return
+	}
+	if len(types) != len(a.currentFunc.Yields) {
+		a.errorMsg(stmt.Pos(), catalog.YieldCount, len(a.currentFunc.Yields), len(types))
+		return
+	}
+	for i, got := range types {
+		want := a.typeAnnotationToTypeInfo(a.currentFunc.Yields[i])
+		if !a.typesCompatible(want, got) {
+			a.errorMsg(stmt.Values[i].Pos(), catalog.YieldType, got, want)
 		}
 	}
 }
@@ -508,33 +758,83 @@ func (a *Analyzer) analyzeIfStmt(stmt *ast.IfStmt) {
 	// Analyze condition
 	condType := a.analyzeExpression(stmt.Condition)
 	if condType.Kind != TypeKindBool && condType.Kind != TypeKindUnknown {
-		a.error(stmt.Pos(), "if condition must be boolean")
+		a.errorMsg(stmt.Pos(), catalog.IfNotBoolean)
+	}
+
+	// Nil-use state: narrow each branch on `x == empty` / `x != empty`,
+	// then join the branches that fall through.
+	nilBefore := a.maybeNil.clone()
+	nilSym, nilIsEmpty, narrowed := a.nilComparison(stmt.Condition)
+	if narrowed {
+		a.narrowNil(nilSym, nilIsEmpty, stmt.Condition.Pos())
 	}
 
 	// Analyze consequence
 	a.symbolTable.EnterScope()
 	a.analyzeBlock(stmt.Consequence)
 	a.symbolTable.ExitScope()
+	a.checkLengthTruncation(stmt)
+	nilAfterCons := a.maybeNil
+	consTerminates := blockTerminates(stmt.Consequence)
+
+	a.maybeNil = nilBefore.clone()
+	if narrowed {
+		a.narrowNil(nilSym, !nilIsEmpty, stmt.Condition.Pos())
+	}
 
 	// Analyze alternative
+	altTerminates := false
 	if stmt.Alternative != nil {
 		a.symbolTable.EnterScope()
 		switch alt := stmt.Alternative.(type) {
 		case *ast.ElseStmt:
 			a.analyzeBlock(alt.Body)
+			altTerminates = blockTerminates(alt.Body)
 		case *ast.IfStmt:
 			a.analyzeIfStmt(alt)
 		}
 		a.symbolTable.ExitScope()
 	}
+
+	if a.maybeNil == nil {
+		return
+	}
+	switch {
+	case consTerminates && altTerminates:
+		a.maybeNil = nilBefore
+	case consTerminates:
+		// Only the alternative (or the implicit empty else) falls through.
+	case altTerminates:
+		a.maybeNil = nilAfterCons
+	default:
+		a.maybeNil.union(nilAfterCons)
+	}
+}
+
+// iteratorYieldTypes returns the values a range-over-func iterator yields:
+// the parameter types of yield in func(yield func(V) bool) or
+// func(yield func(K, V) bool), the shapes of iter.Seq and iter.Seq2.
+// It returns nil for any other type.
+func iteratorYieldTypes(ti *TypeInfo) []*TypeInfo {
+	if ti == nil || ti.Kind != TypeKindFunction || len(ti.Params) != 1 || len(ti.Returns) != 0 {
+		return nil
+	}
+	yield := ti.Params[0]
+	if yield == nil || yield.Kind != TypeKindFunction || len(yield.Params) == 0 || len(yield.Params) > 2 {
+		return nil
+	}
+	return yield.Params
 }
 
 func (a *Analyzer) analyzeForRangeStmt(stmt *ast.ForRangeStmt) {
-	a.loopDepth++
-	defer func() { a.loopDepth-- }()
+	a.enterLoop(stmt.Body)
+	defer a.exitLoop()
 
 	// Analyze collection
 	collType := a.analyzeExpression(stmt.Collection)
+	if collType.Kind == TypeKindChannel && collType.ChanDir == "send-only" {
+		a.errorMsg(stmt.Collection.Pos(), catalog.ReceiveOnSendOnly, collType)
+	}
 
 	a.symbolTable.EnterScope()
 	defer a.symbolTable.ExitScope()
@@ -553,13 +853,23 @@ func (a *Analyzer) analyzeForRangeStmt(stmt *ast.ForRangeStmt) {
 		} else {
 			elemType = &TypeInfo{Kind: TypeKindUnknown}
 		}
+	} else if collType.Kind == TypeKindUnknown {
+		// An untyped Go value may be a map, so its keys aren't ints
+		indexType, elemType = collType, collType
 	} else {
-		// for index, elem in list/string/channel: index is int
+		// for index, elem in list/string: index is int
 		indexType = &TypeInfo{Kind: TypeKindInt}
 		if collType.Kind == TypeKindList && collType.ElementType != nil {
 			elemType = collType.ElementType
 		} else if collType.Kind == TypeKindString {
 			elemType = &TypeInfo{Kind: TypeKindInt} // rune
+		} else if collType.Kind == TypeKindChannel && collType.ElementType != nil {
+			elemType = collType.ElementType
+		} else if yielded := iteratorYieldTypes(collType); len(yielded) == 1 {
+			elemType = yielded[0]
+		} else if len(yielded) == 2 {
+			// for key, value in an iter.Seq2-shaped function
+			indexType, elemType = yielded[0], yielded[1]
 		} else {
 			elemType = &TypeInfo{Kind: TypeKindUnknown}
 		}
@@ -590,19 +900,90 @@ func (a *Analyzer) analyzeForRangeStmt(stmt *ast.ForRangeStmt) {
 	a.analyzeBlock(stmt.Body)
 }
 
+// analyzeFailStmt checks a fail statement. It exits the process, so it is
+// only allowed in package main: library code returns an error instead.
+func (a *Analyzer) analyzeFailStmt(stmt *ast.FailStmt) {
+	if a.program.PetioleDecl != nil && a.program.PetioleDecl.Name != nil &&
+		a.program.PetioleDecl.Name.Value != "main" {
+		a.errorMsg(stmt.Pos(), catalog.FailOutsideMain)
+	}
+	msgType := a.analyzeExpression(stmt.Message)
+	if msgType.Kind != TypeKindString && msgType.Kind != TypeKindUnknown && !isErrorTypeInfo(msgType) {
+		a.errorMsg(stmt.Message.Pos(), catalog.FailMessageType, msgType)
+	}
+	if stmt.Code != nil {
+		codeType := a.analyzeExpression(stmt.Code)
+		if codeType.Kind != TypeKindInt && codeType.Kind != TypeKindUnknown {
+			a.errorMsg(stmt.Code.Pos(), catalog.FailCodeType, codeType)
+		}
+	}
+}
+
+// analyzeSendStmt rejects sending on a receive-only channel.
+func (a *Analyzer) analyzeSendStmt(stmt *ast.SendStmt) {
+	a.analyzeExpression(stmt.Value)
+	chanType := a.analyzeExpression(stmt.Channel)
+	if chanType.Kind == TypeKindChannel && chanType.ChanDir == "receive-only" {
+		a.errorMsg(stmt.Channel.Pos(), catalog.SendOnReceiveOnly, chanType)
+	}
+}
+
+// analyzeRequireStmt checks a guard clause. Its else runs when the condition
+// is false and must not fall through, so afterwards the condition holds:
+// `require user != empty else return` narrows user like an early return.
+func (a *Analyzer) analyzeRequireStmt(stmt *ast.RequireStmt) {
+	condType := a.analyzeExpression(stmt.Condition)
+	if condType.Kind != TypeKindBool && condType.Kind != TypeKindUnknown {
+		a.errorMsg(stmt.Condition.Pos(), catalog.RequireNotBoolean, condType)
+	}
+
+	nilBefore := a.maybeNil.clone()
+	nilSym, nilIsEmpty, narrowed := a.nilComparison(stmt.Condition)
+	if narrowed {
+		a.narrowNil(nilSym, !nilIsEmpty, stmt.Condition.Pos())
+	}
+	a.symbolTable.EnterScope()
+	a.analyzeBlock(stmt.Else)
+	a.symbolTable.ExitScope()
+	if !blockExits(stmt.Else) {
+		a.errorMsg(ast.TokenPos(stmt.Else.Token), catalog.RequireElseMustExit)
+	}
+
+	if a.maybeNil == nil {
+		return
+	}
+	a.maybeNil = nilBefore
+	if narrowed {
+		a.narrowNil(nilSym, nilIsEmpty, stmt.Condition.Pos())
+	}
+}
+
+// blockExits reports whether block ends by leaving the enclosing function or
+// loop, counting fail, which exits the program.
+func blockExits(block *ast.BlockStmt) bool {
+	if blockTerminates(block) {
+		return true
+	}
+	if block == nil || len(block.Statements) == 0 {
+		return false
+	}
+	_, isFail := block.Statements[len(block.Statements)-1].(*ast.FailStmt)
+	return isFail
+}
+
 func (a *Analyzer) analyzeForNumericStmt(stmt *ast.ForNumericStmt) {
-	a.loopDepth++
-	defer func() { a.loopDepth-- }()
+	a.enterLoop(stmt.Body)
+	defer a.exitLoop()
 
 	// Analyze start and end expressions
 	startType := a.analyzeExpression(stmt.Start)
 	endType := a.analyzeExpression(stmt.End)
 
 	if startType.Kind != TypeKindInt && startType.Kind != TypeKindUnknown {
-		a.error(stmt.Pos(), "for loop start must be int")
+		a.errorMsg(stmt.Pos(), catalog.ForStartNotInt)
 	}
 	if endType.Kind != TypeKindInt && endType.Kind != TypeKindUnknown {
-		a.error(stmt.Pos(), "for loop end must be int")
+		a.errorMsg(stmt.Pos(), catalog.ForEndNotInt)
 	}
 
 	a.symbolTable.EnterScope()
@@ -623,13 +1004,13 @@ func (a *Analyzer) analyzeForNumericStmt(stmt *ast.ForNumericStmt) {
 }
 
 func (a *Analyzer) analyzeForConditionStmt(stmt *ast.ForConditionStmt) {
-	a.loopDepth++
-	defer func() { a.loopDepth-- }()
+	a.enterLoop(stmt.Body)
+	defer a.exitLoop()
 
 	// Analyze condition
 	condType := a.analyzeExpression(stmt.Condition)
 	if condType.Kind != TypeKindBool && condType.Kind != TypeKindUnknown {
-		a.error(stmt.Pos(), "for condition must be boolean")
+		a.errorMsg(stmt.Pos(), catalog.ForNotBoolean)
 	}
 
 	a.symbolTable.EnterScope()
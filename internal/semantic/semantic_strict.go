@@ -0,0 +1,55 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// SetStrictTypes makes Analyze report, as errors, each place type inference
+// falls back to Unknown: package members missing from the type registries,
+// methods and fields that can't be resolved. Only the point where the type is
+// lost is reported, not every later use of the Unknown value. Call before
+// Analyze.
+func (a *Analyzer) SetStrictTypes(strict bool) {
+	a.strictTypes = strict
+}
+
+// reportUnknownMember reports obj.name (a method call when isMethod) whose
+// type could not be inferred. objType is the type of obj; a receiver that is
+// itself Unknown was already reported where its type was lost.
+func (a *Analyzer) reportUnknownMember(node ast.Node, obj ast.Expression, objType *TypeInfo, name string, isMethod bool) {
+	if !a.strictTypes {
+		return
+	}
+	if pkg, ok := a.packageOf(obj); ok {
+		a.errorMsg(node.Pos(), catalog.StrictUnknownPackageMember, pkg+"."+name)
+		return
+	}
+	if objType == nil || objType.Kind == TypeKindUnknown || objType.Kind == TypeKindPlaceholder {
+		return
+	}
+	if isMethod {
+		a.errorMsg(node.Pos(), catalog.StrictUnknownMethod, name, objType)
+	} else {
+		a.errorMsg(node.Pos(), catalog.StrictUnknownField, name, objType)
+	}
+}
+
+// packageOf returns the name of the imported package expr refers to, if any.
+// A local variable that shadows the package name is not a package.
+func (a *Analyzer) packageOf(expr ast.Expression) (string, bool) {
+	ident, ok := expr.(*ast.Identifier)
+	if !ok {
+		return "", false
+	}
+	sym := a.symbolTable.Resolve(ident.Value)
+	if sym == nil {
+		return "", false
+	}
+	for _, imp := range a.program.Imports {
+		if sym.Defined == imp.Pos() && a.extractPackageName(imp) == ident.Value {
+			return ident.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,57 @@
+package semantic
+
+import (
+	"testing"
+)
+
+const strictInput = `import "os"
+
+interface Shape
+    Area() float64
+
+type Box
+    w int
+
+func Width on b Box int
+    return b.w
+
+func main()
+    b := Box{w: 1}
+    n := b.size
+    m := b.Grow(2)
+    args := os.Args
+    x := n.Foo()
+    os := "shadowed"
+    s := empty Shape
+    print(n, m, args, x, os.Name, b.Width(), s.Area())
+`
+
+func TestStrictTypesReportsInferenceGaps(t *testing.T) {
+	program := mustParseProgram(t, strictInput)
+	analyzer := New(program)
+	analyzer.SetStrictTypes(true)
+	errs := analyzer.Analyze()
+
+	// n.Foo() is not reported because n is already unknown, and os.Name is a
+	// field of the local string, not a member of the shadowed package.
+	want := []string{
+		"test.kuki:14:10: cannot infer the type of field 'size' on Box (--strict-types)",
+		"test.kuki:15:10: cannot infer the result of method 'Grow' on Box (--strict-types)",
+		"test.kuki:16:14: cannot infer the type of 'os.Args': it is not in Kukicha's type registry (--strict-types)",
+		"test.kuki:20:27: cannot infer the type of field 'Name' on string (--strict-types)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestStrictTypesOffByDefault(t *testing.T) {
+	if _, errs := analyzeSource(t, strictInput); len(errs) != 0 {
+		t.Errorf("expected no errors without strict types, got %v", errs)
+	}
+}
@@ -257,3 +257,129 @@ func main()
 		t.Errorf("unexpected error: %v", e)
 	}
 }
+
+func TestMethodDeclaredBeforeReceiverType(t *testing.T) {
+	input := `func Area on r reference Rect(scale int) int
+    return r.w * r.h * scale
+
+func Split on r Rect() (int, error)
+    return r.w, empty
+
+type Rect
+    w int
+    h int
+
+func main()
+    r := Rect{w: 1, h: 2}
+    a := r.Area()
+    b := r.Area("big")
+    p := reference of r
+    c := p.Area(2) + "x"
+    d := r.Split() onerr 0
+    print(a, b, c, d)
+`
+
+	analyzer, errors := analyzeSource(t, input)
+
+	want := []string{
+		"test.kuki:13:10: expected at least 1 arguments, got 0",
+		"test.kuki:14:10: argument 1: cannot use string as int",
+		"test.kuki:16:19: cannot apply + to int and string",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errors)
+	}
+	for i, w := range want {
+		if errors[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errors[i], w)
+		}
+	}
+
+	for expr, count := range analyzer.ReturnCounts() {
+		if mc, ok := expr.(*ast.MethodCallExpr); ok && mc.Method.Value == "Split" && count != 2 {
+			t.Errorf("expected return count 2 for Split, got %d", count)
+		}
+	}
+}
+
+func TestReferenceMethodNeedsAddressableReceiver(t *testing.T) {
+	input := `type Counter
+    n int
+
+func Inc on c reference Counter
+    c.n = c.n + 1
+
+func Show on c Counter string
+    return "n={c.n}"
+
+func newCounter() Counter
+    return Counter{n: 1}
+
+func newRef() reference Counter
+    return reference of Counter{n: 1}
+
+func main()
+    c := Counter{n: 0}
+    c.Inc()
+    Counter{n: 2}.Inc()
+    newRef().Inc()
+    print(newRef().Show(), (reference of c).Show())
+    newCounter().Inc()
+    newCounter() |> .Inc()
+`
+
+	_, errors := analyzeSource(t, input)
+
+	want := []string{
+		"test.kuki:22:16: cannot call 'Inc' on this Counter value: the method has a reference receiver and the value has no address; assign it to a variable first",
+		"test.kuki:23:20: cannot call 'Inc' on this Counter value: the method has a reference receiver and the value has no address; assign it to a variable first",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errors)
+	}
+	for i, w := range want {
+		if errors[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errors[i], w)
+		}
+	}
+}
+
+func TestInterfaceCastRecordsInterfaceKind(t *testing.T) {
+	input := `import "io"
+
+interface Shape
+    Area() float64
+
+func describe(x any)
+    s := x as Shape
+    r := empty io.Reader
+    print(s, r)
+`
+
+	analyzer, errors := analyzeSource(t, input)
+	for _, e := range errors {
+		t.Errorf("unexpected error: %v", e)
+	}
+
+	found := 0
+	for expr, typeInfo := range analyzer.ExprTypes() {
+		switch e := expr.(type) {
+		case *ast.TypeCastExpr:
+			found++
+			if typeInfo.Kind != TypeKindInterface || typeInfo.Name != "Shape" {
+				t.Errorf("expected interface Shape for cast, got %s (%s)", typeInfo.Kind, typeInfo)
+			}
+		case *ast.EmptyExpr:
+			if e.Type == nil {
+				continue
+			}
+			found++
+			if typeInfo.Kind != TypeKindInterface || typeInfo.Name != "io.Reader" {
+				t.Errorf("expected interface io.Reader for typed empty, got %s (%s)", typeInfo.Kind, typeInfo)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("expected 2 typed expressions to be recorded, got %d", found)
+	}
+}
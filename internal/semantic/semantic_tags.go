@@ -0,0 +1,201 @@
+package semantic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// Struct tags are read by encoders at run time, and their mistakes are
+// silent there: encoding/json ignores a name it considers invalid, drops
+// both fields when two share a name, and a malformed tag reads as no tag at
+// all. checkStructTags reports them where the type is declared.
+
+// tagPair is one key:"value" pair of a struct tag.
+type tagPair struct {
+	key   string
+	value string
+}
+
+// splitStructTag splits a Go struct tag into its pairs as reflect.StructTag
+// reads them, reporting what is wrong when the tag doesn't follow the
+// key:"value" convention.
+func splitStructTag(tag string) ([]tagPair, string) {
+	if strings.ContainsRune(tag, '`') {
+		return nil, "it contains a backtick"
+	}
+	var pairs []tagPair
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return pairs, ""
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, fmt.Sprintf("expected key:\"value\" at %q", tag)
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, fmt.Sprintf("unterminated value for %s", key)
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, fmt.Sprintf("invalid value for %s", key)
+		}
+		tag = tag[i+1:]
+		if tag != "" && tag[0] != ' ' {
+			return nil, fmt.Sprintf("expected a space after the %s value", key)
+		}
+		pairs = append(pairs, tagPair{key: key, value: value})
+	}
+}
+
+// checkStructTags validates the tags of a struct type: each is well formed,
+// json names and options are ones encoding/json accepts, no two fields share
+// a name under the same key, and names follow their format's convention.
+func (a *Analyzer) checkStructTags(decl *ast.TypeDecl) {
+	derivesJSON := false
+	for _, d := range ast.TypeDerives(decl) {
+		derivesJSON = derivesJSON || d.Name == "json"
+	}
+	owners := make(map[string]map[string]*ast.FieldDecl) // tag key → name → field
+	styles := make(map[string]string)                    // tag key → first naming style seen
+	claim := func(key, name string, field *ast.FieldDecl) {
+		if owners[key] == nil {
+			owners[key] = make(map[string]*ast.FieldDecl)
+		}
+		if prev, ok := owners[key][name]; ok {
+			a.errorMsg(field.Name.Pos(), catalog.StructTagCollision, prev.Name.Value, field.Name.Value, key, name)
+			return
+		}
+		owners[key][name] = field
+	}
+
+	for _, field := range decl.Fields {
+		pairs, problem := splitStructTag(field.Tag)
+		if problem != "" {
+			a.errorMsg(field.Name.Pos(), catalog.StructTagMalformed, field.Name.Value, problem)
+			continue
+		}
+		hasJSON := false
+		for _, pair := range pairs {
+			name, options, hasOptions := strings.Cut(pair.value, ",")
+			if pair.key == "json" {
+				hasJSON = true
+				a.checkJSONTag(field, name, options)
+			}
+			if name == "-" && !hasOptions {
+				continue
+			}
+			if name == "" {
+				if pair.key == "json" && (isExported(field.Name.Value) || derivesJSON) {
+					claim("json", field.Name.Value, field)
+				}
+				continue
+			}
+			claim(pair.key, name, field)
+			a.checkTagConvention(field, pair.key, name, styles)
+		}
+		// An untagged field is encoded under its own name
+		if !hasJSON && (isExported(field.Name.Value) || derivesJSON) {
+			claim("json", field.Name.Value, field)
+		}
+	}
+}
+
+// checkJSONTag reports a json name encoding/json would ignore (it falls back
+// to the field name) and options it doesn't know.
+func (a *Analyzer) checkJSONTag(field *ast.FieldDecl, name, options string) {
+	if name != "" && !validJSONName(name) {
+		a.errorMsg(field.Name.Pos(), catalog.StructTagJSONName, name, field.Name.Value)
+	}
+	if options == "" {
+		return
+	}
+	for option := range strings.SplitSeq(options, ",") {
+		switch option {
+		case "omitempty", "omitzero", "string":
+		default:
+			a.errorMsg(field.Name.Pos(), catalog.StructTagJSONOption, option, field.Name.Value)
+		}
+	}
+}
+
+// validJSONName mirrors encoding/json's rule for tag names.
+func validJSONName(name string) bool {
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkTagConvention warns about names that break the convention of their
+// format: db columns are snake_case (unquoted SQL names fold case), env
+// variables UPPER_SNAKE_CASE, and the names of one struct's json or yaml
+// keys share a style rather than mixing user_id with createdAt.
+func (a *Analyzer) checkTagConvention(field *ast.FieldDecl, key, name string, styles map[string]string) {
+	style := namingStyle(name)
+	switch key {
+	case "db":
+		if style != "" && style != "snake_case" {
+			a.warn(field.Name.Pos(), fmt.Sprintf("db column %q on field %s is %s; SQL folds unquoted names, write it in snake_case", name, field.Name.Value, style))
+		}
+	case "env":
+		if name != strings.ToUpper(name) {
+			a.warn(field.Name.Pos(), fmt.Sprintf("env variable %q on field %s is not UPPER_SNAKE_CASE; variable names are case-sensitive", name, field.Name.Value))
+		}
+	case "json", "yaml", "toml":
+		if style == "" || style == "UPPERCASE" {
+			return
+		}
+		first, ok := styles[key]
+		if !ok {
+			styles[key] = style
+			return
+		}
+		if first != style {
+			a.warn(field.Name.Pos(), fmt.Sprintf("%s name %q on field %s is %s but earlier fields use %s; keep one naming style per struct", key, name, field.Name.Value, style, first))
+		}
+	}
+}
+
+// namingStyle classifies a multi-word name, or returns "" for a single
+// lowercase word, which fits any style.
+func namingStyle(name string) string {
+	hasUpper := strings.ToLower(name) != name
+	hasLower := strings.ToUpper(name) != name
+	switch {
+	case strings.Contains(name, "-"):
+		return "kebab-case"
+	case strings.Contains(name, "_") && hasLower && !hasUpper:
+		return "snake_case"
+	case strings.Contains(name, "_") && hasUpper && !hasLower:
+		return "UPPER_SNAKE_CASE"
+	case strings.Contains(name, "_"):
+		return "mixed case"
+	case hasUpper && hasLower && unicode.IsUpper(rune(name[0])):
+		return "PascalCase"
+	case hasUpper && hasLower:
+		return "camelCase"
+	case hasUpper:
+		return "UPPERCASE"
+	}
+	return ""
+}
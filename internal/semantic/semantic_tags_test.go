@@ -0,0 +1,90 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructTagErrors(t *testing.T) {
+	input := `type User
+    ID int as "id"
+    UserID int as "id"
+    Email string json:"e\"mail"
+    Bio string json:"bio,omitnil"
+    Nick string json:"nick's"
+    Title string json:"title" yaml:"name"
+    Name string yaml:"name"
+`
+	errs, _ := analyzeInputWithFile(t, input, "app.kuki")
+	want := []string{
+		`app.kuki:3:4: fields ID and UserID both use the json name "id"`,
+		`app.kuki:4:4: malformed struct tag on field Email: expected a space after the json value`,
+		`app.kuki:5:4: unknown json option "omitnil" on field Bio`,
+		`app.kuki:6:4: encoding/json ignores the json name "nick's" on field Nick`,
+		`app.kuki:8:4: fields Title and Name both use the yaml name "name"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got: %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(errs[i].Error(), w) {
+			t.Errorf("error %d: expected prefix %q, got %q", i, w, errs[i])
+		}
+	}
+}
+
+func TestStructTagDefaultNamesCollide(t *testing.T) {
+	input := `type Account
+    Name string
+    Label string as "Name"
+    secret string
+    hidden string as "secret"
+    Skipped string json:"-"
+    Dash string json:"-,"
+    Other string json:"-"
+`
+	errs, _ := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `fields Name and Label both use the json name "Name"`) {
+		t.Fatalf("expected one collision with the untagged Name, got: %v", errs)
+	}
+
+	derived := `@derive json
+type Account
+    secret string
+    hidden string as "secret"
+`
+	errs, _ = analyzeInputWithFile(t, derived, "app.kuki")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `fields secret and hidden both use the json name "secret"`) {
+		t.Fatalf("expected @derive json to encode unexported fields, got: %v", errs)
+	}
+}
+
+func TestStructTagConventionWarnings(t *testing.T) {
+	input := `type Row
+    CreatedAt string as "createdAt"
+    UpdatedAt string as "updated_at"
+    ID int as "ID"
+    Owner string as "owner"
+    Table string db:"userTable"
+    Key string env:"api_key"
+    Port int env:"PORT"
+    UserID int db:"user_id"
+`
+	errs, warnings := analyzeInputWithFile(t, input, "app.kuki")
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []string{
+		`json name "updated_at" on field UpdatedAt is snake_case but earlier fields use camelCase`,
+		`db column "userTable" on field Table is camelCase`,
+		`env variable "api_key" on field Key is not UPPER_SNAKE_CASE`,
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got: %v", len(want), warnings)
+	}
+	for i, w := range want {
+		if !strings.Contains(warnings[i].Error(), w) {
+			t.Errorf("warning %d: expected %q, got %q", i, w, warnings[i])
+		}
+	}
+}
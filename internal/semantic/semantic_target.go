@@ -0,0 +1,69 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+)
+
+// selectTarget checks the target names of every `when target` block, then
+// resolves the blocks for the program's build target so the rest of the
+// analysis (and codegen) only sees the branch being compiled. Analyzing a
+// file for each of its targets is how `kukicha check` covers every branch.
+func (a *Analyzer) selectTarget() {
+	checkNames := func(names []*ast.Identifier) {
+		seen := make(map[string]bool)
+		for _, n := range names {
+			switch {
+			case !ast.IsTarget(n.Value):
+				a.errorMsg(n.Pos(), catalog.UnknownTarget, n.Value, strings.Join(ast.Targets, ", "))
+			case seen[n.Value]:
+				a.errorMsg(n.Pos(), catalog.TargetListedTwice, n.Value)
+			}
+			seen[n.Value] = true
+		}
+	}
+	checkStmts := func(body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			if ts, ok := stmt.(*ast.TargetStmt); ok {
+				a.targetBlocks = append(a.targetBlocks, ts.Pos())
+				checkNames(ts.Targets)
+			}
+			return false
+		})
+	}
+	// WalkStmts stops at closures; WalkBlock reaches closures at any depth.
+	checkBody := func(body *ast.BlockStmt) {
+		if body == nil {
+			return
+		}
+		checkStmts(body)
+		ast.WalkBlock(body, func(e ast.Expression) bool {
+			switch e := e.(type) {
+			case *ast.FunctionLiteral:
+				checkStmts(e.Body)
+			case *ast.ArrowLambda:
+				checkStmts(e.Block)
+			}
+			return false
+		})
+	}
+	var checkDecls func(decls []ast.Declaration)
+	checkDecls = func(decls []ast.Declaration) {
+		for _, decl := range decls {
+			switch d := decl.(type) {
+			case *ast.FunctionDecl:
+				checkBody(d.Body)
+			case *ast.TargetDecl:
+				a.targetBlocks = append(a.targetBlocks, d.Pos())
+				checkNames(d.Targets)
+				checkDecls(d.Declarations)
+				checkDecls(d.Otherwise)
+			}
+		}
+	}
+	checkDecls(a.program.Declarations)
+
+	ast.SelectTarget(a.program, a.program.Target)
+}
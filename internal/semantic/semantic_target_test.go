@@ -0,0 +1,53 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+const targetSource = `when target mcp
+    func Mode() string
+        return "mcp"
+otherwise
+    func Mode() string
+        return "cli"
+
+func main()
+    when target mcp
+        x := 1
+        print(x)
+    otherwise
+        x := "one"
+        print(x)
+    print(Mode())
+`
+
+func TestWhenTargetSelectsBranch(t *testing.T) {
+	for _, target := range []string{"", "cli", "mcp"} {
+		program := mustParseProgram(t, targetSource)
+		program.Target = target
+		if errs := NewWithFile(program, "test.kuki").Analyze(); len(errs) != 0 {
+			t.Fatalf("target %q: unexpected errors: %v", target, errs)
+		}
+		if len(program.Declarations) != 2 {
+			t.Fatalf("target %q: expected the otherwise Mode to be dropped, got %d declarations", target, len(program.Declarations))
+		}
+	}
+}
+
+func TestWhenTargetUnknownName(t *testing.T) {
+	input := `func main()
+    when target wasm, cli, cli
+        print(1)
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "unknown target 'wasm' (known targets: cli, mcp, a2a, http, worker)") {
+		t.Errorf("unexpected first error: %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "target 'cli' listed twice") {
+		t.Errorf("unexpected second error: %v", errs[1])
+	}
+}
@@ -1,10 +1,17 @@
 package semantic
 
 import (
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 func TestSimpleFunctionAnalysis(t *testing.T) {
@@ -206,6 +213,131 @@ func TestConditionSwitchRequiresBoolWhenBranches(t *testing.T) {
 	}
 }
 
+func TestSwitchWhenGuardMustBeBool(t *testing.T) {
+	input := `func Route(cmd string, retries int) string
+    switch cmd
+        when "fetch" if retries
+            return "fetch"
+        when "list" if retries > 0
+            return "list"
+    return "unknown"
+`
+
+	_, errors := analyzeSource(t, input)
+
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "'if' guard of a when branch must be bool, got int") {
+		t.Fatalf("expected one guard bool error, got: %v", errors)
+	}
+}
+
+func TestWhenPatterns(t *testing.T) {
+	input := `func Size(n int, name string) string
+    switch n
+        when < 0, 1 to 10
+            return "small"
+        when > "big"
+            return "big"
+        when matches re"x"
+            return "x"
+    switch name
+        when matches re"^t\d+"
+            return "test"
+        when matches re"(a"
+            return "bad"
+    switch
+        when >= 3
+            return "three"
+    return "other"
+`
+
+	_, errors := analyzeSource(t, input)
+
+	want := []string{
+		"5:15: cannot compare int and string",
+		"'matches' needs a string switch value and pattern, got int and string",
+		"invalid regex: missing closing ): `(a`",
+		"'when >=' needs a switch value to test",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got: %v", len(want), errors)
+	}
+	for i, w := range want {
+		if !strings.Contains(errors[i].Error(), w) {
+			t.Errorf("error %d = %q, want it to contain %q", i, errors[i], w)
+		}
+	}
+}
+
+func TestContinueToNextPlacement(t *testing.T) {
+	input := `func Grade(n int, v any) string
+    switch
+        when n > 90
+            continue to next
+        when n > 80
+            return "good"
+        otherwise
+            return "meh"
+
+func Bad(x int, v any)
+    for i from 0 to 3
+        continue to next
+    switch x
+        when 1
+            if x > 0
+                continue to next
+        when 2
+            continue to next
+    switch v as t
+        when string
+            continue to next
+        otherwise
+            print(t)
+`
+
+	_, errors := analyzeSource(t, input)
+
+	want := []string{
+		"test.kuki:12:8: 'continue to next' must be the last statement of a when branch in a switch (not a type switch)",
+		"test.kuki:16:16: 'continue to next' must be the last statement of a when branch in a switch (not a type switch)",
+		"test.kuki:18:12: 'continue to next' in the last branch of a switch: there is no next branch",
+		"test.kuki:21:12: 'continue to next' must be the last statement of a when branch in a switch (not a type switch)",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errors), errors)
+	}
+	for i, err := range errors {
+		if err.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, err.Error(), want[i])
+		}
+	}
+}
+
+func TestBigIntegerLiterals(t *testing.T) {
+	input := `const Huge = 1_000_000_000_000_000_000_000
+
+func main()
+    a := 18446744073709551615
+    b := 18446744073709551615 as uint64
+    c := 99_999_999_999_999_999_999
+    print(a, b, c, Huge / 1_000_000_000_000)
+`
+
+	_, errors := analyzeSource(t, input)
+
+	want := []string{
+		"test.kuki:4:9: integer literal 18446744073709551615 overflows int; write '18446744073709551615 as uint64' to keep it unsigned",
+		`test.kuki:6:9: integer literal 99_999_999_999_999_999_999 does not fit in 64 bits; keep it in a const expression or use math/big (new(big.Int).SetString("99_999_999_999_999_999_999", 0))`,
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errors), errors)
+	}
+	for i, err := range errors {
+		if err.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, err.Error(), want[i])
+		}
+	}
+}
+
 func TestTypedPipedSwitchSemantic(t *testing.T) {
 	input := `func Convert(value any) string
     result := value |> switch as v
@@ -259,6 +391,35 @@ func TestBooleanExpression(t *testing.T) {
 	}
 }
 
+func TestComparisonChain(t *testing.T) {
+	input := `func Test(x int, name string) bool
+    return 0 <= x < 10 and "a" <= name < 5
+`
+
+	analyzer, errors := analyzeSource(t, input)
+	_ = analyzer
+
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "cannot compare string and int") {
+		t.Fatalf("expected one comparison error, got: %v", errors)
+	}
+	if !strings.Contains(errors[0].Error(), "2:39") {
+		t.Errorf("expected the error at the second '<', got: %v", errors[0])
+	}
+}
+
+func TestRangeCheck(t *testing.T) {
+	input := `func Test(x int) bool
+    return x between 1 and 10 and x in 0 to "n"
+`
+
+	analyzer, errors := analyzeSource(t, input)
+	_ = analyzer
+
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "cannot compare int and string") {
+		t.Fatalf("expected one comparison error, got: %v", errors)
+	}
+}
+
 func TestInvalidBooleanOperand(t *testing.T) {
 	input := `func Test(x int) bool
     return x and 5
@@ -343,3 +504,666 @@ func Process(w string, data string) error
 		t.Error("expected _ placeholder to be typed as string from WriteJSON's second parameter")
 	}
 }
+
+func TestPipeSpreadsMultipleValues(t *testing.T) {
+	input := `func split(s string) (string, string)
+    return s, s
+
+func join(a string, b string, sep string) string
+    return a + sep + b
+
+func Run() string
+    return split("x") |> join(",")
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) > 0 {
+		t.Fatalf("expected multi-value pipe to spread into join, got: %v", errors)
+	}
+}
+
+func TestPipeSpreadArityMismatch(t *testing.T) {
+	input := `func split(s string) (string, string)
+    return s, s
+
+func join(a string, b string, c string, sep string) string
+    return a + b + c + sep
+
+func Run() string
+    return split("x") |> join(",")
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 arity error, got: %v", errors)
+	}
+	if !strings.Contains(errors[0].Error(), "only the first of the 2 piped values is passed; taking all of them needs 3 parameters") {
+		t.Errorf("expected piped values hint, got: %v", errors[0])
+	}
+}
+
+func TestParallelPipeTypes(t *testing.T) {
+	input := `func square(n int) int
+    return n * n
+
+func parse(s string) (int, error)
+    return len(s), empty
+
+func Run(nums list of int, words list of string) (list of int, error)
+    squares := nums |>> square()
+    lengths := words |>> parse() onerr return
+    return append(squares, many lengths), empty
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) > 0 {
+		t.Fatalf("unexpected errors: %v", errors)
+	}
+}
+
+func TestParallelPipeRequiresList(t *testing.T) {
+	input := `func square(n int) int
+    return n * n
+
+func Run(n int) list of int
+    return n |>> square()
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "parallel pipe '|>>' needs a list on the left, got int") {
+		t.Fatalf("expected list requirement error, got: %v", errors)
+	}
+}
+
+func TestPipeIntoUserMethodChecksArity(t *testing.T) {
+	input := `type Counter
+    total int
+
+func Scale on c Counter (n int, factor int) int
+    return n * factor + c.total
+
+func Run(c Counter) int
+    a := 3 |> c.Scale(2)
+    b := 3 |> c.Scale(2, 4)
+    return a + b
+`
+	_, errors := analyzeSource(t, input)
+	if len(errors) != 1 || !strings.Contains(errors[0].Error(), "expected at most 2 arguments, got 3") {
+		t.Fatalf("expected one arity error for the piped method call, got: %v", errors)
+	}
+}
+
+func TestLocalizedDiagnostics(t *testing.T) {
+	if err := catalog.SetLanguage("es"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { catalog.SetLanguage("en") })
+
+	input := `func main()
+    print(missing)
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "identificador no definido 'missing'") {
+		t.Errorf("expected a Spanish message, got: %v", errs[0])
+	}
+	if id := catalog.IDOf(errs[0]); id != catalog.UndefinedIdentifier {
+		t.Errorf("expected ID %s, got %q", catalog.UndefinedIdentifier, id)
+	}
+}
+
+// TestErrorsAreCatalogued keeps diagnostic text in internal/catalog: a.error
+// may only pass on errors from elsewhere (err.Error()), never a message
+// written here, which would not be translated or carry an ID.
+func TestErrorsAreCatalogued(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goast.Inspect(file, func(n goast.Node) bool {
+			call, ok := n.(*goast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*goast.SelectorExpr)
+			if !ok || sel.Sel.Name != "error" {
+				return true
+			}
+			goast.Inspect(call.Args[1], func(m goast.Node) bool {
+				if lit, ok := m.(*goast.BasicLit); ok && lit.Kind == token.STRING {
+					t.Errorf("%s: uncatalogued error message %s; add it to internal/catalog and report it with errorMsg", fset.Position(lit.Pos()), lit.Value)
+				}
+				return true
+			})
+			return true
+		})
+	}
+}
+
+func TestFailStmtOnlyInMain(t *testing.T) {
+	input := `petiole tools
+
+func Check(n int)
+    if n < 0
+        fail n code "2"
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{"only allowed in petiole main", "fail message must be a string or error, got int", "fail code must be int, got string"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+
+	input = `func main()
+    path := "config.json"
+    fail "missing {path}" code 2
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Errorf("expected no errors in main, got %v", errs)
+	}
+}
+
+func TestRequireStmt(t *testing.T) {
+	input := `type User
+    Name string
+
+func Name(u reference User, n int) string
+    require n else return ""
+    require n > 0 else
+        print("negative")
+    require u != empty else return "nobody"
+    return u.Name
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{"require condition must be bool, got int", "the else of a require must leave"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+
+	// break, continue and fail leave too
+	input = `func main()
+    for i from 0 to 10
+        require i != 3 else continue
+        require i < 8 else break
+        require i >= 0 else fail "negative"
+        print(i)
+`
+	if _, errs := analyzeSource(t, input); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestYieldStmt(t *testing.T) {
+	input := `type Bag
+    items list of string
+
+func Items on b Bag yields string
+    for item in b.items
+        yield item
+
+func Numbers() yields int
+    yield "one"
+    yield 1, 2
+    go
+        yield 3
+
+func Plain()
+    yield 1
+
+func main()
+    for item in Bag{}.Items()
+        print(item + "!")
+    for n in Numbers()
+        print(n + 1)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"9:10: cannot yield string as int",
+		"10:4: expected 1 yielded values, got 2",
+		"12:8: yield inside a go block",
+		"15:4: yield is only allowed in a 'yields' or 'sequence of T' function",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	input := `func Fib(limit int) sequence of int
+    a, b := 0, 1
+    for a < limit
+        yield a
+        a, b = b, a + b
+
+func Words(text string) sequence of string
+    if text equals ""
+        return
+    yield text
+    return text
+
+func Count() int
+    yield 1
+    return 1
+
+func main()
+    for n in Fib(10)
+        print(n + 1)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"11:4: return in an iterator takes no values",
+		"14:4: yield is only allowed in a 'yields' or 'sequence of T' function",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestChannelDirection(t *testing.T) {
+	input := `func produce(out channel of int send-only)
+    send 1 to out
+    x := receive from out
+    for v in out
+        print(v)
+    print(x)
+
+func consume(src channel of int receive-only)
+    send 1 to src
+    select
+        when send 2 to src
+            return
+        when v := receive from src
+            print(v)
+
+func widen(c channel of int)
+    print(c)
+
+func main()
+    ch := make(channel of int)
+    produce(ch)
+    consume(ch)
+    r := make(channel of int receive-only)
+    widen(r)
+    produce(r)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"3:22: cannot receive from channel of int send-only",
+		"4:13: cannot receive from channel of int send-only",
+		"9:14: cannot send on channel of int receive-only",
+		"11:23: cannot send on channel of int receive-only",
+		"cannot use channel of int receive-only as channel of int",
+		"cannot use channel of int receive-only as channel of int send-only",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestWaitExpr(t *testing.T) {
+	input := `import "time"
+
+func check(out channel of int send-only, n int)
+    a := wait for n
+    b := wait for out
+    c := wait for all n
+    ch := make(channel of int)
+    d := wait for ch within "soon"
+    print(a, b, c, d)
+
+func main()
+    ch := make(channel of string)
+    chans := list of channel of string{ch, ch}
+    s := wait for ch
+    got, err := wait for all chans within time.Second
+    v := wait for ch within 10 * time.Millisecond onerr "none"
+    print(s + v, len(got), err)
+`
+	a, errs := analyzeSource(t, input)
+	want := []string{
+		"4:18: wait for needs a channel, got int",
+		"5:18: cannot receive from channel of int send-only",
+		"6:22: wait for all needs a list of channels, got int",
+		"8:28: within needs a duration, got string",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+	// Only the within forms also produce an error
+	timed := 0
+	for expr, n := range a.ReturnCounts() {
+		if wait, ok := expr.(*ast.WaitExpr); ok {
+			if wait.Timeout == nil || n != 2 {
+				t.Errorf("unexpected return count %d for wait at %v", n, wait.Pos())
+			}
+			timed++
+		}
+	}
+	if timed != 3 {
+		t.Errorf("expected return counts for the 3 timed waits, got %d", timed)
+	}
+}
+
+func TestDistinctTypes(t *testing.T) {
+	input := `type Meters int distinct
+type Seconds int distinct
+type Label string distinct
+type Count int
+type Names list of string distinct
+
+func Double on m Meters() Meters
+    return m * 2
+
+func Bump on c Count() Count
+    return c + 1
+
+func main()
+    d := Meters(100)
+    t := 3 as Seconds
+    n := 2
+    far := d.Double() + 50
+    c := Count(1) + n
+    label := Label("x")
+    if label == "x" and far > d
+        print(c)
+    print(d + t, d * n, d < t)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"5:11: distinct needs a number, string or bool type, got list of string",
+		"10:15: cannot declare methods on Count, an alias of int; declare it distinct",
+		"22:12: cannot mix Meters and Seconds; convert one with as",
+		"22:19: cannot mix Meters and int; convert one with as",
+		"22:26: cannot mix Meters and Seconds; convert one with as",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestEnumTypes(t *testing.T) {
+	input := `type Status string distinct
+    Active "active"
+    Banned "banned"
+    Gone "banned"
+
+type Level int distinct
+    Low "low"
+
+type Account
+    status Status
+
+func Check(s Status) string
+    switch s
+        when Active, "banned"
+            return "known"
+        when "deleted"
+            return "?"
+    switch s
+        when Active
+            return "a"
+        when Banned if true
+            return "b"
+    return ""
+
+func Default() Status
+    return "pending"
+
+var fallback Status = "activ"
+
+func main()
+    s := Active
+    s = "gone"
+    a := Account{status: "banned"}
+    if s == "active" or a.status != "archived"
+        print(Check("nope"), fallback, "x" as Status)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"4:9: Status lists \"banned\" twice",
+		"7:4: only a distinct string type can list values; Level is int",
+		"16:13: \"deleted\" is not a Status value (one of \"active\", \"banned\")",
+		"18:4: switch on Status misses \"banned\"; add a when for each or an otherwise",
+		"26:11: \"pending\" is not a Status value",
+		"28:22: \"activ\" is not a Status value",
+		"32:8: \"gone\" is not a Status value",
+		"34:36: \"archived\" is not a Status value",
+		"35:20: \"nope\" is not a Status value",
+		"35:39: \"x\" is not a Status value",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestOperatorMethods(t *testing.T) {
+	input := `type Vector
+    x int
+    y int
+
+type Meters int distinct
+
+func Add on v Vector(o Vector) Vector operator +
+    return Vector{x: v.x + o.x, y: v.y + o.y}
+
+func Less on v Vector(o Vector) bool operator <
+    return v.x < o.x
+
+func Plus on v Vector(o Vector) Vector operator +
+    return v
+
+func Scale on v reference Vector(k int) Vector operator *
+    return Vector{}
+
+func Mod on v Vector(o Vector) Vector operator %
+    return v
+
+func Same on v Vector(o Vector) int operator ==
+    return 0
+
+func Twice on m Meters(o Meters) Meters operator +
+    return m
+
+func main()
+    a := Vector{x: 1, y: 2}
+    sum := a + a
+    bigger := sum > a
+    ok := a <= sum and bigger
+    print(sum.x, ok, a + 1)
+`
+	_, errs := analyzeSource(t, input)
+	want := []string{
+		"13:5: Vector already has operator + (Add)",
+		"16:5: operator * needs a method on a struct type with a value receiver",
+		"19:5: operator % can't be declared; use one of + - * / == <",
+		"22:5: operator == method Same needs one parameter and one result, a bool for == and <",
+		"25:5: operator + needs a method on a struct type with a value receiver",
+		"33:23: cannot apply + to Vector and int",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+}
+
+func TestDecimalLiterals(t *testing.T) {
+	input := `import "stdlib/decimal"
+
+func main()
+    price := 19.99d
+    parsed := decimal.Parse("4.50") onerr 0d
+    total := price * 3d + parsed.Round(2)
+    cheap := total < 100d
+    share := total.Div(3d, 2) - 0.01d
+    print(cheap, share, price * 1.1, 2 + price, price == 10, price / 2d)
+`
+	a, errs := analyzeSource(t, input)
+	want := []string{
+		"9:30: cannot use * on decimal.Decimal and float",
+		"9:39: cannot use + on decimal.Decimal and int",
+		"9:54: cannot use == on decimal.Decimal and int",
+		"9:67: cannot apply / to decimal.Decimal and decimal.Decimal",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+	var methods []string
+	for expr := range a.ExprTypes() {
+		if bin, ok := expr.(*ast.BinaryExpr); ok && bin.Method != "" {
+			methods = append(methods, bin.Method)
+		}
+	}
+	slices.Sort(methods)
+	if got := strings.Join(methods, " "); got != "Add Less Mul Sub" {
+		t.Errorf("expected the decimal operators to resolve to Add Less Mul Sub, got %q", got)
+	}
+}
+
+func TestReadExprYieldsStringAndError(t *testing.T) {
+	input := `func main()
+    line, err := read line
+    if err == empty
+        write(line)
+    all := read all onerr ""
+    write(len(all), "\n")
+`
+	a, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	counts := 0
+	for expr, n := range a.ReturnCounts() {
+		if _, ok := expr.(*ast.ReadExpr); ok {
+			if n != 2 {
+				t.Errorf("expected read to return 2 values, got %d", n)
+			}
+			counts++
+		}
+	}
+	if counts != 2 {
+		t.Errorf("expected return counts for both reads, got %d", counts)
+	}
+}
+
+func TestSentinelErrorDecl(t *testing.T) {
+	input := `func find(key string) (string, error)
+    if key == ""
+        return "", Invalid
+    return "", NotFound
+
+error NotFound "resource not found"
+
+error
+    Invalid "invalid key"
+`
+	a, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	sym := a.symbolTable.Resolve("NotFound")
+	if sym == nil || sym.Type.Kind != TypeKindNamed || sym.Type.Name != "error" || !sym.Exported {
+		t.Errorf("expected NotFound to be an exported error, got %+v", sym)
+	}
+}
+
+func TestSentinelErrorDeclErrors(t *testing.T) {
+	input := `error NotFound "resource not found"
+error NotFound "missing"
+error Empty ""
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "'NotFound' already declared") {
+		t.Errorf("expected duplicate declaration error, got %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "sentinel error 'Empty' needs a message") {
+		t.Errorf("expected empty message error, got %v", errs[1])
+	}
+}
+
+func TestUncheckedErrors(t *testing.T) {
+	input := `func pair() (int, error)
+    return 1, empty
+
+func fail() error
+    return empty
+
+func main()
+    fail()
+    pair()
+    _ := fail()
+    n, _ := pair()
+    _, err := pair()
+    fail() onerr discard
+    m := pair() onerr 0
+    print(n, err, m)
+`
+	a, errs := analyzeSource(t, input)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	var lines []int
+	for _, stmt := range a.UncheckedErrors() {
+		lines = append(lines, stmt.Pos().Line)
+	}
+	if fmt.Sprint(lines) != "[8 9 10 11]" {
+		t.Errorf("expected unchecked errors on lines 8-11, got %v", lines)
+	}
+}
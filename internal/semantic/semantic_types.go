@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
 )
 
 // validateTypeAnnotation checks that a type annotation is valid
@@ -17,6 +18,7 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 			"any":         true,
 			"any2":        true, // Placeholder for second generic type parameter
 			"ordered":     true, // Placeholder for cmp.Ordered type parameter
+			"number":      true, // Placeholder for an integer-or-float type parameter
 			"result":      true, // Placeholder for unconstrained second type parameter (transform output)
 			"error":       true,
 			"byte":        true,
@@ -30,7 +32,7 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 		if strings.Contains(t.Name, ".") {
 			parts := strings.Split(t.Name, ".")
 			if len(parts) != 2 {
-				a.error(t.Pos(), fmt.Sprintf("invalid qualified type '%s'", t.Name))
+				a.errorMsg(t.Pos(), catalog.InvalidQualifiedType, t.Name)
 				return
 			}
 
@@ -38,8 +40,8 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 
 			// Verify the package is imported
 			pkgSymbol := a.symbolTable.Resolve(pkgName)
-			if pkgSymbol == nil {
-				a.error(t.Pos(), fmt.Sprintf("package '%s' not imported (for type '%s')", pkgName, t.Name))
+			if pkgSymbol == nil && !a.autoImportPackage(pkgName, t.Token) {
+				a.errorMsg(t.Pos(), catalog.PackageNotImported, pkgName, t.Name)
 				return
 			}
 
@@ -51,7 +53,7 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 		// Check that the type exists in symbol table
 		symbol := a.symbolTable.Resolve(t.Name)
 		if symbol == nil || (symbol.Kind != SymbolType && symbol.Kind != SymbolInterface) {
-			a.error(t.Pos(), fmt.Sprintf("undefined type '%s'", t.Name))
+			a.errorMsg(t.Pos(), catalog.UndefinedType, t.Name)
 		}
 
 		// Warn if the type is deprecated
@@ -67,6 +69,8 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 		a.validateTypeAnnotation(t.ValueType)
 	case *ast.ChannelType:
 		a.validateTypeAnnotation(t.ElementType)
+	case *ast.SequenceType:
+		a.validateTypeAnnotation(t.ElementType)
 	case *ast.FunctionType:
 		// Validate parameter types
 		for _, param := range t.Parameters {
@@ -79,6 +83,33 @@ func (a *Analyzer) validateTypeAnnotation(typeAnn ast.TypeAnnotation) {
 	}
 }
 
+// resolveInterfaceType promotes a named type that refers to an interface
+// (error, a local interface, or a known Go/Kukicha stdlib interface) to
+// TypeKindInterface. Codegen reads the recorded kind to choose between a type
+// assertion and a conversion, and between nil and *new(T) for zero values.
+func (a *Analyzer) resolveInterfaceType(ti *TypeInfo) *TypeInfo {
+	if ti == nil || ti.Kind != TypeKindNamed {
+		return ti
+	}
+	if ti.Name == "error" || IsKnownInterface(ti.Name) {
+		return &TypeInfo{Kind: TypeKindInterface, Name: ti.Name}
+	}
+	if sym := a.symbolTable.Resolve(ti.Name); sym != nil && sym.Kind == SymbolInterface && sym.Type != nil {
+		return sym.Type
+	}
+	return ti
+}
+
+// resolveTypeAnnotation converts a declared type annotation to TypeInfo,
+// resolving interface names, and records the result for codegen.
+func (a *Analyzer) resolveTypeAnnotation(typeAnn ast.TypeAnnotation) *TypeInfo {
+	ti := a.resolveInterfaceType(a.typeAnnotationToTypeInfo(typeAnn))
+	if typeAnn != nil {
+		a.typeAnnTypes[typeAnn] = ti
+	}
+	return ti
+}
+
 // typeAnnotationToTypeInfo converts AST type annotation to TypeInfo
 func (a *Analyzer) typeAnnotationToTypeInfo(typeAnn ast.TypeAnnotation) *TypeInfo {
 	if typeAnn == nil {
@@ -89,6 +120,17 @@ func (a *Analyzer) typeAnnotationToTypeInfo(typeAnn ast.TypeAnnotation) *TypeInf
 	case *ast.PrimitiveType:
 		return primitiveTypeFromString(t.Name)
 	case *ast.NamedType:
+		if t.Name == "number" {
+			// A type parameter over every integer and float type: arithmetic
+			// and comparisons on it are left to the Go compiler
+			return &TypeInfo{Kind: TypeKindUnknown}
+		}
+		// A type declared as a number, string or bool (type Meters int) takes
+		// that kind, keeping its name for codegen and distinct checks
+		if sym := a.symbolTable.Resolve(t.Name); sym != nil && sym.Kind == SymbolType && isPrimitiveKind(sym.Type.Kind) {
+			resolved := *sym.Type
+			return &resolved
+		}
 		return &TypeInfo{Kind: TypeKindNamed, Name: t.Name}
 	case *ast.ReferenceType:
 		return &TypeInfo{
@@ -110,7 +152,10 @@ func (a *Analyzer) typeAnnotationToTypeInfo(typeAnn ast.TypeAnnotation) *TypeInf
 		return &TypeInfo{
 			Kind:        TypeKindChannel,
 			ElementType: a.typeAnnotationToTypeInfo(t.ElementType),
+			ChanDir:     t.Direction,
 		}
+	case *ast.SequenceType:
+		return a.iteratorType([]ast.TypeAnnotation{t.ElementType})
 	case *ast.FunctionType:
 		var params []*TypeInfo
 		for _, param := range t.Parameters {
@@ -143,7 +188,7 @@ func (a *Analyzer) isReferenceTypeWithVisited(t *TypeInfo, visited map[string]bo
 	case TypeKindReference, TypeKindList, TypeKindMap, TypeKindChannel, TypeKindFunction, TypeKindInterface:
 		return true
 	case TypeKindNamed:
-		if t.Name == "any" || t.Name == "any2" || t.Name == "ordered" || t.Name == "result" || t.Name == "error" || t.Name == "interface{}" {
+		if t.Name == "any" || t.Name == "any2" || t.Name == "ordered" || t.Name == "number" || t.Name == "result" || t.Name == "error" || t.Name == "interface{}" {
 			return true
 		}
 
@@ -210,6 +255,11 @@ func (a *Analyzer) typesCompatible(t1, t2 *TypeInfo) bool {
 		}
 	}
 
+	// Distinct types only match themselves
+	if (t1.Distinct || t2.Distinct) && t1.Name != "" && t2.Name != "" && t1.Name != t2.Name {
+		return false
+	}
+
 	// Special case: time.Duration is compatible with int64 (Duration is defined as int64 in Go)
 	if (t1.Kind == TypeKindNamed && t1.Name == "time.Duration" && t2.Kind == TypeKindInt) ||
 		(t2.Kind == TypeKindNamed && t2.Name == "time.Duration" && t1.Kind == TypeKindInt) {
@@ -226,18 +276,41 @@ func (a *Analyzer) typesCompatible(t1, t2 *TypeInfo) bool {
 			return a.isReferenceType(t1)
 		}
 
+		// A func literal is assignable to a named func type
+		if (t1.Kind == TypeKindFunction && a.funcSignature(t2) != nil) ||
+			(t2.Kind == TypeKindFunction && a.funcSignature(t1) != nil) {
+			return true
+		}
+
 		// Interface types are compatible with named types (defer structural
 		// check to Go compiler — we can't verify interface satisfaction here)
 		if t1.Kind == TypeKindInterface || t2.Kind == TypeKindInterface {
 			return true
 		}
 
+		// An imported named type may be an interface (io.Reader) that a
+		// reference satisfies; its kind isn't known here.
+		if (t1.Kind == TypeKindReference && t2.Kind == TypeKindNamed && strings.Contains(t2.Name, ".")) ||
+			(t2.Kind == TypeKindReference && t1.Kind == TypeKindNamed && strings.Contains(t1.Name, ".")) {
+			return true
+		}
+
 		return false
 	}
 
 	// Check nested types for compound types
 	switch t1.Kind {
-	case TypeKindList, TypeKindChannel, TypeKindReference:
+	case TypeKindChannel:
+		// A bidirectional channel narrows to either direction, but a
+		// directional channel never widens back or flips
+		if t2.ChanDir != "" && t1.ChanDir != t2.ChanDir {
+			return false
+		}
+		if t1.ElementType == nil || t2.ElementType == nil {
+			return true
+		}
+		return a.typesCompatible(t1.ElementType, t2.ElementType)
+	case TypeKindList, TypeKindReference:
 		// If either side has no element type info (e.g., from Go stdlib registry),
 		// treat as compatible — the Go compiler will catch any real mismatch.
 		if t1.ElementType == nil || t2.ElementType == nil {
@@ -264,6 +337,23 @@ func (a *Analyzer) typesCompatible(t1, t2 *TypeInfo) bool {
 	}
 }
 
+// funcSignature returns the signature of a function type, or of a named type
+// declared as one (type Pred func(User) bool); nil for anything else.
+func (a *Analyzer) funcSignature(t *TypeInfo) *TypeInfo {
+	if t == nil {
+		return nil
+	}
+	if t.Kind == TypeKindFunction {
+		return t
+	}
+	if t.Kind == TypeKindNamed && !strings.Contains(t.Name, ".") {
+		if sym := a.symbolTable.Resolve(t.Name); sym != nil && sym.Kind == SymbolType && sym.Type != nil && sym.Type.Kind == TypeKindFunction {
+			return sym.Type
+		}
+	}
+	return nil
+}
+
 // unqualifiedName strips the package prefix from a qualified type name.
 // "ctx.Handle" → "Handle", "Handle" → "Handle"
 func unqualifiedName(name string) string {
@@ -272,3 +362,15 @@ func unqualifiedName(name string) string {
 	}
 	return name
 }
+
+// iteratorType is the function type of `sequence of T` and of what a `yields`
+// function returns: the shape of
+// iter.Seq (one value) or iter.Seq2 (two), func(yield func(V) bool).
+func (a *Analyzer) iteratorType(yields []ast.TypeAnnotation) *TypeInfo {
+	params := make([]*TypeInfo, len(yields))
+	for i, y := range yields {
+		params[i] = a.typeAnnotationToTypeInfo(y)
+	}
+	yield := &TypeInfo{Kind: TypeKindFunction, Params: params, Returns: []*TypeInfo{{Kind: TypeKindBool}}}
+	return &TypeInfo{Kind: TypeKindFunction, Params: []*TypeInfo{yield}}
+}
@@ -0,0 +1,57 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+)
+
+// UncheckedErrors returns the statements that drop an error result without
+// onerr: a call whose last result is an error used as a statement, or an
+// error result assigned to _. Call after Analyze(); `kukicha lint` and
+// `kukicha check` report them (the unchecked-error rule).
+func (a *Analyzer) UncheckedErrors() []ast.Statement {
+	return a.uncheckedErrors
+}
+
+// isCallLike reports whether expr is a call or pipe, the expressions whose
+// results a statement can drop.
+func isCallLike(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.CallExpr, *ast.MethodCallExpr, *ast.PipeExpr:
+		return true
+	}
+	return false
+}
+
+// noteDroppedError records stmt when types, the results of its call, end in
+// an error that names leaves unchecked: the call is a statement (names is
+// nil) or the error's slot is _.
+func (a *Analyzer) noteDroppedError(stmt ast.Statement, types []*TypeInfo, names []string) {
+	if len(types) == 0 || !isErrorTypeInfo(types[len(types)-1]) {
+		return
+	}
+	if names != nil && (len(names) != len(types) || names[len(names)-1] != "_") {
+		return
+	}
+	a.uncheckedErrors = append(a.uncheckedErrors, stmt)
+}
+
+// targetNames returns the names targets assign, with "" for a target that
+// is not a plain identifier.
+func targetNames(targets []ast.Expression) []string {
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		if id, ok := target.(*ast.Identifier); ok {
+			names[i] = id.Value
+		}
+	}
+	return names
+}
+
+// identNames returns the values of ids.
+func identNames(ids []*ast.Identifier) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = id.Value
+	}
+	return names
+}
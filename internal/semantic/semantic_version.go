@@ -0,0 +1,254 @@
+package semantic
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/catalog"
+	"github.com/duber000/kukicha/internal/version"
+)
+
+// checkLanguageVersion enforces a `# kukicha: X.Y.Z` pragma: the file may not
+// ask for a newer language than this compiler, and may not use features that
+// shipped after the version it declares. Files without the pragma get every
+// feature.
+func (a *Analyzer) checkLanguageVersion() {
+	if a.program.Language == "" {
+		return
+	}
+	lang, err := version.ParseLanguage(a.program.Language)
+	if err != nil {
+		return // reported by the parser
+	}
+	if lang.Newer() {
+		a.errorMsg(a.program.LanguagePos, catalog.LanguageTooNew, lang.Text, version.Version)
+		return
+	}
+
+	require := func(pos ast.Position, f version.Feature) {
+		if !lang.Supports(f.Since) {
+			a.errorMsg(pos, catalog.FeatureTooNew, f.Name, f.Since, lang.Text)
+		}
+	}
+	checkTypes := func(types ...ast.TypeAnnotation) {
+		for _, t := range types {
+			typeFeatures(t, require)
+		}
+	}
+	checkParams := func(params []*ast.Parameter, returns []ast.TypeAnnotation) {
+		for _, param := range params {
+			checkTypes(param.Type)
+		}
+		checkTypes(returns...)
+	}
+	// write is a builtin only when the file does not declare its own
+	declaresWrite := false
+	for _, decl := range a.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.FunctionDecl:
+			declaresWrite = declaresWrite || d.Receiver == nil && d.Name.Value == "write"
+		case *ast.VarDeclStmt:
+			for _, n := range d.Names {
+				declaresWrite = declaresWrite || n.Value == "write"
+			}
+		}
+	}
+	checkGuards := func(sw *ast.SwitchStmt) {
+		for _, c := range sw.Cases {
+			if c.Guard != nil {
+				require(c.Guard.Pos(), version.FeatureWhenGuard)
+			}
+		}
+	}
+	checkExprs := func(e ast.Expression) bool {
+		switch e := e.(type) {
+		case *ast.MakeExpr:
+			checkTypes(e.Type)
+		case *ast.TypeCastExpr:
+			checkTypes(e.TargetType)
+		case *ast.FunctionLiteral:
+			checkParams(e.Parameters, e.Returns)
+		case *ast.ParallelPipeExpr:
+			require(e.Pos(), version.FeatureParallelPipe)
+		case *ast.ComparisonChainExpr:
+			require(e.Pos(), version.FeatureComparisonChain)
+		case *ast.RangeCheckExpr:
+			require(e.Pos(), version.FeatureRangeCheck)
+		case *ast.WhenPattern:
+			require(e.Pos(), version.FeatureWhenPattern)
+		case *ast.RegexLiteral:
+			require(e.Pos(), version.FeatureRegexLiteral)
+		case *ast.DecimalLiteral:
+			require(e.Pos(), version.FeatureDecimalLiteral)
+		case *ast.WaitExpr:
+			require(e.Pos(), version.FeatureWait)
+		case *ast.ReadExpr:
+			require(e.Pos(), version.FeatureStdio)
+		case *ast.CallExpr:
+			if id, ok := e.Function.(*ast.Identifier); ok && id.Value == "write" && !declaresWrite {
+				require(id.Pos(), version.FeatureStdio)
+			}
+		case *ast.DataLiteral:
+			require(e.Pos(), version.FeatureData)
+		case *ast.CommandExpr:
+			require(e.Pos(), version.FeatureCommand)
+		case *ast.BuildStringExpr:
+			require(e.Pos(), version.FeatureBuildString)
+		case *ast.PipedSwitchExpr:
+			if sw, ok := e.Switch.(*ast.SwitchStmt); ok {
+				checkGuards(sw)
+			}
+		case *ast.OnErrExpr:
+			require(e.Pos(), version.FeatureOnErrExpr)
+			if e.OnErr.Explain != "" {
+				require(ast.TokenPos(e.OnErr.Token), version.FeatureOnErrExplain)
+			}
+		}
+		return false
+	}
+	checkStmts := func(body *ast.BlockStmt) {
+		ast.WalkStmts(body, func(stmt ast.Statement) bool {
+			var clause *ast.OnErrClause
+			switch s := stmt.(type) {
+			case *ast.VarDeclStmt:
+				clause = s.OnErr
+				checkTypes(s.Type)
+			case *ast.AssignStmt:
+				clause = s.OnErr
+			case *ast.ExpressionStmt:
+				clause = s.OnErr
+			case *ast.RequireStmt:
+				require(s.Pos(), version.FeatureRequire)
+			case *ast.SafelyStmt:
+				clause = s.OnErr
+				require(s.Pos(), version.FeatureSafely)
+			case *ast.FailStmt:
+				require(s.Pos(), version.FeatureFail)
+			case *ast.FallthroughStmt:
+				require(s.Pos(), version.FeatureFallthrough)
+			case *ast.SwitchStmt:
+				checkGuards(s)
+			}
+			if clause != nil && clause.Explain != "" {
+				require(ast.TokenPos(clause.Token), version.FeatureOnErrExplain)
+			}
+			return false
+		})
+	}
+
+	// selectTarget has already spliced these blocks into their parents
+	for _, pos := range a.targetBlocks {
+		require(pos, version.FeatureWhenTarget)
+	}
+
+	for _, decl := range a.program.Declarations {
+		switch d := decl.(type) {
+		case *ast.FunctionDecl:
+			checkParams(d.Parameters, d.Returns)
+			// A generator's yields come from its `sequence of T` result,
+			// checked above
+			if d.Yields != nil && len(d.Returns) == 0 {
+				require(d.Pos(), version.FeatureYields)
+				checkTypes(d.Yields...)
+			}
+			if d.Operator != "" {
+				require(d.Name.Pos(), version.FeatureOperatorMethod)
+			}
+			if d.Body == nil {
+				continue
+			}
+			checkStmts(d.Body)
+			ast.WalkBlock(d.Body, func(e ast.Expression) bool {
+				switch lit := e.(type) {
+				case *ast.FunctionLiteral:
+					checkStmts(lit.Body)
+				case *ast.ArrowLambda:
+					if lit.Block != nil {
+						checkStmts(lit.Block)
+					}
+				case *ast.BlockExpr:
+					checkStmts(lit.Body)
+				case *ast.BuildStringExpr:
+					checkStmts(lit.Body)
+				case *ast.PipedSwitchExpr:
+					switch sw := lit.Switch.(type) {
+					case *ast.SwitchStmt:
+						for _, c := range sw.Cases {
+							checkStmts(c.Body)
+						}
+						if sw.Otherwise != nil {
+							checkStmts(sw.Otherwise.Body)
+						}
+					case *ast.TypeSwitchStmt:
+						for _, c := range sw.Cases {
+							checkStmts(c.Body)
+						}
+						if sw.Otherwise != nil {
+							checkStmts(sw.Otherwise.Body)
+						}
+					}
+				}
+				return checkExprs(e)
+			})
+		case *ast.ConstDecl:
+			for _, spec := range d.Specs {
+				ast.WalkExpr(spec.Value, checkExprs)
+			}
+		case *ast.VarDeclStmt:
+			checkTypes(d.Type)
+			ast.WalkStmt(d, checkExprs)
+		case *ast.TypeDecl:
+			if _, ok := d.AliasType.(*ast.FunctionType); d.AliasType != nil && !ok {
+				require(d.AliasType.Pos(), version.FeatureTypeDefinition)
+			}
+			if d.Distinct {
+				require(d.AliasType.Pos(), version.FeatureDistinct)
+			}
+			if len(d.Values) > 0 {
+				require(d.Values[0].Name.Pos(), version.FeatureEnum)
+			}
+			if derives := ast.TypeDerives(d); len(derives) > 0 {
+				require(ast.TokenPos(derives[0].Directive.Token), version.FeatureDerive)
+			}
+			checkTypes(d.AliasType)
+			for _, field := range d.Fields {
+				checkTypes(field.Type)
+			}
+		case *ast.InterfaceDecl:
+			for _, method := range d.Methods {
+				checkParams(method.Parameters, method.Returns)
+			}
+		case *ast.ErrorDecl:
+			require(d.Pos(), version.FeatureErrorDecl)
+		case *ast.ImplementsDecl:
+			require(d.Pos(), version.FeatureImplements)
+		}
+	}
+}
+
+// typeFeatures reports the gated features type t uses, including in the
+// types nested in it.
+func typeFeatures(t ast.TypeAnnotation, report func(ast.Position, version.Feature)) {
+	switch t := t.(type) {
+	case *ast.ChannelType:
+		if t.Direction != "" {
+			report(t.Pos(), version.FeatureChanDirection)
+		}
+		typeFeatures(t.ElementType, report)
+	case *ast.SequenceType:
+		report(t.Pos(), version.FeatureSequence)
+		typeFeatures(t.ElementType, report)
+	case *ast.ListType:
+		typeFeatures(t.ElementType, report)
+	case *ast.ReferenceType:
+		typeFeatures(t.ElementType, report)
+	case *ast.MapType:
+		typeFeatures(t.KeyType, report)
+		typeFeatures(t.ValueType, report)
+	case *ast.FunctionType:
+		for _, sub := range t.Parameters {
+			typeFeatures(sub, report)
+		}
+		for _, sub := range t.Returns {
+			typeFeatures(sub, report)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package semantic
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/version"
+)
+
+func TestLanguagePragmaGatesFeatures(t *testing.T) {
+	input := `# kukicha: 0.0.15
+import "os"
+
+func Sizes(files list of string) list of os.FileInfo
+    sizes := files |>> os.Stat() onerr panic "{error}"
+    return sizes
+
+func main()
+    data := os.ReadFile("x") onerr explain "check the path"
+    print(len(data), Sizes(empty))
+`
+	_, errs := analyzeSource(t, input)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "'|>>' requires kukicha >= 0.0.22") {
+		t.Errorf("unexpected first error: %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "'onerr explain' requires kukicha >= 0.0.16") {
+		t.Errorf("unexpected second error: %v", errs[1])
+	}
+
+	_, errs = analyzeSource(t, strings.Replace(input, "0.0.15", "0.x", 1))
+	if len(errs) != 0 {
+		t.Errorf("expected 0.x to allow every feature, got %v", errs)
+	}
+}
+
+func TestLanguagePragmaNewerThanCompiler(t *testing.T) {
+	_, errs := analyzeSource(t, "# kukicha: 999.0\n\nfunc main()\n    print(1)\n")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "upgrade the compiler") {
+		t.Errorf("expected upgrade error, got %v", errs)
+	}
+}
+
+// TestLanguagePragmaCoversEveryFeature checks that each gated feature is
+// rejected by a pragma for the release before it and allowed by 0.x. Every entry in version.Features
+// needs a case here.
+func TestLanguagePragmaCoversEveryFeature(t *testing.T) {
+	tests := []struct {
+		feature version.Feature
+		source  string
+	}{
+		{version.FeatureOnErrExplain, "import \"os\"\n\nfunc main()\n    b := os.ReadFile(\"x\") onerr explain \"check the path\"\n    print(b)\n"},
+		{version.FeatureParallelPipe, "import \"strings\"\n\nfunc main()\n    print([\"a\"] |>> strings.ToUpper())\n"},
+		{version.FeatureOnErrExpr, "import \"strconv\"\n\nfunc main()\n    print(strconv.Atoi(\"1\") onerr 0)\n"},
+		{version.FeatureComparisonChain, "func main()\n    x := 2\n    print(1 < x < 3)\n"},
+		{version.FeatureRangeCheck, "func main()\n    x := 2\n    print(x between 1 and 3)\n"},
+		{version.FeatureWhenPattern, "func main()\n    x := 2\n    switch x\n        when > 1\n            print(x)\n"},
+		{version.FeatureRegexLiteral, "func main()\n    print(re\"^a+\")\n"},
+		{version.FeatureRequire, "func f(x int) int\n    require x > 0 else return 0\n    return x\n"},
+		{version.FeatureChanDirection, "func f(out channel of int send-only)\n    send 1 to out\n"},
+		{version.FeatureYields, "func Numbers() yields int\n    yield 1\n"},
+		{version.FeatureSequence, "func Numbers() sequence of int\n    yield 1\n"},
+		{version.FeatureWait, "func f(ch channel of int) int\n    return wait for ch\n"},
+		{version.FeatureTypeDefinition, "type Meters int\n"},
+		{version.FeatureDistinct, "type Meters float64 distinct\n"},
+		{version.FeatureEnum, "type Color string distinct\n    Red \"red\"\n    Blue \"blue\"\n"},
+		{version.FeatureOperatorMethod, "type V\n    x int\n\nfunc Add on v V(o V) V operator +\n    return V{x: v.x + o.x}\n"},
+		{version.FeatureDecimalLiteral, "func main()\n    print(1.25d)\n"},
+		{version.FeatureDerive, "@derive stringer\ntype Point\n    x int\n"},
+		{version.FeatureImplements, "interface Shape\n    Area() float64\n\ntype Square\n    side float64\n\nfunc Area on s Square() float64\n    return s.side * s.side\n\nSquare implements Shape\n"},
+		{version.FeatureWhenGuard, "func main()\n    x := 2\n    switch x\n        when 2 if x > 1\n            print(x)\n"},
+		{version.FeatureFallthrough, "func main()\n    x := 2\n    switch x\n        when 2\n            continue to next\n        when 3\n            print(x)\n"},
+		{version.FeatureSafely, "func main()\n    safely\n        print(1)\n    onerr as e\n        print(\"{e}\")\n"},
+		{version.FeatureFail, "func main()\n    fail \"bad input\" code 2\n"},
+		{version.FeatureStdio, "func main()\n    write(\"prompt: \")\n"},
+		{version.FeatureData, "func main()\n    text := data\n        SELECT 1\n    print(text)\n"},
+		{version.FeatureCommand, "func head() (string, error)\n    h := $ \"git rev-parse HEAD\" onerr return\n    return h, empty\n"},
+		{version.FeatureErrorDecl, "error NotFound \"not found\"\n"},
+		{version.FeatureBuildString, "func main()\n    s := build string as b\n        b.WriteString(\"x\")\n    print(s)\n"},
+		{version.FeatureWhenTarget, "func main()\n    when target cli\n        print(1)\n"},
+		{version.FeatureWhenTarget, "when target cli\n    func Mode() string\n        return \"cli\"\n"},
+	}
+
+	covered := make(map[version.Feature]bool)
+	for _, tt := range tests {
+		covered[tt.feature] = true
+		t.Run(tt.feature.Name, func(t *testing.T) {
+			before := previousRelease(tt.feature.Since)
+			_, errs := analyzeSource(t, "# kukicha: "+before+"\n\n"+tt.source)
+			want := tt.feature.Name + " requires kukicha >= " + tt.feature.Since
+			found := false
+			for _, err := range errs {
+				found = found || strings.Contains(err.Error(), want)
+			}
+			if !found {
+				t.Errorf("# kukicha: %s: expected %q, got %v", before, want, errs)
+			}
+
+			// A file pinned to this compiler can use everything it implements
+			_, errs = analyzeSource(t, "# kukicha: "+version.Version+"\n\n"+tt.source)
+			if len(errs) != 0 {
+				t.Errorf("# kukicha: %s: expected no errors, got %v", version.Version, errs)
+			}
+		})
+	}
+	for _, f := range version.Features {
+		if !covered[f] {
+			t.Errorf("feature %s has no test case", f.Name)
+		}
+	}
+}
+
+// previousRelease returns the patch release before since ("0.0.22" -> "0.0.21").
+func previousRelease(since string) string {
+	i := strings.LastIndex(since, ".")
+	patch, _ := strconv.Atoi(since[i+1:])
+	return since[:i+1] + strconv.Itoa(patch-1)
+}
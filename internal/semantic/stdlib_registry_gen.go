@@ -11,6 +11,7 @@ package semantic
 // Generated from: stdlib/*/*.kuki (excludes *_test.kuki, methods, unexported funcs,
 // and void functions).
 var generatedStdlibRegistry = map[string]goStdlibEntry{
+	"a2a.AddSkill":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"server", "name", "description", "examples"}},
 	"a2a.Ask":                         {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"agent", "text"}},
 	"a2a.Cancel":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Task"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"agent", "taskID"}},
 	"a2a.Close":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"agent"}},
@@ -18,25 +19,64 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"a2a.Discover":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Agent"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url"}},
 	"a2a.DiscoverGuarded":             {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Agent"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url", "httpClient"}},
 	"a2a.GetTask":                     {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Task"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"agent", "taskID"}},
+	"a2a.Handle":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"server", "handler"}},
+	"a2a.Handler":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "http.Handler"}}, ParamNames: []string{"server"}},
 	"a2a.New":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"agent"}},
+	"a2a.NewServer":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"name", "description"}},
+	"a2a.OnArtifact":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "handler"}},
 	"a2a.OnStatus":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "handler"}},
 	"a2a.OnText":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "handler"}},
 	"a2a.Retry":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "maxAttempts", "delayMs"}},
 	"a2a.Send":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Task"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"req"}},
+	"a2a.Serve":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"server", "addr"}},
 	"a2a.Skills":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Skill"}}}, ParamNames: []string{"agent"}},
 	"a2a.Stream":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Task"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"req"}},
 	"a2a.Text":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "text"}},
+	"archive.Create":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"dest", "files"}},
+	"archive.Extract":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path", "dest"}},
+	"archive.ExtractStream":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"r", "dest"}},
+	"archive.List":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
+	"blob.Connect":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Store"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"bucket"}},
+	"blob.Credentials":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "accessKey", "secretKey"}},
+	"blob.Delete":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key"}},
+	"blob.Download":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key", "path"}},
+	"blob.Endpoint":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "endpoint"}},
+	"blob.Exists":                     {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key"}},
+	"blob.Get":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key"}},
+	"blob.List":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Object"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "prefix"}},
+	"blob.Local":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Store"}}, ParamNames: []string{"dir"}},
+	"blob.New":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"bucket"}},
+	"blob.Open":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Store"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"cfg"}},
+	"blob.Presign":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key", "expires"}},
+	"blob.PresignPut":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key", "expires"}},
+	"blob.Put":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "s", "key"}},
+	"blob.PutFrom":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"r", "s", "key"}},
+	"blob.Region":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "region"}},
+	"blob.SessionToken":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "token"}},
+	"blob.Stream":                     {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "io.ReadCloser"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "key"}},
+	"blob.Upload":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path", "s", "key"}},
+	"buildinfo.Get":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Info"}, {Kind: TypeKindBool}}, ParamNames: []string{}},
+	"buildinfo.Version":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{}},
+	"cache.Clear":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
+	"cache.Delete":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "key"}},
+	"cache.Get":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}, {Kind: TypeKindBool}}, ParamNames: []string{"c", "key", "sample"}},
+	"cache.Memoize":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindString}}, Returns: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}, {Kind: TypeKindNamed, Name: "error"}}}}, ParamNames: []string{"c", "sample", "fn"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindString}}}},
+	"cache.New":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"ttl"}},
+	"cache.Open":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"name", "ttl"}},
+	"cache.Remember":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "key", "sample", "fn"}},
+	"cache.Set":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "key", "value"}},
+	"cache.SetTTL":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "key", "value", "ttl"}},
 	"cast.Atoi":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindInt}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
 	"cast.ParseFloat":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindFloat}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "bitSize"}},
 	"cast.SmartBool":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
 	"cast.SmartFloat64":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindFloat}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
 	"cast.SmartInt":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindInt}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
 	"cast.SmartString":                {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
-	"cli.Action":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "cli.Args"}}}},
+	"cli.Action":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "cli.Args"}}}, VoidFuncParams: []int{1}},
 	"cli.AddFlag":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "name", "description", "defaultValue"}},
 	"cli.Arg":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "name", "description"}},
 	"cli.Command":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "name", "desc"}},
-	"cli.CommandAction":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "cmd", "handler"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "cli.Args"}}}},
+	"cli.CommandAction":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "cmd", "handler"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "cli.Args"}}}, VoidFuncParams: []int{2}},
 	"cli.CommandFlag":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "cmd", "name", "desc", "defaultValue"}},
 	"cli.CommandName":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"args"}},
 	"cli.Description":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"app", "desc"}},
@@ -48,8 +88,11 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"cli.New":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "App"}}, ParamNames: []string{"name"}},
 	"cli.NewArgs":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Args"}}, ParamNames: []string{"values"}},
 	"cli.RunApp":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"app"}},
+	"concurrent.Go":                   {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"fn"}, VoidFuncParams: []int{0}},
 	"concurrent.Map":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "result"}}}, ParamNames: []string{"items", "fn"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "any"}}}},
 	"concurrent.MapWithLimit":         {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "result"}}}, ParamNames: []string{"items", "limit", "fn"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"concurrent.Parallel":             {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"tasks"}, VoidFuncParams: []int{0}},
+	"concurrent.ParallelWithLimit":    {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"limit", "tasks"}, VoidFuncParams: []int{1}},
 	"container.APIVersion":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "version"}},
 	"container.AuthEncode":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"auth"}},
 	"container.Build":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "BuildOutput"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"engine", "path", "tag"}},
@@ -93,6 +136,12 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"container.Stop":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"engine", "containerID"}},
 	"container.Wait":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindInt}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"engine", "containerID", "timeoutSeconds"}},
 	"container.WaitCtx":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindInt}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"engine", "h", "containerID"}},
+	"cron.Jobs":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Job"}}}, ParamNames: []string{}},
+	"cron.Next":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{"s", "t"}},
+	"cron.Parse":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Schedule"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"spec"}},
+	"cron.Register":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"name", "spec", "run"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "context.Context"}}}},
+	"cron.Run":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"ctx"}},
+	"cron.RunJobs":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"ctx", "jobs"}},
 	"crypto.Equal":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"a", "b"}},
 	"crypto.HMAC":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"key", "data"}},
 	"crypto.HMACBytes":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}}, ParamNames: []string{"key", "data"}},
@@ -108,6 +157,12 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"ctx.WithDeadlineUnix":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Handle"}}, ParamNames: []string{"parent", "unixSeconds"}},
 	"ctx.WithTimeout":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Handle"}}, ParamNames: []string{"parent", "seconds"}},
 	"ctx.WithTimeoutMs":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Handle"}}, ParamNames: []string{"parent", "timeoutMs"}},
+	"date.Format":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"t", "pattern"}},
+	"date.Layout":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"pattern"}},
+	"date.New":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{"year", "month", "day"}},
+	"date.Parse":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
+	"date.ParseAs":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value", "pattern"}},
+	"date.Today":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{}},
 	"datetime.AddDays":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{"t", "days"}},
 	"datetime.AddMonths":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{"t", "months"}},
 	"datetime.AddWeeks":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{"t", "weeks"}},
@@ -155,6 +210,10 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"datetime.Weeks":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Duration"}}, ParamNames: []string{"n"}},
 	"datetime.Year":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"t"}},
 	"datetime.Yesterday":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "time.Time"}}, ParamNames: []string{}},
+	"decimal.FromFloat":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"f", "places"}},
+	"decimal.FromInt":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"n"}},
+	"decimal.MustParse":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"s"}},
+	"decimal.Parse":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
 	"encoding.Base64Decode":           {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
 	"encoding.Base64Encode":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"data"}},
 	"encoding.Base64RawEncode":        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"data"}},
@@ -178,6 +237,8 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"env.GetOr":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"key", "defaultValue"}},
 	"env.IsSet":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"key"}},
 	"env.IsSetAndNotEmpty":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"key"}},
+	"env.Load":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"sample"}},
+	"env.LoadFile":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
 	"env.ParseBool":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"value"}},
 	"env.Set":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"key", "value"}},
 	"env.SplitAndTrim":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"value", "separator"}},
@@ -190,6 +251,11 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"errors.Public":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"err"}},
 	"errors.Unwrap":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"err"}},
 	"errors.Wrap":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"err", "msg"}},
+	"expect.Check":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"sample", "property"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"expect.Diff":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"want", "got"}},
+	"expect.ForAll":                   {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"t", "sample", "property"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"expect.Match":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path", "got"}},
+	"expect.Show":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"value"}},
 	"fetch.BasicAuth":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "username", "password"}},
 	"fetch.BearerAuth":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "token"}},
 	"fetch.Body":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "data"}},
@@ -198,6 +264,7 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"fetch.Decode":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"resp", "target"}},
 	"fetch.Do":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"req"}},
 	"fetch.DownloadTo":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"resp", "box", "path"}},
+	"fetch.Events":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindNamed, Name: "Event"}}, Returns: []goStdlibType{{Kind: TypeKindBool}}}}}}, ParamNames: []string{"r"}},
 	"fetch.FormData":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "data"}},
 	"fetch.Get":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url"}},
 	"fetch.Header":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "name", "value"}},
@@ -210,6 +277,7 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"fetch.Post":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "url"}},
 	"fetch.QueryEscape":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"value"}},
 	"fetch.Retry":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "maxAttempts", "delayMs"}},
+	"fetch.SSE":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindNamed, Name: "Event"}}, Returns: []goStdlibType{{Kind: TypeKindBool}}}}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url"}},
 	"fetch.SafeGet":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url"}},
 	"fetch.SessionDo":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "req"}},
 	"fetch.SessionGet":                {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "url"}},
@@ -217,11 +285,13 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"fetch.SessionPost":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindReference}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "data", "url"}},
 	"fetch.SessionTimeout":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Session"}}, ParamNames: []string{"s", "durationNs"}},
 	"fetch.SessionTransport":          {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Session"}}, ParamNames: []string{"s", "t"}},
+	"fetch.SocketErr":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"ws"}},
 	"fetch.Text":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"resp"}},
 	"fetch.Timeout":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "durationNs"}},
 	"fetch.Transport":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Request"}}, ParamNames: []string{"req", "t"}},
 	"fetch.URLTemplate":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"tmpl", "params"}},
 	"fetch.URLWithQuery":              {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"baseURL", "params"}},
+	"fetch.WebSocket":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Socket"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url"}},
 	"files.Abs":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
 	"files.Append":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "path"}},
 	"files.AppendString":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "path"}},
@@ -246,6 +316,8 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"files.Size":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindInt}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
 	"files.TempDir":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"prefix"}},
 	"files.TempFile":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"prefix"}},
+	"files.UseWith":                   {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"path", "action"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}, VoidFuncParams: []int{1}},
+	"files.Watch":                     {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"pattern", "callback"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}, VoidFuncParams: []int{1}},
 	"files.Write":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "path"}},
 	"files.WriteString":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data", "path"}},
 	"git.Clone":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"url", "path"}},
@@ -259,6 +331,16 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"git.ReleaseExists":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"repo", "tag"}},
 	"git.RepoExists":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"repo"}},
 	"git.TagExists":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"repo", "tag"}},
+	"hash.Base64":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"data"}},
+	"hash.Base64URL":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"data"}},
+	"hash.Equal":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"a", "b"}},
+	"hash.FromBase64":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"text"}},
+	"hash.FromBase64URL":              {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"text"}},
+	"hash.FromHex":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"text"}},
+	"hash.HMAC":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}}, ParamNames: []string{"data", "key"}},
+	"hash.Hex":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"data"}},
+	"hash.MD5":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}}, ParamNames: []string{"data"}},
+	"hash.SHA256":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}}, ParamNames: []string{"data"}},
 	"http.GetHeader":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"r", "key"}},
 	"http.GetHeaderOr":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"r", "key", "defaultValue"}},
 	"http.GetQueryBool":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"r", "key"}},
@@ -386,6 +468,13 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"kube.WaitPodReadyCtx":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "h", "name"}},
 	"kube.WatchPods":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "PodEvent"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "timeoutSeconds"}},
 	"kube.WatchPodsCtx":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "PodEvent"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c", "h"}},
+	"limit.Allow":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"r"}},
+	"limit.Guard":                     {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "result"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"item", "s", "fn"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"limit.Limited":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}}, ParamNames: []string{"item", "r"}},
+	"limit.NewRate":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"n", "per"}, DefaultValues: []string{"", "1000000000"}},
+	"limit.Run":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "result"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"item", "r", "fn"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"limit.Semaphore":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Slots"}}, ParamNames: []string{"n"}},
+	"limit.TryAcquire":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"s"}},
 	"llm.APIKey":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "key"}},
 	"llm.AddMessage":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "role", "content"}},
 	"llm.AddTool":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "name", "description", "parameters"}},
@@ -429,8 +518,8 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"llm.MSend":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
 	"llm.MSendRaw":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "AnthropicResponse"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
 	"llm.MStopSequences":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "sequences"}},
-	"llm.MStream":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}},
-	"llm.MStreamEvents":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "llm.AnthropicStreamEvent"}}}},
+	"llm.MStream":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}, VoidFuncParams: []int{1}},
+	"llm.MStreamEvents":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "llm.AnthropicStreamEvent"}}}, VoidFuncParams: []int{1}},
 	"llm.MSystem":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "system"}},
 	"llm.MTemperature":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "temp"}},
 	"llm.MThinking":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "MessagesClient"}}, ParamNames: []string{"c", "budgetTokens"}},
@@ -470,8 +559,8 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"llm.RSend":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
 	"llm.RSendRaw":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Response"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
 	"llm.RStore":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c"}},
-	"llm.RStream":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}},
-	"llm.RStreamEvents":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "llm.StreamEvent"}}}},
+	"llm.RStream":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}, VoidFuncParams: []int{1}},
+	"llm.RStreamEvents":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "llm.StreamEvent"}}}, VoidFuncParams: []int{1}},
 	"llm.RSystemMessage":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "content"}},
 	"llm.RTemperature":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c", "temp"}},
 	"llm.RToolChoiceAuto":             {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ResponseClient"}}, ParamNames: []string{"c"}},
@@ -488,7 +577,7 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"llm.SendRaw":                     {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Completion"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"c"}},
 	"llm.SetUser":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "user"}},
 	"llm.Stop":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "sequences"}},
-	"llm.Stream":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}},
+	"llm.Stream":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "handler"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindString}}}, VoidFuncParams: []int{1}},
 	"llm.System":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "content"}},
 	"llm.Temperature":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "temp"}},
 	"llm.ToolChoiceAuto":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c"}},
@@ -496,14 +585,53 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"llm.ToolChoiceRequired":          {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c"}},
 	"llm.TopP":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "p"}},
 	"llm.User":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Client"}}, ParamNames: []string{"c", "content"}},
+	"manifest.APIVersion":             {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"m"}},
+	"manifest.Annotations":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"m"}},
+	"manifest.ByKind":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Manifest"}}}, ParamNames: []string{"docs", "kind"}},
+	"manifest.Copy":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"m"}},
+	"manifest.Decode":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "path", "target"}},
+	"manifest.Delete":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "path"}},
+	"manifest.Emit":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"docs"}},
+	"manifest.Find":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"docs", "kind", "name"}},
+	"manifest.Get":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "path"}},
+	"manifest.Has":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"m", "path"}},
+	"manifest.Images":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"m"}},
+	"manifest.Kind":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"m"}},
+	"manifest.Labels":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"m"}},
+	"manifest.Load":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Manifest"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
+	"manifest.Merge":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"base", "patch"}},
+	"manifest.Name":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"m"}},
+	"manifest.Namespace":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"m"}},
+	"manifest.New":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"apiVersion", "kind", "name"}},
+	"manifest.Overlay":                {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Manifest"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"docs", "patches"}},
+	"manifest.Parse":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Manifest"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"text"}},
+	"manifest.Patch":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "patch"}},
+	"manifest.Read":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Manifest"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"r"}},
+	"manifest.Replicas":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"m"}},
+	"manifest.Save":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"docs", "path"}},
+	"manifest.Set":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "path", "value"}},
+	"manifest.SetAnnotation":          {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"m", "key", "value"}},
+	"manifest.SetImage":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"m", "container", "image"}},
+	"manifest.SetLabel":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"m", "key", "value"}},
+	"manifest.SetNamespace":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"m", "namespace"}},
+	"manifest.SetReplicas":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Manifest"}}, ParamNames: []string{"m", "replicas"}},
 	"maps.Contains":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"m", "key"}},
 	"maps.Has":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"m", "key"}},
 	"maps.Keys":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}}, ParamNames: []string{"m"}},
 	"maps.Merge":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}, ValueType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}}, ParamNames: []string{"base", "overlay"}},
 	"maps.SortedKeys":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"m"}},
 	"maps.Values":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}}, ParamNames: []string{"m"}},
+	"math.Abs":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "number"}}, ParamNames: []string{"x"}},
+	"math.Clamp":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ordered"}}, ParamNames: []string{"x", "low", "high"}},
+	"math.Max":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ordered"}}, ParamNames: []string{"items"}},
+	"math.Mean":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindFloat}}, ParamNames: []string{"items"}},
+	"math.Median":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindFloat}}, ParamNames: []string{"items"}},
+	"math.Min":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "ordered"}}, ParamNames: []string{"items"}},
+	"math.Round":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindFloat}}, ParamNames: []string{"x", "places"}},
+	"math.Sum":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "number"}}, ParamNames: []string{"items"}},
 	"mcp.ErrorResult":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}}, ParamNames: []string{"msg"}},
 	"mcp.New":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"name", "version"}},
+	"mcp.NewApp":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"name", "version"}},
 	"mcp.Prop":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "SchemaProperty"}}, ParamNames: []string{"name", "typ", "description"}},
 	"mcp.Required":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}}, ParamNames: []string{"schema", "names"}},
 	"mcp.Schema":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}}, ParamNames: []string{"props"}},
@@ -537,11 +665,34 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"netguard.NewAllow":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Guard"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"cidrs"}},
 	"netguard.NewBlock":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Guard"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"cidrs"}},
 	"netguard.NewSSRFGuard":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Guard"}}, ParamNames: []string{}},
+	"notify.Auth":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Mailer"}}, ParamNames: []string{"m", "username", "password"}},
+	"notify.Body":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"msg"}},
+	"notify.Data":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Message"}}, ParamNames: []string{"msg", "data"}},
+	"notify.Discord":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"msg", "webhookURL"}},
+	"notify.From":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Mailer"}}, ParamNames: []string{"m", "address"}},
+	"notify.New":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Message"}}, ParamNames: []string{"subject", "body"}},
+	"notify.Port":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Mailer"}}, ParamNames: []string{"m", "port"}},
+	"notify.Render":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Message"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"msg"}},
+	"notify.Retry":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Message"}}, ParamNames: []string{"msg", "maxAttempts", "delayMs"}},
+	"notify.SMTP":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Mailer"}}, ParamNames: []string{"host"}},
+	"notify.SMTPFromEnv":              {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Mailer"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{}},
+	"notify.SendEmail":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"msg", "m", "recipients"}},
+	"notify.Slack":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"msg", "webhookURL"}},
+	"notify.Subject":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"msg"}},
+	"notify.Teams":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"msg", "webhookURL"}},
 	"obs.Component":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Logger"}}, ParamNames: []string{"logger", "component"}},
 	"obs.New":                         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Logger"}}, ParamNames: []string{"service", "environment"}},
 	"obs.NewCorrelationID":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{}},
 	"obs.Start":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Timer"}}, ParamNames: []string{"logger", "operation"}},
 	"obs.WithCorrelation":             {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Logger"}}, ParamNames: []string{"logger", "correlationID"}},
+	"osx.Expand":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"osx.ExpandPath":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"path"}},
+	"osx.ExpandStrict":                {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
+	"osx.Hostname":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{}},
+	"osx.OnInterrupt":                 {Count: 0, Types: []goStdlibType{}, ParamNames: []string{"handler"}, VoidFuncParams: []int{0}},
+	"osx.UserHomeDir":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{}},
+	"osx.WaitForInterrupt":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "os.Signal"}}, ParamNames: []string{}},
+	"osx.Which":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"binary"}},
 	"parse.Csv":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindList}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data"}},
 	"parse.CsvWithHeader":             {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindMap}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data"}},
 	"parse.Json":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data"}},
@@ -576,8 +727,17 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"pg.TxExec":                       {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Result"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"t", "sql", "args"}},
 	"pg.TxQuery":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Rows"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"t", "sql", "args"}},
 	"pg.TxQueryRow":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Row"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"t", "sql", "args"}},
+	"pool.ForEach":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"items", "worker", "concurrency"}, DefaultValues: []string{"", "", "8"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"pool.ForEachContext":             {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"ctx", "items", "worker", "concurrency"}, DefaultValues: []string{"", "", "", "8"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "context.Context"}, {Kind: TypeKindNamed, Name: "any"}}}},
+	"pool.Map":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "result"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"items", "worker", "concurrency"}, DefaultValues: []string{"", "", "8"}, ParamFuncParams: map[int][]goStdlibType{1: {{Kind: TypeKindNamed, Name: "any"}}}},
+	"pool.MapContext":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "result"}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"ctx", "items", "worker", "concurrency"}, DefaultValues: []string{"", "", "", "8"}, ParamFuncParams: map[int][]goStdlibType{2: {{Kind: TypeKindNamed, Name: "context.Context"}, {Kind: TypeKindNamed, Name: "any"}}}},
 	"random.Alphanumeric":             {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"length"}},
+	"random.Choice":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "any"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"items"}},
+	"random.Float":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindFloat}}, ParamNames: []string{}},
+	"random.Int":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"min", "max"}},
+	"random.Shuffle":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}}, ParamNames: []string{"items"}},
 	"random.String":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"length"}},
+	"random.UUID":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{}},
 	"regex.Compile":                   {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Pattern"}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"pattern"}},
 	"regex.Find":                      {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"pattern", "text"}},
 	"regex.FindAll":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"pattern", "text"}},
@@ -718,6 +878,14 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"table.New":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Table"}}, ParamNames: []string{"headers"}},
 	"table.ToString":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"t"}},
 	"table.ToStringWithStyle":         {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"t", "style"}},
+	"telemetry.Auto":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindFunction}}, ParamNames: []string{}},
+	"telemetry.FromEnv":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{}},
+	"telemetry.New":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"service"}},
+	"telemetry.Output":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "output"}},
+	"telemetry.Setup":                 {Count: 2, Types: []goStdlibType{{Kind: TypeKindFunction, Returns: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"cfg"}},
+	"telemetry.Start":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"name"}},
+	"telemetry.StartContext":          {Count: 2, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "context.Context"}, {Kind: TypeKindReference}}, ParamNames: []string{"ctx", "name"}},
+	"telemetry.Writer":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Config"}}, ParamNames: []string{"cfg", "w"}},
 	"template.Data":                   {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "TemplateData"}}, ParamNames: []string{"td", "data"}},
 	"template.Execute":                {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"td"}},
 	"template.Funcs":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "TemplateData"}}, ParamNames: []string{"td", "funcMap"}},
@@ -729,6 +897,35 @@ var generatedStdlibRegistry = map[string]goStdlibEntry{
 	"template.Render":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "TemplateData"}}, ParamNames: []string{"tmplStr"}},
 	"template.RenderSimple":           {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"tmplStr", "data"}},
 	"template.WithContent":            {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "TemplateData"}}, ParamNames: []string{"td", "content"}},
+	"term.Ask":                        {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"question", "fallback"}, DefaultValues: []string{"", "\"\""}},
+	"term.Blue":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.Bold":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.ColorEnabled":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{}},
+	"term.Confirm":                    {Count: 2, Types: []goStdlibType{{Kind: TypeKindBool}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"question", "yes"}, DefaultValues: []string{"", "false"}},
+	"term.Cyan":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.Dim":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.Green":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.InMCP":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{}},
+	"term.IsTerminal":                 {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"f"}},
+	"term.NewBar":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"total", "label"}},
+	"term.Red":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"term.Render":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"b"}},
+	"term.Spin":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindReference}}, ParamNames: []string{"label"}},
+	"term.Yellow":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.CompareFold":                {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"a", "b"}},
+	"text.ContainsFold":               {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"s", "substr"}},
+	"text.EqualFold":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"a", "b"}},
+	"text.Fold":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.Graphemes":                  {Count: 1, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}}, ParamNames: []string{"s"}},
+	"text.HasPrefixFold":              {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"s", "prefix"}},
+	"text.IsNFC":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"s"}},
+	"text.Length":                     {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"s"}},
+	"text.NFC":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.NFD":                        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.NFKC":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.NFKD":                       {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.Reverse":                    {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s"}},
+	"text.Slice":                      {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{"s", "start", "end"}},
 	"validate.Alpha":                  {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
 	"validate.Alphanumeric":           {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s"}},
 	"validate.Contains":               {Count: 2, Types: []goStdlibType{{Kind: TypeKindString}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"s", "substr"}},
@@ -775,16 +972,26 @@ var generatedStdlibDeprecated = map[string]string{}
 // generatedStdlibPanics maps qualified Kukicha stdlib function names to their
 // panic messages. Populated from # kuki:panics directives in stdlib .kuki files.
 var generatedStdlibPanics = map[string]string{
-	"input.Prompt": "if reading from stdin fails",
+	"decimal.FromFloat": "if f is NaN or infinite",
+	"decimal.MustParse": "if s is not a valid decimal",
+	"input.Prompt":      "if reading from stdin fails",
+	"limit.NewRate":     "if n is not positive",
+	"limit.Semaphore":   "if n is not positive",
 }
 
 // generatedSecurityFunctions maps qualified stdlib function names to their
 // security check category. Populated from # kuki:security directives in .kuki files.
 // Categories: "sql", "html", "fetch", "files", "redirect", "shell"
 var generatedSecurityFunctions = map[string]string{
+	"archive.Create":         "files",
+	"archive.Extract":        "files",
+	"archive.ExtractStream":  "files",
+	"archive.List":           "files",
 	"fetch.Get":              "fetch",
 	"fetch.New":              "fetch",
 	"fetch.Post":             "fetch",
+	"fetch.SSE":              "fetch",
+	"fetch.WebSocket":        "fetch",
 	"files.Append":           "files",
 	"files.AppendString":     "files",
 	"files.Copy":             "files",
@@ -823,11 +1030,29 @@ var generatedSecurityFunctions = map[string]string{
 //   - "K"  = uses "any2" placeholder only → emits [K comparable]
 //   - "TK" = uses both                    → emits [T any, K comparable]
 //   - "TR" = uses "any" and "result"      → emits [T any, R any]
+//   - "O"  = uses "ordered" only          → emits [K cmp.Ordered]
+//   - "N"  = uses "number" (stdlib/math)  → emits [N ~int | ... | ~float64]
 //
 // Functions not in this map do not use placeholders and are not made generic.
 var generatedSliceGenericClass = map[string]string{
 	"concurrent.Map":          "TR",
 	"concurrent.MapWithLimit": "TR",
+	"limit.Guard":             "TR",
+	"limit.Limited":           "T",
+	"limit.Run":               "TR",
+	"math.Abs":                "N",
+	"math.Clamp":              "O",
+	"math.Max":                "O",
+	"math.Mean":               "N",
+	"math.Median":             "N",
+	"math.Min":                "O",
+	"math.Sum":                "N",
+	"pool.ForEach":            "T",
+	"pool.ForEachContext":     "T",
+	"pool.Map":                "TR",
+	"pool.MapContext":         "TR",
+	"random.Choice":           "T",
+	"random.Shuffle":          "T",
 	"slice.Chunk":             "T",
 	"slice.Concat":            "T",
 	"slice.Contains":          "K",
@@ -866,3 +1091,95 @@ var generatedSliceGenericClass = map[string]string{
 // generatedStdlibInterfaces lists qualified Kukicha stdlib type names that are interfaces.
 // Used by codegen to decide between type assertion (x.(T)) and type conversion (T(x)).
 var generatedStdlibInterfaces = map[string]bool{}
+
+// generatedStdlibStructFields maps qualified Kukicha stdlib struct names to the
+// types of their exported fields. The analyzer attaches them to struct values
+// returned by stdlib functions, so ws.Receive in "receive from ws.Receive" is typed.
+var generatedStdlibStructFields = map[string]map[string]goStdlibType{
+	"a2a.Agent":                {"Card": {Kind: TypeKindReference}, "Client": {Kind: TypeKindReference}},
+	"a2a.Artifact":             {"ID": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Text": {Kind: TypeKindString}},
+	"a2a.Server":               {"Card": {Kind: TypeKindReference}},
+	"a2a.Skill":                {"Description": {Kind: TypeKindString}, "Examples": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}, "Name": {Kind: TypeKindString}},
+	"a2a.StatusUpdate":         {"Final": {Kind: TypeKindBool}, "Message": {Kind: TypeKindString}, "State": {Kind: TypeKindString}, "TaskID": {Kind: TypeKindString}},
+	"a2a.Task":                 {"Artifacts": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Artifact"}}, "ContextID": {Kind: TypeKindString}, "ID": {Kind: TypeKindString}, "State": {Kind: TypeKindString}, "Text": {Kind: TypeKindString}},
+	"a2a.TaskContext":          {"ContextID": {Kind: TypeKindString}, "ID": {Kind: TypeKindString}, "Text": {Kind: TypeKindString}},
+	"archive.EntryError":       {"Archive": {Kind: TypeKindString}, "Entry": {Kind: TypeKindString}, "Err": {Kind: TypeKindNamed, Name: "error"}, "Op": {Kind: TypeKindString}},
+	"blob.Object":              {"ETag": {Kind: TypeKindString}, "Key": {Kind: TypeKindString}, "Modified": {Kind: TypeKindNamed, Name: "time.Time"}, "Size": {Kind: TypeKindInt}},
+	"buildinfo.Info":           {"Built": {Kind: TypeKindString}, "Source": {Kind: TypeKindString}, "Version": {Kind: TypeKindString}},
+	"cron.Job":                 {"Name": {Kind: TypeKindString}, "Run": {Kind: TypeKindFunction, Params: []goStdlibType{{Kind: TypeKindNamed, Name: "context.Context"}}, Returns: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}}, "Spec": {Kind: TypeKindString}},
+	"fetch.Event":              {"Data": {Kind: TypeKindString}, "Err": {Kind: TypeKindNamed, Name: "error"}, "ID": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}},
+	"fetch.Socket":             {"Receive": {Kind: TypeKindChannel, ElementType: &goStdlibType{Kind: TypeKindString}}, "Send": {Kind: TypeKindChannel, ElementType: &goStdlibType{Kind: TypeKindString}}},
+	"git.ReleaseOptions":       {"Draft": {Kind: TypeKindBool}, "GenerateNotes": {Kind: TypeKindBool}, "Target": {Kind: TypeKindString}, "Title": {Kind: TypeKindString}},
+	"llm.AnthropicDelta":       {"PartialJSON": {Kind: TypeKindString}, "StopReason": {Kind: TypeKindString}, "StopSequence": {Kind: TypeKindString}, "Text": {Kind: TypeKindString}, "Thinking": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.AnthropicMessage":     {"Content": {Kind: TypeKindNamed, Name: "any"}, "Role": {Kind: TypeKindString}},
+	"llm.AnthropicResponse":    {"Content": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "ContentBlock"}}, "ID": {Kind: TypeKindString}, "Model": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}, "StopReason": {Kind: TypeKindString}, "StopSequence": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}, "Usage": {Kind: TypeKindNamed, Name: "AnthropicUsage"}},
+	"llm.AnthropicStreamEvent": {"ContentBlock": {Kind: TypeKindNamed, Name: "ContentBlock"}, "Delta": {Kind: TypeKindNamed, Name: "AnthropicDelta"}, "Index": {Kind: TypeKindInt}, "Message": {Kind: TypeKindNamed, Name: "AnthropicResponse"}, "Type": {Kind: TypeKindString}, "Usage": {Kind: TypeKindNamed, Name: "AnthropicUsage"}},
+	"llm.AnthropicTool":        {"Description": {Kind: TypeKindString}, "InputSchema": {Kind: TypeKindNamed, Name: "any"}, "Name": {Kind: TypeKindString}},
+	"llm.AnthropicToolChoice":  {"Name": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.AnthropicUsage":       {"CacheCreationInputTokens": {Kind: TypeKindInt}, "CacheReadInputTokens": {Kind: TypeKindInt}, "InputTokens": {Kind: TypeKindInt}, "OutputTokens": {Kind: TypeKindInt}},
+	"llm.Choice":               {"FinishReason": {Kind: TypeKindString}, "Index": {Kind: TypeKindInt}, "Message": {Kind: TypeKindNamed, Name: "ResponseMessage"}},
+	"llm.Chunk":                {"Choices": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "ChunkChoice"}}, "Created": {Kind: TypeKindInt}, "ID": {Kind: TypeKindString}, "Model": {Kind: TypeKindString}, "Object": {Kind: TypeKindString}},
+	"llm.ChunkChoice":          {"Delta": {Kind: TypeKindNamed, Name: "ChunkDelta"}, "FinishReason": {Kind: TypeKindString}, "Index": {Kind: TypeKindInt}},
+	"llm.ChunkDelta":           {"Content": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}},
+	"llm.Completion":           {"Choices": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Choice"}}, "Created": {Kind: TypeKindInt}, "ID": {Kind: TypeKindString}, "Model": {Kind: TypeKindString}, "Object": {Kind: TypeKindString}, "Usage": {Kind: TypeKindNamed, Name: "Usage"}},
+	"llm.CompletionRequest":    {"FrequencyPenalty": {Kind: TypeKindFloat}, "MaxTokens": {Kind: TypeKindInt}, "Messages": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Message"}}, "Model": {Kind: TypeKindString}, "N": {Kind: TypeKindInt}, "PresencePenalty": {Kind: TypeKindFloat}, "ResponseFormat": {Kind: TypeKindNamed, Name: "any"}, "Seed": {Kind: TypeKindInt}, "Stop": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}, "Stream": {Kind: TypeKindBool}, "Temperature": {Kind: TypeKindFloat}, "ToolChoice": {Kind: TypeKindNamed, Name: "any"}, "Tools": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Tool"}}, "TopP": {Kind: TypeKindFloat}, "User": {Kind: TypeKindString}},
+	"llm.ContentBlock":         {"Content": {Kind: TypeKindNamed, Name: "any"}, "ID": {Kind: TypeKindString}, "Input": {Kind: TypeKindNamed, Name: "any"}, "Name": {Kind: TypeKindString}, "Source": {Kind: TypeKindNamed, Name: "any"}, "Text": {Kind: TypeKindString}, "Thinking": {Kind: TypeKindString}, "ToolUseID": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.InputItem":            {"Arguments": {Kind: TypeKindString}, "CallID": {Kind: TypeKindString}, "Content": {Kind: TypeKindNamed, Name: "any"}, "ID": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Output": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}, "Status": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.InputTextContent":     {"Text": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.Message":              {"Content": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}},
+	"llm.MessagesRequest":      {"Effort": {Kind: TypeKindString}, "InferenceGeo": {Kind: TypeKindString}, "MaxTokens": {Kind: TypeKindInt}, "Messages": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "AnthropicMessage"}}, "Metadata": {Kind: TypeKindNamed, Name: "any"}, "Model": {Kind: TypeKindString}, "OutputConfig": {Kind: TypeKindNamed, Name: "any"}, "StopSequences": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}, "Stream": {Kind: TypeKindBool}, "System": {Kind: TypeKindString}, "Temperature": {Kind: TypeKindFloat}, "Thinking": {Kind: TypeKindNamed, Name: "any"}, "ToolChoice": {Kind: TypeKindNamed, Name: "any"}, "Tools": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "AnthropicTool"}}, "TopK": {Kind: TypeKindInt}, "TopP": {Kind: TypeKindFloat}},
+	"llm.OutputConfig":         {"Format": {Kind: TypeKindNamed, Name: "any"}},
+	"llm.OutputItem":           {"Arguments": {Kind: TypeKindString}, "CallID": {Kind: TypeKindString}, "Content": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}, "ID": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}, "Status": {Kind: TypeKindString}, "Summary": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}, "Type": {Kind: TypeKindString}},
+	"llm.OutputTextContent":    {"Annotations": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}, "Text": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.RefusalContent":       {"Refusal": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.Response":             {"CompletedAt": {Kind: TypeKindInt}, "CreatedAt": {Kind: TypeKindInt}, "Error": {Kind: TypeKindNamed, Name: "ResponseError"}, "ID": {Kind: TypeKindString}, "Instructions": {Kind: TypeKindString}, "MaxOutputTokens": {Kind: TypeKindInt}, "Metadata": {Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindString}}, "Model": {Kind: TypeKindString}, "Object": {Kind: TypeKindString}, "Output": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "OutputItem"}}, "PreviousResponseID": {Kind: TypeKindString}, "Status": {Kind: TypeKindString}, "Store": {Kind: TypeKindBool}, "Temperature": {Kind: TypeKindFloat}, "ToolChoice": {Kind: TypeKindNamed, Name: "any"}, "Tools": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}, "TopP": {Kind: TypeKindFloat}, "Truncation": {Kind: TypeKindString}, "Usage": {Kind: TypeKindNamed, Name: "ResponseUsage"}},
+	"llm.ResponseError":        {"Code": {Kind: TypeKindString}, "Message": {Kind: TypeKindString}},
+	"llm.ResponseMessage":      {"Content": {Kind: TypeKindString}, "Role": {Kind: TypeKindString}, "ToolCalls": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "ToolCall"}}},
+	"llm.ResponseRequest":      {"FrequencyPenalty": {Kind: TypeKindFloat}, "Input": {Kind: TypeKindNamed, Name: "any"}, "Instructions": {Kind: TypeKindString}, "MaxOutputTokens": {Kind: TypeKindInt}, "Metadata": {Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindString}}, "Model": {Kind: TypeKindString}, "PresencePenalty": {Kind: TypeKindFloat}, "PreviousResponseID": {Kind: TypeKindString}, "Store": {Kind: TypeKindBool}, "Stream": {Kind: TypeKindBool}, "Temperature": {Kind: TypeKindFloat}, "Text": {Kind: TypeKindNamed, Name: "any"}, "ToolChoice": {Kind: TypeKindNamed, Name: "any"}, "Tools": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindNamed, Name: "Tool"}}, "TopP": {Kind: TypeKindFloat}, "Truncation": {Kind: TypeKindString}},
+	"llm.ResponseUsage":        {"InputTokens": {Kind: TypeKindInt}, "OutputTokens": {Kind: TypeKindInt}, "TotalTokens": {Kind: TypeKindInt}},
+	"llm.StreamEvent":          {"Arguments": {Kind: TypeKindString}, "Code": {Kind: TypeKindString}, "ContentIndex": {Kind: TypeKindInt}, "Delta": {Kind: TypeKindString}, "Item": {Kind: TypeKindNamed, Name: "OutputItem"}, "ItemID": {Kind: TypeKindString}, "Message": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "OutputIndex": {Kind: TypeKindInt}, "Part": {Kind: TypeKindNamed, Name: "any"}, "Response": {Kind: TypeKindNamed, Name: "Response"}, "SequenceNumber": {Kind: TypeKindInt}, "Text": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.ThinkingConfig":       {"BudgetTokens": {Kind: TypeKindInt}, "Type": {Kind: TypeKindString}},
+	"llm.Tool":                 {"Function": {Kind: TypeKindNamed, Name: "ToolFunction"}, "Type": {Kind: TypeKindString}},
+	"llm.ToolCall":             {"Function": {Kind: TypeKindNamed, Name: "ToolCallFunction"}, "ID": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"llm.ToolCallFunction":     {"Arguments": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}},
+	"llm.ToolFunction":         {"Description": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Parameters": {Kind: TypeKindNamed, Name: "any"}},
+	"llm.Usage":                {"CompletionTokens": {Kind: TypeKindInt}, "PromptTokens": {Kind: TypeKindInt}, "TotalTokens": {Kind: TypeKindInt}},
+	"mcp.App":                  {"Server": {Kind: TypeKindReference}},
+	"mcp.SchemaProperty":       {"Description": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Type": {Kind: TypeKindString}},
+	"pool.ItemError":           {"Err": {Kind: TypeKindNamed, Name: "error"}, "Index": {Kind: TypeKindInt}},
+	"retry.Config":             {"InitialDelay": {Kind: TypeKindInt}, "MaxAttempts": {Kind: TypeKindInt}, "Strategy": {Kind: TypeKindInt}},
+	"skills.Skill":             {"Content": {Kind: TypeKindString}, "Name": {Kind: TypeKindString}, "Path": {Kind: TypeKindString}},
+	"table.Table":              {"Headers": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindString}}, "Rows": {Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindList}}},
+	"template.TemplateData":    {"Content": {Kind: TypeKindString}, "Data": {Kind: TypeKindMap, KeyType: &goStdlibType{Kind: TypeKindString}, ValueType: &goStdlibType{Kind: TypeKindNamed, Name: "any"}}},
+}
+
+// generatedStdlibOperators maps qualified Kukicha stdlib type names to their
+// operator methods (operator → method name), so a + b on two decimal.Decimal
+// values compiles to a.Add(b). Arithmetic methods return the type itself and
+// == and < methods return bool; the other comparisons derive from those two.
+var generatedStdlibOperators = map[string]map[string]string{
+	"decimal.Decimal": {"*": "Mul", "+": "Add", "-": "Sub", "<": "Less", "==": "Equal"},
+}
+
+// generatedStdlibMethods maps qualified Kukicha stdlib method names
+// (pkg.Type.Method) to their signatures, for the types in
+// generatedStdlibOperators.
+var generatedStdlibMethods = map[string]goStdlibEntry{
+	"decimal.Decimal.Abs":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{}},
+	"decimal.Decimal.Add":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.Cmp":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.Div":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"o", "places"}},
+	"decimal.Decimal.Equal":         {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.Float64":       {Count: 1, Types: []goStdlibType{{Kind: TypeKindFloat}}, ParamNames: []string{}},
+	"decimal.Decimal.IsZero":        {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{}},
+	"decimal.Decimal.Less":          {Count: 1, Types: []goStdlibType{{Kind: TypeKindBool}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.MarshalJSON":   {Count: 2, Types: []goStdlibType{{Kind: TypeKindList, ElementType: &goStdlibType{Kind: TypeKindInt}}, {Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{}},
+	"decimal.Decimal.Mul":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.Neg":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{}},
+	"decimal.Decimal.Places":        {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{}},
+	"decimal.Decimal.Round":         {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"places"}},
+	"decimal.Decimal.Sign":          {Count: 1, Types: []goStdlibType{{Kind: TypeKindInt}}, ParamNames: []string{}},
+	"decimal.Decimal.String":        {Count: 1, Types: []goStdlibType{{Kind: TypeKindString}}, ParamNames: []string{}},
+	"decimal.Decimal.Sub":           {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "Decimal"}}, ParamNames: []string{"o"}},
+	"decimal.Decimal.UnmarshalJSON": {Count: 1, Types: []goStdlibType{{Kind: TypeKindNamed, Name: "error"}}, ParamNames: []string{"data"}},
+}
@@ -8,6 +8,8 @@ type goStdlibType struct {
 	ElementType *goStdlibType  // element type for TypeKindList (e.g. list of string → &goStdlibType{Kind: TypeKindString})
 	KeyType     *goStdlibType  // key type for TypeKindMap
 	ValueType   *goStdlibType  // value type for TypeKindMap
+	Params      []goStdlibType // parameter types for TypeKindFunction (e.g. the yield callback of an iterator)
+	Returns     []goStdlibType // return types for TypeKindFunction
 }
 
 // goStdlibEntry holds the return signature info for a stdlib function:
@@ -16,9 +18,10 @@ type goStdlibType struct {
 type goStdlibEntry struct {
 	Count           int
 	Types           []goStdlibType
-	ParamNames      []string         // Parameter names (populated for Kukicha stdlib; nil for Go stdlib)
-	DefaultValues   []string         // Go expression strings for default parameter values; "" = no default
+	ParamNames      []string               // Parameter names (populated for Kukicha stdlib; nil for Go stdlib)
+	DefaultValues   []string               // Go expression strings for default parameter values; "" = no default
 	ParamFuncParams map[int][]goStdlibType // func-typed param index → inner param types (for lambda inference)
+	VoidFuncParams  []int                  // func-typed param indexes whose func has no results (lambdas there run for effect)
 }
 
 // GetStdlibEntry returns the Kukicha stdlib registry entry for the given
@@ -40,6 +43,12 @@ func GetSecurityCategory(qualifiedName string) string {
 	return generatedSecurityFunctions[qualifiedName]
 }
 
+// GetDeprecation returns the # kuki:deprecated message for a stdlib function
+// (e.g., "Use string.NewFunc instead"), or "" if it is not deprecated.
+func GetDeprecation(qualifiedName string) string {
+	return generatedStdlibDeprecated[qualifiedName]
+}
+
 // IsKnownInterface returns true if the qualified type name is a known interface
 // from either the Go stdlib or the Kukicha stdlib registries.
 func IsKnownInterface(qualifiedName string) bool {
@@ -108,19 +108,22 @@ func (tk TypeKind) String() string {
 
 // TypeInfo represents type information
 type TypeInfo struct {
-	Kind         TypeKind
-	Name         string               // For named types and placeholders
-	ElementType  *TypeInfo            // For lists, channels, references
-	KeyType      *TypeInfo            // For maps
-	ValueType    *TypeInfo            // For maps
-	Params       []*TypeInfo          // For functions
-	Returns      []*TypeInfo          // For functions
-	Constraint   string               // For placeholders: "any", "comparable", "cmp.Ordered"
-	Variadic     bool                 // For functions: true if last param is variadic
-	ParamNames   []string             // For functions: parameter names (for named argument validation)
-	DefaultCount int                  // For functions: number of parameters with default values
-	Fields       map[string]*TypeInfo // For structs: field name → field type
-	Methods      map[string]*TypeInfo // For structs: method name → function TypeInfo
+	Kind              TypeKind
+	Name              string               // For named types and placeholders
+	ElementType       *TypeInfo            // For lists, channels, references
+	ChanDir           string               // For channels: "", "send-only" or "receive-only"
+	KeyType           *TypeInfo            // For maps
+	ValueType         *TypeInfo            // For maps
+	Params            []*TypeInfo          // For functions
+	Returns           []*TypeInfo          // For functions
+	Constraint        string               // For placeholders: "any", "comparable", "cmp.Ordered"
+	Variadic          bool                 // For functions: true if last param is variadic
+	ParamNames        []string             // For functions: parameter names (for named argument validation)
+	DefaultCount      int                  // For functions: number of parameters with default values
+	ReferenceReceiver bool                 // For methods: declared on a reference receiver (func M on r reference T)
+	Fields            map[string]*TypeInfo // For structs: field name → field type
+	Distinct          bool                 // For distinct types: never mixes implicitly with another named type
+	Enum              []string             // For enums (distinct string types with values): the value set
 }
 
 func (ti *TypeInfo) String() string {
@@ -131,6 +134,11 @@ func (ti *TypeInfo) String() string {
 	switch ti.Kind {
 	case TypeKindNamed:
 		return ti.Name
+	case TypeKindInterface:
+		if ti.Name != "" {
+			return ti.Name
+		}
+		return "interface"
 	case TypeKindPlaceholder:
 		return ti.Name // Return the placeholder name (element, item, etc.)
 	case TypeKindList:
@@ -144,10 +152,14 @@ func (ti *TypeInfo) String() string {
 		}
 		return "map"
 	case TypeKindChannel:
+		name := "channel"
 		if ti.ElementType != nil {
-			return fmt.Sprintf("channel of %s", ti.ElementType)
+			name = fmt.Sprintf("channel of %s", ti.ElementType)
 		}
-		return "channel"
+		if ti.ChanDir != "" {
+			name += " " + ti.ChanDir
+		}
+		return name
 	case TypeKindReference:
 		if ti.ElementType != nil {
 			return fmt.Sprintf("reference %s", ti.ElementType)
@@ -173,6 +185,9 @@ func (ti *TypeInfo) String() string {
 		}
 		return fmt.Sprintf("func(%s)", params.String())
 	default:
+		if ti.Distinct {
+			return ti.Name
+		}
 		return ti.Kind.String()
 	}
 }
@@ -197,7 +212,11 @@ func (s *Scope) Define(symbol *Symbol) error {
 	if symbol.Name == "_" {
 		return nil
 	}
-	if _, exists := s.symbols[symbol.Name]; exists {
+	if existing, exists := s.symbols[symbol.Name]; exists {
+		if existing.Defined.Line > 0 {
+			return fmt.Errorf("identifier '%s' already declared in this scope (previous declaration at %s:%d:%d)",
+				symbol.Name, existing.Defined.File, existing.Defined.Line, existing.Defined.Column)
+		}
 		return fmt.Errorf("identifier '%s' already declared in this scope", symbol.Name)
 	}
 	s.symbols[symbol.Name] = symbol
@@ -215,6 +234,18 @@ func (s *Scope) Resolve(name string) *Symbol {
 	return nil
 }
 
+// Names returns the names visible from s: its own symbols and those of its
+// parent scopes.
+func (s *Scope) Names() []string {
+	var names []string
+	for scope := s; scope != nil; scope = scope.parent {
+		for name := range scope.symbols {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // SymbolTable manages scopes and symbols
 type SymbolTable struct {
 	scopes []*Scope
@@ -257,3 +288,8 @@ func (st *SymbolTable) Define(symbol *Symbol) error {
 func (st *SymbolTable) Resolve(name string) *Symbol {
 	return st.CurrentScope().Resolve(name)
 }
+
+// IsGlobal reports whether symbol is declared at package level.
+func (st *SymbolTable) IsGlobal(symbol *Symbol) bool {
+	return st.scopes[0].symbols[symbol.Name] == symbol
+}
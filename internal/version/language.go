@@ -0,0 +1,132 @@
+package version
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Language is a language version declared by a `# kukicha: X.Y.Z` pragma.
+// Trailing components may be "x" (`# kukicha: 1.x`), which accepts every
+// release in that series.
+type Language struct {
+	Text  string // as written in the pragma
+	parts [3]int // missing or "x" components are math.MaxInt
+}
+
+// ParseLanguage parses a pragma version: "0.0.21", "0.1", "1.x", or "1".
+func ParseLanguage(s string) (Language, error) {
+	s = strings.TrimSpace(s)
+	fields := strings.Split(s, ".")
+	if s == "" || len(fields) > 3 {
+		return Language{}, fmt.Errorf("invalid kukicha version %q (want MAJOR[.MINOR[.PATCH]], e.g. 0.0.21 or 1.x)", s)
+	}
+	lang := Language{Text: s, parts: [3]int{math.MaxInt, math.MaxInt, math.MaxInt}}
+	wild := false
+	for i, f := range fields {
+		if f == "x" {
+			wild = true
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || wild {
+			return Language{}, fmt.Errorf("invalid kukicha version %q (want MAJOR[.MINOR[.PATCH]], e.g. 0.0.21 or 1.x)", s)
+		}
+		lang.parts[i] = n
+	}
+	if lang.parts[0] == math.MaxInt {
+		return Language{}, fmt.Errorf("invalid kukicha version %q (the major version cannot be x)", s)
+	}
+	return lang, nil
+}
+
+// Supports reports whether code written for l may use a feature that
+// shipped in release since (a bare X.Y.Z).
+func (l Language) Supports(since string) bool {
+	return compareParts(l.parts, releaseParts(since)) >= 0
+}
+
+// Newer reports whether l asks for a release later than this compiler.
+func (l Language) Newer() bool {
+	min := l.parts
+	for i := range min {
+		if min[i] == math.MaxInt {
+			min[i] = 0
+		}
+	}
+	return compareParts(min, releaseParts(Version)) > 0
+}
+
+func releaseParts(v string) [3]int {
+	var parts [3]int
+	for i, f := range strings.SplitN(v, ".", 3) {
+		parts[i], _ = strconv.Atoi(f)
+	}
+	return parts
+}
+
+func compareParts(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Feature is a language feature that a `# kukicha:` pragma can rule out.
+type Feature struct {
+	Name  string // as shown in diagnostics
+	Since string // first release with the feature
+}
+
+// Gated language features. Add an entry (and list it in Features) when a
+// release introduces syntax, and check it in the semantic analyzer
+// (semantic_version.go).
+var (
+	FeatureOnErrExplain    = Feature{Name: "'onerr explain'", Since: "0.0.16"}
+	FeatureParallelPipe    = Feature{Name: "parallel pipe '|>>'", Since: "0.0.22"}
+	FeatureOnErrExpr       = Feature{Name: "onerr inside an expression", Since: "0.0.22"}
+	FeatureComparisonChain = Feature{Name: "chained comparison 'a < b < c'", Since: "0.0.22"}
+	FeatureRangeCheck      = Feature{Name: "range check 'between' / 'in a to b'", Since: "0.0.22"}
+	FeatureWhenPattern     = Feature{Name: "when pattern '> x' / 'a to b' / 'matches'", Since: "0.0.22"}
+	FeatureRegexLiteral    = Feature{Name: "regex literal 're\"...\"'", Since: "0.0.22"}
+	FeatureRequire         = Feature{Name: "'require ... else' guard clause", Since: "0.0.22"}
+	FeatureChanDirection   = Feature{Name: "channel direction 'send-only' / 'receive-only'", Since: "0.0.22"}
+	FeatureYields          = Feature{Name: "'yields' iterator function", Since: "0.0.22"}
+	FeatureSequence        = Feature{Name: "'sequence of T' type", Since: "0.0.22"}
+	FeatureWait            = Feature{Name: "'wait for' expression", Since: "0.0.22"}
+	FeatureTypeDefinition  = Feature{Name: "type over a non-function type 'type Meters int'", Since: "0.0.22"}
+	FeatureDistinct        = Feature{Name: "'distinct' type", Since: "0.0.22"}
+	FeatureEnum            = Feature{Name: "distinct string type values (enum)", Since: "0.0.22"}
+	FeatureOperatorMethod  = Feature{Name: "operator method 'operator +'", Since: "0.0.22"}
+	FeatureDecimalLiteral  = Feature{Name: "decimal literal '1.23d'", Since: "0.0.22"}
+	FeatureDerive          = Feature{Name: "'@derive' directive", Since: "0.0.22"}
+	FeatureImplements      = Feature{Name: "'implements' assertion", Since: "0.0.22"}
+	FeatureWhenGuard       = Feature{Name: "when guard 'when x if cond'", Since: "0.0.22"}
+	FeatureFallthrough     = Feature{Name: "'continue to next'", Since: "0.0.22"}
+	FeatureSafely          = Feature{Name: "'safely' block", Since: "0.0.22"}
+	FeatureFail            = Feature{Name: "'fail' statement", Since: "0.0.22"}
+	FeatureStdio           = Feature{Name: "'read line' / 'read all' / 'write'", Since: "0.0.22"}
+	FeatureData            = Feature{Name: "'data' block", Since: "0.0.22"}
+	FeatureCommand         = Feature{Name: "command substitution '$ \"...\"'", Since: "0.0.22"}
+	FeatureErrorDecl       = Feature{Name: "sentinel error declaration 'error Name \"...\"'", Since: "0.0.22"}
+	FeatureBuildString     = Feature{Name: "'build string' block", Since: "0.0.22"}
+	FeatureWhenTarget      = Feature{Name: "'when target' block", Since: "0.0.22"}
+)
+
+// Features lists every gated feature above.
+var Features = []Feature{
+	FeatureOnErrExplain, FeatureParallelPipe, FeatureOnErrExpr, FeatureComparisonChain,
+	FeatureRangeCheck, FeatureWhenPattern, FeatureRegexLiteral, FeatureRequire,
+	FeatureChanDirection, FeatureYields, FeatureSequence, FeatureWait,
+	FeatureTypeDefinition, FeatureDistinct, FeatureEnum, FeatureOperatorMethod,
+	FeatureDecimalLiteral, FeatureDerive, FeatureImplements, FeatureWhenGuard,
+	FeatureFallthrough, FeatureSafely, FeatureFail, FeatureStdio,
+	FeatureData, FeatureCommand, FeatureErrorDecl, FeatureBuildString,
+	FeatureWhenTarget,
+}
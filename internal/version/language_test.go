@@ -0,0 +1,67 @@
+package version
+
+import "testing"
+
+func TestParseLanguage(t *testing.T) {
+	for _, ok := range []string{"0.0.21", "0.1", "1", "1.x", "0.0.x"} {
+		if _, err := ParseLanguage(ok); err != nil {
+			t.Errorf("ParseLanguage(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "x", "1.x.2", "v1.0", "1.2.3.4", "1.-2"} {
+		if _, err := ParseLanguage(bad); err == nil {
+			t.Errorf("ParseLanguage(%q): expected error", bad)
+		}
+	}
+}
+
+func TestLanguageSupports(t *testing.T) {
+	tests := []struct {
+		lang, since string
+		want        bool
+	}{
+		{"0.0.16", "0.0.16", true},
+		{"0.0.15", "0.0.16", false},
+		{"0.0.x", "0.0.99", true},
+		{"0.1", "0.1.4", true},
+		{"0.1", "0.2.0", false},
+		{"1.x", "1.7.0", true},
+		{"1.x", "2.0.0", false},
+	}
+	for _, tt := range tests {
+		lang, err := ParseLanguage(tt.lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := lang.Supports(tt.since); got != tt.want {
+			t.Errorf("%s.Supports(%s) = %v, want %v", tt.lang, tt.since, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageNewer(t *testing.T) {
+	current, _ := ParseLanguage(Version)
+	if current.Newer() {
+		t.Errorf("the compiler's own version should not be newer than itself")
+	}
+	future, _ := ParseLanguage("999.0")
+	if !future.Newer() {
+		t.Errorf("999.0 should be newer than %s", Version)
+	}
+	series, _ := ParseLanguage("0.x")
+	if series.Newer() {
+		t.Errorf("0.x should be accepted by %s", Version)
+	}
+}
+
+func TestFeaturesShipInThisVersion(t *testing.T) {
+	current, err := ParseLanguage(Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range Features {
+		if !current.Supports(f.Since) {
+			t.Errorf("%s is since %s, after this compiler (%s): bump Version", f.Name, f.Since, Version)
+		}
+	}
+}
@@ -3,4 +3,4 @@ package version
 
 // Version is the current Kukicha compiler version.
 // This is the single source of truth for versioning across the project.
-const Version = "0.0.21"
+const Version = "0.0.22"
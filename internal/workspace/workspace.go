@@ -0,0 +1,244 @@
+// Package workspace reads kukicha.work, the file that lists Kukicha projects
+// developed together (a shared library alongside the apps that use it):
+//
+//	# kukicha.work
+//	use ./shared
+//	use ./app
+//
+// Each `use` names a directory, relative to the file, holding a go.mod.
+// Imports of those modules' packages resolve to the local directories: the
+// compiler writes a go.work next to kukicha.work for go build and go run,
+// `kukicha check` follows them for import cycles, and the language server
+// for go to definition.
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	goversion "go/version"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// FileName is the workspace file.
+const FileName = "kukicha.work"
+
+// stdlibModule is the module of the Kukicha stdlib, which each project
+// replaces with its own extracted copy (see ensureGoMod in cmd/kukicha).
+const stdlibModule = "github.com/duber000/kukicha/stdlib"
+
+// Workspace is a loaded kukicha.work.
+type Workspace struct {
+	Dir     string   // Directory holding kukicha.work
+	Modules []Module // In the order of the use lines
+}
+
+// Module is one project of a workspace.
+type Module struct {
+	Path      string // Module path from go.mod
+	Dir       string // Absolute directory
+	GoVersion string // go directive of go.mod, "" if none
+}
+
+// Find walks up from dir looking for kukicha.work. It returns "" when none is
+// found.
+func Find(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		path := filepath.Join(d, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		if d == filepath.Dir(d) {
+			return ""
+		}
+	}
+}
+
+// ForDir loads the workspace dir belongs to, or returns nil when there is
+// none. dir must be inside one of the workspace's modules: a kukicha.work
+// further up that does not list the project does not apply to it.
+func ForDir(dir string) (*Workspace, error) {
+	path := Find(dir)
+	if path == "" {
+		return nil, nil
+	}
+	w, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if w.moduleForDir(abs) == nil {
+		return nil, nil
+	}
+	return w, nil
+}
+
+// Load reads the workspace file at path and the go.mod of each module it
+// uses.
+func Load(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	uses, err := Parse(string(data), path)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &Workspace{Dir: filepath.Dir(abs)}
+	for _, use := range uses {
+		dir := filepath.Join(w.Dir, filepath.FromSlash(use))
+		goMod := filepath.Join(dir, "go.mod")
+		data, err := os.ReadFile(goMod)
+		if err != nil {
+			return nil, fmt.Errorf("%s: use %s: no go.mod in %s", path, use, dir)
+		}
+		mod, err := modfile.ParseLax(goMod, data, nil)
+		if err != nil {
+			return nil, err
+		}
+		if mod.Module == nil {
+			return nil, fmt.Errorf("%s: no module directive", goMod)
+		}
+		m := Module{Path: mod.Module.Mod.Path, Dir: dir}
+		if mod.Go != nil {
+			m.GoVersion = mod.Go.Version
+		}
+		w.Modules = append(w.Modules, m)
+	}
+	return w, nil
+}
+
+// Parse returns the directories listed by the use lines of a kukicha.work
+// document. Blank lines and # comments are ignored.
+func Parse(src, filename string) ([]string, error) {
+	var uses []string
+	seen := make(map[string]bool)
+	for i, raw := range strings.Split(src, "\n") {
+		line := raw
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] != "use" || len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected 'use <dir>', got %q", filename, i+1, strings.TrimSpace(line))
+		}
+		dir := filepath.ToSlash(filepath.Clean(fields[1]))
+		if seen[dir] {
+			return nil, fmt.Errorf("%s:%d: %s is already used", filename, i+1, fields[1])
+		}
+		seen[dir] = true
+		uses = append(uses, dir)
+	}
+	return uses, nil
+}
+
+// Resolve returns the directory of the package with import path pkg when it
+// belongs to one of the workspace's modules.
+func (w *Workspace) Resolve(pkg string) (string, bool) {
+	var best *Module
+	for i := range w.Modules {
+		m := &w.Modules[i]
+		if pkg != m.Path && !strings.HasPrefix(pkg, m.Path+"/") {
+			continue
+		}
+		if best == nil || len(m.Path) > len(best.Path) {
+			best = m
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	rel := strings.TrimPrefix(strings.TrimPrefix(pkg, best.Path), "/")
+	return filepath.Join(best.Dir, filepath.FromSlash(rel)), true
+}
+
+// moduleForDir returns the module containing dir, or nil.
+func (w *Workspace) moduleForDir(dir string) *Module {
+	var best *Module
+	for i := range w.Modules {
+		m := &w.Modules[i]
+		rel, err := filepath.Rel(m.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if best == nil || len(m.Dir) > len(best.Dir) {
+			best = m
+		}
+	}
+	return best
+}
+
+// GoWork returns the go.work that makes the go command use the workspace's
+// modules. When stdlibDir is not empty, the Kukicha stdlib is replaced with
+// it for the whole workspace, since each module's go.mod points at its own
+// copy and go refuses conflicting replacements.
+func (w *Workspace) GoWork(stdlibDir string) []byte {
+	goVersion := ""
+	for _, m := range w.Modules {
+		if m.GoVersion != "" && (goVersion == "" || goversion.Compare("go"+m.GoVersion, "go"+goVersion) > 0) {
+			goVersion = m.GoVersion
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by kukicha from %s. DO NOT EDIT.\n", FileName)
+	if goVersion != "" {
+		fmt.Fprintf(&b, "\ngo %s\n", goVersion)
+	}
+	b.WriteString("\nuse (\n")
+	for _, m := range w.Modules {
+		fmt.Fprintf(&b, "\t%s\n", w.relative(m.Dir))
+	}
+	b.WriteString(")\n")
+	if stdlibDir != "" {
+		fmt.Fprintf(&b, "\nreplace %s => %s\n", stdlibModule, w.relative(stdlibDir))
+	}
+	return b.Bytes()
+}
+
+// WriteGoWork writes GoWork(stdlibDir) to go.work next to kukicha.work,
+// leaving the file alone when it is up to date. A go.work the compiler did
+// not generate is not overwritten.
+func (w *Workspace) WriteGoWork(stdlibDir string) error {
+	path := filepath.Join(w.Dir, "go.work")
+	content := w.GoWork(stdlibDir)
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		if bytes.Equal(existing, content) {
+			return nil
+		}
+		if !bytes.HasPrefix(existing, []byte("// Code generated by kukicha")) {
+			return fmt.Errorf("%s exists and was not generated from %s; remove it or add its modules to %s", path, FileName, FileName)
+		}
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// relative returns dir as a ./-prefixed path from the workspace directory.
+func (w *Workspace) relative(dir string) string {
+	rel, err := filepath.Rel(w.Dir, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return "./" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParse(t *testing.T) {
+	uses, err := Parse("# shared code\nuse ./shared\n\nuse app/  # the app\n", FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(uses, ",") != "shared,app" {
+		t.Errorf("uses = %v", uses)
+	}
+
+	for src, want := range map[string]string{
+		"require ./x\n":    "kukicha.work:1: expected 'use <dir>'",
+		"use\n":            "kukicha.work:1: expected 'use <dir>'",
+		"use ./a\nuse a\n": "kukicha.work:2: a is already used",
+		"use ./a ./b\n":    "kukicha.work:1: expected 'use <dir>'",
+	} {
+		if _, err := Parse(src, FileName); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", src, err, want)
+		}
+	}
+}
+
+func TestLoadAndResolve(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		FileName:           "use ./lib\nuse ./lib/extra\nuse ./app\n",
+		"lib/go.mod":       "module example.com/lib\n\ngo 1.25\n",
+		"lib/extra/go.mod": "module example.com/lib/extra\n\ngo 1.26.1\n",
+		"app/go.mod":       "module example.com/app\n\ngo 1.26\n",
+	})
+
+	ws, err := ForDir(filepath.Join(dir, "app", "cmd"))
+	if err != nil || ws == nil {
+		t.Fatalf("ForDir = %v, %v", ws, err)
+	}
+	tests := map[string]string{
+		"example.com/lib":         filepath.Join(dir, "lib"),
+		"example.com/lib/greet":   filepath.Join(dir, "lib", "greet"),
+		"example.com/lib/extra/x": filepath.Join(dir, "lib", "extra", "x"),
+	}
+	for pkg, want := range tests {
+		if got, ok := ws.Resolve(pkg); !ok || got != want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", pkg, got, ok, want)
+		}
+	}
+	for _, pkg := range []string{"example.com/library", "fmt"} {
+		if got, ok := ws.Resolve(pkg); ok {
+			t.Errorf("Resolve(%q) = %q, want no match", pkg, got)
+		}
+	}
+
+	want := "// Code generated by kukicha from kukicha.work. DO NOT EDIT.\n\n" +
+		"go 1.26.1\n\n" +
+		"use (\n\t./lib\n\t./lib/extra\n\t./app\n)\n\n" +
+		"replace github.com/duber000/kukicha/stdlib => ./app/.kukicha/stdlib\n"
+	if got := string(ws.GoWork(filepath.Join(dir, "app", ".kukicha", "stdlib"))); got != want {
+		t.Errorf("GoWork:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestForDirOutsideWorkspace(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		FileName:       "use ./lib\n",
+		"lib/go.mod":   "module example.com/lib\n",
+		"other/go.mod": "module example.com/other\n",
+	})
+	if ws, err := ForDir(filepath.Join(dir, "other")); ws != nil || err != nil {
+		t.Errorf("ForDir(other) = %v, %v; want nil, nil", ws, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("use ./missing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ForDir(filepath.Join(dir, "lib")); err == nil || !strings.Contains(err.Error(), "no go.mod") {
+		t.Errorf("expected a missing go.mod error, got %v", err)
+	}
+}
+
+func TestWriteGoWork(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		FileName:     "use ./lib\n",
+		"lib/go.mod": "module example.com/lib\n",
+	})
+	ws, err := Load(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.WriteGoWork(""); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "go.work"))
+	if err != nil || !strings.Contains(string(data), "\t./lib\n") {
+		t.Fatalf("go.work = %q, %v", data, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.work"), []byte("go 1.26\n\nuse ./lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.WriteGoWork(""); err == nil || !strings.Contains(err.Error(), "was not generated") {
+		t.Errorf("expected a hand-written go.work to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package kukicha
+
+import (
+	"github.com/duber000/kukicha/internal/ast"
+	"github.com/duber000/kukicha/internal/lexer"
+)
+
+// The syntax tree handed to plugins. These are aliases of the compiler's own
+// node types, so a plugin sees and edits exactly what the compiler uses.
+type (
+	Token               = lexer.Token
+	Node                = ast.Node
+	Position            = ast.Position
+	Program             = ast.Program
+	Declaration         = ast.Declaration
+	PetioleDecl         = ast.PetioleDecl
+	SkillDecl           = ast.SkillDecl
+	ImportDecl          = ast.ImportDecl
+	ConstSpec           = ast.ConstSpec
+	ConstDecl           = ast.ConstDecl
+	ErrorSpec           = ast.ErrorSpec
+	ErrorDecl           = ast.ErrorDecl
+	TargetDecl          = ast.TargetDecl
+	Directive           = ast.Directive
+	TypeDecl            = ast.TypeDecl
+	FieldDecl           = ast.FieldDecl
+	InterfaceDecl       = ast.InterfaceDecl
+	ImplementsDecl      = ast.ImplementsDecl
+	MethodSignature     = ast.MethodSignature
+	FunctionDecl        = ast.FunctionDecl
+	Parameter           = ast.Parameter
+	Receiver            = ast.Receiver
+	TypeAnnotation      = ast.TypeAnnotation
+	PrimitiveType       = ast.PrimitiveType
+	NamedType           = ast.NamedType
+	ReferenceType       = ast.ReferenceType
+	ListType            = ast.ListType
+	MapType             = ast.MapType
+	ChannelType         = ast.ChannelType
+	SequenceType        = ast.SequenceType
+	FunctionType        = ast.FunctionType
+	OnErrClause         = ast.OnErrClause
+	OnErrExpr           = ast.OnErrExpr
+	Statement           = ast.Statement
+	BlockStmt           = ast.BlockStmt
+	VarDeclStmt         = ast.VarDeclStmt
+	AssignStmt          = ast.AssignStmt
+	IncDecStmt          = ast.IncDecStmt
+	ReturnStmt          = ast.ReturnStmt
+	ContinueStmt        = ast.ContinueStmt
+	BreakStmt           = ast.BreakStmt
+	FallthroughStmt     = ast.FallthroughStmt
+	IfStmt              = ast.IfStmt
+	ElseStmt            = ast.ElseStmt
+	SwitchStmt          = ast.SwitchStmt
+	TargetStmt          = ast.TargetStmt
+	WhenCase            = ast.WhenCase
+	WhenPattern         = ast.WhenPattern
+	OtherwiseCase       = ast.OtherwiseCase
+	SelectStmt          = ast.SelectStmt
+	SelectCase          = ast.SelectCase
+	TypeSwitchStmt      = ast.TypeSwitchStmt
+	TypeCase            = ast.TypeCase
+	ForRangeStmt        = ast.ForRangeStmt
+	ForNumericStmt      = ast.ForNumericStmt
+	ForConditionStmt    = ast.ForConditionStmt
+	DeferStmt           = ast.DeferStmt
+	GoStmt              = ast.GoStmt
+	SafelyStmt          = ast.SafelyStmt
+	FailStmt            = ast.FailStmt
+	RequireStmt         = ast.RequireStmt
+	SendStmt            = ast.SendStmt
+	YieldStmt           = ast.YieldStmt
+	ExpressionStmt      = ast.ExpressionStmt
+	Expression          = ast.Expression
+	Identifier          = ast.Identifier
+	IntegerLiteral      = ast.IntegerLiteral
+	FloatLiteral        = ast.FloatLiteral
+	DecimalLiteral      = ast.DecimalLiteral
+	RuneLiteral         = ast.RuneLiteral
+	CommandExpr         = ast.CommandExpr
+	BuildStringExpr     = ast.BuildStringExpr
+	DataLiteral         = ast.DataLiteral
+	RegexLiteral        = ast.RegexLiteral
+	StringLiteral       = ast.StringLiteral
+	StringInterpolation = ast.StringInterpolation
+	BooleanLiteral      = ast.BooleanLiteral
+	BinaryExpr          = ast.BinaryExpr
+	ComparisonChainExpr = ast.ComparisonChainExpr
+	RangeCheckExpr      = ast.RangeCheckExpr
+	UnaryExpr           = ast.UnaryExpr
+	PipeExpr            = ast.PipeExpr
+	ParallelPipeExpr    = ast.ParallelPipeExpr
+	NamedArgument       = ast.NamedArgument
+	CallExpr            = ast.CallExpr
+	MethodCallExpr      = ast.MethodCallExpr
+	FieldAccessExpr     = ast.FieldAccessExpr
+	IndexExpr           = ast.IndexExpr
+	SliceExpr           = ast.SliceExpr
+	StructLiteralExpr   = ast.StructLiteralExpr
+	FieldValue          = ast.FieldValue
+	ListLiteralExpr     = ast.ListLiteralExpr
+	MapLiteralExpr      = ast.MapLiteralExpr
+	KeyValuePair        = ast.KeyValuePair
+	ReceiveExpr         = ast.ReceiveExpr
+	WaitExpr            = ast.WaitExpr
+	TypeCastExpr        = ast.TypeCastExpr
+	TypeAssertionExpr   = ast.TypeAssertionExpr
+	EmptyExpr           = ast.EmptyExpr
+	DiscardExpr         = ast.DiscardExpr
+	ErrorExpr           = ast.ErrorExpr
+	ReturnExpr          = ast.ReturnExpr
+	MakeExpr            = ast.MakeExpr
+	CloseExpr           = ast.CloseExpr
+	PanicExpr           = ast.PanicExpr
+	RecoverExpr         = ast.RecoverExpr
+	ReadExpr            = ast.ReadExpr
+	FunctionLiteral     = ast.FunctionLiteral
+	ArrowLambda         = ast.ArrowLambda
+	AddressOfExpr       = ast.AddressOfExpr
+	DerefExpr           = ast.DerefExpr
+	PipedSwitchExpr     = ast.PipedSwitchExpr
+	PipedSwitchBody     = ast.PipedSwitchBody
+	BlockExpr           = ast.BlockExpr
+	Route               = ast.Route
+)
+
+// WalkBlock calls visit for every expression in block, stopping as soon as
+// visit returns true (and then returning true).
+func WalkBlock(block *BlockStmt, visit func(Expression) bool) bool {
+	return ast.WalkBlock(block, visit)
+}
+
+// WalkStmt calls visit for every expression reachable from stmt, like WalkBlock.
+func WalkStmt(stmt Statement, visit func(Expression) bool) bool {
+	return ast.WalkStmt(stmt, visit)
+}
+
+// WalkExpr calls visit for expr and then its sub-expressions, like WalkBlock.
+func WalkExpr(expr Expression, visit func(Expression) bool) bool {
+	return ast.WalkExpr(expr, visit)
+}
+
+// WalkStmts calls visit for every statement in block and in nested blocks,
+// not descending into function literals or lambdas.
+func WalkStmts(block *BlockStmt, visit func(Statement) bool) bool {
+	return ast.WalkStmts(block, visit)
+}
+
+// RewriteProgram replaces every expression in program with fn(expr), for
+// DSL expansions and code injection.
+func RewriteProgram(program *Program, fn func(Expression) Expression) {
+	ast.RewriteProgram(program, fn)
+}
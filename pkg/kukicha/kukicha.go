@@ -0,0 +1,137 @@
+// Package kukicha is the public API of the Kukicha compiler: compile
+// pipeline plugins and a Compile entry point for programs that embed the
+// compiler.
+//
+// A plugin implements Name and any of AfterParse, AfterAnalysis and
+// BeforeCodegen, and is added with Register. To use plugins with the kukicha
+// command, build the command with -tags kukicha_plugins, build each plugin as
+// a Go plugin that registers from init, and list the .so files in
+// $KUKICHA_PLUGINS:
+//
+//	package main
+//
+//	import "github.com/duber000/kukicha/pkg/kukicha"
+//
+//	type noPrint struct{}
+//
+//	func (noPrint) Name() string { return "no-print" }
+//
+//	func (noPrint) AfterAnalysis(pass *kukicha.Pass) {
+//		for _, decl := range pass.Program.Declarations {
+//			fn, ok := decl.(*kukicha.FunctionDecl)
+//			if !ok || fn.Body == nil {
+//				continue
+//			}
+//			kukicha.WalkBlock(fn.Body, func(e kukicha.Expression) bool {
+//				if call, ok := e.(*kukicha.CallExpr); ok {
+//					if id, ok := call.Function.(*kukicha.Identifier); ok && id.Value == "print" {
+//						pass.Errorf(call.Pos(), "use the log package instead of print")
+//					}
+//				}
+//				return false // keep walking
+//			})
+//		}
+//	}
+//
+//	func init() { kukicha.Register(noPrint{}) }
+//
+// built with `go build -buildmode=plugin`, using the same Go version and
+// kukicha module version as the kukicha command.
+package kukicha
+
+import (
+	"errors"
+
+	"github.com/duber000/kukicha/internal/codegen"
+	"github.com/duber000/kukicha/internal/hooks"
+	"github.com/duber000/kukicha/internal/parser"
+	"github.com/duber000/kukicha/internal/semantic"
+)
+
+type (
+	// Plugin is the common part of every plugin.
+	Plugin = hooks.Plugin
+	// AfterParser runs on the program as parsed, before type checking.
+	AfterParser = hooks.AfterParser
+	// AfterAnalyzer runs on the analyzed program (also in `kukicha check`).
+	AfterAnalyzer = hooks.AfterAnalyzer
+	// BeforeCodegener runs just before Go generation.
+	BeforeCodegener = hooks.BeforeCodegener
+	// Pass carries the program to a hook and collects its diagnostics.
+	Pass = hooks.Pass
+	// TypeInfo is a type inferred by the analyzer (Pass.ExprTypes).
+	TypeInfo = semantic.TypeInfo
+)
+
+// Register adds a plugin to the compile pipeline. It panics when the plugin
+// implements no hook or its name is already registered.
+func Register(p Plugin) {
+	hooks.Register(p)
+}
+
+// Result is the output of Compile.
+type Result struct {
+	Go       []byte  // formatted Go source
+	Warnings []error // analyzer and plugin warnings
+}
+
+// Compile translates one Kukicha file to Go, running the registered plugins.
+// filename is used in diagnostics and //line directives. The error joins all
+// parse, semantic, or plugin errors of the first stage that has any.
+func Compile(filename string, source []byte) (*Result, error) {
+	p, err := parser.New(string(source), filename)
+	if err != nil {
+		return nil, err
+	}
+	program, parseErrors := p.Parse()
+	if len(parseErrors) > 0 {
+		return nil, errors.Join(parseErrors...)
+	}
+
+	result := &Result{}
+	pass := &Pass{Program: program, File: filename}
+	run := func(stage hooks.Stage) error {
+		errs, warnings := hooks.Run(stage, pass)
+		result.Warnings = append(result.Warnings, warnings...)
+		return errors.Join(errs...)
+	}
+
+	if err := run(hooks.AfterParse); err != nil {
+		return nil, err
+	}
+
+	analyzer := semantic.NewWithFile(program, filename)
+	if errs := analyzer.Analyze(); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	result.Warnings = append(result.Warnings, analyzer.Warnings()...)
+	pass.ReturnCounts = analyzer.ReturnCounts()
+	pass.ExprTypes = analyzer.ExprTypes()
+	pass.AnnTypes = analyzer.TypeAnnotationTypes()
+	pass.Captures = analyzer.Captures()
+	pass.Constants = analyzer.Constants()
+
+	if err := run(hooks.AfterAnalysis); err != nil {
+		return nil, err
+	}
+	if err := run(hooks.BeforeCodegen); err != nil {
+		return nil, err
+	}
+
+	gen := codegen.New(program)
+	gen.SetSourceFile(filename)
+	gen.SetExprReturnCounts(pass.ReturnCounts)
+	gen.SetExprTypes(pass.ExprTypes)
+	gen.SetTypeAnnotationTypes(pass.AnnTypes)
+	gen.SetCaptures(pass.Captures)
+	gen.SetConstants(pass.Constants)
+	goCode, err := gen.Generate()
+	if err != nil {
+		return nil, err
+	}
+	result.Go, err = codegen.FormatGo([]byte(goCode))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
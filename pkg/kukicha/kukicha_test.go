@@ -0,0 +1,49 @@
+package kukicha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duber000/kukicha/internal/hooks"
+)
+
+type noPrint struct{}
+
+func (noPrint) Name() string { return "no-print" }
+
+func (noPrint) AfterAnalysis(pass *Pass) {
+	for _, decl := range pass.Program.Declarations {
+		fn, ok := decl.(*FunctionDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		WalkBlock(fn.Body, func(e Expression) bool {
+			if call, ok := e.(*CallExpr); ok {
+				if id, ok := call.Function.(*Identifier); ok && id.Value == "print" {
+					pass.Errorf(call.Pos(), "use the log package instead of print")
+				}
+			}
+			return false
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	result, err := Compile("app.kuki", []byte("func main()\n    x := 1\n    print(x)\n"))
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if !strings.Contains(string(result.Go), "func main()") {
+		t.Errorf("expected generated main, got:\n%s", result.Go)
+	}
+}
+
+func TestCompileRunsPlugins(t *testing.T) {
+	t.Cleanup(hooks.Reset)
+	Register(noPrint{})
+
+	_, err := Compile("app.kuki", []byte("func main()\n    print(\"hi\")\n"))
+	if err == nil || !strings.Contains(err.Error(), "app.kuki:2:") || !strings.Contains(err.Error(), "(plugin no-print)") {
+		t.Fatalf("expected a no-print plugin error, got %v", err)
+	}
+}
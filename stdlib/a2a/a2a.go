@@ -3,41 +3,51 @@
 package a2a
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
 	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
 	ctxpkg "github.com/duber000/kukicha/stdlib/ctx"
 	"github.com/duber000/kukicha/stdlib/retry"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"net/http"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:14
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:17
 type TextHandler func(string)
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:17
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:20
 type StatusHandler func(StatusUpdate)
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:20
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:23
+type ArtifactHandler func(Artifact)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:28
+type TaskHandler func(*TaskContext) error
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:31
 type Agent struct {
 	Card   *a2a.AgentCard
 	Client *a2aclient.Client
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:36
 type Request struct {
 	agent            Agent
 	text             string
 	contextID        string
 	onText           TextHandler
 	onStatus         StatusHandler
+	onArtifact       ArtifactHandler
 	retryMaxAttempts int
 	retryDelayMs     int
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:47
 type Task struct {
 	ID        string
 	ContextID string
@@ -46,13 +56,14 @@ type Task struct {
 	Artifacts []Artifact
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:43
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:56
 type Artifact struct {
+	ID   string
 	Name string
 	Text string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:48
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:62
 type StatusUpdate struct {
 	TaskID  string
 	State   string
@@ -60,389 +71,621 @@ type StatusUpdate struct {
 	Final   bool
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:55
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:69
 type Skill struct {
 	Name        string
 	Description string
 	Examples    []string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:76
+type Server struct {
+	Card    *a2a.AgentCard
+	handler TaskHandler
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:83
+type TaskContext struct {
+	ID        string
+	ContextID string
+	Text      string
+	ctx       context.Context
+	reqCtx    *a2asrv.RequestContext
+	queue     eventqueue.Queue
+	artifacts map[string]a2a.ArtifactID
+	finished  bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:94
+type executor struct {
+	server *Server
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:98
 func Discover(url string) (Agent, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:62
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:99
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:100
 	card, err_1 := agentcard.DefaultResolver.Resolve(ctxpkg.Value(bg), url)
 	if err_1 != nil {
 		err_1 = fmt.Errorf("a2a discover: %w", err_1)
 		var _zero0 Agent
 		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:64
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:101
 	client, err_2 := a2aclient.NewFromCard(ctxpkg.Value(bg), card)
 	if err_2 != nil {
 		err_2 = fmt.Errorf("a2a client: %w", err_2)
 		var _zero0 Agent
 		return _zero0, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:102
 	return Agent{Card: card, Client: client}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:68
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:105
 func DiscoverGuarded(url string, httpClient *http.Client) (Agent, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:69
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:106
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:70
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:107
 	resolver := agentcard.NewResolver(httpClient)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:71
-	card, err_3 := resolver.Resolve(ctxpkg.Value(bg), url)
-	if err_3 != nil {
-		err_3 = fmt.Errorf("a2a discover: %w", err_3)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:108
+	card, err_1 := resolver.Resolve(ctxpkg.Value(bg), url)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("a2a discover: %w", err_1)
 		var _zero0 Agent
-		return _zero0, err_3
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:72
-	client, err_4 := a2aclient.NewFromCard(ctxpkg.Value(bg), card, a2aclient.WithJSONRPCTransport(httpClient))
-	if err_4 != nil {
-		err_4 = fmt.Errorf("a2a client: %w", err_4)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:109
+	client, err_2 := a2aclient.NewFromCard(ctxpkg.Value(bg), card, a2aclient.WithJSONRPCTransport(httpClient))
+	if err_2 != nil {
+		err_2 = fmt.Errorf("a2a client: %w", err_2)
 		var _zero0 Agent
-		return _zero0, err_4
+		return _zero0, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:73
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:110
 	return Agent{Card: card, Client: client}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:76
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:113
 func New(agent Agent) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:77
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:114
 	return Request{agent: agent}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:80
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:117
 func Text(req Request, text string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:81
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:118
 	req.text = text
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:82
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:119
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:85
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:122
 func Context(req Request, id string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:86
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:123
 	req.contextID = id
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:124
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:90
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:127
 func OnText(req Request, handler TextHandler) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:128
 	req.onText = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:129
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:95
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:132
 func OnStatus(req Request, handler StatusHandler) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:96
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:133
 	req.onStatus = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:97
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:134
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:137
+func OnArtifact(req Request, handler ArtifactHandler) Request {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:138
+	req.onArtifact = handler
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:139
+	return req
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:144
 func Retry(req Request, maxAttempts int, delayMs int) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:103
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:145
 	req.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:104
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:146
 	req.retryDelayMs = delayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:105
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:147
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:108
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:150
 func Close(agent Agent) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:109
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:151
 	return agent.Client.Destroy()
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:154
 func Skills(agent Agent) []Skill {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:113
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:155
 	skills := make([]Skill, len(agent.Card.Skills))
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:114
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:156
 	for i, s := range agent.Card.Skills {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:115
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:157
 		skills[i] = Skill{Name: s.Name, Description: s.Description, Examples: s.Examples}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:158
 	return skills
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:120
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:162
 func Send(req Request) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:121
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:163
 	if req.retryMaxAttempts <= 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:122
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:164
 		return sendRequest(req.agent, req.text, req.contextID)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:166
 	delayMs := req.retryDelayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:125
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:167
 	if delayMs <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:126
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:168
 		delayMs = 1000
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:127
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:169
 	cfg := retry.Config{MaxAttempts: req.retryMaxAttempts, InitialDelay: delayMs, Strategy: 1}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:128
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:170
 	lastErr := errors.New("no attempts made")
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:129
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:171
 	for attempt := range cfg.MaxAttempts {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:172
 		task, err := sendRequest(req.agent, req.text, req.contextID)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:131
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:173
 		if err == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:132
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:174
 			return task, nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:133
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:175
 		lastErr = err
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:134
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:176
 		retry.Sleep(cfg, attempt)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:135
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:177
 	var _zero0 Task
 	return _zero0, lastErr
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:180
 func Stream(req Request) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:139
-	return streamRequest(req.agent, req.text, req.contextID, req.onText, req.onStatus)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:181
+	return streamRequest(req)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:142
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:184
 func Ask(agent Agent, text string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:143
-	task, err_5 := sendRequest(agent, text, "")
-	if err_5 != nil {
-		err_5 = fmt.Errorf("a2a ask: %w", err_5)
-		return "", err_5
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:185
+	task, err_1 := sendRequest(agent, text, "")
+	if err_1 != nil {
+		err_1 = fmt.Errorf("a2a ask: %w", err_1)
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:144
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:186
 	return task.Text, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:147
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:189
 func GetTask(agent Agent, taskID string) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:148
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:190
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:149
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:191
 	params := a2a.TaskQueryParams{ID: a2a.TaskID(taskID)}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:150
-	t, err_6 := agent.Client.GetTask(ctxpkg.Value(bg), &params)
-	if err_6 != nil {
-		err_6 = fmt.Errorf("a2a get task: %w", err_6)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:192
+	t, err_1 := agent.Client.GetTask(ctxpkg.Value(bg), &params)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("a2a get task: %w", err_1)
 		var _zero0 Task
-		return _zero0, err_6
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:151
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:193
 	return taskFromA2A(t), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:154
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:196
 func Cancel(agent Agent, taskID string) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:155
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:197
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:156
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:198
 	params := a2a.TaskIDParams{ID: a2a.TaskID(taskID)}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:157
-	t, err_7 := agent.Client.CancelTask(ctxpkg.Value(bg), &params)
-	if err_7 != nil {
-		err_7 = fmt.Errorf("a2a cancel: %w", err_7)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:199
+	t, err_1 := agent.Client.CancelTask(ctxpkg.Value(bg), &params)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("a2a cancel: %w", err_1)
 		var _zero0 Task
-		return _zero0, err_7
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:158
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:200
 	return taskFromA2A(t), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:161
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:209
+func NewServer(name string, description string) *Server {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:210
+	return &Server{Card: &a2a.AgentCard{Name: name, Description: description, Version: "1.0.0", ProtocolVersion: string(a2a.Version), PreferredTransport: a2a.TransportProtocolJSONRPC, DefaultInputModes: []string{"text"}, DefaultOutputModes: []string{"text"}, Capabilities: a2a.AgentCapabilities{Streaming: true}}}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:222
+func AddSkill(server *Server, name string, description string, examples []string) *Server {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:223
+	server.Card.Skills = append(server.Card.Skills, a2a.AgentSkill{ID: name, Name: name, Description: description, Examples: examples})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:229
+	return server
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:236
+func Handle(server *Server, handler TaskHandler) *Server {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:237
+	server.handler = handler
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:238
+	return server
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:243
+func Handler(server *Server) http.Handler {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:244
+	requestHandler := a2asrv.NewHandler(&executor{server: server})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:245
+	mux := http.NewServeMux()
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:246
+	mux.Handle("/", a2asrv.NewJSONRPCHandler(requestHandler))
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:247
+	mux.Handle(a2asrv.WellKnownAgentCardPath, a2asrv.NewStaticAgentCardHandler(server.Card))
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:248
+	return mux
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:252
+func Serve(server *Server, addr string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:253
+	if server.Card.URL == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:254
+		host := addr
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:255
+		if kukistring.HasPrefix(addr, ":") {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:256
+			host = fmt.Sprintf("localhost%v", addr)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:257
+		server.Card.URL = fmt.Sprintf("http://%v", host)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:258
+	return http.ListenAndServe(addr, Handler(server))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:261
+func (t *TaskContext) Status(message string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:262
+	return t.write(a2a.NewStatusUpdateEvent(t.reqCtx, a2a.TaskStateWorking, agentMessage(message)))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:269
+func (t *TaskContext) Artifact(name string, text string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:270
+	part := a2a.TextPart{Text: text}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:271
+	id, exists := t.artifacts[name]
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:272
+	if exists {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:273
+		return t.write(a2a.NewArtifactUpdateEvent(t.reqCtx, id, part))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:274
+	event := a2a.NewArtifactEvent(t.reqCtx, part)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:275
+	event.Artifact.Name = name
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:276
+	t.artifacts[name] = event.Artifact.ID
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:277
+	return t.write(event)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:280
+func (t *TaskContext) Complete(message string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:281
+	return t.finish(a2a.TaskStateCompleted, message)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:284
+func (t *TaskContext) Fail(message string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:285
+	return t.finish(a2a.TaskStateFailed, message)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:288
+func (t *TaskContext) finish(state a2a.TaskState, message string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:289
+	if t.finished {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:290
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:291
+	t.finished = true
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:292
+	event := a2a.NewStatusUpdateEvent(t.reqCtx, state, agentMessage(message))
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:293
+	event.Final = true
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:294
+	return t.write(event)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:297
+func (t *TaskContext) write(event a2a.Event) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:298
+	return t.queue.Write(t.ctx, event)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:301
+func (e *executor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:302
+	task := &TaskContext{ID: string(reqCtx.TaskID), ContextID: reqCtx.ContextID, ctx: ctx, reqCtx: reqCtx, queue: queue, artifacts: make(map[string]a2a.ArtifactID)}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:310
+	if reqCtx.Message != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:311
+		task.Text = extractPartsText(reqCtx.Message.Parts)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:312
+	if reqCtx.StoredTask == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:313
+		err_1 := task.write(a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateSubmitted, nil))
+		if err_1 != nil {
+			return err_1
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:314
+	err_2 := task.write(a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateWorking, nil))
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:315
+	if e.server.handler == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:316
+		return task.Fail("agent has no task handler")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:317
+	handlerErr := e.server.handler(task)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:318
+	if handlerErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:319
+		return task.Fail(handlerErr.Error())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:320
+	return task.Complete("")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:323
+func (e *executor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:324
+	event := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateCanceled, nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:325
+	event.Final = true
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:326
+	return queue.Write(ctx, event)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:329
+func agentMessage(text string) *a2a.Message {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:330
+	if text == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:331
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:332
+	return a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: text})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:335
 func sendRequest(agent Agent, text string, contextID string) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:162
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:336
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:337
 	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: text})
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:164
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:338
 	if contextID != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:165
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:339
 		msg.ContextID = contextID
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:340
 	params := a2a.MessageSendParams{Message: msg}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:167
-	resp, err_8 := agent.Client.SendMessage(ctxpkg.Value(bg), &params)
-	if err_8 != nil {
-		err_8 = fmt.Errorf("a2a send: %w", err_8)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:341
+	resp, err_1 := agent.Client.SendMessage(ctxpkg.Value(bg), &params)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("a2a send: %w", err_1)
 		var _zero0 Task
-		return _zero0, err_8
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:168
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:342
 	return resultToTask(resp), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:171
-func streamRequest(agent Agent, text string, contextID string, onText TextHandler, onStatus StatusHandler) (Task, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:172
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:345
+func streamRequest(req Request) (Task, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:346
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:173
-	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: text})
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:174
-	if contextID != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:175
-		msg.ContextID = contextID
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:347
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: req.text})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:348
+	if req.contextID != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:349
+		msg.ContextID = req.contextID
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:177
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:351
 	result := *new(Task)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:178
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:352
 	params := a2a.MessageSendParams{Message: msg}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:179
-	for event, err := range agent.Client.SendStreamingMessage(ctxpkg.Value(bg), &params) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:180
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:353
+	for event, err := range req.agent.Client.SendStreamingMessage(ctxpkg.Value(bg), &params) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:354
 		if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:355
 			return result, fmt.Errorf("a2a stream: %v", err)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:182
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:356
 		switch e := event.(type) {
 		case *a2a.TaskStatusUpdateEvent:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:184
-			if onStatus != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:185
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:358
+			if req.onStatus != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:359
 				statusMsg := ""
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:360
 				if e.Status.Message != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:187
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:361
 					statusMsg = extractPartsText(e.Status.Message.Parts)
 				}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:188
-				onStatus(StatusUpdate{TaskID: string(e.TaskID), State: string(e.Status.State), Message: statusMsg, Final: e.Final})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:362
+				req.onStatus(StatusUpdate{TaskID: string(e.TaskID), State: string(e.Status.State), Message: statusMsg, Final: e.Final})
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:363
 			result.ID = string(e.TaskID)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:190
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:364
 			result.ContextID = e.ContextID
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:191
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:365
 			result.State = string(e.Status.State)
 		case *a2a.TaskArtifactUpdateEvent:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:193
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:367
 			if e.Artifact != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:194
-				artText := extractPartsText(e.Artifact.Parts)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:195
-				if (onText != nil) && (artText != "") {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:196
-					onText(artText)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:368
+				chunk := Artifact{ID: string(e.Artifact.ID), Name: e.Artifact.Name, Text: extractPartsText(e.Artifact.Parts)}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:369
+				result.Artifacts = addArtifactChunk(result.Artifacts, &chunk, e.Append)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:370
+				if req.onText != nil && chunk.Text != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:371
+					req.onText(chunk.Text)
+				}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:372
+				if req.onArtifact != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:373
+					req.onArtifact(chunk)
 				}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:197
-				result.Artifacts = append(result.Artifacts, Artifact{Name: e.Artifact.Name, Text: artText})
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:198
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:374
 			result.ID = string(e.TaskID)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:375
 			result.ContextID = e.ContextID
 		case *a2a.Task:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:201
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:377
 			result = taskFromA2A(e)
 		case *a2a.Message:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:203
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:379
 			msgText := extractPartsText(e.Parts)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:204
-			if (onText != nil) && (msgText != "") {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:205
-				onText(msgText)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:380
+			if req.onText != nil && msgText != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:381
+				req.onText(msgText)
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:206
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:382
 			result.Text = msgText
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:207
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:383
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:210
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:388
+func addArtifactChunk(artifacts []Artifact, chunk *Artifact, isAppend bool) []Artifact {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:389
+	if isAppend {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:390
+		for i, a := range artifacts {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:391
+			if a.ID == chunk.ID {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:392
+				artifacts[i].Text = a.Text + chunk.Text
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:393
+				chunk.Name = a.Name
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:394
+				return artifacts
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:395
+	return append(artifacts, *chunk)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:398
 func resultToTask(result a2a.SendMessageResult) Task {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:211
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:399
 	task := func() Task {
 		switch r := result.(type) {
 		case *a2a.Task:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:213
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:401
 			return taskFromA2A(r)
 		case *a2a.Message:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:215
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:403
 			return Task{ID: string(r.TaskID), ContextID: r.ContextID, Text: extractPartsText(r.Parts)}
 		default:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:217
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:405
 			var _zero0 Task
 			return _zero0
 		}
 	}()
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:218
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:406
 	return task
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:221
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:409
 func taskFromA2A(t *a2a.Task) Task {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:222
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:410
 	result := Task{ID: string(t.ID), ContextID: t.ContextID, State: string(t.Status.State)}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:224
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:412
 	for _, art := range t.Artifacts {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:225
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:413
 		artText := extractPartsText(art.Parts)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:226
-		result.Artifacts = append(result.Artifacts, Artifact{Name: art.Name, Text: artText})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:414
+		result.Artifacts = append(result.Artifacts, Artifact{ID: string(art.ID), Name: art.Name, Text: artText})
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:228
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:416
 	texts := []string{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:229
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:417
 	if t.Status.Message != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:230
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:418
 		msg := extractPartsText(t.Status.Message.Parts)
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:231
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:419
 		if msg != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:232
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:420
 			texts = append(texts, msg)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:233
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:421
 	for _, art := range result.Artifacts {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:234
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:422
 		if art.Text != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:235
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:423
 			texts = append(texts, art.Text)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:236
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:424
 	result.Text = kukistring.Join(texts, "\n")
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:238
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:426
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:241
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:429
 func extractPartsText(parts []a2a.Part) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:242
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:430
 	texts := []string{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:243
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:431
 	for _, p := range parts {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:244
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:432
 		switch tp := p.(type) {
 		case a2a.TextPart:
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:246
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:434
 			texts = append(texts, tp.Text)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:247
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a.kuki:435
 	return kukistring.Join(texts, "")
 }
@@ -3,60 +3,199 @@
 package a2a_test
 
 import (
+	"errors"
 	"github.com/duber000/kukicha/stdlib/a2a"
+	"net/http/httptest"
 	"testing"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:9
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:10
 func TestBasicTypes(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:11
-	agent := a2a.Agent{}
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:12
-	request := a2a.Request{}
+	agent := a2a.Agent{}
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:13
+	request := a2a.Request{}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:14
 	task := a2a.Task{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:17
-	_ = agent
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:18
-	_ = request
+	_ = agent
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:19
+	_ = request
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:20
 	_ = task
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:22
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:23
 func TestRequestBuilder(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:24
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:25
 	agent := a2a.Agent{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:27
-	req := a2a.New(agent)
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:28
-	req = a2a.Text(req, "Hello")
+	req := a2a.New(agent)
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:29
-	req = a2a.Context(req, "context123")
+	req = a2a.Text(req, "Hello")
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:30
+	req = a2a.Context(req, "context123")
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:31
 	req = a2a.Retry(req, 3, 100)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:36
 func TestSkillsFunction(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:39
 	agent := a2a.Agent{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:42
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:43
 	_ = agent
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:45
-func TestCloseFunction(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:46
+func TestCloseFunction(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:47
 	agent := a2a.Agent{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:49
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:50
 	_ = agent
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:52
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:53
 func TestTaskCreation(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:54
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:55
 	emptyTask := a2a.Task{}
-//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:56
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:57
 	_ = emptyTask
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:60
+func TestServerTaskLifecycle(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:61
+	server := a2a.AddSkill(a2a.NewServer("echo", "Echoes the message"), "echo", "Repeat the message", nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:62
+	server = a2a.Handle(server, func(task *a2a.TaskContext) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:63
+		err_1 := task.Status("echoing")
+		if err_1 != nil {
+			return err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:64
+		err_2 := task.Artifact("echo", "you said: ")
+		if err_2 != nil {
+			return err_2
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:65
+		err_3 := task.Artifact("echo", task.Text)
+		if err_3 != nil {
+			return err_3
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:66
+		return nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:68
+	ts := httptest.NewServer(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:69
+	defer ts.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:70
+	server.Card.URL = ts.URL
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:71
+	ts.Config.Handler = a2a.Handler(server)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:73
+	agent, err_1 := a2a.Discover(ts.URL)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:74
+		t.Fatalf("discover failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:75
+	defer a2a.Close(agent)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:76
+	skills := a2a.Skills(agent)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:77
+	if len(skills) != 1 || skills[0].Name != "echo" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:78
+		t.Errorf("skills = %v, want one skill named echo", skills)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:80
+	states := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:81
+	chunks := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:82
+	req := a2a.Text(a2a.New(agent), "hi")
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:83
+	req = a2a.OnStatus(req, func(u a2a.StatusUpdate) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:84
+		states = append(states, u.State)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:86
+	req = a2a.OnArtifact(req, func(a a2a.Artifact) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:87
+		chunks = chunks + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:88
+		if a.Name != "echo" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:89
+			t.Errorf("artifact name = %q, want %q", a.Name, "echo")
+		}
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:91
+	task, err_2 := a2a.Stream(req)
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:92
+		t.Fatalf("stream failed: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:93
+	if task.State != "completed" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:94
+		t.Errorf("state = %q, want completed", task.State)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:95
+	if chunks != 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:96
+		t.Errorf("artifact chunks = %d, want 2", chunks)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:97
+	if len(task.Artifacts) != 1 || task.Artifacts[0].Text != "you said: hi" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:98
+		t.Errorf("artifacts = %v, want one artifact %q", task.Artifacts, "you said: hi")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:99
+	if len(states) == 0 || states[len(states)-1] != "completed" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:100
+		t.Errorf("status updates = %v, want them to end with completed", states)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:103
+func TestServerTaskFailure(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:104
+	server := a2a.Handle(a2a.NewServer("broken", "Always fails"), func(task *a2a.TaskContext) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:105
+		return errors.New("out of coffee")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:107
+	ts := httptest.NewServer(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:108
+	defer ts.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:109
+	server.Card.URL = ts.URL
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:110
+	ts.Config.Handler = a2a.Handler(server)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:112
+	agent, err_1 := a2a.Discover(ts.URL)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:113
+		t.Fatalf("discover failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:114
+	defer a2a.Close(agent)
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:115
+	task, err_2 := a2a.Send(a2a.Text(a2a.New(agent), "hi"))
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:116
+		t.Fatalf("send failed: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:117
+	if task.State != "failed" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:118
+		t.Errorf("state = %q, want failed", task.State)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:119
+	if task.Text != "out of coffee" {
+//line /Users/tluker/repos/go/kukicha/stdlib/a2a/a2a_test.kuki:120
+		t.Errorf("text = %q, want %q", task.Text, "out of coffee")
+	}
+}
@@ -0,0 +1,577 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:30
+type EntryError struct {
+	Op      string
+	Archive string
+	Entry   string
+	Err     error
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:37
+func (e *EntryError) Error() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:38
+	return fmt.Sprintf("archive %v %v: entry %v: %v", e.Op, e.Archive, e.Entry, e.Err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:41
+func (e *EntryError) Unwrap() error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:42
+	return e.Err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:45
+type source struct {
+	name string
+	path string
+	info fs.FileInfo
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:56
+func Create(dest string, files []string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:57
+	format := formatOf(dest)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:58
+	if format == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:59
+		return fmt.Errorf("archive.Create %v: unknown archive type (want .zip, .tar.gz, .tgz or .tar)", dest)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:60
+	sources, err_1 := collect(dest, files)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:61
+	out, err_2 := os.Create(dest)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:62
+	err := writeArchive(out, format, dest, sources)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:63
+	closeErr := out.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:64
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:65
+		_ = os.Remove(dest)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:66
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:67
+	return closeErr
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:74
+func Extract(path string, dest string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:75
+	format := formatOf(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:76
+	if format == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:77
+		return fmt.Errorf("archive.Extract %v: unknown archive type (want .zip, .tar.gz, .tgz or .tar)", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:78
+	if format == "zip" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:79
+		return extractZip(path, dest)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:80
+	f, err_1 := os.Open(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:81
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:82
+	return extractTar(f, format == "tar.gz", path, dest)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:88
+func ExtractStream(r io.Reader, dest string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:89
+	return extractTar(r, true, "stream", dest)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:94
+func List(path string) ([]string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:95
+	format := formatOf(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:96
+	if format == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:97
+		return nil, fmt.Errorf("archive.List %v: unknown archive type (want .zip, .tar.gz, .tgz or .tar)", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:98
+	names := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:99
+	if format == "zip" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:100
+		zr, err_1 := zip.OpenReader(path)
+		if err_1 != nil {
+			return []string{}, err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:101
+		defer zr.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:102
+		for _, f := range zr.File {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:103
+			names = append(names, f.Name)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:104
+		return names, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:105
+	f, err_2 := os.Open(path)
+	if err_2 != nil {
+		return []string{}, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:106
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:107
+	tr, err_3 := tarReader(f, format == "tar.gz")
+	if err_3 != nil {
+		return []string{}, err_3
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:108
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:109
+		hdr, err := tr.Next()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:110
+		if err == io.EOF {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:111
+			return names, nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:112
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:113
+			return nil, fmt.Errorf("archive list %s: %w", path, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:114
+		if hdr.Typeflag != tar.TypeXGlobalHeader {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:115
+			names = append(names, hdr.Name)
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:118
+func formatOf(path string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:119
+	lower := strings.ToLower(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:120
+	if strings.HasSuffix(lower, ".zip") {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:121
+		return "zip"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:122
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:123
+		return "tar.gz"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:124
+	if strings.HasSuffix(lower, ".tar") {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:125
+		return "tar"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:126
+	return ""
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:129
+func collect(dest string, files []string) ([]source, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:130
+	sources := []source{}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:131
+	for _, file := range files {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:132
+		clean := filepath.Clean(file)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:133
+		parent := filepath.Dir(clean)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:134
+		err_1 := filepath.WalkDir(clean, func(path string, d fs.DirEntry, err error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:135
+			if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:136
+				return err
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:137
+			rel, err_1 := filepath.Rel(parent, path)
+			if err_1 != nil {
+				return err_1
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:138
+			if rel == "." {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:139
+				return nil
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:140
+			name := filepath.ToSlash(rel)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:141
+			info, err_2 := d.Info()
+			if err_2 != nil {
+				return err_2
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:142
+			if !info.IsDir() && !info.Mode().IsRegular() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:143
+				return &EntryError{Op: "create", Archive: dest, Entry: name, Err: errors.New("links and special files are not supported")}
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:144
+			sources = append(sources, source{name: name, path: path, info: info})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:145
+			return nil
+		})
+		if err_1 != nil {
+			return []source{}, err_1
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:147
+	return sources, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:150
+func writeArchive(out io.Writer, format string, dest string, sources []source) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:151
+	if format == "zip" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:152
+		zw := zip.NewWriter(out)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:153
+		for _, src := range sources {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:154
+			err := addZip(zw, src)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:155
+			if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:156
+				return &EntryError{Op: "create", Archive: dest, Entry: src.name, Err: err}
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:157
+		return zw.Close()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:158
+	if format == "tar" {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:159
+		return writeTar(out, dest, sources)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:160
+	gz := gzip.NewWriter(out)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:161
+	err := writeTar(gz, dest, sources)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:162
+	closeErr := gz.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:163
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:164
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:165
+	return closeErr
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:168
+func writeTar(w io.Writer, dest string, sources []source) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:169
+	tw := tar.NewWriter(w)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:170
+	for _, src := range sources {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:171
+		err := addTar(tw, src)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:172
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:173
+			return &EntryError{Op: "create", Archive: dest, Entry: src.name, Err: err}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:174
+	return tw.Close()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:177
+func addZip(zw *zip.Writer, src source) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:178
+	hdr, err_1 := zip.FileInfoHeader(src.info)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:179
+	hdr.Name = src.name
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:180
+	if src.info.IsDir() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:181
+		hdr.Name = src.name + "/"
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:182
+		_, err := zw.CreateHeader(hdr)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:183
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:184
+	hdr.Method = zip.Deflate
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:185
+	w, err_2 := zw.CreateHeader(hdr)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:186
+	return copyFrom(w, src.path)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:189
+func addTar(tw *tar.Writer, src source) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:190
+	hdr, err_1 := tar.FileInfoHeader(src.info, "")
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:191
+	hdr.Name = src.name
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:192
+	if src.info.IsDir() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:193
+		hdr.Name = src.name + "/"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:194
+	err_2 := tw.WriteHeader(hdr)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:195
+	if src.info.IsDir() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:196
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:197
+	return copyFrom(tw, src.path)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:200
+func copyFrom(w io.Writer, path string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:201
+	f, err_1 := os.Open(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:202
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:203
+	_, err := io.Copy(w, f)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:204
+	return err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:207
+func extractZip(path string, dest string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:208
+	zr, err_1 := zip.OpenReader(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:209
+	defer zr.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:210
+	root, err_2 := openDest(dest)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:211
+	defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:212
+	for _, f := range zr.File {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:213
+		err := extractZipEntry(root, f)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:214
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:215
+			return &EntryError{Op: "extract", Archive: path, Entry: f.Name, Err: err}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:216
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:219
+func extractZipEntry(root *os.Root, f *zip.File) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:220
+	err_1 := checkName(f.Name)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:221
+	mode := f.Mode()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:222
+	if mode.IsDir() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:223
+		return root.MkdirAll(filepath.FromSlash(f.Name), 0755)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:224
+	if !mode.IsRegular() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:225
+		return errors.New("links and special files are not extracted")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:226
+	rc, err_2 := f.Open()
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:227
+	defer rc.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:228
+	return writeEntry(root, f.Name, rc, mode)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:231
+func extractTar(r io.Reader, gzipped bool, name string, dest string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:232
+	tr, err_1 := tarReader(r, gzipped)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:233
+	root, err_2 := openDest(dest)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:234
+	defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:235
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:236
+		hdr, err := tr.Next()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:237
+		if err == io.EOF {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:238
+			return nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:239
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:240
+			return fmt.Errorf("archive extract %s: %w", name, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:241
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:242
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:243
+		entryErr := extractTarEntry(root, hdr, tr)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:244
+		if entryErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:245
+			return &EntryError{Op: "extract", Archive: name, Entry: hdr.Name, Err: entryErr}
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:248
+func extractTarEntry(root *os.Root, hdr *tar.Header, r io.Reader) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:249
+	err_1 := checkName(hdr.Name)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:250
+	mode := hdr.FileInfo().Mode()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:251
+	if mode.IsDir() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:252
+		return root.MkdirAll(filepath.FromSlash(hdr.Name), 0755)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:253
+	if !mode.IsRegular() {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:254
+		return errors.New("links and special files are not extracted")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:255
+	return writeEntry(root, hdr.Name, r, mode)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:258
+func tarReader(r io.Reader, gzipped bool) (*tar.Reader, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:259
+	if !gzipped {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:260
+		return tar.NewReader(r), nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:261
+	gz, err_1 := gzip.NewReader(r)
+	if err_1 != nil {
+		return nil, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:262
+	return tar.NewReader(gz), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:265
+func openDest(dest string) (*os.Root, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:266
+	err_1 := os.MkdirAll(dest, 0755)
+	if err_1 != nil {
+		return nil, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:267
+	return os.OpenRoot(dest)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:270
+func checkName(name string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:271
+	if !filepath.IsLocal(filepath.FromSlash(strings.TrimSuffix(name, "/"))) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:272
+		return errors.New("path escapes the destination")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:273
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:276
+func writeEntry(root *os.Root, name string, r io.Reader, mode fs.FileMode) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:277
+	path := filepath.FromSlash(name)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:278
+	dir := filepath.Dir(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:279
+	if dir != "." {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:280
+		err_1 := root.MkdirAll(dir, 0755)
+		if err_1 != nil {
+			return err_1
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:281
+	perm := mode.Perm()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:282
+	if perm == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:283
+		perm = 0644
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:284
+	f, err_2 := root.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:285
+	_, err := io.Copy(f, r)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:286
+	closeErr := f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:287
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:288
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive.kuki:289
+	return closeErr
+}
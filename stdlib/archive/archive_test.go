@@ -0,0 +1,248 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/archive"
+	"github.com/duber000/kukicha/stdlib/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:16
+type FormatCase struct {
+	name string
+	file string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:21
+func TestRoundTrip(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:22
+	cases := []FormatCase{FormatCase{name: "zip", file: "site.zip"}, FormatCase{name: "tar.gz", file: "site.tar.gz"}, FormatCase{name: "tgz", file: "site.TGZ"}, FormatCase{name: "tar", file: "site.tar"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:28
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:29
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:30
+			dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:31
+			src := makeTree(t, dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:32
+			out := filepath.Join(dir, tc.file)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:33
+			err_1 := archive.Create(out, []string{src})
+			if err_1 != nil {
+				panic(fmt.Sprintf("create: %v", err_1))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:35
+			names, err_2 := archive.List(out)
+			if err_2 != nil {
+				panic(fmt.Sprintf("list: %v", err_2))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:36
+			test.AssertEqual(t, names, []string{"site/", "site/css/", "site/css/main.css", "site/index.html"})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:38
+			dest := filepath.Join(dir, "restored")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:39
+			err_3 := archive.Extract(out, dest)
+			if err_3 != nil {
+				panic(fmt.Sprintf("extract: %v", err_3))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:40
+			test.AssertEqual(t, readFile(t, filepath.Join(dest, "site", "css", "main.css")), "body {}")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:41
+			test.AssertEqual(t, readFile(t, filepath.Join(dest, "site", "index.html")), "<h1>hi</h1>")
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:45
+func TestCreateContentsOfDot(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:46
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:47
+	src := makeTree(t, dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:48
+	out := filepath.Join(dir, "flat.zip")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:49
+	t.Chdir(src)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:50
+	err_1 := archive.Create(out, []string{"."})
+	if err_1 != nil {
+		panic(fmt.Sprintf("create: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:51
+	names, err_2 := archive.List(out)
+	if err_2 != nil {
+		panic(fmt.Sprintf("list: %v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:52
+	test.AssertEqual(t, names, []string{"css/", "css/main.css", "index.html"})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:55
+func TestExtractStream(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:56
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:57
+	src := makeTree(t, dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:58
+	out := filepath.Join(dir, "site.tgz")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:59
+	err_1 := archive.Create(out, []string{src})
+	if err_1 != nil {
+		panic(fmt.Sprintf("create: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:60
+	data, err_2 := os.ReadFile(out)
+	if err_2 != nil {
+		panic(fmt.Sprintf("read: %v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:61
+	dest := filepath.Join(dir, "streamed")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:62
+	err_3 := archive.ExtractStream(bytes.NewReader(data), dest)
+	if err_3 != nil {
+		panic(fmt.Sprintf("extract: %v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:63
+	test.AssertEqual(t, readFile(t, filepath.Join(dest, "site", "index.html")), "<h1>hi</h1>")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:66
+func TestExtractRefusesEscapes(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:67
+	for _, name := range []string{"../evil.txt", "a/../../evil.txt", "/abs/evil.txt"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:68
+		t.Run(name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:69
+			dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:70
+			bad := filepath.Join(dir, "bad.zip")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:71
+			writeZip(t, bad, name)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:72
+			err := archive.Extract(bad, filepath.Join(dir, "out"))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:73
+			test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:74
+			entryErr := &archive.EntryError{}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:75
+			test.AssertTrue(t, errors.As(err, &entryErr))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:76
+			test.AssertEqual(t, entryErr.Entry, name)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:77
+			test.AssertTrue(t, strings.Contains(err.Error(), "path escapes the destination"))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:78
+			test.AssertFalse(t, exists(filepath.Join(dir, "evil.txt")))
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:82
+func TestErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:83
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:84
+	t.Run("unknown extension", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:85
+		err := archive.Create(filepath.Join(dir, "out.rar"), []string{dir})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:86
+		test.AssertTrue(t, strings.Contains(err.Error(), "unknown archive type"))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:87
+		test.AssertError(t, archive.Extract(filepath.Join(dir, "in.7z"), dir))
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:89
+	t.Run("missing input file", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:90
+		err := archive.Create(filepath.Join(dir, "out.zip"), []string{filepath.Join(dir, "nope")})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:91
+		test.AssertTrue(t, errors.Is(err, os.ErrNotExist))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:92
+		test.AssertFalse(t, exists(filepath.Join(dir, "out.zip")))
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:94
+	t.Run("links are refused", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:95
+		src := makeTree(t, dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:96
+		err_1 := os.Symlink("index.html", filepath.Join(src, "link.html"))
+		if err_1 != nil {
+			panic(fmt.Sprintf("symlink: %v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:97
+		err := archive.Create(filepath.Join(dir, "links.tar"), []string{src})
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:98
+		test.AssertTrue(t, strings.Contains(err.Error(), "entry site/link.html"))
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:102
+func makeTree(t *testing.T, dir string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:103
+	src := filepath.Join(dir, "site")
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:104
+	err_1 := os.MkdirAll(filepath.Join(src, "css"), 0755)
+	if err_1 != nil {
+		panic(fmt.Sprintf("mkdir: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:105
+	err_2 := os.WriteFile(filepath.Join(src, "index.html"), []byte("<h1>hi</h1>"), 0644)
+	if err_2 != nil {
+		panic(fmt.Sprintf("write: %v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:106
+	err_3 := os.WriteFile(filepath.Join(src, "css", "main.css"), []byte("body {}"), 0644)
+	if err_3 != nil {
+		panic(fmt.Sprintf("write: %v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:107
+	return src
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:110
+func writeZip(t *testing.T, path string, name string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:111
+	f, err_1 := os.Create(path)
+	if err_1 != nil {
+		panic(fmt.Sprintf("create: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:112
+	zw := zip.NewWriter(f)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:113
+	w, err_2 := zw.Create(name)
+	if err_2 != nil {
+		panic(fmt.Sprintf("entry: %v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:114
+	w.Write([]byte("pwned"))
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:115
+	zw.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:116
+	f.Close()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:119
+func readFile(t *testing.T, path string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:120
+	data, err_1 := os.ReadFile(path)
+	if err_1 != nil {
+		panic(fmt.Sprintf("read: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:121
+	return string(data)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:124
+func exists(path string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:125
+	_, err := os.Stat(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/archive/archive_test.kuki:126
+	return err == nil
+}
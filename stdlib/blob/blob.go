@@ -0,0 +1,909 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package blob
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/env"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:54
+var ErrNotFound = errors.New("not found")
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:57
+type Store struct {
+	bucket    string
+	dir       string
+	scheme    string
+	host      string
+	basePath  string
+	region    string
+	accessKey string
+	secretKey string
+	token     string
+	client    *http.Client
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:70
+type Config struct {
+	bucket    string
+	endpoint  string
+	region    string
+	accessKey string
+	secretKey string
+	token     string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:79
+type Object struct {
+	Key      string
+	Size     int64
+	Modified time.Time
+	ETag     string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:86
+type listResult struct {
+	Contents              []listEntry `xml:"Contents"`
+	IsTruncated           bool        `xml:"IsTruncated"`
+	NextContinuationToken string      `xml:"NextContinuationToken"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:91
+type listEntry struct {
+	Key          string    `xml:"Key"`
+	Size         int64     `xml:"Size"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:98
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:103
+const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:106
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:109
+const maxPresign = 7 * 24 * time.Hour
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:116
+func Connect(bucket string) (Store, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:117
+	return Open(New(bucket))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:120
+func New(bucket string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:121
+	return Config{bucket: bucket}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:125
+func Endpoint(cfg Config, endpoint string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:126
+	cfg.endpoint = endpoint
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:127
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:130
+func Region(cfg Config, region string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:131
+	cfg.region = region
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:132
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:136
+func Credentials(cfg Config, accessKey string, secretKey string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:137
+	cfg.accessKey = accessKey
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:138
+	cfg.secretKey = secretKey
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:139
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:142
+func SessionToken(cfg Config, token string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:143
+	cfg.token = token
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:144
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:148
+func Open(cfg Config) (Store, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:149
+	if cfg.bucket == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:150
+		return Store{}, errors.New("blob: no bucket name")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:151
+	region := firstOf(cfg.region, env.GetOr("AWS_REGION", ""), env.GetOr("AWS_DEFAULT_REGION", ""), "us-east-1")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:152
+	s := Store{bucket: cfg.bucket, region: region, client: http.DefaultClient}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:153
+	s.accessKey = firstOf(cfg.accessKey, env.GetOr("AWS_ACCESS_KEY_ID", ""))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:154
+	s.secretKey = firstOf(cfg.secretKey, env.GetOr("AWS_SECRET_ACCESS_KEY", ""))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:155
+	s.token = firstOf(cfg.token, env.GetOr("AWS_SESSION_TOKEN", ""))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:156
+	if s.accessKey == "" || s.secretKey == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:157
+		return Store{}, errors.New("blob: no credentials; set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or use blob.Credentials")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:159
+	endpoint := firstOf(cfg.endpoint, env.GetOr("AWS_ENDPOINT_URL_S3", ""), env.GetOr("AWS_ENDPOINT_URL", ""))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:160
+	if endpoint == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:162
+		s.scheme = "https"
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:163
+		s.host = fmt.Sprintf("%v.s3.%v.amazonaws.com", cfg.bucket, region)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:164
+		return s, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:165
+	u, err_1 := url.Parse(endpoint)
+	if err_1 != nil {
+		return Store{}, fmt.Errorf("blob: bad endpoint %v: %v", endpoint, err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:166
+	if u.Scheme == "" || u.Host == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:167
+		return Store{}, fmt.Errorf("blob: bad endpoint %v: want a URL such as http://localhost:9000", endpoint)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:168
+	s.scheme = u.Scheme
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:169
+	s.host = u.Host
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:170
+	s.basePath = strings.TrimSuffix(u.Path, "/") + "/" + escape(cfg.bucket, false)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:171
+	return s, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:176
+func Local(dir string) Store {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:177
+	return Store{dir: dir}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:183
+func Get(s Store, key string) ([]byte, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:184
+	body, err_1 := Stream(s, key)
+	if err_1 != nil {
+		return []byte{}, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:185
+	defer body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:186
+	data, err := io.ReadAll(body)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:187
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:188
+		return nil, fmt.Errorf("blob get %s: %w", key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:189
+	return data, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:193
+func Stream(s Store, key string) (io.ReadCloser, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:194
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:195
+		root, err_1 := openRoot(s, "get", key)
+		if err_1 != nil {
+			return nil, err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:196
+		defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:197
+		f, err := root.Open(filepath.FromSlash(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:198
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:199
+			return nil, localError("get", key, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:200
+		return f, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:201
+	resp, err_2 := request(s, "GET", key, nil, nil, 0, emptyHash)
+	if err_2 != nil {
+		return nil, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:202
+	return resp.Body, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:206
+func Download(s Store, key string, path string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:207
+	body, err_1 := Stream(s, key)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:208
+	defer body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:209
+	f, err_2 := os.Create(path)
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:210
+	_, err := io.Copy(f, body)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:211
+	closeErr := f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:212
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:213
+		return fmt.Errorf("blob download %s: %w", key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:214
+	return closeErr
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:218
+func Exists(s Store, key string) (bool, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:219
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:220
+		root, err_1 := openRoot(s, "exists", key)
+		if err_1 != nil {
+			return false, err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:221
+		defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:222
+		info, err := root.Stat(filepath.FromSlash(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:223
+		if errors.Is(err, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:224
+			return false, nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:225
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:226
+			return false, localError("exists", key, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:227
+		return !info.IsDir(), nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:228
+	resp, err := request(s, "HEAD", key, nil, nil, 0, emptyHash)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:229
+	if errors.Is(err, ErrNotFound) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:230
+		return false, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:231
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:232
+		return false, err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:233
+	resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:234
+	return true, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:238
+func List(s Store, prefix string) ([]Object, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:239
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:240
+		return listLocal(s, prefix)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:241
+	objects := []Object{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:242
+	token := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:243
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:244
+		query := map[string]string{"list-type": "2", "prefix": prefix}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:245
+		if token != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:246
+			query["continuation-token"] = token
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:247
+		resp, err_1 := request(s, "GET", "", query, nil, 0, emptyHash)
+		if err_1 != nil {
+			return []Object{}, err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:248
+		page := listResult{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:249
+		err := xml.NewDecoder(resp.Body).Decode(&page)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:250
+		resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:251
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:252
+			return nil, fmt.Errorf("blob list %s: %w", prefix, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:253
+		for _, e := range page.Contents {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:254
+			objects = append(objects, Object{Key: e.Key, Size: e.Size, Modified: e.LastModified, ETag: strings.Trim(e.ETag, "\"")})
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:255
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:256
+			return objects, nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:257
+		token = page.NextContinuationToken
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:264
+func Put(data []byte, s Store, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:265
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:266
+		return putLocal(bytes.NewReader(data), s, key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:267
+	resp, err_1 := request(s, "PUT", key, nil, bytes.NewReader(data), int64(len(data)), sha256Hex(data))
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:268
+	resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:269
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:274
+func PutFrom(r io.Reader, s Store, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:275
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:276
+		return putLocal(r, s, key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:277
+	size := -int64(1)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:278
+	seeker, ok := r.(io.Seeker)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:279
+	if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:280
+		start, err := seeker.Seek(0, io.SeekCurrent)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:281
+		end, endErr := seeker.Seek(0, io.SeekEnd)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:282
+		if err == nil && endErr == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:283
+			_, err = seeker.Seek(start, io.SeekStart)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:284
+			if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:285
+				return fmt.Errorf("blob put %s: %w", key, err)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:286
+			size = end - start
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:287
+	if size < 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:288
+		data, err := io.ReadAll(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:289
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:290
+			return fmt.Errorf("blob put %s: %w", key, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:291
+		return Put(data, s, key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:292
+	resp, err_1 := request(s, "PUT", key, nil, r, size, unsignedPayload)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:293
+	resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:294
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:298
+func Upload(path string, s Store, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:299
+	f, err_1 := os.Open(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:300
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:301
+	return PutFrom(f, s, key)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:304
+func Delete(s Store, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:305
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:306
+		root, err_1 := openRoot(s, "delete", key)
+		if err_1 != nil {
+			return err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:307
+		defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:308
+		err := root.Remove(filepath.FromSlash(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:309
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:310
+			return localError("delete", key, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:311
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:312
+	resp, err := request(s, "DELETE", key, nil, nil, 0, emptyHash)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:313
+	if errors.Is(err, ErrNotFound) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:314
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:315
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:316
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:317
+	resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:318
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:326
+func Presign(s Store, key string, expires time.Duration) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:327
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:328
+		abs, err_1 := filepath.Abs(filepath.Join(s.dir, filepath.FromSlash(key)))
+		if err_1 != nil {
+			return "", err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:329
+		u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:330
+		return u.String(), nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:331
+	return presignS3(s, "GET", key, expires)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:336
+func PresignPut(s Store, key string, expires time.Duration) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:337
+	if s.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:338
+		return "", fmt.Errorf("blob presign %v: a Local store has no upload URLs", key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:339
+	return presignS3(s, "PUT", key, expires)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:341
+func presignS3(s Store, method string, key string, expires time.Duration) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:342
+	if key == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:343
+		return "", errors.New("blob presign: empty key")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:344
+	if expires <= 0 || expires > maxPresign {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:345
+		return "", fmt.Errorf("blob presign %v: expiry must be between 1s and 7 days, got %v", key, expires)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:346
+	return presign(s, method, key, expires, time.Now().UTC()), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:353
+func request(s Store, method string, key string, query map[string]string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:354
+	op := strings.ToLower(method)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:355
+	if key == "" && len(query) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:356
+		return nil, fmt.Errorf("blob %v: empty key", op)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:357
+	if s.host == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:358
+		return nil, fmt.Errorf("blob %v %v: the Store isn't open; use blob.Connect or blob.Open", op, key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:359
+	req, err := http.NewRequest(method, objectURL(s, key), body)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:360
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:361
+		return nil, fmt.Errorf("blob %s %s: %w", op, key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:362
+	req.URL.RawQuery = canonicalQuery(query)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:363
+	if body != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:364
+		req.ContentLength = size
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:365
+		contentType := mime.TypeByExtension(path.Ext(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:366
+		if contentType != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:367
+			req.Header.Set("Content-Type", contentType)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:368
+	sign(s, req, payloadHash, time.Now().UTC())
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:369
+	resp, doErr := s.client.Do(req)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:370
+	if doErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:371
+		return nil, fmt.Errorf("blob %s %s: %w", op, key, doErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:372
+	if resp.StatusCode < 300 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:373
+		return resp, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:374
+	defer resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:375
+	if resp.StatusCode == http.StatusNotFound {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:376
+		return nil, fmt.Errorf("blob %s %s: %w", op, key, ErrNotFound)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:377
+	detail := resp.Status
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:378
+	data, readErr := io.ReadAll(io.LimitReader(resp.Body, 65536))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:379
+	failure := s3Error{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:380
+	if readErr == nil && xml.Unmarshal(data, &failure) == nil && failure.Code != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:381
+		detail = fmt.Sprintf("%v: %v (%v)", failure.Code, failure.Message, resp.Status)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:382
+	return nil, fmt.Errorf("blob %s %s: %s", op, key, detail)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:385
+func objectURL(s Store, key string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:386
+	return fmt.Sprintf("%v://%v%v/%v", s.scheme, s.host, s.basePath, escape(key, true))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:389
+func sign(s Store, req *http.Request, payloadHash string, now time.Time) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:390
+	amzDate := now.Format("20060102T150405Z")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:391
+	req.Header.Set("X-Amz-Date", amzDate)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:392
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:393
+	names := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:394
+	values := map[string]string{"host": req.URL.Host, "x-amz-content-sha256": payloadHash, "x-amz-date": amzDate}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:395
+	if s.token != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:396
+		req.Header.Set("X-Amz-Security-Token", s.token)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:397
+		names = append(names, "x-amz-security-token")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:398
+		values["x-amz-security-token"] = s.token
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:399
+	headers := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:400
+	for _, name := range names {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:401
+		headers = headers + name + ":" + values[name] + "\n"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:402
+	signed := strings.Join(names, ";")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:403
+	canonical := strings.Join([]string{req.Method, req.URL.EscapedPath(), req.URL.RawQuery, headers, signed, payloadHash}, "\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:404
+	scope := fmt.Sprintf("%v/%v/s3/aws4_request", amzDate[:8], s.region)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:405
+	signature := signature(s, amzDate, canonical)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:406
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v", s.accessKey, scope, signed, signature))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:409
+func presign(s Store, method string, key string, expires time.Duration, now time.Time) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:410
+	amzDate := now.Format("20060102T150405Z")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:411
+	scope := fmt.Sprintf("%v/%v/s3/aws4_request", amzDate[:8], s.region)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:412
+	query := map[string]string{"X-Amz-Algorithm": "AWS4-HMAC-SHA256", "X-Amz-Credential": fmt.Sprintf("%v/%v", s.accessKey, scope), "X-Amz-Date": amzDate, "X-Amz-Expires": strconv.Itoa(int(expires.Seconds())), "X-Amz-SignedHeaders": "host"}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:419
+	if s.token != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:420
+		query["X-Amz-Security-Token"] = s.token
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:421
+	path := fmt.Sprintf("%v/%v", s.basePath, escape(key, true))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:422
+	rawQuery := canonicalQuery(query)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:423
+	canonical := strings.Join([]string{method, path, rawQuery, fmt.Sprintf("host:%v\n", s.host), "host", unsignedPayload}, "\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:424
+	return fmt.Sprintf("%v://%v%v?%v&X-Amz-Signature=%v", s.scheme, s.host, path, rawQuery, signature(s, amzDate, canonical))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:427
+func signature(s Store, amzDate string, canonical string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:428
+	day := amzDate[:8]
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:429
+	scope := fmt.Sprintf("%v/%v/s3/aws4_request", day, s.region)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:430
+	toSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%v\n%v\n%v", amzDate, scope, sha256Hex([]byte(canonical)))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:431
+	key := hmacSHA256([]byte(fmt.Sprintf("AWS4%v", s.secretKey)), day)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:432
+	key = hmacSHA256(key, s.region)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:433
+	key = hmacSHA256(key, "s3")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:434
+	key = hmacSHA256(key, "aws4_request")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:435
+	return hex.EncodeToString(hmacSHA256(key, toSign))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:437
+func sha256Hex(data []byte) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:438
+	h := sha256.New()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:439
+	h.Write(data)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:440
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:442
+func hmacSHA256(key []byte, data string) []byte {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:443
+	mac := hmac.New(sha256.New, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:444
+	mac.Write([]byte(data))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:445
+	return mac.Sum(nil)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:448
+func canonicalQuery(query map[string]string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:449
+	names := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:450
+	for name := range query {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:451
+		names = append(names, name)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:452
+	slices.Sort(names)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:453
+	parts := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:454
+	for _, name := range names {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:455
+		parts = append(parts, escape(name, false)+"="+escape(query[name], false))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:456
+	return strings.Join(parts, "&")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:460
+func escape(s string, keepSlash bool) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:461
+	out := strings.Builder{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:462
+	for i := range len(s) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:463
+		c := s[i]
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:464
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' || c == '/' && keepSlash {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:465
+			out.WriteByte(c)
+		} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:467
+			fmt.Fprintf(&out, "%%%02X", c)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:468
+	return out.String()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:473
+func openRoot(s Store, op string, key string) (*os.Root, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:474
+	if key == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:475
+		return nil, fmt.Errorf("blob %v: empty key", op)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:476
+	err := os.MkdirAll(s.dir, 0755)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:477
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:478
+		return nil, fmt.Errorf("blob %s %s: %w", op, key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:479
+	root, rootErr := os.OpenRoot(s.dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:480
+	if rootErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:481
+		return nil, fmt.Errorf("blob %s %s: %w", op, key, rootErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:482
+	return root, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:484
+func localError(op string, key string, err error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:485
+	if errors.Is(err, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:486
+		return fmt.Errorf("blob %s %s: %w", op, key, ErrNotFound)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:487
+	return fmt.Errorf("blob %s %s: %w", op, key, err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:489
+func putLocal(r io.Reader, s Store, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:490
+	root, err_1 := openRoot(s, "put", key)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:491
+	defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:492
+	name := filepath.FromSlash(key)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:493
+	err := root.MkdirAll(filepath.Dir(name), 0755)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:494
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:495
+		return localError("put", key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:496
+	f, createErr := root.Create(name)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:497
+	if createErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:498
+		return localError("put", key, createErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:499
+	_, err = io.Copy(f, r)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:500
+	closeErr := f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:501
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:502
+		return localError("put", key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:503
+	if closeErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:504
+		return localError("put", key, closeErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:505
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:507
+func listLocal(s Store, prefix string) ([]Object, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:508
+	objects := []Object{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:509
+	_, statErr := os.Stat(s.dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:510
+	if errors.Is(statErr, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:511
+		return objects, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:512
+	root, err_1 := openRoot(s, "list", prefix+"*")
+	if err_1 != nil {
+		return []Object{}, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:513
+	defer root.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:514
+	walkErr := fs.WalkDir(root.FS(), ".", func(name string, d fs.DirEntry, err error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:515
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:516
+			return err
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:517
+		if d.IsDir() || !strings.HasPrefix(name, prefix) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:518
+			return nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:519
+		info, err_1 := d.Info()
+		if err_1 != nil {
+			return err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:520
+		objects = append(objects, Object{Key: name, Size: info.Size(), Modified: info.ModTime()})
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:521
+		return nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:523
+	if walkErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:524
+		return nil, fmt.Errorf("blob list %s: %w", prefix, walkErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:525
+	slices.SortFunc(objects, byKey)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:526
+	return objects, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:528
+func byKey(a Object, b Object) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:529
+	return strings.Compare(a.Key, b.Key)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:532
+func firstOf(values ...string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:533
+	for _, v := range values {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:534
+		if v != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:535
+			return v
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob.kuki:536
+	return ""
+}
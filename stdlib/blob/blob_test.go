@@ -0,0 +1,395 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package blob_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/blob"
+	"github.com/duber000/kukicha/stdlib/test"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:19
+type fakeS3 struct {
+	objects  map[string]string
+	requests []string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:23
+func (f *fakeS3) serve(w http.ResponseWriter, r *http.Request) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:24
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:25
+		w.WriteHeader(http.StatusForbidden)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:26
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:27
+	f.requests = append(f.requests, fmt.Sprintf("%v %v", r.Method, r.URL.EscapedPath()))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:28
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:29
+	switch r.Method {
+	case "PUT":
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:31
+		body, err_1 := io.ReadAll(r.Body)
+		if err_1 != nil {
+			panic(fmt.Sprintf("%v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:32
+		f.objects[key] = string(body)
+	case "DELETE":
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:34
+		delete(f.objects, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:35
+		w.WriteHeader(http.StatusNoContent)
+	case "GET", "HEAD":
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:37
+		if r.URL.Path == "/bucket/" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:38
+			f.listing(w, r)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:39
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:40
+		stored, ok := f.objects[key]
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:41
+		if !ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:42
+			w.WriteHeader(http.StatusNotFound)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:43
+			w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:44
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:45
+		w.Write([]byte(stored))
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:47
+func (f *fakeS3) listing(w http.ResponseWriter, r *http.Request) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:48
+	prefix := r.URL.Query().Get("prefix")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:49
+	keys := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:50
+	for key := range f.objects {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:51
+		if strings.HasPrefix(key, prefix) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:52
+			keys = append(keys, key)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:53
+	sort.Strings(keys)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:54
+	start := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:55
+	if r.URL.Query().Get("continuation-token") != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:56
+		start = 2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:57
+	out := "<ListBucketResult>"
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:58
+	for i, key := range keys {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:59
+		if i >= start && i < start+2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:60
+			out = out + fmt.Sprintf("<Contents><Key>%v</Key><Size>%v</Size><LastModified>2026-10-18T09:30:00.000Z</LastModified><ETag>\"abc\"</ETag></Contents>", key, len(f.objects[key]))
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:61
+	if start == 0 && len(keys) > 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:62
+		out = out + "<IsTruncated>true</IsTruncated><NextContinuationToken>page2</NextContinuationToken>"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:63
+	w.Write([]byte(out + "</ListBucketResult>"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:65
+func openFake(t *testing.T) (blob.Store, *fakeS3) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:66
+	fake := &fakeS3{objects: make(map[string]string)}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:67
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:68
+	t.Cleanup(server.Close)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:69
+	store, err_2 := blob.Open(blob.Credentials(blob.Endpoint(blob.New("bucket"), server.URL), "AKID", "secret"))
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:74
+	return store, fake
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:77
+type StoreCase struct {
+	name string
+	s3   bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:82
+func TestStores(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:83
+	cases := []StoreCase{StoreCase{name: "local", s3: false}, StoreCase{name: "s3", s3: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:87
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:88
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:89
+			store := blob.Local(filepath.Join(t.TempDir(), "bucket"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:90
+			if tc.s3 {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:91
+				store, _ = openFake(t)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:93
+			err_1 := blob.Put([]byte("id,total\n1,9.50\n"), store, "reports/2026/q3.csv")
+			if err_1 != nil {
+				panic(fmt.Sprintf("put: %v", err_1))
+			}
+			_ = []byte("id,total\n1,9.50\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:94
+			err_2 := blob.PutFrom(strings.NewReader("hello"), store, "notes/a b.txt")
+			if err_2 != nil {
+				panic(fmt.Sprintf("put from: %v", err_2))
+			}
+			_ = strings.NewReader("hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:96
+			err_3 := blob.PutFrom(io.MultiReader(strings.NewReader("x")), store, "reports/z.txt")
+			if err_3 != nil {
+				panic(fmt.Sprintf("put from: %v", err_3))
+			}
+			_ = io.MultiReader(strings.NewReader("x"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:98
+			data, err_5 := blob.Get(store, "reports/2026/q3.csv")
+			if err_5 != nil {
+				panic(fmt.Sprintf("get: %v", err_5))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:99
+			test.AssertEqual(t, string(data), "id,total\n1,9.50\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:100
+			note, err_6 := blob.Get(store, "notes/a b.txt")
+			if err_6 != nil {
+				panic(fmt.Sprintf("get: %v", err_6))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:101
+			test.AssertEqual(t, string(note), "hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:103
+			_, err := blob.Get(store, "missing.txt")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:104
+			test.AssertTrue(t, errors.Is(err, blob.ErrNotFound))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:105
+			found, err_7 := blob.Exists(store, "notes/a b.txt")
+			if err_7 != nil {
+				panic(fmt.Sprintf("exists: %v", err_7))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:106
+			test.AssertTrue(t, found)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:107
+			var err_8 error
+			found, err_8 = blob.Exists(store, "missing.txt")
+			if err_8 != nil {
+				panic(fmt.Sprintf("exists: %v", err_8))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:108
+			test.AssertFalse(t, found)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:110
+			objects, err_9 := blob.List(store, "reports/")
+			if err_9 != nil {
+				panic(fmt.Sprintf("list: %v", err_9))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:111
+			test.AssertEqual(t, len(objects), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:112
+			test.AssertEqual(t, objects[0].Key, "reports/2026/q3.csv")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:113
+			test.AssertEqual(t, objects[0].Size, int64(16))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:114
+			test.AssertEqual(t, objects[1].Key, "reports/z.txt")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:115
+			all, err_10 := blob.List(store, "")
+			if err_10 != nil {
+				panic(fmt.Sprintf("list: %v", err_10))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:116
+			test.AssertEqual(t, len(all), 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:118
+			path := filepath.Join(t.TempDir(), "q3.csv")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:119
+			err_11 := blob.Download(store, "reports/2026/q3.csv", path)
+			if err_11 != nil {
+				panic(fmt.Sprintf("download: %v", err_11))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:120
+			err_12 := blob.Upload(path, store, "copy.csv")
+			if err_12 != nil {
+				panic(fmt.Sprintf("upload: %v", err_12))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:121
+			copied, err_13 := blob.Get(store, "copy.csv")
+			if err_13 != nil {
+				panic(fmt.Sprintf("get: %v", err_13))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:122
+			test.AssertEqual(t, string(copied), "id,total\n1,9.50\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:124
+			err_14 := blob.Delete(store, "copy.csv")
+			if err_14 != nil {
+				panic(fmt.Sprintf("delete: %v", err_14))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:125
+			err_15 := blob.Delete(store, "copy.csv")
+			if err_15 != nil {
+				panic(fmt.Sprintf("delete again: %v", err_15))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:126
+			var err_16 error
+			found, err_16 = blob.Exists(store, "copy.csv")
+			if err_16 != nil {
+				panic(fmt.Sprintf("exists: %v", err_16))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:127
+			test.AssertFalse(t, found)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:131
+func TestS3Requests(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:132
+	store, fake := openFake(t)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:133
+	err_1 := blob.Put([]byte("x"), store, "a+b/c~d.txt")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+	_ = []byte("x")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:134
+	_, err := blob.Get(store, "")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:135
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:136
+	test.AssertEqual(t, fake.requests, []string{"PUT /bucket/a%2Bb/c~d.txt"})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:139
+func TestLocalEscape(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:140
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:141
+	store := blob.Local(filepath.Join(dir, "bucket"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:142
+	err := blob.Put([]byte("x"), store, "../outside.txt")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:143
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:144
+	_, statErr := os.Stat(filepath.Join(dir, "outside.txt"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:145
+	test.AssertTrue(t, errors.Is(statErr, os.ErrNotExist))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:148
+func TestPresign(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:149
+	store, _ := openFake(t)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:150
+	link, err_1 := blob.Presign(store, "reports/q3.pdf", time.Hour)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:151
+	test.AssertTrue(t, strings.Contains(link, "/bucket/reports/q3.pdf?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=AKID%2F"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:152
+	test.AssertTrue(t, strings.Contains(link, "&X-Amz-Expires=3600&X-Amz-SignedHeaders=host&X-Amz-Signature="))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:153
+	upload, err_2 := blob.PresignPut(store, "uploads/a.png", 15*time.Minute)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:154
+	test.AssertTrue(t, strings.Contains(upload, "X-Amz-Expires=900"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:156
+	_, err := blob.Presign(store, "a", 8*24*time.Hour)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:157
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:158
+	local := blob.Local(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:159
+	fileLink, err_3 := blob.Presign(local, "a.txt", time.Hour)
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:160
+	test.AssertTrue(t, strings.HasPrefix(fileLink, "file://"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:161
+	_, err = blob.PresignPut(local, "a.txt", time.Hour)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:162
+	test.AssertError(t, err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:165
+type OpenCase struct {
+	name string
+	cfg  blob.Config
+	want string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:171
+func TestOpenErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:172
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:173
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:174
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:175
+	t.Setenv("AWS_ENDPOINT_URL", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:176
+	cases := []OpenCase{OpenCase{name: "no bucket", cfg: blob.New(""), want: "no bucket name"}, OpenCase{name: "no credentials", cfg: blob.New("b"), want: "no credentials"}, OpenCase{name: "bad endpoint", cfg: blob.Endpoint(blob.Credentials(blob.New("b"), "k", "s"), "localhost:9000"), want: "bad endpoint"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:181
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:182
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:183
+			_, err := blob.Open(tc.cfg)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:184
+			test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:185
+			test.AssertTrue(t, strings.Contains(err.Error(), tc.want))
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:188
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:189
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:190
+	t.Setenv("AWS_REGION", "eu-west-1")
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:191
+	store, err_1 := blob.Connect("logs")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:192
+	link, err_2 := blob.Presign(store, "a.txt", time.Minute)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:193
+	test.AssertTrue(t, strings.HasPrefix(link, "https://logs.s3.eu-west-1.amazonaws.com/a.txt?"))
+//line /Users/tluker/repos/go/kukicha/stdlib/blob/blob_test.kuki:194
+	test.AssertTrue(t, strings.Contains(link, "%2Feu-west-1%2Fs3%2Faws4_request"))
+}
@@ -0,0 +1,56 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package buildinfo
+
+import "strings"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:9
+type Info struct {
+	Version string
+	Source  string
+	Built   string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:14
+var raw string
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:18
+func Set(metadata string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:19
+	raw = metadata
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:24
+func Get() (Info, bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:25
+	info := Info{}
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:26
+	body := strings.TrimSuffix(strings.TrimPrefix(raw, "kukicha-build;"), ";end")
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:27
+	for _, field := range strings.Split(body, ";") {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:28
+		key, value, _ := strings.Cut(field, "=")
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:29
+		switch key {
+		case "version":
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:31
+			info.Version = value
+		case "source":
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:33
+			info.Source = value
+		case "built":
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:35
+			info.Built = value
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:36
+	return info, info.Version != ""
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:39
+func Version() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:40
+	info, _ := Get()
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo.kuki:41
+	return info.Version
+}
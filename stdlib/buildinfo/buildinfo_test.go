@@ -0,0 +1,42 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package buildinfo_test
+
+import (
+	"github.com/duber000/kukicha/stdlib/buildinfo"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:8
+type getCase struct {
+	name     string
+	metadata string
+	want     buildinfo.Info
+	ok       bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:14
+func TestGet(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:15
+	tests := []getCase{getCase{name: "unset", metadata: "", want: buildinfo.Info{}, ok: false}, getCase{name: "embedded", metadata: "kukicha-build;version=1.2.3;source=sha256:ab;built=2026-01-02T03:04:05Z;end", want: buildinfo.Info{Version: "1.2.3", Source: "sha256:ab", Built: "2026-01-02T03:04:05Z"}, ok: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:19
+	for _, tc := range tests {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:20
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:21
+			buildinfo.Set(tc.metadata)
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:22
+			got, ok := buildinfo.Get()
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:23
+			if got != tc.want || ok != tc.ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:24
+				t.Errorf("Get() = %v, %v, want %v, %v", got, ok, tc.want, tc.ok)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:25
+			if buildinfo.Version() != tc.want.Version {
+//line /Users/tluker/repos/go/kukicha/stdlib/buildinfo/buildinfo_test.kuki:26
+				t.Errorf("Version() = %v, want %v", buildinfo.Version(), tc.want.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:32
+const Dir = ".kukicha/cache"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:35
+type Cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	dir     string
+	entries map[string]entry
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:42
+type entry struct {
+	Key     string          `json:"key"`
+	Expires time.Time       `json:"expires"`
+	Value   json.RawMessage `json:"value"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:50
+func New(ttl time.Duration) *Cache {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:51
+	return &Cache{ttl: ttl, entries: make(map[string]entry)}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:56
+func Open(name string, ttl time.Duration) (*Cache, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:57
+	if name == "" || !filepath.IsLocal(name) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:58
+		return nil, fmt.Errorf("cache.Open %v: name must be a relative path inside %v", name, Dir)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:59
+	dir := filepath.Join(Dir, name)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:60
+	err_1 := os.MkdirAll(dir, 0755)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("cache.Open: %w", err_1)
+		return nil, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:61
+	return &Cache{ttl: ttl, dir: dir, entries: make(map[string]entry)}, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:67
+func Get[T any](c *Cache, key string, sample T) (T, bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:68
+	data := sample
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:69
+	raw, ok := lookup(c, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:70
+	if !ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:71
+		return data, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:72
+	err := json.Unmarshal(raw, &data)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:73
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:74
+		return sample, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:75
+	return data, true
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:79
+func Set[T any](c *Cache, key string, value T) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:80
+	return SetTTL(c, key, value, c.ttl)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:84
+func SetTTL[T any](c *Cache, key string, value T, ttl time.Duration) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:85
+	raw, err_1 := json.Marshal(value)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("cache.Set: %w", err_1)
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:86
+	e := entry{Key: key, Value: json.RawMessage(raw)}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:87
+	if ttl > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:88
+		e.Expires = time.Now().Add(ttl)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:89
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:90
+	defer c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:91
+	c.entries[key] = e
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:92
+	if c.dir == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:93
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:94
+	return writeEntry(c.dir, e)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:98
+func Delete(c *Cache, key string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:99
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:100
+	defer c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:101
+	delete(c.entries, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:102
+	if c.dir == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:103
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:104
+	return removeFile(entryPath(c.dir, key))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:108
+func Clear(c *Cache) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:109
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:110
+	defer c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:111
+	c.entries = make(map[string]entry)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:112
+	if c.dir == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:113
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:114
+	files, err_1 := os.ReadDir(c.dir)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("cache.Clear: %w", err_1)
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:115
+	for _, f := range files {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:116
+		err_2 := removeFile(filepath.Join(c.dir, f.Name()))
+		if err_2 != nil {
+			return err_2
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:117
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:122
+func Remember[T any](c *Cache, key string, sample T, fn func() (T, error)) (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:123
+	cached, ok := Get(c, key, sample)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:124
+	if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:125
+		return cached, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:126
+	value, err_1 := fn()
+	if err_1 != nil {
+		var _zero0 T
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:127
+	err_2 := Set(c, key, value)
+	if err_2 != nil {
+		var _zero0 T
+		return _zero0, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:128
+	return value, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:136
+func Memoize[T any](c *Cache, sample T, fn func(string) (T, error)) func(string) (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:137
+	return func(arg string) (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:138
+		compute := func() (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:139
+			return fn(arg)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:140
+		return Remember(c, arg, sample, compute)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:144
+func lookup(c *Cache, key string) (json.RawMessage, bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:145
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:146
+	defer c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:147
+	e, ok := c.entries[key]
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:148
+	if !ok && c.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:149
+		e, ok = readEntry(c.dir, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:150
+		if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:151
+			c.entries[key] = e
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:152
+	if !ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:153
+		return nil, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:154
+	if !e.Expires.IsZero() && time.Now().After(e.Expires) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:155
+		delete(c.entries, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:156
+		if c.dir != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:157
+			_ = removeFile(entryPath(c.dir, key))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:158
+		return nil, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:159
+	return e.Value, true
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:163
+func entryPath(dir string, key string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:164
+	sum := sha256.Sum256([]byte(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:165
+	return filepath.Join(dir, hex.EncodeToString(sum[0:])+".json")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:168
+func readEntry(dir string, key string) (entry, bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:169
+	e := entry{}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:170
+	data, err_1 := os.ReadFile(entryPath(dir, key))
+	if err_1 != nil {
+		return e, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:171
+	err := json.Unmarshal(data, &e)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:172
+	if err != nil || e.Key != key {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:173
+		return entry{}, false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:174
+	return e, true
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:178
+func writeEntry(dir string, e entry) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:179
+	data, err_1 := json.Marshal(e)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("cache.Set: %w", err_1)
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:180
+	path := entryPath(dir, e.Key)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:181
+	tmp, err_2 := os.CreateTemp(dir, "tmp-*")
+	if err_2 != nil {
+		err_2 = fmt.Errorf("cache.Set: %w", err_2)
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:182
+	_, err := tmp.Write(data)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:183
+	closeErr := tmp.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:184
+	if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:185
+		err = closeErr
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:186
+	if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:187
+		err = os.Rename(tmp.Name(), path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:188
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:189
+		_ = os.Remove(tmp.Name())
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:190
+		return fmt.Errorf("cache.Set %s: %w", e.Key, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:191
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:194
+func removeFile(path string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:195
+	err := os.Remove(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:196
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:197
+		return err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache.kuki:198
+	return nil
+}
@@ -0,0 +1,285 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package cache_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/cache"
+	"github.com/duber000/kukicha/stdlib/test"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:11
+type Repo struct {
+	Name  string
+	Stars int
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:16
+func TestGetSet(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:17
+	c := cache.New(0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:18
+	err_1 := cache.Set(c, "word", "hello")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:19
+	err_2 := cache.Set(c, "count", 42)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:20
+	err_3 := cache.Set(c, "repos", []Repo{Repo{Name: "kukicha", Stars: 7}})
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:22
+	word, ok := cache.Get(c, "word", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:23
+	test.AssertTrue(t, ok)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:24
+	test.AssertEqual(t, word, "hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:26
+	count, found := cache.Get(c, "count", 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:27
+	test.AssertTrue(t, found)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:28
+	test.AssertEqual(t, count+1, 43)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:30
+	repos, hit := cache.Get(c, "repos", []Repo{})
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:31
+	test.AssertTrue(t, hit)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:32
+	test.AssertEqual(t, repos[0].Name, "kukicha")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:35
+	wrong, decoded := cache.Get(c, "word", 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:36
+	test.AssertFalse(t, decoded)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:37
+	test.AssertEqual(t, wrong, 0)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:40
+func TestGetMissing(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:41
+	c := cache.New(time.Minute)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:42
+	got, ok := cache.Get(c, "nope", "fallback")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:43
+	test.AssertFalse(t, ok)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:44
+	test.AssertEqual(t, got, "fallback")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:47
+func TestExpiry(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:48
+	c := cache.New(time.Hour)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:49
+	err_1 := cache.SetTTL(c, "short", "soon gone", 10*time.Millisecond)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:50
+	err_2 := cache.Set(c, "long", "kept")
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:51
+	time.Sleep(20 * time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:52
+	_, gone := cache.Get(c, "short", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:53
+	test.AssertFalse(t, gone)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:54
+	kept, ok := cache.Get(c, "long", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:55
+	test.AssertTrue(t, ok)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:56
+	test.AssertEqual(t, kept, "kept")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:59
+func TestDeleteClear(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:60
+	c := cache.New(0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:61
+	err_1 := cache.Set(c, "a", 1)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:62
+	err_2 := cache.Set(c, "b", 2)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:63
+	test.AssertNoError(t, cache.Delete(c, "a"))
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:64
+	test.AssertNoError(t, cache.Delete(c, "missing"))
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:65
+	_, ok := cache.Get(c, "a", 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:66
+	test.AssertFalse(t, ok)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:67
+	test.AssertNoError(t, cache.Clear(c))
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:68
+	_, ok = cache.Get(c, "b", 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:69
+	test.AssertFalse(t, ok)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:72
+func TestOpenPersists(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:73
+	t.Chdir(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:74
+	first, err_1 := cache.Open("repos", time.Hour)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:75
+	err_2 := cache.Set(first, "octocat", []Repo{Repo{Name: "hello", Stars: 3}})
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:78
+	second, err_3 := cache.Open("repos", time.Hour)
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:79
+	got, ok := cache.Get(second, "octocat", []Repo{})
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:80
+	test.AssertTrue(t, ok)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:81
+	test.AssertEqual(t, got, []Repo{Repo{Name: "hello", Stars: 3}})
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:83
+	err_4 := cache.Clear(second)
+	if err_4 != nil {
+		panic(fmt.Sprintf("%v", err_4))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:84
+	third, err_5 := cache.Open("repos", time.Hour)
+	if err_5 != nil {
+		panic(fmt.Sprintf("%v", err_5))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:85
+	_, ok = cache.Get(third, "octocat", []Repo{})
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:86
+	test.AssertFalse(t, ok)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:89
+type OpenCase struct {
+	name    string
+	dir     string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:94
+func TestOpenBadName(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:95
+	cases := []OpenCase{OpenCase{name: "plain", dir: "weather", wantErr: false}, OpenCase{name: "nested", dir: "api/v2", wantErr: false}, OpenCase{name: "empty", dir: "", wantErr: true}, OpenCase{name: "parent", dir: "../outside", wantErr: true}, OpenCase{name: "absolute", dir: "/tmp/cache", wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:102
+	t.Chdir(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:103
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:104
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:105
+			_, err := cache.Open(tc.dir, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:106
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:107
+				test.AssertError(t, err)
+			} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:109
+				test.AssertNoError(t, err)
+			}
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:113
+func TestRemember(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:114
+	c := cache.New(0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:115
+	calls := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:116
+	load := func() (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:117
+		calls = calls + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:118
+		return "loaded", nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:119
+	for range 3 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:120
+		got, err_1 := cache.Remember(c, "k", "", load)
+		if err_1 != nil {
+			panic(fmt.Sprintf("%v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:121
+		test.AssertEqual(t, got, "loaded")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:122
+	test.AssertEqual(t, calls, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:124
+	failing := func() (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:125
+		return "", errors.New("offline")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:126
+	_, err := cache.Remember(c, "other", "", failing)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:127
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:128
+	_, ok := cache.Get(c, "other", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:129
+	test.AssertFalse(t, ok)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:132
+func TestMemoize(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:133
+	c := cache.New(0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:134
+	calls := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:135
+	lookup := func(name string) (Repo, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:136
+		calls = calls + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:137
+		return Repo{Name: name, Stars: len(name)}, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:138
+	cached := cache.Memoize(c, Repo{}, lookup)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:139
+	a, err_1 := cached("abc")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:140
+	b, err_2 := cached("abc")
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:141
+	d, err_3 := cached("de")
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:142
+	test.AssertEqual(t, a, Repo{Name: "abc", Stars: 3})
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:143
+	test.AssertEqual(t, b, a)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:144
+	test.AssertEqual(t, d.Stars, 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/cache/cache_test.kuki:145
+	test.AssertEqual(t, calls, 2)
+}
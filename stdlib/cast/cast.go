@@ -105,10 +105,10 @@ func SmartBool(value any) (bool, error) {
 		return v, nil
 	case int:
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast.kuki:80
-		return (v != 0), nil
+		return v != 0, nil
 	case float64:
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast.kuki:82
-		return (v != 0.0), nil
+		return v != 0.0, nil
 	case string:
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast.kuki:84
 		b, err := strconv.ParseBool(v)
@@ -41,28 +41,28 @@ func TestSmartInt(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:25
 	val, err := cast.SmartInt(5)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:26
-	if (err != nil) || (val != 5) {
+	if err != nil || val != 5 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:27
 		t.Fatalf("SmartInt failed for int: %v", err)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:28
 	val2, err2 := cast.SmartInt("7")
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:29
-	if (err2 != nil) || (val2 != 7) {
+	if err2 != nil || val2 != 7 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:30
 		t.Fatalf("SmartInt failed for string: %v", err2)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:31
 	val3, err3 := cast.SmartInt(json.Number("9"))
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:32
-	if (err3 != nil) || (val3 != 9) {
+	if err3 != nil || val3 != 9 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:33
 		t.Fatalf("SmartInt failed for json.Number: %v", err3)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:34
 	val4, err4 := cast.SmartInt(true)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:35
-	if (err4 != nil) || (val4 != 1) {
+	if err4 != nil || val4 != 1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:36
 		t.Fatalf("SmartInt failed for bool: %v", err4)
 	}
@@ -73,14 +73,14 @@ func TestSmartFloat64(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:40
 	val, err := cast.SmartFloat64(1.5)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:41
-	if (err != nil) || (val != 1.5) {
+	if err != nil || val != 1.5 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:42
 		t.Fatalf("SmartFloat64 failed for float64: %v", err)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:43
 	val2, err2 := cast.SmartFloat64("2.5")
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:44
-	if (err2 != nil) || (val2 == 0.0) {
+	if err2 != nil || val2 == 0.0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:45
 		t.Fatalf("SmartFloat64 failed for string: %v", err2)
 	}
@@ -91,28 +91,28 @@ func TestSmartBoolAndString(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:49
 	val, err := cast.SmartBool("true")
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:50
-	if (err != nil) || !val {
+	if err != nil || !val {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:51
 		t.Fatalf("SmartBool failed for string true: %v", err)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:52
 	val2, err2 := cast.SmartBool(0)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:53
-	if (err2 != nil) || val2 {
+	if err2 != nil || val2 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:54
 		t.Fatalf("SmartBool failed for int zero: %v", err2)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:56
 	str, err3 := cast.SmartString(10)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:57
-	if (err3 != nil) || (str != "10") {
+	if err3 != nil || str != "10" {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:58
 		t.Fatalf("SmartString should convert int to string: %v", err3)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:60
 	str2, err4 := cast.SmartString(nil)
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:61
-	if (err4 != nil) || (str2 != "") {
+	if err4 != nil || str2 != "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/cast/cast_test.kuki:62
 		t.Fatalf("SmartString should handle empty value: %v", err4)
 	}
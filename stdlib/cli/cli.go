@@ -167,11 +167,11 @@ func runFlat(app App) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:131
 	for _, argDef := range app.args {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:132
-		if (argIndex < len(args)) && !kukistring.HasPrefix(args[argIndex], "--") {
+		if argIndex < len(args) && !kukistring.HasPrefix(args[argIndex], "--") {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:133
 			values[argDef.name] = args[argIndex]
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:134
-			argIndex = (argIndex + 1)
+			argIndex = argIndex + 1
 		}
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:137
@@ -194,21 +194,21 @@ func runFlat(app App) error {
 				flagValue = parts[1]
 			} else {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:147
-				i = (i + 1)
+				i = i + 1
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:148
-				if (i < len(args)) && !kukistring.HasPrefix(args[i], "--") {
+				if i < len(args) && !kukistring.HasPrefix(args[i], "--") {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:149
 					flagValue = args[i]
 				} else {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:151
-					i = (i - 1)
+					i = i - 1
 				}
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:153
 			values[flagName] = flagValue
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:154
-		i = (i + 1)
+		i = i + 1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:157
 	for _, flagDef := range app.flags {
@@ -236,7 +236,7 @@ func runWithSubcommands(app App) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:172
 	args := os.Args
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:174
-	if ((len(args) < 2) || (args[1] == "-h")) || (args[1] == "--help") {
+	if len(args) < 2 || args[1] == "-h" || args[1] == "--help" {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:175
 		printHelp(app)
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:176
@@ -268,7 +268,7 @@ func runWithSubcommands(app App) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:192
 	cmd := app.subcommands[cmdIndex]
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:195
-	if (len(args) > 2) && ((args[2] == "-h") || (args[2] == "--help")) {
+	if len(args) > 2 && (args[2] == "-h" || args[2] == "--help") {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:196
 		printCommandHelp(app, cmd)
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:197
@@ -298,23 +298,23 @@ func runWithSubcommands(app App) error {
 				flagValue = parts[1]
 			} else {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:213
-				i = (i + 1)
+				i = i + 1
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:214
-				if (i < len(args)) && !kukistring.HasPrefix(args[i], "--") {
+				if i < len(args) && !kukistring.HasPrefix(args[i], "--") {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:215
 					flagValue = args[i]
 				} else {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:217
 					flagValue = "true"
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:218
-					i = (i - 1)
+					i = i - 1
 				}
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:219
 			values[flagName] = flagValue
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:220
-		i = (i + 1)
+		i = i + 1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:223
 	for _, flagDef := range app.globalFlags {
@@ -409,7 +409,7 @@ func printCommandHelp(app App, cmd SubcommandDef) {
 		fmt.Println("")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:274
-	if (len(app.globalFlags) > 0) || (len(cmd.flags) > 0) {
+	if len(app.globalFlags) > 0 || len(cmd.flags) > 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:275
 		fmt.Println("Flags:")
 	}
@@ -452,7 +452,7 @@ func GetBool(args Args, name string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:301
 	val := args.values[name]
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:302
-	return (((val == "true") || (val == "1")) || (val == "yes"))
+	return val == "true" || val == "1" || val == "yes"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/cli/cli.kuki:307
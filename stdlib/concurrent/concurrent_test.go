@@ -62,7 +62,7 @@ func TestMap(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:47
 		t.Run(tc.name, func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:48
-			results := concurrent.Map(tc.input, func(n int) int { return (n * 2) })
+			results := concurrent.Map(tc.input, func(n int) int { return n * 2 })
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:49
 			test.AssertEqual(t, len(results), len(tc.expected))
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:50
@@ -91,7 +91,7 @@ func TestMapWithLimit(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:69
 		t.Run(tc.name, func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:70
-			results := concurrent.MapWithLimit(tc.input, tc.limit, func(n int) int { return (n * 10) })
+			results := concurrent.MapWithLimit(tc.input, tc.limit, func(n int) int { return n * 10 })
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:71
 			test.AssertEqual(t, len(results), len(tc.expected))
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:72
@@ -108,7 +108,7 @@ func TestMapPreservesOrder(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:79
 	input := []int{5, 4, 3, 2, 1}
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:80
-	results := concurrent.Map(input, func(n int) int { return (n * n) })
+	results := concurrent.Map(input, func(n int) int { return n * n })
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:81
 	expected := []int{25, 16, 9, 4, 1}
 //line /Users/tluker/repos/go/kukicha/stdlib/concurrent/concurrent_test.kuki:82
@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/pkg/stdcopy"
 	ctxpkg "github.com/duber000/kukicha/stdlib/ctx"
 	"github.com/duber000/kukicha/stdlib/json"
+	"github.com/duber000/kukicha/stdlib/osx"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"io"
 	"os"
@@ -24,18 +25,18 @@ import (
 	"time"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:42
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:43
 type Engine struct {
 	cli *client.Client
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:46
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:47
 type Config struct {
 	host       string
 	apiVersion string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:51
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:52
 type ContainerInfo struct {
 	id     string
 	image  string
@@ -44,55 +45,55 @@ type ContainerInfo struct {
 	names  []string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:59
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:60
 type ImageInfo struct {
 	id   string
 	tags []string
 	size int64
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:66
 type BuildOutput struct {
 	imageID string
 	output  string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:70
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:71
 type Auth struct {
 	username      string
 	password      string
 	serverAddress string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:76
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:77
 type pullStatusMsg struct {
 	Status string `json:"status"`
 	ID     string `json:"id"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:81
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:82
 type buildStreamMsg struct {
 	Stream string         `json:"stream"`
 	Aux    buildStreamAux `json:"aux"`
 	Error  string         `json:"error"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:88
 type buildStreamAux struct {
 	ID string `json:"ID"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:92
 type dockerAuthEntry struct {
 	Auth string `json:"auth"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:95
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:96
 type dockerConfig struct {
 	Auths map[string]dockerAuthEntry `json:"auths"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:99
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:100
 type ContainerEvent struct {
 	id       string
 	resource string
@@ -101,613 +102,611 @@ type ContainerEvent struct {
 	time     string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:107
-func New() Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:108
+func New() Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:109
 	return Config{}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:111
-func Host(cfg Config, host string) Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:112
-	cfg.host = host
+func Host(cfg Config, host string) Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:113
+	cfg.host = host
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:114
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:116
-func APIVersion(cfg Config, version string) Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:117
-	cfg.apiVersion = version
+func APIVersion(cfg Config, version string) Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:118
+	cfg.apiVersion = version
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:119
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:121
-func Close(engine Engine) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:122
+func Close(engine Engine) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:123
 	return engine.cli.Close()
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:125
-func ListContainers(engine Engine) ([]ContainerInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:126
-	bg := ctxpkg.Background()
+func ListContainers(engine Engine) ([]ContainerInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:127
+	bg := ctxpkg.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:128
 	containers, err_1 := engine.cli.ContainerList(ctxpkg.Value(bg), dockercontainer.ListOptions{All: true})
 	if err_1 != nil {
 		err_1 = fmt.Errorf("container list: %w", err_1)
 		return []ContainerInfo{}, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:129
-	result := make([]ContainerInfo, len(containers))
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:130
-	for i, c := range containers {
+	result := make([]ContainerInfo, len(containers))
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:131
+	for i, c := range containers {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:132
 		result[i] = ContainerInfo{id: c.ID, image: c.Image, status: c.Status, state: c.State, names: c.Names}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:139
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:141
-func ListImages(engine Engine) ([]ImageInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:142
-	bg := ctxpkg.Background()
+func ListImages(engine Engine) ([]ImageInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:143
-	images, err_2 := engine.cli.ImageList(ctxpkg.Value(bg), dockerimage.ListOptions{All: true})
-	if err_2 != nil {
-		err_2 = fmt.Errorf("container list images: %w", err_2)
-		return []ImageInfo{}, err_2
+	bg := ctxpkg.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:144
+	images, err_1 := engine.cli.ImageList(ctxpkg.Value(bg), dockerimage.ListOptions{All: true})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container list images: %w", err_1)
+		return []ImageInfo{}, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:145
-	result := make([]ImageInfo, len(images))
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:146
-	for i, img := range images {
+	result := make([]ImageInfo, len(images))
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:147
+	for i, img := range images {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:148
 		result[i] = ImageInfo{id: img.ID, tags: img.RepoTags, size: img.Size}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:152
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:153
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:155
-func Stop(engine Engine, containerID string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:156
-	bg := ctxpkg.Background()
+func Stop(engine Engine, containerID string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:157
-	err_3 := engine.cli.ContainerStop(ctxpkg.Value(bg), containerID, dockercontainer.StopOptions{})
-	if err_3 != nil {
-		err_3 = fmt.Errorf("container stop: %w", err_3)
-		return err_3
-	}
+	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:158
+	err_1 := engine.cli.ContainerStop(ctxpkg.Value(bg), containerID, dockercontainer.StopOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container stop: %w", err_1)
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:159
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:161
-func Remove(engine Engine, containerID string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:162
-	bg := ctxpkg.Background()
+func Remove(engine Engine, containerID string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:163
-	err_4 := engine.cli.ContainerRemove(ctxpkg.Value(bg), containerID, dockercontainer.RemoveOptions{})
-	if err_4 != nil {
-		err_4 = fmt.Errorf("container remove: %w", err_4)
-		return err_4
-	}
+	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:164
+	err_1 := engine.cli.ContainerRemove(ctxpkg.Value(bg), containerID, dockercontainer.RemoveOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container remove: %w", err_1)
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:165
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:167
-func Login(username string, password string, server string) Auth {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:168
+func Login(username string, password string, server string) Auth {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:169
 	return Auth{username: username, password: password, serverAddress: server}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:171
-func AuthEncode(auth Auth) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:172
+func AuthEncode(auth Auth) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:173
 	authJSON, _ := json.Marshal(map[string]string{"username": auth.username, "password": auth.password, "serveraddress": auth.serverAddress})
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:177
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:178
 	return base64.URLEncoding.EncodeToString(authJSON)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:180
-func ContainerID(c ContainerInfo) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:181
+func ContainerID(c ContainerInfo) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:182
 	return c.id
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:184
-func ContainerImage(c ContainerInfo) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:185
+func ContainerImage(c ContainerInfo) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:186
 	return c.image
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:188
-func ContainerStatus(c ContainerInfo) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:189
+func ContainerStatus(c ContainerInfo) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:190
 	return c.status
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:192
-func ContainerState(c ContainerInfo) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:193
+func ContainerState(c ContainerInfo) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:194
 	return c.state
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:196
-func ContainerNames(c ContainerInfo) []string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:197
+func ContainerNames(c ContainerInfo) []string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:198
 	return c.names
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:200
-func ImageID(img ImageInfo) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:201
+func ImageID(img ImageInfo) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:202
 	return img.id
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:204
-func ImageTags(img ImageInfo) []string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:205
+func ImageTags(img ImageInfo) []string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:206
 	return img.tags
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:208
-func ImageSize(img ImageInfo) int64 {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:209
+func ImageSize(img ImageInfo) int64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:210
 	return img.size
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:212
-func BuildImageID(b BuildOutput) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:213
+func BuildImageID(b BuildOutput) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:214
 	return b.imageID
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:216
-func BuildLog(b BuildOutput) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:217
+func BuildLog(b BuildOutput) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:218
 	return b.output
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:220
-func EventID(event ContainerEvent) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:221
+func EventID(event ContainerEvent) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:222
 	return event.id
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:224
-func EventResource(event ContainerEvent) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:225
+func EventResource(event ContainerEvent) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:226
 	return event.resource
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:228
-func EventAction(event ContainerEvent) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:229
+func EventAction(event ContainerEvent) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:230
 	return event.action
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:232
-func EventActor(event ContainerEvent) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:233
+func EventActor(event ContainerEvent) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:234
 	return event.actor
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:236
-func EventTime(event ContainerEvent) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:237
+func EventTime(event ContainerEvent) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:238
 	return event.time
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:243
-func containerLogs(cli *client.Client, containerID string, tail string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:244
-	bg := ctxpkg.Background()
+func containerLogs(cli *client.Client, containerID string, tail string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:245
-	opts := dockercontainer.LogsOptions{ShowStdout: true, ShowStderr: true}
+	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:246
-	if tail != "" {
+	opts := dockercontainer.LogsOptions{ShowStdout: true, ShowStderr: true}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:247
-		opts.Tail = tail
-	}
+	if tail != "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:248
-	reader, err_5 := cli.ContainerLogs(ctxpkg.Value(bg), containerID, opts)
-	if err_5 != nil {
-		err_5 = fmt.Errorf("container logs: %w", err_5)
-		return "", err_5
+		opts.Tail = tail
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:249
+	reader, err_1 := cli.ContainerLogs(ctxpkg.Value(bg), containerID, opts)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container logs: %w", err_1)
+		return "", err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:250
 	defer reader.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:251
-	stdout := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:252
-	stderr := bytes.Buffer{}
+	stdout := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:253
-	_, err_6 := stdcopy.StdCopy(&stdout, &stderr, reader)
-	if err_6 != nil {
-		//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:254
-		raw, err_7 := io.ReadAll(reader)
-		if err_7 != nil {
-			err_7 = fmt.Errorf("container logs: %w", err_7)
-			return "", err_7
+	stderr := bytes.Buffer{}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:254
+	_, err_2 := stdcopy.StdCopy(&stdout, &stderr, reader)
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:255
+		raw, err_3 := io.ReadAll(reader)
+		if err_3 != nil {
+			err_3 = fmt.Errorf("container logs: %w", err_3)
+			return "", err_3
 		}
-		//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:255
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:256
 		return string(raw), nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:257
-	combined := stdout.String()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:258
-	if stderr.Len() > 0 {
+	combined := stdout.String()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:259
-		combined = (combined + stderr.String())
-	}
+	if stderr.Len() > 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:260
+		combined = combined + stderr.String()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:261
 	return combined, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:263
-func Logs(engine Engine, containerID string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:264
+func Logs(engine Engine, containerID string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:265
 	return containerLogs(engine.cli, containerID, "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:267
-func LogsTail(engine Engine, containerID string, lines int64) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:268
+func LogsTail(engine Engine, containerID string, lines int64) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:269
 	return containerLogs(engine.cli, containerID, fmt.Sprintf("%d", lines))
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:271
-func Run(engine Engine, img string, cmd []string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:272
-	bg := ctxpkg.Background()
+func Run(engine Engine, img string, cmd []string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:273
-	resp, err_8 := engine.cli.ContainerCreate(ctxpkg.Value(bg), &dockercontainer.Config{Image: img, Cmd: cmd}, nil, nil, nil, "")
-	if err_8 != nil {
-		err_8 = fmt.Errorf("container run create: %w", err_8)
-		return "", err_8
+	bg := ctxpkg.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:274
+	resp, err_1 := engine.cli.ContainerCreate(ctxpkg.Value(bg), &dockercontainer.Config{Image: img, Cmd: cmd}, nil, nil, nil, "")
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container run create: %w", err_1)
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:278
-	err_9 := engine.cli.ContainerStart(ctxpkg.Value(bg), resp.ID, dockercontainer.StartOptions{})
-	if err_9 != nil {
-		err_9 = fmt.Errorf("container run start: %w", err_9)
-		return "", err_9
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:279
+	err_2 := engine.cli.ContainerStart(ctxpkg.Value(bg), resp.ID, dockercontainer.StartOptions{})
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container run start: %w", err_2)
+		return "", err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:280
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:281
 	return resp.ID, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:283
-func Inspect(engine Engine, containerID string) (ContainerInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:284
-	bg := ctxpkg.Background()
+func Inspect(engine Engine, containerID string) (ContainerInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:285
-	info, err_10 := engine.cli.ContainerInspect(ctxpkg.Value(bg), containerID)
-	if err_10 != nil {
-		err_10 = fmt.Errorf("container inspect: %w", err_10)
+	bg := ctxpkg.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:286
+	info, err_1 := engine.cli.ContainerInspect(ctxpkg.Value(bg), containerID)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container inspect: %w", err_1)
 		var _zero0 ContainerInfo
-		return _zero0, err_10
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:287
-	names := make([]string, 0)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:288
-	if info.Name != "" {
+	names := make([]string, 0)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:289
+	if info.Name != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:290
 		names = append(names, kukistring.TrimPrefix(info.Name, "/"))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:290
-	status := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:291
-	state := ""
+	status := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:292
-	if info.State != nil {
+	state := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:293
-		status = info.State.Status
+	if info.State != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:294
+		status = info.State.Status
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:295
 		state = info.State.Status
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:295
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:296
 	return ContainerInfo{id: info.ID, image: info.Config.Image, status: status, state: state, names: names}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:305
-func Exec(engine Engine, containerID string, cmd []string, handles ...ctxpkg.Handle) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:306
-	ctx := ctxpkg.Background()
+func Exec(engine Engine, containerID string, cmd []string, handles ...ctxpkg.Handle) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:307
-	if len(handles) > 0 {
+	ctx := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:308
-		ctx = handles[0]
-	}
+	if len(handles) > 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:309
-	createResp, err_11 := engine.cli.ContainerExecCreate(ctxpkg.Value(ctx), containerID, dockertypes.ExecConfig{Cmd: cmd, AttachStdout: true, AttachStderr: true})
-	if err_11 != nil {
-		err_11 = fmt.Errorf("container exec create: %w", err_11)
-		return "", err_11
+		ctx = handles[0]
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:315
-	attachResp, err_12 := engine.cli.ContainerExecAttach(ctxpkg.Value(ctx), createResp.ID, dockertypes.ExecStartCheck{})
-	if err_12 != nil {
-		err_12 = fmt.Errorf("container exec attach: %w", err_12)
-		return "", err_12
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:310
+	createResp, err_1 := engine.cli.ContainerExecCreate(ctxpkg.Value(ctx), containerID, dockertypes.ExecConfig{Cmd: cmd, AttachStdout: true, AttachStderr: true})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container exec create: %w", err_1)
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:316
+	attachResp, err_2 := engine.cli.ContainerExecAttach(ctxpkg.Value(ctx), createResp.ID, dockertypes.ExecStartCheck{})
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container exec attach: %w", err_2)
+		return "", err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:317
 	defer attachResp.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:318
-	stdout := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:319
-	stderr := bytes.Buffer{}
+	stdout := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:320
-	_, err_13 := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader)
-	if err_13 != nil {
-		//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:321
-		raw, err_14 := io.ReadAll(attachResp.Reader)
-		if err_14 != nil {
-			err_14 = fmt.Errorf("container exec read: %w", err_14)
-			return "", err_14
+	stderr := bytes.Buffer{}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:321
+	_, err_3 := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader)
+	if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:322
+		raw, err_4 := io.ReadAll(attachResp.Reader)
+		if err_4 != nil {
+			err_4 = fmt.Errorf("container exec read: %w", err_4)
+			return "", err_4
 		}
-		//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:322
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:323
 		return string(raw), nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:324
-	inspectResult, err_15 := engine.cli.ContainerExecInspect(ctxpkg.Value(ctx), createResp.ID)
-	if err_15 != nil {
-		err_15 = fmt.Errorf("container exec inspect: %w", err_15)
-		return "", err_15
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:325
+	inspectResult, err_5 := engine.cli.ContainerExecInspect(ctxpkg.Value(ctx), createResp.ID)
+	if err_5 != nil {
+		err_5 = fmt.Errorf("container exec inspect: %w", err_5)
+		return "", err_5
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:326
-	combined := stdout.String()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:327
-	if stderr.Len() > 0 {
+	combined := stdout.String()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:328
-		combined = (combined + stderr.String())
-	}
+	if stderr.Len() > 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:329
-	if inspectResult.ExitCode != 0 {
+		combined = combined + stderr.String()
+	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:330
+	if inspectResult.ExitCode != 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:331
 		return combined, fmt.Errorf("container exec exit %v", inspectResult.ExitCode)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:331
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:332
 	return combined, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:337
-func Wait(engine Engine, containerID string, timeoutSeconds int64) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:338
-	h := ctxpkg.Background()
+func Wait(engine Engine, containerID string, timeoutSeconds int64) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:339
-	if timeoutSeconds > 0 {
+	h := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:340
+	if timeoutSeconds > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:341
 		h = ctxpkg.WithTimeout(h, timeoutSeconds)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:341
-	defer ctxpkg.Cancel(h)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:342
+	defer ctxpkg.Cancel(h)
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:343
 	return WaitCtx(engine, h, containerID)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:345
-func WaitCtx(engine Engine, h ctxpkg.Handle, containerID string) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:346
-	goCtx := ctxpkg.Value(h)
+func WaitCtx(engine Engine, h ctxpkg.Handle, containerID string) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:347
-	waitCh, errCh := engine.cli.ContainerWait(goCtx, containerID, dockercontainer.WaitConditionNotRunning)
+	goCtx := ctxpkg.Value(h)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:348
+	waitCh, errCh := engine.cli.ContainerWait(goCtx, containerID, dockercontainer.WaitConditionNotRunning)
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:349
 	select {
 	case err := <-errCh:
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:350
-		if err == nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:351
+		if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:352
 			return -1, errors.New("container wait: unknown wait error")
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:352
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:353
 		return -1, fmt.Errorf("container wait: %v", err)
 	case res := <-waitCh:
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:354
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:355
 		return res.StatusCode, nil
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:359
-func convertEvent(msg dockerevents.Message) ContainerEvent {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:360
-	ts := time.Unix(msg.Time, 0).UTC().Format(time.RFC3339)
+func convertEvent(msg dockerevents.Message) ContainerEvent {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:361
-	actor := msg.Actor.ID
+	ts := time.Unix(msg.Time, 0).UTC().Format(time.RFC3339)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:362
-	name, ok := msg.Actor.Attributes["name"]
+	actor := msg.Actor.ID
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:363
-	if ok && (name != "") {
+	name, ok := msg.Actor.Attributes["name"]
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:364
+	if ok && name != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:365
 		actor = name
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:365
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:366
 	return ContainerEvent{id: msg.ID, resource: string(msg.Type), action: string(msg.Action), actor: actor, time: ts}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:374
-func eventsWithContext(engine Engine, h ctxpkg.Handle) ([]ContainerEvent, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:375
-	goCtx := ctxpkg.Value(h)
+func eventsWithContext(engine Engine, h ctxpkg.Handle) ([]ContainerEvent, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:376
-	msgCh, errCh := engine.cli.Events(goCtx, dockertypes.EventsOptions{})
+	goCtx := ctxpkg.Value(h)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:377
-	events := make([]ContainerEvent, 0)
+	msgCh, errCh := engine.cli.Events(goCtx, dockertypes.EventsOptions{})
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:378
-	for {
+	events := make([]ContainerEvent, 0)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:379
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:380
 		select {
 		case <-goCtx.Done():
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:381
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:382
 			return events, nil
 		case err := <-errCh:
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:383
-			if (err == nil) || (goCtx.Err() != nil) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:384
+			if err == nil || goCtx.Err() != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:385
 				return events, nil
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:385
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:386
 			return events, fmt.Errorf("container events: %v", err)
 		case msg, ok := <-msgCh:
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:387
-			if !ok {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:388
+			if !ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:389
 				return events, nil
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:389
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:390
 			events = append(events, convertEvent(msg))
 		}
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:393
-func Events(engine Engine, timeoutSeconds int64) ([]ContainerEvent, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:394
-	if timeoutSeconds <= 0 {
+func Events(engine Engine, timeoutSeconds int64) ([]ContainerEvent, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:395
+	if timeoutSeconds <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:396
 		timeoutSeconds = 15
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:396
-	h := ctxpkg.WithTimeout(ctxpkg.Background(), timeoutSeconds)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:397
-	defer ctxpkg.Cancel(h)
+	h := ctxpkg.WithTimeout(ctxpkg.Background(), timeoutSeconds)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:398
+	defer ctxpkg.Cancel(h)
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:399
 	return eventsWithContext(engine, h)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:401
-func EventsCtx(engine Engine, h ctxpkg.Handle) ([]ContainerEvent, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:402
+func EventsCtx(engine Engine, h ctxpkg.Handle) ([]ContainerEvent, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:403
 	return eventsWithContext(engine, h)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:408
-func Pull(engine Engine, ref string, handles ...ctxpkg.Handle) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:409
-	bg := ctxpkg.Background()
+func Pull(engine Engine, ref string, handles ...ctxpkg.Handle) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:410
-	if len(handles) > 0 {
+	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:411
-		bg = handles[0]
-	}
+	if len(handles) > 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:412
-	reader, err_16 := engine.cli.ImagePull(ctxpkg.Value(bg), ref, dockerimage.PullOptions{})
-	if err_16 != nil {
-		err_16 = fmt.Errorf("container pull: %w", err_16)
-		return "", err_16
+		bg = handles[0]
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:413
-	defer reader.Close()
+	reader, err_1 := engine.cli.ImagePull(ctxpkg.Value(bg), ref, dockerimage.PullOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container pull: %w", err_1)
+		return "", err_1
+	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:414
-	digest := ""
+	defer reader.Close()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:415
-	scanner := bufio.NewScanner(reader)
+	digest := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:416
-	for scanner.Scan() {
+	scanner := bufio.NewScanner(reader)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:417
-		msg := pullStatusMsg{}
+	for scanner.Scan() {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:418
-		err_17 := json.Unmarshal(scanner.Bytes(), &msg)
-		if err_17 != nil {
-			//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:419
+		msg := pullStatusMsg{}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:419
+		err_2 := json.Unmarshal(scanner.Bytes(), &msg)
+		if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:420
 			continue
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:420
-		if kukistring.HasPrefix(msg.Status, "Digest:") {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:421
+		if kukistring.HasPrefix(msg.Status, "Digest:") {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:422
 			digest = kukistring.TrimPrefix(msg.Status, "Digest: ")
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:422
-	if digest == "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:423
+	if digest == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:424
 		digest = ref
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:424
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:425
 	return digest, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:427
-func PullAuth(engine Engine, ref string, auth Auth) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:428
-	authJSON, err_18 := json.Marshal(map[string]string{"username": auth.username, "password": auth.password, "serveraddress": auth.serverAddress})
-	if err_18 != nil {
-		err_18 = fmt.Errorf("container pull auth: %w", err_18)
-		return "", err_18
+func PullAuth(engine Engine, ref string, auth Auth) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:429
+	authJSON, err_1 := json.Marshal(map[string]string{"username": auth.username, "password": auth.password, "serveraddress": auth.serverAddress})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container pull auth: %w", err_1)
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:434
-	encoded := base64.URLEncoding.EncodeToString(authJSON)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:435
-	reader, err_19 := engine.cli.ImagePull(ctxpkg.Value(ctxpkg.Background()), ref, dockerimage.PullOptions{RegistryAuth: encoded})
-	if err_19 != nil {
-		err_19 = fmt.Errorf("container pull: %w", err_19)
-		return "", err_19
+	encoded := base64.URLEncoding.EncodeToString(authJSON)
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:436
+	reader, err_2 := engine.cli.ImagePull(ctxpkg.Value(ctxpkg.Background()), ref, dockerimage.PullOptions{RegistryAuth: encoded})
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container pull: %w", err_2)
+		return "", err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:438
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:439
 	defer reader.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:440
-	digest := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:441
-	scanner := bufio.NewScanner(reader)
+	digest := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:442
-	for scanner.Scan() {
+	scanner := bufio.NewScanner(reader)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:443
-		msg := pullStatusMsg{}
+	for scanner.Scan() {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:444
-		err_20 := json.Unmarshal(scanner.Bytes(), &msg)
-		if err_20 != nil {
-			//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:445
+		msg := pullStatusMsg{}
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:445
+		err_3 := json.Unmarshal(scanner.Bytes(), &msg)
+		if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:446
 			continue
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:446
-		if kukistring.HasPrefix(msg.Status, "Digest:") {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:447
+		if kukistring.HasPrefix(msg.Status, "Digest:") {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:448
 			digest = kukistring.TrimPrefix(msg.Status, "Digest: ")
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:448
-	if digest == "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:449
+	if digest == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:450
 		digest = ref
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:450
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:451
 	return digest, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:455
-func loadDockerAuth(serverAddress string) (string, string, string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:456
-	home, err_21 := os.UserHomeDir()
-	if err_21 != nil {
-		err_21 = fmt.Errorf("container auth: %w", err_21)
-		return "", "", "", err_21
-	}
+func loadDockerAuth(serverAddress string) (string, string, string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:457
-	configPath := filepath.Join(home, ".docker", "config.json")
+	configPath, err_1 := osx.ExpandPath("~/.docker/config.json")
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container auth: %w", err_1)
+		return "", "", "", err_1
+	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:458
-	data, err_22 := os.ReadFile(configPath)
-	if err_22 != nil {
-		err_22 = fmt.Errorf("container auth: %w", err_22)
-		return "", "", "", err_22
+	data, err_2 := os.ReadFile(configPath)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container auth: %w", err_2)
+		return "", "", "", err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:459
 	config := dockerConfig{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:460
-	err_23 := json.Unmarshal(data, &config)
-	if err_23 != nil {
-		err_23 = fmt.Errorf("container auth parse: %w", err_23)
-		return "", "", "", err_23
+	err_3 := json.Unmarshal(data, &config)
+	if err_3 != nil {
+		err_3 = fmt.Errorf("container auth parse: %w", err_3)
+		return "", "", "", err_3
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:461
 	authEntry, ok := config.Auths[serverAddress]
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:462
 	if !ok {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:463
-		variations := []string{("https://" + serverAddress), ("http://" + serverAddress), kukistring.TrimPrefix(serverAddress, "https://"), kukistring.TrimPrefix(serverAddress, "http://")}
+		variations := []string{"https://" + serverAddress, "http://" + serverAddress, kukistring.TrimPrefix(serverAddress, "https://"), kukistring.TrimPrefix(serverAddress, "http://")}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:469
 		for _, v := range variations {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:470
@@ -734,10 +733,10 @@ func loadDockerAuth(serverAddress string) (string, string, string, error) {
 		return "", "", "", fmt.Errorf("container auth: empty credentials for %v", serverAddress)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:479
-	decoded, err_24 := base64.StdEncoding.DecodeString(authEntry.Auth)
-	if err_24 != nil {
-		err_24 = fmt.Errorf("container auth decode: %w", err_24)
-		return "", "", "", err_24
+	decoded, err_4 := base64.StdEncoding.DecodeString(authEntry.Auth)
+	if err_4 != nil {
+		err_4 = fmt.Errorf("container auth decode: %w", err_4)
+		return "", "", "", err_4
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:480
 	parts := kukistring.SplitN(string(decoded), ":", 2)
@@ -753,10 +752,10 @@ func loadDockerAuth(serverAddress string) (string, string, string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:486
 func LoginFromConfig(server string) (Auth, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:487
-	username, password, addr, err_25 := loadDockerAuth(server)
-	if err_25 != nil {
+	username, password, addr, err_1 := loadDockerAuth(server)
+	if err_1 != nil {
 		var _zero0 Auth
-		return _zero0, err_25
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:488
 	return Auth{username: username, password: password, serverAddress: addr}, nil
@@ -772,10 +771,10 @@ func newClient(host string) (*client.Client, error) {
 		opts = append(opts, client.WithHost(host))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:499
-	cli, err_26 := client.NewClientWithOpts(opts...)
-	if err_26 != nil {
-		err_26 = fmt.Errorf("container connect: %w", err_26)
-		return nil, err_26
+	cli, err_1 := client.NewClientWithOpts(opts...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container connect: %w", err_1)
+		return nil, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:500
 	return cli, nil
@@ -794,16 +793,16 @@ func Connect() (Engine, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:512
 		if statErr == nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:513
-			host = ("unix://" + p)
+			host = "unix://" + p
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:514
 			break
 		}
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:515
-	cli, err_27 := newClient(host)
-	if err_27 != nil {
+	cli, err_1 := newClient(host)
+	if err_1 != nil {
 		var _zero0 Engine
-		return _zero0, err_27
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:516
 	return Engine{cli: cli}, nil
@@ -812,10 +811,10 @@ func Connect() (Engine, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:519
 func ConnectRemote(host string) (Engine, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:520
-	cli, err_28 := newClient(host)
-	if err_28 != nil {
+	cli, err_1 := newClient(host)
+	if err_1 != nil {
 		var _zero0 Engine
-		return _zero0, err_28
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:521
 	return Engine{cli: cli}, nil
@@ -836,11 +835,11 @@ func Open(cfg Config) (Engine, error) {
 		opts = append(opts, client.WithVersion(cfg.apiVersion))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:530
-	cli, err_29 := client.NewClientWithOpts(opts...)
-	if err_29 != nil {
-		err_29 = fmt.Errorf("container open: %w", err_29)
+	cli, err_1 := client.NewClientWithOpts(opts...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container open: %w", err_1)
 		var _zero0 Engine
-		return _zero0, err_29
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:531
 	return Engine{cli: cli}, nil
@@ -853,10 +852,10 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:537
 	tw := tar.NewWriter(&buf)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:538
-	absContextPath, err_30 := filepath.Abs(contextPath)
-	if err_30 != nil {
-		err_30 = fmt.Errorf("container build: %w", err_30)
-		return "", "", err_30
+	absContextPath, err_1 := filepath.Abs(contextPath)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container build: %w", err_1)
+		return "", "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:539
 	walkErr := filepath.WalkDir(absContextPath, func(walkPath string, d os.DirEntry, err error) error {
@@ -866,7 +865,7 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 			return err
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:542
-		if d.IsDir() && (d.Name() == ".git") {
+		if d.IsDir() && d.Name() == ".git" {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:543
 			return filepath.SkipDir
 		}
@@ -930,18 +929,18 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 		return "", "", fmt.Errorf("container build context: %v", walkErr)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:571
-	err_31 := tw.Close()
-	if err_31 != nil {
-		err_31 = fmt.Errorf("container build tar: %w", err_31)
-		return "", "", err_31
+	err_2 := tw.Close()
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container build tar: %w", err_2)
+		return "", "", err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:572
 	buildOpts := dockertypes.ImageBuildOptions{Tags: []string{tag}, Remove: true, Dockerfile: "Dockerfile"}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:577
-	resp, err_32 := cli.ImageBuild(ctxpkg.Value(ctxpkg.Background()), &buf, buildOpts)
-	if err_32 != nil {
-		err_32 = fmt.Errorf("container build: %w", err_32)
-		return "", "", err_32
+	resp, err_3 := cli.ImageBuild(ctxpkg.Value(ctxpkg.Background()), &buf, buildOpts)
+	if err_3 != nil {
+		err_3 = fmt.Errorf("container build: %w", err_3)
+		return "", "", err_3
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:578
 	defer resp.Body.Close()
@@ -962,8 +961,8 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:586
 		msg := buildStreamMsg{}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:587
-		err_33 := json.Unmarshal(scanner.Bytes(), &msg)
-		if err_33 != nil {
+		err_4 := json.Unmarshal(scanner.Bytes(), &msg)
+		if err_4 != nil {
 			continue
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:588
@@ -978,10 +977,10 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 		}
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:592
-	err_34 := scanner.Err()
-	if err_34 != nil {
-		err_34 = fmt.Errorf("container build stream: %w", err_34)
-		return "", "", err_34
+	err_5 := scanner.Err()
+	if err_5 != nil {
+		err_5 = fmt.Errorf("container build stream: %w", err_5)
+		return "", "", err_5
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:593
 	return imageID, output.String(), nil
@@ -990,10 +989,10 @@ func buildImage(cli *client.Client, contextPath string, tag string) (string, str
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:596
 func Build(engine Engine, path string, tag string) (BuildOutput, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:597
-	imageID, output, err_35 := buildImage(engine.cli, path, tag)
-	if err_35 != nil {
+	imageID, output, err_1 := buildImage(engine.cli, path, tag)
+	if err_1 != nil {
 		var _zero0 BuildOutput
-		return _zero0, err_35
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:598
 	return BuildOutput{imageID: imageID, output: output}, nil
@@ -1024,7 +1023,7 @@ func extractTar(reader io.Reader, destPath string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:611
 		target := filepath.Join(destPath, cleanName)
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:612
-		if !kukistring.HasPrefix(target, (cleanDest+string(filepath.Separator))) && (filepath.Clean(target) != cleanDest) {
+		if !kukistring.HasPrefix(target, cleanDest+string(filepath.Separator)) && filepath.Clean(target) != cleanDest {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:613
 			return fmt.Errorf("invalid archive path: %v", header.Name)
 		}
@@ -1032,33 +1031,33 @@ func extractTar(reader io.Reader, destPath string) error {
 		switch header.Typeflag {
 		case tar.TypeDir:
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:616
-			err_36 := os.MkdirAll(target, os.FileMode(header.Mode))
-			if err_36 != nil {
-				return err_36
+			err_1 := os.MkdirAll(target, os.FileMode(header.Mode))
+			if err_1 != nil {
+				return err_1
 			}
 		case tar.TypeReg:
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:618
-			err_37 := os.MkdirAll(filepath.Dir(target), 493)
-			if err_37 != nil {
-				return err_37
+			err_2 := os.MkdirAll(filepath.Dir(target), 493)
+			if err_2 != nil {
+				return err_2
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:619
-			f, err_38 := os.OpenFile(target, ((os.O_CREATE | os.O_WRONLY) | os.O_TRUNC), os.FileMode(header.Mode))
-			if err_38 != nil {
-				return err_38
+			f, err_3 := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err_3 != nil {
+				return err_3
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:620
-			_, err_39 := io.Copy(f, tr)
-			if err_39 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:621
+			_, err_4 := io.Copy(f, tr)
+			if err_4 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:621
 				f.Close()
-				//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:622
-				return fmt.Errorf("%v", err_39)
+//line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:622
+				return fmt.Errorf("%v", err_4)
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:623
-			err_40 := f.Close()
-			if err_40 != nil {
-				return err_40
+			err_5 := f.Close()
+			if err_5 != nil {
+				return err_5
 			}
 		case tar.TypeSymlink, tar.TypeLink:
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:625
@@ -1075,16 +1074,16 @@ func extractTar(reader io.Reader, destPath string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:631
 func createTarFromPath(sourcePath string) (io.Reader, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:632
-	absSourcePath, err_41 := filepath.Abs(sourcePath)
-	if err_41 != nil {
-		err_41 = fmt.Errorf("container copy to abs path: %w", err_41)
-		return nil, err_41
+	absSourcePath, err_1 := filepath.Abs(sourcePath)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container copy to abs path: %w", err_1)
+		return nil, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:635
-	info, err_42 := os.Lstat(absSourcePath)
-	if err_42 != nil {
-		err_42 = fmt.Errorf("container copy to stat: %w", err_42)
-		return nil, err_42
+	info, err_2 := os.Lstat(absSourcePath)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container copy to stat: %w", err_2)
+		return nil, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:637
 	if info.Mode().Type() == os.ModeSymlink {
@@ -1098,7 +1097,7 @@ func createTarFromPath(sourcePath string) (io.Reader, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:641
 	if info.IsDir() {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:642
-		err_43 := filepath.WalkDir(absSourcePath, func(walkPath string, d os.DirEntry, err error) error {
+		err_3 := filepath.WalkDir(absSourcePath, func(walkPath string, d os.DirEntry, err error) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:643
 			if err != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:644
@@ -1134,7 +1133,7 @@ func createTarFromPath(sourcePath string) (io.Reader, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:657
 			if fi.IsDir() {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:658
-				header.Name = (header.Name + "/")
+				header.Name = header.Name + "/"
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:659
 			err_4 := tw.WriteHeader(header)
@@ -1159,36 +1158,36 @@ func createTarFromPath(sourcePath string) (io.Reader, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:664
 			return nil
 		})
-		if err_43 != nil {
-			err_43 = fmt.Errorf("container copy to walk: %w", err_43)
-			return nil, err_43
+		if err_3 != nil {
+			err_3 = fmt.Errorf("container copy to walk: %w", err_3)
+			return nil, err_3
 		}
 	} else {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:667
-		fi, err_44 := os.Stat(absSourcePath)
-		if err_44 != nil {
-			err_44 = fmt.Errorf("container copy to stat file: %w", err_44)
-			return nil, err_44
+		fi, err_4 := os.Stat(absSourcePath)
+		if err_4 != nil {
+			err_4 = fmt.Errorf("container copy to stat file: %w", err_4)
+			return nil, err_4
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:668
-		header, err_45 := tar.FileInfoHeader(fi, "")
-		if err_45 != nil {
-			err_45 = fmt.Errorf("container copy to header: %w", err_45)
-			return nil, err_45
+		header, err_5 := tar.FileInfoHeader(fi, "")
+		if err_5 != nil {
+			err_5 = fmt.Errorf("container copy to header: %w", err_5)
+			return nil, err_5
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:669
 		header.Name = filepath.ToSlash(filepath.Base(absSourcePath))
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:670
-		err_46 := tw.WriteHeader(header)
-		if err_46 != nil {
-			err_46 = fmt.Errorf("container copy to write header: %w", err_46)
-			return nil, err_46
+		err_6 := tw.WriteHeader(header)
+		if err_6 != nil {
+			err_6 = fmt.Errorf("container copy to write header: %w", err_6)
+			return nil, err_6
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:671
-		f, err_47 := os.Open(absSourcePath)
-		if err_47 != nil {
-			err_47 = fmt.Errorf("container copy to open: %w", err_47)
-			return nil, err_47
+		f, err_7 := os.Open(absSourcePath)
+		if err_7 != nil {
+			err_7 = fmt.Errorf("container copy to open: %w", err_7)
+			return nil, err_7
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:672
 		defer f.Close()
@@ -1200,10 +1199,10 @@ func createTarFromPath(sourcePath string) (io.Reader, error) {
 		}
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:675
-	err_48 := tw.Close()
-	if err_48 != nil {
-		err_48 = fmt.Errorf("container copy to close tar: %w", err_48)
-		return nil, err_48
+	err_8 := tw.Close()
+	if err_8 != nil {
+		err_8 = fmt.Errorf("container copy to close tar: %w", err_8)
+		return nil, err_8
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:676
 	return &buf, nil
@@ -1233,16 +1232,16 @@ func copyFromWithContext(engine Engine, ctx ctxpkg.Handle, containerID string, s
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:689
 	defer reader.Close()
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:690
-	err_49 := os.MkdirAll(destPath, 493)
-	if err_49 != nil {
-		err_49 = fmt.Errorf("container copy from mkdir: %w", err_49)
-		return err_49
+	err_1 := os.MkdirAll(destPath, 493)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("container copy from mkdir: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:691
-	err_50 := extractTar(reader, destPath)
-	if err_50 != nil {
-		err_50 = fmt.Errorf("container copy from extract: %w", err_50)
-		return err_50
+	err_2 := extractTar(reader, destPath)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container copy from extract: %w", err_2)
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:692
 	return nil
@@ -1264,17 +1263,17 @@ func CopyTo(engine Engine, containerID string, sourcePath string, destPath strin
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:703
 func copyToWithContext(engine Engine, ctx ctxpkg.Handle, containerID string, sourcePath string, destPath string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:704
-	archive, err_51 := createTarFromPath(sourcePath)
-	if err_51 != nil {
-		return err_51
+	archive, err_1 := createTarFromPath(sourcePath)
+	if err_1 != nil {
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:705
 	copyOpts := dockercontainer.CopyToContainerOptions{AllowOverwriteDirWithFile: true}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:706
-	err_52 := engine.cli.CopyToContainer(ctxpkg.Value(ctx), containerID, destPath, archive, copyOpts)
-	if err_52 != nil {
-		err_52 = fmt.Errorf("container copy to: %w", err_52)
-		return err_52
+	err_2 := engine.cli.CopyToContainer(ctxpkg.Value(ctx), containerID, destPath, archive, copyOpts)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("container copy to: %w", err_2)
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/container/container.kuki:707
 	return nil
@@ -0,0 +1,447 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:33
+type Schedule struct {
+	spec       string
+	minutes    []bool
+	hours      []bool
+	days       []bool
+	months     []bool
+	weekdays   []bool
+	anyDay     bool
+	anyWeekday bool
+	every      time.Duration
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:46
+type Job struct {
+	Name string
+	Spec string
+	Run  func(context.Context) error
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:52
+type entry struct {
+	job      Job
+	schedule Schedule
+	next     time.Time
+	busy     atomic.Bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:58
+var registeredMu sync.Mutex
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:59
+var registered []Job
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:61
+var shorthands = map[string]string{"@yearly": "0 0 1 1 *", "@annually": "0 0 1 1 *", "@monthly": "0 0 1 * *", "@weekly": "0 0 * * 0", "@daily": "0 0 * * *", "@midnight": "0 0 * * *", "@hourly": "0 * * * *"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:71
+var monthNames = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:73
+var weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:78
+func Parse(spec string) (Schedule, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:79
+	text := strings.TrimSpace(spec)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:80
+	interval, isEvery := strings.CutPrefix(text, "@every ")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:81
+	if isEvery {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:82
+		every, err := time.ParseDuration(strings.TrimSpace(interval))
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:83
+		if err != nil || every <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:84
+			return Schedule{}, fmt.Errorf("cron: bad interval in %q (expected a duration such as 90s)", spec)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:85
+		return Schedule{spec: spec, every: every}, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:86
+	expanded, isShorthand := shorthands[text]
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:87
+	if isShorthand {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:88
+		text = expanded
+	} else if strings.HasPrefix(text, "@") {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:90
+		return Schedule{}, fmt.Errorf("cron: unknown shorthand %q", text)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:92
+	fields := strings.Fields(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:93
+	if len(fields) != 5 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:94
+		return Schedule{}, fmt.Errorf("cron: %q has %d fields, expected 5 (minute hour day month weekday)", spec, len(fields))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:95
+	minutes, err_1 := parseField("minute", fields[0], 0, 59, nil)
+	if err_1 != nil {
+		var _zero0 Schedule
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:96
+	hours, err_2 := parseField("hour", fields[1], 0, 23, nil)
+	if err_2 != nil {
+		var _zero0 Schedule
+		return _zero0, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:97
+	days, err_3 := parseField("day", fields[2], 1, 31, nil)
+	if err_3 != nil {
+		var _zero0 Schedule
+		return _zero0, err_3
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:98
+	months, err_4 := parseField("month", fields[3], 1, 12, monthNames)
+	if err_4 != nil {
+		var _zero0 Schedule
+		return _zero0, err_4
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:99
+	weekdays, err_5 := parseField("weekday", fields[4], 0, 7, weekdayNames)
+	if err_5 != nil {
+		var _zero0 Schedule
+		return _zero0, err_5
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:101
+	weekdays[0] = weekdays[0] || weekdays[7]
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:102
+	return Schedule{spec: spec, minutes: minutes, hours: hours, days: days, months: months, weekdays: weekdays, anyDay: strings.HasPrefix(fields[2], "*"), anyWeekday: strings.HasPrefix(fields[4], "*")}, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:114
+func (s Schedule) String() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:115
+	return s.spec
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:118
+func parseField(name string, text string, low int, high int, names []string) ([]bool, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:119
+	matches := make([]bool, high+1)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:120
+	for _, part := range strings.Split(text, ",") {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:121
+		span, stepText, hasStep := strings.Cut(part, "/")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:122
+		step := 1
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:123
+		if hasStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:124
+			n, err := strconv.Atoi(stepText)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:125
+			if err != nil || n <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:126
+				return nil, fmt.Errorf("cron: bad step %q in %s field %q", stepText, name, text)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:127
+			step = n
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:128
+		first := low
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:129
+		last := high
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:130
+		if span != "*" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:131
+			start, end, isRange := strings.Cut(span, "-")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:132
+			first = fieldValue(start, names)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:133
+			if isRange {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:134
+				last = fieldValue(end, names)
+			} else if !hasStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:136
+				last = first
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:137
+			if first < low || last > high || first > last {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:138
+				return nil, fmt.Errorf("cron: %s field %q must be within %d-%d", name, text, low, high)
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:139
+		v := first
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:140
+		for v <= last {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:141
+			matches[v] = true
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:142
+			v = v + step
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:143
+	return matches, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:146
+func fieldValue(text string, names []string) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:147
+	n, err := strconv.Atoi(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:148
+	if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:149
+		return n
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:150
+	if text == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:151
+		return -1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:152
+	return slices.Index(names, strings.ToUpper(text))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:157
+func Next(s Schedule, t time.Time) time.Time {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:158
+	if s.every > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:159
+		return t.Add(s.every)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:160
+	next := t.Truncate(time.Minute).Add(time.Minute)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:161
+	limit := next.AddDate(5, 0, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:162
+	for next.Before(limit) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:163
+		if !s.months[int(next.Month())] {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:164
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:165
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:166
+		if !dayMatches(s, next) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:167
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:168
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:169
+		if !s.hours[next.Hour()] {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:170
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:171
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:172
+		if !s.minutes[next.Minute()] {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:173
+			next = next.Add(time.Minute)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:174
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:175
+		return next
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:176
+	return time.Time{}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:180
+func dayMatches(s Schedule, t time.Time) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:181
+	day := s.days[t.Day()]
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:182
+	weekday := s.weekdays[int(t.Weekday())]
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:183
+	if s.anyDay || s.anyWeekday {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:184
+		return day && weekday
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:185
+	return day || weekday
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:189
+func Register(name string, spec string, run func(context.Context) error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:190
+	_, err := Parse(spec)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:191
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:192
+		return fmt.Errorf("cron: job %s: %w", name, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:193
+	registeredMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:194
+	defer registeredMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:195
+	registered = append(registered, Job{Name: name, Spec: spec, Run: run})
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:196
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:199
+func Jobs() []Job {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:200
+	registeredMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:201
+	defer registeredMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:202
+	return slices.Clone(registered)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:206
+func Run(ctx context.Context) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:207
+	return RunJobs(ctx, Jobs())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:215
+func RunJobs(ctx context.Context, jobs []Job) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:216
+	if len(jobs) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:217
+		return errors.New("cron: no jobs to run")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:218
+	now := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:219
+	entries := []*entry{}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:220
+	for _, job := range jobs {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:221
+		s, err := Parse(job.Spec)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:222
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:223
+			return fmt.Errorf("cron: job %s: %w", job.Name, err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:224
+		e := &entry{job: job, schedule: s, next: Next(s, now)}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:225
+		if e.next.IsZero() {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:226
+			return fmt.Errorf("cron: job %s: %q never runs", job.Name, job.Spec)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:227
+		entries = append(entries, e)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:228
+		slog.Info("job scheduled", "job", job.Name, "schedule", job.Spec, "next", e.next.Format(time.RFC3339))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:230
+	running := &sync.WaitGroup{}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:231
+	for ctx.Err() == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:232
+		due := entries[0].next
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:233
+		for _, e := range entries {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:234
+			if e.next.Before(due) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:235
+				due = e.next
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:236
+		timer := time.NewTimer(time.Until(due))
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:237
+		select {
+		case <-ctx.Done():
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:239
+			timer.Stop()
+		case <-timer.C:
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:241
+			now = time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:242
+			for _, e := range entries {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:243
+				if !e.next.After(now) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:244
+					start(ctx, e, running)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:245
+					e.next = Next(e.schedule, now)
+				}
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:247
+	slog.Info("worker stopping, waiting for running jobs")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:248
+	running.Wait()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:249
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:252
+func start(ctx context.Context, e *entry, running *sync.WaitGroup) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:253
+	if !e.busy.CompareAndSwap(false, true) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:254
+		slog.Warn("job skipped, previous run still in progress", "job", e.job.Name)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:255
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:256
+	running.Add(1)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:257
+	go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:258
+		defer running.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:259
+		defer e.busy.Store(false)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:260
+		started := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:261
+		slog.Info("job started", "job", e.job.Name)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:262
+		err := call(ctx, e.job.Run)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:263
+		elapsed := time.Since(started).Round(time.Millisecond).String()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:264
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:265
+			slog.Error("job failed", "job", e.job.Name, "duration", elapsed, "error", err)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:266
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:267
+		slog.Info("job finished", "job", e.job.Name, "duration", elapsed)
+	}()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:271
+func call(ctx context.Context, run func(context.Context) error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:272
+	result := errors.New("job did not return")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:273
+	err_2 := func() (err_1 error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err_1 = fmt.Errorf("panic: %v", r)
+			}
+		}()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:274
+		result = run(ctx)
+		return nil
+	}()
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron.kuki:276
+	return result
+}
@@ -0,0 +1,194 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package cron_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/cron"
+	"github.com/duber000/kukicha/stdlib/test"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:14
+type NextCase struct {
+	name string
+	spec string
+	want string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:20
+func TestNext(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:22
+	now := time.Date(2026, 10, 18, 9, 30, 20, 0, time.UTC)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:23
+	cases := []NextCase{NextCase{name: "step", spec: "*/15 * * * *", want: "2026-10-18T09:45:00Z"}, NextCase{name: "step from a value", spec: "5/20 * * * *", want: "2026-10-18T09:45:00Z"}, NextCase{name: "weekday range", spec: "0 9-17 * * MON-FRI", want: "2026-10-19T09:00:00Z"}, NextCase{name: "current minute is past", spec: "30 9 * * *", want: "2026-10-19T09:30:00Z"}, NextCase{name: "sunday as 7", spec: "0 0 * * 7", want: "2026-10-25T00:00:00Z"}, NextCase{name: "either day field", spec: "0 0 13 * FRI", want: "2026-10-23T00:00:00Z"}, NextCase{name: "month names", spec: "0 12 * JAN,jul *", want: "2027-01-01T12:00:00Z"}, NextCase{name: "leap day", spec: "0 0 29 2 *", want: "2028-02-29T00:00:00Z"}, NextCase{name: "hourly", spec: "@hourly", want: "2026-10-18T10:00:00Z"}, NextCase{name: "daily", spec: "@daily", want: "2026-10-19T00:00:00Z"}, NextCase{name: "weekly", spec: "@weekly", want: "2026-10-25T00:00:00Z"}, NextCase{name: "monthly", spec: "@monthly", want: "2026-11-01T00:00:00Z"}, NextCase{name: "yearly", spec: "@yearly", want: "2027-01-01T00:00:00Z"}, NextCase{name: "every", spec: "@every 90s", want: "2026-10-18T09:31:50Z"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:39
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:40
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:41
+			s, err_1 := cron.Parse(tc.spec)
+			if err_1 != nil {
+				panic(fmt.Sprintf("%v", err_1))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:42
+			test.AssertEqual(t, cron.Next(s, now).Format(time.RFC3339), tc.want)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:43
+			test.AssertEqual(t, s.String(), tc.spec)
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:46
+	never, err_1 := cron.Parse("0 0 30 2 *")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:47
+	test.AssertTrue(t, cron.Next(never, now).IsZero())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:50
+func TestParseErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:51
+	specs := []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "1,,2 * * * *", "* * * FOO *", "@sometimes", "@every soon", "@every -5s"}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:64
+	for _, spec := range specs {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:65
+		t.Run(spec, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:66
+			_, err := cron.Parse(spec)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:67
+			test.AssertError(t, err)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:71
+type counter struct {
+	mu         sync.Mutex
+	runs       int
+	active     atomic.Int32
+	overlapped bool
+	hold       time.Duration
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:78
+func (c *counter) run(ctx context.Context) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:79
+	if c.active.Add(1) > 1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:80
+		c.overlapped = true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:81
+	defer c.active.Add(-1)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:82
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:83
+	c.runs = c.runs + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:84
+	c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:85
+	time.Sleep(c.hold)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:86
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:88
+func (c *counter) count() int {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:89
+	c.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:90
+	defer c.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:91
+	return c.runs
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:94
+func TestRunJobs(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:95
+	quick := &counter{}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:96
+	slow := &counter{hold: 60 * time.Millisecond}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:97
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:98
+	defer cancel()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:99
+	jobs := []cron.Job{cron.Job{Name: "quick", Spec: "@every 20ms", Run: quick.run}, cron.Job{Name: "slow", Spec: "@every 10ms", Run: slow.run}, cron.Job{Name: "broken", Spec: "@every 30ms", Run: failing}}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:104
+	started := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:105
+	err_1 := cron.RunJobs(ctx, jobs)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:107
+	test.AssertTrue(t, time.Since(started) >= 150*time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:108
+	test.AssertTrue(t, quick.count() >= 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:110
+	test.AssertFalse(t, slow.overlapped)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:111
+	test.AssertTrue(t, slow.count() >= 1 && slow.count() <= 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:113
+	test.AssertEqual(t, slow.active.Load(), int32(0))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:115
+func failing(ctx context.Context) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:116
+	panic("disk full")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:119
+func TestRunJobsErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:120
+	ctx := context.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:121
+	test.AssertError(t, cron.RunJobs(ctx, []cron.Job{}))
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:122
+	err := cron.RunJobs(ctx, []cron.Job{cron.Job{Name: "bad", Spec: "* *", Run: failing}})
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:123
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:124
+	err = cron.RunJobs(ctx, []cron.Job{cron.Job{Name: "never", Spec: "0 0 31 4 *", Run: failing}})
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:125
+	test.AssertTrue(t, err != nil && err.Error() == "cron: job never: \"0 0 31 4 *\" never runs")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:128
+func TestRegister(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:129
+	jobs := &counter{}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:130
+	before := len(cron.Jobs())
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:131
+	err_1 := cron.Register("tick", "@every 5ms", jobs.run)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:132
+	err := cron.Register("bad", "@every", jobs.run)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:133
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:134
+	registered := cron.Jobs()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:135
+	test.AssertEqual(t, len(registered), before+1)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:136
+	test.AssertEqual(t, registered[before].Name, "tick")
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:138
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:139
+	defer cancel()
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:140
+	err_2 := cron.Run(ctx)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/cron/cron_test.kuki:141
+	test.AssertTrue(t, jobs.count() >= 2)
+}
@@ -29,7 +29,7 @@ func WithTimeout(parent Handle, seconds int64) Handle {
 		base = context.Background()
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:35
-	timeout := (time.Duration(seconds) * time.Second)
+	timeout := time.Duration(seconds) * time.Second
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:36
 	child, cancel := context.WithTimeout(base, timeout)
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:37
@@ -46,7 +46,7 @@ func WithTimeoutMs(parent Handle, timeoutMs int64) Handle {
 		base = context.Background()
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:48
-	timeout := (time.Duration(timeoutMs) * time.Millisecond)
+	timeout := time.Duration(timeoutMs) * time.Millisecond
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:49
 	child, cancel := context.WithTimeout(base, timeout)
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:50
@@ -91,7 +91,7 @@ func Done(handle Handle) bool {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:80
-	return (handle.ctx.Err() != nil)
+	return handle.ctx.Err() != nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx.kuki:83
@@ -64,7 +64,7 @@ func TestWithDeadlineUnix(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx_test.kuki:48
 	parent := ctx.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx_test.kuki:49
-	deadline := (time.Now().Unix() + 10)
+	deadline := time.Now().Unix() + 10
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx_test.kuki:50
 	h := ctx.WithDeadlineUnix(parent, deadline)
 //line /Users/tluker/repos/go/kukicha/stdlib/ctx/ctx_test.kuki:52
@@ -0,0 +1,324 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package date
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:20
+var patternTokens = []string{"YYYY", "YY", "MMMM", "MMM", "MM", "M", "DDDD", "DDD", "DD", "D", "HH", "H", "hh", "h", "mm", "m", "SSS", "ss", "s", "A", "ZZ", "Z"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:23
+var parseLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "2006-01"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:30
+func Parse(value string) (time.Time, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:31
+	trimmed := strings.TrimSpace(value)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:32
+	for _, layout := range parseLayouts {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:33
+		t, err := time.Parse(layout, trimmed)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:34
+		if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:35
+			return t, nil
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:36
+	return time.Time{}, fmt.Errorf("date.Parse: unrecognized date \"%v\" (want YYYY-MM-DD, YYYY-MM-DD HH:mm[:ss] or RFC 3339)", value)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:40
+func ParseAs(value string, pattern string) (time.Time, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:41
+	return time.Parse(Layout(pattern), value)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:46
+func Format(t time.Time, pattern string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:47
+	out := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:48
+	i := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:49
+	for i < len(pattern) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:50
+		literal, next := quoted(pattern, i)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:51
+		if next > i {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:52
+			out = out + literal
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:53
+			i = next
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:54
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:55
+		tok := matchToken(pattern, i)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:56
+		if tok == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:57
+			out = out + pattern[i:(i+1)]
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:58
+			i = i + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:59
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:60
+		out = out + formatToken(t, tok)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:61
+		i = i + len(tok)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:62
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:68
+func Layout(pattern string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:69
+	out := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:70
+	i := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:71
+	for i < len(pattern) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:72
+		literal, next := quoted(pattern, i)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:73
+		if next > i {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:74
+			out = out + literal
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:75
+			i = next
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:76
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:77
+		tok := matchToken(pattern, i)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:78
+		if tok == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:79
+			out = out + pattern[i:(i+1)]
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:80
+			i = i + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:81
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:82
+		out = out + layoutToken(tok)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:83
+		i = i + len(tok)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:84
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:89
+func Today() time.Time {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:90
+	now := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:91
+	return New(now.Year(), int(now.Month()), now.Day())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:95
+func New(year int, month int, day int) time.Time {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:96
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:100
+func quoted(pattern string, i int) (string, int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:101
+	if pattern[i:(i+1)] != "'" {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:102
+		return "", i
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:103
+	end := strings.Index(pattern[(i+1):], "'")
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:104
+	if end < 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:105
+		return pattern[(i + 1):], len(pattern)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:106
+	return pattern[(i + 1):(i + 1 + end)], i + end + 2
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:109
+func matchToken(pattern string, i int) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:110
+	rest := pattern[i:]
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:111
+	for _, tok := range patternTokens {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:112
+		if strings.HasPrefix(rest, tok) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:113
+			return tok
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:114
+	return ""
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:117
+func formatToken(t time.Time, tok string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:118
+	switch tok {
+	case "YYYY":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:120
+		return fmt.Sprintf("%04d", t.Year())
+	case "YY":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:122
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "MMMM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:124
+		return t.Month().String()
+	case "MMM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:126
+		return t.Month().String()[:3]
+	case "MM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:128
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "M":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:130
+		return fmt.Sprintf("%d", int(t.Month()))
+	case "DDDD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:132
+		return t.Weekday().String()
+	case "DDD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:134
+		return t.Weekday().String()[:3]
+	case "DD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:136
+		return fmt.Sprintf("%02d", t.Day())
+	case "D":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:138
+		return fmt.Sprintf("%d", t.Day())
+	case "HH":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:140
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:142
+		return fmt.Sprintf("%d", t.Hour())
+	case "hh":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:144
+		return fmt.Sprintf("%02d", hour12(t))
+	case "h":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:146
+		return fmt.Sprintf("%d", hour12(t))
+	case "mm":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:148
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:150
+		return fmt.Sprintf("%d", t.Minute())
+	case "ss":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:152
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:154
+		return fmt.Sprintf("%d", t.Second())
+	case "SSS":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:156
+		return fmt.Sprintf("%03d", t.Nanosecond()/1000000)
+	case "A":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:158
+		if t.Hour() < 12 {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:159
+			return "AM"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:160
+		return "PM"
+	case "ZZ":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:162
+		return t.Format("-0700")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:163
+	return t.Format("Z07:00")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:166
+func layoutToken(tok string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:167
+	switch tok {
+	case "YYYY":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:169
+		return "2006"
+	case "YY":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:171
+		return "06"
+	case "MMMM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:173
+		return "January"
+	case "MMM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:175
+		return "Jan"
+	case "MM":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:177
+		return "01"
+	case "M":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:179
+		return "1"
+	case "DDDD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:181
+		return "Monday"
+	case "DDD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:183
+		return "Mon"
+	case "DD":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:185
+		return "02"
+	case "D":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:187
+		return "2"
+	case "HH", "H":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:189
+		return "15"
+	case "hh":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:191
+		return "03"
+	case "h":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:193
+		return "3"
+	case "mm":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:195
+		return "04"
+	case "m":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:197
+		return "4"
+	case "ss":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:199
+		return "05"
+	case "s":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:201
+		return "5"
+	case "SSS":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:203
+		return "000"
+	case "A":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:205
+		return "PM"
+	case "ZZ":
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:207
+		return "-0700"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:208
+	return "Z07:00"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:211
+func hour12(t time.Time) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:212
+	h := t.Hour() % 12
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:213
+	if h == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:214
+		return 12
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date.kuki:215
+	return h
+}
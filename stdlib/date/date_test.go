@@ -0,0 +1,145 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package date_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/date"
+	"github.com/duber000/kukicha/stdlib/test"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:11
+type ParseCase struct {
+	name  string
+	value string
+	want  string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:17
+func TestParse(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:18
+	cases := []ParseCase{ParseCase{name: "date", value: "2024-01-02", want: "2024-01-02T00:00:00Z"}, ParseCase{name: "month", value: "2024-03", want: "2024-03-01T00:00:00Z"}, ParseCase{name: "minutes", value: "2024-01-02 15:04", want: "2024-01-02T15:04:00Z"}, ParseCase{name: "seconds", value: "2024-01-02 15:04:05", want: "2024-01-02T15:04:05Z"}, ParseCase{name: "T separator", value: "2024-01-02T15:04:05", want: "2024-01-02T15:04:05Z"}, ParseCase{name: "rfc3339 offset", value: "2024-01-02T15:04:05+02:00", want: "2024-01-02T15:04:05+02:00"}, ParseCase{name: "surrounding space", value: " 2024-01-02 ", want: "2024-01-02T00:00:00Z"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:27
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:28
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:29
+			got, err_1 := date.Parse(tc.value)
+			if err_1 != nil {
+				panic(fmt.Sprintf("parse failed: %v", err_1))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:30
+			test.AssertEqual(t, got.Format(time.RFC3339), tc.want)
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:32
+	t.Run("unrecognized", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:33
+		_, err := date.Parse("January 2nd")
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:34
+		test.AssertError(t, err)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:38
+type FormatCase struct {
+	name    string
+	pattern string
+	want    string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:44
+func TestFormat(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:45
+	base := time.Date(2024, 1, 2, 15, 4, 5, 123000000, time.UTC)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:46
+	cases := []FormatCase{FormatCase{name: "iso date", pattern: "YYYY-MM-DD", want: "2024-01-02"}, FormatCase{name: "short year", pattern: "DD/MM/YY", want: "02/01/24"}, FormatCase{name: "unpadded", pattern: "D/M/YYYY", want: "2/1/2024"}, FormatCase{name: "names", pattern: "DDDD, D MMMM YYYY", want: "Tuesday, 2 January 2024"}, FormatCase{name: "short names", pattern: "DDD MMM D", want: "Tue Jan 2"}, FormatCase{name: "24h time", pattern: "HH:mm:ss", want: "15:04:05"}, FormatCase{name: "12h time", pattern: "h:mm A", want: "3:04 PM"}, FormatCase{name: "padded 12h", pattern: "hh:mm", want: "03:04"}, FormatCase{name: "millis", pattern: "ss.SSS", want: "05.123"}, FormatCase{name: "zone", pattern: "HH:mmZ", want: "15:04Z"}, FormatCase{name: "numeric zone", pattern: "ZZ", want: "+0000"}, FormatCase{name: "quoted text", pattern: "D MMM 'at' HH:mm", want: "2 Jan at 15:04"}, FormatCase{name: "unterminated quote", pattern: "YYYY 'Day", want: "2024 Day"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:61
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:62
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:63
+			test.AssertEqual(t, date.Format(base, tc.pattern), tc.want)
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:65
+	t.Run("midnight is 12 AM", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:66
+		test.AssertEqual(t, date.Format(date.New(2024, 1, 2), "h A"), "12 AM")
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:70
+func TestLayout(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:71
+	test.AssertEqual(t, date.Layout("YYYY-MM-DD HH:mm:ss"), "2006-01-02 15:04:05")
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:72
+	test.AssertEqual(t, date.Layout("DDD, DD MMM YYYY"), "Mon, 02 Jan 2006")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:75
+func TestParseAs(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:76
+	t.Run("day first", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:77
+		got, err_1 := date.ParseAs("02/01/2024", "DD/MM/YYYY")
+		if err_1 != nil {
+			panic(fmt.Sprintf("parse failed: %v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:78
+		test.AssertTrue(t, got.Equal(date.New(2024, 1, 2)))
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:80
+	t.Run("round trip", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:81
+		pattern := "D MMMM YYYY h:mm A"
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:82
+		text := date.Format(time.Date(2024, 7, 9, 18, 30, 0, 0, time.UTC), pattern)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:83
+		got, err_1 := date.ParseAs(text, pattern)
+		if err_1 != nil {
+			panic(fmt.Sprintf("parse failed: %v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:84
+		test.AssertEqual(t, date.Format(got, pattern), text)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:86
+	t.Run("mismatch", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:87
+		_, err := date.ParseAs("2024-01-02", "DD/MM/YYYY")
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:88
+		test.AssertError(t, err)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:92
+func TestTodayAndNew(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:93
+	t.Run("Today is midnight UTC", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:94
+		today := date.Today()
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:95
+		test.AssertEqual(t, today.Hour(), 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:96
+		test.AssertEqual(t, today.Location(), time.UTC)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:98
+	t.Run("parsed dates compare with Today", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:99
+		past, err_1 := date.Parse("2000-01-01")
+		if err_1 != nil {
+			panic(fmt.Sprintf("parse failed: %v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:100
+		test.AssertTrue(t, past.Before(date.Today()))
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:101
+		test.AssertTrue(t, date.Today().After(past))
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:103
+	t.Run("New normalizes overflow", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/date/date_test.kuki:104
+		test.AssertTrue(t, date.New(2024, 2, 30).Equal(date.New(2024, 3, 1)))
+	})
+}
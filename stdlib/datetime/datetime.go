@@ -102,49 +102,49 @@ func Yesterday() time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:75
 func Nanoseconds(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:76
-	return time.Duration((n * 1))
+	return time.Duration(n * 1)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:80
 func Microseconds(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:81
-	return time.Duration((n * 1000))
+	return time.Duration(n * 1000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:85
 func Milliseconds(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:86
-	return time.Duration((n * 1000000))
+	return time.Duration(n * 1000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:90
 func Seconds(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:91
-	return time.Duration((n * 1000000000))
+	return time.Duration(n * 1000000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:95
 func Minutes(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:96
-	return time.Duration((n * 60000000000))
+	return time.Duration(n * 60000000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:100
 func Hours(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:101
-	return time.Duration((n * 3600000000000))
+	return time.Duration(n * 3600000000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:105
 func Days(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:106
-	return time.Duration((n * 86400000000000))
+	return time.Duration(n * 86400000000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:110
 func Weeks(n int64) time.Duration {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:111
-	return time.Duration((n * 604800000000000))
+	return time.Duration(n * 604800000000000)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:117
@@ -156,7 +156,7 @@ func AddDays(t time.Time, days int) time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:122
 func AddWeeks(t time.Time, weeks int) time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:123
-	return t.AddDate(0, 0, (weeks * 7))
+	return t.AddDate(0, 0, weeks*7)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:127
@@ -180,7 +180,7 @@ func SubDays(t time.Time, days int) time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:142
 func SubWeeks(t time.Time, weeks int) time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:143
-	return t.AddDate(0, 0, (-weeks * 7))
+	return t.AddDate(0, 0, -weeks*7)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:147
@@ -218,7 +218,7 @@ func IsBetween(t time.Time, start time.Time, end time.Time) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:173
 	beforeEnd := t.Before(end)
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:174
-	return ((atOrAfterStart || afterStart) && (atOrBeforeEnd || beforeEnd))
+	return (atOrAfterStart || afterStart) && (atOrBeforeEnd || beforeEnd)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:178
@@ -236,7 +236,7 @@ func IsSameDay(t1 time.Time, t2 time.Time) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:184
 	d2 := t2.Day()
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:185
-	return (((y1 == y2) && (m1 == m2)) && (d1 == d2))
+	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:189
@@ -350,13 +350,13 @@ func Sleep(d time.Duration) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:285
 func SleepSeconds(n int64) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:286
-	time.Sleep((time.Duration(n) * time.Second))
+	time.Sleep(time.Duration(n) * time.Second)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:290
 func SleepMilliseconds(n int64) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:291
-	time.Sleep((time.Duration(n) * time.Millisecond))
+	time.Sleep(time.Duration(n) * time.Millisecond)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:297
@@ -374,10 +374,10 @@ func InLocal(t time.Time) time.Time {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:307
 func InLocation(t time.Time, location string) (time.Time, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:308
-	loc, err_2 := time.LoadLocation(location)
-	if err_2 != nil {
+	loc, err_1 := time.LoadLocation(location)
+	if err_1 != nil {
 		var _zero0 time.Time
-		return _zero0, err_2
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:309
 	return t.In(loc), nil
@@ -386,17 +386,17 @@ func InLocation(t time.Time, location string) (time.Time, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:312
 func getLayout(format string) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:313
-	if (format == "iso8601") || (format == "ISO8601") {
+	if format == "iso8601" || format == "ISO8601" {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:314
 		return "2006-01-02T15:04:05Z07:00"
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:315
-	if (format == "rfc3339") || (format == "RFC3339") {
+	if format == "rfc3339" || format == "RFC3339" {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:316
 		return time.RFC3339
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:317
-	if (format == "rfc3339nano") || (format == "RFC3339Nano") {
+	if format == "rfc3339nano" || format == "RFC3339Nano" {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime.kuki:318
 		return time.RFC3339Nano
 	}
@@ -345,7 +345,7 @@ func TestUnixTimestamps(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:207
 		unixSec := datetime.Unix(tt)
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:208
-		test.AssertTrue(t, (unixSec > 0))
+		test.AssertTrue(t, unixSec > 0)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:210
 	t.Run("UnixMilli is 1000x Unix", func(t *testing.T) {
@@ -354,9 +354,9 @@ func TestUnixTimestamps(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:212
 		unixMilli := datetime.UnixMilli(tt)
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:213
-		test.AssertTrue(t, (unixMilli > 0))
+		test.AssertTrue(t, unixMilli > 0)
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:214
-		test.AssertEqual(t, unixMilli, (unixSec * 1000))
+		test.AssertEqual(t, unixMilli, unixSec*1000)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/datetime/datetime_test.kuki:216
 	t.Run("FromUnix round-trip", func(t *testing.T) {
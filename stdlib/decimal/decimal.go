@@ -0,0 +1,336 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:24
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:31
+func Parse(s string) (Decimal, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:32
+	digits := strings.TrimSpace(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:33
+	sign := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:34
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:35
+		sign = digits[:1]
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:36
+		digits = digits[1:]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:37
+	whole, frac, hasPoint := strings.Cut(digits, ".")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:38
+	if !allDigits(whole) || hasPoint && !allDigits(frac) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:39
+		return Decimal{}, fmt.Errorf("decimal.Parse: invalid decimal %v", s)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:40
+	coef := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:41
+	coef.SetString(sign+whole+frac, 10)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:42
+	return Decimal{coef: coef, scale: len(frac)}, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:48
+func MustParse(s string) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:49
+	d, err := Parse(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:50
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:51
+		panic(err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:52
+	return d
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:56
+func FromInt(n int) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:57
+	return Decimal{coef: big.NewInt(int64(n))}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:63
+func FromFloat(f float64, places int) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:64
+	d, err := Parse(strconv.FormatFloat(f, 'f', max(places, 0), 64))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:65
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:66
+		panic(fmt.Sprintf("decimal.FromFloat: %v is not a finite number", f))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:67
+	return d
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:71
+func (d Decimal) Add(o Decimal) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:72
+	scale := max(d.scale, o.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:73
+	sum := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:74
+	sum.Add(d.rescaled(scale), o.rescaled(scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:75
+	return Decimal{coef: sum, scale: scale}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:79
+func (d Decimal) Sub(o Decimal) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:80
+	scale := max(d.scale, o.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:81
+	diff := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:82
+	diff.Sub(d.rescaled(scale), o.rescaled(scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:83
+	return Decimal{coef: diff, scale: scale}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:87
+func (d Decimal) Mul(o Decimal) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:88
+	product := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:89
+	product.Mul(d.rescaled(d.scale), o.rescaled(o.scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:90
+	return Decimal{coef: product, scale: d.scale + o.scale}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:96
+func (d Decimal) Div(o Decimal, places int) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:97
+	places = max(places, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:98
+	num := d.rescaled(d.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:99
+	num.Mul(num, pow10(places+o.scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:100
+	den := o.rescaled(o.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:101
+	den.Mul(den, pow10(d.scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:102
+	return Decimal{coef: quoRound(num, den), scale: places}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:106
+func (d Decimal) Round(places int) Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:107
+	places = max(places, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:108
+	if places >= d.scale {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:109
+		return d
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:110
+	return Decimal{coef: quoRound(d.rescaled(d.scale), pow10(d.scale-places)), scale: places}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:114
+func (d Decimal) Cmp(o Decimal) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:115
+	scale := max(d.scale, o.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:116
+	return d.rescaled(scale).Cmp(o.rescaled(scale))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:121
+func (d Decimal) Equal(o Decimal) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:122
+	return d.Cmp(o) == 0
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:126
+func (d Decimal) Less(o Decimal) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:127
+	return d.Cmp(o) < 0
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:130
+func (d Decimal) Sign() int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:131
+	if d.coef == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:132
+		return 0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:133
+	return d.coef.Sign()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:136
+func (d Decimal) IsZero() bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:137
+	return d.Sign() == 0
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:140
+func (d Decimal) Neg() Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:141
+	n := d.rescaled(d.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:142
+	n.Neg(n)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:143
+	return Decimal{coef: n, scale: d.scale}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:146
+func (d Decimal) Abs() Decimal {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:147
+	n := d.rescaled(d.scale)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:148
+	n.Abs(n)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:149
+	return Decimal{coef: n, scale: d.scale}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:153
+func (d Decimal) Places() int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:154
+	return d.scale
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:158
+func (d Decimal) String() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:159
+	digits := d.rescaled(d.scale).String()
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:160
+	sign := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:161
+	if strings.HasPrefix(digits, "-") {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:162
+		sign = "-"
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:163
+		digits = digits[1:]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:164
+	if d.scale == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:165
+		return sign + digits
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:166
+	if len(digits) <= d.scale {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:167
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:168
+	cut := len(digits) - d.scale
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:169
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:173
+func (d Decimal) Float64() float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:174
+	ratio := &big.Rat{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:175
+	ratio.SetFrac(d.rescaled(d.scale), pow10(d.scale))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:176
+	f, _ := ratio.Float64()
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:177
+	return f
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:181
+func (d Decimal) MarshalJSON() ([]byte, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:182
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:185
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:186
+	parsed, err_1 := Parse(strings.Trim(string(data), "\""))
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:187
+	d.coef = parsed.coef
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:188
+	d.scale = parsed.scale
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:189
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:193
+func (d Decimal) rescaled(scale int) *big.Int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:194
+	n := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:195
+	if d.coef != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:196
+		n.Set(d.coef)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:197
+	if scale > d.scale {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:198
+		n.Mul(n, pow10(scale-d.scale))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:199
+	return n
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:202
+func pow10(n int) *big.Int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:203
+	p := big.NewInt(10)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:204
+	return p.Exp(p, big.NewInt(int64(n)), nil)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:207
+func quoRound(num *big.Int, den *big.Int) *big.Int {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:208
+	q := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:209
+	r := &big.Int{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:210
+	q.QuoRem(num, den, r)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:211
+	r.Abs(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:212
+	r.Lsh(r, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:213
+	if r.CmpAbs(den) >= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:214
+		if num.Sign() == den.Sign() {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:215
+			q.Add(q, big.NewInt(1))
+		} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:217
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:218
+	return q
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:221
+func allDigits(s string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:222
+	if s == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:223
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:224
+	for _, r := range s {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:225
+		if r < '0' || r > '9' {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:226
+			return false
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal.kuki:227
+	return true
+}
@@ -0,0 +1,134 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package decimal_test
+
+import (
+	"encoding/json"
+	"github.com/duber000/kukicha/stdlib/decimal"
+	"github.com/duber000/kukicha/stdlib/test"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:11
+func TestParse(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:12
+	t.Run("valid", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:13
+		for _, text := range []string{"19.99", "-0.50", "+3", "0", "007.10"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:14
+			d, err := decimal.Parse(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:15
+			test.AssertNoError(t, err, text)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:16
+			test.AssertEqual(t, d.String(), trimZeros(trimPlus(text)))
+		}
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:18
+	t.Run("invalid", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:19
+		for _, text := range []string{"", "-", ".5", "1.", "1e3", "1_000", "12a"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:20
+			_, err := decimal.Parse(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:21
+			test.AssertError(t, err, text)
+		}
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:25
+func TestExactArithmetic(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:26
+	test.AssertTrue(t, decimal.MustParse("0.1").Add(decimal.MustParse("0.2")).Equal(decimal.MustParse("0.3")))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:27
+	test.AssertEqual(t, decimal.MustParse("19.99").Mul(decimal.MustParse("3")).Add(decimal.MustParse("4.50")).String(), "64.47")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:28
+	test.AssertEqual(t, decimal.MustParse("1.25").Sub(decimal.MustParse("2")).String(), "-0.75")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:29
+	test.AssertEqual(t, decimal.MustParse("0.001").Mul(decimal.MustParse("0.001")).String(), "0.000001")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:30
+	test.AssertTrue(t, decimal.MustParse("1.50").Equal(decimal.MustParse("1.5")))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:31
+	test.AssertTrue(t, decimal.MustParse("0.99").Less(decimal.MustParse("1")) && !decimal.MustParse("1.00").Less(decimal.MustParse("1")) && decimal.MustParse("-2").Less(decimal.MustParse("2")))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:32
+	zero := decimal.Decimal{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:33
+	test.AssertTrue(t, zero.IsZero())
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:34
+	test.AssertEqual(t, zero.Add(decimal.MustParse("1.5")).String(), "1.5")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:37
+func TestDivAndRound(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:38
+	test.AssertEqual(t, decimal.MustParse("10").Div(decimal.MustParse("3"), 2).String(), "3.33")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:39
+	test.AssertEqual(t, decimal.MustParse("2").Div(decimal.MustParse("3"), 2).String(), "0.67")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:40
+	test.AssertEqual(t, decimal.MustParse("-2").Div(decimal.MustParse("3"), 0).String(), "-1")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:41
+	test.AssertEqual(t, decimal.MustParse("1.5").Div(decimal.MustParse("0.25"), 1).String(), "6.0")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:42
+	test.AssertEqual(t, decimal.MustParse("2.345").Round(2).String(), "2.35")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:43
+	test.AssertEqual(t, decimal.MustParse("-2.345").Round(2).String(), "-2.35")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:44
+	test.AssertEqual(t, decimal.MustParse("2.344").Round(2).String(), "2.34")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:45
+	test.AssertEqual(t, decimal.MustParse("2.5").Round(4).String(), "2.5")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:48
+func TestConversions(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:49
+	test.AssertEqual(t, decimal.FromInt(-42).String(), "-42")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:50
+	test.AssertEqual(t, decimal.FromFloat(0.1, 2).String(), "0.10")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:51
+	test.AssertEqual(t, decimal.FromFloat(2.675, 1).String(), "2.7")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:52
+	test.AssertEqual(t, decimal.MustParse("19.99").Float64(), 19.99)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:53
+	test.AssertEqual(t, decimal.MustParse("19.90").Places(), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:54
+	test.AssertEqual(t, decimal.MustParse("-3.5").Abs().String(), "3.5")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:55
+	test.AssertEqual(t, decimal.MustParse("3.5").Neg().Sign(), -1)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:58
+func TestJSON(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:59
+	data, err := json.Marshal(map[string]decimal.Decimal{"price": decimal.MustParse("19.90")})
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:60
+	test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:61
+	test.AssertEqual(t, string(data), "{\"price\":\"19.90\"}")
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:62
+	parsed := map[string]decimal.Decimal{}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:63
+	test.AssertNoError(t, json.Unmarshal([]byte("{\"a\":\"1.25\",\"b\":2.5}"), &parsed))
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:64
+	test.AssertTrue(t, parsed["a"].Add(parsed["b"]).Equal(decimal.MustParse("3.75")))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:67
+func trimPlus(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:68
+	if len(s) > 0 && s[0] == '+' {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:69
+		return s[1:]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:70
+	return s
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:73
+func trimZeros(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:74
+	for len(s) > 1 && s[0] == '0' && s[1] != '.' {
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:75
+		s = s[1:]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/decimal/decimal_test.kuki:76
+	return s
+}
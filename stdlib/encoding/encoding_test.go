@@ -38,8 +38,8 @@ func TestBase64URLEncoding(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:26
 	test.AssertEqual(t, encoded, "SGVsbG8sIFdvcmxkIQ==")
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:28
-	decoded, err_2 := encoding.Base64URLDecode(encoded)
-	if err_2 != nil {
+	decoded, err_1 := encoding.Base64URLDecode(encoded)
+	if err_1 != nil {
 		panic("base64url decode failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:29
@@ -81,8 +81,8 @@ func TestHexEncoding(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:53
 	test.AssertEqual(t, encoded, "48656c6c6f")
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:55
-	decoded, err_3 := encoding.HexDecode(encoded)
-	if err_3 != nil {
+	decoded, err_1 := encoding.HexDecode(encoded)
+	if err_1 != nil {
 		panic("hex decode failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:56
@@ -98,8 +98,8 @@ func TestEmptyInput(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:63
 	test.AssertEqual(t, encoded, "")
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:65
-	decoded, err_4 := encoding.Base64Decode("")
-	if err_4 != nil {
+	decoded, err_1 := encoding.Base64Decode("")
+	if err_1 != nil {
 		panic("empty base64 decode failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:66
@@ -109,8 +109,8 @@ func TestEmptyInput(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:69
 	test.AssertEqual(t, hexEncoded, "")
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:71
-	hexDecoded, err_5 := encoding.HexDecode("")
-	if err_5 != nil {
+	hexDecoded, err_2 := encoding.HexDecode("")
+	if err_2 != nil {
 		panic("empty hex decode failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:72
@@ -140,8 +140,8 @@ func TestRoundTrip(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:89
 	b64Encoded := encoding.Base64Encode(testData)
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:90
-	b64Decoded, err_6 := encoding.Base64Decode(b64Encoded)
-	if err_6 != nil {
+	b64Decoded, err_1 := encoding.Base64Decode(b64Encoded)
+	if err_1 != nil {
 		panic("base64 round-trip failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:91
@@ -149,8 +149,8 @@ func TestRoundTrip(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:93
 	b64urlEncoded := encoding.Base64URLEncode(testData)
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:94
-	b64urlDecoded, err_7 := encoding.Base64URLDecode(b64urlEncoded)
-	if err_7 != nil {
+	b64urlDecoded, err_2 := encoding.Base64URLDecode(b64urlEncoded)
+	if err_2 != nil {
 		panic("base64url round-trip failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:95
@@ -158,8 +158,8 @@ func TestRoundTrip(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:97
 	hexEncoded := encoding.HexEncode(testData)
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:98
-	hexDecoded, err_8 := encoding.HexDecode(hexEncoded)
-	if err_8 != nil {
+	hexDecoded, err_3 := encoding.HexDecode(hexEncoded)
+	if err_3 != nil {
 		panic("hex round-trip failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:99
@@ -173,8 +173,8 @@ func TestSpecialCharacters(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:105
 	b64Encoded := encoding.Base64Encode(special)
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:106
-	b64Decoded, err_9 := encoding.Base64Decode(b64Encoded)
-	if err_9 != nil {
+	b64Decoded, err_1 := encoding.Base64Decode(b64Encoded)
+	if err_1 != nil {
 		panic("special chars base64 failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:107
@@ -182,8 +182,8 @@ func TestSpecialCharacters(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:109
 	hexEncoded := encoding.HexEncode(special)
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:110
-	hexDecoded, err_10 := encoding.HexDecode(hexEncoded)
-	if err_10 != nil {
+	hexDecoded, err_2 := encoding.HexDecode(hexEncoded)
+	if err_2 != nil {
 		panic("special chars hex failed")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/encoding/encoding_test.kuki:111
@@ -8,310 +8,608 @@ import (
 	"github.com/duber000/kukicha/stdlib/cast"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"os"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:14
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:18
 func Get(key string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:15
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:19
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:16
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:20
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:17
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:21
 		return "", fmt.Errorf("environment variable %v is not set", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:18
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:22
 	return value, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:23
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:27
 func GetOr(key string, defaultValue string) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:24
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:28
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:29
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:26
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:30
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:27
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:31
 	return value
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:32
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:36
 func GetInt(key string) (int, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:33
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:37
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:34
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:38
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:39
 		return 0, fmt.Errorf("environment variable %v is not set", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:36
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:40
 	n, err := cast.Atoi(value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:37
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:41
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:42
 		return 0, fmt.Errorf("environment variable %v is not a valid integer", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:39
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:43
 	return n, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:44
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:48
 func GetIntOr(key string, defaultValue int) (int, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:45
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:49
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:46
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:50
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:47
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:51
 		return defaultValue, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:48
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:52
 	n, err := cast.Atoi(value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:49
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:53
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:50
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:54
 		return 0, fmt.Errorf("environment variable %v is not a valid integer", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:51
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:55
 	return n, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:56
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:60
 func GetIntOrDefault(key string, defaultValue int) int {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:57
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:61
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:58
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:62
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:59
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:63
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:60
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:64
 	n, err := cast.Atoi(value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:65
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:62
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:66
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:67
 	return n
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:69
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:73
 func GetBool(key string) (bool, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:70
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:74
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:75
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:72
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:76
 		return false, fmt.Errorf("environment variable %v is not set", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:73
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:77
 	return parseBool(key, value)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:78
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:82
 func GetBoolOr(key string, defaultValue bool) (bool, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:83
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:80
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:84
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:81
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:85
 		return defaultValue, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:82
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:86
 	return parseBool(key, value)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:91
 func GetBoolOrDefault(key string, defaultValue bool) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:88
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:92
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:89
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:93
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:90
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:94
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:95
 	result, err := parseBool(key, value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:96
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:93
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:97
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:94
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:98
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:99
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:103
 func GetFloat(key string) (float64, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:100
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:104
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:101
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:105
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:106
 		return 0.0, fmt.Errorf("environment variable %v is not set", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:103
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:107
 	n, err := cast.ParseFloat(value, 64)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:104
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:108
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:105
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:109
 		return 0.0, fmt.Errorf("environment variable %v is not a valid number", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:110
 	return n, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:111
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:115
 func GetFloatOr(key string, defaultValue float64) (float64, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:116
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:113
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:117
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:114
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:118
 		return defaultValue, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:115
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:119
 	n, err := cast.ParseFloat(value, 64)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:120
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:117
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:121
 		return 0.0, fmt.Errorf("environment variable %v is not a valid number", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:118
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:122
 	return n, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:123
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:127
 func GetList(key string, separator string) ([]string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:128
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:125
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:129
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:126
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:130
 		return []string{}, fmt.Errorf("environment variable %v is not set", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:127
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:131
 	return splitAndTrim(value, separator), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:132
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:136
 func GetListOr(key string, separator string, defaultValue []string) []string {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:133
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:137
 	value := os.Getenv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:134
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:138
 	if value == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:135
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:139
 		return defaultValue
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:136
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:140
 	return splitAndTrim(value, separator)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:144
 func Set(key string, value string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:141
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:145
 	return os.Setenv(key, value)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:145
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:149
 func Unset(key string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:146
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:150
 	return os.Unsetenv(key)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:150
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:154
 func IsSet(key string) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:151
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:155
 	_, exists := os.LookupEnv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:152
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:156
 	return exists
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:156
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:160
 func IsSetAndNotEmpty(key string) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:161
 	value, exists := os.LookupEnv(key)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:158
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:162
 	if !exists {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:159
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:163
 		return false
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:164
 	return !(value == "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:164
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:168
 func All() map[string]string {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:165
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:169
 	result := make(map[string]string)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:170
 	for _, e := range os.Environ() {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:167
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:171
 		parts := kukistring.SplitN(e, "=", 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:168
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:172
 		if len(parts) == 2 {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:169
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:173
 			result[parts[0]] = parts[1]
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:170
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:174
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:176
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:191
+func Load[T any](sample T) (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:192
+	config := sample
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:193
+	target := reflect.ValueOf(&config).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:194
+	if target.Kind() != reflect.Struct {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:195
+		return config, fmt.Errorf("env.Load needs a struct, got %v", target.Type())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:197
+	fileVars := make(map[string]string)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:198
+	if fileExists(".env") {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:199
+		var err_1 error
+		fileVars, err_1 = readEnvFile(".env")
+		if err_1 != nil {
+			return config, fmt.Errorf("env.Load: %v", err_1)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:201
+	problems := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:202
+	structType := target.Type()
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:203
+	for i := range structType.NumField() {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:204
+		field := structType.Field(i)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:205
+		if !field.IsExported() {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:206
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:207
+		name, required := fieldEnvName(field)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:208
+		value, exists := os.LookupEnv(name)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:209
+		if !exists {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:210
+			value, exists = fileVars[name]
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:211
+		where := fmt.Sprintf("%v.%v", structType.Name(), field.Name)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:212
+		if !exists || value == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:213
+			if required {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:214
+				problems = append(problems, fmt.Sprintf("%v: %v is required but not set (set it in the environment or in .env)", where, name))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:215
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:216
+		setErr := setField(target.Field(i), value)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:217
+		if setErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:218
+			problems = append(problems, fmt.Sprintf("%v: %v=%v %v", where, name, strconv.Quote(value), setErr))
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:219
+	if len(problems) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:220
+		return config, fmt.Errorf("env.Load: %v", kukistring.Join(problems, "; "))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:221
+	return config, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:228
+func LoadFile(path string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:229
+	vars, err_1 := readEnvFile(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:230
+	for key, value := range vars {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:231
+		if IsSet(key) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:232
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:233
+		err_2 := os.Setenv(key, value)
+		if err_2 != nil {
+			return err_2
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:234
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:240
 func ParseBool(value string) (bool, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:177
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:241
 	lower := kukistring.ToLower(kukistring.TrimSpace(value))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:178
-	if (((lower == "true") || (lower == "1")) || (lower == "yes")) || (lower == "on") {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:179
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:242
+	if lower == "true" || lower == "1" || lower == "yes" || lower == "on" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:243
 		return true, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:180
-	if (((lower == "false") || (lower == "0")) || (lower == "no")) || (lower == "off") {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:244
+	if lower == "false" || lower == "0" || lower == "no" || lower == "off" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:245
 		return false, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:182
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:246
 	return false, errors.New("not a valid boolean")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:187
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:251
 func SplitAndTrim(value string, separator string) []string {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:188
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:252
 	parts := kukistring.Split(value, separator)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:253
 	result := make([]string, 0, len(parts))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:190
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:254
 	for _, part := range parts {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:191
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:255
 		trimmed := kukistring.TrimSpace(part)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:192
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:256
 		if trimmed != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:193
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:257
 			result = append(result, trimmed)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:194
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:258
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:197
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:261
 func parseBool(key string, value string) (bool, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:198
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:262
 	result, err := ParseBool(value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:263
 	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:264
 		return false, fmt.Errorf("environment variable %v is not a valid boolean", key)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:201
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:265
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:204
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:268
 func splitAndTrim(value string, separator string) []string {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:205
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:269
 	return SplitAndTrim(value, separator)
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:273
+func fieldEnvName(field reflect.StructField) (string, bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:274
+	name := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:275
+	required := false
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:276
+	tag, hasTag := field.Tag.Lookup("env")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:277
+	if hasTag {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:278
+		parts := kukistring.Split(tag, ",")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:279
+		name = kukistring.TrimSpace(parts[0])
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:280
+		for _, opt := range parts[1:] {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:281
+			if kukistring.TrimSpace(opt) == "required" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:282
+				required = true
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:283
+	if name == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:284
+		alias := kukistring.Split(field.Tag.Get("json"), ",")[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:285
+		if alias != "" && alias != "-" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:286
+			name = kukistring.ToUpper(alias)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:287
+	if name == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:288
+		name = upperSnake(field.Name)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:289
+	return name, required
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:293
+func upperSnake(name string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:294
+	runes := []rune(name)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:295
+	out := []rune{}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:296
+	for i, r := range runes {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:297
+		if i > 0 && unicode.IsUpper(r) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:298
+			prev := runes[i-1]
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:299
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:300
+			if !unicode.IsUpper(prev) || nextLower {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:301
+				out = append(out, '_')
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:302
+		out = append(out, unicode.ToUpper(r))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:303
+	return string(out)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:306
+func setField(field reflect.Value, value string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:307
+	if field.Type().String() == "time.Duration" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:308
+		d, durErr := time.ParseDuration(value)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:309
+		if durErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:310
+			return errors.New("is not a valid duration (e.g. 30s, 5m)")
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:311
+		field.SetInt(int64(d))
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:312
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:313
+	switch field.Kind() {
+	case reflect.String:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:315
+		field.SetString(value)
+	case reflect.Bool:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:317
+		b, boolErr := ParseBool(value)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:318
+		if boolErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:319
+			return errors.New("is not a valid boolean (true/false, yes/no, 1/0, on/off)")
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:320
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:322
+		n, intErr := strconv.ParseInt(value, 10, field.Type().Bits())
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:323
+		if intErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:324
+			return fmt.Errorf("is not a valid %v", field.Type())
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:325
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:327
+		u, uintErr := strconv.ParseUint(value, 10, field.Type().Bits())
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:328
+		if uintErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:329
+			return fmt.Errorf("is not a valid %v", field.Type())
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:330
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:332
+		f, floatErr := strconv.ParseFloat(value, field.Type().Bits())
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:333
+		if floatErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:334
+			return fmt.Errorf("is not a valid %v", field.Type())
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:335
+		field.SetFloat(f)
+	case reflect.Slice:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:337
+		if field.Type().Elem().Kind() != reflect.String {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:338
+			return fmt.Errorf("cannot be loaded: unsupported field type %v", field.Type())
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:339
+		field.Set(reflect.ValueOf(splitAndTrim(value, ",")))
+	default:
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:341
+		return fmt.Errorf("cannot be loaded: unsupported field type %v", field.Type())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:342
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:345
+func readEnvFile(path string) (map[string]string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:346
+	data, err_1 := os.ReadFile(path)
+	if err_1 != nil {
+		return nil, fmt.Errorf("%v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:347
+	vars := make(map[string]string)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:348
+	for i, line := range kukistring.Split(string(data), "\n") {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:349
+		line = kukistring.TrimSpace(line)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:350
+		if line == "" || kukistring.HasPrefix(line, "#") {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:351
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:352
+		line = kukistring.TrimPrefix(line, "export ")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:353
+		parts := kukistring.SplitN(line, "=", 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:354
+		key := kukistring.TrimSpace(parts[0])
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:355
+		if len(parts) < 2 || key == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:356
+			return nil, fmt.Errorf("%v:%v: expected KEY=VALUE", path, i+1)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:357
+		value := kukistring.TrimSpace(parts[1])
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:358
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:359
+			value = value[1:(len(value) - 1)]
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:360
+		vars[key] = value
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:361
+	return vars, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:364
+func fileExists(path string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:365
+	_, err := os.Stat(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env.kuki:366
+	return err == nil
+}
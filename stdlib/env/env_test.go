@@ -4,494 +4,628 @@ package env_test
 
 import (
 	"github.com/duber000/kukicha/stdlib/env"
+	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"github.com/duber000/kukicha/stdlib/test"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:11
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:14
 func setupTestEnv() {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:12
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:15
 	os.Setenv("TEST_STRING", "hello")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:13
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:16
 	os.Setenv("TEST_INT", "42")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:14
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:17
 	os.Setenv("TEST_BOOL_TRUE", "true")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:15
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:18
 	os.Setenv("TEST_BOOL_FALSE", "false")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:16
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:19
 	os.Setenv("TEST_FLOAT", "3.14")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:17
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:20
 	os.Setenv("TEST_LIST", "a,b,c")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:18
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:21
 	os.Setenv("TEST_EMPTY", "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:21
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:24
 func cleanupTestEnv() {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:22
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:25
 	os.Unsetenv("TEST_STRING")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:23
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:26
 	os.Unsetenv("TEST_INT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:24
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:27
 	os.Unsetenv("TEST_BOOL_TRUE")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:28
 	os.Unsetenv("TEST_BOOL_FALSE")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:26
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:29
 	os.Unsetenv("TEST_FLOAT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:27
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:30
 	os.Unsetenv("TEST_LIST")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:28
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:31
 	os.Unsetenv("TEST_EMPTY")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:31
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:34
 func TestGet(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:32
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:35
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:33
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:36
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:38
 	value, err_1 := env.Get("TEST_STRING")
 	if err_1 != nil {
 		panic("get failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:36
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:39
 	test.AssertEqual(t, value, "hello")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:41
 	_, err := env.Get("NONEXISTENT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:39
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:42
 	test.AssertNotEmpty(t, err)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:42
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:45
 func TestGetOr(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:43
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:46
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:44
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:47
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:46
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:49
 	value := env.GetOr("TEST_STRING", "default")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:47
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:50
 	test.AssertEqual(t, value, "hello")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:49
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:52
 	value2 := env.GetOr("NONEXISTENT", "default")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:50
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:53
 	test.AssertEqual(t, value2, "default")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:53
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:56
 func TestGetInt(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:54
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:57
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:55
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:58
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:57
-	value, err_2 := env.GetInt("TEST_INT")
-	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:60
+	value, err_1 := env.GetInt("TEST_INT")
+	if err_1 != nil {
 		panic("get int failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:58
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:61
 	test.AssertEqual(t, value, 42)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:60
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:63
 	_, err := env.GetInt("NONEXISTENT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:64
 	test.AssertNotEmpty(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:66
 	os.Setenv("TEST_INVALID_INT", "not_a_number")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:64
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:67
 	defer os.Unsetenv("TEST_INVALID_INT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:68
 	_, err2 := env.GetInt("TEST_INVALID_INT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:66
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:69
 	test.AssertNotEmpty(t, err2)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:69
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:72
 func TestGetIntOr(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:70
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:73
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:74
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:73
-	value, err_3 := env.GetIntOr("TEST_INT", 100)
-	if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:76
+	value, err_1 := env.GetIntOr("TEST_INT", 100)
+	if err_1 != nil {
 		panic("get int or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:74
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:77
 	test.AssertEqual(t, value, 42)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:76
-	value2, err_4 := env.GetIntOr("NONEXISTENT", 100)
-	if err_4 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:79
+	value2, err_2 := env.GetIntOr("NONEXISTENT", 100)
+	if err_2 != nil {
 		panic("get int or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:77
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:80
 	test.AssertEqual(t, value2, 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:82
 	os.Setenv("TEST_INVALID_INT", "not_a_number")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:80
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:83
 	defer os.Unsetenv("TEST_INVALID_INT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:81
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:84
 	_, err := env.GetIntOr("TEST_INVALID_INT", 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:82
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:85
 	test.AssertNotEmpty(t, err)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:85
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:88
 func TestGetIntOrDefault(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:86
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:89
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:90
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:89
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:92
 	value := env.GetIntOrDefault("TEST_INT", 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:90
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:93
 	test.AssertEqual(t, value, 42)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:95
 	value2 := env.GetIntOrDefault("NONEXISTENT", 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:93
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:96
 	test.AssertEqual(t, value2, 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:95
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:98
 	os.Setenv("TEST_INVALID_INT", "not_a_number")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:96
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:99
 	defer os.Unsetenv("TEST_INVALID_INT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:97
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:100
 	value3 := env.GetIntOrDefault("TEST_INVALID_INT", 100)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:98
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:101
 	test.AssertEqual(t, value3, 100)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:101
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:104
 func TestGetBool(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:105
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:103
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:106
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:105
-	value, err_5 := env.GetBool("TEST_BOOL_TRUE")
-	if err_5 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:108
+	value, err_1 := env.GetBool("TEST_BOOL_TRUE")
+	if err_1 != nil {
 		panic("get bool failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:109
 	test.AssertTrue(t, value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:108
-	value2, err_6 := env.GetBool("TEST_BOOL_FALSE")
-	if err_6 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:111
+	value2, err_2 := env.GetBool("TEST_BOOL_FALSE")
+	if err_2 != nil {
 		panic("get bool failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:109
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:112
 	test.AssertFalse(t, value2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:111
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:114
 	_, err := env.GetBool("NONEXISTENT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:115
 	test.AssertNotEmpty(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:114
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:117
 	os.Setenv("TEST_INVALID_BOOL", "invalid")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:115
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:118
 	defer os.Unsetenv("TEST_INVALID_BOOL")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:119
 	_, err2 := env.GetBool("TEST_INVALID_BOOL")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:117
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:120
 	test.AssertNotEmpty(t, err2)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:120
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:123
 func TestGetBoolOr(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:121
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:124
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:122
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:125
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:124
-	value, err_7 := env.GetBoolOr("TEST_BOOL_TRUE", false)
-	if err_7 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:127
+	value, err_1 := env.GetBoolOr("TEST_BOOL_TRUE", false)
+	if err_1 != nil {
 		panic("get bool or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:125
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:128
 	test.AssertTrue(t, value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:127
-	value2, err_8 := env.GetBoolOr("NONEXISTENT", true)
-	if err_8 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:130
+	value2, err_2 := env.GetBoolOr("NONEXISTENT", true)
+	if err_2 != nil {
 		panic("get bool or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:128
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:131
 	test.AssertTrue(t, value2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:133
 	os.Setenv("TEST_INVALID_BOOL", "invalid")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:131
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:134
 	defer os.Unsetenv("TEST_INVALID_BOOL")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:132
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:135
 	_, err := env.GetBoolOr("TEST_INVALID_BOOL", false)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:133
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:136
 	test.AssertNotEmpty(t, err)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:136
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:139
 func TestGetBoolOrDefault(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:137
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:140
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:141
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:143
 	value := env.GetBoolOrDefault("TEST_BOOL_TRUE", false)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:141
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:144
 	test.AssertTrue(t, value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:143
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:146
 	value2 := env.GetBoolOrDefault("NONEXISTENT", true)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:144
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:147
 	test.AssertTrue(t, value2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:146
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:149
 	os.Setenv("TEST_INVALID_BOOL", "invalid")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:147
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:150
 	defer os.Unsetenv("TEST_INVALID_BOOL")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:148
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:151
 	value3 := env.GetBoolOrDefault("TEST_INVALID_BOOL", false)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:149
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:152
 	test.AssertFalse(t, value3)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:152
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:155
 func TestGetFloat(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:153
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:156
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:154
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:157
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:156
-	value, err_9 := env.GetFloat("TEST_FLOAT")
-	if err_9 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:159
+	value, err_1 := env.GetFloat("TEST_FLOAT")
+	if err_1 != nil {
 		panic("get float failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:160
 	test.AssertEqual(t, value, 3.14)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:159
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:162
 	_, err := env.GetFloat("NONEXISTENT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:163
 	test.AssertNotEmpty(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:162
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:165
 	os.Setenv("TEST_INVALID_FLOAT", "not_a_number")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:166
 	defer os.Unsetenv("TEST_INVALID_FLOAT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:164
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:167
 	_, err2 := env.GetFloat("TEST_INVALID_FLOAT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:165
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:168
 	test.AssertNotEmpty(t, err2)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:168
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:171
 func TestGetFloatOr(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:169
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:172
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:170
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:173
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:172
-	value, err_10 := env.GetFloatOr("TEST_FLOAT", 2.71)
-	if err_10 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:175
+	value, err_1 := env.GetFloatOr("TEST_FLOAT", 2.71)
+	if err_1 != nil {
 		panic("get float or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:173
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:176
 	test.AssertEqual(t, value, 3.14)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:175
-	value2, err_11 := env.GetFloatOr("NONEXISTENT", 2.71)
-	if err_11 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:178
+	value2, err_2 := env.GetFloatOr("NONEXISTENT", 2.71)
+	if err_2 != nil {
 		panic("get float or failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:176
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:179
 	test.AssertEqual(t, value2, 2.71)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:178
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:181
 	os.Setenv("TEST_INVALID_FLOAT", "not_a_number")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:179
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:182
 	defer os.Unsetenv("TEST_INVALID_FLOAT")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:180
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:183
 	_, err := env.GetFloatOr("TEST_INVALID_FLOAT", 2.71)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:184
 	test.AssertNotEmpty(t, err)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:184
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:187
 func TestGetList(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:185
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:188
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:189
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:188
-	value, err_12 := env.GetList("TEST_LIST", ",")
-	if err_12 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:191
+	value, err_1 := env.GetList("TEST_LIST", ",")
+	if err_1 != nil {
 		panic("get list failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:192
 	test.AssertEqual(t, len(value), 3)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:190
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:193
 	test.AssertEqual(t, value[0], "a")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:191
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:194
 	test.AssertEqual(t, value[1], "b")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:192
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:195
 	test.AssertEqual(t, value[2], "c")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:194
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:197
 	_, err := env.GetList("NONEXISTENT", ",")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:195
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:198
 	test.AssertNotEmpty(t, err)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:198
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:201
 func TestGetListOr(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:202
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:203
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:202
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:205
 	value := env.GetListOr("TEST_LIST", ",", []string{"default"})
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:203
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:206
 	test.AssertEqual(t, len(value), 3)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:204
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:207
 	test.AssertEqual(t, value[0], "a")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:206
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:209
 	value2 := env.GetListOr("NONEXISTENT", ",", []string{"default"})
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:207
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:210
 	test.AssertEqual(t, len(value2), 1)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:208
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:211
 	test.AssertEqual(t, value2[0], "default")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:211
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:214
 func TestSetUnset(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:212
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:215
 	err := env.Set("TEST_NEW_VAR", "new_value")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:213
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:216
 	test.AssertEqual(t, err, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:214
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:217
 	value := os.Getenv("TEST_NEW_VAR")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:215
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:218
 	test.AssertEqual(t, value, "new_value")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:217
-	err_13 := env.Unset("TEST_NEW_VAR")
-	if err_13 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:220
+	err_1 := env.Unset("TEST_NEW_VAR")
+	if err_1 != nil {
 		panic("unset failed")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:218
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:221
 	value2 := os.Getenv("TEST_NEW_VAR")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:219
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:222
 	test.AssertEqual(t, value2, "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:222
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:225
 func TestIsSet(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:223
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:226
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:224
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:227
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:226
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:229
 	test.AssertTrue(t, env.IsSet("TEST_STRING"))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:228
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:231
 	test.AssertFalse(t, env.IsSet("NONEXISTENT"))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:230
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:233
 	test.AssertTrue(t, env.IsSetAndNotEmpty("TEST_STRING"))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:232
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:235
 	test.AssertFalse(t, env.IsSetAndNotEmpty("TEST_EMPTY"))
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:234
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:237
 	test.AssertFalse(t, env.IsSetAndNotEmpty("NONEXISTENT"))
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:237
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:240
 func TestAll(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:238
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:241
 	setupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:239
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:242
 	defer cleanupTestEnv()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:241
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:244
 	all := env.All()
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:242
-	test.AssertNotEmpty(t, all)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:243
-	test.AssertTrue(t, (len(all) >= 7))
 //line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:245
-	test.AssertEqual(t, all["TEST_STRING"], "hello")
+	test.AssertNotEmpty(t, all)
 //line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:246
+	test.AssertTrue(t, len(all) >= 7)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:248
+	test.AssertEqual(t, all["TEST_STRING"], "hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:249
 	test.AssertEqual(t, all["TEST_INT"], "42")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:249
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:252
 func TestParseBool(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:250
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:253
 	value, err := env.ParseBool("true")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:251
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:254
 	test.AssertEqual(t, err, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:252
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:255
 	test.AssertTrue(t, value)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:254
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:257
 	value2, err2 := env.ParseBool("1")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:255
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:258
 	test.AssertEqual(t, err2, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:256
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:259
 	test.AssertTrue(t, value2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:258
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:261
 	value3, err3 := env.ParseBool("yes")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:259
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:262
 	test.AssertEqual(t, err3, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:260
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:263
 	test.AssertTrue(t, value3)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:262
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:265
 	value4, err4 := env.ParseBool("on")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:263
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:266
 	test.AssertEqual(t, err4, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:264
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:267
 	test.AssertTrue(t, value4)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:266
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:269
 	value5, err5 := env.ParseBool("false")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:267
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:270
 	test.AssertEqual(t, err5, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:268
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:271
 	test.AssertFalse(t, value5)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:270
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:273
 	value6, err6 := env.ParseBool("0")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:271
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:274
 	test.AssertEqual(t, err6, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:272
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:275
 	test.AssertFalse(t, value6)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:274
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:277
 	value7, err7 := env.ParseBool("no")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:275
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:278
 	test.AssertEqual(t, err7, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:276
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:279
 	test.AssertFalse(t, value7)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:278
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:281
 	value8, err8 := env.ParseBool("off")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:279
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:282
 	test.AssertEqual(t, err8, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:280
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:283
 	test.AssertFalse(t, value8)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:282
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:285
 	value9, err9 := env.ParseBool("TRUE")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:283
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:286
 	test.AssertEqual(t, err9, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:284
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:287
 	test.AssertTrue(t, value9)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:286
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:289
 	_, err10 := env.ParseBool("invalid")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:287
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:290
 	test.AssertNotEmpty(t, err10)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:290
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:293
 func TestSplitAndTrim(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:291
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:294
 	result := env.SplitAndTrim("a, b,  c", ",")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:292
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:295
 	test.AssertEqual(t, len(result), 3)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:293
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:296
 	test.AssertEqual(t, result[0], "a")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:294
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:297
 	test.AssertEqual(t, result[1], "b")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:295
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:298
 	test.AssertEqual(t, result[2], "c")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:297
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:300
 	result2 := env.SplitAndTrim("a,, b,  ", ",")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:298
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:301
 	test.AssertEqual(t, len(result2), 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:299
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:302
 	test.AssertEqual(t, result2[0], "a")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:300
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:303
 	test.AssertEqual(t, result2[1], "b")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:302
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:305
 	result3 := env.SplitAndTrim("", ",")
-//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:303
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:306
 	test.AssertEqual(t, len(result3), 0)
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:307
+type loadConfig struct {
+	DatabaseURL string `env:"APP_DB,required"`
+	MaxConns    int
+	Debug       bool `json:"verbose"`
+	Timeout     time.Duration
+	Hosts       []string
+	Ratio       float64
+	name        string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:318
+func TestLoad(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:319
+	t.Chdir(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:320
+	t.Setenv("APP_DB", "postgres://db")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:321
+	t.Setenv("VERBOSE", "yes")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:322
+	t.Setenv("TIMEOUT", "5s")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:323
+	t.Setenv("HOSTS", "a, b")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:325
+	cfg, err_1 := env.Load(loadConfig{MaxConns: 10, Ratio: 0.5})
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:326
+		t.Fatalf("Load failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:327
+	test.AssertEqual(t, cfg.DatabaseURL, "postgres://db")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:328
+	test.AssertEqual(t, cfg.MaxConns, 10)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:329
+	test.AssertEqual(t, cfg.Debug, true)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:330
+	test.AssertEqual(t, cfg.Timeout, 5*time.Second)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:331
+	test.AssertEqual(t, cfg.Hosts, []string{"a", "b"})
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:332
+	test.AssertEqual(t, cfg.Ratio, 0.5)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:335
+func TestLoadErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:336
+	t.Chdir(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:337
+	t.Setenv("APP_DB", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:338
+	t.Setenv("MAX_CONNS", "lots")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:340
+	_, err := env.Load(loadConfig{})
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:341
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:342
+	msg := err.Error()
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:343
+	test.AssertTrue(t, kukistring.Contains(msg, "loadConfig.DatabaseURL: APP_DB is required but not set"), msg)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:344
+	test.AssertTrue(t, kukistring.Contains(msg, "loadConfig.MaxConns: MAX_CONNS=\"lots\" is not a valid int"), msg)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:347
+func TestLoadDotEnv(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:348
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:349
+	t.Chdir(dir)
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:350
+	content := "# local settings\nexport APP_DB='sqlite://dev'\nMAX_CONNS=3\n"
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:351
+	err_1 := os.WriteFile(filepath.Join(dir, ".env"), []byte(content), 0644)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:352
+		t.Fatalf("write .env failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:353
+	t.Setenv("MAX_CONNS", "7")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:355
+	cfg, err_2 := env.Load(loadConfig{})
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:356
+		t.Fatalf("Load failed: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:357
+	test.AssertEqual(t, cfg.DatabaseURL, "sqlite://dev")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:358
+	test.AssertEqual(t, cfg.MaxConns, 7)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:361
+func TestLoadFile(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:362
+	dir := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:363
+	path := filepath.Join(dir, "dev.env")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:364
+	err_1 := os.WriteFile(path, []byte("KUKI_LOADFILE_A=\"one\"\nKUKI_LOADFILE_B=two\n"), 0644)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:365
+		t.Fatalf("write failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:366
+	t.Setenv("KUKI_LOADFILE_B", "kept")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:367
+	os.Unsetenv("KUKI_LOADFILE_A")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:368
+	defer os.Unsetenv("KUKI_LOADFILE_A")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:370
+	err_2 := env.LoadFile(path)
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:371
+		t.Fatalf("LoadFile failed: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:372
+	test.AssertEqual(t, os.Getenv("KUKI_LOADFILE_A"), "one")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:373
+	test.AssertEqual(t, os.Getenv("KUKI_LOADFILE_B"), "kept")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:375
+	bad := filepath.Join(dir, "bad.env")
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:376
+	err_3 := os.WriteFile(bad, []byte("not a pair\n"), 0644)
+	if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:377
+		t.Fatalf("write failed: %v", err_3)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/env/env_test.kuki:378
+	test.AssertError(t, env.LoadFile(bad))
+}
@@ -145,7 +145,7 @@ func TestJoin(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/errors/errors_test.kuki:119
 				test.AssertNotEmpty(t, errStr)
 //line /Users/tluker/repos/go/kukicha/stdlib/errors/errors_test.kuki:120
-				test.AssertTrue(t, (len(errStr) > 0))
+				test.AssertTrue(t, len(errStr) > 0)
 			}
 		})
 	}
@@ -0,0 +1,1194 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package expect
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/random"
+	"io/fs"
+	"math"
+	rand "math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:52
+const UpdateEnv = "KUKICHA_UPDATE_SNAPSHOTS"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:56
+const SnapshotDir = "snapshots"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:59
+const maxDepth = 32
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:62
+const maxLineWidth = 60
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:65
+const diffContext = 2
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:68
+const maxDiffCells = 1000000
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:71
+const Runs = 100
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:74
+const maxLength = 20
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:77
+const maxGenDepth = 4
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:80
+const maxShrinks = 1000
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:84
+const alphabet = "aAzZ09 _-.,'\"\\\n\té世🙂"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:86
+var countsMu sync.Mutex
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:87
+var counts = map[string]int{}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:92
+func Snapshot(t *testing.T, got any) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:93
+	t.Helper()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:94
+	path := snapshotPath(t)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:95
+	err := Match(path, got)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:96
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:97
+		t.Error(err)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:102
+func Match(path string, got any) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:103
+	text := snapshotText(got)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:104
+	if os.Getenv(UpdateEnv) != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:105
+		return record(path, text)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:106
+	data, err := os.ReadFile(path)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:107
+	if errors.Is(err, fs.ErrNotExist) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:108
+		return fmt.Errorf("no snapshot %v; run `kukicha test --update-snapshots` to record it", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:109
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:110
+		return fmt.Errorf("reading snapshot: %v", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:111
+	if string(data) == text {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:112
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:113
+	return fmt.Errorf("snapshot %v differs (- snapshot, + got); run `kukicha test --update-snapshots` to accept the new output:\n%v", path, Diff(string(data), text))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:120
+func Show(value any) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:121
+	return show(reflect.ValueOf(value), "", 0)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:127
+func Diff(want string, got string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:128
+	a := strings.Split(want, "\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:129
+	b := strings.Split(got, "\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:130
+	if len(a)*len(b) > maxDiffCells {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:131
+		return firstDifference(a, b)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:134
+	common := make([][]int, len(a)+1)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:135
+	for i := range len(a) + 1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:136
+		common[i] = make([]int, len(b)+1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:138
+	{
+		_iStart, _iEnd, _iStep := len(a)-1, 0, 1
+		if _iStart > _iEnd {
+			_iStep = -1
+		}
+		for i := _iStart; i != _iEnd+_iStep; i += _iStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:139
+			{
+				_jStart, _jEnd, _jStep := len(b)-1, 0, 1
+				if _jStart > _jEnd {
+					_jStep = -1
+				}
+				for j := _jStart; j != _jEnd+_jStep; j += _jStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:140
+					if a[i] == b[j] {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:141
+						common[i][j] = common[i+1][j+1] + 1
+					} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:143
+						common[i][j] = max(common[i+1][j], common[i][j+1])
+					}
+				}
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:145
+	lines := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:146
+	i := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:147
+	j := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:148
+	for i < len(a) || j < len(b) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:149
+		if i < len(a) && j < len(b) && a[i] == b[j] {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:150
+			lines = append(lines, fmt.Sprintf("  %v", a[i]))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:151
+			i++
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:152
+			j++
+		} else if j == len(b) || i < len(a) && common[i+1][j] >= common[i][j+1] {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:154
+			lines = append(lines, fmt.Sprintf("- %v", a[i]))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:155
+			i++
+		} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:157
+			lines = append(lines, fmt.Sprintf("+ %v", b[j]))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:158
+			j++
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:159
+	return trimUnchanged(lines)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:162
+func snapshotPath(t *testing.T) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:163
+	name := t.Name()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:164
+	countsMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:165
+	counts[name] = counts[name] + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:166
+	n := counts[name]
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:167
+	countsMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:168
+	if n == 1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:170
+		t.Cleanup(func() { forget(name) })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:171
+		return filepath.Join(SnapshotDir, filepath.FromSlash(name)+".snap")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:172
+	return filepath.Join(SnapshotDir, filepath.FromSlash(name)+fmt.Sprintf(".%v.snap", n))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:175
+func forget(name string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:176
+	countsMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:177
+	defer countsMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:178
+	delete(counts, name)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:182
+func snapshotText(value any) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:183
+	text, ok := value.(string)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:184
+	if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:185
+		return text
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:186
+	return Show(value) + "\n"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:189
+func record(path string, text string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:190
+	err_1 := os.MkdirAll(filepath.Dir(path), 0755)
+	if err_1 != nil {
+		return fmt.Errorf("recording snapshot: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:191
+	err_2 := os.WriteFile(path, []byte(text), 0644)
+	if err_2 != nil {
+		return fmt.Errorf("recording snapshot: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:192
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:196
+func show(v reflect.Value, indent string, depth int) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:197
+	if !v.IsValid() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:198
+		return "empty"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:199
+	if depth > maxDepth {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:200
+		return "..."
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:201
+	if v.CanInterface() && !isNil(v) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:202
+		stringer, ok := v.Interface().(fmt.Stringer)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:203
+		if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:204
+			return strconv.Quote(stringer.String())
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:205
+	inner := indent + "    "
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:206
+	switch v.Kind() {
+	case reflect.String:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:208
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:210
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:212
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:214
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:216
+		return showFloat(v.Float())
+	case reflect.Pointer:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:218
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:219
+			return "empty"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:220
+		return fmt.Sprintf("reference of %v", show(v.Elem(), indent, depth+1))
+	case reflect.Interface:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:222
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:223
+			return "empty"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:224
+		return show(v.Elem(), indent, depth+1)
+	case reflect.Slice, reflect.Array:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:226
+		if v.Kind() == reflect.Slice && v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:227
+			return "empty"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:228
+		items := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:229
+		for i := range v.Len() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:230
+			items = append(items, show(v.Index(i), inner, depth+1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:231
+		return typeName(v.Type()) + composite(items, indent)
+	case reflect.Map:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:233
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:234
+			return "empty"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:235
+		entries := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:236
+		for _, key := range v.MapKeys() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:237
+			entries = append(entries, fmt.Sprintf("%v: %v", show(key, inner, depth+1), show(v.MapIndex(key), inner, depth+1)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:238
+		sort.Strings(entries)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:239
+		return typeName(v.Type()) + composite(entries, indent)
+	case reflect.Struct:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:241
+		fields := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:242
+		for i := range v.NumField() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:243
+			fields = append(fields, fmt.Sprintf("%v: %v", v.Type().Field(i).Name, show(v.Field(i), inner, depth+1)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:244
+		return typeName(v.Type()) + composite(fields, indent)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:245
+	return fmt.Sprint(v)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:249
+func isNil(v reflect.Value) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:250
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:252
+		return v.IsNil()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:253
+	return false
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:256
+func showFloat(f float64) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:257
+	text := strconv.FormatFloat(f, 'g', -1, 64)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:258
+	if strings.ContainsAny(text, ".eIN") {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:259
+		return text
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:260
+	return text + ".0"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:264
+func composite(items []string, indent string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:265
+	line := fmt.Sprintf("{%v}", strings.Join(items, ", "))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:266
+	if len(line) <= maxLineWidth && !strings.Contains(line, "\n") {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:267
+		return line
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:268
+	b := strings.Builder{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:269
+	b.WriteString("{\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:270
+	for _, item := range items {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:271
+		b.WriteString(fmt.Sprintf("%v    %v,\n", indent, item))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:272
+	b.WriteString(fmt.Sprintf("%v}", indent))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:273
+	return b.String()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:277
+func typeName(t reflect.Type) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:278
+	if t.Name() != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:279
+		if t.PkgPath() == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:280
+			if t.Kind() == reflect.Uint8 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:281
+				return "byte"
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:282
+			return t.Name()
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:283
+		return strings.TrimPrefix(t.String(), "main.")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:284
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:286
+		return fmt.Sprintf("list of %v", typeName(t.Elem()))
+	case reflect.Map:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:288
+		return fmt.Sprintf("map of %v to %v", typeName(t.Key()), typeName(t.Elem()))
+	case reflect.Pointer:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:290
+		return fmt.Sprintf("reference %v", typeName(t.Elem()))
+	case reflect.Chan:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:292
+		return fmt.Sprintf("channel of %v", typeName(t.Elem()))
+	case reflect.Interface:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:294
+		if t.NumMethod() == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:295
+			return "any"
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:296
+	return t.String()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:300
+func trimUnchanged(lines []string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:301
+	keep := make([]bool, len(lines))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:302
+	for i, line := range lines {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:303
+		if strings.HasPrefix(line, "  ") {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:304
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:305
+		{
+			_kStart, _kEnd, _kStep := max(0, i-diffContext), min(len(lines), i+diffContext+1), 1
+			if _kStart > _kEnd {
+				_kStep = -1
+			}
+			for k := _kStart; k != _kEnd; k += _kStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:306
+				keep[k] = true
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:307
+	out := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:308
+	skipping := false
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:309
+	for i, line := range lines {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:310
+		if keep[i] {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:311
+			out = append(out, line)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:312
+			skipping = false
+		} else if !skipping {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:314
+			out = append(out, "  ...")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:315
+			skipping = true
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:316
+	return strings.Join(out, "\n")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:319
+func firstDifference(a []string, b []string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:320
+	for i := range min(len(a), len(b)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:321
+		if a[i] != b[i] {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:322
+			return fmt.Sprintf("line %v:\n- %v\n+ %v", i+1, a[i], b[i])
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:323
+	if len(a) > len(b) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:324
+		return fmt.Sprintf("line %v:\n- %v\n(got ends here)", len(b)+1, a[len(b)])
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:325
+	return fmt.Sprintf("line %v:\n+ %v\n(snapshot ends here)", len(a)+1, b[len(a)])
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:331
+func ForAll[T any](t *testing.T, sample T, property func(T) bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:332
+	t.Helper()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:333
+	err := Check(sample, property)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:334
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:335
+		t.Error(err)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:342
+func Check[T any](sample T, property func(T) bool) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:343
+	seed := propertySeed()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:344
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:345
+	value := sample
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:346
+	target := reflect.ValueOf(&value).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:347
+	fails := func(v reflect.Value) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:349
+		target.Set(clone(v))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:350
+		return failure(func() bool { return property(value) })
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:351
+	{
+		_runStart, _runEnd, _runStep := 1, Runs, 1
+		if _runStart > _runEnd {
+			_runStep = -1
+		}
+		for run := _runStart; run != _runEnd+_runStep; run += _runStep {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:352
+			original := generate(rng, target.Type(), run, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:353
+			why := fails(original)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:354
+			if why == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:355
+				continue
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:356
+			smallest, steps, reason := shrink(original, why, fails)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:357
+			shown := show(smallest, "    ", 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:358
+			report := fmt.Sprintf("property failed on run %v of %v: %v for\n    %v", run, Runs, reason, shown)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:359
+			if steps > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:360
+				report = report + fmt.Sprintf("\n(shrunk in %v steps from %v)", steps, show(original, "", 0))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:361
+			return fmt.Errorf("%v\nseed %v: rerun with `kukicha test --seed %v` to reproduce", report, seed, seed)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:362
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:365
+func propertySeed() int64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:366
+	value := os.Getenv(random.SeedEnv)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:367
+	if value != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:368
+		seed, err := strconv.ParseInt(value, 10, 64)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:369
+		if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:370
+			return seed
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:371
+	return rand.Int64()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:374
+func failure(holds func() bool) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:375
+	why := "returned false"
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:376
+	evaluate(holds, &why)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:377
+	return why
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:380
+func evaluate(holds func() bool, why *string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:381
+	defer func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:382
+		r := recover()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:383
+		if r != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:384
+			*why = fmt.Sprintf("panicked: %v", r)
+		}
+	}()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:386
+	if holds() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:387
+		*why = ""
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:391
+func generate(rng *rand.Rand, typ reflect.Type, size int, depth int) reflect.Value {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:392
+	out := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:393
+	switch typ.Kind() {
+	case reflect.Bool:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:395
+		out.SetBool(rng.IntN(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:397
+		out.SetInt(randomInt(rng, typ.Bits(), size))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:399
+		out.SetUint(randomUint(rng, typ.Bits(), size))
+	case reflect.Float32, reflect.Float64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:401
+		out.SetFloat((rng.Float64()*2 - 1) * float64(size))
+	case reflect.String:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:403
+		out.SetString(randomString(rng, size))
+	case reflect.Slice:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:405
+		n := randomLength(rng, size, depth)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:406
+		out.Set(reflect.MakeSlice(typ, n, n))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:407
+		for i := range n {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:408
+			out.Index(i).Set(generate(rng, typ.Elem(), size, depth+1))
+		}
+	case reflect.Array:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:410
+		for i := range typ.Len() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:411
+			out.Index(i).Set(generate(rng, typ.Elem(), size, depth+1))
+		}
+	case reflect.Map:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:413
+		entries := randomLength(rng, size, depth)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:414
+		out.Set(reflect.MakeMapWithSize(typ, entries))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:416
+		for entries > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:417
+			entries--
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:418
+			out.SetMapIndex(generate(rng, typ.Key(), size, depth+1), generate(rng, typ.Elem(), size, depth+1))
+		}
+	case reflect.Pointer:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:420
+		if depth < maxGenDepth && rng.IntN(5) != 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:421
+			out.Set(reflect.New(typ.Elem()))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:422
+			out.Elem().Set(generate(rng, typ.Elem(), size, depth+1))
+		}
+	case reflect.Struct:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:424
+		for i := range typ.NumField() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:425
+			if typ.Field(i).IsExported() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:426
+				out.Field(i).Set(generate(rng, typ.Field(i).Type, size, depth+1))
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:428
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:432
+func randomInt(rng *rand.Rand, bits int, size int) int64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:433
+	if rng.IntN(10) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:434
+		top := maxInt(bits)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:435
+		edges := []int64{0, 1, -1, top, -top - 1}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:436
+		return edges[rng.IntN(len(edges))]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:437
+	n := int64(size)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:438
+	return rng.Int64N(2*n+1) - n
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:442
+func randomUint(rng *rand.Rand, bits int, size int) uint64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:443
+	if rng.IntN(10) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:444
+		edges := []uint64{0, 1, maxUint(bits)}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:445
+		return edges[rng.IntN(len(edges))]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:446
+	return rng.Uint64N(uint64(size) + 1)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:449
+func maxInt(bits int) int64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:450
+	switch bits {
+	case 8:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:452
+		return math.MaxInt8
+	case 16:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:454
+		return math.MaxInt16
+	case 32:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:456
+		return math.MaxInt32
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:457
+	return math.MaxInt64
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:460
+func maxUint(bits int) uint64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:461
+	switch bits {
+	case 8:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:463
+		return math.MaxUint8
+	case 16:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:465
+		return math.MaxUint16
+	case 32:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:467
+		return math.MaxUint32
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:468
+	return math.MaxUint64
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:471
+func randomString(rng *rand.Rand, size int) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:472
+	runes := []rune(alphabet)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:473
+	out := make([]rune, rng.IntN(min(size, maxLength)+1))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:474
+	for i := range len(out) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:475
+		out[i] = runes[rng.IntN(len(runes))]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:476
+	return string(out)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:479
+func randomLength(rng *rand.Rand, size int, depth int) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:480
+	if depth >= maxGenDepth {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:481
+		return 0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:482
+	return rng.IntN(min(size, maxLength) + 1)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:487
+func shrink(original reflect.Value, why string, fails func(reflect.Value) string) (reflect.Value, int, string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:488
+	current := original
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:489
+	steps := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:490
+	tries := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:491
+	for tries < maxShrinks {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:492
+		improved := false
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:493
+		for _, candidate := range shrinks(current) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:494
+			if tries >= maxShrinks {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:495
+				break
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:496
+			tries++
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:497
+			reason := fails(candidate)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:498
+			if reason != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:499
+				current = candidate
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:500
+				why = reason
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:501
+				steps++
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:502
+				improved = true
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:503
+				break
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:504
+		if !improved {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:505
+			break
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:506
+	return current, steps, why
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:511
+func shrinks(v reflect.Value) []reflect.Value {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:512
+	typ := v.Type()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:513
+	out := []reflect.Value{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:514
+	switch v.Kind() {
+	case reflect.Bool:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:516
+		if v.Bool() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:517
+			out = append(out, reflect.Zero(typ))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:519
+		for _, n := range intShrinks(v.Int()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:520
+			smaller := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:521
+			smaller.SetInt(n)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:522
+			out = append(out, smaller)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:524
+		for _, n := range uintShrinks(v.Uint()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:525
+			smaller := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:526
+			smaller.SetUint(n)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:527
+			out = append(out, smaller)
+		}
+	case reflect.Float32, reflect.Float64:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:529
+		for _, f := range floatShrinks(v.Float()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:530
+			smaller := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:531
+			smaller.SetFloat(f)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:532
+			out = append(out, smaller)
+		}
+	case reflect.String:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:534
+		for _, s := range stringShrinks(v.String()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:535
+			smaller := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:536
+			smaller.SetString(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:537
+			out = append(out, smaller)
+		}
+	case reflect.Slice:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:539
+		n := v.Len()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:540
+		if n > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:541
+			out = append(out, reflect.MakeSlice(typ, 0, 0))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:542
+		if n > 1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:543
+			out = append(out, v.Slice(0, n/2), v.Slice(n/2, n))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:544
+		for i := range n {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:545
+			dropped := reflect.AppendSlice(reflect.MakeSlice(typ, 0, n-1), v.Slice(0, i))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:546
+			out = append(out, reflect.AppendSlice(dropped, v.Slice(i+1, n)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:547
+		for i := range n {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:548
+			for _, element := range shrinks(v.Index(i)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:549
+				items := reflect.MakeSlice(typ, n, n)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:550
+				reflect.Copy(items, v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:551
+				items.Index(i).Set(element)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:552
+				out = append(out, items)
+			}
+		}
+	case reflect.Array:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:554
+		for i := range v.Len() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:555
+			for _, element := range shrinks(v.Index(i)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:556
+				array := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:557
+				array.Set(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:558
+				array.Index(i).Set(element)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:559
+				out = append(out, array)
+			}
+		}
+	case reflect.Map:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:561
+		keys := sortedKeys(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:562
+		if len(keys) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:563
+			out = append(out, reflect.MakeMap(typ))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:564
+		for _, key := range keys {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:565
+			without := copyMap(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:566
+			without.SetMapIndex(key, reflect.Value{})
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:567
+			out = append(out, without)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:568
+		for _, key := range keys {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:569
+			for _, value := range shrinks(v.MapIndex(key)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:570
+				entries := copyMap(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:571
+				entries.SetMapIndex(key, value)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:572
+				out = append(out, entries)
+			}
+		}
+	case reflect.Pointer:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:574
+		if !v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:575
+			out = append(out, reflect.Zero(typ))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:576
+			for _, pointee := range shrinks(v.Elem()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:577
+				ref := reflect.New(typ.Elem())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:578
+				ref.Elem().Set(pointee)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:579
+				out = append(out, ref)
+			}
+		}
+	case reflect.Struct:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:581
+		for i := range v.NumField() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:582
+			if !typ.Field(i).IsExported() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:583
+				continue
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:584
+			for _, field := range shrinks(v.Field(i)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:585
+				record := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:586
+				record.Set(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:587
+				record.Field(i).Set(field)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:588
+				out = append(out, record)
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:589
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:593
+func intShrinks(n int64) []int64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:594
+	if n == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:595
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:596
+	out := []int64{0}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:597
+	d := n / 2
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:598
+	for d != 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:599
+		out = append(out, n-d)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:600
+		d = d / 2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:601
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:604
+func uintShrinks(n uint64) []uint64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:605
+	if n == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:606
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:607
+	out := []uint64{0}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:608
+	d := n / 2
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:609
+	for d != 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:610
+		out = append(out, n-d)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:611
+		d = d / 2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:612
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:615
+func floatShrinks(f float64) []float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:616
+	out := []float64{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:617
+	if f == 0.0 || math.IsNaN(f) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:618
+		return out
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:619
+	out = append(out, 0.0)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:620
+	if math.Trunc(f) != f {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:621
+		out = append(out, math.Trunc(f))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:622
+	if math.Abs(f) >= 1.0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:623
+		out = append(out, f/2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:624
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:627
+func stringShrinks(s string) []string {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:628
+	out := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:629
+	runes := []rune(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:630
+	if len(runes) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:631
+		return out
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:632
+	out = append(out, "")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:633
+	for i := range len(runes) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:634
+		out = append(out, string(runes[:i])+string(runes[(i+1):]))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:635
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:639
+func sortedKeys(m reflect.Value) []reflect.Value {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:640
+	keys := m.MapKeys()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:641
+	sort.Slice(keys, func(i int, j int) bool { return show(keys[i], "", 0) < show(keys[j], "", 0) })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:642
+	return keys
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:645
+func copyMap(m reflect.Value) reflect.Value {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:646
+	out := reflect.MakeMapWithSize(m.Type(), m.Len())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:647
+	for _, key := range m.MapKeys() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:648
+		out.SetMapIndex(key, m.MapIndex(key))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:649
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:653
+func clone(v reflect.Value) reflect.Value {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:654
+	typ := v.Type()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:655
+	switch v.Kind() {
+	case reflect.Slice:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:657
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:658
+			return v
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:659
+		items := reflect.MakeSlice(typ, v.Len(), v.Len())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:660
+		for i := range v.Len() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:661
+			items.Index(i).Set(clone(v.Index(i)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:662
+		return items
+	case reflect.Array:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:664
+		array := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:665
+		for i := range v.Len() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:666
+			array.Index(i).Set(clone(v.Index(i)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:667
+		return array
+	case reflect.Map:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:669
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:670
+			return v
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:671
+		entries := reflect.MakeMapWithSize(typ, v.Len())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:672
+		for _, key := range v.MapKeys() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:673
+			entries.SetMapIndex(key, clone(v.MapIndex(key)))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:674
+		return entries
+	case reflect.Pointer:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:676
+		if v.IsNil() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:677
+			return v
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:678
+		ref := reflect.New(typ.Elem())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:679
+		ref.Elem().Set(clone(v.Elem()))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:680
+		return ref
+	case reflect.Struct:
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:682
+		record := reflect.New(typ).Elem()
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:683
+		record.Set(v)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:684
+		for i := range v.NumField() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:685
+			if typ.Field(i).IsExported() {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:686
+				record.Field(i).Set(clone(v.Field(i)))
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:687
+		return record
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect.kuki:688
+	return v
+}
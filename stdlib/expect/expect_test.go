@@ -0,0 +1,201 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package expect_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/expect"
+	"github.com/duber000/kukicha/stdlib/test"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:15
+type Point struct {
+	X int
+	Y int
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:19
+type Shape struct {
+	Name    string
+	Corners []Point
+	Tags    map[string]bool
+	Origin  *Point
+	Scale   float64
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:26
+type ShowCase struct {
+	name  string
+	value any
+	want  string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:32
+func TestShow(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:33
+	blank := Shape{}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:34
+	square := Shape{Name: "square", Corners: []Point{Point{X: 0, Y: 0}, Point{X: 0, Y: 1}, Point{X: 1, Y: 1}, Point{X: 1, Y: 0}}, Tags: map[string]bool{"regular": true}, Scale: 2.0}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:40
+	cases := []ShowCase{ShowCase{name: "string", value: "a \"b\"", want: "\"a \\\"b\\\"\""}, ShowCase{name: "float", value: 2.0, want: "2.0"}, ShowCase{name: "nil", value: nil, want: "empty"}, ShowCase{name: "list", value: []int{1, 2}, want: "list of int{1, 2}"}, ShowCase{name: "nil list", value: blank.Corners, want: "empty"}, ShowCase{name: "empty list", value: []string{}, want: "list of string{}"}, ShowCase{name: "bytes", value: []byte{1}, want: "list of byte{1}"}, ShowCase{name: "map", value: map[string]int{"b": 2, "a": 1}, want: "map of string to int{\"a\": 1, \"b\": 2}"}, ShowCase{name: "struct", value: Point{X: 1, Y: 2}, want: "expect_test.Point{X: 1, Y: 2}"}, ShowCase{name: "reference", value: &Point{X: 1}, want: "reference of expect_test.Point{X: 1, Y: 0}"}, ShowCase{name: "stringer", value: 90 * time.Second, want: "\"1m30s\""}, ShowCase{name: "long", value: square, want: "expect_test.Shape{\n    Name: \"square\",\n    Corners: list of expect_test.Point{\n        expect_test.Point{X: 0, Y: 0},\n        expect_test.Point{X: 0, Y: 1},\n        expect_test.Point{X: 1, Y: 1},\n        expect_test.Point{X: 1, Y: 0},\n    },\n    Tags: map of string to bool{\"regular\": true},\n    Origin: empty,\n    Scale: 2.0,\n}"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:54
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:55
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:56
+			test.AssertEqual(t, expect.Show(tc.value), tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:60
+func TestDiff(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:61
+	want := "a\nb\nc\nd\ne\nf\ng\nh"
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:62
+	got := "a\nb\nc\nd\ne\nF\ng\nh\ni"
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:63
+	test.AssertEqual(t, expect.Diff(want, got), "  ...\n  d\n  e\n- f\n+ F\n  g\n  h\n+ i")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:66
+func TestMatch(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:67
+	path := filepath.Join(t.TempDir(), "golden", "report.txt")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:68
+	t.Setenv(expect.UpdateEnv, "")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:69
+	err := expect.Match(path, "total: 3\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:70
+	test.AssertTrue(t, err != nil && strings.Contains(err.Error(), "--update-snapshots"), fmt.Sprintf("expected a missing snapshot error, got %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:72
+	t.Setenv(expect.UpdateEnv, "1")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:73
+	test.AssertNoError(t, expect.Match(path, "lines: 2\ntotal: 3\n"))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:74
+	data, err_1 := os.ReadFile(path)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:75
+	test.AssertEqual(t, string(data), "lines: 2\ntotal: 3\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:76
+	t.Setenv(expect.UpdateEnv, "")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:77
+	test.AssertNoError(t, expect.Match(path, "lines: 2\ntotal: 3\n"))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:79
+	err = expect.Match(path, "lines: 2\ntotal: 4\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:80
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:81
+	test.AssertTrue(t, strings.HasSuffix(err.Error(), "  lines: 2\n- total: 3\n+ total: 4\n  "), fmt.Sprintf("unexpected diff: %v", err))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:84
+func TestSnapshot(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:85
+	t.Chdir(t.TempDir())
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:86
+	t.Setenv(expect.UpdateEnv, "1")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:87
+	expect.Snapshot(t, "first\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:88
+	expect.Snapshot(t, []int{1, 2})
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:89
+	t.Run("nested", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:90
+		expect.Snapshot(t, "inner")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:93
+	first, err_1 := os.ReadFile(filepath.Join("snapshots", "TestSnapshot.snap"))
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:94
+	test.AssertEqual(t, string(first), "first\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:95
+	second, err_2 := os.ReadFile(filepath.Join("snapshots", "TestSnapshot.2.snap"))
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:96
+	test.AssertEqual(t, string(second), "list of int{1, 2}\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:97
+	nested, err_3 := os.ReadFile(filepath.Join("snapshots", "TestSnapshot", "nested.snap"))
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:98
+	test.AssertEqual(t, string(nested), "inner")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:101
+func TestForAll(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:102
+	expect.ForAll(t, 0, func(n int) bool { return n+1 > n || n == math.MaxInt })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:103
+	expect.ForAll(t, "", func(s string) bool {
+		return strings.ToUpper(strings.ToLower(s)) == strings.ToUpper(s) || !utf8.ValidString(s)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:104
+	expect.ForAll(t, []int{}, func(items []int) bool { return len(append(items, 1)) == len(items)+1 })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:105
+	expect.ForAll(t, Shape{}, func(s Shape) bool { return expect.Show(s) != "" && (s.Origin == nil || s.Origin.X == s.Origin.X) })
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:108
+func TestCheckShrinks(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:109
+	t.Setenv("KUKICHA_SEED", "7")
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:110
+	err := expect.Check(0, func(n int) bool { return n < 50 })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:111
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:112
+	test.AssertTrue(t, strings.HasPrefix(err.Error(), "property failed on run ") && strings.Contains(err.Error(), ": returned false for\n    50\n"), fmt.Sprintf("expected 50, got %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:113
+	test.AssertTrue(t, strings.HasSuffix(err.Error(), "seed 7: rerun with `kukicha test --seed 7` to reproduce"), fmt.Sprintf("expected the seed, got %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:115
+	err = expect.Check([]int{}, func(items []int) bool { return len(items) < 3 })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:116
+	test.AssertTrue(t, err != nil && strings.Contains(err.Error(), "for\n    list of int{0, 0, 0}\n"), fmt.Sprintf("expected three zeros, got %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:118
+	again := expect.Check([]int{}, func(items []int) bool { return len(items) < 3 })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:119
+	test.AssertEqual(t, again.Error(), err.Error())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:122
+func TestCheckPanics(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:123
+	err := expect.Check([]int{}, func(items []int) bool { return items[0] == items[0] })
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:124
+	test.AssertTrue(t, err != nil && strings.Contains(err.Error(), "panicked: runtime error: index out of range"), fmt.Sprintf("expected a panic, got %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:125
+	test.AssertTrue(t, strings.Contains(err.Error(), "for\n    list of int{}\n"), fmt.Sprintf("expected an empty list, got %v", err))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:128
+func TestCheckCopiesValues(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:130
+	err := expect.Check([]int{}, func(items []int) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:131
+		if len(items) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:132
+			return true
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:133
+		items[0] = -1
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:134
+		return false
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/expect/expect_test.kuki:136
+	test.AssertTrue(t, err != nil && strings.Contains(err.Error(), "for\n    list of int{0}\n"), fmt.Sprintf("expected list of int{0}, got %v", err))
+}
@@ -3,6 +3,7 @@
 package fetch
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/base64"
 	"errors"
@@ -12,32 +13,36 @@ import (
 	"github.com/duber000/kukicha/stdlib/retry"
 	"github.com/duber000/kukicha/stdlib/sandbox"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
+	"golang.org/x/net/websocket"
 	"io"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:31
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:37
 type limitReadCloser struct {
 	r io.Reader
 	c io.Closer
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:41
 func (b *limitReadCloser) Read(p []byte) (int, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:36
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:42
 	return b.r.Read(p)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:44
 func (b *limitReadCloser) Close() error {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:39
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:45
 	return b.c.Close()
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:42
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:48
 type Request struct {
 	url              string
 	headers          map[string]string
@@ -50,114 +55,114 @@ type Request struct {
 	maxBodySize      int64
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:57
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:63
 func New(url string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:58
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:64
 	req := Request{}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:59
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:65
 	req.url = url
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:60
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:66
 	req.headers = make(map[string]string)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:67
 	req.timeoutNs = 30000000000
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:62
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:68
 	req.method = "GET"
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:69
 	req.body = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:64
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:70
 	req.retryMaxAttempts = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:71
 	req.retryDelayMs = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:66
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:72
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:70
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:76
 func Header(req Request, name string, value string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:77
 	req.headers[name] = value
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:72
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:78
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:77
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:83
 func Timeout(req Request, durationNs int64) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:78
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:84
 	req.timeoutNs = durationNs
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:85
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:83
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:89
 func Method(req Request, method string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:84
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:90
 	req.method = method
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:85
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:91
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:90
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:96
 func Body(req Request, data any) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:97
 	req.body = data
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:98
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:97
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:103
 func Transport(req Request, t *http.Transport) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:98
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:104
 	req.transport = t
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:99
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:105
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:104
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:110
 func MaxBodySize(req Request, limit int64) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:105
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:111
 	req.maxBodySize = limit
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:112
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:111
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:117
 func Retry(req Request, maxAttempts int, delayMs int) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:118
 	req.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:113
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:119
 	if delayMs <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:114
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:120
 		req.retryDelayMs = 1000
 	} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:122
 		req.retryDelayMs = delayMs
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:117
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:123
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:120
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:126
 func doOnce(req Request) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:121
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:127
 	client := http.Client{}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:122
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:128
 	if req.transport != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:123
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:129
 		client.Transport = req.transport
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:130
 	client.Timeout = time.Duration(req.timeoutNs)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:127
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:133
 	var bodyData any
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:128
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:134
 	if req.body != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:136
 		switch bodyStr := req.body.(type) {
 		case string:
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:132
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:138
 			bodyData = []byte(bodyStr)
 		default:
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:135
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:141
 			var err_1 error
 			bodyData, err_1 = json.Marshal(req.body)
 			if err_1 != nil {
@@ -165,424 +170,667 @@ func doOnce(req Request) (*http.Response, error) {
 			}
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:137
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:143
 	httpReq, err_2 := createHTTPRequest(req.method, req.url, bodyData)
 	if err_2 != nil {
 		return nil, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:146
 	for name, value := range req.headers {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:141
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:147
 		httpReq.Header.Set(name, value)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:144
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:150
 	if req.body != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:145
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:151
 		contentType := httpReq.Header.Get("Content-Type")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:146
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:152
 		if contentType == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:147
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:153
 			httpReq.Header.Set("Content-Type", "application/json")
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:149
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:155
 	resp, err_3 := client.Do(httpReq)
 	if err_3 != nil {
 		return nil, err_3
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:150
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:156
 	if req.maxBodySize > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:151
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:157
 		resp.Body = &limitReadCloser{r: io.LimitReader(resp.Body, req.maxBodySize), c: resp.Body}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:152
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:158
 	return resp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:163
 func Do(req Request) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:158
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:164
 	if req.retryMaxAttempts <= 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:159
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:165
 		return doOnce(req)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:161
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:167
 	delayMs := req.retryDelayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:162
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:168
 	if delayMs <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:169
 		delayMs = 1000
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:164
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:170
 	cfg := retry.Config{MaxAttempts: req.retryMaxAttempts, InitialDelay: delayMs, Strategy: 1}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:172
 	lastErr := errors.New("no attempts made")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:167
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:173
 	for attempt := range cfg.MaxAttempts {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:168
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:174
 		resp, err := doOnce(req)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:169
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:175
 		if err == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:171
-			if (resp.StatusCode != 429) && (resp.StatusCode != 503) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:172
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:177
+			if resp.StatusCode != 429 && resp.StatusCode != 503 {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:178
 				return resp, nil
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:174
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:180
 			resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:175
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:181
 			lastErr = fmt.Errorf("request failed: status %v", resp.StatusCode)
 		} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:178
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:184
 			lastErr = err
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:179
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:185
 		retry.Sleep(cfg, attempt)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:187
 	return nil, lastErr
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:184
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:190
 func createHTTPRequest(method string, url string, bodyData any) (*http.Request, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:185
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:191
 	if bodyData != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:192
 		bodyBytes := bodyData.([]byte)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:187
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:193
 		req, err := http.NewRequest(method, url, bytes.NewReader(bodyBytes))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:188
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:194
 		return req, err
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:195
 	req, err := http.NewRequest(method, url, nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:190
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:196
 	return req, err
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:196
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:202
 func Get(url string) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:197
-	resp, err_5 := Do(New(url))
-	if err_5 != nil {
-		return nil, err_5
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:203
+	resp, err_2 := Do(New(url))
+	if err_2 != nil {
+		return nil, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:206
 	return resp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:206
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:212
 func SafeGet(url string) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:207
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:213
 	guard := netguard.NewSSRFGuard()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:208
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:214
 	transport := netguard.HTTPTransport(guard)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:209
-	resp, err_7 := Do(Transport(New(url), transport))
-	if err_7 != nil {
-		return nil, err_7
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:215
+	resp, err_2 := Do(Transport(New(url), transport))
+	if err_2 != nil {
+		return nil, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:213
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:219
 	return resp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:220
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:226
 func Post(data any, url string) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:221
-	resp, err_9 := Do(Body(Method(New(url), "POST"), data))
-	if err_9 != nil {
-		return nil, err_9
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:227
+	resp, err_2 := Do(Body(Method(New(url), "POST"), data))
+	if err_2 != nil {
+		return nil, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:226
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:232
 	return resp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:231
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:237
 func CheckStatus(resp *http.Response) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:232
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:238
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:233
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:239
 		return nil, fmt.Errorf("request failed: %v", resp.Status)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:234
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:240
 	return resp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:238
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:244
 func Text(resp *http.Response) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:239
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:245
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:240
-	bodyBytes, err_10 := io.ReadAll(resp.Body)
-	if err_10 != nil {
-		return "", err_10
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:246
+	bodyBytes, err_1 := io.ReadAll(resp.Body)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:241
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:247
 	return string(bodyBytes), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:246
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:252
 func Bytes(resp *http.Response) ([]byte, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:247
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:253
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:248
-	bodyBytes, err_11 := io.ReadAll(resp.Body)
-	if err_11 != nil {
-		return []byte{}, err_11
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:254
+	bodyBytes, err_1 := io.ReadAll(resp.Body)
+	if err_1 != nil {
+		return []byte{}, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:249
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:255
 	return bodyBytes, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:254
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:260
 func Json[T any](resp *http.Response, sample T) (T, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:255
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:261
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:256
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:262
 	data := sample
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:257
-	err_12 := json.UnmarshalRead(resp.Body, &data)
-	if err_12 != nil {
-		err_12 = fmt.Errorf("failed to decode response json: %w", err_12)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:263
+	err_1 := json.UnmarshalRead(resp.Body, &data)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("failed to decode response json: %w", err_1)
 		var _zero0 T
-		return _zero0, err_12
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:258
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:264
 	return data, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:265
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:271
 func Decode(resp *http.Response, target any) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:266
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:272
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:267
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:273
 	return json.UnmarshalRead(resp.Body, target)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:271
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:277
 func PathEscape(value string) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:272
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:278
 	return url.PathEscape(value)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:276
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:282
 func QueryEscape(value string) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:277
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:283
 	return url.QueryEscape(value)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:283
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:289
 func URLTemplate(tmpl string, params map[string]string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:284
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:290
 	result := tmpl
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:285
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:291
 	for key, value := range params {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:286
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:292
 		placeholder := fmt.Sprintf("{%v}", key)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:287
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:293
 		result = kukistring.ReplaceAll(result, placeholder, url.PathEscape(value))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:289
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:295
 	if kukistring.Contains(result, "{") || kukistring.Contains(result, "}") {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:290
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:296
 		return "", fmt.Errorf("unresolved URL template placeholders: %v", result)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:291
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:297
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:296
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:302
 func URLWithQuery(baseURL string, params map[string]string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:297
-	parsed, err_13 := url.Parse(baseURL)
-	if err_13 != nil {
-		return "", fmt.Errorf("%v", err_13)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:303
+	parsed, err_1 := url.Parse(baseURL)
+	if err_1 != nil {
+		return "", fmt.Errorf("%v", err_1)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:298
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:304
 	query := parsed.Query()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:299
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:305
 	for key, value := range params {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:300
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:306
 		query.Set(key, value)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:301
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:307
 	parsed.RawQuery = query.Encode()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:302
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:308
 	return parsed.String(), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:310
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:316
 func BearerAuth(req Request, token string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:311
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:317
 	return Header(req, "Authorization", fmt.Sprintf("Bearer %v", token))
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:315
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:321
 func BasicAuth(req Request, username string, password string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:316
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:322
 	credentials := fmt.Sprintf("%v:%v", username, password)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:317
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:323
 	encoded := base64.StdEncoding.EncodeToString([]byte(credentials))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:318
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:324
 	return Header(req, "Authorization", fmt.Sprintf("Basic %v", encoded))
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:327
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:333
 func FormData(req Request, data map[string]string) Request {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:328
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:334
 	values := url.Values{}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:329
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:335
 	for key, value := range data {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:330
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:336
 		values.Set(key, value)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:331
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:337
 	req.body = values.Encode()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:332
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:338
 	req = Header(req, "Content-Type", "application/x-www-form-urlencoded")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:333
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:339
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:340
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:346
 type Session struct {
 	client    http.Client
 	headers   map[string]string
 	timeoutNs int64
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:347
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:353
 func NewSession() Session {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:348
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:354
 	jar, _ := cookiejar.New(nil)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:349
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:355
 	client := http.Client{Jar: jar}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:350
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:356
 	s := Session{}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:351
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:357
 	s.client = client
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:352
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:358
 	s.headers = make(map[string]string)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:353
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:359
 	s.timeoutNs = 30000000000
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:354
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:360
 	return s
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:358
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:364
 func SessionHeader(s Session, name string, value string) Session {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:359
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:365
 	s.headers[name] = value
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:360
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:366
 	return s
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:364
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:370
 func SessionTimeout(s Session, durationNs int64) Session {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:365
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:371
 	s.timeoutNs = durationNs
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:366
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:372
 	return s
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:371
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:377
 func SessionDo(s Session, req Request) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:373
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:379
 	for name, value := range s.headers {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:374
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:380
 		_, exists := req.headers[name]
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:375
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:381
 		if !exists {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:376
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:382
 			req.headers[name] = value
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:379
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:385
 	if req.timeoutNs == 30000000000 {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:380
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:386
 		req.timeoutNs = s.timeoutNs
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:383
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:389
 	s.client.Timeout = time.Duration(req.timeoutNs)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:386
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:392
 	var bodyData any
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:387
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:393
 	if req.body != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:389
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:395
 		switch bodyStr := req.body.(type) {
 		case string:
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:391
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:397
 			bodyData = []byte(bodyStr)
 		default:
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:393
-			var err_14 error
-			bodyData, err_14 = json.Marshal(req.body)
-			if err_14 != nil {
-				return nil, err_14
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:399
+			var err_1 error
+			bodyData, err_1 = json.Marshal(req.body)
+			if err_1 != nil {
+				return nil, err_1
 			}
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:395
-	httpReq, err_15 := createHTTPRequest(req.method, req.url, bodyData)
-	if err_15 != nil {
-		return nil, err_15
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:401
+	httpReq, err_2 := createHTTPRequest(req.method, req.url, bodyData)
+	if err_2 != nil {
+		return nil, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:397
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:403
 	for name, value := range req.headers {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:398
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:404
 		httpReq.Header.Set(name, value)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:401
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:407
 	if req.body != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:402
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:408
 		contentType := httpReq.Header.Get("Content-Type")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:403
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:409
 		if contentType == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:404
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:410
 			httpReq.Header.Set("Content-Type", "application/json")
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:406
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:412
 	return s.client.Do(httpReq)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:410
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:416
 func SessionGet(s Session, url string) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:411
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:417
 	req := New(url)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:412
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:418
 	return SessionDo(s, req)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:416
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:422
 func SessionPost(s Session, data any, url string) (*http.Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:417
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:423
 	req := New(url)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:418
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:424
 	req = Method(req, "POST")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:419
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:425
 	req.body = data
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:420
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:426
 	return SessionDo(s, req)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:425
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:431
 func SessionTransport(s Session, t *http.Transport) Session {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:426
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:432
 	s.client.Transport = t
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:427
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:433
 	return s
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:435
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:441
 func DownloadTo(resp *http.Response, box sandbox.Root, path string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:436
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:442
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:437
-	bodyBytes, err_16 := io.ReadAll(resp.Body)
-	if err_16 != nil {
-		return err_16
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:443
+	bodyBytes, err_1 := io.ReadAll(resp.Body)
+	if err_1 != nil {
+		return err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:438
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:444
 	return sandbox.WriteString(box, string(bodyBytes), path)
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:454
+type Socket struct {
+	Send    chan string
+	Receive chan string
+	state   *socketState
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:460
+type socketState struct {
+	mu     sync.Mutex
+	err    error
+	closed bool
+	done   chan bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:473
+func WebSocket(url string) (Socket, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:474
+	if !strings.HasPrefix(url, "ws://") && !strings.HasPrefix(url, "wss://") {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:475
+		return Socket{}, fmt.Errorf("fetch.WebSocket %v: URL must start with ws:// or wss://", url)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:477
+	origin := strings.Replace(url, "ws", "http", 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:478
+	config, err_1 := websocket.NewConfig(url, origin)
+	if err_1 != nil {
+		var _zero0 Socket
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:479
+	config.Dialer = &net.Dialer{Timeout: 30 * time.Second}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:480
+	conn, err_2 := websocket.DialConfig(config)
+	if err_2 != nil {
+		var _zero0 Socket
+		return _zero0, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:481
+	ws := Socket{Send: make(chan string), Receive: make(chan string, 16), state: &socketState{done: make(chan bool)}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:482
+	go readMessages(conn, ws)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:483
+	go writeMessages(conn, ws)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:484
+	return ws, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:489
+func SocketErr(ws Socket) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:490
+	if ws.state == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:491
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:492
+	ws.state.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:493
+	defer ws.state.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:494
+	return ws.state.err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:497
+func readMessages(conn *websocket.Conn, ws Socket) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:498
+	forwardMessages(conn, ws)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:499
+	close(ws.Receive)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:502
+func forwardMessages(conn *websocket.Conn, ws Socket) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:503
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:504
+		msg := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:505
+		err := websocket.Message.Receive(conn, &msg)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:506
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:507
+			if err != io.EOF {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:508
+				recordErr(ws.state, err)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:509
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:510
+		select {
+		case ws.Receive <- msg:
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:512
+			continue
+		case <-ws.state.done:
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:514
+			return
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:517
+func writeMessages(conn *websocket.Conn, ws Socket) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:518
+	for msg := range ws.Send {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:519
+		err := websocket.Message.Send(conn, msg)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:520
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:521
+			recordErr(ws.state, err)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:522
+	ws.state.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:523
+	ws.state.closed = true
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:524
+	ws.state.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:525
+	close(ws.state.done)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:526
+	conn.Close()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:529
+func recordErr(state *socketState, err error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:530
+	state.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:531
+	defer state.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:532
+	if state.err == nil && !state.closed {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:533
+		state.err = fmt.Errorf("fetch.WebSocket: %w", err)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:537
+type Event struct {
+	Name string
+	Data string
+	ID   string
+	Err  error
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:553
+func SSE(url string) (func(func(Event) bool), error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:554
+	resp, err_2 := Do(Timeout(Header(New(url), "Accept", "text/event-stream"), 0))
+	if err_2 != nil {
+		return nil, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:559
+	if resp.StatusCode >= 400 {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:560
+		resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:561
+		return nil, fmt.Errorf("fetch.SSE %v: status %v", url, resp.StatusCode)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:562
+	events := Events(resp.Body)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:563
+	seq := func(yield func(Event) bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:564
+		defer resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:565
+		events(yield)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:566
+	return seq, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:572
+func Events(r io.Reader) func(func(Event) bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:573
+	return func(yield func(Event) bool) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:574
+		scanner := bufio.NewScanner(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:575
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:576
+		ev := Event{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:577
+		data := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:578
+		lastID := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:579
+		for scanner.Scan() {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:580
+			line := scanner.Text()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:581
+			if line == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:582
+				if len(data) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:583
+					ev.Data = strings.Join(data, "\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:584
+					ev.ID = lastID
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:585
+					if !yield(ev) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:586
+						return
+					}
+				}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:587
+				ev = Event{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:588
+				data = []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:589
+				continue
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:590
+			if strings.HasPrefix(line, ":") {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:591
+				continue
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:592
+			field, value, _ := strings.Cut(line, ":")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:593
+			value = strings.TrimPrefix(value, " ")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:594
+			if field == "data" {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:595
+				data = append(data, value)
+			} else if field == "event" {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:597
+				ev.Name = value
+			} else if field == "id" {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:599
+				lastID = value
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:600
+		err := scanner.Err()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:601
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch.kuki:602
+			yield(Event{Err: fmt.Errorf("fetch.Events: %w", err)})
+		}
+	}
+}
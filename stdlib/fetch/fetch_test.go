@@ -4,196 +4,200 @@ package fetch_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/duber000/kukicha/stdlib/fetch"
 	"github.com/duber000/kukicha/stdlib/test"
+	"golang.org/x/net/websocket"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:12
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:15
 type TestData struct {
 	Message string `json:"message"`
 	Count   int    `json:"count"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:16
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:19
 type PostData struct {
 	Name  string `json:"name"`
 	Value int    `json:"value"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:21
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:24
 type GetCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:24
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:27
 func TestGet(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:28
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:26
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:29
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:27
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:30
 		w.Write([]byte("Hello, World!"))
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:29
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:32
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:31
-	cases := []GetCase{GetCase{name: "basic get"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:34
+	cases := []GetCase{GetCase{name: "basic get"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:37
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:35
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:38
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:36
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:39
 			resp, err := fetch.Get(server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:37
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:40
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:41
 			test.AssertEqual(t, resp.StatusCode, http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:39
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:42
 			resp.Body.Close()
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:43
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:46
 type JsonCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:46
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:49
 func TestJson(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:47
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:50
 	testData := TestData{Message: "test", Count: 42}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:48
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:51
 	jsonBytes, _ := json.Marshal(testData)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:50
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:53
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:51
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:54
 		w.Header().Set("Content-Type", "application/json")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:52
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:55
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:53
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:56
 		w.Write(jsonBytes)
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:55
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:58
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:57
-	cases := []JsonCase{JsonCase{name: "parse object"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:60
+	cases := []JsonCase{JsonCase{name: "parse object"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:63
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:64
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:62
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:65
 			resp, err := fetch.Get(server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:66
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:64
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:67
 			data, jsonErr := fetch.Json(resp, *new(TestData))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:68
 			test.AssertNoError(t, jsonErr)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:66
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:69
 			test.AssertEqual(t, data.Message, "test")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:67
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:70
 			test.AssertEqual(t, data.Count, 42)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:74
 type JsonArrayCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:74
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:77
 func TestJsonArray(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:75
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:78
 	jsonBytes := []byte("[{\"message\":\"a\",\"count\":1},{\"message\":\"b\",\"count\":2}]")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:76
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:79
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:77
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:80
 		w.Header().Set("Content-Type", "application/json")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:78
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:81
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:82
 		w.Write(jsonBytes)
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:81
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:84
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:83
-	cases := []JsonArrayCase{JsonArrayCase{name: "parse array"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:86
+	cases := []JsonArrayCase{JsonArrayCase{name: "parse array"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:89
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:90
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:88
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:91
 			resp, err := fetch.Get(server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:89
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:92
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:90
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:93
 			data, jsonErr := fetch.Json(resp, []TestData{})
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:94
 			test.AssertNoError(t, jsonErr)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:95
 			test.AssertEqual(t, len(data), 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:93
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:96
 			test.AssertEqual(t, data[0].Message, "a")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:94
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:97
 			test.AssertEqual(t, data[1].Count, 2)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:98
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:101
 type DecodeCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:101
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:104
 func TestDecode(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:105
 	testData := []TestData{TestData{Message: "typed", Count: 7}, TestData{Message: "decode", Count: 9}}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:109
 	jsonBytes, _ := json.Marshal(testData)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:107
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:110
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:108
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:111
 		w.Header().Set("Content-Type", "application/json")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:109
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:112
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:110
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:113
 		w.Write(jsonBytes)
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:115
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:114
-	cases := []DecodeCase{DecodeCase{name: "decode into reference"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:117
+	cases := []DecodeCase{DecodeCase{name: "decode into reference"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:120
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:118
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:121
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:119
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:122
 			resp, err := fetch.Get(server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:120
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:123
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:121
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:124
 			decoded := []TestData{}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:122
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:125
 			decodeErr := fetch.Decode(resp, &decoded)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:123
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:126
 			test.AssertNoError(t, decodeErr)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:127
 			test.AssertEqual(t, len(decoded), 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:125
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:128
 			test.AssertEqual(t, decoded[0].Message, "typed")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:126
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:129
 			test.AssertEqual(t, decoded[1].Count, 9)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:133
 type URLTemplateCase struct {
 	name    string
 	tmpl    string
@@ -202,33 +206,33 @@ type URLTemplateCase struct {
 	wantErr bool
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:137
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:140
 func TestURLTemplate(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:141
 	tmpl := "https://api.example.com/users/{username}/repos/{repo}"
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:143
 	cases := []URLTemplateCase{URLTemplateCase{name: "success with encoding", tmpl: tmpl, args: map[string]string{"username": "acme/dev team", "repo": "hello world"}, want: "https://api.example.com/users/acme%2Fdev%20team/repos/hello%20world", wantErr: false}, URLTemplateCase{name: "missing placeholder", tmpl: tmpl, args: map[string]string{"username": "golang"}, want: "", wantErr: true}}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:156
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:159
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:160
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:158
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:161
 			builtURL, err := fetch.URLTemplate(tc.tmpl, tc.args)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:159
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:162
 			if tc.wantErr {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:163
 				test.AssertError(t, err)
 			} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:162
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:165
 				test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:166
 				test.AssertEqual(t, builtURL, tc.want)
 			}
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:167
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:170
 type URLWithQueryCase struct {
 	name  string
 	url   string
@@ -237,346 +241,485 @@ type URLWithQueryCase struct {
 	want2 string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:174
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:177
 func TestURLWithQuery(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:175
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:178
 	cases := []URLWithQueryCase{URLWithQueryCase{name: "encode multiple params", url: "https://api.example.com/search", query: map[string]string{"q": "go lang", "sort": "stars desc"}, want1: "https://api.example.com/search?q=go+lang&sort=stars+desc", want2: "https://api.example.com/search?sort=stars+desc&q=go+lang"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:184
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:187
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:185
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:188
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:189
 			builtURL, err := fetch.URLWithQuery(tc.url, tc.query)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:187
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:190
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:188
-			if (builtURL != tc.want1) && (builtURL != tc.want2) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:191
+			if builtURL != tc.want1 && builtURL != tc.want2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:192
 				t.Errorf("Unexpected query URL: %v", builtURL)
 			}
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:193
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:196
 type TextCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:196
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:199
 func TestText(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:197
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:200
 	expectedText := "Hello, Kukicha!"
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:198
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:201
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:202
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:203
 		w.Write([]byte(expectedText))
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:202
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:205
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:204
-	cases := []TextCase{TextCase{name: "read as text"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:207
+	cases := []TextCase{TextCase{name: "read as text"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:210
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:208
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:211
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:209
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:212
 			resp, err := fetch.Get(server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:210
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:213
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:211
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:214
 			text, textErr := fetch.Text(resp)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:212
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:215
 			test.AssertNoError(t, textErr)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:213
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:216
 			test.AssertEqual(t, text, expectedText)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:217
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:220
 type PostCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:220
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:223
 func TestPost(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:221
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:224
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:222
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:225
 		test.AssertEqual(t, r.Method, "POST")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:223
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:226
 		w.WriteHeader(http.StatusCreated)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:224
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:227
 		w.Write([]byte("created"))
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:226
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:229
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:228
-	cases := []PostCase{PostCase{name: "post object"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:231
+	cases := []PostCase{PostCase{name: "post object"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:234
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:232
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:235
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:233
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:236
 			postData := PostData{Name: "test", Value: 123}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:234
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:237
 			resp, err := fetch.Post(postData, server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:235
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:238
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:236
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:239
 			test.AssertEqual(t, resp.StatusCode, http.StatusCreated)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:237
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:240
 			resp.Body.Close()
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:241
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:244
 type CheckStatusCase struct {
 	name    string
 	code    int
 	wantErr bool
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:246
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:249
 func TestCheckStatus(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:247
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:250
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:248
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:251
 		if r.URL.Path == "/ok" {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:249
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:252
 			w.WriteHeader(http.StatusOK)
 		} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:251
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:254
 			w.WriteHeader(http.StatusNotFound)
 		}
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:253
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:256
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:255
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:258
 	cases := []CheckStatusCase{CheckStatusCase{name: "success status", code: 200, wantErr: false}, CheckStatusCase{name: "error status", code: 404, wantErr: true}}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:259
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:262
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:260
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:263
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:261
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:264
 			path := "/ok"
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:262
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:265
 			if tc.wantErr {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:263
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:266
 				path = "/notfound"
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:264
-			resp, err := fetch.Get((server.URL + path))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:265
-			test.AssertNoError(t, err)
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:267
-			checkedResp, checkErr := fetch.CheckStatus(resp)
+			resp, err := fetch.Get(server.URL + path)
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:268
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:270
+			checkedResp, checkErr := fetch.CheckStatus(resp)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:271
 			if tc.wantErr {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:269
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:272
 				test.AssertError(t, checkErr)
 			} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:271
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:274
 				test.AssertNoError(t, checkErr)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:272
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:275
 				if checkedResp == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:273
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:276
 					t.Error("Expected non-nil response")
 				}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:274
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:277
 				checkedResp.Body.Close()
 			}
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:278
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:281
 type RequestBuilderCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:281
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:284
 func TestRequestBuilder(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:282
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:285
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:283
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:286
 		test.AssertEqual(t, r.Header.Get("Authorization"), "Bearer test-token")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:284
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:287
 		w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:285
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:288
 		w.Write([]byte("authorized"))
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:287
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:290
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:289
-	cases := []RequestBuilderCase{RequestBuilderCase{name: "builder properties"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:292
+	cases := []RequestBuilderCase{RequestBuilderCase{name: "builder properties"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:295
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:293
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:296
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:294
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:297
 			req := fetch.Header(fetch.New(server.URL), "Authorization", "Bearer test-token")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:295
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:298
 			resp, err := fetch.Do(req)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:296
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:299
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:297
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:300
 			test.AssertEqual(t, resp.StatusCode, http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:298
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:301
 			resp.Body.Close()
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:302
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:305
 type AuthHelpersCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:305
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:308
 func TestAuthHelpers(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:306
-	cases := []AuthHelpersCase{AuthHelpersCase{name: "auth headers"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:309
+	cases := []AuthHelpersCase{AuthHelpersCase{name: "auth headers"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:312
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:310
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:313
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:311
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:314
 			serverBearer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:312
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:315
 				test.AssertEqual(t, r.Header.Get("Authorization"), "Bearer my-token")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:313
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:316
 				w.WriteHeader(http.StatusOK)
 			}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:315
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:318
 			defer serverBearer.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:317
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:320
 			reqBearer := fetch.BearerAuth(fetch.New(serverBearer.URL), "my-token")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:318
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:321
 			respBearer, errBearer := fetch.Do(reqBearer)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:319
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:322
 			test.AssertNoError(t, errBearer)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:320
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:323
 			respBearer.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:322
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:325
 			serverBasic := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:323
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:326
 				test.AssertEqual(t, r.Header.Get("Authorization"), "Basic dXNlcjpwYXNz")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:324
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:327
 				w.WriteHeader(http.StatusOK)
 			}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:326
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:329
 			defer serverBasic.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:328
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:331
 			reqBasic := fetch.BasicAuth(fetch.New(serverBasic.URL), "user", "pass")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:329
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:332
 			respBasic, errBasic := fetch.Do(reqBasic)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:330
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:333
 			test.AssertNoError(t, errBasic)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:331
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:334
 			respBasic.Body.Close()
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:335
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:338
 type FormDataCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:338
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:341
 func TestFormData(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:339
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:342
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:340
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:343
 		test.AssertEqual(t, r.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:341
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:344
 		r.ParseForm()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:342
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:345
 		test.AssertEqual(t, r.Form.Get("key"), "value")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:343
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:346
 		w.WriteHeader(http.StatusOK)
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:345
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:348
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:347
-	cases := []FormDataCase{FormDataCase{name: "post form data"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:350
+	cases := []FormDataCase{FormDataCase{name: "post form data"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:353
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:351
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:354
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:352
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:355
 			data := map[string]string{"key": "value"}
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:353
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:356
 			req := fetch.Method(fetch.FormData(fetch.New(server.URL), data), "POST")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:354
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:357
 			resp, err := fetch.Do(req)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:355
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:358
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:356
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:359
 			resp.Body.Close()
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:360
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:363
 type SessionCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:363
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:366
 func TestSession(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:364
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:367
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:365
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:368
 		cookie, err := r.Cookie("session_id")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:366
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:369
 		if err == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:367
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:370
 			test.AssertEqual(t, cookie.Value, "12345")
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:368
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:371
 			w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:369
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:372
 			w.Write([]byte("logged in"))
 		} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:371
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:374
 			http.SetCookie(w, &http.Cookie{Name: "session_id", Value: "12345"})
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:372
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:375
 			w.WriteHeader(http.StatusOK)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:373
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:376
 			w.Write([]byte("cookie set"))
 		}
 	}))
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:375
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:378
 	defer server.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:377
-	cases := []SessionCase{SessionCase{name: "persist cookies"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:380
+	cases := []SessionCase{SessionCase{name: "persist cookies"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:383
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:381
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:384
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:382
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:385
 			session := fetch.NewSession()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:383
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:386
 			resp1, err1 := fetch.SessionGet(session, server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:384
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:387
 			test.AssertNoError(t, err1)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:385
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:388
 			resp1.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:387
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:390
 			resp2, err2 := fetch.SessionGet(session, server.URL)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:388
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:391
 			test.AssertNoError(t, err2)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:389
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:392
 			text, errText := fetch.Text(resp2)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:390
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:393
 			test.AssertNoError(t, errText)
-//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:391
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:394
 			test.AssertEqual(t, text, "logged in")
 		})
 	}
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:398
+type EventsCase struct {
+	name  string
+	input string
+	want  []fetch.Event
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:403
+func TestEvents(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:404
+	cases := []EventsCase{EventsCase{name: "single data", input: "data: hello\n\n", want: []fetch.Event{fetch.Event{Data: "hello"}}}, EventsCase{name: "multi-line data", input: "data: a\ndata: b\n\n", want: []fetch.Event{fetch.Event{Data: "a\nb"}}}, EventsCase{name: "event name", input: "event: ping\ndata: {}\n\n", want: []fetch.Event{fetch.Event{Name: "ping", Data: "{}"}}}, EventsCase{name: "comments skipped", input: ": keep-alive\n\ndata: x\n\n", want: []fetch.Event{fetch.Event{Data: "x"}}}, EventsCase{name: "id persists", input: "id: 7\ndata: a\n\ndata: b\n\n", want: []fetch.Event{fetch.Event{Data: "a", ID: "7"}, fetch.Event{Data: "b", ID: "7"}}}, EventsCase{name: "no space after colon", input: "data:raw\n\n", want: []fetch.Event{fetch.Event{Data: "raw"}}}, EventsCase{name: "unterminated event dropped", input: "data: a\n\ndata: b\n", want: []fetch.Event{fetch.Event{Data: "a"}}}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:413
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:414
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:415
+			got := []fetch.Event{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:416
+			for ev := range fetch.Events(strings.NewReader(tc.input)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:417
+				got = append(got, ev)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:418
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:422
+func TestEventsStop(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:423
+	count := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:424
+	for ev := range fetch.Events(strings.NewReader("data: 1\n\ndata: 2\n\ndata: 3\n\n")) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:425
+		count = count + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:426
+		if ev.Data == "2" {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:427
+			break
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:428
+	test.AssertEqual(t, count, 2)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:431
+type SSECase struct {
+	name   string
+	status int
+	body   string
+	want   []string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:437
+func TestSSE(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:438
+	cases := []SSECase{SSECase{name: "stream", status: http.StatusOK, body: "event: tick\ndata: 1\n\nevent: tick\ndata: 2\n\n", want: []string{"tick:1", "tick:2"}}, SSECase{name: "error status", status: http.StatusInternalServerError, body: "boom"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:442
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:443
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:444
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:445
+				test.AssertEqual(t, r.Header.Get("Accept"), "text/event-stream")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:446
+				w.Header().Set("Content-Type", "text/event-stream")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:447
+				w.WriteHeader(tc.status)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:448
+				w.Write([]byte(tc.body))
+			}))
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:450
+			defer server.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:452
+			events, err := fetch.SSE(server.URL)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:453
+			if tc.want == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:454
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:455
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:456
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:457
+			got := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:458
+			for ev := range events {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:459
+				test.AssertNoError(t, ev.Err)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:460
+				got = append(got, fmt.Sprintf("%v:%v", ev.Name, ev.Data))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:461
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:465
+func TestWebSocket(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:466
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:467
+		io.Copy(conn, conn)
+	}))
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:469
+	defer server.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:471
+	ws, err := fetch.WebSocket("ws" + strings.TrimPrefix(server.URL, "http"))
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:472
+	test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:473
+	ws.Send <- "hello"
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:474
+	reply := <-ws.Receive
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:475
+	test.AssertEqual(t, reply, "hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:476
+	close(ws.Send)
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:477
+	for range ws.Receive {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:478
+		continue
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:479
+	test.AssertNoError(t, fetch.SocketErr(ws))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:482
+func TestWebSocketBadURL(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:483
+	_, err := fetch.WebSocket("http://example.com")
+//line /Users/tluker/repos/go/kukicha/stdlib/fetch/fetch_test.kuki:484
+	test.AssertError(t, err)
+}
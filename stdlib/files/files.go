@@ -30,15 +30,15 @@ func ReadBytes(path string) ([]byte, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:32
 func Write(data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:35
-	pipe_3, err_4 := json.MarshalPretty(data)
-	if err_4 != nil {
-		return err_4
+	pipe_1, err_2 := json.MarshalPretty(data)
+	if err_2 != nil {
+		return err_2
 	}
-	err_5 := os.WriteFile(path, pipe_3, 0644)
-	if err_5 != nil {
-		return err_5
+	err_3 := os.WriteFile(path, pipe_1, 0644)
+	if err_3 != nil {
+		return err_3
 	}
-	_ = pipe_3
+	_ = pipe_1
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:37
 	return nil
 }
@@ -54,16 +54,16 @@ func WriteString(data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:52
 func Append(data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:53
-	file, err_6 := os.OpenFile(path, ((os.O_APPEND | os.O_CREATE) | os.O_WRONLY), 0644)
-	if err_6 != nil {
-		return err_6
+	file, err_1 := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err_1 != nil {
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:54
 	defer file.Close()
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:56
-	jsonData, err_7 := json.Marshal(data)
-	if err_7 != nil {
-		return err_7
+	jsonData, err_2 := json.Marshal(data)
+	if err_2 != nil {
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:58
 	jsonData = append(jsonData, '\n')
@@ -79,9 +79,9 @@ func Append(data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:67
 func AppendString(data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:68
-	file, err_8 := os.OpenFile(path, ((os.O_APPEND | os.O_CREATE) | os.O_WRONLY), 0644)
-	if err_8 != nil {
-		return err_8
+	file, err_1 := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err_1 != nil {
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:69
 	defer file.Close()
@@ -99,8 +99,8 @@ func AppendString(data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:78
 func Exists(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:79
-	_, err_9 := os.Stat(path)
-	if err_9 != nil {
+	_, err_1 := os.Stat(path)
+	if err_1 != nil {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:80
@@ -110,8 +110,8 @@ func Exists(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:85
 func IsDir(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:86
-	info, err_10 := os.Stat(path)
-	if err_10 != nil {
+	info, err_1 := os.Stat(path)
+	if err_1 != nil {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:87
@@ -121,8 +121,8 @@ func IsDir(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:92
 func IsFile(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:93
-	info, err_11 := os.Stat(path)
-	if err_11 != nil {
+	info, err_1 := os.Stat(path)
+	if err_1 != nil {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:94
@@ -132,9 +132,9 @@ func IsFile(path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:101
 func List(path string) ([]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:102
-	entries, err_12 := os.ReadDir(path)
-	if err_12 != nil {
-		return []string{}, err_12
+	entries, err_1 := os.ReadDir(path)
+	if err_1 != nil {
+		return []string{}, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:104
 	result := make([]string, 0, len(entries))
@@ -152,7 +152,7 @@ func ListRecursive(path string) ([]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:115
 	result := make([]string, 0)
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:116
-	err_13 := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+	err_1 := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:117
 		if err != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:118
@@ -166,8 +166,8 @@ func ListRecursive(path string) ([]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:121
 		return nil
 	})
-	if err_13 != nil {
-		return []string{}, err_13
+	if err_1 != nil {
+		return []string{}, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:123
 	return result, nil
@@ -188,16 +188,16 @@ func DeleteAll(path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:145
 func Copy(src string, dst string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:146
-	sourceFile, err_14 := os.Open(src)
-	if err_14 != nil {
-		return err_14
+	sourceFile, err_1 := os.Open(src)
+	if err_1 != nil {
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:147
 	defer sourceFile.Close()
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:149
-	destFile, err_15 := os.Create(dst)
-	if err_15 != nil {
-		return err_15
+	destFile, err_2 := os.Create(dst)
+	if err_2 != nil {
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:150
 	defer destFile.Close()
@@ -231,16 +231,16 @@ func MkDirAll(path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:178
 func TempFile(prefix string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:179
-	file, err_16 := os.CreateTemp("", prefix)
-	if err_16 != nil {
-		return "", err_16
+	file, err_1 := os.CreateTemp("", prefix)
+	if err_1 != nil {
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:180
 	path := file.Name()
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:181
-	err_17 := file.Close()
-	if err_17 != nil {
-		return "", err_17
+	err_2 := file.Close()
+	if err_2 != nil {
+		return "", err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:182
 	return path, nil
@@ -255,9 +255,9 @@ func TempDir(prefix string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:194
 func Size(path string) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:195
-	info, err_18 := os.Stat(path)
-	if err_18 != nil {
-		return 0, err_18
+	info, err_1 := os.Stat(path)
+	if err_1 != nil {
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:196
 	return info.Size(), nil
@@ -266,9 +266,9 @@ func Size(path string) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:201
 func ModTime(path string) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:202
-	info, err_19 := os.Stat(path)
-	if err_19 != nil {
-		return 0, err_19
+	info, err_1 := os.Stat(path)
+	if err_1 != nil {
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:203
 	return info.ModTime().Unix(), nil
@@ -317,8 +317,8 @@ func Watch(pattern string, callback func(string)) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:242
 	lastModified := make(map[string]int64)
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:245
-	matches, err_20 := filepath.Glob(pattern)
-	if err_20 != nil {
+	matches, err_1 := filepath.Glob(pattern)
+	if err_1 != nil {
 		return
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:246
@@ -326,9 +326,9 @@ func Watch(pattern string, callback func(string)) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:247
 		for _, match := range matches {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:248
-			info, err_21 := os.Stat(match)
-			if err_21 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:249
+			info, err_2 := os.Stat(match)
+			if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:249
 				continue
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:250
@@ -338,19 +338,19 @@ func Watch(pattern string, callback func(string)) {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:252
 	for {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:253
-		time.Sleep((500 * time.Millisecond))
+		time.Sleep(500 * time.Millisecond)
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:255
-		matches, err_22 := filepath.Glob(pattern)
-		if err_22 != nil {
-			//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:256
+		matches, err_3 := filepath.Glob(pattern)
+		if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:256
 			continue
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:258
 		for _, match := range matches {
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:259
-			info, err_23 := os.Stat(match)
-			if err_23 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:260
+			info, err_4 := os.Stat(match)
+			if err_4 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:260
 				continue
 			}
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files.kuki:261
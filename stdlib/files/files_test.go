@@ -474,7 +474,7 @@ func TestWatch(t *testing.T) {
 				ch <- path
 			})
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files_test.kuki:327
-			time.Sleep((1 * time.Second))
+			time.Sleep(1 * time.Second)
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files_test.kuki:328
 			files.WriteString("modified", testFile)
 //line /Users/tluker/repos/go/kukicha/stdlib/files/files_test.kuki:330
@@ -45,9 +45,9 @@ func TagExists(repo string, tag string) (bool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:46
 func DefaultBranch(repo string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:47
-	branch, err_2 := shell.Output("gh", "repo", "view", repo, "--json", "defaultBranchRef", "--jq", ".defaultBranchRef.name")
-	if err_2 != nil {
-		return "", fmt.Errorf("failed to get default branch for %v: %v", repo, err_2)
+	branch, err_1 := shell.Output("gh", "repo", "view", repo, "--json", "defaultBranchRef", "--jq", ".defaultBranchRef.name")
+	if err_1 != nil {
+		return "", fmt.Errorf("failed to get default branch for %v: %v", repo, err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:48
 	return kukistring.TrimSpace(branch), nil
@@ -56,9 +56,9 @@ func DefaultBranch(repo string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:52
 func CurrentBranch() (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:53
-	branch, err_3 := shell.Output("git", "rev-parse", "--abbrev-ref", "HEAD")
-	if err_3 != nil {
-		return "", fmt.Errorf("failed to get current branch: %v", err_3)
+	branch, err_1 := shell.Output("git", "rev-parse", "--abbrev-ref", "HEAD")
+	if err_1 != nil {
+		return "", fmt.Errorf("failed to get current branch: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:54
 	return kukistring.TrimSpace(branch), nil
@@ -82,7 +82,7 @@ func CreateRelease(repo string, tag string, opts ReleaseOptions) error {
 		title = tag
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:72
-	cmd := shell.FlagIf(shell.FlagIf(shell.FlagIf(shell.New("gh", "release", "create", tag, "--repo", repo, "--title", title), (opts.Target != ""), "--target", opts.Target), opts.GenerateNotes, "--generate-notes"), opts.Draft, "--draft")
+	cmd := shell.FlagIf(shell.FlagIf(shell.FlagIf(shell.New("gh", "release", "create", tag, "--repo", repo, "--title", title), opts.Target != "", "--target", opts.Target), opts.GenerateNotes, "--generate-notes"), opts.Draft, "--draft")
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:77
 	result := shell.Execute(cmd)
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:78
@@ -106,7 +106,7 @@ func PreviewRelease(repo string, tag string, opts ReleaseOptions) string {
 		title = tag
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:91
-	cmd := shell.FlagIf(shell.FlagIf(shell.FlagIf(shell.New("gh", "release", "create", tag, "--repo", repo, "--title", title), (opts.Target != ""), "--target", opts.Target), opts.GenerateNotes, "--generate-notes"), opts.Draft, "--draft")
+	cmd := shell.FlagIf(shell.FlagIf(shell.FlagIf(shell.New("gh", "release", "create", tag, "--repo", repo, "--title", title), opts.Target != "", "--target", opts.Target), opts.GenerateNotes, "--generate-notes"), opts.Draft, "--draft")
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:96
 	return shell.Preview(cmd)
 }
@@ -122,9 +122,9 @@ func RepoExists(repo string) (bool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:108
 func CurrentUser() (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:109
-	login, err_4 := shell.Output("gh", "api", "user", "--jq", ".login")
-	if err_4 != nil {
-		return "", fmt.Errorf("failed to get current user: %v", err_4)
+	login, err_1 := shell.Output("gh", "api", "user", "--jq", ".login")
+	if err_1 != nil {
+		return "", fmt.Errorf("failed to get current user: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:110
 	return kukistring.TrimSpace(login), nil
@@ -133,9 +133,9 @@ func CurrentUser() (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:116
 func Clone(url string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:117
-	_, err_5 := shell.Output("git", "clone", url, path)
-	if err_5 != nil {
-		return fmt.Errorf("clone failed: %v", err_5)
+	_, err_1 := shell.Output("git", "clone", url, path)
+	if err_1 != nil {
+		return fmt.Errorf("clone failed: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:118
 	return nil
@@ -146,9 +146,9 @@ func CloneShallow(url string, path string, depth int) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:123
 	depthStr := fmt.Sprintf("%d", depth)
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:124
-	_, err_6 := shell.Output("git", "clone", "--depth", depthStr, url, path)
-	if err_6 != nil {
-		return fmt.Errorf("shallow clone failed: %v", err_6)
+	_, err_1 := shell.Output("git", "clone", "--depth", depthStr, url, path)
+	if err_1 != nil {
+		return fmt.Errorf("shallow clone failed: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git.kuki:125
 	return nil
@@ -7,26 +7,23 @@ import (
 	"github.com/duber000/kukicha/stdlib/shell"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"github.com/duber000/kukicha/stdlib/test"
-	"os/exec"
 	"testing"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:16
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:15
 func ghAvailable() bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:16
+	if !shell.Which("gh") {
 //line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:17
-	_, err := exec.LookPath("gh")
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:18
-	if err != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:19
 		return false
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:20
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:18
 	result := shell.Execute(shell.New("gh", "auth", "status"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:21
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:19
 	return shell.Success(result)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:23
 type PreviewReleaseCase struct {
 	name           string
 	repo           string
@@ -35,238 +32,238 @@ type PreviewReleaseCase struct {
 	expectContains string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:32
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:30
 func TestPreviewRelease(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:33
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:31
 	cases := []PreviewReleaseCase{PreviewReleaseCase{name: "basic release", repo: "owner/repo", tag: "v1.0.0", opts: git.ReleaseOptions{}, expectContains: "gh release create v1.0.0 --repo owner/repo --title v1.0.0"}, PreviewReleaseCase{name: "with draft", repo: "owner/repo", tag: "v2.0.0", opts: git.ReleaseOptions{Draft: true}, expectContains: "--draft"}, PreviewReleaseCase{name: "with target", repo: "owner/repo", tag: "v1.0.0", opts: git.ReleaseOptions{Target: "main"}, expectContains: "--target main"}, PreviewReleaseCase{name: "with generate notes", repo: "owner/repo", tag: "v1.0.0", opts: git.ReleaseOptions{GenerateNotes: true}, expectContains: "--generate-notes"}, PreviewReleaseCase{name: "custom title", repo: "owner/repo", tag: "v1.0.0", opts: git.ReleaseOptions{Title: "Release 1.0"}, expectContains: "--title Release 1.0"}, PreviewReleaseCase{name: "all options", repo: "myorg/myrepo", tag: "v3.0.0", opts: git.ReleaseOptions{Title: "Big Release", Target: "release-branch", Draft: true, GenerateNotes: true}, expectContains: "gh release create v3.0.0 --repo myorg/myrepo"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:77
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:75
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:78
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:76
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:77
 			got := git.PreviewRelease(tc.repo, tc.tag, tc.opts)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:80
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:78
 			test.AssertTrue(t, kukistring.Contains(got, tc.expectContains))
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:84
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:82
 type PreviewReleaseAllFlagsCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:85
 func TestPreviewReleaseAllFlags(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:88
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:86
 	cases := []PreviewReleaseAllFlagsCase{PreviewReleaseAllFlagsCase{name: "all flags present"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:89
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:92
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:90
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:93
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:91
 			opts := git.ReleaseOptions{Title: "My Release", Target: "main", Draft: true, GenerateNotes: true}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:94
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:92
 			got := git.PreviewRelease("owner/repo", "v1.0.0", opts)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:95
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:93
 			test.AssertTrue(t, kukistring.Contains(got, "--target main"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:96
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:94
 			test.AssertTrue(t, kukistring.Contains(got, "--generate-notes"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:97
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:95
 			test.AssertTrue(t, kukistring.Contains(got, "--draft"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:98
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:96
 			test.AssertTrue(t, kukistring.Contains(got, "--title My Release"))
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:100
 type PreviewReleaseNoFlagsCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:105
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:103
 func TestPreviewReleaseNoFlags(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:104
 	cases := []PreviewReleaseNoFlagsCase{PreviewReleaseNoFlagsCase{name: "no optional flags"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:109
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:107
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:110
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:108
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:111
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:109
 			opts := git.ReleaseOptions{}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:110
 			got := git.PreviewRelease("owner/repo", "v1.0.0", opts)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:113
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:111
 			test.AssertFalse(t, kukistring.Contains(got, "--target"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:114
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:112
 			test.AssertFalse(t, kukistring.Contains(got, "--generate-notes"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:115
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:113
 			test.AssertFalse(t, kukistring.Contains(got, "--draft"))
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:114
 			test.AssertEqual(t, got, "gh release create v1.0.0 --repo owner/repo --title v1.0.0")
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:120
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:118
 type CurrentBranchCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:123
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:121
 func TestCurrentBranch(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:122
 	cases := []CurrentBranchCase{CurrentBranchCase{name: "returns a branch name"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:127
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:125
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:128
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:126
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:129
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:127
 			branch, err := git.CurrentBranch()
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:128
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:131
-			test.AssertTrue(t, (len(branch) > 0))
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:129
+			test.AssertTrue(t, len(branch) > 0)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:135
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:133
 type ListTagsCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:136
 func TestListTags(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:139
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:137
 	if !ghAvailable() {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:138
 		t.Skip("gh CLI not available or not authenticated")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:142
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:140
 	cases := []ListTagsCase{ListTagsCase{name: "list tags from public repo"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:145
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:143
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:146
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:144
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:147
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:145
 			tags, err := git.ListTags("cli/cli")
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:148
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:146
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:149
-			test.AssertTrue(t, (len(tags) > 0))
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:147
+			test.AssertTrue(t, len(tags) > 0)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:153
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:151
 type DefaultBranchCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:156
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:154
 func TestDefaultBranch(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:155
 	if !ghAvailable() {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:158
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:156
 		t.Skip("gh CLI not available or not authenticated")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:158
 	cases := []DefaultBranchCase{DefaultBranchCase{name: "default branch of cli/cli"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:161
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:164
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:162
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:165
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:163
 			branch, err := git.DefaultBranch("cli/cli")
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:164
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:167
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:165
 			test.AssertEqual(t, branch, "trunk")
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:171
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:169
 type CurrentUserCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:174
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:172
 func TestCurrentUser(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:175
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:173
 	if !ghAvailable() {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:176
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:174
 		t.Skip("gh CLI not available or not authenticated")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:178
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:176
 	cases := []CurrentUserCase{CurrentUserCase{name: "returns authenticated user"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:179
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:182
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:180
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:183
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:181
 			user, err := git.CurrentUser()
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:184
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:182
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:185
-			test.AssertTrue(t, (len(user) > 0))
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:183
+			test.AssertTrue(t, len(user) > 0)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:187
 type TagExistsCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:192
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:190
 func TestTagExists(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:193
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:191
 	if !ghAvailable() {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:194
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:192
 		t.Skip("gh CLI not available or not authenticated")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:196
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:194
 	cases := []TagExistsCase{TagExistsCase{name: "existing tag"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:197
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:198
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:201
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:199
 			exists, err := git.TagExists("cli/cli", "v2.0.0")
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:202
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:200
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:203
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:201
 			test.AssertTrue(t, exists)
 		})
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:207
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:205
 type RepoExistsCase struct {
 	name string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:210
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:208
 func TestRepoExists(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:211
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:209
 	if !ghAvailable() {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:212
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:210
 		t.Skip("gh CLI not available or not authenticated")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:214
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:212
 	cases := []RepoExistsCase{RepoExistsCase{name: "existing repo"}}
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:217
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:215
 	for _, tc := range cases {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:218
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:216
 		t.Run(tc.name, func(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:219
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:217
 			exists, err := git.RepoExists("cli/cli")
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:220
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:218
 			test.AssertNoError(t, err)
-//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:221
+//line /Users/tluker/repos/go/kukicha/stdlib/git/git_test.kuki:219
 			test.AssertTrue(t, exists)
 		})
 	}
@@ -0,0 +1,106 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:23
+func SHA256(data string) []byte {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:24
+	h := sha256.New()
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:25
+	h.Write([]byte(data))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:26
+	return h.Sum(nil)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:31
+func MD5(data string) []byte {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:32
+	h := md5.New()
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:33
+	h.Write([]byte(data))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:34
+	return h.Sum(nil)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:38
+func HMAC(data string, key string) []byte {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:39
+	mac := hmac.New(sha256.New, []byte(key))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:40
+	mac.Write([]byte(data))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:41
+	return mac.Sum(nil)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:46
+func Equal(a string, b string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:47
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:51
+func Hex(data []byte) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:52
+	return hex.EncodeToString(data)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:56
+func FromHex(text string) ([]byte, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:57
+	data, err := hex.DecodeString(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:58
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:59
+		return nil, fmt.Errorf("hash.FromHex: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:60
+	return data, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:64
+func Base64(data []byte) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:65
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:69
+func FromBase64(text string) ([]byte, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:70
+	data, err := base64.StdEncoding.DecodeString(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:71
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:72
+		return nil, fmt.Errorf("hash.FromBase64: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:73
+	return data, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:77
+func Base64URL(data []byte) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:78
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:82
+func FromBase64URL(text string) ([]byte, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:83
+	data, err := base64.RawURLEncoding.DecodeString(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:84
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:85
+		return nil, fmt.Errorf("hash.FromBase64URL: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash.kuki:86
+	return data, nil
+}
@@ -0,0 +1,141 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package hash_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/hash"
+	"github.com/duber000/kukicha/stdlib/test"
+	"strings"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:11
+type DigestCase struct {
+	name string
+	got  string
+	want string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:17
+func TestDigests(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:18
+	cases := []DigestCase{DigestCase{name: "sha256", got: hash.Hex(hash.SHA256("hello")), want: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}, DigestCase{name: "sha256 empty", got: hash.Hex(hash.SHA256("")), want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}, DigestCase{name: "md5", got: hash.Hex(hash.MD5("hello")), want: "5d41402abc4b2a76b9719d911017c592"}, DigestCase{name: "hmac", got: hash.Hex(hash.HMAC("The quick brown fox jumps over the lazy dog", "key")), want: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"}, DigestCase{name: "hmac base64", got: hash.Base64(hash.HMAC("message", "secret")), want: "i19IcCmVwVmMVz2x4hhmqbgl1KeU0WnXBgoDYFeWNgs="}}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:25
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:26
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:27
+			test.AssertEqual(t, tc.got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:31
+func TestEqual(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:32
+	sig := hash.Hex(hash.HMAC("body", "secret"))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:33
+	test.AssertTrue(t, hash.Equal(sig, hash.Hex(hash.HMAC("body", "secret"))))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:34
+	test.AssertFalse(t, hash.Equal(sig, hash.Hex(hash.HMAC("body", "other"))))
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:35
+	test.AssertFalse(t, hash.Equal(sig, ""))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:38
+type EncodingCase struct {
+	name    string
+	encoded string
+	want    string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:44
+func TestRoundTrip(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:45
+	data := []byte("user:pa$$word?>")
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:46
+	t.Run("hex", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:47
+		got, err_2 := hash.FromHex(hash.Hex(data))
+		if err_2 != nil {
+			panic(fmt.Sprintf("%v", err_2))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:48
+		test.AssertEqual(t, string(got), "user:pa$$word?>")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:50
+	t.Run("base64", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:51
+		test.AssertEqual(t, hash.Base64(data), "dXNlcjpwYSQkd29yZD8+")
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:52
+		got, err_1 := hash.FromBase64("dXNlcjpwYSQkd29yZD8+")
+		if err_1 != nil {
+			panic(fmt.Sprintf("%v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:53
+		test.AssertEqual(t, string(got), "user:pa$$word?>")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:55
+	t.Run("base64url", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:56
+		test.AssertEqual(t, hash.Base64URL(data), "dXNlcjpwYSQkd29yZD8-")
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:57
+		got, err_1 := hash.FromBase64URL("dXNlcjpwYSQkd29yZD8-")
+		if err_1 != nil {
+			panic(fmt.Sprintf("%v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:58
+		test.AssertEqual(t, string(got), "user:pa$$word?>")
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:62
+func TestDecodeErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:63
+	cases := []EncodingCase{EncodingCase{name: "hex odd length", encoded: "abc", want: "hash.FromHex"}, EncodingCase{name: "hex bad digit", encoded: "zz", want: "hash.FromHex"}, EncodingCase{name: "base64", encoded: "not base64!", want: "hash.FromBase64"}, EncodingCase{name: "base64url padding", encoded: "YQ==", want: "hash.FromBase64URL"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:69
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:70
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:71
+			err := decode(tc.want, tc.encoded)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:72
+			test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:73
+			test.AssertTrue(t, strings.Contains(err.Error(), tc.want))
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:75
+	t.Run("onerr default", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:76
+		raw, err_1 := hash.FromHex("xyz")
+		if err_1 != nil {
+			raw = []byte{}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:77
+		test.AssertEqual(t, len(raw), 0)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:81
+func decode(name string, text string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:82
+	if name == "hash.FromHex" {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:83
+		_, hexErr := hash.FromHex(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:84
+		return hexErr
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:85
+	if name == "hash.FromBase64" {
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:86
+		_, stdErr := hash.FromBase64(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:87
+		return stdErr
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:88
+	_, urlErr := hash.FromBase64URL(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/hash/hash_test.kuki:89
+	return urlErr
+}
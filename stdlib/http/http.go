@@ -262,8 +262,8 @@ func GetQueryIntOr(r *http.Request, key string, defaultValue int) int {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:180
-	val, err_2 := cast.Atoi(value)
-	if err_2 != nil {
+	val, err_1 := cast.Atoi(value)
+	if err_1 != nil {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:181
@@ -280,8 +280,8 @@ func GetQueryBool(r *http.Request, key string) (bool, error) {
 		return false, fmt.Errorf("query parameter '%v' is required", key)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:191
-	val, err_3 := validate.ParseBool(value)
-	if err_3 != nil {
+	val, err_1 := validate.ParseBool(value)
+	if err_1 != nil {
 		return false, fmt.Errorf("query parameter '%v' must be a boolean", key)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:192
@@ -298,8 +298,8 @@ func GetQueryBoolOr(r *http.Request, key string, defaultValue bool) bool {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:201
-	val, err_4 := validate.ParseBool(value)
-	if err_4 != nil {
+	val, err_1 := validate.ParseBool(value)
+	if err_1 != nil {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:202
@@ -346,9 +346,9 @@ func RedirectPermanent(w http.ResponseWriter, r *http.Request, url string) {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:241
 func SafeRedirect(w http.ResponseWriter, r *http.Request, redirectURL string, allowedHosts ...string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:242
-	parsed, err_5 := url.Parse(redirectURL)
-	if err_5 != nil {
-		return err_5
+	parsed, err_1 := url.Parse(redirectURL)
+	if err_1 != nil {
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:243
 	if parsed.Host == "" {
@@ -371,9 +371,9 @@ func SafeRedirect(w http.ResponseWriter, r *http.Request, redirectURL string, al
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:257
 func SafeURL(tmpl string, pathParams map[string]string, queryParams map[string]string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:258
-	base, err_6 := fetch.URLTemplate(tmpl, pathParams)
-	if err_6 != nil {
-		return "", err_6
+	base, err_1 := fetch.URLTemplate(tmpl, pathParams)
+	if err_1 != nil {
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:259
 	return fetch.URLWithQuery(base, queryParams)
@@ -436,31 +436,31 @@ func SetSecureHeaders(w http.ResponseWriter) {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:312
 func IsGet(r *http.Request) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:313
-	return (r.Method == "GET")
+	return r.Method == "GET"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:317
 func IsPost(r *http.Request) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:318
-	return (r.Method == "POST")
+	return r.Method == "POST"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:322
 func IsPut(r *http.Request) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:323
-	return (r.Method == "PUT")
+	return r.Method == "PUT"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:327
 func IsDelete(r *http.Request) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:328
-	return (r.Method == "DELETE")
+	return r.Method == "DELETE"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:332
 func IsPatch(r *http.Request) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:333
-	return (r.Method == "PATCH")
+	return r.Method == "PATCH"
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/http/http.kuki:338
@@ -32,9 +32,9 @@ func ReadLine(prompt string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:26
 func Prompt(prompt string) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:27
-	result, err_2 := ReadLine(prompt)
-	if err_2 != nil {
-		panic(fmt.Sprintf("%v", err_2))
+	result, err_1 := ReadLine(prompt)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:28
 	return result
@@ -43,15 +43,15 @@ func Prompt(prompt string) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:33
 func Confirm(prompt string) (bool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:34
-	answer, err_3 := ReadLine(fmt.Sprintf("%v [y/N]: ", prompt))
-	if err_3 != nil {
-		err_3 = fmt.Errorf("confirm prompt: %w", err_3)
-		return false, err_3
+	answer, err_1 := ReadLine(fmt.Sprintf("%v [y/N]: ", prompt))
+	if err_1 != nil {
+		err_1 = fmt.Errorf("confirm prompt: %w", err_1)
+		return false, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:35
 	lower := kukistring.ToLower(kukistring.TrimSpace(answer))
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:36
-	return ((lower == "y") || (lower == "yes")), nil
+	return lower == "y" || lower == "yes", nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:44
@@ -68,33 +68,33 @@ func Choose(prompt string, options []string) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:50
 	for i, opt := range options {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:51
-		fmt.Printf("  %d) %s\n", (i + 1), opt)
+		fmt.Printf("  %d) %s\n", i+1, opt)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:52
 	fmt.Println("")
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:54
-	raw, err_4 := ReadLine("Enter number (or q to quit): ")
-	if err_4 != nil {
-		err_4 = fmt.Errorf("choose prompt: %w", err_4)
-		return 0, err_4
+	raw, err_1 := ReadLine("Enter number (or q to quit): ")
+	if err_1 != nil {
+		err_1 = fmt.Errorf("choose prompt: %w", err_1)
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:55
 	trimmed := kukistring.TrimSpace(raw)
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:57
-	if ((trimmed == "") || (trimmed == "q")) || (trimmed == "Q") {
+	if trimmed == "" || trimmed == "q" || trimmed == "Q" {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:58
 		return -1, errors.New("cancelled")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:60
-	val, err_5 := strconv.Atoi(trimmed)
-	if err_5 != nil {
+	val, err_2 := strconv.Atoi(trimmed)
+	if err_2 != nil {
 		return -1, fmt.Errorf("invalid selection: %v", trimmed)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:61
-	if (val < 1) || (val > len(options)) {
+	if val < 1 || val > len(options) {
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:62
 		return -1, fmt.Errorf("selection out of range: %v", val)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/input/input.kuki:64
-	return (val - 1), nil
+	return val - 1, nil
 }
@@ -31,21 +31,21 @@ func TestFilter(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:22
 	t.Run("keep even", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:23
-		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return ((n % 2) == 0) }))
+		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return n%2 == 0 }))
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:24
 		test.AssertEqual(t, len(result), 3)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:26
 	t.Run("keep none", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:27
-		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return (n > 100) }))
+		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return n > 100 }))
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:28
 		test.AssertEqual(t, len(result), 0)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:30
 	t.Run("keep all", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:31
-		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return (n > 0) }))
+		result := iterator.Collect(iterator.Filter(iterator.Values(items), func(n int) bool { return n > 0 }))
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:32
 		test.AssertEqual(t, len(result), 6)
 	})
@@ -108,7 +108,7 @@ func TestSkip(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:68
 func addInts(acc int, n int) int {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:69
-	return (acc + n)
+	return acc + n
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:71
@@ -140,14 +140,14 @@ func TestAny(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:86
 	t.Run("has match", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:87
-		result := iterator.Any(iterator.Values(items), func(n int) bool { return (n == 3) })
+		result := iterator.Any(iterator.Values(items), func(n int) bool { return n == 3 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:88
 		test.AssertTrue(t, result)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:90
 	t.Run("no match", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:91
-		result := iterator.Any(iterator.Values(items), func(n int) bool { return (n > 100) })
+		result := iterator.Any(iterator.Values(items), func(n int) bool { return n > 100 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:92
 		test.AssertFalse(t, result)
 	})
@@ -160,7 +160,7 @@ func TestAll(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:98
 	t.Run("all match", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:99
-		result := iterator.All(iterator.Values(items), func(n int) bool { return ((n % 2) == 0) })
+		result := iterator.All(iterator.Values(items), func(n int) bool { return n%2 == 0 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:100
 		test.AssertTrue(t, result)
 	})
@@ -169,7 +169,7 @@ func TestAll(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:103
 		mixed := []int{2, 3, 4}
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:104
-		result := iterator.All(iterator.Values(mixed), func(n int) bool { return ((n % 2) == 0) })
+		result := iterator.All(iterator.Values(mixed), func(n int) bool { return n%2 == 0 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:105
 		test.AssertFalse(t, result)
 	})
@@ -182,7 +182,7 @@ func TestFind(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:111
 	t.Run("found", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:112
-		val, ok := iterator.Find(iterator.Values(items), func(n int) bool { return (n > 15) })
+		val, ok := iterator.Find(iterator.Values(items), func(n int) bool { return n > 15 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:113
 		test.AssertTrue(t, ok)
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:114
@@ -191,7 +191,7 @@ func TestFind(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:116
 	t.Run("not found", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:117
-		_, ok := iterator.Find(iterator.Values(items), func(n int) bool { return (n > 100) })
+		_, ok := iterator.Find(iterator.Values(items), func(n int) bool { return n > 100 })
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:118
 		test.AssertFalse(t, ok)
 	})
@@ -204,14 +204,14 @@ func TestChain(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:124
 	t.Run("filter then take", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:125
-		result := iterator.Collect(iterator.Take(iterator.Filter(iterator.Values(items), func(n int) bool { return ((n % 2) == 0) }), 2))
+		result := iterator.Collect(iterator.Take(iterator.Filter(iterator.Values(items), func(n int) bool { return n%2 == 0 }), 2))
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:126
 		test.AssertEqual(t, len(result), 2)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:128
 	t.Run("skip then filter", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:129
-		result := iterator.Collect(iterator.Filter(iterator.Skip(iterator.Values(items), 5), func(n int) bool { return ((n % 2) == 0) }))
+		result := iterator.Collect(iterator.Filter(iterator.Skip(iterator.Values(items), 5), func(n int) bool { return n%2 == 0 }))
 //line /Users/tluker/repos/go/kukicha/stdlib/iterator/iterator_test.kuki:130
 		test.AssertEqual(t, len(result), 3)
 	})
@@ -67,7 +67,7 @@ func Encode(enc Encoder, value any) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/json/json.kuki:58
 	encoder := json.NewEncoder(enc.writer)
 //line /Users/tluker/repos/go/kukicha/stdlib/json/json.kuki:59
-	if (enc.indent != "") || (enc.prefix != "") {
+	if enc.indent != "" || enc.prefix != "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/json/json.kuki:60
 		encoder.SetIndent(enc.prefix, enc.indent)
 	}
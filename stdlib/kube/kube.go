@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/duber000/kukicha/stdlib/ctx"
+	"github.com/duber000/kukicha/stdlib/osx"
 	"github.com/duber000/kukicha/stdlib/retry"
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"io"
@@ -15,18 +16,16 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"os"
-	"path/filepath"
 	"time"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:37
 type Cluster struct {
 	client    any
 	namespace string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:43
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:42
 type Config struct {
 	kubeconfig       string
 	context          string
@@ -35,57 +34,57 @@ type Config struct {
 	retryDelayMs     int
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:51
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:50
 type PodList struct {
 	items any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:55
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:54
 type Pod struct {
 	pod any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:59
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:58
 type DeploymentList struct {
 	items any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:63
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:62
 type Deployment struct {
 	dep any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:67
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:66
 type ServiceList struct {
 	items any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:70
 type Service struct {
 	svc any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:75
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:74
 type NodeList struct {
 	items any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:79
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:78
 type Node struct {
 	node any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:83
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:82
 type NamespaceList struct {
 	items any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:87
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:86
 type NamespaceItem struct {
 	ns any
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:91
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:90
 type PodEvent struct {
 	eventType string
 	name      string
@@ -94,1005 +93,1004 @@ type PodEvent struct {
 	ready     bool
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:101
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:100
 func New() Config {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:102
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:101
 	return Config{}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:105
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:104
 func Kubeconfig(cfg Config, path string) Config {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:106
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:105
 	cfg.kubeconfig = path
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:107
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:106
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:110
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:109
 func Context(cfg Config, name string) Config {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:111
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:110
 	cfg.context = name
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:112
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:111
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:115
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:114
 func InCluster(cfg Config) Config {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:116
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:115
 	cfg.inCluster = true
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:117
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:116
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:122
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:121
 func Retry(cfg Config, maxAttempts int, delayMs int) Config {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:123
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:122
 	cfg.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:124
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:123
 	cfg.retryDelayMs = delayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:125
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:124
 	return cfg
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:128
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:127
 func Namespace(c Cluster, ns string) Cluster {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:129
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:128
 	c.namespace = ns
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:130
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:129
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:135
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:134
 func PodEventType(event PodEvent) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:136
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:135
 	return event.eventType
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:139
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:138
 func PodEventName(event PodEvent) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:140
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:139
 	return event.name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:143
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:142
 func PodEventNamespace(event PodEvent) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:144
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:143
 	return event.namespace
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:147
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:146
 func PodEventPhase(event PodEvent) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:148
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:147
 	return event.phase
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:151
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:150
 func PodEventReady(event PodEvent) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:152
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:151
 	return event.ready
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:156
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:155
 func clientset(c Cluster) *kubernetes.Clientset {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:157
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:156
 	return c.client.(*kubernetes.Clientset)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:159
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:158
 func pod(p Pod) *corev1.Pod {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:159
 	return p.pod.(*corev1.Pod)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:162
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:161
 func deployment(d Deployment) *appsv1.Deployment {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:163
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:162
 	return d.dep.(*appsv1.Deployment)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:165
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:164
 func service(s Service) *corev1.Service {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:165
 	return s.svc.(*corev1.Service)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:168
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:167
 func node(n Node) *corev1.Node {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:169
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:168
 	return n.node.(*corev1.Node)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:171
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:170
 func nsItem(n NamespaceItem) *corev1.Namespace {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:172
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:171
 	return n.ns.(*corev1.Namespace)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:177
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:176
 func Connect() (Cluster, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:178
-	home, err_1 := os.UserHomeDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:177
+	kubeconfig, err_1 := osx.ExpandPath("~/.kube/config")
 	if err_1 != nil {
 		err_1 = fmt.Errorf("kube connect: %w", err_1)
 		var _zero0 Cluster
 		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:179
-	kubeconfig := filepath.Join(home, ".kube", "config")
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:180
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:178
 	config, err_2 := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err_2 != nil {
 		err_2 = fmt.Errorf("kube connect: %w", err_2)
 		var _zero0 Cluster
 		return _zero0, err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:179
 	cs, err_3 := kubernetes.NewForConfig(config)
 	if err_3 != nil {
 		err_3 = fmt.Errorf("kube connect: %w", err_3)
 		var _zero0 Cluster
 		return _zero0, err_3
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:182
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:180
 	return Cluster{client: cs, namespace: "default"}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:185
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:183
 func openOnce(cfg Config) (Cluster, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:184
 	if cfg.inCluster {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:187
-		restConfig, err_4 := rest.InClusterConfig()
-		if err_4 != nil {
-			err_4 = fmt.Errorf("kube in-cluster: %w", err_4)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:185
+		restConfig, err_1 := rest.InClusterConfig()
+		if err_1 != nil {
+			err_1 = fmt.Errorf("kube in-cluster: %w", err_1)
 			var _zero0 Cluster
-			return _zero0, err_4
+			return _zero0, err_1
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:188
-		cs, err_5 := kubernetes.NewForConfig(restConfig)
-		if err_5 != nil {
-			err_5 = fmt.Errorf("kube open: %w", err_5)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:186
+		cs, err_2 := kubernetes.NewForConfig(restConfig)
+		if err_2 != nil {
+			err_2 = fmt.Errorf("kube open: %w", err_2)
 			var _zero0 Cluster
-			return _zero0, err_5
+			return _zero0, err_2
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:189
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:187
 		return Cluster{client: cs, namespace: "default"}, nil
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:191
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:189
 	kubeconfig := cfg.kubeconfig
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:192
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:190
 	if kubeconfig == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:193
-		home, err_6 := os.UserHomeDir()
-		if err_6 != nil {
-			err_6 = fmt.Errorf("kube config: %w", err_6)
-			var _zero0 Cluster
-			return _zero0, err_6
-		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:194
-		kubeconfig = filepath.Join(home, ".kube", "config")
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:191
+		kubeconfig = "~/.kube/config"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:196
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:192
+	var err_3 error
+	kubeconfig, err_3 = osx.ExpandPath(kubeconfig)
+	if err_3 != nil {
+		err_3 = fmt.Errorf("kube config: %w", err_3)
+		var _zero0 Cluster
+		return _zero0, err_3
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:194
 	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:197
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:195
 	overrides := &clientcmd.ConfigOverrides{}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:198
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:196
 	if cfg.context != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:199
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:197
 		overrides.CurrentContext = cfg.context
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:201
-	restConfig, err_7 := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
-	if err_7 != nil {
-		err_7 = fmt.Errorf("kube open: %w", err_7)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:199
+	restConfig, err_4 := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err_4 != nil {
+		err_4 = fmt.Errorf("kube open: %w", err_4)
 		var _zero0 Cluster
-		return _zero0, err_7
+		return _zero0, err_4
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:202
-	cs, err_8 := kubernetes.NewForConfig(restConfig)
-	if err_8 != nil {
-		err_8 = fmt.Errorf("kube open: %w", err_8)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:200
+	cs, err_5 := kubernetes.NewForConfig(restConfig)
+	if err_5 != nil {
+		err_5 = fmt.Errorf("kube open: %w", err_5)
 		var _zero0 Cluster
-		return _zero0, err_8
+		return _zero0, err_5
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:203
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:201
 	return Cluster{client: cs, namespace: "default"}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:207
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:205
 func Open(cfg Config) (Cluster, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:208
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:206
 	if cfg.retryMaxAttempts <= 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:209
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:207
 		return openOnce(cfg)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:211
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:209
 	delayMs := cfg.retryDelayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:212
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:210
 	if delayMs <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:213
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:211
 		delayMs = 1000
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:214
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:212
 	retryCfg := retry.Config{MaxAttempts: cfg.retryMaxAttempts, InitialDelay: delayMs, Strategy: 1}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:215
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:213
 	lastErr := errors.New("no attempts made")
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:216
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:214
 	for attempt := range retryCfg.MaxAttempts {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:217
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:215
 		cluster, err := openOnce(cfg)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:218
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:216
 		if err == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:219
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:217
 			return cluster, nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:220
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:218
 		lastErr = err
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:221
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:219
 		retry.Sleep(retryCfg, attempt)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:222
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:220
 	return Cluster{}, lastErr
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:227
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:225
 func ListPods(c Cluster) (PodList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:228
-	pods, err_9 := clientset(c).CoreV1().Pods(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
-	if err_9 != nil {
-		err_9 = fmt.Errorf("kube list pods: %w", err_9)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:226
+	pods, err_1 := clientset(c).CoreV1().Pods(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list pods: %w", err_1)
 		var _zero0 PodList
-		return _zero0, err_9
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:229
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:227
 	return PodList{items: pods}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:232
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:230
 func ListPodsLabeled(c Cluster, selector string) (PodList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:233
-	pods, err_10 := clientset(c).CoreV1().Pods(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{LabelSelector: selector})
-	if err_10 != nil {
-		err_10 = fmt.Errorf("kube list pods labeled: %w", err_10)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:231
+	pods, err_1 := clientset(c).CoreV1().Pods(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{LabelSelector: selector})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list pods labeled: %w", err_1)
 		var _zero0 PodList
-		return _zero0, err_10
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:234
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:232
 	return PodList{items: pods}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:237
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:235
 func GetPod(c Cluster, name string) (Pod, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:238
-	p, err_11 := clientset(c).CoreV1().Pods(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_11 != nil {
-		err_11 = fmt.Errorf("kube get pod: %w", err_11)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:236
+	p, err_1 := clientset(c).CoreV1().Pods(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube get pod: %w", err_1)
 		var _zero0 Pod
-		return _zero0, err_11
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:239
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:237
 	return Pod{pod: p}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:242
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:240
 func DeletePod(c Cluster, name string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:243
-	err_12 := clientset(c).CoreV1().Pods(c.namespace).Delete(ctx.Value(ctx.Background()), name, metav1.DeleteOptions{})
-	if err_12 != nil {
-		err_12 = fmt.Errorf("kube delete pod: %w", err_12)
-		return err_12
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:241
+	err_1 := clientset(c).CoreV1().Pods(c.namespace).Delete(ctx.Value(ctx.Background()), name, metav1.DeleteOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube delete pod: %w", err_1)
+		return err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:244
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:242
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:249
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:247
 func ListDeployments(c Cluster) (DeploymentList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:250
-	deps, err_13 := clientset(c).AppsV1().Deployments(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
-	if err_13 != nil {
-		err_13 = fmt.Errorf("kube list deployments: %w", err_13)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:248
+	deps, err_1 := clientset(c).AppsV1().Deployments(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list deployments: %w", err_1)
 		var _zero0 DeploymentList
-		return _zero0, err_13
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:251
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:249
 	return DeploymentList{items: deps}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:254
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:252
 func GetDeployment(c Cluster, name string) (Deployment, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:255
-	dep, err_14 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_14 != nil {
-		err_14 = fmt.Errorf("kube get deployment: %w", err_14)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:253
+	dep, err_1 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube get deployment: %w", err_1)
 		var _zero0 Deployment
-		return _zero0, err_14
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:256
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:254
 	return Deployment{dep: dep}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:259
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:257
 func ScaleDeployment(c Cluster, name string, replicas int32) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:260
-	scale, err_15 := clientset(c).AppsV1().Deployments(c.namespace).GetScale(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_15 != nil {
-		err_15 = fmt.Errorf("kube scale get: %w", err_15)
-		return err_15
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:258
+	scale, err_1 := clientset(c).AppsV1().Deployments(c.namespace).GetScale(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube scale get: %w", err_1)
+		return err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:261
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:259
 	scale.Spec.Replicas = replicas
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:262
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:260
 	_, err := clientset(c).AppsV1().Deployments(c.namespace).UpdateScale(ctx.Value(ctx.Background()), name, scale, metav1.UpdateOptions{})
 	if err != nil {
 		err = fmt.Errorf("kube scale update: %w", err)
 		return err
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:263
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:261
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:266
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:264
 func DeleteDeployment(c Cluster, name string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:267
-	err_16 := clientset(c).AppsV1().Deployments(c.namespace).Delete(ctx.Value(ctx.Background()), name, metav1.DeleteOptions{})
-	if err_16 != nil {
-		err_16 = fmt.Errorf("kube delete deployment: %w", err_16)
-		return err_16
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:265
+	err_1 := clientset(c).AppsV1().Deployments(c.namespace).Delete(ctx.Value(ctx.Background()), name, metav1.DeleteOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube delete deployment: %w", err_1)
+		return err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:268
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:266
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:271
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:269
 func RolloutRestart(c Cluster, name string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:272
-	dep, err_17 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_17 != nil {
-		err_17 = fmt.Errorf("kube rollout restart get: %w", err_17)
-		return err_17
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:270
+	dep, err_1 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube rollout restart get: %w", err_1)
+		return err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:273
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:271
 	if dep.Spec.Template.ObjectMeta.Annotations == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:274
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:272
 		dep.Spec.Template.ObjectMeta.Annotations = map[string]string{}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:275
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:273
 	dep.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().UTC().Format(time.RFC3339)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:276
-	_, err_18 := clientset(c).AppsV1().Deployments(c.namespace).Update(ctx.Value(ctx.Background()), dep, metav1.UpdateOptions{})
-	if err_18 != nil {
-		err_18 = fmt.Errorf("kube rollout restart update: %w", err_18)
-		return err_18
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:274
+	_, err_2 := clientset(c).AppsV1().Deployments(c.namespace).Update(ctx.Value(ctx.Background()), dep, metav1.UpdateOptions{})
+	if err_2 != nil {
+		err_2 = fmt.Errorf("kube rollout restart update: %w", err_2)
+		return err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:277
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:275
 	return nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:283
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:281
 func WaitDeploymentReady(c Cluster, name string, timeoutSeconds int64) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:284
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:282
 	if timeoutSeconds <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:285
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:283
 		timeoutSeconds = 300
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:286
-	deadline := time.Now().Add((time.Duration(timeoutSeconds) * time.Second))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:287
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:284
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:285
 	for {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:288
-		dep, err_19 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-		if err_19 != nil {
-			err_19 = fmt.Errorf("kube wait deployment get: %w", err_19)
-			return err_19
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:286
+		dep, err_1 := clientset(c).AppsV1().Deployments(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+		if err_1 != nil {
+			err_1 = fmt.Errorf("kube wait deployment get: %w", err_1)
+			return err_1
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:289
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:287
 		desired := int32(1)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:290
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:288
 		if dep.Spec.Replicas != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:291
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:289
 			desired = *dep.Spec.Replicas
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:292
-		if ((dep.Status.ObservedGeneration >= dep.Generation) && (dep.Status.ReadyReplicas >= desired)) && (dep.Status.UpdatedReplicas >= desired) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:293
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:290
+		if dep.Status.ObservedGeneration >= dep.Generation && dep.Status.ReadyReplicas >= desired && dep.Status.UpdatedReplicas >= desired {
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:291
 			return nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:294
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:292
 		if time.Now().After(deadline) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:295
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:293
 			return fmt.Errorf("kube wait deployment: timed out after %vs (ready=%v desired=%v updated=%v)", timeoutSeconds, dep.Status.ReadyReplicas, desired, dep.Status.UpdatedReplicas)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:296
-		time.Sleep((2 * time.Second))
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:294
+		time.Sleep(2 * time.Second)
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:300
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:298
 func WaitPodReady(c Cluster, name string, timeoutSeconds int64) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:301
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:299
 	if timeoutSeconds <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:302
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:300
 		timeoutSeconds = 180
 	}
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:301
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:302
+	for {
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:303
-	deadline := time.Now().Add((time.Duration(timeoutSeconds) * time.Second))
+		p, err_1 := clientset(c).CoreV1().Pods(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+		if err_1 != nil {
+			err_1 = fmt.Errorf("kube wait pod get: %w", err_1)
+			return err_1
+		}
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:304
-	for {
+		ready := false
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:305
-		p, err_20 := clientset(c).CoreV1().Pods(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-		if err_20 != nil {
-			err_20 = fmt.Errorf("kube wait pod get: %w", err_20)
-			return err_20
-		}
+		for _, cond := range p.Status.Conditions {
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:306
-		ready := false
+			if cond.Type == corev1.PodReady {
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:307
-		for _, cond := range p.Status.Conditions {
+				ready = cond.Status == corev1.ConditionTrue
 //line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:308
-			if cond.Type == corev1.PodReady {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:309
-				ready = (cond.Status == corev1.ConditionTrue)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:310
 				break
 			}
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:311
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:309
 		if ready {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:312
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:310
 			return nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:313
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:311
 		if time.Now().After(deadline) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:314
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:312
 			return fmt.Errorf("kube wait pod: timed out after %vs", timeoutSeconds)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:315
-		time.Sleep((1 * time.Second))
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:313
+		time.Sleep(1 * time.Second)
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:318
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:316
 func WaitDeploymentReadyCtx(c Cluster, h ctx.Handle, name string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:319
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:317
 	goCtx := ctx.Value(h)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:320
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:318
 	for {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:321
-		dep, err_21 := clientset(c).AppsV1().Deployments(c.namespace).Get(goCtx, name, metav1.GetOptions{})
-		if err_21 != nil {
-			err_21 = fmt.Errorf("kube wait deployment get: %w", err_21)
-			return err_21
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:319
+		dep, err_1 := clientset(c).AppsV1().Deployments(c.namespace).Get(goCtx, name, metav1.GetOptions{})
+		if err_1 != nil {
+			err_1 = fmt.Errorf("kube wait deployment get: %w", err_1)
+			return err_1
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:322
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:320
 		desired := int32(1)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:323
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:321
 		if dep.Spec.Replicas != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:324
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:322
 			desired = *dep.Spec.Replicas
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:325
-		if ((dep.Status.ObservedGeneration >= dep.Generation) && (dep.Status.ReadyReplicas >= desired)) && (dep.Status.UpdatedReplicas >= desired) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:326
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:323
+		if dep.Status.ObservedGeneration >= dep.Generation && dep.Status.ReadyReplicas >= desired && dep.Status.UpdatedReplicas >= desired {
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:324
 			return nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:327
-		err_22 := goCtx.Err()
-		if err_22 != nil {
-			err_22 = fmt.Errorf("kube wait deployment: %w", err_22)
-			return err_22
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:325
+		err_2 := goCtx.Err()
+		if err_2 != nil {
+			err_2 = fmt.Errorf("kube wait deployment: %w", err_2)
+			return err_2
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:328
-		time.Sleep((2 * time.Second))
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:326
+		time.Sleep(2 * time.Second)
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:331
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:329
 func WaitPodReadyCtx(c Cluster, h ctx.Handle, name string) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:332
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:330
 	goCtx := ctx.Value(h)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:333
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:331
 	for {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:334
-		p, err_23 := clientset(c).CoreV1().Pods(c.namespace).Get(goCtx, name, metav1.GetOptions{})
-		if err_23 != nil {
-			err_23 = fmt.Errorf("kube wait pod get: %w", err_23)
-			return err_23
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:332
+		p, err_1 := clientset(c).CoreV1().Pods(c.namespace).Get(goCtx, name, metav1.GetOptions{})
+		if err_1 != nil {
+			err_1 = fmt.Errorf("kube wait pod get: %w", err_1)
+			return err_1
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:335
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:333
 		ready := false
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:336
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:334
 		for _, cond := range p.Status.Conditions {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:337
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:335
 			if cond.Type == corev1.PodReady {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:338
-				ready = (cond.Status == corev1.ConditionTrue)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:339
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:336
+				ready = cond.Status == corev1.ConditionTrue
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:337
 				break
 			}
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:340
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:338
 		if ready {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:341
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:339
 			return nil
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:342
-		err_24 := goCtx.Err()
-		if err_24 != nil {
-			err_24 = fmt.Errorf("kube wait pod: %w", err_24)
-			return err_24
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:340
+		err_2 := goCtx.Err()
+		if err_2 != nil {
+			err_2 = fmt.Errorf("kube wait pod: %w", err_2)
+			return err_2
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:343
-		time.Sleep((1 * time.Second))
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:341
+		time.Sleep(1 * time.Second)
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:348
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:346
 func ListServices(c Cluster) (ServiceList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:349
-	svcs, err_25 := clientset(c).CoreV1().Services(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
-	if err_25 != nil {
-		err_25 = fmt.Errorf("kube list services: %w", err_25)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:347
+	svcs, err_1 := clientset(c).CoreV1().Services(c.namespace).List(ctx.Value(ctx.Background()), metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list services: %w", err_1)
 		var _zero0 ServiceList
-		return _zero0, err_25
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:350
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:348
 	return ServiceList{items: svcs}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:353
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:351
 func GetService(c Cluster, name string) (Service, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:354
-	svc, err_26 := clientset(c).CoreV1().Services(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_26 != nil {
-		err_26 = fmt.Errorf("kube get service: %w", err_26)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:352
+	svc, err_1 := clientset(c).CoreV1().Services(c.namespace).Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube get service: %w", err_1)
 		var _zero0 Service
-		return _zero0, err_26
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:355
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:353
 	return Service{svc: svc}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:360
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:358
 func ListNodes(c Cluster) (NodeList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:361
-	nodes, err_27 := clientset(c).CoreV1().Nodes().List(ctx.Value(ctx.Background()), metav1.ListOptions{})
-	if err_27 != nil {
-		err_27 = fmt.Errorf("kube list nodes: %w", err_27)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:359
+	nodes, err_1 := clientset(c).CoreV1().Nodes().List(ctx.Value(ctx.Background()), metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list nodes: %w", err_1)
 		var _zero0 NodeList
-		return _zero0, err_27
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:362
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:360
 	return NodeList{items: nodes}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:365
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:363
 func GetNode(c Cluster, name string) (Node, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:366
-	n, err_28 := clientset(c).CoreV1().Nodes().Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
-	if err_28 != nil {
-		err_28 = fmt.Errorf("kube get node: %w", err_28)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:364
+	n, err_1 := clientset(c).CoreV1().Nodes().Get(ctx.Value(ctx.Background()), name, metav1.GetOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube get node: %w", err_1)
 		var _zero0 Node
-		return _zero0, err_28
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:367
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:365
 	return Node{node: n}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:372
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:370
 func ListNamespaces(c Cluster) (NamespaceList, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:373
-	nsList, err_29 := clientset(c).CoreV1().Namespaces().List(ctx.Value(ctx.Background()), metav1.ListOptions{})
-	if err_29 != nil {
-		err_29 = fmt.Errorf("kube list namespaces: %w", err_29)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:371
+	nsList, err_1 := clientset(c).CoreV1().Namespaces().List(ctx.Value(ctx.Background()), metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube list namespaces: %w", err_1)
 		var _zero0 NamespaceList
-		return _zero0, err_29
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:374
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:372
 	return NamespaceList{items: nsList}, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:379
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:377
 func Pods(pl PodList) []Pod {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:380
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:378
 	podList := pl.items.(*corev1.PodList)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:381
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:379
 	result := make([]Pod, len(podList.Items))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:382
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:380
 	for i := range len(podList.Items) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:383
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:381
 		result[i] = Pod{pod: &podList.Items[i]}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:384
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:382
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:387
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:385
 func Deployments(dl DeploymentList) []Deployment {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:388
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:386
 	depList := dl.items.(*appsv1.DeploymentList)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:389
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:387
 	result := make([]Deployment, len(depList.Items))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:390
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:388
 	for i := range len(depList.Items) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:391
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:389
 		result[i] = Deployment{dep: &depList.Items[i]}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:392
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:390
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:395
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:393
 func Services(sl ServiceList) []Service {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:396
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:394
 	svcList := sl.items.(*corev1.ServiceList)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:397
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:395
 	result := make([]Service, len(svcList.Items))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:398
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:396
 	for i := range len(svcList.Items) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:399
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:397
 		result[i] = Service{svc: &svcList.Items[i]}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:400
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:398
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:403
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:401
 func Nodes(nl NodeList) []Node {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:404
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:402
 	nodeList := nl.items.(*corev1.NodeList)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:405
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:403
 	result := make([]Node, len(nodeList.Items))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:406
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:404
 	for i := range len(nodeList.Items) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:407
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:405
 		result[i] = Node{node: &nodeList.Items[i]}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:408
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:406
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:411
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:409
 func Namespaces(nsl NamespaceList) []NamespaceItem {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:412
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:410
 	nsList := nsl.items.(*corev1.NamespaceList)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:413
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:411
 	result := make([]NamespaceItem, len(nsList.Items))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:414
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:412
 	for i := range len(nsList.Items) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:415
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:413
 		result[i] = NamespaceItem{ns: &nsList.Items[i]}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:416
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:414
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:421
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:419
 func PodName(p Pod) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:422
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:420
 	return pod(p).Name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:425
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:423
 func PodStatus(p Pod) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:426
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:424
 	return string(pod(p).Status.Phase)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:429
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:427
 func PodIP(p Pod) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:430
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:428
 	return pod(p).Status.PodIP
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:433
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:431
 func PodNode(p Pod) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:434
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:432
 	return pod(p).Spec.NodeName
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:437
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:435
 func PodAge(p Pod) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:438
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:436
 	d := time.Since(pod(p).CreationTimestamp.Time)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:439
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:437
 	if d.Hours() >= 24 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:440
-		days := (d.Hours() / 24)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:441
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:438
+		days := d.Hours() / 24
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:439
 		return fmt.Sprintf("%dd", int(days))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:442
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:440
 	if d.Hours() >= 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:443
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:441
 		return fmt.Sprintf("%dh", int(d.Hours()))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:444
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:442
 	return fmt.Sprintf("%dm", int(d.Minutes()))
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:447
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:445
 func PodReady(p Pod) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:448
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:446
 	for _, cond := range pod(p).Status.Conditions {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:449
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:447
 		if cond.Type == corev1.PodReady {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:450
-			return (cond.Status == corev1.ConditionTrue)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:448
+			return cond.Status == corev1.ConditionTrue
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:451
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:449
 	return false
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:454
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:452
 func PodRestarts(p Pod) int32 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:455
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:453
 	total := int32(0)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:456
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:454
 	for _, cs := range pod(p).Status.ContainerStatuses {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:457
-		total = (total + cs.RestartCount)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:455
+		total = total + cs.RestartCount
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:458
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:456
 	return total
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:461
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:459
 func PodLabels(p Pod) map[string]string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:462
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:460
 	return pod(p).Labels
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:467
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:465
 func DeploymentName(d Deployment) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:468
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:466
 	return deployment(d).Name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:471
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:469
 func DeploymentReplicas(d Deployment) int32 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:472
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:470
 	if deployment(d).Spec.Replicas != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:473
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:471
 		return *deployment(d).Spec.Replicas
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:474
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:472
 	return 1
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:477
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:475
 func DeploymentReady(d Deployment) int32 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:478
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:476
 	return deployment(d).Status.ReadyReplicas
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:481
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:479
 func DeploymentImage(d Deployment) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:482
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:480
 	containers := deployment(d).Spec.Template.Spec.Containers
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:483
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:481
 	if len(containers) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:484
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:482
 		return containers[0].Image
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:485
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:483
 	return ""
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:490
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:488
 func ServiceName(s Service) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:491
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:489
 	return service(s).Name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:494
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:492
 func ServiceType(s Service) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:495
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:493
 	return string(service(s).Spec.Type)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:498
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:496
 func ServiceClusterIP(s Service) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:499
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:497
 	return service(s).Spec.ClusterIP
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:502
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:500
 func ServicePorts(s Service) []string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:503
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:501
 	ports := service(s).Spec.Ports
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:504
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:502
 	result := make([]string, len(ports))
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:505
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:503
 	for i, p := range ports {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:506
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:504
 		result[i] = fmt.Sprintf("%d/%s", p.Port, p.Protocol)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:507
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:505
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:512
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:510
 func NodeName(n Node) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:513
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:511
 	return node(n).Name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:516
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:514
 func NodeReady(n Node) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:517
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:515
 	for _, cond := range node(n).Status.Conditions {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:518
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:516
 		if cond.Type == corev1.NodeReady {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:519
-			return (cond.Status == corev1.ConditionTrue)
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:517
+			return cond.Status == corev1.ConditionTrue
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:520
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:518
 	return false
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:523
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:521
 func NodeRoles(n Node) []string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:524
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:522
 	roles := make([]string, 0)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:525
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:523
 	for label := range node(n).Labels {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:526
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:524
 		prefix := "node-role.kubernetes.io/"
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:527
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:525
 		if kukistring.HasPrefix(label, prefix) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:528
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:526
 			role := kukistring.TrimPrefix(label, prefix)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:529
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:527
 			if role != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:530
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:528
 				roles = append(roles, role)
 			}
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:531
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:529
 	if len(roles) == 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:532
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:530
 		roles = append(roles, "<none>")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:533
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:531
 	return roles
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:536
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:534
 func NodeVersion(n Node) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:537
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:535
 	return node(n).Status.NodeInfo.KubeletVersion
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:542
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:540
 func NamespaceName(n NamespaceItem) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:543
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:541
 	return nsItem(n).Name
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:548
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:546
 func watchPodsWithContext(h ctx.Handle, c Cluster) ([]PodEvent, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:549
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:547
 	goCtx := ctx.Value(h)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:550
-	watcher, err_30 := clientset(c).CoreV1().Pods(c.namespace).Watch(goCtx, metav1.ListOptions{})
-	if err_30 != nil {
-		err_30 = fmt.Errorf("kube watch pods: %w", err_30)
-		return []PodEvent{}, err_30
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:548
+	watcher, err_1 := clientset(c).CoreV1().Pods(c.namespace).Watch(goCtx, metav1.ListOptions{})
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube watch pods: %w", err_1)
+		return []PodEvent{}, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:551
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:549
 	defer watcher.Stop()
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:552
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:550
 	events := make([]PodEvent, 0)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:553
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:551
 	for {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:554
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:552
 		select {
 		case <-goCtx.Done():
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:556
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:554
 			return events, nil
 		case event, ok := <-watcher.ResultChan():
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:558
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:556
 			if !ok {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:559
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:557
 				return events, nil
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:560
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:558
 			p, podOk := event.Object.(*corev1.Pod)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:561
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:559
 			if !podOk {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:562
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:560
 				continue
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:563
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:561
 			ready := false
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:564
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:562
 			for _, cond := range p.Status.Conditions {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:565
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:563
 				if cond.Type == corev1.PodReady {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:566
-					ready = (cond.Status == corev1.ConditionTrue)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:567
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:564
+					ready = cond.Status == corev1.ConditionTrue
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:565
 					break
 				}
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:568
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:566
 			events = append(events, PodEvent{eventType: string(event.Type), name: p.Name, namespace: p.Namespace, phase: string(p.Status.Phase), ready: ready})
 		}
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:578
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:576
 func WatchPods(c Cluster, timeoutSeconds int64) ([]PodEvent, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:579
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:577
 	if timeoutSeconds <= 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:580
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:578
 		timeoutSeconds = 30
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:581
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:579
 	h := ctx.WithTimeout(ctx.Background(), timeoutSeconds)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:582
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:580
 	defer ctx.Cancel(h)
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:583
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:581
 	return watchPodsWithContext(h, c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:586
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:584
 func WatchPodsCtx(c Cluster, h ctx.Handle) ([]PodEvent, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:587
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:585
 	return watchPodsWithContext(h, c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:593
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:591
 func PodLogs(c Cluster, name string, handles ...ctx.Handle) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:594
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:592
 	goCtx := ctx.Value(ctx.Background())
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:595
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:593
 	if len(handles) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:596
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:594
 		goCtx = ctx.Value(handles[0])
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:597
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:595
 	req := clientset(c).CoreV1().Pods(c.namespace).GetLogs(name, &corev1.PodLogOptions{})
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:598
-	stream, err_31 := req.Stream(goCtx)
-	if err_31 != nil {
-		err_31 = fmt.Errorf("kube pod logs: %w", err_31)
-		return "", err_31
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:596
+	stream, err_1 := req.Stream(goCtx)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube pod logs: %w", err_1)
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:599
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:597
 	defer stream.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:600
-	data, err_32 := io.ReadAll(stream)
-	if err_32 != nil {
-		err_32 = fmt.Errorf("kube pod logs read: %w", err_32)
-		return "", err_32
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:598
+	data, err_2 := io.ReadAll(stream)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("kube pod logs read: %w", err_2)
+		return "", err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:601
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:599
 	return string(data), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:604
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:602
 func PodLogsTail(c Cluster, name string, lines int64) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:605
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:603
 	req := clientset(c).CoreV1().Pods(c.namespace).GetLogs(name, &corev1.PodLogOptions{TailLines: &lines})
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:606
-	stream, err_33 := req.Stream(ctx.Value(ctx.Background()))
-	if err_33 != nil {
-		err_33 = fmt.Errorf("kube pod logs tail: %w", err_33)
-		return "", err_33
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:604
+	stream, err_1 := req.Stream(ctx.Value(ctx.Background()))
+	if err_1 != nil {
+		err_1 = fmt.Errorf("kube pod logs tail: %w", err_1)
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:607
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:605
 	defer stream.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:608
-	data, err_34 := io.ReadAll(stream)
-	if err_34 != nil {
-		err_34 = fmt.Errorf("kube pod logs tail read: %w", err_34)
-		return "", err_34
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:606
+	data, err_2 := io.ReadAll(stream)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("kube pod logs tail read: %w", err_2)
+		return "", err_2
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:609
+//line /Users/tluker/repos/go/kukicha/stdlib/kube/kube.kuki:607
 	return string(data), nil
 }
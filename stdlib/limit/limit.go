@@ -0,0 +1,132 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package limit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:22
+type Rate struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:28
+type Slots struct {
+	ch chan bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:35
+func NewRate(n int, per time.Duration) *Rate {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:36
+	if n <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:37
+		panic(fmt.Sprintf("limit.NewRate: n must be positive, got %v", n))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:38
+	return &Rate{interval: time.Duration(per.Nanoseconds() / int64(n))}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:42
+func Wait(r *Rate) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:43
+	time.Sleep(reserve(r, true))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:48
+func Allow(r *Rate) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:49
+	return reserve(r, false) == 0
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:53
+func Limited[T any](item T, r *Rate) T {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:54
+	Wait(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:55
+	return item
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:60
+func Run[T any, R any](item T, r *Rate, fn func(T) (R, error)) (R, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:61
+	Wait(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:62
+	return fn(item)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:67
+func Semaphore(n int) Slots {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:68
+	if n <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:69
+		panic(fmt.Sprintf("limit.Semaphore: n must be positive, got %v", n))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:70
+	return Slots{ch: make(chan bool, n)}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:75
+func Acquire(s Slots) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:76
+	s.ch <- true
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:80
+func TryAcquire(s Slots) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:81
+	select {
+	case s.ch <- true:
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:83
+		return true
+	default:
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:85
+		return false
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:89
+func Release(s Slots) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:90
+	<-s.ch
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:94
+func Guard[T any, R any](item T, s Slots, fn func(T) (R, error)) (R, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:95
+	Acquire(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:96
+	defer Release(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:97
+	return fn(item)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:101
+func reserve(r *Rate, block bool) time.Duration {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:102
+	r.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:103
+	defer r.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:104
+	now := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:105
+	if r.next.Before(now) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:106
+		r.next = now
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:107
+	wait := r.next.Sub(now)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:108
+	if wait > 0 && !block {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:109
+		return wait
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:110
+	r.next = r.next.Add(r.interval)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit.kuki:111
+	return wait
+}
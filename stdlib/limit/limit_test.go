@@ -0,0 +1,237 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package limit_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/limit"
+	"github.com/duber000/kukicha/stdlib/test"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:12
+func upper(s string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:13
+	if s == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:14
+		return "", errors.New("empty word")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:15
+	return strings.ToUpper(s), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:18
+func TestRateSpacing(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:19
+	rate := limit.NewRate(100, 1000000000)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:20
+	start := time.Now()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:21
+	for range 6 {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:22
+		limit.Wait(rate)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:24
+	test.AssertTrue(t, time.Since(start) >= 45*time.Millisecond, fmt.Sprintf("elapsed %v", time.Since(start)))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:27
+type AllowCase struct {
+	name string
+	n    int
+	per  time.Duration
+	want []bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:33
+func TestAllow(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:34
+	cases := []AllowCase{AllowCase{name: "one per minute", n: 1, per: time.Minute, want: []bool{true, false, false}}, AllowCase{name: "two per hour", n: 2, per: time.Hour, want: []bool{true, false}}}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:38
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:39
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:40
+			rate := limit.NewRate(tc.n, tc.per)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:41
+			got := []bool{}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:42
+			for range tc.want {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:43
+				got = append(got, limit.Allow(rate))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:44
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:48
+func TestLimited(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:49
+	rate := limit.NewRate(1000, 1000000000)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:50
+	word := limit.Limited("go", rate)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:51
+	test.AssertEqual(t, word+"!", "go!")
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:52
+	n := limit.Limited(7, rate)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:53
+	test.AssertEqual(t, n+1, 8)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:56
+type RunCase struct {
+	name    string
+	words   []string
+	want    []string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:62
+func TestRun(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:63
+	cases := []RunCase{RunCase{name: "all succeed", words: []string{"a", "b", "c"}, want: []string{"A", "B", "C"}}, RunCase{name: "step error", words: []string{"a", ""}, wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:67
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:68
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:69
+			rate := limit.NewRate(1000, 1000000000)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:70
+			got, err := func() ([]string, error) {
+				items_1 := tc.words
+				results_2 := make([]string, len(items_1))
+				errs_3 := make([]error, len(items_1))
+				sem_4 := make(chan struct{}, runtime.GOMAXPROCS(0))
+				var wg_5 sync.WaitGroup
+				for i_6, item_7 := range items_1 {
+					wg_5.Add(1)
+					sem_4 <- struct{}{}
+					go func() {
+						defer wg_5.Done()
+						defer func() { <-sem_4 }()
+						results_2[i_6], errs_3[i_6] = limit.Run(item_7, rate, upper)
+					}()
+				}
+				wg_5.Wait()
+				for _, err := range errs_3 {
+					if err != nil {
+						return nil, err
+					}
+				}
+				return results_2, nil
+			}()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:71
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:72
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:73
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:74
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:75
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:79
+func TestSemaphore(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:80
+	slots := limit.Semaphore(2)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:81
+	test.AssertTrue(t, limit.TryAcquire(slots))
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:82
+	limit.Acquire(slots)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:83
+	test.AssertFalse(t, limit.TryAcquire(slots))
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:84
+	limit.Release(slots)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:85
+	test.AssertTrue(t, limit.TryAcquire(slots))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:88
+func TestGuardCapsConcurrency(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:89
+	slots := limit.Semaphore(2)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:90
+	mu := sync.Mutex{}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:91
+	running := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:92
+	peak := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:93
+	track := func(s string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:94
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:95
+		running = running + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:96
+		if running > peak {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:97
+			peak = running
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:98
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:99
+		time.Sleep(5 * time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:100
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:101
+		running = running - 1
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:102
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:103
+		return s, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:104
+	words := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:105
+	got, err_1 := limit.Guard("x", slots, track)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:106
+	test.AssertEqual(t, got, "x")
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:107
+	wg := sync.WaitGroup{}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:108
+	for _, w := range words {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:109
+		wg.Add(1)
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:110
+		go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:111
+			defer wg.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:112
+			limit.Guard(w, slots, track)
+		}()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:114
+	wg.Wait()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:115
+	test.AssertTrue(t, peak <= 2, fmt.Sprintf("peak %v", peak))
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:116
+	test.AssertTrue(t, peak >= 1, fmt.Sprintf("peak %v", peak))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:119
+func TestNewRatePanics(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:120
+	defer func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:121
+		r := recover()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:122
+		test.AssertTrue(t, r != nil, "expected a panic")
+	}()
+//line /Users/tluker/repos/go/kukicha/stdlib/limit/limit_test.kuki:124
+	limit.NewRate(0, 1000000000)
+}
@@ -3,7 +3,6 @@
 package llm
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"github.com/duber000/kukicha/stdlib/env"
@@ -12,60 +11,60 @@ import (
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:127
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:126
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:132
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:131
 type ToolFunction struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Parameters  any    `json:"parameters"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:138
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:137
 type Tool struct {
 	Type     string       `json:"type"`
 	Function ToolFunction `json:"function"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:143
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:142
 type ToolCall struct {
 	ID       string           `json:"id"`
 	Type     string           `json:"type"`
 	Function ToolCallFunction `json:"function"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:149
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:148
 type ToolCallFunction struct {
 	Name      string `json:"name"`
 	Arguments string `json:"arguments"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:154
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:153
 type Choice struct {
 	Index        int             `json:"index"`
 	Message      ResponseMessage `json:"message"`
 	FinishReason string          `json:"finish_reason"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:160
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:159
 type ResponseMessage struct {
 	Role      string     `json:"role"`
 	Content   string     `json:"content"`
 	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:166
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:165
 type Usage struct {
 	PromptTokens     int `json:"prompt_tokens"`
 	CompletionTokens int `json:"completion_tokens"`
 	TotalTokens      int `json:"total_tokens"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:172
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:171
 type Completion struct {
 	ID      string   `json:"id"`
 	Object  string   `json:"object"`
@@ -75,20 +74,20 @@ type Completion struct {
 	Usage   Usage    `json:"usage"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:181
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:180
 type ChunkDelta struct {
 	Role    string `json:"role,omitempty"`
 	Content string `json:"content,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:186
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:185
 type ChunkChoice struct {
 	Index        int        `json:"index"`
 	Delta        ChunkDelta `json:"delta"`
 	FinishReason string     `json:"finish_reason,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:192
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:191
 type Chunk struct {
 	ID      string        `json:"id"`
 	Object  string        `json:"object"`
@@ -97,7 +96,7 @@ type Chunk struct {
 	Choices []ChunkChoice `json:"choices"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:200
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:199
 type CompletionRequest struct {
 	Model            string    `json:"model"`
 	Messages         []Message `json:"messages"`
@@ -116,7 +115,7 @@ type CompletionRequest struct {
 	ResponseFormat   any       `json:"response_format,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:218
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:217
 type Client struct {
 	model            string
 	provider         string
@@ -141,733 +140,719 @@ type Client struct {
 	retryDelayMs     int
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:245
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:244
 func New(model string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:246
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:245
 	c := Client{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:247
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:246
 	c.messages = []Message{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:248
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:247
 	c.tools = []Tool{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:249
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:248
 	c.temperature = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:250
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:249
 	c.maxTokens = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:251
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:250
 	c.topP = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:252
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:251
 	c.n = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:253
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:252
 	c.seed = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:254
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:253
 	c.presencePenalty = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:255
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:254
 	c.frequencyPenalty = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:258
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:257
 	if kukistring.Contains(model, ":") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:259
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:258
 		parts := kukistring.SplitN(model, ":", 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:260
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:259
 		c.provider = parts[0]
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:261
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:260
 		c.model = parts[1]
 	} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:263
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:262
 		c.model = model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:264
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:263
 		c.provider = ""
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:266
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:265
 	c.baseURL = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:267
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:266
 	c.path = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:268
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:267
 	c.apiKey = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:269
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:268
 	c.user = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:270
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:269
 	c.streamHandler = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:271
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:270
 	c.toolChoice = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:272
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:271
 	c.responseFormat = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:273
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:272
 	c.retryMaxAttempts = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:274
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:273
 	c.retryDelayMs = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:275
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:274
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:279
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:278
 func Provider(c Client, provider string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:280
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:279
 	c.provider = provider
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:281
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:280
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:285
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:284
 func Gateway(c Client, url string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:286
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:285
 	c.baseURL = url
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:287
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:286
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:292
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:291
 func BaseURL(c Client, url string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:293
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:292
 	c.baseURL = url
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:294
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:293
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:299
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:298
 func Path(c Client, path string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:300
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:299
 	c.path = path
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:301
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:300
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:307
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:306
 func APIKey(c Client, key string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:308
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:307
 	c.apiKey = key
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:309
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:308
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:313
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:312
 func System(c Client, content string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:314
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:313
 	msg := Message{Role: "system", Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:315
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:314
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:316
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:315
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:320
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:319
 func User(c Client, content string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:321
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:320
 	msg := Message{Role: "user", Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:322
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:321
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:323
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:322
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:327
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:326
 func Assistant(c Client, content string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:328
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:327
 	msg := Message{Role: "assistant", Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:329
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:328
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:330
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:329
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:334
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:333
 func AddMessage(c Client, role string, content string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:335
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:334
 	msg := Message{Role: role, Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:336
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:335
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:337
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:336
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:341
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:340
 func Messages(c Client, msgs []Message) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:342
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:341
 	c.messages = msgs
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:343
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:342
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:348
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:347
 func Temperature(c Client, temp float64) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:349
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:348
 	c.temperature = temp
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:350
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:349
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:354
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:353
 func MaxTokens(c Client, max int) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:355
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:354
 	c.maxTokens = max
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:356
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:355
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:360
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:359
 func TopP(c Client, p float64) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:361
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:360
 	c.topP = p
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:362
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:361
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:366
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:365
 func Stop(c Client, sequences []string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:367
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:366
 	c.stop = sequences
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:368
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:367
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:373
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:372
 func PresencePenalty(c Client, penalty float64) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:374
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:373
 	c.presencePenalty = penalty
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:375
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:374
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:380
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:379
 func FrequencyPenalty(c Client, penalty float64) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:381
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:380
 	c.frequencyPenalty = penalty
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:382
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:381
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:386
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:385
 func Seed(c Client, seed int) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:387
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:386
 	c.seed = seed
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:388
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:387
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:392
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:391
 func SetUser(c Client, user string) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:393
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:392
 	c.user = user
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:394
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:393
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:399
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:398
 func AddTool(c Client, name string, description string, parameters any) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:400
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:399
 	fn := ToolFunction{Name: name, Description: description, Parameters: parameters}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:401
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:400
 	tool := Tool{Type: "function", Function: fn}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:402
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:401
 	c.tools = append(c.tools, tool)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:403
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:402
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:407
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:406
 func ToolChoiceAuto(c Client) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:408
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:407
 	c.toolChoice = "auto"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:409
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:408
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:413
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:412
 func ToolChoiceRequired(c Client) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:414
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:413
 	c.toolChoice = "required"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:415
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:414
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:419
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:418
 func ToolChoiceNone(c Client) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:420
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:419
 	c.toolChoice = "none"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:421
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:420
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:425
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:424
 func JSONMode(c Client) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:426
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:425
 	c.responseFormat = map[string]string{"type": "json_object"}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:427
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:426
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:432
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:431
 func Stream(c Client, handler func(string)) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:433
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:432
 	c.streamHandler = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:434
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:433
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:439
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:438
 func Retry(c Client, maxAttempts int, delayMs int) Client {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:440
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:439
 	c.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:441
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:440
 	c.retryDelayMs = delayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:442
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:441
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:449
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:448
 func Ask(c Client, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:450
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:449
 	c = User(c, prompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:451
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:450
 	return execute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:462
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:461
 func Send(c Client) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:463
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:462
 	return execute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:473
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:472
 func SendRaw(c Client) (Completion, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:474
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:473
 	return executeRaw(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:481
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:480
 func Complete(model string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:482
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:481
 	c := New(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:483
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:482
 	return Ask(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:488
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:487
 func CompleteWithSystem(model string, systemPrompt string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:489
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:488
 	c := New(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:490
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:489
 	c = System(c, systemPrompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:491
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:490
 	return Ask(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:496
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:495
 func GetContent(comp Completion) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:497
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:496
 	if len(comp.Choices) == 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:498
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:497
 		return ""
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:499
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:498
 	return comp.Choices[0].Message.Content
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:504
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:503
 func GetToolCalls(comp Completion) []ToolCall {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:505
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:504
 	if len(comp.Choices) == 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:506
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:505
 		return []ToolCall{}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:507
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:506
 	return comp.Choices[0].Message.ToolCalls
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:511
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:510
 func HasToolCalls(comp Completion) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:512
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:511
 	calls := GetToolCalls(comp)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:513
-	return (len(calls) > 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:512
+	return len(calls) > 0
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:516
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:515
 func resolveAPIKey(c Client) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:517
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:516
 	if c.apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:518
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:517
 		return c.apiKey
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:521
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:520
 	if c.provider == "openai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:522
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:521
 		key := env.GetOr("OPENAI_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:523
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:522
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:524
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:523
 	if c.provider == "anthropic" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:525
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:524
 		key := env.GetOr("ANTHROPIC_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:526
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:525
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:527
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:526
 	if c.provider == "mistral" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:528
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:527
 		key := env.GetOr("MISTRAL_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:529
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:528
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:530
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:529
 	if c.provider == "groq" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:531
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:530
 		key := env.GetOr("GROQ_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:532
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:531
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:533
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:532
 	if c.provider == "together" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:534
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:533
 		key := env.GetOr("TOGETHER_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:535
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:534
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:536
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:535
 	if c.provider == "deepseek" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:537
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:536
 		key := env.GetOr("DEEPSEEK_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:538
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:537
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:539
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:538
 	if c.provider == "xai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:540
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:539
 		key := env.GetOr("XAI_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:541
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:540
 		return key
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:544
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:543
 	key := env.GetOr("LLM_API_KEY", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:545
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:544
 	return key
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:548
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:547
 func resolveBaseURL(c Client) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:549
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:548
 	if c.baseURL != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:550
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:549
 		return c.baseURL
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:553
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:552
 	if c.provider == "openai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:554
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:553
 		return "https://api.openai.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:555
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:554
 	if c.provider == "anthropic" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:556
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:555
 		return "https://api.anthropic.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:557
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:556
 	if c.provider == "mistral" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:558
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:557
 		return "https://api.mistral.ai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:559
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:558
 	if c.provider == "groq" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:560
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:559
 		return "https://api.groq.com/openai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:561
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:560
 	if c.provider == "together" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:562
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:561
 		return "https://api.together.xyz"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:563
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:562
 	if c.provider == "deepseek" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:564
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:563
 		return "https://api.deepseek.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:565
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:564
 	if c.provider == "xai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:566
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:565
 		return "https://api.x.ai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:567
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:566
 	if c.provider == "ollama" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:568
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:567
 		return "http://localhost:11434"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:571
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:570
 	envURL := env.GetOr("LLM_BASE_URL", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:572
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:571
 	if envURL != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:573
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:572
 		return envURL
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:574
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:573
 	return "http://localhost:8000"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:577
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:576
 func resolvePath(c Client) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:578
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:577
 	if c.path != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:579
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:578
 		return c.path
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:580
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:579
 	return "/v1/chat/completions"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:583
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:582
 func buildRequest(c Client) CompletionRequest {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:584
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:583
 	req := CompletionRequest{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:585
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:584
 	req.Model = c.model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:586
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:585
 	req.Messages = c.messages
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:588
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:587
 	if c.temperature != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:589
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:588
 		req.Temperature = c.temperature
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:590
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:589
 	if c.maxTokens != 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:591
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:590
 		req.MaxTokens = c.maxTokens
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:592
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:591
 	if c.topP != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:593
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:592
 		req.TopP = c.topP
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:594
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:593
 	if c.n != 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:595
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:594
 		req.N = c.n
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:596
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:595
 	if c.seed != 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:597
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:596
 		req.Seed = c.seed
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:598
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:597
 	if c.presencePenalty != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:599
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:598
 		req.PresencePenalty = c.presencePenalty
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:600
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:599
 	if c.frequencyPenalty != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:601
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:600
 		req.FrequencyPenalty = c.frequencyPenalty
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:602
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:601
 	if c.user != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:603
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:602
 		req.User = c.user
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:604
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:603
 	if len(c.stop) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:605
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:604
 		req.Stop = c.stop
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:606
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:605
 	if len(c.tools) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:607
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:606
 		req.Tools = c.tools
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:608
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:607
 	if c.toolChoice != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:609
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:608
 		req.ToolChoice = c.toolChoice
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:610
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:609
 	if c.responseFormat != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:611
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:610
 		req.ResponseFormat = c.responseFormat
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:612
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:611
 	if c.streamHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:613
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:612
 		req.Stream = true
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:615
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:614
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:618
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:617
 func execute(c Client) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:619
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:618
 	if c.streamHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:620
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:619
 		return executeStream(c)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:622
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:621
 	comp, err_1 := executeRaw(c)
 	if err_1 != nil {
 		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:623
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:622
 	return GetContent(comp), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:626
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:625
 func executeRaw(c Client) (Completion, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:627
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:626
 	baseURL := resolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:628
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:627
 	apiKey := resolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:629
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:628
 	chatPath := resolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:630
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:629
 	url := fmt.Sprintf("%v%v", baseURL, chatPath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:631
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:630
 	body := buildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:633
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:632
 	req := fetch.Body(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:638
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:637
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:639
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:638
 		req = fetch.Header(req, "Authorization", fmt.Sprintf("Bearer %v", apiKey))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:641
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:640
 	if c.retryMaxAttempts > 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:642
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:641
 		req = fetch.Retry(req, c.retryMaxAttempts, c.retryDelayMs)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:644
-	resp, err_2 := fetch.Do(req)
-	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:643
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
 		var _zero0 Completion
-		return _zero0, err_2
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:646
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:645
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:648
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:647
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:649
-		errBody, err_3 := fetch.Bytes(resp)
-		if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:648
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return Completion{}, fmt.Errorf("API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:650
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:649
 		return Completion{}, fmt.Errorf("API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:652
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:651
 	comp := Completion{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:653
-	err_4 := json.UnmarshalRead(resp.Body, &comp)
-	if err_4 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:652
+	err_3 := json.UnmarshalRead(resp.Body, &comp)
+	if err_3 != nil {
 		var _zero0 Completion
-		return _zero0, err_4
+		return _zero0, err_3
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:655
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:654
 	return comp, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:658
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:657
 func executeStream(c Client) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:659
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:658
 	baseURL := resolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:660
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:659
 	apiKey := resolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:661
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:660
 	chatPath := resolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:662
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:661
 	url := fmt.Sprintf("%v%v", baseURL, chatPath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:663
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:662
 	body := buildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:665
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:664
 	req := fetch.Body(fetch.Header(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), "Accept", "text/event-stream"), body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:671
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:670
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:672
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:671
 		req = fetch.Header(req, "Authorization", fmt.Sprintf("Bearer %v", apiKey))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:674
-	resp, err_5 := fetch.Do(req)
-	if err_5 != nil {
-		return "", err_5
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:673
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:676
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:675
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:678
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:677
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:679
-		errBody, err_6 := fetch.Bytes(resp)
-		if err_6 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:678
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return "", fmt.Errorf("API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:680
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:679
 		return "", fmt.Errorf("API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:683
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:682
 	fullContent := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:683
+	for ev := range fetch.Events(resp.Body) {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:684
-	scanner := bufio.NewScanner(resp.Body)
+		if ev.Err != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:685
-	for scanner.Scan() {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:686
-		line := scanner.Text()
+			return fullContent, ev.Err
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:688
+		if ev.Data == "[DONE]" {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:689
-		if (line == "") || kukistring.HasPrefix(line, ":") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:690
-			continue
+			break
 		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:691
+		chunk := Chunk{}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:692
+		err_3 := json.Unmarshal([]byte(ev.Data), &chunk)
+		if err_3 != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:693
-		if line == "data: [DONE]" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:694
-			break
+			continue
 		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:695
+		if len(chunk.Choices) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:696
+			content := chunk.Choices[0].Delta.Content
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:697
-		if kukistring.HasPrefix(line, "data: ") {
+			if content != "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:698
-			data := kukistring.TrimPrefix(line, "data: ")
+				fullContent = fullContent + content
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:699
-			chunk := Chunk{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:700
-			err_7 := json.Unmarshal([]byte(data), &chunk)
-			if err_7 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:701
-				continue
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:703
-			if len(chunk.Choices) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:704
-				content := chunk.Choices[0].Delta.Content
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:705
-				if content != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:706
-					fullContent = (fullContent + content)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:707
-					c.streamHandler(content)
-				}
+				c.streamHandler(content)
 			}
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:709
-	err_8 := scanner.Err()
-	if err_8 != nil {
-		return fullContent, fmt.Errorf("%v", err_8)
-	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:711
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:701
 	return fullContent, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:718
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:708
 type InputTextContent struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:723
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:713
 type OutputTextContent struct {
 	Type        string `json:"type"`
 	Text        string `json:"text"`
 	Annotations []any  `json:"annotations,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:729
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:719
 type RefusalContent struct {
 	Type    string `json:"type"`
 	Refusal string `json:"refusal"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:735
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:725
 type InputItem struct {
 	Type      string `json:"type"`
 	ID        string `json:"id,omitempty"`
@@ -880,7 +865,7 @@ type InputItem struct {
 	Output    string `json:"output,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:748
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:738
 type OutputItem struct {
 	Type      string `json:"type"`
 	ID        string `json:"id,omitempty"`
@@ -893,20 +878,20 @@ type OutputItem struct {
 	Summary   []any  `json:"summary,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:760
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:750
 type ResponseUsage struct {
 	InputTokens  int `json:"input_tokens"`
 	OutputTokens int `json:"output_tokens"`
 	TotalTokens  int `json:"total_tokens"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:766
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:756
 type ResponseError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:771
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:761
 type Response struct {
 	ID                 string            `json:"id"`
 	Object             string            `json:"object"`
@@ -929,7 +914,7 @@ type Response struct {
 	Metadata           map[string]string `json:"metadata,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:793
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:783
 type ResponseRequest struct {
 	Model              string            `json:"model"`
 	Input              any               `json:"input"`
@@ -949,7 +934,7 @@ type ResponseRequest struct {
 	Text               any               `json:"text,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:812
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:802
 type StreamEvent struct {
 	Type           string     `json:"type"`
 	SequenceNumber int        `json:"sequence_number"`
@@ -967,7 +952,7 @@ type StreamEvent struct {
 	Message        string     `json:"message,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:829
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:819
 type ResponseClient struct {
 	model              string
 	provider           string
@@ -994,126 +979,142 @@ type ResponseClient struct {
 	retryDelayMs       int
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:857
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:847
 func NewResponse(model string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:858
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:848
 	c := ResponseClient{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:859
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:849
 	c.input = []InputItem{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:860
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:850
 	c.tools = []Tool{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:861
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:851
 	c.temperature = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:862
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:852
 	c.topP = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:863
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:853
 	c.maxOutputTokens = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:864
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:854
 	c.presencePenalty = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:865
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:855
 	c.frequencyPenalty = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:866
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:856
 	c.store = false
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:867
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:857
 	c.truncation = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:868
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:858
 	c.instructions = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:869
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:859
 	c.previousResponseID = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:872
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:862
 	if kukistring.Contains(model, ":") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:873
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:863
 		parts := kukistring.SplitN(model, ":", 2)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:874
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:864
 		c.provider = parts[0]
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:875
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:865
 		c.model = parts[1]
 	} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:877
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:867
 		c.model = model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:878
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:868
 		c.provider = ""
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:880
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:870
 	c.baseURL = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:881
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:871
 	c.path = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:882
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:872
 	c.apiKey = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:883
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:873
 	c.streamHandler = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:884
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:874
 	c.eventHandler = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:885
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:875
 	c.toolChoice = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:886
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:876
 	c.textFormat = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:887
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:877
 	c.metadata = map[string]string{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:888
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:878
 	c.retryMaxAttempts = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:889
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:879
 	c.retryDelayMs = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:890
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:880
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:894
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:884
 func RProvider(c ResponseClient, provider string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:895
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:885
 	c.provider = provider
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:896
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:886
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:900
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:890
 func RBaseURL(c ResponseClient, url string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:901
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:891
 	c.baseURL = url
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:902
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:892
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:906
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:896
 func RPath(c ResponseClient, path string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:907
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:897
 	c.path = path
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:908
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:898
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:912
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:902
 func RAPIKey(c ResponseClient, key string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:913
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:903
 	c.apiKey = key
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:914
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:904
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:918
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:908
 func Instructions(c ResponseClient, instructions string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:919
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:909
 	c.instructions = instructions
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:920
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:910
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:924
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:914
 func PreviousResponse(c ResponseClient, id string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:925
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:915
 	c.previousResponseID = id
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:916
+	return c
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:920
+func RUserMessage(c ResponseClient, content string) ResponseClient {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:921
+	item := InputItem{}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:922
+	item.Type = "message"
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:923
+	item.Role = "user"
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:924
+	item.Content = content
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:925
+	c.input = append(c.input, item)
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:926
 	return c
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:930
-func RUserMessage(c ResponseClient, content string) ResponseClient {
+func RSystemMessage(c ResponseClient, content string) ResponseClient {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:931
 	item := InputItem{}
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:932
 	item.Type = "message"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:933
-	item.Role = "user"
+	item.Role = "system"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:934
 	item.Content = content
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:935
@@ -1123,13 +1124,13 @@ func RUserMessage(c ResponseClient, content string) ResponseClient {
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:940
-func RSystemMessage(c ResponseClient, content string) ResponseClient {
+func RDeveloperMessage(c ResponseClient, content string) ResponseClient {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:941
 	item := InputItem{}
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:942
 	item.Type = "message"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:943
-	item.Role = "system"
+	item.Role = "developer"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:944
 	item.Content = content
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:945
@@ -1139,13 +1140,13 @@ func RSystemMessage(c ResponseClient, content string) ResponseClient {
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:950
-func RDeveloperMessage(c ResponseClient, content string) ResponseClient {
+func RAssistantMessage(c ResponseClient, content string) ResponseClient {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:951
 	item := InputItem{}
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:952
 	item.Type = "message"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:953
-	item.Role = "developer"
+	item.Role = "assistant"
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:954
 	item.Content = content
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:955
@@ -1155,253 +1156,237 @@ func RDeveloperMessage(c ResponseClient, content string) ResponseClient {
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:960
-func RAssistantMessage(c ResponseClient, content string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:961
-	item := InputItem{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:962
-	item.Type = "message"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:963
-	item.Role = "assistant"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:964
-	item.Content = content
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:965
-	c.input = append(c.input, item)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:966
-	return c
-}
-
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:970
 func RAddInput(c ResponseClient, item InputItem) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:971
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:961
 	c.input = append(c.input, item)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:972
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:962
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:976
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:966
 func FunctionCallOutput(c ResponseClient, callID string, output string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:977
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:967
 	item := InputItem{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:978
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:968
 	item.Type = "function_call_output"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:979
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:969
 	item.CallID = callID
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:980
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:970
 	item.Output = output
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:981
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:971
 	c.input = append(c.input, item)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:982
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:972
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:986
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:976
 func RTemperature(c ResponseClient, temp float64) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:987
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:977
 	c.temperature = temp
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:988
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:978
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:992
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:982
 func RTopP(c ResponseClient, p float64) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:993
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:983
 	c.topP = p
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:994
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:984
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:998
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:988
 func RMaxOutputTokens(c ResponseClient, max int) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:999
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:989
 	c.maxOutputTokens = max
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1000
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:990
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1004
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:994
 func RPresencePenalty(c ResponseClient, penalty float64) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1005
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:995
 	c.presencePenalty = penalty
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1006
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:996
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1010
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1000
 func RFrequencyPenalty(c ResponseClient, penalty float64) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1011
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1001
 	c.frequencyPenalty = penalty
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1012
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1002
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1016
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1006
 func RAddTool(c ResponseClient, name string, description string, parameters any) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1017
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1007
 	fn := ToolFunction{Name: name, Description: description, Parameters: parameters}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1018
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1008
 	tool := Tool{Type: "function", Function: fn}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1019
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1009
 	c.tools = append(c.tools, tool)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1020
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1010
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1024
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1014
 func RToolChoiceAuto(c ResponseClient) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1025
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1015
 	c.toolChoice = "auto"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1026
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1016
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1030
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1020
 func RToolChoiceRequired(c ResponseClient) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1031
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1021
 	c.toolChoice = "required"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1032
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1022
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1036
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1026
 func RToolChoiceNone(c ResponseClient) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1037
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1027
 	c.toolChoice = "none"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1038
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1028
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1042
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1032
 func RJSONMode(c ResponseClient) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1043
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1033
 	c.textFormat = map[string]string{"type": "json_object"}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1044
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1034
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1048
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1038
 func RJSONSchema(c ResponseClient, name string, schema any) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1049
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1039
 	c.textFormat = map[string]any{"type": "json_schema", "name": name, "schema": schema, "strict": true}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1050
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1040
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1054
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1044
 func RStore(c ResponseClient) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1055
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1045
 	c.store = true
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1056
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1046
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1060
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1050
 func RTruncation(c ResponseClient, mode string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1061
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1051
 	c.truncation = mode
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1062
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1052
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1066
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1056
 func RMetadata(c ResponseClient, meta map[string]string) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1067
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1057
 	c.metadata = meta
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1068
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1058
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1073
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1063
 func RStream(c ResponseClient, handler func(string)) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1074
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1064
 	c.streamHandler = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1075
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1065
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1080
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1070
 func RStreamEvents(c ResponseClient, handler func(StreamEvent)) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1081
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1071
 	c.eventHandler = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1082
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1072
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1086
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1076
 func RRetry(c ResponseClient, maxAttempts int, delayMs int) ResponseClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1087
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1077
 	c.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1088
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1078
 	c.retryDelayMs = delayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1089
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1079
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1095
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1085
 func RAsk(c ResponseClient, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1096
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1086
 	c = RUserMessage(c, prompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1097
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1087
 	return rExecute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1107
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1097
 func RSend(c ResponseClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1108
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1098
 	return rExecute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1116
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1106
 func RAskRaw(c ResponseClient, prompt string) (Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1117
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1107
 	c = RUserMessage(c, prompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1118
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1108
 	return rExecuteRaw(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1126
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1116
 func RSendRaw(c ResponseClient) (Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1127
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1117
 	return rExecuteRaw(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1133
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1123
 func Respond(model string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1134
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1124
 	c := NewResponse(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1135
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1125
 	return RAsk(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1140
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1130
 func RespondWithInstructions(model string, instructions string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1141
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1131
 	c := NewResponse(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1142
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1132
 	c = Instructions(c, instructions)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1143
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1133
 	return RAsk(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1148
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1138
 func GetResponseText(resp Response) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1149
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1139
 	for _, item := range resp.Output {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1150
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1140
 		if item.Type == "message" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1151
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1141
 			for _, content := range item.Content {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1152
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1142
 				switch contentMap := content.(type) {
 				case map[string]any:
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1154
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1144
 					switch contentTypeStr := contentMap["type"].(type) {
 					case string:
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1156
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1146
 						if contentTypeStr == "output_text" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1157
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1147
 							switch textStr := contentMap["text"].(type) {
 							case string:
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1159
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1149
 								return textStr
 							}
 						}
@@ -1410,415 +1395,401 @@ func GetResponseText(resp Response) string {
 			}
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1160
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1150
 	return ""
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1165
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1155
 func GetFunctionCalls(resp Response) []OutputItem {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1166
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1156
 	calls := []OutputItem{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1167
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1157
 	for _, item := range resp.Output {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1168
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1158
 		if item.Type == "function_call" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1169
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1159
 			calls = append(calls, item)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1170
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1160
 	return calls
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1174
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1164
 func HasFunctionCalls(resp Response) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1175
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1165
 	calls := GetFunctionCalls(resp)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1176
-	return (len(calls) > 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1166
+	return len(calls) > 0
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1179
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1169
 func rResolveAPIKey(c ResponseClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1180
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1170
 	if c.apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1181
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1171
 		return c.apiKey
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1183
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1173
 	if c.provider == "openai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1184
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1174
 		return env.GetOr("OPENAI_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1185
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1175
 	if c.provider == "anthropic" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1186
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1176
 		return env.GetOr("ANTHROPIC_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1187
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1177
 	if c.provider == "mistral" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1188
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1178
 		return env.GetOr("MISTRAL_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1189
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1179
 	if c.provider == "groq" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1190
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1180
 		return env.GetOr("GROQ_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1191
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1181
 	if c.provider == "together" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1192
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1182
 		return env.GetOr("TOGETHER_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1193
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1183
 	if c.provider == "deepseek" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1194
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1184
 		return env.GetOr("DEEPSEEK_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1195
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1185
 	if c.provider == "xai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1196
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1186
 		return env.GetOr("XAI_API_KEY", "")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1198
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1188
 	return env.GetOr("LLM_API_KEY", "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1201
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1191
 func rResolveBaseURL(c ResponseClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1202
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1192
 	if c.baseURL != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1203
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1193
 		return c.baseURL
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1205
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1195
 	if c.provider == "openai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1206
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1196
 		return "https://api.openai.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1207
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1197
 	if c.provider == "anthropic" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1208
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1198
 		return "https://api.anthropic.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1209
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1199
 	if c.provider == "mistral" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1210
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1200
 		return "https://api.mistral.ai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1211
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1201
 	if c.provider == "groq" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1212
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1202
 		return "https://api.groq.com/openai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1213
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1203
 	if c.provider == "together" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1214
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1204
 		return "https://api.together.xyz"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1215
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1205
 	if c.provider == "deepseek" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1216
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1206
 		return "https://api.deepseek.com"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1217
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1207
 	if c.provider == "xai" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1218
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1208
 		return "https://api.x.ai"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1219
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1209
 	if c.provider == "ollama" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1220
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1210
 		return "http://localhost:11434"
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1223
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1213
 	envURL := env.GetOr("LLM_BASE_URL", "")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1224
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1214
 	if envURL != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1225
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1215
 		return envURL
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1226
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1216
 	return "http://localhost:8000"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1229
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1219
 func rResolvePath(c ResponseClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1230
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1220
 	if c.path != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1231
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1221
 		return c.path
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1232
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1222
 	return "/v1/responses"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1235
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1225
 func rBuildRequest(c ResponseClient) ResponseRequest {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1236
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1226
 	req := ResponseRequest{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1237
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1227
 	req.Model = c.model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1240
-	if ((len(c.input) == 1) && (c.input[0].Type == "message")) && (c.input[0].Role == "user") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1242
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1230
+	if len(c.input) == 1 && c.input[0].Type == "message" && c.input[0].Role == "user" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1232
 		req.Input = c.input
 	} else {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1244
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1234
 		req.Input = c.input
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1246
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1236
 	if c.instructions != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1247
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1237
 		req.Instructions = c.instructions
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1248
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1238
 	if c.previousResponseID != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1249
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1239
 		req.PreviousResponseID = c.previousResponseID
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1250
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1240
 	if c.temperature != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1251
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1241
 		req.Temperature = c.temperature
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1252
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1242
 	if c.topP != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1253
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1243
 		req.TopP = c.topP
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1254
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1244
 	if c.maxOutputTokens != 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1255
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1245
 		req.MaxOutputTokens = c.maxOutputTokens
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1256
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1246
 	if c.presencePenalty != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1257
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1247
 		req.PresencePenalty = c.presencePenalty
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1258
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1248
 	if c.frequencyPenalty != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1259
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1249
 		req.FrequencyPenalty = c.frequencyPenalty
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1260
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1250
 	if len(c.tools) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1261
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1251
 		req.Tools = c.tools
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1262
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1252
 	if c.toolChoice != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1263
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1253
 		req.ToolChoice = c.toolChoice
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1264
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1254
 	if c.store {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1265
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1255
 		req.Store = true
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1266
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1256
 	if c.truncation != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1267
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1257
 		req.Truncation = c.truncation
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1268
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1258
 	if len(c.metadata) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1269
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1259
 		req.Metadata = c.metadata
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1270
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1260
 	if c.textFormat != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1271
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1261
 		req.Text = c.textFormat
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1272
-	if (c.streamHandler != nil) || (c.eventHandler != nil) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1273
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1262
+	if c.streamHandler != nil || c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1263
 		req.Stream = true
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1275
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1265
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1278
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1268
 func rExecute(c ResponseClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1279
-	if (c.streamHandler != nil) || (c.eventHandler != nil) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1280
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1269
+	if c.streamHandler != nil || c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1270
 		return rExecuteStream(c)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1282
-	resp, err_9 := rExecuteRaw(c)
-	if err_9 != nil {
-		return "", err_9
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1272
+	resp, err_1 := rExecuteRaw(c)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1283
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1273
 	return GetResponseText(resp), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1286
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1276
 func rExecuteRaw(c ResponseClient) (Response, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1287
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1277
 	baseURL := rResolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1288
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1278
 	apiKey := rResolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1289
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1279
 	responsePath := rResolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1290
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1280
 	url := fmt.Sprintf("%v%v", baseURL, responsePath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1291
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1281
 	body := rBuildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1293
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1283
 	req := fetch.Body(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1298
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1288
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1299
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1289
 		req = fetch.Header(req, "Authorization", fmt.Sprintf("Bearer %v", apiKey))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1301
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1291
 	if c.retryMaxAttempts > 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1302
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1292
 		req = fetch.Retry(req, c.retryMaxAttempts, c.retryDelayMs)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1304
-	resp, err_10 := fetch.Do(req)
-	if err_10 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1294
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
 		var _zero0 Response
-		return _zero0, err_10
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1306
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1296
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1308
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1298
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1309
-		errBody, err_11 := fetch.Bytes(resp)
-		if err_11 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1299
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return Response{}, fmt.Errorf("API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1310
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1300
 		return Response{}, fmt.Errorf("API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1312
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1302
 	result := Response{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1313
-	err_12 := json.UnmarshalRead(resp.Body, &result)
-	if err_12 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1303
+	err_3 := json.UnmarshalRead(resp.Body, &result)
+	if err_3 != nil {
 		var _zero0 Response
-		return _zero0, err_12
+		return _zero0, err_3
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1315
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1305
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1318
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1308
 func rExecuteStream(c ResponseClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1319
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1309
 	baseURL := rResolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1320
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1310
 	apiKey := rResolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1321
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1311
 	responsePath := rResolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1322
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1312
 	url := fmt.Sprintf("%v%v", baseURL, responsePath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1323
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1313
 	body := rBuildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1325
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1315
 	req := fetch.Body(fetch.Header(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), "Accept", "text/event-stream"), body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1331
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1321
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1332
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1322
 		req = fetch.Header(req, "Authorization", fmt.Sprintf("Bearer %v", apiKey))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1334
-	resp, err_13 := fetch.Do(req)
-	if err_13 != nil {
-		return "", err_13
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1324
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1336
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1326
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1338
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1328
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1339
-		errBody, err_14 := fetch.Bytes(resp)
-		if err_14 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1329
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return "", fmt.Errorf("API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1340
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1330
 		return "", fmt.Errorf("API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1348
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1338
 	fullContent := ""
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1339
+	for ev := range fetch.Events(resp.Body) {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1340
+		if ev.Err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1341
+			return fullContent, ev.Err
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1344
+		if ev.Data == "[DONE]" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1345
+			break
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1347
+		evt := StreamEvent{}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1348
+		err_3 := json.Unmarshal([]byte(ev.Data), &evt)
+		if err_3 != nil {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1349
-	scanner := bufio.NewScanner(resp.Body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1350
-	for scanner.Scan() {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1351
-		line := scanner.Text()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1354
-		if (line == "") || kukistring.HasPrefix(line, ":") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1355
 			continue
 		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1352
+		if c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1353
+			c.eventHandler(evt)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1356
+		if evt.Type == "response.output_text.delta" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1357
+			if evt.Delta != "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1358
-		if line == "data: [DONE]" {
+				fullContent = fullContent + evt.Delta
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1359
-			break
+				if c.streamHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1360
+					c.streamHandler(evt.Delta)
+				}
+			}
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1362
-		if kukistring.HasPrefix(line, "data: ") {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1363
-			data := kukistring.TrimPrefix(line, "data: ")
+		if evt.Type == "error" {
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1364
-			evt := StreamEvent{}
+			errMsg := evt.Message
 //line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1365
-			err_15 := json.Unmarshal([]byte(data), &evt)
-			if err_15 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1366
-				continue
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1369
-			if c.eventHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1370
-				c.eventHandler(evt)
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1373
-			if evt.Type == "response.output_text.delta" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1374
-				if evt.Delta != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1375
-					fullContent = (fullContent + evt.Delta)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1376
-					if c.streamHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1377
-						c.streamHandler(evt.Delta)
-					}
-				}
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1380
-			if evt.Type == "error" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1381
-				errMsg := evt.Message
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1382
-				if errMsg == "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1383
-					errMsg = fmt.Sprintf("streaming error: %v", evt.Code)
-				}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1384
-				return fullContent, errors.New(errMsg)
+			if errMsg == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1366
+				errMsg = fmt.Sprintf("streaming error: %v", evt.Code)
 			}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1367
+			return fullContent, errors.New(errMsg)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1386
-	err_16 := scanner.Err()
-	if err_16 != nil {
-		return fullContent, fmt.Errorf("%v", err_16)
-	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1388
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1369
 	return fullContent, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1396
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1377
 type ContentBlock struct {
 	Type      string `json:"type"`
 	Text      string `json:"text,omitempty"`
@@ -1831,37 +1802,37 @@ type ContentBlock struct {
 	Source    any    `json:"source,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1410
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1391
 type ThinkingConfig struct {
 	Type         string `json:"type"`
 	BudgetTokens int    `json:"budget_tokens,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1416
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1397
 type OutputConfig struct {
 	Format any `json:"format,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1421
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1402
 type AnthropicMessage struct {
 	Role    string `json:"role"`
 	Content any    `json:"content"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1426
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1407
 type AnthropicTool struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	InputSchema any    `json:"input_schema"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1432
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1413
 type AnthropicToolChoice struct {
 	Type string `json:"type"`
 	Name string `json:"name,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1437
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1418
 type AnthropicUsage struct {
 	InputTokens              int `json:"input_tokens"`
 	OutputTokens             int `json:"output_tokens"`
@@ -1869,7 +1840,7 @@ type AnthropicUsage struct {
 	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1444
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1425
 type AnthropicResponse struct {
 	ID           string         `json:"id"`
 	Type         string         `json:"type"`
@@ -1881,7 +1852,7 @@ type AnthropicResponse struct {
 	Usage        AnthropicUsage `json:"usage"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1455
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1436
 type MessagesRequest struct {
 	Model         string             `json:"model"`
 	Messages      []AnthropicMessage `json:"messages"`
@@ -1901,7 +1872,7 @@ type MessagesRequest struct {
 	InferenceGeo  string             `json:"inference_geo,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1475
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1456
 type AnthropicStreamEvent struct {
 	Type         string            `json:"type"`
 	Index        int               `json:"index,omitempty"`
@@ -1911,7 +1882,7 @@ type AnthropicStreamEvent struct {
 	Usage        AnthropicUsage    `json:"usage,omitzero"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1485
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1466
 type AnthropicDelta struct {
 	Type         string `json:"type,omitempty"`
 	Text         string `json:"text,omitempty"`
@@ -1921,7 +1892,7 @@ type AnthropicDelta struct {
 	StopSequence string `json:"stop_sequence,omitempty"`
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1494
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1475
 type MessagesClient struct {
 	model            string
 	baseURL          string
@@ -1948,667 +1919,653 @@ type MessagesClient struct {
 	retryDelayMs     int
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1521
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1502
 func NewMessages(model string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1522
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1503
 	c := MessagesClient{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1523
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1504
 	c.model = model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1524
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1505
 	c.baseURL = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1525
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1506
 	c.path = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1526
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1507
 	c.apiKey = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1527
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1508
 	c.apiVersion = "2023-06-01"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1528
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1509
 	c.system = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1529
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1510
 	c.messages = []AnthropicMessage{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1530
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1511
 	c.maxTokens = 1024
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1531
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1512
 	c.temperature = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1532
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1513
 	c.topP = 0.0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1533
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1514
 	c.topK = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1534
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1515
 	c.stopSequences = []string{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1535
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1516
 	c.tools = []AnthropicTool{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1536
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1517
 	c.toolChoice = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1537
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1518
 	c.metadata = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1538
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1519
 	c.thinking = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1539
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1520
 	c.effort = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1540
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1521
 	c.outputConfig = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1541
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1522
 	c.inferenceGeo = ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1542
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1523
 	c.streamHandler = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1543
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1524
 	c.eventHandler = nil
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1544
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1525
 	c.retryMaxAttempts = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1545
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1526
 	c.retryDelayMs = 0
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1546
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1527
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1550
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1531
 func MBaseURL(c MessagesClient, url string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1551
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1532
 	c.baseURL = url
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1552
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1533
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1556
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1537
 func MPath(c MessagesClient, path string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1557
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1538
 	c.path = path
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1558
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1539
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1563
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1544
 func MAPIKey(c MessagesClient, key string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1564
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1545
 	c.apiKey = key
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1565
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1546
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1570
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1551
 func MAPIVersion(c MessagesClient, version string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1571
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1552
 	c.apiVersion = version
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1572
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1553
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1576
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1557
 func MSystem(c MessagesClient, system string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1577
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1558
 	c.system = system
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1578
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1559
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1582
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1563
 func MUser(c MessagesClient, content string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1583
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1564
 	msg := AnthropicMessage{Role: "user", Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1584
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1565
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1585
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1566
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1590
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1571
 func MAssistant(c MessagesClient, content string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1591
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1572
 	msg := AnthropicMessage{Role: "assistant", Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1592
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1573
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1593
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1574
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1597
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1578
 func MAddMessage(c MessagesClient, role string, content any) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1598
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1579
 	msg := AnthropicMessage{Role: role, Content: content}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1599
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1580
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1600
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1581
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1605
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1586
 func MToolResult(c MessagesClient, toolUseID string, result string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1606
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1587
 	block := ContentBlock{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1607
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1588
 	block.Type = "tool_result"
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1608
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1589
 	block.ToolUseID = toolUseID
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1609
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1590
 	block.Content = result
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1610
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1591
 	blocks := []ContentBlock{block}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1611
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1592
 	msg := AnthropicMessage{Role: "user", Content: blocks}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1612
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1593
 	c.messages = append(c.messages, msg)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1613
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1594
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1618
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1599
 func MMaxTokens(c MessagesClient, max int) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1619
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1600
 	c.maxTokens = max
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1620
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1601
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1624
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1605
 func MTemperature(c MessagesClient, temp float64) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1625
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1606
 	c.temperature = temp
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1626
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1607
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1630
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1611
 func MTopP(c MessagesClient, p float64) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1631
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1612
 	c.topP = p
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1632
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1613
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1636
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1617
 func MTopK(c MessagesClient, k int) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1637
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1618
 	c.topK = k
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1638
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1619
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1642
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1623
 func MStopSequences(c MessagesClient, sequences []string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1643
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1624
 	c.stopSequences = sequences
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1644
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1625
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1649
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1630
 func MAdaptiveThinking(c MessagesClient) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1650
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1631
 	c.thinking = ThinkingConfig{Type: "adaptive"}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1651
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1632
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1655
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1636
 func MThinking(c MessagesClient, budgetTokens int) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1656
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1637
 	c.thinking = ThinkingConfig{Type: "enabled", BudgetTokens: budgetTokens}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1657
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1638
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1664
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1645
 func MEffort(c MessagesClient, effort string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1665
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1646
 	c.effort = effort
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1666
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1647
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1672
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1653
 func MOutputFormat(c MessagesClient, format any) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1673
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1654
 	c.outputConfig = OutputConfig{Format: format}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1674
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1655
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1680
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1661
 func MInferenceGeo(c MessagesClient, geo string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1681
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1662
 	c.inferenceGeo = geo
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1682
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1663
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1686
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1667
 func MAddTool(c MessagesClient, name string, description string, inputSchema any) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1687
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1668
 	tool := AnthropicTool{Name: name, Description: description, InputSchema: inputSchema}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1688
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1669
 	c.tools = append(c.tools, tool)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1689
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1670
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1693
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1674
 func MToolChoiceAuto(c MessagesClient) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1694
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1675
 	c.toolChoice = AnthropicToolChoice{Type: "auto"}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1695
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1676
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1699
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1680
 func MToolChoiceAny(c MessagesClient) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1700
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1681
 	c.toolChoice = AnthropicToolChoice{Type: "any"}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1701
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1682
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1705
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1686
 func MToolChoiceTool(c MessagesClient, name string) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1706
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1687
 	c.toolChoice = AnthropicToolChoice{Type: "tool", Name: name}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1707
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1688
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1712
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1693
 func MStream(c MessagesClient, handler func(string)) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1713
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1694
 	c.streamHandler = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1714
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1695
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1719
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1700
 func MStreamEvents(c MessagesClient, handler func(AnthropicStreamEvent)) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1720
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1701
 	c.eventHandler = handler
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1721
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1702
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1725
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1706
 func MRetry(c MessagesClient, maxAttempts int, delayMs int) MessagesClient {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1726
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1707
 	c.retryMaxAttempts = maxAttempts
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1727
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1708
 	c.retryDelayMs = delayMs
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1728
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1709
 	return c
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1734
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1715
 func MAsk(c MessagesClient, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1735
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1716
 	c = MUser(c, prompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1736
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1717
 	return mExecute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1746
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1727
 func MSend(c MessagesClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1747
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1728
 	return mExecute(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1755
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1736
 func MAskRaw(c MessagesClient, prompt string) (AnthropicResponse, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1756
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1737
 	c = MUser(c, prompt)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1757
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1738
 	return mExecuteRaw(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1765
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1746
 func MSendRaw(c MessagesClient) (AnthropicResponse, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1766
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1747
 	return mExecuteRaw(c)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1770
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1751
 func AnthropicComplete(model string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1771
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1752
 	c := NewMessages(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1772
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1753
 	return MAsk(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1776
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1757
 func AnthropicCompleteWithSystem(model string, system string, prompt string) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1777
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1758
 	c := NewMessages(model)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1778
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1759
 	c = MSystem(c, system)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1779
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1760
 	return MAsk(c, prompt)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1784
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1765
 func GetAnthropicText(resp AnthropicResponse) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1785
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1766
 	result := ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1786
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1767
 	for _, block := range resp.Content {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1787
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1768
 		if block.Type == "text" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1788
-			result = (result + block.Text)
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1769
+			result = result + block.Text
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1789
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1770
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1795
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1776
 func GetThinking(resp AnthropicResponse) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1796
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1777
 	result := ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1797
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1778
 	for _, block := range resp.Content {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1798
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1779
 		if block.Type == "thinking" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1799
-			result = (result + block.Thinking)
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1780
+			result = result + block.Thinking
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1800
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1781
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1805
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1786
 func GetToolUses(resp AnthropicResponse) []ContentBlock {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1806
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1787
 	uses := []ContentBlock{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1807
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1788
 	for _, block := range resp.Content {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1808
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1789
 		if block.Type == "tool_use" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1809
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1790
 			uses = append(uses, block)
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1810
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1791
 	return uses
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1814
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1795
 func HasToolUses(resp AnthropicResponse) bool {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1815
-	return (resp.StopReason == "tool_use")
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1796
+	return resp.StopReason == "tool_use"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1818
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1799
 func mResolveAPIKey(c MessagesClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1819
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1800
 	if c.apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1820
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1801
 		return c.apiKey
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1821
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1802
 	return env.GetOr("ANTHROPIC_API_KEY", "")
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1824
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1805
 func mResolveBaseURL(c MessagesClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1825
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1806
 	if c.baseURL != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1826
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1807
 		return c.baseURL
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1827
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1808
 	return "https://api.anthropic.com"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1830
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1811
 func mResolvePath(c MessagesClient) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1831
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1812
 	if c.path != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1832
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1813
 		return c.path
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1833
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1814
 	return "/v1/messages"
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1836
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1817
 func mBuildRequest(c MessagesClient) MessagesRequest {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1837
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1818
 	req := MessagesRequest{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1838
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1819
 	req.Model = c.model
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1839
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1820
 	req.Messages = c.messages
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1840
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1821
 	req.MaxTokens = c.maxTokens
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1842
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1823
 	if c.system != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1843
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1824
 		req.System = c.system
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1844
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1825
 	if c.temperature != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1845
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1826
 		req.Temperature = c.temperature
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1846
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1827
 	if c.topP != 0.0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1847
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1828
 		req.TopP = c.topP
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1848
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1829
 	if c.topK != 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1849
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1830
 		req.TopK = c.topK
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1850
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1831
 	if len(c.stopSequences) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1851
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1832
 		req.StopSequences = c.stopSequences
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1852
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1833
 	if len(c.tools) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1853
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1834
 		req.Tools = c.tools
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1854
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1835
 	if c.toolChoice != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1855
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1836
 		req.ToolChoice = c.toolChoice
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1856
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1837
 	if c.metadata != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1857
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1838
 		req.Metadata = c.metadata
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1858
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1839
 	if c.thinking != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1859
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1840
 		req.Thinking = c.thinking
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1860
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1841
 	if c.effort != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1861
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1842
 		req.Effort = c.effort
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1862
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1843
 	if c.outputConfig != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1863
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1844
 		req.OutputConfig = c.outputConfig
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1864
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1845
 	if c.inferenceGeo != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1865
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1846
 		req.InferenceGeo = c.inferenceGeo
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1866
-	if (c.streamHandler != nil) || (c.eventHandler != nil) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1867
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1847
+	if c.streamHandler != nil || c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1848
 		req.Stream = true
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1869
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1850
 	return req
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1872
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1853
 func mExecute(c MessagesClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1873
-	if (c.streamHandler != nil) || (c.eventHandler != nil) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1874
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1854
+	if c.streamHandler != nil || c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1855
 		return mExecuteStream(c)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1876
-	resp, err_17 := mExecuteRaw(c)
-	if err_17 != nil {
-		return "", err_17
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1857
+	resp, err_1 := mExecuteRaw(c)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1877
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1858
 	return GetAnthropicText(resp), nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1880
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1861
 func mExecuteRaw(c MessagesClient) (AnthropicResponse, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1881
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1862
 	baseURL := mResolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1882
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1863
 	apiKey := mResolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1883
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1864
 	msgPath := mResolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1884
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1865
 	url := fmt.Sprintf("%v%v", baseURL, msgPath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1885
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1866
 	body := mBuildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1888
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1869
 	req := fetch.Header(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), "anthropic-version", c.apiVersion)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1893
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1874
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1894
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1875
 		req = fetch.Header(req, "x-api-key", apiKey)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1896
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1877
 	req = fetch.Body(req, body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1898
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1879
 	if c.retryMaxAttempts > 1 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1899
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1880
 		req = fetch.Retry(req, c.retryMaxAttempts, c.retryDelayMs)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1901
-	resp, err_18 := fetch.Do(req)
-	if err_18 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1882
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
 		var _zero0 AnthropicResponse
-		return _zero0, err_18
+		return _zero0, err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1903
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1884
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1905
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1886
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1906
-		errBody, err_19 := fetch.Bytes(resp)
-		if err_19 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1887
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return AnthropicResponse{}, fmt.Errorf("Anthropic API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1907
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1888
 		return AnthropicResponse{}, fmt.Errorf("Anthropic API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1909
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1890
 	result := AnthropicResponse{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1910
-	err_20 := json.UnmarshalRead(resp.Body, &result)
-	if err_20 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1891
+	err_3 := json.UnmarshalRead(resp.Body, &result)
+	if err_3 != nil {
 		var _zero0 AnthropicResponse
-		return _zero0, err_20
+		return _zero0, err_3
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1912
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1893
 	return result, nil
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1924
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1905
 func mExecuteStream(c MessagesClient) (string, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1925
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1906
 	baseURL := mResolveBaseURL(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1926
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1907
 	apiKey := mResolveAPIKey(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1927
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1908
 	msgPath := mResolvePath(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1928
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1909
 	url := fmt.Sprintf("%v%v", baseURL, msgPath)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1929
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1910
 	body := mBuildRequest(c)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1931
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1912
 	req := fetch.Header(fetch.Header(fetch.Header(fetch.Method(fetch.New(url), "POST"), "Content-Type", "application/json"), "Accept", "text/event-stream"), "anthropic-version", c.apiVersion)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1937
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1918
 	if apiKey != "" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1938
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1919
 		req = fetch.Header(req, "x-api-key", apiKey)
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1940
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1921
 	req = fetch.Body(req, body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1942
-	resp, err_21 := fetch.Do(req)
-	if err_21 != nil {
-		return "", err_21
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1923
+	resp, err_1 := fetch.Do(req)
+	if err_1 != nil {
+		return "", err_1
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1944
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1925
 	defer resp.Body.Close()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1946
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1927
 	if resp.StatusCode >= 400 {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1947
-		errBody, err_22 := fetch.Bytes(resp)
-		if err_22 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1928
+		errBody, err_2 := fetch.Bytes(resp)
+		if err_2 != nil {
 			return "", fmt.Errorf("Anthropic API request failed with status %v", resp.StatusCode)
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1948
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1929
 		return "", fmt.Errorf("Anthropic API request failed (%v): %v", resp.StatusCode, string(errBody))
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1952
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1933
 	fullContent := ""
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1953
-	scanner := bufio.NewScanner(resp.Body)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1954
-	for scanner.Scan() {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1955
-		line := scanner.Text()
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1958
-		if ((line == "") || kukistring.HasPrefix(line, "event:")) || kukistring.HasPrefix(line, ":") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1959
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1934
+	for ev := range fetch.Events(resp.Body) {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1935
+		if ev.Err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1936
+			return fullContent, ev.Err
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1938
+		evt := AnthropicStreamEvent{}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1939
+		err_3 := json.Unmarshal([]byte(ev.Data), &evt)
+		if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1940
 			continue
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1962
-		if kukistring.HasPrefix(line, "data: ") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1963
-			data := kukistring.TrimPrefix(line, "data: ")
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1964
-			evt := AnthropicStreamEvent{}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1965
-			err_23 := json.Unmarshal([]byte(data), &evt)
-			if err_23 != nil {
-				//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1966
-				continue
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1969
-			if c.eventHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1970
-				c.eventHandler(evt)
-			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1973
-			if evt.Type == "content_block_delta" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1974
-				if (evt.Delta.Type == "text_delta") && (evt.Delta.Text != "") {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1975
-					fullContent = (fullContent + evt.Delta.Text)
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1976
-					if c.streamHandler != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1977
-						c.streamHandler(evt.Delta.Text)
-					}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1943
+		if c.eventHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1944
+			c.eventHandler(evt)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1947
+		if evt.Type == "content_block_delta" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1948
+			if evt.Delta.Type == "text_delta" && evt.Delta.Text != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1949
+				fullContent = fullContent + evt.Delta.Text
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1950
+				if c.streamHandler != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1951
+					c.streamHandler(evt.Delta.Text)
 				}
 			}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1980
-			if evt.Type == "error" {
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1981
-				return fullContent, errors.New("Anthropic streaming error")
-			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1954
+		if evt.Type == "error" {
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1955
+			return fullContent, errors.New("Anthropic streaming error")
 		}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1983
-	err_24 := scanner.Err()
-	if err_24 != nil {
-		return fullContent, fmt.Errorf("%v", err_24)
-	}
-//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1984
+//line /Users/tluker/repos/go/kukicha/stdlib/llm/llm.kuki:1956
 	return fullContent, nil
 }
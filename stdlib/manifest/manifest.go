@@ -0,0 +1,1049 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:39
+type Manifest struct {
+	doc *yaml.Node
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:44
+type step struct {
+	key    string
+	isList bool
+	index  int
+	field  string
+	value  string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:53
+var mergeKeys = []string{"name", "containerPort"}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:59
+func Parse(text string) ([]Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:60
+	return Read(strings.NewReader(text))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:64
+func Read(r io.Reader) ([]Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:65
+	docs := []Manifest{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:66
+	dec := yaml.NewDecoder(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:67
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:68
+		doc := &yaml.Node{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:69
+		err := dec.Decode(doc)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:70
+		if err == io.EOF {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:71
+			return docs, nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:72
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:73
+			return nil, fmt.Errorf("manifest: %w", err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:74
+		if len(doc.Content) == 0 || isNull(doc.Content[0]) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:75
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:76
+		if doc.Content[0].Kind != yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:77
+			return nil, fmt.Errorf("manifest: document %v is not a mapping", len(docs)+1)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:78
+		docs = append(docs, Manifest{doc: doc})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:82
+func Load(path string) ([]Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:83
+	f, err_1 := os.Open(path)
+	if err_1 != nil {
+		return []Manifest{}, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:84
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:85
+	docs, err := Read(f)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:86
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:87
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:88
+	return docs, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:92
+func Emit(docs []Manifest) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:93
+	buf := bytes.Buffer{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:94
+	enc := yaml.NewEncoder(&buf)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:95
+	enc.SetIndent(2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:96
+	for _, m := range docs {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:97
+		err := enc.Encode(document(m))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:98
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:99
+			return "", fmt.Errorf("manifest: %w", err)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:100
+	err := enc.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:101
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:102
+		return "", fmt.Errorf("manifest: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:103
+	return buf.String(), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:107
+func Save(docs []Manifest, path string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:108
+	text, err_1 := Emit(docs)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:109
+	return os.WriteFile(path, []byte(text), 0644)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:115
+func New(apiVersion string, kind string, name string) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:116
+	root := mapping()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:117
+	setKey(root, "apiVersion", scalar(apiVersion))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:118
+	setKey(root, "kind", scalar(kind))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:119
+	metadata := mapping()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:120
+	setKey(metadata, "name", scalar(name))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:121
+	setKey(root, "metadata", metadata)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:122
+	return Manifest{doc: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:125
+func Copy(m Manifest) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:126
+	return Manifest{doc: copyNode(document(m))}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:131
+func APIVersion(m Manifest) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:132
+	return scalarAt(root(m), "apiVersion")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:135
+func Kind(m Manifest) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:136
+	return scalarAt(root(m), "kind")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:139
+func Name(m Manifest) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:140
+	return scalarAt(lookup(root(m), "metadata"), "name")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:143
+func Namespace(m Manifest) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:144
+	return scalarAt(lookup(root(m), "metadata"), "namespace")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:148
+func Labels(m Manifest) map[string]string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:149
+	return stringMap(lookup(lookup(root(m), "metadata"), "labels"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:152
+func Annotations(m Manifest) map[string]string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:153
+	return stringMap(lookup(lookup(root(m), "metadata"), "annotations"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:156
+func Replicas(m Manifest) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:157
+	n, err_1 := strconv.Atoi(scalarAt(lookup(root(m), "spec"), "replicas"))
+	if err_1 != nil {
+		n = 0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:158
+	return n
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:163
+func Images(m Manifest) []string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:164
+	images := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:165
+	for _, c := range containers(podSpec(root(m))) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:166
+		images = append(images, scalarAt(c, "image"))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:167
+	return images
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:173
+func SetNamespace(m Manifest, namespace string) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:174
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:175
+	setKey(child(root(out), "metadata"), "namespace", scalar(namespace))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:176
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:180
+func SetLabel(m Manifest, key string, value string) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:181
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:182
+	setKey(child(child(root(out), "metadata"), "labels"), key, scalar(value))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:183
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:186
+func SetAnnotation(m Manifest, key string, value string) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:187
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:188
+	setKey(child(child(root(out), "metadata"), "annotations"), key, scalar(value))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:189
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:192
+func SetReplicas(m Manifest, replicas int) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:193
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:194
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(replicas)}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:195
+	setKey(child(root(out), "spec"), "replicas", node)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:196
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:201
+func SetImage(m Manifest, container string, image string) (Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:202
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:203
+	for _, c := range containers(podSpec(root(out))) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:204
+		if scalarAt(c, "name") == container {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:205
+			setKey(c, "image", scalar(image))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:206
+			return out, nil
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:207
+	return Manifest{}, fmt.Errorf("manifest: %v %v has no container named %v", Kind(m), Name(m), container)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:213
+func Has(m Manifest, path string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:214
+	steps, err_1 := parsePath(path)
+	if err_1 != nil {
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:215
+	return find(root(m), steps) != nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:219
+func Get(m Manifest, path string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:220
+	steps, err_1 := parsePath(path)
+	if err_1 != nil {
+		return "", err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:221
+	node := find(root(m), steps)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:222
+	if node == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:223
+		return "", fmt.Errorf("manifest: %v is not set", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:224
+	if node.Kind != yaml.ScalarNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:225
+		return "", fmt.Errorf("manifest: %v is not a scalar", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:226
+	return node.Value, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:231
+func Decode(m Manifest, path string, target any) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:232
+	steps, err_1 := parsePath(path)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:233
+	node := find(root(m), steps)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:234
+	if node == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:235
+		return fmt.Errorf("manifest: %v is not set", path)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:236
+	err := node.Decode(target)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:237
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:238
+		return fmt.Errorf("manifest %s: %w", path, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:239
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:244
+func Set(m Manifest, path string, value any) (Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:245
+	steps, err_1 := parsePath(path)
+	if err_1 != nil {
+		var _zero0 Manifest
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:246
+	if len(steps) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:247
+		return Manifest{}, errors.New("manifest: empty path")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:248
+	node := &yaml.Node{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:249
+	err := node.Encode(value)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:250
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:251
+		return Manifest{}, fmt.Errorf("manifest %s: %w", path, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:252
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:253
+	parent := root(out)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:254
+	last := len(steps) - 1
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:255
+	for i, s := range steps[:last] {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:256
+		parent = ensure(parent, s, steps[i+1].isList)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:257
+		if parent == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:258
+			return Manifest{}, fmt.Errorf("manifest: cannot set %v: a step is out of range or not a %v", path, kindName(s))
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:259
+	if !place(parent, steps[last], node) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:260
+		return Manifest{}, fmt.Errorf("manifest: cannot set %v: a step is out of range or not a %v", path, kindName(steps[last]))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:261
+	return out, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:265
+func Delete(m Manifest, path string) (Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:266
+	steps, err_1 := parsePath(path)
+	if err_1 != nil {
+		var _zero0 Manifest
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:267
+	if len(steps) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:268
+		return Manifest{}, errors.New("manifest: empty path")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:269
+	out := Copy(m)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:270
+	last := len(steps) - 1
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:271
+	parent := find(root(out), steps[:last])
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:272
+	if parent == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:273
+		return out, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:274
+	s := steps[last]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:275
+	if !s.isList {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:276
+		deleteKey(parent, s.key)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:277
+		return out, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:278
+	target := at(parent, s)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:279
+	if target != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:280
+		removeItem(parent, target)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:281
+	return out, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:287
+func ByKind(docs []Manifest, kind string) []Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:288
+	matches := []Manifest{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:289
+	for _, m := range docs {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:290
+		if Kind(m) == kind {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:291
+			matches = append(matches, m)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:292
+	return matches
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:296
+func Find(docs []Manifest, kind string, name string) (Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:297
+	for _, m := range docs {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:298
+		if Kind(m) == kind && Name(m) == name {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:299
+			return m, nil
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:300
+	return Manifest{}, fmt.Errorf("manifest: no %v named %v", kind, name)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:309
+func Merge(base Manifest, patch Manifest) Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:310
+	out := Copy(base)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:311
+	out.doc.Content[0] = mergeNode(root(out), root(patch))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:312
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:316
+func Patch(m Manifest, patch string) (Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:317
+	patches, err_1 := Parse(patch)
+	if err_1 != nil {
+		var _zero0 Manifest
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:318
+	out := m
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:319
+	for _, p := range patches {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:320
+		out = Merge(out, p)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:321
+	return out, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:327
+func Overlay(docs []Manifest, patches []Manifest) ([]Manifest, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:328
+	out := slices.Clone(docs)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:329
+	for _, p := range patches {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:330
+		matched := false
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:331
+		for i, m := range out {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:332
+			if Kind(m) == Kind(p) && Name(m) == Name(p) && (Namespace(p) == "" || Namespace(m) == Namespace(p)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:333
+				out[i] = Merge(m, p)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:334
+				matched = true
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:335
+		if !matched {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:336
+			return nil, fmt.Errorf("manifest: patch for %v %v matches no manifest", Kind(p), Name(p))
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:337
+	return out, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:342
+func document(m Manifest) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:343
+	if m.doc == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:344
+		return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{mapping()}}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:345
+	return m.doc
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:348
+func root(m Manifest) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:349
+	if m.doc == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:350
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:351
+	return m.doc.Content[0]
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:353
+func mapping() *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:354
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:356
+func scalar(value string) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:357
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:359
+func isNull(n *yaml.Node) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:360
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:363
+func copyNode(n *yaml.Node) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:364
+	if n == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:365
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:366
+	out := &yaml.Node{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:367
+	*out = *n
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:368
+	out.Content = make([]*yaml.Node, len(n.Content))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:369
+	for i, c := range n.Content {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:370
+		out.Content[i] = copyNode(c)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:371
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:374
+func lookup(n *yaml.Node, key string) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:375
+	if n == nil || n.Kind != yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:376
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:377
+	for i := range len(n.Content) / 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:378
+		if n.Content[2*i].Value == key {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:379
+			return n.Content[2*i+1]
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:380
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:383
+func scalarAt(n *yaml.Node, key string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:384
+	value := lookup(n, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:385
+	if value == nil || value.Kind != yaml.ScalarNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:386
+		return ""
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:387
+	return value.Value
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:390
+func child(n *yaml.Node, key string) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:391
+	value := lookup(n, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:392
+	if value == nil || value.Kind != yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:393
+		value = mapping()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:394
+		setKey(n, key, value)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:395
+	return value
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:399
+func setKey(n *yaml.Node, key string, value *yaml.Node) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:400
+	for i := range len(n.Content) / 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:401
+		if n.Content[2*i].Value == key {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:402
+			if value.LineComment == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:403
+				value.LineComment = n.Content[2*i+1].LineComment
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:404
+			n.Content[2*i+1] = value
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:405
+			return
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:406
+	n.Content = append(n.Content, scalar(key), value)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:408
+func deleteKey(n *yaml.Node, key string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:409
+	for i := range len(n.Content) / 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:410
+		if n.Content[2*i].Value == key {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:411
+			n.Content = slices.Delete(n.Content, 2*i, 2*i+2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:412
+			return
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:414
+func removeItem(seq *yaml.Node, item *yaml.Node) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:415
+	for i, c := range seq.Content {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:416
+		if c == item {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:417
+			seq.Content = slices.Delete(seq.Content, i, i+1)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:418
+			return
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:420
+func stringMap(n *yaml.Node) map[string]string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:421
+	out := make(map[string]string)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:422
+	if n == nil || n.Kind != yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:423
+		return out
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:424
+	for i := range len(n.Content) / 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:425
+		out[n.Content[2*i].Value] = n.Content[2*i+1].Value
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:426
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:429
+func podSpec(root *yaml.Node) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:430
+	spec := lookup(root, "spec")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:431
+	switch scalarAt(root, "kind") {
+	case "Pod":
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:433
+		return spec
+	case "CronJob":
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:435
+		spec = lookup(lookup(spec, "jobTemplate"), "spec")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:436
+	return lookup(lookup(spec, "template"), "spec")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:439
+func containers(spec *yaml.Node) []*yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:440
+	out := []*yaml.Node{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:441
+	for _, key := range []string{"initContainers", "containers"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:442
+		items := lookup(spec, key)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:443
+		if items != nil && items.Kind == yaml.SequenceNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:444
+			out = append(out, items.Content...)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:445
+	return out
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:448
+func parsePath(path string) ([]step, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:449
+	steps := []step{}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:450
+	rest := strings.TrimPrefix(path, ".")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:451
+	for rest != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:452
+		if !strings.HasPrefix(rest, "[") {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:453
+			end := strings.IndexAny(rest, ".[")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:454
+			if end < 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:455
+				end = len(rest)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:456
+			if end == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:457
+				return nil, fmt.Errorf("manifest: bad path %v: empty key", path)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:458
+			steps = append(steps, step{key: rest[:end]})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:459
+			rest = strings.TrimPrefix(rest[end:], ".")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:460
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:461
+		end := strings.Index(rest, "]")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:462
+		if end < 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:463
+			return nil, fmt.Errorf("manifest: bad path %v: missing ]", path)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:464
+		inner := rest[1:end]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:465
+		rest = strings.TrimPrefix(rest[(end+1):], ".")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:466
+		if strings.HasPrefix(inner, "\"") {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:467
+			key, err_1 := strconv.Unquote(inner)
+			if err_1 != nil {
+				return nil, fmt.Errorf("manifest: bad path %v: bad quoted key %v", path, inner)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:468
+			steps = append(steps, step{key: key})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:469
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:470
+		field, value, found := strings.Cut(inner, "=")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:471
+		if found {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:472
+			steps = append(steps, step{isList: true, field: field, value: value})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:473
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:474
+		index, err_2 := strconv.Atoi(inner)
+		if err_2 != nil {
+			return nil, fmt.Errorf("manifest: bad path %v: %v is not an index or field=value", path, inner)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:475
+		steps = append(steps, step{isList: true, index: index})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:476
+	return steps, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:479
+func find(n *yaml.Node, steps []step) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:480
+	for _, s := range steps {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:481
+		n = at(n, s)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:482
+		if n == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:483
+			return nil
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:484
+	return n
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:487
+func at(n *yaml.Node, s step) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:488
+	if !s.isList {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:489
+		return lookup(n, s.key)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:490
+	if n == nil || n.Kind != yaml.SequenceNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:491
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:492
+	if s.field == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:493
+		if s.index < 0 || s.index >= len(n.Content) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:494
+			return nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:495
+		return n.Content[s.index]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:496
+	for _, item := range n.Content {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:497
+		if scalarAt(item, s.field) == s.value && lookup(item, s.field) != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:498
+			return item
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:499
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:504
+func ensure(n *yaml.Node, s step, toList bool) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:505
+	found := at(n, s)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:506
+	if found != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:507
+		return found
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:508
+	fresh := mapping()
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:509
+	if toList {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:510
+		fresh = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:511
+	if !place(n, s, fresh) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:512
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:513
+	return fresh
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:517
+func place(n *yaml.Node, s step, value *yaml.Node) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:518
+	if !s.isList {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:519
+		if n.Kind != yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:520
+			return false
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:521
+		setKey(n, s.key, value)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:522
+		return true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:523
+	if n.Kind != yaml.SequenceNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:524
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:525
+	if s.field == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:526
+		if s.index < 0 || s.index >= len(n.Content) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:527
+			return false
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:528
+		n.Content[s.index] = value
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:529
+		return true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:530
+	existing := at(n, s)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:531
+	if existing != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:532
+		removeItem(n, existing)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:533
+	if value.Kind == yaml.MappingNode && lookup(value, s.field) == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:534
+		setKey(value, s.field, scalar(s.value))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:535
+	n.Content = append(n.Content, value)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:536
+	return true
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:538
+func kindName(s step) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:539
+	if s.isList {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:540
+		return "list"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:541
+	return "mapping"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:544
+func mergeNode(base *yaml.Node, patch *yaml.Node) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:545
+	if patch == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:546
+		return copyNode(base)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:547
+	if base == nil || base.Kind != patch.Kind {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:548
+		return copyNode(patch)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:549
+	if patch.Kind == yaml.MappingNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:550
+		out := copyNode(base)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:551
+		for i := range len(patch.Content) / 2 {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:552
+			key := patch.Content[2*i].Value
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:553
+			value := patch.Content[2*i+1]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:554
+			if isNull(value) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:555
+				deleteKey(out, key)
+			} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:557
+				setKey(out, key, mergeNode(lookup(out, key), value))
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:558
+		return out
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:559
+	if patch.Kind == yaml.SequenceNode {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:560
+		key := mergeKey(base, patch)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:561
+		if key != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:562
+			return mergeList(base, patch, key)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:563
+	return copyNode(patch)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:566
+func mergeKey(base *yaml.Node, patch *yaml.Node) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:567
+	for _, key := range mergeKeys {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:568
+		ok := true
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:569
+		for _, item := range slices.Concat(base.Content, patch.Content) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:570
+			if scalarAt(item, key) == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:571
+				ok = false
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:572
+				break
+			}
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:573
+		if ok {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:574
+			return key
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:575
+	return ""
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:579
+func mergeList(base *yaml.Node, patch *yaml.Node, key string) *yaml.Node {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:580
+	out := copyNode(base)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:581
+	for _, item := range patch.Content {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:582
+		existing := at(out, step{isList: true, field: key, value: scalarAt(item, key)})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:583
+		if scalarAt(item, "$patch") == "delete" {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:584
+			if existing != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:585
+				removeItem(out, existing)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:586
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:587
+		if existing == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:588
+			out.Content = append(out.Content, copyNode(item))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:589
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:590
+		merged := mergeNode(existing, item)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:591
+		for i, c := range out.Content {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:592
+			if c == existing {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:593
+				out.Content[i] = merged
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest.kuki:594
+	return out
+}
@@ -0,0 +1,349 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package manifest_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/manifest"
+	"github.com/duber000/kukicha/stdlib/test"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:12
+func sample() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:13
+	text := "# The web frontend\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  labels:\n    app: web\n    app.kubernetes.io/part-of: shop\nspec:\n  replicas: 2 # scaled by hand\n  template:\n    spec:\n      initContainers:\n        - name: migrate\n          image: shop/migrate:1.0\n      containers:\n        - name: web\n          image: shop/web:1.0\n          env:\n            - name: LOG_LEVEL\n              value: info\n            - name: DEBUG\n              value: \"false\"\n          ports:\n            - containerPort: 8080\n        - name: proxy\n          image: envoy:1.30\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\nspec:\n  ports:\n    - port: 80"
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:49
+	return text + "\n"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:51
+func load(t *testing.T) []manifest.Manifest {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:52
+	docs, err_1 := manifest.Parse(sample())
+	if err_1 != nil {
+		panic(fmt.Sprintf("parse: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:53
+	test.AssertEqual(t, len(docs), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:54
+	return docs
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:57
+func TestRoundTrip(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:58
+	text, err_1 := manifest.Emit(load(t))
+	if err_1 != nil {
+		panic(fmt.Sprintf("emit: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:59
+	test.AssertEqual(t, text, sample())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:62
+func TestAccessors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:63
+	web := load(t)[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:64
+	test.AssertEqual(t, manifest.APIVersion(web), "apps/v1")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:65
+	test.AssertEqual(t, manifest.Kind(web), "Deployment")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:66
+	test.AssertEqual(t, manifest.Name(web), "web")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:67
+	test.AssertEqual(t, manifest.Namespace(web), "")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:68
+	test.AssertEqual(t, manifest.Labels(web), map[string]string{"app": "web", "app.kubernetes.io/part-of": "shop"})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:69
+	test.AssertEqual(t, len(manifest.Annotations(web)), 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:70
+	test.AssertEqual(t, manifest.Replicas(web), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:71
+	test.AssertEqual(t, manifest.Images(web), []string{"shop/migrate:1.0", "shop/web:1.0", "envoy:1.30"})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:74
+type GetCase struct {
+	name    string
+	path    string
+	want    string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:81
+func TestGet(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:82
+	web := load(t)[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:83
+	cases := []GetCase{GetCase{name: "key", path: "spec.replicas", want: "2"}, GetCase{name: "leading dot", path: ".metadata.name", want: "web"}, GetCase{name: "index", path: "spec.template.spec.containers[1].image", want: "envoy:1.30"}, GetCase{name: "selector", path: "spec.template.spec.containers[name=web].env[name=DEBUG].value", want: "false"}, GetCase{name: "quoted key", path: "metadata.labels[\"app.kubernetes.io/part-of\"]", want: "shop"}, GetCase{name: "missing", path: "spec.paused", wantErr: true}, GetCase{name: "out of range", path: "spec.template.spec.containers[5].image", wantErr: true}, GetCase{name: "not a scalar", path: "metadata.labels", wantErr: true}, GetCase{name: "bad index", path: "spec.template.spec.containers[x].image", wantErr: true}, GetCase{name: "unclosed", path: "spec.template.spec.containers[0", wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:95
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:96
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:97
+			got, err := manifest.Get(web, tc.path)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:98
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:99
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:100
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:101
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:102
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:104
+	test.AssertTrue(t, manifest.Has(web, "spec.template.spec.initContainers[name=migrate]"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:105
+	test.AssertFalse(t, manifest.Has(web, "spec.template.spec.initContainers[name=web]"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:108
+type SetCase struct {
+	name  string
+	path  string
+	value any
+	want  string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:115
+func TestSet(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:116
+	web := load(t)[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:117
+	cases := []SetCase{SetCase{name: "replace", path: "spec.replicas", value: 4, want: "4"}, SetCase{name: "new mappings", path: "spec.strategy.rollingUpdate.maxSurge", value: "25%", want: "25%"}, SetCase{name: "existing item", path: "spec.template.spec.containers[name=web].env[name=DEBUG].value", value: "true", want: "true"}, SetCase{name: "new item", path: "spec.template.spec.containers[name=web].env[name=REGION].value", value: "eu", want: "eu"}, SetCase{name: "new list", path: "spec.template.spec.tolerations[key=spot].effect", value: "NoSchedule", want: "NoSchedule"}, SetCase{name: "index", path: "spec.template.spec.containers[1].image", value: "envoy:1.31", want: "envoy:1.31"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:125
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:126
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:127
+			out, err_1 := manifest.Set(web, tc.path, tc.value)
+			if err_1 != nil {
+				panic(fmt.Sprintf("set: %v", err_1))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:128
+			got, err_2 := manifest.Get(out, tc.path)
+			if err_2 != nil {
+				panic(fmt.Sprintf("get: %v", err_2))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:129
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:131
+	_, err := manifest.Set(web, "spec.template.spec.containers[9].image", "x")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:132
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:133
+	_, err = manifest.Set(web, "spec.replicas.count", 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:134
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:135
+	test.AssertEqual(t, manifest.Replicas(web), 2)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:138
+func TestDelete(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:139
+	web := load(t)[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:140
+	out, err_1 := manifest.Delete(web, "spec.template.spec.containers[name=proxy]")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:141
+	test.AssertEqual(t, manifest.Images(out), []string{"shop/migrate:1.0", "shop/web:1.0"})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:142
+	var err_2 error
+	out, err_2 = manifest.Delete(out, "metadata.labels")
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:143
+	test.AssertFalse(t, manifest.Has(out, "metadata.labels"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:144
+	var err_3 error
+	out, err_3 = manifest.Delete(out, "status.conditions")
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:145
+	test.AssertTrue(t, manifest.Has(web, "metadata.labels"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:148
+func TestSetters(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:149
+	web, err_2 := manifest.SetImage(manifest.SetReplicas(manifest.SetAnnotation(manifest.SetLabel(manifest.SetNamespace(load(t)[0], "prod"), "team", "payments"), "owner", "sre"), 3), "migrate", "shop/migrate:2.0")
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:156
+	test.AssertEqual(t, manifest.Namespace(web), "prod")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:157
+	test.AssertEqual(t, manifest.Labels(web)["team"], "payments")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:158
+	test.AssertEqual(t, manifest.Annotations(web)["owner"], "sre")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:159
+	test.AssertEqual(t, manifest.Replicas(web), 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:160
+	test.AssertEqual(t, manifest.Images(web)[0], "shop/migrate:2.0")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:161
+	text, err_3 := manifest.Emit([]manifest.Manifest{web})
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:162
+	test.AssertTrue(t, strings.Contains(text, "  replicas: 3 # scaled by hand\n"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:164
+	_, err := manifest.SetImage(web, "sidecar", "x")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:165
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:167
+	cm := manifest.SetLabel(manifest.New("v1", "ConfigMap", "settings"), "app", "web")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:168
+	var err_4 error
+	text, err_4 = manifest.Emit([]manifest.Manifest{cm})
+	if err_4 != nil {
+		panic(fmt.Sprintf("%v", err_4))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:169
+	test.AssertEqual(t, text, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  labels:\n    app: web\n")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:172
+func TestMerge(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:173
+	web := load(t)[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:174
+	patch := "spec:\n  replicas: 5\n  template:\n    spec:\n      containers:\n        - name: web\n          env:\n            - name: DEBUG\n              $patch: delete\n            - name: REGION\n              value: eu\n        - name: proxy\n          $patch: delete\n        - name: metrics\n          image: prom/exporter:1\nmetadata:\n  labels:\n    app.kubernetes.io/part-of: null"
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:193
+	out, err_1 := manifest.Patch(web, patch)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:194
+	test.AssertEqual(t, manifest.Replicas(out), 5)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:195
+	test.AssertEqual(t, manifest.Images(out), []string{"shop/migrate:1.0", "shop/web:1.0", "prom/exporter:1"})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:196
+	test.AssertEqual(t, manifest.Labels(out), map[string]string{"app": "web"})
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:197
+	env, err_2 := manifest.Get(out, "spec.template.spec.containers[name=web].env[1].value")
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:198
+	test.AssertEqual(t, env, "eu")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:199
+	test.AssertFalse(t, manifest.Has(out, "spec.template.spec.containers[name=web].env[name=DEBUG]"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:200
+	port, err_3 := manifest.Get(out, "spec.template.spec.containers[name=web].ports[0].containerPort")
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:201
+	test.AssertEqual(t, port, "8080")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:202
+	test.AssertEqual(t, manifest.Replicas(web), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:205
+	svc, err_4 := manifest.Patch(load(t)[1], "spec:\n  ports:\n    - port: 443\n    - port: 8443\n")
+	if err_4 != nil {
+		panic(fmt.Sprintf("%v", err_4))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:206
+	v_5, err_6 := manifest.Get(svc, "spec.ports[1].port")
+	if err_6 != nil {
+		v_5 = ""
+	}
+	test.AssertEqual(t, v_5, "8443")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:209
+func TestOverlay(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:210
+	patches, err_1 := manifest.Parse("kind: Service\nmetadata:\n  name: web\nspec:\n  type: NodePort\n")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:211
+	docs, err_2 := manifest.Overlay(load(t), patches)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:212
+	v_3, err_4 := manifest.Get(docs[1], "spec.type")
+	if err_4 != nil {
+		v_3 = ""
+	}
+	test.AssertEqual(t, v_3, "NodePort")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:213
+	test.AssertFalse(t, manifest.Has(docs[0], "spec.type"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:214
+	test.AssertEqual(t, len(manifest.ByKind(docs, "Service")), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:216
+	stray, err_5 := manifest.Parse("kind: Service\nmetadata:\n  name: api\n")
+	if err_5 != nil {
+		panic(fmt.Sprintf("%v", err_5))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:217
+	_, err := manifest.Overlay(docs, stray)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:218
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:219
+	_, err = manifest.Find(docs, "Deployment", "api")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:220
+	test.AssertError(t, err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:223
+func TestLoadSave(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:224
+	path := filepath.Join(t.TempDir(), "app.yaml")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:225
+	err_1 := manifest.Save(load(t), path)
+	if err_1 != nil {
+		panic(fmt.Sprintf("save: %v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:226
+	docs, err_2 := manifest.Load(path)
+	if err_2 != nil {
+		panic(fmt.Sprintf("load: %v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:227
+	web, err_3 := manifest.Find(docs, "Deployment", "web")
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:228
+	test.AssertEqual(t, manifest.Replicas(web), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:230
+	_, err := manifest.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:231
+	test.AssertError(t, err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:234
+func TestParseDocuments(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:235
+	docs, err_1 := manifest.Parse("---\nkind: A\n---\n---\nkind: B\n")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:236
+	test.AssertEqual(t, len(docs), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:237
+	_, err := manifest.Parse("kind: A\n---\n- not a mapping\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:238
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:239
+	_, err = manifest.Parse("kind: [\n")
+//line /Users/tluker/repos/go/kukicha/stdlib/manifest/manifest_test.kuki:240
+	test.AssertError(t, err)
+}
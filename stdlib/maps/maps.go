@@ -53,7 +53,7 @@ func Has(m map[any]any, key any) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/maps/maps.kuki:38
 func Merge(base map[any]any, overlay map[any]any) map[any]any {
 //line /Users/tluker/repos/go/kukicha/stdlib/maps/maps.kuki:39
-	result := make(map[any]any, (len(base) + len(overlay)))
+	result := make(map[any]any, len(base)+len(overlay))
 //line /Users/tluker/repos/go/kukicha/stdlib/maps/maps.kuki:40
 	gomaps.Copy(result, base)
 //line /Users/tluker/repos/go/kukicha/stdlib/maps/maps.kuki:41
@@ -0,0 +1,121 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package math
+
+import (
+	"cmp"
+	gomath "math"
+	"slices"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:14
+func Abs[N ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64](x N) N {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:15
+	if x < 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:16
+		return -x
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:17
+	return x
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:21
+func Min[K cmp.Ordered](items []K) K {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:22
+	if len(items) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:23
+		var _zero0 K
+		return _zero0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:24
+	return slices.Min(items)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:28
+func Max[K cmp.Ordered](items []K) K {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:29
+	if len(items) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:30
+		var _zero0 K
+		return _zero0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:31
+	return slices.Max(items)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:35
+func Clamp[K cmp.Ordered](x K, low K, high K) K {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:36
+	if x < low {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:37
+		return low
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:38
+	if x > high {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:39
+		return high
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:40
+	return x
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:44
+func Sum[N ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64](items []N) N {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:45
+	total := *new(N)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:46
+	for _, item := range items {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:47
+		total = total + item
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:48
+	return total
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:52
+func Mean[N ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64](items []N) float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:53
+	if len(items) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:54
+		return 0.0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:55
+	total := 0.0
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:56
+	for _, item := range items {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:57
+		total = total + float64(item)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:58
+	return total / float64(len(items))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:63
+func Median[N ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64](items []N) float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:64
+	n := len(items)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:65
+	if n == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:66
+		return 0.0
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:67
+	sorted := slices.Clone(items)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:68
+	slices.Sort(sorted)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:69
+	if n%2 == 1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:70
+		return float64(sorted[n/2])
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:71
+	return (float64(sorted[n/2-1]) + float64(sorted[n/2])) / 2
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:76
+func Round(x float64, places int) float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:77
+	scale := gomath.Pow(10, float64(places))
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math.kuki:78
+	return gomath.Round(x*scale) / scale
+}
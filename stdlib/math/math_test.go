@@ -0,0 +1,120 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package math_test
+
+import (
+	"github.com/duber000/kukicha/stdlib/math"
+	"github.com/duber000/kukicha/stdlib/test"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:10
+func TestAbs(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:11
+	test.AssertEqual(t, math.Abs(-3), 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:12
+	test.AssertEqual(t, math.Abs(4), 4)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:13
+	test.AssertEqual(t, math.Abs(-2.5), 2.5)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:16
+func TestMinMax(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:17
+	t.Run("ints", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:18
+		items := []int{3, 1, 2}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:19
+		test.AssertEqual(t, math.Min(items), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:20
+		test.AssertEqual(t, math.Max(items), 3)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:22
+	t.Run("floats", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:23
+		items := []float64{1.5, -0.5, 2.25}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:24
+		test.AssertEqual(t, math.Min(items), -0.5)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:25
+		test.AssertEqual(t, math.Max(items), 2.25)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:27
+	t.Run("strings", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:28
+		test.AssertEqual(t, math.Min([]string{"pear", "apple"}), "apple")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:30
+	t.Run("empty list gives zero", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:31
+		test.AssertEqual(t, math.Max([]int{}), 0)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:35
+func TestClamp(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:36
+	test.AssertEqual(t, math.Clamp(150, 0, 100), 100)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:37
+	test.AssertEqual(t, math.Clamp(-5, 0, 100), 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:38
+	test.AssertEqual(t, math.Clamp(42, 0, 100), 42)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:39
+	test.AssertEqual(t, math.Clamp(0.5, 1.0, 2.0), 1.0)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:42
+func TestSum(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:43
+	test.AssertEqual(t, math.Sum([]int{1, 2, 3}), 6)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:44
+	test.AssertEqual(t, math.Sum([]float64{0.5, 0.25}), 0.75)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:45
+	test.AssertEqual(t, math.Sum([]int{}), 0)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:48
+func TestMeanMedian(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:49
+	t.Run("mean of ints is a float", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:50
+		test.AssertEqual(t, math.Mean([]int{1, 2}), 1.5)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:52
+	t.Run("median odd count", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:53
+		test.AssertEqual(t, math.Median([]int{5, 1, 3}), 3.0)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:55
+	t.Run("median even count", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:56
+		test.AssertEqual(t, math.Median([]int{3, 1, 4, 2}), 2.5)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:58
+	t.Run("median keeps input order", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:59
+		items := []int{3, 1, 2}
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:60
+		math.Median(items)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:61
+		test.AssertEqual(t, items[0], 3)
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:63
+	t.Run("empty lists give zero", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:64
+		test.AssertEqual(t, math.Mean([]int{}), 0.0)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:65
+		test.AssertEqual(t, math.Median([]float64{}), 0.0)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:69
+func TestRound(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:70
+	test.AssertEqual(t, math.Round(3.14159, 2), 3.14)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:71
+	test.AssertEqual(t, math.Round(2.5, 0), 3.0)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:72
+	test.AssertEqual(t, math.Round(-2.5, 0), -3.0)
+//line /Users/tluker/repos/go/kukicha/stdlib/math/math_test.kuki:73
+	test.AssertEqual(t, math.Round(1234.5, -2), 1200.0)
+}
@@ -4,117 +4,345 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	ctxpkg "github.com/duber000/kukicha/stdlib/ctx"
 	"github.com/duber000/kukicha/stdlib/json"
+	"github.com/duber000/kukicha/stdlib/term"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:11
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:18
 type ToolHandler func(map[string]any) (any, error)
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:14
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:21
+type StreamHandler func(map[string]any, *Progress) (any, error)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:26
+type Progress struct {
+	ctx     context.Context
+	session *mcp.ServerSession
+	token   any
+	mu      sync.Mutex
+	done    float64
+	total   float64
+	chunks  []string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:37
+type StateHandler func(map[string]any, any) (any, error)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:43
+type App struct {
+	Server   *mcp.Server
+	init     func() (any, error)
+	shutdown func(any) error
+	mu       sync.Mutex
+	state    any
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:51
 type SchemaProperty struct {
 	Name        string
 	Type        string
 	Description string
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:22
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:59
 func New(name string, version string) *mcp.Server {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:23
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:60
 	return mcp.NewServer(&mcp.Implementation{Name: name, Version: version}, nil)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:32
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:70
 func Serve(server *mcp.Server) error {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:33
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:71
+	term.ForMCP()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:72
 	bg := ctxpkg.Background()
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:34
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:73
 	return server.Run(ctxpkg.Value(bg), &mcp.StdioTransport{})
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:37
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:76
 func Prop(name string, typ string, description string) SchemaProperty {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:38
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:77
 	return SchemaProperty{Name: name, Type: typ, Description: description}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:41
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:80
 func Schema(props []SchemaProperty) map[string]any {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:42
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:81
 	properties := make(map[string]any)
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:43
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:82
 	for _, prop := range props {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:44
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:83
 		properties[prop.Name] = map[string]any{"type": prop.Type, "description": prop.Description}
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:49
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:88
 	return map[string]any{"type": "object", "properties": properties}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:55
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:94
 func Required(schema any, names []string) any {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:56
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:95
 	result := schema
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:57
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:96
 	switch s := schema.(type) {
 	case map[string]any:
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:59
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:98
 		s["required"] = names
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:60
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:99
 		result = s
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:61
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:100
 	return result
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:65
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:104
 func TextResult(text string) any {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:66
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:105
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:71
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:110
 func ErrorResult(msg string) any {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:72
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:111
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: msg}}, IsError: true}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:83
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:122
 func Tool(server *mcp.Server, name string, description string, schema any, handler ToolHandler) {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:84
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:123
 	server.AddTool(&mcp.Tool{Name: name, Description: description, InputSchema: schema}, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:89
-		args := make(map[string]any)
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:90
-		if len(req.Params.Arguments) > 0 {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:91
-			unmarshalErr := json.Unmarshal(req.Params.Arguments, &args)
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:92
-			if unmarshalErr != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:93
-				return nil, unmarshalErr
-			}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:128
+		args, argsErr := toolArgs(req)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:129
+		if argsErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:130
+			return nil, argsErr
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:94
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:131
 		res, handlerErr := handler(args)
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:95
-		if handlerErr != nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:96
-			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: handlerErr.Error()}}, IsError: true}, nil
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:132
+		return toolResult(res, handlerErr), nil
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:145
+func StreamTool(server *mcp.Server, name string, description string, schema any, handler StreamHandler) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:146
+	server.AddTool(&mcp.Tool{Name: name, Description: description, InputSchema: schema}, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:151
+		args, argsErr := toolArgs(req)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:152
+		if argsErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:153
+			return nil, argsErr
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:100
-		switch r := res.(type) {
-		case *mcp.CallToolResult:
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:102
-			return r, nil
-		case string:
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:104
-			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: r}}}, nil
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:154
+		progress := &Progress{ctx: ctx, session: req.Session, token: req.Params.GetProgressToken()}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:155
+		res, handlerErr := handler(args, progress)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:156
+		if res == nil && handlerErr == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:157
+			res = progress.Output()
 		}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:107
-		data, _ := json.Marshal(res)
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:108
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:158
+		return toolResult(res, handlerErr), nil
 	})
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:163
+func (p *Progress) SetTotal(total int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:164
+	p.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:165
+	p.total = float64(total)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:166
+	p.mu.Unlock()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:171
+func (p *Progress) Report(message string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:172
+	p.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:173
+	p.done = p.done + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:174
+	done := p.done
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:175
+	total := p.total
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:176
+	p.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:177
+	if p.token == nil || p.session == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:178
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:180
+	_ = p.session.NotifyProgress(p.ctx, &mcp.ProgressNotificationParams{ProgressToken: p.token, Message: message, Progress: done, Total: total})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:189
+func (p *Progress) Write(chunk string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:190
+	p.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:191
+	p.chunks = append(p.chunks, chunk)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:192
+	p.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:193
+	p.Report(chunk)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:196
+func (p *Progress) Output() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:197
+	p.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:198
+	defer p.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:199
+	return strings.Join(p.chunks, "\n")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:208
+func NewApp(name string, version string) *App {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:209
+	return &App{Server: New(name, version)}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:213
+func (app *App) OnInit(init func() (any, error)) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:214
+	app.init = init
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:218
+func (app *App) OnShutdown(shutdown func(any) error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:219
+	app.shutdown = shutdown
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:229
+func (app *App) Tool(name string, description string, schema any, handler StateHandler) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:234
+	Tool(app.Server, name, description, schema, func(args map[string]any) (any, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:231
+		app.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:232
+		defer app.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:233
+		return handler(args, app.state)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:237
+func (app *App) State() any {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:238
+	app.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:239
+	defer app.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:240
+	return app.state
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:246
+func (app *App) Serve() error {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:247
+	term.ForMCP()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:248
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:249
+	defer stop()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:250
+	return app.Run(sigCtx, &mcp.StdioTransport{})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:255
+func (app *App) Run(ctx context.Context, transport mcp.Transport) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:256
+	if app.init != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:257
+		state, initErr := app.init()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:258
+		if initErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:259
+			return initErr
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:260
+		app.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:261
+		app.state = state
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:262
+		app.mu.Unlock()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:263
+	runErr := app.Server.Run(ctx, transport)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:265
+	if errors.Is(runErr, context.Canceled) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:266
+		runErr = nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:267
+	if app.shutdown == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:268
+		return runErr
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:269
+	app.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:270
+	defer app.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:271
+	return errors.Join(runErr, app.shutdown(app.state))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:274
+func toolArgs(req *mcp.CallToolRequest) (map[string]any, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:275
+	args := make(map[string]any)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:276
+	if len(req.Params.Arguments) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:277
+		unmarshalErr := json.Unmarshal(req.Params.Arguments, &args)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:278
+		if unmarshalErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:279
+			return nil, unmarshalErr
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:280
+	return args, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:284
+func toolResult(res any, handlerErr error) *mcp.CallToolResult {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:285
+	if handlerErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:286
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: handlerErr.Error()}}, IsError: true}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:290
+	switch r := res.(type) {
+	case *mcp.CallToolResult:
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:292
+		return r
+	case string:
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:294
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: r}}}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:297
+	data, marshalErr := json.Marshal(res)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:298
+	if marshalErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:299
+		return toolResult(nil, marshalErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp.kuki:300
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}
+}
@@ -3,33 +3,206 @@
 package mcp_test
 
 import (
+	"context"
+	"fmt"
 	mcppkg "github.com/duber000/kukicha/stdlib/mcp"
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	"testing"
 )
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:9
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:11
 func TestSchemaHelpers(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:13
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:15
 	if true {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:14
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:16
 		return
 	}
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:17
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:19
 func TestResultHelpers(t *testing.T) {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:18
-	text := mcppkg.TextResult("hello")
 //line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:20
+	text := mcppkg.TextResult("hello")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:22
 	if text == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:21
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:23
 		t.Errorf("TextResult should return non-empty result")
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:23
-	errRes := mcppkg.ErrorResult("boom")
 //line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:25
+	errRes := mcppkg.ErrorResult("boom")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:27
 	if errRes == nil {
-//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:26
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:28
 		t.Errorf("ErrorResult should return non-empty result")
 	}
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:32
+func TestProgressOutput(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:33
+	p := &mcppkg.Progress{}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:34
+	p.SetTotal(2)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:35
+	p.Write("one")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:36
+	p.Write("two")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:37
+	if p.Output() != "one\ntwo" {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:38
+		t.Errorf("Output() = %q, want %q", p.Output(), "one\ntwo")
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:42
+func TestStreamToolProgress(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:43
+	server := mcppkg.New("test", "1.0.0")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:44
+	mcppkg.StreamTool(server, "count", "Count to two", mcppkg.Schema(nil), func(args map[string]any, p *mcppkg.Progress) (any, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:45
+		p.SetTotal(2)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:46
+		p.Write("one")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:47
+		p.Write("two")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:48
+		return nil, nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:51
+	bg := context.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:52
+	serverTransport, clientTransport := sdk.NewInMemoryTransports()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:53
+	serverSession, err_1 := server.Connect(bg, serverTransport, nil)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:54
+		t.Fatalf("server connect failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:55
+	defer serverSession.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:57
+	messages := make(chan string, 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:58
+	onProgress := func(ctx context.Context, req *sdk.ProgressNotificationClientRequest) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:59
+		messages <- req.Params.Message
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:60
+	client := sdk.NewClient(&sdk.Implementation{Name: "client", Version: "1.0.0"}, &sdk.ClientOptions{ProgressNotificationHandler: onProgress})
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:61
+	session, err_2 := client.Connect(bg, clientTransport, nil)
+	if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:62
+		t.Fatalf("client connect failed: %v", err_2)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:63
+	defer session.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:65
+	meta := map[string]any{"progressToken": "tok"}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:66
+	res, err_3 := session.CallTool(bg, &sdk.CallToolParams{Name: "count", Meta: meta})
+	if err_3 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:67
+		t.Fatalf("call failed: %v", err_3)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:68
+	text := res.Content[0].(*sdk.TextContent).Text
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:69
+	if text != "one\ntwo" {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:70
+		t.Errorf("result = %q, want %q", text, "one\ntwo")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:71
+	for _, want := range []string{"one", "two"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:72
+		got := <-messages
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:73
+		if got != want {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:74
+			t.Errorf("progress message = %q, want %q", got, want)
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:76
+type counter struct {
+	n      int
+	closed bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:82
+func TestAppLifecycle(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:83
+	app := mcppkg.NewApp("test", "1.0.0")
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:84
+	app.OnInit(func() (any, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:85
+		return &counter{}, nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:87
+	app.OnShutdown(func(state any) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:88
+		state.(*counter).closed = true
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:89
+		return nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:91
+	app.Tool("bump", "Bump the counter", mcppkg.Schema(nil), func(args map[string]any, state any) (any, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:92
+		c := state.(*counter)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:93
+		c.n = c.n + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:94
+		return fmt.Sprintf("%v", c.n), nil
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:97
+	bg := context.Background()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:98
+	serverTransport, clientTransport := sdk.NewInMemoryTransports()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:99
+	done := make(chan error, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:100
+	go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:101
+		done <- app.Run(bg, serverTransport)
+	}()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:104
+	client := sdk.NewClient(&sdk.Implementation{Name: "client", Version: "1.0.0"}, nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:105
+	session, err_1 := client.Connect(bg, clientTransport, nil)
+	if err_1 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:106
+		t.Fatalf("client connect failed: %v", err_1)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:107
+	for _, want := range []string{"1", "2"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:108
+		res, err_2 := session.CallTool(bg, &sdk.CallToolParams{Name: "bump"})
+		if err_2 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:109
+			t.Fatalf("call failed: %v", err_2)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:110
+		text := res.Content[0].(*sdk.TextContent).Text
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:111
+		if text != want {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:112
+			t.Errorf("result = %q, want %q", text, want)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:113
+	session.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:115
+	runErr := <-done
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:116
+	if runErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:117
+		t.Errorf("Run() error = %v", runErr)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:118
+	if !app.State().(*counter).closed {
+//line /Users/tluker/repos/go/kukicha/stdlib/mcp/mcp_test.kuki:119
+		t.Errorf("shutdown hook did not run")
+	}
+}
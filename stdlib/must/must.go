@@ -102,8 +102,8 @@ func EnvIntOr(key string, defaultValue int) int {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:72
-	val, err_2 := cast.Atoi(value)
-	if err_2 != nil {
+	val, err_1 := cast.Atoi(value)
+	if err_1 != nil {
 		panic(fmt.Sprintf("must: environment variable %v must be a valid integer", key))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:73
@@ -120,8 +120,8 @@ func EnvBool(key string) bool {
 		panic(fmt.Sprintf("must: environment variable %v is required but not set", key))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:82
-	val, err_3 := env.ParseBool(value)
-	if err_3 != nil {
+	val, err_1 := env.ParseBool(value)
+	if err_1 != nil {
 		panic(fmt.Sprintf("must: environment variable %v must be a valid boolean", key))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:83
@@ -138,8 +138,8 @@ func EnvBoolOr(key string, defaultValue bool) bool {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:92
-	val, err_4 := env.ParseBool(value)
-	if err_4 != nil {
+	val, err_1 := env.ParseBool(value)
+	if err_1 != nil {
 		panic(fmt.Sprintf("must: environment variable %v must be a valid boolean", key))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/must/must.kuki:93
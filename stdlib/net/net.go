@@ -63,7 +63,7 @@ func IsMulticast(ip net.IP) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net.kuki:69
 func IsNil(ip net.IP) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net.kuki:70
-	return (ip == nil)
+	return ip == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net.kuki:73
@@ -38,7 +38,7 @@ func TestIPAndCIDRHelpers(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:24
 	cidr, err_1 := netutil.ParseCIDR("192.0.2.0/24")
 	if err_1 != nil {
-		//line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:25
+//line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:25
 		t.Fatalf("ParseCIDR failed: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:26
@@ -49,11 +49,11 @@ func TestIPAndCIDRHelpers(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:29
 	host, port, err_2 := netutil.SplitHostPort("example.com:8080")
 	if err_2 != nil {
-		//line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:30
+//line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:30
 		t.Fatalf("SplitHostPort failed: %v", err_2)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:31
-	if (host != "example.com") || (port != "8080") {
+	if host != "example.com" || port != "8080" {
 //line /Users/tluker/repos/go/kukicha/stdlib/net/net_test.kuki:32
 		t.Errorf("SplitHostPort returned wrong parts")
 	}
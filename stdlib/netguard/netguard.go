@@ -69,10 +69,10 @@ func checkIP(g Guard, ip net.IP) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:58
 func NewAllow(cidrs []string) (Guard, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:59
-	nets, err_2 := parseCIDRs(cidrs)
-	if err_2 != nil {
+	nets, err_1 := parseCIDRs(cidrs)
+	if err_1 != nil {
 		var _zero0 Guard
-		return _zero0, err_2
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:60
 	return Guard{networks: nets, mode: "allow"}, nil
@@ -81,10 +81,10 @@ func NewAllow(cidrs []string) (Guard, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:63
 func NewBlock(cidrs []string) (Guard, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:64
-	nets, err_3 := parseCIDRs(cidrs)
-	if err_3 != nil {
+	nets, err_1 := parseCIDRs(cidrs)
+	if err_1 != nil {
 		var _zero0 Guard
-		return _zero0, err_3
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:65
 	return Guard{networks: nets, mode: "block"}, nil
@@ -116,16 +116,16 @@ func Check(g Guard, ipStr string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:103
 func DialContext(g Guard, ctx context.Context, network string, addr string) (net.Conn, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:104
-	host, port, err_4 := net.SplitHostPort(addr)
-	if err_4 != nil {
-		err_4 = fmt.Errorf("netguard: invalid address : %w", err_4)
-		return nil, err_4
+	host, port, err_1 := net.SplitHostPort(addr)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("netguard: invalid address : %w", err_1)
+		return nil, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:106
-	ips, err_5 := net.DefaultResolver.LookupIPAddr(ctx, host)
-	if err_5 != nil {
-		err_5 = fmt.Errorf("netguard: dns lookup : %w", err_5)
-		return nil, err_5
+	ips, err_2 := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("netguard: dns lookup : %w", err_2)
+		return nil, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:109
 	dialIP := net.IPAddr{}
@@ -153,11 +153,14 @@ func DialContext(g Guard, ctx context.Context, network string, addr string) (net
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:121
 	dialer.Control = func(controlNetwork string, controlAddress string, c syscall.RawConn) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:122
-		connHost, _, _ := net.SplitHostPort(controlAddress)
+		connHost, _, err_1 := net.SplitHostPort(controlAddress)
+		if err_1 != nil {
+			return err_1
+		}
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:123
 		connIP := net.ParseIP(connHost)
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:124
-		if (connIP != nil) && !checkIP(g, connIP) {
+		if connIP != nil && !checkIP(g, connIP) {
 //line /Users/tluker/repos/go/kukicha/stdlib/netguard/netguard.kuki:125
 			return fmt.Errorf("netguard: connection to %v blocked by policy", connHost)
 		}
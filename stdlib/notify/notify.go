@@ -0,0 +1,375 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/env"
+	"github.com/duber000/kukicha/stdlib/fetch"
+	"github.com/duber000/kukicha/stdlib/retry"
+	"github.com/duber000/kukicha/stdlib/template"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:39
+type Message struct {
+	subject          string
+	body             string
+	data             map[string]any
+	retryMaxAttempts int
+	retryDelayMs     int
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:48
+func New(subject string, body string) Message {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:49
+	return Message{subject: subject, body: body}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:53
+func Data(msg Message, data map[string]any) Message {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:54
+	msg.data = data
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:55
+	return msg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:61
+func Retry(msg Message, maxAttempts int, delayMs int) Message {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:62
+	msg.retryMaxAttempts = maxAttempts
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:63
+	if delayMs <= 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:64
+		msg.retryDelayMs = 1000
+	} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:66
+		msg.retryDelayMs = delayMs
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:67
+	return msg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:72
+func Render(msg Message) (Message, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:73
+	if msg.data == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:74
+		return msg, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:75
+	var err_1 error
+	msg.subject, err_1 = template.RenderSimple(msg.subject, msg.data)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("notify subject: %w", err_1)
+		var _zero0 Message
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:76
+	var err_2 error
+	msg.body, err_2 = template.RenderSimple(msg.body, msg.data)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("notify body: %w", err_2)
+		var _zero0 Message
+		return _zero0, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:77
+	msg.data = nil
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:78
+	return msg, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:81
+func Subject(msg Message) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:82
+	return msg.subject
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:85
+func Body(msg Message) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:86
+	return msg.body
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:91
+type Mailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	sender   string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:100
+func SMTP(host string) Mailer {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:101
+	return Mailer{host: host, port: 587}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:106
+func SMTPFromEnv() (Mailer, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:107
+	host, err_1 := env.Get("SMTP_HOST")
+	if err_1 != nil {
+		err_1 = fmt.Errorf("notify: %w", err_1)
+		var _zero0 Mailer
+		return _zero0, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:108
+	port, err_2 := env.GetIntOr("SMTP_PORT", 587)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("notify: %w", err_2)
+		var _zero0 Mailer
+		return _zero0, err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:109
+	m := Auth(Port(SMTP(host), port), env.GetOr("SMTP_USERNAME", ""), env.GetOr("SMTP_PASSWORD", ""))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:110
+	return From(m, env.GetOr("SMTP_FROM", "")), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:113
+func Port(m Mailer, port int) Mailer {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:114
+	m.port = port
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:115
+	return m
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:119
+func Auth(m Mailer, username string, password string) Mailer {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:120
+	m.username = username
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:121
+	m.password = password
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:122
+	return m
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:125
+func From(m Mailer, address string) Mailer {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:126
+	m.sender = address
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:127
+	return m
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:132
+func SendEmail(msg Message, m Mailer, recipients []string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:133
+	if m.host == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:134
+		return errors.New("notify: no SMTP host")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:135
+	if len(recipients) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:136
+		return errors.New("notify: no recipients")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:137
+	sender, err := mail.ParseAddress(m.sender)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:138
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:139
+		return fmt.Errorf("notify: bad sender %q: %w", m.sender, err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:140
+	envelope := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:141
+	headerTo := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:142
+	for _, address := range recipients {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:143
+		rcpt, rcptErr := mail.ParseAddress(address)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:144
+		if rcptErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:145
+			return fmt.Errorf("notify: bad recipient %q: %w", address, rcptErr)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:146
+		envelope = append(envelope, rcpt.Address)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:147
+		headerTo = append(headerTo, rcpt.String())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:148
+	out, err_1 := Render(msg)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:150
+	header := []string{fmt.Sprintf("From: %v", sender.String()), fmt.Sprintf("To: %v", strings.Join(headerTo, ", ")), fmt.Sprintf("Subject: %v", mime.QEncoding.Encode("utf-8", oneLine(out.subject))), fmt.Sprintf("Date: %v", time.Now().Format(time.RFC1123Z)), "MIME-Version: 1.0", "Content-Type: text/plain; charset=utf-8", "Content-Transfer-Encoding: 8bit"}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:159
+	content := strings.Join(header, "\r\n") + "\r\n\r\n" + out.body
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:161
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:162
+	auth := *new(smtp.Auth)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:163
+	if m.username != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:164
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:166
+	attempts := max(msg.retryMaxAttempts, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:167
+	cfg := retry.Config{MaxAttempts: attempts, InitialDelay: msg.retryDelayMs, Strategy: 1}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:168
+	for attempt := range cfg.MaxAttempts {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:169
+		err = smtp.SendMail(addr, auth, sender.Address, envelope, []byte(content))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:170
+		if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:171
+			return nil
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:172
+		if permanent(err) || attempt == cfg.MaxAttempts-1 {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:173
+			return fmt.Errorf("notify email: %w", err)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:174
+		retry.Sleep(cfg, attempt)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:175
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:178
+func permanent(err error) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:179
+	reply := &textproto.Error{}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:180
+	return errors.As(err, &reply) && reply.Code >= 500
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:183
+func oneLine(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:184
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\r\n", " ")), " ")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:190
+func Slack(msg Message, webhookURL string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:191
+	out, err_1 := Render(msg)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:192
+	text := joinTitle(fmt.Sprintf("*%v*", out.subject), out.subject, out.body)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:193
+	err := post(msg, webhookURL, map[string]any{"text": text})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:194
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:195
+		return fmt.Errorf("notify slack: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:196
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:201
+func Discord(msg Message, webhookURL string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:202
+	out, err_1 := Render(msg)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:203
+	text := truncate(joinTitle(fmt.Sprintf("**%v**", out.subject), out.subject, out.body), 2000)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:204
+	err := post(msg, webhookURL, map[string]any{"content": text})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:205
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:206
+		return fmt.Errorf("notify discord: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:207
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:212
+func Teams(msg Message, webhookURL string) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:213
+	out, err_1 := Render(msg)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:214
+	blocks := []any{}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:215
+	if out.subject != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:216
+		blocks = append(blocks, map[string]any{"type": "TextBlock", "text": out.subject, "weight": "Bolder", "size": "Medium", "wrap": true})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:217
+	if out.body != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:218
+		blocks = append(blocks, map[string]any{"type": "TextBlock", "text": out.body, "wrap": true})
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:219
+	card := map[string]any{"$schema": "http://adaptivecards.io/schemas/adaptive-card.json", "type": "AdaptiveCard", "version": "1.4", "body": blocks}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:225
+	payload := map[string]any{"type": "message", "attachments": []any{map[string]any{"contentType": "application/vnd.microsoft.card.adaptive", "content": card}}}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:231
+	err := post(msg, webhookURL, payload)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:232
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:233
+		return fmt.Errorf("notify teams: %w", err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:234
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:237
+func post(msg Message, webhookURL string, payload map[string]any) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:238
+	resp, err_2 := fetch.Do(fetch.Retry(fetch.Body(fetch.Method(fetch.New(webhookURL), "POST"), payload), msg.retryMaxAttempts, msg.retryDelayMs))
+	if err_2 != nil {
+		return err_2
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:244
+	defer resp.Body.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:245
+	_, err := fetch.CheckStatus(resp)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:246
+	return err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:250
+func joinTitle(title string, subject string, body string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:251
+	if subject == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:252
+		return body
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:253
+	if body == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:254
+		return title
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:255
+	return title + "\n" + body
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:258
+func truncate(s string, limit int) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:259
+	chars := []rune(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:260
+	if len(chars) <= limit {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:261
+		return s
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify.kuki:262
+	return string(chars[:(limit-1)]) + "…"
+}
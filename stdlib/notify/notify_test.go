@@ -0,0 +1,440 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package notify_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/notify"
+	"github.com/duber000/kukicha/stdlib/test"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:18
+type fakeSMTP struct {
+	listener  net.Listener
+	greetings []string
+	mu        sync.Mutex
+	conns     int
+	rcpts     []string
+	messages  []string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:26
+func startSMTP(t *testing.T, greetings []string) (*fakeSMTP, notify.Mailer) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:27
+	ln, err_1 := net.Listen("tcp", "127.0.0.1:0")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:28
+	fake := &fakeSMTP{listener: ln, greetings: greetings}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:29
+	go fake.accept()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:30
+	t.Cleanup(fake.shutdown)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:31
+	port, err_2 := strconv.Atoi(fake.port())
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:32
+	mailer := notify.From(notify.Port(notify.SMTP("127.0.0.1"), port), "Alerts <alerts@example.com>")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:33
+	return fake, mailer
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:35
+func (f *fakeSMTP) port() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:36
+	_, port, err := net.SplitHostPort(f.listener.Addr().String())
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:37
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:38
+		panic(err)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:39
+	return port
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:41
+func (f *fakeSMTP) accept() {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:42
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:43
+		conn, err := f.listener.Accept()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:44
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:45
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:46
+		go f.session(conn)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:48
+func (f *fakeSMTP) shutdown() {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:49
+	f.listener.Close()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:51
+func (f *fakeSMTP) session(conn net.Conn) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:52
+	defer conn.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:53
+	tp := textproto.NewConn(conn)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:54
+	f.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:55
+	greeting := "220 fake ESMTP"
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:56
+	if f.conns < len(f.greetings) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:57
+		greeting = f.greetings[f.conns]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:58
+	f.conns = f.conns + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:59
+	f.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:60
+	tp.PrintfLine("%s", greeting)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:61
+	if !strings.HasPrefix(greeting, "220") {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:62
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:63
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:64
+		line, err := tp.ReadLine()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:65
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:66
+			return
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:67
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:68
+		switch verb {
+		case "EHLO", "HELO", "MAIL":
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:70
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:72
+			if strings.Contains(line, "reject@") {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:73
+				tp.PrintfLine("550 no such user")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:74
+				continue
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:75
+			f.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:76
+			f.rcpts = append(f.rcpts, line)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:77
+			f.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:78
+			tp.PrintfLine("250 ok")
+		case "DATA":
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:80
+			tp.PrintfLine("354 go ahead")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:81
+			lines, readErr := tp.ReadDotLines()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:82
+			if readErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:83
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:84
+			f.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:85
+			f.messages = append(f.messages, strings.Join(lines, "\n"))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:86
+			f.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:87
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:89
+			tp.PrintfLine("221 bye")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:90
+			return
+		default:
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:92
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:94
+func alert() notify.Message {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:95
+	return notify.Data(notify.New("Disk {{.Host}} at {{.Used}}%", "Free space on {{.Host}} is low."), map[string]any{"Host": "db-1", "Used": 91})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:99
+func TestRender(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:100
+	msg, err_1 := notify.Render(alert())
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:101
+	test.AssertEqual(t, notify.Subject(msg), "Disk db-1 at 91%")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:102
+	test.AssertEqual(t, notify.Body(msg), "Free space on db-1 is low.")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:105
+	plain, err_2 := notify.Render(notify.New("{{.Host}}", ""))
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:106
+	test.AssertEqual(t, notify.Subject(plain), "{{.Host}}")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:108
+	_, err := notify.Render(notify.Data(notify.New("{{.Host", ""), map[string]any{}))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:109
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:110
+	test.AssertTrue(t, strings.HasPrefix(err.Error(), "notify subject: "))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:113
+type fakeHook struct {
+	failures int
+	calls    int
+	body     string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:118
+func (f *fakeHook) serve(w http.ResponseWriter, r *http.Request) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:119
+	f.calls = f.calls + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:120
+	if f.calls <= f.failures {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:121
+		w.WriteHeader(http.StatusServiceUnavailable)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:122
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:123
+	body, err_1 := io.ReadAll(r.Body)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:124
+	f.body = string(body)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:125
+	if r.Header.Get("Content-Type") != "application/json" {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:126
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:128
+func startHook(t *testing.T, failures int) (*fakeHook, string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:129
+	fake := &fakeHook{failures: failures}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:130
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:131
+	t.Cleanup(server.Close)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:132
+	return fake, server.URL
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:135
+type WebhookCase struct {
+	name    string
+	deliver func(notify.Message, string) error
+	want    string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:141
+func TestWebhooks(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:142
+	cases := []WebhookCase{WebhookCase{name: "slack", deliver: notify.Slack, want: "{\"text\":\"*Disk db-1 at 91%*\\nFree space on db-1 is low.\"}"}, WebhookCase{name: "discord", deliver: notify.Discord, want: "{\"content\":\"**Disk db-1 at 91%**\\nFree space on db-1 is low.\"}"}, WebhookCase{name: "teams", deliver: notify.Teams, want: "\"body\":[{\"size\":\"Medium\",\"text\":\"Disk db-1 at 91%\",\"type\":\"TextBlock\",\"weight\":\"Bolder\",\"wrap\":true},{\"text\":\"Free space on db-1 is low.\",\"type\":\"TextBlock\",\"wrap\":true}]"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:147
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:148
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:149
+			hook, url := startHook(t, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:150
+			err_1 := tc.deliver(alert(), url)
+			if err_1 != nil {
+				panic(fmt.Sprintf("%v", err_1))
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:151
+			test.AssertTrue(t, strings.Contains(hook.body, tc.want))
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:155
+func TestWebhookRetry(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:156
+	hook, url := startHook(t, 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:157
+	err_1 := notify.Slack(notify.Retry(alert(), 3, 1), url)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+	_ = notify.Retry(alert(), 3, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:158
+	test.AssertEqual(t, hook.calls, 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:160
+	_, failing := startHook(t, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:161
+	err := notify.Discord(alert(), failing)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:162
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:163
+	test.AssertEqual(t, err.Error(), "notify discord: request failed: 503 Service Unavailable")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:166
+func TestDiscordTruncates(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:167
+	hook, url := startHook(t, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:168
+	err_1 := notify.Discord(notify.New("", strings.Repeat("é", 2500)), url)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+	_ = notify.New("", strings.Repeat("é", 2500))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:169
+	test.AssertEqual(t, hook.body, "{\"content\":\""+strings.Repeat("é", 1999)+"…\"}")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:172
+func TestSendEmail(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:173
+	fake, mailer := startSMTP(t, []string{})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:174
+	recipients := []string{"Ops <ops@example.com>", "b@example.com"}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:175
+	err_1 := notify.SendEmail(alert(), mailer, recipients)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+	_ = alert()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:176
+	test.AssertEqual(t, fake.rcpts, []string{"RCPT TO:<ops@example.com>", "RCPT TO:<b@example.com>"})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:177
+	test.AssertEqual(t, len(fake.messages), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:178
+	message := fake.messages[0]
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:179
+	test.AssertTrue(t, strings.HasPrefix(message, "From: \"Alerts\" <alerts@example.com>\nTo: \"Ops\" <ops@example.com>, <b@example.com>\nSubject: Disk db-1 at 91%\n"))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:180
+	test.AssertTrue(t, strings.HasSuffix(message, "Content-Type: text/plain; charset=utf-8\nContent-Transfer-Encoding: 8bit\n\nFree space on db-1 is low."))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:183
+	err_2 := notify.SendEmail(notify.New("down\r\nBcc: x@example.com", ""), mailer, recipients)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+	_ = notify.New("down\r\nBcc: x@example.com", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:184
+	test.AssertTrue(t, strings.Contains(fake.messages[1], "\nSubject: down Bcc: x@example.com\n"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:187
+func TestSendEmailRetry(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:188
+	fake, mailer := startSMTP(t, []string{"421 busy, try later"})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:189
+	recipients := []string{"ops@example.com"}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:190
+	err_1 := notify.SendEmail(notify.Retry(alert(), 2, 1), mailer, recipients)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+	_ = notify.Retry(alert(), 2, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:191
+	test.AssertEqual(t, fake.conns, 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:192
+	test.AssertEqual(t, len(fake.messages), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:195
+	rejected, rejectMailer := startSMTP(t, []string{})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:196
+	err := notify.SendEmail(notify.Retry(alert(), 3, 1), rejectMailer, []string{"reject@example.com"})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:197
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:198
+	test.AssertTrue(t, strings.HasPrefix(err.Error(), "notify email: 550 "))
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:199
+	test.AssertEqual(t, rejected.conns, 1)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:202
+type SendEmailErrorCase struct {
+	name       string
+	mailer     notify.Mailer
+	recipients []string
+	want       string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:209
+func TestSendEmailErrors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:210
+	mailer := notify.From(notify.SMTP("127.0.0.1"), "alerts@example.com")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:211
+	cases := []SendEmailErrorCase{SendEmailErrorCase{name: "no host", mailer: notify.SMTP(""), recipients: []string{"a@example.com"}, want: "notify: no SMTP host"}, SendEmailErrorCase{name: "no recipients", mailer: mailer, recipients: []string{}, want: "notify: no recipients"}, SendEmailErrorCase{name: "no sender", mailer: notify.SMTP("127.0.0.1"), recipients: []string{"a@example.com"}, want: "notify: bad sender \"\""}, SendEmailErrorCase{name: "bad recipient", mailer: mailer, recipients: []string{"ops"}, want: "notify: bad recipient \"ops\""}}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:217
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:218
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:219
+			err := notify.SendEmail(alert(), tc.mailer, tc.recipients)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:220
+			test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:221
+			test.AssertTrue(t, strings.HasPrefix(err.Error(), tc.want))
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:225
+func TestSMTPFromEnv(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:226
+	t.Setenv("SMTP_HOST", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:227
+	_, err := notify.SMTPFromEnv()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:228
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:230
+	fake, _ := startSMTP(t, []string{})
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:231
+	t.Setenv("SMTP_HOST", "127.0.0.1")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:232
+	t.Setenv("SMTP_PORT", fake.port())
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:233
+	t.Setenv("SMTP_FROM", "alerts@example.com")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:234
+	mailer, err_1 := notify.SMTPFromEnv()
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:235
+	err_2 := notify.SendEmail(alert(), mailer, []string{"ops@example.com"})
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+	_ = alert()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:236
+	test.AssertEqual(t, len(fake.messages), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:238
+	t.Setenv("SMTP_PORT", "smtp")
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:239
+	_, err = notify.SMTPFromEnv()
+//line /Users/tluker/repos/go/kukicha/stdlib/notify/notify_test.kuki:240
+	test.AssertError(t, err)
+}
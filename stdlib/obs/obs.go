@@ -109,9 +109,9 @@ func Log(logger Logger, level string, message string, fields map[string]any) {
 //line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:82
 	line, err_1 := json.Marshal(payload)
 	if err_1 != nil {
-		//line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:83
+//line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:83
 		fmt.Println(fmt.Sprintf("[obs:%v] %v", level, message))
-		//line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:84
+//line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:84
 		return
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/obs/obs.kuki:85
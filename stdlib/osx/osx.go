@@ -0,0 +1,172 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package osx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:23
+var handlersMu sync.Mutex
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:24
+var handlers []func()
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:25
+var watching bool
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:32
+func OnInterrupt(handler func()) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:33
+	handlersMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:34
+	defer handlersMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:35
+	handlers = append(handlers, handler)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:36
+	if watching {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:37
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:38
+	watching = true
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:39
+	signals := make(chan os.Signal, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:40
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:41
+	go runHandlers(signals)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:46
+func WaitForInterrupt() os.Signal {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:47
+	signals := make(chan os.Signal, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:48
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:49
+	defer signal.Stop(signals)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:50
+	return <-signals
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:54
+func Hostname() (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:55
+	return os.Hostname()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:59
+func UserHomeDir() (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:60
+	return os.UserHomeDir()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:65
+func Which(binary string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:66
+	path, err := exec.LookPath(binary)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:67
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:68
+		return "", fmt.Errorf("osx.Which: %v not found in PATH", binary)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:69
+	return path, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:74
+func Expand(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:75
+	return os.ExpandEnv(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:79
+func ExpandStrict(s string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:80
+	missing := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:81
+	lookup := func(name string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:82
+		value, ok := os.LookupEnv(name)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:83
+		if !ok && !slices.Contains(missing, name) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:84
+			missing = append(missing, name)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:85
+		return value
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:86
+	out := os.Expand(s, lookup)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:87
+	if len(missing) > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:88
+		names := strings.Join(missing, ", ")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:89
+		return "", fmt.Errorf("osx.ExpandStrict: unset variables: %v", names)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:90
+	return out, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:95
+func ExpandPath(path string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:96
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, filepath.FromSlash("~/")) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:97
+		home, err_1 := os.UserHomeDir()
+		if err_1 != nil {
+			err_1 = fmt.Errorf("osx.ExpandPath: %w", err_1)
+			return "", err_1
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:98
+		path = home + path[1:]
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:99
+	if path == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:100
+		return "", errors.New("osx.ExpandPath: empty path")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:101
+	return filepath.Clean(os.ExpandEnv(path)), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:105
+func runHandlers(signals chan os.Signal) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:106
+	sig := <-signals
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:107
+	signal.Stop(signals)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:108
+	handlersMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:109
+	pending := slices.Clone(handlers)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:110
+	handlersMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:111
+	slices.Reverse(pending)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:112
+	for _, handler := range pending {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:113
+		handler()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:114
+	code := 130
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:115
+	if sig == syscall.SIGTERM {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:116
+		code = 143
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx.kuki:117
+	os.Exit(code)
+}
@@ -0,0 +1,228 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package osx_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/osx"
+	"github.com/duber000/kukicha/stdlib/test"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:18
+func runChild(testName string, mode string) (string, int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:19
+	cmd := exec.Command(os.Args[0], fmt.Sprintf("-test.run=^%v$", testName))
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:20
+	cmd.Env = append(os.Environ(), fmt.Sprintf("OSX_CHILD=%v", mode))
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:21
+	out, _ := cmd.Output()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:22
+	return string(out), cmd.ProcessState.ExitCode()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:26
+func signalSelf(sig os.Signal) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:27
+	p, err_1 := os.FindProcess(os.Getpid())
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:28
+	err_2 := p.Signal(sig)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:31
+func TestOnInterrupt(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:32
+	if runtime.GOOS == "windows" {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:33
+		t.Skip("signals cannot be sent on windows")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:34
+	if os.Getenv("OSX_CHILD") == "interrupt" {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:35
+		osx.OnInterrupt(func() { fmt.Println("first") })
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:36
+		osx.OnInterrupt(func() { fmt.Println("second") })
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:37
+		signalSelf(syscall.SIGTERM)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:38
+		time.Sleep(5 * time.Second)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:39
+		return
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:40
+	out, code := runChild("TestOnInterrupt", "interrupt")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:41
+	test.AssertEqual(t, code, 143)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:42
+	test.AssertTrue(t, strings.Contains(out, "second\nfirst\n"), fmt.Sprintf("output %v", out))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:45
+func TestWaitForInterrupt(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:46
+	if runtime.GOOS == "windows" {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:47
+		t.Skip("signals cannot be sent on windows")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:48
+	if os.Getenv("OSX_CHILD") == "wait" {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:49
+		go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:50
+			time.Sleep(100 * time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:51
+			signalSelf(os.Interrupt)
+		}()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:53
+		sig := osx.WaitForInterrupt()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:54
+		fmt.Println(fmt.Sprintf("got %v", sig))
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:55
+		os.Exit(0)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:56
+	out, code := runChild("TestWaitForInterrupt", "wait")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:57
+	test.AssertEqual(t, code, 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:58
+	test.AssertTrue(t, strings.Contains(out, "got interrupt"), fmt.Sprintf("output %v", out))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:61
+func TestHostname(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:62
+	host, err := osx.Hostname()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:63
+	test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:64
+	test.AssertNotEmpty(t, host)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:67
+type WhichCase struct {
+	name    string
+	binary  string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:72
+func TestWhich(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:73
+	cases := []WhichCase{WhichCase{name: "installed", binary: "sh", wantErr: false}, WhichCase{name: "missing", binary: "kukicha-no-such-binary", wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:77
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:78
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:79
+			path, err := osx.Which(tc.binary)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:80
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:81
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:82
+				test.AssertTrue(t, strings.Contains(err.Error(), tc.binary), fmt.Sprintf("error %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:83
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:84
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:85
+			test.AssertTrue(t, filepath.IsAbs(path), fmt.Sprintf("path %v", path))
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:89
+type ExpandCase struct {
+	name    string
+	input   string
+	want    string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:95
+func TestExpand(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:96
+	t.Setenv("OSX_HOST", "api.example.com")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:97
+	t.Setenv("OSX_EMPTY", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:98
+	os.Unsetenv("OSX_UNSET")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:99
+	cases := []ExpandCase{ExpandCase{name: "dollar", input: "https://$OSX_HOST/v1", want: "https://api.example.com/v1"}, ExpandCase{name: "braces", input: "${OSX_HOST}:443", want: "api.example.com:443"}, ExpandCase{name: "set but empty", input: "[$OSX_EMPTY]", want: "[]"}, ExpandCase{name: "unset", input: "[$OSX_UNSET]", want: "[]", wantErr: true}, ExpandCase{name: "no variables", input: "plain", want: "plain"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:106
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:107
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:108
+			test.AssertEqual(t, osx.Expand(tc.input), tc.want)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:109
+			got, err := osx.ExpandStrict(tc.input)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:110
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:111
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:112
+				test.AssertTrue(t, strings.Contains(err.Error(), "OSX_UNSET"), fmt.Sprintf("error %v", err))
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:113
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:114
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:115
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:119
+type ExpandPathCase struct {
+	name    string
+	input   string
+	want    string
+	wantErr bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:125
+func TestExpandPath(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:126
+	home := t.TempDir()
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:127
+	t.Setenv("HOME", home)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:128
+	t.Setenv("OSX_DIR", "data")
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:129
+	cases := []ExpandPathCase{ExpandPathCase{name: "tilde", input: "~", want: home}, ExpandPathCase{name: "tilde slash", input: "~/.kube/config", want: filepath.Join(home, ".kube", "config")}, ExpandPathCase{name: "variable", input: "$OSX_DIR/in/../out", want: filepath.Join("data", "out")}, ExpandPathCase{name: "tilde user is kept", input: "~bob/x", want: "~bob/x"}, ExpandPathCase{name: "empty", input: "", wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:136
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:137
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:138
+			got, err := osx.ExpandPath(tc.input)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:139
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:140
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:141
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:142
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/osx/osx_test.kuki:143
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
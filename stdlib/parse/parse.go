@@ -57,9 +57,9 @@ func readAllCSV(reader *csv.Reader) ([][]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:44
 func Csv(data string) ([][]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:45
-	records, err_4 := readAllCSV(csv.NewReader(bytes.NewBufferString(data)))
-	if err_4 != nil {
-		return [][]string{}, err_4
+	records, err_2 := readAllCSV(csv.NewReader(bytes.NewBufferString(data)))
+	if err_2 != nil {
+		return [][]string{}, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:50
 	return records, nil
@@ -68,9 +68,9 @@ func Csv(data string) ([][]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:57
 func CsvWithHeader(data string) ([]map[string]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:58
-	records, err_6 := readAllCSV(csv.NewReader(bytes.NewBufferString(data)))
-	if err_6 != nil {
-		return []map[string]string{}, err_6
+	records, err_2 := readAllCSV(csv.NewReader(bytes.NewBufferString(data)))
+	if err_2 != nil {
+		return []map[string]string{}, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:64
 	if len(records) == 0 {
@@ -80,7 +80,7 @@ func CsvWithHeader(data string) ([]map[string]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:67
 	headers := records[0]
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:68
-	result := make([]map[string]string, 0, (len(records) - 1))
+	result := make([]map[string]string, 0, len(records)-1)
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:71
 	numRecords := len(records)
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:72
@@ -122,9 +122,9 @@ func Yaml(data string) ([]byte, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:96
 func YamlPretty(value any) ([]byte, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:97
-	val, err_7 := yaml.Marshal(value)
-	if err_7 != nil {
-		return []byte{}, err_7
+	val, err_1 := yaml.Marshal(value)
+	if err_1 != nil {
+		return []byte{}, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/parse/parse.kuki:98
 	return val, nil
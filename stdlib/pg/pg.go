@@ -115,11 +115,11 @@ func Retry(cfg Config, maxAttempts int, delayMs int) Config {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:102
 func Open(cfg Config) (Pool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:103
-	poolCfg, err_2 := pgxpool.ParseConfig(cfg.url)
-	if err_2 != nil {
-		err_2 = fmt.Errorf("pg config: %w", err_2)
+	poolCfg, err_1 := pgxpool.ParseConfig(cfg.url)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg config: %w", err_1)
 		var _zero0 Pool
-		return _zero0, err_2
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:105
 	if cfg.maxConns > 0 {
@@ -146,11 +146,11 @@ func Open(cfg Config) (Pool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:115
 	if cfg.retryMaxAttempts <= 1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:116
-		pool, err_3 := pgxpool.NewWithConfig(ctxpkg.Value(bg), poolCfg)
-		if err_3 != nil {
-			err_3 = fmt.Errorf("pg open: %w", err_3)
+		pool, err_2 := pgxpool.NewWithConfig(ctxpkg.Value(bg), poolCfg)
+		if err_2 != nil {
+			err_2 = fmt.Errorf("pg open: %w", err_2)
 			var _zero0 Pool
-			return _zero0, err_3
+			return _zero0, err_2
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:117
 		return Pool{pool: pool}, nil
@@ -189,11 +189,11 @@ func Query(p Pool, sql string, args ...any) (Rows, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:135
 	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:136
-	rows, err_4 := p.pool.Query(ctxpkg.Value(bg), sql, args...)
-	if err_4 != nil {
-		err_4 = fmt.Errorf("pg query: %w", err_4)
+	rows, err_1 := p.pool.Query(ctxpkg.Value(bg), sql, args...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg query: %w", err_1)
 		var _zero0 Rows
-		return _zero0, err_4
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:137
 	return Rows{rows: rows}, nil
@@ -214,11 +214,11 @@ func Exec(p Pool, sql string, args ...any) (Result, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:149
 	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:150
-	tag, err_5 := p.pool.Exec(ctxpkg.Value(bg), sql, args...)
-	if err_5 != nil {
-		err_5 = fmt.Errorf("pg exec: %w", err_5)
+	tag, err_1 := p.pool.Exec(ctxpkg.Value(bg), sql, args...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg exec: %w", err_1)
 		var _zero0 Result
-		return _zero0, err_5
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:151
 	return Result{tag: tag}, nil
@@ -229,10 +229,10 @@ func Scan(r Row, dest ...any) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:155
 	row := r.scanFn.(pgx.Row)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:156
-	err_6 := row.Scan(dest...)
-	if err_6 != nil {
-		err_6 = fmt.Errorf("pg scan: %w", err_6)
-		return err_6
+	err_1 := row.Scan(dest...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:157
 	return nil
@@ -245,10 +245,10 @@ func ScanString(r Row) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:162
 	v := ""
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:163
-	err_7 := row.Scan(&v)
-	if err_7 != nil {
-		err_7 = fmt.Errorf("pg scan string: %w", err_7)
-		return "", err_7
+	err_1 := row.Scan(&v)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan string: %w", err_1)
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:164
 	return v, nil
@@ -261,10 +261,10 @@ func ScanInt(r Row) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:169
 	v := 0
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:170
-	err_8 := row.Scan(&v)
-	if err_8 != nil {
-		err_8 = fmt.Errorf("pg scan int: %w", err_8)
-		return 0, err_8
+	err_1 := row.Scan(&v)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan int: %w", err_1)
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:171
 	return v, nil
@@ -277,10 +277,10 @@ func ScanInt64(r Row) (int64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:176
 	v := int64(0)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:177
-	err_9 := row.Scan(&v)
-	if err_9 != nil {
-		err_9 = fmt.Errorf("pg scan int64: %w", err_9)
-		return 0, err_9
+	err_1 := row.Scan(&v)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan int64: %w", err_1)
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:178
 	return v, nil
@@ -293,10 +293,10 @@ func ScanBool(r Row) (bool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:183
 	v := false
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:184
-	err_10 := row.Scan(&v)
-	if err_10 != nil {
-		err_10 = fmt.Errorf("pg scan bool: %w", err_10)
-		return false, err_10
+	err_1 := row.Scan(&v)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan bool: %w", err_1)
+		return false, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:185
 	return v, nil
@@ -309,10 +309,10 @@ func ScanFloat64(r Row) (float64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:190
 	v := 0.0
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:191
-	err_11 := row.Scan(&v)
-	if err_11 != nil {
-		err_11 = fmt.Errorf("pg scan float64: %w", err_11)
-		return 0, err_11
+	err_1 := row.Scan(&v)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan float64: %w", err_1)
+		return 0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:192
 	return v, nil
@@ -331,10 +331,10 @@ func ScanRow(r Rows, dest ...any) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:201
 	rows := r.rows.(pgx.Rows)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:202
-	err_12 := rows.Scan(dest...)
-	if err_12 != nil {
-		err_12 = fmt.Errorf("pg scan row: %w", err_12)
-		return err_12
+	err_1 := rows.Scan(dest...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg scan row: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:203
 	return nil
@@ -361,10 +361,10 @@ func CollectRows(r Rows) ([]map[string]any, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:216
 	for rows.Next() {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:217
-		values, err_13 := rows.Values()
-		if err_13 != nil {
-			err_13 = fmt.Errorf("pg collect rows: %w", err_13)
-			return []map[string]any{}, err_13
+		values, err_1 := rows.Values()
+		if err_1 != nil {
+			err_1 = fmt.Errorf("pg collect rows: %w", err_1)
+			return []map[string]any{}, err_1
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:218
 		row := make(map[string]any, len(descs))
@@ -377,10 +377,10 @@ func CollectRows(r Rows) ([]map[string]any, error) {
 		results = append(results, row)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:222
-	err_14 := rows.Err()
-	if err_14 != nil {
-		err_14 = fmt.Errorf("pg collect rows: %w", err_14)
-		return []map[string]any{}, err_14
+	err_2 := rows.Err()
+	if err_2 != nil {
+		err_2 = fmt.Errorf("pg collect rows: %w", err_2)
+		return []map[string]any{}, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:223
 	return results, nil
@@ -391,11 +391,11 @@ func Begin(p Pool) (Tx, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:227
 	bg := ctxpkg.Background()
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:228
-	tx, err_15 := p.pool.Begin(ctxpkg.Value(bg))
-	if err_15 != nil {
-		err_15 = fmt.Errorf("pg begin: %w", err_15)
+	tx, err_1 := p.pool.Begin(ctxpkg.Value(bg))
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg begin: %w", err_1)
 		var _zero0 Tx
-		return _zero0, err_15
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:229
 	return Tx{tx: tx}, nil
@@ -408,11 +408,11 @@ func TxQuery(t Tx, sql string, args ...any) (Rows, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:235
 	tx := t.tx.(pgx.Tx)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:236
-	rows, err_16 := tx.Query(ctxpkg.Value(bg), sql, args...)
-	if err_16 != nil {
-		err_16 = fmt.Errorf("pg tx query: %w", err_16)
+	rows, err_1 := tx.Query(ctxpkg.Value(bg), sql, args...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg tx query: %w", err_1)
 		var _zero0 Rows
-		return _zero0, err_16
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:237
 	return Rows{rows: rows}, nil
@@ -437,11 +437,11 @@ func TxExec(t Tx, sql string, args ...any) (Result, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:251
 	tx := t.tx.(pgx.Tx)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:252
-	tag, err_17 := tx.Exec(ctxpkg.Value(bg), sql, args...)
-	if err_17 != nil {
-		err_17 = fmt.Errorf("pg tx exec: %w", err_17)
+	tag, err_1 := tx.Exec(ctxpkg.Value(bg), sql, args...)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg tx exec: %w", err_1)
 		var _zero0 Result
-		return _zero0, err_17
+		return _zero0, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:253
 	return Result{tag: tag}, nil
@@ -454,10 +454,10 @@ func Commit(t Tx) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:258
 	tx := t.tx.(pgx.Tx)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:259
-	err_18 := tx.Commit(ctxpkg.Value(bg))
-	if err_18 != nil {
-		err_18 = fmt.Errorf("pg commit: %w", err_18)
-		return err_18
+	err_1 := tx.Commit(ctxpkg.Value(bg))
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg commit: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:260
 	return nil
@@ -470,10 +470,10 @@ func Rollback(t Tx) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:265
 	tx := t.tx.(pgx.Tx)
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:266
-	err_19 := tx.Rollback(ctxpkg.Value(bg))
-	if err_19 != nil {
-		err_19 = fmt.Errorf("pg rollback: %w", err_19)
-		return err_19
+	err_1 := tx.Rollback(ctxpkg.Value(bg))
+	if err_1 != nil {
+		err_1 = fmt.Errorf("pg rollback: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/pg/pg.kuki:267
 	return nil
@@ -0,0 +1,181 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:25
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:30
+func (e ItemError) Error() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:31
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:34
+func (e ItemError) Unwrap() error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:35
+	return e.Err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:41
+func Map[T any, R any](items []T, worker func(T) (R, error), concurrency int) ([]R, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:42
+	withCtx := func(ctx context.Context, item T) (R, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:43
+		return worker(item)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:44
+	return MapContext(context.Background(), items, withCtx, concurrency)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:50
+func MapContext[T any, R any](ctx context.Context, items []T, worker func(context.Context, T) (R, error), concurrency int) ([]R, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:51
+	results := make([]R, len(items))
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:52
+	step := func(ctx context.Context, i int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:53
+		value, err := worker(ctx, items[i])
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:54
+		if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:55
+			return err
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:56
+		results[i] = value
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:57
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:58
+	err := run(ctx, len(items), concurrency, step)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:59
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:60
+		return nil, err
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:61
+	return results, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:67
+func ForEach[T any](items []T, worker func(T) error, concurrency int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:68
+	withCtx := func(ctx context.Context, item T) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:69
+		return worker(item)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:70
+	return ForEachContext(context.Background(), items, withCtx, concurrency)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:75
+func ForEachContext[T any](ctx context.Context, items []T, worker func(context.Context, T) error, concurrency int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:76
+	step := func(ctx context.Context, i int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:77
+		return worker(ctx, items[i])
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:78
+	return run(ctx, len(items), concurrency, step)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:82
+func run(parent context.Context, n int, concurrency int, step func(context.Context, int) error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:83
+	ctx, cancel := context.WithCancel(parent)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:84
+	defer cancel()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:85
+	mu := sync.Mutex{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:86
+	failures := []ItemError{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:87
+	next := make(chan int)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:88
+	wg := sync.WaitGroup{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:89
+	workers := max(1, min(concurrency, n))
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:90
+	wg.Add(workers)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:91
+	for range workers {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:92
+		go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:93
+			defer wg.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:94
+			for i := range next {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:95
+				err := step(ctx, i)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:96
+				if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:97
+					mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:98
+					failures = append(failures, ItemError{Index: i, Err: err})
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:99
+					mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:100
+					cancel()
+				}
+			}
+		}()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:102
+	for i := range n {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:103
+		if !feed(ctx, next, i) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:104
+			break
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:105
+	close(next)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:106
+	wg.Wait()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:108
+	if len(failures) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:109
+		return parent.Err()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:110
+	slices.SortFunc(failures, func(a ItemError, b ItemError) int { return a.Index - b.Index })
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:111
+	errs := []error{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:112
+	for _, f := range failures {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:113
+		errs = append(errs, f)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:114
+	return errors.Join(errs...)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:118
+func feed(ctx context.Context, next chan int, i int) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:119
+	if ctx.Err() != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:120
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:121
+	select {
+	case next <- i:
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:123
+		return true
+	case <-ctx.Done():
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool.kuki:125
+		return false
+	}
+}
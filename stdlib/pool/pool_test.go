@@ -0,0 +1,242 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/pool"
+	"github.com/duber000/kukicha/stdlib/test"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:14
+func upper(s string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:15
+	if s == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:16
+		return "", errors.New("empty word")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:17
+	return strings.ToUpper(s), nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:20
+type MapCase struct {
+	name        string
+	words       []string
+	concurrency int
+	want        []string
+	wantErr     bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:27
+func TestMap(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:28
+	cases := []MapCase{MapCase{name: "keeps order", words: []string{"a", "b", "c", "d"}, concurrency: 2, want: []string{"A", "B", "C", "D"}}, MapCase{name: "more workers than items", words: []string{"x"}, concurrency: 8, want: []string{"X"}}, MapCase{name: "zero concurrency runs one", words: []string{"a", "b"}, concurrency: 0, want: []string{"A", "B"}}, MapCase{name: "no items", words: []string{}, concurrency: 4, want: []string{}}, MapCase{name: "worker error", words: []string{"a", "", "c"}, concurrency: 1, wantErr: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:35
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:36
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:37
+			got, err := pool.Map(tc.words, upper, tc.concurrency)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:38
+			if tc.wantErr {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:39
+				test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:40
+				return
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:41
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:42
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:46
+func TestMapPipe(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:47
+	got, err_2 := pool.Map([]string{"go", "kuki"}, upper, 8)
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:48
+	test.AssertEqual(t, got[1]+"!", "KUKI!")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:51
+func TestConcurrencyCap(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:52
+	mu := sync.Mutex{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:53
+	running := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:54
+	peak := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:55
+	track := func(n int) (int, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:56
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:57
+		running = running + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:58
+		peak = max(peak, running)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:59
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:60
+		time.Sleep(5 * time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:61
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:62
+		running = running - 1
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:63
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:64
+		return n * 2, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:65
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:66
+	got, err_1 := pool.Map(items, track, 3)
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:67
+	test.AssertEqual(t, got[9], 20)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:68
+	test.AssertTrue(t, peak <= 3, fmt.Sprintf("peak %v", peak))
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:69
+	test.AssertTrue(t, peak >= 2, fmt.Sprintf("peak %v", peak))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:72
+func TestErrorAggregation(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:74
+	words := []string{"", "ok", ""}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:75
+	started := sync.WaitGroup{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:76
+	started.Add(3)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:77
+	gate := func(s string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:78
+		started.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:79
+		started.Wait()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:80
+		return upper(s)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:81
+	_, err := pool.Map(words, gate, 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:82
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:83
+	test.AssertEqual(t, err.Error(), "item 0: empty word\nitem 2: empty word")
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:84
+	first := pool.ItemError{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:85
+	test.AssertTrue(t, errors.As(err, &first))
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:86
+	test.AssertEqual(t, first.Index, 0)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:89
+func TestStopsAfterFailure(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:90
+	mu := sync.Mutex{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:91
+	started := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:92
+	fail := func(n int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:93
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:94
+		started = started + 1
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:95
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:96
+		if n == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:97
+			return errors.New("boom")
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:98
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:99
+	items := make([]int, 100)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:100
+	err := pool.ForEach(items, fail, 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:101
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:102
+	test.AssertTrue(t, started < 100, fmt.Sprintf("started %v", started))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:105
+func TestForEach(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:106
+	mu := sync.Mutex{}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:107
+	total := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:108
+	add := func(n int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:109
+		mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:110
+		total = total + n
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:111
+		mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:112
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:113
+	err := pool.ForEach([]int{1, 2, 3, 4}, add, 8)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:114
+	test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:115
+	test.AssertEqual(t, total, 10)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:118
+func TestContextCancel(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:119
+	ctx, cancel := context.WithCancel(context.Background())
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:120
+	cancel()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:121
+	slow := func(ctx context.Context, n int) (int, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:122
+		return n, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:123
+	_, err := pool.MapContext(ctx, []int{1, 2, 3}, slow, 8)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:124
+	test.AssertTrue(t, errors.Is(err, context.Canceled), fmt.Sprintf("error %v", err))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:127
+func TestContextCancelledOnFailure(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:128
+	wait := func(ctx context.Context, n int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:129
+		if n == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:130
+			return errors.New("boom")
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:132
+		<-ctx.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:133
+		return ctx.Err()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:134
+	err := pool.ForEachContext(context.Background(), []int{0, 1, 2}, wait, 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:135
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/pool/pool_test.kuki:136
+	test.AssertTrue(t, strings.Contains(err.Error(), "item 0: boom"), fmt.Sprintf("error %v", err))
+}
@@ -2,25 +2,163 @@
 
 package random
 
-import "math/rand"
+import (
+	"errors"
+	"fmt"
+	rand "math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+)
 
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:8
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:17
+const SeedEnv = "KUKICHA_SEED"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:19
+var mu sync.Mutex
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:20
+var rng = newGenerator()
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:25
+func Seed(seed int64) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:26
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:27
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:28
+	rng = seeded(seed)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:33
+func Int(min int, max int) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:34
+	if max <= min {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:35
+		return min
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:36
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:37
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:38
+	return min + rng.IntN(max-min+1)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:42
+func Float() float64 {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:43
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:44
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:45
+	return rng.Float64()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:49
+func Choice[T any](items []T) (T, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:50
+	if len(items) == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:51
+		var _zero0 T
+		return _zero0, errors.New("random.Choice: empty list")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:52
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:53
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:54
+	return items[rng.IntN(len(items))], nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:58
+func Shuffle[T any](items []T) []T {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:59
+	result := slices.Clone(items)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:60
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:61
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:62
+	rng.Shuffle(len(result), func(i int, j int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:63
+		swap := result[i]
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:64
+		result[i] = result[j]
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:65
+		result[j] = swap
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:67
+	return result
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:72
+func UUID() string {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:73
+	b := make([]byte, 16)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:74
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:75
+	for i := range 16 {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:76
+		b[i] = byte(rng.IntN(256))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:77
+	mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:78
+	b[6] = b[6]%16 + 0x40
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:79
+	b[8] = b[8]%64 + 0x80
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:83
 func String(length int) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:9
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:84
 	charset := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:10
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:85
 	b := make([]byte, length)
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:11
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:86
+	mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:87
+	defer mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:88
 	for i := range length {
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:12
-		b[i] = charset[rand.Intn(len(charset))]
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:89
+		b[i] = charset[rng.IntN(len(charset))]
 	}
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:13
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:90
 	return string(b)
 }
 
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:16
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:93
 func Alphanumeric(length int) string {
-//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:17
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:94
 	return String(length)
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:97
+func newGenerator() *rand.Rand {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:98
+	value := os.Getenv(SeedEnv)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:99
+	if value != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:100
+		seed, err := strconv.ParseInt(value, 10, 64)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:101
+		if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:102
+			return seeded(seed)
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:103
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:106
+func seeded(seed int64) *rand.Rand {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random.kuki:107
+	return rand.New(rand.NewPCG(uint64(seed), 0))
+}
@@ -3,6 +3,7 @@
 package random_test
 
 import (
+	"fmt"
 	"github.com/duber000/kukicha/stdlib/random"
 	"github.com/duber000/kukicha/stdlib/test"
 	"testing"
@@ -36,3 +37,128 @@ func TestRandomStringLength(t *testing.T) {
 		})
 	}
 }
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:30
+func TestSeedRepeats(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:31
+	random.Seed(42)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:32
+	first := []int{random.Int(1, 100), random.Int(1, 100), random.Int(1, 100)}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:33
+	word := random.String(8)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:34
+	random.Seed(42)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:35
+	second := []int{random.Int(1, 100), random.Int(1, 100), random.Int(1, 100)}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:36
+	test.AssertEqual(t, second, first)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:37
+	test.AssertEqual(t, random.String(8), word)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:40
+type IntCase struct {
+	name string
+	min  int
+	max  int
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:46
+func TestInt(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:47
+	cases := []IntCase{IntCase{name: "dice", min: 1, max: 6}, IntCase{name: "negative range", min: -5, max: -1}, IntCase{name: "single value", min: 3, max: 3}, IntCase{name: "reversed gives min", min: 9, max: 2}}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:53
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:54
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:55
+			for range 50 {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:56
+				n := random.Int(tc.min, tc.max)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:57
+				if tc.max <= tc.min {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:58
+					test.AssertEqual(t, n, tc.min)
+				} else {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:60
+					test.AssertTrue(t, n >= tc.min && n <= tc.max)
+				}
+			}
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:64
+func TestFloat(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:65
+	for range 50 {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:66
+		f := random.Float()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:67
+		test.AssertTrue(t, f >= 0.0 && f < 1.0)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:70
+func TestChoice(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:71
+	t.Run("picks an element", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:72
+		items := []string{"a", "b", "c"}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:73
+		got, err_1 := random.Choice(items)
+		if err_1 != nil {
+			panic(fmt.Sprintf("choice failed: %v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:74
+		test.AssertTrue(t, got == "a" || got == "b" || got == "c")
+	})
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:76
+	t.Run("empty list", func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:77
+		_, err := random.Choice([]int{})
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:78
+		test.AssertError(t, err)
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:82
+func TestShuffle(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:83
+	items := []int{1, 2, 3, 4, 5}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:84
+	shuffled := random.Shuffle(items)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:85
+	test.AssertEqual(t, len(shuffled), 5)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:86
+	test.AssertEqual(t, items, []int{1, 2, 3, 4, 5})
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:87
+	sum := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:88
+	for _, n := range shuffled {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:89
+		sum = sum + n
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:90
+	test.AssertEqual(t, sum, 15)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:93
+func TestUUID(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:94
+	id := random.UUID()
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:95
+	test.AssertEqual(t, len(id), 36)
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:96
+	test.AssertEqual(t, id[14:15], "4")
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:97
+	test.AssertEqual(t, id[8:9], "-")
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:98
+	test.AssertTrue(t, id != random.UUID())
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:101
+func TestSeedEnv(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/random/random_test.kuki:102
+	test.AssertEqual(t, random.SeedEnv, "KUKICHA_SEED")
+}
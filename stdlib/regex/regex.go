@@ -110,7 +110,7 @@ func IsValid(pattern string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/regex/regex.kuki:107
 	_, err := regexp.Compile(pattern)
 //line /Users/tluker/repos/go/kukicha/stdlib/regex/regex.kuki:108
-	return (err == nil)
+	return err == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/regex/regex.kuki:114
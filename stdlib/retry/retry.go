@@ -46,7 +46,7 @@ func Sleep(cfg Config, attempt int) {
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:45
 	delay := calculateDelay(cfg, attempt)
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:46
-	time.Sleep((time.Duration(delay) * time.Millisecond))
+	time.Sleep(time.Duration(delay) * time.Millisecond)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:49
@@ -61,8 +61,8 @@ func calculateDelay(cfg Config, attempt int) int {
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:55
 	for range attempt {
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:56
-		multiplier = (multiplier * 2)
+		multiplier = multiplier * 2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/retry/retry.kuki:58
-	return (cfg.InitialDelay * multiplier)
+	return cfg.InitialDelay * multiplier
 }
@@ -37,10 +37,10 @@ func Close(r Root) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:37
 func Read(r Root, path string) ([]byte, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:38
-	data, err_2 := r.root.ReadFile(path)
-	if err_2 != nil {
-		err_2 = fmt.Errorf("sandbox read: %w", err_2)
-		return []byte{}, err_2
+	data, err_1 := r.root.ReadFile(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox read: %w", err_1)
+		return []byte{}, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:39
 	return data, nil
@@ -49,10 +49,10 @@ func Read(r Root, path string) ([]byte, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:42
 func ReadString(r Root, path string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:43
-	data, err_3 := r.root.ReadFile(path)
-	if err_3 != nil {
-		err_3 = fmt.Errorf("sandbox read: %w", err_3)
-		return "", err_3
+	data, err_1 := r.root.ReadFile(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox read: %w", err_1)
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:44
 	return string(data), nil
@@ -61,10 +61,10 @@ func ReadString(r Root, path string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:47
 func WriteString(r Root, data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:48
-	err_4 := r.root.WriteFile(path, []byte(data), 0644)
-	if err_4 != nil {
-		err_4 = fmt.Errorf("sandbox write: %w", err_4)
-		return err_4
+	err_1 := r.root.WriteFile(path, []byte(data), 0644)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox write: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:49
 	return nil
@@ -73,16 +73,16 @@ func WriteString(r Root, data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:52
 func Write(r Root, data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:53
-	jsonData, err_5 := json.MarshalPretty(data)
-	if err_5 != nil {
-		err_5 = fmt.Errorf("sandbox write marshal: %w", err_5)
-		return err_5
+	jsonData, err_1 := json.MarshalPretty(data)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox write marshal: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:54
-	err_6 := r.root.WriteFile(path, jsonData, 0644)
-	if err_6 != nil {
-		err_6 = fmt.Errorf("sandbox write: %w", err_6)
-		return err_6
+	err_2 := r.root.WriteFile(path, jsonData, 0644)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("sandbox write: %w", err_2)
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:55
 	return nil
@@ -91,10 +91,10 @@ func Write(r Root, data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:58
 func AppendString(r Root, data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:59
-	f, err_7 := r.root.OpenFile(path, ((os.O_APPEND | os.O_CREATE) | os.O_WRONLY), 0644)
-	if err_7 != nil {
-		err_7 = fmt.Errorf("sandbox append: %w", err_7)
-		return err_7
+	f, err_1 := r.root.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox append: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:60
 	defer f.Close()
@@ -111,18 +111,18 @@ func AppendString(r Root, data string, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:65
 func Append(r Root, data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:66
-	jsonData, err_8 := json.Marshal(data)
-	if err_8 != nil {
-		err_8 = fmt.Errorf("sandbox append marshal: %w", err_8)
-		return err_8
+	jsonData, err_1 := json.Marshal(data)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox append marshal: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:67
 	jsonData = append(jsonData, '\n')
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:68
-	f, err_9 := r.root.OpenFile(path, ((os.O_APPEND | os.O_CREATE) | os.O_WRONLY), 0644)
-	if err_9 != nil {
-		err_9 = fmt.Errorf("sandbox append: %w", err_9)
-		return err_9
+	f, err_2 := r.root.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("sandbox append: %w", err_2)
+		return err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:69
 	defer f.Close()
@@ -139,10 +139,10 @@ func Append(r Root, data any, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:74
 func MkDir(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:75
-	err_10 := r.root.Mkdir(path, 0755)
-	if err_10 != nil {
-		err_10 = fmt.Errorf("sandbox mkdir: %w", err_10)
-		return err_10
+	err_1 := r.root.Mkdir(path, 0755)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox mkdir: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:76
 	return nil
@@ -151,10 +151,10 @@ func MkDir(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:79
 func MkDirAll(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:80
-	err_11 := r.root.MkdirAll(path, 0755)
-	if err_11 != nil {
-		err_11 = fmt.Errorf("sandbox mkdirall: %w", err_11)
-		return err_11
+	err_1 := r.root.MkdirAll(path, 0755)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox mkdirall: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:81
 	return nil
@@ -163,18 +163,18 @@ func MkDirAll(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:84
 func List(r Root, path string) ([]string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:85
-	f, err_12 := r.root.Open(path)
-	if err_12 != nil {
-		err_12 = fmt.Errorf("sandbox list: %w", err_12)
-		return []string{}, err_12
+	f, err_1 := r.root.Open(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox list: %w", err_1)
+		return []string{}, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:86
 	defer f.Close()
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:87
-	entries, err_13 := f.ReadDir(-1)
-	if err_13 != nil {
-		err_13 = fmt.Errorf("sandbox list: %w", err_13)
-		return []string{}, err_13
+	entries, err_2 := f.ReadDir(-1)
+	if err_2 != nil {
+		err_2 = fmt.Errorf("sandbox list: %w", err_2)
+		return []string{}, err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:88
 	names := make([]string, len(entries))
@@ -192,14 +192,14 @@ func Exists(r Root, path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:95
 	_, err := r.root.Stat(path)
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:96
-	return (err == nil)
+	return err == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:99
 func IsDir(r Root, path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:100
-	info, err_14 := r.root.Stat(path)
-	if err_14 != nil {
+	info, err_1 := r.root.Stat(path)
+	if err_1 != nil {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:101
@@ -209,8 +209,8 @@ func IsDir(r Root, path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:104
 func IsFile(r Root, path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:105
-	info, err_15 := r.root.Stat(path)
-	if err_15 != nil {
+	info, err_1 := r.root.Stat(path)
+	if err_1 != nil {
 		return false
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:106
@@ -220,10 +220,10 @@ func IsFile(r Root, path string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:109
 func Stat(r Root, path string) (os.FileInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:110
-	info, err_16 := r.root.Stat(path)
-	if err_16 != nil {
-		err_16 = fmt.Errorf("sandbox stat: %w", err_16)
-		return nil, err_16
+	info, err_1 := r.root.Stat(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox stat: %w", err_1)
+		return nil, err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:111
 	return info, nil
@@ -232,10 +232,10 @@ func Stat(r Root, path string) (os.FileInfo, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:114
 func Delete(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:115
-	err_17 := r.root.Remove(path)
-	if err_17 != nil {
-		err_17 = fmt.Errorf("sandbox delete: %w", err_17)
-		return err_17
+	err_1 := r.root.Remove(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox delete: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:116
 	return nil
@@ -244,10 +244,10 @@ func Delete(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:119
 func DeleteAll(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:120
-	err_18 := r.root.RemoveAll(path)
-	if err_18 != nil {
-		err_18 = fmt.Errorf("sandbox deleteall: %w", err_18)
-		return err_18
+	err_1 := r.root.RemoveAll(path)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox deleteall: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:121
 	return nil
@@ -256,10 +256,10 @@ func DeleteAll(r Root, path string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:124
 func Rename(r Root, oldpath string, newpath string) error {
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:125
-	err_19 := r.root.Rename(oldpath, newpath)
-	if err_19 != nil {
-		err_19 = fmt.Errorf("sandbox rename: %w", err_19)
-		return err_19
+	err_1 := r.root.Rename(oldpath, newpath)
+	if err_1 != nil {
+		err_1 = fmt.Errorf("sandbox rename: %w", err_1)
+		return err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/sandbox/sandbox.kuki:126
 	return nil
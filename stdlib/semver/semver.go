@@ -55,7 +55,7 @@ func Parse(tag string) (Version, error) {
 		return Version{}, fmt.Errorf("invalid semver: %v", tag)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:43
-	if ((major < 0) || (minor < 0)) || (patch < 0) {
+	if major < 0 || minor < 0 || patch < 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:44
 		return Version{}, fmt.Errorf("invalid semver: %v", tag)
 	}
@@ -69,19 +69,19 @@ func Bump(v Version, level string) Version {
 	switch level {
 	case "major":
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:54
-		v.major = (v.major + 1)
+		v.major = v.major + 1
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:55
 		v.minor = 0
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:56
 		v.patch = 0
 	case "minor":
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:58
-		v.minor = (v.minor + 1)
+		v.minor = v.minor + 1
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:59
 		v.patch = 0
 	case "patch":
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:61
-		v.patch = (v.patch + 1)
+		v.patch = v.patch + 1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:62
 	return v
@@ -98,7 +98,7 @@ func Valid(tag string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:72
 	_, err := Parse(tag)
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:73
-	return (err == nil)
+	return err == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:77
@@ -140,7 +140,7 @@ func Compare(a Version, b Version) int {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:94
 func Greater(a Version, b Version) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:95
-	return (Compare(a, b) > 0)
+	return Compare(a, b) > 0
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:100
@@ -157,17 +157,17 @@ func Highest(tags []string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:106
 	for _, tag := range valid {
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:107
-		v, err_4 := Parse(tag)
-		if err_4 != nil {
+		v, err_1 := Parse(tag)
+		if err_1 != nil {
 			continue
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:108
 		parsed = append(parsed, v)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:110
-	sorted := sort.By(parsed, func(a Version, b Version) bool { return (Compare(a, b) < 0) })
+	sorted := sort.By(parsed, func(a Version, b Version) bool { return Compare(a, b) < 0 })
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:111
-	best := sorted[(len(sorted) - 1)]
+	best := sorted[len(sorted)-1]
 //line /Users/tluker/repos/go/kukicha/stdlib/semver/semver.kuki:112
 	return Format(best), nil
 }
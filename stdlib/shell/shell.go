@@ -187,7 +187,7 @@ func Preview(cmd Command) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:143
 func Success(result Result) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:144
-	return ((result.exitCode == 0) && (result.err == nil))
+	return result.exitCode == 0 && result.err == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:148
@@ -213,7 +213,7 @@ func Which(name string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:167
 	_, err := exec.LookPath(name)
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:168
-	return (err == nil)
+	return err == nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/shell/shell.kuki:172
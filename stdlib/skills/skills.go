@@ -50,7 +50,10 @@ func Discover(dir string) ([]Skill, error) {
 			return nil
 		}
 //line /Users/tluker/repos/go/kukicha/stdlib/skills/skills.kuki:35
-		absPath, _ := filepath.Abs(filePath)
+		absPath, err_1 := filepath.Abs(filePath)
+		if err_1 != nil {
+			return err_1
+		}
 //line /Users/tluker/repos/go/kukicha/stdlib/skills/skills.kuki:36
 		skillName := filepath.Base(filepath.Dir(filePath))
 //line /Users/tluker/repos/go/kukicha/stdlib/skills/skills.kuki:37
@@ -129,13 +129,13 @@ func Chunk[T any](items []T, size int) [][]T {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:81
 	for i < length {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:82
-		end := min((i + size), length)
+		end := min(i+size, length)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:83
 		chunk := items[i:end]
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:84
 		result = append(result, chunk)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:85
-		i = (i + size)
+		i = i + size
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:87
 	return result
@@ -160,7 +160,7 @@ func Concat[T any](slices [][]T) []T {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:104
 	for _, slice := range slices {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:105
-		totalLength = (totalLength + len(slice))
+		totalLength = totalLength + len(slice)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:107
 	result := make([]T, 0, totalLength)
@@ -235,7 +235,7 @@ func SortBy[T any, K cmp.Ordered](items []T, key func(T) K) []T {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:160
 	result := slices.Clone(items)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:161
-	sort.SliceStable(result, func(i int, j int) bool { return (key(result[i]) < key(result[j])) })
+	sort.SliceStable(result, func(i int, j int) bool { return key(result[i]) < key(result[j]) })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:162
 	return result
 }
@@ -255,10 +255,10 @@ func Get[T any](items []T, index int) (T, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:177
 	if index < 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:178
-		actualIndex = (length + index)
+		actualIndex = length + index
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:180
-	if (actualIndex < 0) || (actualIndex >= length) {
+	if actualIndex < 0 || actualIndex >= length {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:181
 		var _zero0 T
 		return _zero0, fmt.Errorf("index %v out of bounds for slice of length %v", index, length)
@@ -281,10 +281,10 @@ func GetOr[T any](items []T, index int, defaultValue T) T {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:196
 	if index < 0 {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:197
-		actualIndex = (length + index)
+		actualIndex = length + index
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:199
-	if (actualIndex < 0) || (actualIndex >= length) {
+	if actualIndex < 0 || actualIndex >= length {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:200
 		return defaultValue
 	}
@@ -324,7 +324,7 @@ func LastOne[T any](items []T) (T, error) {
 		return _zero0, errors.New("slice is empty")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:224
-	return items[(len(items) - 1)], nil
+	return items[len(items)-1], nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:229
@@ -335,7 +335,7 @@ func LastOr[T any](items []T, defaultValue T) T {
 		return defaultValue
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:232
-	return items[(len(items) - 1)]
+	return items[len(items)-1]
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:236
@@ -391,7 +391,7 @@ func FindLast[T any](items []T, predicate func(T) bool) (T, error) {
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:265
 	{
-		_iStart, _iEnd, _iStep := (len(items) - 1), 0, 1
+		_iStart, _iEnd, _iStep := len(items)-1, 0, 1
 		if _iStart > _iEnd {
 			_iStep = -1
 		}
@@ -417,7 +417,7 @@ func FindLastOr[T any](items []T, predicate func(T) bool, defaultValue T) T {
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:275
 	{
-		_iStart, _iEnd, _iStep := (len(items) - 1), 0, 1
+		_iStart, _iEnd, _iStep := len(items)-1, 0, 1
 		if _iStart > _iEnd {
 			_iStep = -1
 		}
@@ -436,13 +436,13 @@ func FindLastOr[T any](items []T, predicate func(T) bool, defaultValue T) T {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:282
 func IsEmpty[T any](items []T) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:283
-	return (len(items) == 0)
+	return len(items) == 0
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:287
 func IsNotEmpty[T any](items []T) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:288
-	return (len(items) > 0)
+	return len(items) > 0
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:293
@@ -454,7 +454,7 @@ func Pop[T any](items []T) (T, []T, error) {
 		return _zero0, items, errors.New("cannot pop from empty slice")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:296
-	return items[(len(items) - 1)], items[:(len(items) - 1)], nil
+	return items[len(items)-1], items[:(len(items) - 1)], nil
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice.kuki:301
@@ -387,14 +387,14 @@ func TestFilter(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:264
 	t.Run("keeps 6-char strings", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:265
-		result := slice.Filter(items, func(s string) bool { return (len(s) == 6) })
+		result := slice.Filter(items, func(s string) bool { return len(s) == 6 })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:266
 		test.AssertEqual(t, len(result), 2)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:268
 	t.Run("all filtered out", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:269
-		none := slice.Filter(items, func(s string) bool { return (len(s) > 100) })
+		none := slice.Filter(items, func(s string) bool { return len(s) > 100 })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:270
 		test.AssertEqual(t, len(none), 0)
 	})
@@ -405,7 +405,7 @@ func TestMap(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:275
 	items := []int{1, 2, 3}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:276
-	result := slice.Map(items, func(n int) int { return (n * 2) })
+	result := slice.Map(items, func(n int) int { return n * 2 })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:278
 	t.Run("length preserved", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:279
@@ -429,14 +429,14 @@ func TestFindIndex(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:291
 	t.Run("first match", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:292
-		idx := slice.FindIndex(items, func(n int) bool { return (n > 25) })
+		idx := slice.FindIndex(items, func(n int) bool { return n > 25 })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:293
 		test.AssertEqual(t, idx, 2)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:295
 	t.Run("no match returns -1", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:296
-		notFound := slice.FindIndex(items, func(n int) bool { return (n > 100) })
+		notFound := slice.FindIndex(items, func(n int) bool { return n > 100 })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:297
 		test.AssertEqual(t, notFound, -1)
 	})
@@ -449,7 +449,7 @@ func TestFind(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:304
 	t.Run("found element", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:305
-		val, err := slice.Find(items, func(v string) bool { return (v == "banana") })
+		val, err := slice.Find(items, func(v string) bool { return v == "banana" })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:306
 		test.AssertNoError(t, err)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:307
@@ -458,7 +458,7 @@ func TestFind(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:309
 	t.Run("not found returns error", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:310
-		_, err := slice.Find(items, func(v string) bool { return (v == "grape") })
+		_, err := slice.Find(items, func(v string) bool { return v == "grape" })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:311
 		test.AssertError(t, err)
 	})
@@ -471,14 +471,14 @@ func TestFindOr(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:318
 	t.Run("match found", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:319
-		val := slice.FindOr(items, func(s string) bool { return (len(s) == 6) }, "none")
+		val := slice.FindOr(items, func(s string) bool { return len(s) == 6 }, "none")
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:320
 		test.AssertEqual(t, val, "banana")
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:322
 	t.Run("no match uses default", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:323
-		def := slice.FindOr(items, func(s string) bool { return (len(s) > 100) }, "none")
+		def := slice.FindOr(items, func(s string) bool { return len(s) > 100 }, "none")
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:324
 		test.AssertEqual(t, def, "none")
 	})
@@ -605,7 +605,7 @@ func TestFindLast(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:400
 	t.Run("found last element", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:401
-		val, err := slice.FindLast(items, func(v string) bool { return (v == "banana") })
+		val, err := slice.FindLast(items, func(v string) bool { return v == "banana" })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:402
 		test.AssertNoError(t, err)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:403
@@ -616,7 +616,7 @@ func TestFindLast(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:410
 		itemsWithIds := []Item{Item{Id: 1, Name: "a"}, Item{Id: 2, Name: "b"}, Item{Id: 3, Name: "a"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:415
-		val, err := slice.FindLast(itemsWithIds, func(v Item) bool { return (v.Name == "a") })
+		val, err := slice.FindLast(itemsWithIds, func(v Item) bool { return v.Name == "a" })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:416
 		test.AssertNoError(t, err)
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:417
@@ -625,7 +625,7 @@ func TestFindLast(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:420
 	t.Run("not found returns error", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:421
-		_, err := slice.FindLast(items, func(v string) bool { return (v == "grape") })
+		_, err := slice.FindLast(items, func(v string) bool { return v == "grape" })
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:422
 		test.AssertError(t, err)
 	})
@@ -638,7 +638,7 @@ func TestFindLastOr(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:429
 	t.Run("match found", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:430
-		val := slice.FindLastOr(items, func(s string) bool { return (s == "banana") }, "none")
+		val := slice.FindLastOr(items, func(s string) bool { return s == "banana" }, "none")
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:431
 		test.AssertEqual(t, val, "banana")
 	})
@@ -647,14 +647,14 @@ func TestFindLastOr(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:435
 		itemsWithIds := []Item{Item{Id: 1, Name: "a"}, Item{Id: 2, Name: "b"}, Item{Id: 3, Name: "a"}}
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:440
-		val := slice.FindLastOr(itemsWithIds, func(v Item) bool { return (v.Name == "a") }, Item{Id: 0, Name: ""})
+		val := slice.FindLastOr(itemsWithIds, func(v Item) bool { return v.Name == "a" }, Item{Id: 0, Name: ""})
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:441
 		test.AssertEqual(t, Item(val).Id, 3)
 	})
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:444
 	t.Run("no match uses default", func(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:445
-		def := slice.FindLastOr(items, func(s string) bool { return (s == "grape") }, "none")
+		def := slice.FindLastOr(items, func(s string) bool { return s == "grape" }, "none")
 //line /Users/tluker/repos/go/kukicha/stdlib/slice/slice_test.kuki:446
 		test.AssertEqual(t, def, "none")
 	})
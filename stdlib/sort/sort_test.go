@@ -80,7 +80,7 @@ func TestBy(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:60
 			words := []string{"banana", "fig", "apple", "kiwi"}
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:61
-			sorted := sort.By(words, func(a string, b string) bool { return (len(a) < len(b)) })
+			sorted := sort.By(words, func(a string, b string) bool { return len(a) < len(b) })
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:62
 			test.AssertEqual(t, sorted[0], "fig")
 		})
@@ -128,7 +128,7 @@ func TestReverse(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:91
 			nums := []int{1, 3, 2}
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:92
-			sorted := sort.Reverse(nums, func(a int, b int) bool { return (a < b) })
+			sorted := sort.Reverse(nums, func(a int, b int) bool { return a < b })
 //line /Users/tluker/repos/go/kukicha/stdlib/sort/sort_test.kuki:93
 			test.AssertEqual(t, sorted[0], 3)
 		})
@@ -150,7 +150,7 @@ func PadRight(s string, width int, padChar string) string {
 		return s
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:125
-	return (s + strings.Repeat(padChar, (width-length)))
+	return s + strings.Repeat(padChar, width-length)
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:129
@@ -163,7 +163,7 @@ func PadLeft(s string, width int, padChar string) string {
 		return s
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:133
-	return (strings.Repeat(padChar, (width-length)) + s)
+	return strings.Repeat(padChar, width-length) + s
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:150
@@ -181,13 +181,13 @@ func Len(s string) int {
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:160
 func IsEmpty(s string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:161
-	return (len(s) == 0)
+	return len(s) == 0
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:164
 func IsBlank(s string) bool {
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:165
-	return (len(strings.TrimSpace(s)) == 0)
+	return len(strings.TrimSpace(s)) == 0
 }
 
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string.kuki:168
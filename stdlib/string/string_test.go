@@ -204,7 +204,7 @@ func TestSearchFunctions(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:158
 			got := kukistring.Contains(tc.input, tc.cutset)
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:159
-			wantBool := (tc.want == "true")
+			wantBool := tc.want == "true"
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:160
 			test.AssertEqual(t, got, wantBool)
 		})
@@ -393,7 +393,7 @@ func TestEqualFold(t *testing.T) {
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:303
 			got := kukistring.EqualFold(tc.input, tc.cutset)
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:304
-			wantBool := (tc.want == "true")
+			wantBool := tc.want == "true"
 //line /Users/tluker/repos/go/kukicha/stdlib/string/string_test.kuki:305
 			test.AssertEqual(t, got, wantBool)
 		})
@@ -75,7 +75,7 @@ func renderTable(t Table, style string) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:70
 		for i := range len(t.Headers) {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:71
-			if (i < len(row)) && (len(row[i]) > widths[i]) {
+			if i < len(row) && len(row[i]) > widths[i] {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:72
 				widths[i] = len(row[i])
 			}
@@ -140,7 +140,7 @@ func renderPlainRow(cols []string, widths []int) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:103
 		sb.WriteString(fmt.Sprintf("%-*s", widths[i], val))
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:104
-		if i < (len(widths) - 1) {
+		if i < len(widths)-1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:105
 			sb.WriteString("  ")
 		}
@@ -160,7 +160,7 @@ func renderPlainSep(widths []int) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:112
 		sb.WriteString(strings.Repeat("-", widths[i]))
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:113
-		if i < (len(widths) - 1) {
+		if i < len(widths)-1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:114
 			sb.WriteString("  ")
 		}
@@ -227,9 +227,9 @@ func renderBoxTop(widths []int) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:143
 	for i := range len(widths) {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:144
-		sb.WriteString(strings.Repeat("─", (widths[i] + 2)))
+		sb.WriteString(strings.Repeat("─", widths[i]+2))
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:145
-		if i < (len(widths) - 1) {
+		if i < len(widths)-1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:146
 			sb.WriteString("┬")
 		}
@@ -249,9 +249,9 @@ func renderBoxMid(widths []int) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:153
 	for i := range len(widths) {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:154
-		sb.WriteString(strings.Repeat("─", (widths[i] + 2)))
+		sb.WriteString(strings.Repeat("─", widths[i]+2))
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:155
-		if i < (len(widths) - 1) {
+		if i < len(widths)-1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:156
 			sb.WriteString("┼")
 		}
@@ -271,9 +271,9 @@ func renderBoxBottom(widths []int) string {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:163
 	for i := range len(widths) {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:164
-		sb.WriteString(strings.Repeat("─", (widths[i] + 2)))
+		sb.WriteString(strings.Repeat("─", widths[i]+2))
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:165
-		if i < (len(widths) - 1) {
+		if i < len(widths)-1 {
 //line /Users/tluker/repos/go/kukicha/stdlib/table/table.kuki:166
 			sb.WriteString("┴")
 		}
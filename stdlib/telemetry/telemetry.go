@@ -0,0 +1,401 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:51
+type Config struct {
+	service string
+	output  string
+	writer  io.Writer
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:57
+type Call struct {
+	name    string
+	span    trace.Span
+	started time.Time
+	metrics instruments
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:64
+type instruments struct {
+	provider metric.MeterProvider
+	calls    metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:71
+type exporter struct {
+	mu      sync.Mutex
+	service string
+	w       io.Writer
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:77
+type spanRecord struct {
+	Kind       string    `json:"kind"`
+	Service    string    `json:"service"`
+	Name       string    `json:"name"`
+	TraceID    string    `json:"trace_id"`
+	SpanID     string    `json:"span_id"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	Start      time.Time `json:"start"`
+	DurationMs float64   `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:90
+type metricRecord struct {
+	Kind    string `json:"kind"`
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	Unit    string `json:"unit,omitempty"`
+	Data    any    `json:"data"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:98
+const scope = "github.com/duber000/kukicha/stdlib/telemetry"
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:101
+var durationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:103
+var instrumentsMu sync.Mutex
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:104
+var current instruments
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:108
+func New(service string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:109
+	return Config{service: service, output: "stderr"}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:115
+func FromEnv() Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:116
+	service := os.Getenv("OTEL_SERVICE_NAME")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:117
+	if service == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:118
+		service = filepath.Base(os.Args[0])
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:119
+	output := os.Getenv("KUKICHA_TELEMETRY")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:120
+	if output == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:121
+		output = "off"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:122
+	return Config{service: service, output: output}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:127
+func Output(cfg Config, output string) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:128
+	cfg.output = output
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:129
+	cfg.writer = nil
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:130
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:134
+func Writer(cfg Config, w io.Writer) Config {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:135
+	cfg.writer = w
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:136
+	return cfg
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:142
+func Setup(cfg Config) (func() error, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:143
+	w := cfg.writer
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:144
+	closeOutput := func() error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:145
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:146
+	if w == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:147
+		switch cfg.output {
+		case "off":
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:149
+			return closeOutput, nil
+		case "", "stderr":
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:151
+			w = os.Stderr
+		case "stdout":
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:153
+			w = os.Stdout
+		default:
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:155
+			f, err_1 := os.OpenFile(cfg.output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err_1 != nil {
+				return nil, err_1
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:156
+			w = f
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:157
+			closeOutput = f.Close
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:159
+	e := &exporter{service: cfg.service, w: w}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:160
+	res := resource.NewSchemaless(attribute.String("service.name", cfg.service))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:161
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(e), sdktrace.WithResource(res))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:162
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(e)), sdkmetric.WithResource(res))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:163
+	otel.SetTracerProvider(tracerProvider)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:164
+	otel.SetMeterProvider(meterProvider)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:166
+	stop := func() error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:167
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:168
+		defer cancel()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:169
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx), closeOutput())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:170
+	return stop, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:176
+func Auto() func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:177
+	stop, err := Setup(FromEnv())
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:178
+	if err != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:179
+		fmt.Fprintln(os.Stderr, "telemetry:", err)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:180
+		return func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:181
+			return
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:182
+	return func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:183
+		stopErr := stop()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:184
+		if stopErr != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:185
+			fmt.Fprintln(os.Stderr, "telemetry:", stopErr)
+		}
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:189
+func Start(name string) *Call {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:190
+	_, call := StartContext(context.Background(), name)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:191
+	return call
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:196
+func StartContext(ctx context.Context, name string) (context.Context, *Call) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:197
+	metrics := callMetrics()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:198
+	spanCtx, span := otel.Tracer(scope).Start(ctx, name)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:199
+	metrics.calls.Add(spanCtx, 1, metric.WithAttributes(attribute.String("function", name)))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:200
+	return spanCtx, &Call{name: name, span: span, started: time.Now(), metrics: metrics}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:206
+func (c *Call) End(err *error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:207
+	failure := callError(err)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:208
+	recovered := recover()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:209
+	if recovered != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:210
+		failure = fmt.Errorf("panic: %v", recovered)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:212
+	ctx := trace.ContextWithSpan(context.Background(), c.span)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:213
+	attrs := metric.WithAttributes(attribute.String("function", c.name))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:214
+	c.metrics.duration.Record(ctx, time.Since(c.started).Seconds(), attrs)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:215
+	if failure != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:216
+		c.metrics.errors.Add(ctx, 1, attrs)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:217
+		c.span.RecordError(failure)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:218
+		c.span.SetStatus(codes.Error, failure.Error())
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:219
+	c.span.End()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:220
+	if recovered != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:221
+		panic(recovered)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:224
+func callError(err *error) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:225
+	if err == nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:226
+		return nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:227
+	return *err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:231
+func callMetrics() instruments {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:232
+	provider := otel.GetMeterProvider()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:233
+	instrumentsMu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:234
+	defer instrumentsMu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:235
+	if current.provider == provider {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:236
+		return current
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:237
+	meter := provider.Meter(scope)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:239
+	calls, _ := meter.Int64Counter("kukicha.function.calls", metric.WithDescription("Calls started"))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:240
+	failures, _ := meter.Int64Counter("kukicha.function.errors", metric.WithDescription("Calls that returned an error or panicked"))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:241
+	duration, _ := meter.Float64Histogram("kukicha.function.duration", metric.WithDescription("Call duration"), metric.WithUnit("s"), metric.WithExplicitBucketBoundaries(durationBuckets...))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:242
+	current = instruments{provider: provider, calls: calls, errors: failures, duration: duration}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:243
+	return current
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:246
+func (e *exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:247
+	e.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:248
+	defer e.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:249
+	for _, s := range spans {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:250
+		status := "ok"
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:251
+		if s.Status().Code == codes.Error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:252
+			status = "error"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:253
+		record := spanRecord{Kind: "span", Service: e.service, Name: s.Name(), TraceID: s.SpanContext().TraceID().String(), SpanID: s.SpanContext().SpanID().String(), Start: s.StartTime(), DurationMs: float64(s.EndTime().Sub(s.StartTime()).Microseconds()) / 1000.0, Status: status, Error: s.Status().Description}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:264
+		if s.Parent().IsValid() {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:265
+			record.ParentID = s.Parent().SpanID().String()
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:266
+		err_1 := e.write(record)
+		if err_1 != nil {
+			return err_1
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:267
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:270
+func (e *exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:271
+	e.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:272
+	defer e.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:273
+	for _, sm := range rm.ScopeMetrics {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:274
+		for _, m := range sm.Metrics {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:275
+			err_1 := e.write(metricRecord{Kind: "metric", Service: e.service, Name: m.Name, Unit: m.Unit, Data: m.Data})
+			if err_1 != nil {
+				return err_1
+			}
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:276
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:279
+func (e *exporter) write(record any) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:280
+	line, err_1 := json.Marshal(record)
+	if err_1 != nil {
+		return err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:281
+	_, err := fmt.Fprintf(e.w, "%s\n", line)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:282
+	return err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:285
+func (e *exporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:286
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:289
+func (e *exporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:290
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:293
+func (e *exporter) ForceFlush(ctx context.Context) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:294
+	return nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:297
+func (e *exporter) Shutdown(ctx context.Context) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry.kuki:298
+	return nil
+}
@@ -0,0 +1,228 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/telemetry"
+	"github.com/duber000/kukicha/stdlib/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:16
+type line struct {
+	Kind     string `json:"kind"`
+	Service  string `json:"service"`
+	Name     string `json:"name"`
+	SpanID   string `json:"span_id"`
+	ParentID string `json:"parent_id"`
+	Status   string `json:"status"`
+	Error    string `json:"error"`
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:25
+func parseLines(output string) []line {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:26
+	lines := []line{}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:27
+	for _, text := range strings.Split(strings.TrimSpace(output), "\n") {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:28
+		if text == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:29
+			continue
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:30
+		l := line{}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:31
+		err_1 := json.Unmarshal([]byte(text), &l)
+		if err_1 != nil {
+			panic(fmt.Sprintf("%v", err_1))
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:32
+		lines = append(lines, l)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:33
+	return lines
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:35
+func find(lines []line, kind string, name string) line {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:36
+	for _, l := range lines {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:37
+		if l.Kind == kind && l.Name == name {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:38
+			return l
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:39
+	return line{}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:41
+func charge(ctx context.Context, amount int) error {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:42
+	call := telemetry.Start("billing.Charge")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:43
+	err := errors.New("card declined")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:44
+	if amount < 100 {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:45
+		err = nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:46
+	call.End(&err)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:47
+	return err
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:49
+func explode() {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:50
+	defer telemetry.Start("billing.Explode").End(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:51
+	panic("boom")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:54
+func TestSetup(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:55
+	output := &bytes.Buffer{}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:56
+	stop, err_2 := telemetry.Setup(telemetry.Writer(telemetry.New("billing"), output))
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:58
+	ctx, parent := telemetry.StartContext(context.Background(), "billing.Run")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:59
+	_, child := telemetry.StartContext(ctx, "billing.Refund")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:60
+	child.End(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:61
+	err_3 := charge(ctx, 50)
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:62
+	err := charge(ctx, 500)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:63
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:64
+	parent.End(&err)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:65
+	err_5 := func() (err_4 error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err_4 = fmt.Errorf("panic: %v", r)
+			}
+		}()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:66
+		explode()
+		return nil
+	}()
+	if err_5 != nil {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:68
+		test.AssertEqual(t, fmt.Sprintf("%v", err_5), "panic: boom")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:69
+	err_6 := stop()
+	if err_6 != nil {
+		panic(fmt.Sprintf("%v", err_6))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:71
+	lines := parseLines(output.String())
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:72
+	run := find(lines, "span", "billing.Run")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:73
+	refund := find(lines, "span", "billing.Refund")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:74
+	test.AssertEqual(t, run.Service, "billing")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:75
+	test.AssertEqual(t, run.Status, "error")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:76
+	test.AssertEqual(t, run.Error, "card declined")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:77
+	test.AssertEqual(t, refund.Status, "ok")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:78
+	test.AssertEqual(t, refund.ParentID, run.SpanID)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:79
+	test.AssertEqual(t, find(lines, "span", "billing.Explode").Error, "panic: boom")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:81
+	for _, name := range []string{"kukicha.function.calls", "kukicha.function.errors", "kukicha.function.duration"} {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:82
+		test.AssertEqual(t, find(lines, "metric", name).Name, name)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:83
+	test.AssertTrue(t, strings.Contains(output.String(), "\"function\""))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:86
+func TestSetupFile(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:87
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:88
+	stop, err_2 := telemetry.Setup(telemetry.Output(telemetry.New("jobs"), path))
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:89
+	telemetry.Start("jobs.Sync").End(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:90
+	err_3 := stop()
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:92
+	data, err_4 := os.ReadFile(path)
+	if err_4 != nil {
+		panic(fmt.Sprintf("%v", err_4))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:93
+	test.AssertEqual(t, find(parseLines(string(data)), "span", "jobs.Sync").Service, "jobs")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:95
+	_, err := telemetry.Setup(telemetry.Output(telemetry.New("jobs"), filepath.Join(path, "missing", "x")))
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:96
+	test.AssertError(t, err)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:99
+func TestFromEnv(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:100
+	t.Setenv("OTEL_SERVICE_NAME", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:101
+	t.Setenv("KUKICHA_TELEMETRY", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:102
+	stop, err_2 := telemetry.Setup(telemetry.FromEnv())
+	if err_2 != nil {
+		panic(fmt.Sprintf("%v", err_2))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:103
+	test.AssertNoError(t, stop())
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:105
+	path := filepath.Join(t.TempDir(), "env.jsonl")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:106
+	t.Setenv("OTEL_SERVICE_NAME", "nightly")
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:107
+	t.Setenv("KUKICHA_TELEMETRY", path)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:108
+	finish := telemetry.Auto()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:109
+	telemetry.Start("nightly.Backup").End(nil)
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:110
+	finish()
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:111
+	data, err_3 := os.ReadFile(path)
+	if err_3 != nil {
+		panic(fmt.Sprintf("%v", err_3))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/telemetry/telemetry_test.kuki:112
+	test.AssertEqual(t, find(parseLines(string(data)), "span", "nightly.Backup").Service, "nightly")
+}
@@ -66,9 +66,9 @@ func WithContent(td TemplateData, content string) TemplateData {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:52
 func RenderSimple(tmplStr string, data map[string]any) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:53
-	result, err_6 := Execute(Data(Render(tmplStr), data))
-	if err_6 != nil {
-		return "", err_6
+	result, err_2 := Execute(Data(Render(tmplStr), data))
+	if err_2 != nil {
+		return "", err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:57
 	return result, nil
@@ -77,13 +77,13 @@ func RenderSimple(tmplStr string, data map[string]any) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:63
 func HTMLExecute(td TemplateData) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:64
-	pipe_7, err_8 := parseHTMLTemplate(td.Content)
-	if err_8 != nil {
-		return "", err_8
+	pipe_1, err_2 := parseHTMLTemplate(td.Content)
+	if err_2 != nil {
+		return "", err_2
 	}
-	result, err_10 := executeHTMLTemplate(pipe_7, td.Data)
-	if err_10 != nil {
-		return "", err_10
+	result, err_4 := executeHTMLTemplate(pipe_1, td.Data)
+	if err_4 != nil {
+		return "", err_4
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:68
 	return result, nil
@@ -92,9 +92,9 @@ func HTMLExecute(td TemplateData) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:74
 func HTMLRenderSimple(tmplStr string, data map[string]any) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:75
-	result, err_12 := HTMLExecute(Data(Render(tmplStr), data))
-	if err_12 != nil {
-		return "", err_12
+	result, err_2 := HTMLExecute(Data(Render(tmplStr), data))
+	if err_2 != nil {
+		return "", err_2
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:79
 	return result, nil
@@ -128,9 +128,9 @@ func executeTextTemplate(tmpl *template.Template, data map[string]any) (string,
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:100
 	buf := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:101
-	err_13 := tmpl.Execute(&buf, data)
-	if err_13 != nil {
-		return "", err_13
+	err_1 := tmpl.Execute(&buf, data)
+	if err_1 != nil {
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:102
 	return buf.String(), nil
@@ -147,9 +147,9 @@ func executeHTMLTemplate(tmpl *htmltmpl.Template, data map[string]any) (string,
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:108
 	buf := bytes.Buffer{}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:109
-	err_14 := tmpl.Execute(&buf, data)
-	if err_14 != nil {
-		return "", err_14
+	err_1 := tmpl.Execute(&buf, data)
+	if err_1 != nil {
+		return "", err_1
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/template/template.kuki:110
 	return buf.String(), nil
@@ -0,0 +1,405 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package term
+
+import (
+	"errors"
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/input"
+	"github.com/duber000/kukicha/stdlib/table"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:39
+const Auto = 0
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:40
+const Always = 1
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:41
+const Never = 2
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:43
+var mcpMode atomic.Bool
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:44
+var colorMode atomic.Int32
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:48
+type Bar struct {
+	mu    sync.Mutex
+	label string
+	total int
+	done  int
+	start time.Time
+	live  bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:57
+type Spinner struct {
+	label string
+	stop  chan bool
+	done  chan bool
+	live  bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:66
+func IsTerminal(f *os.File) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:67
+	info, err_1 := f.Stat()
+	if err_1 != nil {
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:68
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:73
+func ColorEnabled() bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:74
+	if mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:75
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:76
+	mode := colorMode.Load()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:77
+	if mode == Always {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:78
+		return true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:79
+	if mode == Never {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:80
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:81
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:82
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:83
+	return IsTerminal(os.Stdout)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:88
+func SetColor(mode int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:89
+	colorMode.Store(int32(mode))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:93
+func ForMCP() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:94
+	mcpMode.Store(true)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:97
+func InMCP() bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:98
+	return mcpMode.Load()
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:102
+func Red(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:103
+	return paint(s, "31")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:106
+func Green(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:107
+	return paint(s, "32")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:110
+func Yellow(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:111
+	return paint(s, "33")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:114
+func Blue(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:115
+	return paint(s, "34")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:118
+func Cyan(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:119
+	return paint(s, "36")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:122
+func Bold(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:123
+	return paint(s, "1")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:126
+func Dim(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:127
+	return paint(s, "2")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:131
+func Info(msg string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:132
+	fmt.Fprintln(stdout(), Blue("•")+" "+msg)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:136
+func Success(msg string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:137
+	fmt.Fprintln(stdout(), Green("✓")+" "+msg)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:141
+func Warn(msg string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:142
+	fmt.Fprintln(os.Stderr, Yellow("!")+" "+msg)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:146
+func Error(msg string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:147
+	fmt.Fprintln(os.Stderr, Red("✗")+" "+msg)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:152
+func Table(headers []string, rows [][]string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:153
+	t := table.New(headers)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:154
+	for _, row := range rows {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:155
+		t = table.AddRow(t, row)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:156
+	style := "plain"
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:157
+	if !mcpMode.Load() && IsTerminal(os.Stdout) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:158
+		style = "box"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:159
+	fmt.Fprint(stdout(), table.ToStringWithStyle(t, style))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:163
+func NewBar(total int, label string) *Bar {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:164
+	live := !mcpMode.Load() && IsTerminal(os.Stderr)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:165
+	return &Bar{label: label, total: total, start: time.Now(), live: live}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:169
+func Add(b *Bar, n int) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:170
+	b.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:171
+	defer b.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:172
+	b.done = min(b.done+n, b.total)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:173
+	if b.live {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:174
+		fmt.Fprint(os.Stderr, "\r"+renderBar(b))
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:180
+func Finish(b *Bar) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:181
+	b.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:182
+	defer b.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:183
+	if b.live {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:184
+		fmt.Fprintln(os.Stderr, "\r"+renderBar(b))
+	} else if !mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:186
+		elapsed := time.Since(b.start).Round(time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:187
+		fmt.Fprintf(os.Stderr, "%s: %d/%d in %s\n", b.label, b.done, b.total, elapsed)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:191
+func Render(b *Bar) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:192
+	b.mu.Lock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:193
+	defer b.mu.Unlock()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:194
+	return renderBar(b)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:198
+func Spin(label string) *Spinner {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:199
+	sp := &Spinner{label: label, stop: make(chan bool), done: make(chan bool)}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:200
+	sp.live = !mcpMode.Load() && IsTerminal(os.Stderr)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:201
+	go spin(sp)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:202
+	return sp
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:207
+func Stop(sp *Spinner, msg string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:208
+	close(sp.stop)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:209
+	<-sp.done
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:210
+	if sp.live {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:211
+		fmt.Fprint(os.Stderr, "\r[K")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:212
+	if msg != "" && !mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:213
+		fmt.Fprintln(os.Stderr, msg)
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:218
+func Ask(question string, fallback string) (string, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:219
+	if mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:220
+		return "", errors.New("term.Ask: stdin is the MCP transport")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:221
+	prompt := question + ": "
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:222
+	if fallback != "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:223
+		prompt = fmt.Sprintf("%v [%v]: ", question, fallback)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:224
+	answer, err_1 := input.ReadLine(prompt)
+	if err_1 != nil {
+		return "", err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:225
+	if answer == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:226
+		return fallback, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:227
+	return answer, nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:232
+func Confirm(question string, yes bool) (bool, error) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:233
+	if mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:234
+		return false, errors.New("term.Confirm: stdin is the MCP transport")
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:235
+	hint := "[y/N]"
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:236
+	if yes {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:237
+		hint = "[Y/n]"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:238
+	answer, err_1 := input.ReadLine(fmt.Sprintf("%v %v: ", question, hint))
+	if err_1 != nil {
+		return false, err_1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:239
+	answer = strings.ToLower(answer)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:240
+	if answer == "" {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:241
+		return yes, nil
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:242
+	return answer == "y" || answer == "yes", nil
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:245
+func paint(s string, code string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:246
+	if !ColorEnabled() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:247
+		return s
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:248
+	return "[" + code + "m" + s + "[0m"
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:252
+func stdout() io.Writer {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:253
+	if mcpMode.Load() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:254
+		return os.Stderr
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:255
+	return os.Stdout
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:258
+func renderBar(b *Bar) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:259
+	width := 20
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:260
+	filled := width
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:261
+	percent := 100
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:262
+	if b.total > 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:263
+		filled = b.done * width / b.total
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:264
+		percent = b.done * 100 / b.total
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:265
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", width-filled)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:266
+	return fmt.Sprintf("%s [%s] %d/%d %d%%", b.label, bar, b.done, b.total, percent)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:269
+func spin(sp *Spinner) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:270
+	frames := []string{"|", "/", "-", "\\"}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:271
+	ticker := time.NewTicker(100 * time.Millisecond)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:272
+	defer ticker.Stop()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:273
+	i := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:274
+	for {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:275
+		if sp.live {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:276
+			fmt.Fprintf(os.Stderr, "\r%s %s", frames[i%len(frames)], sp.label)
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:277
+		select {
+		case <-sp.stop:
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:279
+			close(sp.done)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:280
+			return
+		case <-ticker.C:
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term.kuki:282
+			i = i + 1
+		}
+	}
+}
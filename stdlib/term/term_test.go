@@ -0,0 +1,229 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package term_test
+
+import (
+	"fmt"
+	"github.com/duber000/kukicha/stdlib/term"
+	"github.com/duber000/kukicha/stdlib/test"
+	"os"
+	"sync"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:12
+func feedStdin(t *testing.T, text string) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:13
+	r, w, err := os.Pipe()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:14
+	test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:15
+	w.WriteString(text)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:16
+	w.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:17
+	old := os.Stdin
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:18
+	os.Stdin = r
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:19
+	t.Cleanup(func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:20
+		os.Stdin = old
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:21
+		r.Close()
+	})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:25
+type ColorCase struct {
+	name string
+	mode int
+	want string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:30
+func TestColors(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:31
+	cases := []ColorCase{ColorCase{name: "always", mode: term.Always, want: "[31mfail[0m"}, ColorCase{name: "never", mode: term.Never, want: "fail"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:35
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:36
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:37
+			term.SetColor(tc.mode)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:38
+			defer term.SetColor(term.Auto)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:39
+			test.AssertEqual(t, term.Red("fail"), tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:43
+func TestColorAutoOffWhenPiped(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:45
+	test.AssertFalse(t, term.ColorEnabled())
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:46
+	test.AssertEqual(t, term.Bold("x"), "x")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:49
+func TestIsTerminal(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:50
+	f, err_1 := os.CreateTemp(t.TempDir(), "out")
+	if err_1 != nil {
+		panic(fmt.Sprintf("%v", err_1))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:51
+	defer f.Close()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:52
+	test.AssertFalse(t, term.IsTerminal(f))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:55
+type BarCase struct {
+	name  string
+	total int
+	steps int
+	want  string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:61
+func TestBar(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:62
+	cases := []BarCase{BarCase{name: "start", total: 20, steps: 0, want: "upload [--------------------] 0/20 0%"}, BarCase{name: "part way", total: 20, steps: 7, want: "upload [#######-------------] 7/20 35%"}, BarCase{name: "capped at total", total: 4, steps: 9, want: "upload [####################] 4/4 100%"}, BarCase{name: "no total", total: 0, steps: 0, want: "upload [####################] 0/0 100%"}}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:68
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:69
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:70
+			bar := term.NewBar(tc.total, "upload")
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:71
+			for range tc.steps {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:72
+				term.Add(bar, 1)
+			}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:73
+			test.AssertEqual(t, term.Render(bar), tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:77
+func TestBarConcurrent(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:78
+	bar := term.NewBar(100, "jobs")
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:79
+	wg := sync.WaitGroup{}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:80
+	for range 10 {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:81
+		wg.Add(1)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:82
+		go func() {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:83
+			defer wg.Done()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:84
+			for range 10 {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:85
+				term.Add(bar, 1)
+			}
+		}()
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:87
+	wg.Wait()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:88
+	term.Finish(bar)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:89
+	test.AssertEqual(t, term.Render(bar), "jobs [####################] 100/100 100%")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:92
+func TestSpinner(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:93
+	sp := term.Spin("working")
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:94
+	term.Stop(sp, "")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:97
+type AskCase struct {
+	name     string
+	input    string
+	fallback string
+	want     string
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:103
+func TestAsk(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:104
+	cases := []AskCase{AskCase{name: "answer", input: "  kukicha \n", fallback: "demo", want: "kukicha"}, AskCase{name: "empty uses fallback", input: "\n", fallback: "demo", want: "demo"}, AskCase{name: "no fallback", input: "\n", fallback: "", want: ""}}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:109
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:110
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:111
+			feedStdin(t, tc.input)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:112
+			got, err := term.Ask("Project name", tc.fallback)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:113
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:114
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:118
+type ConfirmCase struct {
+	name  string
+	input string
+	yes   bool
+	want  bool
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:124
+func TestConfirm(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:125
+	cases := []ConfirmCase{ConfirmCase{name: "yes", input: "y\n", yes: false, want: true}, ConfirmCase{name: "YES", input: "YES\n", yes: false, want: true}, ConfirmCase{name: "no", input: "n\n", yes: true, want: false}, ConfirmCase{name: "empty default no", input: "\n", yes: false, want: false}, ConfirmCase{name: "empty default yes", input: "\n", yes: true, want: true}}
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:132
+	for _, tc := range cases {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:133
+		t.Run(tc.name, func(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:134
+			feedStdin(t, tc.input)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:135
+			got, err := term.Confirm("Continue?", tc.yes)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:136
+			test.AssertNoError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:137
+			test.AssertEqual(t, got, tc.want)
+		})
+	}
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:142
+func TestMCPMode(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:143
+	test.AssertFalse(t, term.InMCP())
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:144
+	term.ForMCP()
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:145
+	test.AssertTrue(t, term.InMCP())
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:146
+	term.SetColor(term.Always)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:147
+	defer term.SetColor(term.Auto)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:148
+	test.AssertFalse(t, term.ColorEnabled())
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:149
+	test.AssertEqual(t, term.Green("ok"), "ok")
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:150
+	_, err := term.Ask("name", "")
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:151
+	test.AssertError(t, err)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:152
+	_, err = term.Confirm("sure?", false)
+//line /Users/tluker/repos/go/kukicha/stdlib/term/term_test.kuki:153
+	test.AssertError(t, err)
+}
@@ -0,0 +1,255 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package text
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:25
+func Graphemes(s string) []string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:26
+	runes := []rune(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:27
+	clusters := []string{}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:28
+	start := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:29
+	regional := 0
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:30
+	for i, r := range runes {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:31
+		if i > 0 && !joins(runes[i-1], r, regional) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:32
+			clusters = append(clusters, string(runes[start:i]))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:33
+			start = i
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:34
+			regional = 0
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:35
+		if isRegionalIndicator(r) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:36
+			regional = regional + 1
+		}
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:37
+	if start < len(runes) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:38
+		clusters = append(clusters, string(runes[start:]))
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:39
+	return clusters
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:44
+func Length(s string) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:45
+	return len(Graphemes(s))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:50
+func Slice(s string, start int, end int) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:51
+	clusters := Graphemes(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:52
+	start = min(max(start, 0), len(clusters))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:53
+	end = min(max(end, start), len(clusters))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:54
+	return strings.Join(clusters[start:end], "")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:59
+func Reverse(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:60
+	clusters := Graphemes(s)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:61
+	reversed := make([]string, len(clusters))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:62
+	for i, cluster := range clusters {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:63
+		reversed[len(clusters)-1-i] = cluster
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:64
+	return strings.Join(reversed, "")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:69
+func Fold(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:70
+	return norm.NFC.String(cases.Fold().String(s))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:76
+func EqualFold(a string, b string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:77
+	return Fold(a) == Fold(b)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:81
+func CompareFold(a string, b string) int {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:82
+	return strings.Compare(Fold(a), Fold(b))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:86
+func HasPrefixFold(s string, prefix string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:87
+	return strings.HasPrefix(Fold(s), Fold(prefix))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:91
+func ContainsFold(s string, substr string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:92
+	return strings.Contains(Fold(s), Fold(substr))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:97
+func NFC(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:98
+	return norm.NFC.String(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:101
+func NFD(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:102
+	return norm.NFD.String(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:106
+func NFKC(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:107
+	return norm.NFKC.String(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:110
+func NFKD(s string) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:111
+	return norm.NFKD.String(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:114
+func IsNFC(s string) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:115
+	return norm.NFC.IsNormalString(s)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:121
+func joins(prev rune, r rune, regional int) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:122
+	if prev == '\r' {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:123
+		return r == '\n'
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:124
+	if isControl(prev) || isControl(r) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:125
+		return false
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:126
+	if isExtend(r) || r == 0x200D {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:127
+		return true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:128
+	if prev == 0x200D {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:129
+		return isPictographic(r)
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:130
+	if isRegionalIndicator(prev) && isRegionalIndicator(r) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:131
+		return regional%2 == 1
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:132
+	return hangulJoins(prev, r)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:136
+func isExtend(r rune) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:137
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:138
+		return true
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:139
+	return r >= 0x1F3FB && r <= 0x1F3FF || r >= 0xE0020 && r <= 0xE007F
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:142
+func isControl(r rune) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:143
+	return r == '\r' || r == '\n' || unicode.Is(unicode.Cc, r) || r == 0x2028 || r == 0x2029
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:146
+func isPictographic(r rune) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:147
+	return r >= 0x2600 && r <= 0x27BF || r >= 0x1F000 && r <= 0x1FAFF
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:150
+func isRegionalIndicator(r rune) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:151
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:154
+func hangulJoins(prev rune, r rune) bool {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:155
+	p := hangulKind(prev)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:156
+	k := hangulKind(r)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:157
+	if p == "L" {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:158
+		return k == "L" || k == "V" || k == "LV" || k == "LVT"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:159
+	if p == "LV" || p == "V" {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:160
+		return k == "V" || k == "T"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:161
+	if p == "LVT" || p == "T" {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:162
+		return k == "T"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:163
+	return false
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:166
+func hangulKind(r rune) string {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:167
+	if r >= 0x1100 && r <= 0x115F || r >= 0xA960 && r <= 0xA97C {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:168
+		return "L"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:169
+	if r >= 0x1160 && r <= 0x11A7 || r >= 0xD7B0 && r <= 0xD7C6 {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:170
+		return "V"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:171
+	if r >= 0x11A8 && r <= 0x11FF || r >= 0xD7CB && r <= 0xD7FB {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:172
+		return "T"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:173
+	if r >= 0xAC00 && r <= 0xD7A3 {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:174
+		if (r-0xAC00)%28 == 0 {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:175
+			return "LV"
+		}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:176
+		return "LVT"
+	}
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text.kuki:177
+	return ""
+}
@@ -0,0 +1,101 @@
+// Generated by Kukicha (requires Go 1.26+)
+
+package text_test
+
+import (
+	"github.com/duber000/kukicha/stdlib/test"
+	"github.com/duber000/kukicha/stdlib/text"
+	"testing"
+)
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:10
+func TestLength(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:11
+	test.AssertEqual(t, text.Length(""), 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:12
+	test.AssertEqual(t, text.Length("hello"), 5)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:13
+	test.AssertEqual(t, text.Length("café"), 4)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:14
+	test.AssertEqual(t, text.Length("café"), 4)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:15
+	test.AssertEqual(t, text.Length("👍🏽"), 1)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:16
+	test.AssertEqual(t, text.Length("👩‍💻 ok"), 4)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:17
+	test.AssertEqual(t, text.Length("🇯🇵🇫🇷"), 2)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:18
+	test.AssertEqual(t, text.Length("한국어"), 3)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:19
+	test.AssertEqual(t, text.Length("a\r\nb"), 3)
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:22
+func TestGraphemes(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:23
+	test.AssertEqual(t, text.Graphemes("éa"), []string{"é", "a"})
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:24
+	test.AssertEqual(t, text.Graphemes("🇯🇵🇫🇷"), []string{"🇯🇵", "🇫🇷"})
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:25
+	test.AssertEqual(t, text.Graphemes(""), []string{})
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:28
+func TestSlice(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:29
+	test.AssertEqual(t, text.Slice("héllo", 1, 3), "él")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:30
+	test.AssertEqual(t, text.Slice("👍🏽 ok", 0, 1), "👍🏽")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:31
+	test.AssertEqual(t, text.Slice("abc", -2, 10), "abc")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:32
+	test.AssertEqual(t, text.Slice("abc", 2, 1), "")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:35
+func TestReverse(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:36
+	test.AssertEqual(t, text.Reverse("noël"), "lëon")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:37
+	test.AssertEqual(t, text.Reverse("a👍🏽b"), "b👍🏽a")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:38
+	test.AssertEqual(t, text.Reverse(""), "")
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:41
+func TestFold(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:42
+	test.AssertTrue(t, text.EqualFold("Straße", "STRASSE"))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:43
+	test.AssertTrue(t, text.EqualFold("café", "CAFÉ"))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:44
+	test.AssertFalse(t, text.EqualFold("cafe", "café"))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:45
+	test.AssertEqual(t, text.CompareFold("apple", "BANANA"), -1)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:46
+	test.AssertEqual(t, text.CompareFold("Ω", "ω"), 0)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:47
+	test.AssertTrue(t, text.HasPrefixFold("Bearer abc", "bearer "))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:48
+	test.AssertTrue(t, text.ContainsFold("Grüße aus Köln", "GRÜSSE"))
+}
+
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:51
+func TestNormalize(t *testing.T) {
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:52
+	composed := "é"
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:53
+	decomposed := "é"
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:54
+	test.AssertEqual(t, text.NFC(decomposed), composed)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:55
+	test.AssertEqual(t, text.NFD(composed), decomposed)
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:56
+	test.AssertEqual(t, text.NFKC("ﬁ①"), "fi1")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:57
+	test.AssertEqual(t, text.NFKD("ﬁ"), "fi")
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:58
+	test.AssertTrue(t, text.IsNFC(composed))
+//line /Users/tluker/repos/go/kukicha/stdlib/text/text_test.kuki:59
+	test.AssertFalse(t, text.IsNFC(decomposed))
+}
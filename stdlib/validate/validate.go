@@ -10,6 +10,7 @@ import (
 	kukistring "github.com/duber000/kukicha/stdlib/string"
 	"net/url"
 	"regexp"
+	"strconv"
 	"unicode"
 )
 
@@ -62,7 +63,7 @@ func LengthBetween(s string, min int, max int) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:48
 	length := len(s)
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:49
-	if (length < min) || (length > max) {
+	if length < min || length > max {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:50
 		return s, fmt.Errorf("value must be between %v and %v characters", min, max)
 	}
@@ -91,9 +92,9 @@ func Email(s string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:66
 	pattern := "^[a-zA-Z0-9._%+\\-]+@[a-zA-Z0-9.\\-]+\\.[a-zA-Z]{2,}$"
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:67
-	matched, err_2 := regexp.MatchString(pattern, s)
-	if err_2 != nil {
-		panic(fmt.Sprintf("validate: invalid email pattern: %v", err_2))
+	matched, err_1 := regexp.MatchString(pattern, s)
+	if err_1 != nil {
+		panic(fmt.Sprintf("validate: invalid email pattern: %v", err_1))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:68
 	if !matched {
@@ -107,12 +108,12 @@ func Email(s string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:74
 func URL(s string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:75
-	parsed, err_3 := url.Parse(s)
-	if err_3 != nil {
-		return s, fmt.Errorf("invalid URL: %v", err_3)
+	parsed, err_1 := url.Parse(s)
+	if err_1 != nil {
+		return s, fmt.Errorf("invalid URL: %v", err_1)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:76
-	if (parsed.Scheme == "") || (parsed.Host == "") {
+	if parsed.Scheme == "" || parsed.Host == "" {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:77
 		return s, errors.New("URL must have scheme and host")
 	}
@@ -267,7 +268,7 @@ func NonZero(n int) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:172
 func InRange(n int, min int, max int) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:173
-	if (n < min) || (n > max) {
+	if n < min || n > max {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:174
 		return n, fmt.Errorf("value must be between %v and %v", min, max)
 	}
@@ -311,9 +312,9 @@ func PositiveFloat(n float64) (float64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:202
 func InRangeFloat(n float64, min float64, max float64) (float64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:203
-	if (n < min) || (n > max) {
+	if n < min || n > max {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:204
-		return n, fmt.Errorf("value must be between %v and %v", min, max)
+		return n, fmt.Errorf("value must be between %s and %s", strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:205
 	return n, nil
@@ -322,8 +323,8 @@ func InRangeFloat(n float64, min float64, max float64) (float64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:211
 func ParseInt(s string) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:212
-	val, err_4 := cast.Atoi(s)
-	if err_4 != nil {
+	val, err_1 := cast.Atoi(s)
+	if err_1 != nil {
 		return 0, fmt.Errorf("invalid integer: %v", s)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:213
@@ -333,8 +334,8 @@ func ParseInt(s string) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:217
 func ParsePositiveInt(s string) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:218
-	val, err_5 := cast.Atoi(s)
-	if err_5 != nil {
+	val, err_1 := cast.Atoi(s)
+	if err_1 != nil {
 		return 0, fmt.Errorf("invalid integer: %v", s)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:219
@@ -349,8 +350,8 @@ func ParsePositiveInt(s string) (int, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:225
 func ParseFloat(s string) (float64, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:226
-	val, err_6 := cast.ParseFloat(s, 64)
-	if err_6 != nil {
+	val, err_1 := cast.ParseFloat(s, 64)
+	if err_1 != nil {
 		return 0.0, fmt.Errorf("invalid number: %v", s)
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:227
@@ -362,12 +363,12 @@ func ParseBool(s string) (bool, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:233
 	lower := kukistring.ToLower(kukistring.TrimSpace(s))
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:234
-	if (((lower == "true") || (lower == "1")) || (lower == "yes")) || (lower == "on") {
+	if lower == "true" || lower == "1" || lower == "yes" || lower == "on" {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:235
 		return true, nil
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:236
-	if (((lower == "false") || (lower == "0")) || (lower == "no")) || (lower == "off") {
+	if lower == "false" || lower == "0" || lower == "no" || lower == "off" {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:237
 		return false, nil
 	}
@@ -444,12 +445,12 @@ func NoHTML(s string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:293
 func SafeFilename(s string) (string, error) {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:294
-	if ((s == "") || (s == ".")) || (s == "..") {
+	if s == "" || s == "." || s == ".." {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:295
 		return s, errors.New("unsafe filename")
 	}
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:296
-	if ((kukistring.Contains(s, "/") || kukistring.Contains(s, "\\")) || kukistring.Contains(s, "..")) || kukistring.Contains(s, "\x00") {
+	if kukistring.Contains(s, "/") || kukistring.Contains(s, "\\") || kukistring.Contains(s, "..") || kukistring.Contains(s, "\x00") {
 //line /Users/tluker/repos/go/kukicha/stdlib/validate/validate.kuki:297
 		return s, errors.New("filename contains unsafe characters")
 	}